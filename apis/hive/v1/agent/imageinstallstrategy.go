@@ -0,0 +1,57 @@
+package agent
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ImageArtifactType is the type of boot artifacts generated by the agent installer.
+// +kubebuilder:validation:Enum=ISO;PXE
+type ImageArtifactType string
+
+const (
+	// ImageArtifactTypeISO generates a bootable ISO with `openshift-install agent create image`.
+	ImageArtifactTypeISO ImageArtifactType = "ISO"
+	// ImageArtifactTypePXE generates PXE boot artifacts with `openshift-install agent create pxe-files`.
+	ImageArtifactTypePXE ImageArtifactType = "PXE"
+)
+
+// ImageInstallStrategy is the install strategy configuration for provisioning a cluster from boot artifacts
+// generated by the agent installer. The hosts booted from the artifacts install the cluster without any
+// further interaction with Hive, allowing clusters to be installed in disconnected environments.
+type ImageInstallStrategy struct {
+	// AgentConfigSecretRef is the reference to a secret that contains the agent-config.yaml for the cluster
+	// hosts under the "agent-config.yaml" key. It is passed through directly to the installer along with the
+	// InstallConfig.
+	AgentConfigSecretRef corev1.LocalObjectReference `json:"agentConfigSecretRef"`
+
+	// ArtifactType is the type of boot artifacts to generate. Defaults to ISO.
+	// +optional
+	ArtifactType ImageArtifactType `json:"artifactType,omitempty"`
+
+	// S3 is the S3 bucket the generated boot artifacts are uploaded to.
+	S3 S3ArtifactStore `json:"s3"`
+
+	// InstallTimeout is how long to wait for the hosts to be booted from the artifacts and for the cluster
+	// to finish installing. Defaults to 24 hours.
+	// +optional
+	InstallTimeout *metav1.Duration `json:"installTimeout,omitempty"`
+}
+
+// S3ArtifactStore is an S3 bucket that boot artifacts are uploaded to.
+type S3ArtifactStore struct {
+	// CredentialsSecretRef refers to a secret that contains the AWS account access
+	// credentials used to upload the artifacts and sign the download URLs.
+	CredentialsSecretRef corev1.LocalObjectReference `json:"credentialsSecretRef"`
+
+	// Region is the AWS region of the bucket.
+	Region string `json:"region"`
+
+	// Bucket is the name of the bucket.
+	Bucket string `json:"bucket"`
+
+	// URLExpiration is how long the pre-signed download URLs recorded in the ClusterProvision status remain
+	// valid. Defaults to 24 hours, and may not be longer than 7 days.
+	// +optional
+	URLExpiration *metav1.Duration `json:"urlExpiration,omitempty"`
+}
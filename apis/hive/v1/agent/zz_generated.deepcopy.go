@@ -4,6 +4,10 @@
 
 package agent
 
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BareMetalPlatform) DeepCopyInto(out *BareMetalPlatform) {
 	*out = *in
@@ -37,6 +41,29 @@ func (in *ClusterNetworkEntry) DeepCopy() *ClusterNetworkEntry {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageInstallStrategy) DeepCopyInto(out *ImageInstallStrategy) {
+	*out = *in
+	out.AgentConfigSecretRef = in.AgentConfigSecretRef
+	in.S3.DeepCopyInto(&out.S3)
+	if in.InstallTimeout != nil {
+		in, out := &in.InstallTimeout, &out.InstallTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageInstallStrategy.
+func (in *ImageInstallStrategy) DeepCopy() *ImageInstallStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageInstallStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InstallStrategy) DeepCopyInto(out *InstallStrategy) {
 	*out = *in
@@ -133,3 +160,25 @@ func (in *ProvisionRequirements) DeepCopy() *ProvisionRequirements {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *S3ArtifactStore) DeepCopyInto(out *S3ArtifactStore) {
+	*out = *in
+	out.CredentialsSecretRef = in.CredentialsSecretRef
+	if in.URLExpiration != nil {
+		in, out := &in.URLExpiration, &out.URLExpiration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3ArtifactStore.
+func (in *S3ArtifactStore) DeepCopy() *S3ArtifactStore {
+	if in == nil {
+		return nil
+	}
+	out := new(S3ArtifactStore)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -11,10 +11,25 @@ type MachinePoolPlatform struct {
 	// If public subnets are specified, there must be exactly one private and one public subnet specified for each availability zone.
 	Subnets []string `json:"subnets,omitempty"`
 
+	// ZoneSubnets explicitly maps availability zones to the IDs of the private subnets to which to attach the machines
+	// in those zones, for clusters whose existing subnets cannot be inferred from Subnets. The availability zone of
+	// each subnet must match its key. When Zones is not set, the keys are used as the list of zones.
+	// Mutually exclusive with Subnets.
+	// eg. {us-east-1a: subnet-0123456789abcdef0}
+	// +optional
+	ZoneSubnets map[string]string `json:"zoneSubnets,omitempty"`
+
 	// InstanceType defines the ec2 instance type.
 	// eg. m4-large
 	InstanceType string `json:"type"`
 
+	// InstanceTypes is an ordered list of fallback ec2 instance types, used in the availability zones where
+	// InstanceType is not offered. The MachineSet of each zone uses the first of InstanceType and InstanceTypes
+	// which is offered in the zone.
+	// eg. [m5.large, m5a.large]
+	// +optional
+	InstanceTypes []string `json:"instanceTypes,omitempty"`
+
 	// EC2RootVolume defines the storage for ec2 instance.
 	EC2RootVolume `json:"rootVolume"`
 
@@ -23,6 +23,41 @@ type Platform struct {
 	// Endpoint accross AWS accounts and allows clients to connect to services using AWS's
 	// internal networking instead of the Internet.
 	PrivateLink *PrivateLinkAccess `json:"privateLink,omitempty"`
+
+	// CredentialsMode is the mode with which the cloud credential operator of the cluster satisfies the
+	// CredentialsRequests of its components. It is rendered into the InstallConfig. When not set, the mode of the
+	// InstallConfig is used.
+	// +optional
+	CredentialsMode CredentialsMode `json:"credentialsMode,omitempty"`
+
+	// STS configures the install job to create, with ccoctl, the IAM roles and the OIDC provider with which the
+	// components of the cluster authenticate using the AWS Security Token Service. It requires the Manual
+	// credentials mode.
+	// +optional
+	STS *STSConfig `json:"sts,omitempty"`
+}
+
+// CredentialsMode is the mode with which the cloud credential operator of a cluster satisfies the CredentialsRequests
+// of its components.
+// +kubebuilder:validation:Enum=Mint;Passthrough;Manual
+type CredentialsMode string
+
+const (
+	// MintCredentialsMode creates credentials for each CredentialsRequest from the credentials of the cluster.
+	MintCredentialsMode CredentialsMode = "Mint"
+	// PassthroughCredentialsMode copies the credentials of the cluster for each CredentialsRequest.
+	PassthroughCredentialsMode CredentialsMode = "Passthrough"
+	// ManualCredentialsMode does not process CredentialsRequests, whose credentials are instead provided at install
+	// time.
+	ManualCredentialsMode CredentialsMode = "Manual"
+)
+
+// STSConfig configures the creation of the AWS resources of a cluster using the AWS Security Token Service.
+type STSConfig struct {
+	// CreatePrivateS3Bucket creates the S3 bucket which serves the OIDC configuration of the cluster as a private
+	// bucket, accessed through CloudFront, instead of a public one.
+	// +optional
+	CreatePrivateS3Bucket bool `json:"createPrivateS3Bucket,omitempty"`
 }
 
 // PlatformStatus contains the observed state on AWS platform.
@@ -33,6 +68,31 @@ type PlatformStatus struct {
 // PrivateLinkAccess configures access to the cluster API using AWS PrivateLink
 type PrivateLinkAccess struct {
 	Enabled bool `json:"enabled"`
+
+	// AdditionalAllowedPrincipals is a list of ARNs of AWS principals, other than the hub account, that are allowed
+	// to create VPC endpoints for the VPC endpoint service of the cluster. Principals that are added to the endpoint
+	// service outside of this list are removed.
+	// +optional
+	AdditionalAllowedPrincipals []string `json:"additionalAllowedPrincipals,omitempty"`
+
+	// EndpointAcceptance configures the acceptance of connection requests to the VPC endpoint service of the cluster.
+	// When not set, connection requests from the allowed principals are accepted by AWS without review.
+	// +optional
+	EndpointAcceptance *PrivateLinkEndpointAcceptance `json:"endpointAcceptance,omitempty"`
+}
+
+// PrivateLinkEndpointAcceptance configures the acceptance of connection requests to the VPC endpoint service of a
+// cluster.
+type PrivateLinkEndpointAcceptance struct {
+	// AcceptanceRequired configures the VPC endpoint service of the cluster to require that connection requests are
+	// accepted before the VPC endpoints can be used. Pending connection requests are then accepted by the controller
+	// when they come from the account of Hive or from one of AutoAcceptAccountIDs, and rejected otherwise.
+	AcceptanceRequired bool `json:"acceptanceRequired"`
+
+	// AutoAcceptAccountIDs is a list of IDs of AWS accounts whose connection requests to the VPC endpoint service
+	// of the cluster are accepted by the controller.
+	// +optional
+	AutoAcceptAccountIDs []string `json:"autoAcceptAccountIDs,omitempty"`
 }
 
 // PrivateLinkAccessStatus contains the observed state for PrivateLinkAccess resources.
@@ -43,6 +103,10 @@ type PrivateLinkAccessStatus struct {
 	VPCEndpointID string `json:"vpcEndpointID,omitempty"`
 	// +optional
 	HostedZoneID string `json:"hostedZoneID,omitempty"`
+	// AdditionalAllowedPrincipals is the list of additional allowed principals last configured on the VPC endpoint
+	// service of the cluster.
+	// +optional
+	AdditionalAllowedPrincipals []string `json:"additionalAllowedPrincipals,omitempty"`
 }
 
 type VPCEndpointService struct {
@@ -33,6 +33,18 @@ func (in *MachinePoolPlatform) DeepCopyInto(out *MachinePoolPlatform) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.ZoneSubnets != nil {
+		in, out := &in.ZoneSubnets, &out.ZoneSubnets
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.InstanceTypes != nil {
+		in, out := &in.InstanceTypes, &out.InstanceTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	out.EC2RootVolume = in.EC2RootVolume
 	if in.SpotMarketOptions != nil {
 		in, out := &in.SpotMarketOptions, &out.SpotMarketOptions
@@ -66,6 +78,11 @@ func (in *Platform) DeepCopyInto(out *Platform) {
 	if in.PrivateLink != nil {
 		in, out := &in.PrivateLink, &out.PrivateLink
 		*out = new(PrivateLinkAccess)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.STS != nil {
+		in, out := &in.STS, &out.STS
+		*out = new(STSConfig)
 		**out = **in
 	}
 	return
@@ -87,7 +104,7 @@ func (in *PlatformStatus) DeepCopyInto(out *PlatformStatus) {
 	if in.PrivateLink != nil {
 		in, out := &in.PrivateLink, &out.PrivateLink
 		*out = new(PrivateLinkAccessStatus)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	return
 }
@@ -105,6 +122,16 @@ func (in *PlatformStatus) DeepCopy() *PlatformStatus {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PrivateLinkAccess) DeepCopyInto(out *PrivateLinkAccess) {
 	*out = *in
+	if in.AdditionalAllowedPrincipals != nil {
+		in, out := &in.AdditionalAllowedPrincipals, &out.AdditionalAllowedPrincipals
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.EndpointAcceptance != nil {
+		in, out := &in.EndpointAcceptance, &out.EndpointAcceptance
+		*out = new(PrivateLinkEndpointAcceptance)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -122,6 +149,11 @@ func (in *PrivateLinkAccess) DeepCopy() *PrivateLinkAccess {
 func (in *PrivateLinkAccessStatus) DeepCopyInto(out *PrivateLinkAccessStatus) {
 	*out = *in
 	out.VPCEndpointService = in.VPCEndpointService
+	if in.AdditionalAllowedPrincipals != nil {
+		in, out := &in.AdditionalAllowedPrincipals, &out.AdditionalAllowedPrincipals
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -135,6 +167,43 @@ func (in *PrivateLinkAccessStatus) DeepCopy() *PrivateLinkAccessStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrivateLinkEndpointAcceptance) DeepCopyInto(out *PrivateLinkEndpointAcceptance) {
+	*out = *in
+	if in.AutoAcceptAccountIDs != nil {
+		in, out := &in.AutoAcceptAccountIDs, &out.AutoAcceptAccountIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrivateLinkEndpointAcceptance.
+func (in *PrivateLinkEndpointAcceptance) DeepCopy() *PrivateLinkEndpointAcceptance {
+	if in == nil {
+		return nil
+	}
+	out := new(PrivateLinkEndpointAcceptance)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *STSConfig) DeepCopyInto(out *STSConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new STSConfig.
+func (in *STSConfig) DeepCopy() *STSConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(STSConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SpotMarketOptions) DeepCopyInto(out *SpotMarketOptions) {
 	*out = *in
@@ -7,6 +7,13 @@ type MachinePool struct {
 	// eg. ["1", "2", "3"]
 	Zones []string `json:"zones,omitempty"`
 
+	// ZoneSubnets explicitly maps availability zones to the names of the subnets, in the virtual network of the
+	// cluster, to which to attach the machines in those zones. Zones which are not mapped use the compute subnet of
+	// the cluster. When Zones is not set, the keys are used as the list of zones.
+	// eg. {"1": "workers-1"}
+	// +optional
+	ZoneSubnets map[string]string `json:"zoneSubnets,omitempty"`
+
 	// InstanceType defines the azure instance type.
 	// eg. Standard_DS_V2
 	InstanceType string `json:"type"`
@@ -31,6 +38,10 @@ func (a *MachinePool) Set(required *MachinePool) {
 		a.Zones = required.Zones
 	}
 
+	if len(required.ZoneSubnets) > 0 {
+		a.ZoneSubnets = required.ZoneSubnets
+	}
+
 	if required.InstanceType != "" {
 		a.InstanceType = required.InstanceType
 	}
@@ -18,8 +18,29 @@ type Platform struct {
 
 	// BaseDomainResourceGroupName specifies the resource group where the azure DNS zone for the base domain is found
 	BaseDomainResourceGroupName string `json:"baseDomainResourceGroupName,omitempty"`
+
+	// CredentialsMode is the mode with which the cloud credential operator of the cluster satisfies the
+	// CredentialsRequests of its components. It is rendered into the InstallConfig. When not set, the mode of the
+	// InstallConfig is used.
+	// +optional
+	CredentialsMode CredentialsMode `json:"credentialsMode,omitempty"`
 }
 
+// CredentialsMode is the mode with which the cloud credential operator of a cluster satisfies the CredentialsRequests
+// of its components.
+// +kubebuilder:validation:Enum=Mint;Passthrough;Manual
+type CredentialsMode string
+
+const (
+	// MintCredentialsMode creates credentials for each CredentialsRequest from the credentials of the cluster.
+	MintCredentialsMode CredentialsMode = "Mint"
+	// PassthroughCredentialsMode copies the credentials of the cluster for each CredentialsRequest.
+	PassthroughCredentialsMode CredentialsMode = "Passthrough"
+	// ManualCredentialsMode does not process CredentialsRequests, whose credentials are instead provided at install
+	// time.
+	ManualCredentialsMode CredentialsMode = "Manual"
+)
+
 //SetBaseDomain parses the baseDomainID and sets the related fields on azure.Platform
 func (p *Platform) SetBaseDomain(baseDomainID string) error {
 	parts := strings.Split(baseDomainID, "/")
@@ -0,0 +1,74 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// BaseDomainPoolSpec defines the desired state of BaseDomainPool
+type BaseDomainPoolSpec struct {
+	// Domains are the parent domains delegated to Hive from which subdomains are allocated. They must be
+	// managed domains in the HiveConfig. Subdomains are allocated from the domain with the fewest allocations.
+	// +kubebuilder:validation:MinItems=1
+	Domains []string `json:"domains"`
+}
+
+// BaseDomainPoolStatus defines the observed state of BaseDomainPool
+type BaseDomainPoolStatus struct {
+	// Allocations are the subdomains allocated to ClusterDeployments. An allocation is released once its
+	// ClusterDeployment is gone.
+	// +optional
+	Allocations []BaseDomainAllocation `json:"allocations,omitempty"`
+}
+
+// BaseDomainAllocation is a subdomain allocated to a ClusterDeployment as its base domain.
+type BaseDomainAllocation struct {
+	// Domain is the allocated subdomain.
+	Domain string `json:"domain"`
+
+	// ClusterDeploymentNamespace is the namespace of the ClusterDeployment the subdomain is allocated to.
+	ClusterDeploymentNamespace string `json:"clusterDeploymentNamespace"`
+
+	// ClusterDeploymentName is the name of the ClusterDeployment the subdomain is allocated to.
+	ClusterDeploymentName string `json:"clusterDeploymentName"`
+
+	// ClusterDeploymentUID is the UID of the ClusterDeployment the subdomain is allocated to.
+	ClusterDeploymentUID string `json:"clusterDeploymentUID"`
+}
+
+// BaseDomainPoolReference is a reference to a BaseDomainPool
+type BaseDomainPoolReference struct {
+	// Name is the name of the BaseDomainPool that this refers to
+	Name string `json:"name"`
+}
+
+// +genclient:nonNamespaced
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// BaseDomainPool is a pool of delegated parent domains from which Hive allocates unique base domains to
+// ClusterDeployments that manage DNS without specifying a base domain.
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Domains",type="string",JSONPath=".spec.domains"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:path=basedomainpools,shortName=bdp,scope=Cluster
+type BaseDomainPool struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BaseDomainPoolSpec   `json:"spec,omitempty"`
+	Status BaseDomainPoolStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// BaseDomainPoolList contains a list of BaseDomainPool
+type BaseDomainPoolList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []BaseDomainPool `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&BaseDomainPool{}, &BaseDomainPoolList{})
+}
@@ -0,0 +1,97 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ChangeFreezeEmergencyLabel is the label which, set to "true" on a SyncSet or SelectorSyncSet, lets the syncset
+	// be applied to clusters during a change freeze.
+	ChangeFreezeEmergencyLabel = "hive.openshift.io/emergency"
+)
+
+// ChangeFreezeSpec defines the desired state of ChangeFreeze
+type ChangeFreezeSpec struct {
+	// ClusterDeploymentSelector selects the ClusterDeployments frozen during the window. An empty selector selects
+	// all ClusterDeployments.
+	ClusterDeploymentSelector metav1.LabelSelector `json:"clusterDeploymentSelector"`
+
+	// Start is the time at which the freeze begins.
+	Start metav1.Time `json:"start"`
+
+	// End is the time at which the freeze ends. A freeze which does not end after it begins is never active.
+	End metav1.Time `json:"end"`
+
+	// Reason is a human-readable explanation of the freeze.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+}
+
+// ChangeFreezeStatus defines the observed state of ChangeFreeze
+type ChangeFreezeStatus struct {
+	// Active is whether the freeze is in its window.
+	// +optional
+	Active bool `json:"active,omitempty"`
+
+	// FrozenClusters is the number of ClusterDeployments selected by the freeze while it is active.
+	// +optional
+	FrozenClusters int32 `json:"frozenClusters,omitempty"`
+
+	// DeferredChangeCount is the number of changes to the frozen clusters which are deferred until the freeze ends.
+	// +optional
+	DeferredChangeCount int32 `json:"deferredChangeCount,omitempty"`
+
+	// DeferredChanges are the changes to the frozen clusters which are deferred until the freeze ends. At most 100
+	// changes are listed.
+	// +optional
+	DeferredChanges []ChangeFreezeDeferredChange `json:"deferredChanges,omitempty"`
+}
+
+// ChangeFreezeDeferredChange is a change to a frozen cluster which is deferred until the freeze ends.
+type ChangeFreezeDeferredChange struct {
+	// Namespace is the namespace of the ClusterDeployment.
+	Namespace string `json:"namespace"`
+
+	// ClusterDeploymentName is the name of the ClusterDeployment.
+	ClusterDeploymentName string `json:"clusterDeploymentName"`
+
+	// Kind is the kind of the object whose change is deferred: SyncSet, SelectorSyncSet or MachinePool.
+	Kind string `json:"kind"`
+
+	// Name is the name of the object whose change is deferred.
+	Name string `json:"name"`
+}
+
+// +genclient:nonNamespaced
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ChangeFreeze pauses the changes Hive makes to the selected clusters during a window of time. SyncSets and
+// SelectorSyncSets labeled as emergency changes are still applied.
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Start",type="date",JSONPath=".spec.start"
+// +kubebuilder:printcolumn:name="End",type="date",JSONPath=".spec.end"
+// +kubebuilder:printcolumn:name="Active",type="boolean",JSONPath=".status.active"
+// +kubebuilder:printcolumn:name="Deferred",type="integer",JSONPath=".status.deferredChangeCount"
+// +kubebuilder:resource:path=changefreezes,scope=Cluster
+type ChangeFreeze struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ChangeFreezeSpec   `json:"spec,omitempty"`
+	Status ChangeFreezeStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ChangeFreezeList contains a list of ChangeFreeze
+type ChangeFreezeList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ChangeFreeze `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ChangeFreeze{}, &ChangeFreezeList{})
+}
@@ -25,6 +25,24 @@ type ClusterClaimSpec struct {
 	// when the lifetime has elapsed, the claim will be deleted by Hive.
 	// +optional
 	Lifetime *metav1.Duration `json:"lifetime,omitempty"`
+
+	// ActivationTime reserves a cluster for the claim at a future time. The claim is not assigned a cluster before its
+	// activation time, but the pool provisions a cluster for it ahead of time, as configured by the
+	// ReservationLeadTime of the pool, and holds it for the claim so that it is ready at the activation time.
+	// +optional
+	ActivationTime *metav1.Time `json:"activationTime,omitempty"`
+
+	// IdleTimeout is how long the claimed cluster can go without API activity before the claim is deleted by Hive.
+	// Activity is observed from the events and namespaces on the cluster outside of the default,
+	// openshift and kube namespaces.
+	// The idle timeout of the claim is the minimum of the idle timeouts set by the cluster pool and the claim itself.
+	// +optional
+	IdleTimeout *metav1.Duration `json:"idleTimeout,omitempty"`
+
+	// Priority of the claim among the pending claims of the pool. Ready clusters are assigned to the pending claims
+	// with the highest priority first, and to the oldest claims among claims of the same priority. Defaults to 0.
+	// +optional
+	Priority int32 `json:"priority,omitempty"`
 }
 
 // ClusterClaimStatus defines the observed state of ClusterClaim.
@@ -37,6 +55,15 @@ type ClusterClaimStatus struct {
 	// when the lifetime has elapsed, the claim will be deleted by Hive.
 	// +optional
 	Lifetime *metav1.Duration `json:"lifetime,omitempty"`
+
+	// LastActivityTime is the time of the last API activity observed on the claimed cluster, from which the idle
+	// timeout of the claim is measured.
+	// +optional
+	LastActivityTime *metav1.Time `json:"lastActivityTime,omitempty"`
+
+	// ExpirationTime is when the claim will be deleted by Hive, at the end of its lifetime or of its idle timeout.
+	// +optional
+	ExpirationTime *metav1.Time `json:"expirationTime,omitempty"`
 }
 
 // ClusterClaimCondition contains details for the current condition of a cluster claim.
@@ -57,6 +84,9 @@ type ClusterClaimCondition struct {
 	// Message is a human-readable message indicating details about last transition.
 	// +optional
 	Message string `json:"message,omitempty"`
+	// Category is the class of the failure reported by the condition, from its reason.
+	// +optional
+	Category FailureCategory `json:"category,omitempty"`
 }
 
 // ClusterClaimConditionType is a valid value for ClusterClaimCondition.Type.
@@ -69,6 +99,12 @@ const (
 	ClusterClaimClusterDeletedCondition ClusterClaimConditionType = "ClusterDeleted"
 	// ClusterRunningCondition is true when a claimed cluster is running and ready for use.
 	ClusterRunningCondition ClusterClaimConditionType = "ClusterRunning"
+	// ClusterClaimExpiringCondition is true when the claim is about to be deleted at the end of its lifetime or of its
+	// idle timeout.
+	ClusterClaimExpiringCondition ClusterClaimConditionType = "Expiring"
+	// ClusterClaimCloudResourcesTaggedCondition reports whether the cloud resources of the claimed cluster have been
+	// tagged with the metadata of the claim.
+	ClusterClaimCloudResourcesTaggedCondition ClusterClaimConditionType = "CloudResourcesTagged"
 )
 
 // +genclient
@@ -79,9 +115,12 @@ const (
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:path=clusterclaims
 // +kubebuilder:printcolumn:name="Pool",type="string",JSONPath=".spec.clusterPoolName"
+// +kubebuilder:printcolumn:name="Activation",type="date",JSONPath=".spec.activationTime",priority=1
+// +kubebuilder:printcolumn:name="Priority",type="integer",JSONPath=".spec.priority",priority=1
 // +kubebuilder:printcolumn:name="Pending",type="string",JSONPath=".status.conditions[?(@.type=='Pending')].reason"
 // +kubebuilder:printcolumn:name="ClusterNamespace",type="string",JSONPath=".spec.namespace"
 // +kubebuilder:printcolumn:name="ClusterRunning",type="string",JSONPath=".status.conditions[?(@.type=='ClusterRunning')].reason"
+// +kubebuilder:printcolumn:name="Expires",type="date",JSONPath=".status.expirationTime",priority=1
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 type ClusterClaim struct {
 	metav1.TypeMeta   `json:",inline"`
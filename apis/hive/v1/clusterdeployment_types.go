@@ -1,16 +1,21 @@
 package v1
 
 import (
+	configv1 "github.com/openshift/api/config/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 
 	"github.com/openshift/hive/apis/hive/v1/agent"
 	"github.com/openshift/hive/apis/hive/v1/aws"
 	"github.com/openshift/hive/apis/hive/v1/azure"
 	"github.com/openshift/hive/apis/hive/v1/baremetal"
 	"github.com/openshift/hive/apis/hive/v1/gcp"
+	"github.com/openshift/hive/apis/hive/v1/none"
+	"github.com/openshift/hive/apis/hive/v1/oci"
 	"github.com/openshift/hive/apis/hive/v1/openstack"
 	"github.com/openshift/hive/apis/hive/v1/ovirt"
+	"github.com/openshift/hive/apis/hive/v1/powervs"
 	"github.com/openshift/hive/apis/hive/v1/vsphere"
 )
 
@@ -49,6 +54,10 @@ const (
 	// FinalizerMachineManagementTargetNamespace is used on ClusterDeployments to
 	// ensure we clean up the machine management target namespace before cleaning up the API object.
 	FinalizerMachineManagementTargetNamespace string = "hive.openshift.io/machine-management-targetnamespace"
+
+	// FinalizerCMDBExport is used on ClusterDeployments to ensure their deletion is exported to the configured
+	// CMDB connectors before cleaning up the API object.
+	FinalizerCMDBExport string = "hive.openshift.io/cmdb-export"
 )
 
 // ClusterPowerState is used to indicate whether a cluster is running or in a
@@ -75,10 +84,23 @@ type ClusterDeploymentSpec struct {
 	// +required
 	ClusterName string `json:"clusterName"`
 
-	// BaseDomain is the base domain to which the cluster should belong.
+	// DisplayName is a human-friendly name of the cluster for dashboards and tooling. Unlike ClusterName, it can be
+	// changed at any time: it is never used to name, tag or label generated resources, which use immutable
+	// identifiers such as the infra ID of the cluster instead.
+	// +kubebuilder:validation:MaxLength=256
+	// +optional
+	DisplayName string `json:"displayName,omitempty"`
+
+	// BaseDomain is the base domain to which the cluster should belong. It may be left empty when
+	// BaseDomainPoolRef is set, in which case a unique base domain is allocated from the pool.
 	// +required
 	BaseDomain string `json:"baseDomain"`
 
+	// BaseDomainPoolRef is a reference to a BaseDomainPool from which a unique base domain is allocated
+	// when BaseDomain is empty. Requires ManageDNS.
+	// +optional
+	BaseDomainPoolRef *BaseDomainPoolReference `json:"baseDomainPoolRef,omitempty"`
+
 	// Platform is the configuration for the specific platform upon which to
 	// perform the installation.
 	// +required
@@ -91,6 +113,24 @@ type ClusterDeploymentSpec struct {
 	// PreserveOnDelete allows the user to disconnect a cluster from Hive without deprovisioning it
 	PreserveOnDelete bool `json:"preserveOnDelete,omitempty"`
 
+	// ForceCleanup allows the deletion of the ClusterDeployment to complete when the cleanup of the cluster cannot,
+	// for example because the cluster is unreachable or its cloud account is gone. Once the ClusterDeployment has
+	// been deleted for longer than the timeout, Hive removes its finalizers, skipping the cleanup which has not
+	// completed, and records the skipped cleanup in an event. Resources left in the cloud must be removed manually.
+	// +optional
+	ForceCleanup *ForceCleanup `json:"forceCleanup,omitempty"`
+
+	// ExitBackup, when set, backs up the cluster with the Velero installed on the cluster before it is deprovisioned,
+	// so that it can be restored for forensic investigation after it has been deleted.
+	// +optional
+	ExitBackup *ExitBackup `json:"exitBackup,omitempty"`
+
+	// RestoreRef, when set, has the install restore the cluster from a Velero backup, such as the exit backup of a
+	// deleted cluster being recovered, before the cluster is reported installed. Velero must be installed on the
+	// cluster with a BackupStorageLocation of the backup by the manifests of spec.provisioning.manifestsConfigMapRef.
+	// +optional
+	RestoreRef *RestoreReference `json:"restoreRef,omitempty"`
+
 	// ControlPlaneConfig contains additional configuration for the target cluster's control plane
 	// +optional
 	ControlPlaneConfig ControlPlaneConfigSpec `json:"controlPlaneConfig,omitempty"`
@@ -99,6 +139,24 @@ type ClusterDeploymentSpec struct {
 	// +optional
 	Ingress []ClusterIngress `json:"ingress,omitempty"`
 
+	// NodeTuning contains node tuning defaults applied to the nodes of all the MachinePools of the cluster.
+	// +optional
+	NodeTuning *NodeTuning `json:"nodeTuning,omitempty"`
+
+	// SyncAgent, when set, has the SyncSets and SelectorSyncSets of the cluster applied by an agent running in the
+	// cluster, which pulls them from Hive, rather than by Hive pushing them through the admin kubeconfig. This allows
+	// managing clusters whose API is not reachable from Hive. The agent reports the results in the ClusterSync of
+	// the cluster.
+	// +optional
+	SyncAgent *SyncAgent `json:"syncAgent,omitempty"`
+
+	// SelectorSyncSetExclusions are the names of SelectorSyncSets which are not applied to the cluster even though
+	// they select it, for example while the cluster is under an incident freeze. "*" excludes all SelectorSyncSets.
+	// The resources already applied to the cluster by an excluded SelectorSyncSet are left as they are, neither
+	// updated nor deleted, until it is no longer excluded.
+	// +optional
+	SelectorSyncSetExclusions []string `json:"selectorSyncSetExclusions,omitempty"`
+
 	// CertificateBundles is a list of certificate bundles associated with this cluster
 	// +optional
 	CertificateBundles []CertificateBundleSpec `json:"certificateBundles,omitempty"`
@@ -108,6 +166,13 @@ type ClusterDeploymentSpec struct {
 	// +optional
 	ManageDNS bool `json:"manageDNS,omitempty"`
 
+	// PreserveDNSZoneOnDelete specifies whether the managed DNSZone, along with the records delegating to it from the
+	// parent domain, is kept when the ClusterDeployment is deleted. A ClusterDeployment later created in the same
+	// namespace with the same name and base domain adopts the preserved DNSZone, skipping its creation and the wait
+	// for DNS propagation. Preserved DNSZones which are not reused must be deleted manually.
+	// +optional
+	PreserveDNSZoneOnDelete bool `json:"preserveDNSZoneOnDelete,omitempty"`
+
 	// ClusterMetadata contains metadata information about the installed cluster.
 	ClusterMetadata *ClusterMetadata `json:"clusterMetadata,omitempty"`
 
@@ -134,10 +199,30 @@ type ClusterDeploymentSpec struct {
 	// +optional
 	HibernateAfter *metav1.Duration `json:"hibernateAfter,omitempty"`
 
+	// HibernateAfterIdle will transition a cluster to hibernating power state after no user activity has been
+	// observed on it for the given duration. User activity is the requests made to the API servers of the cluster by
+	// users other than the system users, as recorded in the audit logs of the API servers.
+	// +optional
+	HibernateAfterIdle *metav1.Duration `json:"hibernateAfterIdle,omitempty"`
+
+	// PowerStateSchedule hibernates and resumes the cluster on a schedule, by setting its PowerState at the scheduled
+	// times. The PowerState can still be changed in between, and is kept until the next scheduled time.
+	// +optional
+	PowerStateSchedule *PowerStateSchedule `json:"powerStateSchedule,omitempty"`
+
+	// ResumeReadiness configures the checks which the cluster must pass after resuming from hibernation before it is
+	// considered running. When omitted, all the nodes of the cluster must be ready.
+	// +optional
+	ResumeReadiness *ResumeReadiness `json:"resumeReadiness,omitempty"`
+
 	// InstallAttemptsLimit is the maximum number of times Hive will attempt to install the cluster.
 	// +optional
 	InstallAttemptsLimit *int32 `json:"installAttemptsLimit,omitempty"`
 
+	// ProvisionRetryPolicy configures how Hive retries failed provisions of the cluster.
+	// +optional
+	ProvisionRetryPolicy *ProvisionRetryPolicy `json:"provisionRetryPolicy,omitempty"`
+
 	// MachineManagement contains machine management settings including the strategy that will be used when
 	// provisioning worker machines.
 	// +optional
@@ -149,6 +234,187 @@ type ClusterDeploymentSpec struct {
 	// provision AWS clusters to use Amazon's Security Token Service.
 	// +optional
 	BoundServiceAccountSignkingKeySecretRef *corev1.LocalObjectReference `json:"boundServiceAccountSigningKeySecretRef,omitempty"`
+
+	// ReadinessGates are conditions, set in the status of the ClusterDeployment by external systems, which must all
+	// be true for the cluster to be Ready. A cluster of a ClusterPool is only assigned to a claim once it is Ready.
+	// +optional
+	ReadinessGates []ClusterDeploymentReadinessGate `json:"readinessGates,omitempty"`
+
+	// UnreachableRemediation configures the steps which Hive attempts, in order, to restore connectivity to the
+	// cluster when it has been unreachable for longer than a threshold.
+	// +optional
+	UnreachableRemediation *UnreachableRemediation `json:"unreachableRemediation,omitempty"`
+
+	// Kubeadmin configures the management of the kubeadmin user of the cluster once it is installed.
+	// +optional
+	Kubeadmin *KubeadminManagement `json:"kubeadmin,omitempty"`
+}
+
+// RestoreReference identifies the Velero backup to restore a cluster from.
+type RestoreReference struct {
+	// BackupName is the name of the Velero Backup to restore, as synced by Velero from its backup storage locations.
+	BackupName string `json:"backupName"`
+
+	// Namespace is the namespace of Velero on the cluster. Defaults to velero.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// StorageLocation is the name of the Velero BackupStorageLocation on the cluster which holds the backup. Defaults
+	// to default.
+	// +optional
+	StorageLocation string `json:"storageLocation,omitempty"`
+
+	// Etcd is whether etcd is restored from the snapshot of etcd in the backup, taken with exitBackup.etcd, following
+	// the disaster recovery procedure of OpenShift, rather than the resources of the backup being restored by Velero.
+	// +optional
+	Etcd bool `json:"etcd,omitempty"`
+}
+
+// KubeadminManagement configures the management of the kubeadmin user of the cluster.
+type KubeadminManagement struct {
+	// RemoveAfterIdentityProvider is the name of an identity provider configured in the cluster. Once a user has
+	// logged in to the cluster through that identity provider, confirming that it works, Hive removes the kubeadmin
+	// user from the cluster.
+	// +optional
+	RemoveAfterIdentityProvider string `json:"removeAfterIdentityProvider,omitempty"`
+
+	// PasswordRotation is an arbitrary value, changing which makes Hive generate a new password for the kubeadmin
+	// user and store it in the admin password secret of the ClusterDeployment. Ignored once the kubeadmin user has
+	// been removed.
+	// +optional
+	PasswordRotation string `json:"passwordRotation,omitempty"`
+}
+
+// ClusterDeploymentReadinessGate is a condition which must be true for a ClusterDeployment to be Ready.
+type ClusterDeploymentReadinessGate struct {
+	// ConditionType is the type of a condition in the status of the ClusterDeployment. It must be a qualified name,
+	// such as example.com/ComplianceScanPassed, and must not be a condition type managed by Hive.
+	ConditionType ClusterDeploymentConditionType `json:"conditionType"`
+}
+
+// UnreachableRemediationStepType is the type of a step attempted to restore connectivity to an unreachable cluster.
+// +kubebuilder:validation:Enum=RotateKubeconfig;AlternateAPIURL;ResumeHibernation
+type UnreachableRemediationStepType string
+
+const (
+	// RotateKubeconfigRemediationStep replaces the admin kubeconfig of the cluster with a saved kubeconfig, such as
+	// one with a renewed client certificate.
+	RotateKubeconfigRemediationStep UnreachableRemediationStepType = "RotateKubeconfig"
+	// AlternateAPIURLRemediationStep connects to the cluster through an alternate API URL, which becomes the API URL
+	// override of the cluster when the cluster is reachable through it.
+	AlternateAPIURLRemediationStep UnreachableRemediationStepType = "AlternateAPIURL"
+	// ResumeHibernationRemediationStep powers on the machines of the cluster, as when resuming from hibernation.
+	ResumeHibernationRemediationStep UnreachableRemediationStepType = "ResumeHibernation"
+)
+
+// UnreachableRemediation configures the remediation of a cluster which has been unreachable for too long.
+type UnreachableRemediation struct {
+	// Threshold is how long the cluster must be unreachable before the remediation starts. Defaults to 30m.
+	// +optional
+	Threshold *metav1.Duration `json:"threshold,omitempty"`
+
+	// Steps are attempted one at a time, in order, for as long as the cluster stays unreachable. Hive gives up
+	// once every step has been attempted.
+	// +kubebuilder:validation:MinItems=1
+	Steps []UnreachableRemediationStep `json:"steps"`
+}
+
+// UnreachableRemediationStep is a step attempted to restore connectivity to an unreachable cluster.
+type UnreachableRemediationStep struct {
+	// Type is the type of the step.
+	Type UnreachableRemediationStepType `json:"type"`
+
+	// KubeconfigSecretRef refers to a secret in the namespace of the ClusterDeployment whose "kubeconfig" key holds
+	// the kubeconfig which replaces the admin kubeconfig of the cluster. Required for the RotateKubeconfig step.
+	// +optional
+	KubeconfigSecretRef *corev1.LocalObjectReference `json:"kubeconfigSecretRef,omitempty"`
+
+	// APIURL is the alternate API URL of the cluster. Required for the AlternateAPIURL step.
+	// +optional
+	APIURL string `json:"apiURL,omitempty"`
+}
+
+// ForceCleanup configures the forced cleanup of a ClusterDeployment whose deletion is stuck.
+type ForceCleanup struct {
+	// Reason is why the cleanup is forced, recorded in the event emitted when the cleanup is forced.
+	// +kubebuilder:validation:MinLength=1
+	Reason string `json:"reason"`
+
+	// Timeout is how long the cleanup of the deleted ClusterDeployment may take before it is forced.
+	// Defaults to 1h.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// SyncAgent configures the agent applying SyncSets from within the cluster.
+type SyncAgent struct {
+	// HubAPIURL is the URL of the API of the Hive cluster as reachable from the cluster, which the agent pulls from.
+	// Hive deploys the agent to the cluster through the admin kubeconfig once the cluster is reachable.
+	HubAPIURL string `json:"hubAPIURL"`
+
+	// PollInterval is how often the agent checks Hive for changes to the SyncSets to apply. Defaults to 2m.
+	// +optional
+	PollInterval *metav1.Duration `json:"pollInterval,omitempty"`
+}
+
+// NodeTuning contains node tuning defaults which Hive syncs to the cluster, as a KubeletConfig and a MachineConfig
+// for the worker machine config pool, which the nodes of all MachinePools belong to, and a machine config pool with a
+// MachineConfig for each MachinePool with its own sysctl profiles.
+type NodeTuning struct {
+	// KubeletConfig is the kubelet configuration of the nodes, in the format of the kubeletConfig field of a
+	// KubeletConfig. For example: {"maxPods": 500, "podPidsLimit": 4096}.
+	// +optional
+	KubeletConfig *runtime.RawExtension `json:"kubeletConfig,omitempty"`
+
+	// SysctlProfileRefs are references to ConfigMaps in the namespace of the ClusterDeployment whose data are the
+	// kernel parameters to set on the nodes, keyed by sysctl name. A parameter in a later profile overrides the
+	// same parameter in an earlier one.
+	// +optional
+	SysctlProfileRefs []corev1.LocalObjectReference `json:"sysctlProfileRefs,omitempty"`
+
+	// MachinePools are the node tuning defaults of individual MachinePools, applied to their nodes in addition to the
+	// defaults of all MachinePools.
+	// +optional
+	MachinePools []MachinePoolNodeTuning `json:"machinePools,omitempty"`
+}
+
+// MachinePoolNodeTuning contains the node tuning defaults of the nodes of one MachinePool. Hive labels the nodes of
+// the MachinePool with the node-role.kubernetes.io/hive-<name> role and syncs a machine config pool of that name,
+// which inherits the MachineConfigs and KubeletConfig of the worker machine config pool. Nodes of the MachinePool
+// created before it had node tuning defaults are not labeled, and are only tuned once they are replaced.
+type MachinePoolNodeTuning struct {
+	// Name is the name of the MachinePool in its spec, such as "worker" or "infra".
+	// +kubebuilder:validation:MaxLength=58
+	Name string `json:"name"`
+
+	// SysctlProfileRefs are references to ConfigMaps in the namespace of the ClusterDeployment whose data are the
+	// kernel parameters to set on the nodes of the MachinePool, keyed by sysctl name. They override the same
+	// parameters of the sysctl profiles of all MachinePools, and a parameter in a later profile overrides the same
+	// parameter in an earlier one.
+	SysctlProfileRefs []corev1.LocalObjectReference `json:"sysctlProfileRefs"`
+}
+
+// ProvisionRetryPolicy configures the retries of failed provisions.
+type ProvisionRetryPolicy struct {
+	// MaxAttempts is the maximum number of provisions attempted. Takes precedence over InstallAttemptsLimit.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxAttempts *int32 `json:"maxAttempts,omitempty"`
+
+	// BackoffBase is how long Hive waits after the first failed provision before starting the next one. The wait
+	// doubles with each further failed provision. Defaults to 1m.
+	// +optional
+	BackoffBase *metav1.Duration `json:"backoffBase,omitempty"`
+
+	// MaxBackoff is the longest Hive waits after a failed provision before starting the next one. Defaults to 24h.
+	// +optional
+	MaxBackoff *metav1.Duration `json:"maxBackoff,omitempty"`
+
+	// RetryableReasons are the reasons of failed provisions which are retried, such as the reasons given to install
+	// failures by the install log regexes. Hive stops provisioning the cluster after a provision fails for any other
+	// reason. All failed provisions are retried when empty.
+	// +optional
+	RetryableReasons []string `json:"retryableReasons,omitempty"`
 }
 
 // MachineManagement contains settings used for machine management.
@@ -213,6 +479,75 @@ type Provisioning struct {
 	// Defaults to openshift-install if none specified.
 	// +optional
 	InstallStrategy *InstallStrategy `json:"installStrategy,omitempty"`
+
+	// FeatureSet enables a set of features of the cluster which are not enabled by default, such as tech preview
+	// features. It is rendered into the InstallConfig, and must be supported by the version of OpenShift being
+	// installed. Clusters with a feature set enabled cannot be upgraded.
+	// +optional
+	FeatureSet *FeatureSetConfig `json:"featureSet,omitempty"`
+
+	// InstallTimeout is how long the install job of a provision may run before Hive aborts the provision and fails it
+	// with the InstallTimedOut reason, for example "2h". Defaults to the install timeout configured in HiveConfig for
+	// the platform of the cluster. The install job is not timed out when neither is set.
+	// +optional
+	InstallTimeout *metav1.Duration `json:"installTimeout,omitempty"`
+
+	// InstallPod customizes the compute resources and scheduling of the install pods of the cluster, for installs
+	// which need different resources than the defaults.
+	// +optional
+	InstallPod *InstallPodConfig `json:"installPod,omitempty"`
+
+	// Proxy is the cluster-wide proxy of the cluster. It is rendered into the InstallConfig when the InstallConfig
+	// does not configure a proxy itself.
+	// +optional
+	Proxy *ProxyConfig `json:"proxy,omitempty"`
+}
+
+// InstallPodConfig customizes the install pods of a cluster.
+type InstallPodConfig struct {
+	// Resources are the compute resources of the container of the install pod which runs the installer. Defaults to
+	// a request of 800Mi of memory.
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// NodeSelector restricts the nodes the install pods are scheduled on.
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Tolerations are the tolerations of the install pods.
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// PriorityClassName is the name of the PriorityClass of the install pods.
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+}
+
+// FeatureSetConfig is a set of features to enable on the cluster at install time.
+type FeatureSetConfig struct {
+	// FeatureSet is the feature set to enable.
+	// +kubebuilder:validation:Enum=TechPreviewNoUpgrade;CustomNoUpgrade
+	FeatureSet configv1.FeatureSet `json:"featureSet"`
+
+	// FeatureGates are the feature gates to enable or disable with the CustomNoUpgrade feature set, in the form
+	// Name=true or Name=false.
+	// +optional
+	FeatureGates []string `json:"featureGates,omitempty"`
+}
+
+// ProxyConfig is the cluster-wide proxy used by a cluster for its egress traffic.
+type ProxyConfig struct {
+	// HTTPProxy is the URL of the proxy for HTTP requests.
+	// +optional
+	HTTPProxy string `json:"httpProxy,omitempty"`
+
+	// HTTPSProxy is the URL of the proxy for HTTPS requests.
+	// +optional
+	HTTPSProxy string `json:"httpsProxy,omitempty"`
+
+	// NoProxy is a comma-separated list of the domains, IP addresses and CIDRs which are reached without the proxy.
+	// +optional
+	NoProxy string `json:"noProxy,omitempty"`
 }
 
 // ClusterImageSetReference is a reference to a ClusterImageSet
@@ -230,6 +565,13 @@ type ClusterPoolReference struct {
 	// ClaimName is the name of the ClusterClaim that claimed the cluster from the pool.
 	// +optional
 	ClaimName string `json:"claimName,omitempty"`
+	// CustomizationRef is the ClusterDeploymentCustomization of the inventory of the pool applied to the cluster.
+	// +optional
+	CustomizationRef *corev1.LocalObjectReference `json:"customizationRef,omitempty"`
+	// PlatformName is the name of the entry of the platforms of the pool the cluster was created on, when the pool
+	// spreads its clusters across several platforms.
+	// +optional
+	PlatformName string `json:"platformName,omitempty"`
 }
 
 // ClusterMetadata contains metadata information about the installed cluster.
@@ -248,6 +590,59 @@ type ClusterMetadata struct {
 	AdminPasswordSecretRef corev1.LocalObjectReference `json:"adminPasswordSecretRef"`
 }
 
+// PowerStateSchedule is the schedule on which a cluster is hibernated and resumed.
+type PowerStateSchedule struct {
+	// Hibernate is the cron expression of the times at which the cluster is hibernated, with the five fields minute,
+	// hour, day of month, month and day of week. For example, "0 19 * * 1-5" hibernates the cluster at 7pm on
+	// weekdays.
+	Hibernate string `json:"hibernate"`
+
+	// Resume is the cron expression of the times at which the cluster is resumed. For example, "0 7 * * 1-5" resumes
+	// the cluster at 7am on weekdays.
+	Resume string `json:"resume"`
+
+	// TimeZone is the IANA time zone in which the cron expressions are evaluated, such as "Europe/Paris". Defaults to
+	// UTC.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+// ResumeReadiness is the set of checks which a cluster must pass after resuming from hibernation before it is
+// considered running. The checks are retried until they all pass. A check which has not passed within its timeout is
+// reported by the ResumeReadinessTimedOut condition, and keeps being retried.
+type ResumeReadiness struct {
+	// Nodes checks that enough of the nodes of the cluster are ready. When omitted, all the nodes must be ready.
+	// +optional
+	Nodes *NodesResumeCheck `json:"nodes,omitempty"`
+
+	// ClusterOperators checks that all the cluster operators are available and not degraded.
+	// +optional
+	ClusterOperators *ResumeCheck `json:"clusterOperators,omitempty"`
+
+	// Certificates checks that the serving certificate of the API server of the cluster is valid.
+	// +optional
+	Certificates *ResumeCheck `json:"certificates,omitempty"`
+}
+
+// ResumeCheck is a check which a cluster must pass after resuming from hibernation.
+type ResumeCheck struct {
+	// Timeout is the time after the cluster started resuming at which the check is reported as timed out when it has
+	// not passed yet. The check is never reported as timed out when omitted.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// NodesResumeCheck checks that enough of the nodes of a cluster are ready after resuming from hibernation.
+type NodesResumeCheck struct {
+	ResumeCheck `json:",inline"`
+
+	// ReadyPercent is the percentage of the nodes of the cluster which must be ready. Defaults to 100.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	ReadyPercent *int32 `json:"readyPercent,omitempty"`
+}
+
 // ClusterDeploymentStatus defines the observed state of ClusterDeployment
 type ClusterDeploymentStatus struct {
 
@@ -260,6 +655,10 @@ type ClusterDeploymentStatus struct {
 	// WebConsoleURL is the URL for the cluster's web console UI.
 	WebConsoleURL string `json:"webConsoleURL,omitempty"`
 
+	// AdminKubeconfigContext is the context of the admin kubeconfig with which Hive connects to the cluster.
+	// +optional
+	AdminKubeconfigContext AdminKubeconfigContextType `json:"adminKubeconfigContext,omitempty"`
+
 	// InstallerImage is the name of the installer image to use when installing the target cluster
 	// +optional
 	InstallerImage *string `json:"installerImage,omitempty"`
@@ -302,6 +701,120 @@ type ClusterDeploymentStatus struct {
 	// perform the installation.
 	// +optional
 	Platform *PlatformStatus `json:"platformStatus,omitempty"`
+
+	// UnreachableRemediation records the remediation of the current or the last outage of the cluster.
+	// +optional
+	UnreachableRemediation *UnreachableRemediationStatus `json:"unreachableRemediation,omitempty"`
+
+	// KubeadminPasswordRotation is the value of spec.kubeadmin.passwordRotation for which the password of the
+	// kubeadmin user was last rotated.
+	// +optional
+	KubeadminPasswordRotation string `json:"kubeadminPasswordRotation,omitempty"`
+
+	// Restore is the restore of the cluster from the backup requested by Spec.RestoreRef.
+	// +optional
+	Restore *RestoreStatus `json:"restore,omitempty"`
+
+	// Activity is the user activity last observed on the cluster for Spec.HibernateAfterIdle.
+	// +optional
+	Activity *ClusterActivityStatus `json:"activity,omitempty"`
+
+	// Hibernation reports the time the cluster has spent hibernating.
+	// +optional
+	Hibernation *ClusterHibernationStatus `json:"hibernation,omitempty"`
+}
+
+// ClusterActivityStatus records the user activity observed on a cluster.
+type ClusterActivityStatus struct {
+	// LastActivityTime is the time of the latest user activity observed on the cluster.
+	// +optional
+	LastActivityTime *metav1.Time `json:"lastActivityTime,omitempty"`
+
+	// LastCheckTime is the time at which the activity on the cluster was last checked.
+	LastCheckTime metav1.Time `json:"lastCheckTime"`
+
+	// AuditLogPositions are the positions up to which the audit logs of the API servers of the cluster have been read
+	// to check the activity on the cluster.
+	// +optional
+	AuditLogPositions []AuditLogPosition `json:"auditLogPositions,omitempty"`
+}
+
+// AuditLogPosition is the position up to which the audit log of the API server on a control plane node has been read.
+type AuditLogPosition struct {
+	// Node is the name of the control plane node.
+	Node string `json:"node"`
+
+	// Offset is the offset in bytes in the audit log up to which it has been read.
+	Offset int64 `json:"offset"`
+}
+
+// ClusterHibernationStatus reports the time a cluster has spent hibernating.
+type ClusterHibernationStatus struct {
+	// HibernatedTime is the cumulative time during which the machines of the cluster have been stopped. While the
+	// cluster is hibernating, it includes the current hibernation up to LastAccountedTime.
+	HibernatedTime metav1.Duration `json:"hibernatedTime"`
+
+	// LastAccountedTime is the time up to which the current hibernation of the cluster has been added to
+	// HibernatedTime. It is only set while the cluster is hibernating.
+	// +optional
+	LastAccountedTime *metav1.Time `json:"lastAccountedTime,omitempty"`
+}
+
+// RestoreStatus is the status of the restore of a cluster from a Velero backup.
+type RestoreStatus struct {
+	// Name is the name of the Velero Restore on the cluster.
+	Name string `json:"name"`
+
+	// Namespace is the namespace of the Velero Restore on the cluster.
+	Namespace string `json:"namespace"`
+
+	// BackupName is the name of the Velero Backup being restored.
+	BackupName string `json:"backupName"`
+
+	// Phase is the last observed phase of the Velero Restore.
+	// +optional
+	Phase string `json:"phase,omitempty"`
+
+	// CompletionTime is the time the restore completed.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// UnreachableRemediationStatus records the steps attempted to restore connectivity to the cluster during an outage.
+type UnreachableRemediationStatus struct {
+	// UnreachableSince is when the cluster became unreachable for the outage being remediated.
+	UnreachableSince metav1.Time `json:"unreachableSince"`
+
+	// Steps are the steps attempted so far, in order.
+	// +optional
+	Steps []UnreachableRemediationStepStatus `json:"steps,omitempty"`
+}
+
+// UnreachableRemediationStepResult is the result of a step attempted to restore connectivity to the cluster.
+type UnreachableRemediationStepResult string
+
+const (
+	// AppliedRemediationStepResult is used when the step was carried out. Whether the cluster is reachable again is
+	// determined by the next connectivity check.
+	AppliedRemediationStepResult UnreachableRemediationStepResult = "Applied"
+	// FailedRemediationStepResult is used when the step could not be carried out.
+	FailedRemediationStepResult UnreachableRemediationStepResult = "Failed"
+)
+
+// UnreachableRemediationStepStatus records a step attempted to restore connectivity to the cluster.
+type UnreachableRemediationStepStatus struct {
+	// Type is the type of the step.
+	Type UnreachableRemediationStepType `json:"type"`
+
+	// AttemptTime is when the step was attempted.
+	AttemptTime metav1.Time `json:"attemptTime"`
+
+	// Result is the result of the step.
+	Result UnreachableRemediationStepResult `json:"result"`
+
+	// Message is a human-readable explanation of the result.
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
 // InstallStrategyStatus contains observed state from specific install strategies.
@@ -330,6 +843,9 @@ type ClusterDeploymentCondition struct {
 	// Message is a human-readable message indicating details about last transition.
 	// +optional
 	Message string `json:"message,omitempty"`
+	// Category is the class of the failure reported by the condition, from its reason.
+	// +optional
+	Category FailureCategory `json:"category,omitempty"`
 }
 
 // ClusterDeploymentConditionType is a valid value for ClusterDeploymentCondition.Type
@@ -350,6 +866,10 @@ const (
 	// certificates.
 	ControlPlaneCertificateNotFoundCondition ClusterDeploymentConditionType = "ControlPlaneCertificateNotFound"
 
+	// ControlPlaneCertificatesSyncedCondition indicates whether the control plane serving certificates have
+	// been synced to the cluster and rolled out to the kube-apiserver.
+	ControlPlaneCertificatesSyncedCondition ClusterDeploymentConditionType = "ControlPlaneCertificatesSynced"
+
 	// IngressCertificateNotFoundCondition is a condition indicating that one of the CertificateBundle
 	// secrets required by an Ingress is not available.
 	IngressCertificateNotFoundCondition ClusterDeploymentConditionType = "IngressCertificateNotFound"
@@ -394,6 +914,10 @@ const (
 	// AuthenticationFailureCondition is true when platform credentials cannot be used because of authentication failure
 	AuthenticationFailureClusterDeploymentCondition ClusterDeploymentConditionType = "AuthenticationFailure"
 
+	// CredentialsMalformedClusterDeploymentCondition is true when the platform credentials secret does not have the
+	// structure expected for the platform, such as a missing key or a value which does not parse.
+	CredentialsMalformedClusterDeploymentCondition ClusterDeploymentConditionType = "CredentialsMalformed"
+
 	// AWSPrivateLinkReadyClusterDeploymentCondition is true when private link access has been
 	// setup for the cluster.
 	AWSPrivateLinkReadyClusterDeploymentCondition ClusterDeploymentConditionType = "AWSPrivateLinkReady"
@@ -401,6 +925,36 @@ const (
 	// AWSPrivateLinkFailedClusterDeploymentCondition is true controller fails to setup private link access
 	// for the cluster.
 	AWSPrivateLinkFailedClusterDeploymentCondition ClusterDeploymentConditionType = "AWSPrivateLinkFailed"
+
+	// ReadyClusterDeploymentCondition is true when the cluster is installed and all the readiness gates of the
+	// ClusterDeployment are true.
+	ReadyClusterDeploymentCondition ClusterDeploymentConditionType = "Ready"
+
+	// UnreachableRemediationFailedCondition is true when every unreachable remediation step has been attempted and the
+	// cluster is still unreachable.
+	UnreachableRemediationFailedCondition ClusterDeploymentConditionType = "UnreachableRemediationFailed"
+
+	// SanitizationFailedCondition is true when a cluster released to its pool could not be sanitized, or fails the
+	// sanitization checks of the pool.
+	SanitizationFailedCondition ClusterDeploymentConditionType = "SanitizationFailed"
+
+	// FeatureSetNotSupportedCondition is true when the feature set of the cluster is not supported by the version of
+	// OpenShift being installed.
+	FeatureSetNotSupportedCondition ClusterDeploymentConditionType = "FeatureSetNotSupported"
+
+	// KubeadminRemovedCondition is true once the kubeadmin user has been removed from the cluster.
+	KubeadminRemovedCondition ClusterDeploymentConditionType = "KubeadminRemoved"
+
+	// KubeadminPasswordRotationFailedCondition is true when the password of the kubeadmin user could not be rotated.
+	KubeadminPasswordRotationFailedCondition ClusterDeploymentConditionType = "KubeadminPasswordRotationFailed"
+
+	// RestoreFailedCondition is true when the cluster could not be restored from the backup requested by
+	// spec.restoreRef.
+	RestoreFailedCondition ClusterDeploymentConditionType = "RestoreFailed"
+
+	// ResumeReadinessTimedOutCondition is true when a check of spec.resumeReadiness has not passed within its timeout
+	// after the cluster started resuming from hibernation. The reason names the check.
+	ResumeReadinessTimedOutCondition ClusterDeploymentConditionType = "ResumeReadinessTimedOut"
 )
 
 // AllClusterDeploymentConditions is a slice containing all condition types. This can be used for dealing with
@@ -409,6 +963,7 @@ var AllClusterDeploymentConditions = []ClusterDeploymentConditionType{
 	ClusterImageSetNotFoundCondition,
 	InstallerImageResolutionFailedCondition,
 	ControlPlaneCertificateNotFoundCondition,
+	ControlPlaneCertificatesSyncedCondition,
 	IngressCertificateNotFoundCondition,
 	UnreachableCondition,
 	ActiveAPIURLOverrideCondition,
@@ -420,6 +975,14 @@ var AllClusterDeploymentConditions = []ClusterDeploymentConditionType{
 	InstallLaunchErrorCondition,
 	AWSPrivateLinkReadyClusterDeploymentCondition,
 	AWSPrivateLinkFailedClusterDeploymentCondition,
+	ReadyClusterDeploymentCondition,
+	UnreachableRemediationFailedCondition,
+	SanitizationFailedCondition,
+	FeatureSetNotSupportedCondition,
+	KubeadminRemovedCondition,
+	KubeadminPasswordRotationFailedCondition,
+	RestoreFailedCondition,
+	ResumeReadinessTimedOutCondition,
 }
 
 // Cluster hibernating reasons
@@ -452,12 +1015,28 @@ const (
 	SyncSetsNotAppliedReason = "SyncSetsNotApplied"
 )
 
+// Resume readiness timed out reasons
+const (
+	// NodesNotReadyResumeReason is used as the reason when not enough of the nodes of the cluster are ready.
+	NodesNotReadyResumeReason = "NodesNotReady"
+	// ClusterOperatorsNotAvailableResumeReason is used as the reason when some cluster operators are not available or
+	// are degraded.
+	ClusterOperatorsNotAvailableResumeReason = "ClusterOperatorsNotAvailable"
+	// CertificatesNotValidResumeReason is used as the reason when the serving certificate of the API server of the
+	// cluster is not valid.
+	CertificatesNotValidResumeReason = "CertificatesNotValid"
+	// ResumeReadinessChecksPassedReason is used as the reason when all the checks passed and the
+	// ResumeReadinessTimedOut condition is false.
+	ResumeReadinessChecksPassedReason = "ReadinessChecksPassed"
+)
+
 // +genclient
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
 // ClusterDeployment is the Schema for the clusterdeployments API
 // +k8s:openapi-gen=true
 // +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="DisplayName",type="string",JSONPath=".spec.displayName",priority=1
 // +kubebuilder:printcolumn:name="Platform",type="string",JSONPath=".metadata.labels.hive\\.openshift\\.io/cluster-platform"
 // +kubebuilder:printcolumn:name="Region",type="string",JSONPath=".metadata.labels.hive\\.openshift\\.io/cluster-region"
 // +kubebuilder:printcolumn:name="ClusterType",type="string",JSONPath=".metadata.labels.hive\\.openshift\\.io/cluster-type"
@@ -513,6 +1092,19 @@ type Platform struct {
 	// AgentBareMetal is the configuration used when performing an Assisted Agent based installation
 	// to bare metal. Can only be used with the Assisted InstallStrategy.
 	AgentBareMetal *agent.BareMetalPlatform `json:"agentBareMetal,omitempty"`
+
+	// OCI is the configuration used when installing on Oracle Cloud Infrastructure.
+	// +optional
+	OCI *oci.Platform `json:"oci,omitempty"`
+
+	// PowerVS is the configuration used when installing on IBM Power Virtual Server.
+	// +optional
+	PowerVS *powervs.Platform `json:"powervs,omitempty"`
+
+	// None is the configuration used when installing on infrastructure created outside of Hive, with
+	// external infrastructure hooks creating machines from the ignition configs Hive generates.
+	// +optional
+	None *none.Platform `json:"none,omitempty"`
 }
 
 // PlatformStatus contains the observed state for the specific platform upon which to
@@ -528,6 +1120,11 @@ type InstallStrategy struct {
 	// Agent is the install strategy configuration for provisioning a cluster with the
 	// Agent based assisted installer.
 	Agent *agent.InstallStrategy `json:"agent,omitempty"`
+
+	// AgentImage is the install strategy configuration for provisioning a cluster from boot artifacts
+	// generated by the agent installer.
+	// +optional
+	AgentImage *agent.ImageInstallStrategy `json:"agentImage,omitempty"`
 }
 
 // ClusterIngress contains the configurable pieces for any ClusterIngress objects
@@ -538,7 +1135,9 @@ type ClusterIngress struct {
 	Name string `json:"name"`
 
 	// Domain (sometimes referred to as shard) is the full DNS suffix that the resulting
-	// IngressController object will service (eg abcd.mycluster.mydomain.com).
+	// IngressController object will service (eg abcd.mycluster.mydomain.com). The domain of the
+	// default ingress must be within the domain of the cluster. Additional ingress controllers may
+	// serve custom domains, whose DNS records are not managed by Hive.
 	// +required
 	Domain string `json:"domain"`
 
@@ -555,8 +1154,35 @@ type ClusterIngress struct {
 	// should be used for this Ingress
 	// +optional
 	ServingCertificate string `json:"servingCertificate,omitempty"`
+
+	// Replicas is the desired number of router replicas of the ingress controller. The ingress
+	// operator chooses the number of replicas when unset.
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// Scope is the scope at which the load balancer of the ingress controller is exposed, for
+	// example Internal for a sharded router only serving routes reachable from the network of the
+	// cluster. The ingress operator chooses the scope when unset.
+	// +optional
+	Scope IngressScope `json:"scope,omitempty"`
+
+	// NodeSelector restricts the nodes the router pods of the ingress controller are scheduled on,
+	// for example to run a sharded router on dedicated nodes.
+	// +optional
+	NodeSelector *metav1.LabelSelector `json:"nodeSelector,omitempty"`
 }
 
+// IngressScope is the scope at which the load balancer of an ingress controller is exposed.
+// +kubebuilder:validation:Enum=External;Internal
+type IngressScope string
+
+const (
+	// IngressScopeExternal exposes the ingress controller on the public network of the cluster.
+	IngressScopeExternal IngressScope = "External"
+	// IngressScopeInternal exposes the ingress controller only on the private network of the cluster.
+	IngressScopeInternal IngressScope = "Internal"
+)
+
 // ControlPlaneConfigSpec contains additional configuration settings for a target
 // cluster's control plane.
 type ControlPlaneConfigSpec struct {
@@ -613,6 +1239,34 @@ type CertificateBundleSpec struct {
 	// reference. Otherwise, it is expected that the secret should exist in the same namespace
 	// as the ClusterDeployment
 	CertificateSecretRef corev1.LocalObjectReference `json:"certificateSecretRef"`
+
+	// CertManager requests the certificate bundle from cert-manager. Hive creates a cert-manager
+	// Certificate for the control plane domains the bundle is used for, which stores the issued
+	// certificate in the secret referenced by CertificateSecretRef.
+	// +optional
+	CertManager *CertManagerCertificate `json:"certManager,omitempty"`
+}
+
+// CertManagerCertificate specifies how a certificate bundle is issued by cert-manager.
+type CertManagerCertificate struct {
+	// IssuerRef references the cert-manager issuer which issues the certificate.
+	IssuerRef CertManagerIssuerReference `json:"issuerRef"`
+}
+
+// CertManagerIssuerReference references a cert-manager Issuer in the namespace of the
+// ClusterDeployment, or a ClusterIssuer.
+type CertManagerIssuerReference struct {
+	// Name of the issuer.
+	Name string `json:"name"`
+
+	// Kind of the issuer, either Issuer or ClusterIssuer. Defaults to Issuer.
+	// +kubebuilder:validation:Enum=Issuer;ClusterIssuer
+	// +optional
+	Kind string `json:"kind,omitempty"`
+
+	// Group of the issuer. Defaults to cert-manager.io.
+	// +optional
+	Group string `json:"group,omitempty"`
 }
 
 // CertificateBundleStatus specifies whether a certificate bundle was generated for this
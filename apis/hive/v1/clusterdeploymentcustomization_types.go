@@ -0,0 +1,88 @@
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClusterDeploymentCustomizationSpec defines the customization applied to a cluster of a ClusterPool which draws the
+// ClusterDeploymentCustomization from its inventory.
+type ClusterDeploymentCustomizationSpec struct {
+	// ClusterName is the name of the cluster, used in the install-config and the domain of the cluster instead of the
+	// name generated by the pool. The ClusterDeployment and its namespace keep the generated name.
+	// +optional
+	ClusterName string `json:"clusterName,omitempty"`
+
+	// BaseDomain is the base domain of the cluster, used instead of the base domain of the pool. It is typically a
+	// subdomain of the base domain of the pool. A cluster with a customized base domain is not allocated a base
+	// domain from the BaseDomainPool of the pool.
+	// +optional
+	BaseDomain string `json:"baseDomain,omitempty"`
+
+	// InstallConfigPatches are JSON patches applied, in order, to the install-config of the cluster once its cluster
+	// name and base domain are set.
+	// +optional
+	InstallConfigPatches []PatchEntity `json:"installConfigPatches,omitempty"`
+}
+
+// PatchEntity is an operation of a JSON patch.
+type PatchEntity struct {
+	// Op is the operation to perform.
+	// +kubebuilder:validation:Enum=add;remove;replace;move;copy;test
+	Op string `json:"op"`
+
+	// Path is the JSON pointer to the location of the document the operation is performed on.
+	Path string `json:"path"`
+
+	// From is the JSON pointer to the location the value is moved or copied from by move and copy operations.
+	// +optional
+	From string `json:"from,omitempty"`
+
+	// Value is the string value used by add, replace and test operations.
+	// +optional
+	Value string `json:"value,omitempty"`
+}
+
+// ClusterDeploymentCustomizationStatus defines the observed state of ClusterDeploymentCustomization
+type ClusterDeploymentCustomizationStatus struct {
+	// ClusterPoolRef is the ClusterPool which applied the customization to one of its clusters, while the cluster
+	// exists.
+	// +optional
+	ClusterPoolRef *corev1.LocalObjectReference `json:"clusterPoolRef,omitempty"`
+
+	// ClusterDeploymentRef is the ClusterDeployment of the cluster the customization is applied to. It is cleared once
+	// the ClusterDeployment is gone, making the customization available to the pool again.
+	// +optional
+	ClusterDeploymentRef *corev1.LocalObjectReference `json:"clusterDeploymentRef,omitempty"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterDeploymentCustomization is an entry of the inventory of a ClusterPool, customizing one cluster of the pool at
+// a time so that the clusters of the pool are not identical.
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="ClusterPool",type="string",JSONPath=".status.clusterPoolRef.name"
+// +kubebuilder:printcolumn:name="ClusterDeployment",type="string",JSONPath=".status.clusterDeploymentRef.name"
+// +kubebuilder:resource:path=clusterdeploymentcustomizations,shortName=cdc
+type ClusterDeploymentCustomization struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterDeploymentCustomizationSpec   `json:"spec,omitempty"`
+	Status ClusterDeploymentCustomizationStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterDeploymentCustomizationList contains a list of ClusterDeploymentCustomizations
+type ClusterDeploymentCustomizationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterDeploymentCustomization `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterDeploymentCustomization{}, &ClusterDeploymentCustomizationList{})
+}
@@ -3,6 +3,8 @@ package v1
 import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift/hive/apis/hive/v1/none"
 )
 
 // ClusterDeprovisionSpec defines the desired state of ClusterDeprovision
@@ -15,6 +17,49 @@ type ClusterDeprovisionSpec struct {
 
 	// Platform contains platform-specific configuration for a ClusterDeprovision
 	Platform ClusterDeprovisionPlatform `json:"platform,omitempty"`
+
+	// Paused holds the deprovision of the cluster, preserving its infrastructure, for instance while a security incident
+	// is investigated. Any running uninstall job is stopped, and the deprovision resumes from the start once unpaused.
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+
+	// ExitBackup, when set, backs up the cluster with Velero before its infrastructure is deleted. The deprovision is
+	// held until the backup has completed.
+	// +optional
+	ExitBackup *ExitBackup `json:"exitBackup,omitempty"`
+}
+
+// ExitBackup configures the backup of a cluster with the Velero installed on the cluster before it is deprovisioned, so
+// that the cluster can be restored for forensic investigation after it has been deleted.
+type ExitBackup struct {
+	// Namespace is the namespace of Velero on the cluster. Defaults to velero.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// StorageLocation is the name of the Velero BackupStorageLocation on the cluster to upload the backup to. Defaults
+	// to the default location of Velero. The location must be outside of the cluster, such as an object storage
+	// bucket, for the backup to outlive the cluster.
+	// +optional
+	StorageLocation string `json:"storageLocation,omitempty"`
+
+	// SnapshotVolumes is whether snapshots of the persistent volumes of the cluster are taken. Defaults to true.
+	// +optional
+	SnapshotVolumes *bool `json:"snapshotVolumes,omitempty"`
+
+	// TTL is how long the backup is retained by Velero. Defaults to the retention of Velero.
+	// +optional
+	TTL *metav1.Duration `json:"ttl,omitempty"`
+
+	// Etcd is whether a snapshot of etcd is included in the backup. The snapshot is saved to a persistent volume claim
+	// in the openshift-etcd namespace of the cluster, whose volume is backed up with the file system backup of Velero
+	// (restic), which must be enabled.
+	// +optional
+	Etcd bool `json:"etcd,omitempty"`
+
+	// Timeout is how long the deprovision is held for the backup to complete. The deprovision proceeds without the
+	// backup once it has timed out. Defaults to 2h.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
 }
 
 // ClusterDeprovisionStatus defines the observed state of ClusterDeprovision
@@ -25,6 +70,67 @@ type ClusterDeprovisionStatus struct {
 	// Conditions includes more detailed status for the cluster deprovision
 	// +optional
 	Conditions []ClusterDeprovisionCondition `json:"conditions,omitempty"`
+
+	// LogSnapshotRef is the reference to the ConfigMap holding a snapshot of the end of the logs of the last
+	// uninstall pod which completed.
+	// +optional
+	LogSnapshotRef *corev1.LocalObjectReference `json:"logSnapshotRef,omitempty"`
+
+	// Progress is the progress of the uninstall pod in deleting the resources of the cluster, as last reported by
+	// the uninstall pod. Progress is only reported for platforms whose resources Hive deletes in parallel.
+	// +optional
+	Progress *ClusterDeprovisionProgress `json:"progress,omitempty"`
+
+	// ExitBackup is the backup of the cluster taken before its infrastructure was deleted, when requested by
+	// Spec.ExitBackup.
+	// +optional
+	ExitBackup *ExitBackupStatus `json:"exitBackup,omitempty"`
+}
+
+// ExitBackupStatus is the status of the backup of a cluster taken before it is deprovisioned
+type ExitBackupStatus struct {
+	// Name is the name of the Velero Backup on the cluster.
+	Name string `json:"name"`
+
+	// Namespace is the namespace of the Velero Backup on the cluster.
+	Namespace string `json:"namespace"`
+
+	// StorageLocation is the name of the Velero BackupStorageLocation the backup is uploaded to. The backup can be
+	// restored from this location by a Velero sharing it, after the cluster has been deleted.
+	// +optional
+	StorageLocation string `json:"storageLocation,omitempty"`
+
+	// Phase is the last observed phase of the Velero Backup.
+	// +optional
+	Phase string `json:"phase,omitempty"`
+
+	// CompletionTime is the time the backup completed.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// EtcdSnapshotClaim is the name of the persistent volume claim in the openshift-etcd namespace whose volume holds
+	// the snapshot of etcd in the backup, when Spec.ExitBackup.Etcd is set.
+	// +optional
+	EtcdSnapshotClaim string `json:"etcdSnapshotClaim,omitempty"`
+}
+
+// ClusterDeprovisionProgress contains the progress of the deletion of the resources of a cluster
+type ClusterDeprovisionProgress struct {
+	// LastUpdateTime is the last time the uninstall pod reported its progress.
+	LastUpdateTime metav1.Time `json:"lastUpdateTime"`
+
+	// RemainingResources is the number of resources of the cluster remaining to be deleted, by type of resource.
+	// +optional
+	RemainingResources []DeprovisionResourceCount `json:"remainingResources,omitempty"`
+}
+
+// DeprovisionResourceCount is the number of resources of a given type remaining to be deleted
+type DeprovisionResourceCount struct {
+	// Type is the type of the resources, such as ec2:instance.
+	Type string `json:"type"`
+
+	// Count is the number of resources of the type remaining to be deleted.
+	Count int `json:"count"`
 }
 
 // ClusterDeprovisionPlatform contains platform-specific configuration for the
@@ -42,6 +148,12 @@ type ClusterDeprovisionPlatform struct {
 	VSphere *VSphereClusterDeprovision `json:"vsphere,omitempty"`
 	// Ovirt contains oVirt-specific deprovision settings
 	Ovirt *OvirtClusterDeprovision `json:"ovirt,omitempty"`
+	// OCI contains Oracle Cloud Infrastructure-specific deprovision settings
+	OCI *OCIClusterDeprovision `json:"oci,omitempty"`
+	// PowerVS contains IBM Power Virtual Server-specific deprovision settings
+	PowerVS *PowerVSClusterDeprovision `json:"powervs,omitempty"`
+	// None contains deprovision settings for clusters on infrastructure created outside of Hive
+	None *NoneClusterDeprovision `json:"none,omitempty"`
 }
 
 // AWSClusterDeprovision contains AWS-specific configuration for a ClusterDeprovision
@@ -51,6 +163,11 @@ type AWSClusterDeprovision struct {
 
 	// CredentialsSecretRef is the AWS account credentials to use for deprovisioning the cluster
 	CredentialsSecretRef *corev1.LocalObjectReference `json:"credentialsSecretRef,omitempty"`
+
+	// STS is true when the install created, with ccoctl, the IAM roles, the OIDC provider and the S3 bucket of the
+	// cluster for the AWS Security Token Service, named after the infra ID. They are deleted with the cluster.
+	// +optional
+	STS bool `json:"sts,omitempty"`
 }
 
 // AzureClusterDeprovision contains Azure-specific configuration for a ClusterDeprovision
@@ -103,6 +220,35 @@ type OvirtClusterDeprovision struct {
 	CertificatesSecretRef corev1.LocalObjectReference `json:"certificatesSecretRef"`
 }
 
+// OCIClusterDeprovision contains Oracle Cloud Infrastructure-specific configuration for a ClusterDeprovision
+type OCIClusterDeprovision struct {
+	// Region is the OCI region for this deprovision
+	Region string `json:"region"`
+	// CompartmentID is the OCID of the compartment containing the cluster resources
+	CompartmentID string `json:"compartmentID"`
+	// CredentialsSecretRef is the OCI API signing key credentials to use for deprovisioning the cluster
+	CredentialsSecretRef corev1.LocalObjectReference `json:"credentialsSecretRef"`
+}
+
+// PowerVSClusterDeprovision contains IBM Power Virtual Server-specific configuration for a ClusterDeprovision
+type PowerVSClusterDeprovision struct {
+	// Region is the IBM Cloud region for this deprovision
+	Region string `json:"region"`
+	// Zone is the PowerVS zone for this deprovision
+	Zone string `json:"zone"`
+	// ServiceInstanceID is the GUID of the Power Virtual Server service instance containing the cluster resources
+	ServiceInstanceID string `json:"serviceInstanceID"`
+	// CredentialsSecretRef is the IBM Cloud API key to use for deprovisioning the cluster
+	CredentialsSecretRef corev1.LocalObjectReference `json:"credentialsSecretRef"`
+}
+
+// NoneClusterDeprovision contains configuration for a ClusterDeprovision of a cluster on infrastructure
+// created outside of Hive
+type NoneClusterDeprovision struct {
+	// Destroyer is the site-specific teardown automation to invoke
+	Destroyer none.ExternalDestroyer `json:"destroyer"`
+}
+
 // +genclient
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
@@ -112,6 +258,7 @@ type OvirtClusterDeprovision struct {
 // +kubebuilder:printcolumn:name="InfraID",type="string",JSONPath=".spec.infraID"
 // +kubebuilder:printcolumn:name="ClusterID",type="string",JSONPath=".spec.clusterID"
 // +kubebuilder:printcolumn:name="Completed",type="boolean",JSONPath=".status.completed"
+// +kubebuilder:printcolumn:name="Paused",type="boolean",JSONPath=".spec.paused"
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:resource:path=clusterdeprovisions,shortName=cdr,scope=Namespaced
 type ClusterDeprovision struct {
@@ -140,6 +287,9 @@ type ClusterDeprovisionCondition struct {
 	// Message is a human-readable message indicating details about last transition.
 	// +optional
 	Message string `json:"message,omitempty"`
+	// Category is the class of the failure reported by the condition, from its reason.
+	// +optional
+	Category FailureCategory `json:"category,omitempty"`
 }
 
 // ClusterDeprovisionConditionType is a valid value for ClusterDeprovisionCondition.Type
@@ -148,6 +298,26 @@ type ClusterDeprovisionConditionType string
 const (
 	// AuthenticationFailureClusterDeprovisionCondition is true when credentials cannot be used because of authentication failure
 	AuthenticationFailureClusterDeprovisionCondition ClusterDeprovisionConditionType = "AuthenticationFailure"
+
+	// ExternalDestroyerFailedClusterDeprovisionCondition is true when the external destroyer of a cluster on
+	// infrastructure created outside of Hive has failed and is being retried
+	ExternalDestroyerFailedClusterDeprovisionCondition ClusterDeprovisionConditionType = "ExternalDestroyerFailed"
+
+	// AWSPrivateLinkCleanupFailedClusterDeprovisionCondition is true when the resources created for AWS PrivateLink
+	// access to the cluster could not be removed and must be cleaned up manually
+	AWSPrivateLinkCleanupFailedClusterDeprovisionCondition ClusterDeprovisionConditionType = "AWSPrivateLinkCleanupFailed"
+
+	// PausedClusterDeprovisionCondition is true when the deprovision is held by Spec.Paused
+	PausedClusterDeprovisionCondition ClusterDeprovisionConditionType = "Paused"
+
+	// ExitBackupFailedClusterDeprovisionCondition is true when the backup of the cluster requested by
+	// Spec.ExitBackup could not be taken, which holds the deprovision
+	ExitBackupFailedClusterDeprovisionCondition ClusterDeprovisionConditionType = "ExitBackupFailed"
+
+	// ExitBackupSkippedClusterDeprovisionCondition is true when the backup of the cluster requested by
+	// Spec.ExitBackup was skipped, because the cluster could not be backed up or the backup timed out, and the
+	// deprovision proceeded without it
+	ExitBackupSkippedClusterDeprovisionCondition ClusterDeprovisionConditionType = "ExitBackupSkipped"
 )
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
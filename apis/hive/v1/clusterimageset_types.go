@@ -1,6 +1,7 @@
 package v1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -9,10 +10,87 @@ type ClusterImageSetSpec struct {
 	// ReleaseImage is the image that contains the payload to use when installing
 	// a cluster.
 	ReleaseImage string `json:"releaseImage"`
+
+	// PrePullImages pulls the installer and cli images of the release onto the nodes of the hub once the image set
+	// has been validated, so that installs using the image set do not wait for them to be pulled.
+	// +optional
+	PrePullImages bool `json:"prePullImages,omitempty"`
 }
 
 // ClusterImageSetStatus defines the observed state of ClusterImageSet
-type ClusterImageSetStatus struct{}
+type ClusterImageSetStatus struct {
+	// ReleaseImage is the release image that was last validated.
+	// +optional
+	ReleaseImage string `json:"releaseImage,omitempty"`
+
+	// Version is the version of the validated release image.
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// Architecture is the architecture of the validated release image, or multi for a release image of several
+	// architectures.
+	// +optional
+	Architecture string `json:"architecture,omitempty"`
+
+	// InstallerImage is the installer image of the validated release image.
+	// +optional
+	InstallerImage string `json:"installerImage,omitempty"`
+
+	// CLIImage is the cli image of the validated release image.
+	// +optional
+	CLIImage string `json:"cliImage,omitempty"`
+
+	// ClusterDeployments is the number of ClusterDeployments that install with the image set.
+	// +optional
+	ClusterDeployments int32 `json:"clusterDeployments,omitempty"`
+
+	// ClusterPools is the number of ClusterPools that create their clusters with the image set.
+	// +optional
+	ClusterPools int32 `json:"clusterPools,omitempty"`
+
+	// LastUsedTime is the last time the image set was seen referenced by a ClusterDeployment or ClusterPool. It is
+	// not set for an image set that has never been used. Image sets that have not been used in a long time can be
+	// pruned safely.
+	// +optional
+	LastUsedTime *metav1.Time `json:"lastUsedTime,omitempty"`
+
+	// Conditions includes more detailed status for the cluster image set.
+	// +optional
+	Conditions []ClusterImageSetCondition `json:"conditions,omitempty"`
+}
+
+// ClusterImageSetCondition contains details for the current condition of a cluster image set.
+type ClusterImageSetCondition struct {
+	// Type is the type of the condition.
+	Type ClusterImageSetConditionType `json:"type"`
+	// Status is the status of the condition.
+	Status corev1.ConditionStatus `json:"status"`
+	// LastProbeTime is the last time we probed the condition.
+	// +optional
+	LastProbeTime metav1.Time `json:"lastProbeTime,omitempty"`
+	// LastTransitionTime is the last time the condition transitioned from one status to another.
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+	// Reason is a unique, one-word, CamelCase reason for the condition's last transition.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// Message is a human-readable message indicating details about last transition.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// ClusterImageSetConditionType is a valid value for ClusterImageSetCondition.Type
+type ClusterImageSetConditionType string
+
+const (
+	// ClusterImageSetValidCondition reports whether the release image of the image set could be resolved, can run on
+	// the nodes of the hub and has the images required for installs. It is Unknown while the release image is being
+	// validated.
+	ClusterImageSetValidCondition ClusterImageSetConditionType = "Valid"
+	// ClusterImageSetImagesPrePulledCondition is set when the images of the image set are pre-pulled, to report
+	// whether they have been pulled onto all of the nodes of the hub.
+	ClusterImageSetImagesPrePulledCondition ClusterImageSetConditionType = "ImagesPrePulled"
+)
 
 // +genclient:nonNamespaced
 // +genclient
@@ -23,6 +101,11 @@ type ClusterImageSetStatus struct{}
 // +kubebuilder:resource:scope=Cluster
 // +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="Release",type="string",JSONPath=".spec.releaseImage"
+// +kubebuilder:printcolumn:name="Version",type="string",JSONPath=".status.version"
+// +kubebuilder:printcolumn:name="Valid",type="string",JSONPath=".status.conditions[?(@.type=='Valid')].status"
+// +kubebuilder:printcolumn:name="Deployments",type="integer",JSONPath=".status.clusterDeployments"
+// +kubebuilder:printcolumn:name="Pools",type="integer",JSONPath=".status.clusterPools"
+// +kubebuilder:printcolumn:name="LastUsed",type="date",JSONPath=".status.lastUsedTime",priority=1
 // +kubebuilder:resource:path=clusterimagesets,shortName=imgset,scope=Cluster
 type ClusterImageSet struct {
 	metav1.TypeMeta   `json:",inline"`
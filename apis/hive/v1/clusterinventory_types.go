@@ -0,0 +1,97 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// HiveClusterNetworkTypeLabel is a label that is applied to ClusterInventories to denote the
+	// network type of the cluster. This can be used in searching and filtering clusters.
+	HiveClusterNetworkTypeLabel = "hive.openshift.io/network-type"
+
+	// HiveClusterFIPSLabel is a label that is applied to ClusterInventories to denote whether the
+	// cluster was installed in FIPS mode. This can be used in searching and filtering clusters.
+	HiveClusterFIPSLabel = "hive.openshift.io/fips"
+)
+
+// ClusterInventorySpec defines the desired state of ClusterInventory
+type ClusterInventorySpec struct {
+}
+
+// ClusterInventoryStatus defines the observed state of ClusterInventory
+type ClusterInventoryStatus struct {
+	// LastUpdated is the last time that the inventory was updated
+	LastUpdated *metav1.Time `json:"lastUpdated,omitempty"`
+
+	// Version is the version of OpenShift running on the cluster
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// Platform is the platform the cluster was created on
+	// +optional
+	Platform string `json:"platform,omitempty"`
+
+	// Region is the region the cluster was created in
+	// +optional
+	Region string `json:"region,omitempty"`
+
+	// NetworkType is the type of the cluster network, such as OpenShiftSDN or OVNKubernetes
+	// +optional
+	NetworkType string `json:"networkType,omitempty"`
+
+	// FIPS is true when the cluster was installed in FIPS mode
+	// +optional
+	FIPS bool `json:"fips,omitempty"`
+
+	// Operators contains the cluster operators installed on the cluster, as reported by the ClusterState
+	// +optional
+	Operators []ClusterInventoryOperator `json:"operators,omitempty"`
+}
+
+// ClusterInventoryOperator summarizes a single cluster operator installed on the cluster
+type ClusterInventoryOperator struct {
+	// Name is the name of the cluster operator
+	Name string `json:"name"`
+
+	// Available is true when the cluster operator reports that it is available
+	Available bool `json:"available"`
+
+	// Degraded is true when the cluster operator reports that it is degraded
+	Degraded bool `json:"degraded"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterInventory summarizes the version and capabilities of a cluster. It is maintained by Hive
+// and carries the labels of its ClusterDeployment, so that the fleet can be queried with label
+// selectors.
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Version",type="string",JSONPath=".status.version"
+// +kubebuilder:printcolumn:name="Platform",type="string",JSONPath=".status.platform"
+// +kubebuilder:printcolumn:name="Region",type="string",JSONPath=".status.region"
+// +kubebuilder:printcolumn:name="NetworkType",type="string",JSONPath=".status.networkType"
+// +kubebuilder:printcolumn:name="FIPS",type="boolean",JSONPath=".status.fips"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:path=clusterinventories,shortName=cinv,scope=Namespaced
+type ClusterInventory struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterInventorySpec   `json:"spec,omitempty"`
+	Status ClusterInventoryStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterInventoryList contains a list of ClusterInventory
+type ClusterInventoryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterInventory `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterInventory{}, &ClusterInventoryList{})
+}
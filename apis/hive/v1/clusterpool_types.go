@@ -8,9 +8,15 @@ import (
 // ClusterPoolSpec defines the desired state of the ClusterPool.
 type ClusterPoolSpec struct {
 
-	// Platform encompasses the desired platform for the cluster.
-	// +required
-	Platform Platform `json:"platform"`
+	// Platform encompasses the desired platform for the cluster. It must be left empty when Platforms is set.
+	// +optional
+	Platform Platform `json:"platform,omitempty"`
+
+	// Platforms are the platforms, such as several regions or clouds, the new clusters of the pool are spread across
+	// in proportion to their weights, instead of Platform. Spreading the clusters keeps the pool filled when
+	// clusters cannot be provisioned on one of its platforms.
+	// +optional
+	Platforms []ClusterPoolPlatform `json:"platforms,omitempty"`
 
 	// PullSecretRef is the reference to the secret to use when pulling images.
 	// +optional
@@ -21,6 +27,14 @@ type ClusterPoolSpec struct {
 	// +required
 	Size int32 `json:"size"`
 
+	// RunningCount is the number of unclaimed clusters of the pool kept running, so that claims are assigned a running
+	// cluster at once. The rest of the unclaimed clusters are kept hibernating. As running clusters are claimed,
+	// hibernating clusters are resumed to take their place. When it exceeds the number of unclaimed clusters, all of
+	// them are kept running. Defaults to 0, keeping all unclaimed clusters hibernating.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	RunningCount int32 `json:"runningCount,omitempty"`
+
 	// MaxSize is the maximum number of clusters that will be provisioned including clusters that have been claimed
 	// and ones waiting to be used.
 	// By default there is no limit.
@@ -29,14 +43,27 @@ type ClusterPoolSpec struct {
 
 	// MaxConcurrent is the maximum number of clusters that will be provisioned or deprovisioned at an time. This includes the
 	// claimed clusters being deprovisioned.
-	// By default there is no limit.
+	// Defaults to the ClusterPoolMaxConcurrent of the HiveConfig, which has no limit by default.
 	// +optional
 	MaxConcurrent *int32 `json:"maxConcurrent,omitempty"`
 
-	// BaseDomain is the base domain to use for all clusters created in this pool.
+	// MaxClusterAge is how long after its install an unclaimed ready cluster of the pool is replaced by a fresh install,
+	// so that the clusters waiting to be claimed do not drift from the image set of the pool or accumulate expired
+	// certificates. Stale clusters are replaced once the pool is full, as many at a time as MaxConcurrent allows, or
+	// one at a time when MaxConcurrent is not set. By default clusters are not replaced.
+	// +optional
+	MaxClusterAge *metav1.Duration `json:"maxClusterAge,omitempty"`
+
+	// BaseDomain is the base domain to use for all clusters created in this pool. It may be left empty when
+	// BaseDomainPoolRef is set, in which case each cluster is allocated a unique base domain from the pool.
 	// +required
 	BaseDomain string `json:"baseDomain"`
 
+	// BaseDomainPoolRef is a reference to a BaseDomainPool from which unique base domains are allocated to
+	// the clusters created in this pool when BaseDomain is empty. Hive manages DNS for these clusters.
+	// +optional
+	BaseDomainPoolRef *BaseDomainPoolReference `json:"baseDomainPoolRef,omitempty"`
+
 	// ImageSetRef is a reference to a ClusterImageSet. The release image specified in the ClusterImageSet will be used
 	// by clusters created for this cluster pool.
 	ImageSetRef ClusterImageSetReference `json:"imageSetRef"`
@@ -59,6 +86,11 @@ type ClusterPoolSpec struct {
 	// +optional
 	HibernateAfter *metav1.Duration `json:"hibernateAfter,omitempty"`
 
+	// ResumeReadiness will be applied to new ClusterDeployments created for the pool. It configures the checks which
+	// the clusters must pass after resuming from hibernation before they are considered running.
+	// +optional
+	ResumeReadiness *ResumeReadiness `json:"resumeReadiness,omitempty"`
+
 	// SkipMachinePools allows creating clusterpools where the machinepools are not managed by hive after cluster creation
 	// +optional
 	SkipMachinePools bool `json:"skipMachinePools,omitempty"`
@@ -66,8 +98,160 @@ type ClusterPoolSpec struct {
 	// ClaimLifetime defines the lifetimes for claims for the cluster pool.
 	// +optional
 	ClaimLifetime *ClusterPoolClaimLifetime `json:"claimLifetime,omitempty"`
+
+	// ReadinessGates will be applied to new ClusterDeployments created for the pool. Clusters are only assigned to
+	// claims once all of their readiness gates are true.
+	// +optional
+	ReadinessGates []ClusterDeploymentReadinessGate `json:"readinessGates,omitempty"`
+
+	// ReservationLeadTime is how long before the activation time of a reserved claim the pool starts holding a
+	// cluster for it, provisioning one if none is ready. It should cover the time to install a cluster. Defaults to 1h.
+	// +optional
+	ReservationLeadTime *metav1.Duration `json:"reservationLeadTime,omitempty"`
+
+	// PreemptReservedClaims lets pending claims take the clusters held for reserved claims of a lower priority. By
+	// default, a cluster held for a reserved claim is kept for it whatever the priority of the claims that follow.
+	// +optional
+	PreemptReservedClaims bool `json:"preemptReservedClaims,omitempty"`
+
+	// ReleasePolicy is what happens to a claimed cluster when its claim is deleted. Defaults to Destroy.
+	// +kubebuilder:validation:Enum=Destroy;Hibernate;Quarantine
+	// +optional
+	ReleasePolicy ClusterPoolReleasePolicy `json:"releasePolicy,omitempty"`
+
+	// RebaselineSyncSets are the names of SyncSets in the namespace of the pool which are applied to a cluster released
+	// with the Hibernate policy, to wipe the changes made while it was claimed, before it is returned to the pool.
+	// +optional
+	RebaselineSyncSets []corev1.LocalObjectReference `json:"rebaselineSyncSets,omitempty"`
+
+	// Sanitization configures the deletion of what was created in a cluster released with the Hibernate policy, and
+	// the checks of its state, before it is returned to the pool.
+	// +optional
+	Sanitization *ClusterPoolSanitization `json:"sanitization,omitempty"`
+
+	// Inventory is a list of customizations in the namespace of the pool, each applied to one cluster of the pool at a
+	// time, so that the clusters of the pool are not identical. When set, a cluster is only created when an entry of
+	// the inventory is available, so the pool never has more clusters than entries.
+	// +optional
+	Inventory []InventoryEntry `json:"inventory,omitempty"`
+
+	// Mode is the mode of the pool. A Paused pool keeps assigning its clusters to claims but creates no new clusters.
+	// A Draining pool assigns its ready clusters to the pending claims, creates no new clusters and deletes the rest
+	// of its unclaimed clusters, to retire the pool. Defaults to Active.
+	// +kubebuilder:validation:Enum=Active;Paused;Draining
+	// +optional
+	Mode ClusterPoolMode `json:"mode,omitempty"`
+
+	// InstallFailureBudget limits the install failures of the clusters of the pool. When more installs fail within
+	// its window than the budget allows, for example because of a bad image set or an outage of the cloud, the pool
+	// stops creating clusters until enough of the failures are older than the window.
+	// +optional
+	InstallFailureBudget *ClusterPoolInstallFailureBudget `json:"installFailureBudget,omitempty"`
+}
+
+// ClusterPoolInstallFailureBudget is the number of install failures allowed for the clusters of a pool within a window.
+type ClusterPoolInstallFailureBudget struct {
+	// MaxFailures is the number of install failures allowed within the window. The pool stops creating clusters once
+	// more installs have failed.
+	// +kubebuilder:validation:Minimum=0
+	MaxFailures int32 `json:"maxFailures"`
+
+	// Window is the period over which install failures are counted.
+	Window metav1.Duration `json:"window"`
+}
+
+// ClusterPoolMode is the mode of a ClusterPool.
+type ClusterPoolMode string
+
+const (
+	// ClusterPoolModeActive keeps the pool filled to its size.
+	ClusterPoolModeActive ClusterPoolMode = "Active"
+	// ClusterPoolModePaused stops creating new clusters, while the existing clusters are still assigned to claims.
+	ClusterPoolModePaused ClusterPoolMode = "Paused"
+	// ClusterPoolModeDraining stops creating new clusters and deletes the unclaimed clusters which are not assigned to
+	// the pending claims.
+	ClusterPoolModeDraining ClusterPoolMode = "Draining"
+)
+
+// ClusterPoolPlatform is a platform the clusters of a ClusterPool are spread across.
+type ClusterPoolPlatform struct {
+	// Name identifies the platform in the pool. It is recorded on the ClusterDeployments created on the platform.
+	Name string `json:"name"`
+
+	// Weight is the share of the clusters of the pool created on the platform, relative to the weights of the other
+	// platforms. A platform with a weight of 0 is not used for new clusters. Defaults to 1.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Weight *int32 `json:"weight,omitempty"`
+
+	// Platform encompasses the platform of the clusters created on it.
+	Platform `json:",inline"`
 }
 
+// InventoryEntryKind is the kind of an entry of the inventory of a ClusterPool.
+type InventoryEntryKind string
+
+const (
+	// ClusterDeploymentCustomizationInventoryEntry is an entry referencing a ClusterDeploymentCustomization.
+	ClusterDeploymentCustomizationInventoryEntry InventoryEntryKind = "ClusterDeploymentCustomization"
+)
+
+// InventoryEntry is an entry of the inventory of a ClusterPool.
+type InventoryEntry struct {
+	// Kind of the entry. Defaults to ClusterDeploymentCustomization.
+	// +kubebuilder:validation:Enum=ClusterDeploymentCustomization
+	// +optional
+	Kind InventoryEntryKind `json:"kind,omitempty"`
+
+	// Name is the name of the ClusterDeploymentCustomization in the namespace of the pool.
+	Name string `json:"name"`
+}
+
+// ClusterPoolSanitization configures the sanitization of the clusters released to the pool. Namespaces and
+// CustomResourceDefinitions created while a cluster was claimed are deleted from it, and the checks must pass, before
+// it can be claimed again.
+type ClusterPoolSanitization struct {
+	// PreservedNamespaces are the names of namespaces, which may contain "*" wildcards, which are not deleted even
+	// when created while the cluster was claimed. The default, openshift, openshift-* and kube-* namespaces are always
+	// preserved.
+	// +optional
+	PreservedNamespaces []string `json:"preservedNamespaces,omitempty"`
+
+	// DeleteCustomResourceDefinitions deletes the CustomResourceDefinitions created while the cluster was claimed,
+	// along with their custom resources.
+	// +optional
+	DeleteCustomResourceDefinitions bool `json:"deleteCustomResourceDefinitions,omitempty"`
+
+	// Checks of the state of the cluster which must pass before it is returned to the pool.
+	// +optional
+	Checks []ClusterSanitizationCheck `json:"checks,omitempty"`
+}
+
+// ClusterSanitizationCheck is a check of the state of a sanitized cluster.
+// +kubebuilder:validation:Enum=ClusterOperatorsAvailable;NodesReady
+type ClusterSanitizationCheck string
+
+const (
+	// ClusterOperatorsAvailableSanitizationCheck checks that all ClusterOperators are available and not degraded.
+	ClusterOperatorsAvailableSanitizationCheck ClusterSanitizationCheck = "ClusterOperatorsAvailable"
+	// NodesReadySanitizationCheck checks that all nodes are ready.
+	NodesReadySanitizationCheck ClusterSanitizationCheck = "NodesReady"
+)
+
+// ClusterPoolReleasePolicy is what happens to a claimed cluster when its claim is deleted.
+type ClusterPoolReleasePolicy string
+
+const (
+	// ClusterPoolReleasePolicyDestroy deprovisions the cluster.
+	ClusterPoolReleasePolicyDestroy ClusterPoolReleasePolicy = "Destroy"
+	// ClusterPoolReleasePolicyHibernate applies the rebaseline SyncSets of the pool to the cluster, hibernates it and
+	// returns it to the pool to be claimed again.
+	ClusterPoolReleasePolicyHibernate ClusterPoolReleasePolicy = "Hibernate"
+	// ClusterPoolReleasePolicyQuarantine keeps the cluster, still out of the pool, for inspection. It is deprovisioned
+	// once its ClusterDeployment is deleted.
+	ClusterPoolReleasePolicyQuarantine ClusterPoolReleasePolicy = "Quarantine"
+)
+
 // ClusterPoolClaimLifetime defines the lifetimes for claims for the cluster pool.
 type ClusterPoolClaimLifetime struct {
 	// Default is the default lifetime of the claim when no lifetime is set on the claim itself.
@@ -79,6 +263,11 @@ type ClusterPoolClaimLifetime struct {
 	// The lifetime of a claim is the mimimum of the lifetimes set by the cluster pool and the claim itself.
 	// +optional
 	Maximum *metav1.Duration `json:"maximum,omitempty"`
+
+	// IdleTimeout is how long the cluster of a claim can go without API activity before the claim is deleted by Hive.
+	// Claims can set a shorter idle timeout.
+	// +optional
+	IdleTimeout *metav1.Duration `json:"idleTimeout,omitempty"`
 }
 
 // ClusterPoolStatus defines the observed state of ClusterPool
@@ -89,9 +278,37 @@ type ClusterPoolStatus struct {
 	// Ready is the number of unclaimed clusters that have been installed and are ready to be claimed.
 	Ready int32 `json:"ready"`
 
+	// Running is the number of ready unclaimed clusters which are running rather than hibernating or resuming.
+	// +optional
+	Running int32 `json:"running,omitempty"`
+
 	// Conditions includes more detailed status for the cluster pool
 	// +optional
 	Conditions []ClusterPoolCondition `json:"conditions,omitempty"`
+
+	// InstallFailures are the install failures of the clusters of the pool within the window of its install failure
+	// budget, or within the last two hours when it has no budget. Failures are recorded when their ClusterProvision
+	// fails, so they are still counted once the ClusterProvision or its cluster is deleted.
+	// +optional
+	InstallFailures []ClusterPoolInstallFailure `json:"installFailures,omitempty"`
+}
+
+// ClusterPoolInstallFailure is a failed install of a cluster of a pool.
+type ClusterPoolInstallFailure struct {
+	// ClusterProvision is the namespace and name of the failed ClusterProvision, in the form namespace/name.
+	ClusterProvision string `json:"clusterProvision"`
+
+	// Time is when the install failed.
+	Time metav1.Time `json:"time"`
+
+	// Reason is the reason of the failure, such as AWSInsufficientCapacity.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// Platform is the name of the entry of the platforms of the pool the cluster was created on, empty for a pool with
+	// a single platform.
+	// +optional
+	Platform string `json:"platform,omitempty"`
 }
 
 // ClusterPoolCondition contains details for the current condition of a cluster pool
@@ -112,6 +329,9 @@ type ClusterPoolCondition struct {
 	// Message is a human-readable message indicating details about last transition.
 	// +optional
 	Message string `json:"message,omitempty"`
+	// Category is the class of the failure reported by the condition, from its reason.
+	// +optional
+	Category FailureCategory `json:"category,omitempty"`
 }
 
 // ClusterPoolConditionType is a valid value for ClusterPoolCondition.Type
@@ -124,6 +344,21 @@ const (
 	// ClusterPoolCapacityAvailableCondition is set to provide information on whether the cluster pool has capacity
 	// available to create more clusters for the pool.
 	ClusterPoolCapacityAvailableCondition ClusterPoolConditionType = "CapacityAvailable"
+	// ClusterPoolInventoryValidCondition is set when the pool has an inventory, to report whether all of its entries
+	// exist and can be applied to the clusters of the pool.
+	ClusterPoolInventoryValidCondition ClusterPoolConditionType = "InventoryValid"
+	// ClusterPoolActiveCondition is set when the pool is paused or draining, to report that it is not creating new
+	// clusters and, while draining, how many unclaimed clusters remain.
+	ClusterPoolActiveCondition ClusterPoolConditionType = "Active"
+	// ClusterPoolInstallFailureBudgetExceededCondition is set when the pool has an install failure budget, to report
+	// whether more installs have failed within its window than allowed, so that the pool is not creating clusters.
+	ClusterPoolInstallFailureBudgetExceededCondition ClusterPoolConditionType = "InstallFailureBudgetExceeded"
+	// ClusterPoolAllClustersCurrentCondition reports whether the unclaimed clusters of the pool match its current spec,
+	// or some were created from an earlier spec or are older than its max cluster age.
+	ClusterPoolAllClustersCurrentCondition ClusterPoolConditionType = "AllClustersCurrent"
+	// ClusterPoolProvisioningBlockedCondition reports whether the pool is failing to provision clusters, with the most
+	// common reason of its recent install failures.
+	ClusterPoolProvisioningBlockedCondition ClusterPoolConditionType = "ProvisioningBlocked"
 )
 
 // +genclient
@@ -136,8 +371,10 @@ const (
 // +kubebuilder:subresource:scale:specpath=.spec.size,statuspath=.status.size
 // +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.ready"
 // +kubebuilder:printcolumn:name="Size",type="string",JSONPath=".spec.size"
+// +kubebuilder:printcolumn:name="Running",type="string",JSONPath=".status.running"
 // +kubebuilder:printcolumn:name="BaseDomain",type="string",JSONPath=".spec.baseDomain"
 // +kubebuilder:printcolumn:name="ImageSet",type="string",JSONPath=".spec.imageSetRef.name"
+// +kubebuilder:printcolumn:name="Mode",type="string",JSONPath=".spec.mode"
 // +kubebuilder:resource:path=clusterpools,shortName=cp
 type ClusterPool struct {
 	metav1.TypeMeta   `json:",inline"`
@@ -44,6 +44,11 @@ type ClusterProvisionSpec struct {
 
 	// PrevInfraID is the infra ID of the previous failed provision attempt.
 	PrevInfraID *string `json:"prevInfraID,omitempty"`
+
+	// InstallTimeout is how long the install job may run before the provision is aborted and failed with the
+	// InstallTimedOut reason. The install job is not timed out when omitted.
+	// +optional
+	InstallTimeout *metav1.Duration `json:"installTimeout,omitempty"`
 }
 
 // ClusterProvisionStatus defines the observed state of ClusterProvision.
@@ -54,6 +59,86 @@ type ClusterProvisionStatus struct {
 	// Conditions includes more detailed status for the cluster provision
 	// +optional
 	Conditions []ClusterProvisionCondition `json:"conditions,omitempty"`
+
+	// Artifacts are the install artifacts published for the provision, such as the boot artifacts
+	// generated for an agent image install.
+	// +optional
+	Artifacts []ClusterProvisionArtifact `json:"artifacts,omitempty"`
+
+	// StageTimestamps records when the provision entered each of the stages it has reached.
+	// +optional
+	StageTimestamps []ClusterProvisionStageTimestamp `json:"stageTimestamps,omitempty"`
+
+	// InstallPodStartedTime is when the install pod was started on a node. The time between the entry into the
+	// initializing stage and this time is spent waiting for the install pod to be scheduled, rather than installing.
+	// +optional
+	InstallPodStartedTime *metav1.Time `json:"installPodStartedTime,omitempty"`
+
+	// LogSnapshotRef is the reference to the ConfigMap holding a snapshot of the end of the logs of the install pod.
+	// +optional
+	LogSnapshotRef *corev1.LocalObjectReference `json:"logSnapshotRef,omitempty"`
+
+	// CloudAPICallsRef is the reference to the ConfigMap holding the cloud API calls made by the installer, recorded
+	// when the cloud API audit is enabled for the ClusterDeployment. The calls.json key of the ConfigMap holds the
+	// list of the CloudAPICalls.
+	// +optional
+	CloudAPICallsRef *corev1.LocalObjectReference `json:"cloudAPICallsRef,omitempty"`
+
+	// MustGather is the must-gather collected from the cluster of a failed provision which had completed
+	// bootstrapping, when the collection is enabled in HiveConfig.
+	// +optional
+	MustGather *ClusterProvisionMustGather `json:"mustGather,omitempty"`
+}
+
+// CloudAPICall is a cloud API call made by the installer, with the number of times it was made.
+type CloudAPICall struct {
+	// Service is the cloud service called, such as ec2 for AWS, or the host name of the API for other clouds.
+	Service string `json:"service"`
+	// Action is the action of the call, such as RunInstances for AWS, or the HTTP method for other clouds.
+	Action string `json:"action"`
+	// Region is the region of the call, when known.
+	// +optional
+	Region string `json:"region,omitempty"`
+	// Resource identifies the resources acted on: the IDs, names and ARNs in the parameters of AWS calls, or the
+	// path of the URL for other clouds.
+	// +optional
+	Resource string `json:"resource,omitempty"`
+	// Count is the number of times the call was made.
+	Count int `json:"count"`
+	// Failures is the number of times the call failed.
+	// +optional
+	Failures int `json:"failures,omitempty"`
+}
+
+// ClusterProvisionMustGather is the must-gather collected from the cluster of a failed provision.
+type ClusterProvisionMustGather struct {
+	// JobRef is the reference to the job collecting the must-gather.
+	JobRef corev1.LocalObjectReference `json:"jobRef"`
+	// Location is where the must-gather archive was uploaded to. It is set once the upload has succeeded.
+	// +optional
+	Location string `json:"location,omitempty"`
+	// CompletionTime is when the job collecting the must-gather finished, whether or not it succeeded.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// ClusterProvisionStageTimestamp records when a provision entered a stage.
+type ClusterProvisionStageTimestamp struct {
+	// Stage is the stage entered.
+	Stage ClusterProvisionStage `json:"stage"`
+	// EnteredTime is when the provision entered the stage.
+	EnteredTime metav1.Time `json:"enteredTime"`
+}
+
+// ClusterProvisionArtifact is an install artifact published for a provision.
+type ClusterProvisionArtifact struct {
+	// Name is the file name of the artifact.
+	Name string `json:"name"`
+	// URL is where the artifact can be downloaded from.
+	URL string `json:"url"`
+	// ExpirationTime is when the URL stops being valid.
+	// +optional
+	ExpirationTime *metav1.Time `json:"expirationTime,omitempty"`
 }
 
 // ClusterProvisionStage is the stage of provisioning.
@@ -88,6 +173,9 @@ type ClusterProvisionCondition struct {
 	// Message is a human-readable message indicating details about last transition.
 	// +optional
 	Message string `json:"message,omitempty"`
+	// Category is the class of the failure reported by the condition, from its reason.
+	// +optional
+	Category FailureCategory `json:"category,omitempty"`
 }
 
 // ClusterProvisionConditionType is a valid value for ClusterProvisionCondition.Type
@@ -108,6 +196,27 @@ const (
 
 	// InstallPodStuckCondition is set when the install pod is stuck
 	InstallPodStuckCondition ClusterProvisionConditionType = "InstallPodStuck"
+
+	// ClusterProvisionSLABreachedCondition is set when a cluster provision has been running for longer than the
+	// provision SLA configured in HiveConfig.
+	ClusterProvisionSLABreachedCondition ClusterProvisionConditionType = "ProvisionSLABreached"
+
+	// ClusterProvisionIgnitionPublishedCondition is set when the ignition configs for a cluster on externally
+	// created infrastructure have been published and the external infrastructure hooks can create machines.
+	ClusterProvisionIgnitionPublishedCondition ClusterProvisionConditionType = "IgnitionPublished"
+
+	// ClusterProvisionInfrastructureReadyCondition is set when the external infrastructure hooks have reported
+	// that the bootstrap and control plane machines have been created.
+	ClusterProvisionInfrastructureReadyCondition ClusterProvisionConditionType = "InfrastructureReady"
+
+	// ClusterProvisionBootstrapCompleteCondition is set when bootstrapping of a cluster on externally created
+	// infrastructure has completed and the external infrastructure hooks can remove the bootstrap machine. It is
+	// also set when a failed install had completed bootstrapping, so that a must-gather can be collected.
+	ClusterProvisionBootstrapCompleteCondition ClusterProvisionConditionType = "BootstrapComplete"
+
+	// ClusterProvisionArtifactsPublishedCondition is set when the boot artifacts for an agent image install have
+	// been uploaded and their URLs recorded in the status.
+	ClusterProvisionArtifactsPublishedCondition ClusterProvisionConditionType = "ArtifactsPublished"
 )
 
 // +genclient
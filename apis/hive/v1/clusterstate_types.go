@@ -18,6 +18,11 @@ type ClusterStateStatus struct {
 	// ClusterOperators contains the state for every cluster operator in the
 	// target cluster
 	ClusterOperators []ClusterOperatorState `json:"clusterOperators,omitempty"`
+
+	// Nodes contains the operating system of every node in the target cluster. Only collected when CollectNodeInfo
+	// is enabled in the HiveConfig.
+	// +optional
+	Nodes []NodeOSState `json:"nodes,omitempty"`
 }
 
 // ClusterOperatorState summarizes the status of a single cluster operator
@@ -30,6 +35,31 @@ type ClusterOperatorState struct {
 	Conditions []configv1.ClusterOperatorStatusCondition `json:"conditions,omitempty"`
 }
 
+// NodeOSState summarizes the operating system of a single node
+type NodeOSState struct {
+	// Name is the name of the node
+	Name string `json:"name"`
+
+	// OSImage is the operating system image reported by the node, such as
+	// "Red Hat Enterprise Linux CoreOS 46.82.202011260640-0 (Ootpa)"
+	OSImage string `json:"osImage,omitempty"`
+
+	// KernelVersion is the kernel version reported by the node
+	KernelVersion string `json:"kernelVersion,omitempty"`
+
+	// ContainerRuntimeVersion is the container runtime version reported by the node
+	ContainerRuntimeVersion string `json:"containerRuntimeVersion,omitempty"`
+
+	// RHCOSBuild is the RHCOS build which the node runs, such as "46.82.202011260640-0". Not set for nodes which
+	// do not run RHCOS.
+	// +optional
+	RHCOSBuild string `json:"rhcosBuild,omitempty"`
+
+	// RHCOSBuildTime is the time at which the RHCOS build which the node runs was created.
+	// +optional
+	RHCOSBuildTime *metav1.Time `json:"rhcosBuildTime,omitempty"`
+}
+
 // +genclient
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
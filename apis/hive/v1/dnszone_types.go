@@ -37,6 +37,10 @@ type DNSZoneSpec struct {
 	// Azure specifes Azure-specific cloud configuration
 	// +optional
 	Azure *AzureDNSZoneSpec `json:"azure,omitempty"`
+
+	// OCI specifies Oracle Cloud Infrastructure-specific cloud configuration
+	// +optional
+	OCI *OCIDNSZoneSpec `json:"oci,omitempty"`
 }
 
 // AWSDNSZoneSpec contains AWS-specific DNSZone specifications
@@ -86,6 +90,25 @@ type AzureDNSZoneSpec struct {
 	ResourceGroupName string `json:"resourceGroupName"`
 }
 
+// OCIDNSZoneSpec contains OCI-specific DNSZone specifications
+type OCIDNSZoneSpec struct {
+	// CredentialsSecretRef references a secret that will be used to authenticate with
+	// OCI DNS. It will need permission to create and manage DNS zones in the compartment.
+	// Secret should have keys named 'tenancy', 'user', 'fingerprint' and 'privateKey'.
+	CredentialsSecretRef corev1.LocalObjectReference `json:"credentialsSecretRef"`
+
+	// Region is the OCI region to use for DNS API calls.
+	Region string `json:"region"`
+
+	// CompartmentID is the OCID of the compartment in which the zone should be created.
+	CompartmentID string `json:"compartmentID"`
+
+	// FreeformTags is a set of additional freeform tags to set on the DNS zone. In addition to these tags,
+	// the DNS Zone controller will set a hive.openshift.io_managed tag identifying the zones it created.
+	// +optional
+	FreeformTags map[string]string `json:"freeformTags,omitempty"`
+}
+
 // DNSZoneStatus defines the observed state of DNSZone
 type DNSZoneStatus struct {
 	// LastSyncTimestamp is the time that the zone was last sync'd.
@@ -112,6 +135,10 @@ type DNSZoneStatus struct {
 	// AzureDNSZoneStatus contains status information specific to Azure
 	Azure *AzureDNSZoneStatus `json:"azure,omitempty"`
 
+	// OCIDNSZoneStatus contains status information specific to OCI
+	// +optional
+	OCI *OCIDNSZoneStatus `json:"oci,omitempty"`
+
 	// Conditions includes more detailed status for the DNSZone
 	// +optional
 	Conditions []DNSZoneCondition `json:"conditions,omitempty"`
@@ -135,6 +162,13 @@ type GCPDNSZoneStatus struct {
 	ZoneName *string `json:"zoneName,omitempty"`
 }
 
+// OCIDNSZoneStatus contains status information specific to OCI DNS zones
+type OCIDNSZoneStatus struct {
+	// ZoneID is the OCID of the zone in OCI DNS
+	// +optional
+	ZoneID *string `json:"zoneID,omitempty"`
+}
+
 // DNSZoneCondition contains details for the current condition of a DNSZone
 type DNSZoneCondition struct {
 	// Type is the type of the condition.
@@ -153,6 +187,9 @@ type DNSZoneCondition struct {
 	// Message is a human-readable message indicating details about last transition.
 	// +optional
 	Message string `json:"message,omitempty"`
+	// Category is the class of the failure reported by the condition, from its reason.
+	// +optional
+	Category FailureCategory `json:"category,omitempty"`
 }
 
 // DNSZoneConditionType is a valid value for DNSZoneCondition.Type
@@ -172,6 +209,9 @@ const (
 	// AuthenticationFailureCondition is true when credentials cannot be used to create a
 	// DNS zone because they fail authentication
 	AuthenticationFailureCondition DNSZoneConditionType = "AuthenticationFailure"
+	// DNSNotDelegatedCondition is true when the parent domain does not delegate to the name servers of the DNS zone,
+	// as seen by the resolvers used for the zone check. The message describes the name servers observed.
+	DNSNotDelegatedCondition DNSZoneConditionType = "DNSNotDelegated"
 )
 
 // +genclient
@@ -0,0 +1,25 @@
+package v1
+
+// FailureCategory is the class of a failure reported by a condition, shared by all Hive controllers so that alerts can
+// be routed by the class of the failure rather than by its many individual reasons.
+// +kubebuilder:validation:Enum=Authn;Quota;Network;UserConfig;CloudOutage;HiveInternal
+type FailureCategory string
+
+const (
+	// FailureCategoryAuthn is a failure to authenticate or be authorized with the cloud provider or another service,
+	// such as invalid, malformed or insufficient credentials.
+	FailureCategoryAuthn FailureCategory = "Authn"
+	// FailureCategoryQuota is a failure caused by a limit or quota of the cloud account, including API rate limits.
+	FailureCategoryQuota FailureCategory = "Quota"
+	// FailureCategoryNetwork is a failure to reach the cluster or a service, or to resolve its DNS.
+	FailureCategoryNetwork FailureCategory = "Network"
+	// FailureCategoryUserConfig is a failure caused by the configuration of the resource, which needs to be fixed by
+	// its owner.
+	FailureCategoryUserConfig FailureCategory = "UserConfig"
+	// FailureCategoryCloudOutage is a failure of the cloud provider or of the installed cluster which is expected to
+	// resolve without a change of configuration.
+	FailureCategoryCloudOutage FailureCategory = "CloudOutage"
+	// FailureCategoryHiveInternal is a failure of Hive itself, or an unknown failure which needs to be investigated by
+	// the operators of Hive.
+	FailureCategoryHiveInternal FailureCategory = "HiveInternal"
+)
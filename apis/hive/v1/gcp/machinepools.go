@@ -5,6 +5,13 @@ type MachinePool struct {
 	// Zones is list of availability zones that can be used.
 	Zones []string `json:"zones,omitempty"`
 
+	// ZoneSubnets explicitly maps zones to the names of the subnetworks, in the network of the cluster, to which to
+	// attach the machines in those zones. Zones which are not mapped use the compute subnetwork of the cluster. When
+	// Zones is not set, the keys are used as the list of zones.
+	// eg. {us-east1-b: workers-b}
+	// +optional
+	ZoneSubnets map[string]string `json:"zoneSubnets,omitempty"`
+
 	// InstanceType defines the GCP instance type.
 	// eg. n1-standard-4
 	InstanceType string `json:"type"`
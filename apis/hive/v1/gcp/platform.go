@@ -13,4 +13,25 @@ type Platform struct {
 
 	// Region specifies the GCP region where the cluster will be created.
 	Region string `json:"region"`
+
+	// CredentialsMode is the mode with which the cloud credential operator of the cluster satisfies the
+	// CredentialsRequests of its components. It is rendered into the InstallConfig. When not set, the mode of the
+	// InstallConfig is used.
+	// +optional
+	CredentialsMode CredentialsMode `json:"credentialsMode,omitempty"`
 }
+
+// CredentialsMode is the mode with which the cloud credential operator of a cluster satisfies the CredentialsRequests
+// of its components.
+// +kubebuilder:validation:Enum=Mint;Passthrough;Manual
+type CredentialsMode string
+
+const (
+	// MintCredentialsMode creates credentials for each CredentialsRequest from the credentials of the cluster.
+	MintCredentialsMode CredentialsMode = "Mint"
+	// PassthroughCredentialsMode copies the credentials of the cluster for each CredentialsRequest.
+	PassthroughCredentialsMode CredentialsMode = "Passthrough"
+	// ManualCredentialsMode does not process CredentialsRequests, whose credentials are instead provided at install
+	// time.
+	ManualCredentialsMode CredentialsMode = "Manual"
+)
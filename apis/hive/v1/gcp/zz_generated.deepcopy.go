@@ -49,6 +49,13 @@ func (in *MachinePool) DeepCopyInto(out *MachinePool) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.ZoneSubnets != nil {
+		in, out := &in.ZoneSubnets, &out.ZoneSubnets
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	in.OSDisk.DeepCopyInto(&out.OSDisk)
 	return
 }
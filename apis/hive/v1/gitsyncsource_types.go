@@ -0,0 +1,153 @@
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// GitSyncSourceLabel is the label on SelectorSyncSets rendered from a GitSyncSource, set to the name of the
+	// GitSyncSource.
+	GitSyncSourceLabel = "hive.openshift.io/git-sync-source"
+
+	// GitSyncSourceURLAnnotation is the annotation on SelectorSyncSets rendered from a GitSyncSource with the URL of
+	// the repository they were rendered from.
+	GitSyncSourceURLAnnotation = "hive.openshift.io/git-sync-source-url"
+
+	// GitSyncSourceCommitAnnotation is the annotation on SelectorSyncSets rendered from a GitSyncSource with the
+	// commit they were rendered from.
+	GitSyncSourceCommitAnnotation = "hive.openshift.io/git-sync-source-commit"
+
+	// GitSyncSourcePathAnnotation is the annotation on SelectorSyncSets rendered from a GitSyncSource with the path
+	// of the file of the repository they were rendered from.
+	GitSyncSourcePathAnnotation = "hive.openshift.io/git-sync-source-path"
+
+	// GitSyncSourceHashAnnotation is the annotation on SelectorSyncSets rendered from a GitSyncSource with a hash of
+	// their rendered labels, annotations and spec, which detects changes made to them outside of the repository.
+	GitSyncSourceHashAnnotation = "hive.openshift.io/git-sync-source-hash"
+)
+
+// GitSyncSourceSpec defines the desired state of GitSyncSource
+type GitSyncSourceSpec struct {
+	// URL is the HTTPS URL of the Git repository.
+	// +kubebuilder:validation:Pattern=`^https://`
+	URL string `json:"url"`
+
+	// Ref is the branch, tag or commit of the repository from which the SelectorSyncSets are rendered.
+	Ref GitReference `json:"ref"`
+
+	// Path is the directory of the repository holding the manifests of the SelectorSyncSets, in files with a .yaml,
+	// .yml or .json extension. Subdirectories are not read. Defaults to the root of the repository.
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// CredentialsSecretRef is a secret in the namespace of Hive with the "username" and "password" keys used to
+	// authenticate with the repository. The password may be an access token.
+	// +optional
+	CredentialsSecretRef *corev1.LocalObjectReference `json:"credentialsSecretRef,omitempty"`
+
+	// PollInterval is how often a branch or tag is resolved to check for new commits. Defaults to 5m.
+	// +optional
+	PollInterval *metav1.Duration `json:"pollInterval,omitempty"`
+}
+
+// GitReference is a reference to a branch, tag or commit of a Git repository. Exactly one of Branch, Tag or Commit
+// must be set.
+type GitReference struct {
+	// Branch is the name of a branch. The SelectorSyncSets follow the commits of the branch.
+	// +optional
+	Branch string `json:"branch,omitempty"`
+
+	// Tag is the name of a tag.
+	// +optional
+	Tag string `json:"tag,omitempty"`
+
+	// Commit is the full SHA-1 hash of a commit, which pins the content of the SelectorSyncSets.
+	// +kubebuilder:validation:Pattern=`^[a-f0-9]{40}$`
+	// +optional
+	Commit string `json:"commit,omitempty"`
+}
+
+// GitSyncSourceStatus defines the observed state of GitSyncSource
+type GitSyncSourceStatus struct {
+	// ObservedGeneration is the generation of the GitSyncSource last synced.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Commit is the commit from which the SelectorSyncSets were last rendered.
+	// +optional
+	Commit string `json:"commit,omitempty"`
+
+	// LastSyncTime is the last time the SelectorSyncSets were rendered.
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// SelectorSyncSets are the names of the SelectorSyncSets rendered from the commit.
+	// +optional
+	SelectorSyncSets []string `json:"selectorSyncSets,omitempty"`
+
+	// Conditions includes more detailed status for the GitSyncSource.
+	// +optional
+	Conditions []GitSyncSourceCondition `json:"conditions,omitempty"`
+}
+
+// GitSyncSourceCondition contains details for the current condition of a GitSyncSource
+type GitSyncSourceCondition struct {
+	// Type is the type of the condition.
+	Type GitSyncSourceConditionType `json:"type"`
+	// Status is the status of the condition.
+	Status corev1.ConditionStatus `json:"status"`
+	// LastProbeTime is the last time we probed the condition.
+	// +optional
+	LastProbeTime metav1.Time `json:"lastProbeTime,omitempty"`
+	// LastTransitionTime is the last time the condition transitioned from one status to another.
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+	// Reason is a unique, one-word, CamelCase reason for the condition's last transition.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// Message is a human-readable message indicating details about last transition.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// GitSyncSourceConditionType is a valid value for GitSyncSourceCondition.Type
+type GitSyncSourceConditionType string
+
+const (
+	// GitSyncSourceSyncFailedCondition is true when the SelectorSyncSets could not be rendered from the latest
+	// commit. The SelectorSyncSets rendered from the previous commit are left unchanged.
+	GitSyncSourceSyncFailedCondition GitSyncSourceConditionType = "SyncFailed"
+)
+
+// +genclient:nonNamespaced
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// GitSyncSource is a Git repository from which Hive renders SelectorSyncSets.
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="URL",type="string",JSONPath=".spec.url"
+// +kubebuilder:printcolumn:name="Commit",type="string",JSONPath=".status.commit"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:path=gitsyncsources,shortName=gss,scope=Cluster
+type GitSyncSource struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   GitSyncSourceSpec   `json:"spec,omitempty"`
+	Status GitSyncSourceStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// GitSyncSourceList contains a list of GitSyncSource
+type GitSyncSourceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GitSyncSource `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&GitSyncSource{}, &GitSyncSourceList{})
+}
@@ -30,6 +30,11 @@ type HiveConfigSpec struct {
 	// +optional
 	ManagedDomains []ManageDNSConfig `json:"managedDomains,omitempty"`
 
+	// DNSPropagation configures how the DNS zones of managed domains are checked for delegation before clusters
+	// using them are installed.
+	// +optional
+	DNSPropagation *DNSPropagationConfig `json:"dnsPropagation,omitempty"`
+
 	// AdditionalCertificateAuthoritiesSecretRef is a list of references to secrets in the
 	// TargetNamespace that contain an additional Certificate Authority to use when communicating
 	// with target clusters. These certificate authorities will be used in addition to any self-signed
@@ -37,13 +42,32 @@ type HiveConfigSpec struct {
 	// +optional
 	AdditionalCertificateAuthoritiesSecretRef []corev1.LocalObjectReference `json:"additionalCertificateAuthoritiesSecretRef,omitempty"`
 
+	// AdditionalCertificateAuthoritiesSecretRefs is a list of references to secrets in the TargetNamespace that
+	// contain, in their ca.crt key, certificate authorities trusted by the clusters Hive installs, such as the CAs of
+	// corporate proxies. Unlike AdditionalCertificateAuthoritiesSecretRef, these CAs are not used by Hive itself:
+	// they are merged into the additionalTrustBundle of the install-config of every cluster, and kept in sync in the
+	// user-ca-bundle ConfigMap of the installed clusters by a generated SyncSet.
+	// +optional
+	AdditionalCertificateAuthoritiesSecretRefs []corev1.LocalObjectReference `json:"additionalCertificateAuthoritiesSecretRefs,omitempty"`
+
 	// GlobalPullSecretRef is used to specify a pull secret that will be used globally by all of the cluster deployments.
-	// For each cluster deployment, the contents of GlobalPullSecret will be merged with the specific pull secret for
-	// a cluster deployment(if specified), with precedence given to the contents of the pull secret for the cluster deployment.
+	// For each cluster deployment, the contents of GlobalPullSecret will be merged with the default pull secret of the
+	// namespace of the cluster deployment (the hive-default-pull-secret secret, if it exists) and the specific pull
+	// secret for a cluster deployment (if specified), with precedence given to the most specific pull secret unless
+	// PullSecretConflictPolicy says otherwise.
 	// The global pull secret is assumed to be in the TargetNamespace.
 	// +optional
 	GlobalPullSecretRef *corev1.LocalObjectReference `json:"globalPullSecretRef,omitempty"`
 
+	// PullSecretConflictPolicy is how Hive resolves a registry with different auths in the global pull secret, the
+	// default pull secret of the namespace and the pull secret of a cluster deployment when merging them.
+	// PreferClusterDeployment, the default, uses the auth of the most specific pull secret. PreferGlobal uses the auth
+	// of the least specific pull secret, so that the global pull secret cannot be overridden. Reject fails the merge,
+	// and the cluster deployment is not provisioned until the conflict is resolved.
+	// +kubebuilder:validation:Enum=PreferClusterDeployment;PreferGlobal;Reject
+	// +optional
+	PullSecretConflictPolicy PullSecretConflictPolicy `json:"pullSecretConflictPolicy,omitempty"`
+
 	// Backup specifies configuration for backup integration.
 	// If absent, backup integration will be disabled.
 	// +optional
@@ -53,6 +77,94 @@ type HiveConfigSpec struct {
 	// +optional
 	FailedProvisionConfig FailedProvisionConfig `json:"failedProvisionConfig,omitempty"`
 
+	// ProvisionSLA configures how long a cluster provision may take before Hive reports it as breaching the SLA,
+	// and what Hive does about it.
+	// +optional
+	ProvisionSLA *ProvisionSLAConfig `json:"provisionSLA,omitempty"`
+
+	// InstallTimeouts configures how long the install jobs of provisions may run, per platform, before Hive aborts the
+	// provisions and fails them. The timeout set on a ClusterDeployment takes precedence. Install jobs are not timed
+	// out when omitted.
+	// +optional
+	InstallTimeouts *InstallTimeoutsConfig `json:"installTimeouts,omitempty"`
+
+	// StageTimeouts configures how long provisions may stay in each stage before Hive aborts them and fails them with
+	// the StageTimeout_<Stage> reason, rather than waiting for the timeouts of the installer. Provisions are not timed
+	// out per stage when omitted.
+	// +optional
+	StageTimeouts *ProvisionStageTimeoutsConfig `json:"stageTimeouts,omitempty"`
+
+	// InstallPodStuckRemediation configures how Hive remediates install pods which are missing or stuck in the
+	// pending phase.
+	// +optional
+	InstallPodStuckRemediation *InstallPodStuckRemediationConfig `json:"installPodStuckRemediation,omitempty"`
+
+	// PodLogSnapshots configures snapshots of the end of the logs of install and deprovision pods. The snapshots are
+	// stored in ConfigMaps referenced from the status of the ClusterProvision or ClusterDeprovision, so that the logs
+	// remain available after the pods are garbage collected. Snapshots are not taken when omitted.
+	// +optional
+	PodLogSnapshots *PodLogSnapshotsConfig `json:"podLogSnapshots,omitempty"`
+
+	// InstallLogStreaming configures the streaming of the installer logs of provisions to an external sink while the
+	// installs run, so that their progress can be followed without exec'ing into the install pods. The logs are not
+	// streamed when omitted.
+	// +optional
+	InstallLogStreaming *InstallLogStreamingConfig `json:"installLogStreaming,omitempty"`
+
+	// InstallArtifacts configures the upload of the installer working directories of provisions to object storage
+	// when their installs finish, so that the artifacts remain available for postmortems after the namespaces of the
+	// provisions are deleted. Artifacts are not uploaded when omitted.
+	// +optional
+	InstallArtifacts *InstallArtifactsConfig `json:"installArtifacts,omitempty"`
+
+	// InstallJobSpread configures how Hive spreads the pods of concurrent install jobs across the nodes of the hub, so
+	// that many installs starting at once do not exhaust the network or storage throughput of a single node.
+	// +optional
+	InstallJobSpread *InstallJobSpreadConfig `json:"installJobSpread,omitempty"`
+
+	// ClusterPoolMaxConcurrent is the maximum number of clusters of each ClusterPool that are provisioned or
+	// deprovisioned at a time, for the pools that do not set MaxConcurrent. The clusters beyond the limit are queued
+	// until the installs and deletions in progress finish, so that bursts of installs do not trip the rate limits of
+	// the cloud APIs. By default there is no limit.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	ClusterPoolMaxConcurrent *int32 `json:"clusterPoolMaxConcurrent,omitempty"`
+
+	// ClusterClaimQuota limits the number of ClusterClaims which may exist at once in each namespace and for each
+	// requester, across all ClusterPools, so that shared pools cannot be monopolized by one team. Claims are not
+	// limited when omitted.
+	// +optional
+	ClusterClaimQuota *ClusterClaimQuotaConfig `json:"clusterClaimQuota,omitempty"`
+
+	// InstallEgressPolicy configures policies restricting the network egress of install and deprovision pods to the
+	// endpoints they need, for hubs with strict egress requirements. The egress is not restricted when omitted.
+	// +optional
+	InstallEgressPolicy *InstallEgressPolicyConfig `json:"installEgressPolicy,omitempty"`
+
+	// CMDBExport configures the export of cluster lifecycle records to external configuration management databases
+	// (CMDBs). No records are exported when omitted.
+	// +optional
+	CMDBExport *CMDBExportConfig `json:"cmdbExport,omitempty"`
+
+	// SnapshotExport configures the periodic export of snapshots of the state of ClusterDeployments, ClusterSyncs and
+	// ClusterStates to object storage, for analytics beyond the retention of the Hive metrics. No snapshots are
+	// exported when omitted.
+	// +optional
+	SnapshotExport *SnapshotExportConfig `json:"snapshotExport,omitempty"`
+
+	// AWSRateLimit configures the rate limiting of the AWS API calls made by the Hive controllers. The calls made
+	// with the same credentials share a token bucket across all the controllers, whose rate adapts down when AWS
+	// throttles the calls, and throttled calls are retried with exponential backoff. The defaults described in
+	// AWSRateLimitConfig apply when omitted.
+	// +optional
+	AWSRateLimit *AWSRateLimitConfig `json:"awsRateLimit,omitempty"`
+
+	// ProviderPlugins are external executables which implement hibernation and machine management for platforms that
+	// Hive does not support natively. A ClusterDeployment is handled by the plugin named in its
+	// hive.openshift.io/provider-plugin annotation.
+	// +optional
+	ProviderPlugins []ProviderPlugin `json:"providerPlugins,omitempty"`
+
 	// LogLevel is the level of logging to use for the Hive controllers.
 	// Acceptable levels, from coarsest to finest, are panic, fatal, error, warn, info, debug, and trace.
 	// The default level is info.
@@ -64,6 +176,30 @@ type HiveConfigSpec struct {
 	// The default reapply interval is two hours.
 	SyncSetReapplyInterval string `json:"syncSetReapplyInterval,omitempty"`
 
+	// PeriodicSync configures the base intervals of the periodic syncs of each cluster. Each cluster is synced on its
+	// own schedule, which extends the base interval by a jitter derived from the cluster, so that the periodic syncs
+	// of all the clusters are spread out rather than aligned.
+	// +optional
+	PeriodicSync *PeriodicSyncConfig `json:"periodicSync,omitempty"`
+
+	// CollectNodeInfo can be set to true to have Hive collect the operating system of the nodes of each cluster, such
+	// as the RHCOS build which they run, into the ClusterState of the cluster along with the states of its cluster
+	// operators. This allows finding the clusters which run old images and need upgrading to pick up security fixes.
+	// +optional
+	CollectNodeInfo bool `json:"collectNodeInfo,omitempty"`
+
+	// SyncSetAudit can be set to have Hive stamp the resources which it applies to clusters from SyncSets and
+	// SelectorSyncSets with annotations recording where they came from: the syncset and its generation, the hub
+	// cluster, and the time of the last apply.
+	// +optional
+	SyncSetAudit *SyncSetAuditConfig `json:"syncSetAudit,omitempty"`
+
+	// CSRApprovalPolicy restricts the certificate signing requests of nodes which the hibernation controller approves
+	// while clusters resume from hibernation. When not set, the requests which match the machines of a cluster are all
+	// approved.
+	// +optional
+	CSRApprovalPolicy *CSRApprovalPolicyConfig `json:"csrApprovalPolicy,omitempty"`
+
 	// MaintenanceMode can be set to true to disable the hive controllers in situations where we need to ensure
 	// nothing is running that will add or act upon finalizers on Hive types. This should rarely be needed.
 	// Sets replicas to 0 for the hive-controllers deployment to accomplish this.
@@ -80,6 +216,38 @@ type HiveConfigSpec struct {
 	// +optional
 	DeleteProtection DeleteProtectionType `json:"deleteProtection,omitempty"`
 
+	// NamespaceCleanup can be set to "enabled" to have Hive delete the namespaces it created to house a single
+	// ClusterDeployment, such as the namespaces created when relocating a ClusterDeployment, once they are empty.
+	// Namespaces created for ClusterPool clusters are always deleted once their ClusterDeployment is gone.
+	// +kubebuilder:validation:Enum=enabled
+	// +optional
+	NamespaceCleanup NamespaceCleanupType `json:"namespaceCleanup,omitempty"`
+
+	// ScopedRemoteAccess can be set to "enabled" to have Hive access installed clusters with short-lived tokens of a
+	// least-privilege service account which Hive creates in each cluster, rather than with the admin kubeconfig.
+	// Hive falls back to the admin kubeconfig while the token is not available, and keeps using it to apply SyncSets
+	// and to mint the tokens.
+	// +kubebuilder:validation:Enum=enabled
+	// +optional
+	ScopedRemoteAccess ScopedRemoteAccessType `json:"scopedRemoteAccess,omitempty"`
+
+	// RemoteAccessRBAC configures the permissions which Hive holds in installed clusters when ScopedRemoteAccess is
+	// enabled.
+	// +optional
+	RemoteAccessRBAC *RemoteAccessRBACConfig `json:"remoteAccessRBAC,omitempty"`
+
+	// AdminKubeconfigContext is the context of the admin kubeconfig with which Hive connects to installed clusters.
+	// Hive adds an Internal context to the admin kubeconfig of each cluster for its internal API URL, on the api-int
+	// host name, which resolves within the network of the cluster, for example through a private endpoint or a
+	// peered network. Hive connects with the External context, the current context of the admin kubeconfig, when the
+	// admin kubeconfig has no Internal context. Defaults to External.
+	// +optional
+	AdminKubeconfigContext AdminKubeconfigContextType `json:"adminKubeconfigContext,omitempty"`
+
+	// Metrics configures how the hive-controllers and hive-clustersync pods serve their metrics.
+	// +optional
+	Metrics *MetricsConfig `json:"metrics,omitempty"`
+
 	// DisabledControllers allows selectively disabling Hive controllers by name.
 	// The name of an individual controller matches the name of the controller as seen in the Hive logging output.
 	DisabledControllers []string `json:"disabledControllers,omitempty"`
@@ -101,6 +269,117 @@ type HiveConfigSpec struct {
 	FeatureGates *FeatureGateSelection `json:"featureGates,omitempty"`
 }
 
+// ClusterClaimQuotaConfig contains the limits on the number of ClusterClaims which may exist at once. ClusterClaims
+// beyond a limit are rejected by hiveadmission when they are created, and are not assigned a cluster until they are
+// within their limits, in the order they were created.
+type ClusterClaimQuotaConfig struct {
+	// MaxClaimsPerNamespace is the maximum number of ClusterClaims which may exist at once in each namespace.
+	// Unlimited when not set.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxClaimsPerNamespace *int32 `json:"maxClaimsPerNamespace,omitempty"`
+
+	// MaxClaimsPerRequester is the maximum number of ClusterClaims which each user or service account may have
+	// created and which exist at once, across all namespaces. The requester of a ClusterClaim is recorded by
+	// hiveadmission in its hive.openshift.io/claim-requester annotation. Unlimited when not set.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxClaimsPerRequester *int32 `json:"maxClaimsPerRequester,omitempty"`
+}
+
+// SyncSetAuditConfig configures the annotations and label with which Hive stamps the resources which it applies to
+// clusters from SyncSets and SelectorSyncSets.
+type SyncSetAuditConfig struct {
+	// HubName identifies this hub cluster on the resources which it applies, so that the resources applied to a
+	// cluster by several hubs can be told apart. Must be a valid label value.
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^(([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9])?$`
+	// +optional
+	HubName string `json:"hubName,omitempty"`
+
+	// OwnershipLabel can be set to true to also label the resources with the HubName, so that tooling in the
+	// cluster can select the resources applied by this hub. Has no effect when HubName is not set.
+	// +optional
+	OwnershipLabel bool `json:"ownershipLabel,omitempty"`
+}
+
+// CSRApprovalPolicyConfig restricts the certificate signing requests of nodes which the hibernation controller
+// approves while clusters resume from hibernation. Requests must still match the machines of the cluster to be
+// approved. Each approval and each request denied by the policy is recorded by an event on the ClusterDeployment.
+type CSRApprovalPolicyConfig struct {
+	// AllowedNodeNamePatterns are regular expressions, one of which the whole name of the node of a request must match
+	// for the request to be approved. When empty, the names of the nodes are not restricted unless DenyByDefault is
+	// set.
+	// +optional
+	AllowedNodeNamePatterns []string `json:"allowedNodeNamePatterns,omitempty"`
+
+	// MaxApprovalsPerResume is the maximum number of requests approved each time a cluster resumes. Further requests
+	// are left pending. Unlimited when not set.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxApprovalsPerResume *int32 `json:"maxApprovalsPerResume,omitempty"`
+
+	// DenyByDefault can be set to true to deny the requests which are not explicitly allowed by
+	// AllowedNodeNamePatterns, so that no request is approved when no pattern is set.
+	// +optional
+	DenyByDefault bool `json:"denyByDefault,omitempty"`
+}
+
+// PeriodicSyncConfig contains the base intervals of the periodic syncs of each cluster.
+type PeriodicSyncConfig struct {
+	// ClusterSyncFullApplyInterval is how often all the SyncSets and SelectorSyncSets of a cluster are reapplied,
+	// regardless of whether they have changed. Takes precedence over SyncSetReapplyInterval. Defaults to 2h.
+	// +optional
+	ClusterSyncFullApplyInterval *metav1.Duration `json:"clusterSyncFullApplyInterval,omitempty"`
+
+	// ClusterStatePollInterval is how often the states of the cluster operators of a cluster are polled.
+	// Defaults to 10m.
+	// +optional
+	ClusterStatePollInterval *metav1.Duration `json:"clusterStatePollInterval,omitempty"`
+
+	// MachineSetResyncInterval is how often the MachineSets of a MachinePool are resynced to the cluster, undoing
+	// changes made to them in the cluster. Defaults to 2h.
+	// +optional
+	MachineSetResyncInterval *metav1.Duration `json:"machineSetResyncInterval,omitempty"`
+
+	// JitterPercent is the maximum jitter of the periodic syncs of each cluster, as a percentage of their base
+	// interval. Defaults to 10.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	JitterPercent *int32 `json:"jitterPercent,omitempty"`
+}
+
+// MetricsConfig configures how the metrics of Hive are served.
+type MetricsConfig struct {
+	// KubeRBACProxy puts a kube-rbac-proxy sidecar in front of the metrics of the hive-controllers and
+	// hive-clustersync pods. The metrics are then only served over TLS, on the metrics port of their services, to
+	// clients authorized to get the path of the metrics as a non-resource URL.
+	// +optional
+	KubeRBACProxy *KubeRBACProxyConfig `json:"kubeRBACProxy,omitempty"`
+
+	// NamespaceFiltering can be set to "enabled" to also serve, under /metrics/namespaces/<namespace>, only the
+	// metrics labeled with that namespace, such as the metrics of the clusters in the namespace. Together with
+	// KubeRBACProxy, this allows tenants of a shared Hive to be authorized to scrape the metrics of their own
+	// namespaces only.
+	// +kubebuilder:validation:Enum=enabled
+	// +optional
+	NamespaceFiltering MetricsNamespaceFilteringType `json:"namespaceFiltering,omitempty"`
+}
+
+// KubeRBACProxyConfig configures the kube-rbac-proxy sidecar serving the metrics of Hive.
+type KubeRBACProxyConfig struct {
+	// Image is the kube-rbac-proxy image.
+	// +kubebuilder:validation:MinLength=1
+	Image string `json:"image"`
+
+	// ServingCertSecretRef references a kubernetes.io/tls secret in the TargetNamespace holding the serving
+	// certificate of the proxy. Defaults to a secret of the same name as the service of the pod, suffixed with
+	// -metrics-serving-cert, which the service CA operator generates when running on OpenShift.
+	// +optional
+	ServingCertSecretRef *corev1.LocalObjectReference `json:"servingCertSecretRef,omitempty"`
+}
+
 // AWSPrivateLinkConfig defines the configuration for the aws-private-link controller.
 type AWSPrivateLinkConfig struct {
 	// CredentialsSecretRef references a secret in the TargetNamespace that will be used to authenticate with
@@ -119,6 +398,27 @@ type AWSPrivateLinkConfig struct {
 	//
 	// This list should at minimum include the VPC where the current Hive controller is running.
 	AssociatedVPCs []AWSAssociatedVPC `json:"associatedVPCs,omitempty"`
+
+	// SharedHostedZone is a Private Hosted Zone shared by all the clusters in which the DNS addresses setup for
+	// Private Link are published, instead of creating a Private Hosted Zone for each cluster and associating it
+	// with the AssociatedVPCs. The shared Private Hosted Zone is made resolvable by the VPCs outside of Hive, for
+	// example with Route53 Resolver rules forwarding its domain to a central VPC associated with it.
+	// +optional
+	SharedHostedZone *AWSPrivateLinkSharedHostedZone `json:"sharedHostedZone,omitempty"`
+}
+
+// AWSPrivateLinkSharedHostedZone defines a Private Hosted Zone shared by all the clusters for Private Link.
+type AWSPrivateLinkSharedHostedZone struct {
+	// HostedZoneID is the ID of the shared Private Hosted Zone. The domain of the hosted zone must contain the
+	// API domains of the clusters.
+	HostedZoneID string `json:"hostedZoneID"`
+
+	// CredentialsSecretRef references a secret in the TargetNamespace, the namespace Hive runs in, that will be
+	// used to authenticate with AWS for publishing the DNS addresses in the shared Private Hosted Zone.
+	// When not provided, the CredentialsSecretRef of the AWSPrivateLinkConfig is used.
+	//
+	// +optional
+	CredentialsSecretRef *corev1.LocalObjectReference `json:"credentialsSecretRef,omitempty"`
 }
 
 // AWSPrivateLinkInventory is a VPC and its corresponding subnets in an AWS region.
@@ -212,6 +512,15 @@ type HiveConfigStatus struct {
 	// ConfigApplied will be set by the hive operator to indicate whether or not the LastGenerationObserved
 	// was successfully reconciled.
 	ConfigApplied bool `json:"configApplied,omitempty"`
+
+	// DisabledFeatures lists the Hive features which cannot work with the permissions granted to Hive in installed
+	// clusters by the remote access RBAC mode.
+	// +optional
+	DisabledFeatures []string `json:"disabledFeatures,omitempty"`
+
+	// CMDBConnectors reports the export of cluster lifecycle records by each of the configured CMDB connectors.
+	// +optional
+	CMDBConnectors []CMDBConnectorStatus `json:"cmdbConnectors,omitempty"`
 }
 
 // BackupConfig contains settings for the Velero backup integration.
@@ -249,6 +558,301 @@ type FailedProvisionConfig struct {
 	// DEPRECATED: This flag is no longer respected and will be removed in the future.
 	SkipGatherLogs bool                      `json:"skipGatherLogs,omitempty"`
 	AWS            *FailedProvisionAWSConfig `json:"aws,omitempty"`
+
+	// CollectMustGather enables running a job which collects a must-gather from the cluster of a failed provision
+	// which had completed bootstrapping, and uploads it alongside the install logs. The next install attempt waits
+	// for the job to finish. Requires the install log upload to be configured.
+	// +optional
+	CollectMustGather bool `json:"collectMustGather,omitempty"`
+
+	// InstallLogRegexesConfigMapRefs references ConfigMaps in the TargetNamespace which hold additional rule sets
+	// classifying the install logs of failed provisions, in the same format as the regexes data entry of the
+	// install-log-regexes ConfigMap. The rule sets are evaluated in the order listed, before the built-in rules, and
+	// the first matching rule sets the reason of the ProvisionFailed condition. Named capture groups of the matching
+	// search string can be referenced in its installFailingMessage as ${name}.
+	// +optional
+	InstallLogRegexesConfigMapRefs []corev1.LocalObjectReference `json:"installLogRegexesConfigMapRefs,omitempty"`
+}
+
+// ProvisionSLAConfig configures the SLA of cluster provisions.
+type ProvisionSLAConfig struct {
+	// Duration is how long a cluster provision may take, from the creation of the ClusterProvision until its install
+	// job completes, for example "90m". When exceeded, the ProvisionSLABreached condition is set on the
+	// ClusterProvision.
+	Duration metav1.Duration `json:"duration"`
+
+	// Action is what Hive does with a provision breaching the SLA. Defaults to None, which only sets the condition.
+	// +optional
+	Action ProvisionSLAAction `json:"action,omitempty"`
+}
+
+// ProvisionSLAAction is what Hive does with a provision breaching the SLA.
+// +kubebuilder:validation:Enum=None;Abort
+type ProvisionSLAAction string
+
+const (
+	// ProvisionSLAActionNone only sets the ProvisionSLABreached condition on the provision.
+	ProvisionSLAActionNone ProvisionSLAAction = "None"
+	// ProvisionSLAActionAbort also aborts the provision, which is then retried like any other failed provision.
+	ProvisionSLAActionAbort ProvisionSLAAction = "Abort"
+)
+
+// InstallTimeoutsConfig configures the default install timeouts of cluster provisions.
+type InstallTimeoutsConfig struct {
+	// Default is the install timeout of the provisions of clusters on platforms without a timeout in Platforms.
+	// +optional
+	Default *metav1.Duration `json:"default,omitempty"`
+
+	// Platforms are the install timeouts of the provisions of clusters on specific platforms.
+	// +optional
+	Platforms []PlatformInstallTimeout `json:"platforms,omitempty"`
+}
+
+// PlatformInstallTimeout is the install timeout of the provisions of clusters on a platform.
+type PlatformInstallTimeout struct {
+	// Platform is the platform of the clusters, as reported in the hive.openshift.io/cluster-platform label of
+	// ClusterDeployments, for example "aws" or "vsphere".
+	Platform string `json:"platform"`
+
+	// Timeout is how long the install jobs of the provisions may run, for example "90m".
+	Timeout metav1.Duration `json:"timeout"`
+}
+
+// ProvisionStageTimeoutsConfig configures how long cluster provisions may stay in each stage.
+type ProvisionStageTimeoutsConfig struct {
+	// Initializing is how long a provision may stay in the initializing stage, from the creation of its install job
+	// until the installer reports the infrastructure of the cluster, for example "20m".
+	// +optional
+	Initializing *metav1.Duration `json:"initializing,omitempty"`
+
+	// Provisioning is how long a provision may stay in the provisioning stage, from the initialization of the
+	// provision until its install job completes, for example "75m".
+	// +optional
+	Provisioning *metav1.Duration `json:"provisioning,omitempty"`
+}
+
+// InstallPodStuckRemediationConfig configures how Hive remediates install pods which are missing or stuck in the
+// pending phase.
+type InstallPodStuckRemediationConfig struct {
+	// RecreateJob can be set to true to have Hive delete and recreate the install job, once per provision attempt,
+	// when its pod is stuck while the provision is initializing.
+	// +optional
+	RecreateJob bool `json:"recreateJob,omitempty"`
+
+	// FailAfter is how long the install pod may be stuck before Hive fails the provision attempt with the
+	// SchedulingStuck reason, for example "30m". By default, Hive keeps waiting for the install pod.
+	// +optional
+	FailAfter *metav1.Duration `json:"failAfter,omitempty"`
+}
+
+// InstallJobSpreadConfig configures how Hive spreads the pods of concurrent install jobs.
+type InstallJobSpreadConfig struct {
+	// Mode is whether install pods should (Preferred) or must (Required) avoid the topology domains already running
+	// the pod of another install job. With Required, install pods stay pending while every domain runs one.
+	// +kubebuilder:validation:Enum=Preferred;Required
+	Mode InstallJobSpreadMode `json:"mode"`
+
+	// TopologyKey is the node label whose values are the topology domains install pods are spread across. The
+	// default key is "kubernetes.io/hostname", spreading install pods across nodes.
+	// +optional
+	TopologyKey string `json:"topologyKey,omitempty"`
+}
+
+// InstallJobSpreadMode is how strictly the pods of concurrent install jobs are spread.
+type InstallJobSpreadMode string
+
+const (
+	// InstallJobSpreadModePreferred makes the scheduler prefer topology domains not running another install pod.
+	InstallJobSpreadModePreferred InstallJobSpreadMode = "Preferred"
+	// InstallJobSpreadModeRequired prevents the scheduler from placing install pods in topology domains running
+	// another install pod.
+	InstallJobSpreadModeRequired InstallJobSpreadMode = "Required"
+)
+
+// PullSecretConflictPolicy is how Hive resolves a registry with different auths in the pull secrets it merges.
+type PullSecretConflictPolicy string
+
+const (
+	// PullSecretConflictPolicyPreferClusterDeployment uses the auth of the most specific pull secret.
+	PullSecretConflictPolicyPreferClusterDeployment PullSecretConflictPolicy = "PreferClusterDeployment"
+	// PullSecretConflictPolicyPreferGlobal uses the auth of the least specific pull secret.
+	PullSecretConflictPolicyPreferGlobal PullSecretConflictPolicy = "PreferGlobal"
+	// PullSecretConflictPolicyReject fails the merge of pull secrets with different auths for a registry.
+	PullSecretConflictPolicyReject PullSecretConflictPolicy = "Reject"
+)
+
+// InstallEgressPolicyConfig configures policies restricting the network egress of install and deprovision pods.
+type InstallEgressPolicyConfig struct {
+	// Type is the kind of policy Hive creates in the namespace of each ClusterDeployment. A NetworkPolicy only
+	// restricts the egress of install and deprovision pods by port, as it cannot match DNS names. An OVN-Kubernetes
+	// EgressFirewall allows only the endpoints of the platform, but applies to every pod in the namespace.
+	// +kubebuilder:validation:Enum=NetworkPolicy;EgressFirewall
+	Type InstallEgressPolicyType `json:"type"`
+
+	// AdditionalEndpoints are DNS names or CIDRs install and deprovision pods may reach in addition to the cloud APIs
+	// of the platform, the API of the installed cluster and the registry of the release image, such as a mirror
+	// registry or a proxy.
+	// +optional
+	AdditionalEndpoints []string `json:"additionalEndpoints,omitempty"`
+}
+
+// InstallEgressPolicyType is the kind of policy restricting the network egress of install and deprovision pods.
+type InstallEgressPolicyType string
+
+const (
+	// InstallEgressPolicyTypeNetworkPolicy restricts the egress with a NetworkPolicy.
+	InstallEgressPolicyTypeNetworkPolicy InstallEgressPolicyType = "NetworkPolicy"
+	// InstallEgressPolicyTypeEgressFirewall restricts the egress with an OVN-Kubernetes EgressFirewall.
+	InstallEgressPolicyTypeEgressFirewall InstallEgressPolicyType = "EgressFirewall"
+)
+
+// CMDBExportConfig configures the export of cluster lifecycle records to external configuration management databases.
+type CMDBExportConfig struct {
+	// Connectors are the configuration management databases to which the records are exported.
+	Connectors []CMDBConnector `json:"connectors"`
+}
+
+// CMDBConnector configures the export of cluster lifecycle records to a configuration management database.
+type CMDBConnector struct {
+	// Name identifies the connector in the status of the HiveConfig and in the ClusterDeployments it exported.
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	// Type is the kind of configuration management database.
+	// +kubebuilder:validation:Enum=ServiceNow;REST
+	Type CMDBConnectorType `json:"type"`
+
+	// URL is the endpoint to which REST connectors post the records, or the base URL of the ServiceNow instance,
+	// such as https://example.service-now.com.
+	URL string `json:"url"`
+
+	// CredentialsSecretRef references a secret in the TargetNamespace holding either a "token" key, sent as a bearer
+	// token, or "username" and "password" keys, used for basic authentication.
+	// +optional
+	CredentialsSecretRef *corev1.LocalObjectReference `json:"credentialsSecretRef,omitempty"`
+
+	// Table is the table to which ServiceNow connectors export the clusters. The default table is
+	// cmdb_ci_kubernetes_cluster.
+	// +optional
+	Table string `json:"table,omitempty"`
+
+	// Events limits the lifecycle events exported by the connector. Every event is exported when omitted.
+	// +optional
+	Events []CMDBLifecycleEvent `json:"events,omitempty"`
+}
+
+// CMDBConnectorType is the kind of configuration management database a connector exports to.
+type CMDBConnectorType string
+
+const (
+	// CMDBConnectorTypeServiceNow exports the records to a table of a ServiceNow instance through its Table API.
+	CMDBConnectorTypeServiceNow CMDBConnectorType = "ServiceNow"
+	// CMDBConnectorTypeREST posts the records as JSON to a REST endpoint.
+	CMDBConnectorTypeREST CMDBConnectorType = "REST"
+)
+
+// CMDBLifecycleEvent is a cluster lifecycle event exported to configuration management databases.
+// +kubebuilder:validation:Enum=Created;Installed;VersionChanged;Deleted
+type CMDBLifecycleEvent string
+
+const (
+	// CMDBLifecycleEventCreated is exported when a ClusterDeployment is created.
+	CMDBLifecycleEventCreated CMDBLifecycleEvent = "Created"
+	// CMDBLifecycleEventInstalled is exported when a cluster is installed or adopted.
+	CMDBLifecycleEventInstalled CMDBLifecycleEvent = "Installed"
+	// CMDBLifecycleEventVersionChanged is exported when an installed cluster is upgraded.
+	CMDBLifecycleEventVersionChanged CMDBLifecycleEvent = "VersionChanged"
+	// CMDBLifecycleEventDeleted is exported when a ClusterDeployment is deleted.
+	CMDBLifecycleEventDeleted CMDBLifecycleEvent = "Deleted"
+)
+
+// CMDBConnectorStatus reports the export of cluster lifecycle records by a CMDB connector.
+type CMDBConnectorStatus struct {
+	// Name is the name of the connector.
+	Name string `json:"name"`
+
+	// LastExportTime is when the connector last exported a record.
+	// +optional
+	LastExportTime *metav1.Time `json:"lastExportTime,omitempty"`
+
+	// LastFailureTime is when the connector last failed to export a record.
+	// +optional
+	LastFailureTime *metav1.Time `json:"lastFailureTime,omitempty"`
+
+	// LastError is the error of the last failed export.
+	// +optional
+	LastError string `json:"lastError,omitempty"`
+
+	// PendingClusters is the number of clusters whose latest record failed to export and is being retried.
+	PendingClusters int `json:"pendingClusters"`
+}
+
+// SnapshotExportConfig configures the periodic export of snapshots of Hive custom resources to object storage.
+// Exactly one destination must be configured.
+type SnapshotExportConfig struct {
+	// Interval is how often snapshots are exported, for example "1h". The default interval is 1 hour.
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	// Prefix is prepended to the names of the objects the snapshots are written to, for example "hive/prod/".
+	// +optional
+	Prefix string `json:"prefix,omitempty"`
+
+	// S3 exports the snapshots to an S3 bucket.
+	// +optional
+	S3 *ObjectStorageS3Config `json:"s3,omitempty"`
+
+	// GCS exports the snapshots to a Google Cloud Storage bucket.
+	// +optional
+	GCS *ObjectStorageGCSConfig `json:"gcs,omitempty"`
+}
+
+// PodLogSnapshotsConfig configures snapshots of the end of the logs of install and deprovision pods.
+type PodLogSnapshotsConfig struct {
+	// MaxSizeKB is the size, in KB, of the end of the logs of a pod which is kept in a snapshot. It is shared between
+	// the containers of the pod. The default size is 256.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=900
+	// +optional
+	MaxSizeKB int `json:"maxSizeKB,omitempty"`
+}
+
+// AWSRateLimitConfig configures the rate limiting of the AWS API calls made by the Hive controllers.
+type AWSRateLimitConfig struct {
+	// RequestsPerSecond is the maximum rate of the AWS API calls made with the same credentials. The rate is lowered
+	// while AWS throttles the calls, and recovers as they succeed. The default rate is 10.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	RequestsPerSecond int `json:"requestsPerSecond,omitempty"`
+
+	// Burst is the maximum number of AWS API calls which can be made at once with the same credentials. The default
+	// burst is 20.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Burst int `json:"burst,omitempty"`
+
+	// MaxRetries is the maximum number of times a failed AWS API call is retried, with exponential backoff. The
+	// default is 8.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxRetries *int `json:"maxRetries,omitempty"`
+}
+
+// ProviderPlugin is an external executable implementing hibernation and machine management for a platform.
+type ProviderPlugin struct {
+	// Name is the name of the plugin, as referenced from the hive.openshift.io/provider-plugin annotation of
+	// ClusterDeployments.
+	Name string `json:"name"`
+
+	// Command is the path of the executable, which must be available in the hive-controllers container, followed by
+	// any arguments. Hive appends the name of the operation to the arguments, writes the request to the standard input
+	// of the executable as JSON, and reads the response from its standard output as JSON.
+	// +kubebuilder:validation:MinItems=1
+	Command []string `json:"command"`
+
+	// Timeout is how long each call to the plugin may run before it is killed. Defaults to 5m.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
 }
 
 // ManageDNSConfig contains the domain being managed, and the cloud-specific
@@ -299,6 +903,131 @@ type FailedProvisionAWSConfig struct {
 	Bucket string `json:"bucket,omitempty"`
 }
 
+// InstallLogStreamingConfig configures the streaming of installer logs to an external sink. Exactly one sink must be
+// configured.
+type InstallLogStreamingConfig struct {
+	// Interval is how often the new lines of the installer log are sent to the sink, for example "30s". The default
+	// interval is 30 seconds.
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	// S3 streams the installer logs to an S3 bucket.
+	// +optional
+	S3 *ObjectStorageS3Config `json:"s3,omitempty"`
+
+	// GCS streams the installer logs to a Google Cloud Storage bucket.
+	// +optional
+	GCS *ObjectStorageGCSConfig `json:"gcs,omitempty"`
+
+	// HTTP streams the installer logs to an HTTP endpoint.
+	// +optional
+	HTTP *InstallLogStreamingHTTPConfig `json:"http,omitempty"`
+}
+
+// InstallLogStreamingHTTPConfig configures the streaming of installer logs to an HTTP endpoint.
+type InstallLogStreamingHTTPConfig struct {
+	// URL is the endpoint the chunks of installer logs are POSTed to.
+	URL string `json:"url"`
+
+	// CredentialsSecretRef optionally references a secret in the namespace of Hive with a token key, which is sent
+	// as a bearer token to the endpoint.
+	// +optional
+	CredentialsSecretRef *corev1.LocalObjectReference `json:"credentialsSecretRef,omitempty"`
+}
+
+// InstallArtifactsConfig configures the upload of the installer working directories of provisions to object storage.
+// Exactly one destination must be configured.
+type InstallArtifactsConfig struct {
+	// Upload is which provisions have their artifacts uploaded. OnFailure, the default, only uploads the artifacts of
+	// failed provisions. Always uploads the artifacts of all provisions. The terraform state is only uploaded for
+	// failed provisions.
+	// +kubebuilder:validation:Enum=OnFailure;Always
+	// +optional
+	Upload InstallArtifactsUpload `json:"upload,omitempty"`
+
+	// Prefix is prepended to the names of the objects the artifacts are written to, for example "hive/prod/".
+	// +optional
+	Prefix string `json:"prefix,omitempty"`
+
+	// S3 uploads the artifacts to an S3 bucket.
+	// +optional
+	S3 *ObjectStorageS3Config `json:"s3,omitempty"`
+
+	// GCS uploads the artifacts to a Google Cloud Storage bucket.
+	// +optional
+	GCS *ObjectStorageGCSConfig `json:"gcs,omitempty"`
+
+	// AzureBlob uploads the artifacts to an Azure Blob Storage container.
+	// +optional
+	AzureBlob *ObjectStorageAzureBlobConfig `json:"azureBlob,omitempty"`
+}
+
+// InstallArtifactsUpload is which provisions have their installer artifacts uploaded.
+type InstallArtifactsUpload string
+
+const (
+	// InstallArtifactsUploadOnFailure uploads the artifacts of failed provisions.
+	InstallArtifactsUploadOnFailure InstallArtifactsUpload = "OnFailure"
+	// InstallArtifactsUploadAlways uploads the artifacts of all provisions.
+	InstallArtifactsUploadAlways InstallArtifactsUpload = "Always"
+)
+
+// ObjectStorageS3Config configures an S3 bucket Hive writes objects to.
+type ObjectStorageS3Config struct {
+	// Bucket is the S3 bucket to store the objects in.
+	Bucket string `json:"bucket"`
+
+	// Region is the AWS region of the bucket.
+	Region string `json:"region"`
+
+	// CredentialsSecretRef references a secret in the namespace of Hive with the aws_access_key_id and
+	// aws_secret_access_key keys, whose credentials are allowed to put objects in the bucket.
+	CredentialsSecretRef corev1.LocalObjectReference `json:"credentialsSecretRef"`
+}
+
+// ObjectStorageGCSConfig configures a Google Cloud Storage bucket Hive writes objects to.
+type ObjectStorageGCSConfig struct {
+	// Bucket is the GCS bucket to store the objects in.
+	Bucket string `json:"bucket"`
+
+	// CredentialsSecretRef references a secret in the namespace of Hive with the osServiceAccount.json key, whose
+	// service account is allowed to create objects in the bucket.
+	CredentialsSecretRef corev1.LocalObjectReference `json:"credentialsSecretRef"`
+}
+
+// ObjectStorageAzureBlobConfig configures an Azure Blob Storage container Hive writes objects to.
+type ObjectStorageAzureBlobConfig struct {
+	// StorageAccount is the name of the storage account of the container.
+	StorageAccount string `json:"storageAccount"`
+
+	// Container is the blob container to store the objects in.
+	Container string `json:"container"`
+
+	// CredentialsSecretRef references a secret in the namespace of Hive with a sasToken key, holding a shared access
+	// signature allowed to create blobs in the container.
+	CredentialsSecretRef corev1.LocalObjectReference `json:"credentialsSecretRef"`
+}
+
+// DNSPropagationConfig configures the checks that a managed DNS zone is delegated from its parent domain and
+// resolvable.
+type DNSPropagationConfig struct {
+	// Resolvers are the DNS resolvers queried for the SOA and NS records of a zone. Each resolver is either an
+	// address ("8.8.8.8" or "8.8.8.8:53") queried over UDP, a "tls://" URL queried with DNS over TLS (port 853 by
+	// default), or an "https://" URL queried with DNS over HTTPS. The resolvers are tried in order until one answers.
+	// Defaults to the resolvers of the hive-controllers pod.
+	// +optional
+	Resolvers []string `json:"resolvers,omitempty"`
+
+	// QueryTimeout is the timeout of a single DNS query. Defaults to 30s.
+	// +optional
+	QueryTimeout *metav1.Duration `json:"queryTimeout,omitempty"`
+
+	// MaxCheckInterval caps the interval between checks of a zone which is not yet resolvable. The interval starts
+	// at 30s and doubles while the zone is waiting for delegation. Defaults to 5m.
+	// +optional
+	MaxCheckInterval *metav1.Duration `json:"maxCheckInterval,omitempty"`
+}
+
 // ManageDNSAWSConfig contains AWS-specific info to manage a given domain.
 type ManageDNSAWSConfig struct {
 	// CredentialsSecretRef references a secret in the TargetNamespace that will be used to authenticate with
@@ -312,6 +1041,23 @@ type ManageDNSAWSConfig struct {
 	// For AWS China, use cn-northwest-1.
 	// +optional
 	Region string `json:"region,omitempty"`
+
+	// AssumeRole is a role assumed with the credentials for the route53 operations on the managed domains. It allows
+	// the hosted zones of the managed domains to live in a different AWS account than the credentials. The DNS zones
+	// of clusters are still created in the cluster accounts, with the delegation records written to the managed
+	// domains through the role.
+	// +optional
+	AssumeRole *AWSAssumeRole `json:"assumeRole,omitempty"`
+}
+
+// AWSAssumeRole is an AWS IAM role to assume.
+type AWSAssumeRole struct {
+	// RoleARN is the ARN of the role to assume.
+	RoleARN string `json:"roleARN"`
+
+	// ExternalID is the external ID required by the trust policy of the role, if any.
+	// +optional
+	ExternalID string `json:"externalID,omitempty"`
 }
 
 // ManageDNSGCPConfig contains GCP-specific info to manage a given domain.
@@ -331,6 +1077,61 @@ const (
 	DeleteProtectionEnabled DeleteProtectionType = "enabled"
 )
 
+type NamespaceCleanupType string
+
+const (
+	NamespaceCleanupEnabled NamespaceCleanupType = "enabled"
+)
+
+type ScopedRemoteAccessType string
+
+const (
+	ScopedRemoteAccessEnabled ScopedRemoteAccessType = "enabled"
+)
+
+type MetricsNamespaceFilteringType string
+
+const (
+	MetricsNamespaceFilteringEnabled MetricsNamespaceFilteringType = "enabled"
+)
+
+// AdminKubeconfigContextType is a context of the admin kubeconfig of installed clusters.
+// +kubebuilder:validation:Enum=External;Internal
+type AdminKubeconfigContextType string
+
+const (
+	// AdminKubeconfigContextExternal is the current context of the admin kubeconfig, for the API URL of the cluster.
+	AdminKubeconfigContextExternal AdminKubeconfigContextType = "External"
+	// AdminKubeconfigContextInternal is the context of the admin kubeconfig for the internal API URL of the cluster.
+	AdminKubeconfigContextInternal AdminKubeconfigContextType = "Internal"
+)
+
+// RemoteAccessRBACMode is the mode of the permissions which Hive holds in installed clusters.
+// +kubebuilder:validation:Enum=Default;Restricted
+type RemoteAccessRBACMode string
+
+const (
+	// RemoteAccessRBACModeDefault grants Hive the permissions needed by all its features. SyncSets are applied with
+	// the admin kubeconfig.
+	RemoteAccessRBACModeDefault RemoteAccessRBACMode = "Default"
+	// RemoteAccessRBACModeRestricted limits Hive to the machine API, to reading the cluster version and operators,
+	// and to the API groups allowed for SyncSets. SyncSets are applied with the same permissions, and the features
+	// needing other permissions are disabled.
+	RemoteAccessRBACModeRestricted RemoteAccessRBACMode = "Restricted"
+)
+
+// RemoteAccessRBACConfig configures the permissions which Hive holds in installed clusters.
+type RemoteAccessRBACConfig struct {
+	// Mode is the mode of the permissions. Defaults to Default.
+	// +optional
+	Mode RemoteAccessRBACMode `json:"mode,omitempty"`
+
+	// SyncSetAPIGroups are the API groups of the resources which SyncSets can manage in the Restricted mode. The
+	// core API group is "".
+	// +optional
+	SyncSetAPIGroups []string `json:"syncSetAPIGroups,omitempty"`
+}
+
 // ManageDNSAzureConfig contains Azure-specific info to manage a given domain
 type ManageDNSAzureConfig struct {
 	// CredentialsSecretRef references a secret in the TargetNamespace that will be used to authenticate with
@@ -367,7 +1168,7 @@ type ControllerConfig struct {
 	Replicas *int32 `json:"replicas,omitempty"`
 }
 
-// +kubebuilder:validation:Enum=clusterDeployment;clusterrelocate;clusterstate;clusterversion;controlPlaneCerts;dnsendpoint;dnszone;remoteingress;remotemachineset;syncidentityprovider;unreachable;velerobackup;clusterprovision;clusterDeprovision;clusterpool;clusterpoolnamespace;hibernation;clusterclaim;metrics;clustersync
+// +kubebuilder:validation:Enum=basedomainpool;clusterDeployment;clusterinventory;clusterrelocate;clusterstate;clusterversion;controlPlaneCerts;dnsendpoint;dnszone;remoteingress;remotemachineset;syncidentityprovider;unreachable;velerobackup;clusterprovision;clusterDeprovision;clusterpool;clusterpoolnamespace;hibernation;clusterclaim;metrics;clustersync;nodetuning;namespacecleanup;remoteaccess;cmdbexport;gitsyncsource;clustersanitization;kubeadmin;snapshotexport;clusterimageset;changefreeze
 type ControllerName string
 
 func (controllerName ControllerName) String() string {
@@ -390,9 +1191,11 @@ func (c ControllerNames) Contains(controllerName ControllerName) bool {
 
 // WARNING: All the controller names below should also be added to the kubebuilder validation of the type ControllerName
 const (
+	BaseDomainPoolControllerName       ControllerName = "basedomainpool"
 	ClusterClaimControllerName         ControllerName = "clusterclaim"
 	ClusterDeploymentControllerName    ControllerName = "clusterDeployment"
 	ClusterDeprovisionControllerName   ControllerName = "clusterDeprovision"
+	ClusterInventoryControllerName     ControllerName = "clusterinventory"
 	ClusterpoolControllerName          ControllerName = "clusterpool"
 	ClusterpoolNamespaceControllerName ControllerName = "clusterpoolnamespace"
 	ClusterProvisionControllerName     ControllerName = "clusterProvision"
@@ -411,7 +1214,17 @@ const (
 	MetricsControllerName              ControllerName = "metrics"
 	ClustersyncControllerName          ControllerName = "clustersync"
 	MachineManagementControllerName    ControllerName = "machineManagement"
+	NodeTuningControllerName           ControllerName = "nodetuning"
+	NamespaceCleanupControllerName     ControllerName = "namespacecleanup"
+	RemoteAccessControllerName         ControllerName = "remoteaccess"
 	AWSPrivateLinkControllerName       ControllerName = "awsprivatelink"
+	CMDBExportControllerName           ControllerName = "cmdbexport"
+	SnapshotExportControllerName       ControllerName = "snapshotexport"
+	GitSyncSourceControllerName        ControllerName = "gitsyncsource"
+	ClusterSanitizationControllerName  ControllerName = "clustersanitization"
+	KubeadminControllerName            ControllerName = "kubeadmin"
+	ClusterImageSetControllerName      ControllerName = "clusterimageset"
+	ChangeFreezeControllerName         ControllerName = "changefreeze"
 )
 
 // SpecificControllerConfig contains the configuration for a specific controller
@@ -0,0 +1,71 @@
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// DefaultHiveNamespaceConfigName is the name of the HiveNamespaceConfig whose defaults are applied to the
+	// ClusterDeployments created in its namespace. HiveNamespaceConfigs with other names are ignored.
+	DefaultHiveNamespaceConfigName = "default"
+)
+
+// HiveNamespaceConfigSpec defines the defaults for the ClusterDeployments created in the namespace of the
+// HiveNamespaceConfig. A default is only applied to a ClusterDeployment which does not set the field itself.
+type HiveNamespaceConfigSpec struct {
+	// BaseDomain is the base domain of ClusterDeployments which neither set a base domain nor use a BaseDomainPool.
+	// +optional
+	BaseDomain string `json:"baseDomain,omitempty"`
+
+	// CredentialsSecretRef refers to a secret in the namespace holding the cloud credentials used by
+	// ClusterDeployments which do not set credentials for their platform.
+	// +optional
+	CredentialsSecretRef *corev1.LocalObjectReference `json:"credentialsSecretRef,omitempty"`
+
+	// ImageSetRef is the ClusterImageSet installed by ClusterDeployments which set neither a release image nor an
+	// image set.
+	// +optional
+	ImageSetRef *ClusterImageSetReference `json:"imageSetRef,omitempty"`
+
+	// UserTags are additional tags for the cloud resources of ClusterDeployments on AWS. Tags set by a
+	// ClusterDeployment take precedence over the tags with the same keys.
+	// +optional
+	UserTags map[string]string `json:"userTags,omitempty"`
+
+	// Proxy is the cluster-wide proxy of ClusterDeployments which do not set a proxy.
+	// +optional
+	Proxy *ProxyConfig `json:"proxy,omitempty"`
+}
+
+// +genclient
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// HiveNamespaceConfig provides defaults for the ClusterDeployments created in its namespace, so that the users of the
+// namespace only need to specify what is particular to their clusters. The defaults are applied when a
+// ClusterDeployment is created, and only the HiveNamespaceConfig named default is used.
+// +k8s:openapi-gen=true
+// +kubebuilder:printcolumn:name="BaseDomain",type="string",JSONPath=".spec.baseDomain"
+// +kubebuilder:printcolumn:name="ImageSet",type="string",JSONPath=".spec.imageSetRef.name"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:path=hivenamespaceconfigs,shortName=hnc
+type HiveNamespaceConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec HiveNamespaceConfigSpec `json:"spec,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// HiveNamespaceConfigList contains a list of HiveNamespaceConfigs
+type HiveNamespaceConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []HiveNamespaceConfig `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&HiveNamespaceConfig{}, &HiveNamespaceConfigList{})
+}
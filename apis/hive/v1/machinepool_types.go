@@ -7,8 +7,10 @@ import (
 	"github.com/openshift/hive/apis/hive/v1/aws"
 	"github.com/openshift/hive/apis/hive/v1/azure"
 	"github.com/openshift/hive/apis/hive/v1/gcp"
+	"github.com/openshift/hive/apis/hive/v1/oci"
 	"github.com/openshift/hive/apis/hive/v1/openstack"
 	"github.com/openshift/hive/apis/hive/v1/ovirt"
+	"github.com/openshift/hive/apis/hive/v1/powervs"
 	"github.com/openshift/hive/apis/hive/v1/vsphere"
 )
 
@@ -54,6 +56,13 @@ type MachinePoolSpec struct {
 	// This list will overwrite any modifications made to Node taints on an ongoing basis.
 	// +optional
 	Taints []corev1.Taint `json:"taints,omitempty"`
+
+	// DryRun makes Hive record the changes it would make to the MachineSets of the remote cluster in the status of
+	// the machine pool instead of applying them, so that changes to the machine pool can be reviewed first.
+	// The MachineAutoscalers and the ClusterAutoscaler of the remote cluster are not changed either.
+	// Deleting the machine pool still deletes its MachineSets.
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
 }
 
 // MachinePoolAutoscaling details how the machine pool is to be auto-scaled.
@@ -80,6 +89,10 @@ type MachinePoolPlatform struct {
 	VSphere *vsphere.MachinePool `json:"vsphere,omitempty"`
 	// Ovirt is the configuration used when installing on oVirt.
 	Ovirt *ovirt.MachinePool `json:"ovirt,omitempty"`
+	// OCI is the configuration used when installing on Oracle Cloud Infrastructure.
+	OCI *oci.MachinePool `json:"oci,omitempty"`
+	// PowerVS is the configuration used when installing on IBM Power Virtual Server.
+	PowerVS *powervs.MachinePool `json:"powervs,omitempty"`
 }
 
 // MachinePoolStatus defines the observed state of MachinePool
@@ -91,9 +104,18 @@ type MachinePoolStatus struct {
 	// MachineSets is the status of the machine sets for the machine pool on the remote cluster.
 	MachineSets []MachineSetStatus `json:"machineSets,omitempty"`
 
+	// ObservedGeneration is the generation of the machine pool last synced to the remote cluster.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
 	// Conditions includes more detailed status for the cluster deployment
 	// +optional
 	Conditions []MachinePoolCondition `json:"conditions,omitempty"`
+
+	// DryRun is the changes to the machine sets on the remote cluster which Hive would make for the machine pool,
+	// recorded while the machine pool is in dry run mode.
+	// +optional
+	DryRun *MachinePoolDryRunStatus `json:"dryRun,omitempty"`
 }
 
 // MachineSetStatus is the status of a machineset in the remote cluster.
@@ -109,8 +131,53 @@ type MachineSetStatus struct {
 
 	// MaxReplicas is the maximum number of replicas for the machine set.
 	MaxReplicas int32 `json:"maxReplicas"`
+
+	// InsufficientCapacityInstanceTypes are the instance types with which machines of the machine set failed for lack
+	// of capacity in its availability zone. The machine set uses the next of the instance types of the machine pool
+	// instead. They are cleared once all the instance types of the machine pool lacked capacity, so that they are
+	// retried. This is only recorded on AWS, for machine pools with fallback instance types.
+	// +optional
+	InsufficientCapacityInstanceTypes []string `json:"insufficientCapacityInstanceTypes,omitempty"`
+}
+
+// MachinePoolDryRunStatus is the changes to the machine sets on the remote cluster which Hive would make for a machine
+// pool in dry run mode.
+type MachinePoolDryRunStatus struct {
+	// ObservedGeneration is the generation of the machine pool for which the changes were computed.
+	ObservedGeneration int64 `json:"observedGeneration"`
+
+	// MachineSets is the changes to the machine sets. It is empty when the machine sets are in sync with the
+	// machine pool.
+	// +optional
+	MachineSets []MachineSetChange `json:"machineSets,omitempty"`
 }
 
+// MachineSetChange is a change to a machine set on the remote cluster.
+type MachineSetChange struct {
+	// Name is the name of the machine set.
+	Name string `json:"name"`
+
+	// Action is how the machine set would be changed.
+	Action MachineSetChangeAction `json:"action"`
+
+	// Diff is the unified diff between the machine set on the remote cluster and the machine set Hive would apply,
+	// both rendered as YAML.
+	Diff string `json:"diff"`
+}
+
+// MachineSetChangeAction is the action of a MachineSetChange.
+// +kubebuilder:validation:Enum=Create;Update;Delete
+type MachineSetChangeAction string
+
+const (
+	// CreateMachineSetChangeAction is the action of a machine set which would be created.
+	CreateMachineSetChangeAction MachineSetChangeAction = "Create"
+	// UpdateMachineSetChangeAction is the action of a machine set which would be updated.
+	UpdateMachineSetChangeAction MachineSetChangeAction = "Update"
+	// DeleteMachineSetChangeAction is the action of a machine set which would be deleted.
+	DeleteMachineSetChangeAction MachineSetChangeAction = "Delete"
+)
+
 // MachinePoolCondition contains details for the current condition of a machine pool
 type MachinePoolCondition struct {
 	// Type is the type of the condition.
@@ -129,6 +196,9 @@ type MachinePoolCondition struct {
 	// Message is a human-readable message indicating details about last transition.
 	// +optional
 	Message string `json:"message,omitempty"`
+	// Category is the class of the failure reported by the condition, from its reason.
+	// +optional
+	Category FailureCategory `json:"category,omitempty"`
 }
 
 // MachinePoolConditionType is a valid value for MachinePoolCondition.Type
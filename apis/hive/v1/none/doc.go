@@ -0,0 +1,4 @@
+// Package none contains API Schema definitions for clusters whose infrastructure is created outside of Hive.
+// +k8s:deepcopy-gen=package,register
+// +k8s:conversion-gen=github.com/openshift/hive/apis/hive
+package none
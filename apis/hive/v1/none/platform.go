@@ -0,0 +1,108 @@
+package none
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Platform stores the configuration for clusters installed on infrastructure that Hive does not create.
+// Hive generates the ignition configs, publishes them for external infrastructure hooks to consume, and then
+// waits for the hooks to report back before completing the install.
+type Platform struct {
+	// IgnitionDelivery configures how the generated ignition configs are made available to the external
+	// infrastructure hooks.
+	// +optional
+	IgnitionDelivery IgnitionDelivery `json:"ignitionDelivery,omitempty"`
+
+	// InfrastructureTimeout is how long to wait for the external infrastructure hooks to report that the
+	// bootstrap and control plane machines have been created before failing the provision. Defaults to 2h.
+	// +optional
+	InfrastructureTimeout *metav1.Duration `json:"infrastructureTimeout,omitempty"`
+
+	// Destroyer is the site-specific teardown automation invoked when the ClusterDeployment is deleted. When
+	// unset, deleting the ClusterDeployment leaves the infrastructure in place.
+	// +optional
+	Destroyer *ExternalDestroyer `json:"destroyer,omitempty"`
+}
+
+// IgnitionDeliveryType is the method used to publish ignition configs.
+// +kubebuilder:validation:Enum="";Secret;ObjectStore
+type IgnitionDeliveryType string
+
+const (
+	// IgnitionDeliverySecret stores the ignition configs in a Secret in the ClusterDeployment namespace
+	// named after the ClusterProvision.
+	IgnitionDeliverySecret IgnitionDeliveryType = "Secret"
+
+	// IgnitionDeliveryObjectStore uploads the ignition configs to an object store with an HTTP PUT to
+	// pre-signed URLs.
+	IgnitionDeliveryObjectStore IgnitionDeliveryType = "ObjectStore"
+)
+
+// IgnitionDelivery configures how the generated ignition configs are published.
+type IgnitionDelivery struct {
+	// Type is the method used to publish the ignition configs. Defaults to Secret.
+	// +optional
+	Type IgnitionDeliveryType `json:"type,omitempty"`
+
+	// ObjectStore configures uploads of the ignition configs to an object store. Required when Type is ObjectStore.
+	// +optional
+	ObjectStore *IgnitionObjectStore `json:"objectStore,omitempty"`
+}
+
+// IgnitionObjectStore configures uploads of ignition configs to an object store.
+type IgnitionObjectStore struct {
+	// URLsSecretRef refers to a secret holding the URLs to upload each ignition config to, keyed by the ignition
+	// file name: bootstrap.ign, master.ign and worker.ign. The URLs are typically pre-signed object store URLs.
+	URLsSecretRef corev1.LocalObjectReference `json:"urlsSecretRef"`
+}
+
+// ExternalDestroyer is the teardown automation for infrastructure created outside of Hive. Exactly one of
+// Job or Webhook must be set.
+type ExternalDestroyer struct {
+	// Job is run as the deprovision job to tear down the infrastructure.
+	// +optional
+	Job *DestroyerJob `json:"job,omitempty"`
+
+	// Webhook is called by the deprovision job to tear down the infrastructure.
+	// +optional
+	Webhook *DestroyerWebhook `json:"webhook,omitempty"`
+}
+
+// DestroyerJob configures the container run to tear down the infrastructure. The HIVE_INFRA_ID, HIVE_CLUSTER_ID,
+// HIVE_CLUSTER_DEPLOYMENT_NAME and HIVE_CLUSTER_DEPLOYMENT_NAMESPACE environment variables identify the cluster
+// being deprovisioned. The container is run until it exits successfully.
+type DestroyerJob struct {
+	// Image is the container image to run.
+	Image string `json:"image"`
+
+	// Command is the entrypoint of the container. The image's entrypoint is used if not set.
+	// +optional
+	Command []string `json:"command,omitempty"`
+
+	// Args are the arguments to the entrypoint.
+	// +optional
+	Args []string `json:"args,omitempty"`
+
+	// Env are extra environment variables to set in the container.
+	// +optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// ServiceAccountName is the service account to run the job as. Defaults to the default service account
+	// of the ClusterDeployment namespace.
+	// +optional
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+}
+
+// DestroyerWebhook configures the webhook called to tear down the infrastructure. The webhook receives a POST
+// with a JSON body identifying the cluster. It may respond with 200 or 204 once teardown is done, or with 202
+// and a Location header for a status URL, which is polled until it responds with 200.
+type DestroyerWebhook struct {
+	// URL is the http or https URL of the webhook.
+	URL string `json:"url"`
+
+	// TokenSecretRef refers to a secret with a bearer token under the "token" key that is sent in the
+	// Authorization header of the webhook requests.
+	// +optional
+	TokenSecretRef *corev1.LocalObjectReference `json:"tokenSecretRef,omitempty"`
+}
@@ -0,0 +1,4 @@
+// Package oci contains API Schema definitions for Oracle Cloud Infrastructure clusters.
+// +k8s:deepcopy-gen=package,register
+// +k8s:conversion-gen=github.com/openshift/hive/apis/hive
+package oci
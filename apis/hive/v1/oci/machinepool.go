@@ -0,0 +1,40 @@
+package oci
+
+// MachinePool stores the configuration for a machine pool installed on OCI.
+type MachinePool struct {
+	// Shape is the OCI compute shape to use for instances.
+	// eg. VM.Standard.E4.Flex
+	Shape string `json:"shape"`
+
+	// OCPUs is the number of OCPUs to allocate to each instance. Only used with flexible shapes.
+	// +optional
+	OCPUs *int32 `json:"ocpus,omitempty"`
+
+	// MemoryGBs is the amount of memory in gigabytes to allocate to each instance. Only used
+	// with flexible shapes.
+	// +optional
+	MemoryGBs *int32 `json:"memoryGBs,omitempty"`
+
+	// BootVolume defines the boot volume for instances.
+	// +optional
+	BootVolume BootVolume `json:"bootVolume,omitempty"`
+
+	// AvailabilityDomains is the list of availability domains that can be used.
+	// +optional
+	AvailabilityDomains []string `json:"availabilityDomains,omitempty"`
+}
+
+// BootVolume defines the boot volume for machines on OCI.
+type BootVolume struct {
+	// SizeGBs defines the size of the boot volume in GB.
+	// Defaulted internally to 120.
+	//
+	// +kubebuilder:validation:Minimum=50
+	// +kubebuilder:validation:Maximum=32768
+	// +optional
+	SizeGBs int64 `json:"sizeGBs,omitempty"`
+
+	// VPUsPerGB is the number of volume performance units per GB for the boot volume.
+	// +optional
+	VPUsPerGB *int64 `json:"vpusPerGB,omitempty"`
+}
@@ -0,0 +1,28 @@
+package oci
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Platform stores all the global configuration that all machinesets
+// use.
+type Platform struct {
+	// CredentialsSecretRef refers to a secret that contains the OCI API signing key
+	// credentials with fields: tenancy, user, fingerprint, privateKey and optionally passphrase.
+	CredentialsSecretRef corev1.LocalObjectReference `json:"credentialsSecretRef"`
+
+	// Region specifies the OCI region where the cluster will be created.
+	Region string `json:"region"`
+
+	// CompartmentID is the OCID of the compartment in which cluster resources will be created.
+	CompartmentID string `json:"compartmentID"`
+
+	// DNSCompartmentID is the OCID of the compartment holding the DNS zone for the cluster's
+	// base domain. Defaults to CompartmentID.
+	// +optional
+	DNSCompartmentID string `json:"dnsCompartmentID,omitempty"`
+
+	// FreeformTags are additional tags applied to OCI resources created for the cluster.
+	// +optional
+	FreeformTags map[string]string `json:"freeformTags,omitempty"`
+}
@@ -0,0 +1,4 @@
+// Package powervs contains API Schema definitions for IBM Power Virtual Server clusters.
+// +k8s:deepcopy-gen=package,register
+// +k8s:conversion-gen=github.com/openshift/hive/apis/hive
+package powervs
@@ -0,0 +1,24 @@
+package powervs
+
+// MachinePool stores the configuration for a machine pool installed on IBM PowerVS.
+type MachinePool struct {
+	// SysType is the system type of the instances.
+	// eg. s922, e980
+	// +optional
+	SysType string `json:"sysType,omitempty"`
+
+	// ProcType defines the processor sharing model for the instances.
+	// +kubebuilder:validation:Enum="";dedicated;shared;capped
+	// +optional
+	ProcType string `json:"procType,omitempty"`
+
+	// Processors is the number of virtual processors in each instance, as a decimal string in
+	// increments of 0.25 for shared processors or whole numbers for dedicated processors.
+	// eg. "0.5", "2"
+	// +optional
+	Processors string `json:"processors,omitempty"`
+
+	// MemoryGiB is the size of a machine's memory in GiB.
+	// +optional
+	MemoryGiB int32 `json:"memoryGiB,omitempty"`
+}
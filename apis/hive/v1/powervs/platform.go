@@ -0,0 +1,34 @@
+package powervs
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Platform stores all the global configuration that all machinesets
+// use.
+type Platform struct {
+	// CredentialsSecretRef refers to a secret that contains the IBM Cloud API key
+	// with field: ibmcloud_api_key.
+	CredentialsSecretRef corev1.LocalObjectReference `json:"credentialsSecretRef"`
+
+	// Region specifies the IBM Cloud region where the cluster will be created.
+	// eg. dal, lon, syd
+	Region string `json:"region"`
+
+	// Zone specifies the PowerVS zone (datacenter) within the region.
+	// eg. dal12, lon04
+	Zone string `json:"zone"`
+
+	// ServiceInstanceID is the GUID of the existing Power Virtual Server service instance in which
+	// cluster resources will be created.
+	ServiceInstanceID string `json:"serviceInstanceID"`
+
+	// NetworkName is the name of an existing network in the service instance to attach machines to.
+	// If unset, the installer creates a DHCP network for the cluster.
+	// +optional
+	NetworkName string `json:"networkName,omitempty"`
+
+	// UserTags are additional tags applied to IBM Cloud resources created for the cluster.
+	// +optional
+	UserTags []string `json:"userTags,omitempty"`
+}
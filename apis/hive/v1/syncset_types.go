@@ -103,6 +103,53 @@ type SecretMapping struct {
 	TargetRef SecretReference `json:"targetRef"`
 }
 
+// SyncSetResourceRef is a reference to a source of resources to sync. Exactly one of ConfigMap or OCIArtifact must
+// be set.
+type SyncSetResourceRef struct {
+	// ConfigMap is a ConfigMap on the management cluster whose data holds the manifests of the resources.
+	// +optional
+	ConfigMap *ConfigMapResourceSource `json:"configMap,omitempty"`
+
+	// OCIArtifact is an artifact in an OCI registry whose layers hold the manifests of the resources.
+	// +optional
+	OCIArtifact *OCIArtifactResourceSource `json:"ociArtifact,omitempty"`
+}
+
+// ConfigMapResourceSource is a ConfigMap holding manifests of resources to sync. Each data key holds one or more
+// YAML or JSON manifests, separated by "---". The keys are read in sorted order.
+type ConfigMapResourceSource struct {
+	// Name is the name of the ConfigMap.
+	Name string `json:"name"`
+
+	// Namespace is the namespace of the ConfigMap. It is required for SelectorSyncSets. For SyncSets, it must be
+	// the namespace of the SyncSet, which is assumed if it is not present.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Digest is the sha256 digest, in the form "sha256:<hex>", of the values of the data keys of the ConfigMap
+	// concatenated in the sorted order of the keys. The resources are not synced if the ConfigMap does not match
+	// the digest.
+	// +kubebuilder:validation:Pattern=`^sha256:[a-f0-9]{64}$`
+	// +optional
+	Digest string `json:"digest,omitempty"`
+}
+
+// OCIArtifactResourceSource is an artifact in an OCI registry holding manifests of resources to sync. Each layer of
+// the artifact is either a tar archive, optionally gzipped, of manifest files with a .yaml, .yml or .json
+// extension, or a file of one or more YAML or JSON manifests separated by "---".
+type OCIArtifactResourceSource struct {
+	// Image is the reference of the artifact, pinned by digest, such as
+	// quay.io/example/manifests@sha256:<hex>. The digests of the manifest and layers of the artifact are
+	// verified before the resources are synced.
+	// +kubebuilder:validation:Pattern=`^[^/@]+/[^@]+@sha256:[a-f0-9]{64}$`
+	Image string `json:"image"`
+
+	// PullSecretRef is a secret of type kubernetes.io/dockerconfigjson with the credentials for the registry. Its
+	// namespace follows the same rules as the namespace of the source of SecretMappings.
+	// +optional
+	PullSecretRef *SecretReference `json:"pullSecretRef,omitempty"`
+}
+
 // SyncConditionType is a valid value for SyncCondition.Type
 type SyncConditionType string
 
@@ -208,6 +255,12 @@ type SyncSetCommonSpec struct {
 	// +optional
 	Resources []runtime.RawExtension `json:"resources,omitempty"`
 
+	// ResourceRefs is the list of ConfigMaps and OCI artifacts holding manifests of further objects to sync. They
+	// allow large sets of manifests to be synced without storing them in the syncset, and to be shared by several
+	// syncsets. The objects are synced after the objects in Resources.
+	// +optional
+	ResourceRefs []SyncSetResourceRef `json:"resourceRefs,omitempty"`
+
 	// ResourceApplyMode indicates if the Resource apply mode is "Upsert" (default) or "Sync".
 	// ApplyMode "Upsert" indicates create and update.
 	// ApplyMode "Sync" indicates create, update and delete.
@@ -257,6 +310,20 @@ type SyncSetSpec struct {
 	// SyncSet applies to in the SyncSet's namespace.
 	// +required
 	ClusterDeploymentRefs []corev1.LocalObjectReference `json:"clusterDeploymentRefs"`
+
+	// ApplyAs is a ServiceAccount on the target cluster which the resources, patches and secrets of the SyncSet are
+	// applied as. The ServiceAccount is impersonated, so the RBAC of the target cluster limits what the SyncSet can
+	// change, and the changes are audited as made by the ServiceAccount. By default, the SyncSet is applied as Hive.
+	// +optional
+	ApplyAs *ServiceAccountReference `json:"applyAs,omitempty"`
+}
+
+// ServiceAccountReference is a reference to a ServiceAccount by name and namespace
+type ServiceAccountReference struct {
+	// Name is the name of the ServiceAccount
+	Name string `json:"name"`
+	// Namespace is the namespace where the ServiceAccount lives
+	Namespace string `json:"namespace"`
 }
 
 // SyncSetStatus defines the observed state of a SyncSet
@@ -11,8 +11,11 @@ import (
 	azure "github.com/openshift/hive/apis/hive/v1/azure"
 	baremetal "github.com/openshift/hive/apis/hive/v1/baremetal"
 	gcp "github.com/openshift/hive/apis/hive/v1/gcp"
+	none "github.com/openshift/hive/apis/hive/v1/none"
+	oci "github.com/openshift/hive/apis/hive/v1/oci"
 	openstack "github.com/openshift/hive/apis/hive/v1/openstack"
 	ovirt "github.com/openshift/hive/apis/hive/v1/ovirt"
+	powervs "github.com/openshift/hive/apis/hive/v1/powervs"
 	vsphere "github.com/openshift/hive/apis/hive/v1/vsphere"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
@@ -42,6 +45,22 @@ func (in *AWSAssociatedVPC) DeepCopy() *AWSAssociatedVPC {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AWSAssumeRole) DeepCopyInto(out *AWSAssumeRole) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSAssumeRole.
+func (in *AWSAssumeRole) DeepCopy() *AWSAssumeRole {
+	if in == nil {
+		return nil
+	}
+	out := new(AWSAssumeRole)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AWSClusterDeprovision) DeepCopyInto(out *AWSClusterDeprovision) {
 	*out = *in
@@ -124,6 +143,11 @@ func (in *AWSPrivateLinkConfig) DeepCopyInto(out *AWSPrivateLinkConfig) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.SharedHostedZone != nil {
+		in, out := &in.SharedHostedZone, &out.SharedHostedZone
+		*out = new(AWSPrivateLinkSharedHostedZone)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -159,6 +183,27 @@ func (in *AWSPrivateLinkInventory) DeepCopy() *AWSPrivateLinkInventory {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AWSPrivateLinkSharedHostedZone) DeepCopyInto(out *AWSPrivateLinkSharedHostedZone) {
+	*out = *in
+	if in.CredentialsSecretRef != nil {
+		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSPrivateLinkSharedHostedZone.
+func (in *AWSPrivateLinkSharedHostedZone) DeepCopy() *AWSPrivateLinkSharedHostedZone {
+	if in == nil {
+		return nil
+	}
+	out := new(AWSPrivateLinkSharedHostedZone)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AWSPrivateLinkSubnet) DeepCopyInto(out *AWSPrivateLinkSubnet) {
 	*out = *in
@@ -191,6 +236,27 @@ func (in *AWSPrivateLinkVPC) DeepCopy() *AWSPrivateLinkVPC {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AWSRateLimitConfig) DeepCopyInto(out *AWSRateLimitConfig) {
+	*out = *in
+	if in.MaxRetries != nil {
+		in, out := &in.MaxRetries, &out.MaxRetries
+		*out = new(int)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSRateLimitConfig.
+func (in *AWSRateLimitConfig) DeepCopy() *AWSRateLimitConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AWSRateLimitConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AWSResourceTag) DeepCopyInto(out *AWSResourceTag) {
 	*out = *in
@@ -207,6 +273,22 @@ func (in *AWSResourceTag) DeepCopy() *AWSResourceTag {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuditLogPosition) DeepCopyInto(out *AuditLogPosition) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuditLogPosition.
+func (in *AuditLogPosition) DeepCopy() *AuditLogPosition {
+	if in == nil {
+		return nil
+	}
+	out := new(AuditLogPosition)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AzureClusterDeprovision) DeepCopyInto(out *AzureClusterDeprovision) {
 	*out = *in
@@ -300,76 +382,43 @@ func (in *BackupReference) DeepCopy() *BackupReference {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CentralMachineManagement) DeepCopyInto(out *CentralMachineManagement) {
-	*out = *in
-	return
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CentralMachineManagement.
-func (in *CentralMachineManagement) DeepCopy() *CentralMachineManagement {
-	if in == nil {
-		return nil
-	}
-	out := new(CentralMachineManagement)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CertificateBundleSpec) DeepCopyInto(out *CertificateBundleSpec) {
-	*out = *in
-	out.CertificateSecretRef = in.CertificateSecretRef
-	return
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateBundleSpec.
-func (in *CertificateBundleSpec) DeepCopy() *CertificateBundleSpec {
-	if in == nil {
-		return nil
-	}
-	out := new(CertificateBundleSpec)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CertificateBundleStatus) DeepCopyInto(out *CertificateBundleStatus) {
+func (in *BaseDomainAllocation) DeepCopyInto(out *BaseDomainAllocation) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateBundleStatus.
-func (in *CertificateBundleStatus) DeepCopy() *CertificateBundleStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BaseDomainAllocation.
+func (in *BaseDomainAllocation) DeepCopy() *BaseDomainAllocation {
 	if in == nil {
 		return nil
 	}
-	out := new(CertificateBundleStatus)
+	out := new(BaseDomainAllocation)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Checkpoint) DeepCopyInto(out *Checkpoint) {
+func (in *BaseDomainPool) DeepCopyInto(out *BaseDomainPool) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Checkpoint.
-func (in *Checkpoint) DeepCopy() *Checkpoint {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BaseDomainPool.
+func (in *BaseDomainPool) DeepCopy() *BaseDomainPool {
 	if in == nil {
 		return nil
 	}
-	out := new(Checkpoint)
+	out := new(BaseDomainPool)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Checkpoint) DeepCopyObject() runtime.Object {
+func (in *BaseDomainPool) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -377,13 +426,13 @@ func (in *Checkpoint) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CheckpointList) DeepCopyInto(out *CheckpointList) {
+func (in *BaseDomainPoolList) DeepCopyInto(out *BaseDomainPoolList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]Checkpoint, len(*in))
+		*out = make([]BaseDomainPool, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -391,18 +440,18 @@ func (in *CheckpointList) DeepCopyInto(out *CheckpointList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CheckpointList.
-func (in *CheckpointList) DeepCopy() *CheckpointList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BaseDomainPoolList.
+func (in *BaseDomainPoolList) DeepCopy() *BaseDomainPoolList {
 	if in == nil {
 		return nil
 	}
-	out := new(CheckpointList)
+	out := new(BaseDomainPoolList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CheckpointList) DeepCopyObject() runtime.Object {
+func (in *BaseDomainPoolList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -410,93 +459,119 @@ func (in *CheckpointList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CheckpointSpec) DeepCopyInto(out *CheckpointSpec) {
+func (in *BaseDomainPoolReference) DeepCopyInto(out *BaseDomainPoolReference) {
 	*out = *in
-	in.LastBackupTime.DeepCopyInto(&out.LastBackupTime)
-	out.LastBackupRef = in.LastBackupRef
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CheckpointSpec.
-func (in *CheckpointSpec) DeepCopy() *CheckpointSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BaseDomainPoolReference.
+func (in *BaseDomainPoolReference) DeepCopy() *BaseDomainPoolReference {
 	if in == nil {
 		return nil
 	}
-	out := new(CheckpointSpec)
+	out := new(BaseDomainPoolReference)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CheckpointStatus) DeepCopyInto(out *CheckpointStatus) {
+func (in *BaseDomainPoolSpec) DeepCopyInto(out *BaseDomainPoolSpec) {
 	*out = *in
+	if in.Domains != nil {
+		in, out := &in.Domains, &out.Domains
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CheckpointStatus.
-func (in *CheckpointStatus) DeepCopy() *CheckpointStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BaseDomainPoolSpec.
+func (in *BaseDomainPoolSpec) DeepCopy() *BaseDomainPoolSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(CheckpointStatus)
+	out := new(BaseDomainPoolSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterClaim) DeepCopyInto(out *ClusterClaim) {
+func (in *BaseDomainPoolStatus) DeepCopyInto(out *BaseDomainPoolStatus) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	if in.Allocations != nil {
+		in, out := &in.Allocations, &out.Allocations
+		*out = make([]BaseDomainAllocation, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterClaim.
-func (in *ClusterClaim) DeepCopy() *ClusterClaim {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BaseDomainPoolStatus.
+func (in *BaseDomainPoolStatus) DeepCopy() *BaseDomainPoolStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterClaim)
+	out := new(BaseDomainPoolStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterClaim) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CMDBConnector) DeepCopyInto(out *CMDBConnector) {
+	*out = *in
+	if in.CredentialsSecretRef != nil {
+		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
 	}
-	return nil
+	if in.Events != nil {
+		in, out := &in.Events, &out.Events
+		*out = make([]CMDBLifecycleEvent, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CMDBConnector.
+func (in *CMDBConnector) DeepCopy() *CMDBConnector {
+	if in == nil {
+		return nil
+	}
+	out := new(CMDBConnector)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterClaimCondition) DeepCopyInto(out *ClusterClaimCondition) {
+func (in *CMDBConnectorStatus) DeepCopyInto(out *CMDBConnectorStatus) {
 	*out = *in
-	in.LastProbeTime.DeepCopyInto(&out.LastProbeTime)
-	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	if in.LastExportTime != nil {
+		in, out := &in.LastExportTime, &out.LastExportTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastFailureTime != nil {
+		in, out := &in.LastFailureTime, &out.LastFailureTime
+		*out = (*in).DeepCopy()
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterClaimCondition.
-func (in *ClusterClaimCondition) DeepCopy() *ClusterClaimCondition {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CMDBConnectorStatus.
+func (in *CMDBConnectorStatus) DeepCopy() *CMDBConnectorStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterClaimCondition)
+	out := new(CMDBConnectorStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterClaimList) DeepCopyInto(out *ClusterClaimList) {
+func (in *CMDBExportConfig) DeepCopyInto(out *CMDBExportConfig) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]ClusterClaim, len(*in))
+	if in.Connectors != nil {
+		in, out := &in.Connectors, &out.Connectors
+		*out = make([]CMDBConnector, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -504,295 +579,131 @@ func (in *ClusterClaimList) DeepCopyInto(out *ClusterClaimList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterClaimList.
-func (in *ClusterClaimList) DeepCopy() *ClusterClaimList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CMDBExportConfig.
+func (in *CMDBExportConfig) DeepCopy() *CMDBExportConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterClaimList)
+	out := new(CMDBExportConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterClaimList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterClaimSpec) DeepCopyInto(out *ClusterClaimSpec) {
+func (in *CSRApprovalPolicyConfig) DeepCopyInto(out *CSRApprovalPolicyConfig) {
 	*out = *in
-	if in.Subjects != nil {
-		in, out := &in.Subjects, &out.Subjects
-		*out = make([]rbacv1.Subject, len(*in))
+	if in.AllowedNodeNamePatterns != nil {
+		in, out := &in.AllowedNodeNamePatterns, &out.AllowedNodeNamePatterns
+		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.Lifetime != nil {
-		in, out := &in.Lifetime, &out.Lifetime
-		*out = new(metav1.Duration)
+	if in.MaxApprovalsPerResume != nil {
+		in, out := &in.MaxApprovalsPerResume, &out.MaxApprovalsPerResume
+		*out = new(int32)
 		**out = **in
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterClaimSpec.
-func (in *ClusterClaimSpec) DeepCopy() *ClusterClaimSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CSRApprovalPolicyConfig.
+func (in *CSRApprovalPolicyConfig) DeepCopy() *CSRApprovalPolicyConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterClaimSpec)
+	out := new(CSRApprovalPolicyConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterClaimStatus) DeepCopyInto(out *ClusterClaimStatus) {
+func (in *CentralMachineManagement) DeepCopyInto(out *CentralMachineManagement) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]ClusterClaimCondition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.Lifetime != nil {
-		in, out := &in.Lifetime, &out.Lifetime
-		*out = new(metav1.Duration)
-		**out = **in
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterClaimStatus.
-func (in *ClusterClaimStatus) DeepCopy() *ClusterClaimStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CentralMachineManagement.
+func (in *CentralMachineManagement) DeepCopy() *CentralMachineManagement {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterClaimStatus)
+	out := new(CentralMachineManagement)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterDeployment) DeepCopyInto(out *ClusterDeployment) {
+func (in *CertManagerCertificate) DeepCopyInto(out *CertManagerCertificate) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	out.IssuerRef = in.IssuerRef
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeployment.
-func (in *ClusterDeployment) DeepCopy() *ClusterDeployment {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertManagerCertificate.
+func (in *CertManagerCertificate) DeepCopy() *CertManagerCertificate {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterDeployment)
+	out := new(CertManagerCertificate)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterDeployment) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterDeploymentCondition) DeepCopyInto(out *ClusterDeploymentCondition) {
+func (in *CertManagerIssuerReference) DeepCopyInto(out *CertManagerIssuerReference) {
 	*out = *in
-	in.LastProbeTime.DeepCopyInto(&out.LastProbeTime)
-	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeploymentCondition.
-func (in *ClusterDeploymentCondition) DeepCopy() *ClusterDeploymentCondition {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertManagerIssuerReference.
+func (in *CertManagerIssuerReference) DeepCopy() *CertManagerIssuerReference {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterDeploymentCondition)
+	out := new(CertManagerIssuerReference)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterDeploymentList) DeepCopyInto(out *ClusterDeploymentList) {
+func (in *CertificateBundleSpec) DeepCopyInto(out *CertificateBundleSpec) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]ClusterDeployment, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	out.CertificateSecretRef = in.CertificateSecretRef
+	if in.CertManager != nil {
+		in, out := &in.CertManager, &out.CertManager
+		*out = new(CertManagerCertificate)
+		**out = **in
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeploymentList.
-func (in *ClusterDeploymentList) DeepCopy() *ClusterDeploymentList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateBundleSpec.
+func (in *CertificateBundleSpec) DeepCopy() *CertificateBundleSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterDeploymentList)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterDeploymentList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterDeploymentSpec) DeepCopyInto(out *ClusterDeploymentSpec) {
-	*out = *in
-	in.Platform.DeepCopyInto(&out.Platform)
-	if in.PullSecretRef != nil {
-		in, out := &in.PullSecretRef, &out.PullSecretRef
-		*out = new(corev1.LocalObjectReference)
-		**out = **in
-	}
-	in.ControlPlaneConfig.DeepCopyInto(&out.ControlPlaneConfig)
-	if in.Ingress != nil {
-		in, out := &in.Ingress, &out.Ingress
-		*out = make([]ClusterIngress, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.CertificateBundles != nil {
-		in, out := &in.CertificateBundles, &out.CertificateBundles
-		*out = make([]CertificateBundleSpec, len(*in))
-		copy(*out, *in)
-	}
-	if in.ClusterMetadata != nil {
-		in, out := &in.ClusterMetadata, &out.ClusterMetadata
-		*out = new(ClusterMetadata)
-		**out = **in
-	}
-	if in.Provisioning != nil {
-		in, out := &in.Provisioning, &out.Provisioning
-		*out = new(Provisioning)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.ClusterPoolRef != nil {
-		in, out := &in.ClusterPoolRef, &out.ClusterPoolRef
-		*out = new(ClusterPoolReference)
-		**out = **in
-	}
-	if in.HibernateAfter != nil {
-		in, out := &in.HibernateAfter, &out.HibernateAfter
-		*out = new(metav1.Duration)
-		**out = **in
-	}
-	if in.InstallAttemptsLimit != nil {
-		in, out := &in.InstallAttemptsLimit, &out.InstallAttemptsLimit
-		*out = new(int32)
-		**out = **in
-	}
-	if in.MachineManagement != nil {
-		in, out := &in.MachineManagement, &out.MachineManagement
-		*out = new(MachineManagement)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.BoundServiceAccountSignkingKeySecretRef != nil {
-		in, out := &in.BoundServiceAccountSignkingKeySecretRef, &out.BoundServiceAccountSignkingKeySecretRef
-		*out = new(corev1.LocalObjectReference)
-		**out = **in
-	}
-	return
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeploymentSpec.
-func (in *ClusterDeploymentSpec) DeepCopy() *ClusterDeploymentSpec {
-	if in == nil {
-		return nil
-	}
-	out := new(ClusterDeploymentSpec)
+	out := new(CertificateBundleSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterDeploymentStatus) DeepCopyInto(out *ClusterDeploymentStatus) {
+func (in *CertificateBundleStatus) DeepCopyInto(out *CertificateBundleStatus) {
 	*out = *in
-	if in.InstallerImage != nil {
-		in, out := &in.InstallerImage, &out.InstallerImage
-		*out = new(string)
-		**out = **in
-	}
-	if in.InstallVersion != nil {
-		in, out := &in.InstallVersion, &out.InstallVersion
-		*out = new(string)
-		**out = **in
-	}
-	if in.CLIImage != nil {
-		in, out := &in.CLIImage, &out.CLIImage
-		*out = new(string)
-		**out = **in
-	}
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]ClusterDeploymentCondition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.CertificateBundles != nil {
-		in, out := &in.CertificateBundles, &out.CertificateBundles
-		*out = make([]CertificateBundleStatus, len(*in))
-		copy(*out, *in)
-	}
-	if in.InstallStartedTimestamp != nil {
-		in, out := &in.InstallStartedTimestamp, &out.InstallStartedTimestamp
-		*out = (*in).DeepCopy()
-	}
-	if in.InstalledTimestamp != nil {
-		in, out := &in.InstalledTimestamp, &out.InstalledTimestamp
-		*out = (*in).DeepCopy()
-	}
-	if in.ProvisionRef != nil {
-		in, out := &in.ProvisionRef, &out.ProvisionRef
-		*out = new(corev1.LocalObjectReference)
-		**out = **in
-	}
-	if in.InstallStrategy != nil {
-		in, out := &in.InstallStrategy, &out.InstallStrategy
-		*out = new(InstallStrategyStatus)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Platform != nil {
-		in, out := &in.Platform, &out.Platform
-		*out = new(PlatformStatus)
-		(*in).DeepCopyInto(*out)
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeploymentStatus.
-func (in *ClusterDeploymentStatus) DeepCopy() *ClusterDeploymentStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateBundleStatus.
+func (in *CertificateBundleStatus) DeepCopy() *CertificateBundleStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterDeploymentStatus)
+	out := new(CertificateBundleStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterDeprovision) DeepCopyInto(out *ClusterDeprovision) {
+func (in *ChangeFreeze) DeepCopyInto(out *ChangeFreeze) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -801,18 +712,18 @@ func (in *ClusterDeprovision) DeepCopyInto(out *ClusterDeprovision) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeprovision.
-func (in *ClusterDeprovision) DeepCopy() *ClusterDeprovision {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChangeFreeze.
+func (in *ChangeFreeze) DeepCopy() *ChangeFreeze {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterDeprovision)
+	out := new(ChangeFreeze)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterDeprovision) DeepCopyObject() runtime.Object {
+func (in *ChangeFreeze) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -820,31 +731,29 @@ func (in *ClusterDeprovision) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterDeprovisionCondition) DeepCopyInto(out *ClusterDeprovisionCondition) {
+func (in *ChangeFreezeDeferredChange) DeepCopyInto(out *ChangeFreezeDeferredChange) {
 	*out = *in
-	in.LastProbeTime.DeepCopyInto(&out.LastProbeTime)
-	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeprovisionCondition.
-func (in *ClusterDeprovisionCondition) DeepCopy() *ClusterDeprovisionCondition {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChangeFreezeDeferredChange.
+func (in *ChangeFreezeDeferredChange) DeepCopy() *ChangeFreezeDeferredChange {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterDeprovisionCondition)
+	out := new(ChangeFreezeDeferredChange)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterDeprovisionList) DeepCopyInto(out *ClusterDeprovisionList) {
+func (in *ChangeFreezeList) DeepCopyInto(out *ChangeFreezeList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]ClusterDeprovision, len(*in))
+		*out = make([]ChangeFreeze, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -852,18 +761,18 @@ func (in *ClusterDeprovisionList) DeepCopyInto(out *ClusterDeprovisionList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeprovisionList.
-func (in *ClusterDeprovisionList) DeepCopy() *ClusterDeprovisionList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChangeFreezeList.
+func (in *ChangeFreezeList) DeepCopy() *ChangeFreezeList {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterDeprovisionList)
+	out := new(ChangeFreezeList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterDeprovisionList) DeepCopyObject() runtime.Object {
+func (in *ChangeFreezeList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -871,113 +780,67 @@ func (in *ClusterDeprovisionList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterDeprovisionPlatform) DeepCopyInto(out *ClusterDeprovisionPlatform) {
-	*out = *in
-	if in.AWS != nil {
-		in, out := &in.AWS, &out.AWS
-		*out = new(AWSClusterDeprovision)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Azure != nil {
-		in, out := &in.Azure, &out.Azure
-		*out = new(AzureClusterDeprovision)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.GCP != nil {
-		in, out := &in.GCP, &out.GCP
-		*out = new(GCPClusterDeprovision)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.OpenStack != nil {
-		in, out := &in.OpenStack, &out.OpenStack
-		*out = new(OpenStackClusterDeprovision)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.VSphere != nil {
-		in, out := &in.VSphere, &out.VSphere
-		*out = new(VSphereClusterDeprovision)
-		**out = **in
-	}
-	if in.Ovirt != nil {
-		in, out := &in.Ovirt, &out.Ovirt
-		*out = new(OvirtClusterDeprovision)
-		**out = **in
-	}
-	return
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeprovisionPlatform.
-func (in *ClusterDeprovisionPlatform) DeepCopy() *ClusterDeprovisionPlatform {
-	if in == nil {
-		return nil
-	}
-	out := new(ClusterDeprovisionPlatform)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterDeprovisionSpec) DeepCopyInto(out *ClusterDeprovisionSpec) {
+func (in *ChangeFreezeSpec) DeepCopyInto(out *ChangeFreezeSpec) {
 	*out = *in
-	in.Platform.DeepCopyInto(&out.Platform)
+	in.ClusterDeploymentSelector.DeepCopyInto(&out.ClusterDeploymentSelector)
+	in.Start.DeepCopyInto(&out.Start)
+	in.End.DeepCopyInto(&out.End)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeprovisionSpec.
-func (in *ClusterDeprovisionSpec) DeepCopy() *ClusterDeprovisionSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChangeFreezeSpec.
+func (in *ChangeFreezeSpec) DeepCopy() *ChangeFreezeSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterDeprovisionSpec)
+	out := new(ChangeFreezeSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterDeprovisionStatus) DeepCopyInto(out *ClusterDeprovisionStatus) {
+func (in *ChangeFreezeStatus) DeepCopyInto(out *ChangeFreezeStatus) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]ClusterDeprovisionCondition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.DeferredChanges != nil {
+		in, out := &in.DeferredChanges, &out.DeferredChanges
+		*out = make([]ChangeFreezeDeferredChange, len(*in))
+		copy(*out, *in)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeprovisionStatus.
-func (in *ClusterDeprovisionStatus) DeepCopy() *ClusterDeprovisionStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChangeFreezeStatus.
+func (in *ChangeFreezeStatus) DeepCopy() *ChangeFreezeStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterDeprovisionStatus)
+	out := new(ChangeFreezeStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterImageSet) DeepCopyInto(out *ClusterImageSet) {
+func (in *Checkpoint) DeepCopyInto(out *Checkpoint) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	out.Status = in.Status
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterImageSet.
-func (in *ClusterImageSet) DeepCopy() *ClusterImageSet {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Checkpoint.
+func (in *Checkpoint) DeepCopy() *Checkpoint {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterImageSet)
+	out := new(Checkpoint)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterImageSet) DeepCopyObject() runtime.Object {
+func (in *Checkpoint) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -985,13 +848,13 @@ func (in *ClusterImageSet) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterImageSetList) DeepCopyInto(out *ClusterImageSetList) {
+func (in *CheckpointList) DeepCopyInto(out *CheckpointList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]ClusterImageSet, len(*in))
+		*out = make([]Checkpoint, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -999,18 +862,18 @@ func (in *ClusterImageSetList) DeepCopyInto(out *ClusterImageSetList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterImageSetList.
-func (in *ClusterImageSetList) DeepCopy() *ClusterImageSetList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CheckpointList.
+func (in *CheckpointList) DeepCopy() *CheckpointList {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterImageSetList)
+	out := new(CheckpointList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterImageSetList) DeepCopyObject() runtime.Object {
+func (in *CheckpointList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1018,122 +881,83 @@ func (in *ClusterImageSetList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterImageSetReference) DeepCopyInto(out *ClusterImageSetReference) {
+func (in *CheckpointSpec) DeepCopyInto(out *CheckpointSpec) {
 	*out = *in
+	in.LastBackupTime.DeepCopyInto(&out.LastBackupTime)
+	out.LastBackupRef = in.LastBackupRef
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterImageSetReference.
-func (in *ClusterImageSetReference) DeepCopy() *ClusterImageSetReference {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CheckpointSpec.
+func (in *CheckpointSpec) DeepCopy() *CheckpointSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterImageSetReference)
+	out := new(CheckpointSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterImageSetSpec) DeepCopyInto(out *ClusterImageSetSpec) {
+func (in *CheckpointStatus) DeepCopyInto(out *CheckpointStatus) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterImageSetSpec.
-func (in *ClusterImageSetSpec) DeepCopy() *ClusterImageSetSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CheckpointStatus.
+func (in *CheckpointStatus) DeepCopy() *CheckpointStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterImageSetSpec)
+	out := new(CheckpointStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterImageSetStatus) DeepCopyInto(out *ClusterImageSetStatus) {
+func (in *CloudAPICall) DeepCopyInto(out *CloudAPICall) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterImageSetStatus.
-func (in *ClusterImageSetStatus) DeepCopy() *ClusterImageSetStatus {
-	if in == nil {
-		return nil
-	}
-	out := new(ClusterImageSetStatus)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterIngress) DeepCopyInto(out *ClusterIngress) {
-	*out = *in
-	if in.NamespaceSelector != nil {
-		in, out := &in.NamespaceSelector, &out.NamespaceSelector
-		*out = new(metav1.LabelSelector)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.RouteSelector != nil {
-		in, out := &in.RouteSelector, &out.RouteSelector
-		*out = new(metav1.LabelSelector)
-		(*in).DeepCopyInto(*out)
-	}
-	return
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterIngress.
-func (in *ClusterIngress) DeepCopy() *ClusterIngress {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudAPICall.
+func (in *CloudAPICall) DeepCopy() *CloudAPICall {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterIngress)
+	out := new(CloudAPICall)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterMetadata) DeepCopyInto(out *ClusterMetadata) {
+func (in *ClusterActivityStatus) DeepCopyInto(out *ClusterActivityStatus) {
 	*out = *in
-	out.AdminKubeconfigSecretRef = in.AdminKubeconfigSecretRef
-	out.AdminPasswordSecretRef = in.AdminPasswordSecretRef
-	return
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterMetadata.
-func (in *ClusterMetadata) DeepCopy() *ClusterMetadata {
-	if in == nil {
-		return nil
+	if in.LastActivityTime != nil {
+		in, out := &in.LastActivityTime, &out.LastActivityTime
+		*out = (*in).DeepCopy()
 	}
-	out := new(ClusterMetadata)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterOperatorState) DeepCopyInto(out *ClusterOperatorState) {
-	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]configv1.ClusterOperatorStatusCondition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	in.LastCheckTime.DeepCopyInto(&out.LastCheckTime)
+	if in.AuditLogPositions != nil {
+		in, out := &in.AuditLogPositions, &out.AuditLogPositions
+		*out = make([]AuditLogPosition, len(*in))
+		copy(*out, *in)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterOperatorState.
-func (in *ClusterOperatorState) DeepCopy() *ClusterOperatorState {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterActivityStatus.
+func (in *ClusterActivityStatus) DeepCopy() *ClusterActivityStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterOperatorState)
+	out := new(ClusterActivityStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterPool) DeepCopyInto(out *ClusterPool) {
+func (in *ClusterClaim) DeepCopyInto(out *ClusterClaim) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -1142,18 +966,18 @@ func (in *ClusterPool) DeepCopyInto(out *ClusterPool) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPool.
-func (in *ClusterPool) DeepCopy() *ClusterPool {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterClaim.
+func (in *ClusterClaim) DeepCopy() *ClusterClaim {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterPool)
+	out := new(ClusterClaim)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterPool) DeepCopyObject() runtime.Object {
+func (in *ClusterClaim) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1161,57 +985,31 @@ func (in *ClusterPool) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterPoolClaimLifetime) DeepCopyInto(out *ClusterPoolClaimLifetime) {
-	*out = *in
-	if in.Default != nil {
-		in, out := &in.Default, &out.Default
-		*out = new(metav1.Duration)
-		**out = **in
-	}
-	if in.Maximum != nil {
-		in, out := &in.Maximum, &out.Maximum
-		*out = new(metav1.Duration)
-		**out = **in
-	}
-	return
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPoolClaimLifetime.
-func (in *ClusterPoolClaimLifetime) DeepCopy() *ClusterPoolClaimLifetime {
-	if in == nil {
-		return nil
-	}
-	out := new(ClusterPoolClaimLifetime)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterPoolCondition) DeepCopyInto(out *ClusterPoolCondition) {
+func (in *ClusterClaimCondition) DeepCopyInto(out *ClusterClaimCondition) {
 	*out = *in
 	in.LastProbeTime.DeepCopyInto(&out.LastProbeTime)
 	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPoolCondition.
-func (in *ClusterPoolCondition) DeepCopy() *ClusterPoolCondition {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterClaimCondition.
+func (in *ClusterClaimCondition) DeepCopy() *ClusterClaimCondition {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterPoolCondition)
+	out := new(ClusterClaimCondition)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterPoolList) DeepCopyInto(out *ClusterPoolList) {
+func (in *ClusterClaimList) DeepCopyInto(out *ClusterClaimList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]ClusterPool, len(*in))
+		*out = make([]ClusterClaim, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -1219,18 +1017,18 @@ func (in *ClusterPoolList) DeepCopyInto(out *ClusterPoolList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPoolList.
-func (in *ClusterPoolList) DeepCopy() *ClusterPoolList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterClaimList.
+func (in *ClusterClaimList) DeepCopy() *ClusterClaimList {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterPoolList)
+	out := new(ClusterClaimList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterPoolList) DeepCopyObject() runtime.Object {
+func (in *ClusterClaimList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1238,101 +1036,104 @@ func (in *ClusterPoolList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterPoolReference) DeepCopyInto(out *ClusterPoolReference) {
+func (in *ClusterClaimQuotaConfig) DeepCopyInto(out *ClusterClaimQuotaConfig) {
 	*out = *in
+	if in.MaxClaimsPerNamespace != nil {
+		in, out := &in.MaxClaimsPerNamespace, &out.MaxClaimsPerNamespace
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxClaimsPerRequester != nil {
+		in, out := &in.MaxClaimsPerRequester, &out.MaxClaimsPerRequester
+		*out = new(int32)
+		**out = **in
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPoolReference.
-func (in *ClusterPoolReference) DeepCopy() *ClusterPoolReference {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterClaimQuotaConfig.
+func (in *ClusterClaimQuotaConfig) DeepCopy() *ClusterClaimQuotaConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterPoolReference)
+	out := new(ClusterClaimQuotaConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterPoolSpec) DeepCopyInto(out *ClusterPoolSpec) {
+func (in *ClusterClaimSpec) DeepCopyInto(out *ClusterClaimSpec) {
 	*out = *in
-	in.Platform.DeepCopyInto(&out.Platform)
-	if in.PullSecretRef != nil {
-		in, out := &in.PullSecretRef, &out.PullSecretRef
-		*out = new(corev1.LocalObjectReference)
-		**out = **in
-	}
-	if in.MaxSize != nil {
-		in, out := &in.MaxSize, &out.MaxSize
-		*out = new(int32)
-		**out = **in
+	if in.Subjects != nil {
+		in, out := &in.Subjects, &out.Subjects
+		*out = make([]rbacv1.Subject, len(*in))
+		copy(*out, *in)
 	}
-	if in.MaxConcurrent != nil {
-		in, out := &in.MaxConcurrent, &out.MaxConcurrent
-		*out = new(int32)
+	if in.Lifetime != nil {
+		in, out := &in.Lifetime, &out.Lifetime
+		*out = new(metav1.Duration)
 		**out = **in
 	}
-	out.ImageSetRef = in.ImageSetRef
-	if in.Labels != nil {
-		in, out := &in.Labels, &out.Labels
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.InstallConfigSecretTemplateRef != nil {
-		in, out := &in.InstallConfigSecretTemplateRef, &out.InstallConfigSecretTemplateRef
-		*out = new(corev1.LocalObjectReference)
-		**out = **in
+	if in.ActivationTime != nil {
+		in, out := &in.ActivationTime, &out.ActivationTime
+		*out = (*in).DeepCopy()
 	}
-	if in.HibernateAfter != nil {
-		in, out := &in.HibernateAfter, &out.HibernateAfter
+	if in.IdleTimeout != nil {
+		in, out := &in.IdleTimeout, &out.IdleTimeout
 		*out = new(metav1.Duration)
 		**out = **in
 	}
-	if in.ClaimLifetime != nil {
-		in, out := &in.ClaimLifetime, &out.ClaimLifetime
-		*out = new(ClusterPoolClaimLifetime)
-		(*in).DeepCopyInto(*out)
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPoolSpec.
-func (in *ClusterPoolSpec) DeepCopy() *ClusterPoolSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterClaimSpec.
+func (in *ClusterClaimSpec) DeepCopy() *ClusterClaimSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterPoolSpec)
+	out := new(ClusterClaimSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterPoolStatus) DeepCopyInto(out *ClusterPoolStatus) {
+func (in *ClusterClaimStatus) DeepCopyInto(out *ClusterClaimStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]ClusterPoolCondition, len(*in))
+		*out = make([]ClusterClaimCondition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Lifetime != nil {
+		in, out := &in.Lifetime, &out.Lifetime
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.LastActivityTime != nil {
+		in, out := &in.LastActivityTime, &out.LastActivityTime
+		*out = (*in).DeepCopy()
+	}
+	if in.ExpirationTime != nil {
+		in, out := &in.ExpirationTime, &out.ExpirationTime
+		*out = (*in).DeepCopy()
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPoolStatus.
-func (in *ClusterPoolStatus) DeepCopy() *ClusterPoolStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterClaimStatus.
+func (in *ClusterClaimStatus) DeepCopy() *ClusterClaimStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterPoolStatus)
+	out := new(ClusterClaimStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterProvision) DeepCopyInto(out *ClusterProvision) {
+func (in *ClusterDeployment) DeepCopyInto(out *ClusterDeployment) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -1341,18 +1142,18 @@ func (in *ClusterProvision) DeepCopyInto(out *ClusterProvision) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterProvision.
-func (in *ClusterProvision) DeepCopy() *ClusterProvision {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeployment.
+func (in *ClusterDeployment) DeepCopy() *ClusterDeployment {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterProvision)
+	out := new(ClusterDeployment)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterProvision) DeepCopyObject() runtime.Object {
+func (in *ClusterDeployment) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1360,31 +1161,59 @@ func (in *ClusterProvision) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterProvisionCondition) DeepCopyInto(out *ClusterProvisionCondition) {
+func (in *ClusterDeploymentCondition) DeepCopyInto(out *ClusterDeploymentCondition) {
 	*out = *in
 	in.LastProbeTime.DeepCopyInto(&out.LastProbeTime)
 	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterProvisionCondition.
-func (in *ClusterProvisionCondition) DeepCopy() *ClusterProvisionCondition {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeploymentCondition.
+func (in *ClusterDeploymentCondition) DeepCopy() *ClusterDeploymentCondition {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterProvisionCondition)
+	out := new(ClusterDeploymentCondition)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterProvisionList) DeepCopyInto(out *ClusterProvisionList) {
+func (in *ClusterDeploymentCustomization) DeepCopyInto(out *ClusterDeploymentCustomization) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeploymentCustomization.
+func (in *ClusterDeploymentCustomization) DeepCopy() *ClusterDeploymentCustomization {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterDeploymentCustomization)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterDeploymentCustomization) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterDeploymentCustomizationList) DeepCopyInto(out *ClusterDeploymentCustomizationList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]ClusterProvision, len(*in))
+		*out = make([]ClusterDeploymentCustomization, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -1392,18 +1221,18 @@ func (in *ClusterProvisionList) DeepCopyInto(out *ClusterProvisionList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterProvisionList.
-func (in *ClusterProvisionList) DeepCopy() *ClusterProvisionList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeploymentCustomizationList.
+func (in *ClusterDeploymentCustomizationList) DeepCopy() *ClusterDeploymentCustomizationList {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterProvisionList)
+	out := new(ClusterDeploymentCustomizationList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterProvisionList) DeepCopyObject() runtime.Object {
+func (in *ClusterDeploymentCustomizationList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1411,421 +1240,2474 @@ func (in *ClusterProvisionList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterProvisionSpec) DeepCopyInto(out *ClusterProvisionSpec) {
+func (in *ClusterDeploymentCustomizationSpec) DeepCopyInto(out *ClusterDeploymentCustomizationSpec) {
 	*out = *in
-	out.ClusterDeploymentRef = in.ClusterDeploymentRef
-	in.PodSpec.DeepCopyInto(&out.PodSpec)
-	if in.ClusterID != nil {
-		in, out := &in.ClusterID, &out.ClusterID
-		*out = new(string)
-		**out = **in
+	if in.InstallConfigPatches != nil {
+		in, out := &in.InstallConfigPatches, &out.InstallConfigPatches
+		*out = make([]PatchEntity, len(*in))
+		copy(*out, *in)
 	}
-	if in.InfraID != nil {
-		in, out := &in.InfraID, &out.InfraID
-		*out = new(string)
-		**out = **in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeploymentCustomizationSpec.
+func (in *ClusterDeploymentCustomizationSpec) DeepCopy() *ClusterDeploymentCustomizationSpec {
+	if in == nil {
+		return nil
 	}
-	if in.InstallLog != nil {
-		in, out := &in.InstallLog, &out.InstallLog
-		*out = new(string)
+	out := new(ClusterDeploymentCustomizationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterDeploymentCustomizationStatus) DeepCopyInto(out *ClusterDeploymentCustomizationStatus) {
+	*out = *in
+	if in.ClusterPoolRef != nil {
+		in, out := &in.ClusterPoolRef, &out.ClusterPoolRef
+		*out = new(corev1.LocalObjectReference)
 		**out = **in
 	}
-	if in.Metadata != nil {
-		in, out := &in.Metadata, &out.Metadata
-		*out = new(runtime.RawExtension)
-		(*in).DeepCopyInto(*out)
+	if in.ClusterDeploymentRef != nil {
+		in, out := &in.ClusterDeploymentRef, &out.ClusterDeploymentRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
 	}
-	if in.AdminKubeconfigSecretRef != nil {
-		in, out := &in.AdminKubeconfigSecretRef, &out.AdminKubeconfigSecretRef
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeploymentCustomizationStatus.
+func (in *ClusterDeploymentCustomizationStatus) DeepCopy() *ClusterDeploymentCustomizationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterDeploymentCustomizationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterDeploymentList) DeepCopyInto(out *ClusterDeploymentList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterDeployment, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeploymentList.
+func (in *ClusterDeploymentList) DeepCopy() *ClusterDeploymentList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterDeploymentList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterDeploymentList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterDeploymentReadinessGate) DeepCopyInto(out *ClusterDeploymentReadinessGate) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeploymentReadinessGate.
+func (in *ClusterDeploymentReadinessGate) DeepCopy() *ClusterDeploymentReadinessGate {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterDeploymentReadinessGate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterDeploymentSpec) DeepCopyInto(out *ClusterDeploymentSpec) {
+	*out = *in
+	if in.BaseDomainPoolRef != nil {
+		in, out := &in.BaseDomainPoolRef, &out.BaseDomainPoolRef
+		*out = new(BaseDomainPoolReference)
+		**out = **in
+	}
+	in.Platform.DeepCopyInto(&out.Platform)
+	if in.PullSecretRef != nil {
+		in, out := &in.PullSecretRef, &out.PullSecretRef
 		*out = new(corev1.LocalObjectReference)
 		**out = **in
 	}
-	if in.AdminPasswordSecretRef != nil {
-		in, out := &in.AdminPasswordSecretRef, &out.AdminPasswordSecretRef
+	if in.ForceCleanup != nil {
+		in, out := &in.ForceCleanup, &out.ForceCleanup
+		*out = new(ForceCleanup)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExitBackup != nil {
+		in, out := &in.ExitBackup, &out.ExitBackup
+		*out = new(ExitBackup)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RestoreRef != nil {
+		in, out := &in.RestoreRef, &out.RestoreRef
+		*out = new(RestoreReference)
+		**out = **in
+	}
+	in.ControlPlaneConfig.DeepCopyInto(&out.ControlPlaneConfig)
+	if in.Ingress != nil {
+		in, out := &in.Ingress, &out.Ingress
+		*out = make([]ClusterIngress, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NodeTuning != nil {
+		in, out := &in.NodeTuning, &out.NodeTuning
+		*out = new(NodeTuning)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SyncAgent != nil {
+		in, out := &in.SyncAgent, &out.SyncAgent
+		*out = new(SyncAgent)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SelectorSyncSetExclusions != nil {
+		in, out := &in.SelectorSyncSetExclusions, &out.SelectorSyncSetExclusions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CertificateBundles != nil {
+		in, out := &in.CertificateBundles, &out.CertificateBundles
+		*out = make([]CertificateBundleSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ClusterMetadata != nil {
+		in, out := &in.ClusterMetadata, &out.ClusterMetadata
+		*out = new(ClusterMetadata)
+		**out = **in
+	}
+	if in.Provisioning != nil {
+		in, out := &in.Provisioning, &out.Provisioning
+		*out = new(Provisioning)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClusterPoolRef != nil {
+		in, out := &in.ClusterPoolRef, &out.ClusterPoolRef
+		*out = new(ClusterPoolReference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HibernateAfter != nil {
+		in, out := &in.HibernateAfter, &out.HibernateAfter
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.HibernateAfterIdle != nil {
+		in, out := &in.HibernateAfterIdle, &out.HibernateAfterIdle
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.PowerStateSchedule != nil {
+		in, out := &in.PowerStateSchedule, &out.PowerStateSchedule
+		*out = new(PowerStateSchedule)
+		**out = **in
+	}
+	if in.ResumeReadiness != nil {
+		in, out := &in.ResumeReadiness, &out.ResumeReadiness
+		*out = new(ResumeReadiness)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.InstallAttemptsLimit != nil {
+		in, out := &in.InstallAttemptsLimit, &out.InstallAttemptsLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ProvisionRetryPolicy != nil {
+		in, out := &in.ProvisionRetryPolicy, &out.ProvisionRetryPolicy
+		*out = new(ProvisionRetryPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MachineManagement != nil {
+		in, out := &in.MachineManagement, &out.MachineManagement
+		*out = new(MachineManagement)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BoundServiceAccountSignkingKeySecretRef != nil {
+		in, out := &in.BoundServiceAccountSignkingKeySecretRef, &out.BoundServiceAccountSignkingKeySecretRef
 		*out = new(corev1.LocalObjectReference)
 		**out = **in
 	}
-	if in.PrevClusterID != nil {
-		in, out := &in.PrevClusterID, &out.PrevClusterID
+	if in.ReadinessGates != nil {
+		in, out := &in.ReadinessGates, &out.ReadinessGates
+		*out = make([]ClusterDeploymentReadinessGate, len(*in))
+		copy(*out, *in)
+	}
+	if in.UnreachableRemediation != nil {
+		in, out := &in.UnreachableRemediation, &out.UnreachableRemediation
+		*out = new(UnreachableRemediation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Kubeadmin != nil {
+		in, out := &in.Kubeadmin, &out.Kubeadmin
+		*out = new(KubeadminManagement)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeploymentSpec.
+func (in *ClusterDeploymentSpec) DeepCopy() *ClusterDeploymentSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterDeploymentSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterDeploymentStatus) DeepCopyInto(out *ClusterDeploymentStatus) {
+	*out = *in
+	if in.InstallerImage != nil {
+		in, out := &in.InstallerImage, &out.InstallerImage
+		*out = new(string)
+		**out = **in
+	}
+	if in.InstallVersion != nil {
+		in, out := &in.InstallVersion, &out.InstallVersion
 		*out = new(string)
 		**out = **in
 	}
-	if in.PrevInfraID != nil {
-		in, out := &in.PrevInfraID, &out.PrevInfraID
-		*out = new(string)
+	if in.CLIImage != nil {
+		in, out := &in.CLIImage, &out.CLIImage
+		*out = new(string)
+		**out = **in
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]ClusterDeploymentCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CertificateBundles != nil {
+		in, out := &in.CertificateBundles, &out.CertificateBundles
+		*out = make([]CertificateBundleStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.InstallStartedTimestamp != nil {
+		in, out := &in.InstallStartedTimestamp, &out.InstallStartedTimestamp
+		*out = (*in).DeepCopy()
+	}
+	if in.InstalledTimestamp != nil {
+		in, out := &in.InstalledTimestamp, &out.InstalledTimestamp
+		*out = (*in).DeepCopy()
+	}
+	if in.ProvisionRef != nil {
+		in, out := &in.ProvisionRef, &out.ProvisionRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.InstallStrategy != nil {
+		in, out := &in.InstallStrategy, &out.InstallStrategy
+		*out = new(InstallStrategyStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Platform != nil {
+		in, out := &in.Platform, &out.Platform
+		*out = new(PlatformStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UnreachableRemediation != nil {
+		in, out := &in.UnreachableRemediation, &out.UnreachableRemediation
+		*out = new(UnreachableRemediationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Restore != nil {
+		in, out := &in.Restore, &out.Restore
+		*out = new(RestoreStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Activity != nil {
+		in, out := &in.Activity, &out.Activity
+		*out = new(ClusterActivityStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Hibernation != nil {
+		in, out := &in.Hibernation, &out.Hibernation
+		*out = new(ClusterHibernationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeploymentStatus.
+func (in *ClusterDeploymentStatus) DeepCopy() *ClusterDeploymentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterDeploymentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterDeprovision) DeepCopyInto(out *ClusterDeprovision) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeprovision.
+func (in *ClusterDeprovision) DeepCopy() *ClusterDeprovision {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterDeprovision)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterDeprovision) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterDeprovisionCondition) DeepCopyInto(out *ClusterDeprovisionCondition) {
+	*out = *in
+	in.LastProbeTime.DeepCopyInto(&out.LastProbeTime)
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeprovisionCondition.
+func (in *ClusterDeprovisionCondition) DeepCopy() *ClusterDeprovisionCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterDeprovisionCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterDeprovisionList) DeepCopyInto(out *ClusterDeprovisionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterDeprovision, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeprovisionList.
+func (in *ClusterDeprovisionList) DeepCopy() *ClusterDeprovisionList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterDeprovisionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterDeprovisionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterDeprovisionPlatform) DeepCopyInto(out *ClusterDeprovisionPlatform) {
+	*out = *in
+	if in.AWS != nil {
+		in, out := &in.AWS, &out.AWS
+		*out = new(AWSClusterDeprovision)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Azure != nil {
+		in, out := &in.Azure, &out.Azure
+		*out = new(AzureClusterDeprovision)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GCP != nil {
+		in, out := &in.GCP, &out.GCP
+		*out = new(GCPClusterDeprovision)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OpenStack != nil {
+		in, out := &in.OpenStack, &out.OpenStack
+		*out = new(OpenStackClusterDeprovision)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VSphere != nil {
+		in, out := &in.VSphere, &out.VSphere
+		*out = new(VSphereClusterDeprovision)
+		**out = **in
+	}
+	if in.Ovirt != nil {
+		in, out := &in.Ovirt, &out.Ovirt
+		*out = new(OvirtClusterDeprovision)
+		**out = **in
+	}
+	if in.OCI != nil {
+		in, out := &in.OCI, &out.OCI
+		*out = new(OCIClusterDeprovision)
+		**out = **in
+	}
+	if in.PowerVS != nil {
+		in, out := &in.PowerVS, &out.PowerVS
+		*out = new(PowerVSClusterDeprovision)
+		**out = **in
+	}
+	if in.None != nil {
+		in, out := &in.None, &out.None
+		*out = new(NoneClusterDeprovision)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeprovisionPlatform.
+func (in *ClusterDeprovisionPlatform) DeepCopy() *ClusterDeprovisionPlatform {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterDeprovisionPlatform)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterDeprovisionProgress) DeepCopyInto(out *ClusterDeprovisionProgress) {
+	*out = *in
+	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
+	if in.RemainingResources != nil {
+		in, out := &in.RemainingResources, &out.RemainingResources
+		*out = make([]DeprovisionResourceCount, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeprovisionProgress.
+func (in *ClusterDeprovisionProgress) DeepCopy() *ClusterDeprovisionProgress {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterDeprovisionProgress)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterDeprovisionSpec) DeepCopyInto(out *ClusterDeprovisionSpec) {
+	*out = *in
+	in.Platform.DeepCopyInto(&out.Platform)
+	if in.ExitBackup != nil {
+		in, out := &in.ExitBackup, &out.ExitBackup
+		*out = new(ExitBackup)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeprovisionSpec.
+func (in *ClusterDeprovisionSpec) DeepCopy() *ClusterDeprovisionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterDeprovisionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterDeprovisionStatus) DeepCopyInto(out *ClusterDeprovisionStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]ClusterDeprovisionCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LogSnapshotRef != nil {
+		in, out := &in.LogSnapshotRef, &out.LogSnapshotRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.Progress != nil {
+		in, out := &in.Progress, &out.Progress
+		*out = new(ClusterDeprovisionProgress)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExitBackup != nil {
+		in, out := &in.ExitBackup, &out.ExitBackup
+		*out = new(ExitBackupStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeprovisionStatus.
+func (in *ClusterDeprovisionStatus) DeepCopy() *ClusterDeprovisionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterDeprovisionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterHibernationStatus) DeepCopyInto(out *ClusterHibernationStatus) {
+	*out = *in
+	out.HibernatedTime = in.HibernatedTime
+	if in.LastAccountedTime != nil {
+		in, out := &in.LastAccountedTime, &out.LastAccountedTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterHibernationStatus.
+func (in *ClusterHibernationStatus) DeepCopy() *ClusterHibernationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterHibernationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterImageSet) DeepCopyInto(out *ClusterImageSet) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterImageSet.
+func (in *ClusterImageSet) DeepCopy() *ClusterImageSet {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterImageSet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterImageSet) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterImageSetCondition) DeepCopyInto(out *ClusterImageSetCondition) {
+	*out = *in
+	in.LastProbeTime.DeepCopyInto(&out.LastProbeTime)
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterImageSetCondition.
+func (in *ClusterImageSetCondition) DeepCopy() *ClusterImageSetCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterImageSetCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterImageSetList) DeepCopyInto(out *ClusterImageSetList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterImageSet, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterImageSetList.
+func (in *ClusterImageSetList) DeepCopy() *ClusterImageSetList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterImageSetList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterImageSetList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterImageSetReference) DeepCopyInto(out *ClusterImageSetReference) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterImageSetReference.
+func (in *ClusterImageSetReference) DeepCopy() *ClusterImageSetReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterImageSetReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterImageSetSpec) DeepCopyInto(out *ClusterImageSetSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterImageSetSpec.
+func (in *ClusterImageSetSpec) DeepCopy() *ClusterImageSetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterImageSetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterImageSetStatus) DeepCopyInto(out *ClusterImageSetStatus) {
+	*out = *in
+	if in.LastUsedTime != nil {
+		in, out := &in.LastUsedTime, &out.LastUsedTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]ClusterImageSetCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterImageSetStatus.
+func (in *ClusterImageSetStatus) DeepCopy() *ClusterImageSetStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterImageSetStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterIngress) DeepCopyInto(out *ClusterIngress) {
+	*out = *in
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RouteSelector != nil {
+		in, out := &in.RouteSelector, &out.RouteSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterIngress.
+func (in *ClusterIngress) DeepCopy() *ClusterIngress {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterIngress)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterInventory) DeepCopyInto(out *ClusterInventory) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterInventory.
+func (in *ClusterInventory) DeepCopy() *ClusterInventory {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterInventory)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterInventory) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterInventoryList) DeepCopyInto(out *ClusterInventoryList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterInventory, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterInventoryList.
+func (in *ClusterInventoryList) DeepCopy() *ClusterInventoryList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterInventoryList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterInventoryList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterInventoryOperator) DeepCopyInto(out *ClusterInventoryOperator) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterInventoryOperator.
+func (in *ClusterInventoryOperator) DeepCopy() *ClusterInventoryOperator {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterInventoryOperator)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterInventorySpec) DeepCopyInto(out *ClusterInventorySpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterInventorySpec.
+func (in *ClusterInventorySpec) DeepCopy() *ClusterInventorySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterInventorySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterInventoryStatus) DeepCopyInto(out *ClusterInventoryStatus) {
+	*out = *in
+	if in.LastUpdated != nil {
+		in, out := &in.LastUpdated, &out.LastUpdated
+		*out = (*in).DeepCopy()
+	}
+	if in.Operators != nil {
+		in, out := &in.Operators, &out.Operators
+		*out = make([]ClusterInventoryOperator, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterInventoryStatus.
+func (in *ClusterInventoryStatus) DeepCopy() *ClusterInventoryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterInventoryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterMetadata) DeepCopyInto(out *ClusterMetadata) {
+	*out = *in
+	out.AdminKubeconfigSecretRef = in.AdminKubeconfigSecretRef
+	out.AdminPasswordSecretRef = in.AdminPasswordSecretRef
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterMetadata.
+func (in *ClusterMetadata) DeepCopy() *ClusterMetadata {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterMetadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterOperatorState) DeepCopyInto(out *ClusterOperatorState) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]configv1.ClusterOperatorStatusCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterOperatorState.
+func (in *ClusterOperatorState) DeepCopy() *ClusterOperatorState {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterOperatorState)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPool) DeepCopyInto(out *ClusterPool) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPool.
+func (in *ClusterPool) DeepCopy() *ClusterPool {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPool)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterPool) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPoolClaimLifetime) DeepCopyInto(out *ClusterPoolClaimLifetime) {
+	*out = *in
+	if in.Default != nil {
+		in, out := &in.Default, &out.Default
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Maximum != nil {
+		in, out := &in.Maximum, &out.Maximum
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.IdleTimeout != nil {
+		in, out := &in.IdleTimeout, &out.IdleTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPoolClaimLifetime.
+func (in *ClusterPoolClaimLifetime) DeepCopy() *ClusterPoolClaimLifetime {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPoolClaimLifetime)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPoolCondition) DeepCopyInto(out *ClusterPoolCondition) {
+	*out = *in
+	in.LastProbeTime.DeepCopyInto(&out.LastProbeTime)
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPoolCondition.
+func (in *ClusterPoolCondition) DeepCopy() *ClusterPoolCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPoolCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPoolInstallFailure) DeepCopyInto(out *ClusterPoolInstallFailure) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPoolInstallFailure.
+func (in *ClusterPoolInstallFailure) DeepCopy() *ClusterPoolInstallFailure {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPoolInstallFailure)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPoolInstallFailureBudget) DeepCopyInto(out *ClusterPoolInstallFailureBudget) {
+	*out = *in
+	out.Window = in.Window
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPoolInstallFailureBudget.
+func (in *ClusterPoolInstallFailureBudget) DeepCopy() *ClusterPoolInstallFailureBudget {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPoolInstallFailureBudget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPoolList) DeepCopyInto(out *ClusterPoolList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterPool, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPoolList.
+func (in *ClusterPoolList) DeepCopy() *ClusterPoolList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPoolList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterPoolList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPoolPlatform) DeepCopyInto(out *ClusterPoolPlatform) {
+	*out = *in
+	if in.Weight != nil {
+		in, out := &in.Weight, &out.Weight
+		*out = new(int32)
+		**out = **in
+	}
+	in.Platform.DeepCopyInto(&out.Platform)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPoolPlatform.
+func (in *ClusterPoolPlatform) DeepCopy() *ClusterPoolPlatform {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPoolPlatform)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPoolReference) DeepCopyInto(out *ClusterPoolReference) {
+	*out = *in
+	if in.CustomizationRef != nil {
+		in, out := &in.CustomizationRef, &out.CustomizationRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPoolReference.
+func (in *ClusterPoolReference) DeepCopy() *ClusterPoolReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPoolReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPoolSanitization) DeepCopyInto(out *ClusterPoolSanitization) {
+	*out = *in
+	if in.PreservedNamespaces != nil {
+		in, out := &in.PreservedNamespaces, &out.PreservedNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Checks != nil {
+		in, out := &in.Checks, &out.Checks
+		*out = make([]ClusterSanitizationCheck, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPoolSanitization.
+func (in *ClusterPoolSanitization) DeepCopy() *ClusterPoolSanitization {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPoolSanitization)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPoolSpec) DeepCopyInto(out *ClusterPoolSpec) {
+	*out = *in
+	in.Platform.DeepCopyInto(&out.Platform)
+	if in.Platforms != nil {
+		in, out := &in.Platforms, &out.Platforms
+		*out = make([]ClusterPoolPlatform, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PullSecretRef != nil {
+		in, out := &in.PullSecretRef, &out.PullSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.MaxSize != nil {
+		in, out := &in.MaxSize, &out.MaxSize
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxConcurrent != nil {
+		in, out := &in.MaxConcurrent, &out.MaxConcurrent
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxClusterAge != nil {
+		in, out := &in.MaxClusterAge, &out.MaxClusterAge
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.BaseDomainPoolRef != nil {
+		in, out := &in.BaseDomainPoolRef, &out.BaseDomainPoolRef
+		*out = new(BaseDomainPoolReference)
+		**out = **in
+	}
+	out.ImageSetRef = in.ImageSetRef
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.InstallConfigSecretTemplateRef != nil {
+		in, out := &in.InstallConfigSecretTemplateRef, &out.InstallConfigSecretTemplateRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.HibernateAfter != nil {
+		in, out := &in.HibernateAfter, &out.HibernateAfter
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.ResumeReadiness != nil {
+		in, out := &in.ResumeReadiness, &out.ResumeReadiness
+		*out = new(ResumeReadiness)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClaimLifetime != nil {
+		in, out := &in.ClaimLifetime, &out.ClaimLifetime
+		*out = new(ClusterPoolClaimLifetime)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReadinessGates != nil {
+		in, out := &in.ReadinessGates, &out.ReadinessGates
+		*out = make([]ClusterDeploymentReadinessGate, len(*in))
+		copy(*out, *in)
+	}
+	if in.ReservationLeadTime != nil {
+		in, out := &in.ReservationLeadTime, &out.ReservationLeadTime
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.RebaselineSyncSets != nil {
+		in, out := &in.RebaselineSyncSets, &out.RebaselineSyncSets
+		*out = make([]corev1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.Sanitization != nil {
+		in, out := &in.Sanitization, &out.Sanitization
+		*out = new(ClusterPoolSanitization)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Inventory != nil {
+		in, out := &in.Inventory, &out.Inventory
+		*out = make([]InventoryEntry, len(*in))
+		copy(*out, *in)
+	}
+	if in.InstallFailureBudget != nil {
+		in, out := &in.InstallFailureBudget, &out.InstallFailureBudget
+		*out = new(ClusterPoolInstallFailureBudget)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPoolSpec.
+func (in *ClusterPoolSpec) DeepCopy() *ClusterPoolSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPoolSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPoolStatus) DeepCopyInto(out *ClusterPoolStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]ClusterPoolCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.InstallFailures != nil {
+		in, out := &in.InstallFailures, &out.InstallFailures
+		*out = make([]ClusterPoolInstallFailure, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPoolStatus.
+func (in *ClusterPoolStatus) DeepCopy() *ClusterPoolStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPoolStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterProvision) DeepCopyInto(out *ClusterProvision) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterProvision.
+func (in *ClusterProvision) DeepCopy() *ClusterProvision {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterProvision)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterProvision) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterProvisionArtifact) DeepCopyInto(out *ClusterProvisionArtifact) {
+	*out = *in
+	if in.ExpirationTime != nil {
+		in, out := &in.ExpirationTime, &out.ExpirationTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterProvisionArtifact.
+func (in *ClusterProvisionArtifact) DeepCopy() *ClusterProvisionArtifact {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterProvisionArtifact)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterProvisionCondition) DeepCopyInto(out *ClusterProvisionCondition) {
+	*out = *in
+	in.LastProbeTime.DeepCopyInto(&out.LastProbeTime)
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterProvisionCondition.
+func (in *ClusterProvisionCondition) DeepCopy() *ClusterProvisionCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterProvisionCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterProvisionList) DeepCopyInto(out *ClusterProvisionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterProvision, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterProvisionList.
+func (in *ClusterProvisionList) DeepCopy() *ClusterProvisionList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterProvisionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterProvisionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterProvisionMustGather) DeepCopyInto(out *ClusterProvisionMustGather) {
+	*out = *in
+	out.JobRef = in.JobRef
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterProvisionMustGather.
+func (in *ClusterProvisionMustGather) DeepCopy() *ClusterProvisionMustGather {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterProvisionMustGather)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterProvisionSpec) DeepCopyInto(out *ClusterProvisionSpec) {
+	*out = *in
+	out.ClusterDeploymentRef = in.ClusterDeploymentRef
+	in.PodSpec.DeepCopyInto(&out.PodSpec)
+	if in.ClusterID != nil {
+		in, out := &in.ClusterID, &out.ClusterID
+		*out = new(string)
+		**out = **in
+	}
+	if in.InfraID != nil {
+		in, out := &in.InfraID, &out.InfraID
+		*out = new(string)
+		**out = **in
+	}
+	if in.InstallLog != nil {
+		in, out := &in.InstallLog, &out.InstallLog
+		*out = new(string)
+		**out = **in
+	}
+	if in.Metadata != nil {
+		in, out := &in.Metadata, &out.Metadata
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AdminKubeconfigSecretRef != nil {
+		in, out := &in.AdminKubeconfigSecretRef, &out.AdminKubeconfigSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.AdminPasswordSecretRef != nil {
+		in, out := &in.AdminPasswordSecretRef, &out.AdminPasswordSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.PrevClusterID != nil {
+		in, out := &in.PrevClusterID, &out.PrevClusterID
+		*out = new(string)
+		**out = **in
+	}
+	if in.PrevInfraID != nil {
+		in, out := &in.PrevInfraID, &out.PrevInfraID
+		*out = new(string)
+		**out = **in
+	}
+	if in.InstallTimeout != nil {
+		in, out := &in.InstallTimeout, &out.InstallTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterProvisionSpec.
+func (in *ClusterProvisionSpec) DeepCopy() *ClusterProvisionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterProvisionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterProvisionStageTimestamp) DeepCopyInto(out *ClusterProvisionStageTimestamp) {
+	*out = *in
+	in.EnteredTime.DeepCopyInto(&out.EnteredTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterProvisionStageTimestamp.
+func (in *ClusterProvisionStageTimestamp) DeepCopy() *ClusterProvisionStageTimestamp {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterProvisionStageTimestamp)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterProvisionStatus) DeepCopyInto(out *ClusterProvisionStatus) {
+	*out = *in
+	if in.JobRef != nil {
+		in, out := &in.JobRef, &out.JobRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]ClusterProvisionCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Artifacts != nil {
+		in, out := &in.Artifacts, &out.Artifacts
+		*out = make([]ClusterProvisionArtifact, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.StageTimestamps != nil {
+		in, out := &in.StageTimestamps, &out.StageTimestamps
+		*out = make([]ClusterProvisionStageTimestamp, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.InstallPodStartedTime != nil {
+		in, out := &in.InstallPodStartedTime, &out.InstallPodStartedTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LogSnapshotRef != nil {
+		in, out := &in.LogSnapshotRef, &out.LogSnapshotRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.CloudAPICallsRef != nil {
+		in, out := &in.CloudAPICallsRef, &out.CloudAPICallsRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.MustGather != nil {
+		in, out := &in.MustGather, &out.MustGather
+		*out = new(ClusterProvisionMustGather)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterProvisionStatus.
+func (in *ClusterProvisionStatus) DeepCopy() *ClusterProvisionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterProvisionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterRelocate) DeepCopyInto(out *ClusterRelocate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRelocate.
+func (in *ClusterRelocate) DeepCopy() *ClusterRelocate {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterRelocate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterRelocate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterRelocateList) DeepCopyInto(out *ClusterRelocateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterRelocate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRelocateList.
+func (in *ClusterRelocateList) DeepCopy() *ClusterRelocateList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterRelocateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterRelocateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterRelocateSpec) DeepCopyInto(out *ClusterRelocateSpec) {
+	*out = *in
+	out.KubeconfigSecretRef = in.KubeconfigSecretRef
+	in.ClusterDeploymentSelector.DeepCopyInto(&out.ClusterDeploymentSelector)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRelocateSpec.
+func (in *ClusterRelocateSpec) DeepCopy() *ClusterRelocateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterRelocateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterRelocateStatus) DeepCopyInto(out *ClusterRelocateStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRelocateStatus.
+func (in *ClusterRelocateStatus) DeepCopy() *ClusterRelocateStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterRelocateStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterState) DeepCopyInto(out *ClusterState) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterState.
+func (in *ClusterState) DeepCopy() *ClusterState {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterState)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterState) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterStateList) DeepCopyInto(out *ClusterStateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterState, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterStateList.
+func (in *ClusterStateList) DeepCopy() *ClusterStateList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterStateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterStateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterStateSpec) DeepCopyInto(out *ClusterStateSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterStateSpec.
+func (in *ClusterStateSpec) DeepCopy() *ClusterStateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterStateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterStateStatus) DeepCopyInto(out *ClusterStateStatus) {
+	*out = *in
+	if in.LastUpdated != nil {
+		in, out := &in.LastUpdated, &out.LastUpdated
+		*out = (*in).DeepCopy()
+	}
+	if in.ClusterOperators != nil {
+		in, out := &in.ClusterOperators, &out.ClusterOperators
+		*out = make([]ClusterOperatorState, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]NodeOSState, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterStateStatus.
+func (in *ClusterStateStatus) DeepCopy() *ClusterStateStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterStateStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigMapResourceSource) DeepCopyInto(out *ConfigMapResourceSource) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigMapResourceSource.
+func (in *ConfigMapResourceSource) DeepCopy() *ConfigMapResourceSource {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigMapResourceSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControlPlaneAdditionalCertificate) DeepCopyInto(out *ControlPlaneAdditionalCertificate) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneAdditionalCertificate.
+func (in *ControlPlaneAdditionalCertificate) DeepCopy() *ControlPlaneAdditionalCertificate {
+	if in == nil {
+		return nil
+	}
+	out := new(ControlPlaneAdditionalCertificate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControlPlaneConfigSpec) DeepCopyInto(out *ControlPlaneConfigSpec) {
+	*out = *in
+	in.ServingCertificates.DeepCopyInto(&out.ServingCertificates)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneConfigSpec.
+func (in *ControlPlaneConfigSpec) DeepCopy() *ControlPlaneConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ControlPlaneConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControlPlaneServingCertificateSpec) DeepCopyInto(out *ControlPlaneServingCertificateSpec) {
+	*out = *in
+	if in.Additional != nil {
+		in, out := &in.Additional, &out.Additional
+		*out = make([]ControlPlaneAdditionalCertificate, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneServingCertificateSpec.
+func (in *ControlPlaneServingCertificateSpec) DeepCopy() *ControlPlaneServingCertificateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ControlPlaneServingCertificateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControllerConfig) DeepCopyInto(out *ControllerConfig) {
+	*out = *in
+	if in.ConcurrentReconciles != nil {
+		in, out := &in.ConcurrentReconciles, &out.ConcurrentReconciles
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ClientQPS != nil {
+		in, out := &in.ClientQPS, &out.ClientQPS
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ClientBurst != nil {
+		in, out := &in.ClientBurst, &out.ClientBurst
+		*out = new(int32)
+		**out = **in
+	}
+	if in.QueueQPS != nil {
+		in, out := &in.QueueQPS, &out.QueueQPS
+		*out = new(int32)
+		**out = **in
+	}
+	if in.QueueBurst != nil {
+		in, out := &in.QueueBurst, &out.QueueBurst
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllerConfig.
+func (in *ControllerConfig) DeepCopy() *ControllerConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ControllerConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in ControllerNames) DeepCopyInto(out *ControllerNames) {
+	{
+		in := &in
+		*out = make(ControllerNames, len(*in))
+		copy(*out, *in)
+		return
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllerNames.
+func (in ControllerNames) DeepCopy() ControllerNames {
+	if in == nil {
+		return nil
+	}
+	out := new(ControllerNames)
+	in.DeepCopyInto(out)
+	return *out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControllersConfig) DeepCopyInto(out *ControllersConfig) {
+	*out = *in
+	if in.Default != nil {
+		in, out := &in.Default, &out.Default
+		*out = new(ControllerConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Controllers != nil {
+		in, out := &in.Controllers, &out.Controllers
+		*out = make([]SpecificControllerConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllersConfig.
+func (in *ControllersConfig) DeepCopy() *ControllersConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ControllersConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSPropagationConfig) DeepCopyInto(out *DNSPropagationConfig) {
+	*out = *in
+	if in.Resolvers != nil {
+		in, out := &in.Resolvers, &out.Resolvers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.QueryTimeout != nil {
+		in, out := &in.QueryTimeout, &out.QueryTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.MaxCheckInterval != nil {
+		in, out := &in.MaxCheckInterval, &out.MaxCheckInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSPropagationConfig.
+func (in *DNSPropagationConfig) DeepCopy() *DNSPropagationConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSPropagationConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSZone) DeepCopyInto(out *DNSZone) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSZone.
+func (in *DNSZone) DeepCopy() *DNSZone {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSZone)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DNSZone) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSZoneCondition) DeepCopyInto(out *DNSZoneCondition) {
+	*out = *in
+	in.LastProbeTime.DeepCopyInto(&out.LastProbeTime)
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSZoneCondition.
+func (in *DNSZoneCondition) DeepCopy() *DNSZoneCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSZoneCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSZoneList) DeepCopyInto(out *DNSZoneList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DNSZone, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSZoneList.
+func (in *DNSZoneList) DeepCopy() *DNSZoneList {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSZoneList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DNSZoneList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSZoneSpec) DeepCopyInto(out *DNSZoneSpec) {
+	*out = *in
+	if in.AWS != nil {
+		in, out := &in.AWS, &out.AWS
+		*out = new(AWSDNSZoneSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GCP != nil {
+		in, out := &in.GCP, &out.GCP
+		*out = new(GCPDNSZoneSpec)
+		**out = **in
+	}
+	if in.Azure != nil {
+		in, out := &in.Azure, &out.Azure
+		*out = new(AzureDNSZoneSpec)
+		**out = **in
+	}
+	if in.OCI != nil {
+		in, out := &in.OCI, &out.OCI
+		*out = new(OCIDNSZoneSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSZoneSpec.
+func (in *DNSZoneSpec) DeepCopy() *DNSZoneSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSZoneSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSZoneStatus) DeepCopyInto(out *DNSZoneStatus) {
+	*out = *in
+	if in.LastSyncTimestamp != nil {
+		in, out := &in.LastSyncTimestamp, &out.LastSyncTimestamp
+		*out = (*in).DeepCopy()
+	}
+	if in.NameServers != nil {
+		in, out := &in.NameServers, &out.NameServers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AWS != nil {
+		in, out := &in.AWS, &out.AWS
+		*out = new(AWSDNSZoneStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GCP != nil {
+		in, out := &in.GCP, &out.GCP
+		*out = new(GCPDNSZoneStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Azure != nil {
+		in, out := &in.Azure, &out.Azure
+		*out = new(AzureDNSZoneStatus)
+		**out = **in
+	}
+	if in.OCI != nil {
+		in, out := &in.OCI, &out.OCI
+		*out = new(OCIDNSZoneStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]DNSZoneCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSZoneStatus.
+func (in *DNSZoneStatus) DeepCopy() *DNSZoneStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSZoneStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeprovisionResourceCount) DeepCopyInto(out *DeprovisionResourceCount) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeprovisionResourceCount.
+func (in *DeprovisionResourceCount) DeepCopy() *DeprovisionResourceCount {
+	if in == nil {
+		return nil
+	}
+	out := new(DeprovisionResourceCount)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExitBackup) DeepCopyInto(out *ExitBackup) {
+	*out = *in
+	if in.SnapshotVolumes != nil {
+		in, out := &in.SnapshotVolumes, &out.SnapshotVolumes
+		*out = new(bool)
+		**out = **in
+	}
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
 		**out = **in
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterProvisionSpec.
-func (in *ClusterProvisionSpec) DeepCopy() *ClusterProvisionSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExitBackup.
+func (in *ExitBackup) DeepCopy() *ExitBackup {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterProvisionSpec)
+	out := new(ExitBackup)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterProvisionStatus) DeepCopyInto(out *ClusterProvisionStatus) {
+func (in *ExitBackupStatus) DeepCopyInto(out *ExitBackupStatus) {
 	*out = *in
-	if in.JobRef != nil {
-		in, out := &in.JobRef, &out.JobRef
-		*out = new(corev1.LocalObjectReference)
-		**out = **in
-	}
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]ClusterProvisionCondition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterProvisionStatus.
-func (in *ClusterProvisionStatus) DeepCopy() *ClusterProvisionStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExitBackupStatus.
+func (in *ExitBackupStatus) DeepCopy() *ExitBackupStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterProvisionStatus)
+	out := new(ExitBackupStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterRelocate) DeepCopyInto(out *ClusterRelocate) {
+func (in *FailedProvisionAWSConfig) DeepCopyInto(out *FailedProvisionAWSConfig) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	out.CredentialsSecretRef = in.CredentialsSecretRef
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRelocate.
-func (in *ClusterRelocate) DeepCopy() *ClusterRelocate {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailedProvisionAWSConfig.
+func (in *FailedProvisionAWSConfig) DeepCopy() *FailedProvisionAWSConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterRelocate)
+	out := new(FailedProvisionAWSConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterRelocate) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterRelocateList) DeepCopyInto(out *ClusterRelocateList) {
+func (in *FailedProvisionConfig) DeepCopyInto(out *FailedProvisionConfig) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]ClusterRelocate, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.AWS != nil {
+		in, out := &in.AWS, &out.AWS
+		*out = new(FailedProvisionAWSConfig)
+		**out = **in
+	}
+	if in.InstallLogRegexesConfigMapRefs != nil {
+		in, out := &in.InstallLogRegexesConfigMapRefs, &out.InstallLogRegexesConfigMapRefs
+		*out = make([]corev1.LocalObjectReference, len(*in))
+		copy(*out, *in)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRelocateList.
-func (in *ClusterRelocateList) DeepCopy() *ClusterRelocateList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailedProvisionConfig.
+func (in *FailedProvisionConfig) DeepCopy() *FailedProvisionConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterRelocateList)
+	out := new(FailedProvisionConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterRelocateList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterRelocateSpec) DeepCopyInto(out *ClusterRelocateSpec) {
+func (in *FeatureGateSelection) DeepCopyInto(out *FeatureGateSelection) {
 	*out = *in
-	out.KubeconfigSecretRef = in.KubeconfigSecretRef
-	in.ClusterDeploymentSelector.DeepCopyInto(&out.ClusterDeploymentSelector)
+	if in.Custom != nil {
+		in, out := &in.Custom, &out.Custom
+		*out = new(FeatureGatesEnabled)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRelocateSpec.
-func (in *ClusterRelocateSpec) DeepCopy() *ClusterRelocateSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FeatureGateSelection.
+func (in *FeatureGateSelection) DeepCopy() *FeatureGateSelection {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterRelocateSpec)
+	out := new(FeatureGateSelection)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterRelocateStatus) DeepCopyInto(out *ClusterRelocateStatus) {
+func (in *FeatureGatesEnabled) DeepCopyInto(out *FeatureGatesEnabled) {
 	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRelocateStatus.
-func (in *ClusterRelocateStatus) DeepCopy() *ClusterRelocateStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FeatureGatesEnabled.
+func (in *FeatureGatesEnabled) DeepCopy() *FeatureGatesEnabled {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterRelocateStatus)
+	out := new(FeatureGatesEnabled)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterState) DeepCopyInto(out *ClusterState) {
+func (in *FeatureSetConfig) DeepCopyInto(out *FeatureSetConfig) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
-	in.Status.DeepCopyInto(&out.Status)
+	if in.FeatureGates != nil {
+		in, out := &in.FeatureGates, &out.FeatureGates
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterState.
-func (in *ClusterState) DeepCopy() *ClusterState {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FeatureSetConfig.
+func (in *FeatureSetConfig) DeepCopy() *FeatureSetConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterState)
+	out := new(FeatureSetConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterState) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterStateList) DeepCopyInto(out *ClusterStateList) {
+func (in *ForceCleanup) DeepCopyInto(out *ForceCleanup) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]ClusterState, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterStateList.
-func (in *ClusterStateList) DeepCopy() *ClusterStateList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ForceCleanup.
+func (in *ForceCleanup) DeepCopy() *ForceCleanup {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterStateList)
+	out := new(ForceCleanup)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterStateList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPClusterDeprovision) DeepCopyInto(out *GCPClusterDeprovision) {
+	*out = *in
+	if in.CredentialsSecretRef != nil {
+		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
 	}
-	return nil
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPClusterDeprovision.
+func (in *GCPClusterDeprovision) DeepCopy() *GCPClusterDeprovision {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPClusterDeprovision)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterStateSpec) DeepCopyInto(out *ClusterStateSpec) {
+func (in *GCPDNSZoneSpec) DeepCopyInto(out *GCPDNSZoneSpec) {
 	*out = *in
+	out.CredentialsSecretRef = in.CredentialsSecretRef
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterStateSpec.
-func (in *ClusterStateSpec) DeepCopy() *ClusterStateSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPDNSZoneSpec.
+func (in *GCPDNSZoneSpec) DeepCopy() *GCPDNSZoneSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterStateSpec)
+	out := new(GCPDNSZoneSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterStateStatus) DeepCopyInto(out *ClusterStateStatus) {
+func (in *GCPDNSZoneStatus) DeepCopyInto(out *GCPDNSZoneStatus) {
 	*out = *in
-	if in.LastUpdated != nil {
-		in, out := &in.LastUpdated, &out.LastUpdated
-		*out = (*in).DeepCopy()
-	}
-	if in.ClusterOperators != nil {
-		in, out := &in.ClusterOperators, &out.ClusterOperators
-		*out = make([]ClusterOperatorState, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.ZoneName != nil {
+		in, out := &in.ZoneName, &out.ZoneName
+		*out = new(string)
+		**out = **in
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterStateStatus.
-func (in *ClusterStateStatus) DeepCopy() *ClusterStateStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPDNSZoneStatus.
+func (in *GCPDNSZoneStatus) DeepCopy() *GCPDNSZoneStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterStateStatus)
+	out := new(GCPDNSZoneStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ControlPlaneAdditionalCertificate) DeepCopyInto(out *ControlPlaneAdditionalCertificate) {
+func (in *GitReference) DeepCopyInto(out *GitReference) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneAdditionalCertificate.
-func (in *ControlPlaneAdditionalCertificate) DeepCopy() *ControlPlaneAdditionalCertificate {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitReference.
+func (in *GitReference) DeepCopy() *GitReference {
 	if in == nil {
 		return nil
 	}
-	out := new(ControlPlaneAdditionalCertificate)
+	out := new(GitReference)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ControlPlaneConfigSpec) DeepCopyInto(out *ControlPlaneConfigSpec) {
+func (in *GitSyncSource) DeepCopyInto(out *GitSyncSource) {
 	*out = *in
-	in.ServingCertificates.DeepCopyInto(&out.ServingCertificates)
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneConfigSpec.
-func (in *ControlPlaneConfigSpec) DeepCopy() *ControlPlaneConfigSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitSyncSource.
+func (in *GitSyncSource) DeepCopy() *GitSyncSource {
 	if in == nil {
 		return nil
 	}
-	out := new(ControlPlaneConfigSpec)
+	out := new(GitSyncSource)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GitSyncSource) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ControlPlaneServingCertificateSpec) DeepCopyInto(out *ControlPlaneServingCertificateSpec) {
+func (in *GitSyncSourceCondition) DeepCopyInto(out *GitSyncSourceCondition) {
 	*out = *in
-	if in.Additional != nil {
-		in, out := &in.Additional, &out.Additional
-		*out = make([]ControlPlaneAdditionalCertificate, len(*in))
-		copy(*out, *in)
-	}
+	in.LastProbeTime.DeepCopyInto(&out.LastProbeTime)
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneServingCertificateSpec.
-func (in *ControlPlaneServingCertificateSpec) DeepCopy() *ControlPlaneServingCertificateSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitSyncSourceCondition.
+func (in *GitSyncSourceCondition) DeepCopy() *GitSyncSourceCondition {
 	if in == nil {
 		return nil
 	}
-	out := new(ControlPlaneServingCertificateSpec)
+	out := new(GitSyncSourceCondition)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ControllerConfig) DeepCopyInto(out *ControllerConfig) {
+func (in *GitSyncSourceList) DeepCopyInto(out *GitSyncSourceList) {
 	*out = *in
-	if in.ConcurrentReconciles != nil {
-		in, out := &in.ConcurrentReconciles, &out.ConcurrentReconciles
-		*out = new(int32)
-		**out = **in
-	}
-	if in.ClientQPS != nil {
-		in, out := &in.ClientQPS, &out.ClientQPS
-		*out = new(int32)
-		**out = **in
-	}
-	if in.ClientBurst != nil {
-		in, out := &in.ClientBurst, &out.ClientBurst
-		*out = new(int32)
-		**out = **in
-	}
-	if in.QueueQPS != nil {
-		in, out := &in.QueueQPS, &out.QueueQPS
-		*out = new(int32)
-		**out = **in
-	}
-	if in.QueueBurst != nil {
-		in, out := &in.QueueBurst, &out.QueueBurst
-		*out = new(int32)
-		**out = **in
-	}
-	if in.Replicas != nil {
-		in, out := &in.Replicas, &out.Replicas
-		*out = new(int32)
-		**out = **in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GitSyncSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllerConfig.
-func (in *ControllerConfig) DeepCopy() *ControllerConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitSyncSourceList.
+func (in *GitSyncSourceList) DeepCopy() *GitSyncSourceList {
 	if in == nil {
 		return nil
 	}
-	out := new(ControllerConfig)
+	out := new(GitSyncSourceList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GitSyncSourceList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in ControllerNames) DeepCopyInto(out *ControllerNames) {
-	{
-		in := &in
-		*out = make(ControllerNames, len(*in))
-		copy(*out, *in)
-		return
+func (in *GitSyncSourceSpec) DeepCopyInto(out *GitSyncSourceSpec) {
+	*out = *in
+	out.Ref = in.Ref
+	if in.CredentialsSecretRef != nil {
+		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
 	}
+	if in.PollInterval != nil {
+		in, out := &in.PollInterval, &out.PollInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllerNames.
-func (in ControllerNames) DeepCopy() ControllerNames {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitSyncSourceSpec.
+func (in *GitSyncSourceSpec) DeepCopy() *GitSyncSourceSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ControllerNames)
+	out := new(GitSyncSourceSpec)
 	in.DeepCopyInto(out)
-	return *out
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ControllersConfig) DeepCopyInto(out *ControllersConfig) {
+func (in *GitSyncSourceStatus) DeepCopyInto(out *GitSyncSourceStatus) {
 	*out = *in
-	if in.Default != nil {
-		in, out := &in.Default, &out.Default
-		*out = new(ControllerConfig)
-		(*in).DeepCopyInto(*out)
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
 	}
-	if in.Controllers != nil {
-		in, out := &in.Controllers, &out.Controllers
-		*out = make([]SpecificControllerConfig, len(*in))
+	if in.SelectorSyncSets != nil {
+		in, out := &in.SelectorSyncSets, &out.SelectorSyncSets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]GitSyncSourceCondition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -1833,18 +3715,18 @@ func (in *ControllersConfig) DeepCopyInto(out *ControllersConfig) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllersConfig.
-func (in *ControllersConfig) DeepCopy() *ControllersConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitSyncSourceStatus.
+func (in *GitSyncSourceStatus) DeepCopy() *GitSyncSourceStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ControllersConfig)
+	out := new(GitSyncSourceStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DNSZone) DeepCopyInto(out *DNSZone) {
+func (in *HiveConfig) DeepCopyInto(out *HiveConfig) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -1853,18 +3735,18 @@ func (in *DNSZone) DeepCopyInto(out *DNSZone) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSZone.
-func (in *DNSZone) DeepCopy() *DNSZone {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HiveConfig.
+func (in *HiveConfig) DeepCopy() *HiveConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(DNSZone)
+	out := new(HiveConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DNSZone) DeepCopyObject() runtime.Object {
+func (in *HiveConfig) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1872,31 +3754,13 @@ func (in *DNSZone) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DNSZoneCondition) DeepCopyInto(out *DNSZoneCondition) {
-	*out = *in
-	in.LastProbeTime.DeepCopyInto(&out.LastProbeTime)
-	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
-	return
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSZoneCondition.
-func (in *DNSZoneCondition) DeepCopy() *DNSZoneCondition {
-	if in == nil {
-		return nil
-	}
-	out := new(DNSZoneCondition)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DNSZoneList) DeepCopyInto(out *DNSZoneList) {
+func (in *HiveConfigList) DeepCopyInto(out *HiveConfigList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]DNSZone, len(*in))
+		*out = make([]HiveConfig, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -1904,18 +3768,18 @@ func (in *DNSZoneList) DeepCopyInto(out *DNSZoneList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSZoneList.
-func (in *DNSZoneList) DeepCopy() *DNSZoneList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HiveConfigList.
+func (in *HiveConfigList) DeepCopy() *HiveConfigList {
 	if in == nil {
 		return nil
 	}
-	out := new(DNSZoneList)
+	out := new(HiveConfigList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DNSZoneList) DeepCopyObject() runtime.Object {
+func (in *HiveConfigList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1923,66 +3787,245 @@ func (in *DNSZoneList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DNSZoneSpec) DeepCopyInto(out *DNSZoneSpec) {
+func (in *HiveConfigSpec) DeepCopyInto(out *HiveConfigSpec) {
 	*out = *in
-	if in.AWS != nil {
-		in, out := &in.AWS, &out.AWS
-		*out = new(AWSDNSZoneSpec)
+	if in.ManagedDomains != nil {
+		in, out := &in.ManagedDomains, &out.ManagedDomains
+		*out = make([]ManageDNSConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DNSPropagation != nil {
+		in, out := &in.DNSPropagation, &out.DNSPropagation
+		*out = new(DNSPropagationConfig)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.GCP != nil {
-		in, out := &in.GCP, &out.GCP
-		*out = new(GCPDNSZoneSpec)
+	if in.AdditionalCertificateAuthoritiesSecretRef != nil {
+		in, out := &in.AdditionalCertificateAuthoritiesSecretRef, &out.AdditionalCertificateAuthoritiesSecretRef
+		*out = make([]corev1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.AdditionalCertificateAuthoritiesSecretRefs != nil {
+		in, out := &in.AdditionalCertificateAuthoritiesSecretRefs, &out.AdditionalCertificateAuthoritiesSecretRefs
+		*out = make([]corev1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.GlobalPullSecretRef != nil {
+		in, out := &in.GlobalPullSecretRef, &out.GlobalPullSecretRef
+		*out = new(corev1.LocalObjectReference)
 		**out = **in
 	}
-	if in.Azure != nil {
-		in, out := &in.Azure, &out.Azure
-		*out = new(AzureDNSZoneSpec)
+	if in.RemoteAccessRBAC != nil {
+		in, out := &in.RemoteAccessRBAC, &out.RemoteAccessRBAC
+		*out = new(RemoteAccessRBACConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Backup.DeepCopyInto(&out.Backup)
+	in.FailedProvisionConfig.DeepCopyInto(&out.FailedProvisionConfig)
+	if in.ProvisionSLA != nil {
+		in, out := &in.ProvisionSLA, &out.ProvisionSLA
+		*out = new(ProvisionSLAConfig)
+		**out = **in
+	}
+	if in.InstallTimeouts != nil {
+		in, out := &in.InstallTimeouts, &out.InstallTimeouts
+		*out = new(InstallTimeoutsConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StageTimeouts != nil {
+		in, out := &in.StageTimeouts, &out.StageTimeouts
+		*out = new(ProvisionStageTimeoutsConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.InstallPodStuckRemediation != nil {
+		in, out := &in.InstallPodStuckRemediation, &out.InstallPodStuckRemediation
+		*out = new(InstallPodStuckRemediationConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodLogSnapshots != nil {
+		in, out := &in.PodLogSnapshots, &out.PodLogSnapshots
+		*out = new(PodLogSnapshotsConfig)
+		**out = **in
+	}
+	if in.InstallLogStreaming != nil {
+		in, out := &in.InstallLogStreaming, &out.InstallLogStreaming
+		*out = new(InstallLogStreamingConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.InstallArtifacts != nil {
+		in, out := &in.InstallArtifacts, &out.InstallArtifacts
+		*out = new(InstallArtifactsConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.InstallJobSpread != nil {
+		in, out := &in.InstallJobSpread, &out.InstallJobSpread
+		*out = new(InstallJobSpreadConfig)
+		**out = **in
+	}
+	if in.ClusterPoolMaxConcurrent != nil {
+		in, out := &in.ClusterPoolMaxConcurrent, &out.ClusterPoolMaxConcurrent
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ClusterClaimQuota != nil {
+		in, out := &in.ClusterClaimQuota, &out.ClusterClaimQuota
+		*out = new(ClusterClaimQuotaConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.InstallEgressPolicy != nil {
+		in, out := &in.InstallEgressPolicy, &out.InstallEgressPolicy
+		*out = new(InstallEgressPolicyConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CMDBExport != nil {
+		in, out := &in.CMDBExport, &out.CMDBExport
+		*out = new(CMDBExportConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SnapshotExport != nil {
+		in, out := &in.SnapshotExport, &out.SnapshotExport
+		*out = new(SnapshotExportConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AWSRateLimit != nil {
+		in, out := &in.AWSRateLimit, &out.AWSRateLimit
+		*out = new(AWSRateLimitConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ProviderPlugins != nil {
+		in, out := &in.ProviderPlugins, &out.ProviderPlugins
+		*out = make([]ProviderPlugin, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PeriodicSync != nil {
+		in, out := &in.PeriodicSync, &out.PeriodicSync
+		*out = new(PeriodicSyncConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SyncSetAudit != nil {
+		in, out := &in.SyncSetAudit, &out.SyncSetAudit
+		*out = new(SyncSetAuditConfig)
+		**out = **in
+	}
+	if in.CSRApprovalPolicy != nil {
+		in, out := &in.CSRApprovalPolicy, &out.CSRApprovalPolicy
+		*out = new(CSRApprovalPolicyConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaintenanceMode != nil {
+		in, out := &in.MaintenanceMode, &out.MaintenanceMode
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DeprovisionsDisabled != nil {
+		in, out := &in.DeprovisionsDisabled, &out.DeprovisionsDisabled
+		*out = new(bool)
 		**out = **in
 	}
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = new(MetricsConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DisabledControllers != nil {
+		in, out := &in.DisabledControllers, &out.DisabledControllers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ControllersConfig != nil {
+		in, out := &in.ControllersConfig, &out.ControllersConfig
+		*out = new(ControllersConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AWSPrivateLink != nil {
+		in, out := &in.AWSPrivateLink, &out.AWSPrivateLink
+		*out = new(AWSPrivateLinkConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FeatureGates != nil {
+		in, out := &in.FeatureGates, &out.FeatureGates
+		*out = new(FeatureGateSelection)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSZoneSpec.
-func (in *DNSZoneSpec) DeepCopy() *DNSZoneSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HiveConfigSpec.
+func (in *HiveConfigSpec) DeepCopy() *HiveConfigSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(DNSZoneSpec)
+	out := new(HiveConfigSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DNSZoneStatus) DeepCopyInto(out *DNSZoneStatus) {
+func (in *HiveConfigStatus) DeepCopyInto(out *HiveConfigStatus) {
 	*out = *in
-	if in.LastSyncTimestamp != nil {
-		in, out := &in.LastSyncTimestamp, &out.LastSyncTimestamp
-		*out = (*in).DeepCopy()
-	}
-	if in.NameServers != nil {
-		in, out := &in.NameServers, &out.NameServers
+	if in.DisabledFeatures != nil {
+		in, out := &in.DisabledFeatures, &out.DisabledFeatures
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.AWS != nil {
-		in, out := &in.AWS, &out.AWS
-		*out = new(AWSDNSZoneStatus)
-		(*in).DeepCopyInto(*out)
+	if in.CMDBConnectors != nil {
+		in, out := &in.CMDBConnectors, &out.CMDBConnectors
+		*out = make([]CMDBConnectorStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
-	if in.GCP != nil {
-		in, out := &in.GCP, &out.GCP
-		*out = new(GCPDNSZoneStatus)
-		(*in).DeepCopyInto(*out)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HiveConfigStatus.
+func (in *HiveConfigStatus) DeepCopy() *HiveConfigStatus {
+	if in == nil {
+		return nil
 	}
-	if in.Azure != nil {
-		in, out := &in.Azure, &out.Azure
-		*out = new(AzureDNSZoneStatus)
-		**out = **in
+	out := new(HiveConfigStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HiveNamespaceConfig) DeepCopyInto(out *HiveNamespaceConfig) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HiveNamespaceConfig.
+func (in *HiveNamespaceConfig) DeepCopy() *HiveNamespaceConfig {
+	if in == nil {
+		return nil
 	}
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]DNSZoneCondition, len(*in))
+	out := new(HiveNamespaceConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *HiveNamespaceConfig) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HiveNamespaceConfigList) DeepCopyInto(out *HiveNamespaceConfigList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]HiveNamespaceConfig, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -1990,191 +4033,242 @@ func (in *DNSZoneStatus) DeepCopyInto(out *DNSZoneStatus) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSZoneStatus.
-func (in *DNSZoneStatus) DeepCopy() *DNSZoneStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HiveNamespaceConfigList.
+func (in *HiveNamespaceConfigList) DeepCopy() *HiveNamespaceConfigList {
 	if in == nil {
 		return nil
 	}
-	out := new(DNSZoneStatus)
+	out := new(HiveNamespaceConfigList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *HiveNamespaceConfigList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FailedProvisionAWSConfig) DeepCopyInto(out *FailedProvisionAWSConfig) {
+func (in *HiveNamespaceConfigSpec) DeepCopyInto(out *HiveNamespaceConfigSpec) {
 	*out = *in
-	out.CredentialsSecretRef = in.CredentialsSecretRef
+	if in.CredentialsSecretRef != nil {
+		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.ImageSetRef != nil {
+		in, out := &in.ImageSetRef, &out.ImageSetRef
+		*out = new(ClusterImageSetReference)
+		**out = **in
+	}
+	if in.UserTags != nil {
+		in, out := &in.UserTags, &out.UserTags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Proxy != nil {
+		in, out := &in.Proxy, &out.Proxy
+		*out = new(ProxyConfig)
+		**out = **in
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailedProvisionAWSConfig.
-func (in *FailedProvisionAWSConfig) DeepCopy() *FailedProvisionAWSConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HiveNamespaceConfigSpec.
+func (in *HiveNamespaceConfigSpec) DeepCopy() *HiveNamespaceConfigSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(FailedProvisionAWSConfig)
+	out := new(HiveNamespaceConfigSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FailedProvisionConfig) DeepCopyInto(out *FailedProvisionConfig) {
+func (in *IdentityProviderStatus) DeepCopyInto(out *IdentityProviderStatus) {
 	*out = *in
-	if in.AWS != nil {
-		in, out := &in.AWS, &out.AWS
-		*out = new(FailedProvisionAWSConfig)
-		**out = **in
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailedProvisionConfig.
-func (in *FailedProvisionConfig) DeepCopy() *FailedProvisionConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IdentityProviderStatus.
+func (in *IdentityProviderStatus) DeepCopy() *IdentityProviderStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(FailedProvisionConfig)
+	out := new(IdentityProviderStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FeatureGateSelection) DeepCopyInto(out *FeatureGateSelection) {
+func (in *InstallArtifactsConfig) DeepCopyInto(out *InstallArtifactsConfig) {
 	*out = *in
-	if in.Custom != nil {
-		in, out := &in.Custom, &out.Custom
-		*out = new(FeatureGatesEnabled)
-		(*in).DeepCopyInto(*out)
+	if in.S3 != nil {
+		in, out := &in.S3, &out.S3
+		*out = new(ObjectStorageS3Config)
+		**out = **in
+	}
+	if in.GCS != nil {
+		in, out := &in.GCS, &out.GCS
+		*out = new(ObjectStorageGCSConfig)
+		**out = **in
+	}
+	if in.AzureBlob != nil {
+		in, out := &in.AzureBlob, &out.AzureBlob
+		*out = new(ObjectStorageAzureBlobConfig)
+		**out = **in
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FeatureGateSelection.
-func (in *FeatureGateSelection) DeepCopy() *FeatureGateSelection {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstallArtifactsConfig.
+func (in *InstallArtifactsConfig) DeepCopy() *InstallArtifactsConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(FeatureGateSelection)
+	out := new(InstallArtifactsConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FeatureGatesEnabled) DeepCopyInto(out *FeatureGatesEnabled) {
+func (in *InstallEgressPolicyConfig) DeepCopyInto(out *InstallEgressPolicyConfig) {
 	*out = *in
-	if in.Enabled != nil {
-		in, out := &in.Enabled, &out.Enabled
+	if in.AdditionalEndpoints != nil {
+		in, out := &in.AdditionalEndpoints, &out.AdditionalEndpoints
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FeatureGatesEnabled.
-func (in *FeatureGatesEnabled) DeepCopy() *FeatureGatesEnabled {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstallEgressPolicyConfig.
+func (in *InstallEgressPolicyConfig) DeepCopy() *InstallEgressPolicyConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(FeatureGatesEnabled)
+	out := new(InstallEgressPolicyConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GCPClusterDeprovision) DeepCopyInto(out *GCPClusterDeprovision) {
+func (in *InstallJobSpreadConfig) DeepCopyInto(out *InstallJobSpreadConfig) {
 	*out = *in
-	if in.CredentialsSecretRef != nil {
-		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
-		*out = new(corev1.LocalObjectReference)
-		**out = **in
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPClusterDeprovision.
-func (in *GCPClusterDeprovision) DeepCopy() *GCPClusterDeprovision {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstallJobSpreadConfig.
+func (in *InstallJobSpreadConfig) DeepCopy() *InstallJobSpreadConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(GCPClusterDeprovision)
+	out := new(InstallJobSpreadConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GCPDNSZoneSpec) DeepCopyInto(out *GCPDNSZoneSpec) {
+func (in *InstallLogStreamingConfig) DeepCopyInto(out *InstallLogStreamingConfig) {
 	*out = *in
-	out.CredentialsSecretRef = in.CredentialsSecretRef
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.S3 != nil {
+		in, out := &in.S3, &out.S3
+		*out = new(ObjectStorageS3Config)
+		**out = **in
+	}
+	if in.GCS != nil {
+		in, out := &in.GCS, &out.GCS
+		*out = new(ObjectStorageGCSConfig)
+		**out = **in
+	}
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(InstallLogStreamingHTTPConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPDNSZoneSpec.
-func (in *GCPDNSZoneSpec) DeepCopy() *GCPDNSZoneSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstallLogStreamingConfig.
+func (in *InstallLogStreamingConfig) DeepCopy() *InstallLogStreamingConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(GCPDNSZoneSpec)
+	out := new(InstallLogStreamingConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GCPDNSZoneStatus) DeepCopyInto(out *GCPDNSZoneStatus) {
+func (in *InstallLogStreamingHTTPConfig) DeepCopyInto(out *InstallLogStreamingHTTPConfig) {
 	*out = *in
-	if in.ZoneName != nil {
-		in, out := &in.ZoneName, &out.ZoneName
-		*out = new(string)
+	if in.CredentialsSecretRef != nil {
+		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
+		*out = new(corev1.LocalObjectReference)
 		**out = **in
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPDNSZoneStatus.
-func (in *GCPDNSZoneStatus) DeepCopy() *GCPDNSZoneStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstallLogStreamingHTTPConfig.
+func (in *InstallLogStreamingHTTPConfig) DeepCopy() *InstallLogStreamingHTTPConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(GCPDNSZoneStatus)
+	out := new(InstallLogStreamingHTTPConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HiveConfig) DeepCopyInto(out *HiveConfig) {
+func (in *InstallPodStuckRemediationConfig) DeepCopyInto(out *InstallPodStuckRemediationConfig) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	if in.FailAfter != nil {
+		in, out := &in.FailAfter, &out.FailAfter
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HiveConfig.
-func (in *HiveConfig) DeepCopy() *HiveConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstallPodStuckRemediationConfig.
+func (in *InstallPodStuckRemediationConfig) DeepCopy() *InstallPodStuckRemediationConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(HiveConfig)
+	out := new(InstallPodStuckRemediationConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *HiveConfig) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HiveConfigList) DeepCopyInto(out *HiveConfigList) {
+func (in *InstallPodConfig) DeepCopyInto(out *InstallPodConfig) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]HiveConfig, len(*in))
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -2182,159 +4276,138 @@ func (in *HiveConfigList) DeepCopyInto(out *HiveConfigList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HiveConfigList.
-func (in *HiveConfigList) DeepCopy() *HiveConfigList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstallPodConfig.
+func (in *InstallPodConfig) DeepCopy() *InstallPodConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(HiveConfigList)
+	out := new(InstallPodConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *HiveConfigList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HiveConfigSpec) DeepCopyInto(out *HiveConfigSpec) {
+func (in *InstallStrategy) DeepCopyInto(out *InstallStrategy) {
 	*out = *in
-	if in.ManagedDomains != nil {
-		in, out := &in.ManagedDomains, &out.ManagedDomains
-		*out = make([]ManageDNSConfig, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.AdditionalCertificateAuthoritiesSecretRef != nil {
-		in, out := &in.AdditionalCertificateAuthoritiesSecretRef, &out.AdditionalCertificateAuthoritiesSecretRef
-		*out = make([]corev1.LocalObjectReference, len(*in))
-		copy(*out, *in)
+	if in.Agent != nil {
+		in, out := &in.Agent, &out.Agent
+		*out = new(agent.InstallStrategy)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.GlobalPullSecretRef != nil {
-		in, out := &in.GlobalPullSecretRef, &out.GlobalPullSecretRef
-		*out = new(corev1.LocalObjectReference)
-		**out = **in
+	if in.AgentImage != nil {
+		in, out := &in.AgentImage, &out.AgentImage
+		*out = new(agent.ImageInstallStrategy)
+		(*in).DeepCopyInto(*out)
 	}
-	in.Backup.DeepCopyInto(&out.Backup)
-	in.FailedProvisionConfig.DeepCopyInto(&out.FailedProvisionConfig)
-	if in.MaintenanceMode != nil {
-		in, out := &in.MaintenanceMode, &out.MaintenanceMode
-		*out = new(bool)
-		**out = **in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstallStrategy.
+func (in *InstallStrategy) DeepCopy() *InstallStrategy {
+	if in == nil {
+		return nil
 	}
-	if in.DeprovisionsDisabled != nil {
-		in, out := &in.DeprovisionsDisabled, &out.DeprovisionsDisabled
-		*out = new(bool)
+	out := new(InstallStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstallTimeoutsConfig) DeepCopyInto(out *InstallTimeoutsConfig) {
+	*out = *in
+	if in.Default != nil {
+		in, out := &in.Default, &out.Default
+		*out = new(metav1.Duration)
 		**out = **in
 	}
-	if in.DisabledControllers != nil {
-		in, out := &in.DisabledControllers, &out.DisabledControllers
-		*out = make([]string, len(*in))
+	if in.Platforms != nil {
+		in, out := &in.Platforms, &out.Platforms
+		*out = make([]PlatformInstallTimeout, len(*in))
 		copy(*out, *in)
 	}
-	if in.ControllersConfig != nil {
-		in, out := &in.ControllersConfig, &out.ControllersConfig
-		*out = new(ControllersConfig)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.AWSPrivateLink != nil {
-		in, out := &in.AWSPrivateLink, &out.AWSPrivateLink
-		*out = new(AWSPrivateLinkConfig)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.FeatureGates != nil {
-		in, out := &in.FeatureGates, &out.FeatureGates
-		*out = new(FeatureGateSelection)
-		(*in).DeepCopyInto(*out)
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HiveConfigSpec.
-func (in *HiveConfigSpec) DeepCopy() *HiveConfigSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstallTimeoutsConfig.
+func (in *InstallTimeoutsConfig) DeepCopy() *InstallTimeoutsConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(HiveConfigSpec)
+	out := new(InstallTimeoutsConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HiveConfigStatus) DeepCopyInto(out *HiveConfigStatus) {
+func (in *InstallStrategyStatus) DeepCopyInto(out *InstallStrategyStatus) {
 	*out = *in
+	if in.Agent != nil {
+		in, out := &in.Agent, &out.Agent
+		*out = new(agent.InstallStrategyStatus)
+		**out = **in
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HiveConfigStatus.
-func (in *HiveConfigStatus) DeepCopy() *HiveConfigStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstallStrategyStatus.
+func (in *InstallStrategyStatus) DeepCopy() *InstallStrategyStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(HiveConfigStatus)
+	out := new(InstallStrategyStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IdentityProviderStatus) DeepCopyInto(out *IdentityProviderStatus) {
+func (in *InventoryEntry) DeepCopyInto(out *InventoryEntry) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IdentityProviderStatus.
-func (in *IdentityProviderStatus) DeepCopy() *IdentityProviderStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InventoryEntry.
+func (in *InventoryEntry) DeepCopy() *InventoryEntry {
 	if in == nil {
 		return nil
 	}
-	out := new(IdentityProviderStatus)
+	out := new(InventoryEntry)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InstallStrategy) DeepCopyInto(out *InstallStrategy) {
+func (in *KubeRBACProxyConfig) DeepCopyInto(out *KubeRBACProxyConfig) {
 	*out = *in
-	if in.Agent != nil {
-		in, out := &in.Agent, &out.Agent
-		*out = new(agent.InstallStrategy)
-		(*in).DeepCopyInto(*out)
+	if in.ServingCertSecretRef != nil {
+		in, out := &in.ServingCertSecretRef, &out.ServingCertSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstallStrategy.
-func (in *InstallStrategy) DeepCopy() *InstallStrategy {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeRBACProxyConfig.
+func (in *KubeRBACProxyConfig) DeepCopy() *KubeRBACProxyConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(InstallStrategy)
+	out := new(KubeRBACProxyConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InstallStrategyStatus) DeepCopyInto(out *InstallStrategyStatus) {
-	*out = *in
-	if in.Agent != nil {
-		in, out := &in.Agent, &out.Agent
-		*out = new(agent.InstallStrategyStatus)
-		**out = **in
-	}
+func (in *KubeadminManagement) DeepCopyInto(out *KubeadminManagement) {
+	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstallStrategyStatus.
-func (in *InstallStrategyStatus) DeepCopy() *InstallStrategyStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeadminManagement.
+func (in *KubeadminManagement) DeepCopy() *KubeadminManagement {
 	if in == nil {
 		return nil
 	}
-	out := new(InstallStrategyStatus)
+	out := new(KubeadminManagement)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -2438,6 +4511,27 @@ func (in *MachinePoolCondition) DeepCopy() *MachinePoolCondition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachinePoolDryRunStatus) DeepCopyInto(out *MachinePoolDryRunStatus) {
+	*out = *in
+	if in.MachineSets != nil {
+		in, out := &in.MachineSets, &out.MachineSets
+		*out = make([]MachineSetChange, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachinePoolDryRunStatus.
+func (in *MachinePoolDryRunStatus) DeepCopy() *MachinePoolDryRunStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MachinePoolDryRunStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MachinePoolList) DeepCopyInto(out *MachinePoolList) {
 	*out = *in
@@ -2564,6 +4658,27 @@ func (in *MachinePoolNameLeaseStatus) DeepCopy() *MachinePoolNameLeaseStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachinePoolNodeTuning) DeepCopyInto(out *MachinePoolNodeTuning) {
+	*out = *in
+	if in.SysctlProfileRefs != nil {
+		in, out := &in.SysctlProfileRefs, &out.SysctlProfileRefs
+		*out = make([]corev1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachinePoolNodeTuning.
+func (in *MachinePoolNodeTuning) DeepCopy() *MachinePoolNodeTuning {
+	if in == nil {
+		return nil
+	}
+	out := new(MachinePoolNodeTuning)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MachinePoolPlatform) DeepCopyInto(out *MachinePoolPlatform) {
 	*out = *in
@@ -2597,6 +4712,16 @@ func (in *MachinePoolPlatform) DeepCopyInto(out *MachinePoolPlatform) {
 		*out = new(ovirt.MachinePool)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.OCI != nil {
+		in, out := &in.OCI, &out.OCI
+		*out = new(oci.MachinePool)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PowerVS != nil {
+		in, out := &in.PowerVS, &out.PowerVS
+		*out = new(powervs.MachinePool)
+		**out = **in
+	}
 	return
 }
 
@@ -2647,138 +4772,418 @@ func (in *MachinePoolSpec) DeepCopy() *MachinePoolSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(MachinePoolSpec)
+	out := new(MachinePoolSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachinePoolStatus) DeepCopyInto(out *MachinePoolStatus) {
+	*out = *in
+	if in.MachineSets != nil {
+		in, out := &in.MachineSets, &out.MachineSets
+		*out = make([]MachineSetStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]MachinePoolCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DryRun != nil {
+		in, out := &in.DryRun, &out.DryRun
+		*out = new(MachinePoolDryRunStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachinePoolStatus.
+func (in *MachinePoolStatus) DeepCopy() *MachinePoolStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MachinePoolStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachineSetChange) DeepCopyInto(out *MachineSetChange) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineSetChange.
+func (in *MachineSetChange) DeepCopy() *MachineSetChange {
+	if in == nil {
+		return nil
+	}
+	out := new(MachineSetChange)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachineSetStatus) DeepCopyInto(out *MachineSetStatus) {
+	*out = *in
+	if in.InsufficientCapacityInstanceTypes != nil {
+		in, out := &in.InsufficientCapacityInstanceTypes, &out.InsufficientCapacityInstanceTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineSetStatus.
+func (in *MachineSetStatus) DeepCopy() *MachineSetStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MachineSetStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManageDNSAWSConfig) DeepCopyInto(out *ManageDNSAWSConfig) {
+	*out = *in
+	out.CredentialsSecretRef = in.CredentialsSecretRef
+	if in.AssumeRole != nil {
+		in, out := &in.AssumeRole, &out.AssumeRole
+		*out = new(AWSAssumeRole)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManageDNSAWSConfig.
+func (in *ManageDNSAWSConfig) DeepCopy() *ManageDNSAWSConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ManageDNSAWSConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManageDNSAzureConfig) DeepCopyInto(out *ManageDNSAzureConfig) {
+	*out = *in
+	out.CredentialsSecretRef = in.CredentialsSecretRef
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManageDNSAzureConfig.
+func (in *ManageDNSAzureConfig) DeepCopy() *ManageDNSAzureConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ManageDNSAzureConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManageDNSConfig) DeepCopyInto(out *ManageDNSConfig) {
+	*out = *in
+	if in.Domains != nil {
+		in, out := &in.Domains, &out.Domains
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AWS != nil {
+		in, out := &in.AWS, &out.AWS
+		*out = new(ManageDNSAWSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GCP != nil {
+		in, out := &in.GCP, &out.GCP
+		*out = new(ManageDNSGCPConfig)
+		**out = **in
+	}
+	if in.Azure != nil {
+		in, out := &in.Azure, &out.Azure
+		*out = new(ManageDNSAzureConfig)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManageDNSConfig.
+func (in *ManageDNSConfig) DeepCopy() *ManageDNSConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ManageDNSConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManageDNSGCPConfig) DeepCopyInto(out *ManageDNSGCPConfig) {
+	*out = *in
+	out.CredentialsSecretRef = in.CredentialsSecretRef
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManageDNSGCPConfig.
+func (in *ManageDNSGCPConfig) DeepCopy() *ManageDNSGCPConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ManageDNSGCPConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricsConfig) DeepCopyInto(out *MetricsConfig) {
+	*out = *in
+	if in.KubeRBACProxy != nil {
+		in, out := &in.KubeRBACProxy, &out.KubeRBACProxy
+		*out = new(KubeRBACProxyConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricsConfig.
+func (in *MetricsConfig) DeepCopy() *MetricsConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricsConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeOSState) DeepCopyInto(out *NodeOSState) {
+	*out = *in
+	if in.RHCOSBuildTime != nil {
+		in, out := &in.RHCOSBuildTime, &out.RHCOSBuildTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeOSState.
+func (in *NodeOSState) DeepCopy() *NodeOSState {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeOSState)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeTuning) DeepCopyInto(out *NodeTuning) {
+	*out = *in
+	if in.KubeletConfig != nil {
+		in, out := &in.KubeletConfig, &out.KubeletConfig
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SysctlProfileRefs != nil {
+		in, out := &in.SysctlProfileRefs, &out.SysctlProfileRefs
+		*out = make([]corev1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.MachinePools != nil {
+		in, out := &in.MachinePools, &out.MachinePools
+		*out = make([]MachinePoolNodeTuning, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeTuning.
+func (in *NodeTuning) DeepCopy() *NodeTuning {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeTuning)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodesResumeCheck) DeepCopyInto(out *NodesResumeCheck) {
+	*out = *in
+	in.ResumeCheck.DeepCopyInto(&out.ResumeCheck)
+	if in.ReadyPercent != nil {
+		in, out := &in.ReadyPercent, &out.ReadyPercent
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodesResumeCheck.
+func (in *NodesResumeCheck) DeepCopy() *NodesResumeCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(NodesResumeCheck)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MachinePoolStatus) DeepCopyInto(out *MachinePoolStatus) {
+func (in *NoneClusterDeprovision) DeepCopyInto(out *NoneClusterDeprovision) {
 	*out = *in
-	if in.MachineSets != nil {
-		in, out := &in.MachineSets, &out.MachineSets
-		*out = make([]MachineSetStatus, len(*in))
-		copy(*out, *in)
-	}
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]MachinePoolCondition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
+	in.Destroyer.DeepCopyInto(&out.Destroyer)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachinePoolStatus.
-func (in *MachinePoolStatus) DeepCopy() *MachinePoolStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NoneClusterDeprovision.
+func (in *NoneClusterDeprovision) DeepCopy() *NoneClusterDeprovision {
 	if in == nil {
 		return nil
 	}
-	out := new(MachinePoolStatus)
+	out := new(NoneClusterDeprovision)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MachineSetStatus) DeepCopyInto(out *MachineSetStatus) {
+func (in *OCIArtifactResourceSource) DeepCopyInto(out *OCIArtifactResourceSource) {
 	*out = *in
+	if in.PullSecretRef != nil {
+		in, out := &in.PullSecretRef, &out.PullSecretRef
+		*out = new(SecretReference)
+		**out = **in
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineSetStatus.
-func (in *MachineSetStatus) DeepCopy() *MachineSetStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OCIArtifactResourceSource.
+func (in *OCIArtifactResourceSource) DeepCopy() *OCIArtifactResourceSource {
 	if in == nil {
 		return nil
 	}
-	out := new(MachineSetStatus)
+	out := new(OCIArtifactResourceSource)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ManageDNSAWSConfig) DeepCopyInto(out *ManageDNSAWSConfig) {
+func (in *OCIClusterDeprovision) DeepCopyInto(out *OCIClusterDeprovision) {
 	*out = *in
 	out.CredentialsSecretRef = in.CredentialsSecretRef
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManageDNSAWSConfig.
-func (in *ManageDNSAWSConfig) DeepCopy() *ManageDNSAWSConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OCIClusterDeprovision.
+func (in *OCIClusterDeprovision) DeepCopy() *OCIClusterDeprovision {
 	if in == nil {
 		return nil
 	}
-	out := new(ManageDNSAWSConfig)
+	out := new(OCIClusterDeprovision)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ManageDNSAzureConfig) DeepCopyInto(out *ManageDNSAzureConfig) {
+func (in *OCIDNSZoneSpec) DeepCopyInto(out *OCIDNSZoneSpec) {
 	*out = *in
 	out.CredentialsSecretRef = in.CredentialsSecretRef
+	if in.FreeformTags != nil {
+		in, out := &in.FreeformTags, &out.FreeformTags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManageDNSAzureConfig.
-func (in *ManageDNSAzureConfig) DeepCopy() *ManageDNSAzureConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OCIDNSZoneSpec.
+func (in *OCIDNSZoneSpec) DeepCopy() *OCIDNSZoneSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ManageDNSAzureConfig)
+	out := new(OCIDNSZoneSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ManageDNSConfig) DeepCopyInto(out *ManageDNSConfig) {
+func (in *OCIDNSZoneStatus) DeepCopyInto(out *OCIDNSZoneStatus) {
 	*out = *in
-	if in.Domains != nil {
-		in, out := &in.Domains, &out.Domains
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.AWS != nil {
-		in, out := &in.AWS, &out.AWS
-		*out = new(ManageDNSAWSConfig)
+	if in.ZoneID != nil {
+		in, out := &in.ZoneID, &out.ZoneID
+		*out = new(string)
 		**out = **in
 	}
-	if in.GCP != nil {
-		in, out := &in.GCP, &out.GCP
-		*out = new(ManageDNSGCPConfig)
-		**out = **in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OCIDNSZoneStatus.
+func (in *OCIDNSZoneStatus) DeepCopy() *OCIDNSZoneStatus {
+	if in == nil {
+		return nil
 	}
-	if in.Azure != nil {
-		in, out := &in.Azure, &out.Azure
-		*out = new(ManageDNSAzureConfig)
-		**out = **in
+	out := new(OCIDNSZoneStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectStorageAzureBlobConfig) DeepCopyInto(out *ObjectStorageAzureBlobConfig) {
+	*out = *in
+	out.CredentialsSecretRef = in.CredentialsSecretRef
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectStorageAzureBlobConfig.
+func (in *ObjectStorageAzureBlobConfig) DeepCopy() *ObjectStorageAzureBlobConfig {
+	if in == nil {
+		return nil
 	}
+	out := new(ObjectStorageAzureBlobConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectStorageGCSConfig) DeepCopyInto(out *ObjectStorageGCSConfig) {
+	*out = *in
+	out.CredentialsSecretRef = in.CredentialsSecretRef
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManageDNSConfig.
-func (in *ManageDNSConfig) DeepCopy() *ManageDNSConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectStorageGCSConfig.
+func (in *ObjectStorageGCSConfig) DeepCopy() *ObjectStorageGCSConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(ManageDNSConfig)
+	out := new(ObjectStorageGCSConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ManageDNSGCPConfig) DeepCopyInto(out *ManageDNSGCPConfig) {
+func (in *ObjectStorageS3Config) DeepCopyInto(out *ObjectStorageS3Config) {
 	*out = *in
 	out.CredentialsSecretRef = in.CredentialsSecretRef
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManageDNSGCPConfig.
-func (in *ManageDNSGCPConfig) DeepCopy() *ManageDNSGCPConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectStorageS3Config.
+func (in *ObjectStorageS3Config) DeepCopy() *ObjectStorageS3Config {
 	if in == nil {
 		return nil
 	}
-	out := new(ManageDNSGCPConfig)
+	out := new(ObjectStorageS3Config)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -2827,6 +5232,58 @@ func (in *OvirtClusterDeprovision) DeepCopy() *OvirtClusterDeprovision {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PatchEntity) DeepCopyInto(out *PatchEntity) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PatchEntity.
+func (in *PatchEntity) DeepCopy() *PatchEntity {
+	if in == nil {
+		return nil
+	}
+	out := new(PatchEntity)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PeriodicSyncConfig) DeepCopyInto(out *PeriodicSyncConfig) {
+	*out = *in
+	if in.ClusterSyncFullApplyInterval != nil {
+		in, out := &in.ClusterSyncFullApplyInterval, &out.ClusterSyncFullApplyInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.ClusterStatePollInterval != nil {
+		in, out := &in.ClusterStatePollInterval, &out.ClusterStatePollInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.MachineSetResyncInterval != nil {
+		in, out := &in.MachineSetResyncInterval, &out.MachineSetResyncInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.JitterPercent != nil {
+		in, out := &in.JitterPercent, &out.JitterPercent
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PeriodicSyncConfig.
+func (in *PeriodicSyncConfig) DeepCopy() *PeriodicSyncConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PeriodicSyncConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Platform) DeepCopyInto(out *Platform) {
 	*out = *in
@@ -2870,6 +5327,21 @@ func (in *Platform) DeepCopyInto(out *Platform) {
 		*out = new(agent.BareMetalPlatform)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.OCI != nil {
+		in, out := &in.OCI, &out.OCI
+		*out = new(oci.Platform)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PowerVS != nil {
+		in, out := &in.PowerVS, &out.PowerVS
+		*out = new(powervs.Platform)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.None != nil {
+		in, out := &in.None, &out.None
+		*out = new(none.Platform)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -2878,28 +5350,199 @@ func (in *Platform) DeepCopy() *Platform {
 	if in == nil {
 		return nil
 	}
-	out := new(Platform)
+	out := new(Platform)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlatformInstallTimeout) DeepCopyInto(out *PlatformInstallTimeout) {
+	*out = *in
+	out.Timeout = in.Timeout
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlatformInstallTimeout.
+func (in *PlatformInstallTimeout) DeepCopy() *PlatformInstallTimeout {
+	if in == nil {
+		return nil
+	}
+	out := new(PlatformInstallTimeout)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlatformStatus) DeepCopyInto(out *PlatformStatus) {
+	*out = *in
+	if in.AWS != nil {
+		in, out := &in.AWS, &out.AWS
+		*out = new(aws.PlatformStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlatformStatus.
+func (in *PlatformStatus) DeepCopy() *PlatformStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PlatformStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodLogSnapshotsConfig) DeepCopyInto(out *PodLogSnapshotsConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodLogSnapshotsConfig.
+func (in *PodLogSnapshotsConfig) DeepCopy() *PodLogSnapshotsConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PodLogSnapshotsConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PowerStateSchedule) DeepCopyInto(out *PowerStateSchedule) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PowerStateSchedule.
+func (in *PowerStateSchedule) DeepCopy() *PowerStateSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(PowerStateSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PowerVSClusterDeprovision) DeepCopyInto(out *PowerVSClusterDeprovision) {
+	*out = *in
+	out.CredentialsSecretRef = in.CredentialsSecretRef
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PowerVSClusterDeprovision.
+func (in *PowerVSClusterDeprovision) DeepCopy() *PowerVSClusterDeprovision {
+	if in == nil {
+		return nil
+	}
+	out := new(PowerVSClusterDeprovision)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProviderPlugin) DeepCopyInto(out *ProviderPlugin) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderPlugin.
+func (in *ProviderPlugin) DeepCopy() *ProviderPlugin {
+	if in == nil {
+		return nil
+	}
+	out := new(ProviderPlugin)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProvisionRetryPolicy) DeepCopyInto(out *ProvisionRetryPolicy) {
+	*out = *in
+	if in.MaxAttempts != nil {
+		in, out := &in.MaxAttempts, &out.MaxAttempts
+		*out = new(int32)
+		**out = **in
+	}
+	if in.BackoffBase != nil {
+		in, out := &in.BackoffBase, &out.BackoffBase
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.MaxBackoff != nil {
+		in, out := &in.MaxBackoff, &out.MaxBackoff
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.RetryableReasons != nil {
+		in, out := &in.RetryableReasons, &out.RetryableReasons
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProvisionRetryPolicy.
+func (in *ProvisionRetryPolicy) DeepCopy() *ProvisionRetryPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ProvisionRetryPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProvisionSLAConfig) DeepCopyInto(out *ProvisionSLAConfig) {
+	*out = *in
+	out.Duration = in.Duration
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProvisionSLAConfig.
+func (in *ProvisionSLAConfig) DeepCopy() *ProvisionSLAConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ProvisionSLAConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PlatformStatus) DeepCopyInto(out *PlatformStatus) {
+func (in *ProvisionStageTimeoutsConfig) DeepCopyInto(out *ProvisionStageTimeoutsConfig) {
 	*out = *in
-	if in.AWS != nil {
-		in, out := &in.AWS, &out.AWS
-		*out = new(aws.PlatformStatus)
-		(*in).DeepCopyInto(*out)
+	if in.Initializing != nil {
+		in, out := &in.Initializing, &out.Initializing
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Provisioning != nil {
+		in, out := &in.Provisioning, &out.Provisioning
+		*out = new(metav1.Duration)
+		**out = **in
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlatformStatus.
-func (in *PlatformStatus) DeepCopy() *PlatformStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProvisionStageTimeoutsConfig.
+func (in *ProvisionStageTimeoutsConfig) DeepCopy() *ProvisionStageTimeoutsConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(PlatformStatus)
+	out := new(ProvisionStageTimeoutsConfig)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -2944,6 +5587,26 @@ func (in *Provisioning) DeepCopyInto(out *Provisioning) {
 		*out = new(InstallStrategy)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.FeatureSet != nil {
+		in, out := &in.FeatureSet, &out.FeatureSet
+		*out = new(FeatureSetConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.InstallTimeout != nil {
+		in, out := &in.InstallTimeout, &out.InstallTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.InstallPod != nil {
+		in, out := &in.InstallPod, &out.InstallPod
+		*out = new(InstallPodConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Proxy != nil {
+		in, out := &in.Proxy, &out.Proxy
+		*out = new(ProxyConfig)
+		**out = **in
+	}
 	return
 }
 
@@ -2957,6 +5620,131 @@ func (in *Provisioning) DeepCopy() *Provisioning {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxyConfig) DeepCopyInto(out *ProxyConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyConfig.
+func (in *ProxyConfig) DeepCopy() *ProxyConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxyConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteAccessRBACConfig) DeepCopyInto(out *RemoteAccessRBACConfig) {
+	*out = *in
+	if in.SyncSetAPIGroups != nil {
+		in, out := &in.SyncSetAPIGroups, &out.SyncSetAPIGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteAccessRBACConfig.
+func (in *RemoteAccessRBACConfig) DeepCopy() *RemoteAccessRBACConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteAccessRBACConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreReference) DeepCopyInto(out *RestoreReference) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreReference.
+func (in *RestoreReference) DeepCopy() *RestoreReference {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreStatus) DeepCopyInto(out *RestoreStatus) {
+	*out = *in
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreStatus.
+func (in *RestoreStatus) DeepCopy() *RestoreStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResumeCheck) DeepCopyInto(out *ResumeCheck) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResumeCheck.
+func (in *ResumeCheck) DeepCopy() *ResumeCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(ResumeCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResumeReadiness) DeepCopyInto(out *ResumeReadiness) {
+	*out = *in
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = new(NodesResumeCheck)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClusterOperators != nil {
+		in, out := &in.ClusterOperators, &out.ClusterOperators
+		*out = new(ResumeCheck)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Certificates != nil {
+		in, out := &in.Certificates, &out.Certificates
+		*out = new(ResumeCheck)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResumeReadiness.
+func (in *ResumeReadiness) DeepCopy() *ResumeReadiness {
+	if in == nil {
+		return nil
+	}
+	out := new(ResumeReadiness)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecretMapping) DeepCopyInto(out *SecretMapping) {
 	*out = *in
@@ -3165,6 +5953,53 @@ func (in *SelectorSyncSetStatus) DeepCopy() *SelectorSyncSetStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceAccountReference) DeepCopyInto(out *ServiceAccountReference) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceAccountReference.
+func (in *ServiceAccountReference) DeepCopy() *ServiceAccountReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceAccountReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnapshotExportConfig) DeepCopyInto(out *SnapshotExportConfig) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.S3 != nil {
+		in, out := &in.S3, &out.S3
+		*out = new(ObjectStorageS3Config)
+		**out = **in
+	}
+	if in.GCS != nil {
+		in, out := &in.GCS, &out.GCS
+		*out = new(ObjectStorageGCSConfig)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotExportConfig.
+func (in *SnapshotExportConfig) DeepCopy() *SnapshotExportConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(SnapshotExportConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SpecificControllerConfig) DeepCopyInto(out *SpecificControllerConfig) {
 	*out = *in
@@ -3182,6 +6017,27 @@ func (in *SpecificControllerConfig) DeepCopy() *SpecificControllerConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SyncAgent) DeepCopyInto(out *SyncAgent) {
+	*out = *in
+	if in.PollInterval != nil {
+		in, out := &in.PollInterval, &out.PollInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyncAgent.
+func (in *SyncAgent) DeepCopy() *SyncAgent {
+	if in == nil {
+		return nil
+	}
+	out := new(SyncAgent)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SyncCondition) DeepCopyInto(out *SyncCondition) {
 	*out = *in
@@ -3350,6 +6206,22 @@ func (in *SyncSet) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SyncSetAuditConfig) DeepCopyInto(out *SyncSetAuditConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyncSetAuditConfig.
+func (in *SyncSetAuditConfig) DeepCopy() *SyncSetAuditConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(SyncSetAuditConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SyncSetCommonSpec) DeepCopyInto(out *SyncSetCommonSpec) {
 	*out = *in
@@ -3360,6 +6232,13 @@ func (in *SyncSetCommonSpec) DeepCopyInto(out *SyncSetCommonSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ResourceRefs != nil {
+		in, out := &in.ResourceRefs, &out.ResourceRefs
+		*out = make([]SyncSetResourceRef, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Patches != nil {
 		in, out := &in.Patches, &out.Patches
 		*out = make([]SyncObjectPatch, len(*in))
@@ -3460,6 +6339,32 @@ func (in *SyncSetObjectStatus) DeepCopy() *SyncSetObjectStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SyncSetResourceRef) DeepCopyInto(out *SyncSetResourceRef) {
+	*out = *in
+	if in.ConfigMap != nil {
+		in, out := &in.ConfigMap, &out.ConfigMap
+		*out = new(ConfigMapResourceSource)
+		**out = **in
+	}
+	if in.OCIArtifact != nil {
+		in, out := &in.OCIArtifact, &out.OCIArtifact
+		*out = new(OCIArtifactResourceSource)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyncSetResourceRef.
+func (in *SyncSetResourceRef) DeepCopy() *SyncSetResourceRef {
+	if in == nil {
+		return nil
+	}
+	out := new(SyncSetResourceRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SyncSetSpec) DeepCopyInto(out *SyncSetSpec) {
 	*out = *in
@@ -3469,6 +6374,11 @@ func (in *SyncSetSpec) DeepCopyInto(out *SyncSetSpec) {
 		*out = make([]corev1.LocalObjectReference, len(*in))
 		copy(*out, *in)
 	}
+	if in.ApplyAs != nil {
+		in, out := &in.ApplyAs, &out.ApplyAs
+		*out = new(ServiceAccountReference)
+		**out = **in
+	}
 	return
 }
 
@@ -3521,6 +6431,96 @@ func (in *SyncStatus) DeepCopy() *SyncStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UnreachableRemediation) DeepCopyInto(out *UnreachableRemediation) {
+	*out = *in
+	if in.Threshold != nil {
+		in, out := &in.Threshold, &out.Threshold
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]UnreachableRemediationStep, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UnreachableRemediation.
+func (in *UnreachableRemediation) DeepCopy() *UnreachableRemediation {
+	if in == nil {
+		return nil
+	}
+	out := new(UnreachableRemediation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UnreachableRemediationStatus) DeepCopyInto(out *UnreachableRemediationStatus) {
+	*out = *in
+	in.UnreachableSince.DeepCopyInto(&out.UnreachableSince)
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]UnreachableRemediationStepStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UnreachableRemediationStatus.
+func (in *UnreachableRemediationStatus) DeepCopy() *UnreachableRemediationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(UnreachableRemediationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UnreachableRemediationStep) DeepCopyInto(out *UnreachableRemediationStep) {
+	*out = *in
+	if in.KubeconfigSecretRef != nil {
+		in, out := &in.KubeconfigSecretRef, &out.KubeconfigSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UnreachableRemediationStep.
+func (in *UnreachableRemediationStep) DeepCopy() *UnreachableRemediationStep {
+	if in == nil {
+		return nil
+	}
+	out := new(UnreachableRemediationStep)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UnreachableRemediationStepStatus) DeepCopyInto(out *UnreachableRemediationStepStatus) {
+	*out = *in
+	in.AttemptTime.DeepCopyInto(&out.AttemptTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UnreachableRemediationStepStatus.
+func (in *UnreachableRemediationStepStatus) DeepCopy() *UnreachableRemediationStepStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(UnreachableRemediationStepStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VSphereClusterDeprovision) DeepCopyInto(out *VSphereClusterDeprovision) {
 	*out = *in
@@ -3,6 +3,8 @@ package v1alpha1
 import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
 )
 
 // +genclient
@@ -50,6 +52,11 @@ type SyncStatus struct {
 	// ObservedGeneration is the generation of the SyncSet or SelectorSyncSet that was last observed.
 	ObservedGeneration int64 `json:"observedGeneration"`
 
+	// ResourceRefsDigest is the digest of the data of the ConfigMaps referenced by the resourceRefs of the SyncSet or
+	// SelectorSyncSet when it was last applied. The SyncSet or SelectorSyncSet is applied again when the data changes.
+	// +optional
+	ResourceRefsDigest string `json:"resourceRefsDigest,omitempty"`
+
 	// ResourcesToDelete is the list of resources in the cluster that should be deleted when the SyncSet or SelectorSyncSet
 	// is deleted or is no longer matched to the cluster.
 	// +optional
@@ -119,6 +126,9 @@ type ClusterSyncCondition struct {
 	// Message is a human-readable message indicating details about the last transition.
 	// +optional
 	Message string `json:"message,omitempty"`
+	// Category is the class of the failure reported by the condition, from its reason.
+	// +optional
+	Category hivev1.FailureCategory `json:"category,omitempty"`
 }
 
 // ClusterSyncConditionType is a valid value for ClusterSyncCondition.Type
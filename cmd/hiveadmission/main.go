@@ -8,6 +8,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivemutatingwebhooks "github.com/openshift/hive/pkg/mutating-webhooks/hive/v1"
 	hivevalidatingwebhooks "github.com/openshift/hive/pkg/validating-webhooks/hive/v1"
 	"github.com/openshift/hive/pkg/version"
 )
@@ -25,11 +26,15 @@ func main() {
 		hivevalidatingwebhooks.NewDNSZoneValidatingAdmissionHook(decoder),
 		hivevalidatingwebhooks.NewClusterDeploymentValidatingAdmissionHook(decoder),
 		hivevalidatingwebhooks.NewClusterPoolValidatingAdmissionHook(decoder),
+		hivevalidatingwebhooks.NewClusterClaimValidatingAdmissionHook(decoder),
 		hivevalidatingwebhooks.NewClusterImageSetValidatingAdmissionHook(decoder),
 		hivevalidatingwebhooks.NewClusterProvisionValidatingAdmissionHook(decoder),
 		hivevalidatingwebhooks.NewMachinePoolValidatingAdmissionHook(decoder),
+		hivevalidatingwebhooks.NewBaseDomainPoolValidatingAdmissionHook(decoder),
 		hivevalidatingwebhooks.NewSyncSetValidatingAdmissionHook(decoder),
 		hivevalidatingwebhooks.NewSelectorSyncSetValidatingAdmissionHook(decoder),
+		hivemutatingwebhooks.NewClusterDeploymentMutatingAdmissionHook(decoder),
+		hivemutatingwebhooks.NewClusterClaimMutatingAdmissionHook(decoder),
 	)
 }
 
@@ -27,6 +27,7 @@ import (
 	"k8s.io/klog"
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	crmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
 	"sigs.k8s.io/controller-runtime/pkg/runtime/signals"
 
 	openshiftapiv1 "github.com/openshift/api/config/v1"
@@ -36,28 +37,41 @@ import (
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	"github.com/openshift/hive/pkg/constants"
 	"github.com/openshift/hive/pkg/controller/awsprivatelink"
+	"github.com/openshift/hive/pkg/controller/basedomainpool"
+	"github.com/openshift/hive/pkg/controller/changefreeze"
 	"github.com/openshift/hive/pkg/controller/clusterclaim"
 	"github.com/openshift/hive/pkg/controller/clusterdeployment"
 	"github.com/openshift/hive/pkg/controller/clusterdeprovision"
+	"github.com/openshift/hive/pkg/controller/clusterimageset"
+	"github.com/openshift/hive/pkg/controller/clusterinventory"
 	"github.com/openshift/hive/pkg/controller/clusterpool"
 	"github.com/openshift/hive/pkg/controller/clusterpoolnamespace"
 	"github.com/openshift/hive/pkg/controller/clusterprovision"
 	"github.com/openshift/hive/pkg/controller/clusterrelocate"
+	"github.com/openshift/hive/pkg/controller/clustersanitization"
 	"github.com/openshift/hive/pkg/controller/clusterstate"
 	"github.com/openshift/hive/pkg/controller/clustersync"
 	"github.com/openshift/hive/pkg/controller/clusterversion"
+	"github.com/openshift/hive/pkg/controller/cmdbexport"
 	"github.com/openshift/hive/pkg/controller/controlplanecerts"
 	"github.com/openshift/hive/pkg/controller/dnsendpoint"
 	"github.com/openshift/hive/pkg/controller/dnszone"
+	"github.com/openshift/hive/pkg/controller/gitsyncsource"
 	"github.com/openshift/hive/pkg/controller/hibernation"
+	"github.com/openshift/hive/pkg/controller/kubeadmin"
 	"github.com/openshift/hive/pkg/controller/machinemanagement"
 	"github.com/openshift/hive/pkg/controller/metrics"
+	"github.com/openshift/hive/pkg/controller/namespacecleanup"
+	"github.com/openshift/hive/pkg/controller/nodetuning"
+	"github.com/openshift/hive/pkg/controller/remoteaccess"
 	"github.com/openshift/hive/pkg/controller/remoteingress"
 	"github.com/openshift/hive/pkg/controller/remotemachineset"
+	"github.com/openshift/hive/pkg/controller/snapshotexport"
 	"github.com/openshift/hive/pkg/controller/syncidentityprovider"
 	"github.com/openshift/hive/pkg/controller/unreachable"
 	"github.com/openshift/hive/pkg/controller/utils"
 	"github.com/openshift/hive/pkg/controller/velerobackup"
+	"github.com/openshift/hive/pkg/remoteclient"
 	utillogrus "github.com/openshift/hive/pkg/util/logrus"
 	"github.com/openshift/hive/pkg/version"
 )
@@ -72,10 +86,27 @@ const (
 
 type controllerSetupFunc func(manager.Manager) error
 
+// disabledControllerFuncs are the setup functions of the controllers which still clean up after themselves while they
+// are disabled.
+var disabledControllerFuncs = map[hivev1.ControllerName]controllerSetupFunc{
+	cmdbexport.ControllerName: cmdbexport.AddDisabled,
+}
+
+// controllerFeatures maps the controllers which only serve a Hive feature needing permissions in installed clusters
+// beyond those of the Restricted remote access RBAC mode to the feature. They are not started while the feature is
+// disabled by the mode.
+var controllerFeatures = map[hivev1.ControllerName]string{
+	controlplanecerts.ControllerName:    remoteclient.ControlPlaneCertificatesFeature,
+	nodetuning.ControllerName:           remoteclient.NodeTuningFeature,
+	remoteingress.ControllerName:        remoteclient.RemoteIngressFeature,
+	syncidentityprovider.ControllerName: remoteclient.IdentityProvidersFeature,
+}
+
 var controllerFuncs = map[hivev1.ControllerName]controllerSetupFunc{
 	clusterclaim.ControllerName:         clusterclaim.Add,
 	clusterdeployment.ControllerName:    clusterdeployment.Add,
 	clusterdeprovision.ControllerName:   clusterdeprovision.Add,
+	clusterinventory.ControllerName:     clusterinventory.Add,
 	clusterpoolnamespace.ControllerName: clusterpoolnamespace.Add,
 	clusterprovision.ControllerName:     clusterprovision.Add,
 	clusterrelocate.ControllerName:      clusterrelocate.Add,
@@ -95,6 +126,17 @@ var controllerFuncs = map[hivev1.ControllerName]controllerSetupFunc{
 	hibernation.ControllerName:          hibernation.Add,
 	machinemanagement.ControllerName:    machinemanagement.Add,
 	awsprivatelink.ControllerName:       awsprivatelink.Add,
+	basedomainpool.ControllerName:       basedomainpool.Add,
+	nodetuning.ControllerName:           nodetuning.Add,
+	namespacecleanup.ControllerName:     namespacecleanup.Add,
+	remoteaccess.ControllerName:         remoteaccess.Add,
+	cmdbexport.ControllerName:           cmdbexport.Add,
+	gitsyncsource.ControllerName:        gitsyncsource.Add,
+	clustersanitization.ControllerName:  clustersanitization.Add,
+	kubeadmin.ControllerName:            kubeadmin.Add,
+	snapshotexport.ControllerName:       snapshotexport.Add,
+	clusterimageset.ControllerName:      clusterimageset.Add,
+	changefreeze.ControllerName:         changefreeze.Add,
 }
 
 type controllerManagerOptions struct {
@@ -166,16 +208,27 @@ func newRootCommand() *cobra.Command {
 			ctx, cancel := context.WithCancel(context.Background())
 			defer cancel()
 
+			metricsBindAddress := ":2112"
+			if addr := os.Getenv(constants.MetricsBindAddressEnvVar); addr != "" {
+				metricsBindAddress = addr
+			}
+
 			run := func(ctx context.Context) {
 				// Create a new Cmd to provide shared dependencies and start components
 				mgr, err := manager.New(cfg, manager.Options{
-					MetricsBindAddress: ":2112",
+					MetricsBindAddress: metricsBindAddress,
 					Logger:             utillogrus.NewLogr(log.StandardLogger()),
 				})
 				if err != nil {
 					log.Fatal(err)
 				}
 
+				if os.Getenv(constants.MetricsNamespaceFilteringEnvVar) == "true" {
+					if err := mgr.AddMetricsExtraHandler(metrics.NamespaceMetricsPath, metrics.NewNamespaceMetricsHandler(crmetrics.Registry)); err != nil {
+						log.Fatal(err)
+					}
+				}
+
 				log.Info("Registering Components.")
 
 				if err := utils.SetupAdditionalCA(); err != nil {
@@ -212,6 +265,16 @@ func newRootCommand() *cobra.Command {
 					}
 					if disabledControllersSet.Has(name) {
 						log.WithField("controller", name).Debugf("skipping disabled controller")
+						if fn, ok := disabledControllerFuncs[hivev1.ControllerName(name)]; ok {
+							if err := fn(mgr); err != nil {
+								log.WithError(err).WithField("controller", name).Fatal("failed to start cleanup of disabled controller")
+							}
+						}
+						continue
+					}
+					if feature, ok := controllerFeatures[hivev1.ControllerName(name)]; ok && remoteclient.FeatureDisabled(feature) {
+						log.WithField("controller", name).WithField("feature", feature).
+							Info("skipping controller whose feature is disabled by the restricted remote access RBAC mode")
 						continue
 					}
 					if err := fn(mgr); err != nil {
@@ -8,16 +8,19 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/openshift/hive/contrib/pkg/adm"
+	"github.com/openshift/hive/contrib/pkg/await"
 	"github.com/openshift/hive/contrib/pkg/certificate"
 	"github.com/openshift/hive/contrib/pkg/clusterpool"
 	"github.com/openshift/hive/contrib/pkg/createcluster"
 	"github.com/openshift/hive/contrib/pkg/deprovision"
+	"github.com/openshift/hive/contrib/pkg/query"
 	"github.com/openshift/hive/contrib/pkg/report"
 	"github.com/openshift/hive/contrib/pkg/testresource"
 	"github.com/openshift/hive/contrib/pkg/verification"
 	"github.com/openshift/hive/contrib/pkg/version"
 	"github.com/openshift/hive/pkg/imageset"
 	"github.com/openshift/hive/pkg/installmanager"
+	"github.com/openshift/hive/pkg/syncagent"
 )
 
 func main() {
@@ -46,7 +49,10 @@ func newHiveutilCommand() *cobra.Command {
 	cmd.AddCommand(deprovision.NewDeprovisionCommand())
 	cmd.AddCommand(verification.NewVerifyImportsCommand())
 	cmd.AddCommand(installmanager.NewInstallManagerCommand())
+	cmd.AddCommand(installmanager.NewMustGatherCommand())
 	cmd.AddCommand(imageset.NewUpdateInstallerImageCommand())
+	cmd.AddCommand(imageset.NewValidateImageSetCommand())
+	cmd.AddCommand(syncagent.NewSyncAgentCommand())
 	cmd.AddCommand(testresource.NewTestResourceCommand())
 	cmd.AddCommand(createcluster.NewCreateClusterCommand())
 	cmd.AddCommand(report.NewClusterReportCommand())
@@ -54,6 +60,8 @@ func newHiveutilCommand() *cobra.Command {
 	cmd.AddCommand(adm.NewAdmCommand())
 	cmd.AddCommand(version.NewVersionCommand())
 	cmd.AddCommand(clusterpool.NewClusterPoolCommand())
+	cmd.AddCommand(query.NewQueryCommand())
+	cmd.AddCommand(await.NewAwaitCommand())
 
 	return cmd
 }
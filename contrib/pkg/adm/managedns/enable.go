@@ -64,6 +64,9 @@ type Options struct {
 
 	AzureResourceGroup string
 
+	AWSAssumeRoleARN        string
+	AWSAssumeRoleExternalID string
+
 	dynamicClient dynamic.Interface
 	hiveClient    *hiveclient.Clientset
 }
@@ -101,6 +104,8 @@ func NewEnableManageDNSCommand() *cobra.Command {
 	flags.StringVar(&opt.Cloud, "cloud", cloudAWS, "Cloud provider: aws(default)|gcp|azure)")
 	flags.StringVar(&opt.CredsFile, "creds-file", "", "Cloud credentials file (defaults vary depending on cloud)")
 	flags.StringVar(&opt.AzureResourceGroup, "azure-resource-group-name", "os4-common", "Azure Resource Group (Only applicable if --cloud azure)")
+	flags.StringVar(&opt.AWSAssumeRoleARN, "aws-assume-role-arn", "", "Role to assume with the credentials to manage domains hosted in another AWS account (Only applicable if --cloud aws)")
+	flags.StringVar(&opt.AWSAssumeRoleExternalID, "aws-assume-role-external-id", "", "External ID required to assume the role (Only applicable if --aws-assume-role-arn is set)")
 	return cmd
 }
 
@@ -116,6 +121,14 @@ func (o *Options) Complete(cmd *cobra.Command, args []string) error {
 
 // Validate ensures that option values make sense
 func (o *Options) Validate(cmd *cobra.Command) error {
+	if o.AWSAssumeRoleARN != "" && o.Cloud != cloudAWS {
+		log.Error("--aws-assume-role-arn is only applicable if --cloud aws")
+		return fmt.Errorf("--aws-assume-role-arn is only applicable if --cloud aws")
+	}
+	if o.AWSAssumeRoleExternalID != "" && o.AWSAssumeRoleARN == "" {
+		log.Error("--aws-assume-role-external-id requires --aws-assume-role-arn")
+		return fmt.Errorf("--aws-assume-role-external-id requires --aws-assume-role-arn")
+	}
 	return nil
 }
 
@@ -152,6 +165,12 @@ func (o *Options) Run(args []string) error {
 		dnsConf.AWS = &hivev1.ManageDNSAWSConfig{
 			CredentialsSecretRef: corev1.LocalObjectReference{Name: credsSecret.Name},
 		}
+		if o.AWSAssumeRoleARN != "" {
+			dnsConf.AWS.AssumeRole = &hivev1.AWSAssumeRole{
+				RoleARN:    o.AWSAssumeRoleARN,
+				ExternalID: o.AWSAssumeRoleExternalID,
+			}
+		}
 	case cloudGCP:
 		// Apply a secret for credentials to manage the root domain:
 		credsSecret, err = o.generateGCPCredentialsSecret()
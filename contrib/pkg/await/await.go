@@ -0,0 +1,305 @@
+package await
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	contributils "github.com/openshift/hive/contrib/pkg/utils"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+// Options is the set of options to wait for a Hive resource.
+type Options struct {
+	// Name is the name of the resource.
+	Name string
+	// Namespace is the namespace of the resource. The namespace of the current context is used when empty.
+	Namespace string
+	// For are the conditions to wait for. All of them must be met.
+	For []string
+	// Timeout is how long to wait for the conditions.
+	Timeout time.Duration
+	// Interval is how often the resource is checked.
+	Interval time.Duration
+
+	kind *kind
+}
+
+// kind describes how to wait for a kind of resource.
+type kind struct {
+	name      string
+	newObject func() runtime.Object
+	// conditions are the conditions which can be waited for, by name.
+	conditions map[string]func(runtime.Object) bool
+	// failed returns an error when the resource can no longer meet the conditions, to stop waiting early.
+	failed func(runtime.Object) error
+	// progress describes the current state of the resource.
+	progress func(runtime.Object) string
+}
+
+var clusterDeploymentKind = &kind{
+	name:      "clusterdeployment",
+	newObject: func() runtime.Object { return &hivev1.ClusterDeployment{} },
+	conditions: map[string]func(runtime.Object) bool{
+		"installed": func(obj runtime.Object) bool {
+			return obj.(*hivev1.ClusterDeployment).Spec.Installed
+		},
+		"ready": func(obj runtime.Object) bool {
+			cd := obj.(*hivev1.ClusterDeployment)
+			return controllerutils.IsClusterDeploymentReady(cd) && powerState(cd) == hivev1.RunningHibernationReason
+		},
+		"running": func(obj runtime.Object) bool {
+			return powerState(obj.(*hivev1.ClusterDeployment)) == hivev1.RunningHibernationReason
+		},
+		"hibernating": func(obj runtime.Object) bool {
+			return powerState(obj.(*hivev1.ClusterDeployment)) == hivev1.HibernatingHibernationReason
+		},
+	},
+	failed: func(obj runtime.Object) error {
+		cd := obj.(*hivev1.ClusterDeployment)
+		if cd.Spec.Installed {
+			return nil
+		}
+		cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.ProvisionStoppedCondition)
+		if cond != nil && cond.Status == corev1.ConditionTrue {
+			return fmt.Errorf("provisioning stopped: %s", cond.Message)
+		}
+		return nil
+	},
+	progress: func(obj runtime.Object) string {
+		cd := obj.(*hivev1.ClusterDeployment)
+		state := fmt.Sprintf("installed=%t", cd.Spec.Installed)
+		if ps := powerState(cd); ps != "" {
+			state += fmt.Sprintf(" powerState=%s", ps)
+		}
+		if gates := controllerutils.UnmetReadinessGates(cd); cd.Spec.Installed && len(gates) > 0 {
+			state += fmt.Sprintf(" unmetReadinessGates=%d", len(gates))
+		}
+		if cd.Status.InstallRestarts > 0 {
+			state += fmt.Sprintf(" installRestarts=%d", cd.Status.InstallRestarts)
+		}
+		return state
+	},
+}
+
+var clusterClaimKind = &kind{
+	name:      "clusterclaim",
+	newObject: func() runtime.Object { return &hivev1.ClusterClaim{} },
+	conditions: map[string]func(runtime.Object) bool{
+		"assigned": func(obj runtime.Object) bool {
+			return obj.(*hivev1.ClusterClaim).Spec.Namespace != ""
+		},
+		"running": func(obj runtime.Object) bool {
+			claim := obj.(*hivev1.ClusterClaim)
+			cond := controllerutils.FindClusterClaimCondition(claim.Status.Conditions, hivev1.ClusterRunningCondition)
+			return cond != nil && cond.Status == corev1.ConditionTrue
+		},
+	},
+	failed: func(obj runtime.Object) error {
+		claim := obj.(*hivev1.ClusterClaim)
+		cond := controllerutils.FindClusterClaimCondition(claim.Status.Conditions, hivev1.ClusterClaimClusterDeletedCondition)
+		if cond != nil && cond.Status == corev1.ConditionTrue {
+			return fmt.Errorf("cluster of the claim was deleted: %s", cond.Message)
+		}
+		return nil
+	},
+	progress: func(obj runtime.Object) string {
+		claim := obj.(*hivev1.ClusterClaim)
+		state := fmt.Sprintf("cluster=%q", claim.Spec.Namespace)
+		if cond := controllerutils.FindClusterClaimCondition(claim.Status.Conditions, hivev1.ClusterClaimPendingCondition); cond != nil {
+			state += fmt.Sprintf(" pending=%s(%s)", cond.Status, cond.Reason)
+		}
+		if cond := controllerutils.FindClusterClaimCondition(claim.Status.Conditions, hivev1.ClusterRunningCondition); cond != nil {
+			state += fmt.Sprintf(" running=%s(%s)", cond.Status, cond.Reason)
+		}
+		return state
+	},
+}
+
+var clusterPoolKind = &kind{
+	name:      "clusterpool",
+	newObject: func() runtime.Object { return &hivev1.ClusterPool{} },
+	conditions: map[string]func(runtime.Object) bool{
+		"populated": func(obj runtime.Object) bool {
+			pool := obj.(*hivev1.ClusterPool)
+			return pool.Status.Size >= pool.Spec.Size
+		},
+		"ready": func(obj runtime.Object) bool {
+			pool := obj.(*hivev1.ClusterPool)
+			return pool.Status.Ready >= pool.Spec.Size
+		},
+		"drained": func(obj runtime.Object) bool {
+			pool := obj.(*hivev1.ClusterPool)
+			cond := controllerutils.FindClusterPoolCondition(pool.Status.Conditions, hivev1.ClusterPoolActiveCondition)
+			return pool.Spec.Mode == hivev1.ClusterPoolModeDraining && cond != nil && cond.Reason == "Drained"
+		},
+	},
+	failed: func(obj runtime.Object) error { return nil },
+	progress: func(obj runtime.Object) string {
+		pool := obj.(*hivev1.ClusterPool)
+		state := fmt.Sprintf("size=%d ready=%d desired=%d", pool.Status.Size, pool.Status.Ready, pool.Spec.Size)
+		if pool.Spec.Mode != "" {
+			state += fmt.Sprintf(" mode=%s", pool.Spec.Mode)
+		}
+		return state
+	},
+}
+
+// powerState returns the reason of the Hibernating condition of the cluster, which is its current power state.
+func powerState(cd *hivev1.ClusterDeployment) string {
+	cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.ClusterHibernatingCondition)
+	if cond == nil {
+		return ""
+	}
+	return cond.Reason
+}
+
+// NewAwaitCommand creates a command that waits for Hive resources to meet conditions.
+func NewAwaitCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "await",
+		Short: "Waits for Hive resources to meet conditions",
+		Long: `Waits for a Hive resource to meet all of the given conditions, printing its state as it changes. Transient
+errors reaching the API server are retried until the timeout. The command fails as soon as the resource can no longer
+meet the conditions, such as when the provisioning of a cluster is stopped.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.Usage()
+		},
+	}
+	cmd.AddCommand(newAwaitKindCommand(clusterDeploymentKind, "cd"))
+	cmd.AddCommand(newAwaitKindCommand(clusterClaimKind, "claim"))
+	cmd.AddCommand(newAwaitKindCommand(clusterPoolKind, "cp"))
+	return cmd
+}
+
+func newAwaitKindCommand(k *kind, alias string) *cobra.Command {
+	opt := &Options{kind: k}
+	cmd := &cobra.Command{
+		Use:     fmt.Sprintf("%s NAME --for=CONDITION", k.name),
+		Aliases: []string{alias},
+		Short:   fmt.Sprintf("Waits for a %s to meet conditions", k.name),
+		Long:    fmt.Sprintf("Waits for a %s to meet all of the conditions. Supported conditions: %s.", k.name, strings.Join(k.conditionNames(), ", ")),
+		Args:    cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			log.SetLevel(log.InfoLevel)
+			if err := opt.Complete(cmd, args); err != nil {
+				log.WithError(err).Fatal("Error")
+			}
+			if err := opt.Validate(cmd); err != nil {
+				log.WithError(err).Fatal("Error")
+			}
+			dynClient, err := contributils.GetClient()
+			if err != nil {
+				log.WithError(err).Fatal("error creating kube clients")
+			}
+			if err := opt.Run(dynClient, os.Stdout); err != nil {
+				log.WithError(err).Fatal("Error")
+			}
+		},
+	}
+	flags := cmd.Flags()
+	flags.StringVarP(&opt.Namespace, "namespace", "n", "", "Namespace of the resource (default the namespace of the current context)")
+	flags.StringSliceVar(&opt.For, "for", nil, "Conditions to wait for, all of which must be met. May be repeated or comma separated")
+	flags.DurationVar(&opt.Timeout, "timeout", 30*time.Minute, "How long to wait for the conditions")
+	flags.DurationVar(&opt.Interval, "interval", 10*time.Second, "How often the resource is checked")
+	return cmd
+}
+
+func (k *kind) conditionNames() []string {
+	names := make([]string, 0, len(k.conditions))
+	for name := range k.conditions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Complete finishes parsing arguments for the command
+func (o *Options) Complete(cmd *cobra.Command, args []string) error {
+	o.Name = args[0]
+	if o.Namespace == "" {
+		ns, err := contributils.DefaultNamespace()
+		if err != nil {
+			return errors.Wrap(err, "cannot determine default namespace")
+		}
+		o.Namespace = ns
+	}
+	return nil
+}
+
+// Validate ensures that option values make sense
+func (o *Options) Validate(cmd *cobra.Command) error {
+	if len(o.For) == 0 {
+		cmd.Usage()
+		return errors.New("--for is required")
+	}
+	for _, c := range o.For {
+		if _, ok := o.kind.conditions[c]; !ok {
+			return fmt.Errorf("unsupported condition %q for %s, must be one of: %s", c, o.kind.name, strings.Join(o.kind.conditionNames(), ", "))
+		}
+	}
+	if o.Timeout <= 0 || o.Interval <= 0 {
+		return errors.New("--timeout and --interval must be positive")
+	}
+	return nil
+}
+
+// Run executes the command
+func (o *Options) Run(c client.Client, out io.Writer) error {
+	start := time.Now()
+	lastState := ""
+	report := func(state string) {
+		if state == lastState {
+			return
+		}
+		lastState = state
+		fmt.Fprintf(out, "[%s] %s/%s: %s\n", time.Since(start).Round(time.Second), o.kind.name, o.Name, state)
+	}
+
+	err := wait.PollImmediate(o.Interval, o.Timeout, func() (bool, error) {
+		obj := o.kind.newObject()
+		switch err := c.Get(context.TODO(), client.ObjectKey{Namespace: o.Namespace, Name: o.Name}, obj); {
+		case apierrors.IsNotFound(err):
+			report("not found")
+			return false, nil
+		case err != nil:
+			// Keep polling through transient errors, the timeout bounds the wait.
+			log.WithError(err).Warnf("error getting %s, retrying", o.kind.name)
+			return false, nil
+		}
+		report(o.kind.progress(obj))
+		if err := o.kind.failed(obj); err != nil {
+			return false, err
+		}
+		for _, c := range o.For {
+			if !o.kind.conditions[c](obj) {
+				return false, nil
+			}
+		}
+		return true, nil
+	})
+	switch {
+	case err == wait.ErrWaitTimeout:
+		return fmt.Errorf("timed out after %s waiting for %s/%s to be %s, last state: %s",
+			o.Timeout, o.kind.name, o.Name, strings.Join(o.For, ","), lastState)
+	case err != nil:
+		return errors.Wrapf(err, "%s/%s cannot become %s", o.kind.name, o.Name, strings.Join(o.For, ","))
+	}
+	fmt.Fprintf(out, "[%s] %s/%s: condition met: %s\n", time.Since(start).Round(time.Second), o.kind.name, o.Name, strings.Join(o.For, ","))
+	return nil
+}
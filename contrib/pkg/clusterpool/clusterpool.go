@@ -45,6 +45,12 @@ cluster pool using --release-image flag. If neither of these flags are
 specified, default image is obtained from the following URL:
 https://openshift-release.svc.ci.openshift.org/api/v1/releasestream/4-stable/latest
 
+DRY RUN
+To check the artifacts against the current cluster without applying them,
+specify --server-dry-run. The artifacts are then validated by the API server
+and the Hive admission webhooks, and printed as they would be persisted, in
+the format of the output flag (yaml by default).
+
 ENVIRONMENT VARIABLES
 The command will use the following environment variables for its output:
 
@@ -86,6 +92,7 @@ type ClusterPoolOptions struct {
 	homeDir           string
 	log               log.FieldLogger
 	Output            string
+	ServerDryRun      bool
 }
 
 func NewCreateClusterPoolCommand() *cobra.Command {
@@ -139,6 +146,7 @@ create-pool CLUSTER_POOL_NAME --cloud=gcp`,
 	flags.StringVar(&opt.AzureBaseDomainResourceGroupName, "azure-base-domain-resource-group-name", "os4-common", "Resource group where the azure DNS zone for the base domain is found")
 	flags.StringVar(&opt.HibernateAfter, "hibernate-after", "", "Automatically hibernate clusterpool clusters when they have been running for the given duration")
 	flags.StringVarP(&opt.Output, "output", "o", "", "Output of this command (nothing will be created on cluster). Valid values: yaml,json")
+	flags.BoolVar(&opt.ServerDryRun, "server-dry-run", false, "Validate the artifacts with the API server and print them as they would be persisted, without creating anything on cluster")
 
 	return cmd
 }
@@ -223,21 +231,11 @@ func (o *ClusterPoolOptions) run() error {
 		return err
 	}
 
-	if len(o.Output) > 0 {
-		var printer printers.ResourcePrinter
-		if o.Output == "yaml" {
-			printer = &printers.YAMLPrinter{}
-		} else {
-			printer = &printers.JSONPrinter{}
-		}
-		printObjects(objs, scheme, printer)
+	if len(o.Output) > 0 && !o.ServerDryRun {
+		printObjects(objs, scheme, o.printer())
 		return err
 	}
 
-	rh, err := utils.GetResourceHelper(o.log)
-	if err != nil {
-		return err
-	}
 	if len(o.Namespace) == 0 {
 		o.Namespace, err = utils.DefaultNamespace()
 		if err != nil {
@@ -250,6 +248,24 @@ func (o *ClusterPoolOptions) run() error {
 			return errors.Wrapf(err, "cannot create accessor for object of type %T", obj)
 		}
 		accessor.SetNamespace(o.Namespace)
+	}
+	if o.ServerDryRun {
+		c, err := utils.GetClient()
+		if err != nil {
+			return errors.Wrap(err, "cannot get client")
+		}
+		if err := utils.ServerDryRun(c, objs); err != nil {
+			return err
+		}
+		printObjects(objs, scheme, o.printer())
+		return nil
+	}
+
+	rh, err := utils.GetResourceHelper(o.log)
+	if err != nil {
+		return err
+	}
+	for _, obj := range objs {
 		if _, err := rh.ApplyRuntimeObject(obj, scheme); err != nil {
 			return err
 		}
@@ -402,6 +418,14 @@ func (o *ClusterPoolOptions) generateClusterPool(builder *clusterresource.Builde
 	return cp
 }
 
+// printer returns the printer for the output format, which defaults to yaml.
+func (o *ClusterPoolOptions) printer() printers.ResourcePrinter {
+	if o.Output == "json" {
+		return &printers.JSONPrinter{}
+	}
+	return &printers.YAMLPrinter{}
+}
+
 func printObjects(objects []runtime.Object, scheme *runtime.Scheme, printer printers.ResourcePrinter) {
 	typeSetterPrinter := printers.NewTypeSetter(scheme).ToPrinter(printer)
 	switch len(objects) {
@@ -14,6 +14,7 @@ func NewClusterPoolCommand() *cobra.Command {
 	}
 	cmd.AddCommand(NewCreateClusterPoolCommand())
 	cmd.AddCommand(NewClaimClusterPoolCommand())
+	cmd.AddCommand(NewSimulateClusterPoolCommand())
 	return cmd
 
 }
@@ -0,0 +1,489 @@
+package clusterpool
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/pkg/errors"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/contrib/pkg/utils"
+)
+
+const (
+	// defaultInstallDuration is the simulated install duration when the pool has no installed clusters to measure.
+	defaultInstallDuration = 40 * time.Minute
+
+	// defaultResumeDuration is the simulated time to resume a hibernating cluster.
+	defaultResumeDuration = 10 * time.Minute
+
+	simulateLongDesc = `
+OVERVIEW
+The hiveutil clusterpool simulate command replays the past claims of a cluster
+pool against the sizing rules of the pool controller, and reports how long the
+claims would have waited for a cluster and what the clusters waiting in the
+pool would have cost. Several sizes can be simulated at once to compare them.
+
+CLAIMS
+By default, the claims are the ClusterClaims of the pool. Deleted claims are
+gone from the cluster, so for a longer history export the creation times of
+past claims, for example from the hive_clusterclaim_assignment_delay_seconds
+metric, to a file with one RFC 3339 timestamp per line and pass it with
+--claim-history-file.
+
+WAITS
+Pool clusters are created hibernating, apart from the runningCount of the
+pool which are kept running. A claim assigned a hibernating cluster waits for
+it to resume, which takes --resume-duration.
+
+COST
+The cost of the pool is the cost of its installing and unclaimed clusters. It
+is computed from --hourly-cost for installing, resuming and running clusters
+and from --hibernating-hourly-cost for hibernating clusters. Claimed clusters
+are not included.
+
+ACCURACY
+The simulation is an estimate. All installs take the same time and succeed,
+and claim priorities, reservations, readiness gates and inventory are not
+simulated.
+`
+)
+
+type ClusterPoolSimulateOptions struct {
+	ClusterPoolName       string
+	Namespace             string
+	Sizes                 []int
+	MaxSize               int
+	ClaimHistoryFile      string
+	ClaimLifetime         time.Duration
+	InstallDuration       time.Duration
+	ResumeDuration        time.Duration
+	RunningCount          int
+	HourlyCost            float64
+	HibernatingHourlyCost float64
+
+	log log.FieldLogger
+}
+
+func NewSimulateClusterPoolCommand() *cobra.Command {
+	opt := &ClusterPoolSimulateOptions{log: log.WithField("command", "clusterpool simulate")}
+
+	cmd := &cobra.Command{
+		Use:   "simulate CLUSTER_POOL_NAME",
+		Short: "Simulates the claims of a ClusterPool to help choosing its size",
+		Long:  simulateLongDesc,
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			opt.ClusterPoolName = args[0]
+			err := opt.run()
+			if err != nil {
+				opt.log.WithError(err).Fatal("Error")
+			}
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&opt.Namespace, "namespace", "n", "", "Namespace of the cluster pool")
+	flags.IntSliceVar(&opt.Sizes, "sizes", nil, "Pool sizes to simulate. Defaults to the size of the pool")
+	flags.IntVar(&opt.MaxSize, "max-size", 0, "Maximum number of waiting and claimed clusters. Defaults to the maxSize of the pool")
+	flags.StringVar(&opt.ClaimHistoryFile, "claim-history-file", "", "File with the creation times of past claims, one RFC 3339 timestamp per line. Defaults to the ClusterClaims of the pool")
+	flags.DurationVar(&opt.ClaimLifetime, "claim-lifetime", 0, "Lifetime of the claims. Defaults to the lifetime of each claim, or the default claim lifetime of the pool")
+	flags.DurationVar(&opt.InstallDuration, "install-duration", 0, fmt.Sprintf("Time to install a cluster. Defaults to the average of the installed clusters of the pool, or %s", defaultInstallDuration))
+	flags.DurationVar(&opt.ResumeDuration, "resume-duration", defaultResumeDuration, "Time to resume a hibernating cluster")
+	flags.IntVar(&opt.RunningCount, "running-count", -1, "Number of unclaimed clusters kept running. Defaults to the runningCount of the pool")
+	flags.Float64Var(&opt.HourlyCost, "hourly-cost", 0, "Cost of running a cluster for an hour")
+	flags.Float64Var(&opt.HibernatingHourlyCost, "hibernating-hourly-cost", 0, "Cost of a hibernating cluster for an hour")
+
+	return cmd
+}
+
+func (o *ClusterPoolSimulateOptions) run() error {
+	c, err := utils.GetClient()
+	if err != nil {
+		return errors.Wrap(err, "cannot create client")
+	}
+	if len(o.Namespace) == 0 {
+		o.Namespace, err = utils.DefaultNamespace()
+		if err != nil {
+			return errors.Wrap(err, "cannot determine default namespace")
+		}
+	}
+	pool := &hivev1.ClusterPool{}
+	if err := c.Get(context.Background(), types.NamespacedName{Namespace: o.Namespace, Name: o.ClusterPoolName}, pool); err != nil {
+		return errors.Wrap(err, "cannot get cluster pool")
+	}
+
+	claims, err := o.claims(c, pool)
+	if err != nil {
+		return err
+	}
+	if len(claims) == 0 {
+		return errors.New("no claims to simulate")
+	}
+	installDuration := o.InstallDuration
+	if installDuration == 0 {
+		if installDuration, err = o.averageInstallDuration(c, pool); err != nil {
+			return err
+		}
+	}
+
+	sim := poolSimulation{
+		installDuration:       installDuration,
+		resumeDuration:        o.ResumeDuration,
+		runningCount:          int(pool.Spec.RunningCount),
+		hourlyCost:            o.HourlyCost,
+		hibernatingHourlyCost: o.HibernatingHourlyCost,
+	}
+	if o.MaxSize > 0 {
+		sim.maxSize = o.MaxSize
+	} else if pool.Spec.MaxSize != nil {
+		sim.maxSize = int(*pool.Spec.MaxSize)
+	}
+	if pool.Spec.MaxConcurrent != nil {
+		sim.maxConcurrent = int(*pool.Spec.MaxConcurrent)
+	}
+	if o.RunningCount >= 0 {
+		sim.runningCount = o.RunningCount
+	}
+	sizes := o.Sizes
+	if len(sizes) == 0 {
+		sizes = []int{int(pool.Spec.Size)}
+	}
+
+	fmt.Printf("Simulating %d claims from %s to %s with an install duration of %s\n\n",
+		len(claims), claims[0].arrival.Format(time.RFC3339), claims[len(claims)-1].arrival.Format(time.RFC3339), installDuration)
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 3, ' ', 0)
+	fmt.Fprintln(w, "SIZE\tASSIGNED\tWAITED\tMEAN WAIT\tP90 WAIT\tMAX WAIT\tUNASSIGNED\tCOST/DAY")
+	for _, size := range sizes {
+		sim.size = size
+		result := sim.run(claims)
+		costPerDay := "-"
+		if result.duration > 0 {
+			costPerDay = fmt.Sprintf("%.2f", result.cost/result.duration.Hours()*24)
+		}
+		fmt.Fprintf(w, "%d\t%d\t%d\t%s\t%s\t%s\t%d\t%s\n",
+			size, len(result.waits), result.waited,
+			formatWait(result.meanWait()), formatWait(result.percentileWait(90)), formatWait(result.percentileWait(100)),
+			result.unassigned, costPerDay)
+	}
+	return w.Flush()
+}
+
+// claims returns the claims to simulate, sorted by their arrival.
+func (o *ClusterPoolSimulateOptions) claims(c client.Client, pool *hivev1.ClusterPool) ([]simulatedClaim, error) {
+	lifetime := func(claim *hivev1.ClusterClaim) time.Duration {
+		switch {
+		case o.ClaimLifetime > 0:
+			return o.ClaimLifetime
+		case claim != nil && claim.Status.Lifetime != nil:
+			return claim.Status.Lifetime.Duration
+		case claim != nil && claim.Spec.Lifetime != nil:
+			return claim.Spec.Lifetime.Duration
+		case pool.Spec.ClaimLifetime != nil && pool.Spec.ClaimLifetime.Default != nil:
+			return pool.Spec.ClaimLifetime.Default.Duration
+		}
+		return 0
+	}
+
+	var claims []simulatedClaim
+	if o.ClaimHistoryFile != "" {
+		f, err := os.Open(o.ClaimHistoryFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "cannot open claim history file")
+		}
+		defer f.Close()
+		scanner := bufio.NewScanner(f)
+		for line := 1; scanner.Scan(); line++ {
+			text := strings.TrimSpace(scanner.Text())
+			if text == "" || strings.HasPrefix(text, "#") {
+				continue
+			}
+			arrival, err := time.Parse(time.RFC3339, text)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid timestamp on line %d of claim history file", line)
+			}
+			claims = append(claims, simulatedClaim{arrival: arrival, lifetime: lifetime(nil)})
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, errors.Wrap(err, "cannot read claim history file")
+		}
+	} else {
+		claimList := &hivev1.ClusterClaimList{}
+		if err := c.List(context.Background(), claimList, client.InNamespace(pool.Namespace)); err != nil {
+			return nil, errors.Wrap(err, "cannot list cluster claims")
+		}
+		for i := range claimList.Items {
+			claim := &claimList.Items[i]
+			if claim.Spec.ClusterPoolName != pool.Name {
+				continue
+			}
+			claims = append(claims, simulatedClaim{arrival: claim.CreationTimestamp.Time, lifetime: lifetime(claim)})
+		}
+	}
+	sort.SliceStable(claims, func(i, j int) bool { return claims[i].arrival.Before(claims[j].arrival) })
+	return claims, nil
+}
+
+// averageInstallDuration returns the average time it took to install the clusters of the pool.
+func (o *ClusterPoolSimulateOptions) averageInstallDuration(c client.Client, pool *hivev1.ClusterPool) (time.Duration, error) {
+	cdList := &hivev1.ClusterDeploymentList{}
+	if err := c.List(context.Background(), cdList); err != nil {
+		return 0, errors.Wrap(err, "cannot list cluster deployments")
+	}
+	var total time.Duration
+	var count int
+	for _, cd := range cdList.Items {
+		poolRef := cd.Spec.ClusterPoolRef
+		if poolRef == nil || poolRef.Namespace != pool.Namespace || poolRef.PoolName != pool.Name || cd.Status.InstalledTimestamp == nil {
+			continue
+		}
+		total += cd.Status.InstalledTimestamp.Sub(cd.CreationTimestamp.Time)
+		count++
+	}
+	if count == 0 {
+		o.log.Infof("No installed clusters in pool, using an install duration of %s", defaultInstallDuration)
+		return defaultInstallDuration, nil
+	}
+	return (total / time.Duration(count)).Round(time.Minute), nil
+}
+
+func formatWait(d time.Duration) string {
+	return d.Round(time.Second).String()
+}
+
+// simulatedClaim is a claim of a simulated cluster pool.
+type simulatedClaim struct {
+	arrival time.Time
+	// lifetime is how long the claimed cluster lives. Zero means forever.
+	lifetime time.Duration
+}
+
+// poolSimulation follows the sizing rules of the clusterpool controller: the pool installs clusters until its ready
+// and installing clusters cover its size and the pending claims, within the limits of maxSize and maxConcurrent.
+// Installed clusters hibernate unless they are needed to keep runningCount clusters running. Claims are assigned ready
+// clusters in the order they were created, running clusters first, and wait for hibernating clusters to resume.
+type poolSimulation struct {
+	size int
+	// maxSize and maxConcurrent are not limited when zero.
+	maxSize       int
+	maxConcurrent int
+	// runningCount is the number of unclaimed clusters kept running.
+	runningCount          int
+	installDuration       time.Duration
+	resumeDuration        time.Duration
+	hourlyCost            float64
+	hibernatingHourlyCost float64
+}
+
+// simulatedCluster is an unclaimed ready cluster of a simulated pool.
+type simulatedCluster struct {
+	// running is set for running and resuming clusters.
+	running bool
+	// awakeAt is when a running cluster is done resuming.
+	awakeAt time.Time
+}
+
+// simulationResult is the outcome of a simulation.
+type simulationResult struct {
+	// waits are the waits of the claims which were assigned a cluster, including resuming the cluster.
+	waits []time.Duration
+	// waited is the number of claims which were not assigned a running cluster right away.
+	waited int
+	// unassigned is the number of claims which were never assigned a cluster, because of maxSize.
+	unassigned int
+	// cost is the cost of the installing and unclaimed clusters over the duration of the simulation.
+	cost     float64
+	duration time.Duration
+}
+
+func (r simulationResult) meanWait() time.Duration {
+	if len(r.waits) == 0 {
+		return 0
+	}
+	var total time.Duration
+	for _, w := range r.waits {
+		total += w
+	}
+	return total / time.Duration(len(r.waits))
+}
+
+func (r simulationResult) percentileWait(p float64) time.Duration {
+	if len(r.waits) == 0 {
+		return 0
+	}
+	waits := append([]time.Duration{}, r.waits...)
+	sort.Slice(waits, func(i, j int) bool { return waits[i] < waits[j] })
+	return waits[int(math.Ceil(p/100*float64(len(waits))))-1]
+}
+
+// run simulates the pool from the arrival of the first claim, when the pool is assumed to be full, until all the claims
+// are assigned.
+func (s poolSimulation) run(claims []simulatedClaim) simulationResult {
+	result := simulationResult{}
+	start := claims[0].arrival
+	now := start
+	// installing holds the install completion times, and claimed the expiry times of the claimed clusters.
+	var installing, claimed []time.Time
+	var ready []simulatedCluster
+	var pending []simulatedClaim
+	next := 0
+
+	initial := s.size
+	if s.maxSize > 0 && initial > s.maxSize {
+		initial = s.maxSize
+	}
+	for i := 0; i < initial; i++ {
+		ready = append(ready, simulatedCluster{running: i < s.runningCount, awakeAt: start})
+	}
+
+	for {
+		for next < len(claims) && !claims[next].arrival.After(now) {
+			pending = append(pending, claims[next])
+			next++
+		}
+		claimed = removeUntil(claimed, now, true)
+		for _, t := range installing {
+			if !t.After(now) {
+				// Installed clusters stay running only if they are needed in the running tier.
+				ready = append(ready, simulatedCluster{running: countRunning(ready) < s.runningCount, awakeAt: t})
+			}
+		}
+		installing = removeUntil(installing, now, false)
+		// Running clusters are assigned first, the earliest awake first.
+		sort.SliceStable(ready, func(i, j int) bool {
+			if ready[i].running != ready[j].running {
+				return ready[i].running
+			}
+			return ready[i].awakeAt.Before(ready[j].awakeAt)
+		})
+
+		for len(pending) > 0 && len(ready) > 0 {
+			claim, cluster := pending[0], ready[0]
+			pending, ready = pending[1:], ready[1:]
+			awake := now.Add(s.resumeDuration)
+			if cluster.running {
+				awake = latest(now, cluster.awakeAt)
+			}
+			wait := awake.Sub(claim.arrival)
+			result.waits = append(result.waits, wait)
+			if wait > 0 {
+				result.waited++
+			}
+			var expiry time.Time
+			if claim.lifetime > 0 {
+				expiry = now.Add(claim.lifetime)
+			}
+			claimed = append(claimed, expiry)
+		}
+
+		// The running tier resumes hibernating clusters to replace the running clusters which were claimed.
+		for i := range ready {
+			if countRunning(ready) >= s.runningCount {
+				break
+			}
+			if !ready[i].running {
+				ready[i] = simulatedCluster{running: true, awakeAt: now.Add(s.resumeDuration)}
+			}
+		}
+
+		if toAdd := s.size - (len(installing) + len(ready) - len(pending)); toAdd > 0 {
+			if s.maxSize > 0 {
+				toAdd = minInt(toAdd, s.maxSize-len(installing)-len(ready)-len(claimed))
+			}
+			if s.maxConcurrent > 0 {
+				toAdd = minInt(toAdd, s.maxConcurrent-len(installing))
+			}
+			for i := 0; i < toAdd; i++ {
+				installing = append(installing, now.Add(s.installDuration))
+			}
+		}
+
+		if next == len(claims) && len(pending) == 0 {
+			break
+		}
+		var nextEvent time.Time
+		if next < len(claims) {
+			nextEvent = claims[next].arrival
+		}
+		for _, t := range installing {
+			nextEvent = earliest(nextEvent, t)
+		}
+		for _, t := range claimed {
+			nextEvent = earliest(nextEvent, t)
+		}
+		if nextEvent.IsZero() {
+			// Only claims which never expire hold the clusters, so the pending claims can never be assigned.
+			result.unassigned = len(pending)
+			break
+		}
+		result.cost += s.cost(now, nextEvent, len(installing), ready)
+		now = nextEvent
+	}
+	result.duration = now.Sub(start)
+	return result
+}
+
+// cost returns the cost of the installing and ready clusters between from and to.
+func (s poolSimulation) cost(from, to time.Time, installing int, ready []simulatedCluster) float64 {
+	running := countRunning(ready)
+	hours := to.Sub(from).Hours()
+	return float64(installing+running)*hours*s.hourlyCost + float64(len(ready)-running)*hours*s.hibernatingHourlyCost
+}
+
+// countRunning returns the number of running or resuming clusters.
+func countRunning(clusters []simulatedCluster) int {
+	count := 0
+	for _, c := range clusters {
+		if c.running {
+			count++
+		}
+	}
+	return count
+}
+
+// removeUntil returns the times after now. Zero times are kept when keepZero is set.
+func removeUntil(times []time.Time, now time.Time, keepZero bool) []time.Time {
+	var kept []time.Time
+	for _, t := range times {
+		if t.After(now) || (keepZero && t.IsZero()) {
+			kept = append(kept, t)
+		}
+	}
+	return kept
+}
+
+// earliest returns the earliest of the non-zero times.
+func earliest(a, b time.Time) time.Time {
+	if a.IsZero() || (!b.IsZero() && b.Before(a)) {
+		return b
+	}
+	return a
+}
+
+// latest returns the latest of the times.
+func latest(a, b time.Time) time.Time {
+	if b.After(a) {
+		return b
+	}
+	return a
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
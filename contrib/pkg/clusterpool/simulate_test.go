@@ -0,0 +1,104 @@
+package clusterpool
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPoolSimulation(t *testing.T) {
+	start := time.Date(2021, 3, 1, 8, 0, 0, 0, time.UTC)
+	claimsAt := func(offsets ...time.Duration) []simulatedClaim {
+		var claims []simulatedClaim
+		for _, offset := range offsets {
+			claims = append(claims, simulatedClaim{arrival: start.Add(offset), lifetime: time.Hour})
+		}
+		return claims
+	}
+	tests := []struct {
+		name               string
+		sim                poolSimulation
+		claims             []simulatedClaim
+		expectedWaits      []time.Duration
+		expectedWaited     int
+		expectedUnassigned int
+		expectedCost       float64
+	}{
+		{
+			name:           "empty pool waits for install and resume",
+			sim:            poolSimulation{size: 0},
+			claims:         claimsAt(0, 10*time.Minute),
+			expectedWaits:  []time.Duration{50 * time.Minute, 50 * time.Minute},
+			expectedWaited: 2,
+		},
+		{
+			name:           "hibernating clusters wait for resume",
+			sim:            poolSimulation{size: 2},
+			claims:         claimsAt(0, 0),
+			expectedWaits:  []time.Duration{10 * time.Minute, 10 * time.Minute},
+			expectedWaited: 2,
+		},
+		{
+			name:          "running clusters assigned right away",
+			sim:           poolSimulation{size: 2, runningCount: 2},
+			claims:        claimsAt(0, 0),
+			expectedWaits: []time.Duration{0, 0},
+		},
+		{
+			name:           "claim waits for running tier to resume a cluster",
+			sim:            poolSimulation{size: 2, runningCount: 1},
+			claims:         claimsAt(0, time.Minute),
+			expectedWaits:  []time.Duration{0, 9 * time.Minute},
+			expectedWaited: 1,
+		},
+		{
+			name: "claims beyond max size unassigned",
+			sim:  poolSimulation{size: 1, maxSize: 1},
+			claims: []simulatedClaim{
+				{arrival: start},
+				{arrival: start.Add(time.Minute)},
+			},
+			expectedWaits:      []time.Duration{10 * time.Minute},
+			expectedWaited:     1,
+			expectedUnassigned: 1,
+		},
+		{
+			name:           "cost of installing and hibernating clusters",
+			sim:            poolSimulation{size: 1, hourlyCost: 10, hibernatingHourlyCost: 1},
+			claims:         claimsAt(0, 2*time.Hour),
+			expectedWaits:  []time.Duration{10 * time.Minute, 10 * time.Minute},
+			expectedWaited: 2,
+			// Installing for 40m, then hibernating for 80m.
+			expectedCost: 10*40.0/60 + 1*80.0/60,
+		},
+		{
+			name:          "cost of running clusters",
+			sim:           poolSimulation{size: 1, runningCount: 1, hourlyCost: 10, hibernatingHourlyCost: 1},
+			claims:        claimsAt(0, 2*time.Hour),
+			expectedWaits: []time.Duration{0, 0},
+			// Installing for 40m, then running for 80m.
+			expectedCost: 10 * 2.0,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.sim.installDuration = 40 * time.Minute
+			test.sim.resumeDuration = 10 * time.Minute
+			result := test.sim.run(test.claims)
+			assert.Equal(t, test.expectedWaits, result.waits, "unexpected waits")
+			assert.Equal(t, test.expectedWaited, result.waited, "unexpected number of claims which waited")
+			assert.Equal(t, test.expectedUnassigned, result.unassigned, "unexpected number of unassigned claims")
+			assert.InDelta(t, test.expectedCost, result.cost, 0.001, "unexpected cost")
+		})
+	}
+}
+
+func TestSimulationResultWaits(t *testing.T) {
+	result := simulationResult{waits: []time.Duration{4 * time.Minute, 0, time.Minute, 3 * time.Minute, 2 * time.Minute}}
+	assert.Equal(t, 2*time.Minute, result.meanWait(), "unexpected mean wait")
+	assert.Equal(t, 2*time.Minute, result.percentileWait(50), "unexpected median wait")
+	assert.Equal(t, 4*time.Minute, result.percentileWait(90), "unexpected p90 wait")
+	assert.Equal(t, 4*time.Minute, result.percentileWait(100), "unexpected max wait")
+	assert.Equal(t, time.Duration(0), simulationResult{}.meanWait(), "unexpected mean wait without claims")
+}
@@ -26,8 +26,10 @@ import (
 	awsutils "github.com/openshift/hive/contrib/pkg/utils/aws"
 	azurecredutil "github.com/openshift/hive/contrib/pkg/utils/azure"
 	gcputils "github.com/openshift/hive/contrib/pkg/utils/gcp"
+	ociutils "github.com/openshift/hive/contrib/pkg/utils/oci"
 	openstackutils "github.com/openshift/hive/contrib/pkg/utils/openstack"
 	ovirtutils "github.com/openshift/hive/contrib/pkg/utils/ovirt"
+	powervsutils "github.com/openshift/hive/contrib/pkg/utils/powervs"
 	"github.com/openshift/hive/pkg/clusterresource"
 	"github.com/openshift/hive/pkg/constants"
 	"github.com/openshift/hive/pkg/gcpclient"
@@ -42,7 +44,10 @@ generated along with corresponding secrets and then applied to the current
 cluster. If you don't need secrets generated, specify --include-secrets=false
 in the command line. If you don't want to apply the cluster deployment and
 only output it locally, specify the output flag (-o json) or (-o yaml) to
-specify your output format.
+specify your output format. To check the artifacts against the current cluster
+without applying them, specify --server-dry-run. The artifacts are then
+validated by the API server and the Hive admission webhooks, and printed as
+they would be persisted, in the format of the output flag (yaml by default).
 
 IMAGES
 An existing ClusterImageSet can be specified with the --image-set
@@ -91,6 +96,8 @@ const (
 	cloudOpenStack       = "openstack"
 	cloudVSphere         = "vsphere"
 	cloudOVirt           = "ovirt"
+	cloudOCI             = "oci"
+	cloudPowerVS         = "powervs"
 
 	testFailureManifest = `apiVersion: v1
 kind: NotARealSecret
@@ -109,12 +116,15 @@ var (
 		cloudOpenStack: true,
 		cloudVSphere:   true,
 		cloudOVirt:     true,
+		cloudOCI:       true,
+		cloudPowerVS:   true,
 	}
 )
 
 // Options is the set of options to generate and apply a new cluster deployment
 type Options struct {
 	Name                              string
+	DisplayName                       string
 	Namespace                         string
 	SSHPublicKeyFile                  string
 	SSHPublicKey                      string
@@ -137,6 +147,7 @@ type Options struct {
 	UseClusterImageSet                bool
 	ManageDNS                         bool
 	Output                            string
+	ServerDryRun                      bool
 	IncludeSecrets                    bool
 	InstallOnce                       bool
 	UninstallOnce                     bool
@@ -193,6 +204,17 @@ type Options struct {
 	OvirtIngressVIP      string
 	OvirtCACerts         string
 
+	// OCI
+	OCICompartmentID    string
+	OCIDNSCompartmentID string
+	OCIShape            string
+
+	// PowerVS
+	PowerVSZone              string
+	PowerVSServiceInstanceID string
+	PowerVSNetworkName       string
+	PowerVSSysType           string
+
 	homeDir string
 	log     log.FieldLogger
 }
@@ -242,8 +264,9 @@ create-cluster CLUSTER_DEPLOYMENT_NAME --cloud=ovirt --ovirt-api-vip 192.168.1.2
 	}
 
 	flags := cmd.Flags()
-	flags.StringVar(&opt.Cloud, "cloud", cloudAWS, "Cloud provider: aws|azure|gcp|openstack")
+	flags.StringVar(&opt.Cloud, "cloud", cloudAWS, "Cloud provider: aws|azure|gcp|openstack|oci|powervs")
 	flags.StringVarP(&opt.Namespace, "namespace", "n", "", "Namespace to create cluster deployment in")
+	flags.StringVar(&opt.DisplayName, "display-name", "", "Human-friendly name for the cluster, which can be changed after it is created")
 	flags.StringVar(&opt.SSHPrivateKeyFile, "ssh-private-key-file", "", "file name containing private key contents")
 	flags.StringVar(&opt.SSHPublicKeyFile, "ssh-public-key-file", defaultSSHPublicKeyFile, "file name of SSH public key for cluster")
 	flags.StringVar(&opt.SSHPublicKey, "ssh-public-key", "", "SSH public key for cluster")
@@ -261,9 +284,10 @@ create-cluster CLUSTER_DEPLOYMENT_NAME --cloud=ovirt --ovirt-api-vip 192.168.1.2
 	flags.StringVar(&opt.ReleaseImageSource, "release-image-source", "https://openshift-release.svc.ci.openshift.org/api/v1/releasestream/4-stable/latest", "URL to JSON describing the release image pull spec")
 	flags.StringVar(&opt.ServingCert, "serving-cert", "", "Serving certificate for control plane and routes")
 	flags.StringVar(&opt.ServingCertKey, "serving-cert-key", "", "Serving certificate key for control plane and routes")
-	flags.BoolVar(&opt.ManageDNS, "manage-dns", false, "Manage this cluster's DNS. This is only available for AWS and GCP.")
+	flags.BoolVar(&opt.ManageDNS, "manage-dns", false, "Manage this cluster's DNS. This is only available for AWS, GCP and OCI.")
 	flags.BoolVar(&opt.UseClusterImageSet, "use-image-set", true, "If true, use a cluster image set for this cluster")
 	flags.StringVarP(&opt.Output, "output", "o", "", "Output of this command (nothing will be created on cluster). Valid values: yaml,json")
+	flags.BoolVar(&opt.ServerDryRun, "server-dry-run", false, "Validate the artifacts with the API server and print them as they would be persisted, without creating anything on cluster")
 	flags.BoolVar(&opt.IncludeSecrets, "include-secrets", true, "Include secrets along with ClusterDeployment")
 	flags.BoolVar(&opt.InstallOnce, "install-once", false, "Run the install only one time and fail if not successful")
 	flags.BoolVar(&opt.UninstallOnce, "uninstall-once", false, "Run the uninstall only one time and fail if not successful")
@@ -272,7 +296,7 @@ create-cluster CLUSTER_DEPLOYMENT_NAME --cloud=ovirt --ovirt-api-vip 192.168.1.2
 	flags.BoolVar(&opt.CreateSampleSyncsets, "create-sample-syncsets", false, "Create a set of sample syncsets for testing")
 	flags.StringVar(&opt.ManifestsDir, "manifests", "", "Directory containing manifests to add during installation")
 	flags.StringVar(&opt.MachineNetwork, "machine-network", "10.0.0.0/16", "Cluster's MachineNetwork to pass to the installer")
-	flags.StringVar(&opt.Region, "region", "", "Region to which to install the cluster. This is only relevant to AWS, Azure, and GCP.")
+	flags.StringVar(&opt.Region, "region", "", "Region to which to install the cluster. This is only relevant to AWS, Azure, GCP, OCI and PowerVS.")
 	flags.StringSliceVarP(&opt.Labels, "labels", "l", nil, "Label to apply to the ClusterDeployment (key=val)")
 	flags.StringSliceVarP(&opt.Annotations, "annotations", "a", nil, "Annotation to apply to the ClusterDeployment (key=val)")
 	flags.BoolVar(&opt.SkipMachinePools, "skip-machine-pools", false, "Skip generation of Hive MachinePools for day 2 MachineSet management")
@@ -322,6 +346,17 @@ OpenShift Installer publishes all the services of the cluster like API server an
 	flags.StringVar(&opt.OvirtIngressVIP, "ovirt-ingress-vip", "", "External IP which routes to the default ingress controller")
 	flags.StringVar(&opt.OvirtCACerts, "ovirt-ca-certs", "", "Path to oVirt CA certificate, multiple CA paths can be : delimited")
 
+	// OCI flags
+	flags.StringVar(&opt.OCICompartmentID, "oci-compartment-id", "", "OCID of the compartment in which to create cluster resources")
+	flags.StringVar(&opt.OCIDNSCompartmentID, "oci-dns-compartment-id", "", "OCID of the compartment holding the cluster DNS zone (defaults to --oci-compartment-id)")
+	flags.StringVar(&opt.OCIShape, "oci-shape", "VM.Standard.E4.Flex", "Compute shape to use for worker nodes")
+
+	// PowerVS flags
+	flags.StringVar(&opt.PowerVSZone, "powervs-zone", "", "PowerVS zone in which to create the cluster (eg. dal12)")
+	flags.StringVar(&opt.PowerVSServiceInstanceID, "powervs-service-instance-id", "", "GUID of the PowerVS service instance in which to create cluster resources")
+	flags.StringVar(&opt.PowerVSNetworkName, "powervs-network-name", "", "Name of an existing network in the service instance to use for the cluster")
+	flags.StringVar(&opt.PowerVSSysType, "powervs-sys-type", "s922", "System type to use for worker nodes")
+
 	// Additional CA Trust Bundle
 	flags.StringVar(&opt.AdditionalTrustBundle, "additional-trust-bundle", "", "Path to a CA Trust Bundle which will be added to the nodes trusted certificate store.")
 
@@ -340,6 +375,10 @@ func (o *Options) Complete(cmd *cobra.Command, args []string) error {
 			o.Region = "centralus"
 		case cloudGCP:
 			o.Region = "us-east1"
+		case cloudOCI:
+			o.Region = "us-ashburn-1"
+		case cloudPowerVS:
+			o.Region = "dal"
 		}
 	}
 
@@ -418,7 +457,7 @@ func (o *Options) Validate(cmd *cobra.Command) error {
 
 	if o.Region != "" {
 		switch c := o.Cloud; c {
-		case cloudAWS, cloudAzure, cloudGCP:
+		case cloudAWS, cloudAzure, cloudGCP, cloudOCI, cloudPowerVS:
 		default:
 			return fmt.Errorf("cannot specify region when cloud is %q", c)
 		}
@@ -449,18 +488,8 @@ func (o *Options) Run() error {
 	if err != nil {
 		return err
 	}
-	if len(o.Output) > 0 {
-		var printer printers.ResourcePrinter
-		if o.Output == "yaml" {
-			printer = &printers.YAMLPrinter{}
-		} else {
-			printer = &printers.JSONPrinter{}
-		}
-		printObjects(objs, scheme.Scheme, printer)
-		return err
-	}
-	rh, err := utils.GetResourceHelper(o.log)
-	if err != nil {
+	if len(o.Output) > 0 && !o.ServerDryRun {
+		printObjects(objs, scheme.Scheme, o.printer())
 		return err
 	}
 	if len(o.Namespace) == 0 {
@@ -477,6 +506,24 @@ func (o *Options) Run() error {
 			return err
 		}
 		accessor.SetNamespace(o.Namespace)
+	}
+	if o.ServerDryRun {
+		c, err := utils.GetClient()
+		if err != nil {
+			o.log.WithError(err).Error("Cannot get client")
+			return err
+		}
+		if err := utils.ServerDryRun(c, objs); err != nil {
+			return err
+		}
+		printObjects(objs, scheme.Scheme, o.printer())
+		return nil
+	}
+	rh, err := utils.GetResourceHelper(o.log)
+	if err != nil {
+		return err
+	}
+	for _, obj := range objs {
 		if _, err := rh.ApplyRuntimeObject(obj, scheme.Scheme); err != nil {
 			return err
 		}
@@ -530,6 +577,7 @@ func (o *Options) GenerateObjects() ([]runtime.Object, error) {
 
 	builder := &clusterresource.Builder{
 		Name:                     o.Name,
+		DisplayName:              o.DisplayName,
 		Namespace:                o.Namespace,
 		WorkerNodesCount:         o.WorkerNodesCount,
 		PullSecret:               pullSecret,
@@ -735,6 +783,42 @@ func (o *Options) GenerateObjects() ([]runtime.Object, error) {
 		}
 		builder.CloudBuilder = oVirtProvider
 		builder.SkipMachinePools = true
+	case cloudOCI:
+		if o.OCICompartmentID == "" {
+			return nil, errors.New("must provide --oci-compartment-id")
+		}
+		creds, err := ociutils.GetCreds(o.CredsFile)
+		if err != nil {
+			return nil, err
+		}
+		ociProvider := &clusterresource.OCICloudBuilder{
+			Credentials:      creds,
+			Region:           o.Region,
+			CompartmentID:    o.OCICompartmentID,
+			DNSCompartmentID: o.OCIDNSCompartmentID,
+			Shape:            o.OCIShape,
+		}
+		builder.CloudBuilder = ociProvider
+	case cloudPowerVS:
+		if o.PowerVSServiceInstanceID == "" {
+			return nil, errors.New("must provide --powervs-service-instance-id")
+		}
+		if o.PowerVSZone == "" {
+			return nil, errors.New("must provide --powervs-zone")
+		}
+		apiKey, err := powervsutils.GetAPIKey()
+		if err != nil {
+			return nil, err
+		}
+		powerVSProvider := &clusterresource.PowerVSCloudBuilder{
+			APIKey:            apiKey,
+			Region:            o.Region,
+			Zone:              o.PowerVSZone,
+			ServiceInstanceID: o.PowerVSServiceInstanceID,
+			NetworkName:       o.PowerVSNetworkName,
+			SysType:           o.PowerVSSysType,
+		}
+		builder.CloudBuilder = powerVSProvider
 	}
 
 	if o.Internal {
@@ -972,6 +1056,14 @@ func sampleCM(name string) *corev1.ConfigMap {
 	}
 }
 
+// printer returns the printer for the output format, which defaults to yaml.
+func (o *Options) printer() printers.ResourcePrinter {
+	if o.Output == "json" {
+		return &printers.JSONPrinter{}
+	}
+	return &printers.YAMLPrinter{}
+}
+
 func printObjects(objects []runtime.Object, scheme *runtime.Scheme, printer printers.ResourcePrinter) {
 	typeSetterPrinter := printers.NewTypeSetter(scheme).ToPrinter(printer)
 	switch len(objects) {
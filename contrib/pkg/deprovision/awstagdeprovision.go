@@ -1,6 +1,7 @@
 package deprovision
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -8,13 +9,19 @@ import (
 	"strings"
 	"time"
 
+	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 
+	"k8s.io/apimachinery/pkg/types"
+
 	"github.com/openshift/installer/pkg/destroy/aws"
 	"github.com/openshift/library-go/pkg/controller/fileobserver"
 
+	"github.com/openshift/hive/contrib/pkg/utils"
+	"github.com/openshift/hive/pkg/awsclient"
 	"github.com/openshift/hive/pkg/constants"
+	hivedeprovision "github.com/openshift/hive/pkg/deprovision"
 )
 
 // NewDeprovisionAWSWithTagsCommand is the entrypoint to create the 'aws-tag-deprovision' subcommand
@@ -22,6 +29,9 @@ import (
 func NewDeprovisionAWSWithTagsCommand() *cobra.Command {
 	opt := &aws.ClusterUninstaller{}
 	var logLevel string
+	var workersPerType int
+	var stsName string
+	deprovision := types.NamespacedName{}
 	cmd := &cobra.Command{
 		Use:   "aws-tag-deprovision KEY=VALUE ...",
 		Short: "Deprovision AWS assets (as created by openshift-installer) with the given tag(s)",
@@ -79,17 +89,75 @@ func NewDeprovisionAWSWithTagsCommand() *cobra.Command {
 				}()
 			}
 
-			if err := opt.Run(); err != nil {
-				log.WithError(err).Fatal("Runtime error")
+			if workersPerType <= 0 {
+				if err := opt.Run(); err != nil {
+					log.WithError(err).Fatal("Runtime error")
+				}
+			} else {
+				destroyer, err := newAWSParallelDestroyer(opt, workersPerType, deprovision)
+				if err != nil {
+					log.WithError(err).Fatal("could not set up parallel deprovision")
+				}
+				if err := destroyer.Run(context.Background()); err != nil {
+					log.WithError(err).Fatal("Runtime error")
+				}
+			}
+			if stsName != "" {
+				awsClient, err := awsclient.NewClient(nil, "", "", opt.Region)
+				if err != nil {
+					log.WithError(err).Fatal("could not create AWS client")
+				}
+				if err := hivedeprovision.DeleteAWSSTSResources(awsClient, stsName, opt.Region, opt.Logger); err != nil {
+					log.WithError(err).Fatal("could not delete STS resources")
+				}
 			}
 		},
 	}
 	flags := cmd.Flags()
 	flags.StringVar(&logLevel, "loglevel", "info", "log level, one of: debug, info, warn, error, fatal, panic")
 	flags.StringVar(&opt.Region, "region", "us-east-1", "AWS region to use")
+	flags.IntVar(&workersPerType, "workers-per-type", 10, "maximum number of resources of each type deleted concurrently before the remaining resources are deleted one at a time, 0 to delete all resources one at a time")
+	flags.StringVar(&stsName, "sts-name", "", "name with which ccoctl created the IAM roles, the OIDC provider and the S3 bucket of the cluster for the AWS Security Token Service, which are also deleted")
+	flags.StringVar(&deprovision.Name, "clusterdeprovision", "", "name of the ClusterDeprovision in whose status the progress of the deprovision is reported")
+	flags.StringVar(&deprovision.Namespace, "clusterdeprovision-namespace", "", "namespace of the ClusterDeprovision in whose status the progress of the deprovision is reported")
 	return cmd
 }
 
+// newAWSParallelDestroyer returns a destroyer which deletes the resources of the cluster in parallel before running the
+// uninstaller, reporting its progress in the status of the ClusterDeprovision when one is given.
+func newAWSParallelDestroyer(o *aws.ClusterUninstaller, workersPerType int, deprovision types.NamespacedName) (*hivedeprovision.ParallelDestroyer, error) {
+	awsClient, err := awsclient.NewClient(nil, "", "", o.Region)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create AWS client")
+	}
+	filters := make([]map[string]string, len(o.Filters))
+	for i, filter := range o.Filters {
+		filters[i] = filter
+	}
+	destroyer := hivedeprovision.NewAWSParallelDestroyer(
+		awsClient,
+		filters,
+		func(ctx context.Context) error {
+			_, err := o.RunWithContext(ctx)
+			return err
+		},
+		o.Logger,
+	)
+	destroyer.WorkersPerType = workersPerType
+	if deprovision.Name != "" {
+		c, err := utils.GetClient()
+		if err != nil {
+			return nil, errors.Wrap(err, "could not create kube client")
+		}
+		destroyer.Reporter = &hivedeprovision.StatusProgressReporter{
+			Client:      c,
+			Deprovision: deprovision,
+			Logger:      o.Logger,
+		}
+	}
+	return destroyer, nil
+}
+
 func completeAWSUninstaller(o *aws.ClusterUninstaller, logLevel string, args []string) error {
 
 	for _, arg := range args {
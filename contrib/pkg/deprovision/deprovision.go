@@ -36,6 +36,9 @@ func NewDeprovisionCommand() *cobra.Command {
 	cmd.AddCommand(NewDeprovisionOpenStackCommand())
 	cmd.AddCommand(NewDeprovisionvSphereCommand())
 	cmd.AddCommand(NewDeprovisionOvirtCommand())
+	cmd.AddCommand(NewDeprovisionOCICommand())
+	cmd.AddCommand(NewDeprovisionPowerVSCommand())
+	cmd.AddCommand(NewDeprovisionExternalWebhookCommand())
 	return cmd
 }
 
@@ -0,0 +1,98 @@
+package deprovision
+
+import (
+	"fmt"
+	"os"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/externaldestroyer"
+)
+
+// externalWebhookOptions is the set of options to deprovision a cluster with an external destroyer webhook
+type externalWebhookOptions struct {
+	logLevel                   string
+	url                        string
+	clusterID                  string
+	clusterDeploymentName      string
+	clusterDeploymentNamespace string
+	infraID                    string
+}
+
+// NewDeprovisionExternalWebhookCommand is the entrypoint to create the external webhook deprovision subcommand
+func NewDeprovisionExternalWebhookCommand() *cobra.Command {
+	opt := &externalWebhookOptions{}
+	cmd := &cobra.Command{
+		Use:   "external-webhook INFRAID --url=URL --cluster-deployment-name=NAME --cluster-deployment-namespace=NAMESPACE",
+		Short: "Deprovision a cluster on externally created infrastructure by calling its destroyer webhook",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := opt.Complete(cmd, args); err != nil {
+				log.WithError(err).Fatal("failed to complete options")
+			}
+			if err := opt.Validate(cmd); err != nil {
+				log.WithError(err).Fatal("validation failed")
+			}
+			if err := opt.Run(); err != nil {
+				log.WithError(err).Fatal("Runtime error")
+			}
+		},
+	}
+	flags := cmd.Flags()
+	flags.StringVar(&opt.logLevel, "loglevel", "info", "log level, one of: debug, info, warn, error, fatal, panic")
+	flags.StringVar(&opt.url, "url", "", "URL of the destroyer webhook")
+	flags.StringVar(&opt.clusterID, "cluster-id", "", "Cluster ID of the cluster")
+	flags.StringVar(&opt.clusterDeploymentName, "cluster-deployment-name", "", "Name of the ClusterDeployment")
+	flags.StringVar(&opt.clusterDeploymentNamespace, "cluster-deployment-namespace", "", "Namespace of the ClusterDeployment")
+	return cmd
+}
+
+// Complete finishes parsing arguments for the command
+func (o *externalWebhookOptions) Complete(cmd *cobra.Command, args []string) error {
+	o.infraID = args[0]
+	return nil
+}
+
+// Validate ensures that option values make sense
+func (o *externalWebhookOptions) Validate(cmd *cobra.Command) error {
+	if o.url == "" {
+		cmd.Usage()
+		log.Info("URL is required")
+		return fmt.Errorf("missing url")
+	}
+	return nil
+}
+
+// Run executes the command
+func (o *externalWebhookOptions) Run() error {
+	// Set log level
+	level, err := log.ParseLevel(o.logLevel)
+	if err != nil {
+		log.WithError(err).Error("cannot parse log level")
+		return err
+	}
+
+	logger := log.NewEntry(&log.Logger{
+		Out: os.Stdout,
+		Formatter: &log.TextFormatter{
+			FullTimestamp: true,
+		},
+		Hooks: make(log.LevelHooks),
+		Level: level,
+	}).WithField("infraID", o.infraID)
+
+	destroyer := &externaldestroyer.WebhookDestroyer{
+		URL:   o.url,
+		Token: os.Getenv(constants.DestroyerWebhookTokenEnvVar),
+		Request: externaldestroyer.Request{
+			InfraID:                    o.infraID,
+			ClusterID:                  o.clusterID,
+			ClusterDeploymentName:      o.clusterDeploymentName,
+			ClusterDeploymentNamespace: o.clusterDeploymentNamespace,
+		},
+		Logger: logger,
+	}
+	return destroyer.Run()
+}
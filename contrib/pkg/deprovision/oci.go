@@ -0,0 +1,106 @@
+package deprovision
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	ociutils "github.com/openshift/hive/contrib/pkg/utils/oci"
+	"github.com/openshift/hive/pkg/ociclient"
+)
+
+// ociOptions is the set of options to deprovision an OCI cluster
+type ociOptions struct {
+	logLevel      string
+	infraID       string
+	region        string
+	compartmentID string
+	client        ociclient.Client
+}
+
+// NewDeprovisionOCICommand is the entrypoint to create the OCI deprovision subcommand
+func NewDeprovisionOCICommand() *cobra.Command {
+	opt := &ociOptions{}
+	cmd := &cobra.Command{
+		Use:   "oci INFRAID --region=REGION --compartment-id=COMPARTMENT",
+		Short: "Deprovision OCI assets (as created by openshift-installer)",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := opt.Complete(cmd, args); err != nil {
+				log.WithError(err).Fatal("failed to complete options")
+			}
+			if err := opt.Validate(cmd); err != nil {
+				log.WithError(err).Fatal("validation failed")
+			}
+			if err := opt.Run(); err != nil {
+				log.WithError(err).Fatal("Runtime error")
+			}
+		},
+	}
+	flags := cmd.Flags()
+	flags.StringVar(&opt.logLevel, "loglevel", "info", "log level, one of: debug, info, warn, error, fatal, panic")
+	flags.StringVar(&opt.region, "region", "", "OCI region where the cluster is installed")
+	flags.StringVar(&opt.compartmentID, "compartment-id", "", "OCID of the compartment where the cluster is installed")
+	return cmd
+}
+
+// Complete finishes parsing arguments for the command
+func (o *ociOptions) Complete(cmd *cobra.Command, args []string) error {
+	o.infraID = args[0]
+	return nil
+}
+
+// Validate ensures that option values make sense
+func (o *ociOptions) Validate(cmd *cobra.Command) error {
+	if o.region == "" {
+		cmd.Usage()
+		log.Info("Region is required")
+		return fmt.Errorf("missing region")
+	}
+	if o.compartmentID == "" {
+		cmd.Usage()
+		log.Info("Compartment ID is required")
+		return fmt.Errorf("missing compartment ID")
+	}
+
+	creds, err := ociutils.GetCreds("")
+	if err != nil {
+		return errors.Wrap(err, "failed to get OCI credentials")
+	}
+	client, err := ociclient.NewClient(creds, o.region)
+	if err != nil {
+		return errors.Wrap(err, "could not create OCI client")
+	}
+	o.client = client
+	return nil
+}
+
+// Run executes the command
+func (o *ociOptions) Run() error {
+	// Set log level
+	level, err := log.ParseLevel(o.logLevel)
+	if err != nil {
+		log.WithError(err).Error("cannot parse log level")
+		return err
+	}
+
+	logger := log.NewEntry(&log.Logger{
+		Out: os.Stdout,
+		Formatter: &log.TextFormatter{
+			FullTimestamp: true,
+		},
+		Hooks: make(log.LevelHooks),
+		Level: level,
+	})
+
+	uninstaller := &ociclient.ClusterUninstaller{
+		Client:        o.client,
+		CompartmentID: o.compartmentID,
+		InfraID:       o.infraID,
+		Logger:        logger,
+	}
+	return uninstaller.Run()
+}
@@ -0,0 +1,107 @@
+package deprovision
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	powervsutils "github.com/openshift/hive/contrib/pkg/utils/powervs"
+	"github.com/openshift/hive/pkg/powervsclient"
+)
+
+// powerVSOptions is the set of options to deprovision a PowerVS cluster
+type powerVSOptions struct {
+	logLevel          string
+	infraID           string
+	region            string
+	zone              string
+	serviceInstanceID string
+	client            powervsclient.Client
+}
+
+// NewDeprovisionPowerVSCommand is the entrypoint to create the PowerVS deprovision subcommand
+func NewDeprovisionPowerVSCommand() *cobra.Command {
+	opt := &powerVSOptions{}
+	cmd := &cobra.Command{
+		Use:   "powervs INFRAID --region=REGION --zone=ZONE --service-instance-id=GUID",
+		Short: "Deprovision IBM PowerVS assets (as created by openshift-installer)",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := opt.Complete(cmd, args); err != nil {
+				log.WithError(err).Fatal("failed to complete options")
+			}
+			if err := opt.Validate(cmd); err != nil {
+				log.WithError(err).Fatal("validation failed")
+			}
+			if err := opt.Run(); err != nil {
+				log.WithError(err).Fatal("Runtime error")
+			}
+		},
+	}
+	flags := cmd.Flags()
+	flags.StringVar(&opt.logLevel, "loglevel", "info", "log level, one of: debug, info, warn, error, fatal, panic")
+	flags.StringVar(&opt.region, "region", "", "IBM Cloud region where the cluster is installed")
+	flags.StringVar(&opt.zone, "zone", "", "PowerVS zone where the cluster is installed")
+	flags.StringVar(&opt.serviceInstanceID, "service-instance-id", "", "GUID of the PowerVS service instance where the cluster is installed")
+	return cmd
+}
+
+// Complete finishes parsing arguments for the command
+func (o *powerVSOptions) Complete(cmd *cobra.Command, args []string) error {
+	o.infraID = args[0]
+	return nil
+}
+
+// Validate ensures that option values make sense
+func (o *powerVSOptions) Validate(cmd *cobra.Command) error {
+	if o.region == "" {
+		cmd.Usage()
+		log.Info("Region is required")
+		return fmt.Errorf("missing region")
+	}
+	if o.serviceInstanceID == "" {
+		cmd.Usage()
+		log.Info("Service instance ID is required")
+		return fmt.Errorf("missing service instance ID")
+	}
+
+	apiKey, err := powervsutils.GetAPIKey()
+	if err != nil {
+		return errors.Wrap(err, "failed to get PowerVS API key")
+	}
+	client, err := powervsclient.NewClient(apiKey, o.region, o.serviceInstanceID)
+	if err != nil {
+		return errors.Wrap(err, "could not create PowerVS client")
+	}
+	o.client = client
+	return nil
+}
+
+// Run executes the command
+func (o *powerVSOptions) Run() error {
+	// Set log level
+	level, err := log.ParseLevel(o.logLevel)
+	if err != nil {
+		log.WithError(err).Error("cannot parse log level")
+		return err
+	}
+
+	logger := log.NewEntry(&log.Logger{
+		Out: os.Stdout,
+		Formatter: &log.TextFormatter{
+			FullTimestamp: true,
+		},
+		Hooks: make(log.LevelHooks),
+		Level: level,
+	}).WithField("zone", o.zone)
+
+	uninstaller := &powervsclient.ClusterUninstaller{
+		Client:  o.client,
+		InfraID: o.infraID,
+		Logger:  logger,
+	}
+	return uninstaller.Run()
+}
@@ -0,0 +1,165 @@
+package query
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	contributils "github.com/openshift/hive/contrib/pkg/utils"
+)
+
+const (
+	// installedAttribute is the attribute of a cluster which is "true" once the cluster is installed.
+	installedAttribute = "installed"
+	// powerStateAttribute is the attribute of a cluster with its desired power state.
+	powerStateAttribute = "power-state"
+	// operatorAttributePrefix prefixes the attributes of a cluster with the status of the conditions of its cluster
+	// operators, as operator.<name>.<condition type>.
+	operatorAttributePrefix = "operator."
+)
+
+// Options is the set of options for the fleet query.
+type Options struct {
+	// Selector is the label selector matched against the attributes of every cluster.
+	Selector string
+	// Namespace limits the query to the clusters in the namespace. All namespaces are queried when empty.
+	Namespace string
+	// Output is the output format, either a table or just the names of the matching clusters.
+	Output string
+
+	selector labels.Selector
+}
+
+// NewQueryCommand creates a command that queries the fleet of clusters with a label selector.
+func NewQueryCommand() *cobra.Command {
+	opt := &Options{}
+	cmd := &cobra.Command{
+		Use:   "query --selector SELECTOR",
+		Short: "Prints the clusters matching a selector",
+		Long: `Matches a label selector against the attributes of every ClusterDeployment and its ClusterState, and prints
+the clusters which match. The attributes of a cluster are the labels of its ClusterDeployment, such as
+hive.openshift.io/cluster-region, and:
+  installed                    "true" once the cluster is installed, "false" otherwise
+  power-state                  the desired power state of the cluster, when set
+  operator.<name>.<condition>  the status of a condition of a cluster operator from the ClusterState, for example
+                               operator.ingress.degraded=True
+
+The selector supports the usual label selector operators: =, ==, !=, in, notin, exists (key) and does not
+exist (!key).
+
+Example:
+  hiveutil query -l 'hive.openshift.io/cluster-region=us-east-1,operator.ingress.degraded=True'`,
+		Run: func(cmd *cobra.Command, args []string) {
+			log.SetLevel(log.InfoLevel)
+			if err := opt.Complete(cmd, args); err != nil {
+				log.WithError(err).Fatal("Error")
+			}
+			if err := opt.Validate(cmd); err != nil {
+				log.WithError(err).Fatal("Error")
+			}
+			dynClient, err := contributils.GetClient()
+			if err != nil {
+				log.WithError(err).Fatal("error creating kube clients")
+			}
+			if err := opt.Run(dynClient, os.Stdout); err != nil {
+				log.WithError(err).Fatal("Error")
+			}
+		},
+	}
+	flags := cmd.Flags()
+	flags.StringVarP(&opt.Selector, "selector", "l", "", "The label selector to match against the attributes of every cluster")
+	flags.StringVarP(&opt.Namespace, "namespace", "n", "", "Only query the clusters in this namespace (default all namespaces)")
+	flags.StringVarP(&opt.Output, "output", "o", "", "Output format. One of: (empty) or name")
+	return cmd
+}
+
+// Complete finishes parsing arguments for the command
+func (o *Options) Complete(cmd *cobra.Command, args []string) error {
+	return nil
+}
+
+// Validate ensures that option values make sense
+func (o *Options) Validate(cmd *cobra.Command) error {
+	if o.Selector == "" {
+		cmd.Usage()
+		return errors.New("--selector is required")
+	}
+	if o.Output != "" && o.Output != "name" {
+		return fmt.Errorf("unsupported output format %q", o.Output)
+	}
+	selector, err := labels.Parse(o.Selector)
+	if err != nil {
+		return errors.Wrap(err, "invalid selector")
+	}
+	o.selector = selector
+	return nil
+}
+
+// Run executes the command
+func (o *Options) Run(c client.Client, out io.Writer) error {
+	cds := &hivev1.ClusterDeploymentList{}
+	if err := c.List(context.TODO(), cds, client.InNamespace(o.Namespace)); err != nil {
+		return errors.Wrap(err, "error listing cluster deployments")
+	}
+	states := &hivev1.ClusterStateList{}
+	if err := c.List(context.TODO(), states, client.InNamespace(o.Namespace)); err != nil {
+		return errors.Wrap(err, "error listing cluster states")
+	}
+	statesByName := make(map[types.NamespacedName]*hivev1.ClusterState, len(states.Items))
+	for i := range states.Items {
+		st := &states.Items[i]
+		statesByName[types.NamespacedName{Namespace: st.Namespace, Name: st.Name}] = st
+	}
+
+	w := tabwriter.NewWriter(out, 0, 8, 3, ' ', 0)
+	if o.Output == "" {
+		fmt.Fprintln(w, "NAMESPACE\tNAME")
+	}
+	for i := range cds.Items {
+		cd := &cds.Items[i]
+		st := statesByName[types.NamespacedName{Namespace: cd.Namespace, Name: cd.Name}]
+		if !o.selector.Matches(clusterAttributes(cd, st)) {
+			continue
+		}
+		if o.Output == "name" {
+			fmt.Fprintf(w, "clusterdeployment/%s\n", cd.Name)
+		} else {
+			fmt.Fprintf(w, "%s\t%s\n", cd.Namespace, cd.Name)
+		}
+	}
+	return w.Flush()
+}
+
+// clusterAttributes returns the attributes of a ClusterDeployment and its ClusterState, which may be nil, which the
+// selector is matched against.
+func clusterAttributes(cd *hivev1.ClusterDeployment, st *hivev1.ClusterState) labels.Set {
+	attributes := labels.Set{}
+	for k, v := range cd.Labels {
+		attributes[k] = v
+	}
+	attributes[installedAttribute] = strconv.FormatBool(cd.Spec.Installed)
+	if cd.Spec.PowerState != "" {
+		attributes[powerStateAttribute] = string(cd.Spec.PowerState)
+	}
+	if st != nil {
+		for _, operator := range st.Status.ClusterOperators {
+			for _, cond := range operator.Conditions {
+				attributes[operatorAttributePrefix+operator.Name+"."+strings.ToLower(string(cond.Type))] = string(cond.Status)
+			}
+		}
+	}
+	return attributes
+}
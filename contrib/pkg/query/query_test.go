@@ -0,0 +1,94 @@
+package query
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	configv1 "github.com/openshift/api/config/v1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/openshift/hive/apis"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+const testNamespace = "fleet"
+
+func TestQuery(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+
+	existing := []runtime.Object{
+		testClusterDeployment("east-degraded", "us-east-1", true),
+		testClusterState("east-degraded", configv1.OperatorDegraded, configv1.ConditionTrue),
+		testClusterDeployment("east-healthy", "us-east-1", true),
+		testClusterState("east-healthy", configv1.OperatorDegraded, configv1.ConditionFalse),
+		testClusterDeployment("west-degraded", "us-west-2", true),
+		testClusterState("west-degraded", configv1.OperatorDegraded, configv1.ConditionTrue),
+		testClusterDeployment("east-installing", "us-east-1", false),
+	}
+
+	tests := []struct {
+		name     string
+		selector string
+		expected string
+	}{
+		{
+			name:     "region and degraded operator",
+			selector: "hive.openshift.io/cluster-region=us-east-1,operator.ingress.degraded=True",
+			expected: "clusterdeployment/east-degraded\n",
+		},
+		{
+			name:     "set based requirement",
+			selector: "hive.openshift.io/cluster-region in (us-east-1,us-west-2),operator.ingress.degraded=True",
+			expected: "clusterdeployment/east-degraded\nclusterdeployment/west-degraded\n",
+		},
+		{
+			name:     "clusters without cluster state",
+			selector: "installed=false,!operator.ingress.degraded",
+			expected: "clusterdeployment/east-installing\n",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			o := &Options{Selector: test.selector, Output: "name"}
+			require.NoError(t, o.Validate(nil), "unexpected error validating selector")
+			out := &bytes.Buffer{}
+			require.NoError(t, o.Run(fake.NewFakeClientWithScheme(scheme.Scheme, existing...), out), "unexpected error running query")
+			assert.Equal(t, test.expected, out.String(), "unexpected matching clusters")
+		})
+	}
+}
+
+func TestValidateInvalidSelector(t *testing.T) {
+	o := &Options{Selector: "hive.openshift.io/cluster-region in us-east-1"}
+	assert.Error(t, o.Validate(nil), "expected invalid selector")
+}
+
+func testClusterDeployment(name, region string, installed bool) *hivev1.ClusterDeployment {
+	return &hivev1.ClusterDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNamespace,
+			Name:      name,
+			Labels:    map[string]string{hivev1.HiveClusterRegionLabel: region},
+		},
+		Spec: hivev1.ClusterDeploymentSpec{Installed: installed},
+	}
+}
+
+func testClusterState(name string, condType configv1.ClusterStatusConditionType, status configv1.ConditionStatus) *hivev1.ClusterState {
+	return &hivev1.ClusterState{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: name},
+		Status: hivev1.ClusterStateStatus{
+			ClusterOperators: []hivev1.ClusterOperatorState{{
+				Name:       "ingress",
+				Conditions: []configv1.ClusterOperatorStatusCondition{{Type: condType, Status: status}},
+			}},
+		},
+	}
+}
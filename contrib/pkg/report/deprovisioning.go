@@ -98,6 +98,9 @@ func (o *DeprovisioningReportOptions) Run(dynClient client.Client) error {
 		deprovisioningFor := time.Since(cd.DeletionTimestamp.Time).Seconds() / 60 / 60
 
 		fmt.Printf("\n\nCluster: %s\n", cd.Name)
+		if cd.Spec.DisplayName != "" {
+			fmt.Printf("Display name: %s\n", cd.Spec.DisplayName)
+		}
 		fmt.Printf("Namespace: %s\n", cd.Namespace)
 		fmt.Printf("Cluster type: %s\n", ct)
 		fmt.Printf("Created: %s\n", cd.CreationTimestamp.Time)
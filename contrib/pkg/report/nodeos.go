@@ -0,0 +1,181 @@
+package report
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/hive/apis"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	contributils "github.com/openshift/hive/contrib/pkg/utils"
+	"github.com/openshift/hive/pkg/constants"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// NodeOSReportOptions is the set of options for the desired report.
+type NodeOSReportOptions struct {
+	// OlderThan is a duration to filter to clusters with nodes running RHCOS builds created more than this duration
+	// ago.
+	OlderThan string
+	// ClusterType filters the report to only clusters of the given type.
+	ClusterType string
+}
+
+// NewNodeOSReportCommand creates a command that generates and outputs the node operating system report.
+func NewNodeOSReportCommand() *cobra.Command {
+
+	opt := &NodeOSReportOptions{}
+	cmd := &cobra.Command{
+		Use:   "node-os",
+		Short: "Prints a report on all clusters with nodes running old RHCOS builds",
+		Long: `Prints a report on all clusters with nodes running RHCOS builds older than the given age, which need
+upgrading to pick up security fixes. Relies on the node operating systems which Hive collects into the ClusterStates
+of the clusters when collectNodeInfo is enabled in the HiveConfig.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			log.SetLevel(log.InfoLevel)
+			if err := opt.Complete(cmd, args); err != nil {
+				return
+			}
+
+			if err := opt.Validate(cmd); err != nil {
+				log.WithError(err).Error("invalid options")
+				return
+			}
+
+			dynClient, err := contributils.GetClient()
+			if err != nil {
+				log.WithError(err).Fatal("error creating kube clients")
+			}
+
+			err = opt.Run(dynClient)
+			if err != nil {
+				log.WithError(err).Error("Error")
+			}
+		},
+	}
+	flags := cmd.Flags()
+	flags.StringVarP(&opt.OlderThan, "older-than", "", "720h", "Only include clusters with nodes running RHCOS builds created more than this duration ago.")
+	flags.StringVarP(&opt.ClusterType, "cluster-type", "", "", "Only include clusters with the given hive.openshift.io/cluster-type label.")
+	return cmd
+}
+
+// Complete finishes parsing arguments for the command
+func (o *NodeOSReportOptions) Complete(cmd *cobra.Command, args []string) error {
+	return nil
+}
+
+// Validate ensures that option values make sense
+func (o *NodeOSReportOptions) Validate(cmd *cobra.Command) error {
+	if _, err := time.ParseDuration(o.OlderThan); err != nil {
+		return fmt.Errorf("invalid --older-than: %v", err)
+	}
+	return nil
+}
+
+// staleCluster is a cluster with nodes running old RHCOS builds.
+type staleCluster struct {
+	cd          *hivev1.ClusterDeployment
+	oldestBuild hivev1.NodeOSState
+	staleNodes  int
+	totalNodes  int
+}
+
+// Run executes the command
+func (o *NodeOSReportOptions) Run(dynClient client.Client) error {
+	if err := apis.AddToScheme(scheme.Scheme); err != nil {
+		return err
+	}
+	olderThan, err := time.ParseDuration(o.OlderThan)
+	if err != nil {
+		return err
+	}
+
+	cdList := &hivev1.ClusterDeploymentList{}
+	if err := dynClient.List(context.Background(), cdList); err != nil {
+		log.WithError(err).Fatal("error listing cluster deployments")
+	}
+	stList := &hivev1.ClusterStateList{}
+	if err := dynClient.List(context.Background(), stList); err != nil {
+		log.WithError(err).Fatal("error listing cluster states")
+	}
+	states := make(map[types.NamespacedName]*hivev1.ClusterState, len(stList.Items))
+	for i, st := range stList.Items {
+		states[types.NamespacedName{Namespace: st.Namespace, Name: st.Name}] = &stList.Items[i]
+	}
+	fmt.Printf("Loaded %d total clusters\n", len(cdList.Items))
+
+	cutoff := time.Now().Add(-olderThan)
+	var total, withoutNodeInfo int
+	var stale []staleCluster
+	for i, cd := range cdList.Items {
+		if !cd.Spec.Installed || cd.DeletionTimestamp != nil {
+			continue
+		}
+		if o.ClusterType != "" {
+			ct, ok := cd.Labels[hivev1.HiveClusterTypeLabel]
+			if !ok || ct != o.ClusterType {
+				continue
+			}
+		}
+		total++
+
+		st := states[types.NamespacedName{Namespace: cd.Namespace, Name: cd.Name}]
+		if st == nil || len(st.Status.Nodes) == 0 {
+			withoutNodeInfo++
+			continue
+		}
+		sc := staleCluster{cd: &cdList.Items[i], totalNodes: len(st.Status.Nodes)}
+		for _, node := range st.Status.Nodes {
+			if node.RHCOSBuildTime == nil || !node.RHCOSBuildTime.Time.Before(cutoff) {
+				continue
+			}
+			sc.staleNodes++
+			if sc.oldestBuild.RHCOSBuildTime == nil || node.RHCOSBuildTime.Before(sc.oldestBuild.RHCOSBuildTime) {
+				sc.oldestBuild = node
+			}
+		}
+		if sc.staleNodes > 0 {
+			stale = append(stale, sc)
+		}
+	}
+
+	// The clusters running the oldest builds are the most in need of patching, so they are listed first.
+	sort.Slice(stale, func(i, j int) bool {
+		return stale[i].oldestBuild.RHCOSBuildTime.Before(stale[j].oldestBuild.RHCOSBuildTime)
+	})
+	for _, sc := range stale {
+		ct, ok := sc.cd.Labels[hivev1.HiveClusterTypeLabel]
+		if !ok {
+			ct = "unspecified"
+		}
+		version, ok := sc.cd.Labels[constants.VersionMajorMinorPatchLabel]
+		if !ok {
+			version = "unknown"
+		}
+
+		fmt.Printf("\n\nCluster: %s\n", sc.cd.Name)
+		if sc.cd.Spec.DisplayName != "" {
+			fmt.Printf("Display name: %s\n", sc.cd.Spec.DisplayName)
+		}
+		fmt.Printf("Namespace: %s\n", sc.cd.Namespace)
+		fmt.Printf("Cluster type: %s\n", ct)
+		fmt.Printf("Version: %s\n", version)
+		fmt.Printf("Oldest RHCOS build: %s\n", sc.oldestBuild.RHCOSBuild)
+		fmt.Printf("Oldest RHCOS build age: %.0f days\n", time.Since(sc.oldestBuild.RHCOSBuildTime.Time).Hours()/24)
+		fmt.Printf("Nodes on old builds: %d of %d\n", sc.staleNodes, sc.totalNodes)
+	}
+
+	fmt.Printf("\n%d of %d clusters have nodes running RHCOS builds older than %s\n", len(stale), total, olderThan)
+	if withoutNodeInfo > 0 {
+		fmt.Printf("%d clusters have no node info collected\n", withoutNodeInfo)
+	}
+
+	return nil
+}
@@ -140,6 +140,9 @@ func (o *ProvisioningReportOptions) Run(dynClient client.Client) error {
 		}
 
 		fmt.Printf("\n\nCluster: %s\n", cd.Name)
+		if cd.Spec.DisplayName != "" {
+			fmt.Printf("Display name: %s\n", cd.Spec.DisplayName)
+		}
 		fmt.Printf("Namespace: %s\n", cd.Namespace)
 		fmt.Printf("Cluster type: %s\n", ct)
 		fmt.Printf("Created: %s\n", cd.CreationTimestamp.Time)
@@ -16,5 +16,6 @@ func NewClusterReportCommand() *cobra.Command {
 	}
 	cmd.AddCommand(NewProvisioningReportCommand())
 	cmd.AddCommand(NewDeprovisioningReportCommand())
+	cmd.AddCommand(NewNodeOSReportCommand())
 	return cmd
 }
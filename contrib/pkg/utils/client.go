@@ -1,6 +1,13 @@
 package utils
 
 import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/scheme"
 	restclient "k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
@@ -32,3 +39,40 @@ func GetClientConfig() (*restclient.Config, error) {
 	kubeconfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(rules, &clientcmd.ConfigOverrides{})
 	return kubeconfig.ClientConfig()
 }
+
+// ServerDryRun sends the objects to the API server as dry-run creates, or as dry-run updates of the objects which
+// already exist. The objects are validated and mutated by admission as if they were applied, but nothing is persisted.
+// Each object is replaced by the result returned by the API server.
+func ServerDryRun(c client.Client, objs []runtime.Object) error {
+	for _, obj := range objs {
+		accessor, err := meta.Accessor(obj)
+		if err != nil {
+			return errors.Wrapf(err, "cannot create accessor for object of type %T", obj)
+		}
+		// The type of typed objects is cleared when they are decoded, but it is needed to print them.
+		gvk := obj.GetObjectKind().GroupVersionKind()
+		kind := gvk.Kind
+		err = c.Create(context.TODO(), obj, client.DryRunAll)
+		if err == nil {
+			obj.GetObjectKind().SetGroupVersionKind(gvk)
+			continue
+		}
+		if !apierrors.IsAlreadyExists(err) {
+			return errors.Wrapf(err, "dry run create of %s %s failed", kind, accessor.GetName())
+		}
+		existing := obj.DeepCopyObject()
+		if err := c.Get(context.TODO(), client.ObjectKey{Namespace: accessor.GetNamespace(), Name: accessor.GetName()}, existing); err != nil {
+			return errors.Wrapf(err, "could not get existing %s %s", kind, accessor.GetName())
+		}
+		existingAccessor, err := meta.Accessor(existing)
+		if err != nil {
+			return errors.Wrapf(err, "cannot create accessor for object of type %T", existing)
+		}
+		accessor.SetResourceVersion(existingAccessor.GetResourceVersion())
+		if err := c.Update(context.TODO(), obj, client.DryRunAll); err != nil {
+			return errors.Wrapf(err, "dry run update of %s %s failed", kind, accessor.GetName())
+		}
+		obj.GetObjectKind().SetGroupVersionKind(gvk)
+	}
+	return nil
+}
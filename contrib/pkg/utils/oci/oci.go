@@ -0,0 +1,36 @@
+package oci
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/ociclient"
+)
+
+// GetCreds reads OCI API signing key credentials from the standard OCI CLI environment variables. If keyFile
+// is specified, the private key is read from that file rather than from the environment.
+func GetCreds(keyFile string) (ociclient.Credentials, error) {
+	creds := ociclient.Credentials{
+		Tenancy:     os.Getenv(constants.OCITenancyEnvVar),
+		User:        os.Getenv(constants.OCIUserEnvVar),
+		Fingerprint: os.Getenv(constants.OCIFingerprintEnvVar),
+		PrivateKey:  []byte(os.Getenv(constants.OCIPrivateKeyEnvVar)),
+		Passphrase:  []byte(os.Getenv(constants.OCIPassphraseEnvVar)),
+	}
+	if keyFile != "" {
+		log.WithField("keyFile", keyFile).Info("Loading OCI API signing key")
+		key, err := ioutil.ReadFile(keyFile)
+		if err != nil {
+			return creds, err
+		}
+		creds.PrivateKey = key
+	}
+	if len(creds.PrivateKey) == 0 {
+		return creds, fmt.Errorf("no OCI API signing key found; set %s or specify a key file", constants.OCIPrivateKeyEnvVar)
+	}
+	return creds, nil
+}
@@ -0,0 +1,17 @@
+package powervs
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/openshift/hive/pkg/constants"
+)
+
+// GetAPIKey reads the IBM Cloud API key from the standard IBM Cloud CLI environment variable.
+func GetAPIKey() (string, error) {
+	apiKey := os.Getenv(constants.PowerVSAPIKeyEnvVar)
+	if apiKey == "" {
+		return "", fmt.Errorf("no IBM Cloud API key found; set %s", constants.PowerVSAPIKeyEnvVar)
+	}
+	return apiKey, nil
+}
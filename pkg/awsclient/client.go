@@ -14,6 +14,7 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
 	"github.com/aws/aws-sdk-go/aws/endpoints"
 	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/aws/session"
@@ -37,6 +38,11 @@ import (
 	"github.com/openshift/hive/pkg/constants"
 )
 
+const (
+	// assumeRoleSessionName is the session name of the roles assumed by Hive.
+	assumeRoleSessionName = "hive"
+)
+
 var (
 	metricAWSAPICalls = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
@@ -57,6 +63,7 @@ func init() {
 type Client interface {
 	// EC2
 	DescribeAvailabilityZones(*ec2.DescribeAvailabilityZonesInput) (*ec2.DescribeAvailabilityZonesOutput, error)
+	DescribeInstanceTypeOfferings(*ec2.DescribeInstanceTypeOfferingsInput) (*ec2.DescribeInstanceTypeOfferingsOutput, error)
 	DescribeImages(*ec2.DescribeImagesInput) (*ec2.DescribeImagesOutput, error)
 	DescribeVpcs(*ec2.DescribeVpcsInput) (*ec2.DescribeVpcsOutput, error)
 	DescribeSubnets(*ec2.DescribeSubnetsInput) (*ec2.DescribeSubnetsOutput, error)
@@ -64,6 +71,7 @@ type Client interface {
 	DescribeSecurityGroups(*ec2.DescribeSecurityGroupsInput) (*ec2.DescribeSecurityGroupsOutput, error)
 	RunInstances(*ec2.RunInstancesInput) (*ec2.Reservation, error)
 	DescribeInstances(*ec2.DescribeInstancesInput) (*ec2.DescribeInstancesOutput, error)
+	GetConsoleOutput(*ec2.GetConsoleOutputInput) (*ec2.GetConsoleOutputOutput, error)
 	TerminateInstances(*ec2.TerminateInstancesInput) (*ec2.TerminateInstancesOutput, error)
 	StopInstances(*ec2.StopInstancesInput) (*ec2.StopInstancesOutput, error)
 	StartInstances(*ec2.StartInstancesInput) (*ec2.StartInstancesOutput, error)
@@ -77,12 +85,21 @@ type Client interface {
 	DescribeVpcEndpoints(*ec2.DescribeVpcEndpointsInput) (*ec2.DescribeVpcEndpointsOutput, error)
 	CreateVpcEndpoint(*ec2.CreateVpcEndpointInput) (*ec2.CreateVpcEndpointOutput, error)
 	DeleteVpcEndpoints(*ec2.DeleteVpcEndpointsInput) (*ec2.DeleteVpcEndpointsOutput, error)
+	DescribeVpcEndpointConnections(*ec2.DescribeVpcEndpointConnectionsInput) (*ec2.DescribeVpcEndpointConnectionsOutput, error)
+	AcceptVpcEndpointConnections(*ec2.AcceptVpcEndpointConnectionsInput) (*ec2.AcceptVpcEndpointConnectionsOutput, error)
+	RejectVpcEndpointConnections(*ec2.RejectVpcEndpointConnectionsInput) (*ec2.RejectVpcEndpointConnectionsOutput, error)
+	DeleteVolume(*ec2.DeleteVolumeInput) (*ec2.DeleteVolumeOutput, error)
+	DeleteNatGateway(*ec2.DeleteNatGatewayInput) (*ec2.DeleteNatGatewayOutput, error)
+	ReleaseAddress(*ec2.ReleaseAddressInput) (*ec2.ReleaseAddressOutput, error)
 
 	// ELB
 	RegisterInstancesWithLoadBalancer(*elb.RegisterInstancesWithLoadBalancerInput) (*elb.RegisterInstancesWithLoadBalancerOutput, error)
+	DeleteClassicLoadBalancer(*elb.DeleteLoadBalancerInput) (*elb.DeleteLoadBalancerOutput, error)
 
 	// ELBV2
 	DescribeLoadBalancers(*elbv2.DescribeLoadBalancersInput) (*elbv2.DescribeLoadBalancersOutput, error)
+	DeleteLoadBalancer(*elbv2.DeleteLoadBalancerInput) (*elbv2.DeleteLoadBalancerOutput, error)
+	DeleteTargetGroup(*elbv2.DeleteTargetGroupInput) (*elbv2.DeleteTargetGroupOutput, error)
 
 	// IAM
 	CreateAccessKey(*iam.CreateAccessKeyInput) (*iam.CreateAccessKeyOutput, error)
@@ -94,12 +111,21 @@ type Client interface {
 	ListAccessKeys(*iam.ListAccessKeysInput) (*iam.ListAccessKeysOutput, error)
 	ListUserPolicies(*iam.ListUserPoliciesInput) (*iam.ListUserPoliciesOutput, error)
 	PutUserPolicy(*iam.PutUserPolicyInput) (*iam.PutUserPolicyOutput, error)
+	DeleteRole(*iam.DeleteRoleInput) (*iam.DeleteRoleOutput, error)
+	DeleteRolePolicy(*iam.DeleteRolePolicyInput) (*iam.DeleteRolePolicyOutput, error)
+	ListRolePolicies(*iam.ListRolePoliciesInput) (*iam.ListRolePoliciesOutput, error)
+	ListRoleTags(*iam.ListRoleTagsInput) (*iam.ListRoleTagsOutput, error)
+	DeleteOpenIDConnectProvider(*iam.DeleteOpenIDConnectProviderInput) (*iam.DeleteOpenIDConnectProviderOutput, error)
+	ListOpenIDConnectProviders(*iam.ListOpenIDConnectProvidersInput) (*iam.ListOpenIDConnectProvidersOutput, error)
+	ListRolesPages(*iam.ListRolesInput, func(*iam.ListRolesOutput, bool) bool) error
 
 	// S3
 	CreateBucket(*s3.CreateBucketInput) (*s3.CreateBucketOutput, error)
 	DeleteBucket(*s3.DeleteBucketInput) (*s3.DeleteBucketOutput, error)
 	ListBuckets(*s3.ListBucketsInput) (*s3.ListBucketsOutput, error)
 	PutObject(input *s3.PutObjectInput) (*s3.PutObjectOutput, error)
+	DeleteObjects(*s3.DeleteObjectsInput) (*s3.DeleteObjectsOutput, error)
+	ListObjectsV2Pages(*s3.ListObjectsV2Input, func(*s3.ListObjectsV2Output, bool) bool) error
 
 	// S3 Manager
 	Upload(*s3manager.UploadInput) (*s3manager.UploadOutput, error)
@@ -124,6 +150,7 @@ type Client interface {
 	DisassociateVPCFromHostedZone(input *route53.DisassociateVPCFromHostedZoneInput) (*route53.DisassociateVPCFromHostedZoneOutput, error)
 	// ResourceTagging
 	GetResourcesPages(input *resourcegroupstaggingapi.GetResourcesInput, fn func(*resourcegroupstaggingapi.GetResourcesOutput, bool) bool) error
+	TagResources(input *resourcegroupstaggingapi.TagResourcesInput) (*resourcegroupstaggingapi.TagResourcesOutput, error)
 
 	// STS
 	GetCallerIdentity(input *sts.GetCallerIdentityInput) (*sts.GetCallerIdentityOutput, error)
@@ -146,6 +173,11 @@ func (c *awsClient) DescribeAvailabilityZones(input *ec2.DescribeAvailabilityZon
 	return c.ec2Client.DescribeAvailabilityZones(input)
 }
 
+func (c *awsClient) DescribeInstanceTypeOfferings(input *ec2.DescribeInstanceTypeOfferingsInput) (*ec2.DescribeInstanceTypeOfferingsOutput, error) {
+	metricAWSAPICalls.WithLabelValues("DescribeInstanceTypeOfferings").Inc()
+	return c.ec2Client.DescribeInstanceTypeOfferings(input)
+}
+
 func (c *awsClient) DescribeImages(input *ec2.DescribeImagesInput) (*ec2.DescribeImagesOutput, error) {
 	metricAWSAPICalls.WithLabelValues("DescribeImages").Inc()
 	return c.ec2Client.DescribeImages(input)
@@ -181,6 +213,11 @@ func (c *awsClient) DescribeInstances(input *ec2.DescribeInstancesInput) (*ec2.D
 	return c.ec2Client.DescribeInstances(input)
 }
 
+func (c *awsClient) GetConsoleOutput(input *ec2.GetConsoleOutputInput) (*ec2.GetConsoleOutputOutput, error) {
+	metricAWSAPICalls.WithLabelValues("GetConsoleOutput").Inc()
+	return c.ec2Client.GetConsoleOutput(input)
+}
+
 func (c *awsClient) TerminateInstances(input *ec2.TerminateInstancesInput) (*ec2.TerminateInstancesOutput, error) {
 	metricAWSAPICalls.WithLabelValues("TerminateInstances").Inc()
 	return c.ec2Client.TerminateInstances(input)
@@ -246,16 +283,61 @@ func (c *awsClient) DeleteVpcEndpoints(input *ec2.DeleteVpcEndpointsInput) (*ec2
 	return c.ec2Client.DeleteVpcEndpoints(input)
 }
 
+func (c *awsClient) DescribeVpcEndpointConnections(input *ec2.DescribeVpcEndpointConnectionsInput) (*ec2.DescribeVpcEndpointConnectionsOutput, error) {
+	metricAWSAPICalls.WithLabelValues("DescribeVpcEndpointConnections").Inc()
+	return c.ec2Client.DescribeVpcEndpointConnections(input)
+}
+
+func (c *awsClient) AcceptVpcEndpointConnections(input *ec2.AcceptVpcEndpointConnectionsInput) (*ec2.AcceptVpcEndpointConnectionsOutput, error) {
+	metricAWSAPICalls.WithLabelValues("AcceptVpcEndpointConnections").Inc()
+	return c.ec2Client.AcceptVpcEndpointConnections(input)
+}
+
+func (c *awsClient) RejectVpcEndpointConnections(input *ec2.RejectVpcEndpointConnectionsInput) (*ec2.RejectVpcEndpointConnectionsOutput, error) {
+	metricAWSAPICalls.WithLabelValues("RejectVpcEndpointConnections").Inc()
+	return c.ec2Client.RejectVpcEndpointConnections(input)
+}
+
+func (c *awsClient) DeleteVolume(input *ec2.DeleteVolumeInput) (*ec2.DeleteVolumeOutput, error) {
+	metricAWSAPICalls.WithLabelValues("DeleteVolume").Inc()
+	return c.ec2Client.DeleteVolume(input)
+}
+
+func (c *awsClient) DeleteNatGateway(input *ec2.DeleteNatGatewayInput) (*ec2.DeleteNatGatewayOutput, error) {
+	metricAWSAPICalls.WithLabelValues("DeleteNatGateway").Inc()
+	return c.ec2Client.DeleteNatGateway(input)
+}
+
+func (c *awsClient) ReleaseAddress(input *ec2.ReleaseAddressInput) (*ec2.ReleaseAddressOutput, error) {
+	metricAWSAPICalls.WithLabelValues("ReleaseAddress").Inc()
+	return c.ec2Client.ReleaseAddress(input)
+}
+
 func (c *awsClient) RegisterInstancesWithLoadBalancer(input *elb.RegisterInstancesWithLoadBalancerInput) (*elb.RegisterInstancesWithLoadBalancerOutput, error) {
 	metricAWSAPICalls.WithLabelValues("RegisterInstancesWithLoadBalancer").Inc()
 	return c.elbClient.RegisterInstancesWithLoadBalancer(input)
 }
 
+func (c *awsClient) DeleteClassicLoadBalancer(input *elb.DeleteLoadBalancerInput) (*elb.DeleteLoadBalancerOutput, error) {
+	metricAWSAPICalls.WithLabelValues("DeleteClassicLoadBalancer").Inc()
+	return c.elbClient.DeleteLoadBalancer(input)
+}
+
 func (c *awsClient) DescribeLoadBalancers(input *elbv2.DescribeLoadBalancersInput) (*elbv2.DescribeLoadBalancersOutput, error) {
 	metricAWSAPICalls.WithLabelValues("DescribeLoadBalancers").Inc()
 	return c.elbv2Client.DescribeLoadBalancers(input)
 }
 
+func (c *awsClient) DeleteLoadBalancer(input *elbv2.DeleteLoadBalancerInput) (*elbv2.DeleteLoadBalancerOutput, error) {
+	metricAWSAPICalls.WithLabelValues("DeleteLoadBalancer").Inc()
+	return c.elbv2Client.DeleteLoadBalancer(input)
+}
+
+func (c *awsClient) DeleteTargetGroup(input *elbv2.DeleteTargetGroupInput) (*elbv2.DeleteTargetGroupOutput, error) {
+	metricAWSAPICalls.WithLabelValues("DeleteTargetGroup").Inc()
+	return c.elbv2Client.DeleteTargetGroup(input)
+}
+
 func (c *awsClient) CreateAccessKey(input *iam.CreateAccessKeyInput) (*iam.CreateAccessKeyOutput, error) {
 	metricAWSAPICalls.WithLabelValues("CreateAccessKey").Inc()
 	return c.iamClient.CreateAccessKey(input)
@@ -300,6 +382,41 @@ func (c *awsClient) PutUserPolicy(input *iam.PutUserPolicyInput) (*iam.PutUserPo
 	return c.iamClient.PutUserPolicy(input)
 }
 
+func (c *awsClient) DeleteRole(input *iam.DeleteRoleInput) (*iam.DeleteRoleOutput, error) {
+	metricAWSAPICalls.WithLabelValues("DeleteRole").Inc()
+	return c.iamClient.DeleteRole(input)
+}
+
+func (c *awsClient) DeleteRolePolicy(input *iam.DeleteRolePolicyInput) (*iam.DeleteRolePolicyOutput, error) {
+	metricAWSAPICalls.WithLabelValues("DeleteRolePolicy").Inc()
+	return c.iamClient.DeleteRolePolicy(input)
+}
+
+func (c *awsClient) ListRolePolicies(input *iam.ListRolePoliciesInput) (*iam.ListRolePoliciesOutput, error) {
+	metricAWSAPICalls.WithLabelValues("ListRolePolicies").Inc()
+	return c.iamClient.ListRolePolicies(input)
+}
+
+func (c *awsClient) ListRoleTags(input *iam.ListRoleTagsInput) (*iam.ListRoleTagsOutput, error) {
+	metricAWSAPICalls.WithLabelValues("ListRoleTags").Inc()
+	return c.iamClient.ListRoleTags(input)
+}
+
+func (c *awsClient) DeleteOpenIDConnectProvider(input *iam.DeleteOpenIDConnectProviderInput) (*iam.DeleteOpenIDConnectProviderOutput, error) {
+	metricAWSAPICalls.WithLabelValues("DeleteOpenIDConnectProvider").Inc()
+	return c.iamClient.DeleteOpenIDConnectProvider(input)
+}
+
+func (c *awsClient) ListOpenIDConnectProviders(input *iam.ListOpenIDConnectProvidersInput) (*iam.ListOpenIDConnectProvidersOutput, error) {
+	metricAWSAPICalls.WithLabelValues("ListOpenIDConnectProviders").Inc()
+	return c.iamClient.ListOpenIDConnectProviders(input)
+}
+
+func (c *awsClient) ListRolesPages(input *iam.ListRolesInput, fn func(*iam.ListRolesOutput, bool) bool) error {
+	metricAWSAPICalls.WithLabelValues("ListRolesPages").Inc()
+	return c.iamClient.ListRolesPages(input, fn)
+}
+
 func (c *awsClient) CreateBucket(input *s3.CreateBucketInput) (*s3.CreateBucketOutput, error) {
 	metricAWSAPICalls.WithLabelValues("CreateBucket").Inc()
 	return c.s3Client.CreateBucket(input)
@@ -320,6 +437,16 @@ func (c *awsClient) PutObject(input *s3.PutObjectInput) (*s3.PutObjectOutput, er
 	return c.s3Client.PutObject(input)
 }
 
+func (c *awsClient) DeleteObjects(input *s3.DeleteObjectsInput) (*s3.DeleteObjectsOutput, error) {
+	metricAWSAPICalls.WithLabelValues("DeleteObjects").Inc()
+	return c.s3Client.DeleteObjects(input)
+}
+
+func (c *awsClient) ListObjectsV2Pages(input *s3.ListObjectsV2Input, fn func(*s3.ListObjectsV2Output, bool) bool) error {
+	metricAWSAPICalls.WithLabelValues("ListObjectsV2Pages").Inc()
+	return c.s3Client.ListObjectsV2Pages(input, fn)
+}
+
 func (c *awsClient) GetS3API() s3iface.S3API {
 	return c.s3Client
 }
@@ -372,6 +499,11 @@ func (c *awsClient) GetResourcesPages(input *resourcegroupstaggingapi.GetResourc
 	return c.tagClient.GetResourcesPages(input, fn)
 }
 
+func (c *awsClient) TagResources(input *resourcegroupstaggingapi.TagResourcesInput) (*resourcegroupstaggingapi.TagResourcesOutput, error) {
+	metricAWSAPICalls.WithLabelValues("TagResources").Inc()
+	return c.tagClient.TagResources(input)
+}
+
 func (c *awsClient) ListResourceRecordSets(input *route53.ListResourceRecordSetsInput) (*route53.ListResourceRecordSetsOutput, error) {
 	metricAWSAPICalls.WithLabelValues("ListResourceRecordSets").Inc()
 	return c.route53Client.ListResourceRecordSets(input)
@@ -435,6 +567,31 @@ func NewClient(kubeClient client.Client, secretName, namespace, region string) (
 	return NewClientFromSecret(secret, region)
 }
 
+// NewClientAssumingRole creates our client wrapper object for the actual AWS clients we use, authenticating
+// with a role assumed with the credentials from the secret. The external ID is only passed when not empty.
+//
+// Pass an empty secret name and namespace to assume the role with the standard AWS environment variables.
+func NewClientAssumingRole(kubeClient client.Client, secretName, namespace, region, roleARN, externalID string) (Client, error) {
+	var secret *corev1.Secret
+	if secretName != "" {
+		secret = &corev1.Secret{}
+		if err := kubeClient.Get(context.TODO(), types.NamespacedName{Name: secretName, Namespace: namespace}, secret); err != nil {
+			return nil, err
+		}
+	}
+	s, err := newSessionFromSecret(secret, region)
+	if err != nil {
+		return nil, err
+	}
+	creds := stscreds.NewCredentials(s, roleARN, func(p *stscreds.AssumeRoleProvider) {
+		p.RoleSessionName = assumeRoleSessionName
+		if externalID != "" {
+			p.ExternalID = aws.String(externalID)
+		}
+	})
+	return newClientFromSession(withRateLimit(s.Copy(&aws.Config{Credentials: creds}), roleARN)), nil
+}
+
 // NewClientFromSecret creates our client wrapper object for the actual AWS clients we use.
 // For authentication the underlying clients will use either the cluster AWS credentials
 // secret if defined (i.e. in the root cluster),
@@ -442,6 +599,22 @@ func NewClient(kubeClient client.Client, secretName, namespace, region string) (
 //
 // Pass a nil secret to load credentials from the standard AWS environment variables.
 func NewClientFromSecret(secret *corev1.Secret, region string) (Client, error) {
+	s, err := newSessionFromSecret(secret, region)
+	if err != nil {
+		return nil, err
+	}
+	return newClientFromSession(withRateLimit(s, credentialsKey(secret))), nil
+}
+
+// credentialsKey returns the key of the token bucket shared by the clients using the credentials from the secret.
+func credentialsKey(secret *corev1.Secret) string {
+	if secret == nil {
+		return ""
+	}
+	return string(secret.Data[constants.AWSAccessKeyIDSecretKey])
+}
+
+func newSessionFromSecret(secret *corev1.Secret, region string) (*session.Session, error) {
 	awsConfig := &aws.Config{
 		Region:           aws.String(region),
 		EndpointResolver: endpoints.ResolverFunc(awsChinaEndpointResolver),
@@ -475,7 +648,10 @@ func NewClientFromSecret(secret *corev1.Secret, region string) (Client, error) {
 		Name: "openshift.io/hive",
 		Fn:   request.MakeAddToUserAgentHandler("openshift.io hive", "v1"),
 	})
+	return s, nil
+}
 
+func newClientFromSession(s *session.Session) Client {
 	return &awsClient{
 		ec2Client:     ec2.New(s),
 		elbClient:     elb.New(s),
@@ -486,7 +662,7 @@ func NewClientFromSecret(secret *corev1.Secret, region string) (Client, error) {
 		route53Client: route53.New(s),
 		stsClient:     sts.New(s),
 		tagClient:     resourcegroupstaggingapi.New(s),
-	}, nil
+	}
 }
 
 func awsChinaEndpointResolver(service, region string, optFns ...func(*endpoints.Options)) (endpoints.ResolvedEndpoint, error) {
@@ -0,0 +1,187 @@
+package awsclient
+
+import (
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/time/rate"
+
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/client"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+
+	"github.com/openshift/hive/pkg/constants"
+)
+
+const (
+	defaultRequestsPerSecond = 10
+	defaultBurst             = 20
+	defaultMaxRetries        = 8
+
+	// minRequestsPerSecond is the rate below which throttling does not lower the rate of a token bucket.
+	minRequestsPerSecond = 1
+	// rateRecoveryFraction is the fraction of the configured rate which a successful call adds back to the rate of a
+	// token bucket lowered by throttling.
+	rateRecoveryFraction = 0.05
+	// throttleDecreaseInterval is the minimum time between two decreases of the rate of a token bucket, so that the
+	// calls throttled at once only lower the rate once.
+	throttleDecreaseInterval = time.Second
+)
+
+var (
+	metricAWSAPIThrottled = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "hive_aws_api_throttled_total",
+			Help: "Number of API calls to AWS which were throttled, partitioned by service.",
+		},
+		[]string{"service"},
+	)
+	metricAWSRateLimitDelaySeconds = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "hive_aws_rate_limit_delay_seconds",
+			Help:    "Time API calls to AWS waited for the rate limit of their credentials, partitioned by service.",
+			Buckets: []float64{0.01, 0.1, 0.5, 1, 5, 10, 30, 60},
+		},
+		[]string{"service"},
+	)
+	metricAWSRateLimitDecreases = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "hive_aws_rate_limit_decreases_total",
+			Help: "Number of times the rate limit of AWS credentials was lowered because of throttling.",
+		},
+	)
+
+	// accountLimiters are the token buckets shared by the AWS clients, by credentials.
+	accountLimiters      = map[string]*accountLimiter{}
+	accountLimitersMutex sync.Mutex
+
+	loadRateLimitConfigOnce sync.Once
+	requestsPerSecond       = defaultRequestsPerSecond
+	burst                   = defaultBurst
+	maxRetries              = defaultMaxRetries
+)
+
+func init() {
+	metrics.Registry.MustRegister(metricAWSAPIThrottled)
+	metrics.Registry.MustRegister(metricAWSRateLimitDelaySeconds)
+	metrics.Registry.MustRegister(metricAWSRateLimitDecreases)
+}
+
+// loadRateLimitConfig loads the rate limit configuration from the environment, falling back to the defaults for
+// missing or invalid values.
+func loadRateLimitConfig() {
+	loadRateLimitConfigOnce.Do(func() {
+		requestsPerSecond = intFromEnv(constants.AWSRequestsPerSecondEnvVar, defaultRequestsPerSecond, 1)
+		burst = intFromEnv(constants.AWSBurstEnvVar, defaultBurst, 1)
+		maxRetries = intFromEnv(constants.AWSMaxRetriesEnvVar, defaultMaxRetries, 0)
+	})
+}
+
+func intFromEnv(name string, defaultValue, minValue int) int {
+	value, err := strconv.Atoi(os.Getenv(name))
+	if err != nil || value < minValue {
+		return defaultValue
+	}
+	return value
+}
+
+// accountLimiter is a token bucket shared by all the AWS clients using the same credentials, and so the same account.
+// Its rate is halved when AWS throttles a call, and recovers gradually as calls succeed, up to the configured rate.
+type accountLimiter struct {
+	limiter      *rate.Limiter
+	maxRate      rate.Limit
+	mutex        sync.Mutex
+	lastDecrease time.Time
+}
+
+func newAccountLimiter(requestsPerSecond, burst int) *accountLimiter {
+	return &accountLimiter{
+		limiter: rate.NewLimiter(rate.Limit(requestsPerSecond), burst),
+		maxRate: rate.Limit(requestsPerSecond),
+	}
+}
+
+// limiterForCredentials returns the token bucket shared by the AWS clients using the credentials with the given key.
+func limiterForCredentials(key string) *accountLimiter {
+	loadRateLimitConfig()
+	accountLimitersMutex.Lock()
+	defer accountLimitersMutex.Unlock()
+	l, ok := accountLimiters[key]
+	if !ok {
+		l = newAccountLimiter(requestsPerSecond, burst)
+		accountLimiters[key] = l
+	}
+	return l
+}
+
+func (l *accountLimiter) throttled(now time.Time) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if now.Sub(l.lastDecrease) < throttleDecreaseInterval {
+		return
+	}
+	l.lastDecrease = now
+	limit := l.limiter.Limit() / 2
+	if limit < minRequestsPerSecond {
+		limit = minRequestsPerSecond
+	}
+	l.limiter.SetLimitAt(now, limit)
+	metricAWSRateLimitDecreases.Inc()
+}
+
+func (l *accountLimiter) succeeded(now time.Time) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	limit := l.limiter.Limit()
+	if limit >= l.maxRate {
+		return
+	}
+	limit += l.maxRate * rateRecoveryFraction
+	if limit > l.maxRate {
+		limit = l.maxRate
+	}
+	l.limiter.SetLimitAt(now, limit)
+}
+
+// withRateLimit makes every attempt of the calls of the session wait for the token bucket shared by the clients using
+// the credentials with the given key, and retries failed calls with exponential backoff.
+func withRateLimit(s *session.Session, credentialsKey string) *session.Session {
+	l := limiterForCredentials(credentialsKey)
+	s = s.Copy(request.WithRetryer(aws.NewConfig(), client.DefaultRetryer{
+		NumMaxRetries:    maxRetries,
+		MinRetryDelay:    client.DefaultRetryerMinRetryDelay,
+		MaxRetryDelay:    client.DefaultRetryerMaxRetryDelay,
+		MinThrottleDelay: client.DefaultRetryerMinThrottleDelay,
+		MaxThrottleDelay: client.DefaultRetryerMaxThrottleDelay,
+	}))
+	s.Handlers.Send.PushFrontNamed(request.NamedHandler{
+		Name: "openshift.io/hive/ratelimit",
+		Fn: func(r *request.Request) {
+			start := time.Now()
+			if err := l.limiter.Wait(r.Context()); err != nil {
+				r.Error = err
+				return
+			}
+			metricAWSRateLimitDelaySeconds.WithLabelValues(r.ClientInfo.ServiceName).Observe(time.Since(start).Seconds())
+		},
+	})
+	s.Handlers.CompleteAttempt.PushBackNamed(request.NamedHandler{
+		Name: "openshift.io/hive/ratelimit",
+		Fn: func(r *request.Request) {
+			switch {
+			case r.Error == nil:
+				l.succeeded(time.Now())
+			case r.IsErrorThrottle():
+				metricAWSAPIThrottled.WithLabelValues(r.ClientInfo.ServiceName).Inc()
+				l.throttled(time.Now())
+			}
+		},
+	})
+	return s
+}
@@ -0,0 +1,37 @@
+package awsclient
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/time/rate"
+)
+
+func TestAccountLimiter(t *testing.T) {
+	now := time.Now()
+	l := newAccountLimiter(8, 10)
+
+	l.throttled(now)
+	assert.Equal(t, rate.Limit(4), l.limiter.Limit(), "expected throttling to halve the rate")
+	l.throttled(now.Add(100 * time.Millisecond))
+	assert.Equal(t, rate.Limit(4), l.limiter.Limit(), "expected calls throttled at once to lower the rate once")
+
+	now = now.Add(time.Minute)
+	for i := 0; i < 5; i++ {
+		l.throttled(now.Add(time.Duration(i) * throttleDecreaseInterval))
+	}
+	assert.Equal(t, rate.Limit(minRequestsPerSecond), l.limiter.Limit(), "expected the rate not to drop below the minimum")
+
+	l.succeeded(now)
+	assert.InDelta(t, float64(minRequestsPerSecond)+8*rateRecoveryFraction, float64(l.limiter.Limit()), 0.001, "expected success to raise the rate")
+	for i := 0; i < 100; i++ {
+		l.succeeded(now)
+	}
+	assert.Equal(t, rate.Limit(8), l.limiter.Limit(), "expected the rate to recover up to the configured rate")
+}
+
+func TestLimiterForCredentials(t *testing.T) {
+	assert.Same(t, limiterForCredentials("AKIAEXAMPLE"), limiterForCredentials("AKIAEXAMPLE"), "expected clients with the same credentials to share a token bucket")
+	assert.NotSame(t, limiterForCredentials("AKIAEXAMPLE"), limiterForCredentials("AKIAOTHER"), "expected clients with other credentials not to share a token bucket")
+}
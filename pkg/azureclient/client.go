@@ -4,18 +4,51 @@ import (
 	"context"
 	"encoding/json"
 	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2019-12-01/compute"
 	"github.com/Azure/azure-sdk-for-go/services/dns/mgmt/2018-05-01/dns"
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-12-01/network"
+	"github.com/Azure/go-autorest/autorest"
 	"github.com/Azure/go-autorest/autorest/azure"
 	"github.com/Azure/go-autorest/autorest/azure/auth"
 	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
 	corev1 "k8s.io/api/core/v1"
 
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
 	"github.com/openshift/hive/pkg/constants"
 )
 
+const (
+	// retryAttempts is the maximum number of times a call failing with a transient error, such as rate limiting or a
+	// temporary server error, is retried. Only the calls with an idempotent request method are retried.
+	retryAttempts = 5
+	// retryDuration is the delay before the first retry of a call. The delay doubles with each retry.
+	retryDuration = time.Second
+
+	// expiredClientSecretErrorCode is the Azure AD error code of a token request with an expired client secret.
+	expiredClientSecretErrorCode = "AADSTS7000222"
+)
+
+var (
+	metricAzureAPICalls = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "hive_azure_api_calls_total",
+			Help: "Number of API calls made to Azure, partitioned by function.",
+		},
+		[]string{"function"},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(metricAzureAPICalls)
+}
+
 //go:generate mockgen -source=./client.go -destination=./mock/client_generated.go -package=mock
 
 // Client is a wrapper object for actual Azure libraries to allow for easier mocking/testing.
@@ -36,6 +69,9 @@ type Client interface {
 	ListAllVirtualMachines(ctx context.Context, statusOnly string) (compute.VirtualMachineListResultPage, error)
 	DeallocateVirtualMachine(ctx context.Context, resourceGroup, name string) (compute.VirtualMachinesDeallocateFuture, error)
 	StartVirtualMachine(ctx context.Context, resourceGroup, name string) (compute.VirtualMachinesStartFuture, error)
+
+	// Subnets
+	GetSubnet(ctx context.Context, resourceGroupName, virtualNetworkName, subnetName string) (network.Subnet, error)
 }
 
 // ResourceSKUsPage is a page of results from listing resource SKUs.
@@ -57,14 +93,17 @@ type azureClient struct {
 	recordSetsClient      *dns.RecordSetsClient
 	zonesClient           *dns.ZonesClient
 	virtualMachinesClient *compute.VirtualMachinesClient
+	subnetsClient         *network.SubnetsClient
 }
 
 func (c *azureClient) ListResourceSKUs(ctx context.Context, filter string) (ResourceSKUsPage, error) {
+	metricAzureAPICalls.WithLabelValues("ListResourceSKUs").Inc()
 	page, err := c.resourceSKUsClient.List(ctx, filter)
 	return &page, err
 }
 
 func (c *azureClient) CreateOrUpdateZone(ctx context.Context, resourceGroupName string, zone string) (dns.Zone, error) {
+	metricAzureAPICalls.WithLabelValues("CreateOrUpdateZone").Inc()
 	return c.zonesClient.CreateOrUpdate(ctx, resourceGroupName, zone, dns.Zone{
 		Location: to.StringPtr("global"),
 		ZoneProperties: &dns.ZoneProperties{
@@ -74,6 +113,7 @@ func (c *azureClient) CreateOrUpdateZone(ctx context.Context, resourceGroupName
 }
 
 func (c *azureClient) DeleteZone(ctx context.Context, resourceGroupName string, zone string) error {
+	metricAzureAPICalls.WithLabelValues("DeleteZone").Inc()
 	future, err := c.zonesClient.Delete(ctx, resourceGroupName, zone, "")
 	if err != nil {
 		return err
@@ -83,35 +123,47 @@ func (c *azureClient) DeleteZone(ctx context.Context, resourceGroupName string,
 }
 
 func (c *azureClient) DeleteRecordSet(ctx context.Context, resourceGroupName string, zone string, recordSetName string, recordType dns.RecordType) error {
+	metricAzureAPICalls.WithLabelValues("DeleteRecordSet").Inc()
 	_, err := c.recordSetsClient.Delete(ctx, resourceGroupName, zone, recordSetName, recordType, "")
 	return err
 }
 
 func (c *azureClient) ListRecordSetsByZone(ctx context.Context, resourceGroupName string, zone string, suffix string) (RecordSetPage, error) {
+	metricAzureAPICalls.WithLabelValues("ListRecordSetsByZone").Inc()
 	page, err := c.recordSetsClient.ListByDNSZone(ctx, resourceGroupName, zone, nil, suffix)
 	return &page, err
 }
 
 func (c *azureClient) GetZone(ctx context.Context, resourceGroupName string, zone string) (dns.Zone, error) {
+	metricAzureAPICalls.WithLabelValues("GetZone").Inc()
 	return c.zonesClient.Get(ctx, resourceGroupName, zone)
 }
 
 func (c *azureClient) CreateOrUpdateRecordSet(ctx context.Context, resourceGroupName string, zone string, recordSetName string, recordType dns.RecordType, recordSet dns.RecordSet) (dns.RecordSet, error) {
+	metricAzureAPICalls.WithLabelValues("CreateOrUpdateRecordSet").Inc()
 	return c.recordSetsClient.CreateOrUpdate(ctx, resourceGroupName, zone, recordSetName, recordType, recordSet, "", "")
 }
 
 func (c *azureClient) ListAllVirtualMachines(ctx context.Context, statusOnly string) (compute.VirtualMachineListResultPage, error) {
+	metricAzureAPICalls.WithLabelValues("ListAllVirtualMachines").Inc()
 	return c.virtualMachinesClient.ListAll(ctx, statusOnly)
 }
 
 func (c *azureClient) DeallocateVirtualMachine(ctx context.Context, resourceGroup, name string) (compute.VirtualMachinesDeallocateFuture, error) {
+	metricAzureAPICalls.WithLabelValues("DeallocateVirtualMachine").Inc()
 	return c.virtualMachinesClient.Deallocate(ctx, resourceGroup, name)
 }
 
 func (c *azureClient) StartVirtualMachine(ctx context.Context, resourceGroup, name string) (compute.VirtualMachinesStartFuture, error) {
+	metricAzureAPICalls.WithLabelValues("StartVirtualMachine").Inc()
 	return c.virtualMachinesClient.Start(ctx, resourceGroup, name)
 }
 
+func (c *azureClient) GetSubnet(ctx context.Context, resourceGroupName, virtualNetworkName, subnetName string) (network.Subnet, error) {
+	metricAzureAPICalls.WithLabelValues("GetSubnet").Inc()
+	return c.subnetsClient.Get(ctx, resourceGroupName, virtualNetworkName, subnetName, "")
+}
+
 // NewClientFromSecret creates our client wrapper object for interacting with Azure. The Azure creds are read from the
 // specified secret.
 func NewClientFromSecret(secret *corev1.Secret) (Client, error) {
@@ -130,32 +182,10 @@ func NewClient(creds []byte) (Client, error) {
 }
 
 func newClient(authJSONSource func() ([]byte, error)) (*azureClient, error) {
-	authJSON, err := authJSONSource()
+	config, subscriptionID, err := clientCredentialsConfig(authJSONSource)
 	if err != nil {
 		return nil, err
 	}
-	var authMap map[string]string
-	if err := json.Unmarshal(authJSON, &authMap); err != nil {
-		return nil, err
-	}
-	clientID, ok := authMap["clientId"]
-	if !ok {
-		return nil, errors.New("missing clientId in auth")
-	}
-	clientSecret, ok := authMap["clientSecret"]
-	if !ok {
-		return nil, errors.New("missing clientSecret in auth")
-	}
-	tenantID, ok := authMap["tenantId"]
-	if !ok {
-		return nil, errors.New("missing tenantId in auth")
-	}
-	subscriptionID, ok := authMap["subscriptionId"]
-	if !ok {
-		return nil, errors.New("missing subscriptionId in auth")
-	}
-
-	config := auth.NewClientCredentialsConfig(clientID, clientSecret, tenantID)
 
 	authorizer, err := config.Authorizer()
 	if err != nil {
@@ -174,14 +204,98 @@ func newClient(authJSONSource func() ([]byte, error)) (*azureClient, error) {
 	virtualMachinesClient := compute.NewVirtualMachinesClientWithBaseURI(azure.PublicCloud.ResourceManagerEndpoint, subscriptionID)
 	virtualMachinesClient.Authorizer = authorizer
 
+	subnetsClient := network.NewSubnetsClientWithBaseURI(azure.PublicCloud.ResourceManagerEndpoint, subscriptionID)
+	subnetsClient.Authorizer = authorizer
+
+	for _, c := range []*autorest.Client{
+		&resourceSKUsClient.Client,
+		&recordSetsClient.Client,
+		&zonesClient.Client,
+		&virtualMachinesClient.Client,
+		&subnetsClient.Client,
+	} {
+		c.RetryAttempts = retryAttempts
+		c.RetryDuration = retryDuration
+		c.SendDecorators = []autorest.SendDecorator{retryIdempotent(*c)}
+	}
+
 	return &azureClient{
 		resourceSKUsClient:    &resourceSKUsClient,
 		recordSetsClient:      &recordSetsClient,
 		zonesClient:           &zonesClient,
 		virtualMachinesClient: &virtualMachinesClient,
+		subnetsClient:         &subnetsClient,
 	}, nil
 }
 
+// clientCredentialsConfig returns the config authenticating as the service principal of the Azure creds, and the ID of
+// their subscription.
+func clientCredentialsConfig(authJSONSource func() ([]byte, error)) (auth.ClientCredentialsConfig, string, error) {
+	authJSON, err := authJSONSource()
+	if err != nil {
+		return auth.ClientCredentialsConfig{}, "", err
+	}
+	var authMap map[string]string
+	if err := json.Unmarshal(authJSON, &authMap); err != nil {
+		return auth.ClientCredentialsConfig{}, "", err
+	}
+	clientID, ok := authMap["clientId"]
+	if !ok {
+		return auth.ClientCredentialsConfig{}, "", errors.New("missing clientId in auth")
+	}
+	clientSecret, ok := authMap["clientSecret"]
+	if !ok {
+		return auth.ClientCredentialsConfig{}, "", errors.New("missing clientSecret in auth")
+	}
+	tenantID, ok := authMap["tenantId"]
+	if !ok {
+		return auth.ClientCredentialsConfig{}, "", errors.New("missing tenantId in auth")
+	}
+	subscriptionID, ok := authMap["subscriptionId"]
+	if !ok {
+		return auth.ClientCredentialsConfig{}, "", errors.New("missing subscriptionId in auth")
+	}
+	return auth.NewClientCredentialsConfig(clientID, clientSecret, tenantID), subscriptionID, nil
+}
+
+// ValidateServicePrincipal requests a token for the service principal of the Azure creds in the secret. It returns an
+// error when Azure AD does not issue one, such as when the client secret of the service principal has expired.
+func ValidateServicePrincipal(ctx context.Context, secret *corev1.Secret) error {
+	return validateServicePrincipal(ctx, authJSONFromSecretSource(secret), nil)
+}
+
+// validateServicePrincipal requests a token for the service principal of the Azure creds, sending the request with the
+// given sender when it is not nil.
+func validateServicePrincipal(ctx context.Context, authJSONSource func() ([]byte, error), sender autorest.Sender) error {
+	config, _, err := clientCredentialsConfig(authJSONSource)
+	if err != nil {
+		return err
+	}
+	token, err := config.ServicePrincipalToken()
+	if err != nil {
+		return err
+	}
+	if sender != nil {
+		token.SetSender(sender)
+	}
+	metricAzureAPICalls.WithLabelValues("ValidateServicePrincipal").Inc()
+	return token.RefreshWithContext(ctx)
+}
+
+// IsTokenRefused returns whether the error is Azure AD refusing to issue a token for a service principal, as opposed
+// to Azure AD not being reachable.
+func IsTokenRefused(err error) bool {
+	var refused interface{ Response() *http.Response }
+	return errors.As(err, &refused) && refused.Response() != nil &&
+		refused.Response().StatusCode >= 400 && refused.Response().StatusCode < 500
+}
+
+// IsExpiredServicePrincipal returns whether the error is Azure AD refusing to issue a token because the client secret
+// of the service principal has expired.
+func IsExpiredServicePrincipal(err error) bool {
+	return IsTokenRefused(err) && strings.Contains(err.Error(), expiredClientSecretErrorCode)
+}
+
 func authJSONFromBytes(creds []byte) func() ([]byte, error) {
 	return func() ([]byte, error) {
 		return creds, nil
@@ -203,3 +317,19 @@ func authJSONFromFileSource(filename string) func() ([]byte, error) {
 		return ioutil.ReadFile(filename)
 	}
 }
+
+// retryIdempotent returns a SendDecorator which retries the requests failing with a transient error, as the Azure
+// clients do by default, but only when the request method is idempotent. Requests such as starting or deallocating a
+// virtual machine are sent once, so that a request which reached Azure before failing is not repeated.
+func retryIdempotent(client autorest.Client) autorest.SendDecorator {
+	return func(s autorest.Sender) autorest.Sender {
+		retrying := autorest.DecorateSender(s, azure.DoRetryWithRegistration(client))
+		return autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+			switch r.Method {
+			case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+				return retrying.Do(r)
+			}
+			return s.Do(r)
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package azureclient
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryIdempotent(t *testing.T) {
+	tests := []struct {
+		name          string
+		method        string
+		expectedCalls int
+	}{
+		{
+			name:          "get retried",
+			method:        http.MethodGet,
+			expectedCalls: 3,
+		},
+		{
+			name:          "put retried",
+			method:        http.MethodPut,
+			expectedCalls: 3,
+		},
+		{
+			name:          "delete retried",
+			method:        http.MethodDelete,
+			expectedCalls: 3,
+		},
+		{
+			name:          "post not retried",
+			method:        http.MethodPost,
+			expectedCalls: 1,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			calls := 0
+			sender := autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+				calls++
+				if calls <= 2 {
+					return &http.Response{StatusCode: http.StatusServiceUnavailable, Body: http.NoBody, Request: r}, nil
+				}
+				return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody, Request: r}, nil
+			})
+			client := autorest.NewClientWithUserAgent("test")
+			client.RetryAttempts = retryAttempts
+			client.RetryDuration = 0
+			req, err := http.NewRequest(test.method, "https://management.azure.com/test", nil)
+			if !assert.NoError(t, err, "unexpected error creating request") {
+				return
+			}
+			_, err = autorest.SendWithSender(sender, req, retryIdempotent(client))
+			assert.NoError(t, err, "unexpected error sending request")
+			assert.Equal(t, test.expectedCalls, calls, "unexpected number of requests")
+		})
+	}
+}
+
+func TestValidateServicePrincipal(t *testing.T) {
+	tests := []struct {
+		name            string
+		status          int
+		body            string
+		sendErr         error
+		expectErr       bool
+		expectedRefused bool
+		expectedExpired bool
+	}{
+		{
+			name:   "token issued",
+			status: http.StatusOK,
+			body:   `{"access_token":"token","expires_in":"3600","expires_on":"0","not_before":"0","token_type":"Bearer"}`,
+		},
+		{
+			name:            "expired client secret",
+			status:          http.StatusUnauthorized,
+			body:            `{"error":"invalid_client","error_description":"AADSTS7000222: The provided client secret keys are expired."}`,
+			expectErr:       true,
+			expectedRefused: true,
+			expectedExpired: true,
+		},
+		{
+			name:            "invalid client secret",
+			status:          http.StatusUnauthorized,
+			body:            `{"error":"invalid_client","error_description":"AADSTS7000215: Invalid client secret is provided."}`,
+			expectErr:       true,
+			expectedRefused: true,
+		},
+		{
+			name:      "unreachable",
+			sendErr:   errors.New("connection refused"),
+			expectErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sender := autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+				if test.sendErr != nil {
+					return nil, test.sendErr
+				}
+				return &http.Response{
+					StatusCode: test.status,
+					Body:       ioutil.NopCloser(strings.NewReader(test.body)),
+					Request:    r,
+				}, nil
+			})
+			creds := []byte(`{"clientId":"client","clientSecret":"secret","tenantId":"tenant","subscriptionId":"subscription"}`)
+			err := validateServicePrincipal(context.Background(), authJSONFromBytes(creds), sender)
+			if test.expectErr {
+				assert.Error(t, err, "expected error validating service principal")
+			} else {
+				assert.NoError(t, err, "unexpected error validating service principal")
+			}
+			assert.Equal(t, test.expectedRefused, IsTokenRefused(err), "unexpected token refused")
+			assert.Equal(t, test.expectedExpired, IsExpiredServicePrincipal(err), "unexpected expired service principal")
+		})
+	}
+}
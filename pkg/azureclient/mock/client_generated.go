@@ -8,6 +8,7 @@ import (
 	context "context"
 	compute "github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2019-12-01/compute"
 	dns "github.com/Azure/azure-sdk-for-go/services/dns/mgmt/2018-05-01/dns"
+	network "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-12-01/network"
 	gomock "github.com/golang/mock/gomock"
 	azureclient "github.com/openshift/hive/pkg/azureclient"
 	reflect "reflect"
@@ -184,6 +185,21 @@ func (mr *MockClientMockRecorder) StartVirtualMachine(ctx, resourceGroup, name i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartVirtualMachine", reflect.TypeOf((*MockClient)(nil).StartVirtualMachine), ctx, resourceGroup, name)
 }
 
+// GetSubnet mocks base method
+func (m *MockClient) GetSubnet(ctx context.Context, resourceGroupName, virtualNetworkName, subnetName string) (network.Subnet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubnet", ctx, resourceGroupName, virtualNetworkName, subnetName)
+	ret0, _ := ret[0].(network.Subnet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubnet indicates an expected call of GetSubnet
+func (mr *MockClientMockRecorder) GetSubnet(ctx, resourceGroupName, virtualNetworkName, subnetName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubnet", reflect.TypeOf((*MockClient)(nil).GetSubnet), ctx, resourceGroupName, virtualNetworkName, subnetName)
+}
+
 // MockResourceSKUsPage is a mock of ResourceSKUsPage interface
 type MockResourceSKUsPage struct {
 	ctrl     *gomock.Controller
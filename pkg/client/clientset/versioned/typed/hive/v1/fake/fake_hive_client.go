@@ -12,6 +12,14 @@ type FakeHiveV1 struct {
 	*testing.Fake
 }
 
+func (c *FakeHiveV1) BaseDomainPools() v1.BaseDomainPoolInterface {
+	return &FakeBaseDomainPools{c}
+}
+
+func (c *FakeHiveV1) ChangeFreezes() v1.ChangeFreezeInterface {
+	return &FakeChangeFreezes{c}
+}
+
 func (c *FakeHiveV1) Checkpoints(namespace string) v1.CheckpointInterface {
 	return &FakeCheckpoints{c, namespace}
 }
@@ -24,6 +32,10 @@ func (c *FakeHiveV1) ClusterDeployments(namespace string) v1.ClusterDeploymentIn
 	return &FakeClusterDeployments{c, namespace}
 }
 
+func (c *FakeHiveV1) ClusterDeploymentCustomizations(namespace string) v1.ClusterDeploymentCustomizationInterface {
+	return &FakeClusterDeploymentCustomizations{c, namespace}
+}
+
 func (c *FakeHiveV1) ClusterDeprovisions(namespace string) v1.ClusterDeprovisionInterface {
 	return &FakeClusterDeprovisions{c, namespace}
 }
@@ -32,6 +44,10 @@ func (c *FakeHiveV1) ClusterImageSets() v1.ClusterImageSetInterface {
 	return &FakeClusterImageSets{c}
 }
 
+func (c *FakeHiveV1) ClusterInventories(namespace string) v1.ClusterInventoryInterface {
+	return &FakeClusterInventories{c, namespace}
+}
+
 func (c *FakeHiveV1) ClusterPools(namespace string) v1.ClusterPoolInterface {
 	return &FakeClusterPools{c, namespace}
 }
@@ -52,10 +68,18 @@ func (c *FakeHiveV1) DNSZones(namespace string) v1.DNSZoneInterface {
 	return &FakeDNSZones{c, namespace}
 }
 
+func (c *FakeHiveV1) GitSyncSources() v1.GitSyncSourceInterface {
+	return &FakeGitSyncSources{c}
+}
+
 func (c *FakeHiveV1) HiveConfigs() v1.HiveConfigInterface {
 	return &FakeHiveConfigs{c}
 }
 
+func (c *FakeHiveV1) HiveNamespaceConfigs(namespace string) v1.HiveNamespaceConfigInterface {
+	return &FakeHiveNamespaceConfigs{c, namespace}
+}
+
 func (c *FakeHiveV1) MachinePools(namespace string) v1.MachinePoolInterface {
 	return &FakeMachinePools{c, namespace}
 }
@@ -2,16 +2,24 @@
 
 package v1
 
+type BaseDomainPoolExpansion interface{}
+
+type ChangeFreezeExpansion interface{}
+
 type CheckpointExpansion interface{}
 
 type ClusterClaimExpansion interface{}
 
 type ClusterDeploymentExpansion interface{}
 
+type ClusterDeploymentCustomizationExpansion interface{}
+
 type ClusterDeprovisionExpansion interface{}
 
 type ClusterImageSetExpansion interface{}
 
+type ClusterInventoryExpansion interface{}
+
 type ClusterPoolExpansion interface{}
 
 type ClusterProvisionExpansion interface{}
@@ -22,8 +30,12 @@ type ClusterStateExpansion interface{}
 
 type DNSZoneExpansion interface{}
 
+type GitSyncSourceExpansion interface{}
+
 type HiveConfigExpansion interface{}
 
+type HiveNamespaceConfigExpansion interface{}
+
 type MachinePoolExpansion interface{}
 
 type MachinePoolNameLeaseExpansion interface{}
@@ -10,17 +10,23 @@ import (
 
 type HiveV1Interface interface {
 	RESTClient() rest.Interface
+	BaseDomainPoolsGetter
+	ChangeFreezesGetter
 	CheckpointsGetter
 	ClusterClaimsGetter
 	ClusterDeploymentsGetter
+	ClusterDeploymentCustomizationsGetter
 	ClusterDeprovisionsGetter
 	ClusterImageSetsGetter
+	ClusterInventoriesGetter
 	ClusterPoolsGetter
 	ClusterProvisionsGetter
 	ClusterRelocatesGetter
 	ClusterStatesGetter
 	DNSZonesGetter
+	GitSyncSourcesGetter
 	HiveConfigsGetter
+	HiveNamespaceConfigsGetter
 	MachinePoolsGetter
 	MachinePoolNameLeasesGetter
 	SelectorSyncIdentityProvidersGetter
@@ -34,6 +40,14 @@ type HiveV1Client struct {
 	restClient rest.Interface
 }
 
+func (c *HiveV1Client) BaseDomainPools() BaseDomainPoolInterface {
+	return newBaseDomainPools(c)
+}
+
+func (c *HiveV1Client) ChangeFreezes() ChangeFreezeInterface {
+	return newChangeFreezes(c)
+}
+
 func (c *HiveV1Client) Checkpoints(namespace string) CheckpointInterface {
 	return newCheckpoints(c, namespace)
 }
@@ -46,6 +60,10 @@ func (c *HiveV1Client) ClusterDeployments(namespace string) ClusterDeploymentInt
 	return newClusterDeployments(c, namespace)
 }
 
+func (c *HiveV1Client) ClusterDeploymentCustomizations(namespace string) ClusterDeploymentCustomizationInterface {
+	return newClusterDeploymentCustomizations(c, namespace)
+}
+
 func (c *HiveV1Client) ClusterDeprovisions(namespace string) ClusterDeprovisionInterface {
 	return newClusterDeprovisions(c, namespace)
 }
@@ -54,6 +72,10 @@ func (c *HiveV1Client) ClusterImageSets() ClusterImageSetInterface {
 	return newClusterImageSets(c)
 }
 
+func (c *HiveV1Client) ClusterInventories(namespace string) ClusterInventoryInterface {
+	return newClusterInventories(c, namespace)
+}
+
 func (c *HiveV1Client) ClusterPools(namespace string) ClusterPoolInterface {
 	return newClusterPools(c, namespace)
 }
@@ -74,10 +96,18 @@ func (c *HiveV1Client) DNSZones(namespace string) DNSZoneInterface {
 	return newDNSZones(c, namespace)
 }
 
+func (c *HiveV1Client) GitSyncSources() GitSyncSourceInterface {
+	return newGitSyncSources(c)
+}
+
 func (c *HiveV1Client) HiveConfigs() HiveConfigInterface {
 	return newHiveConfigs(c)
 }
 
+func (c *HiveV1Client) HiveNamespaceConfigs(namespace string) HiveNamespaceConfigInterface {
+	return newHiveNamespaceConfigs(c, namespace)
+}
+
 func (c *HiveV1Client) MachinePools(namespace string) MachinePoolInterface {
 	return newMachinePools(c, namespace)
 }
@@ -38,16 +38,24 @@ func (f *genericInformer) Lister() cache.GenericLister {
 func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource) (GenericInformer, error) {
 	switch resource {
 	// Group=hive.openshift.io, Version=v1
+	case v1.SchemeGroupVersion.WithResource("basedomainpools"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Hive().V1().BaseDomainPools().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("changefreezes"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Hive().V1().ChangeFreezes().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("checkpoints"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Hive().V1().Checkpoints().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("clusterclaims"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Hive().V1().ClusterClaims().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("clusterdeployments"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Hive().V1().ClusterDeployments().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("clusterdeploymentcustomizations"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Hive().V1().ClusterDeploymentCustomizations().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("clusterdeprovisions"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Hive().V1().ClusterDeprovisions().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("clusterimagesets"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Hive().V1().ClusterImageSets().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("clusterinventories"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Hive().V1().ClusterInventories().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("clusterpools"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Hive().V1().ClusterPools().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("clusterprovisions"):
@@ -58,8 +66,12 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Hive().V1().ClusterStates().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("dnszones"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Hive().V1().DNSZones().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("gitsyncsources"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Hive().V1().GitSyncSources().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("hiveconfigs"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Hive().V1().HiveConfigs().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("hivenamespaceconfigs"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Hive().V1().HiveNamespaceConfigs().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("machinepools"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Hive().V1().MachinePools().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("machinepoolnameleases"):
@@ -8,16 +8,24 @@ import (
 
 // Interface provides access to all the informers in this group version.
 type Interface interface {
+	// BaseDomainPools returns a BaseDomainPoolInformer.
+	BaseDomainPools() BaseDomainPoolInformer
+	// ChangeFreezes returns a ChangeFreezeInformer.
+	ChangeFreezes() ChangeFreezeInformer
 	// Checkpoints returns a CheckpointInformer.
 	Checkpoints() CheckpointInformer
 	// ClusterClaims returns a ClusterClaimInformer.
 	ClusterClaims() ClusterClaimInformer
 	// ClusterDeployments returns a ClusterDeploymentInformer.
 	ClusterDeployments() ClusterDeploymentInformer
+	// ClusterDeploymentCustomizations returns a ClusterDeploymentCustomizationInformer.
+	ClusterDeploymentCustomizations() ClusterDeploymentCustomizationInformer
 	// ClusterDeprovisions returns a ClusterDeprovisionInformer.
 	ClusterDeprovisions() ClusterDeprovisionInformer
 	// ClusterImageSets returns a ClusterImageSetInformer.
 	ClusterImageSets() ClusterImageSetInformer
+	// ClusterInventories returns a ClusterInventoryInformer.
+	ClusterInventories() ClusterInventoryInformer
 	// ClusterPools returns a ClusterPoolInformer.
 	ClusterPools() ClusterPoolInformer
 	// ClusterProvisions returns a ClusterProvisionInformer.
@@ -28,8 +36,12 @@ type Interface interface {
 	ClusterStates() ClusterStateInformer
 	// DNSZones returns a DNSZoneInformer.
 	DNSZones() DNSZoneInformer
+	// GitSyncSources returns a GitSyncSourceInformer.
+	GitSyncSources() GitSyncSourceInformer
 	// HiveConfigs returns a HiveConfigInformer.
 	HiveConfigs() HiveConfigInformer
+	// HiveNamespaceConfigs returns a HiveNamespaceConfigInformer.
+	HiveNamespaceConfigs() HiveNamespaceConfigInformer
 	// MachinePools returns a MachinePoolInformer.
 	MachinePools() MachinePoolInformer
 	// MachinePoolNameLeases returns a MachinePoolNameLeaseInformer.
@@ -55,6 +67,16 @@ func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakList
 	return &version{factory: f, namespace: namespace, tweakListOptions: tweakListOptions}
 }
 
+// BaseDomainPools returns a BaseDomainPoolInformer.
+func (v *version) BaseDomainPools() BaseDomainPoolInformer {
+	return &baseDomainPoolInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// ChangeFreezes returns a ChangeFreezeInformer.
+func (v *version) ChangeFreezes() ChangeFreezeInformer {
+	return &changeFreezeInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // Checkpoints returns a CheckpointInformer.
 func (v *version) Checkpoints() CheckpointInformer {
 	return &checkpointInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -70,6 +92,11 @@ func (v *version) ClusterDeployments() ClusterDeploymentInformer {
 	return &clusterDeploymentInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// ClusterDeploymentCustomizations returns a ClusterDeploymentCustomizationInformer.
+func (v *version) ClusterDeploymentCustomizations() ClusterDeploymentCustomizationInformer {
+	return &clusterDeploymentCustomizationInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // ClusterDeprovisions returns a ClusterDeprovisionInformer.
 func (v *version) ClusterDeprovisions() ClusterDeprovisionInformer {
 	return &clusterDeprovisionInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -80,6 +107,11 @@ func (v *version) ClusterImageSets() ClusterImageSetInformer {
 	return &clusterImageSetInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
 
+// ClusterInventories returns a ClusterInventoryInformer.
+func (v *version) ClusterInventories() ClusterInventoryInformer {
+	return &clusterInventoryInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // ClusterPools returns a ClusterPoolInformer.
 func (v *version) ClusterPools() ClusterPoolInformer {
 	return &clusterPoolInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -105,11 +137,21 @@ func (v *version) DNSZones() DNSZoneInformer {
 	return &dNSZoneInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// GitSyncSources returns a GitSyncSourceInformer.
+func (v *version) GitSyncSources() GitSyncSourceInformer {
+	return &gitSyncSourceInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // HiveConfigs returns a HiveConfigInformer.
 func (v *version) HiveConfigs() HiveConfigInformer {
 	return &hiveConfigInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
 
+// HiveNamespaceConfigs returns a HiveNamespaceConfigInformer.
+func (v *version) HiveNamespaceConfigs() HiveNamespaceConfigInformer {
+	return &hiveNamespaceConfigInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // MachinePools returns a MachinePoolInformer.
 func (v *version) MachinePools() MachinePoolInformer {
 	return &machinePoolInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
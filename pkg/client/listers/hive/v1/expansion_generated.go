@@ -2,6 +2,14 @@
 
 package v1
 
+// BaseDomainPoolListerExpansion allows custom methods to be added to
+// BaseDomainPoolLister.
+type BaseDomainPoolListerExpansion interface{}
+
+// ChangeFreezeListerExpansion allows custom methods to be added to
+// ChangeFreezeLister.
+type ChangeFreezeListerExpansion interface{}
+
 // CheckpointListerExpansion allows custom methods to be added to
 // CheckpointLister.
 type CheckpointListerExpansion interface{}
@@ -26,6 +34,14 @@ type ClusterDeploymentListerExpansion interface{}
 // ClusterDeploymentNamespaceLister.
 type ClusterDeploymentNamespaceListerExpansion interface{}
 
+// ClusterDeploymentCustomizationListerExpansion allows custom methods to be added to
+// ClusterDeploymentCustomizationLister.
+type ClusterDeploymentCustomizationListerExpansion interface{}
+
+// ClusterDeploymentCustomizationNamespaceListerExpansion allows custom methods to be added to
+// ClusterDeploymentCustomizationNamespaceLister.
+type ClusterDeploymentCustomizationNamespaceListerExpansion interface{}
+
 // ClusterDeprovisionListerExpansion allows custom methods to be added to
 // ClusterDeprovisionLister.
 type ClusterDeprovisionListerExpansion interface{}
@@ -38,6 +54,14 @@ type ClusterDeprovisionNamespaceListerExpansion interface{}
 // ClusterImageSetLister.
 type ClusterImageSetListerExpansion interface{}
 
+// ClusterInventoryListerExpansion allows custom methods to be added to
+// ClusterInventoryLister.
+type ClusterInventoryListerExpansion interface{}
+
+// ClusterInventoryNamespaceListerExpansion allows custom methods to be added to
+// ClusterInventoryNamespaceLister.
+type ClusterInventoryNamespaceListerExpansion interface{}
+
 // ClusterPoolListerExpansion allows custom methods to be added to
 // ClusterPoolLister.
 type ClusterPoolListerExpansion interface{}
@@ -74,10 +98,22 @@ type DNSZoneListerExpansion interface{}
 // DNSZoneNamespaceLister.
 type DNSZoneNamespaceListerExpansion interface{}
 
+// GitSyncSourceListerExpansion allows custom methods to be added to
+// GitSyncSourceLister.
+type GitSyncSourceListerExpansion interface{}
+
 // HiveConfigListerExpansion allows custom methods to be added to
 // HiveConfigLister.
 type HiveConfigListerExpansion interface{}
 
+// HiveNamespaceConfigListerExpansion allows custom methods to be added to
+// HiveNamespaceConfigLister.
+type HiveNamespaceConfigListerExpansion interface{}
+
+// HiveNamespaceConfigNamespaceListerExpansion allows custom methods to be added to
+// HiveNamespaceConfigNamespaceLister.
+type HiveNamespaceConfigNamespaceListerExpansion interface{}
+
 // MachinePoolListerExpansion allows custom methods to be added to
 // MachinePoolLister.
 type MachinePoolListerExpansion interface{}
@@ -29,6 +29,10 @@ type Builder struct {
 	// tagging.
 	Name string
 
+	// DisplayName is an optional human-friendly name for the cluster, set as ClusterDeployment.Spec.DisplayName.
+	// Unlike Name, it can be changed after the cluster is created.
+	DisplayName string
+
 	// Namespace where the ClusterDeployment and all associated artifacts will be created.
 	Namespace string
 
@@ -58,6 +62,10 @@ type Builder struct {
 	// BaseDomain is the DNS base domain to be used for the cluster.
 	BaseDomain string
 
+	// BaseDomainPoolRef is the BaseDomainPool to allocate the base domain of the cluster from when BaseDomain
+	// is empty.
+	BaseDomainPoolRef *hivev1.BaseDomainPoolReference
+
 	// WorkerNodesCount is the number of worker nodes to create in the cluster initially.
 	WorkerNodesCount int64
 
@@ -72,6 +80,9 @@ type Builder struct {
 	// HibernateAfter is the duration after which a running cluster should be automatically hibernated.
 	HibernateAfter *time.Duration
 
+	// ResumeReadiness is the set of checks which the cluster must pass after resuming from hibernation.
+	ResumeReadiness *hivev1.ResumeReadiness
+
 	// ServingCert is the contents of a serving certificate to be used for the cluster.
 	ServingCert string
 
@@ -144,7 +155,7 @@ func (o *Builder) Validate() error {
 	if len(o.Name) == 0 {
 		return fmt.Errorf("name is required")
 	}
-	if len(o.BaseDomain) == 0 {
+	if len(o.BaseDomain) == 0 && o.BaseDomainPoolRef == nil {
 		return fmt.Errorf("BaseDomain is required")
 	}
 	if o.CloudBuilder == nil {
@@ -274,10 +285,12 @@ func (o *Builder) generateClusterDeployment() *hivev1.ClusterDeployment {
 			Labels:      o.Labels,
 		},
 		Spec: hivev1.ClusterDeploymentSpec{
-			ClusterName:  o.Name,
-			BaseDomain:   o.BaseDomain,
-			ManageDNS:    o.ManageDNS,
-			Provisioning: &hivev1.Provisioning{},
+			ClusterName:       o.Name,
+			DisplayName:       o.DisplayName,
+			BaseDomain:        o.BaseDomain,
+			BaseDomainPoolRef: o.BaseDomainPoolRef,
+			ManageDNS:         o.ManageDNS,
+			Provisioning:      &hivev1.Provisioning{},
 		},
 	}
 
@@ -320,6 +333,8 @@ func (o *Builder) generateClusterDeployment() *hivev1.ClusterDeployment {
 		cd.Spec.HibernateAfter = &metav1.Duration{Duration: *o.HibernateAfter}
 	}
 
+	cd.Spec.ResumeReadiness = o.ResumeReadiness
+
 	if o.Adopt {
 		cd.Spec.ClusterMetadata = &hivev1.ClusterMetadata{
 			ClusterID:                o.AdoptClusterID,
@@ -0,0 +1,96 @@
+package clusterresource
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	installertypes "github.com/openshift/installer/pkg/types"
+	installernone "github.com/openshift/installer/pkg/types/none"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1oci "github.com/openshift/hive/apis/hive/v1/oci"
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/ociclient"
+)
+
+var _ CloudBuilder = (*OCICloudBuilder)(nil)
+
+// OCICloudBuilder encapsulates cluster artifact generation logic specific to OCI.
+type OCICloudBuilder struct {
+	// Credentials are the OCI API signing key credentials used for cluster provisioning.
+	Credentials ociclient.Credentials
+
+	// Region is the OCI region to which the cluster will be deployed.
+	Region string
+
+	// CompartmentID is the OCID of the compartment in which cluster resources are created.
+	CompartmentID string
+
+	// DNSCompartmentID is the OCID of the compartment holding the cluster DNS zone. Defaults to CompartmentID.
+	DNSCompartmentID string
+
+	// Shape is the OCI compute shape to use for workers.
+	Shape string
+}
+
+func (p *OCICloudBuilder) GenerateCredentialsSecret(o *Builder) *corev1.Secret {
+	data := map[string][]byte{
+		constants.OCITenancySecretKey:     []byte(p.Credentials.Tenancy),
+		constants.OCIUserSecretKey:        []byte(p.Credentials.User),
+		constants.OCIFingerprintSecretKey: []byte(p.Credentials.Fingerprint),
+		constants.OCIPrivateKeySecretKey:  p.Credentials.PrivateKey,
+	}
+	if len(p.Credentials.Passphrase) > 0 {
+		data[constants.OCIPassphraseSecretKey] = p.Credentials.Passphrase
+	}
+	return &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Secret",
+			APIVersion: corev1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      p.CredsSecretName(o),
+			Namespace: o.Namespace,
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: data,
+	}
+}
+
+func (p *OCICloudBuilder) GetCloudPlatform(o *Builder) hivev1.Platform {
+	return hivev1.Platform{
+		OCI: &hivev1oci.Platform{
+			CredentialsSecretRef: corev1.LocalObjectReference{
+				Name: p.CredsSecretName(o),
+			},
+			Region:           p.Region,
+			CompartmentID:    p.CompartmentID,
+			DNSCompartmentID: p.DNSCompartmentID,
+		},
+	}
+}
+
+func (p *OCICloudBuilder) addMachinePoolPlatform(o *Builder, mp *hivev1.MachinePool) {
+	mp.Spec.Platform.OCI = &hivev1oci.MachinePool{
+		Shape: p.Shape,
+	}
+}
+
+// addInstallConfigPlatform uses the "none" platform, as the vendored installer has no native OCI platform.
+// The OCI infrastructure is expected to be created by install manifests supplied with the ClusterDeployment.
+func (p *OCICloudBuilder) addInstallConfigPlatform(o *Builder, ic *installertypes.InstallConfig) {
+	ic.Platform = installertypes.Platform{
+		None: &installernone.Platform{},
+	}
+}
+
+func (p *OCICloudBuilder) CredsSecretName(o *Builder) string {
+	return fmt.Sprintf("%s-oci-creds", o.Name)
+}
+
+func (p *OCICloudBuilder) GenerateCloudObjects(o *Builder) []runtime.Object {
+	return []runtime.Object{}
+}
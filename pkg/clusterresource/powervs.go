@@ -0,0 +1,96 @@
+package clusterresource
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	installertypes "github.com/openshift/installer/pkg/types"
+	installernone "github.com/openshift/installer/pkg/types/none"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1powervs "github.com/openshift/hive/apis/hive/v1/powervs"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+var _ CloudBuilder = (*PowerVSCloudBuilder)(nil)
+
+// PowerVSCloudBuilder encapsulates cluster artifact generation logic specific to IBM PowerVS.
+type PowerVSCloudBuilder struct {
+	// APIKey is the IBM Cloud API key used for cluster provisioning.
+	APIKey string
+
+	// Region is the IBM Cloud region to which the cluster will be deployed.
+	Region string
+
+	// Zone is the PowerVS zone to which the cluster will be deployed.
+	Zone string
+
+	// ServiceInstanceID is the GUID of the PowerVS service instance in which cluster resources are created.
+	ServiceInstanceID string
+
+	// NetworkName is the name of an existing network in the service instance to use for the cluster.
+	NetworkName string
+
+	// SysType is the system type to use for workers.
+	SysType string
+}
+
+func (p *PowerVSCloudBuilder) GenerateCredentialsSecret(o *Builder) *corev1.Secret {
+	return &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Secret",
+			APIVersion: corev1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      p.CredsSecretName(o),
+			Namespace: o.Namespace,
+		},
+		Type: corev1.SecretTypeOpaque,
+		StringData: map[string]string{
+			constants.PowerVSAPIKeySecretKey: p.APIKey,
+		},
+	}
+}
+
+func (p *PowerVSCloudBuilder) GetCloudPlatform(o *Builder) hivev1.Platform {
+	return hivev1.Platform{
+		PowerVS: &hivev1powervs.Platform{
+			CredentialsSecretRef: corev1.LocalObjectReference{
+				Name: p.CredsSecretName(o),
+			},
+			Region:            p.Region,
+			Zone:              p.Zone,
+			ServiceInstanceID: p.ServiceInstanceID,
+			NetworkName:       p.NetworkName,
+		},
+	}
+}
+
+func (p *PowerVSCloudBuilder) addMachinePoolPlatform(o *Builder, mp *hivev1.MachinePool) {
+	mp.Spec.Platform.PowerVS = &hivev1powervs.MachinePool{
+		SysType: p.SysType,
+	}
+}
+
+// addInstallConfigPlatform uses the "none" platform, as the vendored installer has no native PowerVS platform.
+// The PowerVS infrastructure is expected to be created by install manifests supplied with the ClusterDeployment.
+func (p *PowerVSCloudBuilder) addInstallConfigPlatform(o *Builder, ic *installertypes.InstallConfig) {
+	ic.Platform = installertypes.Platform{
+		None: &installernone.Platform{},
+	}
+	ic.ControlPlane.Architecture = installertypes.ArchitecturePPC64LE
+	for i := range ic.Compute {
+		ic.Compute[i].Architecture = installertypes.ArchitecturePPC64LE
+	}
+}
+
+func (p *PowerVSCloudBuilder) CredsSecretName(o *Builder) string {
+	return fmt.Sprintf("%s-powervs-creds", o.Name)
+}
+
+func (p *PowerVSCloudBuilder) GenerateCloudObjects(o *Builder) []runtime.Object {
+	return []runtime.Object{}
+}
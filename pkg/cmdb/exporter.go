@@ -0,0 +1,130 @@
+// Package cmdb exports cluster lifecycle records to external configuration management databases.
+package cmdb
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+const requestTimeout = 30 * time.Second
+
+// Record describes a cluster at a lifecycle event. It is identified by the UID of the ClusterDeployment, as names can
+// be reused once a ClusterDeployment is deleted.
+type Record struct {
+	Event       hivev1.CMDBLifecycleEvent `json:"event"`
+	Timestamp   time.Time                 `json:"timestamp"`
+	UID         string                    `json:"uid"`
+	Namespace   string                    `json:"namespace"`
+	Name        string                    `json:"name"`
+	DisplayName string                    `json:"displayName,omitempty"`
+	InfraID     string                    `json:"infraID,omitempty"`
+	ClusterID   string                    `json:"clusterID,omitempty"`
+	Platform    string                    `json:"platform,omitempty"`
+	Region      string                    `json:"region,omitempty"`
+	Version     string                    `json:"version,omitempty"`
+	APIURL      string                    `json:"apiURL,omitempty"`
+	Labels      map[string]string         `json:"labels,omitempty"`
+}
+
+// NewRecord builds the record of the cluster of the ClusterDeployment at the event.
+func NewRecord(cd *hivev1.ClusterDeployment, event hivev1.CMDBLifecycleEvent, now time.Time) *Record {
+	record := &Record{
+		Event:       event,
+		Timestamp:   now.UTC(),
+		UID:         string(cd.UID),
+		Namespace:   cd.Namespace,
+		Name:        cd.Name,
+		DisplayName: cd.Spec.DisplayName,
+		Platform:    cd.Labels[hivev1.HiveClusterPlatformLabel],
+		Region:      cd.Labels[hivev1.HiveClusterRegionLabel],
+		Version:     ClusterVersion(cd),
+		APIURL:      cd.Status.APIURL,
+		Labels:      cd.Labels,
+	}
+	if cd.Spec.ClusterMetadata != nil {
+		record.InfraID = cd.Spec.ClusterMetadata.InfraID
+		record.ClusterID = cd.Spec.ClusterMetadata.ClusterID
+	}
+	return record
+}
+
+// ClusterVersion returns the version of the cluster of the ClusterDeployment, or an empty string if it is not known yet.
+func ClusterVersion(cd *hivev1.ClusterDeployment) string {
+	if version := cd.Labels[constants.VersionMajorMinorPatchLabel]; version != "" {
+		return version
+	}
+	if cd.Status.InstallVersion != nil {
+		return *cd.Status.InstallVersion
+	}
+	return ""
+}
+
+// Exporter exports cluster lifecycle records to a configuration management database.
+type Exporter interface {
+	// Export creates or updates the cluster of the record in the configuration management database.
+	Export(record *Record) error
+}
+
+// NewExporter returns the exporter of the connector, authenticating with the credentials secret, if any.
+func NewExporter(connector *hivev1.CMDBConnector, credentials *corev1.Secret, client *http.Client) (Exporter, error) {
+	if client == nil {
+		client = &http.Client{Timeout: requestTimeout}
+	}
+	auth := &authenticator{}
+	if credentials != nil {
+		auth.token = string(credentials.Data[constants.CMDBTokenSecretKey])
+		auth.username = string(credentials.Data[constants.UsernameSecretKey])
+		auth.password = string(credentials.Data[constants.PasswordSecretKey])
+		if auth.token == "" && auth.username == "" {
+			return nil, errors.Errorf("credentials secret %s has neither a %q key nor a %q key",
+				credentials.Name, constants.CMDBTokenSecretKey, constants.UsernameSecretKey)
+		}
+	}
+	switch connector.Type {
+	case hivev1.CMDBConnectorTypeREST:
+		return &restExporter{url: connector.URL, client: client, auth: auth}, nil
+	case hivev1.CMDBConnectorTypeServiceNow:
+		table := connector.Table
+		if table == "" {
+			table = defaultServiceNowTable
+		}
+		return &serviceNowExporter{baseURL: connector.URL, table: table, client: client, auth: auth}, nil
+	}
+	return nil, errors.Errorf("unknown CMDB connector type %q", connector.Type)
+}
+
+// authenticator sets the credentials of a connector on its requests.
+type authenticator struct {
+	token    string
+	username string
+	password string
+}
+
+func (a *authenticator) do(client *http.Client, req *http.Request) (*http.Response, error) {
+	switch {
+	case a.token != "":
+		req.Header.Set("Authorization", "Bearer "+a.token)
+	case a.username != "":
+		req.SetBasicAuth(a.username, a.password)
+	}
+	req.Header.Set("Accept", "application/json")
+	return client.Do(req)
+}
+
+// checkResponse returns an error for responses without a 2xx status.
+func checkResponse(resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	body, _ := ioutil.ReadAll(resp.Body)
+	return fmt.Errorf("%s %s failed: %s: %s", resp.Request.Method, resp.Request.URL.Path, resp.Status, string(body))
+}
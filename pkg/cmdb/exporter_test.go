@@ -0,0 +1,170 @@
+package cmdb
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+func testRecord(event hivev1.CMDBLifecycleEvent) *Record {
+	cd := &hivev1.ClusterDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "team-a",
+			Name:      "payments",
+			UID:       "0123-4567",
+			Labels: map[string]string{
+				hivev1.HiveClusterPlatformLabel:       "aws",
+				hivev1.HiveClusterRegionLabel:         "us-east-1",
+				constants.VersionMajorMinorPatchLabel: "4.9.1",
+			},
+		},
+		Spec: hivev1.ClusterDeploymentSpec{
+			DisplayName:     "Payments",
+			ClusterMetadata: &hivev1.ClusterMetadata{InfraID: "payments-abcde", ClusterID: "cluster-id"},
+		},
+	}
+	return NewRecord(cd, event, time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC))
+}
+
+func TestRESTExporter(t *testing.T) {
+	cases := []struct {
+		name        string
+		credentials map[string]string
+		status      int
+		expectAuth  string
+		expectErr   bool
+	}{
+		{
+			name:        "bearer token",
+			credentials: map[string]string{constants.CMDBTokenSecretKey: "secret-token"},
+			status:      http.StatusOK,
+			expectAuth:  "Bearer secret-token",
+		},
+		{
+			name:        "basic auth",
+			credentials: map[string]string{constants.UsernameSecretKey: "hive", constants.PasswordSecretKey: "pw"},
+			status:      http.StatusCreated,
+			expectAuth:  "Basic aGl2ZTpwdw==",
+		},
+		{
+			name:      "rejected",
+			status:    http.StatusServiceUnavailable,
+			expectErr: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var received Record
+			var auth string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				auth = r.Header.Get("Authorization")
+				assert.Equal(t, http.MethodPost, r.Method, "unexpected method")
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&received), "could not decode record")
+				w.WriteHeader(tc.status)
+			}))
+			defer server.Close()
+
+			var credentials *corev1.Secret
+			if tc.credentials != nil {
+				credentials = &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "creds"}, Data: map[string][]byte{}}
+				for k, v := range tc.credentials {
+					credentials.Data[k] = []byte(v)
+				}
+			}
+			exporter, err := NewExporter(&hivev1.CMDBConnector{Name: "rest", Type: hivev1.CMDBConnectorTypeREST, URL: server.URL}, credentials, nil)
+			require.NoError(t, err, "unexpected error creating exporter")
+
+			err = exporter.Export(testRecord(hivev1.CMDBLifecycleEventInstalled))
+			if tc.expectErr {
+				assert.Error(t, err, "expected export to fail")
+				return
+			}
+			require.NoError(t, err, "unexpected error exporting record")
+			assert.Equal(t, tc.expectAuth, auth, "unexpected authorization")
+			assert.Equal(t, *testRecord(hivev1.CMDBLifecycleEventInstalled), received, "unexpected record")
+		})
+	}
+}
+
+func TestServiceNowExporter(t *testing.T) {
+	cases := []struct {
+		name          string
+		existingSysID string
+		event         hivev1.CMDBLifecycleEvent
+		expectMethod  string
+		expectPath    string
+		expectStatus  string
+	}{
+		{
+			name:         "create configuration item",
+			event:        hivev1.CMDBLifecycleEventCreated,
+			expectMethod: http.MethodPost,
+			expectPath:   "/api/now/table/cmdb_ci_kubernetes_cluster",
+			expectStatus: serviceNowNonOperational,
+		},
+		{
+			name:          "update configuration item",
+			existingSysID: "abc123",
+			event:         hivev1.CMDBLifecycleEventInstalled,
+			expectMethod:  http.MethodPatch,
+			expectPath:    "/api/now/table/cmdb_ci_kubernetes_cluster/abc123",
+			expectStatus:  serviceNowOperational,
+		},
+		{
+			name:          "retire configuration item",
+			existingSysID: "abc123",
+			event:         hivev1.CMDBLifecycleEventDeleted,
+			expectMethod:  http.MethodPatch,
+			expectPath:    "/api/now/table/cmdb_ci_kubernetes_cluster/abc123",
+			expectStatus:  serviceNowRetired,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var method, path string
+			item := map[string]string{}
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodGet {
+					assert.Equal(t, "correlation_id=0123-4567", r.URL.Query().Get("sysparm_query"), "unexpected query")
+					result := []map[string]string{}
+					if tc.existingSysID != "" {
+						result = append(result, map[string]string{"sys_id": tc.existingSysID})
+					}
+					json.NewEncoder(w).Encode(map[string]interface{}{"result": result})
+					return
+				}
+				method, path = r.Method, r.URL.Path
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&item), "could not decode configuration item")
+			}))
+			defer server.Close()
+
+			exporter, err := NewExporter(&hivev1.CMDBConnector{Name: "snow", Type: hivev1.CMDBConnectorTypeServiceNow, URL: server.URL + "/"}, nil, nil)
+			require.NoError(t, err, "unexpected error creating exporter")
+
+			require.NoError(t, exporter.Export(testRecord(tc.event)), "unexpected error exporting record")
+			assert.Equal(t, tc.expectMethod, method, "unexpected method")
+			assert.Equal(t, tc.expectPath, path, "unexpected path")
+			assert.Equal(t, "Payments", item["name"], "unexpected name")
+			assert.Equal(t, "0123-4567", item["correlation_id"], "unexpected correlation ID")
+			assert.Equal(t, tc.expectStatus, item["operational_status"], "unexpected operational status")
+			assert.Equal(t, "4.9.1", item["version"], "unexpected version")
+		})
+	}
+}
+
+func TestNewExporterInvalidCredentials(t *testing.T) {
+	credentials := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "creds"}, Data: map[string][]byte{"other": []byte("x")}}
+	_, err := NewExporter(&hivev1.CMDBConnector{Name: "rest", Type: hivev1.CMDBConnectorTypeREST, URL: "https://cmdb.example.com"}, credentials, nil)
+	assert.Error(t, err, "expected credentials without a token or username to be rejected")
+}
@@ -0,0 +1,35 @@
+package cmdb
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+
+	"github.com/pkg/errors"
+)
+
+// restExporter posts the records as JSON to a REST endpoint, which is expected to create or update the cluster
+// identified by the UID of the record.
+type restExporter struct {
+	url    string
+	client *http.Client
+	auth   *authenticator
+}
+
+func (e *restExporter) Export(record *Record) error {
+	body, err := json.Marshal(record)
+	if err != nil {
+		return errors.Wrap(err, "could not marshal record")
+	}
+	req, err := http.NewRequest(http.MethodPost, e.url, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "error creating request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := e.auth.do(e.client, req)
+	if err != nil {
+		return errors.Wrap(err, "error posting record")
+	}
+	defer resp.Body.Close()
+	return checkResponse(resp)
+}
@@ -0,0 +1,121 @@
+package cmdb
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+const defaultServiceNowTable = "cmdb_ci_kubernetes_cluster"
+
+// Operational statuses of ServiceNow configuration items.
+const (
+	serviceNowOperational    = "1"
+	serviceNowNonOperational = "2"
+	serviceNowRetired        = "6"
+)
+
+// serviceNowExporter creates or updates a configuration item per cluster in a table of a ServiceNow instance. The
+// configuration items are correlated with clusters by the UID of their ClusterDeployment.
+type serviceNowExporter struct {
+	baseURL string
+	table   string
+	client  *http.Client
+	auth    *authenticator
+}
+
+func (e *serviceNowExporter) Export(record *Record) error {
+	sysID, err := e.findConfigurationItem(record.UID)
+	if err != nil {
+		return err
+	}
+	item := serviceNowConfigurationItem(record)
+	if sysID == "" {
+		return e.send(http.MethodPost, e.tableURL(), item)
+	}
+	return e.send(http.MethodPatch, e.tableURL()+"/"+url.PathEscape(sysID), item)
+}
+
+func (e *serviceNowExporter) tableURL() string {
+	return fmt.Sprintf("%s/api/now/table/%s", strings.TrimSuffix(e.baseURL, "/"), url.PathEscape(e.table))
+}
+
+// findConfigurationItem returns the sys_id of the configuration item correlated with the UID, or an empty string if
+// there is none.
+func (e *serviceNowExporter) findConfigurationItem(uid string) (string, error) {
+	query := url.Values{}
+	query.Set("sysparm_query", "correlation_id="+uid)
+	query.Set("sysparm_fields", "sys_id")
+	query.Set("sysparm_limit", "1")
+	req, err := http.NewRequest(http.MethodGet, e.tableURL()+"?"+query.Encode(), nil)
+	if err != nil {
+		return "", errors.Wrap(err, "error creating request")
+	}
+	resp, err := e.auth.do(e.client, req)
+	if err != nil {
+		return "", errors.Wrap(err, "error querying configuration item")
+	}
+	defer resp.Body.Close()
+	if err := checkResponse(resp); err != nil {
+		return "", err
+	}
+	result := struct {
+		Result []struct {
+			SysID string `json:"sys_id"`
+		} `json:"result"`
+	}{}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", errors.Wrap(err, "could not decode configuration item query")
+	}
+	if len(result.Result) == 0 {
+		return "", nil
+	}
+	return result.Result[0].SysID, nil
+}
+
+func (e *serviceNowExporter) send(method, target string, item map[string]string) error {
+	body, err := json.Marshal(item)
+	if err != nil {
+		return errors.Wrap(err, "could not marshal configuration item")
+	}
+	req, err := http.NewRequest(method, target, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "error creating request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := e.auth.do(e.client, req)
+	if err != nil {
+		return errors.Wrap(err, "error exporting configuration item")
+	}
+	defer resp.Body.Close()
+	return checkResponse(resp)
+}
+
+// serviceNowConfigurationItem returns the fields of the configuration item of the cluster of the record.
+func serviceNowConfigurationItem(record *Record) map[string]string {
+	name := record.DisplayName
+	if name == "" {
+		name = record.Namespace + "/" + record.Name
+	}
+	status := serviceNowOperational
+	switch record.Event {
+	case hivev1.CMDBLifecycleEventCreated:
+		status = serviceNowNonOperational
+	case hivev1.CMDBLifecycleEventDeleted:
+		status = serviceNowRetired
+	}
+	return map[string]string{
+		"name":               name,
+		"correlation_id":     record.UID,
+		"operational_status": status,
+		"version":            record.Version,
+		"short_description":  fmt.Sprintf("OpenShift cluster of ClusterDeployment %s/%s", record.Namespace, record.Name),
+	}
+}
@@ -1,6 +1,8 @@
 package constants
 
 import (
+	"fmt"
+
 	apihelpers "github.com/openshift/hive/apis/helpers"
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 )
@@ -11,12 +13,23 @@ const (
 	PlatformBaremetal      = "baremetal"
 	PlatformAgentBaremetal = "agent-baremetal"
 	PlatformGCP            = "gcp"
+	PlatformNone           = "none"
+	PlatformOCI            = "oci"
 	PlatformOpenStack      = "openstack"
+	PlatformPowerVS        = "powervs"
 	PlatformUnknown        = "unknown"
 	PlatformVSphere        = "vsphere"
 
 	mergedPullSecretSuffix = "merged-pull-secret"
 
+	additionalTrustBundleSuffix = "additional-trust-bundle"
+
+	syncAgentSuffix = "sync-agent"
+
+	syncAgentTokenSuffix = "sync-agent-token"
+
+	controllerKubeconfigSuffix = "controller-kubeconfig"
+
 	// VeleroBackupEnvVar is the name of the environment variable used to tell the controller manager to enable velero backup integration.
 	VeleroBackupEnvVar = "HIVE_VELERO_BACKUP"
 
@@ -30,9 +43,114 @@ const (
 	// MinBackupPeriodSecondsEnvVar is the name of the environment variable used to tell the controller manager the minimum period of time between backups.
 	MinBackupPeriodSecondsEnvVar = "HIVE_MIN_BACKUP_PERIOD_SECONDS"
 
+	// ZoneCheckDNSServersEnvVar is the name of the environment variable used to tell the controller manager which DNS
+	// resolvers to query when checking that managed DNS zones are delegated. The value is a comma-separated list.
+	ZoneCheckDNSServersEnvVar = "ZONE_CHECK_DNS_SERVERS"
+
+	// ZoneCheckQueryTimeoutEnvVar is the name of the environment variable used to tell the controller manager the
+	// timeout of the DNS queries checking that managed DNS zones are delegated.
+	ZoneCheckQueryTimeoutEnvVar = "ZONE_CHECK_QUERY_TIMEOUT"
+
+	// ZoneCheckMaxIntervalEnvVar is the name of the environment variable used to tell the controller manager the
+	// maximum interval between checks of a managed DNS zone which is not yet delegated.
+	ZoneCheckMaxIntervalEnvVar = "ZONE_CHECK_MAX_INTERVAL"
+
+	// ProvisionSLAEnvVar is the name of the environment variable used to tell the controller manager how long a
+	// cluster provision may take before breaching the SLA.
+	ProvisionSLAEnvVar = "PROVISION_SLA"
+
+	// ProvisionSLAActionEnvVar is the name of the environment variable used to tell the controller manager what to
+	// do with a cluster provision breaching the SLA.
+	ProvisionSLAActionEnvVar = "PROVISION_SLA_ACTION"
+
+	// DefaultInstallTimeoutEnvVar is the name of the environment variable used to tell the controller manager how
+	// long the install jobs of clusters on platforms without a platform install timeout may run.
+	DefaultInstallTimeoutEnvVar = "DEFAULT_INSTALL_TIMEOUT"
+
+	// PlatformInstallTimeoutsEnvVar is the name of the environment variable used to tell the controller manager how
+	// long the install jobs of clusters on specific platforms may run, as a comma-separated list of platform=duration.
+	PlatformInstallTimeoutsEnvVar = "PLATFORM_INSTALL_TIMEOUTS"
+
+	// InitializingStageTimeoutEnvVar is the name of the environment variable used to tell the controller manager how
+	// long a cluster provision may stay in the initializing stage.
+	InitializingStageTimeoutEnvVar = "INITIALIZING_STAGE_TIMEOUT"
+
+	// ProvisioningStageTimeoutEnvVar is the name of the environment variable used to tell the controller manager how
+	// long a cluster provision may stay in the provisioning stage.
+	ProvisioningStageTimeoutEnvVar = "PROVISIONING_STAGE_TIMEOUT"
+
+	// InstallPodStuckRecreateJobEnvVar is the name of the environment variable used to tell the controller manager
+	// to recreate the install job once when its pod is stuck.
+	InstallPodStuckRecreateJobEnvVar = "INSTALL_POD_STUCK_RECREATE_JOB"
+
+	// InstallPodStuckFailAfterEnvVar is the name of the environment variable used to tell the controller manager how
+	// long an install pod may be stuck before the provision attempt is failed.
+	InstallPodStuckFailAfterEnvVar = "INSTALL_POD_STUCK_FAIL_AFTER"
+
+	// AbortProvisionAnnotation is an annotation used on ClusterProvisions to abort a provision which is initializing
+	// or provisioning. Its install job is deleted, the cloud resources it created are deprovisioned, and the provision is
+	// failed with the Aborted reason. Set to "true".
+	AbortProvisionAnnotation = "hive.openshift.io/abort-provision"
+
+	// RecreatedInstallJobUIDAnnotation is the annotation set on a ClusterProvision with the UID of the install job
+	// deleted to recreate it when its pod was stuck.
+	RecreatedInstallJobUIDAnnotation = "hive.openshift.io/recreated-install-job-uid"
+
+	// InstallJobSpreadModeEnvVar is the name of the environment variable used to tell the controller manager how
+	// strictly to spread the pods of concurrent install jobs.
+	InstallJobSpreadModeEnvVar = "INSTALL_JOB_SPREAD_MODE"
+
+	// InstallJobSpreadTopologyKeyEnvVar is the name of the environment variable used to tell the controller manager
+	// the node label whose values are the topology domains install pods are spread across.
+	InstallJobSpreadTopologyKeyEnvVar = "INSTALL_JOB_SPREAD_TOPOLOGY_KEY"
+
+	// ClusterPoolMaxConcurrentEnvVar is the name of the environment variable used to tell the controller manager the
+	// maximum number of clusters of each ClusterPool provisioned or deprovisioned at a time, for the pools that do not
+	// set their own.
+	ClusterPoolMaxConcurrentEnvVar = "CLUSTERPOOL_MAX_CONCURRENT"
+
+	// InstallEgressPolicyTypeEnvVar is the name of the environment variable used to tell the controller manager the
+	// kind of policy restricting the network egress of install and deprovision pods.
+	InstallEgressPolicyTypeEnvVar = "INSTALL_EGRESS_POLICY_TYPE"
+
+	// InstallEgressAdditionalEndpointsEnvVar is the name of the environment variable used to tell the controller
+	// manager the comma-separated DNS names and CIDRs install and deprovision pods may reach in addition to those of
+	// their platform.
+	InstallEgressAdditionalEndpointsEnvVar = "INSTALL_EGRESS_ADDITIONAL_ENDPOINTS"
+
+	// AWSRequestsPerSecondEnvVar is the name of the environment variable used to tell the controller manager the
+	// maximum rate of the AWS API calls made with the same credentials.
+	AWSRequestsPerSecondEnvVar = "AWS_REQUESTS_PER_SECOND"
+
+	// AWSBurstEnvVar is the name of the environment variable used to tell the controller manager the maximum number
+	// of AWS API calls which can be made at once with the same credentials.
+	AWSBurstEnvVar = "AWS_BURST"
+
+	// AWSMaxRetriesEnvVar is the name of the environment variable used to tell the controller manager the maximum
+	// number of times a failed AWS API call is retried.
+	AWSMaxRetriesEnvVar = "AWS_MAX_RETRIES"
+
+	// ProviderPluginsEnvVar is the name of the environment variable used to tell the controller manager the provider
+	// plugins configured in HiveConfig, as a JSON list.
+	ProviderPluginsEnvVar = "PROVIDER_PLUGINS"
+
+	// PodLogSnapshotMaxSizeKBEnvVar is the name of the environment variable used to tell the controller manager to
+	// snapshot the end of the logs of install and deprovision pods, and the size in KB of the snapshots.
+	PodLogSnapshotMaxSizeKBEnvVar = "POD_LOG_SNAPSHOT_MAX_SIZE_KB"
+
+	// PodLogSnapshotLabel is the label set on the ConfigMaps holding a snapshot of the logs of a pod.
+	PodLogSnapshotLabel = "hive.openshift.io/pod-log-snapshot"
+
+	// PodLogSnapshotPodAnnotation is the annotation set on a ConfigMap holding a snapshot of the logs of a pod with the
+	// name of the pod.
+	PodLogSnapshotPodAnnotation = "hive.openshift.io/pod-log-snapshot-pod"
+
 	// InstallJobLabel is the label used for artifacts specific to Hive cluster installations.
 	InstallJobLabel = "hive.openshift.io/install"
 
+	// MustGatherJobLabel is the label used for the jobs collecting a must-gather from the cluster of a failed provision.
+	MustGatherJobLabel = "hive.openshift.io/must-gather"
+
 	// UninstallJobLabel is the label used for artifacts specific to Hive cluster deprovision.
 	UninstallJobLabel = "hive.openshift.io/uninstall"
 
@@ -53,6 +171,11 @@ const (
 	// has been deleted.
 	ClusterPoolNameLabel = "hive.openshift.io/cluster-pool-name"
 
+	// DedicatedNamespaceLabel is the label that is used to signal that a namespace was created by Hive to house a
+	// single ClusterDeployment. The label is used to reap namespaces once they are empty when namespace cleanup is
+	// enabled.
+	DedicatedNamespaceLabel = "hive.openshift.io/dedicated-namespace"
+
 	// SyncSetNameLabel is the label that is used to identify a relationship to a given syncset object.
 	SyncSetNameLabel = "hive.openshift.io/syncset-name"
 
@@ -89,12 +212,36 @@ const (
 	// SecretTypeMergedPullSecret is used as a value of SecretTypeLabel that says the secret is specifically used for storing a pull secret.
 	SecretTypeMergedPullSecret = "merged-pull-secret"
 
+	// SecretTypeAdditionalTrustBundle is used as a value of SecretTypeLabel that says the secret is specifically used for storing the additional trust bundle of a cluster.
+	SecretTypeAdditionalTrustBundle = "additional-trust-bundle"
+
 	// SecretTypeKubeConfig is used as a value of SecretTypeLabel that says the secret is specifically used for storing a kubeconfig.
 	SecretTypeKubeConfig = "kubeconfig"
 
 	// SecretTypeKubeAdminCreds is used as a value of SecretTypeLabel that says the secret is specifically used for storing kubeadmin credentials.
 	SecretTypeKubeAdminCreds = "kubeadmincreds"
 
+	// SecretTypeSyncAgentState is used as a value of SecretTypeLabel that says the secret is specifically used for storing the state pulled by the sync agent of a cluster.
+	SecretTypeSyncAgentState = "sync-agent-state"
+
+	// SecretTypeControllerKubeconfig is used as a value of SecretTypeLabel that says the secret is specifically used for storing the short-lived kubeconfig used by the controllers to access a cluster.
+	SecretTypeControllerKubeconfig = "controller-kubeconfig"
+
+	// SyncAgentStateSecretKey is the key in the sync agent state secrets which holds their shard of the state.
+	SyncAgentStateSecretKey = "state"
+
+	// SyncAgentStateShardsSecretKey is the key in the first sync agent state secret which holds the number of shards
+	// the state is split into.
+	SyncAgentStateShardsSecretKey = "shards"
+
+	// SyncAgentStateHashSecretKey is the key in the first sync agent state secret which holds the SHA-256 hash of the
+	// whole state, which tells the agent whether it read every shard of the same state.
+	SyncAgentStateHashSecretKey = "hash"
+
+	// SyncAgentDeployedAnnotation is the annotation on the sync agent service account which holds the checksum of the
+	// resources of the agent last deployed to the cluster.
+	SyncAgentDeployedAnnotation = "hive.openshift.io/sync-agent-deployed"
+
 	// SyncSetTypeLabel is the label that is used to identify what a SyncSet is being used for.
 	SyncSetTypeLabel = "hive.openshift.io/syncset-type"
 
@@ -107,9 +254,34 @@ const (
 	// SyncSetTypeIdentityProvider is used as a value of SyncSetTypeLabel that says the syncset is specifically used to distribute identity provider information.
 	SyncSetTypeIdentityProvider = "identityprovider"
 
+	// SyncSetTypeNodeTuning is used as a value of SyncSetTypeLabel that says the syncset is specifically used to distribute node tuning defaults.
+	SyncSetTypeNodeTuning = "nodetuning"
+
+	// SyncSetTypeAdditionalTrustBundle is used as a value of SyncSetTypeLabel that says the syncset is specifically used to distribute the additional trust bundle.
+	SyncSetTypeAdditionalTrustBundle = "additionaltrustbundle"
+
+	// SyncSetTypeRemoteAccess is used as a value of SyncSetTypeLabel that says the syncset is specifically used to distribute the service account used by the controllers to access a cluster.
+	SyncSetTypeRemoteAccess = "remoteaccess"
+
 	// GlobalPullSecret is the environment variable for controllers to get the global pull secret
 	GlobalPullSecret = "GLOBAL_PULL_SECRET"
 
+	// PullSecretConflictPolicyEnvVar is the environment variable for controllers to get how to resolve a registry with
+	// different auths in the pull secrets they merge.
+	PullSecretConflictPolicyEnvVar = "PULL_SECRET_CONFLICT_POLICY"
+
+	// NamespaceDefaultPullSecretName is the name of the secret holding the default pull secret of the cluster
+	// deployments in its namespace. It is merged between the global pull secret and the pull secret of each cluster
+	// deployment.
+	NamespaceDefaultPullSecretName = "hive-default-pull-secret"
+
+	// AdditionalTrustBundleSecretEnvVar is the environment variable for controllers to get the name of the secret
+	// in the hive namespace which contains the additional certificate authorities trusted by installed clusters.
+	AdditionalTrustBundleSecretEnvVar = "ADDITIONAL_TRUST_BUNDLE_SECRET"
+
+	// AdditionalTrustBundleSecretKey is the key of the secrets which contain additional trust bundles.
+	AdditionalTrustBundleSecretKey = "ca.crt"
+
 	// DefaultHiveNamespace is the default namespace where core hive components will run. It is used if the environment variable is not defined.
 	DefaultHiveNamespace = "hive"
 
@@ -118,6 +290,9 @@ const (
 	// The default is defined above.
 	HiveNamespaceEnvVar = "HIVE_NS"
 
+	// HiveConfigName is the one and only name for a HiveConfig supported in the cluster.
+	HiveConfigName = "hive"
+
 	// CheckpointName is the name of the object in each namespace in which the namespace's backup information is stored.
 	CheckpointName = "hive"
 
@@ -128,6 +303,26 @@ const (
 	// managed by Hive, and any manual changes may be undone the next time the resource is reconciled.
 	HiveManagedLabel = "hive.openshift.io/managed"
 
+	// SyncSetSourceAnnotation is set on the resources applied from syncsets, when SyncSet auditing is enabled, to the
+	// syncset they were applied from, as SyncSet/namespace/name or SelectorSyncSet/name.
+	SyncSetSourceAnnotation = "hive.openshift.io/syncset-source"
+
+	// SyncSetGenerationAnnotation is set on the resources applied from syncsets, when SyncSet auditing is enabled, to
+	// the generation of the syncset which was applied.
+	SyncSetGenerationAnnotation = "hive.openshift.io/syncset-generation"
+
+	// SyncSetHubAnnotation is set on the resources applied from syncsets, when SyncSet auditing is enabled, to the
+	// name of the hub cluster which applied them.
+	SyncSetHubAnnotation = "hive.openshift.io/syncset-hub"
+
+	// SyncSetAppliedAtAnnotation is set on the resources applied from syncsets, when SyncSet auditing is enabled, to
+	// the time at which they were last applied, in RFC 3339 format.
+	SyncSetAppliedAtAnnotation = "hive.openshift.io/syncset-applied-at"
+
+	// SyncSetHubLabel is set on the resources applied from syncsets, when the SyncSet audit ownership label is
+	// enabled, to the name of the hub cluster which applied them.
+	SyncSetHubLabel = "hive.openshift.io/managed-by-hub"
+
 	// DisableInstallLogPasswordRedactionAnnotation is an annotation used on ClusterDeployments to disable the installmanager
 	// functionality which refuses to print output if it appears to contain a password or sensitive info. This can be
 	// useful in scenarios where debugging is needed and important info is being redacted. Set to "true".
@@ -142,14 +337,120 @@ const (
 	// for the cluster provision to complete by running `openshift-install wait-for install-complete` command.
 	WaitForInstallCompleteExecutionsAnnotation = "hive.openshift.io/wait-for-install-complete-executions"
 
+	// CloudAPIAuditAnnotation is an annotation used on ClusterDeployments to record the cloud API calls made by the
+	// installer in a ConfigMap referenced by the ClusterProvision. Set to "true".
+	CloudAPIAuditAnnotation = "hive.openshift.io/cloud-api-audit"
+
 	// ProtectedDeleteAnnotation is an annotation used on ClusterDeployments to indicate that the ClusterDeployment
 	// cannot be deleted. The annotation must be removed in order to delete the ClusterDeployment.
 	ProtectedDeleteAnnotation = "hive.openshift.io/protected-delete"
 
+	// ExternalInfraReadyAnnotation is an annotation set on ClusterProvisions by external infrastructure hooks for
+	// clusters on the "none" platform to report that the bootstrap and control plane machines have been created.
+	// Set to "true".
+	ExternalInfraReadyAnnotation = "hive.openshift.io/external-infra-ready"
+
+	// ExternalInfraFailedAnnotation is an annotation set on ClusterProvisions by external infrastructure hooks for
+	// clusters on the "none" platform to report that creating the infrastructure failed. The value is a message
+	// describing the failure, and the provision is failed.
+	ExternalInfraFailedAnnotation = "hive.openshift.io/external-infra-failed"
+
+	// PreservedDNSZoneAnnotation is an annotation set on managed DNSZones which were kept when their ClusterDeployment
+	// was deleted because of spec.preserveDNSZoneOnDelete. A new ClusterDeployment with the same name and base domain
+	// adopts the DNSZone and removes the annotation.
+	PreservedDNSZoneAnnotation = "hive.openshift.io/preserved-dnszone"
+
+	// DNSZoneIDAnnotation is an annotation set on DNSZones with the ID identifying their zone in the cloud, such as
+	// with a tag of the AWS hosted zone. It is the UID of the DNSZone when it is first reconciled. Unlike the UID, it
+	// is kept when the DNSZone is relocated to another Hive instance, and unlike the namespace and name of the DNSZone,
+	// it is not reused by a later DNSZone.
+	DNSZoneIDAnnotation = "hive.openshift.io/dnszone-id"
+
 	// ProtectedDeleteEnvVar is the name of the environment variable used to tell the controller manager whether
 	// protected delete is enabled.
 	ProtectedDeleteEnvVar = "PROTECTED_DELETE"
 
+	// NamespaceCleanupEnvVar is the name of the environment variable used to tell the controller manager whether
+	// to delete the empty namespaces it created to house a single ClusterDeployment.
+	NamespaceCleanupEnvVar = "NAMESPACE_CLEANUP"
+
+	// ScopedRemoteAccessEnvVar is the name of the environment variable used to tell the controller manager whether
+	// to access installed clusters with short-lived tokens of a least-privilege service account.
+	ScopedRemoteAccessEnvVar = "SCOPED_REMOTE_ACCESS"
+
+	// RemoteAccessRBACModeEnvVar is the name of the environment variable used to tell the controller manager the mode
+	// of the permissions which Hive holds in installed clusters when scoped remote access is enabled.
+	RemoteAccessRBACModeEnvVar = "REMOTE_ACCESS_RBAC_MODE"
+
+	// RemoteAccessSyncSetAPIGroupsEnvVar is the name of the environment variable used to tell the controller manager
+	// the API groups of the resources which SyncSets can manage in the Restricted remote access RBAC mode, as a JSON
+	// list.
+	RemoteAccessSyncSetAPIGroupsEnvVar = "REMOTE_ACCESS_SYNCSET_API_GROUPS"
+
+	// MetricsBindAddressEnvVar is the name of the environment variable used to tell the controller manager the
+	// address on which to serve its metrics. Defaults to :2112.
+	MetricsBindAddressEnvVar = "METRICS_BIND_ADDRESS"
+
+	// MetricsNamespaceFilteringEnvVar is the name of the environment variable used to tell the controller manager
+	// whether to also serve the metrics of each namespace under their own path.
+	MetricsNamespaceFilteringEnvVar = "METRICS_NAMESPACE_FILTERING"
+
+	// AdminKubeconfigContextEnvVar is the name of the environment variable used to tell the controller manager the
+	// context of the admin kubeconfig with which to connect to installed clusters.
+	AdminKubeconfigContextEnvVar = "ADMIN_KUBECONFIG_CONTEXT"
+
+	// ClusterClaimQuotaPerNamespaceEnvVar is the name of the environment variable used to tell the controller manager
+	// and hiveadmission the maximum number of ClusterClaims which may exist at once in each namespace.
+	ClusterClaimQuotaPerNamespaceEnvVar = "CLUSTER_CLAIM_QUOTA_PER_NAMESPACE"
+
+	// ClusterClaimQuotaPerRequesterEnvVar is the name of the environment variable used to tell the controller manager
+	// and hiveadmission the maximum number of ClusterClaims which each requester may have at once.
+	ClusterClaimQuotaPerRequesterEnvVar = "CLUSTER_CLAIM_QUOTA_PER_REQUESTER"
+
+	// SyncSetAuditEnvVar is the name of the environment variable used to tell the clustersync controller whether to
+	// stamp the resources applied from syncsets with annotations recording where they came from.
+	SyncSetAuditEnvVar = "SYNCSET_AUDIT"
+
+	// SyncSetAuditHubNameEnvVar is the name of the environment variable used to tell the clustersync controller the
+	// name identifying the hub cluster on the resources applied from syncsets.
+	SyncSetAuditHubNameEnvVar = "SYNCSET_AUDIT_HUB_NAME"
+
+	// SyncSetAuditOwnershipLabelEnvVar is the name of the environment variable used to tell the clustersync controller
+	// whether to label the resources applied from syncsets with the hub cluster which applies them.
+	SyncSetAuditOwnershipLabelEnvVar = "SYNCSET_AUDIT_OWNERSHIP_LABEL"
+
+	// ClusterStatePollIntervalEnvVar is the name of the environment variable used to tell the controller manager the
+	// base interval at which the states of the cluster operators of each cluster are polled.
+	ClusterStatePollIntervalEnvVar = "CLUSTERSTATE_POLL_INTERVAL"
+
+	// ClusterStateCollectNodeInfoEnvVar is the name of the environment variable used to tell the clusterstate
+	// controller whether to collect the operating system of the nodes of each cluster.
+	ClusterStateCollectNodeInfoEnvVar = "CLUSTERSTATE_COLLECT_NODE_INFO"
+
+	// MachineSetResyncIntervalEnvVar is the name of the environment variable used to tell the controller manager the
+	// base interval at which the MachineSets of each MachinePool are resynced to the cluster.
+	MachineSetResyncIntervalEnvVar = "MACHINESET_RESYNC_INTERVAL"
+
+	// PeriodicSyncJitterPercentEnvVar is the name of the environment variable used to tell the controllers the
+	// maximum jitter of the periodic syncs of each cluster, as a percentage of their base interval.
+	PeriodicSyncJitterPercentEnvVar = "PERIODIC_SYNC_JITTER_PERCENT"
+
+	// AdminKubeconfigInternalContextName is the name of the context which Hive adds to the admin kubeconfig of
+	// installed clusters for the internal API URL of the cluster.
+	AdminKubeconfigInternalContextName = "admin-internal"
+
+	// TokenExpirationAnnotation is an annotation set on secrets holding a short-lived token with the time at which
+	// the token expires, in RFC 3339 format.
+	TokenExpirationAnnotation = "hive.openshift.io/token-expiration"
+
+	// RemoteAccessNamespace is the namespace in installed clusters holding the service account used by the
+	// controllers to access the cluster.
+	RemoteAccessNamespace = "openshift-hive-remote-access"
+
+	// RemoteAccessServiceAccountName is the name of the service account used by the controllers to access installed
+	// clusters.
+	RemoteAccessServiceAccountName = "hive-controller"
+
 	// RelocateAnnotation is an annotation used on ClusterDeployments and DNSZones to indicate that the resource
 	// is involved in a relocation between Hive instances.
 	// The value of the annotation has the format "{ClusterRelocate}/{Status}", where
@@ -287,6 +588,68 @@ const (
 	// OvirtConfigEnvVar is the environment variable specifying the oVirt config path
 	OvirtConfigEnvVar = "OVIRT_CONFIG"
 
+	// OCITenancySecretKey is the key in an OCI credentials secret holding the tenancy OCID.
+	OCITenancySecretKey = "tenancy"
+
+	// OCIUserSecretKey is the key in an OCI credentials secret holding the user OCID.
+	OCIUserSecretKey = "user"
+
+	// OCIFingerprintSecretKey is the key in an OCI credentials secret holding the API signing key fingerprint.
+	OCIFingerprintSecretKey = "fingerprint"
+
+	// OCIPrivateKeySecretKey is the key in an OCI credentials secret holding the PEM encoded API signing key.
+	OCIPrivateKeySecretKey = "privateKey"
+
+	// OCIPassphraseSecretKey is the optional key in an OCI credentials secret holding the passphrase for
+	// the API signing key.
+	OCIPassphraseSecretKey = "passphrase"
+
+	// OCITenancyEnvVar is the environment variable specifying the OCI tenancy OCID.
+	OCITenancyEnvVar = "OCI_CLI_TENANCY"
+
+	// OCIUserEnvVar is the environment variable specifying the OCI user OCID.
+	OCIUserEnvVar = "OCI_CLI_USER"
+
+	// OCIFingerprintEnvVar is the environment variable specifying the OCI API signing key fingerprint.
+	OCIFingerprintEnvVar = "OCI_CLI_FINGERPRINT"
+
+	// OCIPrivateKeyEnvVar is the environment variable specifying the OCI API signing key contents.
+	OCIPrivateKeyEnvVar = "OCI_CLI_KEY_CONTENT"
+
+	// OCIPassphraseEnvVar is the environment variable specifying the passphrase for the OCI API signing key.
+	OCIPassphraseEnvVar = "OCI_CLI_PASSPHRASE"
+
+	// OCIRegionEnvVar is the environment variable specifying the OCI region.
+	OCIRegionEnvVar = "OCI_CLI_REGION"
+
+	// PowerVSAPIKeySecretKey is the key in a PowerVS credentials secret holding the IBM Cloud API key.
+	PowerVSAPIKeySecretKey = "ibmcloud_api_key"
+
+	// PowerVSAPIKeyEnvVar is the environment variable specifying the IBM Cloud API key.
+	PowerVSAPIKeyEnvVar = "IBMCLOUD_API_KEY"
+
+	// DestroyerInfraIDEnvVar is the environment variable holding the infra ID of the cluster torn down by an
+	// external destroyer.
+	DestroyerInfraIDEnvVar = "HIVE_INFRA_ID"
+
+	// DestroyerClusterIDEnvVar is the environment variable holding the cluster ID of the cluster torn down by an
+	// external destroyer.
+	DestroyerClusterIDEnvVar = "HIVE_CLUSTER_ID"
+
+	// DestroyerClusterDeploymentNameEnvVar is the environment variable holding the name of the ClusterDeployment
+	// torn down by an external destroyer.
+	DestroyerClusterDeploymentNameEnvVar = "HIVE_CLUSTER_DEPLOYMENT_NAME"
+
+	// DestroyerClusterDeploymentNamespaceEnvVar is the environment variable holding the namespace of the
+	// ClusterDeployment torn down by an external destroyer.
+	DestroyerClusterDeploymentNamespaceEnvVar = "HIVE_CLUSTER_DEPLOYMENT_NAMESPACE"
+
+	// DestroyerWebhookTokenSecretKey is the key in an external destroyer webhook token secret holding the bearer token.
+	DestroyerWebhookTokenSecretKey = "token"
+
+	// DestroyerWebhookTokenEnvVar is the environment variable specifying the bearer token for an external destroyer webhook.
+	DestroyerWebhookTokenEnvVar = "HIVE_DESTROYER_WEBHOOK_TOKEN"
+
 	// AWSCredsMount is the location where the AWS credentials secret is mounted for uninstall pods.
 	AWSCredsMount = "/etc/aws-creds"
 
@@ -308,6 +671,41 @@ const (
 	// InstallLogsAWSS3BucketEnvVar is the environment variable specifying the S3 bucket to use.
 	InstallLogsAWSS3BucketEnvVar = "HIVE_INSTALL_LOGS_AWS_S3_BUCKET"
 
+	// FailedProvisionMustGatherEnvVar is the environment variable which enables collecting a must-gather from the
+	// cluster of a failed provision which had completed bootstrapping.
+	FailedProvisionMustGatherEnvVar = "HIVE_FAILED_PROVISION_MUST_GATHER"
+
+	// InstallLogRegexesConfigMapsEnvVar is the environment variable specifying the comma-separated names of the
+	// ConfigMaps holding additional rule sets which classify the install logs of failed provisions.
+	InstallLogRegexesConfigMapsEnvVar = "HIVE_INSTALL_LOG_REGEXES_CONFIGMAPS"
+
+	// InstallLogStreamEnvVarPrefix is the prefix of the environment variables specifying the destination installer
+	// logs are streamed to while the install runs. Installer logs are not streamed when the destination is not set.
+	InstallLogStreamEnvVarPrefix = "HIVE_INSTALL_LOG_STREAM"
+
+	// InstallLogStreamDestinationHTTP is used to specify that installer logs are streamed to an HTTP endpoint rather
+	// than to object storage.
+	InstallLogStreamDestinationHTTP = "http"
+
+	// InstallLogStreamIntervalEnvVar is the environment variable specifying how often installer logs are streamed.
+	InstallLogStreamIntervalEnvVar = "HIVE_INSTALL_LOG_STREAM_INTERVAL"
+
+	// InstallLogStreamURLEnvVar is the environment variable specifying the HTTP endpoint installer logs are streamed
+	// to.
+	InstallLogStreamURLEnvVar = "HIVE_INSTALL_LOG_STREAM_URL"
+
+	// InstallArtifactsEnvVarPrefix is the prefix of the environment variables specifying the object storage the
+	// installer artifacts of provisions are uploaded to. Artifacts are not uploaded when the destination is not set.
+	InstallArtifactsEnvVarPrefix = "HIVE_INSTALL_ARTIFACTS"
+
+	// InstallArtifactsUploadEnvVar is the environment variable specifying which provisions have their installer
+	// artifacts uploaded, OnFailure or Always.
+	InstallArtifactsUploadEnvVar = "HIVE_INSTALL_ARTIFACTS_UPLOAD"
+
+	// InstallArtifactsPrefixEnvVar is the environment variable specifying the prefix of the names of the objects
+	// installer artifacts are uploaded to.
+	InstallArtifactsPrefixEnvVar = "HIVE_INSTALL_ARTIFACTS_PREFIX"
+
 	// HiveFakeClusterAnnotation can be set to true on a cluster deployment to create a fake cluster that never
 	// provisions resources, and all communication with the cluster will be faked.
 	HiveFakeClusterAnnotation = "hive.openshift.io/fake-cluster"
@@ -325,6 +723,42 @@ const (
 	// from the pool.
 	ClusterClaimRemoveClusterAnnotation = "hive.openshift.io/remove-claimed-cluster-from-pool"
 
+	// ClusterPoolSpecHashAnnotation is set by the clusterpool controller on the ClusterDeployments it creates to the
+	// hash of the fields of the spec of the pool which shape its clusters, to report whether the clusters of the pool
+	// match its current spec.
+	ClusterPoolSpecHashAnnotation = "hive.openshift.io/cluster-pool-spec-hash"
+
+	// ClusterRebaseliningAnnotation is used by the cluster claim controller to mark that a cluster released with the
+	// Hibernate policy of its pool is being rebaselined, and cannot be claimed again until the rebaseline SyncSets have
+	// been applied.
+	ClusterRebaseliningAnnotation = "hive.openshift.io/rebaselining"
+
+	// ClusterQuarantinedAnnotation is used by the cluster claim controller to mark that a cluster released with the
+	// Quarantine policy of its pool is kept for inspection.
+	ClusterQuarantinedAnnotation = "hive.openshift.io/quarantined"
+
+	// RebaselineSyncSetLabel is the label set on the SyncSets applied to rebaseline a released cluster.
+	RebaselineSyncSetLabel = "hive.openshift.io/rebaseline"
+
+	// ClusterSanitizingAnnotation is used by the cluster claim controller to mark that a cluster released with the
+	// Hibernate policy of its pool is being sanitized, and cannot be claimed again until the sanitization controller
+	// removes the annotation.
+	ClusterSanitizingAnnotation = "hive.openshift.io/sanitizing"
+
+	// ClusterCredentialsRotatingAnnotation is used by the cluster claim controller to mark that the admin credentials of
+	// a cluster released with the Hibernate policy of its pool are being rotated, and that the cluster cannot be claimed
+	// again until the kubeadmin controller removes the annotation.
+	ClusterCredentialsRotatingAnnotation = "hive.openshift.io/rotating-credentials"
+
+	// ClaimedTimestampAnnotation is set by the cluster claim controller on a ClusterDeployment to the time at which it
+	// was claimed. What was created in the cluster after that time is deleted when it is sanitized.
+	ClaimedTimestampAnnotation = "hive.openshift.io/claimed-timestamp"
+
+	// PowerStateScheduleAppliedAnnotation is set by the hibernation controller on a ClusterDeployment to the last time of
+	// its power state schedule which was applied, so that a power state set after that time is kept until the next
+	// scheduled time.
+	PowerStateScheduleAppliedAnnotation = "hive.openshift.io/power-state-schedule-applied"
+
 	// HiveFeatureGatesEnabledEnvVar is the the environment variable specifying the comma separated list of
 	// feature gates that are enabled.
 	HiveFeatureGatesEnabledEnvVar = "HIVE_FEATURE_GATES_ENABLED"
@@ -335,8 +769,90 @@ const (
 	// AWSPrivateLinkControllerConfigFileEnvVar if present, points to a simple text
 	// file that includes configuration for aws-private-link-controller
 	AWSPrivateLinkControllerConfigFileEnvVar = "AWS_PRIVATELINK_CONTROLLER_CONFIG_FILE"
+
+	// CMDBExportConfigFileEnvVar if present, points to a file holding the configuration of the CMDB connectors
+	// to which cluster lifecycle records are exported.
+	CMDBExportConfigFileEnvVar = "CMDB_EXPORT_CONFIG_FILE"
+
+	// CSRApprovalPolicyConfigFileEnvVar if present, points to a file holding the policy restricting the certificate
+	// signing requests which the hibernation controller approves while clusters resume.
+	CSRApprovalPolicyConfigFileEnvVar = "CSR_APPROVAL_POLICY_CONFIG_FILE"
+
+	// CMDBExportedAnnotation is set on ClusterDeployments to the lifecycle records exported by each CMDB connector.
+	CMDBExportedAnnotation = "hive.openshift.io/cmdb-exported"
+
+	// CMDBTokenSecretKey is the key in a CMDB connector credentials secret holding the bearer token.
+	CMDBTokenSecretKey = "token"
+
+	// SnapshotExportEnvVarPrefix is the prefix of the environment variables specifying the object storage Hive
+	// resource snapshots are exported to. Snapshots are not exported when the destination is not set.
+	SnapshotExportEnvVarPrefix = "HIVE_SNAPSHOT_EXPORT"
+
+	// SnapshotExportIntervalEnvVar is the environment variable specifying how often snapshots are exported.
+	SnapshotExportIntervalEnvVar = "HIVE_SNAPSHOT_EXPORT_INTERVAL"
+
+	// SnapshotExportPrefixEnvVar is the environment variable specifying the prefix of the names of the objects
+	// snapshots are exported to.
+	SnapshotExportPrefixEnvVar = "HIVE_SNAPSHOT_EXPORT_PREFIX"
+
+	// ClaimNamespaceAnnotation is set on claimed ClusterDeployments to the namespace of the ClusterClaim.
+	ClaimNamespaceAnnotation = "hive.openshift.io/claim-namespace"
+
+	// ClaimNameAnnotation is set on claimed ClusterDeployments to the name of the ClusterClaim.
+	ClaimNameAnnotation = "hive.openshift.io/claim-name"
+
+	// ClaimSubjectsAnnotation is set on claimed ClusterDeployments to the space-separated kind:name of the subjects of
+	// the ClusterClaim.
+	ClaimSubjectsAnnotation = "hive.openshift.io/claim-subjects"
+
+	// ClaimRequesterAnnotation is set by hiveadmission on ClusterClaims to the name of the user or service account which
+	// created them. It cannot be changed, and counts the claim against the claim quota of its requester.
+	ClaimRequesterAnnotation = "hive.openshift.io/claim-requester"
+
+	// ClaimTicketIDAnnotation is the annotation on a ClusterClaim with the ID of the ticket the cluster was requested
+	// for. It is copied to the claimed ClusterDeployment.
+	ClaimTicketIDAnnotation = "hive.openshift.io/ticket-id"
+
+	// ClaimTagsAppliedAnnotation is set to "true" on claimed ClusterDeployments once the claim annotations have been
+	// applied as tags to the cloud resources of the cluster.
+	ClaimTagsAppliedAnnotation = "hive.openshift.io/claim-tags-applied"
+
+	// ProviderPluginAnnotation is the annotation of a ClusterDeployment naming the provider plugin, configured in
+	// HiveConfig, which implements hibernation and machine management for the cluster.
+	ProviderPluginAnnotation = "hive.openshift.io/provider-plugin"
 )
 
+// GetAdditionalTrustBundleName returns the name of the additional trust bundle secret and syncset per cluster deployment
+func GetAdditionalTrustBundleName(cd *hivev1.ClusterDeployment) string {
+	return apihelpers.GetResourceName(cd.Name, additionalTrustBundleSuffix)
+}
+
+// GetSyncAgentName returns the name of the state secret and of the service account, role and role binding of the
+// sync agent per cluster deployment
+func GetSyncAgentName(cd *hivev1.ClusterDeployment) string {
+	return apihelpers.GetResourceName(cd.Name, syncAgentSuffix)
+}
+
+// GetSyncAgentStateShardName returns the name of the secret holding a shard of the state of the sync agent per
+// cluster deployment. The first shard is held by the secret named after the sync agent.
+func GetSyncAgentStateShardName(cd *hivev1.ClusterDeployment, shard int) string {
+	if shard == 0 {
+		return GetSyncAgentName(cd)
+	}
+	return fmt.Sprintf("%s-%d", GetSyncAgentName(cd), shard)
+}
+
+// GetSyncAgentTokenSecretName returns the name of the service account token secret of the sync agent per cluster deployment
+func GetSyncAgentTokenSecretName(cd *hivev1.ClusterDeployment) string {
+	return apihelpers.GetResourceName(cd.Name, syncAgentTokenSuffix)
+}
+
+// GetControllerKubeconfigSecretName returns the name of the secret holding the short-lived kubeconfig used by the
+// controllers to access the cluster per cluster deployment
+func GetControllerKubeconfigSecretName(cd *hivev1.ClusterDeployment) string {
+	return apihelpers.GetResourceName(cd.Name, controllerKubeconfigSuffix)
+}
+
 // GetMergedPullSecretName returns name for merged pull secret name per cluster deployment
 func GetMergedPullSecretName(cd *hivev1.ClusterDeployment) string {
 	return apihelpers.GetResourceName(cd.Name, mergedPullSecretSuffix)
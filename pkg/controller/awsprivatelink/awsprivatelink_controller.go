@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
 	log "github.com/sirupsen/logrus"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -25,6 +26,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/flowcontrol"
 	"k8s.io/client-go/util/retry"
 	"k8s.io/client-go/util/workqueue"
@@ -32,6 +34,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
@@ -50,8 +53,30 @@ const (
 	lastCleanupAnnotationKey = "aws-private-link-controller.hive.openshift.io/last-cleanup-for"
 
 	defaultRequeueLater = 1 * time.Minute
+
+	// pendingConnectionsRequeueAfter is how often the connection requests to a VPC endpoint service that requires
+	// acceptance are reviewed.
+	pendingConnectionsRequeueAfter = 5 * time.Minute
+
+	// cleanupTimeout is how long the cleanup of the resources created for private link access is retried for a
+	// deleted cluster before the resources that remain are reported for manual cleanup.
+	cleanupTimeout = 1 * time.Hour
+
+	// maxCleanupRetryInterval is the longest interval between the retries of the cleanup for a deleted cluster.
+	maxCleanupRetryInterval = 30 * time.Minute
 )
 
+var (
+	metricLeakedResources = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "hive_privatelink_leaked_resources",
+		Help: "Number of resources created for AWS PrivateLink access to deleted clusters that must be cleaned up manually.",
+	}, []string{"cluster_deployment", "namespace", "resource"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(metricLeakedResources)
+}
+
 // Add creates a new AWSPrivateLink Controller and adds it to the Manager with default RBAC.
 // The Manager will set fields on the Controller and Start it when the Manager is Started.
 func Add(mgr manager.Manager) error {
@@ -73,7 +98,8 @@ func Add(mgr manager.Manager) error {
 func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) (*ReconcileAWSPrivateLink, error) {
 	logger := log.WithField("controller", ControllerName)
 	reconciler := &ReconcileAWSPrivateLink{
-		Client: controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
+		Client:        controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
+		eventRecorder: mgr.GetEventRecorderFor(ControllerName.String()),
 	}
 
 	config, err := ReadAWSPrivateLinkControllerConfigFile()
@@ -136,6 +162,8 @@ type ReconcileAWSPrivateLink struct {
 
 	controllerconfig *hivev1.AWSPrivateLinkConfig
 
+	eventRecorder record.EventRecorder
+
 	// testing purpose
 	awsClientFn awsClientFn
 }
@@ -303,6 +331,16 @@ func shouldSync(desired *hivev1.ClusterDeployment) (shouldSync bool, delta time.
 		delta = time.Now().Sub(readyCondition.LastTransitionTime.Time)
 	}
 
+	if !allowedPrincipalsInSync(desired) {
+		// The additional allowed principals have changed since the last sync, sync now.
+		return true, delta
+	}
+
+	if endpointAcceptanceRequired(desired) {
+		// The pending connection requests to the VPC endpoint service must be reviewed, sync now.
+		return true, delta
+	}
+
 	if delta >= 2*time.Hour {
 		// We haven't sync'd in over resync duration time, sync now.
 		return true, delta
@@ -312,6 +350,29 @@ func shouldSync(desired *hivev1.ClusterDeployment) (shouldSync bool, delta time.
 	return false, delta
 }
 
+// allowedPrincipalsInSync returns true when the additional allowed principals of the VPC endpoint service of the
+// cluster were last reconciled for the additional allowed principals in the spec.
+func allowedPrincipalsInSync(cd *hivev1.ClusterDeployment) bool {
+	desired, current := sets.NewString(), sets.NewString()
+	if cd.Spec.Platform.AWS != nil && cd.Spec.Platform.AWS.PrivateLink != nil {
+		desired.Insert(cd.Spec.Platform.AWS.PrivateLink.AdditionalAllowedPrincipals...)
+	}
+	if cd.Status.Platform != nil && cd.Status.Platform.AWS != nil && cd.Status.Platform.AWS.PrivateLink != nil {
+		current.Insert(cd.Status.Platform.AWS.PrivateLink.AdditionalAllowedPrincipals...)
+	}
+	return desired.Equal(current)
+}
+
+// endpointAcceptanceRequired returns true when the VPC endpoint service of the cluster must require acceptance of
+// connection requests.
+func endpointAcceptanceRequired(cd *hivev1.ClusterDeployment) bool {
+	if cd.Spec.Platform.AWS == nil || cd.Spec.Platform.AWS.PrivateLink == nil {
+		return false
+	}
+	acceptance := cd.Spec.Platform.AWS.PrivateLink.EndpointAcceptance
+	return acceptance != nil && acceptance.AcceptanceRequired
+}
+
 func (r *ReconcileAWSPrivateLink) setErrCondition(cd *hivev1.ClusterDeployment,
 	reason string, err error,
 	logger log.FieldLogger) error {
@@ -471,6 +532,16 @@ func (r *ReconcileAWSPrivateLink) reconcilePrivateLink(cd *hivev1.ClusterDeploym
 		}
 	}
 
+	if err := r.reconcileVPCEndpointConnections(awsClient, cd, vpcEndpointService, logger); err != nil {
+		logger.WithError(err).Error("failed to reconcile the VPC Endpoint connections")
+
+		if err := r.setErrCondition(cd, "VPCEndpointConnectionsReconcileFailed", err, logger); err != nil {
+			logger.WithError(err).Error("failed to update condition on cluster deployment")
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{}, errors.Wrap(err, "failed to reconcile the VPC Endpoint connections")
+	}
+
 	// Figure out the API address for cluster.
 	apiDomain, err := initialURL(r.Client,
 		client.ObjectKey{Namespace: cd.Namespace, Name: clusterMetadata.AdminKubeconfigSecretRef.Name})
@@ -484,6 +555,35 @@ func (r *ReconcileAWSPrivateLink) reconcilePrivateLink(cd *hivev1.ClusterDeploym
 		return reconcile.Result{}, err
 	}
 
+	if r.controllerconfig.SharedHostedZone != nil {
+		err = r.reconcileSharedHostedZone(awsClient, cd, clusterMetadata, vpcEndpoint, apiDomain, logger)
+	} else {
+		err = r.reconcilePrivateHostedZone(awsClient, cd, clusterMetadata, vpcEndpoint, apiDomain, logger)
+	}
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if err := r.setProgressCondition(cd, corev1.ConditionTrue,
+		"PrivateLinkAccessReady",
+		"private link access is ready for use",
+		logger); err != nil {
+		logger.WithError(err).Error("failed to update condition on cluster deployment")
+		return reconcile.Result{}, err
+	}
+
+	if endpointAcceptanceRequired(cd) {
+		return reconcile.Result{RequeueAfter: pendingConnectionsRequeueAfter}, nil
+	}
+	return reconcile.Result{}, nil
+}
+
+// reconcilePrivateHostedZone ensures that the Private Hosted Zone for the VPC Endpoint of the cluster exists and is
+// associated with all the required VPCs.
+func (r *ReconcileAWSPrivateLink) reconcilePrivateHostedZone(awsClient *awsClient,
+	cd *hivev1.ClusterDeployment, clusterMetadata *hivev1.ClusterMetadata,
+	vpcEndpoint *ec2.VpcEndpoint, apiDomain string,
+	logger log.FieldLogger) error {
 	// Create the Private Hosted Zone for the VPC Endpoint.
 	hzModified, hostedZoneID, err := r.reconcileHostedZone(awsClient, cd, clusterMetadata, vpcEndpoint, apiDomain, logger)
 	if err != nil {
@@ -491,9 +591,9 @@ func (r *ReconcileAWSPrivateLink) reconcilePrivateLink(cd *hivev1.ClusterDeploym
 
 		if err := r.setErrCondition(cd, "PrivateHostedZoneReconcileFailed", err, logger); err != nil {
 			logger.WithError(err).Error("failed to update condition on cluster deployment")
-			return reconcile.Result{}, err
+			return err
 		}
-		return reconcile.Result{}, err
+		return err
 	}
 
 	if hzModified {
@@ -502,7 +602,7 @@ func (r *ReconcileAWSPrivateLink) reconcilePrivateLink(cd *hivev1.ClusterDeploym
 			"reconciled the Private Hosted Zone for the VPC Endpoint of the cluster",
 			logger); err != nil {
 			logger.WithError(err).Error("failed to update condition on cluster deployment")
-			return reconcile.Result{}, err
+			return err
 		}
 	}
 
@@ -513,9 +613,9 @@ func (r *ReconcileAWSPrivateLink) reconcilePrivateLink(cd *hivev1.ClusterDeploym
 
 		if err := r.setErrCondition(cd, "AssociatingVPCsToHostedZoneFailed", err, logger); err != nil {
 			logger.WithError(err).Error("failed to update condition on cluster deployment")
-			return reconcile.Result{}, err
+			return err
 		}
-		return reconcile.Result{}, err
+		return err
 	}
 
 	if associationsModified {
@@ -524,19 +624,145 @@ func (r *ReconcileAWSPrivateLink) reconcilePrivateLink(cd *hivev1.ClusterDeploym
 			"reconciled the associations of all the required VPCs to the Private Hosted Zone for the VPC Endpoint",
 			logger); err != nil {
 			logger.WithError(err).Error("failed to update condition on cluster deployment")
-			return reconcile.Result{}, err
+			return err
 		}
 	}
 
-	if err := r.setProgressCondition(cd, corev1.ConditionTrue,
-		"PrivateLinkAccessReady",
-		"private link access is ready for use",
+	return nil
+}
+
+// reconcileSharedHostedZone ensures that the records for the VPC Endpoint of the cluster are published in the shared
+// Private Hosted Zone. The Private Hosted Zone of the cluster is removed once the records are published, so that
+// clusters which used a Private Hosted Zone before the shared one was configured are migrated.
+func (r *ReconcileAWSPrivateLink) reconcileSharedHostedZone(awsClient *awsClient,
+	cd *hivev1.ClusterDeployment, clusterMetadata *hivev1.ClusterMetadata,
+	vpcEndpoint *ec2.VpcEndpoint, apiDomain string,
+	logger log.FieldLogger) error {
+	sharedZone := r.controllerconfig.SharedHostedZone
+	hzLog := logger.WithField("hostedZoneID", sharedZone.HostedZoneID)
+
+	sharedClient, err := r.sharedHostedZoneClient(awsClient, cd.Spec.Platform.AWS.Region)
+	if err != nil {
+		hzLog.WithError(err).Error("failed to create AWS client for the shared Hosted Zone")
+		if err := r.setErrCondition(cd, "SharedHostedZoneReconcileFailed", err, logger); err != nil {
+			logger.WithError(err).Error("failed to update condition on cluster deployment")
+			return err
+		}
+		return err
+	}
+
+	endpointDNSName := vpcEndpoint.DnsEntries[0].DnsName
+	endpointDNSHostedZone := vpcEndpoint.DnsEntries[0].HostedZoneId
+	existing, err := findSharedHostedZoneRecords(sharedClient, sharedZone.HostedZoneID, apiDomain)
+	if err != nil {
+		hzLog.WithError(err).Error("failed to list the shared hosted zone")
+		if err := r.setErrCondition(cd, "SharedHostedZoneReconcileFailed", err, logger); err != nil {
+			logger.WithError(err).Error("failed to update condition on cluster deployment")
+			return err
+		}
+		return err
+	}
+	var changes []*route53.Change
+	for _, domain := range apiDomains(apiDomain) {
+		if recordAliasesEndpoint(existing, domain, endpointDNSName, endpointDNSHostedZone) {
+			continue
+		}
+		changes = append(changes, &route53.Change{
+			Action: aws.String(route53.ChangeActionUpsert),
+			ResourceRecordSet: &route53.ResourceRecordSet{
+				Type: aws.String("A"),
+				AliasTarget: &route53.AliasTarget{
+					DNSName:              endpointDNSName,
+					HostedZoneId:         endpointDNSHostedZone,
+					EvaluateTargetHealth: aws.Bool(false),
+				},
+				Name: aws.String(domain),
+			},
+		})
+	}
+	if len(changes) == 0 {
+		hzLog.Debug("the records for the VPC Endpoint are up to date in the shared Hosted Zone")
+	} else if _, err := sharedClient.ChangeResourceRecordSets(&route53.ChangeResourceRecordSetsInput{
+		HostedZoneId: aws.String(sharedZone.HostedZoneID),
+		ChangeBatch:  &route53.ChangeBatch{Changes: changes},
+	}); err != nil {
+		hzLog.WithField("aliasDNSName", endpointDNSName).
+			WithField("aliasHostedZone", endpointDNSHostedZone).
+			WithError(err).Error("error adding records to the shared Hosted Zone for VPC Endpoint")
+		if err := r.setErrCondition(cd, "SharedHostedZoneReconcileFailed", err, logger); err != nil {
+			logger.WithError(err).Error("failed to update condition on cluster deployment")
+			return err
+		}
+		return err
+	}
+
+	initPrivateLinkStatus(cd)
+	if cd.Status.Platform.AWS.PrivateLink.HostedZoneID == sharedZone.HostedZoneID {
+		return nil
+	}
+
+	// The records were published in the shared Hosted Zone for the first time, so remove the Private Hosted Zone
+	// the cluster used before, if any.
+	if err := r.cleanupHostedZone(awsClient.hub, cd, clusterMetadata, logger); err != nil {
+		logger.WithError(err).Error("error cleaning up the Private Hosted Zone of the cluster")
+		if err := r.setErrCondition(cd, "SharedHostedZoneReconcileFailed", err, logger); err != nil {
+			logger.WithError(err).Error("failed to update condition on cluster deployment")
+			return err
+		}
+		return err
+	}
+
+	cd.Status.Platform.AWS.PrivateLink.HostedZoneID = sharedZone.HostedZoneID
+	if err := r.updatePrivateLinkStatus(cd, logger); err != nil {
+		logger.WithError(err).Error("failed to update the hosted zone ID for cluster deployment")
+		return err
+	}
+
+	if err := r.setProgressCondition(cd, corev1.ConditionFalse,
+		"ReconciledSharedHostedZone",
+		"reconciled the records for the VPC Endpoint of the cluster in the shared Hosted Zone",
 		logger); err != nil {
 		logger.WithError(err).Error("failed to update condition on cluster deployment")
-		return reconcile.Result{}, err
+		return err
 	}
 
-	return reconcile.Result{}, nil
+	return nil
+}
+
+// recordAliasesEndpoint returns whether the records include an A record for the domain which is an alias of the DNS
+// name of the VPC Endpoint.
+func recordAliasesEndpoint(records []*route53.ResourceRecordSet, domain string, dnsName, hostedZoneID *string) bool {
+	normalize := func(name string) string {
+		return strings.ToLower(strings.TrimSuffix(name, "."))
+	}
+	for _, record := range records {
+		if normalize(aws.StringValue(record.Name)) != normalize(domain) || record.AliasTarget == nil {
+			continue
+		}
+		return normalize(aws.StringValue(record.AliasTarget.DNSName)) == normalize(aws.StringValue(dnsName)) &&
+			aws.StringValue(record.AliasTarget.HostedZoneId) == aws.StringValue(hostedZoneID)
+	}
+	return false
+}
+
+// sharedHostedZoneClient returns the AWS client used to publish the records in the shared Private Hosted Zone.
+func (r *ReconcileAWSPrivateLink) sharedHostedZoneClient(awsClient *awsClient, region string) (awsclient.Client, error) {
+	secretRef := r.controllerconfig.SharedHostedZone.CredentialsSecretRef
+	if secretRef == nil {
+		return awsClient.hub, nil
+	}
+	return r.awsClientFn(r.Client, secretRef.Name, controllerutils.GetHiveNamespace(), region)
+}
+
+// apiDomains returns the domains which are published for the VPC Endpoint of the cluster in the shared Private Hosted
+// Zone: the API domain and, when the API domain is on the api host name of the cluster, the internal API domain on the
+// api-int host name.
+func apiDomains(apiDomain string) []string {
+	domains := []string{apiDomain}
+	if strings.HasPrefix(apiDomain, "api.") {
+		domains = append(domains, "api-int."+strings.TrimPrefix(apiDomain, "api."))
+	}
+	return domains
 }
 
 // discoverNLBForCluster uses the AWS client to find the NLB for cluster's internal APIserver.
@@ -606,11 +832,12 @@ func (r *ReconcileAWSPrivateLink) reconcileVPCEndpointService(awsClient *awsClie
 
 	oldNLBs := sets.NewString(aws.StringValueSlice(serviceConfig.NetworkLoadBalancerArns)...)
 	desiredNLBs := sets.NewString(nlbARN)
-	if aws.BoolValue(serviceConfig.AcceptanceRequired) ||
+	acceptanceRequired := endpointAcceptanceRequired(cd)
+	if aws.BoolValue(serviceConfig.AcceptanceRequired) != acceptanceRequired ||
 		!desiredNLBs.Equal(oldNLBs) {
 		modified = true
 		modification := &ec2.ModifyVpcEndpointServiceConfigurationInput{
-			AcceptanceRequired: aws.Bool(false),
+			AcceptanceRequired: aws.Bool(acceptanceRequired),
 			ServiceId:          serviceConfig.ServiceId,
 		}
 
@@ -647,6 +874,8 @@ func (r *ReconcileAWSPrivateLink) reconcileVPCEndpointService(awsClient *awsClie
 		oldPerms.Insert(aws.StringValue(allowed.Principal))
 	}
 	desriredPerms := sets.NewString(aws.StringValue(stsResp.Arn))
+	additionalPerms := cd.Spec.Platform.AWS.PrivateLink.AdditionalAllowedPrincipals
+	desriredPerms.Insert(additionalPerms...)
 
 	if !desriredPerms.Equal(oldPerms) {
 		modified = true
@@ -668,6 +897,14 @@ func (r *ReconcileAWSPrivateLink) reconcileVPCEndpointService(awsClient *awsClie
 		}
 	}
 
+	if !sets.NewString(additionalPerms...).Equal(sets.NewString(cd.Status.Platform.AWS.PrivateLink.AdditionalAllowedPrincipals...)) {
+		cd.Status.Platform.AWS.PrivateLink.AdditionalAllowedPrincipals = sets.NewString(additionalPerms...).List()
+		if err := r.updatePrivateLinkStatus(cd, logger); err != nil {
+			serviceLog.WithError(err).Error("error updating clusterdeployment status with additionalAllowedPrincipals")
+			return modified, nil, err
+		}
+	}
+
 	return modified, serviceConfig, nil
 }
 
@@ -710,7 +947,7 @@ func (r *ReconcileAWSPrivateLink) ensureVPCEndpointService(awsClient awsclient.C
 
 func createVPCEndpointService(awsClient awsclient.Client, cd *hivev1.ClusterDeployment, metadata *hivev1.ClusterMetadata, clusterNLB string, logger log.FieldLogger) (*ec2.ServiceConfiguration, error) {
 	resp, err := awsClient.CreateVpcEndpointServiceConfiguration(&ec2.CreateVpcEndpointServiceConfigurationInput{
-		AcceptanceRequired:      aws.Bool(false),
+		AcceptanceRequired:      aws.Bool(endpointAcceptanceRequired(cd)),
 		NetworkLoadBalancerArns: aws.StringSlice([]string{clusterNLB}),
 		TagSpecifications:       []*ec2.TagSpecification{ec2TagSpecification(metadata, "vpc-endpoint-service")},
 	})
@@ -809,7 +1046,13 @@ func (r *ReconcileAWSPrivateLink) createVPCEndpoint(awsClient awsclient.Client,
 	}
 	endpointLog := logger.WithField("endpointID", *resp.VpcEndpoint.VpcEndpointId)
 
-	if err := waitForState("available", 1*time.Minute, func() (string, error) {
+	// The connection request of the VPC Endpoint stays pending until it is accepted when the VPC Endpoint Service
+	// requires acceptance.
+	desiredState := "available"
+	if endpointAcceptanceRequired(cd) {
+		desiredState = "pendingAcceptance"
+	}
+	if err := waitForState(desiredState, 1*time.Minute, func() (string, error) {
 		resp, err := awsClient.DescribeVpcEndpoints(&ec2.DescribeVpcEndpointsInput{
 			VpcEndpointIds: aws.StringSlice([]string{*resp.VpcEndpoint.VpcEndpointId}),
 		})
@@ -825,6 +1068,115 @@ func (r *ReconcileAWSPrivateLink) createVPCEndpoint(awsClient awsclient.Client,
 	return resp.VpcEndpoint, nil
 }
 
+// reconcileVPCEndpointConnections reviews the pending connection requests to the VPC Endpoint Service when the
+// service requires acceptance. Connection requests from the account of Hive and from the auto-accepted accounts are
+// accepted, and connection requests from any other account are rejected.
+func (r *ReconcileAWSPrivateLink) reconcileVPCEndpointConnections(awsClient *awsClient,
+	cd *hivev1.ClusterDeployment, vpcEndpointService *ec2.ServiceConfiguration,
+	logger log.FieldLogger) error {
+	if !endpointAcceptanceRequired(cd) {
+		return nil
+	}
+	serviceLog := logger.WithField("serviceID", *vpcEndpointService.ServiceId)
+
+	stsResp, err := awsClient.hub.GetCallerIdentity(&sts.GetCallerIdentityInput{})
+	if err != nil {
+		serviceLog.WithError(err).Error("error getting the identity of the user that created the VPC Endpoint")
+		return err
+	}
+	acceptedAccounts := sets.NewString(cd.Spec.Platform.AWS.PrivateLink.EndpointAcceptance.AutoAcceptAccountIDs...).
+		Insert(aws.StringValue(stsResp.Account))
+
+	var accept []string
+	var reject []*ec2.VpcEndpointConnection
+	input := &ec2.DescribeVpcEndpointConnectionsInput{
+		Filters: []*ec2.Filter{{
+			Name:   aws.String("service-id"),
+			Values: aws.StringSlice([]string{*vpcEndpointService.ServiceId}),
+		}, {
+			Name:   aws.String("vpc-endpoint-state"),
+			Values: aws.StringSlice([]string{"pendingAcceptance"}),
+		}},
+	}
+	for {
+		resp, err := awsClient.user.DescribeVpcEndpointConnections(input)
+		if err != nil {
+			serviceLog.WithError(err).Error("error getting the pending VPC Endpoint connections")
+			return err
+		}
+		for _, conn := range resp.VpcEndpointConnections {
+			if acceptedAccounts.Has(aws.StringValue(conn.VpcEndpointOwner)) {
+				accept = append(accept, aws.StringValue(conn.VpcEndpointId))
+			} else {
+				reject = append(reject, conn)
+			}
+		}
+		if aws.StringValue(resp.NextToken) == "" {
+			break
+		}
+		input.NextToken = resp.NextToken
+	}
+
+	if len(accept) > 0 {
+		resp, err := awsClient.user.AcceptVpcEndpointConnections(&ec2.AcceptVpcEndpointConnectionsInput{
+			ServiceId:      vpcEndpointService.ServiceId,
+			VpcEndpointIds: aws.StringSlice(accept),
+		})
+		if err != nil {
+			serviceLog.WithField("endpoints", accept).WithError(err).Error("error accepting VPC Endpoint connections")
+			return err
+		}
+		if len(resp.Unsuccessful) > 0 {
+			return errors.Errorf("failed to accept the connections of VPC Endpoints: %s",
+				strings.Join(unsuccessfulItemIDs(resp.Unsuccessful), ", "))
+		}
+		serviceLog.WithField("endpoints", accept).Info("accepted VPC Endpoint connections")
+	}
+
+	if len(reject) > 0 {
+		rejectIDs := make([]string, 0, len(reject))
+		for _, conn := range reject {
+			rejectIDs = append(rejectIDs, aws.StringValue(conn.VpcEndpointId))
+		}
+		resp, err := awsClient.user.RejectVpcEndpointConnections(&ec2.RejectVpcEndpointConnectionsInput{
+			ServiceId:      vpcEndpointService.ServiceId,
+			VpcEndpointIds: aws.StringSlice(rejectIDs),
+		})
+		if err != nil {
+			serviceLog.WithField("endpoints", rejectIDs).WithError(err).Error("error rejecting VPC Endpoint connections")
+			return err
+		}
+		unsuccessful := sets.NewString(unsuccessfulItemIDs(resp.Unsuccessful)...)
+		for _, conn := range reject {
+			if unsuccessful.Has(aws.StringValue(conn.VpcEndpointId)) {
+				continue
+			}
+			serviceLog.WithField("endpoint", aws.StringValue(conn.VpcEndpointId)).
+				WithField("owner", aws.StringValue(conn.VpcEndpointOwner)).
+				Info("rejected VPC Endpoint connection")
+			if r.eventRecorder != nil {
+				r.eventRecorder.Eventf(cd, corev1.EventTypeWarning, "VPCEndpointConnectionRejected",
+					"Rejected the connection request of VPC Endpoint %s from account %s, which is not auto-accepted",
+					aws.StringValue(conn.VpcEndpointId), aws.StringValue(conn.VpcEndpointOwner))
+			}
+		}
+		if unsuccessful.Len() > 0 {
+			return errors.Errorf("failed to reject the connections of VPC Endpoints: %s",
+				strings.Join(unsuccessful.List(), ", "))
+		}
+	}
+
+	return nil
+}
+
+func unsuccessfulItemIDs(items []*ec2.UnsuccessfulItem) []string {
+	ids := make([]string, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, aws.StringValue(item.ResourceId))
+	}
+	return ids
+}
+
 // reconcileHostedZone ensures that a Private Hosted Zone apiDomain exists for the VPC
 // where VPC endpoint was created. It also make sure the DNS zone has an ALIAS record pointing
 // to the regional DNS name of the VPC endpoint.
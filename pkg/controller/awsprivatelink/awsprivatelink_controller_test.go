@@ -21,6 +21,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -578,6 +579,34 @@ users:
 		return endpoint
 	}
 
+	mockSharedHZRecords := func(m *mock.MockClient, endpoint *ec2.VpcEndpoint, hzID string, domains ...string) {
+		var changes []*route53.Change
+		for _, domain := range domains {
+			m.EXPECT().ListResourceRecordSets(&route53.ListResourceRecordSetsInput{
+				HostedZoneId:    aws.String(hzID),
+				StartRecordName: aws.String(domain),
+				StartRecordType: aws.String("A"),
+				MaxItems:        aws.String("1"),
+			}).Return(&route53.ListResourceRecordSetsOutput{}, nil)
+			changes = append(changes, &route53.Change{
+				Action: aws.String("UPSERT"),
+				ResourceRecordSet: &route53.ResourceRecordSet{
+					AliasTarget: &route53.AliasTarget{
+						DNSName:              endpoint.DnsEntries[0].DnsName,
+						EvaluateTargetHealth: aws.Bool(false),
+						HostedZoneId:         endpoint.DnsEntries[0].HostedZoneId,
+					},
+					Name: aws.String(domain),
+					Type: aws.String("A"),
+				},
+			})
+		}
+		m.EXPECT().ChangeResourceRecordSets(&route53.ChangeResourceRecordSetsInput{
+			ChangeBatch:  &route53.ChangeBatch{Changes: changes},
+			HostedZoneId: aws.String(hzID),
+		}).Return(nil, nil)
+	}
+
 	mockPHZ := func(m *mock.MockClient, endpoint *ec2.VpcEndpoint, apiDomain string, existingSummary *route53.HostedZoneSummary) string {
 		byVPCOut := &route53.ListHostedZonesByVPCOutput{}
 		if existingSummary != nil {
@@ -635,12 +664,14 @@ users:
 		existing           []runtime.Object
 		inventory          []hivev1.AWSPrivateLinkInventory
 		associate          []hivev1.AWSAssociatedVPC
+		sharedHostedZone   *hivev1.AWSPrivateLinkSharedHostedZone
 		configureAWSClient func(*mock.MockClient)
 
 		hasFinalizer        bool
 		expectedAnnotations map[string]string
 		expectedStatus      *hivev1aws.PrivateLinkAccessStatus
 		expectedConditions  []hivev1.ClusterDeploymentCondition
+		expectedEvents      []string
 		err                 string
 	}{{
 		name: "cd with gcp platform",
@@ -910,6 +941,57 @@ users:
 			Message: "AccessDenied: not authorized to DescribeVpcEndpoints",
 		}},
 		err: "failed to reconcile the VPC Endpoint: AccessDenied: not authorized to DescribeVpcEndpoints",
+	}, {
+		name: "cd with privatelink enabled, provision started, nlb found, previous service exists, additional allowed principals change, endpoint access denied",
+
+		existing: []runtime.Object{
+			testProvision("test-cd-provision-0",
+				provisionWithInfraID("test-cd-1234"),
+				provisionWithAdminKubeconfig("test-cd-provision-0-kubeconfig")),
+			cdBuilder.Build(
+				testcd.WithAWSPlatform(&hivev1aws.Platform{Region: "us-east-1",
+					PrivateLink: &hivev1aws.PrivateLinkAccess{
+						Enabled:                     true,
+						AdditionalAllowedPrincipals: []string{"aws:iam:67890:customer-role", "aws:iam:12345:hub-user"},
+					}}),
+				withClusterProvision("test-cd-provision-0"),
+			),
+		},
+		inventory: validInventory,
+		configureAWSClient: func(m *mock.MockClient) {
+			clusternlb := mockDiscoverLB(m)
+			service := mockExistingService(m, clusternlb, func(s *ec2.ServiceConfiguration) {})
+
+			m.EXPECT().GetCallerIdentity(gomock.Any()).Return(&sts.GetCallerIdentityOutput{Arn: aws.String("aws:iam:12345:hub-user")}, nil)
+			m.EXPECT().DescribeVpcEndpointServicePermissions(gomock.Any()).
+				Return(&ec2.DescribeVpcEndpointServicePermissionsOutput{
+					AllowedPrincipals: []*ec2.AllowedPrincipal{{
+						Principal: aws.String("aws:iam:12345:hub-user"),
+					}, {
+						Principal: aws.String("aws:iam:67890:removed-role"),
+					}},
+				}, nil)
+			m.EXPECT().ModifyVpcEndpointServicePermissions(&ec2.ModifyVpcEndpointServicePermissionsInput{
+				AddAllowedPrincipals:    aws.StringSlice([]string{"aws:iam:67890:customer-role"}),
+				RemoveAllowedPrincipals: aws.StringSlice([]string{"aws:iam:67890:removed-role"}),
+				ServiceId:               service.ServiceId,
+			}).Return(nil, nil)
+
+			m.EXPECT().DescribeVpcEndpoints(gomock.Any()).Return(nil, awserr.New("AccessDenied", "not authorized to DescribeVpcEndpoints", nil))
+		},
+
+		hasFinalizer: true,
+		expectedStatus: &hivev1aws.PrivateLinkAccessStatus{
+			VPCEndpointService:          hivev1aws.VPCEndpointService{Name: "vpce-svc-12345.vpc.amazon.com", ID: "vpce-svc-12345"},
+			AdditionalAllowedPrincipals: []string{"aws:iam:12345:hub-user", "aws:iam:67890:customer-role"},
+		},
+		expectedConditions: []hivev1.ClusterDeploymentCondition{{
+			Type:    hivev1.AWSPrivateLinkFailedClusterDeploymentCondition,
+			Status:  corev1.ConditionTrue,
+			Reason:  "VPCEndpointReconcileFailed",
+			Message: "AccessDenied: not authorized to DescribeVpcEndpoints",
+		}},
+		err: "failed to reconcile the VPC Endpoint: AccessDenied: not authorized to DescribeVpcEndpoints",
 	}, {
 		name: "cd with privatelink enabled, provision started, nlb found, no previous service, no previous endpoint, no matching az",
 
@@ -1028,6 +1110,159 @@ users:
 			Message: "could not get admin kubeconfig secret: secrets \"test-cd-provision-0-kubeconfig\" not found",
 		}},
 		err: "could not get admin kubeconfig secret: secrets \"test-cd-provision-0-kubeconfig\" not found",
+	}, {
+		name: "cd with privatelink enabled, provision started, nlb found, previous service exists, acceptance required, pending connections reviewed",
+
+		existing: []runtime.Object{
+			testProvision("test-cd-provision-0",
+				provisionWithInfraID("test-cd-1234"),
+				provisionWithAdminKubeconfig("test-cd-provision-0-kubeconfig")),
+			cdBuilder.Build(
+				testcd.WithAWSPlatform(&hivev1aws.Platform{Region: "us-east-1",
+					PrivateLink: &hivev1aws.PrivateLinkAccess{
+						Enabled: true,
+						EndpointAcceptance: &hivev1aws.PrivateLinkEndpointAcceptance{
+							AcceptanceRequired:   true,
+							AutoAcceptAccountIDs: []string{"67890"},
+						},
+					}}),
+				withClusterProvision("test-cd-provision-0"),
+			),
+		},
+		inventory: validInventory,
+		configureAWSClient: func(m *mock.MockClient) {
+			clusternlb := mockDiscoverLB(m)
+			service := mockExistingService(m, clusternlb, func(s *ec2.ServiceConfiguration) {})
+
+			m.EXPECT().ModifyVpcEndpointServiceConfiguration(&ec2.ModifyVpcEndpointServiceConfigurationInput{
+				ServiceId:          service.ServiceId,
+				AcceptanceRequired: aws.Bool(true),
+			}).Return(&ec2.ModifyVpcEndpointServiceConfigurationOutput{}, nil)
+
+			mockServicePerms(m, service)
+
+			m.EXPECT().DescribeVpcEndpoints(gomock.Any()).Return(&ec2.DescribeVpcEndpointsOutput{
+				VpcEndpoints: []*ec2.VpcEndpoint{{
+					VpcEndpointId: aws.String("vpce-12345"),
+					VpcId:         aws.String("vpc-1"),
+					State:         aws.String("pendingAcceptance"),
+				}},
+			}, nil)
+
+			m.EXPECT().GetCallerIdentity(gomock.Any()).Return(&sts.GetCallerIdentityOutput{
+				Account: aws.String("12345"),
+				Arn:     aws.String("aws:iam:12345:hub-user"),
+			}, nil)
+			m.EXPECT().DescribeVpcEndpointConnections(&ec2.DescribeVpcEndpointConnectionsInput{
+				Filters: []*ec2.Filter{{
+					Name:   aws.String("service-id"),
+					Values: aws.StringSlice([]string{"vpce-svc-12345"}),
+				}, {
+					Name:   aws.String("vpc-endpoint-state"),
+					Values: aws.StringSlice([]string{"pendingAcceptance"}),
+				}},
+			}).Return(&ec2.DescribeVpcEndpointConnectionsOutput{
+				VpcEndpointConnections: []*ec2.VpcEndpointConnection{{
+					VpcEndpointId:    aws.String("vpce-12345"),
+					VpcEndpointOwner: aws.String("12345"),
+				}, {
+					VpcEndpointId:    aws.String("vpce-67890"),
+					VpcEndpointOwner: aws.String("67890"),
+				}, {
+					VpcEndpointId:    aws.String("vpce-99999"),
+					VpcEndpointOwner: aws.String("99999"),
+				}},
+			}, nil)
+			m.EXPECT().AcceptVpcEndpointConnections(&ec2.AcceptVpcEndpointConnectionsInput{
+				ServiceId:      service.ServiceId,
+				VpcEndpointIds: aws.StringSlice([]string{"vpce-12345", "vpce-67890"}),
+			}).Return(&ec2.AcceptVpcEndpointConnectionsOutput{}, nil)
+			m.EXPECT().RejectVpcEndpointConnections(&ec2.RejectVpcEndpointConnectionsInput{
+				ServiceId:      service.ServiceId,
+				VpcEndpointIds: aws.StringSlice([]string{"vpce-99999"}),
+			}).Return(&ec2.RejectVpcEndpointConnectionsOutput{}, nil)
+		},
+
+		hasFinalizer: true,
+		expectedStatus: &hivev1aws.PrivateLinkAccessStatus{
+			VPCEndpointService: hivev1aws.VPCEndpointService{Name: "vpce-svc-12345.vpc.amazon.com", ID: "vpce-svc-12345"},
+			VPCEndpointID:      "vpce-12345",
+		},
+		expectedConditions: []hivev1.ClusterDeploymentCondition{{
+			Type:    hivev1.AWSPrivateLinkFailedClusterDeploymentCondition,
+			Status:  corev1.ConditionTrue,
+			Reason:  "CouldNotCalculateAPIDomain",
+			Message: "could not get admin kubeconfig secret: secrets \"test-cd-provision-0-kubeconfig\" not found",
+		}},
+		expectedEvents: []string{
+			"Warning VPCEndpointConnectionRejected Rejected the connection request of VPC Endpoint vpce-99999 from account 99999, which is not auto-accepted",
+		},
+		err: "could not get admin kubeconfig secret: secrets \"test-cd-provision-0-kubeconfig\" not found",
+	}, {
+		name: "cd with privatelink enabled, provision started, nlb found, previous service exists, acceptance required, accepting connections fails",
+
+		existing: []runtime.Object{
+			testProvision("test-cd-provision-0",
+				provisionWithInfraID("test-cd-1234"),
+				provisionWithAdminKubeconfig("test-cd-provision-0-kubeconfig")),
+			cdBuilder.Build(
+				testcd.WithAWSPlatform(&hivev1aws.Platform{Region: "us-east-1",
+					PrivateLink: &hivev1aws.PrivateLinkAccess{
+						Enabled: true,
+						EndpointAcceptance: &hivev1aws.PrivateLinkEndpointAcceptance{
+							AcceptanceRequired: true,
+						},
+					}}),
+				withClusterProvision("test-cd-provision-0"),
+			),
+		},
+		inventory: validInventory,
+		configureAWSClient: func(m *mock.MockClient) {
+			clusternlb := mockDiscoverLB(m)
+			service := mockExistingService(m, clusternlb, func(s *ec2.ServiceConfiguration) {
+				s.AcceptanceRequired = aws.Bool(true)
+			})
+
+			mockServicePerms(m, service)
+
+			m.EXPECT().DescribeVpcEndpoints(gomock.Any()).Return(&ec2.DescribeVpcEndpointsOutput{
+				VpcEndpoints: []*ec2.VpcEndpoint{{
+					VpcEndpointId: aws.String("vpce-12345"),
+					VpcId:         aws.String("vpc-1"),
+					State:         aws.String("pendingAcceptance"),
+				}},
+			}, nil)
+
+			m.EXPECT().GetCallerIdentity(gomock.Any()).Return(&sts.GetCallerIdentityOutput{
+				Account: aws.String("12345"),
+				Arn:     aws.String("aws:iam:12345:hub-user"),
+			}, nil)
+			m.EXPECT().DescribeVpcEndpointConnections(gomock.Any()).Return(&ec2.DescribeVpcEndpointConnectionsOutput{
+				VpcEndpointConnections: []*ec2.VpcEndpointConnection{{
+					VpcEndpointId:    aws.String("vpce-12345"),
+					VpcEndpointOwner: aws.String("12345"),
+				}},
+			}, nil)
+			m.EXPECT().AcceptVpcEndpointConnections(&ec2.AcceptVpcEndpointConnectionsInput{
+				ServiceId:      service.ServiceId,
+				VpcEndpointIds: aws.StringSlice([]string{"vpce-12345"}),
+			}).Return(&ec2.AcceptVpcEndpointConnectionsOutput{
+				Unsuccessful: []*ec2.UnsuccessfulItem{{ResourceId: aws.String("vpce-12345")}},
+			}, nil)
+		},
+
+		hasFinalizer: true,
+		expectedStatus: &hivev1aws.PrivateLinkAccessStatus{
+			VPCEndpointService: hivev1aws.VPCEndpointService{Name: "vpce-svc-12345.vpc.amazon.com", ID: "vpce-svc-12345"},
+			VPCEndpointID:      "vpce-12345",
+		},
+		expectedConditions: []hivev1.ClusterDeploymentCondition{{
+			Type:    hivev1.AWSPrivateLinkFailedClusterDeploymentCondition,
+			Status:  corev1.ConditionTrue,
+			Reason:  "VPCEndpointConnectionsReconcileFailed",
+			Message: "failed to accept the connections of VPC Endpoints: vpce-12345",
+		}},
+		err: "failed to reconcile the VPC Endpoint connections: failed to accept the connections of VPC Endpoints: vpce-12345",
 	}, {
 		name: "cd with privatelink enabled, provision started, nlb found, no previous service, no previous endpoint, no previous PHZ",
 
@@ -1509,6 +1744,277 @@ users:
 		expectedAnnotations: map[string]string{
 			lastCleanupAnnotationKey: "test-cd-1234",
 		},
+	}, {
+		name: "cd with privatelink enabled, no previous private link, shared hosted zone",
+
+		existing: []runtime.Object{
+			testSecret("test-cd-provision-0-kubeconfig", kubeConfigSecret),
+			testProvision("test-cd-provision-0",
+				provisionWithInfraID("test-cd-1234"),
+				provisionWithAdminKubeconfig("test-cd-provision-0-kubeconfig")),
+			enabledPrivateLinkBuilder.Build(withClusterProvision("test-cd-provision-0")),
+		},
+		inventory:        validInventory,
+		sharedHostedZone: &hivev1.AWSPrivateLinkSharedHostedZone{HostedZoneID: "SHZ12345"},
+		configureAWSClient: func(m *mock.MockClient) {
+			clusternlb := mockDiscoverLB(m)
+			service := mockCreateService(m, clusternlb)
+			mockServicePerms(m, service)
+			endpoint := mockCreateEndpoint(m, service)
+
+			mockSharedHZRecords(m, endpoint, "SHZ12345", "api.test-cluster", "api-int.test-cluster")
+
+			m.EXPECT().DescribeVpcEndpoints(gomock.Any()).
+				Return(&ec2.DescribeVpcEndpointsOutput{VpcEndpoints: []*ec2.VpcEndpoint{endpoint}}, nil)
+			m.EXPECT().ListHostedZonesByVPC(&route53.ListHostedZonesByVPCInput{
+				MaxItems:  aws.String("100"),
+				VPCId:     endpoint.VpcId,
+				VPCRegion: aws.String("us-east-1"),
+			}).Return(&route53.ListHostedZonesByVPCOutput{}, nil)
+		},
+
+		hasFinalizer: true,
+		expectedStatus: &hivev1aws.PrivateLinkAccessStatus{
+			VPCEndpointService: hivev1aws.VPCEndpointService{Name: "vpce-svc-12345.vpc.amazon.com", ID: "vpce-svc-12345"},
+			VPCEndpointID:      "vpce-12345",
+			HostedZoneID:       "SHZ12345",
+		},
+		expectedConditions: []hivev1.ClusterDeploymentCondition{{
+			Type:    hivev1.AWSPrivateLinkReadyClusterDeploymentCondition,
+			Status:  corev1.ConditionTrue,
+			Reason:  "PrivateLinkAccessReady",
+			Message: "private link access is ready for use",
+		}},
+	}, {
+		name: "cd with privatelink enabled, shared hosted zone records up to date",
+
+		existing: []runtime.Object{
+			testSecret("test-cd-provision-0-kubeconfig", kubeConfigSecret),
+			testProvision("test-cd-provision-0",
+				provisionWithInfraID("test-cd-1234"),
+				provisionWithAdminKubeconfig("test-cd-provision-0-kubeconfig")),
+			enabledPrivateLinkBuilder.Build(
+				withClusterProvision("test-cd-provision-0"),
+				withPrivateLink(&hivev1aws.PrivateLinkAccessStatus{
+					VPCEndpointService: hivev1aws.VPCEndpointService{Name: "vpce-svc-12345.vpc.amazon.com", ID: "vpce-svc-12345"},
+					VPCEndpointID:      "vpce-12345",
+					HostedZoneID:       "SHZ12345",
+				}),
+			),
+		},
+		inventory:        validInventory,
+		sharedHostedZone: &hivev1.AWSPrivateLinkSharedHostedZone{HostedZoneID: "SHZ12345"},
+		configureAWSClient: func(m *mock.MockClient) {
+			clusternlb := mockDiscoverLB(m)
+			service := mockCreateService(m, clusternlb)
+			mockServicePerms(m, service)
+			endpoint := mockCreateEndpoint(m, service)
+
+			for _, domain := range []string{"api.test-cluster", "api-int.test-cluster"} {
+				m.EXPECT().ListResourceRecordSets(&route53.ListResourceRecordSetsInput{
+					HostedZoneId:    aws.String("SHZ12345"),
+					StartRecordName: aws.String(domain),
+					StartRecordType: aws.String("A"),
+					MaxItems:        aws.String("1"),
+				}).Return(&route53.ListResourceRecordSetsOutput{
+					ResourceRecordSets: []*route53.ResourceRecordSet{{
+						Name: aws.String(domain + "."),
+						Type: aws.String("A"),
+						AliasTarget: &route53.AliasTarget{
+							DNSName:      aws.String(aws.StringValue(endpoint.DnsEntries[0].DnsName) + "."),
+							HostedZoneId: endpoint.DnsEntries[0].HostedZoneId,
+						},
+					}},
+				}, nil)
+			}
+		},
+
+		hasFinalizer: true,
+		expectedStatus: &hivev1aws.PrivateLinkAccessStatus{
+			VPCEndpointService: hivev1aws.VPCEndpointService{Name: "vpce-svc-12345.vpc.amazon.com", ID: "vpce-svc-12345"},
+			VPCEndpointID:      "vpce-12345",
+			HostedZoneID:       "SHZ12345",
+		},
+		expectedConditions: []hivev1.ClusterDeploymentCondition{{
+			Type:    hivev1.AWSPrivateLinkReadyClusterDeploymentCondition,
+			Status:  corev1.ConditionTrue,
+			Reason:  "PrivateLinkAccessReady",
+			Message: "private link access is ready for use",
+		}},
+	}, {
+		name: "cd with privatelink enabled, existing PHZ, shared hosted zone",
+
+		existing: []runtime.Object{
+			testSecret("test-cd-provision-0-kubeconfig", kubeConfigSecret),
+			testProvision("test-cd-provision-0",
+				provisionWithInfraID("test-cd-1234"),
+				provisionWithAdminKubeconfig("test-cd-provision-0-kubeconfig")),
+			enabledPrivateLinkBuilder.Build(withClusterProvision("test-cd-provision-0")),
+		},
+		inventory: validInventory,
+		sharedHostedZone: &hivev1.AWSPrivateLinkSharedHostedZone{
+			HostedZoneID:         "SHZ12345",
+			CredentialsSecretRef: &corev1.LocalObjectReference{Name: "shared-hz-creds"},
+		},
+		configureAWSClient: func(m *mock.MockClient) {
+			clusternlb := mockDiscoverLB(m)
+			service := mockCreateService(m, clusternlb)
+			mockServicePerms(m, service)
+			endpoint := mockCreateEndpoint(m, service)
+
+			mockSharedHZRecords(m, endpoint, "SHZ12345", "api.test-cluster", "api-int.test-cluster")
+
+			m.EXPECT().DescribeVpcEndpoints(gomock.Any()).
+				Return(&ec2.DescribeVpcEndpointsOutput{VpcEndpoints: []*ec2.VpcEndpoint{endpoint}}, nil)
+			m.EXPECT().ListHostedZonesByVPC(&route53.ListHostedZonesByVPCInput{
+				MaxItems:  aws.String("100"),
+				VPCId:     endpoint.VpcId,
+				VPCRegion: aws.String("us-east-1"),
+			}).Return(&route53.ListHostedZonesByVPCOutput{
+				HostedZoneSummaries: []*route53.HostedZoneSummary{{
+					HostedZoneId: aws.String("HZ12345"),
+					Name:         aws.String("api.test-cluster."),
+				}},
+			}, nil)
+			rr := &route53.ResourceRecordSet{
+				Type: aws.String("A"),
+				Name: aws.String("api.test-cluster."),
+			}
+			m.EXPECT().ListResourceRecordSets(&route53.ListResourceRecordSetsInput{
+				HostedZoneId: aws.String("HZ12345"),
+			}).Return(&route53.ListResourceRecordSetsOutput{
+				ResourceRecordSets: []*route53.ResourceRecordSet{{
+					Type: aws.String("SOA"),
+				}, rr},
+			}, nil)
+			m.EXPECT().ChangeResourceRecordSets(&route53.ChangeResourceRecordSetsInput{
+				HostedZoneId: aws.String("HZ12345"),
+				ChangeBatch: &route53.ChangeBatch{
+					Changes: []*route53.Change{{
+						Action:            aws.String("DELETE"),
+						ResourceRecordSet: rr,
+					}},
+				},
+			}).Return(nil, nil)
+			m.EXPECT().DeleteHostedZone(&route53.DeleteHostedZoneInput{
+				Id: aws.String("HZ12345"),
+			}).Return(nil, nil)
+		},
+
+		hasFinalizer: true,
+		expectedStatus: &hivev1aws.PrivateLinkAccessStatus{
+			VPCEndpointService: hivev1aws.VPCEndpointService{Name: "vpce-svc-12345.vpc.amazon.com", ID: "vpce-svc-12345"},
+			VPCEndpointID:      "vpce-12345",
+			HostedZoneID:       "SHZ12345",
+		},
+		expectedConditions: []hivev1.ClusterDeploymentCondition{{
+			Type:    hivev1.AWSPrivateLinkReadyClusterDeploymentCondition,
+			Status:  corev1.ConditionTrue,
+			Reason:  "PrivateLinkAccessReady",
+			Message: "private link access is ready for use",
+		}},
+	}, {
+		name: "cd with privatelink enabled, previous provision failed, new started, shared hosted zone",
+
+		existing: []runtime.Object{
+			testSecret("test-cd-provision-0-kubeconfig", kubeConfigSecret),
+			testProvision("test-cd-provision-0",
+				provisionWithInfraID("test-cd-1234"),
+				provisionWithAdminKubeconfig("test-cd-provision-0-kubeconfig"),
+				provisionWithFailed()),
+			testProvision("test-cd-provision-1",
+				provisionWithPrevInfraID("test-cd-1234")),
+			enabledPrivateLinkBuilder.Build(
+				withClusterMetadata("test-cd-1234", "test-cd-provision-0-kubeconfig"),
+				withClusterProvision("test-cd-provision-1"),
+				withPrivateLink(&hivev1aws.PrivateLinkAccessStatus{
+					VPCEndpointService: hivev1aws.VPCEndpointService{Name: "vpce-svc-12345.vpc.amazon.com", ID: "vpce-svc-12345"},
+					VPCEndpointID:      "vpce-12345",
+					HostedZoneID:       "SHZ12345",
+				}),
+				testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+					Type:    hivev1.AWSPrivateLinkReadyClusterDeploymentCondition,
+					Status:  corev1.ConditionTrue,
+					Reason:  "PrivateLinkAccessReady",
+					Message: "private link access is ready for use",
+				}),
+			),
+		},
+		inventory:        validInventory,
+		sharedHostedZone: &hivev1.AWSPrivateLinkSharedHostedZone{HostedZoneID: "SHZ12345"},
+		configureAWSClient: func(m *mock.MockClient) {
+			apiRecord := &route53.ResourceRecordSet{
+				Type: aws.String("A"),
+				Name: aws.String("api.test-cluster."),
+			}
+			m.EXPECT().ListResourceRecordSets(&route53.ListResourceRecordSetsInput{
+				HostedZoneId:    aws.String("SHZ12345"),
+				MaxItems:        aws.String("1"),
+				StartRecordName: aws.String("api.test-cluster"),
+				StartRecordType: aws.String("A"),
+			}).Return(&route53.ListResourceRecordSetsOutput{
+				ResourceRecordSets: []*route53.ResourceRecordSet{apiRecord},
+			}, nil)
+			m.EXPECT().ChangeResourceRecordSets(&route53.ChangeResourceRecordSetsInput{
+				HostedZoneId: aws.String("SHZ12345"),
+				ChangeBatch: &route53.ChangeBatch{
+					Changes: []*route53.Change{{
+						Action:            aws.String("DELETE"),
+						ResourceRecordSet: apiRecord,
+					}},
+				},
+			}).Return(nil, nil)
+			// the internal API record was already deleted, so the next record is returned
+			m.EXPECT().ListResourceRecordSets(&route53.ListResourceRecordSetsInput{
+				HostedZoneId:    aws.String("SHZ12345"),
+				MaxItems:        aws.String("1"),
+				StartRecordName: aws.String("api-int.test-cluster"),
+				StartRecordType: aws.String("A"),
+			}).Return(&route53.ListResourceRecordSetsOutput{
+				ResourceRecordSets: []*route53.ResourceRecordSet{{
+					Type: aws.String("A"),
+					Name: aws.String("api.other-cluster."),
+				}},
+			}, nil)
+
+			endpoint := &ec2.VpcEndpoint{
+				VpcEndpointId: aws.String("vpce-12345"),
+				VpcId:         aws.String("vpc-1"),
+			}
+			m.EXPECT().DescribeVpcEndpoints(gomock.Any()).
+				Return(&ec2.DescribeVpcEndpointsOutput{
+					VpcEndpoints: []*ec2.VpcEndpoint{endpoint},
+				}, nil).Times(2)
+			m.EXPECT().ListHostedZonesByVPC(&route53.ListHostedZonesByVPCInput{
+				MaxItems:  aws.String("100"),
+				VPCId:     endpoint.VpcId,
+				VPCRegion: aws.String("us-east-1"),
+			}).Return(&route53.ListHostedZonesByVPCOutput{}, nil)
+			m.EXPECT().DeleteVpcEndpoints(&ec2.DeleteVpcEndpointsInput{
+				VpcEndpointIds: aws.StringSlice([]string{*endpoint.VpcEndpointId}),
+			}).Return(nil, nil)
+
+			m.EXPECT().DescribeVpcEndpointServiceConfigurations(gomock.Any()).
+				Return(&ec2.DescribeVpcEndpointServiceConfigurationsOutput{
+					ServiceConfigurations: []*ec2.ServiceConfiguration{{
+						ServiceId: aws.String("vpce-svc-12345"),
+					}},
+				}, nil)
+			m.EXPECT().DeleteVpcEndpointServiceConfigurations(&ec2.DeleteVpcEndpointServiceConfigurationsInput{
+				ServiceIds: aws.StringSlice([]string{"vpce-svc-12345"}),
+			}).Return(nil, nil)
+		},
+
+		hasFinalizer: true,
+		expectedAnnotations: map[string]string{
+			lastCleanupAnnotationKey: "test-cd-1234",
+		},
+		expectedConditions: []hivev1.ClusterDeploymentCondition{{
+			Type:    hivev1.AWSPrivateLinkReadyClusterDeploymentCondition,
+			Status:  corev1.ConditionTrue,
+			Reason:  "PrivateLinkAccessReady",
+			Message: "private link access is ready for use",
+		}},
 	}}
 
 	for _, test := range cases {
@@ -1522,13 +2028,16 @@ users:
 			}
 
 			fakeClient := fake.NewFakeClientWithScheme(scheme, test.existing...)
+			fakeRecorder := record.NewFakeRecorder(10)
 			log.SetLevel(log.DebugLevel)
 			reconciler := &ReconcileAWSPrivateLink{
 				Client: fakeClient,
 				controllerconfig: &hivev1.AWSPrivateLinkConfig{
 					EndpointVPCInventory: test.inventory,
 					AssociatedVPCs:       test.associate,
+					SharedHostedZone:     test.sharedHostedZone,
 				},
+				eventRecorder: fakeRecorder,
 
 				awsClientFn: func(_ client.Client, _, _, _ string) (awsclient.Client, error) {
 					return mockedAWSClient, nil
@@ -1567,6 +2076,13 @@ users:
 				cd.Status.Platform = &hivev1.PlatformStatus{AWS: &hivev1aws.PlatformStatus{}}
 			}
 			assert.Equal(t, test.expectedStatus, cd.Status.Platform.AWS.PrivateLink)
+
+			close(fakeRecorder.Events)
+			var events []string
+			for event := range fakeRecorder.Events {
+				events = append(events, event)
+			}
+			assert.Equal(t, test.expectedEvents, events)
 		})
 	}
 }
@@ -1755,6 +2271,67 @@ func Test_shouldSync(t *testing.T) {
 
 		shouldSync:           false,
 		deltaGreaterThanZero: true,
+	}, {
+		name: "ready for less than 2 hours, additional allowed principals changed",
+
+		desired: cdBuilder.Build(
+			testcd.WithAWSPlatform(&hivev1aws.Platform{Region: "us-east-1",
+				PrivateLink: &hivev1aws.PrivateLinkAccess{
+					Enabled:                     true,
+					AdditionalAllowedPrincipals: []string{"aws:iam:67890:customer-role"},
+				}}),
+			testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+				Type:               hivev1.AWSPrivateLinkReadyClusterDeploymentCondition,
+				Status:             corev1.ConditionTrue,
+				LastTransitionTime: metav1.Time{Time: time.Now().Add(-1 * time.Hour)},
+			}),
+		),
+
+		shouldSync:           true,
+		deltaGreaterThanZero: true,
+	}, {
+		name: "ready for less than 2 hours, additional allowed principals unchanged",
+
+		desired: cdBuilder.Build(
+			testcd.WithAWSPlatform(&hivev1aws.Platform{Region: "us-east-1",
+				PrivateLink: &hivev1aws.PrivateLinkAccess{
+					Enabled:                     true,
+					AdditionalAllowedPrincipals: []string{"aws:iam:67890:customer-role"},
+				}}),
+			testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+				Type:               hivev1.AWSPrivateLinkReadyClusterDeploymentCondition,
+				Status:             corev1.ConditionTrue,
+				LastTransitionTime: metav1.Time{Time: time.Now().Add(-1 * time.Hour)},
+			}),
+			func(cd *hivev1.ClusterDeployment) {
+				cd.Status.Platform = &hivev1.PlatformStatus{AWS: &hivev1aws.PlatformStatus{
+					PrivateLink: &hivev1aws.PrivateLinkAccessStatus{
+						AdditionalAllowedPrincipals: []string{"aws:iam:67890:customer-role"},
+					},
+				}}
+			},
+		),
+
+		shouldSync:           false,
+		deltaGreaterThanZero: true,
+	}, {
+		name: "ready for less than 2 hours, endpoint acceptance required",
+
+		desired: cdBuilder.Build(
+			testcd.WithAWSPlatform(&hivev1aws.Platform{Region: "us-east-1",
+				PrivateLink: &hivev1aws.PrivateLinkAccess{
+					Enabled:            true,
+					EndpointAcceptance: &hivev1aws.PrivateLinkEndpointAcceptance{AcceptanceRequired: true},
+				}}),
+			testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+				Type:               hivev1.AWSPrivateLinkReadyClusterDeploymentCondition,
+				Status:             corev1.ConditionTrue,
+				LastTransitionTime: metav1.Time{Time: time.Now().Add(-1 * time.Hour)},
+			}),
+		),
+
+		shouldSync:           true,
+		deltaGreaterThanZero: true,
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
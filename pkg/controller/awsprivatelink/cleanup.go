@@ -2,6 +2,10 @@ package awsprivatelink
 
 import (
 	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
@@ -10,6 +14,8 @@ import (
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/route53"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
@@ -39,12 +45,63 @@ func (r *ReconcileAWSPrivateLink) cleanupClusterDeployment(cd *hivev1.ClusterDep
 			logger.WithError(err).Error("failed to update condition on cluster deployment")
 			return reconcile.Result{}, err
 		}
+		if cleanupTimedOut(cd) {
+			if err := r.setDeprovisionCleanupCondition(cd, corev1.ConditionTrue,
+				"ManualCleanupRequired",
+				fmt.Sprintf("failed to clean up the resources created for private link access: %v", err),
+				logger); err != nil {
+				logger.WithError(err).Error("failed to update condition on cluster deprovision")
+				return reconcile.Result{}, err
+			}
+		}
+		return reconcile.Result{}, err
+	}
+
+	// Verify that the resources are gone, as some of them are deleted asynchronously by AWS.
+	leaked, err := r.findLeakedResources(cd, metadata, logger)
+	if err != nil {
+		logger.WithError(err).Error("error verifying the cleanup of PrivateLink resources for ClusterDeployment")
+
+		if err := r.setErrCondition(cd, "CleanupVerificationFailed", err, logger); err != nil {
+			logger.WithError(err).Error("failed to update condition on cluster deployment")
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{}, err
+	}
+	if len(leaked) > 0 {
+		retryAfter := cleanupRetryInterval(cd)
+		logger.WithField("resources", leaked.String()).WithField("retryAfter", retryAfter).
+			Info("PrivateLink resources remain after cleanup, will retry")
+		if cleanupTimedOut(cd) {
+			reportLeakedResources(cd, leaked)
+			if err := r.setDeprovisionCleanupCondition(cd, corev1.ConditionTrue,
+				"ManualCleanupRequired",
+				fmt.Sprintf("the following resources created for private link access remain after cleanup: %s", leaked),
+				logger); err != nil {
+				logger.WithError(err).Error("failed to update condition on cluster deprovision")
+				return reconcile.Result{}, err
+			}
+		}
+		return reconcile.Result{RequeueAfter: retryAfter}, nil
+	}
+
+	clearLeakedResources(cd)
+	if err := r.setDeprovisionCleanupCondition(cd, corev1.ConditionFalse,
+		"CleanupCompleted",
+		"the resources created for private link access have been cleaned up",
+		logger); err != nil {
+		logger.WithError(err).Error("failed to update condition on cluster deprovision")
 		return reconcile.Result{}, err
 	}
 
 	logger.Info("removing finalizer from ClusterDeployment")
-	controllerutils.DeleteFinalizer(cd, finalizer)
-	if err := r.Update(context.Background(), cd); err != nil {
+	curr := &hivev1.ClusterDeployment{}
+	if err := r.Get(context.TODO(), types.NamespacedName{Namespace: cd.Namespace, Name: cd.Name}, curr); err != nil {
+		logger.WithError(err).Error("could not get ClusterDeployment")
+		return reconcile.Result{}, err
+	}
+	controllerutils.DeleteFinalizer(curr, finalizer)
+	if err := r.Update(context.Background(), curr); err != nil {
 		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not remove finalizer from ClusterDeployment")
 		return reconcile.Result{}, err
 	}
@@ -52,6 +109,171 @@ func (r *ReconcileAWSPrivateLink) cleanupClusterDeployment(cd *hivev1.ClusterDep
 	return reconcile.Result{}, nil
 }
 
+// cleanupTimedOut returns true when the cleanup for a deleted cluster has been retried for longer than the cleanup
+// timeout.
+func cleanupTimedOut(cd *hivev1.ClusterDeployment) bool {
+	return cd.DeletionTimestamp != nil && time.Since(cd.DeletionTimestamp.Time) >= cleanupTimeout
+}
+
+// cleanupRetryInterval returns the interval after which the cleanup for a cluster is retried. The interval grows with
+// the time since the cluster was deleted, so that the retries back off exponentially.
+func cleanupRetryInterval(cd *hivev1.ClusterDeployment) time.Duration {
+	if cd.DeletionTimestamp == nil {
+		return defaultRequeueLater
+	}
+	interval := time.Since(cd.DeletionTimestamp.Time)
+	if interval < defaultRequeueLater {
+		return defaultRequeueLater
+	}
+	if interval > maxCleanupRetryInterval {
+		return maxCleanupRetryInterval
+	}
+	return interval
+}
+
+// setDeprovisionCleanupCondition sets the AWSPrivateLinkCleanupFailed condition on the ClusterDeprovision of a deleted
+// cluster.
+func (r *ReconcileAWSPrivateLink) setDeprovisionCleanupCondition(cd *hivev1.ClusterDeployment,
+	status corev1.ConditionStatus,
+	reason string, message string,
+	logger log.FieldLogger) error {
+	if cd.DeletionTimestamp == nil {
+		return nil
+	}
+
+	curr := &hivev1.ClusterDeprovision{}
+	err := r.Get(context.TODO(), types.NamespacedName{Namespace: cd.Namespace, Name: cd.Name}, curr)
+	if apierrors.IsNotFound(err) {
+		logger.Debug("no ClusterDeprovision found for the ClusterDeployment")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	conditions, changed := controllerutils.SetClusterDeprovisionConditionWithChangeCheck(
+		curr.Status.Conditions,
+		hivev1.AWSPrivateLinkCleanupFailedClusterDeprovisionCondition,
+		status,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange)
+	if !changed {
+		return nil
+	}
+	curr.Status.Conditions = conditions
+	logger.Debugf("setting AWSPrivateLinkCleanupFailedClusterDeprovisionCondition to %s", status)
+	return r.Status().Update(context.TODO(), curr)
+}
+
+const (
+	leakedVPCEndpoint            = "vpc_endpoint"
+	leakedVPCEndpointService     = "vpc_endpoint_service"
+	leakedHostedZone             = "hosted_zone"
+	leakedSharedHostedZoneRecord = "shared_hosted_zone_record"
+)
+
+var leakedResourceTypes = []string{leakedVPCEndpoint, leakedVPCEndpointService, leakedHostedZone, leakedSharedHostedZoneRecord}
+
+// leakedResources maps the types of the resources created for private link access to the resources of each type that
+// remain after cleanup.
+type leakedResources map[string][]string
+
+func (l leakedResources) String() string {
+	resourceTypes := make([]string, 0, len(l))
+	for t := range l {
+		resourceTypes = append(resourceTypes, t)
+	}
+	sort.Strings(resourceTypes)
+	parts := make([]string, 0, len(resourceTypes))
+	for _, t := range resourceTypes {
+		parts = append(parts, fmt.Sprintf("%s %s", t, strings.Join(l[t], ", ")))
+	}
+	return strings.Join(parts, "; ")
+}
+
+func reportLeakedResources(cd *hivev1.ClusterDeployment, leaked leakedResources) {
+	for _, t := range leakedResourceTypes {
+		metricLeakedResources.WithLabelValues(cd.Name, cd.Namespace, t).Set(float64(len(leaked[t])))
+	}
+}
+
+func clearLeakedResources(cd *hivev1.ClusterDeployment) {
+	for _, t := range leakedResourceTypes {
+		metricLeakedResources.DeleteLabelValues(cd.Name, cd.Namespace, t)
+	}
+}
+
+// findLeakedResources returns the resources created for private link access to the cluster that still exist.
+// Resources that AWS is still deleting are reported as well.
+func (r *ReconcileAWSPrivateLink) findLeakedResources(cd *hivev1.ClusterDeployment, metadata *hivev1.ClusterMetadata,
+	logger log.FieldLogger) (leakedResources, error) {
+	awsClient, err := newAWSClient(r,
+		corev1.SecretReference{Name: cd.Spec.Platform.AWS.CredentialsSecretRef.Name, Namespace: cd.Namespace},
+		cd.Spec.Platform.AWS.Region)
+	if err != nil {
+		logger.WithError(err).Error("error creating AWS client for the cluster")
+		return nil, err
+	}
+	apiDomain, err := initialURL(r.Client,
+		client.ObjectKey{Namespace: cd.Namespace, Name: metadata.AdminKubeconfigSecretRef.Name})
+	if err != nil {
+		logger.WithError(err).Error("could not get API URL from kubeconfig")
+		return nil, err
+	}
+
+	leaked := leakedResources{}
+	idLog := logger.WithField("infraID", metadata.InfraID)
+	endpointResp, err := awsClient.hub.DescribeVpcEndpoints(&ec2.DescribeVpcEndpointsInput{
+		Filters: []*ec2.Filter{ec2FilterForCluster(metadata)},
+	})
+	if err != nil {
+		idLog.WithError(err).Error("error getting the VPC Endpoint")
+		return nil, err
+	}
+	for _, endpoint := range endpointResp.VpcEndpoints {
+		if !strings.EqualFold(aws.StringValue(endpoint.State), "deleted") {
+			leaked[leakedVPCEndpoint] = append(leaked[leakedVPCEndpoint], aws.StringValue(endpoint.VpcEndpointId))
+		}
+		// The Private Hosted Zone is found through the VPC of the endpoint, which is still listed for a while after
+		// the endpoint is deleted.
+		hzID, err := findHostedZone(awsClient.hub, aws.StringValue(endpoint.VpcId), cd.Spec.Platform.AWS.Region, apiDomain, logger)
+		if err != nil && !errors.Is(err, errNoHostedZoneFoundForVPC) {
+			idLog.WithError(err).Error("error getting the Hosted Zone")
+			return nil, err
+		}
+		if err == nil {
+			leaked[leakedHostedZone] = append(leaked[leakedHostedZone], hzID)
+		}
+	}
+
+	serviceResp, err := awsClient.user.DescribeVpcEndpointServiceConfigurations(&ec2.DescribeVpcEndpointServiceConfigurationsInput{
+		Filters: []*ec2.Filter{ec2FilterForCluster(metadata)},
+	})
+	if err != nil {
+		idLog.WithError(err).Error("error getting the VPC Endpoint Service")
+		return nil, err
+	}
+	for _, service := range serviceResp.ServiceConfigurations {
+		if aws.StringValue(service.ServiceState) != ec2.ServiceStateDeleted {
+			leaked[leakedVPCEndpointService] = append(leaked[leakedVPCEndpointService], aws.StringValue(service.ServiceId))
+		}
+	}
+
+	if r.controllerconfig.SharedHostedZone != nil {
+		records, err := r.sharedHostedZoneRecords(awsClient, cd, apiDomain, logger)
+		if err != nil {
+			return nil, err
+		}
+		for _, record := range records {
+			leaked[leakedSharedHostedZoneRecord] = append(leaked[leakedSharedHostedZoneRecord],
+				strings.TrimSuffix(aws.StringValue(record.Name), "."))
+		}
+	}
+
+	return leaked, nil
+}
+
 func (r *ReconcileAWSPrivateLink) cleanupPreviousProvisionAttempt(cd *hivev1.ClusterDeployment, cp *hivev1.ClusterProvision,
 	logger log.FieldLogger) error {
 	if cd.Spec.ClusterMetadata == nil {
@@ -93,6 +315,12 @@ func (r *ReconcileAWSPrivateLink) cleanupPrivateLink(cd *hivev1.ClusterDeploymen
 		return err
 	}
 
+	if r.controllerconfig.SharedHostedZone != nil {
+		if err := r.cleanupSharedHostedZone(awsClient, cd, metadata, logger); err != nil {
+			logger.WithError(err).Error("error cleaning up records in the shared Hosted Zone")
+			return err
+		}
+	}
 	if err := r.cleanupHostedZone(awsClient.hub, cd, metadata, logger); err != nil {
 		logger.WithError(err).Error("error cleaning up Hosted Zone")
 		return err
@@ -188,6 +416,95 @@ func (r *ReconcileAWSPrivateLink) cleanupHostedZone(awsClient awsclient.Client,
 
 }
 
+// cleanupSharedHostedZone deletes the records published for the cluster in the shared Private Hosted Zone.
+func (r *ReconcileAWSPrivateLink) cleanupSharedHostedZone(awsClient *awsClient,
+	cd *hivev1.ClusterDeployment, metadata *hivev1.ClusterMetadata,
+	logger log.FieldLogger) error {
+	apiDomain, err := initialURL(r.Client,
+		client.ObjectKey{Namespace: cd.Namespace, Name: metadata.AdminKubeconfigSecretRef.Name})
+	if err != nil {
+		logger.WithError(err).Error("could not get API URL from kubeconfig")
+		return err
+	}
+
+	hostedZoneID := r.controllerconfig.SharedHostedZone.HostedZoneID
+	hzLog := logger.WithField("hostedZoneID", hostedZoneID)
+	sharedClient, err := r.sharedHostedZoneClient(awsClient, cd.Spec.Platform.AWS.Region)
+	if err != nil {
+		hzLog.WithError(err).Error("failed to create AWS client for the shared Hosted Zone")
+		return err
+	}
+
+	records, err := findSharedHostedZoneRecords(sharedClient, hostedZoneID, apiDomain)
+	if err != nil {
+		hzLog.WithError(err).Error("failed to list the shared hosted zone")
+		return err
+	}
+	for _, record := range records {
+		_, err = sharedClient.ChangeResourceRecordSets(&route53.ChangeResourceRecordSetsInput{
+			HostedZoneId: aws.String(hostedZoneID),
+			ChangeBatch: &route53.ChangeBatch{
+				Changes: []*route53.Change{{
+					Action:            aws.String("DELETE"),
+					ResourceRecordSet: record,
+				}},
+			},
+		})
+		if err != nil {
+			hzLog.WithField("record", aws.StringValue(record.Name)).WithError(err).Error("failed to delete the record from the shared hosted zone")
+			return err
+		}
+	}
+
+	return nil
+}
+
+// sharedHostedZoneRecords returns the records published for the cluster in the shared Private Hosted Zone.
+func (r *ReconcileAWSPrivateLink) sharedHostedZoneRecords(awsClient *awsClient,
+	cd *hivev1.ClusterDeployment, apiDomain string,
+	logger log.FieldLogger) ([]*route53.ResourceRecordSet, error) {
+	hostedZoneID := r.controllerconfig.SharedHostedZone.HostedZoneID
+	hzLog := logger.WithField("hostedZoneID", hostedZoneID)
+	sharedClient, err := r.sharedHostedZoneClient(awsClient, cd.Spec.Platform.AWS.Region)
+	if err != nil {
+		hzLog.WithError(err).Error("failed to create AWS client for the shared Hosted Zone")
+		return nil, err
+	}
+	records, err := findSharedHostedZoneRecords(sharedClient, hostedZoneID, apiDomain)
+	if err != nil {
+		hzLog.WithError(err).Error("failed to list the shared hosted zone")
+		return nil, err
+	}
+	return records, nil
+}
+
+// findSharedHostedZoneRecords returns the A records for the API domains of the cluster in the shared Private Hosted
+// Zone.
+func findSharedHostedZoneRecords(sharedClient awsclient.Client, hostedZoneID, apiDomain string) ([]*route53.ResourceRecordSet, error) {
+	var records []*route53.ResourceRecordSet
+	for _, domain := range apiDomains(apiDomain) {
+		recordsResp, err := sharedClient.ListResourceRecordSets(&route53.ListResourceRecordSetsInput{
+			HostedZoneId:    aws.String(hostedZoneID),
+			StartRecordName: aws.String(domain),
+			StartRecordType: aws.String("A"),
+			MaxItems:        aws.String("1"),
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(recordsResp.ResourceRecordSets) == 0 {
+			continue
+		}
+		record := recordsResp.ResourceRecordSets[0]
+		if !strings.EqualFold(domain, strings.TrimSuffix(aws.StringValue(record.Name), ".")) ||
+			aws.StringValue(record.Type) != "A" {
+			continue
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
 func (r *ReconcileAWSPrivateLink) cleanupVPCEndpoint(awsClient awsclient.Client,
 	cd *hivev1.ClusterDeployment, metadata *hivev1.ClusterMetadata,
 	logger log.FieldLogger) error {
@@ -0,0 +1,253 @@
+package awsprivatelink
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/golang/mock/gomock"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
+	"github.com/openshift/hive/pkg/awsclient"
+	"github.com/openshift/hive/pkg/awsclient/mock"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+	testcdp "github.com/openshift/hive/pkg/test/clusterdeprovision"
+	"github.com/openshift/hive/pkg/test/generic"
+)
+
+func TestCleanupVerification(t *testing.T) {
+	scheme := runtime.NewScheme()
+	hivev1.AddToScheme(scheme)
+	corev1.AddToScheme(scheme)
+
+	key := client.ObjectKey{Name: "test-cd", Namespace: testNS}
+	deletedAt := func(ago time.Duration) generic.Option {
+		return func(meta hivev1.MetaRuntimeObject) {
+			deleted := metav1.NewTime(time.Now().Add(-ago))
+			meta.SetDeletionTimestamp(&deleted)
+		}
+	}
+	deletedCD := func(ago time.Duration) *hivev1.ClusterDeployment {
+		return testcd.FullBuilder(testNS, "test-cd", scheme).
+			GenericOptions(generic.WithFinalizer(finalizer), deletedAt(ago)).
+			Build(
+				testcd.WithAWSPlatform(&hivev1aws.Platform{
+					Region:               "us-east-1",
+					CredentialsSecretRef: corev1.LocalObjectReference{Name: "aws-creds"},
+					PrivateLink:          &hivev1aws.PrivateLinkAccess{Enabled: true},
+				}),
+				withClusterMetadata("test-cd-1234", "test-cd-kubeconfig"),
+			)
+	}
+	kubeconfigSecret := testSecret("test-cd-kubeconfig", map[string]string{
+		"kubeconfig": `apiVersion: v1
+clusters:
+- cluster:
+    server: https://api.test-cluster:6443
+  name: test-cluster
+contexts:
+- context:
+    cluster: test-cluster
+    user: admin
+  name: admin
+current-context: admin
+kind: Config
+users:
+- name: admin`,
+	})
+	cdpBuilder := testcdp.FullBuilder(testNS, "test-cd", scheme)
+	withCleanupFailedCondition := func(cdp *hivev1.ClusterDeprovision) {
+		cdp.Status.Conditions = []hivev1.ClusterDeprovisionCondition{{
+			Type:    hivev1.AWSPrivateLinkCleanupFailedClusterDeprovisionCondition,
+			Status:  corev1.ConditionTrue,
+			Reason:  "ManualCleanupRequired",
+			Message: "the following resources created for private link access remain after cleanup: vpc_endpoint_service vpce-svc-12345",
+		}}
+	}
+
+	mockEndpoints := func(m *mock.MockClient, endpoints ...*ec2.VpcEndpoint) {
+		m.EXPECT().DescribeVpcEndpoints(gomock.Any()).
+			Return(&ec2.DescribeVpcEndpointsOutput{VpcEndpoints: endpoints}, nil).AnyTimes()
+		if len(endpoints) > 0 {
+			m.EXPECT().ListHostedZonesByVPC(gomock.Any()).
+				Return(&route53.ListHostedZonesByVPCOutput{}, nil).AnyTimes()
+			m.EXPECT().DeleteVpcEndpoints(gomock.Any()).Return(&ec2.DeleteVpcEndpointsOutput{}, nil)
+		}
+	}
+	mockServices := func(m *mock.MockClient, services ...*ec2.ServiceConfiguration) {
+		m.EXPECT().DescribeVpcEndpointServiceConfigurations(gomock.Any()).
+			Return(&ec2.DescribeVpcEndpointServiceConfigurationsOutput{ServiceConfigurations: services}, nil).AnyTimes()
+		if len(services) > 0 {
+			m.EXPECT().DeleteVpcEndpointServiceConfigurations(gomock.Any()).
+				Return(&ec2.DeleteVpcEndpointServiceConfigurationsOutput{}, nil)
+		}
+	}
+
+	cases := []struct {
+		name string
+
+		cd                 *hivev1.ClusterDeployment
+		cdp                *hivev1.ClusterDeprovision
+		configureAWSClient func(*mock.MockClient)
+
+		expectedRequeueAfter time.Duration
+		expectFinalizer      bool
+		expectedCondition    *hivev1.ClusterDeprovisionCondition
+		expectedLeaked       map[string]float64
+	}{{
+		name: "all resources removed",
+
+		cd:  deletedCD(5 * time.Minute),
+		cdp: cdpBuilder.Build(),
+		configureAWSClient: func(m *mock.MockClient) {
+			mockEndpoints(m)
+			mockServices(m)
+		},
+	}, {
+		name: "all resources removed after manual cleanup",
+
+		cd:  deletedCD(3 * time.Hour),
+		cdp: cdpBuilder.Build(withCleanupFailedCondition),
+		configureAWSClient: func(m *mock.MockClient) {
+			mockEndpoints(m, &ec2.VpcEndpoint{
+				VpcEndpointId: aws.String("vpce-12345"),
+				VpcId:         aws.String("vpc-1"),
+				State:         aws.String("deleted"),
+			})
+			mockServices(m, &ec2.ServiceConfiguration{
+				ServiceId:    aws.String("vpce-svc-12345"),
+				ServiceState: aws.String(ec2.ServiceStateDeleted),
+			})
+		},
+
+		expectedCondition: &hivev1.ClusterDeprovisionCondition{
+			Type:    hivev1.AWSPrivateLinkCleanupFailedClusterDeprovisionCondition,
+			Status:  corev1.ConditionFalse,
+			Reason:  "CleanupCompleted",
+			Message: "the resources created for private link access have been cleaned up",
+		},
+	}, {
+		name: "endpoint still being deleted",
+
+		cd:  deletedCD(5 * time.Minute),
+		cdp: cdpBuilder.Build(),
+		configureAWSClient: func(m *mock.MockClient) {
+			mockEndpoints(m, &ec2.VpcEndpoint{
+				VpcEndpointId: aws.String("vpce-12345"),
+				VpcId:         aws.String("vpc-1"),
+				State:         aws.String("deleting"),
+			})
+			mockServices(m)
+		},
+
+		expectedRequeueAfter: 5 * time.Minute,
+		expectFinalizer:      true,
+	}, {
+		name: "endpoint still being deleted, recently deleted cluster",
+
+		cd:  deletedCD(10 * time.Second),
+		cdp: cdpBuilder.Build(),
+		configureAWSClient: func(m *mock.MockClient) {
+			mockEndpoints(m, &ec2.VpcEndpoint{
+				VpcEndpointId: aws.String("vpce-12345"),
+				VpcId:         aws.String("vpc-1"),
+				State:         aws.String("deleting"),
+			})
+			mockServices(m)
+		},
+
+		expectedRequeueAfter: defaultRequeueLater,
+		expectFinalizer:      true,
+	}, {
+		name: "endpoint service remains after timeout",
+
+		cd:  deletedCD(3 * time.Hour),
+		cdp: cdpBuilder.Build(),
+		configureAWSClient: func(m *mock.MockClient) {
+			mockEndpoints(m)
+			mockServices(m, &ec2.ServiceConfiguration{
+				ServiceId:    aws.String("vpce-svc-12345"),
+				ServiceState: aws.String(ec2.ServiceStateAvailable),
+			})
+		},
+
+		expectedRequeueAfter: maxCleanupRetryInterval,
+		expectFinalizer:      true,
+		expectedCondition: &hivev1.ClusterDeprovisionCondition{
+			Type:    hivev1.AWSPrivateLinkCleanupFailedClusterDeprovisionCondition,
+			Status:  corev1.ConditionTrue,
+			Reason:  "ManualCleanupRequired",
+			Message: "the following resources created for private link access remain after cleanup: vpc_endpoint_service vpce-svc-12345",
+		},
+		expectedLeaked: map[string]float64{
+			leakedVPCEndpoint:            0,
+			leakedVPCEndpointService:     1,
+			leakedHostedZone:             0,
+			leakedSharedHostedZoneRecord: 0,
+		},
+	}}
+
+	for _, test := range cases {
+		t.Run(test.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			mockedAWSClient := mock.NewMockClient(mockCtrl)
+			test.configureAWSClient(mockedAWSClient)
+			defer clearLeakedResources(test.cd)
+
+			fakeClient := fake.NewFakeClientWithScheme(scheme, test.cd, test.cdp, kubeconfigSecret)
+			log.SetLevel(log.DebugLevel)
+			reconciler := &ReconcileAWSPrivateLink{
+				Client: fakeClient,
+				controllerconfig: &hivev1.AWSPrivateLinkConfig{
+					EndpointVPCInventory: []hivev1.AWSPrivateLinkInventory{{
+						AWSPrivateLinkVPC: hivev1.AWSPrivateLinkVPC{Region: "us-east-1", VPCID: "vpc-1"},
+					}},
+				},
+				awsClientFn: func(_ client.Client, _, _, _ string) (awsclient.Client, error) {
+					return mockedAWSClient, nil
+				},
+			}
+
+			result, err := reconciler.Reconcile(reconcile.Request{NamespacedName: key})
+			require.NoError(t, err, "unexpected error from Reconcile")
+			assert.InDelta(t, test.expectedRequeueAfter, result.RequeueAfter, float64(time.Second), "unexpected requeue")
+
+			cd := &hivev1.ClusterDeployment{}
+			require.NoError(t, fakeClient.Get(context.TODO(), key, cd))
+			assert.Equal(t, test.expectFinalizer, sets.NewString(cd.Finalizers...).Has(finalizer), "unexpected finalizer")
+
+			cdp := &hivev1.ClusterDeprovision{}
+			require.NoError(t, fakeClient.Get(context.TODO(), key, cdp))
+			cond := controllerutils.FindClusterDeprovisionCondition(cdp.Status.Conditions,
+				hivev1.AWSPrivateLinkCleanupFailedClusterDeprovisionCondition)
+			if cond != nil {
+				cond.LastProbeTime = metav1.Time{}
+				cond.LastTransitionTime = metav1.Time{}
+			}
+			assert.Equal(t, test.expectedCondition, cond)
+
+			for _, resource := range leakedResourceTypes {
+				gauge, err := metricLeakedResources.GetMetricWithLabelValues(cd.Name, cd.Namespace, resource)
+				require.NoError(t, err)
+				assert.Equal(t, test.expectedLeaked[resource], testutil.ToFloat64(gauge), "unexpected leaked %s", resource)
+			}
+		})
+	}
+}
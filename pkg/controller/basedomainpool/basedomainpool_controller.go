@@ -0,0 +1,229 @@
+package basedomainpool
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/client-go/util/workqueue"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	ControllerName = hivev1.BaseDomainPoolControllerName
+
+	// subdomainLength is the length of the random label of allocated subdomains.
+	subdomainLength = 8
+)
+
+// Add creates a new BaseDomainPool controller and adds it to the manager with default RBAC.
+func Add(mgr manager.Manager) error {
+	logger := log.WithField("controller", ControllerName)
+	concurrentReconciles, clientRateLimiter, queueRateLimiter, err := controllerutils.GetControllerConfig(mgr.GetClient(), ControllerName)
+	if err != nil {
+		logger.WithError(err).Error("could not get controller configurations")
+		return err
+	}
+	return AddToManager(mgr, NewReconciler(mgr, clientRateLimiter), concurrentReconciles, queueRateLimiter)
+}
+
+// NewReconciler returns a new reconcile.Reconciler
+func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) reconcile.Reconciler {
+	return &ReconcileBaseDomainPool{
+		Client: controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
+		logger: log.WithField("controller", ControllerName),
+	}
+}
+
+// AddToManager adds a new Controller to mgr with r as the reconcile.Reconciler
+func AddToManager(mgr manager.Manager, r reconcile.Reconciler, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
+	c, err := controller.New("basedomainpool-controller", mgr, controller.Options{
+		Reconciler:              r,
+		MaxConcurrentReconciles: concurrentReconciles,
+		RateLimiter:             rateLimiter,
+	})
+	if err != nil {
+		log.WithField("controller", ControllerName).WithError(err).Error("Error creating new basedomainpool controller")
+		return err
+	}
+
+	// Watch for changes to BaseDomainPool
+	if err := c.Watch(&source.Kind{Type: &hivev1.BaseDomainPool{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		log.WithField("controller", ControllerName).WithError(err).Error("Error watching base domain pool")
+		return err
+	}
+
+	// Watch for changes to the ClusterDeployments allocating base domains from a pool
+	if err := c.Watch(&source.Kind{Type: &hivev1.ClusterDeployment{}}, &handler.EnqueueRequestsFromMapFunc{
+		ToRequests: handler.ToRequestsFunc(requestsForClusterDeployment),
+	}); err != nil {
+		log.WithField("controller", ControllerName).WithError(err).Error("Error watching cluster deployments")
+		return err
+	}
+	return nil
+}
+
+func requestsForClusterDeployment(o handler.MapObject) []reconcile.Request {
+	cd, ok := o.Object.(*hivev1.ClusterDeployment)
+	if !ok || cd.Spec.BaseDomainPoolRef == nil {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: cd.Spec.BaseDomainPoolRef.Name}}}
+}
+
+var _ reconcile.Reconciler = &ReconcileBaseDomainPool{}
+
+// ReconcileBaseDomainPool reconciles a BaseDomainPool, allocating base domains to the ClusterDeployments which
+// reference it and releasing the allocations of ClusterDeployments which are gone.
+type ReconcileBaseDomainPool struct {
+	client.Client
+	logger log.FieldLogger
+}
+
+// Reconcile allocates and releases the base domains of a BaseDomainPool.
+func (r *ReconcileBaseDomainPool) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	logger := controllerutils.BuildControllerLogger(ControllerName, "baseDomainPool", request.NamespacedName)
+	logger.Info("reconciling base domain pool")
+	recobsrv := hivemetrics.NewReconcileObserver(ControllerName, logger)
+	defer recobsrv.ObserveControllerReconcileTime()
+
+	pool := &hivev1.BaseDomainPool{}
+	if err := r.Get(context.TODO(), request.NamespacedName, pool); err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Debug("base domain pool not found")
+			return reconcile.Result{}, nil
+		}
+		logger.WithError(err).Error("error getting base domain pool")
+		return reconcile.Result{}, err
+	}
+
+	cds := &hivev1.ClusterDeploymentList{}
+	if err := r.List(context.TODO(), cds); err != nil {
+		logger.WithError(err).Error("error listing cluster deployments")
+		return reconcile.Result{}, err
+	}
+	existing := sets.NewString()
+	var pending []*hivev1.ClusterDeployment
+	for i := range cds.Items {
+		cd := &cds.Items[i]
+		if cd.Spec.BaseDomainPoolRef == nil || cd.Spec.BaseDomainPoolRef.Name != pool.Name {
+			continue
+		}
+		existing.Insert(string(cd.UID))
+		if cd.Spec.BaseDomain == "" && cd.DeletionTimestamp == nil {
+			pending = append(pending, cd)
+		}
+	}
+
+	// Release the allocations of ClusterDeployments which are gone.
+	var allocations []hivev1.BaseDomainAllocation
+	for _, a := range pool.Status.Allocations {
+		if !existing.Has(a.ClusterDeploymentUID) {
+			logger.WithField("domain", a.Domain).
+				WithField("clusterDeployment", types.NamespacedName{Namespace: a.ClusterDeploymentNamespace, Name: a.ClusterDeploymentName}).
+				Info("releasing base domain")
+			continue
+		}
+		allocations = append(allocations, a)
+	}
+
+	// Allocate base domains to pending ClusterDeployments, reusing allocations made by earlier reconciles which
+	// failed to update the ClusterDeployment.
+	assigned := make(map[types.UID]string, len(pending))
+	for _, cd := range pending {
+		cdLog := logger.WithField("clusterDeployment", types.NamespacedName{Namespace: cd.Namespace, Name: cd.Name})
+		if domain := allocatedDomain(allocations, cd); domain != "" {
+			assigned[cd.UID] = domain
+			continue
+		}
+		if len(pool.Spec.Domains) == 0 {
+			cdLog.Warn("base domain pool has no domains to allocate from")
+			break
+		}
+		a := allocate(pool.Spec.Domains, allocations, cd)
+		cdLog.WithField("domain", a.Domain).Info("allocating base domain")
+		allocations = append(allocations, a)
+		assigned[cd.UID] = a.Domain
+	}
+
+	if !reflect.DeepEqual(allocations, pool.Status.Allocations) {
+		pool.Status.Allocations = allocations
+		if err := r.Status().Update(context.TODO(), pool); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "error updating base domain pool allocations")
+			return reconcile.Result{}, err
+		}
+	}
+
+	// The allocations are recorded, it is now safe to hand the base domains out.
+	for _, cd := range pending {
+		domain, ok := assigned[cd.UID]
+		if !ok {
+			continue
+		}
+		cd.Spec.BaseDomain = domain
+		if err := r.Update(context.TODO(), cd); err != nil {
+			logger.WithField("clusterDeployment", types.NamespacedName{Namespace: cd.Namespace, Name: cd.Name}).
+				WithError(err).Log(controllerutils.LogLevel(err), "error setting allocated base domain")
+			return reconcile.Result{}, err
+		}
+	}
+	return reconcile.Result{}, nil
+}
+
+// allocatedDomain returns the base domain already allocated to the ClusterDeployment, if any.
+func allocatedDomain(allocations []hivev1.BaseDomainAllocation, cd *hivev1.ClusterDeployment) string {
+	for _, a := range allocations {
+		if a.ClusterDeploymentUID == string(cd.UID) {
+			return a.Domain
+		}
+	}
+	return ""
+}
+
+// allocate allocates a unique subdomain of the parent domain with the fewest allocations to the ClusterDeployment.
+func allocate(domains []string, allocations []hivev1.BaseDomainAllocation, cd *hivev1.ClusterDeployment) hivev1.BaseDomainAllocation {
+	counts := make(map[string]int, len(domains))
+	used := sets.NewString()
+	for _, a := range allocations {
+		used.Insert(a.Domain)
+		for _, d := range domains {
+			if strings.HasSuffix(a.Domain, "."+d) {
+				counts[d]++
+			}
+		}
+	}
+	parent := domains[0]
+	for _, d := range domains[1:] {
+		if counts[d] < counts[parent] {
+			parent = d
+		}
+	}
+	domain := fmt.Sprintf("%s.%s", utilrand.String(subdomainLength), parent)
+	for used.Has(domain) {
+		domain = fmt.Sprintf("%s.%s", utilrand.String(subdomainLength), parent)
+	}
+	return hivev1.BaseDomainAllocation{
+		Domain:                     domain,
+		ClusterDeploymentNamespace: cd.Namespace,
+		ClusterDeploymentName:      cd.Name,
+		ClusterDeploymentUID:       string(cd.UID),
+	}
+}
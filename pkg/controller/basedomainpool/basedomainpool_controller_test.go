@@ -0,0 +1,191 @@
+package basedomainpool
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/openshift/hive/apis"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+const (
+	testPoolName  = "test-pool"
+	testNamespace = "test-namespace"
+)
+
+func TestBaseDomainPoolReconcile(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+	log.SetLevel(log.DebugLevel)
+
+	tests := []struct {
+		name                string
+		domains             []string
+		allocations         []hivev1.BaseDomainAllocation
+		existing            []runtime.Object
+		expectedAllocations map[string]string
+		expectedParents     map[string]string
+	}{
+		{
+			name:    "allocate base domain",
+			domains: []string{"a.example.com"},
+			existing: []runtime.Object{
+				testClusterDeployment("cd1", ""),
+			},
+			expectedParents: map[string]string{"cd1": "a.example.com"},
+		},
+		{
+			name:    "allocate from least used domain",
+			domains: []string{"a.example.com", "b.example.com"},
+			allocations: []hivev1.BaseDomainAllocation{
+				testAllocation("cd1", "abcd1234.a.example.com"),
+			},
+			existing: []runtime.Object{
+				testClusterDeployment("cd1", "abcd1234.a.example.com"),
+				testClusterDeployment("cd2", ""),
+			},
+			expectedAllocations: map[string]string{"cd1": "abcd1234.a.example.com"},
+			expectedParents:     map[string]string{"cd2": "b.example.com"},
+		},
+		{
+			name:    "reuse existing allocation",
+			domains: []string{"a.example.com"},
+			allocations: []hivev1.BaseDomainAllocation{
+				testAllocation("cd1", "abcd1234.a.example.com"),
+			},
+			existing: []runtime.Object{
+				testClusterDeployment("cd1", ""),
+			},
+			expectedAllocations: map[string]string{"cd1": "abcd1234.a.example.com"},
+		},
+		{
+			name:    "release allocation of deleted cluster",
+			domains: []string{"a.example.com"},
+			allocations: []hivev1.BaseDomainAllocation{
+				testAllocation("cd1", "abcd1234.a.example.com"),
+				testAllocation("cd2", "efgh5678.a.example.com"),
+			},
+			existing: []runtime.Object{
+				testClusterDeployment("cd2", "efgh5678.a.example.com"),
+			},
+			expectedAllocations: map[string]string{"cd2": "efgh5678.a.example.com"},
+		},
+		{
+			name:    "ignore clusters of other pools",
+			domains: []string{"a.example.com"},
+			existing: []runtime.Object{
+				func() *hivev1.ClusterDeployment {
+					cd := testClusterDeployment("cd1", "")
+					cd.Spec.BaseDomainPoolRef.Name = "other-pool"
+					return cd
+				}(),
+			},
+		},
+		{
+			name:    "no allocation for deleted cluster",
+			domains: []string{"a.example.com"},
+			existing: []runtime.Object{
+				func() *hivev1.ClusterDeployment {
+					cd := testClusterDeployment("cd1", "")
+					now := metav1.Now()
+					cd.DeletionTimestamp = &now
+					return cd
+				}(),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pool := &hivev1.BaseDomainPool{
+				ObjectMeta: metav1.ObjectMeta{Name: testPoolName},
+				Spec:       hivev1.BaseDomainPoolSpec{Domains: test.domains},
+				Status:     hivev1.BaseDomainPoolStatus{Allocations: test.allocations},
+			}
+			c := fake.NewFakeClientWithScheme(scheme.Scheme, append(test.existing, pool)...)
+			r := &ReconcileBaseDomainPool{
+				Client: c,
+				logger: log.WithField("controller", ControllerName),
+			}
+			_, err := r.Reconcile(reconcile.Request{NamespacedName: types.NamespacedName{Name: testPoolName}})
+			require.NoError(t, err, "unexpected error from reconcile")
+
+			require.NoError(t, c.Get(context.TODO(), types.NamespacedName{Name: testPoolName}, pool))
+			assert.Len(t, pool.Status.Allocations, len(test.expectedAllocations)+len(test.expectedParents), "unexpected number of allocations")
+			for _, a := range pool.Status.Allocations {
+				assert.Equal(t, testNamespace, a.ClusterDeploymentNamespace, "unexpected allocation namespace")
+				assert.Equal(t, a.ClusterDeploymentName+"-uid", a.ClusterDeploymentUID, "unexpected allocation UID")
+				cd := getClusterDeployment(t, c, a.ClusterDeploymentName)
+				assert.Equal(t, a.Domain, cd.Spec.BaseDomain, "unexpected base domain")
+				if domain, ok := test.expectedAllocations[a.ClusterDeploymentName]; ok {
+					assert.Equal(t, domain, a.Domain, "unexpected allocated domain")
+					continue
+				}
+				parent, ok := test.expectedParents[a.ClusterDeploymentName]
+				if assert.True(t, ok, "unexpected allocation for %s", a.ClusterDeploymentName) {
+					assert.True(t, strings.HasSuffix(a.Domain, "."+parent), "unexpected parent domain for %s", a.Domain)
+					assert.Len(t, strings.TrimSuffix(a.Domain, "."+parent), subdomainLength, "unexpected subdomain length")
+				}
+			}
+		})
+	}
+}
+
+func TestAllocateUnique(t *testing.T) {
+	domains := []string{"a.example.com", "b.example.com"}
+	var allocations []hivev1.BaseDomainAllocation
+	for i := 0; i < 50; i++ {
+		allocations = append(allocations, allocate(domains, allocations, testClusterDeployment("cd", "")))
+	}
+	seen := map[string]bool{}
+	counts := map[string]int{}
+	for _, a := range allocations {
+		assert.False(t, seen[a.Domain], "duplicate allocation %s", a.Domain)
+		seen[a.Domain] = true
+		counts[a.Domain[subdomainLength+1:]]++
+	}
+	assert.Equal(t, map[string]int{"a.example.com": 25, "b.example.com": 25}, counts, "allocations not balanced across domains")
+}
+
+func getClusterDeployment(t *testing.T, c client.Client, name string) *hivev1.ClusterDeployment {
+	cd := &hivev1.ClusterDeployment{}
+	require.NoError(t, c.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: name}, cd))
+	return cd
+}
+
+func testAllocation(cdName, domain string) hivev1.BaseDomainAllocation {
+	return hivev1.BaseDomainAllocation{
+		Domain:                     domain,
+		ClusterDeploymentNamespace: testNamespace,
+		ClusterDeploymentName:      cdName,
+		ClusterDeploymentUID:       cdName + "-uid",
+	}
+}
+
+func testClusterDeployment(name, baseDomain string) *hivev1.ClusterDeployment {
+	return &hivev1.ClusterDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: testNamespace,
+			UID:       types.UID(name + "-uid"),
+		},
+		Spec: hivev1.ClusterDeploymentSpec{
+			BaseDomain:        baseDomain,
+			ManageDNS:         true,
+			BaseDomainPoolRef: &hivev1.BaseDomainPoolReference{Name: testPoolName},
+		},
+	}
+}
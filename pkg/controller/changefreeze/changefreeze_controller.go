@@ -0,0 +1,257 @@
+package changefreeze
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/client-go/util/workqueue"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hiveintv1alpha1 "github.com/openshift/hive/apis/hiveinternal/v1alpha1"
+	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	ControllerName = hivev1.ChangeFreezeControllerName
+
+	// statusRefreshInterval is how often the deferred changes of an active ChangeFreeze are recomputed.
+	statusRefreshInterval = 5 * time.Minute
+
+	// maxDeferredChanges is the maximum number of deferred changes listed in the status of a ChangeFreeze.
+	maxDeferredChanges = 100
+)
+
+// Add creates a new ChangeFreeze controller and adds it to the manager with default RBAC.
+func Add(mgr manager.Manager) error {
+	logger := log.WithField("controller", ControllerName)
+	concurrentReconciles, clientRateLimiter, queueRateLimiter, err := controllerutils.GetControllerConfig(mgr.GetClient(), ControllerName)
+	if err != nil {
+		logger.WithError(err).Error("could not get controller configurations")
+		return err
+	}
+	return AddToManager(mgr, NewReconciler(mgr, clientRateLimiter), concurrentReconciles, queueRateLimiter)
+}
+
+// NewReconciler returns a new reconcile.Reconciler
+func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) reconcile.Reconciler {
+	return &ReconcileChangeFreeze{
+		Client: controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
+		scheme: mgr.GetScheme(),
+	}
+}
+
+// AddToManager adds a new Controller to mgr with r as the reconcile.Reconciler
+func AddToManager(mgr manager.Manager, r reconcile.Reconciler, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
+	c, err := controller.New("changefreeze-controller", mgr, controller.Options{
+		Reconciler:              r,
+		MaxConcurrentReconciles: concurrentReconciles,
+		RateLimiter:             rateLimiter,
+	})
+	if err != nil {
+		log.WithField("controller", ControllerName).WithError(err).Error("Error creating new changefreeze controller")
+		return err
+	}
+
+	// Watch for changes to ChangeFreeze
+	if err := c.Watch(&source.Kind{Type: &hivev1.ChangeFreeze{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		log.WithField("controller", ControllerName).WithError(err).Error("Error watching change freeze")
+		return err
+	}
+	return nil
+}
+
+var _ reconcile.Reconciler = &ReconcileChangeFreeze{}
+
+// ReconcileChangeFreeze reconciles a ChangeFreeze, reporting the changes to the frozen clusters which are deferred
+// until the freeze ends. The changes themselves are held back by the controllers making them.
+type ReconcileChangeFreeze struct {
+	client.Client
+	scheme *runtime.Scheme
+}
+
+// Reconcile updates the status of the ChangeFreeze.
+func (r *ReconcileChangeFreeze) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	logger := controllerutils.BuildControllerLogger(ControllerName, "changeFreeze", request.NamespacedName)
+	logger.Info("reconciling change freeze")
+	recobsrv := hivemetrics.NewReconcileObserver(ControllerName, logger)
+	defer recobsrv.ObserveControllerReconcileTime()
+
+	freeze := &hivev1.ChangeFreeze{}
+	if err := r.Get(context.TODO(), request.NamespacedName, freeze); err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Debug("change freeze not found")
+			return reconcile.Result{}, nil
+		}
+		logger.WithError(err).Error("error getting change freeze")
+		return reconcile.Result{}, err
+	}
+	if freeze.DeletionTimestamp != nil {
+		return reconcile.Result{}, nil
+	}
+
+	now := time.Now()
+	status := hivev1.ChangeFreezeStatus{}
+	result := reconcile.Result{}
+	switch {
+	case controllerutils.IsChangeFreezeActive(freeze, now):
+		cds, err := controllerutils.ClusterDeploymentsForChangeFreeze(r, freeze)
+		if err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not list frozen cluster deployments")
+			return reconcile.Result{}, err
+		}
+		var deferred []hivev1.ChangeFreezeDeferredChange
+		for i := range cds {
+			changes, err := r.deferredChanges(&cds[i], logger)
+			if err != nil {
+				return reconcile.Result{}, err
+			}
+			deferred = append(deferred, changes...)
+		}
+		status.Active = true
+		status.FrozenClusters = int32(len(cds))
+		status.DeferredChangeCount = int32(len(deferred))
+		if len(deferred) > maxDeferredChanges {
+			deferred = deferred[:maxDeferredChanges]
+		}
+		status.DeferredChanges = deferred
+		// Refresh the deferred changes while the freeze is active, and clear them once it ends.
+		result.RequeueAfter = statusRefreshInterval
+		if untilEnd := freeze.Spec.End.Sub(now); untilEnd < result.RequeueAfter {
+			result.RequeueAfter = untilEnd
+		}
+	case now.Before(freeze.Spec.Start.Time):
+		result.RequeueAfter = freeze.Spec.Start.Sub(now)
+	}
+
+	if reflect.DeepEqual(freeze.Status, status) {
+		return result, nil
+	}
+	freeze.Status = status
+	if err := r.Status().Update(context.TODO(), freeze); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update change freeze status")
+		return reconcile.Result{}, err
+	}
+	return result, nil
+}
+
+// deferredChanges returns the changes to the frozen cluster which are deferred until the freeze ends: the syncsets
+// which are new, changed or deleted since they were last applied to the cluster, and the machine pools which are
+// changed or deleted since they were last synced to the cluster. Emergency syncsets are applied during the freeze and
+// excluded SelectorSyncSets are not applied at all, so neither are deferred.
+func (r *ReconcileChangeFreeze) deferredChanges(cd *hivev1.ClusterDeployment, logger log.FieldLogger) ([]hivev1.ChangeFreezeDeferredChange, error) {
+	logger = logger.WithField("clusterDeployment", cd.Namespace+"/"+cd.Name)
+	var deferred []hivev1.ChangeFreezeDeferredChange
+	addDeferred := func(kind, name string) {
+		deferred = append(deferred, hivev1.ChangeFreezeDeferredChange{
+			Namespace:             cd.Namespace,
+			ClusterDeploymentName: cd.Name,
+			Kind:                  kind,
+			Name:                  name,
+		})
+	}
+
+	clusterSync := &hiveintv1alpha1.ClusterSync{}
+	if err := r.Get(context.TODO(), client.ObjectKey{Namespace: cd.Namespace, Name: cd.Name}, clusterSync); err != nil && !apierrors.IsNotFound(err) {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not get ClusterSync")
+		return nil, err
+	}
+
+	syncSets := &hivev1.SyncSetList{}
+	if err := r.List(context.TODO(), syncSets, client.InNamespace(cd.Namespace)); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not list SyncSets")
+		return nil, err
+	}
+	var syncSetObjects []metav1.Object
+	for i, ss := range syncSets.Items {
+		for _, ref := range ss.Spec.ClusterDeploymentRefs {
+			if ref.Name == cd.Name {
+				syncSetObjects = append(syncSetObjects, &syncSets.Items[i])
+				break
+			}
+		}
+	}
+	for _, name := range deferredSyncSets(syncSetObjects, clusterSync.Status.SyncSets) {
+		addDeferred("SyncSet", name)
+	}
+
+	selectorSyncSets := &hivev1.SelectorSyncSetList{}
+	if err := r.List(context.TODO(), selectorSyncSets); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not list SelectorSyncSets")
+		return nil, err
+	}
+	var selectorSyncSetObjects []metav1.Object
+	for i, sss := range selectorSyncSets.Items {
+		selector, err := metav1.LabelSelectorAsSelector(&sss.Spec.ClusterDeploymentSelector)
+		if err != nil || !selector.Matches(labels.Set(cd.Labels)) || controllerutils.IsSelectorSyncSetExcluded(cd, sss.Name) {
+			continue
+		}
+		selectorSyncSetObjects = append(selectorSyncSetObjects, &selectorSyncSets.Items[i])
+	}
+	var selectorSyncSetStatuses []hiveintv1alpha1.SyncStatus
+	for _, status := range clusterSync.Status.SelectorSyncSets {
+		if !controllerutils.IsSelectorSyncSetExcluded(cd, status.Name) {
+			selectorSyncSetStatuses = append(selectorSyncSetStatuses, status)
+		}
+	}
+	for _, name := range deferredSyncSets(selectorSyncSetObjects, selectorSyncSetStatuses) {
+		addDeferred("SelectorSyncSet", name)
+	}
+
+	pools := &hivev1.MachinePoolList{}
+	if err := r.List(context.TODO(), pools, client.InNamespace(cd.Namespace)); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not list MachinePools")
+		return nil, err
+	}
+	for _, pool := range pools.Items {
+		if pool.Spec.ClusterDeploymentRef.Name != cd.Name {
+			continue
+		}
+		if pool.DeletionTimestamp != nil || pool.Generation != pool.Status.ObservedGeneration {
+			addDeferred("MachinePool", pool.Name)
+		}
+	}
+
+	return deferred, nil
+}
+
+// deferredSyncSets returns the names of the non-emergency syncsets which are not applied in their current generation
+// and of the syncsets which are deleted but whose resources are still tracked in the sync statuses, sorted by name.
+func deferredSyncSets(syncSets []metav1.Object, syncStatuses []hiveintv1alpha1.SyncStatus) []string {
+	observedGenerations := map[string]int64{}
+	for _, status := range syncStatuses {
+		observedGenerations[status.Name] = status.ObservedGeneration
+	}
+	deferred := sets.NewString()
+	for _, syncSet := range syncSets {
+		name := syncSet.GetName()
+		observedGeneration, applied := observedGenerations[name]
+		delete(observedGenerations, name)
+		if controllerutils.IsEmergencyChange(syncSet) {
+			continue
+		}
+		if !applied || observedGeneration != syncSet.GetGeneration() {
+			deferred.Insert(name)
+		}
+	}
+	for name := range observedGenerations {
+		deferred.Insert(name)
+	}
+	return deferred.List()
+}
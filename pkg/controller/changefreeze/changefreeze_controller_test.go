@@ -0,0 +1,202 @@
+package changefreeze
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hiveintv1alpha1 "github.com/openshift/hive/apis/hiveinternal/v1alpha1"
+)
+
+const (
+	testName      = "test-freeze"
+	testNamespace = "test-namespace"
+	testCDName    = "test-cd"
+)
+
+func TestReconcileChangeFreeze(t *testing.T) {
+	scheme := runtime.NewScheme()
+	hivev1.AddToScheme(scheme)
+	hiveintv1alpha1.AddToScheme(scheme)
+
+	freeze := func(start, end time.Duration) *hivev1.ChangeFreeze {
+		return &hivev1.ChangeFreeze{
+			ObjectMeta: metav1.ObjectMeta{Name: testName},
+			Spec: hivev1.ChangeFreezeSpec{
+				ClusterDeploymentSelector: metav1.LabelSelector{
+					MatchLabels: map[string]string{"frozen": "true"},
+				},
+				Start: metav1.NewTime(time.Now().Add(start)),
+				End:   metav1.NewTime(time.Now().Add(end)),
+			},
+		}
+	}
+	cd := func(name string, frozen bool) *hivev1.ClusterDeployment {
+		return &hivev1.ClusterDeployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: testNamespace,
+				Name:      name,
+				Labels:    map[string]string{"frozen": fmt.Sprint(frozen)},
+			},
+		}
+	}
+	syncSet := func(name string, generation int64, emergency bool) *hivev1.SyncSet {
+		ss := &hivev1.SyncSet{
+			ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: name, Generation: generation},
+			Spec: hivev1.SyncSetSpec{
+				ClusterDeploymentRefs: []corev1.LocalObjectReference{{Name: testCDName}},
+			},
+		}
+		if emergency {
+			ss.Labels = map[string]string{hivev1.ChangeFreezeEmergencyLabel: "true"}
+		}
+		return ss
+	}
+	selectorSyncSet := func(name string, generation int64) *hivev1.SelectorSyncSet {
+		return &hivev1.SelectorSyncSet{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Generation: generation},
+			Spec: hivev1.SelectorSyncSetSpec{
+				ClusterDeploymentSelector: metav1.LabelSelector{
+					MatchLabels: map[string]string{"frozen": "true"},
+				},
+			},
+		}
+	}
+	clusterSync := func(syncSets, selectorSyncSets map[string]int64) *hiveintv1alpha1.ClusterSync {
+		cs := &hiveintv1alpha1.ClusterSync{
+			ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: testCDName},
+		}
+		for name, generation := range syncSets {
+			cs.Status.SyncSets = append(cs.Status.SyncSets, hiveintv1alpha1.SyncStatus{Name: name, ObservedGeneration: generation})
+		}
+		for name, generation := range selectorSyncSets {
+			cs.Status.SelectorSyncSets = append(cs.Status.SelectorSyncSets, hiveintv1alpha1.SyncStatus{Name: name, ObservedGeneration: generation})
+		}
+		return cs
+	}
+	machinePool := func(name string, generation, observedGeneration int64) *hivev1.MachinePool {
+		return &hivev1.MachinePool{
+			ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: name, Generation: generation},
+			Spec: hivev1.MachinePoolSpec{
+				ClusterDeploymentRef: corev1.LocalObjectReference{Name: testCDName},
+			},
+			Status: hivev1.MachinePoolStatus{ObservedGeneration: observedGeneration},
+		}
+	}
+	deferred := func(kind, name string) hivev1.ChangeFreezeDeferredChange {
+		return hivev1.ChangeFreezeDeferredChange{
+			Namespace:             testNamespace,
+			ClusterDeploymentName: testCDName,
+			Kind:                  kind,
+			Name:                  name,
+		}
+	}
+
+	cases := []struct {
+		name            string
+		freeze          *hivev1.ChangeFreeze
+		existing        []runtime.Object
+		expectedStatus  hivev1.ChangeFreezeStatus
+		expectRequeue   bool
+		expectNoRequeue bool
+	}{
+		{
+			name:            "past freeze",
+			freeze:          freeze(-2*time.Hour, -time.Hour),
+			existing:        []runtime.Object{cd(testCDName, true), syncSet("new-syncset", 1, false)},
+			expectNoRequeue: true,
+		},
+		{
+			name:          "future freeze",
+			freeze:        freeze(time.Hour, 2*time.Hour),
+			existing:      []runtime.Object{cd(testCDName, true), syncSet("new-syncset", 1, false)},
+			expectRequeue: true,
+		},
+		{
+			name:   "active freeze without deferred changes",
+			freeze: freeze(-time.Hour, time.Hour),
+			existing: []runtime.Object{
+				cd(testCDName, true),
+				cd("other-cd", false),
+				syncSet("applied-syncset", 1, false),
+				clusterSync(map[string]int64{"applied-syncset": 1}, nil),
+				machinePool("synced-pool", 2, 2),
+			},
+			expectedStatus: hivev1.ChangeFreezeStatus{
+				Active:         true,
+				FrozenClusters: 1,
+			},
+			expectRequeue: true,
+		},
+		{
+			name:   "active freeze with deferred changes",
+			freeze: freeze(-time.Hour, time.Hour),
+			existing: []runtime.Object{
+				cd(testCDName, true),
+				syncSet("applied-syncset", 1, false),
+				syncSet("changed-syncset", 2, false),
+				syncSet("new-syncset", 1, false),
+				syncSet("emergency-syncset", 2, true),
+				selectorSyncSet("changed-selectorsyncset", 3),
+				clusterSync(
+					map[string]int64{
+						"applied-syncset":   1,
+						"changed-syncset":   1,
+						"emergency-syncset": 1,
+						"deleted-syncset":   1,
+					},
+					map[string]int64{"changed-selectorsyncset": 2},
+				),
+				machinePool("synced-pool", 2, 2),
+				machinePool("changed-pool", 2, 1),
+			},
+			expectedStatus: hivev1.ChangeFreezeStatus{
+				Active:              true,
+				FrozenClusters:      1,
+				DeferredChangeCount: 5,
+				DeferredChanges: []hivev1.ChangeFreezeDeferredChange{
+					deferred("SyncSet", "changed-syncset"),
+					deferred("SyncSet", "deleted-syncset"),
+					deferred("SyncSet", "new-syncset"),
+					deferred("SelectorSyncSet", "changed-selectorsyncset"),
+					deferred("MachinePool", "changed-pool"),
+				},
+			},
+			expectRequeue: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := fake.NewFakeClientWithScheme(scheme, append(tc.existing, tc.freeze)...)
+			r := &ReconcileChangeFreeze{Client: c, scheme: scheme}
+
+			result, err := r.Reconcile(reconcile.Request{NamespacedName: types.NamespacedName{Name: testName}})
+			require.NoError(t, err, "unexpected error from Reconcile")
+			if tc.expectRequeue {
+				assert.Greater(t, int64(result.RequeueAfter), int64(0), "expected requeue")
+				assert.LessOrEqual(t, result.RequeueAfter.Seconds(), time.Hour.Seconds(), "requeue too late")
+			}
+			if tc.expectNoRequeue {
+				assert.Zero(t, result.RequeueAfter, "unexpected requeue")
+			}
+
+			actual := &hivev1.ChangeFreeze{}
+			require.NoError(t, c.Get(context.TODO(), client.ObjectKey{Name: testName}, actual))
+			assert.Equal(t, tc.expectedStatus, actual.Status, "unexpected status")
+		})
+	}
+}
@@ -12,6 +12,7 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/flowcontrol"
 	"k8s.io/client-go/util/workqueue"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -22,9 +23,11 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/awsclient"
 	"github.com/openshift/hive/pkg/constants"
 	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/remoteclient"
 	"github.com/openshift/hive/pkg/resource"
 )
 
@@ -50,10 +53,19 @@ func Add(mgr manager.Manager) error {
 // NewReconciler returns a new ReconcileClusterClaim
 func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) *ReconcileClusterClaim {
 	logger := log.WithField("controller", ControllerName)
-	return &ReconcileClusterClaim{
-		Client: controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
-		logger: logger,
-	}
+	r := &ReconcileClusterClaim{
+		Client:        controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
+		logger:        logger,
+		awsClientFn:   getAWSClient,
+		eventRecorder: mgr.GetEventRecorderFor(string(ControllerName)),
+		quota:         controllerutils.ClaimQuotaFromEnv(logger),
+	}
+	r.remoteClientBuilder = func(cd *hivev1.ClusterDeployment) remoteclient.Builder {
+		// The activity on claimed clusters is observed from their namespaces and events, which the scoped client of
+		// the controller is not permitted to list, so the admin kubeconfig of the cluster is used.
+		return remoteclient.NewAdminBuilder(r.Client, cd, ControllerName)
+	}
+	return r
 }
 
 // AddToManager adds a new Controller to mgr with r as the reconcile.Reconciler
@@ -73,6 +85,18 @@ func AddToManager(mgr manager.Manager, r *ReconcileClusterClaim, concurrentRecon
 		return err
 	}
 
+	// Watch for changes to the ClusterClaims counted against the quotas of other ClusterClaims
+	if r.quota.IsLimited() {
+		if err := c.Watch(
+			&source.Kind{Type: &hivev1.ClusterClaim{}},
+			&handler.EnqueueRequestsFromMapFunc{
+				ToRequests: requestsForQuotaClaims(r.Client, r.logger),
+			},
+		); err != nil {
+			return err
+		}
+	}
+
 	// Watch for changes to ClusterDeployment
 	if err := c.Watch(
 		&source.Kind{Type: &hivev1.ClusterDeployment{}},
@@ -156,6 +180,17 @@ var _ reconcile.Reconciler = &ReconcileClusterClaim{}
 type ReconcileClusterClaim struct {
 	client.Client
 	logger log.FieldLogger
+
+	// awsClientFn is the function to build an AWS client, here for testing
+	awsClientFn func(*hivev1.ClusterDeployment, client.Client, log.FieldLogger) (awsclient.Client, error)
+
+	// remoteClientBuilder is the function to build a client for the claimed cluster, here for testing
+	remoteClientBuilder func(*hivev1.ClusterDeployment) remoteclient.Builder
+
+	eventRecorder record.EventRecorder
+
+	// quota limits the number of claims per namespace and per requester
+	quota controllerutils.ClaimQuota
 }
 
 // Reconcile reconciles a ClusterClaim.
@@ -195,7 +230,7 @@ func (r *ReconcileClusterClaim) Reconcile(request reconcile.Request) (result rec
 	clusterName := claim.Spec.Namespace
 	if clusterName == "" {
 		logger.Debug("claim has not yet been assigned a cluster")
-		return reconcile.Result{}, nil
+		return reconcile.Result{}, r.reconcileQuota(claim, logger)
 	}
 
 	logger = logger.WithField("cluster", clusterName)
@@ -206,6 +241,7 @@ func (r *ReconcileClusterClaim) Reconcile(request reconcile.Request) (result rec
 		return reconcile.Result{}, err
 	}
 	lifetime := getClaimLifetime(poolLifetime, claim.Spec.Lifetime)
+	idleTimeout := getClaimIdleTimeout(poolLifetime, claim.Spec.IdleTimeout)
 
 	if (lifetime != nil) != (claim.Status.Lifetime != nil) ||
 		lifetime != nil && claim.Status.Lifetime != nil && lifetime.Duration != claim.Status.Lifetime.Duration {
@@ -216,29 +252,16 @@ func (r *ReconcileClusterClaim) Reconcile(request reconcile.Request) (result rec
 		}
 	}
 
-	// Delete ClusterClaim after its lifetime elapses
-	if lifetime != nil {
-		logger.WithField("lifetime", lifetime).Debug("checking whether lifetime of ClusterClaim has elapsed")
-		pendingCond := controllerutils.FindClusterClaimCondition(claim.Status.Conditions, hivev1.ClusterClaimPendingCondition)
-		if pendingCond != nil && pendingCond.Status == corev1.ConditionFalse {
-			if timeSinceAssigned := time.Since(pendingCond.LastTransitionTime.Time); timeSinceAssigned >= lifetime.Duration {
-				logger.WithField("timeSinceAssigned", timeSinceAssigned).
-					WithField("lifetime", lifetime).
-					Info("deleting ClusterClaim because its lifetime has elapsed")
-				if err := r.Delete(context.Background(), claim); err != nil {
-					logger.WithError(err).Log(controllerutils.LogLevel(err), "could not delete ClusterClaim")
-					return reconcile.Result{}, errors.Wrap(err, "could not delete ClusterClaim")
-				}
-				return reconcile.Result{}, nil
-			}
-			defer func() {
-				result, returnErr = controllerutils.EnsureRequeueAtLeastWithin(
-					lifetime.Duration-time.Since(pendingCond.LastTransitionTime.Time),
-					result,
-					returnErr,
-				)
-			}()
-		}
+	// Delete ClusterClaim after its lifetime elapses or its cluster has been idle for too long
+	switch requeueAfter, expired, err := r.reconcileExpiration(claim, lifetime, idleTimeout, logger); {
+	case err != nil:
+		return reconcile.Result{}, err
+	case expired:
+		return reconcile.Result{}, nil
+	case requeueAfter > 0:
+		defer func() {
+			result, returnErr = controllerutils.EnsureRequeueAtLeastWithin(requeueAfter, result, returnErr)
+		}()
 	}
 
 	cd := &hivev1.ClusterDeployment{}
@@ -282,6 +305,18 @@ func getClaimLifetime(poolLifetime *hivev1.ClusterPoolClaimLifetime, claimLifeti
 	return lifetime
 }
 
+// getClaimIdleTimeout returns the idle timeout for a claim, which is the minimum of the idle timeouts set on the pool and
+// the claim.
+func getClaimIdleTimeout(poolLifetime *hivev1.ClusterPoolClaimLifetime, claimIdleTimeout *metav1.Duration) *metav1.Duration {
+	idleTimeout := claimIdleTimeout
+	if poolLifetime != nil && poolLifetime.IdleTimeout != nil {
+		if idleTimeout == nil || poolLifetime.IdleTimeout.Duration < idleTimeout.Duration {
+			idleTimeout = poolLifetime.IdleTimeout
+		}
+	}
+	return idleTimeout
+}
+
 // clusterPoolLifetimeForClaim returns the default and max lifetimes for the cluster pool the claim belongs to.
 func (r *ReconcileClusterClaim) clusterPoolLifetimeForClaim(claim *hivev1.ClusterClaim, logger log.FieldLogger) (*hivev1.ClusterPoolClaimLifetime, error) {
 	// Fetch the ClusterPool instance
@@ -363,21 +398,8 @@ func (r *ReconcileClusterClaim) cleanupResources(claim *hivev1.ClusterClaim, log
 		return err
 	}
 
-	// Delete ClusterDeployment
-	toRemove := controllerutils.IsClaimedClusterMarkedForRemoval(cd)
-	if cd.DeletionTimestamp == nil && !toRemove {
-		logger.Info("deleting clusterDeployment")
-		if cd.Annotations == nil {
-			cd.Annotations = map[string]string{}
-		}
-		cd.Annotations[constants.ClusterClaimRemoveClusterAnnotation] = "true"
-		if err := r.Update(context.Background(), cd); err != nil {
-			logger.WithError(err).Log(controllerutils.LogLevel(err), "error updating ClusterDeployment to mark it for deletion")
-			return err
-		}
-	}
-
-	return nil
+	// Delete, return to the pool or quarantine the ClusterDeployment
+	return r.releaseCluster(cd, logger)
 }
 
 func (r *ReconcileClusterClaim) reconcileForDeletedCluster(claim *hivev1.ClusterClaim, logger log.FieldLogger) (reconcile.Result, error) {
@@ -404,6 +426,10 @@ func (r *ReconcileClusterClaim) reconcileForNewAssignment(claim *hivev1.ClusterC
 	logger.Info("cluster assigned to claim")
 	cd.Spec.ClusterPoolRef.ClaimName = claim.Name
 	cd.Spec.PowerState = hivev1.RunningClusterPowerState
+	if cd.Annotations == nil {
+		cd.Annotations = map[string]string{}
+	}
+	cd.Annotations[constants.ClaimedTimestampAnnotation] = time.Now().UTC().Format(time.RFC3339)
 	if err := r.Update(context.Background(), cd); err != nil {
 		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not set claim for ClusterDeployment")
 		return reconcile.Result{}, err
@@ -416,10 +442,16 @@ func (r *ReconcileClusterClaim) reconcileForExistingAssignment(claim *hivev1.Clu
 	if err := r.createRBAC(claim, cd, logger); err != nil {
 		return reconcile.Result{}, err
 	}
+	tagErr, err := r.reconcileClaimMetadata(claim, cd, logger)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
 	var statusChanged bool
 	var changed bool
 	conds := claim.Status.Conditions
 
+	conds, statusChanged = setCloudResourcesTaggedCondition(conds, cd, tagErr)
+
 	conds, changed = controllerutils.SetClusterClaimConditionWithChangeCheck(
 		conds,
 		hivev1.ClusterClaimPendingCondition,
@@ -461,6 +493,9 @@ func (r *ReconcileClusterClaim) reconcileForExistingAssignment(claim *hivev1.Clu
 			return reconcile.Result{}, err
 		}
 	}
+	if tagErr != nil {
+		return reconcile.Result{RequeueAfter: tagRetryInterval}, nil
+	}
 	return reconcile.Result{}, nil
 }
 
@@ -155,10 +155,11 @@ func TestReconcileClusterClaim(t *testing.T) {
 			cd:                 cdBuilder.Build(testcd.WithClusterPoolReference(claimNamespace, "test-pool", "other-claim")),
 			expectNoAssignment: true,
 			expectedConditions: []hivev1.ClusterClaimCondition{{
-				Type:    hivev1.ClusterClaimPendingCondition,
-				Status:  corev1.ConditionTrue,
-				Reason:  "AssignmentConflict",
-				Message: "Assigned cluster was claimed by a different ClusterClaim",
+				Type:     hivev1.ClusterClaimPendingCondition,
+				Status:   corev1.ConditionTrue,
+				Reason:   "AssignmentConflict",
+				Message:  "Assigned cluster was claimed by a different ClusterClaim",
+				Category: hivev1.FailureCategoryHiveInternal,
 			}},
 		},
 		{
@@ -692,7 +693,7 @@ func TestReconcileClusterClaim(t *testing.T) {
 				},
 			},
 			expectRBAC:           true,
-			expectedRequeueAfter: func(d time.Duration) *time.Duration { return &d }(2 * time.Hour),
+			expectedRequeueAfter: func(d time.Duration) *time.Duration { return &d }(2*time.Hour - 45*time.Minute),
 		},
 	}
 
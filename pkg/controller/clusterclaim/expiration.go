@@ -0,0 +1,299 @@
+package clusterclaim
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	// maxExpirationWarningPeriod is the longest time before the deletion of an expiring claim that the Expiring
+	// condition is set. Shorter lifetimes and idle timeouts are warned about for a quarter of their duration.
+	maxExpirationWarningPeriod = time.Hour
+
+	// activityRecheckInterval is how often the activity on a claimed cluster is checked again while it cannot be
+	// observed, because the cluster is hibernating, is unreachable or cannot be queried.
+	activityRecheckInterval = 10 * time.Minute
+
+	// activityListPageSize is the number of namespaces or events listed per request when checking the activity on a
+	// claimed cluster.
+	activityListPageSize = 500
+
+	lifetimeExpiringReason = "LifetimeExpiring"
+	lifetimeExpiredReason  = "LifetimeExpired"
+	idleReason             = "Idle"
+	idleTimeoutReason      = "IdleTimeout"
+	notExpiringReason      = "NotExpiring"
+	activityUnknownReason  = "ActivityUnknown"
+)
+
+// reconcileExpiration deletes the claim once its lifetime has elapsed or its cluster has been idle for longer than its
+// idle timeout, and warns of the deletion ahead of time with the Expiring condition and an event. A claim is never
+// deleted for being idle while the activity on its cluster cannot be observed. It returns how long until the claim
+// needs to be reconciled again, and whether the claim was deleted.
+func (r *ReconcileClusterClaim) reconcileExpiration(
+	claim *hivev1.ClusterClaim,
+	lifetime, idleTimeout *metav1.Duration,
+	logger log.FieldLogger,
+) (time.Duration, bool, error) {
+	pendingCond := controllerutils.FindClusterClaimCondition(claim.Status.Conditions, hivev1.ClusterClaimPendingCondition)
+	if pendingCond == nil || pendingCond.Status != corev1.ConditionFalse {
+		// The lifetime and idle timeout of a claim start when it is assigned a cluster.
+		return 0, false, nil
+	}
+	assignedTime := pendingCond.LastTransitionTime.Time
+	statusChanged := false
+	activityUnknown := false
+
+	var deadline time.Time
+	var warningPeriod time.Duration
+	var expiringReason, expiringMessage, expiredReason, expiredMessage string
+	if lifetime != nil {
+		logger.WithField("lifetime", lifetime).Debug("checking whether lifetime of ClusterClaim has elapsed")
+		deadline = assignedTime.Add(lifetime.Duration)
+		warningPeriod = expirationWarningPeriod(lifetime.Duration)
+		expiringReason = lifetimeExpiringReason
+		expiringMessage = fmt.Sprintf("Lifetime of the claim ends at %s", deadline.UTC().Format(time.RFC3339))
+		expiredReason = lifetimeExpiredReason
+		expiredMessage = fmt.Sprintf("Deleting claim because its lifetime of %s has elapsed", lifetime.Duration)
+	}
+	if idleTimeout != nil {
+		logger.WithField("idleTimeout", idleTimeout).Debug("checking whether ClusterClaim is idle")
+		lastActivity := assignedTime
+		if t := claim.Status.LastActivityTime; t != nil && t.After(lastActivity) {
+			lastActivity = t.Time
+		}
+		idleWarningPeriod := expirationWarningPeriod(idleTimeout.Duration)
+		idleDeadline := lastActivity.Add(idleTimeout.Duration)
+		// Only look for activity on the cluster once the claim is close to being idle for too long, to keep the
+		// load on the claimed clusters down.
+		if !time.Now().Before(idleDeadline.Add(-idleWarningPeriod)) {
+			activity, known := r.lastClusterActivity(claim, logger)
+			switch {
+			case !known:
+				activityUnknown = true
+			case activity.After(lastActivity):
+				logger.WithField("lastActivity", activity).Debug("observed activity on the claimed cluster")
+				lastActivity = activity
+				idleDeadline = lastActivity.Add(idleTimeout.Duration)
+				claim.Status.LastActivityTime = &metav1.Time{Time: activity}
+				statusChanged = true
+			}
+		}
+		if !activityUnknown && (deadline.IsZero() || idleDeadline.Before(deadline)) {
+			deadline = idleDeadline
+			warningPeriod = idleWarningPeriod
+			expiringReason = idleReason
+			expiringMessage = fmt.Sprintf("No activity on the cluster since %s, the claim will be deleted at %s",
+				lastActivity.UTC().Format(time.RFC3339), deadline.UTC().Format(time.RFC3339))
+			expiredReason = idleTimeoutReason
+			expiredMessage = fmt.Sprintf("Deleting claim because its cluster has been idle since %s",
+				lastActivity.UTC().Format(time.RFC3339))
+		}
+	}
+
+	if deadline.IsZero() {
+		// Clear the expiration of a claim whose lifetime and idle timeout have been removed, or whose cluster activity
+		// cannot be observed.
+		if claim.Status.ExpirationTime != nil {
+			claim.Status.ExpirationTime = nil
+			statusChanged = true
+		}
+		reason, message, requeueAfter := notExpiringReason, "Claim has no lifetime or idle timeout", time.Duration(0)
+		if activityUnknown {
+			reason, message, requeueAfter = activityUnknownReason,
+				"Activity on the cluster cannot be observed, the claim is not deleted for being idle", activityRecheckInterval
+		}
+		if cond := controllerutils.FindClusterClaimCondition(claim.Status.Conditions, hivev1.ClusterClaimExpiringCondition); cond != nil || activityUnknown {
+			var changed bool
+			claim.Status.Conditions, changed = controllerutils.SetClusterClaimConditionWithChangeCheck(
+				claim.Status.Conditions,
+				hivev1.ClusterClaimExpiringCondition,
+				corev1.ConditionFalse,
+				reason,
+				message,
+				controllerutils.UpdateConditionIfReasonOrMessageChange,
+			)
+			statusChanged = statusChanged || changed
+		}
+		return requeueAfter, false, r.updateExpirationStatus(claim, statusChanged, logger)
+	}
+	deadline = deadline.Truncate(time.Second)
+
+	if !time.Now().Before(deadline) {
+		logger.WithField("reason", expiredReason).WithField("deadline", deadline).Info("deleting ClusterClaim because it has expired")
+		r.recordEvent(claim, corev1.EventTypeNormal, expiredReason, expiredMessage)
+		if err := r.Delete(context.Background(), claim); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not delete ClusterClaim")
+			return 0, false, errors.Wrap(err, "could not delete ClusterClaim")
+		}
+		return 0, true, nil
+	}
+
+	if claim.Status.ExpirationTime == nil || !claim.Status.ExpirationTime.Time.Equal(deadline) {
+		claim.Status.ExpirationTime = &metav1.Time{Time: deadline}
+		statusChanged = true
+	}
+
+	expiring := !time.Now().Before(deadline.Add(-warningPeriod))
+	var changed bool
+	switch {
+	case expiring:
+		claim.Status.Conditions, changed = controllerutils.SetClusterClaimConditionWithChangeCheck(
+			claim.Status.Conditions,
+			hivev1.ClusterClaimExpiringCondition,
+			corev1.ConditionTrue,
+			expiringReason,
+			expiringMessage,
+			controllerutils.UpdateConditionIfReasonOrMessageChange,
+		)
+		if changed {
+			r.recordEvent(claim, corev1.EventTypeWarning, expiringReason, expiringMessage)
+		}
+	case controllerutils.FindClusterClaimCondition(claim.Status.Conditions, hivev1.ClusterClaimExpiringCondition) != nil:
+		claim.Status.Conditions, changed = controllerutils.SetClusterClaimConditionWithChangeCheck(
+			claim.Status.Conditions,
+			hivev1.ClusterClaimExpiringCondition,
+			corev1.ConditionFalse,
+			notExpiringReason,
+			fmt.Sprintf("Claim expires at %s", deadline.UTC().Format(time.RFC3339)),
+			controllerutils.UpdateConditionIfReasonOrMessageChange,
+		)
+	}
+	statusChanged = statusChanged || changed
+	if err := r.updateExpirationStatus(claim, statusChanged, logger); err != nil {
+		return 0, false, err
+	}
+
+	requeueAfter := time.Until(deadline.Add(-warningPeriod))
+	if expiring {
+		requeueAfter = time.Until(deadline)
+	}
+	if activityUnknown && activityRecheckInterval < requeueAfter {
+		requeueAfter = activityRecheckInterval
+	}
+	return requeueAfter, false, nil
+}
+
+func (r *ReconcileClusterClaim) updateExpirationStatus(claim *hivev1.ClusterClaim, statusChanged bool, logger log.FieldLogger) error {
+	if !statusChanged {
+		return nil
+	}
+	if err := r.Status().Update(context.Background(), claim); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update ClusterClaim expiration")
+		return errors.Wrap(err, "could not update ClusterClaim expiration")
+	}
+	return nil
+}
+
+// expirationWarningPeriod returns how long before the end of the given lifetime or idle timeout the claim is marked as
+// expiring.
+func expirationWarningPeriod(d time.Duration) time.Duration {
+	if p := d / 4; p < maxExpirationWarningPeriod {
+		return p
+	}
+	return maxExpirationWarningPeriod
+}
+
+// lastClusterActivity returns the time of the latest activity observed on the claimed cluster, and whether the activity
+// on the cluster could be observed. The activity cannot be observed on a cluster which is hibernating, is unreachable
+// or cannot be queried. The user activity recorded on the ClusterDeployment by the hibernation controller is also taken
+// into account.
+func (r *ReconcileClusterClaim) lastClusterActivity(claim *hivev1.ClusterClaim, logger log.FieldLogger) (time.Time, bool) {
+	cd := &hivev1.ClusterDeployment{}
+	if err := r.Get(context.Background(), client.ObjectKey{Namespace: claim.Spec.Namespace, Name: claim.Spec.Namespace}, cd); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "error getting ClusterDeployment, activity on the claimed cluster is unknown")
+		return time.Time{}, false
+	}
+	if hc := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.ClusterHibernatingCondition); hc != nil && hc.Status == corev1.ConditionTrue {
+		logger.Debug("claimed cluster is hibernating, activity on it cannot be observed")
+		return time.Time{}, false
+	}
+	if uc := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.UnreachableCondition); uc != nil && uc.Status == corev1.ConditionTrue {
+		logger.Debug("claimed cluster is unreachable, activity on it cannot be observed")
+		return time.Time{}, false
+	}
+	remoteClient, err := r.remoteClientBuilder(cd).Build()
+	if err != nil {
+		logger.WithError(err).Warn("error building client for the claimed cluster, activity on it is unknown")
+		return time.Time{}, false
+	}
+	latest, err := latestActivity(remoteClient)
+	if err != nil {
+		logger.WithError(err).Warn("error checking activity on the claimed cluster, activity on it is unknown")
+		return time.Time{}, false
+	}
+	if a := cd.Status.Activity; a != nil && a.LastActivityTime != nil && a.LastActivityTime.After(latest) {
+		latest = a.LastActivityTime.Time.Truncate(time.Second)
+	}
+	return latest, true
+}
+
+// latestActivity returns the time of the latest event, or of the creation of the latest namespace, in the namespaces
+// of the users of a cluster.
+func latestActivity(c client.Client) (time.Time, error) {
+	var latest time.Time
+	userNamespaces := sets.NewString()
+	namespaces := &corev1.NamespaceList{}
+	for opts := (&client.ListOptions{Limit: activityListPageSize}); ; {
+		if err := c.List(context.Background(), namespaces, opts); err != nil {
+			return latest, errors.Wrap(err, "could not list namespaces of the claimed cluster")
+		}
+		for _, ns := range namespaces.Items {
+			if isSystemNamespace(ns.Name) {
+				continue
+			}
+			userNamespaces.Insert(ns.Name)
+			if ns.CreationTimestamp.After(latest) {
+				latest = ns.CreationTimestamp.Time
+			}
+		}
+		if opts.Continue = namespaces.Continue; opts.Continue == "" {
+			break
+		}
+	}
+	// Events are listed per namespace, so that the events of the platform, which are most of the events of a cluster,
+	// are not listed.
+	for _, ns := range userNamespaces.List() {
+		events := &corev1.EventList{}
+		for opts := (&client.ListOptions{Namespace: ns, Limit: activityListPageSize}); ; {
+			if err := c.List(context.Background(), events, opts); err != nil {
+				return latest, errors.Wrapf(err, "could not list events of namespace %s of the claimed cluster", ns)
+			}
+			for _, event := range events.Items {
+				if event.LastTimestamp.After(latest) {
+					latest = event.LastTimestamp.Time
+				}
+				if event.EventTime.After(latest) {
+					latest = event.EventTime.Time
+				}
+			}
+			if opts.Continue = events.Continue; opts.Continue == "" {
+				break
+			}
+		}
+	}
+	return latest.Truncate(time.Second), nil
+}
+
+func isSystemNamespace(name string) bool {
+	return name == "default" || name == "openshift" ||
+		strings.HasPrefix(name, "openshift-") || strings.HasPrefix(name, "kube-")
+}
+
+func (r *ReconcileClusterClaim) recordEvent(claim *hivev1.ClusterClaim, eventType, reason, message string) {
+	if r.eventRecorder != nil {
+		r.eventRecorder.Event(claim, eventType, reason, message)
+	}
+}
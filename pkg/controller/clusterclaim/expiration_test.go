@@ -0,0 +1,395 @@
+package clusterclaim
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/remoteclient"
+	remoteclientmock "github.com/openshift/hive/pkg/remoteclient/mock"
+	testclaim "github.com/openshift/hive/pkg/test/clusterclaim"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+	testcp "github.com/openshift/hive/pkg/test/clusterpool"
+)
+
+func TestReconcileClusterClaimExpiration(t *testing.T) {
+	scheme := runtime.NewScheme()
+	hivev1.AddToScheme(scheme)
+	rbacv1.AddToScheme(scheme)
+	remoteScheme := runtime.NewScheme()
+	corev1.AddToScheme(remoteScheme)
+
+	poolBuilder := testcp.FullBuilder(claimNamespace, testLeasePoolName, scheme).Options(
+		testcp.ForAWS("secret", "us-east-1"),
+	)
+	claimBuilder := testclaim.FullBuilder(claimNamespace, claimName, scheme).Options(
+		testclaim.WithPool(testLeasePoolName),
+		testclaim.WithCluster(clusterName),
+		testclaim.WithSubjects(subjects),
+	)
+	assignedAgo := func(d time.Duration) testclaim.Option {
+		return testclaim.WithCondition(hivev1.ClusterClaimCondition{
+			Type:               hivev1.ClusterClaimPendingCondition,
+			Status:             corev1.ConditionFalse,
+			Reason:             "ClusterClaimed",
+			Message:            "Cluster claimed",
+			LastTransitionTime: metav1.NewTime(time.Now().Add(-d)),
+		})
+	}
+	cdBuilder := testcd.FullBuilder(clusterName, clusterName, scheme).Options(
+		testcd.Installed(),
+		testcd.WithClusterPoolReference(claimNamespace, testLeasePoolName, claimName),
+		func(cd *hivev1.ClusterDeployment) {
+			cd.Spec.ClusterMetadata = &hivev1.ClusterMetadata{
+				AdminKubeconfigSecretRef: corev1.LocalObjectReference{Name: kubeconfigSecretName},
+				AdminPasswordSecretRef:   corev1.LocalObjectReference{Name: passwordSecretName},
+			}
+		},
+	)
+	namespace := func(name string, createdAgo time.Duration) runtime.Object {
+		return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			CreationTimestamp: metav1.NewTime(time.Now().Add(-createdAgo)),
+		}}
+	}
+	event := func(namespace string, ago time.Duration) runtime.Object {
+		return &corev1.Event{
+			ObjectMeta:    metav1.ObjectMeta{Namespace: namespace, Name: "event"},
+			LastTimestamp: metav1.NewTime(time.Now().Add(-ago)),
+		}
+	}
+	systemActivity := []runtime.Object{
+		namespace("default", 24*time.Hour),
+		namespace("openshift-monitoring", 24*time.Hour),
+		namespace("kube-system", 24*time.Hour),
+		event("openshift-monitoring", time.Minute),
+		event("default", time.Minute),
+	}
+	duration := func(d time.Duration) *time.Duration { return &d }
+
+	tests := []struct {
+		name                   string
+		claim                  *hivev1.ClusterClaim
+		pool                   *hivev1.ClusterPool
+		cd                     *hivev1.ClusterDeployment
+		remoteObjects          []runtime.Object
+		remoteErr              error
+		expectRemote           bool
+		expectErr              bool
+		expectDeleted          bool
+		expectedRequeueAfter   *time.Duration
+		expectedExpiringStatus corev1.ConditionStatus
+		expectedExpiringReason string
+		expectedEvent          string
+		expectedLastActivity   *time.Duration
+		expectedExpiration     *time.Duration
+	}{
+		{
+			name:                 "idle timeout not close, cluster not checked",
+			claim:                claimBuilder.Build(testclaim.WithIdleTimeout(4*time.Hour), assignedAgo(time.Hour)),
+			cd:                   cdBuilder.Build(),
+			expectedRequeueAfter: duration(2 * time.Hour),
+			expectedExpiration:   duration(3 * time.Hour),
+		},
+		{
+			name:                   "idle cluster warned about",
+			claim:                  claimBuilder.Build(testclaim.WithIdleTimeout(4*time.Hour), assignedAgo(3*time.Hour+30*time.Minute)),
+			cd:                     cdBuilder.Build(),
+			remoteObjects:          systemActivity,
+			expectRemote:           true,
+			expectedRequeueAfter:   duration(30 * time.Minute),
+			expectedExpiringStatus: corev1.ConditionTrue,
+			expectedExpiringReason: idleReason,
+			expectedEvent:          "Warning " + idleReason,
+			expectedExpiration:     duration(30 * time.Minute),
+		},
+		{
+			name:                 "activity on cluster extends idle timeout",
+			claim:                claimBuilder.Build(testclaim.WithIdleTimeout(4*time.Hour), assignedAgo(3*time.Hour+30*time.Minute)),
+			cd:                   cdBuilder.Build(),
+			remoteObjects:        append([]runtime.Object{namespace("my-app", 2*time.Hour), event("my-app", 10*time.Minute)}, systemActivity...),
+			expectRemote:         true,
+			expectedRequeueAfter: duration(2*time.Hour + 50*time.Minute),
+			expectedLastActivity: duration(10 * time.Minute),
+			expectedExpiration:   duration(3*time.Hour + 50*time.Minute),
+		},
+		{
+			name: "activity on cluster clears expiring condition",
+			claim: claimBuilder.Build(
+				testclaim.WithIdleTimeout(4*time.Hour),
+				assignedAgo(3*time.Hour+30*time.Minute),
+				testclaim.WithCondition(hivev1.ClusterClaimCondition{
+					Type:   hivev1.ClusterClaimExpiringCondition,
+					Status: corev1.ConditionTrue,
+					Reason: idleReason,
+				}),
+			),
+			cd:                     cdBuilder.Build(),
+			remoteObjects:          []runtime.Object{namespace("my-app", time.Minute)},
+			expectRemote:           true,
+			expectedRequeueAfter:   duration(2*time.Hour + 59*time.Minute),
+			expectedExpiringStatus: corev1.ConditionFalse,
+			expectedExpiringReason: notExpiringReason,
+			expectedLastActivity:   duration(time.Minute),
+			expectedExpiration:     duration(3*time.Hour + 59*time.Minute),
+		},
+		{
+			name:          "idle claim deleted",
+			claim:         claimBuilder.Build(testclaim.WithIdleTimeout(4*time.Hour), assignedAgo(5*time.Hour)),
+			cd:            cdBuilder.Build(),
+			remoteObjects: systemActivity,
+			expectRemote:  true,
+			expectDeleted: true,
+			expectedEvent: "Normal " + idleTimeoutReason,
+		},
+		{
+			name: "idle claim with earlier activity deleted",
+			claim: claimBuilder.Build(
+				testclaim.WithIdleTimeout(4*time.Hour),
+				assignedAgo(10*time.Hour),
+				testclaim.WithLastActivityTime(time.Now().Add(-5*time.Hour)),
+			),
+			cd:            cdBuilder.Build(),
+			remoteObjects: []runtime.Object{namespace("my-app", 5*time.Hour)},
+			expectRemote:  true,
+			expectDeleted: true,
+			expectedEvent: "Normal " + idleTimeoutReason,
+		},
+		{
+			name:  "hibernating cluster is not idle",
+			claim: claimBuilder.Build(testclaim.WithIdleTimeout(4*time.Hour), assignedAgo(5*time.Hour)),
+			cd: cdBuilder.Build(testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+				Type:   hivev1.ClusterHibernatingCondition,
+				Status: corev1.ConditionTrue,
+			})),
+			expectedRequeueAfter:   duration(activityRecheckInterval),
+			expectedExpiringStatus: corev1.ConditionFalse,
+			expectedExpiringReason: activityUnknownReason,
+		},
+		{
+			name:  "unreachable cluster is not idle",
+			claim: claimBuilder.Build(testclaim.WithIdleTimeout(4*time.Hour), assignedAgo(5*time.Hour)),
+			cd: cdBuilder.Build(testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+				Type:   hivev1.UnreachableCondition,
+				Status: corev1.ConditionTrue,
+			})),
+			expectedRequeueAfter:   duration(activityRecheckInterval),
+			expectedExpiringStatus: corev1.ConditionFalse,
+			expectedExpiringReason: activityUnknownReason,
+		},
+		{
+			name: "lifetime applies while activity is unknown",
+			claim: claimBuilder.Build(
+				testclaim.WithLifetime(6*time.Hour),
+				testclaim.WithIdleTimeout(4*time.Hour),
+				assignedAgo(5*time.Hour+45*time.Minute),
+			),
+			cd: cdBuilder.Build(testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+				Type:   hivev1.ClusterHibernatingCondition,
+				Status: corev1.ConditionTrue,
+			})),
+			expectedRequeueAfter:   duration(activityRecheckInterval),
+			expectedExpiringStatus: corev1.ConditionTrue,
+			expectedExpiringReason: lifetimeExpiringReason,
+			expectedEvent:          "Warning " + lifetimeExpiringReason,
+			expectedExpiration:     duration(15 * time.Minute),
+		},
+		{
+			name:  "activity recorded by hibernation controller",
+			claim: claimBuilder.Build(testclaim.WithIdleTimeout(4*time.Hour), assignedAgo(5*time.Hour)),
+			cd: cdBuilder.Build(func(cd *hivev1.ClusterDeployment) {
+				cd.Status.Activity = &hivev1.ClusterActivityStatus{LastActivityTime: &metav1.Time{Time: time.Now().Add(-time.Hour)}}
+			}),
+			expectRemote:         true,
+			expectedRequeueAfter: duration(2 * time.Hour),
+			expectedExpiration:   duration(3 * time.Hour),
+			expectedLastActivity: duration(time.Hour),
+		},
+		{
+			name:          "pool idle timeout shorter than claim",
+			claim:         claimBuilder.Build(testclaim.WithIdleTimeout(8*time.Hour), assignedAgo(3*time.Hour)),
+			pool:          poolBuilder.Build(testcp.WithClaimIdleTimeout(2 * time.Hour)),
+			cd:            cdBuilder.Build(),
+			remoteObjects: systemActivity,
+			expectRemote:  true,
+			expectDeleted: true,
+			expectedEvent: "Normal " + idleTimeoutReason,
+		},
+		{
+			name:                 "pool idle timeout applies to claim",
+			claim:                claimBuilder.Build(assignedAgo(time.Hour)),
+			pool:                 poolBuilder.Build(testcp.WithClaimIdleTimeout(4 * time.Hour)),
+			cd:                   cdBuilder.Build(),
+			expectedRequeueAfter: duration(2 * time.Hour),
+			expectedExpiration:   duration(3 * time.Hour),
+		},
+		{
+			name:                   "error observing activity",
+			claim:                  claimBuilder.Build(testclaim.WithIdleTimeout(4*time.Hour), assignedAgo(5*time.Hour)),
+			cd:                     cdBuilder.Build(),
+			remoteErr:              errors.New("connection refused"),
+			expectedRequeueAfter:   duration(activityRecheckInterval),
+			expectedExpiringStatus: corev1.ConditionFalse,
+			expectedExpiringReason: activityUnknownReason,
+		},
+		{
+			name:                   "lifetime ending warned about",
+			claim:                  claimBuilder.Build(testclaim.WithLifetime(2*time.Hour), assignedAgo(time.Hour+45*time.Minute)),
+			cd:                     cdBuilder.Build(),
+			expectedRequeueAfter:   duration(15 * time.Minute),
+			expectedExpiringStatus: corev1.ConditionTrue,
+			expectedExpiringReason: lifetimeExpiringReason,
+			expectedEvent:          "Warning " + lifetimeExpiringReason,
+			expectedExpiration:     duration(15 * time.Minute),
+		},
+		{
+			name: "lifetime ending before idle timeout",
+			claim: claimBuilder.Build(
+				testclaim.WithLifetime(2*time.Hour),
+				testclaim.WithIdleTimeout(4*time.Hour),
+				assignedAgo(time.Hour+45*time.Minute),
+			),
+			cd:                     cdBuilder.Build(),
+			expectedRequeueAfter:   duration(15 * time.Minute),
+			expectedExpiringStatus: corev1.ConditionTrue,
+			expectedExpiringReason: lifetimeExpiringReason,
+			expectedEvent:          "Warning " + lifetimeExpiringReason,
+			expectedExpiration:     duration(15 * time.Minute),
+		},
+		{
+			name:          "elapsed lifetime deleted",
+			claim:         claimBuilder.Build(testclaim.WithLifetime(2*time.Hour), assignedAgo(3*time.Hour)),
+			cd:            cdBuilder.Build(),
+			expectDeleted: true,
+			expectedEvent: "Normal " + lifetimeExpiredReason,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			existing := []runtime.Object{test.claim, test.cd, testRole(), testRoleBinding()}
+			if test.pool != nil {
+				existing = append(existing, test.pool)
+			}
+			c := fake.NewFakeClientWithScheme(scheme, existing...)
+			remoteClient := fake.NewFakeClientWithScheme(remoteScheme, test.remoteObjects...)
+			mockRemoteClientBuilder := remoteclientmock.NewMockBuilder(mockCtrl)
+			if test.expectRemote || test.remoteErr != nil {
+				mockRemoteClientBuilder.EXPECT().Build().Return(remoteClient, test.remoteErr)
+			}
+			recorder := record.NewFakeRecorder(10)
+			rcp := &ReconcileClusterClaim{
+				Client:              c,
+				logger:              log.WithField("controller", "clusterclaim"),
+				remoteClientBuilder: func(*hivev1.ClusterDeployment) remoteclient.Builder { return mockRemoteClientBuilder },
+				eventRecorder:       recorder,
+			}
+
+			result, err := rcp.Reconcile(reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: claimNamespace, Name: claimName},
+			})
+			if test.expectErr {
+				assert.Error(t, err, "expected error from Reconcile")
+			} else {
+				require.NoError(t, err, "unexpected error from Reconcile")
+			}
+
+			if test.expectedRequeueAfter == nil {
+				assert.Zero(t, result.RequeueAfter, "expected no requeue after")
+			} else {
+				assert.InDelta(t, test.expectedRequeueAfter.Seconds(), result.RequeueAfter.Seconds(), 10, "unexpected requeue after")
+			}
+
+			select {
+			case e := <-recorder.Events:
+				assert.True(t, strings.HasPrefix(e, test.expectedEvent+" "), "unexpected event %q", e)
+			default:
+				assert.Empty(t, test.expectedEvent, "expected an event")
+			}
+
+			claim := &hivev1.ClusterClaim{}
+			err = c.Get(context.Background(), client.ObjectKey{Namespace: claimNamespace, Name: claimName}, claim)
+			if test.expectDeleted {
+				assert.True(t, apierrors.IsNotFound(err), "expected claim to be deleted")
+				return
+			}
+			require.NoError(t, err, "unexpected error getting claim")
+
+			cond := controllerutils.FindClusterClaimCondition(claim.Status.Conditions, hivev1.ClusterClaimExpiringCondition)
+			if test.expectedExpiringStatus == "" {
+				assert.Nil(t, cond, "expected no Expiring condition")
+			} else if assert.NotNil(t, cond, "expected Expiring condition") {
+				assert.Equal(t, test.expectedExpiringStatus, cond.Status, "unexpected Expiring condition status")
+				assert.Equal(t, test.expectedExpiringReason, cond.Reason, "unexpected Expiring condition reason")
+			}
+
+			if test.expectedLastActivity == nil {
+				assert.Nil(t, claim.Status.LastActivityTime, "expected no last activity time")
+			} else if assert.NotNil(t, claim.Status.LastActivityTime, "expected last activity time") {
+				assert.WithinDuration(t, time.Now().Add(-*test.expectedLastActivity), claim.Status.LastActivityTime.Time, 10*time.Second, "unexpected last activity time")
+			}
+
+			if test.expectedExpiration == nil {
+				assert.Nil(t, claim.Status.ExpirationTime, "expected no expiration time")
+			} else if assert.NotNil(t, claim.Status.ExpirationTime, "expected expiration time") {
+				assert.WithinDuration(t, time.Now().Add(*test.expectedExpiration), claim.Status.ExpirationTime.Time, 10*time.Second, "unexpected expiration time")
+			}
+		})
+	}
+}
+
+func Test_getClaimIdleTimeout(t *testing.T) {
+	cases := []struct {
+		name     string
+		pool     *hivev1.ClusterPoolClaimLifetime
+		claim    *metav1.Duration
+		expected *metav1.Duration
+	}{
+		{name: "none"},
+		{name: "claim only", claim: &metav1.Duration{Duration: time.Hour}, expected: &metav1.Duration{Duration: time.Hour}},
+		{
+			name:     "pool only",
+			pool:     &hivev1.ClusterPoolClaimLifetime{IdleTimeout: &metav1.Duration{Duration: time.Hour}},
+			expected: &metav1.Duration{Duration: time.Hour},
+		},
+		{
+			name:     "claim shorter than pool",
+			pool:     &hivev1.ClusterPoolClaimLifetime{IdleTimeout: &metav1.Duration{Duration: 2 * time.Hour}},
+			claim:    &metav1.Duration{Duration: time.Hour},
+			expected: &metav1.Duration{Duration: time.Hour},
+		},
+		{
+			name:     "pool shorter than claim",
+			pool:     &hivev1.ClusterPoolClaimLifetime{IdleTimeout: &metav1.Duration{Duration: time.Hour}},
+			claim:    &metav1.Duration{Duration: 2 * time.Hour},
+			expected: &metav1.Duration{Duration: time.Hour},
+		},
+	}
+	for _, test := range cases {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, getClaimIdleTimeout(test.pool, test.claim))
+		})
+	}
+}
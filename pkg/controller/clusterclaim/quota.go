@@ -0,0 +1,106 @@
+package clusterclaim
+
+import (
+	"context"
+
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+// reconcileQuota reports whether the pending claim exceeds the quotas of its namespace or its requester. The pool
+// controller does not assign a cluster to a claim which exceeds its quotas. Claims which are let through again are
+// left waiting for a cluster from the pool.
+func (r *ReconcileClusterClaim) reconcileQuota(claim *hivev1.ClusterClaim, logger log.FieldLogger) error {
+	cond := controllerutils.FindClusterClaimCondition(claim.Status.Conditions, hivev1.ClusterClaimPendingCondition)
+	exceeded := cond != nil && cond.Status == corev1.ConditionTrue && cond.Reason == controllerutils.ClaimQuotaExceededReason
+	if !r.quota.IsLimited() && !exceeded {
+		return nil
+	}
+
+	var message string
+	if r.quota.IsLimited() {
+		claims := &hivev1.ClusterClaimList{}
+		if err := r.List(context.Background(), claims); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "error listing ClusterClaims")
+			return err
+		}
+		message = r.quota.ExceededClaimQuota(claim, claims.Items)
+	}
+
+	var conds []hivev1.ClusterClaimCondition
+	var changed bool
+	switch {
+	case message != "":
+		logger.WithField("reason", message).Info("claim exceeds its quota")
+		conds, changed = controllerutils.SetClusterClaimConditionWithChangeCheck(
+			claim.Status.Conditions,
+			hivev1.ClusterClaimPendingCondition,
+			corev1.ConditionTrue,
+			controllerutils.ClaimQuotaExceededReason,
+			message,
+			controllerutils.UpdateConditionIfReasonOrMessageChange,
+		)
+	case exceeded:
+		logger.Info("claim is within its quota")
+		conds, changed = controllerutils.SetClusterClaimConditionWithChangeCheck(
+			claim.Status.Conditions,
+			hivev1.ClusterClaimPendingCondition,
+			corev1.ConditionTrue,
+			"WaitingForCluster",
+			"Claim is within its quota, waiting for a cluster from the pool",
+			controllerutils.UpdateConditionIfReasonOrMessageChange,
+		)
+	}
+	if !changed {
+		return nil
+	}
+	claim.Status.Conditions = conds
+	if err := r.Status().Update(context.Background(), claim); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update status of ClusterClaim")
+		return err
+	}
+	return nil
+}
+
+// requestsForQuotaClaims returns the claims held back by their quotas which share the namespace or the requester of
+// the changed claim, as they may be let through once the changed claim is assigned a cluster or deleted.
+func requestsForQuotaClaims(c client.Client, logger log.FieldLogger) handler.ToRequestsFunc {
+	return func(o handler.MapObject) []reconcile.Request {
+		claim, ok := o.Object.(*hivev1.ClusterClaim)
+		if !ok {
+			return nil
+		}
+		claims := &hivev1.ClusterClaimList{}
+		if err := c.List(context.Background(), claims); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to list ClusterClaims for quota")
+			return nil
+		}
+		requester := claim.Annotations[constants.ClaimRequesterAnnotation]
+		var requests []reconcile.Request
+		for _, other := range claims.Items {
+			if other.Namespace == claim.Namespace && other.Name == claim.Name {
+				continue
+			}
+			cond := controllerutils.FindClusterClaimCondition(other.Status.Conditions, hivev1.ClusterClaimPendingCondition)
+			if cond == nil || cond.Reason != controllerutils.ClaimQuotaExceededReason {
+				continue
+			}
+			if other.Namespace != claim.Namespace &&
+				(requester == "" || other.Annotations[constants.ClaimRequesterAnnotation] != requester) {
+				continue
+			}
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: other.Namespace, Name: other.Name},
+			})
+		}
+		return requests
+	}
+}
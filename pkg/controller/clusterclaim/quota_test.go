@@ -0,0 +1,176 @@
+package clusterclaim
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	testclaim "github.com/openshift/hive/pkg/test/clusterclaim"
+	testgeneric "github.com/openshift/hive/pkg/test/generic"
+)
+
+func TestReconcileClusterClaimQuota(t *testing.T) {
+	scheme := runtime.NewScheme()
+	hivev1.AddToScheme(scheme)
+
+	quota := func(n int) *int {
+		return &n
+	}
+	claimBuilder := func(namespace, name, requester string, age time.Duration) testclaim.Builder {
+		return testclaim.FullBuilder(namespace, name, scheme).GenericOptions(
+			testgeneric.WithFinalizer(finalizer),
+			testgeneric.WithAnnotation(constants.ClaimRequesterAnnotation, requester),
+			testgeneric.WithCreationTimestamp(time.Now().Add(-age)),
+		).Options(
+			testclaim.WithPool(testLeasePoolName),
+		)
+	}
+	quotaExceeded := testclaim.WithCondition(hivev1.ClusterClaimCondition{
+		Type:   hivev1.ClusterClaimPendingCondition,
+		Status: corev1.ConditionTrue,
+		Reason: controllerutils.ClaimQuotaExceededReason,
+	})
+
+	tests := []struct {
+		name           string
+		quota          controllerutils.ClaimQuota
+		claim          *hivev1.ClusterClaim
+		existing       []runtime.Object
+		expectedReason string
+	}{
+		{
+			name:  "no quota",
+			claim: claimBuilder(claimNamespace, claimName, "test-user", time.Minute).Build(),
+			existing: []runtime.Object{
+				claimBuilder(claimNamespace, "older-claim", "test-user", time.Hour).Build(),
+			},
+		},
+		{
+			name:  "within namespace quota",
+			quota: controllerutils.ClaimQuota{PerNamespace: quota(2)},
+			claim: claimBuilder(claimNamespace, claimName, "test-user", time.Minute).Build(),
+			existing: []runtime.Object{
+				claimBuilder(claimNamespace, "older-claim", "other-user", time.Hour).Build(),
+				claimBuilder(claimNamespace, "newer-claim", "other-user", time.Second).Build(),
+			},
+		},
+		{
+			name:  "exceeds namespace quota",
+			quota: controllerutils.ClaimQuota{PerNamespace: quota(1)},
+			claim: claimBuilder(claimNamespace, claimName, "test-user", time.Minute).Build(),
+			existing: []runtime.Object{
+				claimBuilder(claimNamespace, "older-claim", "other-user", time.Hour).Build(),
+			},
+			expectedReason: controllerutils.ClaimQuotaExceededReason,
+		},
+		{
+			name:  "assigned claims count against quota",
+			quota: controllerutils.ClaimQuota{PerNamespace: quota(1)},
+			claim: claimBuilder(claimNamespace, claimName, "test-user", time.Hour).Build(),
+			existing: []runtime.Object{
+				claimBuilder(claimNamespace, "newer-claim", "other-user", time.Minute).Build(testclaim.WithCluster(clusterName)),
+			},
+			expectedReason: controllerutils.ClaimQuotaExceededReason,
+		},
+		{
+			name:  "exceeds requester quota",
+			quota: controllerutils.ClaimQuota{PerRequester: quota(1)},
+			claim: claimBuilder(claimNamespace, claimName, "test-user", time.Minute).Build(),
+			existing: []runtime.Object{
+				claimBuilder("other-namespace", "older-claim", "test-user", time.Hour).Build(),
+			},
+			expectedReason: controllerutils.ClaimQuotaExceededReason,
+		},
+		{
+			name:  "let through once within quota",
+			quota: controllerutils.ClaimQuota{PerNamespace: quota(1)},
+			claim: claimBuilder(claimNamespace, claimName, "test-user", time.Minute).Build(quotaExceeded),
+			existing: []runtime.Object{
+				claimBuilder(claimNamespace, "older-claim", "other-user", time.Hour).GenericOptions(
+					testgeneric.Deleted(),
+				).Build(),
+			},
+			expectedReason: "WaitingForCluster",
+		},
+		{
+			name:           "let through once quota is removed",
+			claim:          claimBuilder(claimNamespace, claimName, "test-user", time.Minute).Build(quotaExceeded),
+			expectedReason: "WaitingForCluster",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := fake.NewFakeClientWithScheme(scheme, append(test.existing, test.claim)...)
+			rcp := &ReconcileClusterClaim{
+				Client: c,
+				logger: log.New(),
+				quota:  test.quota,
+			}
+			_, err := rcp.Reconcile(reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: claimNamespace, Name: claimName},
+			})
+			require.NoError(t, err, "unexpected error from Reconcile")
+
+			actual := &hivev1.ClusterClaim{}
+			require.NoError(t, c.Get(context.Background(), client.ObjectKey{Namespace: claimNamespace, Name: claimName}, actual))
+			cond := controllerutils.FindClusterClaimCondition(actual.Status.Conditions, hivev1.ClusterClaimPendingCondition)
+			if test.expectedReason == "" {
+				assert.Nil(t, cond, "expected no pending condition")
+				return
+			}
+			if assert.NotNil(t, cond, "expected pending condition") {
+				assert.Equal(t, corev1.ConditionTrue, cond.Status, "unexpected pending condition status")
+				assert.Equal(t, test.expectedReason, cond.Reason, "unexpected pending condition reason")
+			}
+		})
+	}
+}
+
+func Test_requestsForQuotaClaims(t *testing.T) {
+	scheme := runtime.NewScheme()
+	hivev1.AddToScheme(scheme)
+
+	claimBuilder := func(namespace, name, requester string) testclaim.Builder {
+		return testclaim.FullBuilder(namespace, name, scheme).GenericOptions(
+			testgeneric.WithAnnotation(constants.ClaimRequesterAnnotation, requester),
+		)
+	}
+	quotaExceeded := testclaim.WithCondition(hivev1.ClusterClaimCondition{
+		Type:   hivev1.ClusterClaimPendingCondition,
+		Status: corev1.ConditionTrue,
+		Reason: controllerutils.ClaimQuotaExceededReason,
+	})
+	changed := claimBuilder(claimNamespace, claimName, "test-user").Build()
+	c := fake.NewFakeClientWithScheme(scheme,
+		changed,
+		claimBuilder(claimNamespace, "same-namespace", "other-user").Build(quotaExceeded),
+		claimBuilder("other-namespace", "same-requester", "test-user").Build(quotaExceeded),
+		claimBuilder("other-namespace", "other-requester", "other-user").Build(quotaExceeded),
+		claimBuilder(claimNamespace, "within-quota", "test-user").Build(),
+	)
+
+	requests := requestsForQuotaClaims(c, log.New())(handler.MapObject{Meta: changed, Object: changed})
+
+	assert.ElementsMatch(t,
+		[]reconcile.Request{
+			{NamespacedName: types.NamespacedName{Namespace: claimNamespace, Name: "same-namespace"}},
+			{NamespacedName: types.NamespacedName{Namespace: "other-namespace", Name: "same-requester"}},
+		},
+		requests,
+		"unexpected requests",
+	)
+}
@@ -0,0 +1,149 @@
+package clusterclaim
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apihelpers "github.com/openshift/hive/apis/helpers"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+// releaseCluster handles the cluster of a deleted claim according to the release policy of its pool.
+func (r *ReconcileClusterClaim) releaseCluster(cd *hivev1.ClusterDeployment, logger log.FieldLogger) error {
+	if cd.DeletionTimestamp != nil || controllerutils.IsClaimedClusterMarkedForRemoval(cd) {
+		return nil
+	}
+	pool := &hivev1.ClusterPool{}
+	switch err := r.Get(
+		context.Background(),
+		client.ObjectKey{Namespace: cd.Spec.ClusterPoolRef.Namespace, Name: cd.Spec.ClusterPoolRef.PoolName},
+		pool,
+	); {
+	case apierrors.IsNotFound(err):
+		logger.Info("cluster pool does not exist, destroying cluster")
+		return r.markClusterForRemoval(cd, logger)
+	case err != nil:
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "error getting ClusterPool")
+		return err
+	}
+
+	switch pool.Spec.ReleasePolicy {
+	case hivev1.ClusterPoolReleasePolicyHibernate:
+		return r.returnClusterToPool(pool, cd, logger)
+	case hivev1.ClusterPoolReleasePolicyQuarantine:
+		return r.quarantineCluster(cd, logger)
+	default:
+		return r.markClusterForRemoval(cd, logger)
+	}
+}
+
+func (r *ReconcileClusterClaim) markClusterForRemoval(cd *hivev1.ClusterDeployment, logger log.FieldLogger) error {
+	logger.Info("deleting clusterDeployment")
+	if cd.Annotations == nil {
+		cd.Annotations = map[string]string{}
+	}
+	cd.Annotations[constants.ClusterClaimRemoveClusterAnnotation] = "true"
+	if err := r.Update(context.Background(), cd); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "error updating ClusterDeployment to mark it for deletion")
+		return err
+	}
+	return nil
+}
+
+func (r *ReconcileClusterClaim) quarantineCluster(cd *hivev1.ClusterDeployment, logger log.FieldLogger) error {
+	if cd.Annotations[constants.ClusterQuarantinedAnnotation] == "true" {
+		return nil
+	}
+	logger.Info("quarantining clusterDeployment for inspection")
+	if cd.Annotations == nil {
+		cd.Annotations = map[string]string{}
+	}
+	cd.Annotations[constants.ClusterQuarantinedAnnotation] = "true"
+	if err := r.Update(context.Background(), cd); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "error updating ClusterDeployment to quarantine it")
+		return err
+	}
+	return nil
+}
+
+// returnClusterToPool unclaims the cluster so that it can be claimed again. The admin credentials of the cluster are
+// rotated so that the previous claimant loses access to it, which the kubeadmin controller does while the cluster is
+// marked with the credentials rotating annotation. When the pool has rebaseline SyncSets, they are copied to the
+// namespace of the cluster, and when the pool has a sanitization policy, the cluster is marked for the sanitization
+// controller. The cluster is kept running until the pool controller sees it rebaselined, and then hibernated.
+func (r *ReconcileClusterClaim) returnClusterToPool(pool *hivev1.ClusterPool, cd *hivev1.ClusterDeployment, logger log.FieldLogger) error {
+	for _, ref := range pool.Spec.RebaselineSyncSets {
+		if err := r.createRebaselineSyncSet(pool, ref.Name, cd, logger); err != nil {
+			return err
+		}
+	}
+
+	logger.Info("returning clusterDeployment to pool")
+	cd.Spec.ClusterPoolRef.ClaimName = ""
+	for _, annotation := range []string{
+		constants.ClaimNamespaceAnnotation,
+		constants.ClaimNameAnnotation,
+		constants.ClaimSubjectsAnnotation,
+		constants.ClaimTicketIDAnnotation,
+		constants.ClaimTagsAppliedAnnotation,
+	} {
+		delete(cd.Annotations, annotation)
+	}
+	if cd.Annotations == nil {
+		cd.Annotations = map[string]string{}
+	}
+	cd.Annotations[constants.ClusterRebaseliningAnnotation] = "true"
+	cd.Annotations[constants.ClusterCredentialsRotatingAnnotation] = "true"
+	if pool.Spec.Sanitization != nil {
+		cd.Annotations[constants.ClusterSanitizingAnnotation] = "true"
+	}
+	if cd.Spec.Kubeadmin == nil {
+		cd.Spec.Kubeadmin = &hivev1.KubeadminManagement{}
+	}
+	cd.Spec.Kubeadmin.PasswordRotation = fmt.Sprintf("released-%d", time.Now().Unix())
+	if err := r.Update(context.Background(), cd); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "error updating ClusterDeployment to return it to the pool")
+		return err
+	}
+	return nil
+}
+
+func (r *ReconcileClusterClaim) createRebaselineSyncSet(pool *hivev1.ClusterPool, name string, cd *hivev1.ClusterDeployment, logger log.FieldLogger) error {
+	logger = logger.WithField("syncSet", name)
+	ss := &hivev1.SyncSet{}
+	if err := r.Get(context.Background(), client.ObjectKey{Namespace: pool.Namespace, Name: name}, ss); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "error getting rebaseline SyncSet")
+		return err
+	}
+	rebaseline := &hivev1.SyncSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: cd.Namespace,
+			Name:      apihelpers.GetResourceName(cd.Name, "rebaseline-"+name),
+			Labels: map[string]string{
+				constants.RebaselineSyncSetLabel:     "true",
+				constants.ClusterDeploymentNameLabel: cd.Name,
+			},
+		},
+		Spec: hivev1.SyncSetSpec{
+			SyncSetCommonSpec:     *ss.Spec.SyncSetCommonSpec.DeepCopy(),
+			ClusterDeploymentRefs: []corev1.LocalObjectReference{{Name: cd.Name}},
+		},
+	}
+	// The rebaseline SyncSet is deleted once applied, which must not delete what it applied.
+	rebaseline.Spec.ResourceApplyMode = hivev1.UpsertResourceApplyMode
+	logger.Info("creating rebaseline SyncSet for clusterDeployment")
+	if err := r.Create(context.Background(), rebaseline); err != nil && !apierrors.IsAlreadyExists(err) {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "error creating rebaseline SyncSet")
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package clusterclaim
+
+import (
+	"context"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	testclaim "github.com/openshift/hive/pkg/test/clusterclaim"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+	testcp "github.com/openshift/hive/pkg/test/clusterpool"
+	testgeneric "github.com/openshift/hive/pkg/test/generic"
+)
+
+func TestReleaseClusterOfDeletedClaim(t *testing.T) {
+	scheme := runtime.NewScheme()
+	hivev1.AddToScheme(scheme)
+	rbacv1.AddToScheme(scheme)
+
+	poolBuilder := testcp.FullBuilder(claimNamespace, "test-pool", scheme).Options(
+		testcp.ForAWS("secret", "us-east-1"),
+	)
+	claim := testclaim.FullBuilder(claimNamespace, claimName, scheme).GenericOptions(
+		testgeneric.WithFinalizer(finalizer),
+		testgeneric.Deleted(),
+	).Build(testclaim.WithCluster(clusterName))
+	cd := testcd.FullBuilder(clusterName, clusterName, scheme).GenericOptions(
+		testgeneric.WithAnnotation(constants.ClaimNameAnnotation, claimName),
+	).Build(
+		testcd.Installed(),
+		testcd.WithPowerState(hivev1.RunningClusterPowerState),
+		testcd.WithClusterPoolReference(claimNamespace, "test-pool", claimName),
+	)
+	wipeSyncSet := &hivev1.SyncSet{
+		ObjectMeta: metav1.ObjectMeta{Namespace: claimNamespace, Name: "wipe"},
+		Spec: hivev1.SyncSetSpec{
+			SyncSetCommonSpec: hivev1.SyncSetCommonSpec{
+				ResourceApplyMode: hivev1.SyncResourceApplyMode,
+				Patches:           []hivev1.SyncObjectPatch{{Kind: "ConfigMap", Name: "test", Patch: "{}"}},
+			},
+		},
+	}
+
+	tests := []struct {
+		name                      string
+		pool                      *hivev1.ClusterPool
+		expectRemoval             bool
+		expectClaimed             bool
+		expectPowerState          hivev1.ClusterPowerState
+		expectRebaselining        bool
+		expectQuarantined         bool
+		expectSanitizing          bool
+		expectRebaselineSSs       int
+		expectCredentialsRotation bool
+	}{
+		{
+			name:             "destroy by default",
+			pool:             poolBuilder.Build(),
+			expectRemoval:    true,
+			expectClaimed:    true,
+			expectPowerState: hivev1.RunningClusterPowerState,
+		},
+		{
+			name:                      "hibernate and return to pool",
+			pool:                      poolBuilder.Build(testcp.WithReleasePolicy(hivev1.ClusterPoolReleasePolicyHibernate)),
+			expectPowerState:          hivev1.RunningClusterPowerState,
+			expectRebaselining:        true,
+			expectCredentialsRotation: true,
+		},
+		{
+			name:                      "rebaseline before returning to pool",
+			pool:                      poolBuilder.Build(testcp.WithReleasePolicy(hivev1.ClusterPoolReleasePolicyHibernate, "wipe")),
+			expectPowerState:          hivev1.RunningClusterPowerState,
+			expectRebaselining:        true,
+			expectCredentialsRotation: true,
+			expectRebaselineSSs:       1,
+		},
+		{
+			name: "sanitize before returning to pool",
+			pool: poolBuilder.Build(
+				testcp.WithReleasePolicy(hivev1.ClusterPoolReleasePolicyHibernate),
+				func(pool *hivev1.ClusterPool) {
+					pool.Spec.Sanitization = &hivev1.ClusterPoolSanitization{}
+				},
+			),
+			expectPowerState:          hivev1.RunningClusterPowerState,
+			expectRebaselining:        true,
+			expectCredentialsRotation: true,
+			expectSanitizing:          true,
+		},
+		{
+			name:              "quarantine",
+			pool:              poolBuilder.Build(testcp.WithReleasePolicy(hivev1.ClusterPoolReleasePolicyQuarantine)),
+			expectClaimed:     true,
+			expectPowerState:  hivev1.RunningClusterPowerState,
+			expectQuarantined: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := fake.NewFakeClientWithScheme(scheme, claim.DeepCopy(), cd.DeepCopy(), test.pool, wipeSyncSet.DeepCopy())
+			rcp := &ReconcileClusterClaim{
+				Client: c,
+				logger: log.New(),
+			}
+			_, err := rcp.Reconcile(reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: claimNamespace, Name: claimName},
+			})
+			require.NoError(t, err, "unexpected error from Reconcile")
+
+			actual := &hivev1.ClusterDeployment{}
+			require.NoError(t, c.Get(context.Background(), client.ObjectKey{Namespace: clusterName, Name: clusterName}, actual))
+			assert.Equal(t, test.expectRemoval, actual.Annotations[constants.ClusterClaimRemoveClusterAnnotation] == "true", "unexpected removal")
+			assert.Equal(t, test.expectClaimed, actual.Spec.ClusterPoolRef.ClaimName == claimName, "unexpected claim of cluster")
+			assert.Equal(t, test.expectPowerState, actual.Spec.PowerState, "unexpected power state")
+			assert.Equal(t, test.expectRebaselining, actual.Annotations[constants.ClusterRebaseliningAnnotation] == "true", "unexpected rebaselining")
+			assert.Equal(t, test.expectQuarantined, actual.Annotations[constants.ClusterQuarantinedAnnotation] == "true", "unexpected quarantine")
+			assert.Equal(t, test.expectSanitizing, actual.Annotations[constants.ClusterSanitizingAnnotation] == "true", "unexpected sanitizing")
+			assert.Equal(t, test.expectCredentialsRotation, actual.Annotations[constants.ClusterCredentialsRotatingAnnotation] == "true", "unexpected credentials rotation")
+			if test.expectCredentialsRotation && assert.NotNil(t, actual.Spec.Kubeadmin, "expected kubeadmin management") {
+				assert.NotEmpty(t, actual.Spec.Kubeadmin.PasswordRotation, "expected kubeadmin password rotation")
+			}
+			if !test.expectClaimed {
+				assert.NotContains(t, actual.Annotations, constants.ClaimNameAnnotation, "expected claim metadata to be removed")
+			}
+
+			syncSets := &hivev1.SyncSetList{}
+			require.NoError(t, c.List(context.Background(), syncSets, client.InNamespace(clusterName)))
+			if assert.Len(t, syncSets.Items, test.expectRebaselineSSs, "unexpected rebaseline SyncSets") && test.expectRebaselineSSs > 0 {
+				ss := syncSets.Items[0]
+				assert.Equal(t, "true", ss.Labels[constants.RebaselineSyncSetLabel], "expected rebaseline label")
+				assert.Equal(t, hivev1.UpsertResourceApplyMode, ss.Spec.ResourceApplyMode, "expected upsert apply mode")
+				assert.Equal(t, wipeSyncSet.Spec.Patches, ss.Spec.Patches, "unexpected patches")
+				assert.Equal(t, clusterName, ss.Spec.ClusterDeploymentRefs[0].Name, "unexpected cluster deployment ref")
+			}
+		})
+	}
+}
@@ -0,0 +1,236 @@
+package clusterclaim
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/awsclient"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	// tagResourcesBatchSize is the maximum number of resources which can be tagged in one AWS TagResources call.
+	tagResourcesBatchSize = 20
+
+	// tagRetryInterval is how long to wait before retrying to tag the cloud resources of a cluster after a failure.
+	tagRetryInterval = 10 * time.Minute
+
+	// awsMaxTagsPerResource is the maximum number of tags of an AWS resource.
+	awsMaxTagsPerResource = 50
+
+	// awsMaxTagKeyLength and awsMaxTagValueLength are the maximum lengths, in characters, of the key and the value of
+	// an AWS tag.
+	awsMaxTagKeyLength   = 128
+	awsMaxTagValueLength = 256
+)
+
+// claimMetadata returns the metadata of the claim which is propagated to the claimed ClusterDeployment and to the
+// tags of the cloud resources of the cluster, for chargeback to the team consuming the cluster.
+func claimMetadata(claim *hivev1.ClusterClaim) map[string]string {
+	subjects := make([]string, len(claim.Spec.Subjects))
+	for i, s := range claim.Spec.Subjects {
+		subjects[i] = fmt.Sprintf("%s:%s", s.Kind, s.Name)
+	}
+	metadata := map[string]string{
+		constants.ClaimNamespaceAnnotation: claim.Namespace,
+		constants.ClaimNameAnnotation:      claim.Name,
+		constants.ClaimSubjectsAnnotation:  strings.Join(subjects, " "),
+	}
+	if ticketID := claim.Annotations[constants.ClaimTicketIDAnnotation]; ticketID != "" {
+		metadata[constants.ClaimTicketIDAnnotation] = ticketID
+	}
+	return metadata
+}
+
+// reconcileClaimMetadata copies the metadata of the claim to the annotations of the ClusterDeployment and, once the
+// cluster is installed, tags its cloud resources with it. Tagging is best-effort: a failure to tag the resources is
+// returned as tagErr, to be reported in the conditions of the claim, and does not fail the claim.
+func (r *ReconcileClusterClaim) reconcileClaimMetadata(claim *hivev1.ClusterClaim, cd *hivev1.ClusterDeployment, logger log.FieldLogger) (tagErr error, err error) {
+	metadata := claimMetadata(claim)
+	if cd.Annotations == nil {
+		cd.Annotations = map[string]string{}
+	}
+	changed := false
+	for k, v := range metadata {
+		if cd.Annotations[k] != v {
+			cd.Annotations[k] = v
+			changed = true
+		}
+	}
+	if _, ok := metadata[constants.ClaimTicketIDAnnotation]; !ok {
+		if _, ok := cd.Annotations[constants.ClaimTicketIDAnnotation]; ok {
+			delete(cd.Annotations, constants.ClaimTicketIDAnnotation)
+			changed = true
+		}
+	}
+	if changed {
+		delete(cd.Annotations, constants.ClaimTagsAppliedAnnotation)
+	}
+
+	if cd.Annotations[constants.ClaimTagsAppliedAnnotation] != "true" && canTagCloudResources(cd) {
+		if tagErr = r.tagCloudResources(cd, metadata, logger); tagErr != nil {
+			logger.WithError(tagErr).Warn("could not tag cloud resources with claim metadata")
+		} else {
+			cd.Annotations[constants.ClaimTagsAppliedAnnotation] = "true"
+			changed = true
+		}
+	}
+
+	if !changed {
+		return tagErr, nil
+	}
+	logger.Info("updating claim metadata of ClusterDeployment")
+	if err := r.Update(context.Background(), cd); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update claim metadata of ClusterDeployment")
+		return tagErr, err
+	}
+	return tagErr, nil
+}
+
+// setCloudResourcesTaggedCondition sets the CloudResourcesTagged condition of the claim from the outcome of tagging
+// the cloud resources of the cluster. The condition is not set for clusters whose resources are not tagged.
+func setCloudResourcesTaggedCondition(conds []hivev1.ClusterClaimCondition, cd *hivev1.ClusterDeployment, tagErr error) ([]hivev1.ClusterClaimCondition, bool) {
+	switch {
+	case tagErr != nil:
+		return controllerutils.SetClusterClaimConditionWithChangeCheck(
+			conds,
+			hivev1.ClusterClaimCloudResourcesTaggedCondition,
+			corev1.ConditionFalse,
+			"TaggingFailed",
+			tagErr.Error(),
+			controllerutils.UpdateConditionIfReasonOrMessageChange,
+		)
+	case cd.Annotations[constants.ClaimTagsAppliedAnnotation] == "true":
+		return controllerutils.SetClusterClaimConditionWithChangeCheck(
+			conds,
+			hivev1.ClusterClaimCloudResourcesTaggedCondition,
+			corev1.ConditionTrue,
+			"Tagged",
+			"Cloud resources are tagged with the claim metadata",
+			controllerutils.UpdateConditionIfReasonOrMessageChange,
+		)
+	}
+	return conds, false
+}
+
+// validateAWSTags checks the tags against the limits of AWS on the number of tags of a resource and on the length
+// of their keys and values.
+func validateAWSTags(tags map[string]string) error {
+	if len(tags) > awsMaxTagsPerResource {
+		return fmt.Errorf("%d tags exceed the limit of %d tags of an AWS resource", len(tags), awsMaxTagsPerResource)
+	}
+	for k, v := range tags {
+		if n := utf8.RuneCountInString(k); n == 0 || n > awsMaxTagKeyLength {
+			return fmt.Errorf("length of tag key %q is not between 1 and %d characters", k, awsMaxTagKeyLength)
+		}
+		if strings.HasPrefix(strings.ToLower(k), "aws:") {
+			return fmt.Errorf("tag key %q uses the reserved aws: prefix", k)
+		}
+		if utf8.RuneCountInString(v) > awsMaxTagValueLength {
+			return fmt.Errorf("value of tag %s is longer than %d characters", k, awsMaxTagValueLength)
+		}
+	}
+	return nil
+}
+
+// canTagCloudResources returns whether the cloud resources of the cluster can be tagged with the claim metadata.
+// Only AWS clusters are supported.
+func canTagCloudResources(cd *hivev1.ClusterDeployment) bool {
+	return cd.Spec.Installed && cd.Spec.ClusterMetadata != nil && cd.Spec.Platform.AWS != nil
+}
+
+// tagCloudResources adds the tags to all of the AWS resources owned by the cluster.
+func (r *ReconcileClusterClaim) tagCloudResources(cd *hivev1.ClusterDeployment, tags map[string]string, logger log.FieldLogger) error {
+	if err := validateAWSTags(tags); err != nil {
+		return err
+	}
+	awsClient, err := r.awsClientFn(cd, r.Client, logger)
+	if err != nil {
+		return errors.Wrap(err, "could not create AWS client")
+	}
+
+	var arns []string
+	input := &resourcegroupstaggingapi.GetResourcesInput{
+		TagFilters: []*resourcegroupstaggingapi.TagFilter{{
+			Key:    aws.String(fmt.Sprintf("kubernetes.io/cluster/%s", cd.Spec.ClusterMetadata.InfraID)),
+			Values: aws.StringSlice([]string{"owned"}),
+		}},
+	}
+	var full []string
+	if err := awsClient.GetResourcesPages(input, func(page *resourcegroupstaggingapi.GetResourcesOutput, lastPage bool) bool {
+		for _, resource := range page.ResourceTagMappingList {
+			arn := aws.StringValue(resource.ResourceARN)
+			// Tags whose keys the resource already has are replaced rather than added.
+			count := len(resource.Tags)
+			for k := range tags {
+				if !hasTag(resource.Tags, k) {
+					count++
+				}
+			}
+			if count > awsMaxTagsPerResource {
+				full = append(full, arn)
+				continue
+			}
+			arns = append(arns, arn)
+		}
+		return !lastPage
+	}); err != nil {
+		return errors.Wrap(err, "could not list cloud resources of cluster")
+	}
+	for _, arn := range full {
+		logger.WithField("resource", arn).Warn("not tagging cloud resource which would exceed the limit of tags")
+	}
+
+	for start := 0; start < len(arns); start += tagResourcesBatchSize {
+		end := start + tagResourcesBatchSize
+		if end > len(arns) {
+			end = len(arns)
+		}
+		out, err := awsClient.TagResources(&resourcegroupstaggingapi.TagResourcesInput{
+			ResourceARNList: aws.StringSlice(arns[start:end]),
+			Tags:            aws.StringMap(tags),
+		})
+		if err != nil {
+			return errors.Wrap(err, "could not tag cloud resources of cluster")
+		}
+		for arn, failure := range out.FailedResourcesMap {
+			logger.WithField("resource", arn).
+				WithField("error", aws.StringValue(failure.ErrorMessage)).
+				Warn("could not tag cloud resource")
+		}
+		if len(out.FailedResourcesMap) > 0 {
+			return fmt.Errorf("could not tag %d cloud resources of cluster", len(out.FailedResourcesMap))
+		}
+	}
+	logger.WithField("resources", len(arns)).Info("tagged cloud resources with claim metadata")
+	if len(full) > 0 {
+		return fmt.Errorf("could not tag %d cloud resources of cluster which would exceed the limit of %d tags", len(full), awsMaxTagsPerResource)
+	}
+	return nil
+}
+
+// hasTag returns whether the tags include one with the key.
+func hasTag(tags []*resourcegroupstaggingapi.Tag, key string) bool {
+	for _, tag := range tags {
+		if aws.StringValue(tag.Key) == key {
+			return true
+		}
+	}
+	return false
+}
+
+func getAWSClient(cd *hivev1.ClusterDeployment, c client.Client, logger log.FieldLogger) (awsclient.Client, error) {
+	return awsclient.NewClient(c, cd.Spec.Platform.AWS.CredentialsSecretRef.Name, cd.Namespace, cd.Spec.Platform.AWS.Region)
+}
@@ -0,0 +1,256 @@
+package clusterclaim
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
+	"github.com/golang/mock/gomock"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
+	"github.com/openshift/hive/pkg/awsclient"
+	mockaws "github.com/openshift/hive/pkg/awsclient/mock"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	testclaim "github.com/openshift/hive/pkg/test/clusterclaim"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+	testgeneric "github.com/openshift/hive/pkg/test/generic"
+)
+
+func TestReconcileClusterClaimMetadata(t *testing.T) {
+	scheme := runtime.NewScheme()
+	hivev1.AddToScheme(scheme)
+	rbacv1.AddToScheme(scheme)
+
+	claimBuilder := testclaim.FullBuilder(claimNamespace, claimName, scheme).Options(
+		testclaim.WithSubjects(subjects),
+		testclaim.WithCluster(clusterName),
+	)
+	cdBuilder := testcd.FullBuilder(clusterName, clusterName, scheme).Options(
+		testcd.WithClusterPoolReference(claimNamespace, "test-pool", claimName),
+		testcd.WithAWSPlatform(&hivev1aws.Platform{
+			CredentialsSecretRef: corev1.LocalObjectReference{Name: "aws-creds"},
+			Region:               "us-east-1",
+		}),
+		func(cd *hivev1.ClusterDeployment) {
+			cd.Spec.ClusterMetadata = &hivev1.ClusterMetadata{
+				InfraID:                  "test-infra-id",
+				AdminKubeconfigSecretRef: corev1.LocalObjectReference{Name: kubeconfigSecretName},
+				AdminPasswordSecretRef:   corev1.LocalObjectReference{Name: passwordSecretName},
+			}
+		},
+	)
+	expectedTags := map[string]string{
+		constants.ClaimNamespaceAnnotation: claimNamespace,
+		constants.ClaimNameAnnotation:      claimName,
+		constants.ClaimSubjectsAnnotation:  "Group:test-group User:test-user",
+		constants.ClaimTicketIDAnnotation:  "TICKET-1",
+	}
+
+	tests := []struct {
+		name                string
+		claim               *hivev1.ClusterClaim
+		cd                  *hivev1.ClusterDeployment
+		resources           int
+		resourceTags        int
+		tagErr              error
+		expectedAnnotations map[string]string
+		expectTagged        bool
+		expectedCondition   corev1.ConditionStatus
+	}{
+		{
+			name:  "uninstalled cluster not tagged",
+			claim: claimBuilder.GenericOptions(testgeneric.WithAnnotation(constants.ClaimTicketIDAnnotation, "TICKET-1")).Build(),
+			cd:    cdBuilder.Build(),
+			expectedAnnotations: map[string]string{
+				constants.ClaimNamespaceAnnotation: claimNamespace,
+				constants.ClaimNameAnnotation:      claimName,
+				constants.ClaimSubjectsAnnotation:  "Group:test-group User:test-user",
+				constants.ClaimTicketIDAnnotation:  "TICKET-1",
+			},
+		},
+		{
+			name:      "installed cluster tagged",
+			claim:     claimBuilder.GenericOptions(testgeneric.WithAnnotation(constants.ClaimTicketIDAnnotation, "TICKET-1")).Build(),
+			cd:        cdBuilder.Build(testcd.Installed()),
+			resources: 25,
+			expectedAnnotations: map[string]string{
+				constants.ClaimNamespaceAnnotation:   claimNamespace,
+				constants.ClaimNameAnnotation:        claimName,
+				constants.ClaimSubjectsAnnotation:    "Group:test-group User:test-user",
+				constants.ClaimTicketIDAnnotation:    "TICKET-1",
+				constants.ClaimTagsAppliedAnnotation: "true",
+			},
+			expectTagged:      true,
+			expectedCondition: corev1.ConditionTrue,
+		},
+		{
+			name:  "tags already applied",
+			claim: claimBuilder.GenericOptions(testgeneric.WithAnnotation(constants.ClaimTicketIDAnnotation, "TICKET-1")).Build(),
+			cd: cdBuilder.GenericOptions(
+				testgeneric.WithAnnotation(constants.ClaimNamespaceAnnotation, claimNamespace),
+				testgeneric.WithAnnotation(constants.ClaimNameAnnotation, claimName),
+				testgeneric.WithAnnotation(constants.ClaimSubjectsAnnotation, "Group:test-group User:test-user"),
+				testgeneric.WithAnnotation(constants.ClaimTicketIDAnnotation, "TICKET-1"),
+				testgeneric.WithAnnotation(constants.ClaimTagsAppliedAnnotation, "true"),
+			).Build(testcd.Installed()),
+			expectedAnnotations: map[string]string{
+				constants.ClaimNamespaceAnnotation:   claimNamespace,
+				constants.ClaimNameAnnotation:        claimName,
+				constants.ClaimSubjectsAnnotation:    "Group:test-group User:test-user",
+				constants.ClaimTicketIDAnnotation:    "TICKET-1",
+				constants.ClaimTagsAppliedAnnotation: "true",
+			},
+			expectedCondition: corev1.ConditionTrue,
+		},
+		{
+			name:  "changed ticket retagged",
+			claim: claimBuilder.GenericOptions(testgeneric.WithAnnotation(constants.ClaimTicketIDAnnotation, "TICKET-1")).Build(),
+			cd: cdBuilder.GenericOptions(
+				testgeneric.WithAnnotation(constants.ClaimNamespaceAnnotation, claimNamespace),
+				testgeneric.WithAnnotation(constants.ClaimNameAnnotation, claimName),
+				testgeneric.WithAnnotation(constants.ClaimSubjectsAnnotation, "Group:test-group User:test-user"),
+				testgeneric.WithAnnotation(constants.ClaimTicketIDAnnotation, "TICKET-0"),
+				testgeneric.WithAnnotation(constants.ClaimTagsAppliedAnnotation, "true"),
+			).Build(testcd.Installed()),
+			resources: 3,
+			expectedAnnotations: map[string]string{
+				constants.ClaimNamespaceAnnotation:   claimNamespace,
+				constants.ClaimNameAnnotation:        claimName,
+				constants.ClaimSubjectsAnnotation:    "Group:test-group User:test-user",
+				constants.ClaimTicketIDAnnotation:    "TICKET-1",
+				constants.ClaimTagsAppliedAnnotation: "true",
+			},
+			expectTagged:      true,
+			expectedCondition: corev1.ConditionTrue,
+		},
+		{
+			name:      "tagging failure does not block claim",
+			claim:     claimBuilder.GenericOptions(testgeneric.WithAnnotation(constants.ClaimTicketIDAnnotation, "TICKET-1")).Build(),
+			cd:        cdBuilder.Build(testcd.Installed()),
+			resources: 3,
+			tagErr:    errors.New("access denied"),
+			expectedAnnotations: map[string]string{
+				constants.ClaimNamespaceAnnotation: claimNamespace,
+				constants.ClaimNameAnnotation:      claimName,
+				constants.ClaimSubjectsAnnotation:  "Group:test-group User:test-user",
+				constants.ClaimTicketIDAnnotation:  "TICKET-1",
+			},
+			expectTagged:      true,
+			expectedCondition: corev1.ConditionFalse,
+		},
+		{
+			name:  "tag value too long",
+			claim: claimBuilder.GenericOptions(testgeneric.WithAnnotation(constants.ClaimTicketIDAnnotation, strings.Repeat("x", 257))).Build(),
+			cd:    cdBuilder.Build(testcd.Installed()),
+			expectedAnnotations: map[string]string{
+				constants.ClaimTicketIDAnnotation: strings.Repeat("x", 257),
+			},
+			expectedCondition: corev1.ConditionFalse,
+		},
+		{
+			name:         "resources at tag limit not tagged",
+			claim:        claimBuilder.GenericOptions(testgeneric.WithAnnotation(constants.ClaimTicketIDAnnotation, "TICKET-1")).Build(),
+			cd:           cdBuilder.Build(testcd.Installed()),
+			resources:    3,
+			resourceTags: 48,
+			expectedAnnotations: map[string]string{
+				constants.ClaimTicketIDAnnotation: "TICKET-1",
+			},
+			expectTagged:      true,
+			expectedCondition: corev1.ConditionFalse,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			mockAWSClient := mockaws.NewMockClient(mockCtrl)
+			if test.expectTagged {
+				var arns []string
+				for i := 0; i < test.resources; i++ {
+					arns = append(arns, fmt.Sprintf("arn:aws:ec2:us-east-1:123456789012:instance/i-%d", i))
+				}
+				var resourceTags []*resourcegroupstaggingapi.Tag
+				for i := 0; i < test.resourceTags; i++ {
+					resourceTags = append(resourceTags, &resourcegroupstaggingapi.Tag{Key: aws.String(fmt.Sprintf("tag-%d", i)), Value: aws.String("value")})
+				}
+				mockAWSClient.EXPECT().GetResourcesPages(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(input *resourcegroupstaggingapi.GetResourcesInput, fn func(*resourcegroupstaggingapi.GetResourcesOutput, bool) bool) error {
+						assert.Equal(t, "kubernetes.io/cluster/test-infra-id", aws.StringValue(input.TagFilters[0].Key), "unexpected tag filter")
+						page := &resourcegroupstaggingapi.GetResourcesOutput{}
+						for _, arn := range arns {
+							page.ResourceTagMappingList = append(page.ResourceTagMappingList, &resourcegroupstaggingapi.ResourceTagMapping{
+								ResourceARN: aws.String(arn),
+								Tags:        resourceTags,
+							})
+						}
+						fn(page, true)
+						return nil
+					})
+				if test.resourceTags+len(expectedTags) <= awsMaxTagsPerResource {
+					var tagged []string
+					mockAWSClient.EXPECT().TagResources(gomock.Any()).DoAndReturn(
+						func(input *resourcegroupstaggingapi.TagResourcesInput) (*resourcegroupstaggingapi.TagResourcesOutput, error) {
+							assert.LessOrEqual(t, len(input.ResourceARNList), tagResourcesBatchSize, "too many resources tagged at once")
+							assert.Equal(t, expectedTags, aws.StringValueMap(input.Tags), "unexpected tags")
+							tagged = append(tagged, aws.StringValueSlice(input.ResourceARNList)...)
+							return &resourcegroupstaggingapi.TagResourcesOutput{}, test.tagErr
+						}).MinTimes(1)
+					defer func() {
+						assert.ElementsMatch(t, arns, tagged, "unexpected resources tagged")
+					}()
+				}
+			}
+
+			c := fake.NewFakeClientWithScheme(scheme, test.claim, test.cd)
+			rcp := &ReconcileClusterClaim{
+				Client: c,
+				logger: log.New(),
+				awsClientFn: func(*hivev1.ClusterDeployment, client.Client, log.FieldLogger) (awsclient.Client, error) {
+					return mockAWSClient, nil
+				},
+			}
+			result, err := rcp.Reconcile(reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: claimNamespace, Name: claimName},
+			})
+			require.NoError(t, err, "unexpected error from Reconcile")
+			if test.expectedCondition == corev1.ConditionFalse {
+				assert.Equal(t, tagRetryInterval, result.RequeueAfter, "expected requeue to retry tagging")
+			}
+
+			claim := &hivev1.ClusterClaim{}
+			require.NoError(t, c.Get(context.Background(), client.ObjectKey{Namespace: claimNamespace, Name: claimName}, claim))
+			cond := controllerutils.FindClusterClaimCondition(claim.Status.Conditions, hivev1.ClusterClaimCloudResourcesTaggedCondition)
+			if test.expectedCondition == "" {
+				assert.Nil(t, cond, "unexpected tagged condition")
+			} else if assert.NotNil(t, cond, "expected tagged condition") {
+				assert.Equal(t, test.expectedCondition, cond.Status, "unexpected status of tagged condition")
+			}
+
+			cd := &hivev1.ClusterDeployment{}
+			require.NoError(t, c.Get(context.Background(), client.ObjectKey{Namespace: clusterName, Name: clusterName}, cd))
+			for k, v := range test.expectedAnnotations {
+				assert.Equal(t, v, cd.Annotations[k], "unexpected value of annotation %s", k)
+			}
+			if _, ok := test.expectedAnnotations[constants.ClaimTagsAppliedAnnotation]; !ok {
+				assert.NotContains(t, cd.Annotations, constants.ClaimTagsAppliedAnnotation, "unexpected tags applied annotation")
+			}
+		})
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	utilrand "k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/util/flowcontrol"
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/utils/pointer"
@@ -44,6 +45,7 @@ import (
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 	"github.com/openshift/hive/pkg/imageset"
 	"github.com/openshift/hive/pkg/install"
+	"github.com/openshift/hive/pkg/objectstorage"
 	"github.com/openshift/hive/pkg/remoteclient"
 	k8slabels "github.com/openshift/hive/pkg/util/labels"
 )
@@ -59,6 +61,10 @@ const (
 	platformAuthFailureReason = "PlatformAuthError"
 	platformAuthSuccessReason = "PlatformAuthSuccess"
 
+	credentialsMalformedReason  = "CredentialsMalformed"
+	credentialsExpiredReason    = "CredentialsExpired"
+	credentialsWellFormedReason = "CredentialsWellFormed"
+
 	clusterImageSetNotFoundReason = "ClusterImageSetNotFound"
 	clusterImageSetFoundReason    = "ClusterImageSetFound"
 
@@ -71,6 +77,7 @@ const (
 	dnsReadyAnnotation            = "hive.openshift.io/dnsready"
 
 	installAttemptsLimitReachedReason = "InstallAttemptsLimitReached"
+	failureNotRetryableReason         = "FailureNotRetryable"
 	installOnlyOnceSetReason          = "InstallOnlyOnceSet"
 	provisionNotStoppedReason         = "ProvisionNotStopped"
 
@@ -167,6 +174,7 @@ func NewReconciler(mgr manager.Manager, logger log.FieldLogger, rateLimiter flow
 		logger:                                  logger,
 		expectations:                            controllerutils.NewExpectations(logger),
 		validateCredentialsForClusterDeployment: controllerutils.ValidateCredentialsForClusterDeployment,
+		checkCredentialsExpiry:                  controllerutils.CheckCredentialsExpiry,
 	}
 	r.remoteClusterAPIClientBuilder = func(cd *hivev1.ClusterDeployment) remoteclient.Builder {
 		return remoteclient.NewBuilder(r.Client, cd, ControllerName)
@@ -178,6 +186,18 @@ func NewReconciler(mgr manager.Manager, logger log.FieldLogger, rateLimiter flow
 		r.protectedDelete = true
 	}
 
+	r.pullSecretConflictPolicy = hivev1.PullSecretConflictPolicy(os.Getenv(constants.PullSecretConflictPolicyEnvVar))
+
+	r.installTimeouts = loadInstallTimeouts(logger)
+
+	if egressPolicyType := os.Getenv(constants.InstallEgressPolicyTypeEnvVar); egressPolicyType != "" {
+		logger.WithField("type", egressPolicyType).Info("install egress policy enabled")
+		r.installEgressPolicyType = hivev1.InstallEgressPolicyType(egressPolicyType)
+		if endpoints := os.Getenv(constants.InstallEgressAdditionalEndpointsEnvVar); endpoints != "" {
+			r.installEgressAdditionalEndpoints = strings.Split(endpoints, ",")
+		}
+	}
+
 	return r
 }
 
@@ -257,6 +277,13 @@ func AddToManager(mgr manager.Manager, r reconcile.Reconciler, concurrentReconci
 		return errors.Wrap(err, "cannot start watch on ClusterSyncs")
 	}
 
+	// Watch for changes to the additional trust bundle of installed clusters
+	if err := c.Watch(&source.Kind{Type: &corev1.Secret{}}, &handler.EnqueueRequestsFromMapFunc{
+		ToRequests: requestsForAdditionalTrustBundleSecret(mgr.GetClient()),
+	}); err != nil {
+		return errors.Wrap(err, "cannot start watch on the additional trust bundle secret")
+	}
+
 	return nil
 }
 
@@ -279,7 +306,25 @@ type ReconcileClusterDeployment struct {
 	// that the platform creds are good (used for testing)
 	validateCredentialsForClusterDeployment func(client.Client, *hivev1.ClusterDeployment, log.FieldLogger) (bool, error)
 
+	// checkCredentialsExpiry is what this controller will call to check that the platform creds have not expired
+	// (used for testing)
+	checkCredentialsExpiry func(client.Client, *hivev1.ClusterDeployment, log.FieldLogger) (string, error)
+
 	protectedDelete bool
+
+	// installEgressPolicyType is the kind of policy restricting the network egress of install and deprovision pods.
+	// The egress is not restricted when empty.
+	installEgressPolicyType hivev1.InstallEgressPolicyType
+
+	// installEgressAdditionalEndpoints are the DNS names and CIDRs install and deprovision pods may reach in addition
+	// to those of their platform.
+	installEgressAdditionalEndpoints []string
+
+	// pullSecretConflictPolicy is how a registry with different auths in the merged pull secrets is resolved.
+	pullSecretConflictPolicy hivev1.PullSecretConflictPolicy
+
+	// installTimeouts are the default install timeouts of provisions.
+	installTimeouts installTimeouts
 }
 
 // Reconcile reads that state of the cluster for a ClusterDeployment object and makes changes based on the state read
@@ -381,13 +426,15 @@ func generateOwnershipUniqueKeys(owner hivev1.MetaRuntimeObject) []*controllerut
 	}
 }
 
-func (r *ReconcileClusterDeployment) addAdditionalKubeconfigCAs(cd *hivev1.ClusterDeployment,
-	cdLog log.FieldLogger) error {
+// reconcileAdminKubeconfig adds the additional certificate authorities and the internal API context to the admin
+// kubeconfig, and returns the type of the context with which the controllers connect to the cluster.
+func (r *ReconcileClusterDeployment) reconcileAdminKubeconfig(cd *hivev1.ClusterDeployment,
+	cdLog log.FieldLogger) (hivev1.AdminKubeconfigContextType, error) {
 
 	adminKubeconfigSecret := &corev1.Secret{}
 	if err := r.Get(context.Background(), types.NamespacedName{Namespace: cd.Namespace, Name: cd.Spec.ClusterMetadata.AdminKubeconfigSecretRef.Name}, adminKubeconfigSecret); err != nil {
 		cdLog.WithError(err).Error("failed to get admin kubeconfig secret")
-		return err
+		return "", err
 	}
 
 	originalSecret := adminKubeconfigSecret.DeepCopy()
@@ -398,26 +445,38 @@ func (r *ReconcileClusterDeployment) addAdditionalKubeconfigCAs(cd *hivev1.Clust
 		rawData = adminKubeconfigSecret.Data[constants.KubeconfigSecretKey]
 	}
 
-	var err error
-	adminKubeconfigSecret.Data[constants.KubeconfigSecretKey], err = controllerutils.AddAdditionalKubeconfigCAs(rawData)
+	data, err := controllerutils.AddAdditionalKubeconfigCAs(rawData)
 	if err != nil {
 		cdLog.WithError(err).Errorf("error adding additional CAs to admin kubeconfig")
-		return err
+		return "", err
 	}
+	data, err = controllerutils.AddInternalKubeconfigContext(data)
+	if err != nil {
+		cdLog.WithError(err).Errorf("error adding internal API context to admin kubeconfig")
+		return "", err
+	}
+	adminKubeconfigSecret.Data[constants.KubeconfigSecretKey] = data
+
+	config, err := clientcmd.Load(data)
+	if err != nil {
+		cdLog.WithError(err).Errorf("error loading admin kubeconfig")
+		return "", err
+	}
+	_, contextType := controllerutils.AdminKubeconfigContext(config)
 
 	if reflect.DeepEqual(originalSecret.Data, adminKubeconfigSecret.Data) {
 		cdLog.Debug("secret data has not changed, no need to update")
-		return nil
+		return contextType, nil
 	}
 
 	cdLog.Info("admin kubeconfig has been modified, updating")
 	err = r.Update(context.TODO(), adminKubeconfigSecret)
 	if err != nil {
 		cdLog.WithError(err).Log(controllerutils.LogLevel(err), "error updating admin kubeconfig secret")
-		return err
+		return "", err
 	}
 
-	return nil
+	return contextType, nil
 }
 
 func (r *ReconcileClusterDeployment) reconcile(request reconcile.Request, cd *hivev1.ClusterDeployment, cdLog log.FieldLogger) (result reconcile.Result, returnErr error) {
@@ -440,6 +499,7 @@ func (r *ReconcileClusterDeployment) reconcile(request reconcile.Request, cd *hi
 
 	// Set region label on the ClusterDeployment
 	if region := getClusterRegion(cd); cd.Spec.Platform.BareMetal == nil && cd.Spec.Platform.AgentBareMetal == nil &&
+		cd.Spec.Platform.None == nil &&
 		cd.Labels[hivev1.HiveClusterRegionLabel] != region {
 
 		if cd.Labels == nil {
@@ -520,6 +580,21 @@ func (r *ReconcileClusterDeployment) reconcile(request reconcile.Request, cd *hi
 		return reconcile.Result{}, nil
 	}
 
+	if cd.Spec.BaseDomain == "" && cd.Spec.BaseDomainPoolRef != nil {
+		// The basedomainpool controller sets the base domain, which will trigger another reconcile.
+		cdLog.WithField("baseDomainPool", cd.Spec.BaseDomainPoolRef.Name).Info("waiting for a base domain to be allocated")
+		return reconcile.Result{}, nil
+	}
+
+	switch updated, err := r.ensureAdditionalTrustBundle(cd, cdLog); {
+	case err != nil:
+		cdLog.WithError(err).Log(controllerutils.LogLevel(err), "Error updating the additional trust bundle")
+		return reconcile.Result{}, err
+	case updated:
+		// The controller is not watching for secrets, so requeue manually.
+		return reconcile.Result{Requeue: true}, nil
+	}
+
 	if cd.Spec.Installed {
 		// set installedTimestamp for adopted clusters
 		if cd.Status.InstalledTimestamp == nil {
@@ -537,6 +612,11 @@ func (r *ReconcileClusterDeployment) reconcile(request reconcile.Request, cd *hi
 			return reconcile.Result{}, err
 		}
 
+		if err := r.setReadyCondition(cd, cdLog); err != nil {
+			cdLog.WithError(err).Error("Error updating Ready status condition")
+			return reconcile.Result{}, err
+		}
+
 		// delete failed provisions which are more than 7 days old
 		existingProvisions, err := r.existingProvisions(cd, cdLog)
 		if err != nil {
@@ -550,9 +630,18 @@ func (r *ReconcileClusterDeployment) reconcile(request reconcile.Request, cd *hi
 		if cd.Spec.ClusterMetadata != nil &&
 			cd.Spec.ClusterMetadata.AdminKubeconfigSecretRef.Name != "" {
 
-			if err := r.addAdditionalKubeconfigCAs(cd, cdLog); err != nil {
+			contextType, err := r.reconcileAdminKubeconfig(cd, cdLog)
+			if err != nil {
 				return reconcile.Result{}, err
 			}
+			if cd.Status.AdminKubeconfigContext != contextType {
+				cdLog.WithField("context", contextType).Info("setting admin kubeconfig context in status")
+				cd.Status.AdminKubeconfigContext = contextType
+				if err := r.Status().Update(context.TODO(), cd); err != nil {
+					cdLog.WithError(err).Log(controllerutils.LogLevel(err), "failed to update admin kubeconfig context in status")
+					return reconcile.Result{}, err
+				}
+			}
 
 			// Add cluster deployment as additional owner reference to admin secrets
 			if err := r.addOwnershipToSecret(cd, cdLog, cd.Spec.ClusterMetadata.AdminKubeconfigSecretRef.Name); err != nil {
@@ -585,6 +674,32 @@ func (r *ReconcileClusterDeployment) reconcile(request reconcile.Request, cd *hi
 		return *result, err
 	}
 
+	// Check the structure of the platform credentials secret and that the credentials have not expired, so that
+	// malformed or expired credentials are reported precisely rather than failing inside the install job.
+	malformedCredsReason := credentialsMalformedReason
+	malformedCredsMessage, err := controllerutils.CheckCredentialsSecretSchema(r.Client, cd)
+	if err != nil {
+		cdLog.WithError(err).Log(controllerutils.LogLevel(err), "unable to check platform credentials secret")
+		return reconcile.Result{}, err
+	}
+	if malformedCredsMessage == "" {
+		malformedCredsReason = credentialsExpiredReason
+		malformedCredsMessage, err = r.checkCredentialsExpiry(r.Client, cd, cdLog)
+		if err != nil {
+			cdLog.WithError(err).Log(controllerutils.LogLevel(err), "unable to check expiry of platform credentials")
+			return reconcile.Result{}, err
+		}
+	}
+	if err := r.setCredentialsMalformedCondition(cd, malformedCredsReason, malformedCredsMessage, cdLog); err != nil {
+		cdLog.WithError(err).Log(controllerutils.LogLevel(err), "unable to update clusterdeployment")
+		return reconcile.Result{}, err
+	}
+	if malformedCredsMessage != "" {
+		credsError := errors.New(malformedCredsMessage)
+		cdLog.WithError(credsError).Error("cannot proceed with provision while platform credentials are malformed")
+		return reconcile.Result{}, credsError
+	}
+
 	// Sanity check the platform/cloud credentials.
 	validCreds, err := r.validatePlatformCreds(cd, cdLog)
 	if err != nil {
@@ -631,6 +746,13 @@ func (r *ReconcileClusterDeployment) reconcile(request reconcile.Request, cd *hi
 		return reconcile.Result{Requeue: true}, nil
 	}
 
+	switch result, err := r.checkImageSetValid(cd, imageSet, releaseImage, cdLog); {
+	case err != nil:
+		return reconcile.Result{}, err
+	case result != nil:
+		return *result, nil
+	}
+
 	switch result, err := r.resolveInstallerImage(cd, imageSet, releaseImage, cdLog); {
 	case err != nil:
 		return reconcile.Result{}, err
@@ -638,6 +760,19 @@ func (r *ReconcileClusterDeployment) reconcile(request reconcile.Request, cd *hi
 		return *result, nil
 	}
 
+	// The feature set can only be validated once the install images have been resolved, as that is when the version
+	// being installed is known.
+	featureSetMessage := validateFeatureSet(cd)
+	if err := r.setFeatureSetNotSupportedCondition(cd, featureSetMessage, cdLog); err != nil {
+		cdLog.WithError(err).Log(controllerutils.LogLevel(err), "unable to update clusterdeployment")
+		return reconcile.Result{}, err
+	}
+	if featureSetMessage != "" {
+		// Changing the feature set or the release image of the clusterdeployment will trigger another reconcile.
+		cdLog.WithField("reason", featureSetMessage).Error("cannot proceed with provision while the feature set is not supported")
+		return reconcile.Result{}, nil
+	}
+
 	if !r.expectations.SatisfiedExpectations(request.String()) {
 		cdLog.Debug("waiting for expectations to be satisfied")
 		return reconcile.Result{}, nil
@@ -725,7 +860,7 @@ func (r *ReconcileClusterDeployment) startNewProvision(
 		}
 		return reconcile.Result{}, nil
 	}
-	if cd.Spec.InstallAttemptsLimit != nil && cd.Status.InstallRestarts >= int(*cd.Spec.InstallAttemptsLimit) {
+	if limit := installAttemptsLimit(cd); limit != nil && cd.Status.InstallRestarts >= int(*limit) {
 		cdLog.Debug("not creating new provision since the install attempts limit has been reached")
 		conditions, changed := controllerutils.SetClusterDeploymentConditionWithChangeCheck(
 			cd.Status.Conditions,
@@ -744,6 +879,25 @@ func (r *ReconcileClusterDeployment) startNewProvision(
 		}
 		return reconcile.Result{}, nil
 	}
+	if reason, retryable := provisionFailureRetryable(cd); !retryable {
+		cdLog.WithField("reason", reason).Debug("not creating new provision since the provision failure is not retryable")
+		conditions, changed := controllerutils.SetClusterDeploymentConditionWithChangeCheck(
+			cd.Status.Conditions,
+			hivev1.ProvisionStoppedCondition,
+			corev1.ConditionTrue,
+			failureNotRetryableReason,
+			fmt.Sprintf("Provision failed with reason %s, which is not retryable", reason),
+			controllerutils.UpdateConditionIfReasonOrMessageChange)
+		if changed {
+			cd.Status.Conditions = conditions
+			cdLog.Debugf("setting ProvisionStoppedCondition to %v", corev1.ConditionTrue)
+			if err := r.Status().Update(context.TODO(), cd); err != nil {
+				cdLog.WithError(err).Log(controllerutils.LogLevel(err), "failed to update cluster deployment status")
+				return reconcile.Result{}, err
+			}
+		}
+		return reconcile.Result{}, nil
+	}
 
 	conditions, changed := controllerutils.SetClusterDeploymentConditionWithChangeCheck(
 		cd.Status.Conditions,
@@ -751,7 +905,7 @@ func (r *ReconcileClusterDeployment) startNewProvision(
 		corev1.ConditionFalse,
 		provisionNotStoppedReason,
 		"Provision is not stopped",
-		controllerutils.UpdateConditionNever)
+		controllerutils.UpdateConditionIfReasonOrMessageChange)
 	if changed {
 		cd.Status.Conditions = conditions
 		cdLog.Debugf("setting ProvisionStoppedCondition to %v", corev1.ConditionFalse)
@@ -767,6 +921,11 @@ func (r *ReconcileClusterDeployment) startNewProvision(
 		return reconcile.Result{}, err
 	}
 
+	if err := r.ensureInstallEgressPolicy(cd, releaseImage, cdLog); err != nil {
+		cdLog.WithError(err).Log(controllerutils.LogLevel(err), "error setting up install egress policy")
+		return reconcile.Result{}, err
+	}
+
 	provisionName := apihelpers.GetResourceName(cd.Name, fmt.Sprintf("%d-%s", cd.Status.InstallRestarts, utilrand.String(5)))
 
 	labels := cd.Labels
@@ -775,7 +934,10 @@ func (r *ReconcileClusterDeployment) startNewProvision(
 	}
 	labels[constants.ClusterDeploymentNameLabel] = cd.Name
 
-	extraEnvVars := getInstallLogEnvVars(cd.Name)
+	extraEnvVars := append(getInstallLogEnvVars(cd.Name), getObjectStorageEnvVars(constants.InstallLogStreamEnvVarPrefix, cd.Name,
+		constants.InstallLogStreamIntervalEnvVar, constants.InstallLogStreamURLEnvVar)...)
+	extraEnvVars = append(extraEnvVars, getObjectStorageEnvVars(constants.InstallArtifactsEnvVarPrefix, cd.Name,
+		constants.InstallArtifactsUploadEnvVar, constants.InstallArtifactsPrefixEnvVar)...)
 
 	podSpec, err := install.InstallerPodSpec(
 		cd,
@@ -799,9 +961,10 @@ func (r *ReconcileClusterDeployment) startNewProvision(
 			ClusterDeploymentRef: corev1.LocalObjectReference{
 				Name: cd.Name,
 			},
-			PodSpec: *podSpec,
-			Attempt: cd.Status.InstallRestarts,
-			Stage:   hivev1.ClusterProvisionStageInitializing,
+			PodSpec:        *podSpec,
+			Attempt:        cd.Status.InstallRestarts,
+			Stage:          hivev1.ClusterProvisionStageInitializing,
+			InstallTimeout: r.installTimeouts.installTimeoutFor(cd),
 		},
 	}
 
@@ -826,6 +989,18 @@ func (r *ReconcileClusterDeployment) startNewProvision(
 			return reconcile.Result{}, err
 		}
 	}
+	if err := r.copySecretFromEnvVar(objectstorage.NewEnvVarNames(constants.InstallLogStreamEnvVarPrefix).CredentialsSecret, provision.Namespace, extraEnvVars); err != nil {
+		if !apierrors.IsAlreadyExists(err) {
+			cdLog.WithError(err).Error("could not copy install log stream secret")
+			return reconcile.Result{}, err
+		}
+	}
+	if err := r.copySecretFromEnvVar(objectstorage.NewEnvVarNames(constants.InstallArtifactsEnvVarPrefix).CredentialsSecret, provision.Namespace, extraEnvVars); err != nil {
+		if !apierrors.IsAlreadyExists(err) {
+			cdLog.WithError(err).Error("could not copy install artifacts secret")
+			return reconcile.Result{}, err
+		}
+	}
 
 	r.expectations.ExpectCreations(types.NamespacedName{Namespace: cd.Namespace, Name: cd.Name}.String(), 1)
 	if err := r.Create(context.TODO(), provision); err != nil {
@@ -846,9 +1021,15 @@ func (r *ReconcileClusterDeployment) startNewProvision(
 }
 
 func (r *ReconcileClusterDeployment) copyInstallLogSecret(destNamespace string, extraEnvVars []corev1.EnvVar) error {
+	return r.copySecretFromEnvVar(constants.InstallLogsCredentialsSecretRefEnvVar, destNamespace, extraEnvVars)
+}
+
+// copySecretFromEnvVar copies the secret named by the environment variable of the controller from the Hive namespace
+// to the destination namespace, under the name the install pod gets in the same environment variable.
+func (r *ReconcileClusterDeployment) copySecretFromEnvVar(envVarName, destNamespace string, extraEnvVars []corev1.EnvVar) error {
 	hiveNS := controllerutils.GetHiveNamespace()
 
-	srcSecretName, foundSrc := os.LookupEnv(constants.InstallLogsCredentialsSecretRefEnvVar)
+	srcSecretName, foundSrc := os.LookupEnv(envVarName)
 	if !foundSrc {
 		// If the src secret reference wasn't found, then don't attempt to copy the secret.
 		return nil
@@ -857,7 +1038,7 @@ func (r *ReconcileClusterDeployment) copyInstallLogSecret(destNamespace string,
 	foundDest := false
 	var destSecretName string
 	for _, envVar := range extraEnvVars {
-		if envVar.Name == constants.InstallLogsCredentialsSecretRefEnvVar {
+		if envVar.Name == envVarName {
 			destSecretName = envVar.Value
 			foundDest = true
 		}
@@ -903,6 +1084,28 @@ func getInstallLogEnvVars(secretPrefix string) []corev1.EnvVar {
 	return extraEnvVars
 }
 
+// getObjectStorageEnvVars returns the environment variables configuring the install pod to write to the destination
+// of a feature, when the destination is configured, along with the other environment variables of the feature. The
+// credentials secret is copied into the namespace of the install pod with the secret prefix.
+func getObjectStorageEnvVars(envVarPrefix, secretPrefix string, featureEnvVars ...string) []corev1.EnvVar {
+	extraEnvVars := []corev1.EnvVar{}
+
+	names := objectstorage.NewEnvVarNames(envVarPrefix)
+	config := objectstorage.ConfigFromEnv(names)
+	if config == nil {
+		return extraEnvVars
+	}
+	if config.CredentialsSecret != "" {
+		config.CredentialsSecret = secretPrefix + "-" + config.CredentialsSecret
+	}
+	extraEnvVars = append(extraEnvVars, config.EnvVars(names)...)
+	for _, name := range featureEnvVars {
+		extraEnvVars = addEnvVarIfFound(name, extraEnvVars)
+	}
+
+	return extraEnvVars
+}
+
 func addEnvVarIfFound(name string, envVars []corev1.EnvVar) []corev1.EnvVar {
 	value, found := os.LookupEnv(name)
 	if !found {
@@ -999,18 +1202,25 @@ func (r *ReconcileClusterDeployment) reconcileFailedProvision(cd *hivev1.Cluster
 
 	failedCond := controllerutils.FindClusterProvisionCondition(provision.Status.Conditions, hivev1.ClusterProvisionFailedCondition)
 	if failedCond != nil && failedCond.Status == corev1.ConditionTrue {
-		nextProvisionTime = calculateNextProvisionTime(failedCond.LastTransitionTime.Time, cd.Status.InstallRestarts, cdLog)
 		reason = failedCond.Reason
 	} else {
 		cdLog.Warnf("failed provision does not have a %s condition", hivev1.ClusterProvisionFailedCondition)
 	}
 
+	message := fmt.Sprintf("Provision %s failed. Failure is not retryable.", provision.Name)
+	if retryableProvisionFailureReason(cd.Spec.ProvisionRetryPolicy, reason) {
+		if failedCond != nil && failedCond.Status == corev1.ConditionTrue {
+			nextProvisionTime = calculateNextProvisionTime(failedCond.LastTransitionTime.Time, cd.Status.InstallRestarts, cd.Spec.ProvisionRetryPolicy, cdLog)
+		}
+		message = fmt.Sprintf("Provision %s failed. Next provision at %s.", provision.Name, nextProvisionTime.UTC().Format(time.RFC3339))
+	}
+
 	newConditions, condChange := controllerutils.SetClusterDeploymentConditionWithChangeCheck(
 		cd.Status.Conditions,
 		hivev1.ProvisionFailedCondition,
 		corev1.ConditionTrue,
 		reason,
-		fmt.Sprintf("Provision %s failed. Next provision at %s.", provision.Name, nextProvisionTime.UTC().Format(time.RFC3339)),
+		message,
 		controllerutils.UpdateConditionIfReasonOrMessageChange,
 	)
 	cd.Status.Conditions = newConditions
@@ -1026,6 +1236,17 @@ func (r *ReconcileClusterDeployment) reconcileFailedProvision(cd *hivev1.Cluster
 		return reconcile.Result{RequeueAfter: timeUntilNextProvision}, nil
 	}
 
+	// The next provision destroys the cluster of the failed one, so let its must-gather be collected first.
+	if mustGather := provision.Status.MustGather; mustGather != nil && mustGather.CompletionTime == nil {
+		cdLog.WithField("job", mustGather.JobRef.Name).Info("waiting for the must-gather of the failed provision to be collected")
+		if condChange {
+			if err := r.statusUpdate(cd, cdLog); err != nil {
+				return reconcile.Result{}, err
+			}
+		}
+		return reconcile.Result{}, nil
+	}
+
 	cdLog.Info("clearing current failed provision to make way for a new provision")
 	return r.clearOutCurrentProvision(cd, cdLog)
 }
@@ -1154,8 +1375,43 @@ func (r *ReconcileClusterDeployment) statusUpdate(cd *hivev1.ClusterDeployment,
 const (
 	imagesResolvedReason = "ImagesResolved"
 	imagesResolvedMsg    = "Images required for cluster deployment installations are resolved"
+
+	imageSetValidatingReason      = "ClusterImageSetValidating"
+	imageSetInvalidReason         = "ClusterImageSetInvalid"
+	imageSetValidatingRequeueTime = 30 * time.Second
+	imageSetInvalidRequeueTime    = 5 * time.Minute
 )
 
+// checkImageSetValid holds the resolution of the install images of a cluster using the release image of a
+// ClusterImageSet until the release image has been validated, and reports an invalid release image in the
+// InstallImagesNotResolved condition. Image sets that have not been picked up for validation are used as they are.
+func (r *ReconcileClusterDeployment) checkImageSetValid(cd *hivev1.ClusterDeployment, imageSet *hivev1.ClusterImageSet, releaseImage string, cdLog log.FieldLogger) (*reconcile.Result, error) {
+	if imageSet == nil || cd.Status.InstallerImage != nil || imageSet.Spec.ReleaseImage != releaseImage {
+		return nil, nil
+	}
+	validCond := controllerutils.FindClusterImageSetCondition(imageSet.Status.Conditions, hivev1.ClusterImageSetValidCondition)
+	switch {
+	case validCond == nil:
+		return nil, nil
+	case validCond.Status == corev1.ConditionUnknown:
+		cdLog.WithField("clusterimageset", imageSet.Name).Debug("waiting for clusterimageset to be validated")
+		if err := r.setInstallImagesNotResolvedCondition(cd, corev1.ConditionTrue, imageSetValidatingReason,
+			fmt.Sprintf("Waiting for ClusterImageSet %s to be validated", imageSet.Name), cdLog); err != nil {
+			return nil, err
+		}
+		return &reconcile.Result{RequeueAfter: imageSetValidatingRequeueTime}, nil
+	case validCond.Status == corev1.ConditionFalse:
+		cdLog.WithField("clusterimageset", imageSet.Name).Warning("clusterdeployment references invalid clusterimageset")
+		if err := r.setInstallImagesNotResolvedCondition(cd, corev1.ConditionTrue, imageSetInvalidReason,
+			fmt.Sprintf("ClusterImageSet %s is not valid: %s", imageSet.Name, validCond.Message), cdLog); err != nil {
+			return nil, err
+		}
+		// ClusterImageSets are not watched, so check again later for the image set to be fixed.
+		return &reconcile.Result{RequeueAfter: imageSetInvalidRequeueTime}, nil
+	}
+	return nil, nil
+}
+
 func (r *ReconcileClusterDeployment) resolveInstallerImage(cd *hivev1.ClusterDeployment, imageSet *hivev1.ClusterImageSet, releaseImage string, cdLog log.FieldLogger) (*reconcile.Result, error) {
 	areImagesResolved := cd.Status.InstallerImage != nil && cd.Status.CLIImage != nil
 
@@ -1312,6 +1568,28 @@ func (r *ReconcileClusterDeployment) setAuthenticationFailure(cd *hivev1.Cluster
 	return changed, r.Status().Update(context.TODO(), cd)
 }
 
+// setCredentialsMalformedCondition sets the CredentialsMalformed condition with the reason when the message describes a
+// problem with the platform credentials, and clears it otherwise.
+func (r *ReconcileClusterDeployment) setCredentialsMalformedCondition(cd *hivev1.ClusterDeployment, reason, message string, cdLog log.FieldLogger) error {
+	status := corev1.ConditionTrue
+	if message == "" {
+		status, reason, message = corev1.ConditionFalse, credentialsWellFormedReason, "Platform credentials are well formed and not expired"
+	}
+	conditions, changed := controllerutils.SetClusterDeploymentConditionWithChangeCheck(
+		cd.Status.Conditions,
+		hivev1.CredentialsMalformedClusterDeploymentCondition,
+		status,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange)
+	if !changed {
+		return nil
+	}
+	cd.Status.Conditions = conditions
+	cdLog.WithField("status", status).Debug("setting CredentialsMalformedCondition")
+	return r.Status().Update(context.TODO(), cd)
+}
+
 func (r *ReconcileClusterDeployment) setInstallLaunchErrorCondition(cd *hivev1.ClusterDeployment, status corev1.ConditionStatus, reason string, message string, cdLog log.FieldLogger) error {
 	conditions, changed := controllerutils.SetClusterDeploymentConditionWithChangeCheck(
 		cd.Status.Conditions,
@@ -1422,6 +1700,9 @@ func (r *ReconcileClusterDeployment) ensureManagedDNSZoneDeleted(cd *hivev1.Clus
 	if !cd.Spec.ManageDNS {
 		return true, nil
 	}
+	if cd.Spec.PreserveDNSZoneOnDelete {
+		return r.preserveManagedDNSZone(cd, cdLog)
+	}
 	dnsZone := &hivev1.DNSZone{}
 	dnsZoneNamespacedName := types.NamespacedName{Namespace: cd.Namespace, Name: controllerutils.DNSZoneName(cd.Name)}
 	switch err := r.Get(context.TODO(), dnsZoneNamespacedName, dnsZone); {
@@ -1442,6 +1723,44 @@ func (r *ReconcileClusterDeployment) ensureManagedDNSZoneDeleted(cd *hivev1.Clus
 	return false, nil
 }
 
+// preserveManagedDNSZone releases the managed DNSZone from the ClusterDeployment being deleted so that it is not
+// garbage collected, and marks it for adoption by a later ClusterDeployment with the same name and base domain.
+func (r *ReconcileClusterDeployment) preserveManagedDNSZone(cd *hivev1.ClusterDeployment, cdLog log.FieldLogger) (done bool, returnErr error) {
+	dnsZone := &hivev1.DNSZone{}
+	dnsZoneNamespacedName := types.NamespacedName{Namespace: cd.Namespace, Name: controllerutils.DNSZoneName(cd.Name)}
+	switch err := r.Get(context.TODO(), dnsZoneNamespacedName, dnsZone); {
+	case apierrors.IsNotFound(err):
+		cdLog.Debug("dnszone has been removed from storage")
+		return true, nil
+	case err != nil:
+		cdLog.WithError(err).Error("error looking up managed dnszone")
+		return false, err
+	case !dnsZone.DeletionTimestamp.IsZero():
+		cdLog.Warn("dnszone cannot be preserved as it has already been deleted")
+		return false, nil
+	case !metav1.IsControlledBy(dnsZone, cd):
+		cdLog.Debug("dnszone has already been released")
+		return true, nil
+	}
+	var ownerRefs []metav1.OwnerReference
+	for _, ref := range dnsZone.OwnerReferences {
+		if ref.UID != cd.UID {
+			ownerRefs = append(ownerRefs, ref)
+		}
+	}
+	dnsZone.OwnerReferences = ownerRefs
+	if dnsZone.Annotations == nil {
+		dnsZone.Annotations = map[string]string{}
+	}
+	dnsZone.Annotations[constants.PreservedDNSZoneAnnotation] = "true"
+	if err := r.Update(context.TODO(), dnsZone); err != nil {
+		cdLog.WithError(err).Log(controllerutils.LogLevel(err), "error releasing managed dnszone")
+		return false, err
+	}
+	cdLog.WithField("zone", dnsZone.Spec.Zone).Info("preserved managed dnszone")
+	return true, nil
+}
+
 func (r *ReconcileClusterDeployment) ensureClusterDeprovisioned(cd *hivev1.ClusterDeployment, cdLog log.FieldLogger) (deprovisioned bool, returnErr error) {
 	// Skips creation of deprovision request if PreserveOnDelete is true and cluster is installed
 	if cd.Spec.PreserveOnDelete {
@@ -1465,12 +1784,20 @@ func (r *ReconcileClusterDeployment) ensureClusterDeprovisioned(cd *hivev1.Clust
 		return true, nil
 	}
 
+	// Infrastructure for the none platform is created and destroyed by external infrastructure hooks, unless an
+	// external destroyer has been configured for us to invoke.
+	if cd.Spec.Platform.None != nil && cd.Spec.Platform.None.Destroyer == nil {
+		cdLog.Info("skipping deprovision for cluster on externally managed infrastructure, removing finalizer")
+		return true, nil
+	}
+
 	// Generate a deprovision request
-	request, err := generateDeprovision(cd)
+	request, err := install.GenerateDeprovision(cd, cd.Name, cd.Spec.ClusterMetadata.InfraID, cd.Spec.ClusterMetadata.ClusterID)
 	if err != nil {
 		cdLog.WithError(err).Error("error generating deprovision request")
 		return false, err
 	}
+	request.Spec.ExitBackup = cd.Spec.ExitBackup.DeepCopy()
 
 	cdLog.WithField("derivedObject", request.Name).Debug("Setting label on derived object")
 	request.Labels = k8slabels.AddLabel(request.Labels, constants.ClusterDeploymentNameLabel, cd.Name)
@@ -1485,7 +1812,10 @@ func (r *ReconcileClusterDeployment) ensureClusterDeprovisioned(cd *hivev1.Clust
 	switch err = r.Get(context.TODO(), types.NamespacedName{Name: cd.Name, Namespace: cd.Namespace}, existingRequest); {
 	case apierrors.IsNotFound(err):
 		cdLog.Info("creating deprovision request for cluster deployment")
-		switch err = r.Create(context.TODO(), request); {
+		if err = r.ensureInstallEgressPolicy(cd, "", cdLog); err == nil {
+			err = r.Create(context.TODO(), request)
+		}
+		switch {
 		case apierrors.IsAlreadyExists(err):
 			cdLog.Info("deprovision request already exists")
 			return false, nil
@@ -1534,7 +1864,7 @@ func (r *ReconcileClusterDeployment) ensureClusterDeprovisioned(cd *hivev1.Clust
 	return true, nil
 }
 
-func (r *ReconcileClusterDeployment) syncDeletedClusterDeployment(cd *hivev1.ClusterDeployment, cdLog log.FieldLogger) (reconcile.Result, error) {
+func (r *ReconcileClusterDeployment) syncDeletedClusterDeployment(cd *hivev1.ClusterDeployment, cdLog log.FieldLogger) (result reconcile.Result, returnErr error) {
 	switch _, relocateStatus, err := controllerutils.IsRelocating(cd); {
 	case err != nil:
 		cdLog.WithError(err).Error("could not determine relocate status")
@@ -1556,6 +1886,17 @@ func (r *ReconcileClusterDeployment) syncDeletedClusterDeployment(cd *hivev1.Clu
 		return reconcile.Result{}, nil
 	}
 
+	if cd.Spec.ForceCleanup != nil {
+		forced, untilForced, err := r.forceCleanup(cd, cdLog)
+		if err != nil || forced {
+			return reconcile.Result{}, err
+		}
+		// Make sure to come back to force the cleanup if the regular cleanup is still stuck by then.
+		defer func() {
+			result, returnErr = controllerutils.EnsureRequeueAtLeastWithin(untilForced, result, returnErr)
+		}()
+	}
+
 	dnsZoneGone, err := r.ensureManagedDNSZoneDeleted(cd, cdLog)
 	if err != nil {
 		return reconcile.Result{}, err
@@ -1656,6 +1997,11 @@ func (r *ReconcileClusterDeployment) setDNSDelayMetric(cd *hivev1.ClusterDeploym
 	}
 
 	dnsDelayDuration := readyTimestamp.Sub(cd.CreationTimestamp.Time)
+	if dnsDelayDuration < 0 {
+		// The DNSZone was preserved from a previous installation of the cluster and was ready before the
+		// ClusterDeployment was created.
+		dnsDelayDuration = 0
+	}
 	cdLog.WithField("duration", dnsDelayDuration.Seconds()).Info("DNS ready")
 	cd.Annotations[dnsReadyAnnotation] = dnsDelayDuration.String()
 	if err := r.Update(context.TODO(), cd); err != nil {
@@ -1696,6 +2042,19 @@ func (r *ReconcileClusterDeployment) ensureManagedDNSZone(cd *hivev1.ClusterDepl
 		return nil, err
 	}
 
+	if isPreservedDNSZone(dnsZone, cd) {
+		logger.Info("adopting DNSZone preserved from a previous installation of the cluster")
+		if err := controllerutil.SetControllerReference(cd, dnsZone, r.scheme); err != nil {
+			logger.WithError(err).Error("error setting controller reference on dnszone")
+			return nil, err
+		}
+		delete(dnsZone.Annotations, constants.PreservedDNSZoneAnnotation)
+		if err := r.Update(context.TODO(), dnsZone); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "error adopting preserved dnszone")
+			return nil, err
+		}
+	}
+
 	if !metav1.IsControlledBy(dnsZone, cd) {
 		cdLog.Error("DNS zone already exists but is not owned by cluster deployment")
 		if err := r.setDNSNotReadyCondition(cd, corev1.ConditionTrue, dnsZoneResourceConflictReason, "Existing DNS zone not owned by cluster deployment", cdLog); err != nil {
@@ -1729,6 +2088,9 @@ func (r *ReconcileClusterDeployment) ensureManagedDNSZone(cd *hivev1.ClusterDepl
 		status = corev1.ConditionTrue
 		reason = dnsNotReadyReason
 		message = "DNS Zone not yet available"
+		if notDelegated := controllerutils.FindDNSZoneCondition(dnsZone.Status.Conditions, hivev1.DNSNotDelegatedCondition); notDelegated != nil && notDelegated.Status == corev1.ConditionTrue {
+			message = fmt.Sprintf("%s: %s", message, notDelegated.Message)
+		}
 
 		isDNSNotReadyConditionSet, dnsNotReadyCondition := isDNSNotReadyConditionSet(cd)
 		if isDNSNotReadyConditionSet {
@@ -1753,6 +2115,17 @@ func (r *ReconcileClusterDeployment) ensureManagedDNSZone(cd *hivev1.ClusterDepl
 	return dnsZone, nil
 }
 
+// isPreservedDNSZone returns true if the DNSZone was preserved when a previous ClusterDeployment with the same name
+// was deleted, and can be adopted by the ClusterDeployment.
+func isPreservedDNSZone(dnsZone *hivev1.DNSZone, cd *hivev1.ClusterDeployment) bool {
+	if _, ok := dnsZone.Annotations[constants.PreservedDNSZoneAnnotation]; !ok {
+		return false
+	}
+	return metav1.GetControllerOf(dnsZone) == nil &&
+		dnsZone.DeletionTimestamp.IsZero() &&
+		dnsZone.Spec.Zone == cd.Spec.BaseDomain
+}
+
 func (r *ReconcileClusterDeployment) createManagedDNSZone(cd *hivev1.ClusterDeployment, logger log.FieldLogger) error {
 	dnsZone := &hivev1.DNSZone{
 		ObjectMeta: metav1.ObjectMeta{
@@ -1789,6 +2162,17 @@ func (r *ReconcileClusterDeployment) createManagedDNSZone(cd *hivev1.ClusterDepl
 			CredentialsSecretRef: cd.Spec.Platform.Azure.CredentialsSecretRef,
 			ResourceGroupName:    cd.Spec.Platform.Azure.BaseDomainResourceGroupName,
 		}
+	case cd.Spec.Platform.OCI != nil:
+		compartmentID := cd.Spec.Platform.OCI.DNSCompartmentID
+		if compartmentID == "" {
+			compartmentID = cd.Spec.Platform.OCI.CompartmentID
+		}
+		dnsZone.Spec.OCI = &hivev1.OCIDNSZoneSpec{
+			CredentialsSecretRef: cd.Spec.Platform.OCI.CredentialsSecretRef,
+			Region:               cd.Spec.Platform.OCI.Region,
+			CompartmentID:        compartmentID,
+			FreeformTags:         cd.Spec.Platform.OCI.FreeformTags,
+		}
 	}
 
 	logger.WithField("derivedObject", dnsZone.Name).Debug("Setting labels on derived object")
@@ -1883,58 +2267,6 @@ func (r *ReconcileClusterDeployment) cleanupInstallLogPVC(cd *hivev1.ClusterDepl
 	return nil
 }
 
-func generateDeprovision(cd *hivev1.ClusterDeployment) (*hivev1.ClusterDeprovision, error) {
-	req := &hivev1.ClusterDeprovision{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      cd.Name,
-			Namespace: cd.Namespace,
-		},
-		Spec: hivev1.ClusterDeprovisionSpec{
-			InfraID:   cd.Spec.ClusterMetadata.InfraID,
-			ClusterID: cd.Spec.ClusterMetadata.ClusterID,
-		},
-	}
-
-	switch {
-	case cd.Spec.Platform.AWS != nil:
-		req.Spec.Platform.AWS = &hivev1.AWSClusterDeprovision{
-			Region:               cd.Spec.Platform.AWS.Region,
-			CredentialsSecretRef: &cd.Spec.Platform.AWS.CredentialsSecretRef,
-		}
-	case cd.Spec.Platform.Azure != nil:
-		req.Spec.Platform.Azure = &hivev1.AzureClusterDeprovision{
-			CredentialsSecretRef: &cd.Spec.Platform.Azure.CredentialsSecretRef,
-		}
-	case cd.Spec.Platform.GCP != nil:
-		req.Spec.Platform.GCP = &hivev1.GCPClusterDeprovision{
-			Region:               cd.Spec.Platform.GCP.Region,
-			CredentialsSecretRef: &cd.Spec.Platform.GCP.CredentialsSecretRef,
-		}
-	case cd.Spec.Platform.OpenStack != nil:
-		req.Spec.Platform.OpenStack = &hivev1.OpenStackClusterDeprovision{
-			Cloud:                 cd.Spec.Platform.OpenStack.Cloud,
-			CredentialsSecretRef:  &cd.Spec.Platform.OpenStack.CredentialsSecretRef,
-			CertificatesSecretRef: cd.Spec.Platform.OpenStack.CertificatesSecretRef,
-		}
-	case cd.Spec.Platform.VSphere != nil:
-		req.Spec.Platform.VSphere = &hivev1.VSphereClusterDeprovision{
-			CredentialsSecretRef:  cd.Spec.Platform.VSphere.CredentialsSecretRef,
-			CertificatesSecretRef: cd.Spec.Platform.VSphere.CertificatesSecretRef,
-			VCenter:               cd.Spec.Platform.VSphere.VCenter,
-		}
-	case cd.Spec.Platform.Ovirt != nil:
-		req.Spec.Platform.Ovirt = &hivev1.OvirtClusterDeprovision{
-			CredentialsSecretRef:  cd.Spec.Platform.Ovirt.CredentialsSecretRef,
-			CertificatesSecretRef: cd.Spec.Platform.Ovirt.CertificatesSecretRef,
-			ClusterID:             cd.Spec.Platform.Ovirt.ClusterID,
-		}
-	default:
-		return nil, errors.New("unsupported cloud provider for deprovision")
-	}
-
-	return req, nil
-}
-
 func generatePullSecretObj(pullSecret string, pullSecretName string, cd *hivev1.ClusterDeployment) *corev1.Secret {
 	return &corev1.Secret{
 		TypeMeta: metav1.TypeMeta{
@@ -1980,50 +2312,50 @@ func initializeAnnotations(cd *hivev1.ClusterDeployment) {
 	}
 }
 
-// mergePullSecrets merges the global pull secret JSON (if defined) with the cluster's pull secret JSON (if defined)
-// An error will be returned if neither is defined
+// mergePullSecrets merges the global pull secret JSON (if defined), the default pull secret JSON of the namespace (if
+// it exists) and the cluster's pull secret JSON (if defined) into the effective pull secret of the cluster.
 func (r *ReconcileClusterDeployment) mergePullSecrets(cd *hivev1.ClusterDeployment, cdLog log.FieldLogger) (string, error) {
-	var localPullSecret string
-	var err error
+	var layers []controllerutils.PullSecretLayer
 
-	// For code readability let's call the pull secret in cluster deployment config as local pull secret
-	if cd.Spec.PullSecretRef != nil {
-		localPullSecret, err = controllerutils.LoadSecretData(r.Client, cd.Spec.PullSecretRef.Name, cd.Namespace, corev1.DockerConfigJsonKey)
+	// Check if global pull secret from env as it comes from hive config
+	if globalPullSecretName := os.Getenv(constants.GlobalPullSecret); len(globalPullSecretName) != 0 {
+		globalPullSecret, err := controllerutils.LoadSecretData(r.Client, globalPullSecretName, controllerutils.GetHiveNamespace(), corev1.DockerConfigJsonKey)
 		if err != nil {
-			return "", errors.Wrap(err, "local pull secret could not be retrieved")
+			return "", errors.Wrap(err, "global pull secret could not be retrieved")
 		}
+		layers = append(layers, controllerutils.PullSecretLayer{Name: "global pull secret", PullSecret: globalPullSecret})
 	}
 
-	// Check if global pull secret from env as it comes from hive config
-	globalPullSecretName := os.Getenv(constants.GlobalPullSecret)
-	var globalPullSecret string
-	if len(globalPullSecretName) != 0 {
-		globalPullSecret, err = controllerutils.LoadSecretData(r.Client, globalPullSecretName, controllerutils.GetHiveNamespace(), corev1.DockerConfigJsonKey)
+	// The default pull secret of the namespace is optional.
+	switch namespacePullSecret, err := controllerutils.LoadSecretData(r.Client, constants.NamespaceDefaultPullSecretName, cd.Namespace, corev1.DockerConfigJsonKey); {
+	case apierrors.IsNotFound(err):
+	case err != nil:
+		return "", errors.Wrap(err, "namespace default pull secret could not be retrieved")
+	default:
+		layers = append(layers, controllerutils.PullSecretLayer{Name: "namespace default pull secret", PullSecret: namespacePullSecret})
+	}
+
+	// For code readability let's call the pull secret in cluster deployment config as local pull secret
+	if cd.Spec.PullSecretRef != nil {
+		localPullSecret, err := controllerutils.LoadSecretData(r.Client, cd.Spec.PullSecretRef.Name, cd.Namespace, corev1.DockerConfigJsonKey)
 		if err != nil {
-			return "", errors.Wrap(err, "global pull secret could not be retrieved")
+			return "", errors.Wrap(err, "local pull secret could not be retrieved")
 		}
+		layers = append(layers, controllerutils.PullSecretLayer{Name: "cluster deployment pull secret", PullSecret: localPullSecret})
 	}
 
-	switch {
-	case globalPullSecret != "" && localPullSecret != "":
-		// Merge local pullSecret and globalPullSecret. If both pull secrets have same registry name
-		// then the merged pull secret will have registry secret from local pull secret
-		pullSecret, err := controllerutils.MergeJsons(globalPullSecret, localPullSecret, cdLog)
-		if err != nil {
-			errMsg := "unable to merge global pull secret with local pull secret"
-			cdLog.WithError(err).Error(errMsg)
-			return "", errors.Wrap(err, errMsg)
-		}
-		return pullSecret, nil
-	case globalPullSecret != "":
-		return globalPullSecret, nil
-	case localPullSecret != "":
-		return localPullSecret, nil
-	default:
+	if len(layers) == 0 {
 		errMsg := "clusterdeployment must specify pull secret since hiveconfig does not specify a global pull secret"
 		cdLog.Error(errMsg)
 		return "", errors.New(errMsg)
 	}
+	pullSecret, err := controllerutils.MergePullSecretLayers(layers, r.pullSecretConflictPolicy, cdLog)
+	if err != nil {
+		errMsg := "unable to merge pull secrets"
+		cdLog.WithError(err).Error(errMsg)
+		return "", errors.Wrap(err, errMsg)
+	}
+	return pullSecret, nil
 }
 
 // updatePullSecretInfo creates or updates the merged pull secret for the clusterdeployment.
@@ -2085,15 +2417,54 @@ func (r *ReconcileClusterDeployment) updatePullSecretInfo(pullSecret string, cd
 	return true, nil
 }
 
-func calculateNextProvisionTime(failureTime time.Time, retries int, cdLog log.FieldLogger) time.Time {
-	// (2^currentRetries) * 60 seconds up to a max of 24 hours.
-	const sleepCap = 24 * time.Hour
-	const retryCap = 11 // log_2_(24*60)
+func calculateNextProvisionTime(failureTime time.Time, retries int, policy *hivev1.ProvisionRetryPolicy, cdLog log.FieldLogger) time.Time {
+	// (2^currentRetries) * backoff base (1 minute by default) up to the max backoff (24 hours by default).
+	backoff, backoffCap := time.Minute, 24*time.Hour
+	if policy != nil {
+		if policy.BackoffBase != nil {
+			backoff = policy.BackoffBase.Duration
+		}
+		if policy.MaxBackoff != nil {
+			backoffCap = policy.MaxBackoff.Duration
+		}
+	}
+	for i := 0; i < retries && backoff < backoffCap; i++ {
+		backoff *= 2
+	}
+	if backoff > backoffCap {
+		backoff = backoffCap
+	}
+	return failureTime.Add(backoff)
+}
+
+// installAttemptsLimit returns the maximum number of provisions attempted for the cluster, if any.
+func installAttemptsLimit(cd *hivev1.ClusterDeployment) *int32 {
+	if policy := cd.Spec.ProvisionRetryPolicy; policy != nil && policy.MaxAttempts != nil {
+		return policy.MaxAttempts
+	}
+	return cd.Spec.InstallAttemptsLimit
+}
+
+// provisionFailureRetryable returns the reason the last provision of the cluster failed, and whether the retry policy
+// of the cluster allows retrying it. Provisions which have not failed are always retryable.
+func provisionFailureRetryable(cd *hivev1.ClusterDeployment) (string, bool) {
+	failedCond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.ProvisionFailedCondition)
+	if failedCond == nil || failedCond.Status != corev1.ConditionTrue {
+		return "", true
+	}
+	return failedCond.Reason, retryableProvisionFailureReason(cd.Spec.ProvisionRetryPolicy, failedCond.Reason)
+}
 
-	if retries >= retryCap {
-		return failureTime.Add(sleepCap)
+func retryableProvisionFailureReason(policy *hivev1.ProvisionRetryPolicy, reason string) bool {
+	if policy == nil || len(policy.RetryableReasons) == 0 {
+		return true
+	}
+	for _, r := range policy.RetryableReasons {
+		if r == reason {
+			return true
+		}
 	}
-	return failureTime.Add((1 << uint(retries)) * time.Minute)
+	return false
 }
 
 func (r *ReconcileClusterDeployment) existingProvisions(cd *hivev1.ClusterDeployment, cdLog log.FieldLogger) ([]*hivev1.ClusterProvision, error) {
@@ -2236,6 +2607,41 @@ func (r *ReconcileClusterDeployment) setSyncSetFailedCondition(cd *hivev1.Cluste
 	return nil
 }
 
+// setReadyCondition sets the Ready condition of an installed cluster from its readiness gates, whose conditions are
+// set by external systems.
+func (r *ReconcileClusterDeployment) setReadyCondition(cd *hivev1.ClusterDeployment, cdLog log.FieldLogger) error {
+	status := corev1.ConditionTrue
+	reason := "ClusterReady"
+	message := "Cluster is installed and all readiness gates are met"
+	if unmet := controllerutils.UnmetReadinessGates(cd); len(unmet) > 0 {
+		gates := make([]string, len(unmet))
+		for i, t := range unmet {
+			gates[i] = string(t)
+		}
+		status = corev1.ConditionFalse
+		reason = "ReadinessGatesNotMet"
+		message = fmt.Sprintf("Waiting for readiness gates: %s", strings.Join(gates, ", "))
+	}
+	conds, changed := controllerutils.SetClusterDeploymentConditionWithChangeCheck(
+		cd.Status.Conditions,
+		hivev1.ReadyClusterDeploymentCondition,
+		status,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	if !changed {
+		return nil
+	}
+	cdLog.WithField("status", status).WithField("reason", reason).Info("updating Ready condition")
+	cd.Status.Conditions = conds
+	if err := r.Status().Update(context.TODO(), cd); err != nil {
+		cdLog.WithError(err).Log(controllerutils.LogLevel(err), "error updating ready condition")
+		return err
+	}
+	return nil
+}
+
 // addOwnershipToSecret adds cluster deployment as an additional non-controlling owner to secret
 func (r *ReconcileClusterDeployment) addOwnershipToSecret(cd *hivev1.ClusterDeployment, cdLog log.FieldLogger, name string) error {
 	cdLog = cdLog.WithField("secret", name)
@@ -2294,6 +2700,12 @@ func getClusterPlatform(cd *hivev1.ClusterDeployment) string {
 		return constants.PlatformBaremetal
 	case cd.Spec.Platform.AgentBareMetal != nil:
 		return constants.PlatformAgentBaremetal
+	case cd.Spec.Platform.OCI != nil:
+		return constants.PlatformOCI
+	case cd.Spec.Platform.PowerVS != nil:
+		return constants.PlatformPowerVS
+	case cd.Spec.Platform.None != nil:
+		return constants.PlatformNone
 	}
 	return constants.PlatformUnknown
 }
@@ -2307,6 +2719,10 @@ func getClusterRegion(cd *hivev1.ClusterDeployment) string {
 		return cd.Spec.Platform.Azure.Region
 	case cd.Spec.Platform.GCP != nil:
 		return cd.Spec.Platform.GCP.Region
+	case cd.Spec.Platform.OCI != nil:
+		return cd.Spec.Platform.OCI.Region
+	case cd.Spec.Platform.PowerVS != nil:
+		return cd.Spec.Platform.PowerVS.Region
 	}
 	return regionUnknown
 }
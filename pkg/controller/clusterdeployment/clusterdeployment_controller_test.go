@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -22,6 +23,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/diff"
 	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/utils/pointer"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -131,6 +133,7 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 		validate                      func(client.Client, *testing.T)
 		reconcilerSetup               func(*ReconcileClusterDeployment)
 		platformCredentialsValidation func(client.Client, *hivev1.ClusterDeployment, log.FieldLogger) (bool, error)
+		credentialsExpiry             func(client.Client, *hivev1.ClusterDeployment, log.FieldLogger) (string, error)
 	}{
 		{
 			name: "Add finalizer",
@@ -158,6 +161,69 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 				assert.Len(t, provisions, 1, "expected provision to exist")
 			},
 		},
+		{
+			name: "Create provision with supported feature set",
+			existing: []runtime.Object{
+				func() *hivev1.ClusterDeployment {
+					cd := testClusterDeployment()
+					cd.Spec.Provisioning.FeatureSet = &hivev1.FeatureSetConfig{
+						FeatureSet:   openshiftapiv1.CustomNoUpgrade,
+						FeatureGates: []string{"GatewayAPI=true"},
+					}
+					cd.Status.InstallVersion = pointer.StringPtr("4.14.0-rc.1")
+					return cd
+				}(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			expectPendingCreation: true,
+			validate: func(c client.Client, t *testing.T) {
+				provisions := getProvisions(c)
+				assert.Len(t, provisions, 1, "expected provision to exist")
+				cd := getCD(c)
+				assert.Nil(t, controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.FeatureSetNotSupportedCondition), "unexpected FeatureSetNotSupported condition")
+			},
+		},
+		{
+			name: "Provision not created when feature set is not supported",
+			existing: []runtime.Object{
+				func() *hivev1.ClusterDeployment {
+					cd := testClusterDeployment()
+					cd.Spec.Provisioning.FeatureSet = &hivev1.FeatureSetConfig{
+						FeatureSet:   openshiftapiv1.CustomNoUpgrade,
+						FeatureGates: []string{"GatewayAPI=true"},
+					}
+					cd.Status.InstallVersion = pointer.StringPtr("4.12.3")
+					return cd
+				}(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			validate: func(c client.Client, t *testing.T) {
+				provisions := getProvisions(c)
+				assert.Empty(t, provisions, "expected provision to not exist")
+				cd := getCD(c)
+				assertConditionStatus(t, cd, hivev1.FeatureSetNotSupportedCondition, corev1.ConditionTrue)
+				assertConditionReason(t, cd, hivev1.FeatureSetNotSupportedCondition, featureSetNotSupportedReason)
+			},
+		},
+		{
+			name: "Provision not created while waiting for base domain allocation",
+			existing: []runtime.Object{
+				func() *hivev1.ClusterDeployment {
+					cd := testClusterDeployment()
+					cd.Spec.BaseDomain = ""
+					cd.Spec.BaseDomainPoolRef = &hivev1.BaseDomainPoolReference{Name: "test-pool"}
+					return cd
+				}(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			validate: func(c client.Client, t *testing.T) {
+				provisions := getProvisions(c)
+				assert.Empty(t, provisions, "expected provision to not exist")
+			},
+		},
 		{
 			name: "Provision not created when pending create",
 			existing: []runtime.Object{
@@ -273,6 +339,42 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 				assert.Contains(t, akcSecret.Data, constants.RawKubeconfigSecretKey)
 			},
 		},
+		{
+			name: "Add internal API context to admin kubeconfig",
+			existing: []runtime.Object{
+				func() *hivev1.ClusterDeployment {
+					cd := testClusterDeployment()
+					cd.Spec.Installed = true
+					cd.Spec.ClusterMetadata = &hivev1.ClusterMetadata{
+						InfraID:                  "fakeinfra",
+						AdminKubeconfigSecretRef: corev1.LocalObjectReference{Name: adminKubeconfigSecret},
+					}
+					cd.Status.WebConsoleURL = "https://example.com"
+					cd.Status.APIURL = "https://example.com"
+					return cd
+				}(),
+				testSecret(corev1.SecretTypeOpaque, adminKubeconfigSecret, "kubeconfig",
+					strings.Replace(adminKubeconfig, "bar-api.clusters", "api.bar.clusters", 1)),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+				testMetadataConfigMap(),
+			},
+			validate: func(c client.Client, t *testing.T) {
+				akcSecret := &corev1.Secret{}
+				err := c.Get(context.TODO(), client.ObjectKey{Name: adminKubeconfigSecret, Namespace: testNamespace},
+					akcSecret)
+				require.NoError(t, err)
+				config, err := clientcmd.Load(akcSecret.Data[constants.KubeconfigSecretKey])
+				require.NoError(t, err)
+				assert.Equal(t, "admin", config.CurrentContext, "unexpected current context")
+				if assert.Contains(t, config.Contexts, constants.AdminKubeconfigInternalContextName) {
+					internalCluster := config.Contexts[constants.AdminKubeconfigInternalContextName].Cluster
+					assert.Equal(t, "https://api-int.bar.clusters.example.com:6443", config.Clusters[internalCluster].Server)
+				}
+				cd := getCD(c)
+				assert.Equal(t, hivev1.AdminKubeconfigContextExternal, cd.Status.AdminKubeconfigContext)
+			},
+		},
 		{
 			name: "Completed provision",
 			existing: []runtime.Object{
@@ -502,6 +604,65 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 
 			},
 		},
+		{
+			name: "Wait for clusterimageset to be validated",
+			existing: []runtime.Object{
+				func() *hivev1.ClusterDeployment {
+					cd := testClusterDeployment()
+					cd.Status.InstallerImage = nil
+					cd.Spec.Provisioning.ImageSetRef = &hivev1.ClusterImageSetReference{Name: testClusterImageSetName}
+					return cd
+				}(),
+				testClusterImageSetWithValidCondition(corev1.ConditionUnknown, "Validating"),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			expectedRequeueAfter: imageSetValidatingRequeueTime,
+			validate: func(c client.Client, t *testing.T) {
+				assert.Nil(t, getImageSetJob(c), "expected no imageset job")
+				cd := getCD(c)
+				assertConditionStatus(t, cd, hivev1.InstallImagesNotResolvedCondition, corev1.ConditionTrue)
+				assertConditionReason(t, cd, hivev1.InstallImagesNotResolvedCondition, imageSetValidatingReason)
+			},
+		},
+		{
+			name: "invalid clusterimageset should set InstallImagesNotResolved condition on clusterdeployment",
+			existing: []runtime.Object{
+				func() *hivev1.ClusterDeployment {
+					cd := testClusterDeployment()
+					cd.Status.InstallerImage = nil
+					cd.Spec.Provisioning.ImageSetRef = &hivev1.ClusterImageSetReference{Name: testClusterImageSetName}
+					return cd
+				}(),
+				testClusterImageSetWithValidCondition(corev1.ConditionFalse, "Invalid"),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			expectedRequeueAfter: imageSetInvalidRequeueTime,
+			validate: func(c client.Client, t *testing.T) {
+				assert.Nil(t, getImageSetJob(c), "expected no imageset job")
+				cd := getCD(c)
+				assertConditionStatus(t, cd, hivev1.InstallImagesNotResolvedCondition, corev1.ConditionTrue)
+				assertConditionReason(t, cd, hivev1.InstallImagesNotResolvedCondition, imageSetInvalidReason)
+			},
+		},
+		{
+			name: "Create job to resolve installer image of valid clusterimageset",
+			existing: []runtime.Object{
+				func() *hivev1.ClusterDeployment {
+					cd := testClusterDeployment()
+					cd.Status.InstallerImage = nil
+					cd.Spec.Provisioning.ImageSetRef = &hivev1.ClusterImageSetReference{Name: testClusterImageSetName}
+					return cd
+				}(),
+				testClusterImageSetWithValidCondition(corev1.ConditionTrue, "Valid"),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			validate: func(c client.Client, t *testing.T) {
+				assert.NotNil(t, getImageSetJob(c), "expected imageset job")
+			},
+		},
 		{
 			name: "clear InstallImagesNotResolved condition on success",
 			existing: []runtime.Object{
@@ -675,6 +836,36 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 				assertConditionStatus(t, cd, hivev1.DNSNotReadyCondition, corev1.ConditionTrue)
 			},
 		},
+		{
+			name: "Report delegation mismatch when DNSZone is not available yet",
+			existing: []runtime.Object{
+				func() *hivev1.ClusterDeployment {
+					cd := testClusterDeployment()
+					cd.Spec.ManageDNS = true
+					return cd
+				}(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+				func() *hivev1.DNSZone {
+					zone := testDNSZone()
+					zone.Status.Conditions = []hivev1.DNSZoneCondition{{
+						Type:    hivev1.DNSNotDelegatedCondition,
+						Status:  corev1.ConditionTrue,
+						Reason:  "NameServerMismatch",
+						Message: "Resolvers returned name servers ns-3.example.net for zone, expected ns-1.example.net",
+					}}
+					return zone
+				}(),
+			},
+			expectedRequeueAfter: defaultDNSNotReadyTimeout + defaultRequeueTime,
+			validate: func(c client.Client, t *testing.T) {
+				cd := getCD(c)
+				cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.DNSNotReadyCondition)
+				if assert.NotNil(t, cond, "expected to find condition") {
+					assert.Equal(t, "DNS Zone not yet available: Resolvers returned name servers ns-3.example.net for zone, expected ns-1.example.net", cond.Message, "unexpected condition message")
+				}
+			},
+		},
 		{
 			name: "Set condition when DNSZone cannot be created due to credentials missing permissions",
 			existing: []runtime.Object{
@@ -792,6 +983,64 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 				assert.NotNil(t, zone, "expected DNSZone to exist")
 			},
 		},
+		{
+			name: "Adopt preserved DNSZone",
+			existing: []runtime.Object{
+				func() *hivev1.ClusterDeployment {
+					cd := testClusterDeployment()
+					cd.Spec.ManageDNS = true
+					cd.CreationTimestamp = metav1.Now()
+					return cd
+				}(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+				func() *hivev1.DNSZone {
+					zone := testAvailableDNSZone()
+					zone.OwnerReferences = nil
+					zone.Annotations = map[string]string{constants.PreservedDNSZoneAnnotation: "true"}
+					zone.Spec.Zone = testClusterDeployment().Spec.BaseDomain
+					zone.Status.Conditions[0].LastTransitionTime = metav1.NewTime(time.Now().Add(-24 * time.Hour))
+					return zone
+				}(),
+			},
+			validate: func(c client.Client, t *testing.T) {
+				zone := getDNSZone(c)
+				if assert.NotNil(t, zone, "expected DNSZone to exist") {
+					assert.True(t, metav1.IsControlledBy(zone, testClusterDeployment()), "expected DNSZone to be owned by cluster deployment")
+					assert.NotContains(t, zone.Annotations, constants.PreservedDNSZoneAnnotation, "unexpected preserved annotation")
+				}
+				cd := getCD(c)
+				if assert.NotNil(t, cd, "missing clusterdeployment") {
+					assert.Equal(t, "0s", cd.Annotations[dnsReadyAnnotation], "unexpected DNS delay")
+				}
+			},
+		},
+		{
+			name: "Do not adopt preserved DNSZone for other base domain",
+			existing: []runtime.Object{
+				func() *hivev1.ClusterDeployment {
+					cd := testClusterDeployment()
+					cd.Spec.ManageDNS = true
+					return cd
+				}(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+				func() *hivev1.DNSZone {
+					zone := testAvailableDNSZone()
+					zone.OwnerReferences = nil
+					zone.Annotations = map[string]string{constants.PreservedDNSZoneAnnotation: "true"}
+					zone.Spec.Zone = "other.example.com"
+					return zone
+				}(),
+			},
+			expectErr: true,
+			validate: func(c client.Client, t *testing.T) {
+				zone := getDNSZone(c)
+				if assert.NotNil(t, zone, "expected DNSZone to exist") {
+					assert.Empty(t, zone.OwnerReferences, "unexpected owner of DNSZone")
+				}
+			},
+		},
 		{
 			name: "Create provision when DNSZone is ready",
 			existing: []runtime.Object{
@@ -846,6 +1095,27 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 				assert.Nil(t, dnsZone, "dnsZone should not exist")
 			},
 		},
+		{
+			name: "Preserve managed DNSZone when cluster deployment is deleted",
+			existing: []runtime.Object{
+				func() *hivev1.ClusterDeployment {
+					cd := testDeletedClusterDeployment()
+					cd.Spec.ManageDNS = true
+					cd.Spec.PreserveDNSZoneOnDelete = true
+					return cd
+				}(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+				testDNSZone(),
+			},
+			validate: func(c client.Client, t *testing.T) {
+				zone := getDNSZone(c)
+				if assert.NotNil(t, zone, "expected DNSZone to be preserved") {
+					assert.Empty(t, zone.OwnerReferences, "expected DNSZone to be released")
+					assert.Equal(t, "true", zone.Annotations[constants.PreservedDNSZoneAnnotation], "expected preserved annotation")
+				}
+			},
+		},
 		{
 			name: "Delete cluster deployment with missing clusterimageset",
 			existing: []runtime.Object{
@@ -984,6 +1254,32 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "Wait for must-gather of failed provision",
+			existing: []runtime.Object{
+				testClusterDeploymentWithProvision(),
+				func() runtime.Object {
+					provision := testFailedProvisionTime(time.Now().Add(-2 * time.Minute))
+					provision.Status.MustGather = &hivev1.ClusterProvisionMustGather{
+						JobRef: corev1.LocalObjectReference{Name: "must-gather-job"},
+					}
+					return provision
+				}(),
+				testMetadataConfigMap(),
+				testSecret(corev1.SecretTypeOpaque, adminKubeconfigSecret, "kubeconfig", adminKubeconfig),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			validate: func(c client.Client, t *testing.T) {
+				cd := getCD(c)
+				if assert.NotNil(t, cd, "missing clusterdeployment") {
+					if assert.NotNil(t, cd.Status.ProvisionRef, "missing provision ref") {
+						assert.Equal(t, provisionName, cd.Status.ProvisionRef.Name, "unexpected provision ref name")
+					}
+					assert.Equal(t, 0, cd.Status.InstallRestarts, "unexpected install restart count")
+				}
+			},
+		},
 		{
 			name: "Delete outstanding provision on delete",
 			existing: []runtime.Object{
@@ -1119,6 +1415,75 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "Ready condition waits for readiness gates",
+			existing: []runtime.Object{
+				func() runtime.Object {
+					cd := testInstalledClusterDeployment(time.Now())
+					cd.Spec.ReadinessGates = []hivev1.ClusterDeploymentReadinessGate{
+						{ConditionType: "example.com/ComplianceScanPassed"},
+						{ConditionType: "example.com/BackupConfigured"},
+					}
+					cd.Status.Conditions = append(
+						cd.Status.Conditions,
+						hivev1.ClusterDeploymentCondition{
+							Type:   "example.com/BackupConfigured",
+							Status: corev1.ConditionTrue,
+						},
+						hivev1.ClusterDeploymentCondition{
+							Type:   hivev1.ReadyClusterDeploymentCondition,
+							Status: corev1.ConditionTrue,
+							Reason: "ClusterReady",
+						},
+					)
+					return cd
+				}(),
+				testSecret(corev1.SecretTypeOpaque, adminKubeconfigSecret, "kubeconfig", adminKubeconfig),
+				testSecret(corev1.SecretTypeOpaque, adminPasswordSecret, "password", adminPassword),
+			},
+			validate: func(c client.Client, t *testing.T) {
+				cd := getCD(c)
+				cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.ReadyClusterDeploymentCondition)
+				if assert.NotNil(t, cond, "missing Ready status condition") {
+					assert.Equal(t, corev1.ConditionFalse, cond.Status, "did not get expected state for Ready condition")
+					assert.Equal(t, "ReadinessGatesNotMet", cond.Reason, "did not get expected reason for Ready condition")
+					assert.Contains(t, cond.Message, "example.com/ComplianceScanPassed", "expected unmet gate in message")
+				}
+			},
+		},
+		{
+			name: "Ready condition set when readiness gates are met",
+			existing: []runtime.Object{
+				func() runtime.Object {
+					cd := testInstalledClusterDeployment(time.Now())
+					cd.Spec.ReadinessGates = []hivev1.ClusterDeploymentReadinessGate{
+						{ConditionType: "example.com/ComplianceScanPassed"},
+					}
+					cd.Status.Conditions = append(
+						cd.Status.Conditions,
+						hivev1.ClusterDeploymentCondition{
+							Type:   "example.com/ComplianceScanPassed",
+							Status: corev1.ConditionTrue,
+						},
+						hivev1.ClusterDeploymentCondition{
+							Type:   hivev1.ReadyClusterDeploymentCondition,
+							Status: corev1.ConditionFalse,
+							Reason: "ReadinessGatesNotMet",
+						},
+					)
+					return cd
+				}(),
+				testSecret(corev1.SecretTypeOpaque, adminKubeconfigSecret, "kubeconfig", adminKubeconfig),
+				testSecret(corev1.SecretTypeOpaque, adminPasswordSecret, "password", adminPassword),
+			},
+			validate: func(c client.Client, t *testing.T) {
+				cd := getCD(c)
+				cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.ReadyClusterDeploymentCondition)
+				if assert.NotNil(t, cond, "missing Ready status condition") {
+					assert.Equal(t, corev1.ConditionTrue, cond.Status, "did not get expected state for Ready condition")
+				}
+			},
+		},
 		{
 			name: "Add cluster platform label",
 			existing: []runtime.Object{
@@ -1485,6 +1850,98 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 				assertConditionReason(t, cd, hivev1.ProvisionStoppedCondition, "InstallAttemptsLimitReached")
 			},
 		},
+		{
+			name: "retry policy max attempts takes precedence over the limit",
+			existing: []runtime.Object{
+				func() runtime.Object {
+					cd := testClusterDeployment()
+					cd.Status.InstallRestarts = 2
+					cd.Spec.InstallAttemptsLimit = pointer.Int32Ptr(5)
+					cd.Spec.ProvisionRetryPolicy = &hivev1.ProvisionRetryPolicy{MaxAttempts: pointer.Int32Ptr(2)}
+					return cd
+				}(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			validate: func(c client.Client, t *testing.T) {
+				cd := getCD(c)
+				require.NotNil(t, cd, "could not get ClusterDeployment")
+				assertConditionStatus(t, cd, hivev1.ProvisionStoppedCondition, corev1.ConditionTrue)
+				assertConditionReason(t, cd, hivev1.ProvisionStoppedCondition, "InstallAttemptsLimitReached")
+			},
+		},
+		{
+			name: "provision failure is retryable",
+			existing: []runtime.Object{
+				func() runtime.Object {
+					cd := testClusterDeployment()
+					cd.Status.InstallRestarts = 1
+					cd.Spec.ProvisionRetryPolicy = &hivev1.ProvisionRetryPolicy{RetryableReasons: []string{"AWSInsufficientCapacity"}}
+					cd.Status.Conditions = []hivev1.ClusterDeploymentCondition{{
+						Type:   hivev1.ProvisionFailedCondition,
+						Status: corev1.ConditionTrue,
+						Reason: "AWSInsufficientCapacity",
+					}}
+					return cd
+				}(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			expectPendingCreation: true,
+			validate: func(c client.Client, t *testing.T) {
+				cd := getCD(c)
+				require.NotNil(t, cd, "could not get ClusterDeployment")
+				assert.Nil(t, controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.ProvisionStoppedCondition), "ClusterDeployment should not have ProvisionStopped condition")
+			},
+		},
+		{
+			name: "provision failure is not retryable",
+			existing: []runtime.Object{
+				func() runtime.Object {
+					cd := testClusterDeployment()
+					cd.Status.InstallRestarts = 1
+					cd.Spec.ProvisionRetryPolicy = &hivev1.ProvisionRetryPolicy{RetryableReasons: []string{"AWSInsufficientCapacity"}}
+					cd.Status.Conditions = []hivev1.ClusterDeploymentCondition{{
+						Type:   hivev1.ProvisionFailedCondition,
+						Status: corev1.ConditionTrue,
+						Reason: "InvalidInstallConfig",
+					}}
+					return cd
+				}(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			validate: func(c client.Client, t *testing.T) {
+				cd := getCD(c)
+				require.NotNil(t, cd, "could not get ClusterDeployment")
+				assertConditionStatus(t, cd, hivev1.ProvisionStoppedCondition, corev1.ConditionTrue)
+				assertConditionReason(t, cd, hivev1.ProvisionStoppedCondition, "FailureNotRetryable")
+			},
+		},
+		{
+			name: "provision no longer stopped after install attempts limit raised",
+			existing: []runtime.Object{
+				func() runtime.Object {
+					cd := testClusterDeployment()
+					cd.Status.InstallRestarts = 2
+					cd.Spec.InstallAttemptsLimit = pointer.Int32Ptr(3)
+					cd.Status.Conditions = []hivev1.ClusterDeploymentCondition{{
+						Type:   hivev1.ProvisionStoppedCondition,
+						Status: corev1.ConditionTrue,
+						Reason: "InstallAttemptsLimitReached",
+					}}
+					return cd
+				}(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			validate: func(c client.Client, t *testing.T) {
+				cd := getCD(c)
+				require.NotNil(t, cd, "could not get ClusterDeployment")
+				assertConditionStatus(t, cd, hivev1.ProvisionStoppedCondition, corev1.ConditionFalse)
+				assertConditionReason(t, cd, hivev1.ProvisionStoppedCondition, "ProvisionNotStopped")
+			},
+		},
 		{
 			name: "auth condition when platform creds are bad",
 			existing: []runtime.Object{
@@ -1532,6 +1989,76 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 				assert.Zero(t, len(provisionList.Items), "expected no ClusterProvision objects when platform creds are bad")
 			},
 		},
+		{
+			name: "no ClusterProvision when platform creds are malformed",
+			existing: []runtime.Object{
+				testClusterDeployment(),
+				testSecret(corev1.SecretTypeOpaque, "aws-credentials", constants.AWSAccessKeyIDSecretKey, "key-id"),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+			},
+			expectErr: true,
+			validate: func(c client.Client, t *testing.T) {
+				cd := getCD(c)
+				require.NotNil(t, cd, "could not get ClusterDeployment")
+				assertConditionStatus(t, cd, hivev1.CredentialsMalformedClusterDeploymentCondition, corev1.ConditionTrue)
+				assertConditionReason(t, cd, hivev1.CredentialsMalformedClusterDeploymentCondition, credentialsMalformedReason)
+
+				provisionList := &hivev1.ClusterProvisionList{}
+				err := c.List(context.TODO(), provisionList, client.InNamespace(cd.Namespace))
+				require.NoError(t, err, "unexpected error listing ClusterProvisions")
+				assert.Zero(t, len(provisionList.Items), "expected no ClusterProvision objects when platform creds are malformed")
+			},
+		},
+		{
+			name: "clear malformed creds condition",
+			existing: []runtime.Object{
+				func() *hivev1.ClusterDeployment {
+					cd := testClusterDeployment()
+					cd.Status.Conditions = []hivev1.ClusterDeploymentCondition{
+						{
+							Status:  corev1.ConditionTrue,
+							Type:    hivev1.CredentialsMalformedClusterDeploymentCondition,
+							Reason:  credentialsMalformedReason,
+							Message: "credentials secret \"aws-credentials\" is missing the \"aws_secret_access_key\" key",
+						},
+					}
+					return cd
+				}(),
+				func() *corev1.Secret {
+					secret := testSecret(corev1.SecretTypeOpaque, "aws-credentials", constants.AWSAccessKeyIDSecretKey, "key-id")
+					secret.Data[constants.AWSSecretAccessKeySecretKey] = []byte("secret-key")
+					return secret
+				}(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+			},
+			validate: func(c client.Client, t *testing.T) {
+				cd := getCD(c)
+				require.NotNil(t, cd, "could not get ClusterDeployment")
+				assertConditionStatus(t, cd, hivev1.CredentialsMalformedClusterDeploymentCondition, corev1.ConditionFalse)
+			},
+		},
+		{
+			name: "no ClusterProvision when platform creds are expired",
+			existing: []runtime.Object{
+				testClusterDeployment(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+			},
+			credentialsExpiry: func(client.Client, *hivev1.ClusterDeployment, log.FieldLogger) (string, error) {
+				return "credentials secret \"aws-credentials\" has expired", nil
+			},
+			expectErr: true,
+			validate: func(c client.Client, t *testing.T) {
+				cd := getCD(c)
+				require.NotNil(t, cd, "could not get ClusterDeployment")
+				assertConditionStatus(t, cd, hivev1.CredentialsMalformedClusterDeploymentCondition, corev1.ConditionTrue)
+				assertConditionReason(t, cd, hivev1.CredentialsMalformedClusterDeploymentCondition, credentialsExpiredReason)
+
+				provisionList := &hivev1.ClusterProvisionList{}
+				err := c.List(context.TODO(), provisionList, client.InNamespace(cd.Namespace))
+				require.NoError(t, err, "unexpected error listing ClusterProvisions")
+				assert.Zero(t, len(provisionList.Items), "expected no ClusterProvision objects when platform creds are expired")
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -1549,6 +2076,11 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 					return true, nil
 				}
 			}
+			if test.credentialsExpiry == nil {
+				test.credentialsExpiry = func(client.Client, *hivev1.ClusterDeployment, log.FieldLogger) (string, error) {
+					return "", nil
+				}
+			}
 			rcd := &ReconcileClusterDeployment{
 				Client:                                  fakeClient,
 				scheme:                                  scheme.Scheme,
@@ -1556,6 +2088,7 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 				expectations:                            controllerExpectations,
 				remoteClusterAPIClientBuilder:           func(*hivev1.ClusterDeployment) remoteclient.Builder { return mockRemoteClientBuilder },
 				validateCredentialsForClusterDeployment: test.platformCredentialsValidation,
+				checkCredentialsExpiry:                  test.credentialsExpiry,
 			}
 
 			if test.reconcilerSetup != nil {
@@ -1654,6 +2187,7 @@ func TestCalculateNextProvisionTime(t *testing.T) {
 		name             string
 		failureTime      time.Time
 		attempt          int
+		policy           *hivev1.ProvisionRetryPolicy
 		expectedNextTime time.Time
 	}{
 		{
@@ -1698,10 +2232,47 @@ func TestCalculateNextProvisionTime(t *testing.T) {
 			attempt:          999999,
 			expectedNextTime: time.Date(2019, time.July, 17, 0, 0, 0, 0, time.UTC),
 		},
+		{
+			name:        "first attempt with backoff base",
+			failureTime: time.Date(2019, time.July, 16, 0, 0, 0, 0, time.UTC),
+			attempt:     0,
+			policy: &hivev1.ProvisionRetryPolicy{
+				BackoffBase: &metav1.Duration{Duration: 10 * time.Minute},
+			},
+			expectedNextTime: time.Date(2019, time.July, 16, 0, 10, 0, 0, time.UTC),
+		},
+		{
+			name:        "third attempt with backoff base",
+			failureTime: time.Date(2019, time.July, 16, 0, 0, 0, 0, time.UTC),
+			attempt:     2,
+			policy: &hivev1.ProvisionRetryPolicy{
+				BackoffBase: &metav1.Duration{Duration: 10 * time.Minute},
+			},
+			expectedNextTime: time.Date(2019, time.July, 16, 0, 40, 0, 0, time.UTC),
+		},
+		{
+			name:        "max backoff reached",
+			failureTime: time.Date(2019, time.July, 16, 0, 0, 0, 0, time.UTC),
+			attempt:     5,
+			policy: &hivev1.ProvisionRetryPolicy{
+				MaxBackoff: &metav1.Duration{Duration: 15 * time.Minute},
+			},
+			expectedNextTime: time.Date(2019, time.July, 16, 0, 15, 0, 0, time.UTC),
+		},
+		{
+			name:        "millionth attempt with max backoff",
+			failureTime: time.Date(2019, time.July, 16, 0, 0, 0, 0, time.UTC),
+			attempt:     999999,
+			policy: &hivev1.ProvisionRetryPolicy{
+				BackoffBase: &metav1.Duration{Duration: 30 * time.Second},
+				MaxBackoff:  &metav1.Duration{Duration: time.Hour},
+			},
+			expectedNextTime: time.Date(2019, time.July, 16, 1, 0, 0, 0, time.UTC),
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
-			actualNextTime := calculateNextProvisionTime(tc.failureTime, tc.attempt, log.WithField("controller", "clusterDeployment"))
+			actualNextTime := calculateNextProvisionTime(tc.failureTime, tc.attempt, tc.policy, log.WithField("controller", "clusterDeployment"))
 			assert.Equal(t, tc.expectedNextTime.String(), actualNextTime.String(), "unexpected next provision time")
 		})
 	}
@@ -2046,6 +2617,17 @@ func testClusterImageSet() *hivev1.ClusterImageSet {
 	return cis
 }
 
+func testClusterImageSetWithValidCondition(status corev1.ConditionStatus, reason string) *hivev1.ClusterImageSet {
+	cis := testClusterImageSet()
+	cis.Status.ReleaseImage = cis.Spec.ReleaseImage
+	cis.Status.Conditions = []hivev1.ClusterImageSetCondition{{
+		Type:   hivev1.ClusterImageSetValidCondition,
+		Status: status,
+		Reason: reason,
+	}}
+	return cis
+}
+
 func testDNSZone() *hivev1.DNSZone {
 	zone := &hivev1.DNSZone{}
 	zone.Name = testName + "-zone"
@@ -2197,6 +2779,9 @@ func TestUpdatePullSecretInfo(t *testing.T) {
 				validateCredentialsForClusterDeployment: func(client.Client, *hivev1.ClusterDeployment, log.FieldLogger) (bool, error) {
 					return true, nil
 				},
+				checkCredentialsExpiry: func(client.Client, *hivev1.ClusterDeployment, log.FieldLogger) (string, error) {
+					return "", nil
+				},
 			}
 
 			_, err := rcd.Reconcile(reconcile.Request{
@@ -2277,6 +2862,8 @@ func TestMergePullSecrets(t *testing.T) {
 		name                    string
 		localPullSecret         string
 		globalPullSecret        string
+		namespacePullSecret     string
+		conflictPolicy          hivev1.PullSecretConflictPolicy
 		mergedPullSecret        string
 		existingObjs            []runtime.Object
 		expectedErr             bool
@@ -2337,6 +2924,91 @@ func TestMergePullSecrets(t *testing.T) {
 			},
 			expectedErr: true,
 		},
+		{
+			name:                "namespace default pull secret merged between global and local pull secrets",
+			localPullSecret:     `{"auths":{"quay.io":{"auth":"bG9jYWw="}}}`,
+			namespacePullSecret: `{"auths":{"quay.io":{"auth":"bmFtZXNwYWNl"},"registry.example.com":{"auth":"bmFtZXNwYWNl"}}}`,
+			globalPullSecret:    `{"auths":{"registry.example.com":{"auth":"Z2xvYmFs"},"cloud.okd.com":{"auth":"Z2xvYmFs"}}}`,
+			mergedPullSecret:    `{"auths":{"cloud.okd.com":{"auth":"Z2xvYmFs"},"quay.io":{"auth":"bG9jYWw="},"registry.example.com":{"auth":"bmFtZXNwYWNl"}}}`,
+			existingObjs: []runtime.Object{
+				func() *hivev1.ClusterDeployment {
+					cd := getCDWithoutPullSecret()
+					cd.Spec.PullSecretRef = &corev1.LocalObjectReference{
+						Name: pullSecretSecret,
+					}
+					return cd
+				}(),
+			},
+			addGlobalSecretToHiveNs: true,
+		},
+		{
+			name:                "namespace default pull secret alone",
+			namespacePullSecret: `{"auths": {"quay.io": {"auth": "bmFtZXNwYWNl"}}}`,
+			mergedPullSecret:    `{"auths": {"quay.io": {"auth": "bmFtZXNwYWNl"}}}`,
+			existingObjs: []runtime.Object{
+				getCDWithoutPullSecret(),
+			},
+		},
+		{
+			name:                "global pull secret preferred",
+			localPullSecret:     `{"auths":{"quay.io":{"auth":"bG9jYWw="}}}`,
+			namespacePullSecret: `{"auths":{"quay.io":{"auth":"bmFtZXNwYWNl"}}}`,
+			globalPullSecret:    `{"auths":{"quay.io":{"auth":"Z2xvYmFs"}}}`,
+			conflictPolicy:      hivev1.PullSecretConflictPolicyPreferGlobal,
+			mergedPullSecret:    `{"auths":{"quay.io":{"auth":"Z2xvYmFs"}}}`,
+			existingObjs: []runtime.Object{
+				func() *hivev1.ClusterDeployment {
+					cd := getCDWithoutPullSecret()
+					cd.Spec.PullSecretRef = &corev1.LocalObjectReference{
+						Name: pullSecretSecret,
+					}
+					return cd
+				}(),
+			},
+			addGlobalSecretToHiveNs: true,
+		},
+		{
+			name:             "conflicting pull secrets rejected",
+			localPullSecret:  `{"auths":{"quay.io":{"auth":"bG9jYWw="}}}`,
+			globalPullSecret: `{"auths":{"quay.io":{"auth":"Z2xvYmFs"}}}`,
+			conflictPolicy:   hivev1.PullSecretConflictPolicyReject,
+			existingObjs: []runtime.Object{
+				func() *hivev1.ClusterDeployment {
+					cd := getCDWithoutPullSecret()
+					cd.Spec.PullSecretRef = &corev1.LocalObjectReference{
+						Name: pullSecretSecret,
+					}
+					return cd
+				}(),
+			},
+			addGlobalSecretToHiveNs: true,
+			expectedErr:             true,
+		},
+		{
+			name:             "identical auths not rejected",
+			localPullSecret:  `{"auths":{"quay.io":{"auth":"Z2xvYmFs"}}}`,
+			globalPullSecret: `{"auths":{"quay.io":{"auth":"Z2xvYmFs"}}}`,
+			conflictPolicy:   hivev1.PullSecretConflictPolicyReject,
+			mergedPullSecret: `{"auths":{"quay.io":{"auth":"Z2xvYmFs"}}}`,
+			existingObjs: []runtime.Object{
+				func() *hivev1.ClusterDeployment {
+					cd := getCDWithoutPullSecret()
+					cd.Spec.PullSecretRef = &corev1.LocalObjectReference{
+						Name: pullSecretSecret,
+					}
+					return cd
+				}(),
+			},
+			addGlobalSecretToHiveNs: true,
+		},
+		{
+			name:                "invalid namespace default pull secret",
+			namespacePullSecret: `{"auths":["quay.io"]}`,
+			existingObjs: []runtime.Object{
+				getCDWithoutPullSecret(),
+			},
+			expectedErr: true,
+		},
 	}
 
 	for _, test := range tests {
@@ -2349,6 +3021,10 @@ func TestMergePullSecrets(t *testing.T) {
 				localSecretObject := testSecret(corev1.SecretTypeDockercfg, pullSecretSecret, corev1.DockerConfigJsonKey, test.localPullSecret)
 				test.existingObjs = append(test.existingObjs, localSecretObject)
 			}
+			if test.namespacePullSecret != "" {
+				namespaceSecretObject := testSecret(corev1.SecretTypeDockerConfigJson, constants.NamespaceDefaultPullSecretName, corev1.DockerConfigJsonKey, test.namespacePullSecret)
+				test.existingObjs = append(test.existingObjs, namespaceSecretObject)
+			}
 			fakeClient := fake.NewFakeClient(test.existingObjs...)
 			mockCtrl := gomock.NewController(t)
 			defer mockCtrl.Finish()
@@ -2358,6 +3034,7 @@ func TestMergePullSecrets(t *testing.T) {
 				scheme:                        scheme.Scheme,
 				logger:                        log.WithField("controller", "clusterDeployment"),
 				remoteClusterAPIClientBuilder: func(*hivev1.ClusterDeployment) remoteclient.Builder { return mockRemoteClientBuilder },
+				pullSecretConflictPolicy:      test.conflictPolicy,
 			}
 
 			cd := getCDFromClient(rcd.Client)
@@ -2475,9 +3152,10 @@ func TestEnsureManagedDNSZone(t *testing.T) {
 			),
 			expectedErr: true,
 			expectedDNSNotReadyCondition: &hivev1.ClusterDeploymentCondition{
-				Type:   hivev1.DNSNotReadyCondition,
-				Status: corev1.ConditionTrue,
-				Reason: dnsUnsupportedPlatformReason,
+				Type:     hivev1.DNSNotReadyCondition,
+				Status:   corev1.ConditionTrue,
+				Reason:   dnsUnsupportedPlatformReason,
+				Category: hivev1.FailureCategoryUserConfig,
 			},
 		},
 		{
@@ -2501,9 +3179,10 @@ func TestEnsureManagedDNSZone(t *testing.T) {
 			),
 			expectedErr: true,
 			expectedDNSNotReadyCondition: &hivev1.ClusterDeploymentCondition{
-				Type:   hivev1.DNSNotReadyCondition,
-				Status: corev1.ConditionTrue,
-				Reason: dnsNotReadyReason,
+				Type:     hivev1.DNSNotReadyCondition,
+				Status:   corev1.ConditionTrue,
+				Reason:   dnsNotReadyReason,
+				Category: hivev1.FailureCategoryNetwork,
 			},
 		},
 		{
@@ -2518,9 +3197,10 @@ func TestEnsureManagedDNSZone(t *testing.T) {
 			),
 			expectedErr: true,
 			expectedDNSNotReadyCondition: &hivev1.ClusterDeploymentCondition{
-				Type:   hivev1.DNSNotReadyCondition,
-				Status: corev1.ConditionTrue,
-				Reason: dnsZoneResourceConflictReason,
+				Type:     hivev1.DNSNotReadyCondition,
+				Status:   corev1.ConditionTrue,
+				Reason:   dnsZoneResourceConflictReason,
+				Category: hivev1.FailureCategoryUserConfig,
 			},
 		},
 		{
@@ -2545,9 +3225,10 @@ func TestEnsureManagedDNSZone(t *testing.T) {
 			),
 			expectedErr: true,
 			expectedDNSNotReadyCondition: &hivev1.ClusterDeploymentCondition{
-				Type:   hivev1.DNSNotReadyCondition,
-				Status: corev1.ConditionTrue,
-				Reason: dnsNotReadyTimedoutReason,
+				Type:     hivev1.DNSNotReadyCondition,
+				Status:   corev1.ConditionTrue,
+				Reason:   dnsNotReadyTimedoutReason,
+				Category: hivev1.FailureCategoryNetwork,
 			},
 		},
 	}
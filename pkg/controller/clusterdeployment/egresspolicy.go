@@ -0,0 +1,156 @@
+package clusterdeployment
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/install"
+)
+
+// ensureInstallEgressPolicy creates or updates the policy restricting the network egress of the install and
+// deprovision pods of the clusterdeployment to the endpoints they need, when the HiveConfig configures one. Policies
+// of a type which is no longer configured are removed.
+func (r *ReconcileClusterDeployment) ensureInstallEgressPolicy(cd *hivev1.ClusterDeployment, releaseImage string, cdLog log.FieldLogger) error {
+	if r.installEgressPolicyType != hivev1.InstallEgressPolicyTypeNetworkPolicy {
+		if err := r.deleteInstallEgressNetworkPolicy(cd, cdLog); err != nil {
+			return err
+		}
+	}
+	if r.installEgressPolicyType != hivev1.InstallEgressPolicyTypeEgressFirewall {
+		if err := r.deleteInstallEgressFirewall(cd, cdLog); err != nil {
+			return err
+		}
+	}
+	if r.installEgressPolicyType == "" {
+		return nil
+	}
+	endpoints := install.InstallEgressEndpoints(cd, getClusterPlatform(cd), getClusterRegion(cd), releaseImage, r.installEgressAdditionalEndpoints)
+	switch r.installEgressPolicyType {
+	case hivev1.InstallEgressPolicyTypeNetworkPolicy:
+		return r.updateInstallEgressNetworkPolicy(install.GenerateInstallEgressNetworkPolicy(cd, endpoints), cd, cdLog)
+	case hivev1.InstallEgressPolicyTypeEgressFirewall:
+		return r.updateInstallEgressFirewall(install.GenerateInstallEgressFirewall(cd, endpoints), cd, cdLog)
+	}
+	return errors.Errorf("unknown install egress policy type %q", r.installEgressPolicyType)
+}
+
+// updateInstallEgressNetworkPolicy creates or updates the NetworkPolicy restricting the egress of the install and
+// deprovision pods of the clusterdeployment.
+func (r *ReconcileClusterDeployment) updateInstallEgressNetworkPolicy(desired *networkingv1.NetworkPolicy, cd *hivev1.ClusterDeployment, cdLog log.FieldLogger) error {
+	if err := controllerutil.SetControllerReference(cd, desired, r.scheme); err != nil {
+		cdLog.WithError(err).Error("error setting controller reference on install egress network policy")
+		return err
+	}
+	existing := &networkingv1.NetworkPolicy{}
+	switch err := r.Get(context.TODO(), types.NamespacedName{Namespace: desired.Namespace, Name: desired.Name}, existing); {
+	case apierrors.IsNotFound(err):
+		if err := r.Create(context.TODO(), desired); err != nil {
+			return errors.Wrap(err, "error creating install egress network policy")
+		}
+		cdLog.WithField("networkPolicy", desired.Name).Info("created the install egress network policy")
+		return nil
+	case err != nil:
+		return errors.Wrap(err, "error getting install egress network policy")
+	}
+
+	if reflect.DeepEqual(existing.Spec, desired.Spec) && metav1.IsControlledBy(existing, cd) {
+		return nil
+	}
+	existing.Labels = desired.Labels
+	existing.OwnerReferences = desired.OwnerReferences
+	existing.Spec = desired.Spec
+	if err := r.Update(context.TODO(), existing); err != nil {
+		return errors.Wrap(err, "error updating install egress network policy")
+	}
+	cdLog.WithField("networkPolicy", existing.Name).Info("updated the install egress network policy")
+	return nil
+}
+
+// updateInstallEgressFirewall creates or updates the EgressFirewall restricting the egress of the pods in the namespace
+// of the clusterdeployment. A namespace holds a single EgressFirewall, so one which was not created for the
+// clusterdeployment is left alone.
+func (r *ReconcileClusterDeployment) updateInstallEgressFirewall(desired *unstructured.Unstructured, cd *hivev1.ClusterDeployment, cdLog log.FieldLogger) error {
+	if err := controllerutil.SetControllerReference(cd, desired, r.scheme); err != nil {
+		cdLog.WithError(err).Error("error setting controller reference on install egress firewall")
+		return err
+	}
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(install.EgressFirewallGVK)
+	switch err := r.Get(context.TODO(), types.NamespacedName{Namespace: desired.GetNamespace(), Name: desired.GetName()}, existing); {
+	case apierrors.IsNotFound(err):
+		if err := r.Create(context.TODO(), desired); err != nil {
+			return errors.Wrap(err, "error creating install egress firewall")
+		}
+		cdLog.WithField("egressFirewall", desired.GetName()).Info("created the install egress firewall")
+		return nil
+	case err != nil:
+		return errors.Wrap(err, "error getting install egress firewall")
+	}
+
+	if existing.GetLabels()[constants.ClusterDeploymentNameLabel] != cd.Name {
+		cdLog.WithField("egressFirewall", existing.GetName()).Warn("not replacing an egress firewall which was not created for the cluster deployment")
+		return nil
+	}
+	if reflect.DeepEqual(existing.Object["spec"], desired.Object["spec"]) {
+		return nil
+	}
+	existing.Object["spec"] = desired.Object["spec"]
+	if err := r.Update(context.TODO(), existing); err != nil {
+		return errors.Wrap(err, "error updating install egress firewall")
+	}
+	cdLog.WithField("egressFirewall", existing.GetName()).Info("updated the install egress firewall")
+	return nil
+}
+
+// deleteInstallEgressNetworkPolicy deletes the install egress NetworkPolicy of the clusterdeployment, if any.
+func (r *ReconcileClusterDeployment) deleteInstallEgressNetworkPolicy(cd *hivev1.ClusterDeployment, cdLog log.FieldLogger) error {
+	existing := &networkingv1.NetworkPolicy{}
+	switch err := r.Get(context.TODO(), types.NamespacedName{Namespace: cd.Namespace, Name: install.InstallEgressNetworkPolicyName}, existing); {
+	case apierrors.IsNotFound(err):
+		return nil
+	case err != nil:
+		return errors.Wrap(err, "error getting install egress network policy")
+	}
+	if !metav1.IsControlledBy(existing, cd) {
+		return nil
+	}
+	if err := r.Delete(context.TODO(), existing); err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrap(err, "error deleting install egress network policy")
+	}
+	cdLog.WithField("networkPolicy", existing.Name).Info("deleted the install egress network policy")
+	return nil
+}
+
+// deleteInstallEgressFirewall deletes the EgressFirewall created for the clusterdeployment, if any. Clusters without
+// the EgressFirewall API have nothing to delete.
+func (r *ReconcileClusterDeployment) deleteInstallEgressFirewall(cd *hivev1.ClusterDeployment, cdLog log.FieldLogger) error {
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(install.EgressFirewallGVK)
+	switch err := r.Get(context.TODO(), types.NamespacedName{Namespace: cd.Namespace, Name: install.InstallEgressFirewallName}, existing); {
+	case apierrors.IsNotFound(err), meta.IsNoMatchError(err):
+		return nil
+	case err != nil:
+		return errors.Wrap(err, "error getting install egress firewall")
+	}
+	if existing.GetLabels()[constants.ClusterDeploymentNameLabel] != cd.Name {
+		return nil
+	}
+	if err := r.Delete(context.TODO(), existing); err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrap(err, "error deleting install egress firewall")
+	}
+	cdLog.WithField("egressFirewall", existing.GetName()).Info("deleted the install egress firewall")
+	return nil
+}
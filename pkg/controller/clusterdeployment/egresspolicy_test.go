@@ -0,0 +1,167 @@
+package clusterdeployment
+
+import (
+	"context"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/openshift/hive/apis"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/install"
+)
+
+func TestEnsureInstallEgressPolicy(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+
+	tests := []struct {
+		name                  string
+		policyType            hivev1.InstallEgressPolicyType
+		existing              []runtime.Object
+		expectNetworkPolicy   bool
+		expectFirewallAllowed []string
+	}{
+		{
+			name: "no egress policy configured",
+		},
+		{
+			name:                "create network policy",
+			policyType:          hivev1.InstallEgressPolicyTypeNetworkPolicy,
+			expectNetworkPolicy: true,
+		},
+		{
+			name:       "update network policy",
+			policyType: hivev1.InstallEgressPolicyTypeNetworkPolicy,
+			existing: []runtime.Object{
+				&networkingv1.NetworkPolicy{
+					ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: install.InstallEgressNetworkPolicyName},
+				},
+			},
+			expectNetworkPolicy: true,
+		},
+		{
+			name:       "create egress firewall",
+			policyType: hivev1.InstallEgressPolicyTypeEgressFirewall,
+			expectFirewallAllowed: []string{
+				"10.0.0.0/8",
+				"api.bar.example.com",
+				"ec2.us-east-1.amazonaws.com",
+				"mirror.example.com",
+				"quay.io",
+			},
+		},
+		{
+			name: "network policy removed when no egress policy configured",
+			existing: []runtime.Object{
+				&networkingv1.NetworkPolicy{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace:       testNamespace,
+						Name:            install.InstallEgressNetworkPolicyName,
+						OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(testClusterDeployment(), hivev1.SchemeGroupVersion.WithKind("ClusterDeployment"))},
+					},
+				},
+			},
+		},
+		{
+			name:       "egress firewall removed when switching to network policy",
+			policyType: hivev1.InstallEgressPolicyTypeNetworkPolicy,
+			existing: []runtime.Object{
+				testEgressFirewall(map[string]string{constants.ClusterDeploymentNameLabel: testName}),
+			},
+			expectNetworkPolicy: true,
+		},
+		{
+			name:       "existing egress firewall not replaced",
+			policyType: hivev1.InstallEgressPolicyTypeEgressFirewall,
+			existing: []runtime.Object{
+				testEgressFirewall(nil),
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cd := testClusterDeployment()
+			cd.Spec.BaseDomain = "example.com"
+			existing := append([]runtime.Object{cd}, test.existing...)
+			c := fake.NewFakeClientWithScheme(scheme.Scheme, existing...)
+			r := &ReconcileClusterDeployment{
+				Client:                           c,
+				scheme:                           scheme.Scheme,
+				installEgressPolicyType:          test.policyType,
+				installEgressAdditionalEndpoints: []string{"mirror.example.com", "10.0.0.0/8"},
+			}
+
+			err := r.ensureInstallEgressPolicy(cd, "quay.io/openshift-release-dev/ocp-release:4.6.0", log.WithField("test", test.name))
+			require.NoError(t, err, "unexpected error ensuring install egress policy")
+
+			policy := &networkingv1.NetworkPolicy{}
+			err = c.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: install.InstallEgressNetworkPolicyName}, policy)
+			if !test.expectNetworkPolicy {
+				if len(test.existing) == 0 {
+					assert.True(t, apierrors.IsNotFound(err), "expected no network policy")
+				}
+			} else {
+				require.NoError(t, err, "unexpected error getting network policy")
+				assert.Equal(t, []networkingv1.PolicyType{networkingv1.PolicyTypeEgress}, policy.Spec.PolicyTypes, "unexpected policy types")
+				if assert.Len(t, policy.Spec.Egress, 3, "unexpected number of egress rules") {
+					assert.Equal(t, "10.0.0.0/8", policy.Spec.Egress[2].To[0].IPBlock.CIDR, "unexpected additional CIDR")
+				}
+				assert.NotNil(t, metav1.GetControllerOf(policy), "expected network policy to be controlled by the cluster deployment")
+			}
+
+			firewall := testEgressFirewall(nil)
+			err = c.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: install.InstallEgressFirewallName}, firewall)
+			if test.policyType != hivev1.InstallEgressPolicyTypeEgressFirewall {
+				assert.True(t, apierrors.IsNotFound(err), "expected no egress firewall")
+				return
+			}
+			require.NoError(t, err, "unexpected error getting egress firewall")
+			rules, _, _ := unstructured.NestedSlice(firewall.Object, "spec", "egress")
+			if test.expectFirewallAllowed == nil {
+				assert.Len(t, rules, 1, "expected existing egress firewall to be left alone")
+				return
+			}
+			allowed := []string{}
+			for _, rule := range rules {
+				rule := rule.(map[string]interface{})
+				to := rule["to"].(map[string]interface{})
+				if rule["type"] != "Allow" {
+					assert.Equal(t, "0.0.0.0/0", to["cidrSelector"], "expected the last rule to deny all egress")
+					continue
+				}
+				if dnsName, ok := to["dnsName"]; ok {
+					allowed = append(allowed, dnsName.(string))
+				} else {
+					allowed = append(allowed, to["cidrSelector"].(string))
+				}
+			}
+			assert.Subset(t, allowed, test.expectFirewallAllowed, "missing allowed endpoints")
+		})
+	}
+}
+
+func testEgressFirewall(labels map[string]string) *unstructured.Unstructured {
+	firewall := &unstructured.Unstructured{}
+	firewall.SetGroupVersionKind(install.EgressFirewallGVK)
+	firewall.SetNamespace(testNamespace)
+	firewall.SetName(install.InstallEgressFirewallName)
+	firewall.SetLabels(labels)
+	firewall.Object["spec"] = map[string]interface{}{
+		"egress": []interface{}{
+			map[string]interface{}{"type": "Deny", "to": map[string]interface{}{"cidrSelector": "0.0.0.0/0"}},
+		},
+	}
+	return firewall
+}
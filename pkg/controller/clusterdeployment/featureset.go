@@ -0,0 +1,73 @@
+package clusterdeployment
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/blang/semver/v4"
+	configv1 "github.com/openshift/api/config/v1"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	featureSetNotSupportedReason = "FeatureSetNotSupported"
+	featureSetSupportedReason    = "FeatureSetSupported"
+)
+
+var (
+	// versionsSupportingFeatureSet are the versions of the installer which accept a featureSet in the install-config.
+	versionsSupportingFeatureSet = semver.MustParseRange(">=4.10.0")
+	// versionsSupportingFeatureGates are the versions of the installer which accept the featureGates of the
+	// CustomNoUpgrade feature set in the install-config.
+	versionsSupportingFeatureGates = semver.MustParseRange(">=4.14.0")
+)
+
+// validateFeatureSet returns a message describing why the feature set of the clusterdeployment is not supported by
+// the version being installed, or an empty string when it is supported. The version is only known once the install
+// images have been resolved; until then, the feature set is not validated.
+func validateFeatureSet(cd *hivev1.ClusterDeployment) string {
+	if cd.Spec.Provisioning == nil || cd.Spec.Provisioning.FeatureSet == nil || cd.Status.InstallVersion == nil {
+		return ""
+	}
+	version, err := semver.ParseTolerant(*cd.Status.InstallVersion)
+	if err != nil {
+		return ""
+	}
+	// Ignore pre-release versions so that nightlies of a supported release are supported.
+	version = semver.Version{Major: version.Major, Minor: version.Minor, Patch: version.Patch}
+	featureSet := cd.Spec.Provisioning.FeatureSet
+	if !versionsSupportingFeatureSet(version) {
+		return fmt.Sprintf("feature set %s is not supported by version %s", featureSet.FeatureSet, version)
+	}
+	if featureSet.FeatureSet == configv1.CustomNoUpgrade && len(featureSet.FeatureGates) > 0 && !versionsSupportingFeatureGates(version) {
+		return fmt.Sprintf("feature gates of the %s feature set are not supported by version %s", configv1.CustomNoUpgrade, version)
+	}
+	return ""
+}
+
+// setFeatureSetNotSupportedCondition sets the FeatureSetNotSupported condition when the message describes why the
+// feature set is not supported, and clears it otherwise.
+func (r *ReconcileClusterDeployment) setFeatureSetNotSupportedCondition(cd *hivev1.ClusterDeployment, message string, cdLog log.FieldLogger) error {
+	status, reason := corev1.ConditionTrue, featureSetNotSupportedReason
+	if message == "" {
+		status, reason, message = corev1.ConditionFalse, featureSetSupportedReason, "Feature set is supported"
+	}
+	conditions, changed := controllerutils.SetClusterDeploymentConditionWithChangeCheck(
+		cd.Status.Conditions,
+		hivev1.FeatureSetNotSupportedCondition,
+		status,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange)
+	if !changed {
+		return nil
+	}
+	cd.Status.Conditions = conditions
+	cdLog.WithField("status", status).Debug("setting FeatureSetNotSupportedCondition")
+	return r.Status().Update(context.TODO(), cd)
+}
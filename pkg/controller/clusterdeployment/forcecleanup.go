@@ -0,0 +1,150 @@
+package clusterdeployment
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	defaultForceCleanupTimeout = time.Hour
+
+	forcedCleanupEventReason = "ForcedCleanup"
+
+	skippedDeprovision = "deprovision"
+	skippedDNSZone     = "dnszone"
+)
+
+// forceCleanup forces the cleanup of a deleted clusterdeployment once the force cleanup timeout has passed:
+// the finalizers of the clusterdeployment and of its managed DNSZone are removed, skipping the cleanup which has
+// not completed, and an event recording the skipped cleanup is emitted.
+// It returns whether the cleanup was forced and, when it was not, how long until it is.
+func (r *ReconcileClusterDeployment) forceCleanup(cd *hivev1.ClusterDeployment, cdLog log.FieldLogger) (bool, time.Duration, error) {
+	timeout := defaultForceCleanupTimeout
+	if cd.Spec.ForceCleanup.Timeout != nil {
+		timeout = cd.Spec.ForceCleanup.Timeout.Duration
+	}
+	if untilForced := time.Until(cd.DeletionTimestamp.Add(timeout)); untilForced > 0 {
+		cdLog.WithField("timeout", timeout).Debugf("cleanup will be forced in %s", untilForced)
+		return false, untilForced, nil
+	}
+
+	var skipped []string
+	deprovisioned, err := r.isDeprovisioned(cd)
+	if err != nil {
+		return false, 0, err
+	}
+	if !deprovisioned {
+		skipped = append(skipped, skippedDeprovision)
+	}
+	dnsZoneSkipped, err := r.releaseManagedDNSZone(cd, cdLog)
+	if err != nil {
+		return false, 0, err
+	}
+	if dnsZoneSkipped {
+		skipped = append(skipped, skippedDNSZone)
+	}
+
+	skippedMsg := "none"
+	if len(skipped) > 0 {
+		skippedMsg = strings.Join(skipped, ", ")
+	}
+	message := fmt.Sprintf("Cleanup forced after %s: %s. Skipped cleanup: %s", timeout, cd.Spec.ForceCleanup.Reason, skippedMsg)
+	cdLog.WithField("reason", cd.Spec.ForceCleanup.Reason).WithField("skippedCleanup", skipped).Warn("forcing cleanup of deleted clusterdeployment")
+	if err := r.recordForcedCleanup(cd, message); err != nil {
+		cdLog.WithError(err).Log(controllerutils.LogLevel(err), "error recording forced cleanup event")
+		return false, 0, err
+	}
+
+	if err := r.removeClusterDeploymentFinalizer(cd, cdLog); err != nil {
+		cdLog.WithError(err).Log(controllerutils.LogLevel(err), "error removing finalizer")
+		return false, 0, err
+	}
+	return true, 0, nil
+}
+
+// isDeprovisioned returns whether the deprovision of the clusterdeployment is not needed or has completed.
+func (r *ReconcileClusterDeployment) isDeprovisioned(cd *hivev1.ClusterDeployment) (bool, error) {
+	if (cd.Spec.PreserveOnDelete && cd.Spec.Installed) || cd.Spec.ClusterMetadata == nil || cd.Spec.Platform.BareMetal != nil ||
+		(cd.Spec.Platform.None != nil && cd.Spec.Platform.None.Destroyer == nil) {
+		return true, nil
+	}
+	deprovision := &hivev1.ClusterDeprovision{}
+	switch err := r.Get(context.TODO(), types.NamespacedName{Namespace: cd.Namespace, Name: cd.Name}, deprovision); {
+	case apierrors.IsNotFound(err):
+		return false, nil
+	case err != nil:
+		return false, errors.Wrap(err, "could not get deprovision request")
+	}
+	return deprovision.Status.Completed, nil
+}
+
+// releaseManagedDNSZone removes the finalizer of the managed DNSZone so that it does not block once garbage
+// collected, unless the DNSZone can be preserved. It returns whether the cleanup of the DNSZone was skipped.
+func (r *ReconcileClusterDeployment) releaseManagedDNSZone(cd *hivev1.ClusterDeployment, cdLog log.FieldLogger) (bool, error) {
+	if !cd.Spec.ManageDNS {
+		return false, nil
+	}
+	if cd.Spec.PreserveDNSZoneOnDelete {
+		switch done, err := r.preserveManagedDNSZone(cd, cdLog); {
+		case err != nil:
+			return false, err
+		case done:
+			return false, nil
+		}
+	}
+	dnsZone := &hivev1.DNSZone{}
+	switch err := r.Get(context.TODO(), types.NamespacedName{Namespace: cd.Namespace, Name: controllerutils.DNSZoneName(cd.Name)}, dnsZone); {
+	case apierrors.IsNotFound(err):
+		return false, nil
+	case err != nil:
+		return false, errors.Wrap(err, "could not get managed dnszone")
+	}
+	if !controllerutils.HasFinalizer(dnsZone, hivev1.FinalizerDNSZone) {
+		return false, nil
+	}
+	controllerutils.DeleteFinalizer(dnsZone, hivev1.FinalizerDNSZone)
+	if err := r.Update(context.TODO(), dnsZone); err != nil {
+		return false, errors.Wrap(err, "could not remove managed dnszone finalizer")
+	}
+	cdLog.WithField("dnsZone", dnsZone.Name).Warn("removed managed dnszone finalizer")
+	return true, nil
+}
+
+// recordForcedCleanup emits a warning event on the clusterdeployment recording the forced cleanup.
+func (r *ReconcileClusterDeployment) recordForcedCleanup(cd *hivev1.ClusterDeployment, message string) error {
+	now := metav1.Now()
+	event := &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s.%x", cd.Name, now.UnixNano()),
+			Namespace: cd.Namespace,
+		},
+		InvolvedObject: corev1.ObjectReference{
+			APIVersion: hivev1.SchemeGroupVersion.String(),
+			Kind:       "ClusterDeployment",
+			Name:       cd.Name,
+			Namespace:  cd.Namespace,
+			UID:        cd.UID,
+		},
+		Reason:         forcedCleanupEventReason,
+		Message:        message,
+		Type:           corev1.EventTypeWarning,
+		Source:         corev1.EventSource{Component: string(ControllerName)},
+		FirstTimestamp: now,
+		LastTimestamp:  now,
+		Count:          1,
+	}
+	return r.Create(context.TODO(), event)
+}
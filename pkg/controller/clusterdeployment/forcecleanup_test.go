@@ -0,0 +1,122 @@
+package clusterdeployment
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/openshift/hive/apis"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+func TestForceCleanup(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+
+	tests := []struct {
+		name                     string
+		deletedAgo               time.Duration
+		timeout                  *metav1.Duration
+		manageDNS                bool
+		existing                 []runtime.Object
+		expectFinalizerRemoved   bool
+		expectSkippedCleanup     string
+		expectRequeueWithinLimit time.Duration
+	}{
+		{
+			name:                     "timeout not reached",
+			deletedAgo:               10 * time.Minute,
+			expectRequeueWithinLimit: 50 * time.Minute,
+		},
+		{
+			name:                     "custom timeout not reached",
+			deletedAgo:               10 * time.Minute,
+			timeout:                  &metav1.Duration{Duration: 15 * time.Minute},
+			expectRequeueWithinLimit: 5 * time.Minute,
+		},
+		{
+			name:                   "deprovision skipped",
+			deletedAgo:             2 * time.Hour,
+			expectFinalizerRemoved: true,
+			expectSkippedCleanup:   "deprovision",
+		},
+		{
+			name:       "deprovision completed",
+			deletedAgo: 2 * time.Hour,
+			existing: []runtime.Object{
+				&hivev1.ClusterDeprovision{
+					ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: testName},
+					Status:     hivev1.ClusterDeprovisionStatus{Completed: true},
+				},
+			},
+			expectFinalizerRemoved: true,
+			expectSkippedCleanup:   "none",
+		},
+		{
+			name:       "managed dnszone finalizer removed",
+			deletedAgo: 2 * time.Hour,
+			timeout:    &metav1.Duration{Duration: time.Hour},
+			manageDNS:  true,
+			existing: []runtime.Object{
+				func() *hivev1.DNSZone {
+					zone := testDNSZone()
+					zone.Finalizers = []string{hivev1.FinalizerDNSZone}
+					return zone
+				}(),
+			},
+			expectFinalizerRemoved: true,
+			expectSkippedCleanup:   "deprovision, dnszone",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cd := testDeletedClusterDeployment()
+			cd.DeletionTimestamp = &metav1.Time{Time: time.Now().Add(-test.deletedAgo)}
+			cd.Spec.ManageDNS = test.manageDNS
+			cd.Spec.ForceCleanup = &hivev1.ForceCleanup{
+				Reason:  "cloud account closed",
+				Timeout: test.timeout,
+			}
+			c := fake.NewFakeClientWithScheme(scheme.Scheme, append(test.existing, cd)...)
+			r := &ReconcileClusterDeployment{Client: c, scheme: scheme.Scheme}
+
+			forced, untilForced, err := r.forceCleanup(cd, log.WithField("test", test.name))
+			require.NoError(t, err, "unexpected error forcing cleanup")
+			assert.Equal(t, test.expectFinalizerRemoved, forced, "unexpected forced result")
+
+			events := &corev1.EventList{}
+			require.NoError(t, c.List(context.TODO(), events), "unexpected error listing events")
+			cd = getCDFromClient(c)
+			if !test.expectFinalizerRemoved {
+				assert.Contains(t, cd.Finalizers, hivev1.FinalizerDeprovision, "expected deprovision finalizer")
+				assert.True(t, untilForced > 0 && untilForced <= test.expectRequeueWithinLimit, "unexpected time until forced cleanup: %s", untilForced)
+				assert.Empty(t, events.Items, "unexpected events")
+				return
+			}
+			assert.NotContains(t, cd.Finalizers, hivev1.FinalizerDeprovision, "expected deprovision finalizer to be removed")
+			if assert.Len(t, events.Items, 1, "expected a forced cleanup event") {
+				event := events.Items[0]
+				assert.Equal(t, corev1.EventTypeWarning, event.Type, "unexpected event type")
+				assert.Equal(t, forcedCleanupEventReason, event.Reason, "unexpected event reason")
+				assert.Equal(t, testName, event.InvolvedObject.Name, "unexpected involved object")
+				assert.Contains(t, event.Message, "cloud account closed", "expected reason in event message")
+				assert.Contains(t, event.Message, "Skipped cleanup: "+test.expectSkippedCleanup, "unexpected skipped cleanup")
+			}
+			if test.manageDNS {
+				zone := &hivev1.DNSZone{}
+				require.NoError(t, c.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: testName + "-zone"}, zone), "unexpected error getting dnszone")
+				assert.Empty(t, zone.Finalizers, "expected dnszone finalizer to be removed")
+			}
+		})
+	}
+}
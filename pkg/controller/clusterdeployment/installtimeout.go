@@ -0,0 +1,66 @@
+package clusterdeployment
+
+import (
+	"os"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+// installTimeouts are the default install timeouts of provisions configured in HiveConfig.
+type installTimeouts struct {
+	// defaultTimeout applies to the clusters on platforms without a timeout in platforms. Zero when not set.
+	defaultTimeout time.Duration
+	// platforms are the timeouts of the clusters on specific platforms.
+	platforms map[string]time.Duration
+}
+
+// loadInstallTimeouts reads the default install timeouts from the environment of the controller manager. Invalid
+// timeouts are logged and ignored.
+func loadInstallTimeouts(logger log.FieldLogger) installTimeouts {
+	timeouts := installTimeouts{platforms: map[string]time.Duration{}}
+	if defaultTimeout := os.Getenv(constants.DefaultInstallTimeoutEnvVar); defaultTimeout != "" {
+		if d, err := time.ParseDuration(defaultTimeout); err != nil {
+			logger.WithError(err).WithField("timeout", defaultTimeout).Warn("invalid default install timeout, ignoring it")
+		} else {
+			timeouts.defaultTimeout = d
+		}
+	}
+	if platformTimeouts := os.Getenv(constants.PlatformInstallTimeoutsEnvVar); platformTimeouts != "" {
+		for _, pt := range strings.Split(platformTimeouts, ",") {
+			parts := strings.SplitN(pt, "=", 2)
+			if len(parts) != 2 {
+				logger.WithField("timeout", pt).Warn("invalid platform install timeout, ignoring it")
+				continue
+			}
+			d, err := time.ParseDuration(parts[1])
+			if err != nil {
+				logger.WithError(err).WithField("timeout", pt).Warn("invalid platform install timeout, ignoring it")
+				continue
+			}
+			timeouts.platforms[parts[0]] = d
+		}
+	}
+	return timeouts
+}
+
+// installTimeoutFor returns the install timeout of the provisions of the clusterdeployment, or nil when its install
+// jobs are not timed out. The timeout set on the clusterdeployment takes precedence over the defaults.
+func (t installTimeouts) installTimeoutFor(cd *hivev1.ClusterDeployment) *metav1.Duration {
+	if cd.Spec.Provisioning != nil && cd.Spec.Provisioning.InstallTimeout != nil {
+		return cd.Spec.Provisioning.InstallTimeout.DeepCopy()
+	}
+	if d, ok := t.platforms[getClusterPlatform(cd)]; ok {
+		return &metav1.Duration{Duration: d}
+	}
+	if t.defaultTimeout > 0 {
+		return &metav1.Duration{Duration: t.defaultTimeout}
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package clusterdeployment
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift/hive/pkg/constants"
+)
+
+func TestInstallTimeoutFor(t *testing.T) {
+	tests := []struct {
+		name             string
+		defaultTimeout   string
+		platformTimeouts string
+		cdTimeout        *metav1.Duration
+		expectedTimeout  *metav1.Duration
+	}{
+		{
+			name: "no timeouts",
+		},
+		{
+			name:            "clusterdeployment timeout",
+			cdTimeout:       &metav1.Duration{Duration: time.Hour},
+			expectedTimeout: &metav1.Duration{Duration: time.Hour},
+		},
+		{
+			name:             "clusterdeployment timeout takes precedence",
+			defaultTimeout:   "3h",
+			platformTimeouts: "aws=90m",
+			cdTimeout:        &metav1.Duration{Duration: time.Hour},
+			expectedTimeout:  &metav1.Duration{Duration: time.Hour},
+		},
+		{
+			name:             "platform timeout",
+			defaultTimeout:   "3h",
+			platformTimeouts: "gcp=2h,aws=90m",
+			expectedTimeout:  &metav1.Duration{Duration: 90 * time.Minute},
+		},
+		{
+			name:             "default timeout",
+			defaultTimeout:   "3h",
+			platformTimeouts: "gcp=2h",
+			expectedTimeout:  &metav1.Duration{Duration: 3 * time.Hour},
+		},
+		{
+			name:             "invalid timeouts ignored",
+			defaultTimeout:   "forever",
+			platformTimeouts: "aws,gcp=2h",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			os.Setenv(constants.DefaultInstallTimeoutEnvVar, test.defaultTimeout)
+			defer os.Unsetenv(constants.DefaultInstallTimeoutEnvVar)
+			os.Setenv(constants.PlatformInstallTimeoutsEnvVar, test.platformTimeouts)
+			defer os.Unsetenv(constants.PlatformInstallTimeoutsEnvVar)
+
+			cd := testClusterDeployment()
+			cd.Spec.Provisioning.InstallTimeout = test.cdTimeout
+
+			timeouts := loadInstallTimeouts(log.StandardLogger())
+			assert.Equal(t, test.expectedTimeout, timeouts.installTimeoutFor(cd), "unexpected install timeout")
+		})
+	}
+}
@@ -0,0 +1,337 @@
+package clusterdeployment
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"reflect"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	configv1 "github.com/openshift/api/config/v1"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/yaml"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/remoteclient"
+	k8slabels "github.com/openshift/hive/pkg/util/labels"
+)
+
+const (
+	installConfigSecretKey = "install-config.yaml"
+
+	// userCABundleConfigMap is the ConfigMap in the openshift-config namespace which contains the additional
+	// trust bundle of installed clusters, from the install-config or as edited by the owners of the cluster.
+	userCABundleConfigMap = "user-ca-bundle"
+	// hiveCABundleConfigMap is the ConfigMap in the openshift-config namespace which Hive syncs with the merge of the
+	// user-ca-bundle of the cluster and the additional trust bundle, and which the proxy of the cluster trusts.
+	hiveCABundleConfigMap    = "hive-trusted-ca-bundle"
+	userCABundleKey          = "ca-bundle.crt"
+	openshiftConfigNamespace = "openshift-config"
+	clusterProxyName         = "cluster"
+
+	proxyTrustedCAPatch = `{"spec":{"trustedCA":{"name":"` + hiveCABundleConfigMap + `"}}}`
+)
+
+// ensureAdditionalTrustBundle copies the certificate authorities which the HiveConfig configures for installed
+// clusters into the namespace of the clusterdeployment, where the install pod merges them into the install-config,
+// and generates the syncset which keeps them in sync on the cluster once installed. When the HiveConfig no longer
+// configures them, it removes them from the cluster and deletes the copy and the syncset.
+// It returns true when the copy of the trust bundle has been created or updated.
+func (r *ReconcileClusterDeployment) ensureAdditionalTrustBundle(cd *hivev1.ClusterDeployment, cdLog log.FieldLogger) (bool, error) {
+	trustBundleSecretName := os.Getenv(constants.AdditionalTrustBundleSecretEnvVar)
+	// Only clusters installed by Hive get the trust bundle: the trust bundle of other clusters is not known before
+	// they are installed.
+	if trustBundleSecretName == "" || cd.Spec.Provisioning == nil || cd.Spec.Provisioning.InstallConfigSecretRef == nil {
+		return false, r.removeAdditionalTrustBundle(cd, cdLog)
+	}
+	trustBundle, err := controllerutils.LoadSecretData(r.Client, trustBundleSecretName, controllerutils.GetHiveNamespace(), constants.AdditionalTrustBundleSecretKey)
+	if err != nil {
+		return false, errors.Wrap(err, "additional trust bundle could not be retrieved")
+	}
+	if updated, err := r.updateAdditionalTrustBundleSecret(trustBundle, cd, cdLog); err != nil || updated {
+		return updated, err
+	}
+	// The syncset cannot be applied in the Restricted remote access RBAC mode without the core and config API groups.
+	// The trust bundle is still merged into the install-config, which needs no access to the cluster.
+	if remoteclient.FeatureDisabled(remoteclient.AdditionalTrustBundleFeature) {
+		return false, nil
+	}
+	return false, r.updateAdditionalTrustBundleSyncSet(trustBundle, cd, cdLog)
+}
+
+// requestsForAdditionalTrustBundleSecret enqueues the clusterdeployments installed by Hive when the additional trust
+// bundle changes, so that their copies of the trust bundle and their syncsets are updated.
+func requestsForAdditionalTrustBundleSecret(c client.Client) handler.ToRequestsFunc {
+	return func(o handler.MapObject) []reconcile.Request {
+		trustBundleSecretName := os.Getenv(constants.AdditionalTrustBundleSecretEnvVar)
+		if trustBundleSecretName == "" || o.Meta.GetName() != trustBundleSecretName || o.Meta.GetNamespace() != controllerutils.GetHiveNamespace() {
+			return nil
+		}
+		cds := &hivev1.ClusterDeploymentList{}
+		if err := c.List(context.TODO(), cds); err != nil {
+			log.WithField("controller", ControllerName).WithError(err).Error("error listing cluster deployments")
+			return nil
+		}
+		var requests []reconcile.Request
+		for _, cd := range cds.Items {
+			if cd.Spec.Provisioning == nil || cd.Spec.Provisioning.InstallConfigSecretRef == nil {
+				continue
+			}
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: cd.Namespace, Name: cd.Name}})
+		}
+		return requests
+	}
+}
+
+// updateAdditionalTrustBundleSecret creates or updates the copy of the additional trust bundle for the clusterdeployment.
+// It returns true when the copy has been created or updated.
+func (r *ReconcileClusterDeployment) updateAdditionalTrustBundleSecret(trustBundle string, cd *hivev1.ClusterDeployment, cdLog log.FieldLogger) (bool, error) {
+	secretName := constants.GetAdditionalTrustBundleName(cd)
+	secret := &corev1.Secret{}
+	switch err := r.Get(context.TODO(), types.NamespacedName{Namespace: cd.Namespace, Name: secretName}, secret); {
+	case apierrors.IsNotFound(err):
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      secretName,
+				Namespace: cd.Namespace,
+			},
+			Data: map[string][]byte{
+				constants.AdditionalTrustBundleSecretKey: []byte(trustBundle),
+			},
+		}
+		cdLog.WithField("derivedObject", secret.Name).Debug("Setting labels on derived object")
+		secret.Labels = k8slabels.AddLabel(secret.Labels, constants.ClusterDeploymentNameLabel, cd.Name)
+		secret.Labels = k8slabels.AddLabel(secret.Labels, constants.SecretTypeLabel, constants.SecretTypeAdditionalTrustBundle)
+		if err := controllerutil.SetControllerReference(cd, secret, r.scheme); err != nil {
+			cdLog.WithError(err).Error("error setting controller reference on additional trust bundle secret")
+			return false, err
+		}
+		if err := r.Create(context.TODO(), secret); err != nil {
+			return false, errors.Wrap(err, "error creating additional trust bundle secret")
+		}
+		cdLog.WithField("secretName", secretName).Info("created the additional trust bundle secret")
+		return true, nil
+	case err != nil:
+		return false, errors.Wrap(err, "error getting additional trust bundle secret")
+	}
+
+	if string(secret.Data[constants.AdditionalTrustBundleSecretKey]) == trustBundle {
+		return false, nil
+	}
+	secret.Data = map[string][]byte{constants.AdditionalTrustBundleSecretKey: []byte(trustBundle)}
+	if err := r.Update(context.TODO(), secret); err != nil {
+		return false, errors.Wrap(err, "error updating additional trust bundle secret")
+	}
+	cdLog.WithField("secretName", secretName).Info("updated the additional trust bundle secret")
+	return true, nil
+}
+
+// updateAdditionalTrustBundleSyncSet creates or updates the syncset which keeps the CA bundle trusted by the cluster in
+// sync with its user-ca-bundle and the additional trust bundle. Before the cluster is installed, its user-ca-bundle is
+// the trust bundle of the install-config.
+func (r *ReconcileClusterDeployment) updateAdditionalTrustBundleSyncSet(trustBundle string, cd *hivev1.ClusterDeployment, cdLog log.FieldLogger) error {
+	var userCABundle string
+	if cd.Spec.Installed {
+		remoteClient, unreachable, _ := remoteclient.ConnectToRemoteCluster(cd, r.remoteClusterAPIClientBuilder(cd), r.Client, cdLog)
+		if unreachable {
+			cdLog.Debug("cluster unreachable, not syncing the additional trust bundle")
+			return nil
+		}
+		configMap := &corev1.ConfigMap{}
+		switch err := remoteClient.Get(context.TODO(), types.NamespacedName{Namespace: openshiftConfigNamespace, Name: userCABundleConfigMap}, configMap); {
+		case apierrors.IsNotFound(err):
+		case err != nil:
+			return errors.Wrap(err, "could not get the user-ca-bundle of the cluster")
+		default:
+			userCABundle = configMap.Data[userCABundleKey]
+		}
+	} else {
+		installConfig, err := controllerutils.LoadSecretData(r.Client, cd.Spec.Provisioning.InstallConfigSecretRef.Name, cd.Namespace, installConfigSecretKey)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				// Without the install-config, the syncset could drop the certificate authorities it trusts.
+				cdLog.Warn("install-config secret not found, not syncing the additional trust bundle")
+				return nil
+			}
+			return errors.Wrap(err, "install-config could not be retrieved")
+		}
+		ic := struct {
+			AdditionalTrustBundle string `json:"additionalTrustBundle,omitempty"`
+		}{}
+		if err := yaml.Unmarshal([]byte(installConfig), &ic); err != nil {
+			return errors.Wrap(err, "could not unmarshal install-config")
+		}
+		userCABundle = ic.AdditionalTrustBundle
+	}
+	caBundle := string(controllerutils.MergeTrustBundles([]byte(userCABundle), []byte(trustBundle)))
+
+	desired, err := generateAdditionalTrustBundleSyncSet(caBundle, cd)
+	if err != nil {
+		return errors.Wrap(err, "could not generate additional trust bundle syncset")
+	}
+	if err := controllerutil.SetControllerReference(cd, desired, r.scheme); err != nil {
+		cdLog.WithError(err).Error("error setting controller reference on additional trust bundle syncset")
+		return err
+	}
+
+	existing := &hivev1.SyncSet{}
+	switch err := r.Get(context.TODO(), types.NamespacedName{Namespace: desired.Namespace, Name: desired.Name}, existing); {
+	case apierrors.IsNotFound(err):
+		if err := r.Create(context.TODO(), desired); err != nil {
+			return errors.Wrap(err, "error creating additional trust bundle syncset")
+		}
+		cdLog.WithField("syncSet", desired.Name).Info("created the additional trust bundle syncset")
+		return nil
+	case err != nil:
+		return errors.Wrap(err, "error getting additional trust bundle syncset")
+	}
+
+	if syncedCABundle(existing) == caBundle && reflect.DeepEqual(existing.Spec.Patches, desired.Spec.Patches) {
+		return nil
+	}
+	existing.Spec = desired.Spec
+	if err := r.Update(context.TODO(), existing); err != nil {
+		return errors.Wrap(err, "error updating additional trust bundle syncset")
+	}
+	cdLog.WithField("syncSet", existing.Name).Info("updated the additional trust bundle syncset")
+	return nil
+}
+
+// removeAdditionalTrustBundle removes the additional trust bundle from a cluster which should no longer trust it: the
+// syncset is deleted, the proxy of the cluster trusts its user-ca-bundle again and the copy of the trust bundle is
+// deleted. The copy is deleted last so that the cluster is cleaned up by later reconciles should the cleanup fail.
+func (r *ReconcileClusterDeployment) removeAdditionalTrustBundle(cd *hivev1.ClusterDeployment, cdLog log.FieldLogger) error {
+	name := types.NamespacedName{Namespace: cd.Namespace, Name: constants.GetAdditionalTrustBundleName(cd)}
+	syncSet := &hivev1.SyncSet{}
+	switch err := r.Get(context.TODO(), name, syncSet); {
+	case apierrors.IsNotFound(err):
+	case err != nil:
+		return errors.Wrap(err, "error getting additional trust bundle syncset")
+	default:
+		if err := r.Delete(context.TODO(), syncSet); err != nil && !apierrors.IsNotFound(err) {
+			return errors.Wrap(err, "error deleting additional trust bundle syncset")
+		}
+		cdLog.WithField("syncSet", syncSet.Name).Info("deleted the additional trust bundle syncset")
+	}
+
+	secret := &corev1.Secret{}
+	switch err := r.Get(context.TODO(), name, secret); {
+	case apierrors.IsNotFound(err):
+		return nil
+	case err != nil:
+		return errors.Wrap(err, "error getting additional trust bundle secret")
+	}
+	if cd.Spec.Installed && !remoteclient.FeatureDisabled(remoteclient.AdditionalTrustBundleFeature) {
+		remoteClient, unreachable, _ := remoteclient.ConnectToRemoteCluster(cd, r.remoteClusterAPIClientBuilder(cd), r.Client, cdLog)
+		if unreachable {
+			cdLog.Debug("cluster unreachable, not removing the additional trust bundle")
+			return nil
+		}
+		if err := removeAdditionalTrustBundleFromCluster(remoteClient, cdLog); err != nil {
+			return err
+		}
+	}
+	if err := r.Delete(context.TODO(), secret); err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrap(err, "error deleting additional trust bundle secret")
+	}
+	cdLog.WithField("secretName", secret.Name).Info("deleted the additional trust bundle secret")
+	return nil
+}
+
+// removeAdditionalTrustBundleFromCluster points the proxy of the cluster back at its user-ca-bundle, if it has one,
+// and deletes the CA bundle synced by Hive.
+func removeAdditionalTrustBundleFromCluster(remoteClient client.Client, cdLog log.FieldLogger) error {
+	proxy := &configv1.Proxy{}
+	if err := remoteClient.Get(context.TODO(), types.NamespacedName{Name: clusterProxyName}, proxy); err != nil {
+		return errors.Wrap(err, "could not get the proxy of the cluster")
+	}
+	if proxy.Spec.TrustedCA.Name == hiveCABundleConfigMap {
+		proxy.Spec.TrustedCA.Name = ""
+		switch err := remoteClient.Get(context.TODO(), types.NamespacedName{Namespace: openshiftConfigNamespace, Name: userCABundleConfigMap}, &corev1.ConfigMap{}); {
+		case apierrors.IsNotFound(err):
+		case err != nil:
+			return errors.Wrap(err, "could not get the user-ca-bundle of the cluster")
+		default:
+			proxy.Spec.TrustedCA.Name = userCABundleConfigMap
+		}
+		if err := remoteClient.Update(context.TODO(), proxy); err != nil {
+			return errors.Wrap(err, "could not update the trusted CA of the proxy of the cluster")
+		}
+		cdLog.WithField("trustedCA", proxy.Spec.TrustedCA.Name).Info("restored the trusted CA of the proxy of the cluster")
+	}
+	configMap := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: openshiftConfigNamespace, Name: hiveCABundleConfigMap}}
+	if err := remoteClient.Delete(context.TODO(), configMap); err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrap(err, "could not delete the additional trust bundle from the cluster")
+	}
+	return nil
+}
+
+// generateAdditionalTrustBundleSyncSet generates the syncset which syncs the CA bundle to the cluster and makes the
+// proxy of the cluster trust it.
+func generateAdditionalTrustBundleSyncSet(caBundle string, cd *hivev1.ClusterDeployment) (*hivev1.SyncSet, error) {
+	configMap, err := json.Marshal(&corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "ConfigMap",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      hiveCABundleConfigMap,
+			Namespace: openshiftConfigNamespace,
+		},
+		Data: map[string]string{
+			userCABundleKey: caBundle,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	syncSet := &hivev1.SyncSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        constants.GetAdditionalTrustBundleName(cd),
+			Namespace:   cd.Namespace,
+			Annotations: map[string]string{constants.SyncSetMetricsGroupAnnotation: "additional-trust-bundle"},
+		},
+		Spec: hivev1.SyncSetSpec{
+			SyncSetCommonSpec: hivev1.SyncSetCommonSpec{
+				ResourceApplyMode: hivev1.UpsertResourceApplyMode,
+				Resources:         []runtime.RawExtension{{Raw: configMap}},
+				Patches: []hivev1.SyncObjectPatch{{
+					APIVersion: "config.openshift.io/v1",
+					Kind:       "Proxy",
+					Name:       "cluster",
+					Patch:      proxyTrustedCAPatch,
+					PatchType:  "merge",
+				}},
+			},
+			ClusterDeploymentRefs: []corev1.LocalObjectReference{{Name: cd.Name}},
+		},
+	}
+	syncSet.Labels = k8slabels.AddLabel(syncSet.Labels, constants.ClusterDeploymentNameLabel, cd.Name)
+	syncSet.Labels = k8slabels.AddLabel(syncSet.Labels, constants.SyncSetTypeLabel, constants.SyncSetTypeAdditionalTrustBundle)
+	return syncSet, nil
+}
+
+// syncedCABundle returns the CA bundle synced by an additional trust bundle syncset.
+func syncedCABundle(syncSet *hivev1.SyncSet) string {
+	if len(syncSet.Spec.Resources) != 1 {
+		return ""
+	}
+	configMap := &corev1.ConfigMap{}
+	if err := json.Unmarshal(syncSet.Spec.Resources[0].Raw, configMap); err != nil {
+		return ""
+	}
+	return configMap.Data[userCABundleKey]
+}
@@ -0,0 +1,289 @@
+package clusterdeployment
+
+import (
+	"context"
+	"encoding/pem"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	configv1 "github.com/openshift/api/config/v1"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/yaml"
+
+	"github.com/openshift/hive/apis"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/remoteclient"
+	remoteclientmock "github.com/openshift/hive/pkg/remoteclient/mock"
+)
+
+const testTrustBundleSecret = "hive-additional-trust-bundle"
+
+func TestEnsureAdditionalTrustBundle(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+
+	hubCA := testCertificate("hub-ca")
+	otherHubCA := testCertificate("other-hub-ca")
+	installConfigCA := testCertificate("install-config-ca")
+	installConfig, err := yaml.Marshal(map[string]interface{}{
+		"baseDomain":            "example.com",
+		"additionalTrustBundle": string(installConfigCA),
+	})
+	require.NoError(t, err, "unexpected error marshalling install-config")
+
+	tests := []struct {
+		name                string
+		trustBundleSecret   string
+		cd                  *hivev1.ClusterDeployment
+		existing            []runtime.Object
+		remote              []runtime.Object
+		expectUpdated       bool
+		expectSecret        string
+		expectSyncSetBundle string
+		expectTrustedCA     string
+	}{
+		{
+			name: "no additional trust bundle configured",
+			cd:   testClusterDeployment(),
+		},
+		{
+			name:              "create trust bundle secret",
+			trustBundleSecret: testTrustBundleSecret,
+			cd:                testClusterDeployment(),
+			expectUpdated:     true,
+			expectSecret:      string(hubCA),
+		},
+		{
+			name:              "create syncset merging install-config trust bundle",
+			trustBundleSecret: testTrustBundleSecret,
+			cd:                testClusterDeployment(),
+			existing: []runtime.Object{
+				testTrustBundleCopy(string(hubCA)),
+				testSecret(corev1.SecretTypeOpaque, "install-config-secret", installConfigSecretKey, string(installConfig)),
+			},
+			expectSecret:        string(hubCA),
+			expectSyncSetBundle: string(installConfigCA) + string(hubCA),
+		},
+		{
+			name:              "update trust bundle secret",
+			trustBundleSecret: testTrustBundleSecret,
+			cd:                testClusterDeployment(),
+			existing: []runtime.Object{
+				testTrustBundleCopy(string(otherHubCA)),
+			},
+			expectUpdated: true,
+			expectSecret:  string(hubCA),
+		},
+		{
+			name:              "no syncset without install-config",
+			trustBundleSecret: testTrustBundleSecret,
+			cd:                testClusterDeployment(),
+			existing: []runtime.Object{
+				testTrustBundleCopy(string(hubCA)),
+			},
+			expectSecret: string(hubCA),
+		},
+		{
+			name:              "installed cluster merges its user-ca-bundle",
+			trustBundleSecret: testTrustBundleSecret,
+			cd:                testReachableClusterDeployment(),
+			existing: []runtime.Object{
+				testTrustBundleCopy(string(hubCA)),
+			},
+			remote: []runtime.Object{
+				testUserCABundle(string(installConfigCA) + string(otherHubCA)),
+			},
+			expectSecret:        string(hubCA),
+			expectSyncSetBundle: string(installConfigCA) + string(otherHubCA) + string(hubCA),
+		},
+		{
+			name: "trust bundle removed from installed cluster",
+			cd:   testReachableClusterDeployment(),
+			existing: []runtime.Object{
+				testTrustBundleCopy(string(hubCA)),
+				testTrustBundleSyncSet(),
+			},
+			remote: []runtime.Object{
+				testUserCABundle(string(installConfigCA)),
+				testHiveCABundle(),
+				testProxy(hiveCABundleConfigMap),
+			},
+			expectTrustedCA: userCABundleConfigMap,
+		},
+		{
+			name: "trust bundle removed from installed cluster without user-ca-bundle",
+			cd:   testReachableClusterDeployment(),
+			existing: []runtime.Object{
+				testTrustBundleCopy(string(hubCA)),
+				testTrustBundleSyncSet(),
+			},
+			remote: []runtime.Object{
+				testHiveCABundle(),
+				testProxy(hiveCABundleConfigMap),
+			},
+		},
+		{
+			name: "trust bundle removed from cluster not installed",
+			cd:   testClusterDeployment(),
+			existing: []runtime.Object{
+				testTrustBundleCopy(string(hubCA)),
+				testTrustBundleSyncSet(),
+			},
+		},
+		{
+			name:              "adopted cluster",
+			trustBundleSecret: testTrustBundleSecret,
+			cd: func() *hivev1.ClusterDeployment {
+				cd := testClusterDeployment()
+				cd.Spec.Provisioning = nil
+				return cd
+			}(),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.trustBundleSecret != "" {
+				os.Setenv(constants.AdditionalTrustBundleSecretEnvVar, test.trustBundleSecret)
+				defer os.Unsetenv(constants.AdditionalTrustBundleSecretEnvVar)
+			}
+			existing := []runtime.Object{
+				test.cd,
+				createGlobalPullSecretObj(corev1.SecretTypeOpaque, testTrustBundleSecret, constants.AdditionalTrustBundleSecretKey, string(hubCA)),
+			}
+			existing = append(existing, test.existing...)
+			c := fake.NewFakeClientWithScheme(scheme.Scheme, existing...)
+			remoteScheme := runtime.NewScheme()
+			corev1.AddToScheme(remoteScheme)
+			configv1.Install(remoteScheme)
+			remoteClient := fake.NewFakeClientWithScheme(remoteScheme, test.remote...)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			mockRemoteClientBuilder := remoteclientmock.NewMockBuilder(mockCtrl)
+			mockRemoteClientBuilder.EXPECT().Build().Return(remoteClient, nil).AnyTimes()
+			r := &ReconcileClusterDeployment{
+				Client:                        c,
+				scheme:                        scheme.Scheme,
+				remoteClusterAPIClientBuilder: func(*hivev1.ClusterDeployment) remoteclient.Builder { return mockRemoteClientBuilder },
+			}
+
+			updated, err := r.ensureAdditionalTrustBundle(test.cd, log.WithField("test", test.name))
+			require.NoError(t, err, "unexpected error ensuring additional trust bundle")
+			assert.Equal(t, test.expectUpdated, updated, "unexpected updated result")
+
+			name := types.NamespacedName{Namespace: testNamespace, Name: constants.GetAdditionalTrustBundleName(test.cd)}
+			secret := &corev1.Secret{}
+			err = c.Get(context.TODO(), name, secret)
+			if test.expectSecret == "" {
+				assert.True(t, apierrors.IsNotFound(err), "expected no trust bundle secret")
+			} else if assert.NoError(t, err, "expected trust bundle secret") {
+				assert.Equal(t, test.expectSecret, string(secret.Data[constants.AdditionalTrustBundleSecretKey]), "unexpected trust bundle")
+				assert.Equal(t, constants.SecretTypeAdditionalTrustBundle, secret.Labels[constants.SecretTypeLabel], "unexpected secret type label")
+			}
+
+			if len(test.remote) > 0 && test.expectSyncSetBundle == "" {
+				proxy := &configv1.Proxy{}
+				require.NoError(t, remoteClient.Get(context.TODO(), types.NamespacedName{Name: clusterProxyName}, proxy), "unexpected error getting proxy")
+				assert.Equal(t, test.expectTrustedCA, proxy.Spec.TrustedCA.Name, "unexpected trusted CA")
+				err := remoteClient.Get(context.TODO(), types.NamespacedName{Namespace: openshiftConfigNamespace, Name: hiveCABundleConfigMap}, &corev1.ConfigMap{})
+				assert.True(t, apierrors.IsNotFound(err), "expected no synced CA bundle on the cluster")
+			}
+
+			syncSet := &hivev1.SyncSet{}
+			err = c.Get(context.TODO(), name, syncSet)
+			if test.expectSyncSetBundle == "" {
+				assert.True(t, apierrors.IsNotFound(err), "expected no trust bundle syncset")
+				return
+			}
+			require.NoError(t, err, "expected trust bundle syncset")
+			assert.Equal(t, test.expectSyncSetBundle, syncedCABundle(syncSet), "unexpected synced CA bundle")
+			assert.Equal(t, []corev1.LocalObjectReference{{Name: testName}}, syncSet.Spec.ClusterDeploymentRefs, "unexpected cluster deployment refs")
+			if assert.Len(t, syncSet.Spec.Patches, 1, "expected proxy patch") {
+				assert.Equal(t, "Proxy", syncSet.Spec.Patches[0].Kind, "unexpected patched kind")
+			}
+		})
+	}
+}
+
+func testCertificate(content string) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte(content)})
+}
+
+func testTrustBundleCopy(trustBundle string) *corev1.Secret {
+	s := testSecret(corev1.SecretTypeOpaque, constants.GetAdditionalTrustBundleName(testClusterDeployment()), constants.AdditionalTrustBundleSecretKey, trustBundle)
+	s.Labels = map[string]string{constants.SecretTypeLabel: constants.SecretTypeAdditionalTrustBundle}
+	return s
+}
+
+func testReachableClusterDeployment() *hivev1.ClusterDeployment {
+	cd := testInstalledClusterDeployment(time.Now())
+	cd.Status.Conditions = append(cd.Status.Conditions, hivev1.ClusterDeploymentCondition{
+		Type:   hivev1.UnreachableCondition,
+		Status: corev1.ConditionFalse,
+	})
+	return cd
+}
+
+func testTrustBundleSyncSet() *hivev1.SyncSet {
+	return &hivev1.SyncSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNamespace,
+			Name:      constants.GetAdditionalTrustBundleName(testClusterDeployment()),
+		},
+	}
+}
+
+func testUserCABundle(caBundle string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: openshiftConfigNamespace, Name: userCABundleConfigMap},
+		Data:       map[string]string{userCABundleKey: caBundle},
+	}
+}
+
+func testHiveCABundle() *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: openshiftConfigNamespace, Name: hiveCABundleConfigMap},
+	}
+}
+
+func testProxy(trustedCA string) *configv1.Proxy {
+	return &configv1.Proxy{
+		ObjectMeta: metav1.ObjectMeta{Name: clusterProxyName},
+		Spec:       configv1.ProxySpec{TrustedCA: configv1.ConfigMapNameReference{Name: trustedCA}},
+	}
+}
+
+func TestRequestsForAdditionalTrustBundleSecret(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+	os.Setenv(constants.AdditionalTrustBundleSecretEnvVar, testTrustBundleSecret)
+	defer os.Unsetenv(constants.AdditionalTrustBundleSecretEnvVar)
+
+	adopted := testClusterDeployment()
+	adopted.Name = "adopted"
+	adopted.Spec.Provisioning = nil
+	c := fake.NewFakeClientWithScheme(scheme.Scheme, testClusterDeployment(), adopted)
+	mapper := requestsForAdditionalTrustBundleSecret(c)
+
+	trustBundleSecret := createGlobalPullSecretObj(corev1.SecretTypeOpaque, testTrustBundleSecret, constants.AdditionalTrustBundleSecretKey, "")
+	requests := mapper(handler.MapObject{Meta: trustBundleSecret, Object: trustBundleSecret})
+	assert.Equal(t, []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: testName}}}, requests,
+		"expected only the cluster installed by Hive to be enqueued")
+
+	otherSecret := testSecret(corev1.SecretTypeOpaque, testTrustBundleSecret, constants.AdditionalTrustBundleSecretKey, "")
+	assert.Empty(t, mapper(handler.MapObject{Meta: otherSecret, Object: otherSecret}), "unexpected requests for a secret outside the hive namespace")
+}
@@ -15,6 +15,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/util/flowcontrol"
 	"k8s.io/client-go/util/workqueue"
 
@@ -32,6 +33,7 @@ import (
 	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 	"github.com/openshift/hive/pkg/install"
+	"github.com/openshift/hive/pkg/remoteclient"
 	k8slabels "github.com/openshift/hive/pkg/util/labels"
 )
 
@@ -40,6 +42,10 @@ const (
 	jobHashAnnotation             = "hive.openshift.io/jobhash"
 	authenticationFailedReason    = "AuthenticationFailed"
 	authenticationSucceededReason = "AuthenticationSucceeded"
+	destroyerFailedReason         = "DestroyerFailed"
+	destroyerSucceededReason      = "DestroyerSucceeded"
+	pausedReason                  = "Paused"
+	resumedReason                 = "Resumed"
 )
 
 var (
@@ -95,11 +101,28 @@ func newReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) (re
 			return nil, err
 		}
 	}
-	return &ReconcileClusterDeprovision{
+	r := &ReconcileClusterDeprovision{
 		Client:               controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
 		scheme:               mgr.GetScheme(),
 		deprovisionsDisabled: deprovisionsDisabled,
-	}, nil
+	}
+	r.remoteClusterAPIClientBuilder = func(cd *hivev1.ClusterDeployment) remoteclient.Builder {
+		// The exit backup creates Velero backups and etcd snapshot pods, which the scoped client of the controller
+		// is not permitted to, so the admin kubeconfig of the cluster is used.
+		return remoteclient.NewAdminBuilder(r.Client, cd, ControllerName)
+	}
+	if maxSizeKB := os.Getenv(constants.PodLogSnapshotMaxSizeKBEnvVar); maxSizeKB != "" {
+		kubeClient, err := kubernetes.NewForConfig(mgr.GetConfig())
+		if err != nil {
+			log.WithError(err).Error("could not create kube client, not taking pod log snapshots")
+		} else if kb, err := strconv.Atoi(maxSizeKB); err != nil {
+			log.WithError(err).WithField("maxSizeKB", maxSizeKB).Warn("invalid pod log snapshot size, not taking pod log snapshots")
+		} else {
+			r.podLogTailer = controllerutils.NewPodLogTailer(kubeClient)
+			r.podLogSnapshotMaxBytes = kb * 1024
+		}
+	}
+	return r, nil
 }
 
 // add adds a new Controller to mgr with r as the reconcile.Reconciler
@@ -142,6 +165,12 @@ type ReconcileClusterDeprovision struct {
 	client.Client
 	scheme               *runtime.Scheme
 	deprovisionsDisabled bool
+	// podLogTailer reads the logs of uninstall pods to snapshot them. Nil when no snapshots are taken.
+	podLogTailer controllerutils.PodLogTailer
+	// podLogSnapshotMaxBytes is the size of the snapshots of the logs of uninstall pods.
+	podLogSnapshotMaxBytes int
+	// remoteClusterAPIClientBuilder builds clients for the clusters being deprovisioned, to take their exit backups.
+	remoteClusterAPIClientBuilder func(cd *hivev1.ClusterDeployment) remoteclient.Builder
 }
 
 // Reconcile reads that state of the cluster for a ClusterDeprovision object and makes changes based on the state read
@@ -213,6 +242,13 @@ func (r *ReconcileClusterDeprovision) Reconcile(request reconcile.Request) (reco
 		return reconcile.Result{}, nil
 	}
 
+	if instance.Spec.Paused {
+		return reconcile.Result{}, r.pause(instance, rLog)
+	}
+	if err := r.resumeIfPaused(instance, rLog); err != nil {
+		return reconcile.Result{}, err
+	}
+
 	// Check if deprovisions are currently disabled: (originates in HiveConfig in real world)
 	if r.deprovisionsDisabled {
 		rLog.Warn("deprovisions are currently disabled in HiveConfig, skipping")
@@ -265,6 +301,10 @@ func (r *ReconcileClusterDeprovision) Reconcile(request reconcile.Request) (reco
 		}
 	}
 
+	if instance.Spec.ExitBackup != nil && !exitBackupDone(instance) {
+		return r.reconcileExitBackup(instance, cd, rLog)
+	}
+
 	// Generate an uninstall job
 	rLog.Debug("generating uninstall job")
 	uninstallJob, err := install.GenerateUninstallerJobForDeprovision(instance)
@@ -298,6 +338,12 @@ func (r *ReconcileClusterDeprovision) Reconcile(request reconcile.Request) (reco
 	err = r.Get(context.TODO(), types.NamespacedName{Name: uninstallJob.Name, Namespace: uninstallJob.Namespace}, existingJob)
 	if err != nil && errors.IsNotFound(err) {
 		rLog.Debug("uninstall job does not exist, creating it")
+		if uninstallJob.Spec.Template.Spec.ServiceAccountName == controllerutils.ServiceAccountName {
+			if err := controllerutils.SetupClusterInstallServiceAccount(r, instance.Namespace, rLog); err != nil {
+				rLog.WithError(err).Log(controllerutils.LogLevel(err), "error setting up service account and role")
+				return reconcile.Result{}, err
+			}
+		}
 		err = r.Create(context.TODO(), uninstallJob)
 		if err != nil {
 			rLog.WithError(err).Log(controllerutils.LogLevel(err), "error creating uninstall job")
@@ -317,6 +363,17 @@ func (r *ReconcileClusterDeprovision) Reconcile(request reconcile.Request) (reco
 		jobDuration := existingJob.Status.CompletionTime.Time.Sub(existingJob.Status.StartTime.Time)
 		rLog.WithField("duration", jobDuration.Seconds()).Debug("uninstall job completed")
 		instance.Status.Completed = true
+		r.snapshotUninstallPodLogs(instance, existingJob, rLog)
+		if instance.Spec.Platform.None != nil {
+			instance.Status.Conditions = controllerutils.SetClusterDeprovisionCondition(
+				instance.Status.Conditions,
+				hivev1.ExternalDestroyerFailedClusterDeprovisionCondition,
+				corev1.ConditionFalse,
+				destroyerSucceededReason,
+				"External destroyer completed successfully",
+				controllerutils.UpdateConditionIfReasonOrMessageChange,
+			)
+		}
 		err = r.Status().Update(context.TODO(), instance)
 		if err != nil {
 			rLog.WithError(err).Log(controllerutils.LogLevel(err), "error updating request status")
@@ -350,9 +407,144 @@ func (r *ReconcileClusterDeprovision) Reconcile(request reconcile.Request) (reco
 	}
 
 	rLog.Infof("uninstall job not yet successful")
+	if existingJob.Status.Failed > 0 && r.snapshotUninstallPodLogs(instance, existingJob, rLog) {
+		if err := r.Status().Update(context.TODO(), instance); err != nil {
+			rLog.WithError(err).Log(controllerutils.LogLevel(err), "error updating reference to pod log snapshot")
+			return reconcile.Result{}, err
+		}
+	}
+	if instance.Spec.Platform.None != nil && existingJob.Status.Failed > 0 {
+		return reconcile.Result{}, r.setExternalDestroyerFailed(instance, existingJob)
+	}
 	return reconcile.Result{}, nil
 }
 
+// pause stops any running uninstall job of the deprovision, preserving whatever infrastructure of the cluster remains,
+// and records in the status that the deprovision is paused. Completed deprovisions are skipped before pausing, so a
+// successful uninstall job is never deleted here.
+func (r *ReconcileClusterDeprovision) pause(instance *hivev1.ClusterDeprovision, rLog log.FieldLogger) error {
+	rLog.Info("clusterdeprovision is paused, holding the deprovision")
+	job := &batchv1.Job{}
+	err := r.Get(context.TODO(), types.NamespacedName{Namespace: instance.Namespace, Name: install.GetUninstallJobName(instance.Name)}, job)
+	switch {
+	case errors.IsNotFound(err):
+	case err != nil:
+		rLog.WithError(err).Error("error getting uninstall job")
+		return err
+	case job.DeletionTimestamp == nil:
+		rLog.Info("deleting uninstall job of paused deprovision")
+		if err := r.Delete(context.TODO(), job, client.PropagationPolicy(metav1.DeletePropagationForeground)); err != nil && !errors.IsNotFound(err) {
+			rLog.WithError(err).Log(controllerutils.LogLevel(err), "error deleting uninstall job of paused deprovision")
+			return err
+		}
+	}
+
+	conditions, changed := controllerutils.SetClusterDeprovisionConditionWithChangeCheck(
+		instance.Status.Conditions,
+		hivev1.PausedClusterDeprovisionCondition,
+		corev1.ConditionTrue,
+		pausedReason,
+		"Deprovision is paused, the infrastructure of the cluster is preserved until it is unpaused",
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	if !changed {
+		return nil
+	}
+	instance.Status.Conditions = conditions
+	if err := r.Status().Update(context.TODO(), instance); err != nil {
+		rLog.WithError(err).Log(controllerutils.LogLevel(err), "error updating paused condition")
+		return err
+	}
+	return nil
+}
+
+// resumeIfPaused records in the status that a previously paused deprovision has resumed.
+func (r *ReconcileClusterDeprovision) resumeIfPaused(instance *hivev1.ClusterDeprovision, rLog log.FieldLogger) error {
+	cond := controllerutils.FindClusterDeprovisionCondition(instance.Status.Conditions, hivev1.PausedClusterDeprovisionCondition)
+	if cond == nil || cond.Status != corev1.ConditionTrue {
+		return nil
+	}
+	rLog.Info("clusterdeprovision is no longer paused, resuming the deprovision")
+	instance.Status.Conditions = controllerutils.SetClusterDeprovisionCondition(
+		instance.Status.Conditions,
+		hivev1.PausedClusterDeprovisionCondition,
+		corev1.ConditionFalse,
+		resumedReason,
+		"Deprovision has resumed",
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	if err := r.Status().Update(context.TODO(), instance); err != nil {
+		rLog.WithError(err).Log(controllerutils.LogLevel(err), "error updating paused condition")
+		return err
+	}
+	return nil
+}
+
+// snapshotUninstallPodLogs stores the end of the logs of the last finished uninstall pod of the job in a ConfigMap, and
+// references it from the status of the deprovision. It returns whether the reference was added to the status.
+// Snapshots are best effort, so failures are only logged.
+func (r *ReconcileClusterDeprovision) snapshotUninstallPodLogs(instance *hivev1.ClusterDeprovision, job *batchv1.Job, rLog log.FieldLogger) bool {
+	if r.podLogTailer == nil {
+		return false
+	}
+	pod, err := r.lastFinishedPod(job)
+	if err != nil {
+		rLog.WithError(err).Warn("could not get uninstall pod to snapshot its logs")
+		return false
+	}
+	if pod == nil {
+		rLog.Debug("no finished uninstall pod to snapshot the logs of")
+		return false
+	}
+	ref, err := controllerutils.SnapshotPodLogs(r, r.podLogTailer, instance, pod, r.podLogSnapshotMaxBytes, r.scheme, rLog)
+	if err != nil {
+		rLog.WithError(err).Warn("could not snapshot uninstall pod logs")
+		return false
+	}
+	added := instance.Status.LogSnapshotRef == nil
+	instance.Status.LogSnapshotRef = ref
+	return added
+}
+
+// lastFinishedPod returns the most recently created pod of the job which succeeded or failed, or nil when there is none.
+func (r *ReconcileClusterDeprovision) lastFinishedPod(job *batchv1.Job) (*corev1.Pod, error) {
+	selector, err := metav1.LabelSelectorAsSelector(job.Spec.Selector)
+	if err != nil {
+		return nil, err
+	}
+	pods := &corev1.PodList{}
+	if err := r.List(context.TODO(), pods, client.InNamespace(job.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, err
+	}
+	var last *corev1.Pod
+	for i, pod := range pods.Items {
+		if pod.Status.Phase != corev1.PodSucceeded && pod.Status.Phase != corev1.PodFailed {
+			continue
+		}
+		if last == nil || last.CreationTimestamp.Before(&pod.CreationTimestamp) {
+			last = &pods.Items[i]
+		}
+	}
+	return last, nil
+}
+
+// setExternalDestroyerFailed reports the failures of the external destroyer job in the ClusterDeprovision status.
+func (r *ReconcileClusterDeprovision) setExternalDestroyerFailed(instance *hivev1.ClusterDeprovision, job *batchv1.Job) error {
+	conditions, changed := controllerutils.SetClusterDeprovisionConditionWithChangeCheck(
+		instance.Status.Conditions,
+		hivev1.ExternalDestroyerFailedClusterDeprovisionCondition,
+		corev1.ConditionTrue,
+		destroyerFailedReason,
+		fmt.Sprintf("External destroyer has failed %d times, retrying", job.Status.Failed),
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	if !changed {
+		return nil
+	}
+	instance.Status.Conditions = conditions
+	return r.Status().Update(context.TODO(), instance)
+}
+
 func generateOwnershipUniqueKeys(owner hivev1.MetaRuntimeObject) []*controllerutils.OwnershipUniqueKey {
 	return []*controllerutils.OwnershipUniqueKey{
 		{
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/golang/mock/gomock"
@@ -25,10 +26,12 @@ import (
 
 	"github.com/openshift/hive/apis"
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1none "github.com/openshift/hive/apis/hive/v1/none"
 	awsclient "github.com/openshift/hive/pkg/awsclient"
 	"github.com/openshift/hive/pkg/constants"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 	"github.com/openshift/hive/pkg/install"
+	k8slabels "github.com/openshift/hive/pkg/util/labels"
 )
 
 const (
@@ -53,6 +56,7 @@ func TestClusterDeprovisionReconcile(t *testing.T) {
 		validate                       func(t *testing.T, c client.Client)
 		expectErr                      bool
 		deprovisionsDisabled           bool
+		podLogSnapshots                bool
 	}{
 		{
 			name: "no-op deleting",
@@ -110,7 +114,11 @@ func TestClusterDeprovisionReconcile(t *testing.T) {
 			deployment:            testDeletedClusterDeployment(),
 			mockGetCallerIdentity: true,
 			validate: func(t *testing.T, c client.Client) {
-				validateJobExists(t, c)
+				job := validateJobExists(t, c)
+				assert.Equal(t, controllerutils.ServiceAccountName, job.Spec.Template.Spec.ServiceAccountName, "unexpected service account of uninstall pod")
+				sa := &corev1.ServiceAccount{}
+				err := c.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: controllerutils.ServiceAccountName}, sa)
+				assert.NoError(t, err, "expected service account for uninstall pod")
 			},
 		},
 		{
@@ -122,6 +130,72 @@ func TestClusterDeprovisionReconcile(t *testing.T) {
 				validateNoJobExists(t, c)
 			},
 		},
+		{
+			name: "do not create uninstall job when paused",
+			deprovision: func() *hivev1.ClusterDeprovision {
+				req := testClusterDeprovision()
+				req.Spec.Paused = true
+				return req
+			}(),
+			deployment: testDeletedClusterDeployment(),
+			validate: func(t *testing.T, c client.Client) {
+				validateNoJobExists(t, c)
+				validateCondition(t, c, []hivev1.ClusterDeprovisionCondition{
+					{
+						Type:   hivev1.PausedClusterDeprovisionCondition,
+						Reason: "Paused",
+						Status: corev1.ConditionTrue,
+					},
+				})
+			},
+		},
+		{
+			name: "delete uninstall job in progress when paused",
+			deprovision: func() *hivev1.ClusterDeprovision {
+				req := testClusterDeprovision()
+				req.Spec.Paused = true
+				return req
+			}(),
+			deployment: testDeletedClusterDeployment(),
+			existing: []runtime.Object{
+				testUninstallJob(),
+			},
+			validate: func(t *testing.T, c client.Client) {
+				validateNoJobExists(t, c)
+				validateNotCompleted(t, c)
+				validateCondition(t, c, []hivev1.ClusterDeprovisionCondition{
+					{
+						Type:   hivev1.PausedClusterDeprovisionCondition,
+						Reason: "Paused",
+						Status: corev1.ConditionTrue,
+					},
+				})
+			},
+		},
+		{
+			name: "resume when unpaused",
+			deprovision: func() *hivev1.ClusterDeprovision {
+				req := testClusterDeprovision()
+				req.Status.Conditions = []hivev1.ClusterDeprovisionCondition{{
+					Type:   hivev1.PausedClusterDeprovisionCondition,
+					Status: corev1.ConditionTrue,
+					Reason: "Paused",
+				}}
+				return req
+			}(),
+			deployment:            testDeletedClusterDeployment(),
+			mockGetCallerIdentity: true,
+			validate: func(t *testing.T, c client.Client) {
+				validateJobExists(t, c)
+				validateCondition(t, c, []hivev1.ClusterDeprovisionCondition{
+					{
+						Type:   hivev1.PausedClusterDeprovisionCondition,
+						Reason: "Resumed",
+						Status: corev1.ConditionFalse,
+					},
+				})
+			},
+		},
 		{
 			name:        "no-op when job in progress",
 			deprovision: testClusterDeprovision(),
@@ -190,6 +264,78 @@ func TestClusterDeprovisionReconcile(t *testing.T) {
 			},
 			expectErr: true,
 		},
+		{
+			name:        "external destroyer running",
+			deprovision: testNoneClusterDeprovision(),
+			deployment:  testDeletedClusterDeployment(),
+			existing: []runtime.Object{
+				testUninstallJobForDeprovision(testNoneClusterDeprovision()),
+			},
+			validate: func(t *testing.T, c client.Client) {
+				validateNotCompleted(t, c)
+				validateCondition(t, c, []hivev1.ClusterDeprovisionCondition{})
+			},
+		},
+		{
+			name:        "external destroyer retrying",
+			deprovision: testNoneClusterDeprovision(),
+			deployment:  testDeletedClusterDeployment(),
+			existing: []runtime.Object{
+				func() runtime.Object {
+					job := testUninstallJobForDeprovision(testNoneClusterDeprovision())
+					job.Status.Failed = 2
+					return job
+				}(),
+			},
+			validate: func(t *testing.T, c client.Client) {
+				validateNotCompleted(t, c)
+				validateCondition(t, c, []hivev1.ClusterDeprovisionCondition{
+					{
+						Type:   hivev1.ExternalDestroyerFailedClusterDeprovisionCondition,
+						Reason: "DestroyerFailed",
+						Status: corev1.ConditionTrue,
+					},
+				})
+			},
+		},
+		{
+			name: "external destroyer completed after failing",
+			deprovision: func() *hivev1.ClusterDeprovision {
+				req := testNoneClusterDeprovision()
+				req.Status.Conditions = []hivev1.ClusterDeprovisionCondition{{
+					Type:   hivev1.ExternalDestroyerFailedClusterDeprovisionCondition,
+					Status: corev1.ConditionTrue,
+					Reason: "DestroyerFailed",
+				}}
+				return req
+			}(),
+			deployment: testDeletedClusterDeployment(),
+			existing: []runtime.Object{
+				func() runtime.Object {
+					job := testUninstallJobForDeprovision(testNoneClusterDeprovision())
+					job.Status.Conditions = []batchv1.JobCondition{
+						{
+							Type:   batchv1.JobComplete,
+							Status: corev1.ConditionTrue,
+						},
+					}
+					now := metav1.Now()
+					job.Status.CompletionTime = &now
+					job.Status.StartTime = &now
+					return job
+				}(),
+			},
+			validate: func(t *testing.T, c client.Client) {
+				validateCompleted(t, c)
+				validateCondition(t, c, []hivev1.ClusterDeprovisionCondition{
+					{
+						Type:   hivev1.ExternalDestroyerFailedClusterDeprovisionCondition,
+						Reason: "DestroyerSucceeded",
+						Status: corev1.ConditionFalse,
+					},
+				})
+			},
+		},
 		{
 			name:        "regenerate job when hash missing",
 			deprovision: testClusterDeprovision(),
@@ -222,6 +368,54 @@ func TestClusterDeprovisionReconcile(t *testing.T) {
 				validateNoJobExists(t, c)
 			},
 		},
+		{
+			name:        "snapshot logs of completed uninstall pod",
+			deprovision: testClusterDeprovision(),
+			deployment:  testDeletedClusterDeployment(),
+			existing: []runtime.Object{
+				func() runtime.Object {
+					job := withJobSelector(testUninstallJob())
+					job.Status.Conditions = []batchv1.JobCondition{
+						{
+							Type:   batchv1.JobComplete,
+							Status: corev1.ConditionTrue,
+						},
+					}
+					now := metav1.Now()
+					job.Status.CompletionTime = &now
+					job.Status.StartTime = &now
+					return job
+				}(),
+				testUninstallPod("failed", corev1.PodFailed, time.Now().Add(-time.Hour)),
+				testUninstallPod("succeeded", corev1.PodSucceeded, time.Now()),
+			},
+			mockGetCallerIdentity: true,
+			podLogSnapshots:       true,
+			validate: func(t *testing.T, c client.Client) {
+				validateCompleted(t, c)
+				validatePodLogSnapshot(t, c, "succeeded")
+			},
+		},
+		{
+			name:        "snapshot logs of failed uninstall pod",
+			deprovision: testNoneClusterDeprovision(),
+			deployment:  testDeletedClusterDeployment(),
+			existing: []runtime.Object{
+				func() runtime.Object {
+					job := withJobSelector(testUninstallJobForDeprovision(testNoneClusterDeprovision()))
+					job.Status.Failed = 2
+					return job
+				}(),
+				testUninstallPod("failed-1", corev1.PodFailed, time.Now().Add(-time.Hour)),
+				testUninstallPod("failed-2", corev1.PodFailed, time.Now().Add(-time.Minute)),
+				testUninstallPod("running", corev1.PodRunning, time.Now()),
+			},
+			podLogSnapshots: true,
+			validate: func(t *testing.T, c client.Client) {
+				validateNotCompleted(t, c)
+				validatePodLogSnapshot(t, c, "failed-2")
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -253,6 +447,10 @@ func TestClusterDeprovisionReconcile(t *testing.T) {
 				scheme:               scheme.Scheme,
 				deprovisionsDisabled: test.deprovisionsDisabled,
 			}
+			if test.podLogSnapshots {
+				r.podLogTailer = &fakePodLogTailer{}
+				r.podLogSnapshotMaxBytes = 1024
+			}
 
 			// Save the list of actuators so that it can be restored at the end of this test
 			actuatorsSaved := actuators
@@ -302,6 +500,20 @@ func testClusterDeprovision() *hivev1.ClusterDeprovision {
 	}
 }
 
+func testNoneClusterDeprovision() *hivev1.ClusterDeprovision {
+	req := testClusterDeprovision()
+	req.Spec.Platform = hivev1.ClusterDeprovisionPlatform{
+		None: &hivev1.NoneClusterDeprovision{
+			Destroyer: hivev1none.ExternalDestroyer{
+				Job: &hivev1none.DestroyerJob{
+					Image: "example.com/destroyer:latest",
+				},
+			},
+		},
+	}
+	return req
+}
+
 func testDeletedClusterDeployment() *hivev1.ClusterDeployment {
 	now := metav1.Now()
 	cd := testClusterDeployment()
@@ -322,7 +534,14 @@ func testClusterDeployment() *hivev1.ClusterDeployment {
 }
 
 func testUninstallJob() *batchv1.Job {
-	uninstallJob, _ := install.GenerateUninstallerJobForDeprovision(testClusterDeprovision())
+	return testUninstallJobForDeprovision(testClusterDeprovision())
+}
+
+func testUninstallJobForDeprovision(req *hivev1.ClusterDeprovision) *batchv1.Job {
+	uninstallJob, _ := install.GenerateUninstallerJobForDeprovision(req)
+	// The controller labels the job before hashing it, and the labels are shared with the pod template.
+	uninstallJob.Labels = k8slabels.AddLabel(uninstallJob.Labels, constants.ClusterDeprovisionNameLabel, req.Name)
+	uninstallJob.Labels = k8slabels.AddLabel(uninstallJob.Labels, constants.JobTypeLabel, constants.JobTypeDeprovision)
 	hash, err := controllerutils.CalculateJobSpecHash(uninstallJob)
 	if err != nil {
 		panic("should never get error calculating job spec hash")
@@ -332,6 +551,51 @@ func testUninstallJob() *batchv1.Job {
 	return uninstallJob
 }
 
+func withJobSelector(job *batchv1.Job) *batchv1.Job {
+	job.Spec.Selector = &metav1.LabelSelector{MatchLabels: map[string]string{"job-name": job.Name}}
+	return job
+}
+
+func testUninstallPod(name string, phase corev1.PodPhase, created time.Time) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         testNamespace,
+			Name:              name,
+			Labels:            map[string]string{"job-name": testName + "-uninstall"},
+			CreationTimestamp: metav1.NewTime(created),
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "deprovision"}},
+		},
+		Status: corev1.PodStatus{
+			Phase: phase,
+		},
+	}
+}
+
+type fakePodLogTailer struct{}
+
+func (t *fakePodLogTailer) TailLogs(namespace, pod, container string, maxBytes int) (string, error) {
+	return fmt.Sprintf("logs of %s/%s", pod, container), nil
+}
+
+func validatePodLogSnapshot(t *testing.T, c client.Client, podName string) {
+	req := &hivev1.ClusterDeprovision{}
+	if err := c.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: testName}, req); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	if !assert.NotNil(t, req.Status.LogSnapshotRef, "expected reference to pod log snapshot") {
+		return
+	}
+	cm := &corev1.ConfigMap{}
+	if err := c.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: req.Status.LogSnapshotRef.Name}, cm); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	assert.Equal(t, map[string]string{"deprovision.log": "logs of " + podName + "/deprovision"}, cm.Data, "unexpected pod log snapshot")
+}
+
 func validateNoJobExists(t *testing.T, c client.Client) {
 	job := &batchv1.Job{}
 	err := c.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: testName + "-uninstall"}, job)
@@ -344,7 +608,7 @@ func validateNoJobExists(t *testing.T, c client.Client) {
 	}
 }
 
-func validateJobExists(t *testing.T, c client.Client) {
+func validateJobExists(t *testing.T, c client.Client) *batchv1.Job {
 	job := &batchv1.Job{}
 	err := c.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: testName + "-uninstall"}, job)
 	if err != nil {
@@ -354,6 +618,7 @@ func validateJobExists(t *testing.T, c client.Client) {
 	require.NotNil(t, job, "expected job")
 	assert.Equal(t, testClusterDeprovision().Name, job.Labels[constants.ClusterDeprovisionNameLabel], "incorrect cluster deprovision name label")
 	assert.Equal(t, constants.JobTypeDeprovision, job.Labels[constants.JobTypeLabel], "incorrect job type label")
+	return job
 }
 
 func validateNotCompleted(t *testing.T, c client.Client) {
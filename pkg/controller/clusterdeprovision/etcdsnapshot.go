@@ -0,0 +1,168 @@
+package clusterdeprovision
+
+import (
+	"context"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	// etcdNamespace is the namespace of the etcd pods of the cluster, where the snapshot of etcd is taken.
+	etcdNamespace = "openshift-etcd"
+
+	// etcdSnapshotName is the name of the pod taking the snapshot of etcd and of the claim of the volume it is saved to.
+	etcdSnapshotName = "hive-exit-etcd-snapshot"
+
+	// etcdSnapshotVolume is the name of the volume of the snapshot in the pod, which Velero backs up with restic.
+	etcdSnapshotVolume = "snapshot"
+
+	// etcdSnapshotPath is the path of the snapshot in the pod.
+	etcdSnapshotPath = "/snapshot/etcd.db"
+
+	// etcdctlContainerName is the name of the container of the etcd pods with the etcdctl client configured.
+	etcdctlContainerName = "etcdctl"
+
+	// resticBackupVolumesAnnotation lists the volumes of a pod which Velero backs up with restic.
+	resticBackupVolumesAnnotation = "backup.velero.io/backup-volumes"
+
+	exitBackupEtcdSnapshotFailedReason = "EtcdSnapshotFailed"
+)
+
+// etcdSnapshotSize is the size of the volume of the snapshot, which holds the largest etcd database allowed by the
+// default quota of etcd.
+var etcdSnapshotSize = resource.MustParse("10Gi")
+
+// reconcileEtcdSnapshot takes a snapshot of etcd to a persistent volume of the cluster, so that it is included in the
+// exit backup. The snapshot is taken by a pod on a control plane node, with the image and certificates of the etcdctl
+// container of the etcd pod of the node, which stays running once the snapshot is saved for Velero to back up its
+// volume with restic. The snapshot is not retaken when the pod is restored with its volume from the backup. It returns
+// whether the snapshot has been saved. A failed snapshot is reported in the ExitBackupFailed condition and retried.
+func (r *ReconcileClusterDeprovision) reconcileEtcdSnapshot(instance *hivev1.ClusterDeprovision, remoteClient client.Client, rLog log.FieldLogger) (bool, error) {
+	snapshotLog := rLog.WithField("pod", fmt.Sprintf("%s/%s", etcdNamespace, etcdSnapshotName))
+
+	claim := &corev1.PersistentVolumeClaim{}
+	err := remoteClient.Get(context.TODO(), types.NamespacedName{Namespace: etcdNamespace, Name: etcdSnapshotName}, claim)
+	switch {
+	case errors.IsNotFound(err):
+		claim = &corev1.PersistentVolumeClaim{}
+		claim.Namespace = etcdNamespace
+		claim.Name = etcdSnapshotName
+		claim.Labels = map[string]string{constants.ClusterDeprovisionNameLabel: instance.Name}
+		claim.Spec.AccessModes = []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}
+		claim.Spec.Resources.Requests = corev1.ResourceList{corev1.ResourceStorage: etcdSnapshotSize}
+		snapshotLog.Info("creating volume claim for etcd snapshot")
+		if err := remoteClient.Create(context.TODO(), claim); err != nil {
+			snapshotLog.WithError(err).Log(controllerutils.LogLevel(err), "error creating volume claim for etcd snapshot")
+			return false, err
+		}
+	case err != nil:
+		snapshotLog.WithError(err).Log(controllerutils.LogLevel(err), "error getting volume claim for etcd snapshot")
+		return false, err
+	}
+
+	pod := &corev1.Pod{}
+	err = remoteClient.Get(context.TODO(), types.NamespacedName{Namespace: etcdNamespace, Name: etcdSnapshotName}, pod)
+	switch {
+	case errors.IsNotFound(err):
+		pod, err = newEtcdSnapshotPod(instance, remoteClient)
+		if err != nil {
+			snapshotLog.WithError(err).Warn("cannot take etcd snapshot")
+			return false, r.setExitBackupFailedCondition(instance, corev1.ConditionTrue, exitBackupEtcdSnapshotFailedReason,
+				fmt.Sprintf("Cannot take a snapshot of etcd: %v", err), rLog)
+		}
+		snapshotLog.Info("creating pod to take etcd snapshot")
+		if err := remoteClient.Create(context.TODO(), pod); err != nil {
+			snapshotLog.WithError(err).Log(controllerutils.LogLevel(err), "error creating pod to take etcd snapshot")
+			return false, err
+		}
+		return false, nil
+	case err != nil:
+		snapshotLog.WithError(err).Log(controllerutils.LogLevel(err), "error getting pod taking etcd snapshot")
+		return false, err
+	}
+
+	if pod.Status.Phase == corev1.PodFailed {
+		snapshotLog.Warn("etcd snapshot failed, deleting pod to retry it")
+		if err := remoteClient.Delete(context.TODO(), pod); err != nil && !errors.IsNotFound(err) {
+			snapshotLog.WithError(err).Log(controllerutils.LogLevel(err), "error deleting failed etcd snapshot pod")
+			return false, err
+		}
+		return false, r.setExitBackupFailedCondition(instance, corev1.ConditionTrue, exitBackupEtcdSnapshotFailedReason,
+			fmt.Sprintf("Snapshot of etcd failed in pod %s/%s, retrying it", etcdNamespace, etcdSnapshotName), rLog)
+	}
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == corev1.PodReady && cond.Status == corev1.ConditionTrue {
+			return true, nil
+		}
+	}
+	snapshotLog.WithField("phase", pod.Status.Phase).Debug("waiting for etcd snapshot")
+	return false, nil
+}
+
+// newEtcdSnapshotPod returns the pod taking the snapshot of etcd, on the node of a running etcd pod.
+func newEtcdSnapshotPod(instance *hivev1.ClusterDeprovision, remoteClient client.Client) (*corev1.Pod, error) {
+	etcdPods := &corev1.PodList{}
+	if err := remoteClient.List(context.TODO(), etcdPods, client.InNamespace(etcdNamespace), client.MatchingLabels{"app": "etcd"}); err != nil {
+		return nil, err
+	}
+	for _, etcdPod := range etcdPods.Items {
+		if etcdPod.Status.Phase != corev1.PodRunning || etcdPod.Status.HostIP == "" {
+			continue
+		}
+		for _, c := range etcdPod.Spec.Containers {
+			if c.Name != etcdctlContainerName {
+				continue
+			}
+			// etcdctl saves snapshots from a single member, the member of the node.
+			env := []corev1.EnvVar{}
+			for _, e := range c.Env {
+				if e.Name != "ETCDCTL_ENDPOINTS" {
+					env = append(env, e)
+				}
+			}
+			env = append(env, corev1.EnvVar{Name: "ETCDCTL_ENDPOINTS", Value: fmt.Sprintf("https://%s:2379", etcdPod.Status.HostIP)})
+			pod := &corev1.Pod{}
+			pod.Namespace = etcdNamespace
+			pod.Name = etcdSnapshotName
+			pod.Labels = map[string]string{constants.ClusterDeprovisionNameLabel: instance.Name}
+			pod.Annotations = map[string]string{resticBackupVolumesAnnotation: etcdSnapshotVolume}
+			pod.Spec = corev1.PodSpec{
+				NodeName:      etcdPod.Spec.NodeName,
+				HostNetwork:   true,
+				RestartPolicy: corev1.RestartPolicyNever,
+				Tolerations:   []corev1.Toleration{{Operator: corev1.TolerationOpExists}},
+				Volumes: append(etcdPod.Spec.Volumes, corev1.Volume{
+					Name: etcdSnapshotVolume,
+					VolumeSource: corev1.VolumeSource{
+						PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: etcdSnapshotName},
+					},
+				}),
+				Containers: []corev1.Container{{
+					Name:  "snapshot",
+					Image: c.Image,
+					Env:   env,
+					Command: []string{"/bin/sh", "-c",
+						fmt.Sprintf("test -f %[1]s || etcdctl snapshot save %[1]s && exec sleep infinity", etcdSnapshotPath)},
+					VolumeMounts: append(c.VolumeMounts, corev1.VolumeMount{Name: etcdSnapshotVolume, MountPath: "/snapshot"}),
+					ReadinessProbe: &corev1.Probe{
+						Handler: corev1.Handler{
+							Exec: &corev1.ExecAction{Command: []string{"test", "-f", etcdSnapshotPath}},
+						},
+					},
+				}},
+			}
+			return pod, nil
+		}
+	}
+	return nil, fmt.Errorf("no running etcd pod with an %s container found", etcdctlContainerName)
+}
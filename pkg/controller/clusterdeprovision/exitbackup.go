@@ -0,0 +1,226 @@
+package clusterdeprovision
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	velerov1 "github.com/heptio/velero/pkg/apis/velero/v1"
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apihelpers "github.com/openshift/hive/apis/helpers"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	defaultExitBackupNamespace = "velero"
+	defaultExitBackupTimeout   = 2 * time.Hour
+	exitBackupPollInterval     = 30 * time.Second
+
+	exitBackupFailedReason       = "BackupFailed"
+	exitBackupUnreachableReason  = "RemoteClusterUnreachable"
+	exitBackupCompletedReason    = "BackupCompleted"
+	exitBackupHibernatingReason  = "ClusterHibernating"
+	exitBackupNotInstalledReason = "ClusterNotInstalled"
+	exitBackupTimedOutReason     = "BackupTimedOut"
+)
+
+// exitBackupCompleted returns whether the backup requested by the spec of the deprovision has completed.
+func exitBackupCompleted(instance *hivev1.ClusterDeprovision) bool {
+	return instance.Status.ExitBackup != nil && instance.Status.ExitBackup.Phase == string(velerov1.BackupPhaseCompleted)
+}
+
+// exitBackupDone returns whether the deprovision can proceed, because the backup requested by its spec has completed
+// or has been skipped.
+func exitBackupDone(instance *hivev1.ClusterDeprovision) bool {
+	if exitBackupCompleted(instance) {
+		return true
+	}
+	cond := controllerutils.FindClusterDeprovisionCondition(instance.Status.Conditions, hivev1.ExitBackupSkippedClusterDeprovisionCondition)
+	return cond != nil && cond.Status == corev1.ConditionTrue
+}
+
+// reconcileExitBackup creates the Velero Backup of the cluster requested by the spec of the deprovision, and records
+// its progress in the status of the deprovision. The deprovision is held, requeuing until the backup has completed.
+// A failed backup is reported in the ExitBackupFailed condition and is not retried, so that no forensic data is lost
+// by deleting the infrastructure until the backup times out: the backup must be deleted on the cluster to be retried
+// in the meantime. The backup is skipped, and the deprovision proceeds without it, when it times out or when the
+// cluster cannot be backed up because it was never installed, is hibernating or is unreachable.
+func (r *ReconcileClusterDeprovision) reconcileExitBackup(instance *hivev1.ClusterDeprovision, cd *hivev1.ClusterDeployment, rLog log.FieldLogger) (reconcile.Result, error) {
+	spec := instance.Spec.ExitBackup
+	timeout := defaultExitBackupTimeout
+	if spec.Timeout != nil {
+		timeout = spec.Timeout.Duration
+	}
+	switch {
+	case !cd.Spec.Installed:
+		return reconcile.Result{}, r.skipExitBackup(instance, exitBackupNotInstalledReason,
+			"Cluster was never installed, there is nothing to back up", rLog)
+	case cd.Spec.PowerState == hivev1.HibernatingClusterPowerState || conditionIsTrue(cd, hivev1.ClusterHibernatingCondition):
+		return reconcile.Result{}, r.skipExitBackup(instance, exitBackupHibernatingReason,
+			"Cluster is hibernating, it cannot be backed up", rLog)
+	case conditionIsTrue(cd, hivev1.UnreachableCondition):
+		return reconcile.Result{}, r.skipExitBackup(instance, exitBackupUnreachableReason,
+			"Cluster is unreachable, it cannot be backed up", rLog)
+	case time.Since(instance.CreationTimestamp.Time) > timeout:
+		return reconcile.Result{}, r.skipExitBackup(instance, exitBackupTimedOutReason,
+			fmt.Sprintf("Backup of the cluster did not complete within %s", timeout), rLog)
+	}
+
+	remoteClient, err := r.remoteClusterAPIClientBuilder(cd).Build()
+	if err != nil {
+		rLog.WithError(err).Warn("could not connect to cluster to back it up")
+		if err := r.setExitBackupFailedCondition(instance, corev1.ConditionTrue, exitBackupUnreachableReason,
+			fmt.Sprintf("Cannot connect to the cluster to back it up: %v", err), rLog); err != nil {
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{RequeueAfter: exitBackupPollInterval}, nil
+	}
+
+	var etcdSnapshotClaim string
+	if spec.Etcd {
+		ready, err := r.reconcileEtcdSnapshot(instance, remoteClient, rLog)
+		if err != nil || !ready {
+			return reconcile.Result{RequeueAfter: exitBackupPollInterval}, err
+		}
+		etcdSnapshotClaim = etcdSnapshotName
+	}
+
+	namespace := spec.Namespace
+	if namespace == "" {
+		namespace = defaultExitBackupNamespace
+	}
+	name := apihelpers.GetResourceName(instance.Name, "exit")
+	backupLog := rLog.WithField("backup", fmt.Sprintf("%s/%s", namespace, name))
+
+	backup := &velerov1.Backup{}
+	err = remoteClient.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: name}, backup)
+	switch {
+	case errors.IsNotFound(err):
+		backup = &velerov1.Backup{}
+		backup.Namespace = namespace
+		backup.Name = name
+		backup.Labels = map[string]string{constants.ClusterDeprovisionNameLabel: instance.Name}
+		backup.Spec.IncludedNamespaces = []string{"*"}
+		backup.Spec.StorageLocation = spec.StorageLocation
+		backup.Spec.SnapshotVolumes = pointer.BoolPtr(true)
+		if spec.SnapshotVolumes != nil {
+			backup.Spec.SnapshotVolumes = pointer.BoolPtr(*spec.SnapshotVolumes)
+		}
+		if spec.TTL != nil {
+			backup.Spec.TTL = *spec.TTL
+		}
+		backupLog.Info("creating exit backup of cluster")
+		if err := remoteClient.Create(context.TODO(), backup); err != nil {
+			backupLog.WithError(err).Log(controllerutils.LogLevel(err), "error creating exit backup")
+			return reconcile.Result{}, err
+		}
+	case err != nil:
+		backupLog.WithError(err).Log(controllerutils.LogLevel(err), "error getting exit backup")
+		return reconcile.Result{}, err
+	}
+
+	status := &hivev1.ExitBackupStatus{
+		Name:              backup.Name,
+		Namespace:         backup.Namespace,
+		StorageLocation:   backup.Spec.StorageLocation,
+		Phase:             string(backup.Status.Phase),
+		EtcdSnapshotClaim: etcdSnapshotClaim,
+	}
+	if !backup.Status.CompletionTimestamp.IsZero() {
+		status.CompletionTime = backup.Status.CompletionTimestamp.DeepCopy()
+	}
+	changed := !reflect.DeepEqual(instance.Status.ExitBackup, status)
+	instance.Status.ExitBackup = status
+
+	var conditionStatus corev1.ConditionStatus
+	var reason, message string
+	switch backup.Status.Phase {
+	case velerov1.BackupPhaseCompleted:
+		backupLog.Info("exit backup of cluster completed")
+		conditionStatus, reason, message = corev1.ConditionFalse, exitBackupCompletedReason, "Backup of the cluster completed"
+	case velerov1.BackupPhaseFailed, velerov1.BackupPhasePartiallyFailed, velerov1.BackupPhaseFailedValidation:
+		backupLog.WithField("phase", backup.Status.Phase).Warn("exit backup of cluster failed")
+		conditionStatus, reason = corev1.ConditionTrue, exitBackupFailedReason
+		message = fmt.Sprintf("Backup %s/%s of the cluster is %s, delete it on the cluster to retry it before the backup times out",
+			backup.Namespace, backup.Name, backup.Status.Phase)
+	}
+	if reason != "" {
+		conditions, conditionChanged := controllerutils.SetClusterDeprovisionConditionWithChangeCheck(
+			instance.Status.Conditions,
+			hivev1.ExitBackupFailedClusterDeprovisionCondition,
+			conditionStatus,
+			reason,
+			message,
+			controllerutils.UpdateConditionIfReasonOrMessageChange,
+		)
+		instance.Status.Conditions = conditions
+		changed = changed || conditionChanged
+	}
+	if changed {
+		if err := r.Status().Update(context.TODO(), instance); err != nil {
+			rLog.WithError(err).Log(controllerutils.LogLevel(err), "error updating exit backup status")
+			return reconcile.Result{}, err
+		}
+	}
+	if exitBackupCompleted(instance) {
+		// The watch on the deprovision requeues it now that its status has changed.
+		return reconcile.Result{}, nil
+	}
+	backupLog.WithField("phase", backup.Status.Phase).Debug("waiting for exit backup of cluster")
+	return reconcile.Result{RequeueAfter: exitBackupPollInterval}, nil
+}
+
+func (r *ReconcileClusterDeprovision) setExitBackupFailedCondition(instance *hivev1.ClusterDeprovision, status corev1.ConditionStatus, reason, message string, rLog log.FieldLogger) error {
+	conditions, changed := controllerutils.SetClusterDeprovisionConditionWithChangeCheck(
+		instance.Status.Conditions,
+		hivev1.ExitBackupFailedClusterDeprovisionCondition,
+		status,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	if !changed {
+		return nil
+	}
+	instance.Status.Conditions = conditions
+	if err := r.Status().Update(context.TODO(), instance); err != nil {
+		rLog.WithError(err).Log(controllerutils.LogLevel(err), "error updating exit backup condition")
+		return err
+	}
+	return nil
+}
+
+// skipExitBackup records in the ExitBackupSkipped condition that the deprovision proceeds without the backup requested
+// by its spec. The watch on the deprovision requeues it once its status has been updated.
+func (r *ReconcileClusterDeprovision) skipExitBackup(instance *hivev1.ClusterDeprovision, reason, message string, rLog log.FieldLogger) error {
+	rLog.WithField("reason", reason).Warn("skipping exit backup of cluster")
+	instance.Status.Conditions = controllerutils.SetClusterDeprovisionCondition(
+		instance.Status.Conditions,
+		hivev1.ExitBackupSkippedClusterDeprovisionCondition,
+		corev1.ConditionTrue,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	if err := r.Status().Update(context.TODO(), instance); err != nil {
+		rLog.WithError(err).Log(controllerutils.LogLevel(err), "error updating exit backup condition")
+		return err
+	}
+	return nil
+}
+
+// conditionIsTrue returns whether the condition of the ClusterDeployment is true.
+func conditionIsTrue(cd *hivev1.ClusterDeployment, conditionType hivev1.ClusterDeploymentConditionType) bool {
+	cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, conditionType)
+	return cond != nil && cond.Status == corev1.ConditionTrue
+}
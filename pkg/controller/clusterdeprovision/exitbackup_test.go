@@ -0,0 +1,354 @@
+package clusterdeprovision
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	velerov1 "github.com/heptio/velero/pkg/apis/velero/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/openshift/hive/apis"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/remoteclient"
+	remoteclientmock "github.com/openshift/hive/pkg/remoteclient/mock"
+)
+
+func TestClusterDeprovisionExitBackup(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+	remoteScheme := runtime.NewScheme()
+	velerov1.AddToScheme(remoteScheme)
+	corev1.AddToScheme(remoteScheme)
+
+	backupName := testName + "-exit"
+	testBackup := func(phase velerov1.BackupPhase) *velerov1.Backup {
+		b := &velerov1.Backup{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "velero", Name: backupName},
+			Spec:       velerov1.BackupSpec{IncludedNamespaces: []string{"*"}, StorageLocation: "s3-forensics"},
+			Status:     velerov1.BackupStatus{Phase: phase},
+		}
+		if phase == velerov1.BackupPhaseCompleted {
+			b.Status.CompletionTimestamp = metav1.NewTime(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC))
+		}
+		return b
+	}
+
+	tests := []struct {
+		name            string
+		deployment      *hivev1.ClusterDeployment
+		deprovision     *hivev1.ClusterDeprovision
+		remoteExisting  []runtime.Object
+		remoteErr       error
+		expectBackup    bool
+		expectPhase     string
+		expectCondition *hivev1.ClusterDeprovisionCondition
+		expectRequeue   bool
+		expectJob       bool
+		expectEtcdPod   bool
+	}{
+		{
+			name:          "create backup",
+			deployment:    testDeletedClusterDeployment(),
+			deprovision:   testExitBackupDeprovision(),
+			expectBackup:  true,
+			expectRequeue: true,
+		},
+		{
+			name:           "wait for backup in progress",
+			deployment:     testDeletedClusterDeployment(),
+			deprovision:    testExitBackupDeprovision(),
+			remoteExisting: []runtime.Object{testBackup(velerov1.BackupPhaseInProgress)},
+			expectBackup:   true,
+			expectPhase:    string(velerov1.BackupPhaseInProgress),
+			expectRequeue:  true,
+		},
+		{
+			name:           "backup completed",
+			deployment:     testDeletedClusterDeployment(),
+			deprovision:    testExitBackupDeprovision(),
+			remoteExisting: []runtime.Object{testBackup(velerov1.BackupPhaseCompleted)},
+			expectBackup:   true,
+			expectPhase:    string(velerov1.BackupPhaseCompleted),
+		},
+		{
+			name:           "backup failed",
+			deployment:     testDeletedClusterDeployment(),
+			deprovision:    testExitBackupDeprovision(),
+			remoteExisting: []runtime.Object{testBackup(velerov1.BackupPhaseFailed)},
+			expectBackup:   true,
+			expectPhase:    string(velerov1.BackupPhaseFailed),
+			expectCondition: &hivev1.ClusterDeprovisionCondition{
+				Type:   hivev1.ExitBackupFailedClusterDeprovisionCondition,
+				Status: "True",
+				Reason: exitBackupFailedReason,
+			},
+			expectRequeue: true,
+		},
+		{
+			name:        "cluster unreachable",
+			deployment:  testDeletedClusterDeployment(),
+			deprovision: testExitBackupDeprovision(),
+			remoteErr:   fmt.Errorf("connection refused"),
+			expectCondition: &hivev1.ClusterDeprovisionCondition{
+				Type:   hivev1.ExitBackupFailedClusterDeprovisionCondition,
+				Status: "True",
+				Reason: exitBackupUnreachableReason,
+			},
+			expectRequeue: true,
+		},
+		{
+			name: "skip backup of hibernating cluster",
+			deployment: func() *hivev1.ClusterDeployment {
+				cd := testDeletedClusterDeployment()
+				cd.Spec.PowerState = hivev1.HibernatingClusterPowerState
+				return cd
+			}(),
+			deprovision: testExitBackupDeprovision(),
+			expectCondition: &hivev1.ClusterDeprovisionCondition{
+				Type:   hivev1.ExitBackupSkippedClusterDeprovisionCondition,
+				Status: "True",
+				Reason: exitBackupHibernatingReason,
+			},
+		},
+		{
+			name: "skip backup of cluster never installed",
+			deployment: func() *hivev1.ClusterDeployment {
+				cd := testDeletedClusterDeployment()
+				cd.Spec.Installed = false
+				return cd
+			}(),
+			deprovision: testExitBackupDeprovision(),
+			expectCondition: &hivev1.ClusterDeprovisionCondition{
+				Type:   hivev1.ExitBackupSkippedClusterDeprovisionCondition,
+				Status: "True",
+				Reason: exitBackupNotInstalledReason,
+			},
+		},
+		{
+			name: "skip backup of unreachable cluster",
+			deployment: func() *hivev1.ClusterDeployment {
+				cd := testDeletedClusterDeployment()
+				cd.Status.Conditions = []hivev1.ClusterDeploymentCondition{{
+					Type:   hivev1.UnreachableCondition,
+					Status: corev1.ConditionTrue,
+				}}
+				return cd
+			}(),
+			deprovision: testExitBackupDeprovision(),
+			expectCondition: &hivev1.ClusterDeprovisionCondition{
+				Type:   hivev1.ExitBackupSkippedClusterDeprovisionCondition,
+				Status: "True",
+				Reason: exitBackupUnreachableReason,
+			},
+		},
+		{
+			name:       "skip backup which timed out",
+			deployment: testDeletedClusterDeployment(),
+			deprovision: func() *hivev1.ClusterDeprovision {
+				req := testExitBackupDeprovision()
+				req.CreationTimestamp = metav1.NewTime(time.Now().Add(-3 * time.Hour))
+				return req
+			}(),
+			expectCondition: &hivev1.ClusterDeprovisionCondition{
+				Type:   hivev1.ExitBackupSkippedClusterDeprovisionCondition,
+				Status: "True",
+				Reason: exitBackupTimedOutReason,
+			},
+		},
+		{
+			name:       "deprovision once backup skipped",
+			deployment: testDeletedClusterDeployment(),
+			deprovision: func() *hivev1.ClusterDeprovision {
+				req := testExitBackupDeprovision()
+				req.Status.Conditions = []hivev1.ClusterDeprovisionCondition{{
+					Type:   hivev1.ExitBackupSkippedClusterDeprovisionCondition,
+					Status: corev1.ConditionTrue,
+					Reason: exitBackupTimedOutReason,
+				}}
+				return req
+			}(),
+			expectJob: true,
+		},
+		{
+			name:           "take etcd snapshot before backup",
+			deployment:     testDeletedClusterDeployment(),
+			deprovision:    testEtcdExitBackupDeprovision(),
+			remoteExisting: []runtime.Object{testEtcdPod()},
+			expectEtcdPod:  true,
+			expectRequeue:  true,
+		},
+		{
+			name:           "wait for etcd snapshot",
+			deployment:     testDeletedClusterDeployment(),
+			deprovision:    testEtcdExitBackupDeprovision(),
+			remoteExisting: []runtime.Object{testEtcdPod(), testEtcdSnapshotPod(false)},
+			expectEtcdPod:  true,
+			expectRequeue:  true,
+		},
+		{
+			name:           "create backup once etcd snapshot saved",
+			deployment:     testDeletedClusterDeployment(),
+			deprovision:    testEtcdExitBackupDeprovision(),
+			remoteExisting: []runtime.Object{testEtcdPod(), testEtcdSnapshotPod(true)},
+			expectEtcdPod:  true,
+			expectBackup:   true,
+			expectRequeue:  true,
+		},
+		{
+			name:        "etcd snapshot without etcd pod",
+			deployment:  testDeletedClusterDeployment(),
+			deprovision: testEtcdExitBackupDeprovision(),
+			expectCondition: &hivev1.ClusterDeprovisionCondition{
+				Type:   hivev1.ExitBackupFailedClusterDeprovisionCondition,
+				Status: "True",
+				Reason: exitBackupEtcdSnapshotFailedReason,
+			},
+			expectRequeue: true,
+		},
+		{
+			name:       "deprovision once backup recorded as completed",
+			deployment: testDeletedClusterDeployment(),
+			deprovision: func() *hivev1.ClusterDeprovision {
+				req := testExitBackupDeprovision()
+				req.Status.ExitBackup = &hivev1.ExitBackupStatus{
+					Name:      backupName,
+					Namespace: "velero",
+					Phase:     string(velerov1.BackupPhaseCompleted),
+				}
+				return req
+			}(),
+			expectPhase: string(velerov1.BackupPhaseCompleted),
+			expectJob:   true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.NoError(t, controllerutil.SetControllerReference(test.deployment, test.deprovision, scheme.Scheme))
+			mocks := setupDefaultMocks(t, test.deprovision, test.deployment)
+			defer mocks.mockCtrl.Finish()
+			mocks.mockAWSClient.EXPECT().GetCallerIdentity(gomock.Any()).Return(nil, nil).AnyTimes()
+
+			remoteClient := fake.NewFakeClientWithScheme(remoteScheme, test.remoteExisting...)
+			mockRemoteClientBuilder := remoteclientmock.NewMockBuilder(mocks.mockCtrl)
+			if test.remoteErr != nil {
+				mockRemoteClientBuilder.EXPECT().Build().Return(nil, test.remoteErr).AnyTimes()
+			} else {
+				mockRemoteClientBuilder.EXPECT().Build().Return(remoteClient, nil).AnyTimes()
+			}
+			r := &ReconcileClusterDeprovision{
+				Client: mocks.fakeKubeClient,
+				scheme: scheme.Scheme,
+				remoteClusterAPIClientBuilder: func(*hivev1.ClusterDeployment) remoteclient.Builder {
+					return mockRemoteClientBuilder
+				},
+			}
+			actuatorsSaved := actuators
+			defer func() { actuators = actuatorsSaved }()
+			actuators = []Actuator{}
+
+			result, err := r.Reconcile(reconcile.Request{NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: testName}})
+			require.NoError(t, err, "unexpected error")
+			assert.Equal(t, test.expectRequeue, result.RequeueAfter > 0, "unexpected requeue")
+
+			backup := &velerov1.Backup{}
+			err = remoteClient.Get(context.TODO(), types.NamespacedName{Namespace: "velero", Name: backupName}, backup)
+			if test.expectBackup {
+				require.NoError(t, err, "expected backup on the cluster")
+				assert.Equal(t, []string{"*"}, backup.Spec.IncludedNamespaces, "unexpected namespaces backed up")
+			} else {
+				assert.Error(t, err, "expected no backup on the cluster")
+			}
+
+			req := &hivev1.ClusterDeprovision{}
+			require.NoError(t, mocks.fakeKubeClient.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: testName}, req))
+			if test.expectBackup || test.expectPhase != "" {
+				require.NotNil(t, req.Status.ExitBackup, "expected exit backup status")
+				assert.Equal(t, backupName, req.Status.ExitBackup.Name, "unexpected backup name")
+				assert.Equal(t, test.expectPhase, req.Status.ExitBackup.Phase, "unexpected backup phase")
+				if test.expectBackup && req.Spec.ExitBackup.Etcd {
+					assert.Equal(t, etcdSnapshotName, req.Status.ExitBackup.EtcdSnapshotClaim, "unexpected etcd snapshot claim")
+				}
+			} else {
+				assert.Nil(t, req.Status.ExitBackup, "expected no exit backup status")
+			}
+			if test.expectCondition != nil {
+				require.Len(t, req.Status.Conditions, 1, "expected exit backup condition")
+				assert.Equal(t, test.expectCondition.Type, req.Status.Conditions[0].Type, "unexpected condition type")
+				assert.Equal(t, test.expectCondition.Status, req.Status.Conditions[0].Status, "unexpected condition status")
+				assert.Equal(t, test.expectCondition.Reason, req.Status.Conditions[0].Reason, "unexpected condition reason")
+			}
+			pod := &corev1.Pod{}
+			err = remoteClient.Get(context.TODO(), types.NamespacedName{Namespace: etcdNamespace, Name: etcdSnapshotName}, pod)
+			if test.expectEtcdPod {
+				require.NoError(t, err, "expected etcd snapshot pod on the cluster")
+				assert.Equal(t, "master-0", pod.Spec.NodeName, "unexpected node of etcd snapshot pod")
+				assert.Contains(t, pod.Spec.Containers[0].Env, corev1.EnvVar{Name: "ETCDCTL_ENDPOINTS", Value: "https://10.0.0.1:2379"}, "unexpected etcd endpoint")
+				assert.Equal(t, etcdSnapshotVolume, pod.Annotations[resticBackupVolumesAnnotation], "expected snapshot volume to be backed up")
+				claim := &corev1.PersistentVolumeClaim{}
+				assert.NoError(t, remoteClient.Get(context.TODO(), types.NamespacedName{Namespace: etcdNamespace, Name: etcdSnapshotName}, claim), "expected etcd snapshot volume claim")
+			} else {
+				assert.Error(t, err, "expected no etcd snapshot pod on the cluster")
+			}
+			if test.expectJob {
+				validateJobExists(t, mocks.fakeKubeClient)
+			} else {
+				validateNoJobExists(t, mocks.fakeKubeClient)
+			}
+		})
+	}
+}
+
+func testExitBackupDeprovision() *hivev1.ClusterDeprovision {
+	req := testClusterDeprovision()
+	req.CreationTimestamp = metav1.Now()
+	req.Spec.ExitBackup = &hivev1.ExitBackup{StorageLocation: "s3-forensics"}
+	return req
+}
+
+func testEtcdExitBackupDeprovision() *hivev1.ClusterDeprovision {
+	req := testExitBackupDeprovision()
+	req.Spec.ExitBackup.Etcd = true
+	return req
+}
+
+func testEtcdPod() *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: etcdNamespace, Name: "etcd-master-0", Labels: map[string]string{"app": "etcd"}},
+		Spec: corev1.PodSpec{
+			NodeName: "master-0",
+			Containers: []corev1.Container{
+				{Name: "etcd", Image: "etcd-image"},
+				{
+					Name:  etcdctlContainerName,
+					Image: "etcd-image",
+					Env: []corev1.EnvVar{
+						{Name: "ETCDCTL_API", Value: "3"},
+						{Name: "ETCDCTL_ENDPOINTS", Value: "https://10.0.0.1:2379,https://10.0.0.2:2379,https://10.0.0.3:2379"},
+					},
+				},
+			},
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning, HostIP: "10.0.0.1"},
+	}
+}
+
+func testEtcdSnapshotPod(ready bool) *corev1.Pod {
+	pod, _ := newEtcdSnapshotPod(testEtcdExitBackupDeprovision(), fake.NewFakeClientWithScheme(scheme.Scheme, testEtcdPod()))
+	pod.Status.Phase = corev1.PodRunning
+	if ready {
+		pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+	}
+	return pod
+}
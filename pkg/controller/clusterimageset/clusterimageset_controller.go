@@ -0,0 +1,420 @@
+package clusterimageset
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/imageset"
+)
+
+const (
+	ControllerName = hivev1.ClusterImageSetControllerName
+
+	// validationRetryInterval is how long a failed validation job is kept before the validation is retried.
+	validationRetryInterval = 10 * time.Minute
+
+	validatingReason       = "Validating"
+	validReason            = "Valid"
+	invalidReason          = "Invalid"
+	validationFailedReason = "ValidationFailed"
+
+	// jobNameLabel is the label set by Kubernetes on the pods of a job with the name of the job.
+	jobNameLabel = "job-name"
+)
+
+// Add creates a new ClusterImageSet Controller and adds it to the Manager with default RBAC. The Manager will set
+// fields on the Controller and Start it when the Manager is Started.
+func Add(mgr manager.Manager) error {
+	logger := log.WithField("controller", ControllerName)
+	concurrentReconciles, clientRateLimiter, queueRateLimiter, err := controllerutils.GetControllerConfig(mgr.GetClient(), ControllerName)
+	if err != nil {
+		logger.WithError(err).Error("could not get controller configurations")
+		return err
+	}
+	return AddToManager(mgr, NewReconciler(mgr, clientRateLimiter), concurrentReconciles, queueRateLimiter)
+}
+
+// NewReconciler returns a new reconcile.Reconciler
+func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) reconcile.Reconciler {
+	return &ReconcileClusterImageSet{
+		Client: controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
+		scheme: mgr.GetScheme(),
+	}
+}
+
+// AddToManager adds a new Controller to mgr with r as the reconcile.Reconciler
+func AddToManager(mgr manager.Manager, r reconcile.Reconciler, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
+	c, err := controller.New(
+		fmt.Sprintf("%s-controller", ControllerName),
+		mgr,
+		controller.Options{
+			Reconciler:              r,
+			MaxConcurrentReconciles: concurrentReconciles,
+			RateLimiter:             rateLimiter,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to ClusterImageSets
+	if err := c.Watch(&source.Kind{Type: &hivev1.ClusterImageSet{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		return err
+	}
+
+	// Watch for the validation jobs of ClusterImageSets
+	if err := c.Watch(&source.Kind{Type: &batchv1.Job{}}, &handler.EnqueueRequestForOwner{
+		IsController: true,
+		OwnerType:    &hivev1.ClusterImageSet{},
+	}); err != nil {
+		return err
+	}
+
+	// Watch for the pre-pull daemon set shared by the ClusterImageSets pre-pulling their images
+	if err := c.Watch(&source.Kind{Type: &appsv1.DaemonSet{}}, &handler.EnqueueRequestsFromMapFunc{
+		ToRequests: requestsForPrePullDaemonSet(mgr.GetClient()),
+	}); err != nil {
+		return err
+	}
+
+	// Watch for the ClusterDeployments and ClusterPools using ClusterImageSets, which are indexed by the name of their
+	// ClusterImageSet so that the usage of a ClusterImageSet is counted without listing all of them.
+	if err := controllerutils.IndexClusterImageSetUsers(mgr.GetFieldIndexer()); err != nil {
+		return err
+	}
+	if err := c.Watch(&source.Kind{Type: &hivev1.ClusterDeployment{}}, &handler.EnqueueRequestsFromMapFunc{
+		ToRequests: handler.ToRequestsFunc(requestsForClusterDeployment),
+	}); err != nil {
+		return err
+	}
+	if err := c.Watch(&source.Kind{Type: &hivev1.ClusterPool{}}, &handler.EnqueueRequestsFromMapFunc{
+		ToRequests: handler.ToRequestsFunc(requestsForClusterPool),
+	}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var _ reconcile.Reconciler = &ReconcileClusterImageSet{}
+
+// ReconcileClusterImageSet validates the release images of ClusterImageSets, pre-pulls their images and tracks their
+// usage.
+type ReconcileClusterImageSet struct {
+	client.Client
+	scheme *runtime.Scheme
+}
+
+// Reconcile validates the release image of a ClusterImageSet with a job in the Hive namespace whenever the release
+// image changes, and records the result in the Valid condition and the status of the image set. The job resolves the
+// release image on a node of the hub and checks that it has a version and the installer and cli images. When the
+// image set asks for its images to be pre-pulled, the images of a valid release image are pulled onto the nodes of the
+// hub by a daemon set shared by all of the image sets. The number of ClusterDeployments and ClusterPools using the image set, and the last time it was
+// used, are recorded in its status.
+func (r *ReconcileClusterImageSet) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	logger := controllerutils.BuildControllerLogger(ControllerName, "clusterImageSet", request.NamespacedName)
+	logger.Debug("reconciling cluster image set")
+	recobsrv := hivemetrics.NewReconcileObserver(ControllerName, logger)
+	defer recobsrv.ObserveControllerReconcileTime()
+
+	imageSet := &hivev1.ClusterImageSet{}
+	if err := r.Get(context.Background(), request.NamespacedName, imageSet); err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Debug("cluster image set not found")
+			// Stop pulling the images of the deleted image set.
+			_, _, err := r.syncPrePullDaemonSet(logger)
+			return reconcile.Result{}, err
+		}
+		logger.WithError(err).Error("error getting cluster image set")
+		return reconcile.Result{}, err
+	}
+	if imageSet.DeletionTimestamp != nil {
+		_, _, err := r.syncPrePullDaemonSet(logger)
+		return reconcile.Result{}, err
+	}
+
+	usageResult, err := r.reconcileUsage(imageSet, logger)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	result, err := r.reconcileValidation(imageSet, logger)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if err := r.reconcilePrePull(imageSet, logger); err != nil {
+		return reconcile.Result{}, err
+	}
+	if result.RequeueAfter == 0 || (usageResult.RequeueAfter > 0 && usageResult.RequeueAfter < result.RequeueAfter) {
+		result.RequeueAfter = usageResult.RequeueAfter
+	}
+	return result, nil
+}
+
+// isValidated returns whether the current release image of the image set has been found to be valid or invalid.
+func isValidated(imageSet *hivev1.ClusterImageSet) bool {
+	if imageSet.Status.ReleaseImage != imageSet.Spec.ReleaseImage {
+		return false
+	}
+	cond := controllerutils.FindClusterImageSetCondition(imageSet.Status.Conditions, hivev1.ClusterImageSetValidCondition)
+	return cond != nil && (cond.Reason == validReason || cond.Reason == invalidReason)
+}
+
+func (r *ReconcileClusterImageSet) reconcileValidation(imageSet *hivev1.ClusterImageSet, logger log.FieldLogger) (reconcile.Result, error) {
+	jobKey := client.ObjectKey{Namespace: controllerutils.GetHiveNamespace(), Name: imageset.GetImageSetValidationJobName(imageSet.Name)}
+	jobLog := logger.WithField("job", jobKey.Name)
+
+	job := &batchv1.Job{}
+	switch err := r.Get(context.Background(), jobKey, job); {
+	case apierrors.IsNotFound(err):
+		if isValidated(imageSet) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, r.createValidationJob(imageSet, jobLog)
+	case err != nil:
+		jobLog.WithError(err).Error("cannot get validation job")
+		return reconcile.Result{}, err
+	}
+
+	switch {
+	case job.DeletionTimestamp != nil:
+		jobLog.Debug("validation job is being deleted")
+		return reconcile.Result{}, nil
+
+	// The release image was changed while it was being validated, or the job was left behind after a validation.
+	case job.Annotations[imageset.ImageSetReleaseImageAnnotation] != imageSet.Spec.ReleaseImage || isValidated(imageSet):
+		jobLog.Info("deleting stale validation job")
+		return reconcile.Result{}, r.deleteValidationJob(job, jobLog)
+
+	case controllerutils.IsSuccessful(job):
+		recorded, err := r.recordValidationResult(imageSet, job, jobLog)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		if !recorded {
+			return r.retryFailedValidation(job, jobLog)
+		}
+		return reconcile.Result{}, r.deleteValidationJob(job, jobLog)
+
+	case controllerutils.IsFailed(job):
+		if err := r.recordValidationFailure(imageSet, job, jobLog); err != nil {
+			return reconcile.Result{}, err
+		}
+		return r.retryFailedValidation(job, jobLog)
+
+	default:
+		jobLog.Debug("validation job is in progress")
+		return reconcile.Result{}, nil
+	}
+}
+
+// retryFailedValidation keeps a finished job that did not validate the release image for a while, both to not retry
+// the validation right away and for its pods to be inspected, then deletes it for the validation to be retried.
+func (r *ReconcileClusterImageSet) retryFailedValidation(job *batchv1.Job, jobLog log.FieldLogger) (reconcile.Result, error) {
+	finishedTime := job.CreationTimestamp.Time
+	for _, cond := range job.Status.Conditions {
+		if (cond.Type == batchv1.JobFailed || cond.Type == batchv1.JobComplete) && cond.Status == corev1.ConditionTrue {
+			finishedTime = cond.LastTransitionTime.Time
+		}
+	}
+	if retryAfter := time.Until(finishedTime.Add(validationRetryInterval)); retryAfter > 0 {
+		return reconcile.Result{RequeueAfter: retryAfter}, nil
+	}
+	jobLog.Info("retrying failed validation")
+	return reconcile.Result{}, r.deleteValidationJob(job, jobLog)
+}
+
+func (r *ReconcileClusterImageSet) createValidationJob(imageSet *hivev1.ClusterImageSet, jobLog log.FieldLogger) error {
+	job := imageset.GenerateImageSetValidationJob(imageSet, controllerutils.GetHiveNamespace(), os.Getenv(constants.GlobalPullSecret))
+	if err := controllerutil.SetControllerReference(imageSet, job, r.scheme); err != nil {
+		jobLog.WithError(err).Error("error setting controller reference on job")
+		return err
+	}
+	jobLog.WithField("releaseImage", imageSet.Spec.ReleaseImage).Info("creating validation job")
+	if err := r.Create(context.Background(), job); err != nil {
+		jobLog.WithError(err).Log(controllerutils.LogLevel(err), "error creating validation job")
+		return err
+	}
+	return r.setValidCondition(
+		imageSet,
+		corev1.ConditionUnknown,
+		validatingReason,
+		fmt.Sprintf("Validating release image %s", imageSet.Spec.ReleaseImage),
+		jobLog,
+	)
+}
+
+func (r *ReconcileClusterImageSet) deleteValidationJob(job *batchv1.Job, jobLog log.FieldLogger) error {
+	if err := r.Delete(context.Background(), job, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil && !apierrors.IsNotFound(err) {
+		jobLog.WithError(err).Log(controllerutils.LogLevel(err), "cannot delete validation job")
+		return err
+	}
+	return nil
+}
+
+// recordValidationResult records in the status of the image set the result of the validation reported by the
+// successful validation job. It returns false, after recording a failed validation, when the result cannot be read.
+func (r *ReconcileClusterImageSet) recordValidationResult(imageSet *hivev1.ClusterImageSet, job *batchv1.Job, jobLog log.FieldLogger) (bool, error) {
+	pod, err := r.latestJobPod(job, jobLog)
+	if err != nil {
+		return false, err
+	}
+	result := &imageset.ImageSetValidationResult{}
+	message := ""
+	if pod != nil {
+		if status := findContainerStatus(pod.Status.ContainerStatuses, "hiveutil"); status != nil && status.State.Terminated != nil {
+			message = status.State.Terminated.Message
+		}
+	}
+	if err := json.Unmarshal([]byte(message), result); err != nil {
+		jobLog.WithError(err).Warn("could not read result of validation job")
+		return false, r.recordValidationFailure(imageSet, job, jobLog)
+	}
+
+	imageSet.Status.ReleaseImage = imageSet.Spec.ReleaseImage
+	imageSet.Status.Version = result.Version
+	imageSet.Status.Architecture = result.Architecture
+	imageSet.Status.InstallerImage = result.InstallerImage
+	imageSet.Status.CLIImage = result.CLIImage
+	status, reason := corev1.ConditionTrue, validReason
+	message = fmt.Sprintf("Release image %s is valid", imageSet.Spec.ReleaseImage)
+	if result.Error != "" {
+		status, reason, message = corev1.ConditionFalse, invalidReason, result.Error
+	}
+	jobLog.WithField("valid", status).Info("release image validated")
+	imageSet.Status.Conditions, _ = controllerutils.SetClusterImageSetConditionWithChangeCheck(
+		imageSet.Status.Conditions,
+		hivev1.ClusterImageSetValidCondition,
+		status,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	return true, r.updateStatus(imageSet, jobLog)
+}
+
+// recordValidationFailure records that the release image of the image set could not be validated by the failed
+// validation job, with the cause reported by the latest pod of the job.
+func (r *ReconcileClusterImageSet) recordValidationFailure(imageSet *hivev1.ClusterImageSet, job *batchv1.Job, jobLog log.FieldLogger) error {
+	message := fmt.Sprintf("The job %s/%s to validate the release image failed", job.Namespace, job.Name)
+	for _, cond := range job.Status.Conditions {
+		if cond.Type == batchv1.JobFailed && cond.Status == corev1.ConditionTrue {
+			message = fmt.Sprintf("%s because of (%s) %s", message, cond.Reason, cond.Message)
+		}
+	}
+	pod, err := r.latestJobPod(job, jobLog)
+	if err != nil {
+		return err
+	}
+	if pod != nil {
+		if cause := podFailureCause(pod); cause != "" {
+			message = fmt.Sprintf("%s: %s", message, cause)
+		}
+	}
+	conds, changed := controllerutils.SetClusterImageSetConditionWithChangeCheck(
+		imageSet.Status.Conditions,
+		hivev1.ClusterImageSetValidCondition,
+		corev1.ConditionFalse,
+		validationFailedReason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	if !changed {
+		return nil
+	}
+	jobLog.WithField("message", message).Warn("validation job failed")
+	imageSet.Status.Conditions = conds
+	return r.updateStatus(imageSet, jobLog)
+}
+
+func (r *ReconcileClusterImageSet) setValidCondition(imageSet *hivev1.ClusterImageSet, status corev1.ConditionStatus, reason, message string, logger log.FieldLogger) error {
+	conds, changed := controllerutils.SetClusterImageSetConditionWithChangeCheck(
+		imageSet.Status.Conditions,
+		hivev1.ClusterImageSetValidCondition,
+		status,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	if !changed {
+		return nil
+	}
+	imageSet.Status.Conditions = conds
+	return r.updateStatus(imageSet, logger)
+}
+
+func (r *ReconcileClusterImageSet) updateStatus(imageSet *hivev1.ClusterImageSet, logger log.FieldLogger) error {
+	if err := r.Status().Update(context.Background(), imageSet); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update ClusterImageSet status")
+		return err
+	}
+	return nil
+}
+
+// latestJobPod returns the most recently created pod of the job, or nil when the job has no pods.
+func (r *ReconcileClusterImageSet) latestJobPod(job *batchv1.Job, jobLog log.FieldLogger) (*corev1.Pod, error) {
+	pods := &corev1.PodList{}
+	if err := r.List(context.Background(), pods, client.InNamespace(job.Namespace), client.MatchingLabels{jobNameLabel: job.Name}); err != nil {
+		jobLog.WithError(err).Error("error listing pods of validation job")
+		return nil, err
+	}
+	if len(pods.Items) == 0 {
+		return nil, nil
+	}
+	sort.Slice(pods.Items, func(i, j int) bool {
+		return pods.Items[i].CreationTimestamp.After(pods.Items[j].CreationTimestamp.Time)
+	})
+	return &pods.Items[0], nil
+}
+
+// podFailureCause returns why the containers of the failed pod of a validation job did not complete, such as the
+// release image not being pulled or not running on the node.
+func podFailureCause(pod *corev1.Pod) string {
+	var causes []string
+	statuses := append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+	for _, status := range statuses {
+		switch {
+		case status.State.Waiting != nil && status.State.Waiting.Reason != "" && status.State.Waiting.Reason != "PodInitializing":
+			causes = append(causes, strings.TrimSpace(fmt.Sprintf("container %s is waiting because of (%s) %s",
+				status.Name, status.State.Waiting.Reason, status.State.Waiting.Message)))
+		case status.State.Terminated != nil && status.State.Terminated.ExitCode != 0:
+			causes = append(causes, strings.TrimSpace(fmt.Sprintf("container %s exited with code %d: %s",
+				status.Name, status.State.Terminated.ExitCode, status.State.Terminated.Message)))
+		}
+	}
+	return strings.Join(causes, "; ")
+}
+
+func findContainerStatus(statuses []corev1.ContainerStatus, name string) *corev1.ContainerStatus {
+	for i, status := range statuses {
+		if status.Name == name {
+			return &statuses[i]
+		}
+	}
+	return nil
+}
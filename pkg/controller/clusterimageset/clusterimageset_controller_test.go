@@ -0,0 +1,577 @@
+package clusterimageset
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/imageset"
+)
+
+const (
+	testName           = "test-image-set"
+	testReleaseImage   = "registry.io/test-release-image:latest"
+	testInstallerImage = "registry.io/test-installer-image:latest"
+	testCLIImage       = "registry.io/test-cli-image:latest"
+	testVersion        = "4.6.1"
+)
+
+func TestReconcileClusterImageSet(t *testing.T) {
+	log.SetLevel(log.DebugLevel)
+	scheme := runtime.NewScheme()
+	hivev1.AddToScheme(scheme)
+	corev1.AddToScheme(scheme)
+	batchv1.AddToScheme(scheme)
+	appsv1.AddToScheme(scheme)
+
+	hiveNS := controllerutils.GetHiveNamespace()
+	jobName := imageset.GetImageSetValidationJobName(testName)
+	otherInstallerImage := "registry.io/other-installer-image:latest"
+	otherCLIImage := "registry.io/other-cli-image:latest"
+
+	imageSet := func(opts ...func(*hivev1.ClusterImageSet)) *hivev1.ClusterImageSet {
+		is := &hivev1.ClusterImageSet{
+			ObjectMeta: metav1.ObjectMeta{Name: testName},
+			Spec:       hivev1.ClusterImageSetSpec{ReleaseImage: testReleaseImage},
+		}
+		for _, o := range opts {
+			o(is)
+		}
+		return is
+	}
+	validated := func(status corev1.ConditionStatus, reason string) func(*hivev1.ClusterImageSet) {
+		return func(is *hivev1.ClusterImageSet) {
+			is.Status.ReleaseImage = testReleaseImage
+			is.Status.InstallerImage = testInstallerImage
+			is.Status.CLIImage = testCLIImage
+			is.Status.Conditions = []hivev1.ClusterImageSetCondition{{
+				Type:   hivev1.ClusterImageSetValidCondition,
+				Status: status,
+				Reason: reason,
+			}}
+		}
+	}
+	prePull := func(is *hivev1.ClusterImageSet) {
+		is.Spec.PrePullImages = true
+	}
+	job := func(releaseImage string, conditionType batchv1.JobConditionType, finished time.Time) *batchv1.Job {
+		j := imageset.GenerateImageSetValidationJob(imageSet(func(is *hivev1.ClusterImageSet) {
+			is.Spec.ReleaseImage = releaseImage
+		}), hiveNS, "")
+		if conditionType != "" {
+			j.Status.Conditions = []batchv1.JobCondition{{
+				Type:               conditionType,
+				Status:             corev1.ConditionTrue,
+				Reason:             "TestReason",
+				Message:            "test message",
+				LastTransitionTime: metav1.NewTime(finished),
+			}}
+		}
+		return j
+	}
+	pod := func(result *imageset.ImageSetValidationResult, initStatus *corev1.ContainerStatus) *corev1.Pod {
+		p := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: hiveNS,
+				Name:      jobName + "-abcde",
+				Labels:    map[string]string{jobNameLabel: jobName},
+			},
+		}
+		if result != nil {
+			raw, err := json.Marshal(result)
+			require.NoError(t, err, "unexpected error marshalling result")
+			p.Status.ContainerStatuses = []corev1.ContainerStatus{{
+				Name:  "hiveutil",
+				State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Message: string(raw)}},
+			}}
+		}
+		if initStatus != nil {
+			p.Status.InitContainerStatuses = []corev1.ContainerStatus{*initStatus}
+		}
+		return p
+	}
+	otherImageSet := func(prePullImages bool) *hivev1.ClusterImageSet {
+		is := imageSet(validated(corev1.ConditionTrue, validReason))
+		is.Name = "other-image-set"
+		is.Spec.PrePullImages = prePullImages
+		is.Status.InstallerImage = otherInstallerImage
+		is.Status.CLIImage = otherCLIImage
+		return is
+	}
+	daemonSet := func(desired, ready int32, images ...string) *appsv1.DaemonSet {
+		if len(images) == 0 {
+			images = []string{testCLIImage, testInstallerImage}
+		}
+		ds := generatePrePullDaemonSet(images, client.ObjectKey{Namespace: hiveNS, Name: prePullDaemonSetName})
+		ds.Status.DesiredNumberScheduled = desired
+		ds.Status.UpdatedNumberScheduled = desired
+		ds.Status.NumberReady = ready
+		return ds
+	}
+	lastUsed := func(d time.Duration, deployments, pools int32) func(*hivev1.ClusterImageSet) {
+		return func(is *hivev1.ClusterImageSet) {
+			used := metav1.NewTime(time.Now().Add(-d))
+			is.Status.LastUsedTime = &used
+			is.Status.ClusterDeployments = deployments
+			is.Status.ClusterPools = pools
+		}
+	}
+	clusterDeployment := func(name, imageSetName string) *hivev1.ClusterDeployment {
+		return &hivev1.ClusterDeployment{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: name},
+			Spec: hivev1.ClusterDeploymentSpec{
+				Provisioning: &hivev1.Provisioning{
+					ImageSetRef: &hivev1.ClusterImageSetReference{Name: imageSetName},
+				},
+			},
+		}
+	}
+	clusterPool := func(name, imageSetName string) *hivev1.ClusterPool {
+		return &hivev1.ClusterPool{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: name},
+			Spec: hivev1.ClusterPoolSpec{
+				ImageSetRef: hivev1.ClusterImageSetReference{Name: imageSetName},
+			},
+		}
+	}
+	assertUsage := func(deployments, pools int32, lastUsedAgo *time.Duration) func(t *testing.T, is *hivev1.ClusterImageSet) {
+		return func(t *testing.T, is *hivev1.ClusterImageSet) {
+			assert.Equal(t, deployments, is.Status.ClusterDeployments, "unexpected number of ClusterDeployments")
+			assert.Equal(t, pools, is.Status.ClusterPools, "unexpected number of ClusterPools")
+			if lastUsedAgo == nil {
+				assert.Nil(t, is.Status.LastUsedTime, "unexpected last used time")
+			} else if assert.NotNil(t, is.Status.LastUsedTime, "expected last used time") {
+				assert.InDelta(t, *lastUsedAgo, time.Since(is.Status.LastUsedTime.Time), float64(10*time.Second), "unexpected last used time")
+			}
+		}
+	}
+	durationPtr := func(d time.Duration) *time.Duration { return &d }
+
+	tests := []struct {
+		name                    string
+		existing                []runtime.Object
+		expectJob               bool
+		expectDaemonSet         bool
+		expectedPrePulledImages []string
+		expectedValid           corev1.ConditionStatus
+		expectedValidReason     string
+		expectedValidMessage    string
+		expectedPrePulled       corev1.ConditionStatus
+		expectedPrePulledReason string
+		expectedRequeueAfter    time.Duration
+		validate                func(t *testing.T, is *hivev1.ClusterImageSet)
+	}{
+		{
+			name:                "new image set",
+			existing:            []runtime.Object{imageSet()},
+			expectJob:           true,
+			expectedValid:       corev1.ConditionUnknown,
+			expectedValidReason: validatingReason,
+		},
+		{
+			name: "validation in progress",
+			existing: []runtime.Object{
+				imageSet(func(is *hivev1.ClusterImageSet) {
+					is.Status.Conditions = []hivev1.ClusterImageSetCondition{{
+						Type:   hivev1.ClusterImageSetValidCondition,
+						Status: corev1.ConditionUnknown,
+						Reason: validatingReason,
+					}}
+				}),
+				job(testReleaseImage, "", time.Time{}),
+			},
+			expectJob:           true,
+			expectedValid:       corev1.ConditionUnknown,
+			expectedValidReason: validatingReason,
+		},
+		{
+			name: "valid release image",
+			existing: []runtime.Object{
+				imageSet(),
+				job(testReleaseImage, batchv1.JobComplete, time.Now()),
+				pod(&imageset.ImageSetValidationResult{
+					Version:        testVersion,
+					Architecture:   "amd64",
+					InstallerImage: testInstallerImage,
+					CLIImage:       testCLIImage,
+				}, nil),
+			},
+			expectedValid:       corev1.ConditionTrue,
+			expectedValidReason: validReason,
+			validate: func(t *testing.T, is *hivev1.ClusterImageSet) {
+				assert.Equal(t, testReleaseImage, is.Status.ReleaseImage, "unexpected release image")
+				assert.Equal(t, testVersion, is.Status.Version, "unexpected version")
+				assert.Equal(t, "amd64", is.Status.Architecture, "unexpected architecture")
+				assert.Equal(t, testInstallerImage, is.Status.InstallerImage, "unexpected installer image")
+				assert.Equal(t, testCLIImage, is.Status.CLIImage, "unexpected cli image")
+			},
+		},
+		{
+			name: "invalid release image",
+			existing: []runtime.Object{
+				imageSet(),
+				job(testReleaseImage, batchv1.JobComplete, time.Now()),
+				pod(&imageset.ImageSetValidationResult{
+					Version: testVersion,
+					Error:   "release payload is missing the images cli",
+				}, nil),
+			},
+			expectedValid:        corev1.ConditionFalse,
+			expectedValidReason:  invalidReason,
+			expectedValidMessage: "release payload is missing the images cli",
+		},
+		{
+			name: "validation job failed",
+			existing: []runtime.Object{
+				imageSet(),
+				job(testReleaseImage, batchv1.JobFailed, time.Now()),
+				pod(nil, &corev1.ContainerStatus{
+					Name: "release",
+					State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{
+						Reason:  "ImagePullBackOff",
+						Message: "Back-off pulling image",
+					}},
+				}),
+			},
+			expectJob:            true,
+			expectedValid:        corev1.ConditionFalse,
+			expectedValidReason:  validationFailedReason,
+			expectedValidMessage: "The job " + hiveNS + "/" + jobName + " to validate the release image failed because of (TestReason) test message: container release is waiting because of (ImagePullBackOff) Back-off pulling image",
+			expectedRequeueAfter: validationRetryInterval,
+		},
+		{
+			name: "retry failed validation",
+			existing: []runtime.Object{
+				imageSet(func(is *hivev1.ClusterImageSet) {
+					is.Status.Conditions = []hivev1.ClusterImageSetCondition{{
+						Type:   hivev1.ClusterImageSetValidCondition,
+						Status: corev1.ConditionFalse,
+						Reason: validationFailedReason,
+					}}
+				}),
+				job(testReleaseImage, batchv1.JobFailed, time.Now().Add(-validationRetryInterval-time.Minute)),
+			},
+			expectedValid:       corev1.ConditionFalse,
+			expectedValidReason: validationFailedReason,
+		},
+		{
+			name: "stale validation job",
+			existing: []runtime.Object{
+				imageSet(),
+				job("registry.io/old-release-image:latest", "", time.Time{}),
+			},
+		},
+		{
+			name:                "validated image set",
+			existing:            []runtime.Object{imageSet(validated(corev1.ConditionTrue, validReason))},
+			expectedValid:       corev1.ConditionTrue,
+			expectedValidReason: validReason,
+		},
+		{
+			name: "release image changed",
+			existing: []runtime.Object{imageSet(validated(corev1.ConditionTrue, validReason), func(is *hivev1.ClusterImageSet) {
+				is.Spec.ReleaseImage = "registry.io/new-release-image:latest"
+			})},
+			expectJob:           true,
+			expectedValid:       corev1.ConditionUnknown,
+			expectedValidReason: validatingReason,
+		},
+		{
+			name:                    "pre-pull images",
+			existing:                []runtime.Object{imageSet(validated(corev1.ConditionTrue, validReason), prePull)},
+			expectDaemonSet:         true,
+			expectedValid:           corev1.ConditionTrue,
+			expectedValidReason:     validReason,
+			expectedPrePulled:       corev1.ConditionFalse,
+			expectedPrePulledReason: imagesPullingReason,
+		},
+		{
+			name: "images pre-pulled",
+			existing: []runtime.Object{
+				imageSet(validated(corev1.ConditionTrue, validReason), prePull),
+				daemonSet(3, 3),
+			},
+			expectDaemonSet:         true,
+			expectedValid:           corev1.ConditionTrue,
+			expectedValidReason:     validReason,
+			expectedPrePulled:       corev1.ConditionTrue,
+			expectedPrePulledReason: imagesPulledReason,
+		},
+		{
+			name: "images pulled onto some nodes",
+			existing: []runtime.Object{
+				imageSet(validated(corev1.ConditionTrue, validReason), prePull),
+				daemonSet(3, 1),
+			},
+			expectDaemonSet:         true,
+			expectedValid:           corev1.ConditionTrue,
+			expectedValidReason:     validReason,
+			expectedPrePulled:       corev1.ConditionFalse,
+			expectedPrePulledReason: imagesPullingReason,
+		},
+		{
+			name: "pre-pull images with other image set",
+			existing: []runtime.Object{
+				imageSet(validated(corev1.ConditionTrue, validReason), prePull),
+				otherImageSet(true),
+				daemonSet(3, 3, otherCLIImage, otherInstallerImage),
+			},
+			expectDaemonSet:         true,
+			expectedPrePulledImages: []string{otherCLIImage, otherInstallerImage, testCLIImage, testInstallerImage},
+			expectedValid:           corev1.ConditionTrue,
+			expectedValidReason:     validReason,
+			expectedPrePulled:       corev1.ConditionFalse,
+			expectedPrePulledReason: imagesPullingReason,
+		},
+		{
+			name: "images pre-pulled with other image set",
+			existing: []runtime.Object{
+				imageSet(validated(corev1.ConditionTrue, validReason), prePull),
+				otherImageSet(true),
+				daemonSet(3, 3, otherCLIImage, otherInstallerImage, testCLIImage, testInstallerImage),
+			},
+			expectDaemonSet:         true,
+			expectedPrePulledImages: []string{otherCLIImage, otherInstallerImage, testCLIImage, testInstallerImage},
+			expectedValid:           corev1.ConditionTrue,
+			expectedValidReason:     validReason,
+			expectedPrePulled:       corev1.ConditionTrue,
+			expectedPrePulledReason: imagesPulledReason,
+		},
+		{
+			name: "pre-pull of other image set kept",
+			existing: []runtime.Object{
+				imageSet(validated(corev1.ConditionTrue, validReason)),
+				otherImageSet(true),
+				daemonSet(3, 3, otherCLIImage, otherInstallerImage, testCLIImage, testInstallerImage),
+			},
+			expectDaemonSet:         true,
+			expectedPrePulledImages: []string{otherCLIImage, otherInstallerImage},
+			expectedValid:           corev1.ConditionTrue,
+			expectedValidReason:     validReason,
+		},
+		{
+			name:                "no pre-pull of invalid image set",
+			existing:            []runtime.Object{imageSet(validated(corev1.ConditionFalse, invalidReason), prePull)},
+			expectedValid:       corev1.ConditionFalse,
+			expectedValidReason: invalidReason,
+		},
+		{
+			name: "pre-pull turned off",
+			existing: []runtime.Object{
+				imageSet(validated(corev1.ConditionTrue, validReason), func(is *hivev1.ClusterImageSet) {
+					is.Status.Conditions = append(is.Status.Conditions, hivev1.ClusterImageSetCondition{
+						Type:   hivev1.ClusterImageSetImagesPrePulledCondition,
+						Status: corev1.ConditionTrue,
+						Reason: imagesPulledReason,
+					})
+				}),
+				daemonSet(3, 3),
+			},
+			expectedValid:           corev1.ConditionTrue,
+			expectedValidReason:     validReason,
+			expectedPrePulled:       corev1.ConditionFalse,
+			expectedPrePulledReason: prePullNotRequestedReason,
+		},
+		{
+			name:                "never used",
+			existing:            []runtime.Object{imageSet(validated(corev1.ConditionTrue, validReason))},
+			expectedValid:       corev1.ConditionTrue,
+			expectedValidReason: validReason,
+			validate:            assertUsage(0, 0, nil),
+		},
+		{
+			name: "in use",
+			existing: []runtime.Object{
+				imageSet(validated(corev1.ConditionTrue, validReason)),
+				clusterDeployment("cd1", testName),
+				clusterDeployment("cd2", testName),
+				clusterDeployment("cd3", "other-image-set"),
+				clusterPool("pool1", testName),
+				clusterPool("pool2", "other-image-set"),
+			},
+			expectedValid:        corev1.ConditionTrue,
+			expectedValidReason:  validReason,
+			expectedRequeueAfter: lastUsedRefreshInterval,
+			validate:             assertUsage(2, 1, durationPtr(0)),
+		},
+		{
+			name: "in use, recently used",
+			existing: []runtime.Object{
+				imageSet(validated(corev1.ConditionTrue, validReason), lastUsed(10*time.Minute, 0, 1)),
+				clusterPool("pool1", testName),
+			},
+			expectedValid:        corev1.ConditionTrue,
+			expectedValidReason:  validReason,
+			expectedRequeueAfter: lastUsedRefreshInterval - 10*time.Minute,
+			validate:             assertUsage(0, 1, durationPtr(10*time.Minute)),
+		},
+		{
+			name: "in use, last used time due for refresh",
+			existing: []runtime.Object{
+				imageSet(validated(corev1.ConditionTrue, validReason), lastUsed(2*time.Hour, 1, 0)),
+				clusterDeployment("cd1", testName),
+			},
+			expectedValid:        corev1.ConditionTrue,
+			expectedValidReason:  validReason,
+			expectedRequeueAfter: lastUsedRefreshInterval,
+			validate:             assertUsage(1, 0, durationPtr(0)),
+		},
+		{
+			name:                "no longer used",
+			existing:            []runtime.Object{imageSet(validated(corev1.ConditionTrue, validReason), lastUsed(30*time.Minute, 1, 1))},
+			expectedValid:       corev1.ConditionTrue,
+			expectedValidReason: validReason,
+			validate:            assertUsage(0, 0, durationPtr(0)),
+		},
+		{
+			name:                "unused since last used",
+			existing:            []runtime.Object{imageSet(validated(corev1.ConditionTrue, validReason), lastUsed(48*time.Hour, 0, 0))},
+			expectedValid:       corev1.ConditionTrue,
+			expectedValidReason: validReason,
+			validate:            assertUsage(0, 0, durationPtr(48*time.Hour)),
+		},
+		{
+			name: "in use while validating",
+			existing: []runtime.Object{
+				imageSet(),
+				clusterDeployment("cd1", testName),
+			},
+			expectJob:            true,
+			expectedValid:        corev1.ConditionUnknown,
+			expectedValidReason:  validatingReason,
+			expectedRequeueAfter: lastUsedRefreshInterval,
+			validate:             assertUsage(1, 0, durationPtr(0)),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := fake.NewFakeClientWithScheme(scheme, test.existing...)
+			rcis := &ReconcileClusterImageSet{
+				Client: c,
+				scheme: scheme,
+			}
+
+			result, err := rcis.Reconcile(reconcile.Request{NamespacedName: types.NamespacedName{Name: testName}})
+			require.NoError(t, err, "unexpected error from Reconcile")
+			if test.expectedRequeueAfter == 0 {
+				assert.Zero(t, result.RequeueAfter, "expected no requeue after")
+			} else {
+				assert.InDelta(t, test.expectedRequeueAfter, result.RequeueAfter, float64(10*time.Second), "unexpected requeue after")
+			}
+
+			is := &hivev1.ClusterImageSet{}
+			require.NoError(t, c.Get(context.Background(), types.NamespacedName{Name: testName}, is), "could not get ClusterImageSet")
+
+			validCond := controllerutils.FindClusterImageSetCondition(is.Status.Conditions, hivev1.ClusterImageSetValidCondition)
+			if test.expectedValid == "" {
+				assert.Nil(t, validCond, "unexpected Valid condition")
+			} else if assert.NotNil(t, validCond, "expected Valid condition") {
+				assert.Equal(t, test.expectedValid, validCond.Status, "unexpected Valid condition status")
+				assert.Equal(t, test.expectedValidReason, validCond.Reason, "unexpected Valid condition reason")
+				if test.expectedValidMessage != "" {
+					assert.Equal(t, test.expectedValidMessage, validCond.Message, "unexpected Valid condition message")
+				}
+			}
+
+			prePulledCond := controllerutils.FindClusterImageSetCondition(is.Status.Conditions, hivev1.ClusterImageSetImagesPrePulledCondition)
+			if test.expectedPrePulled == "" {
+				assert.Nil(t, prePulledCond, "unexpected ImagesPrePulled condition")
+			} else if assert.NotNil(t, prePulledCond, "expected ImagesPrePulled condition") {
+				assert.Equal(t, test.expectedPrePulled, prePulledCond.Status, "unexpected ImagesPrePulled condition status")
+				assert.Equal(t, test.expectedPrePulledReason, prePulledCond.Reason, "unexpected ImagesPrePulled condition reason")
+			}
+
+			job := &batchv1.Job{}
+			err = c.Get(context.Background(), types.NamespacedName{Namespace: hiveNS, Name: jobName}, job)
+			if test.expectJob {
+				if assert.NoError(t, err, "expected validation job") {
+					assert.Equal(t, is.Spec.ReleaseImage, job.Annotations[imageset.ImageSetReleaseImageAnnotation], "unexpected release image of job")
+				}
+			} else {
+				assert.True(t, apierrors.IsNotFound(err), "expected no validation job")
+			}
+
+			ds := &appsv1.DaemonSet{}
+			err = c.Get(context.Background(), types.NamespacedName{Namespace: hiveNS, Name: prePullDaemonSetName}, ds)
+			if test.expectDaemonSet {
+				expectedImages := test.expectedPrePulledImages
+				if expectedImages == nil {
+					expectedImages = []string{testCLIImage, testInstallerImage}
+				}
+				if assert.NoError(t, err, "expected pre-pull daemon set") {
+					assert.Equal(t, expectedImages, containerImages(ds.Spec.Template.Spec.InitContainers), "unexpected pre-pulled images")
+				}
+			} else {
+				assert.True(t, apierrors.IsNotFound(err), "expected no pre-pull daemon set")
+			}
+
+			if test.validate != nil {
+				test.validate(t, is)
+			}
+		})
+	}
+}
+
+func TestReconcileDeletedClusterImageSet(t *testing.T) {
+	scheme := runtime.NewScheme()
+	hivev1.AddToScheme(scheme)
+	appsv1.AddToScheme(scheme)
+
+	hiveNS := controllerutils.GetHiveNamespace()
+	ds := generatePrePullDaemonSet([]string{testCLIImage, testInstallerImage}, client.ObjectKey{Namespace: hiveNS, Name: prePullDaemonSetName})
+	c := fake.NewFakeClientWithScheme(scheme, ds)
+	rcis := &ReconcileClusterImageSet{
+		Client: c,
+		scheme: scheme,
+	}
+
+	_, err := rcis.Reconcile(reconcile.Request{NamespacedName: types.NamespacedName{Name: testName}})
+	require.NoError(t, err, "unexpected error from Reconcile")
+
+	err = c.Get(context.Background(), types.NamespacedName{Namespace: hiveNS, Name: prePullDaemonSetName}, &appsv1.DaemonSet{})
+	assert.True(t, apierrors.IsNotFound(err), "expected pre-pull daemon set to be deleted")
+}
+
+func TestRequestsForPrePullDaemonSet(t *testing.T) {
+	scheme := runtime.NewScheme()
+	hivev1.AddToScheme(scheme)
+	appsv1.AddToScheme(scheme)
+
+	imageSet := func(name string, prePullImages bool) *hivev1.ClusterImageSet {
+		return &hivev1.ClusterImageSet{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       hivev1.ClusterImageSetSpec{ReleaseImage: testReleaseImage, PrePullImages: prePullImages},
+		}
+	}
+	c := fake.NewFakeClientWithScheme(scheme, imageSet("a", true), imageSet("b", false), imageSet("c", true))
+	mapFn := requestsForPrePullDaemonSet(c)
+
+	ds := generatePrePullDaemonSet([]string{testCLIImage}, client.ObjectKey{Namespace: controllerutils.GetHiveNamespace(), Name: prePullDaemonSetName})
+	requests := mapFn(handler.MapObject{Meta: ds, Object: ds})
+	assert.ElementsMatch(t, []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Name: "a"}},
+		{NamespacedName: types.NamespacedName{Name: "c"}},
+	}, requests, "unexpected requests")
+
+	other := ds.DeepCopy()
+	other.Name = "other-daemon-set"
+	assert.Empty(t, mapFn(handler.MapObject{Meta: other, Object: other}), "expected no requests for other daemon sets")
+}
@@ -0,0 +1,248 @@
+package clusterimageset
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"reflect"
+
+	log "github.com/sirupsen/logrus"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/controller/images"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	// prePullDaemonSetName is the name of the daemon set pre-pulling the images of the ClusterImageSets which ask for
+	// it. A single daemon set pulls the images of all of the image sets, so that each node of the hub runs a single
+	// pre-pull pod however many image sets are pre-pulled.
+	prePullDaemonSetName = "hive-imageset-prepull"
+
+	// prePullLabel is the label selecting the pods of the pre-pull daemon set.
+	prePullLabel = "hive.openshift.io/imageset-prepull"
+
+	imagesPulledReason        = "ImagesPulled"
+	imagesPullingReason       = "ImagesPulling"
+	prePullNotRequestedReason = "NotRequested"
+	imageSetNotValidReason    = "ImageSetNotValid"
+)
+
+// requestsForPrePullDaemonSet returns a function enqueueing the image sets which pre-pull their images when the
+// pre-pull daemon set changes, so that their ImagesPrePulled conditions follow the progress of the daemon set.
+func requestsForPrePullDaemonSet(c client.Client) handler.ToRequestsFunc {
+	return func(o handler.MapObject) []reconcile.Request {
+		if o.Meta.GetNamespace() != controllerutils.GetHiveNamespace() || o.Meta.GetName() != prePullDaemonSetName {
+			return nil
+		}
+		imageSets := &hivev1.ClusterImageSetList{}
+		if err := c.List(context.Background(), imageSets); err != nil {
+			log.WithError(err).Error("cannot list cluster image sets for pre-pull daemon set")
+			return nil
+		}
+		var requests []reconcile.Request
+		for _, imageSet := range imageSets.Items {
+			if imageSet.Spec.PrePullImages {
+				requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: imageSet.Name}})
+			}
+		}
+		return requests
+	}
+}
+
+// isPrePulled returns whether the images of the image set are to be pulled onto the nodes of the hub, which is when
+// the image set asks for it and its current release image is valid.
+func isPrePulled(imageSet *hivev1.ClusterImageSet) bool {
+	if !imageSet.Spec.PrePullImages || imageSet.DeletionTimestamp != nil {
+		return false
+	}
+	validCond := controllerutils.FindClusterImageSetCondition(imageSet.Status.Conditions, hivev1.ClusterImageSetValidCondition)
+	return validCond != nil && validCond.Status == corev1.ConditionTrue && imageSet.Status.ReleaseImage == imageSet.Spec.ReleaseImage
+}
+
+// prePullImages returns the images of the image set pulled onto the nodes of the hub.
+func prePullImages(imageSet *hivev1.ClusterImageSet) []string {
+	return []string{imageSet.Status.InstallerImage, imageSet.Status.CLIImage}
+}
+
+// syncPrePullDaemonSet makes the pre-pull daemon set pull the images of all of the image sets which are pre-pulled,
+// and deletes it when no image set is pre-pulled. It returns the daemon set, or nil when there is none, and whether its
+// images were changed, in which case its status is not up to date yet.
+func (r *ReconcileClusterImageSet) syncPrePullDaemonSet(logger log.FieldLogger) (*appsv1.DaemonSet, bool, error) {
+	dsKey := client.ObjectKey{Namespace: controllerutils.GetHiveNamespace(), Name: prePullDaemonSetName}
+	dsLog := logger.WithField("daemonSet", dsKey.Name)
+
+	imageSets := &hivev1.ClusterImageSetList{}
+	if err := r.List(context.Background(), imageSets); err != nil {
+		dsLog.WithError(err).Error("cannot list cluster image sets")
+		return nil, false, err
+	}
+	images := sets.NewString()
+	for i := range imageSets.Items {
+		if isPrePulled(&imageSets.Items[i]) {
+			images.Insert(prePullImages(&imageSets.Items[i])...)
+		}
+	}
+
+	existing := &appsv1.DaemonSet{}
+	switch err := r.Get(context.Background(), dsKey, existing); {
+	case apierrors.IsNotFound(err):
+		existing = nil
+	case err != nil:
+		dsLog.WithError(err).Error("cannot get pre-pull daemon set")
+		return nil, false, err
+	}
+
+	if images.Len() == 0 {
+		if existing != nil && existing.DeletionTimestamp == nil {
+			dsLog.Info("deleting pre-pull daemon set")
+			if err := r.Delete(context.Background(), existing); err != nil && !apierrors.IsNotFound(err) {
+				dsLog.WithError(err).Log(controllerutils.LogLevel(err), "cannot delete pre-pull daemon set")
+				return nil, false, err
+			}
+		}
+		return nil, false, nil
+	}
+
+	ds := generatePrePullDaemonSet(images.List(), dsKey)
+	switch {
+	case existing == nil:
+		dsLog.Info("creating pre-pull daemon set")
+		if err := r.Create(context.Background(), ds); err != nil {
+			dsLog.WithError(err).Log(controllerutils.LogLevel(err), "error creating pre-pull daemon set")
+			return nil, false, err
+		}
+		return ds, true, nil
+	case !reflect.DeepEqual(containerImages(existing.Spec.Template.Spec.InitContainers), containerImages(ds.Spec.Template.Spec.InitContainers)):
+		dsLog.WithField("images", images.Len()).Info("updating images of pre-pull daemon set")
+		existing.Spec.Template = ds.Spec.Template
+		if err := r.Update(context.Background(), existing); err != nil {
+			dsLog.WithError(err).Log(controllerutils.LogLevel(err), "error updating pre-pull daemon set")
+			return nil, false, err
+		}
+		return existing, true, nil
+	}
+	return existing, false, nil
+}
+
+// reconcilePrePull pulls the installer and cli images of a valid image set onto the nodes of the hub with the pre-pull
+// daemon set when the image set asks for it, and reports in the ImagesPrePulled condition whether the images have
+// been pulled onto all of the nodes. The images are kept on the nodes by the pods of the daemon set, so that they are
+// not garbage collected while the image set is in use.
+func (r *ReconcileClusterImageSet) reconcilePrePull(imageSet *hivev1.ClusterImageSet, logger log.FieldLogger) error {
+	ds, updated, err := r.syncPrePullDaemonSet(logger)
+	if err != nil {
+		return err
+	}
+	dsLog := logger.WithField("daemonSet", prePullDaemonSetName)
+
+	if !isPrePulled(imageSet) {
+		if controllerutils.FindClusterImageSetCondition(imageSet.Status.Conditions, hivev1.ClusterImageSetImagesPrePulledCondition) == nil {
+			return nil
+		}
+		reason, message := prePullNotRequestedReason, "Images of the image set are not pre-pulled"
+		if imageSet.Spec.PrePullImages {
+			reason, message = imageSetNotValidReason, "Images are pre-pulled once the image set has been validated"
+		}
+		return r.setImagesPrePulledCondition(imageSet, corev1.ConditionFalse, reason, message, dsLog)
+	}
+
+	// The daemon set may not pull the images of the image set yet when the cache of image sets is behind.
+	var status appsv1.DaemonSetStatus
+	pulled := false
+	if ds != nil && !updated {
+		status = ds.Status
+		pulled = sets.NewString(containerImages(ds.Spec.Template.Spec.InitContainers)...).HasAll(prePullImages(imageSet)...) &&
+			ds.Generation == status.ObservedGeneration && status.DesiredNumberScheduled > 0 &&
+			status.UpdatedNumberScheduled == status.DesiredNumberScheduled && status.NumberReady == status.DesiredNumberScheduled
+	}
+	if pulled {
+		return r.setImagesPrePulledCondition(imageSet, corev1.ConditionTrue, imagesPulledReason,
+			fmt.Sprintf("Images have been pulled onto all %d nodes", status.DesiredNumberScheduled), dsLog)
+	}
+	return r.setImagesPrePulledCondition(imageSet, corev1.ConditionFalse, imagesPullingReason,
+		fmt.Sprintf("Images have been pulled onto %d of %d nodes", status.NumberReady, status.DesiredNumberScheduled), dsLog)
+}
+
+// generatePrePullDaemonSet returns a daemon set whose pods pull the images in init containers, then idle.
+func generatePrePullDaemonSet(pullImages []string, key client.ObjectKey) *appsv1.DaemonSet {
+	labels := map[string]string{prePullLabel: key.Name}
+	resources := corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("1m"),
+			corev1.ResourceMemory: resource.MustParse("10Mi"),
+		},
+	}
+	podSpec := corev1.PodSpec{
+		Containers: []corev1.Container{
+			{
+				Name:            "sleep",
+				Image:           images.GetHiveImage(),
+				ImagePullPolicy: images.GetHiveImagePullPolicy(),
+				Command:         []string{"/bin/sh", "-c", "sleep infinity"},
+				Resources:       resources,
+			},
+		},
+	}
+	for i, image := range pullImages {
+		podSpec.InitContainers = append(podSpec.InitContainers, corev1.Container{
+			Name:            fmt.Sprintf("pull-%d", i),
+			Image:           image,
+			ImagePullPolicy: corev1.PullIfNotPresent,
+			Command:         []string{"/bin/sh", "-c", "exit 0"},
+			Resources:       resources,
+		})
+	}
+	if pullSecretName := os.Getenv(constants.GlobalPullSecret); pullSecretName != "" {
+		podSpec.ImagePullSecrets = []corev1.LocalObjectReference{{Name: pullSecretName}}
+	}
+	return &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      key.Name,
+			Namespace: key.Namespace,
+			Labels:    labels,
+		},
+		Spec: appsv1.DaemonSetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec:       podSpec,
+			},
+		},
+	}
+}
+
+func containerImages(containers []corev1.Container) []string {
+	images := make([]string, len(containers))
+	for i, c := range containers {
+		images[i] = c.Image
+	}
+	return images
+}
+
+func (r *ReconcileClusterImageSet) setImagesPrePulledCondition(imageSet *hivev1.ClusterImageSet, status corev1.ConditionStatus, reason, message string, logger log.FieldLogger) error {
+	conds, changed := controllerutils.SetClusterImageSetConditionWithChangeCheck(
+		imageSet.Status.Conditions,
+		hivev1.ClusterImageSetImagesPrePulledCondition,
+		status,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	if !changed {
+		return nil
+	}
+	imageSet.Status.Conditions = conds
+	return r.updateStatus(imageSet, logger)
+}
@@ -0,0 +1,74 @@
+package clusterimageset
+
+import (
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+// lastUsedRefreshInterval is how often the last used time of an image set in use is refreshed.
+const lastUsedRefreshInterval = time.Hour
+
+func requestsForClusterDeployment(o handler.MapObject) []reconcile.Request {
+	cd, ok := o.Object.(*hivev1.ClusterDeployment)
+	if !ok || cd.Spec.Provisioning == nil || cd.Spec.Provisioning.ImageSetRef == nil {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: cd.Spec.Provisioning.ImageSetRef.Name}}}
+}
+
+func requestsForClusterPool(o handler.MapObject) []reconcile.Request {
+	pool, ok := o.Object.(*hivev1.ClusterPool)
+	if !ok || pool.Spec.ImageSetRef.Name == "" {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: pool.Spec.ImageSetRef.Name}}}
+}
+
+// reconcileUsage records in the status of the image set how many ClusterDeployments and ClusterPools reference it,
+// and when it was last used. The last used time is refreshed periodically while the image set is in use, and set
+// one last time when it stops being used.
+func (r *ReconcileClusterImageSet) reconcileUsage(imageSet *hivev1.ClusterImageSet, logger log.FieldLogger) (reconcile.Result, error) {
+	cds, pools, err := controllerutils.IndexedClusterImageSetUsers(r, imageSet.Name)
+	if err != nil {
+		logger.WithError(err).Error("error listing users of cluster image set")
+		return reconcile.Result{}, err
+	}
+	deployments, clusterPools := int32(len(cds)), int32(len(pools))
+	inUse := deployments+clusterPools > 0
+	wasInUse := imageSet.Status.ClusterDeployments+imageSet.Status.ClusterPools > 0
+
+	now := metav1.Now()
+	lastUsed := imageSet.Status.LastUsedTime
+	switch {
+	case inUse && (lastUsed == nil || now.Sub(lastUsed.Time) >= lastUsedRefreshInterval):
+		lastUsed = &now
+	case !inUse && wasInUse:
+		lastUsed = &now
+	}
+
+	var result reconcile.Result
+	if inUse {
+		result.RequeueAfter = lastUsed.Add(lastUsedRefreshInterval).Sub(now.Time)
+	}
+	if deployments == imageSet.Status.ClusterDeployments && clusterPools == imageSet.Status.ClusterPools &&
+		lastUsed == imageSet.Status.LastUsedTime {
+		return result, nil
+	}
+
+	logger.WithFields(log.Fields{
+		"clusterDeployments": deployments,
+		"clusterPools":       clusterPools,
+	}).Debug("updating usage of cluster image set")
+	imageSet.Status.ClusterDeployments = deployments
+	imageSet.Status.ClusterPools = clusterPools
+	imageSet.Status.LastUsedTime = lastUsed
+	return result, r.updateStatus(imageSet, logger)
+}
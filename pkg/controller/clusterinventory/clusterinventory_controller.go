@@ -0,0 +1,273 @@
+package clusterinventory
+
+import (
+	"context"
+	"reflect"
+	"strconv"
+
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	configv1 "github.com/openshift/api/config/v1"
+	installertypes "github.com/openshift/installer/pkg/types"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	ControllerName = hivev1.ClusterInventoryControllerName
+
+	installConfigSecretKey = "install-config.yaml"
+)
+
+// Add creates a new ClusterInventory controller and adds it to the manager with default RBAC.
+func Add(mgr manager.Manager) error {
+	logger := log.WithField("controller", ControllerName)
+	concurrentReconciles, clientRateLimiter, queueRateLimiter, err := controllerutils.GetControllerConfig(mgr.GetClient(), ControllerName)
+	if err != nil {
+		logger.WithError(err).Error("could not get controller configurations")
+		return err
+	}
+	return AddToManager(mgr, NewReconciler(mgr, clientRateLimiter), concurrentReconciles, queueRateLimiter)
+}
+
+// NewReconciler returns a new reconcile.Reconciler
+func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) reconcile.Reconciler {
+	return &ReconcileClusterInventory{
+		Client: controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
+		scheme: mgr.GetScheme(),
+	}
+}
+
+// AddToManager adds a new Controller to mgr with r as the reconcile.Reconciler
+func AddToManager(mgr manager.Manager, r reconcile.Reconciler, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
+	c, err := controller.New("clusterinventory-controller", mgr, controller.Options{
+		Reconciler:              r,
+		MaxConcurrentReconciles: concurrentReconciles,
+		RateLimiter:             rateLimiter,
+	})
+	if err != nil {
+		log.WithField("controller", ControllerName).WithError(err).Error("Error creating new clusterinventory controller")
+		return err
+	}
+
+	// Watch for changes to ClusterDeployment
+	if err := c.Watch(&source.Kind{Type: &hivev1.ClusterDeployment{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		log.WithField("controller", ControllerName).WithError(err).Error("Error watching cluster deployment")
+		return err
+	}
+
+	// Watch for changes to ClusterState, which shares the name of its ClusterDeployment
+	if err := c.Watch(&source.Kind{Type: &hivev1.ClusterState{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		log.WithField("controller", ControllerName).WithError(err).Error("Error watching cluster state")
+		return err
+	}
+
+	// Watch for changes to the ClusterInventory owned by the ClusterDeployment
+	if err := c.Watch(&source.Kind{Type: &hivev1.ClusterInventory{}}, &handler.EnqueueRequestForOwner{
+		IsController: true,
+		OwnerType:    &hivev1.ClusterDeployment{},
+	}); err != nil {
+		log.WithField("controller", ControllerName).WithError(err).Error("Error watching cluster inventory")
+		return err
+	}
+	return nil
+}
+
+var _ reconcile.Reconciler = &ReconcileClusterInventory{}
+
+// ReconcileClusterInventory is the reconciler for ClusterInventory. It will sync on ClusterDeployment resources
+// and ensure that a ClusterInventory exists which summarizes the version and capabilities of the cluster.
+type ReconcileClusterInventory struct {
+	client.Client
+	scheme *runtime.Scheme
+}
+
+// Reconcile ensures that a given ClusterInventory resource exists and reflects its ClusterDeployment,
+// install config and ClusterState.
+func (r *ReconcileClusterInventory) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	logger := controllerutils.BuildControllerLogger(ControllerName, "clusterDeployment", request.NamespacedName)
+	logger.Info("reconciling cluster deployment")
+	recobsrv := hivemetrics.NewReconcileObserver(ControllerName, logger)
+	defer recobsrv.ObserveControllerReconcileTime()
+
+	// Fetch the ClusterDeployment instance
+	cd := &hivev1.ClusterDeployment{}
+	err := r.Get(context.TODO(), request.NamespacedName, cd)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			// Object not found, return. The ClusterInventory is garbage collected with its ClusterDeployment.
+			logger.Debug("cluster deployment not found")
+			return reconcile.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		logger.WithError(err).Error("Error getting cluster deployment")
+		return reconcile.Result{}, err
+	}
+
+	if !cd.DeletionTimestamp.IsZero() {
+		logger.Debug("ClusterDeployment resource has been deleted")
+		return reconcile.Result{}, nil
+	}
+	if !cd.Spec.Installed {
+		logger.Debug("ClusterDeployment is not yet installed")
+		return reconcile.Result{}, nil
+	}
+
+	status, err := r.buildInventoryStatus(cd, logger)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	labels := inventoryLabels(cd, status)
+
+	inv := &hivev1.ClusterInventory{}
+	switch err = r.Get(context.TODO(), request.NamespacedName, inv); {
+	case apierrors.IsNotFound(err):
+		logger.Info("Creating cluster inventory resource for cluster deployment")
+		inv.Name = cd.Name
+		inv.Namespace = cd.Namespace
+		inv.Labels = labels
+		if err := controllerutil.SetControllerReference(cd, inv, r.scheme); err != nil {
+			logger.WithError(err).Error("error setting controller reference on cluster inventory")
+			return reconcile.Result{}, err
+		}
+		if err := r.Create(context.TODO(), inv); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to create cluster inventory")
+			return reconcile.Result{}, err
+		}
+	case err != nil:
+		logger.WithError(err).Error("Error getting cluster inventory")
+		return reconcile.Result{}, err
+	case !reflect.DeepEqual(inv.Labels, labels):
+		logger.Info("Updating cluster inventory labels")
+		inv.Labels = labels
+		if err := r.Update(context.TODO(), inv); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to update cluster inventory labels")
+			return reconcile.Result{}, err
+		}
+	}
+
+	status.LastUpdated = inv.Status.LastUpdated
+	if reflect.DeepEqual(inv.Status, *status) {
+		logger.Debug("cluster inventory is up to date")
+		return reconcile.Result{}, nil
+	}
+	now := metav1.Now()
+	status.LastUpdated = &now
+	inv.Status = *status
+	if err := r.Status().Update(context.TODO(), inv); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to update cluster inventory status")
+		return reconcile.Result{}, err
+	}
+	logger.Info("cluster inventory has been updated")
+	return reconcile.Result{}, nil
+}
+
+// buildInventoryStatus collects the inventory of the cluster from the ClusterDeployment, its install config and
+// its ClusterState.
+func (r *ReconcileClusterInventory) buildInventoryStatus(cd *hivev1.ClusterDeployment, logger log.FieldLogger) (*hivev1.ClusterInventoryStatus, error) {
+	status := &hivev1.ClusterInventoryStatus{
+		Version:  cd.Labels[constants.VersionMajorMinorPatchLabel],
+		Platform: cd.Labels[hivev1.HiveClusterPlatformLabel],
+		Region:   cd.Labels[hivev1.HiveClusterRegionLabel],
+	}
+	if status.Version == "" && cd.Status.InstallVersion != nil {
+		status.Version = *cd.Status.InstallVersion
+	}
+
+	installConfig, err := r.getInstallConfig(cd, logger)
+	if err != nil {
+		return nil, err
+	}
+	if installConfig != nil {
+		status.FIPS = installConfig.FIPS
+		if installConfig.Networking != nil {
+			status.NetworkType = installConfig.Networking.NetworkType
+		}
+	}
+
+	st := &hivev1.ClusterState{}
+	switch err := r.Get(context.TODO(), types.NamespacedName{Namespace: cd.Namespace, Name: cd.Name}, st); {
+	case apierrors.IsNotFound(err):
+		logger.Debug("cluster state not found, operators will not be included in the inventory")
+	case err != nil:
+		logger.WithError(err).Error("Error getting cluster state")
+		return nil, err
+	default:
+		for _, operator := range st.Status.ClusterOperators {
+			status.Operators = append(status.Operators, hivev1.ClusterInventoryOperator{
+				Name:      operator.Name,
+				Available: operatorConditionTrue(operator, configv1.OperatorAvailable),
+				Degraded:  operatorConditionTrue(operator, configv1.OperatorDegraded),
+			})
+		}
+	}
+	return status, nil
+}
+
+// getInstallConfig returns the install config the cluster was installed with, or nil if it is not available, as is
+// the case for adopted clusters.
+func (r *ReconcileClusterInventory) getInstallConfig(cd *hivev1.ClusterDeployment, logger log.FieldLogger) (*installertypes.InstallConfig, error) {
+	if cd.Spec.Provisioning == nil || cd.Spec.Provisioning.InstallConfigSecretRef == nil {
+		return nil, nil
+	}
+	secret := &corev1.Secret{}
+	err := r.Get(context.TODO(), types.NamespacedName{Namespace: cd.Namespace, Name: cd.Spec.Provisioning.InstallConfigSecretRef.Name}, secret)
+	if apierrors.IsNotFound(err) {
+		logger.Debug("install-config secret not found, network type and FIPS mode will not be included in the inventory")
+		return nil, nil
+	}
+	if err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "error reading install-config secret")
+		return nil, err
+	}
+	installConfig := &installertypes.InstallConfig{}
+	if err := yaml.Unmarshal(secret.Data[installConfigSecretKey], installConfig); err != nil {
+		logger.WithError(err).Warn("could not parse install-config, network type and FIPS mode will not be included in the inventory")
+		return nil, nil
+	}
+	return installConfig, nil
+}
+
+// inventoryLabels returns the labels of the ClusterInventory: those of its ClusterDeployment, along with labels
+// for the inventoried capabilities, so that clusters can be selected on either.
+func inventoryLabels(cd *hivev1.ClusterDeployment, status *hivev1.ClusterInventoryStatus) map[string]string {
+	labels := make(map[string]string, len(cd.Labels)+3)
+	for k, v := range cd.Labels {
+		labels[k] = v
+	}
+	labels[constants.ClusterDeploymentNameLabel] = cd.Name
+	if status.NetworkType != "" {
+		labels[hivev1.HiveClusterNetworkTypeLabel] = status.NetworkType
+	}
+	labels[hivev1.HiveClusterFIPSLabel] = strconv.FormatBool(status.FIPS)
+	return labels
+}
+
+func operatorConditionTrue(operator hivev1.ClusterOperatorState, conditionType configv1.ClusterStatusConditionType) bool {
+	for _, c := range operator.Conditions {
+		if c.Type == conditionType {
+			return c.Status == configv1.ConditionTrue
+		}
+	}
+	return false
+}
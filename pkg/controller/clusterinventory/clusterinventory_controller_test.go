@@ -0,0 +1,255 @@
+package clusterinventory
+
+import (
+	"context"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/openshift/hive/apis"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+const (
+	testName                  = "cluster1"
+	testNamespace             = "cluster1namespace"
+	testInstallConfigSecret   = "install-config"
+	testInstallConfigWithFIPS = `
+apiVersion: v1
+metadata:
+  name: cluster1
+baseDomain: example.com
+fips: true
+networking:
+  networkType: OVNKubernetes
+`
+)
+
+func TestClusterInventoryReconcile(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+	log.SetLevel(log.DebugLevel)
+
+	tests := []struct {
+		name             string
+		existing         []runtime.Object
+		expectNoInv      bool
+		expectedStatus   hivev1.ClusterInventoryStatus
+		expectedLabels   map[string]string
+		unexpectedLabels []string
+	}{
+		{
+			name:        "cluster not installed",
+			existing:    []runtime.Object{testClusterDeployment(false)},
+			expectNoInv: true,
+		},
+		{
+			name: "create inventory",
+			existing: []runtime.Object{
+				testClusterDeployment(true),
+				testInstallConfig(testInstallConfigWithFIPS),
+				testClusterState(),
+			},
+			expectedStatus: hivev1.ClusterInventoryStatus{
+				Version:     "4.12.3",
+				Platform:    "aws",
+				Region:      "us-east-1",
+				NetworkType: "OVNKubernetes",
+				FIPS:        true,
+				Operators: []hivev1.ClusterInventoryOperator{
+					{Name: "console", Available: true},
+					{Name: "ingress", Available: true, Degraded: true},
+				},
+			},
+			expectedLabels: map[string]string{
+				constants.VersionMajorMinorLabel:     "4.12",
+				hivev1.HiveClusterPlatformLabel:      "aws",
+				hivev1.HiveClusterNetworkTypeLabel:   "OVNKubernetes",
+				hivev1.HiveClusterFIPSLabel:          "true",
+				constants.ClusterDeploymentNameLabel: testName,
+				"team":                               "a",
+			},
+		},
+		{
+			name: "no install config or cluster state",
+			existing: []runtime.Object{
+				testClusterDeployment(true),
+			},
+			expectedStatus: hivev1.ClusterInventoryStatus{
+				Version:  "4.12.3",
+				Platform: "aws",
+				Region:   "us-east-1",
+			},
+			expectedLabels: map[string]string{
+				hivev1.HiveClusterFIPSLabel: "false",
+			},
+			unexpectedLabels: []string{hivev1.HiveClusterNetworkTypeLabel},
+		},
+		{
+			name: "version falls back to install version",
+			existing: []runtime.Object{
+				func() *hivev1.ClusterDeployment {
+					cd := testClusterDeployment(true)
+					delete(cd.Labels, constants.VersionMajorMinorPatchLabel)
+					version := "4.11.0"
+					cd.Status.InstallVersion = &version
+					return cd
+				}(),
+			},
+			expectedStatus: hivev1.ClusterInventoryStatus{
+				Version:  "4.11.0",
+				Platform: "aws",
+				Region:   "us-east-1",
+			},
+		},
+		{
+			name: "update existing inventory",
+			existing: []runtime.Object{
+				testClusterDeployment(true),
+				testInstallConfig(testInstallConfigWithFIPS),
+				&hivev1.ClusterInventory{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      testName,
+						Namespace: testNamespace,
+						Labels: map[string]string{
+							constants.VersionMajorMinorLabel: "4.11",
+							"stale":                          "label",
+						},
+					},
+					Status: hivev1.ClusterInventoryStatus{Version: "4.11.2"},
+				},
+			},
+			expectedStatus: hivev1.ClusterInventoryStatus{
+				Version:     "4.12.3",
+				Platform:    "aws",
+				Region:      "us-east-1",
+				NetworkType: "OVNKubernetes",
+				FIPS:        true,
+			},
+			expectedLabels: map[string]string{
+				constants.VersionMajorMinorLabel: "4.12",
+			},
+			unexpectedLabels: []string{"stale"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := fake.NewFakeClientWithScheme(scheme.Scheme, test.existing...)
+			r := &ReconcileClusterInventory{
+				Client: c,
+				scheme: scheme.Scheme,
+			}
+			_, err := r.Reconcile(reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: testName},
+			})
+			require.NoError(t, err, "unexpected error from reconcile")
+
+			inv := getClusterInventory(t, c)
+			if test.expectNoInv {
+				assert.Nil(t, inv, "expected no cluster inventory")
+				return
+			}
+			require.NotNil(t, inv, "expected cluster inventory")
+			assert.NotNil(t, inv.Status.LastUpdated, "expected last updated time")
+			inv.Status.LastUpdated = nil
+			assert.Equal(t, test.expectedStatus, inv.Status, "unexpected inventory status")
+			for k, v := range test.expectedLabels {
+				assert.Equal(t, v, inv.Labels[k], "unexpected value for label %s", k)
+			}
+			for _, k := range test.unexpectedLabels {
+				assert.NotContains(t, inv.Labels, k, "unexpected label")
+			}
+		})
+	}
+}
+
+func getClusterInventory(t *testing.T, c client.Client) *hivev1.ClusterInventory {
+	inv := &hivev1.ClusterInventory{}
+	err := c.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: testName}, inv)
+	switch {
+	case errors.IsNotFound(err):
+		return nil
+	case err != nil:
+		t.Fatalf("unexpected: %v", err)
+	}
+	return inv
+}
+
+func testClusterDeployment(installed bool) *hivev1.ClusterDeployment {
+	return &hivev1.ClusterDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testName,
+			Namespace: testNamespace,
+			UID:       "1234",
+			Labels: map[string]string{
+				constants.VersionMajorLabel:           "4",
+				constants.VersionMajorMinorLabel:      "4.12",
+				constants.VersionMajorMinorPatchLabel: "4.12.3",
+				hivev1.HiveClusterPlatformLabel:       "aws",
+				hivev1.HiveClusterRegionLabel:         "us-east-1",
+				"team":                                "a",
+			},
+		},
+		Spec: hivev1.ClusterDeploymentSpec{
+			ClusterName: testName,
+			Installed:   installed,
+			Provisioning: &hivev1.Provisioning{
+				InstallConfigSecretRef: &corev1.LocalObjectReference{Name: testInstallConfigSecret},
+			},
+		},
+	}
+}
+
+func testInstallConfig(installConfig string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testInstallConfigSecret,
+			Namespace: testNamespace,
+		},
+		Data: map[string][]byte{
+			installConfigSecretKey: []byte(installConfig),
+		},
+	}
+}
+
+func testClusterState() *hivev1.ClusterState {
+	return &hivev1.ClusterState{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testName,
+			Namespace: testNamespace,
+		},
+		Status: hivev1.ClusterStateStatus{
+			ClusterOperators: []hivev1.ClusterOperatorState{
+				{
+					Name: "console",
+					Conditions: []configv1.ClusterOperatorStatusCondition{
+						{Type: configv1.OperatorAvailable, Status: configv1.ConditionTrue},
+						{Type: configv1.OperatorDegraded, Status: configv1.ConditionFalse},
+					},
+				},
+				{
+					Name: "ingress",
+					Conditions: []configv1.ClusterOperatorStatusCondition{
+						{Type: configv1.OperatorAvailable, Status: configv1.ConditionTrue},
+						{Type: configv1.OperatorDegraded, Status: configv1.ConditionTrue},
+					},
+				},
+			},
+		},
+	}
+}
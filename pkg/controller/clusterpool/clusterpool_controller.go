@@ -4,10 +4,14 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"os"
 	"reflect"
 	"sort"
+	"strconv"
+	"time"
 
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
 	log "github.com/sirupsen/logrus"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 
@@ -17,12 +21,14 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	utilrand "k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/util/flowcontrol"
 	"k8s.io/client-go/util/workqueue"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
@@ -43,13 +49,43 @@ const (
 	clusterPoolAdminRoleName        = "hive-cluster-pool-admin"
 	clusterPoolAdminRoleBindingName = "hive-cluster-pool-admin-binding"
 	icSecretDependent               = "install config template secret"
+
+	// defaultReservationLeadTime is how long before the activation time of a reserved claim a cluster is held for it,
+	// when the pool does not set a lead time.
+	defaultReservationLeadTime = time.Hour
+
+	// reservationResumeLeadTime is how long before the activation time of a reserved claim the cluster held for it is
+	// resumed from hibernation, so that it is running when it is assigned to the claim.
+	reservationResumeLeadTime = 15 * time.Minute
 )
 
 var (
 	// controllerKind contains the schema.GroupVersionKind for this controller type.
 	controllerKind = hivev1.SchemeGroupVersion.WithKind("ClusterPool")
+
+	metricClaimAssignmentDelaySeconds = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "hive_clusterclaim_assignment_delay_seconds",
+			Help:    "Time between the creation, or the activation time when reserved, of a ClusterClaim and the assignment of a cluster from its pool.",
+			Buckets: []float64{10, 30, 60, 300, 600, 1200, 1800, 3600, 7200},
+		},
+		[]string{"clusterpool_namespace", "clusterpool_name"},
+	)
+
+	metricStaleClustersRotated = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "hive_clusterpool_stale_clusters_rotated_total",
+			Help: "Counter incremented every time an unclaimed cluster older than the max cluster age of its pool is deleted to be replaced.",
+		},
+		[]string{"clusterpool_namespace", "clusterpool_name"},
+	)
 )
 
+func init() {
+	metrics.Registry.MustRegister(metricClaimAssignmentDelaySeconds)
+	metrics.Registry.MustRegister(metricStaleClustersRotated)
+}
+
 // Add creates a new ClusterPool Controller and adds it to the Manager with default RBAC. The Manager will set fields on the Controller
 // and Start it when the Manager is Started.
 func Add(mgr manager.Manager) error {
@@ -66,12 +102,29 @@ func Add(mgr manager.Manager) error {
 func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) *ReconcileClusterPool {
 	logger := log.WithField("controller", ControllerName)
 	return &ReconcileClusterPool{
-		Client:       controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
-		logger:       logger,
-		expectations: controllerutils.NewExpectations(logger),
+		Client:               controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
+		logger:               logger,
+		expectations:         controllerutils.NewExpectations(logger),
+		defaultMaxConcurrent: defaultMaxConcurrentFromEnv(logger),
 	}
 }
 
+// defaultMaxConcurrentFromEnv returns the maximum number of clusters provisioned or deprovisioned at a time for the
+// pools that do not set their own, as configured in the HiveConfig, or nil when there is no limit.
+func defaultMaxConcurrentFromEnv(logger log.FieldLogger) *int32 {
+	value := os.Getenv(constants.ClusterPoolMaxConcurrentEnvVar)
+	if value == "" {
+		return nil
+	}
+	maxConcurrent, err := strconv.ParseInt(value, 10, 32)
+	if err != nil || maxConcurrent < 1 {
+		logger.WithError(err).WithField("maxConcurrent", value).Warn("invalid default max concurrent for cluster pools, not limiting")
+		return nil
+	}
+	m := int32(maxConcurrent)
+	return &m
+}
+
 // AddToManager adds a new Controller to mgr with r as the reconcile.Reconciler
 func AddToManager(mgr manager.Manager, r *ReconcileClusterPool, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
 	// Create a new controller
@@ -116,6 +169,16 @@ func AddToManager(mgr manager.Manager, r *ReconcileClusterPool, concurrentReconc
 		return err
 	}
 
+	// Watch for changes to the ClusterDeploymentCustomizations of the inventories
+	if err := c.Watch(
+		&source.Kind{Type: &hivev1.ClusterDeploymentCustomization{}},
+		&handler.EnqueueRequestsFromMapFunc{
+			ToRequests: requestsForInventory(r.Client, r.logger),
+		},
+	); err != nil {
+		return err
+	}
+
 	// Watch for changes to the hive cluster pool admin RoleBindings
 	if err := c.Watch(
 		&source.Kind{Type: &rbacv1.RoleBinding{}},
@@ -194,6 +257,8 @@ type ReconcileClusterPool struct {
 	logger log.FieldLogger
 	// A TTLCache of ClusterDeployment creates each ClusterPool expects to see
 	expectations controllerutils.ExpectationsInterface
+	// defaultMaxConcurrent is the MaxConcurrent of the pools that do not set it. Nil means no limit.
+	defaultMaxConcurrent *int32
 }
 
 // Reconcile reads the state of the ClusterPool, checks if we currently have enough ClusterDeployments waiting, and
@@ -257,29 +322,45 @@ func (r *ReconcileClusterPool) Reconcile(request reconcile.Request) (reconcile.R
 	}
 
 	var installingCDs []*hivev1.ClusterDeployment
+	// gatedCDs are installed clusters waiting for their readiness gates, which cannot be claimed yet.
+	var gatedCDs []*hivev1.ClusterDeployment
 	var readyCDs []*hivev1.ClusterDeployment
+	// rebaseliningCDs are released clusters returning to the pool once their rebaseline SyncSets are applied.
+	var rebaseliningCDs []*hivev1.ClusterDeployment
 	numberOfDeletingCDs := 0
 	for _, cd := range unClaminedCDs {
 		switch {
 		case cd.DeletionTimestamp != nil:
 			numberOfDeletingCDs++
+		case isRebaselining(cd):
+			rebaseliningCDs = append(rebaseliningCDs, cd)
 		case !cd.Spec.Installed:
 			installingCDs = append(installingCDs, cd)
+		case !controllerutils.IsClusterDeploymentReady(cd):
+			gatedCDs = append(gatedCDs, cd)
 		default:
 			readyCDs = append(readyCDs, cd)
 		}
 	}
 
 	logger.WithFields(log.Fields{
-		"installing": len(installingCDs),
-		"deleting":   numberOfDeletingCDs,
-		"total":      len(unClaminedCDs),
-		"ready":      len(readyCDs),
+		"installing":   len(installingCDs),
+		"gated":        len(gatedCDs),
+		"rebaselining": len(rebaseliningCDs),
+		"deleting":     numberOfDeletingCDs,
+		"total":        len(unClaminedCDs),
+		"ready":        len(readyCDs),
 	}).Debug("found clusters for ClusterPool")
 
 	origStatus := clp.Status.DeepCopy()
-	clp.Status.Size = int32(len(installingCDs) + len(readyCDs))
+	clp.Status.Size = int32(len(installingCDs) + len(gatedCDs) + len(rebaseliningCDs) + len(readyCDs))
 	clp.Status.Ready = int32(len(readyCDs))
+	clp.Status.Running = 0
+	for _, cd := range readyCDs {
+		if isAwake(cd) {
+			clp.Status.Running++
+		}
+	}
 	if !reflect.DeepEqual(origStatus, &clp.Status) {
 		if err := r.Status().Update(context.Background(), clp); err != nil {
 			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update ClusterPool status")
@@ -302,24 +383,68 @@ func (r *ReconcileClusterPool) Reconcile(request reconcile.Request) (reconcile.R
 		logger.WithError(err).Error("error setting CapacityAvailable condition")
 		return reconcile.Result{}, err
 	}
+	if err := r.setActiveCondition(clp, len(unClaminedCDs), logger); err != nil {
+		logger.WithError(err).Error("error setting Active condition")
+		return reconcile.Result{}, err
+	}
+	failures, err := r.recordInstallFailures(clp, installFailureWindow(clp), append(claimedCDs, unClaminedCDs...), logger)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	budgetExceeded, budgetRetryAfter, err := r.reconcileInstallFailureBudget(clp, failures, logger)
+	if err != nil {
+		logger.WithError(err).Error("error setting InstallFailureBudgetExceeded condition")
+		return reconcile.Result{}, err
+	}
+	if err := r.setProvisioningBlockedCondition(clp, failures, append(claimedCDs, unClaminedCDs...), budgetExceeded, logger); err != nil {
+		logger.WithError(err).Error("error setting ProvisioningBlocked condition")
+		return reconcile.Result{}, err
+	}
+
+	// When the pool has an inventory, each new cluster needs an available entry.
+	var customizations []*hivev1.ClusterDeploymentCustomization
+	var missingCustomizations []string
+	if len(clp.Spec.Inventory) > 0 {
+		customizations, missingCustomizations, err = r.reconcileInventory(clp, append(claimedCDs, unClaminedCDs...), logger)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		if len(customizations) < availableCapacity {
+			logger.WithField("available", len(customizations)).Debug("clusters limited by the available entries of the inventory")
+			availableCapacity = len(customizations)
+		}
+	}
+	var invalidCustomizations []string
 
 	pendingClaims, err := r.getAllPendingClusterClaims(clp, logger)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
-	logger.WithField("count", len(pendingClaims)).Debug("found pending claims for ClusterPool")
+	pendingClaims, laterClaims, requeueAfter := splitReservedClaims(clp, pendingClaims, time.Now())
+	logger.WithFields(log.Fields{
+		"count":    len(pendingClaims),
+		"reserved": len(laterClaims),
+	}).Debug("found pending claims for ClusterPool")
+	if err := r.setReservedClaimConditions(laterClaims, logger); err != nil {
+		return reconcile.Result{}, err
+	}
 
 	// reserveSize is the number of clusters that the pool currently has in reserve
-	reserveSize := len(installingCDs) + len(readyCDs) - len(pendingClaims)
+	reserveSize := len(installingCDs) + len(gatedCDs) + len(rebaseliningCDs) + len(readyCDs) - len(pendingClaims)
 
-	readyCDs, err = r.assignClustersToClaims(pendingClaims, readyCDs, logger)
+	orderByAwake(readyCDs, true)
+	readyCDs, err = r.assignClustersToClaims(pendingClaims, readyCDs, clp.Spec.PreemptReservedClaims, logger)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
 
+	maxConcurrent := clp.Spec.MaxConcurrent
+	if maxConcurrent == nil {
+		maxConcurrent = r.defaultMaxConcurrent
+	}
 	availableCurrent := math.MaxInt32
-	if clp.Spec.MaxConcurrent != nil {
-		availableCurrent = int(*clp.Spec.MaxConcurrent) - len(installingCDs) - numberOfDeletingCDs - numberOfDeletingClaimedCDs
+	if maxConcurrent != nil {
+		availableCurrent = int(*maxConcurrent) - len(installingCDs) - numberOfDeletingCDs - numberOfDeletingClaimedCDs
 		if availableCurrent < 0 {
 			availableCurrent = 0
 		}
@@ -337,29 +462,95 @@ func (r *ReconcileClusterPool) Reconcile(request reconcile.Request) (reconcile.R
 	}
 	availableCurrent -= toDel
 
-	switch drift := reserveSize - int(clp.Spec.Size); {
+	desiredSize := int(clp.Spec.Size)
+	if clp.Spec.Mode == hivev1.ClusterPoolModeDraining {
+		// A draining pool keeps only the clusters needed for the pending claims.
+		desiredSize = 0
+	}
+
+	added := 0
+	deleted := false
+	drift := reserveSize - desiredSize
+	switch {
 	// activity quota exceeded, so no action
 	case availableCurrent <= 0:
 		logger.WithFields(log.Fields{
-			"MaxConcurrent": *clp.Spec.MaxConcurrent,
+			"MaxConcurrent": *maxConcurrent,
 			"Available":     availableCurrent,
 		}).Info("Cannot create/delete clusters as max concurrent quota exceeded.")
 	// If too many, delete some.
 	case drift > 0:
 		toDel := minIntVarible(drift, availableCurrent)
-		if err := r.deleteExcessClusters(installingCDs, readyCDs, toDel, logger); err != nil {
+		// Clusters being rebaselined or waiting for their readiness gates are deleted before the ready ones, and
+		// hibernating ready clusters before the running ones.
+		notReadyCDs := append(rebaseliningCDs, gatedCDs...)
+		hibernatingFirstCDs := append([]*hivev1.ClusterDeployment{}, readyCDs...)
+		orderByAwake(hibernatingFirstCDs, false)
+		if err := r.deleteExcessClusters(installingCDs, append(notReadyCDs, hibernatingFirstCDs...), toDel, logger); err != nil {
 			return reconcile.Result{}, err
 		}
+		deleted = true
 	// If too few, create new InstallConfig and ClusterDeployment.
 	case drift < 0:
 		if availableCapacity <= 0 {
 			break
 		}
+		if mode := clp.Spec.Mode; mode == hivev1.ClusterPoolModePaused || mode == hivev1.ClusterPoolModeDraining {
+			logger.WithField("mode", mode).Debug("not adding clusters to the pool")
+			break
+		}
+		if budgetExceeded {
+			logger.Info("not adding clusters to the pool while its install failure budget is exceeded")
+			break
+		}
 		toAdd := minIntVarible(-drift, availableCapacity, availableCurrent)
-		if err := r.addClusters(clp, toAdd, logger); err != nil {
+		invalidCustomizations, err = r.addClusters(clp, toAdd, customizations, countClustersByPlatform(unClaminedCDs), failingPlatforms(failures), logger)
+		if err != nil {
 			log.WithError(err).Error("error adding clusters")
 			return reconcile.Result{}, err
 		}
+		added = toAdd
+	}
+
+	// Replace the unclaimed ready clusters older than the max cluster age of the pool with fresh installs once the
+	// pool is full. Stale clusters are deleted, and replaced by the pool once they are gone, as many at a time as
+	// maxConcurrent allows, or one at a time when the pool has no maxConcurrent.
+	stale, nextStaleAfter := staleClusters(clp, readyCDs, time.Now())
+	mode := clp.Spec.Mode
+	if len(stale) > 0 && drift == 0 && mode != hivev1.ClusterPoolModePaused && mode != hivev1.ClusterPoolModeDraining && !budgetExceeded {
+		rotations := availableCurrent - added
+		if maxConcurrent == nil {
+			rotations = 1 - len(installingCDs) - numberOfDeletingCDs
+		}
+		logger.WithFields(log.Fields{
+			"stale":     len(stale),
+			"rotations": rotations,
+		}).Debug("found stale clusters in pool")
+		if rotations > 0 {
+			if err := r.rotateStaleClusters(clp, stale, rotations, logger); err != nil {
+				return reconcile.Result{}, err
+			}
+			deleted = true
+		}
+	}
+
+	// Clusters deleted by this reconcile are still listed, so the running tier is left to the next reconcile.
+	if !deleted {
+		if err := r.reconcileRunningTier(clp, append(append(append([]*hivev1.ClusterDeployment{}, readyCDs...), gatedCDs...), installingCDs...), logger); err != nil {
+			logger.WithError(err).Error("error reconciling running tier")
+			return reconcile.Result{}, err
+		}
+	}
+
+	unclaimed := append(append(append(append([]*hivev1.ClusterDeployment{}, installingCDs...), gatedCDs...), rebaseliningCDs...), readyCDs...)
+	if err := r.setAllClustersCurrentCondition(clp, unclaimed, len(stale), logger); err != nil {
+		logger.WithError(err).Error("error setting AllClustersCurrent condition")
+		return reconcile.Result{}, err
+	}
+
+	if err := r.setInventoryValidCondition(clp, missingCustomizations, invalidCustomizations, logger); err != nil {
+		logger.WithError(err).Error("error setting InventoryValid condition")
+		return reconcile.Result{}, err
 	}
 
 	if err := r.reconcileRBAC(clp, logger); err != nil {
@@ -367,7 +558,21 @@ func (r *ReconcileClusterPool) Reconcile(request reconcile.Request) (reconcile.R
 		return reconcile.Result{}, err
 	}
 
-	return reconcile.Result{}, nil
+	rebaselinePending, err := r.reconcileRebaseliningClusters(rebaseliningCDs, logger)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if rebaselinePending && (requeueAfter == 0 || requeueAfter > rebaselinePollInterval) {
+		requeueAfter = rebaselinePollInterval
+	}
+	if budgetExceeded && (requeueAfter == 0 || requeueAfter > budgetRetryAfter) {
+		requeueAfter = budgetRetryAfter
+	}
+	if nextStaleAfter > 0 && (requeueAfter == 0 || requeueAfter > nextStaleAfter) {
+		requeueAfter = nextStaleAfter
+	}
+
+	return reconcile.Result{RequeueAfter: requeueAfter}, nil
 }
 
 func minIntVarible(v1 int, vn ...int) (m int) {
@@ -478,11 +683,17 @@ func (r *ReconcileClusterPool) applyRoleBinding(desired, observed *rbacv1.RoleBi
 	return nil
 }
 
+// addClusters creates new clusters for the pool, spread across its platforms given the number of clusters of the pool
+// already on each platform. When the pool has an inventory, each cluster is customized with the next of the given
+// customizations, skipping those which cannot be applied, whose names are returned.
 func (r *ReconcileClusterPool) addClusters(
 	clp *hivev1.ClusterPool,
 	newClusterCount int,
+	customizations []*hivev1.ClusterDeploymentCustomization,
+	platformCounts map[string]int,
+	failing sets.String,
 	logger log.FieldLogger,
-) error {
+) ([]string, error) {
 	logger.WithField("count", newClusterCount).Info("Adding new clusters")
 
 	var errs []error
@@ -503,7 +714,7 @@ func (r *ReconcileClusterPool) addClusters(
 		errs = append(errs, fmt.Errorf("%s: %w", icSecretDependent, err))
 	}
 
-	cloudBuilder, err := r.createCloudBuilder(clp, logger)
+	platforms, err := r.createPoolPlatforms(clp, logger)
 	if err != nil {
 		errs = append(errs, fmt.Errorf("%s: %w", credentialsSecretDependent, err))
 	}
@@ -511,59 +722,108 @@ func (r *ReconcileClusterPool) addClusters(
 	dependenciesError := utilerrors.NewAggregate(errs)
 
 	if err := r.setMissingDependenciesCondition(clp, dependenciesError, logger); err != nil {
-		return err
+		return nil, err
 	}
 
 	if dependenciesError != nil {
-		return dependenciesError
+		return nil, dependenciesError
 	}
 
-	for i := 0; i < newClusterCount; i++ {
-		if err := r.createCluster(clp, cloudBuilder, pullSecret, installConfigTemplate, logger); err != nil {
-			return err
+	platforms = healthyPlatforms(platforms, failing, logger)
+
+	var invalid []string
+	for created := 0; created < newClusterCount; {
+		var cdc *hivev1.ClusterDeploymentCustomization
+		if len(clp.Spec.Inventory) > 0 {
+			if len(customizations) == 0 {
+				break
+			}
+			cdc, customizations = customizations[0], customizations[1:]
+		}
+		platform := nextPlatform(platforms, platformCounts)
+		if platform == nil {
+			logger.Info("no platform of the pool can be used for new clusters")
+			break
+		}
+		err := r.createCluster(clp, platform, pullSecret, installConfigTemplate, cdc, logger)
+		var customizationErr *invalidCustomizationError
+		switch {
+		case errors.As(err, &customizationErr):
+			logger.WithError(err).Warn("skipping invalid entry of the inventory")
+			invalid = append(invalid, customizationErr.name)
+			continue
+		case err != nil:
+			return invalid, err
 		}
+		platformCounts[platform.name]++
+		created++
 	}
 
-	return nil
+	return invalid, nil
 }
 
 func (r *ReconcileClusterPool) createCluster(
 	clp *hivev1.ClusterPool,
-	cloudBuilder clusterresource.CloudBuilder,
+	platform *poolPlatform,
 	pullSecret string,
 	installConfigTemplate string,
+	cdc *hivev1.ClusterDeploymentCustomization,
 	logger log.FieldLogger,
 ) error {
-	ns, err := r.createRandomNamespace(clp)
-	if err != nil {
-		logger.WithError(err).Error("error obtaining random namespace")
-		return err
-	}
-	logger.WithField("cluster", ns.Name).Info("Creating new cluster")
-
 	// We will use this unique random namespace name for our cluster name.
+	namespaceName := apihelpers.GetResourceName(clp.Name, utilrand.String(5))
 	builder := &clusterresource.Builder{
-		Name:                  ns.Name,
-		Namespace:             ns.Name,
+		Name:                  namespaceName,
+		Namespace:             namespaceName,
 		BaseDomain:            clp.Spec.BaseDomain,
+		BaseDomainPoolRef:     clp.Spec.BaseDomainPoolRef,
+		ManageDNS:             clp.Spec.BaseDomainPoolRef != nil,
 		ImageSet:              clp.Spec.ImageSetRef.Name,
 		WorkerNodesCount:      int64(3),
 		MachineNetwork:        "10.0.0.0/16",
 		PullSecret:            pullSecret,
-		CloudBuilder:          cloudBuilder,
+		CloudBuilder:          platform.cloudBuilder,
 		Labels:                clp.Spec.Labels,
 		InstallConfigTemplate: installConfigTemplate,
 		SkipMachinePools:      clp.Spec.SkipMachinePools,
+		ResumeReadiness:       clp.Spec.ResumeReadiness,
 	}
 
 	if clp.Spec.HibernateAfter != nil {
 		builder.HibernateAfter = &clp.Spec.HibernateAfter.Duration
 	}
 
+	if cdc != nil && cdc.Spec.BaseDomain != "" {
+		builder.BaseDomain = cdc.Spec.BaseDomain
+		builder.BaseDomainPoolRef = nil
+		builder.ManageDNS = false
+	}
+
 	objs, err := builder.Build()
 	if err != nil {
 		return errors.Wrap(err, "error building resources")
 	}
+	specHash, err := poolSpecHash(clp)
+	if err != nil {
+		return errors.Wrap(err, "error computing hash of pool spec")
+	}
+
+	// Customize the resources before creating anything, so that an invalid entry of the inventory leaves nothing behind.
+	if cdc != nil {
+		if err := applyCustomization(objs, cdc); err != nil {
+			return &invalidCustomizationError{name: cdc.Name, err: err}
+		}
+		if err := r.reserveCustomization(clp, cdc, namespaceName, logger); err != nil {
+			return err
+		}
+	}
+
+	ns, err := r.createNamespace(clp, namespaceName)
+	if err != nil {
+		logger.WithError(err).Error("error creating namespace")
+		return err
+	}
+	logger.WithField("cluster", ns.Name).Info("Creating new cluster")
 
 	poolKey := types.NamespacedName{Namespace: clp.Namespace, Name: clp.Name}.String()
 	r.expectations.ExpectCreations(poolKey, 1)
@@ -574,8 +834,17 @@ func (r *ReconcileClusterPool) createCluster(
 			continue
 		}
 		poolRef := poolReference(clp)
+		if cdc != nil {
+			poolRef.CustomizationRef = &corev1.LocalObjectReference{Name: cdc.Name}
+		}
+		poolRef.PlatformName = platform.name
 		cd.Spec.ClusterPoolRef = &poolRef
+		if cd.Annotations == nil {
+			cd.Annotations = map[string]string{}
+		}
+		cd.Annotations[constants.ClusterPoolSpecHashAnnotation] = specHash
 		cd.Spec.PowerState = hivev1.HibernatingClusterPowerState
+		cd.Spec.ReadinessGates = clp.Spec.ReadinessGates
 		lastIndex := len(objs) - 1
 		objs[i], objs[lastIndex] = objs[lastIndex], objs[i]
 	}
@@ -590,8 +859,7 @@ func (r *ReconcileClusterPool) createCluster(
 	return nil
 }
 
-func (r *ReconcileClusterPool) createRandomNamespace(clp *hivev1.ClusterPool) (*corev1.Namespace, error) {
-	namespaceName := apihelpers.GetResourceName(clp.Name, utilrand.String(5))
+func (r *ReconcileClusterPool) createNamespace(clp *hivev1.ClusterPool, namespaceName string) (*corev1.Namespace, error) {
 	ns := &corev1.Namespace{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: namespaceName,
@@ -769,6 +1037,49 @@ func (r *ReconcileClusterPool) setAvailableCapacityCondition(pool *hivev1.Cluste
 	return nil
 }
 
+// setActiveCondition reports whether the pool is creating new clusters. The condition is only added once the pool is
+// paused or draining, with the number of unclaimed clusters remaining in a draining pool.
+func (r *ReconcileClusterPool) setActiveCondition(pool *hivev1.ClusterPool, unclaimed int, logger log.FieldLogger) error {
+	mode := pool.Spec.Mode
+	if (mode == "" || mode == hivev1.ClusterPoolModeActive) &&
+		controllerutils.FindClusterPoolCondition(pool.Status.Conditions, hivev1.ClusterPoolActiveCondition) == nil {
+		return nil
+	}
+	status := corev1.ConditionTrue
+	reason := "Active"
+	message := "The pool is creating new clusters."
+	switch {
+	case mode == hivev1.ClusterPoolModePaused:
+		status = corev1.ConditionFalse
+		reason = "Paused"
+		message = "The pool is paused, no new clusters are created."
+	case mode == hivev1.ClusterPoolModeDraining && unclaimed > 0:
+		status = corev1.ConditionFalse
+		reason = "Draining"
+		message = fmt.Sprintf("The pool is draining, %d unclaimed clusters remaining.", unclaimed)
+	case mode == hivev1.ClusterPoolModeDraining:
+		status = corev1.ConditionFalse
+		reason = "Drained"
+		message = "The pool is drained, all of its unclaimed clusters are deleted."
+	}
+	conds, changed := controllerutils.SetClusterPoolConditionWithChangeCheck(
+		pool.Status.Conditions,
+		hivev1.ClusterPoolActiveCondition,
+		status,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	if changed {
+		pool.Status.Conditions = conds
+		if err := r.Status().Update(context.Background(), pool); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update ClusterPool conditions")
+			return errors.Wrap(err, "could not update ClusterPool conditions")
+		}
+	}
+	return nil
+}
+
 func (r *ReconcileClusterPool) verifyClusterImageSet(pool *hivev1.ClusterPool, logger log.FieldLogger) error {
 	err := r.Get(context.Background(), client.ObjectKey{Name: pool.Spec.ImageSetRef.Name}, &hivev1.ClusterImageSet{})
 	if err != nil {
@@ -822,8 +1133,8 @@ func (r *ReconcileClusterPool) getPullSecret(pool *hivev1.ClusterPool, logger lo
 	return string(pullSecret), nil
 }
 
-func (r *ReconcileClusterPool) createCloudBuilder(pool *hivev1.ClusterPool, logger log.FieldLogger) (clusterresource.CloudBuilder, error) {
-	switch platform := pool.Spec.Platform; {
+func (r *ReconcileClusterPool) createCloudBuilder(pool *hivev1.ClusterPool, platform hivev1.Platform, logger log.FieldLogger) (clusterresource.CloudBuilder, error) {
+	switch {
 	case platform.AWS != nil:
 		credsSecret, err := r.getCredentialsSecret(pool, platform.AWS.CredentialsSecretRef.Name, logger)
 		if err != nil {
@@ -860,7 +1171,8 @@ func (r *ReconcileClusterPool) createCloudBuilder(pool *hivev1.ClusterPool, logg
 	}
 }
 
-// getAllPendingClusterClaims returns all of the ClusterClaims that are requesting clusters from the specified pool.
+// getAllPendingClusterClaims returns all of the ClusterClaims that are requesting clusters from the specified pool,
+// except for the claims held back by their quotas.
 // The claims are returned in order of creation time, from oldest to youngest.
 func (r *ReconcileClusterPool) getAllPendingClusterClaims(pool *hivev1.ClusterPool, logger log.FieldLogger) ([]*hivev1.ClusterClaim, error) {
 	claimsList := &hivev1.ClusterClaimList{}
@@ -878,6 +1190,12 @@ func (r *ReconcileClusterPool) getAllPendingClusterClaims(pool *hivev1.ClusterPo
 		if claim.Spec.Namespace != "" {
 			continue
 		}
+		// skip claims held back by their quotas
+		if cond := controllerutils.FindClusterClaimCondition(claim.Status.Conditions, hivev1.ClusterClaimPendingCondition); cond != nil &&
+			cond.Status == corev1.ConditionTrue && cond.Reason == controllerutils.ClaimQuotaExceededReason {
+			logger.WithField("claim", claim.Name).Debug("claim exceeds its quota")
+			continue
+		}
 		pendingClaims = append(pendingClaims, &claimsList.Items[i])
 	}
 	sort.Slice(
@@ -889,12 +1207,141 @@ func (r *ReconcileClusterPool) getAllPendingClusterClaims(pool *hivev1.ClusterPo
 	return pendingClaims, nil
 }
 
-func (r *ReconcileClusterPool) assignClustersToClaims(claims []*hivev1.ClusterClaim, cds []*hivev1.ClusterDeployment, logger log.FieldLogger) ([]*hivev1.ClusterDeployment, error) {
+// splitReservedClaims separates the pending claims which are due from the claims reserved further in the future. Due
+// claims are either active, or reserved with an activation time within the reservation lead time of the pool. It also
+// returns how long until the next reserved claim becomes due or active, when the pool must be reconciled again, or zero
+// when there are no reserved claims.
+func splitReservedClaims(pool *hivev1.ClusterPool, claims []*hivev1.ClusterClaim, now time.Time) (due, later []*hivev1.ClusterClaim, requeueAfter time.Duration) {
+	leadTime := defaultReservationLeadTime
+	if pool.Spec.ReservationLeadTime != nil {
+		leadTime = pool.Spec.ReservationLeadTime.Duration
+	}
+	next := func(t time.Time) {
+		if d := t.Sub(now); d > 0 && (requeueAfter == 0 || d < requeueAfter) {
+			requeueAfter = d
+		}
+	}
+	for _, claim := range claims {
+		if !isReserved(claim, now) {
+			due = append(due, claim)
+			continue
+		}
+		activation := claim.Spec.ActivationTime.Time
+		if activation.Sub(now) > leadTime {
+			later = append(later, claim)
+			next(activation.Add(-leadTime))
+			continue
+		}
+		due = append(due, claim)
+		next(activation.Add(-reservationResumeLeadTime))
+		next(activation)
+	}
+	return due, later, requeueAfter
+}
+
+// isReserved returns whether the claim is reserved for a future activation time.
+func isReserved(claim *hivev1.ClusterClaim, now time.Time) bool {
+	return claim.Spec.ActivationTime != nil && claim.Spec.ActivationTime.Time.After(now)
+}
+
+// setReservedClaimConditions reports that the claims reserved beyond the lead time of the pool are waiting for their
+// activation time.
+func (r *ReconcileClusterPool) setReservedClaimConditions(claims []*hivev1.ClusterClaim, logger log.FieldLogger) error {
 	for _, claim := range claims {
+		conds, changed := controllerutils.SetClusterClaimConditionWithChangeCheck(
+			claim.Status.Conditions,
+			hivev1.ClusterClaimPendingCondition,
+			corev1.ConditionTrue,
+			"Reserved",
+			fmt.Sprintf("Claim is reserved for activation at %s", claim.Spec.ActivationTime.UTC().Format(time.RFC3339)),
+			controllerutils.UpdateConditionIfReasonOrMessageChange,
+		)
+		if !changed {
+			continue
+		}
+		claim.Status.Conditions = conds
+		if err := r.Status().Update(context.Background(), claim); err != nil {
+			logger.WithField("claim", claim.Name).WithError(err).Log(controllerutils.LogLevel(err), "could not update status of ClusterClaim")
+			return err
+		}
+	}
+	return nil
+}
+
+// orderClaimsByPriority returns the claims ordered by priority, from highest to lowest. Claims of the same priority
+// keep their order.
+func orderClaimsByPriority(claims []*hivev1.ClusterClaim) []*hivev1.ClusterClaim {
+	ordered := make([]*hivev1.ClusterClaim, len(claims))
+	copy(ordered, claims)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].Spec.Priority > ordered[j].Spec.Priority
+	})
+	return ordered
+}
+
+// resumeHeldCluster resumes the hibernating cluster held for a reserved claim once the activation time of the claim is
+// within the resume lead time, so that the cluster is running by the time it is assigned to the claim.
+func (r *ReconcileClusterPool) resumeHeldCluster(claim *hivev1.ClusterClaim, cd *hivev1.ClusterDeployment, now time.Time, logger log.FieldLogger) error {
+	if isRunning(cd) || claim.Spec.ActivationTime.Time.Sub(now) > reservationResumeLeadTime {
+		return nil
+	}
+	cdLog := logger.WithField("cluster", cd.Namespace)
+	cdLog.Info("resuming cluster held for reserved claim")
+	cd.Spec.PowerState = hivev1.RunningClusterPowerState
+	if err := r.Update(context.Background(), cd); err != nil {
+		cdLog.WithError(err).Log(controllerutils.LogLevel(err), "could not resume cluster held for reserved claim")
+		return err
+	}
+	return nil
+}
+
+// assignClustersToClaims assigns the clusters to the claims, which are in order of creation, by priority. Claims
+// reserved for a future activation time are not assigned a cluster, but a cluster is held for them, so that it cannot
+// be assigned to the claims which follow. Claims follow the claims created before them, unless the pool preempts
+// reserved claims, in which case claims follow the claims of a higher or the same priority, and take the clusters held
+// for reserved claims of a lower priority.
+func (r *ReconcileClusterPool) assignClustersToClaims(claims []*hivev1.ClusterClaim, cds []*hivev1.ClusterDeployment, preemptReserved bool, logger log.FieldLogger) ([]*hivev1.ClusterDeployment, error) {
+	now := time.Now()
+	prioritizedClaims := orderClaimsByPriority(claims)
+
+	// Find the reserved claims which are held a cluster, and set the held clusters aside.
+	holdOrder := claims
+	if preemptReserved {
+		holdOrder = prioritizedClaims
+	}
+	held := map[*hivev1.ClusterClaim]*hivev1.ClusterDeployment{}
+	for i, claim := range holdOrder {
+		if i >= len(cds) {
+			break
+		}
+		if isReserved(claim, now) {
+			held[claim] = cds[len(held)]
+		}
+	}
+	cds = cds[len(held):]
+
+	for _, claim := range prioritizedClaims {
 		logger := logger.WithField("claim", claim.Name)
 		var conds []hivev1.ClusterClaimCondition
 		var statusChanged bool
-		if len(cds) > 0 {
+		if isReserved(claim, now) {
+			message := "Claim is reserved, waiting for a cluster to hold for it"
+			if cd := held[claim]; cd != nil {
+				logger.WithField("cluster", cd.Namespace).Debug("holding cluster for reserved claim")
+				message = "Claim is reserved, a cluster is held for it"
+				if err := r.resumeHeldCluster(claim, cd, now, logger); err != nil {
+					return cds, err
+				}
+			}
+			conds, statusChanged = controllerutils.SetClusterClaimConditionWithChangeCheck(
+				claim.Status.Conditions,
+				hivev1.ClusterClaimPendingCondition,
+				corev1.ConditionTrue,
+				"Reserved",
+				fmt.Sprintf("%s until its activation at %s", message, claim.Spec.ActivationTime.UTC().Format(time.RFC3339)),
+				controllerutils.UpdateConditionIfReasonOrMessageChange,
+			)
+		} else if len(cds) > 0 {
 			claim.Spec.Namespace = cds[0].Namespace
 			cds = cds[1:]
 			logger.WithField("cluster", claim.Spec.Namespace).Info("assigning cluster to claim")
@@ -902,6 +1349,12 @@ func (r *ReconcileClusterPool) assignClustersToClaims(claims []*hivev1.ClusterCl
 				logger.WithError(err).Log(controllerutils.LogLevel(err), "could not assign cluster to claim")
 				return cds, err
 			}
+			requestTime := claim.CreationTimestamp.Time
+			if claim.Spec.ActivationTime != nil && claim.Spec.ActivationTime.Time.After(requestTime) {
+				requestTime = claim.Spec.ActivationTime.Time
+			}
+			metricClaimAssignmentDelaySeconds.WithLabelValues(claim.Namespace, claim.Spec.ClusterPoolName).
+				Observe(time.Since(requestTime).Seconds())
 			conds = controllerutils.SetClusterClaimCondition(
 				claim.Status.Conditions,
 				hivev1.ClusterClaimPendingCondition,
@@ -2,6 +2,7 @@ package clusterpool
 
 import (
 	"context"
+	"fmt"
 	"sort"
 	"testing"
 	"time"
@@ -18,11 +19,13 @@ import (
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hiveintv1alpha1 "github.com/openshift/hive/apis/hiveinternal/v1alpha1"
 	"github.com/openshift/hive/pkg/constants"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 	testclaim "github.com/openshift/hive/pkg/test/clusterclaim"
@@ -43,6 +46,7 @@ const (
 func TestReconcileClusterPool(t *testing.T) {
 	scheme := runtime.NewScheme()
 	hivev1.AddToScheme(scheme)
+	hiveintv1alpha1.AddToScheme(scheme)
 	corev1.AddToScheme(scheme)
 	rbacv1.AddToScheme(scheme)
 
@@ -65,34 +69,73 @@ func TestReconcileClusterPool(t *testing.T) {
 			testcd.WithUnclaimedClusterPoolReference(testNamespace, testLeasePoolName),
 		)
 	}
+	failedProvisionWithReason := func(cdName string, attempt int, failedAgo time.Duration, reason string) *hivev1.ClusterProvision {
+		return &hivev1.ClusterProvision{
+			ObjectMeta: metav1.ObjectMeta{Namespace: cdName, Name: fmt.Sprintf("%s-%d", cdName, attempt)},
+			Spec: hivev1.ClusterProvisionSpec{
+				ClusterDeploymentRef: corev1.LocalObjectReference{Name: cdName},
+				Attempt:              attempt,
+				Stage:                hivev1.ClusterProvisionStageFailed,
+			},
+			Status: hivev1.ClusterProvisionStatus{
+				Conditions: []hivev1.ClusterProvisionCondition{{
+					Type:               hivev1.ClusterProvisionFailedCondition,
+					Status:             corev1.ConditionTrue,
+					Reason:             reason,
+					LastTransitionTime: metav1.NewTime(time.Now().Add(-failedAgo)),
+				}},
+			},
+		}
+	}
+	failedProvision := func(cdName string, attempt int, failedAgo time.Duration) *hivev1.ClusterProvision {
+		return failedProvisionWithReason(cdName, attempt, failedAgo, "")
+	}
 
 	tests := []struct {
 		name                               string
 		existing                           []runtime.Object
+		defaultMaxConcurrent               *int32
 		noClusterImageSet                  bool
 		noCredsSecret                      bool
 		expectError                        bool
 		expectedTotalClusters              int
 		expectedObservedSize               int32
 		expectedObservedReady              int32
+		expectedObservedRunning            int32
 		expectedDeletedClusters            []string
 		expectFinalizerRemoved             bool
 		expectedMissingDependenciesStatus  *bool
 		expectedCapacityStatus             *bool
+		expectedActiveReason               string
+		expectedBudgetExceededStatus       corev1.ConditionStatus
+		expectedInstallFailures            []string
+		expectedProvisioningBlockedStatus  corev1.ConditionStatus
+		expectedProvisioningBlockedReason  string
+		expectedAllClustersCurrentReason   string
 		expectedMissingDependenciesMessage string
 		expectedAssignedClaims             int
 		expectedUnassignedClaims           int
+		expectedAssignedClaimNames         []string
+		expectedClaimedClusters            []string
+		expectedRunningClusters            []string
 		expectedLabels                     map[string]string // Tested on all clusters, so will not work if your test has pre-existing cds in the pool.
+		expectedReadinessGates             []hivev1.ClusterDeploymentReadinessGate
+		expectedRequeueAfter               time.Duration
+		expectedRebaseliningClusters       int
+		expectedRebaselineSyncSets         int
 	}{
 		{
 			name: "create all clusters",
 			existing: []runtime.Object{
 				poolBuilder.Build(testcp.WithSize(5), testcp.WithClusterDeploymentLabels(map[string]string{"foo": "bar"})),
 			},
-			expectedTotalClusters: 5,
-			expectedObservedSize:  0,
-			expectedObservedReady: 0,
-			expectedLabels:        map[string]string{"foo": "bar"},
+			expectedTotalClusters:             5,
+			expectedObservedSize:              0,
+			expectedObservedReady:             0,
+			expectedLabels:                    map[string]string{"foo": "bar"},
+			expectedProvisioningBlockedStatus: corev1.ConditionFalse,
+			expectedProvisioningBlockedReason: "NoRecentFailures",
+			expectedAllClustersCurrentReason:  "ClustersCurrent",
 		},
 		{
 			name: "scale up",
@@ -182,6 +225,42 @@ func TestReconcileClusterPool(t *testing.T) {
 			expectedObservedSize:  3,
 			expectedObservedReady: 2,
 		},
+		{
+			name: "scale up with default max concurrent",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(5)),
+				unclaimedCDBuilder("c1").Build(testcd.Installed()),
+				unclaimedCDBuilder("c2").Build(),
+			},
+			defaultMaxConcurrent:  pointer.Int32Ptr(2),
+			expectedTotalClusters: 3,
+			expectedObservedSize:  2,
+			expectedObservedReady: 1,
+		},
+		{
+			name: "scale up with no more default max concurrent",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(5)),
+				unclaimedCDBuilder("c1").Build(),
+				unclaimedCDBuilder("c2").Build(),
+			},
+			defaultMaxConcurrent:  pointer.Int32Ptr(2),
+			expectedTotalClusters: 2,
+			expectedObservedSize:  2,
+			expectedObservedReady: 0,
+		},
+		{
+			name: "max concurrent of pool overrides default",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(5), testcp.WithMaxConcurrent(3)),
+				unclaimedCDBuilder("c1").Build(),
+				unclaimedCDBuilder("c2").Build(),
+			},
+			defaultMaxConcurrent:  pointer.Int32Ptr(2),
+			expectedTotalClusters: 3,
+			expectedObservedSize:  2,
+			expectedObservedReady: 0,
+		},
 		{
 			name: "scale up with max concurrent and max size",
 			existing: []runtime.Object{
@@ -304,6 +383,70 @@ func TestReconcileClusterPool(t *testing.T) {
 			expectedObservedSize:  6,
 			expectedObservedReady: 6,
 		},
+		{
+			name: "rotate stale cluster",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(3), testcp.WithMaxClusterAge(24*time.Hour)),
+				unclaimedCDBuilder("c1").Build(testcd.InstalledTimestamp(time.Now().Add(-48 * time.Hour))),
+				unclaimedCDBuilder("c2").Build(testcd.InstalledTimestamp(time.Now().Add(-time.Hour))),
+				unclaimedCDBuilder("c3").Build(testcd.InstalledTimestamp(time.Now().Add(-2 * time.Hour))),
+			},
+			expectedTotalClusters:            2,
+			expectedObservedSize:             3,
+			expectedObservedReady:            3,
+			expectedDeletedClusters:          []string{"c1"},
+			expectedRequeueAfter:             22 * time.Hour,
+			expectedAllClustersCurrentReason: "ClustersStale",
+		},
+		{
+			name: "rotate oldest stale clusters up to max concurrent",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(4), testcp.WithMaxConcurrent(2), testcp.WithMaxClusterAge(24*time.Hour)),
+				unclaimedCDBuilder("c1").Build(testcd.InstalledTimestamp(time.Now().Add(-30 * time.Hour))),
+				unclaimedCDBuilder("c2").Build(testcd.InstalledTimestamp(time.Now().Add(-48 * time.Hour))),
+				unclaimedCDBuilder("c3").Build(testcd.InstalledTimestamp(time.Now().Add(-36 * time.Hour))),
+				unclaimedCDBuilder("c4").Build(testcd.InstalledTimestamp(time.Now().Add(-12 * time.Hour))),
+			},
+			expectedTotalClusters:   2,
+			expectedObservedSize:    4,
+			expectedObservedReady:   4,
+			expectedDeletedClusters: []string{"c2", "c3"},
+			expectedRequeueAfter:    12 * time.Hour,
+		},
+		{
+			name: "no rotation of stale clusters while installing without max concurrent",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(3), testcp.WithMaxClusterAge(24*time.Hour)),
+				unclaimedCDBuilder("c1").Build(testcd.InstalledTimestamp(time.Now().Add(-48 * time.Hour))),
+				unclaimedCDBuilder("c2").Build(testcd.InstalledTimestamp(time.Now().Add(-time.Hour))),
+				unclaimedCDBuilder("c3").Build(),
+			},
+			expectedTotalClusters: 3,
+			expectedObservedSize:  3,
+			expectedObservedReady: 2,
+			expectedRequeueAfter:  23 * time.Hour,
+		},
+		{
+			name: "no rotation of stale clusters while pool is filling",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(3), testcp.WithMaxClusterAge(24*time.Hour)),
+				unclaimedCDBuilder("c1").Build(testcd.InstalledTimestamp(time.Now().Add(-48 * time.Hour))),
+			},
+			expectedTotalClusters: 3,
+			expectedObservedSize:  1,
+			expectedObservedReady: 1,
+		},
+		{
+			name: "no rotation of stale clusters of paused pool",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(1), testcp.WithMaxClusterAge(24*time.Hour), testcp.WithMode(hivev1.ClusterPoolModePaused)),
+				unclaimedCDBuilder("c1").Build(testcd.InstalledTimestamp(time.Now().Add(-48 * time.Hour))),
+			},
+			expectedTotalClusters: 1,
+			expectedObservedSize:  1,
+			expectedObservedReady: 1,
+			expectedActiveReason:  "Paused",
+		},
 		{
 			name: "delete installing clusters first",
 			existing: []runtime.Object{
@@ -579,6 +722,33 @@ func TestReconcileClusterPool(t *testing.T) {
 			expectedAssignedClaims:   1,
 			expectedUnassignedClaims: 0,
 		},
+		{
+			name: "do not assign clusters waiting for readiness gates",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(2)),
+				unclaimedCDBuilder("c1").Build(testcd.Installed(), testcd.WithReadinessGates("example.com/Scanned")),
+				unclaimedCDBuilder("c2").Build(
+					testcd.Installed(),
+					testcd.WithReadinessGates("example.com/Scanned"),
+					testcd.WithCondition(hivev1.ClusterDeploymentCondition{Type: "example.com/Scanned", Status: corev1.ConditionTrue}),
+				),
+				testclaim.FullBuilder(testNamespace, "test-claim-1", scheme).Build(testclaim.WithPool(testLeasePoolName)),
+				testclaim.FullBuilder(testNamespace, "test-claim-2", scheme).Build(testclaim.WithPool(testLeasePoolName)),
+			},
+			expectedTotalClusters:    4,
+			expectedObservedSize:     2,
+			expectedObservedReady:    1,
+			expectedAssignedClaims:   1,
+			expectedUnassignedClaims: 1,
+		},
+		{
+			name: "readiness gates applied to new clusters",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(2), testcp.WithReadinessGates("example.com/Scanned")),
+			},
+			expectedTotalClusters:  2,
+			expectedReadinessGates: []hivev1.ClusterDeploymentReadinessGate{{ConditionType: "example.com/Scanned"}},
+		},
 		{
 			name: "no ready clusters to assign to claim",
 			existing: []runtime.Object{
@@ -611,6 +781,251 @@ func TestReconcileClusterPool(t *testing.T) {
 			expectedAssignedClaims:   2,
 			expectedUnassignedClaims: 1,
 		},
+		{
+			name: "do not assign to reserved claim",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(3)),
+				unclaimedCDBuilder("c1").Build(testcd.Installed()),
+				unclaimedCDBuilder("c2").Build(testcd.Installed()),
+				unclaimedCDBuilder("c3").Build(),
+				testclaim.FullBuilder(testNamespace, "test-claim", scheme).Build(
+					testclaim.WithPool(testLeasePoolName),
+					testclaim.WithActivationTime(time.Now().Add(30*time.Minute)),
+				),
+			},
+			expectedTotalClusters:    4,
+			expectedObservedSize:     3,
+			expectedObservedReady:    2,
+			expectedAssignedClaims:   0,
+			expectedUnassignedClaims: 1,
+			expectedRequeueAfter:     15 * time.Minute,
+		},
+		{
+			name: "do not assign to claim exceeding its quota",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(3)),
+				unclaimedCDBuilder("c1").Build(testcd.Installed()),
+				unclaimedCDBuilder("c2").Build(testcd.Installed()),
+				unclaimedCDBuilder("c3").Build(),
+				testclaim.FullBuilder(testNamespace, "test-claim", scheme).Build(
+					testclaim.WithPool(testLeasePoolName),
+					testclaim.WithCondition(hivev1.ClusterClaimCondition{
+						Type:   hivev1.ClusterClaimPendingCondition,
+						Status: corev1.ConditionTrue,
+						Reason: controllerutils.ClaimQuotaExceededReason,
+					}),
+				),
+			},
+			expectedTotalClusters:    3,
+			expectedObservedSize:     3,
+			expectedObservedReady:    2,
+			expectedAssignedClaims:   0,
+			expectedUnassignedClaims: 1,
+		},
+		{
+			name: "wait for rebaseline SyncSets",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(2)),
+				unclaimedCDBuilder("c1").Build(testcd.Installed()),
+				unclaimedCDBuilder("c2").GenericOptions(
+					testgeneric.WithAnnotation(constants.ClusterRebaseliningAnnotation, "true"),
+				).Build(testcd.Installed()),
+				rebaselineSyncSet("c2", 1),
+				clusterSync("c2", rebaselineSyncSetName("c2"), 0),
+			},
+			expectedTotalClusters:        2,
+			expectedObservedSize:         2,
+			expectedObservedReady:        1,
+			expectedRebaseliningClusters: 1,
+			expectedRebaselineSyncSets:   1,
+			expectedRequeueAfter:         time.Minute,
+		},
+		{
+			name: "wait for admin credentials rotation",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(2)),
+				unclaimedCDBuilder("c1").Build(testcd.Installed()),
+				unclaimedCDBuilder("c2").GenericOptions(
+					testgeneric.WithAnnotation(constants.ClusterRebaseliningAnnotation, "true"),
+					testgeneric.WithAnnotation(constants.ClusterCredentialsRotatingAnnotation, "true"),
+				).Build(testcd.Installed(), testcd.WithPowerState(hivev1.RunningClusterPowerState)),
+			},
+			expectedTotalClusters:        2,
+			expectedObservedSize:         2,
+			expectedObservedReady:        1,
+			expectedRebaseliningClusters: 1,
+			expectedRunningClusters:      []string{"c2"},
+			expectedRequeueAfter:         time.Minute,
+		},
+		{
+			name: "return rebaselined cluster to pool",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(2)),
+				unclaimedCDBuilder("c1").Build(testcd.Installed()),
+				unclaimedCDBuilder("c2").GenericOptions(
+					testgeneric.WithAnnotation(constants.ClusterRebaseliningAnnotation, "true"),
+				).Build(testcd.Installed(), testcd.WithPowerState(hivev1.RunningClusterPowerState)),
+				rebaselineSyncSet("c2", 1),
+				clusterSync("c2", rebaselineSyncSetName("c2"), 1),
+			},
+			expectedTotalClusters: 2,
+			expectedObservedSize:  2,
+			expectedObservedReady: 1,
+		},
+		{
+			name: "do not provision for claim reserved beyond lead time",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(3), testcp.WithReservationLeadTime(2*time.Hour)),
+				unclaimedCDBuilder("c1").Build(testcd.Installed()),
+				unclaimedCDBuilder("c2").Build(testcd.Installed()),
+				unclaimedCDBuilder("c3").Build(),
+				testclaim.FullBuilder(testNamespace, "test-claim", scheme).Build(
+					testclaim.WithPool(testLeasePoolName),
+					testclaim.WithActivationTime(time.Now().Add(3*time.Hour)),
+				),
+			},
+			expectedTotalClusters:    3,
+			expectedObservedSize:     3,
+			expectedObservedReady:    2,
+			expectedAssignedClaims:   0,
+			expectedUnassignedClaims: 1,
+			expectedRequeueAfter:     time.Hour,
+		},
+		{
+			name: "assign to reserved claim once activated",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(3)),
+				unclaimedCDBuilder("c1").Build(testcd.Installed()),
+				unclaimedCDBuilder("c2").Build(testcd.Installed()),
+				unclaimedCDBuilder("c3").Build(),
+				testclaim.FullBuilder(testNamespace, "test-claim", scheme).Build(
+					testclaim.WithPool(testLeasePoolName),
+					testclaim.WithActivationTime(time.Now().Add(-time.Minute)),
+				),
+			},
+			expectedTotalClusters:    4,
+			expectedObservedSize:     3,
+			expectedObservedReady:    2,
+			expectedAssignedClaims:   1,
+			expectedUnassignedClaims: 0,
+		},
+		{
+			name: "hold cluster for reserved claim",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(2)),
+				unclaimedCDBuilder("c1").Build(testcd.Installed()),
+				unclaimedCDBuilder("c2").Build(),
+				testclaim.FullBuilder(testNamespace, "reserved-claim", scheme).GenericOptions(
+					testgeneric.WithCreationTimestamp(time.Now().Add(-time.Hour)),
+				).Build(
+					testclaim.WithPool(testLeasePoolName),
+					testclaim.WithActivationTime(time.Now().Add(10*time.Minute)),
+				),
+				testclaim.FullBuilder(testNamespace, "test-claim", scheme).GenericOptions(
+					testgeneric.WithCreationTimestamp(time.Now()),
+				).Build(testclaim.WithPool(testLeasePoolName)),
+			},
+			expectedTotalClusters:    4,
+			expectedObservedSize:     2,
+			expectedObservedReady:    1,
+			expectedAssignedClaims:   0,
+			expectedUnassignedClaims: 2,
+			expectedRunningClusters:  []string{"c1"},
+			expectedRequeueAfter:     10 * time.Minute,
+		},
+		{
+			name: "assign to claims by priority",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(2)),
+				unclaimedCDBuilder("c1").Build(testcd.Installed()),
+				unclaimedCDBuilder("c2").Build(),
+				testclaim.FullBuilder(testNamespace, "bulk-claim", scheme).GenericOptions(
+					testgeneric.WithCreationTimestamp(time.Now().Add(-time.Hour)),
+				).Build(testclaim.WithPool(testLeasePoolName)),
+				testclaim.FullBuilder(testNamespace, "urgent-claim", scheme).GenericOptions(
+					testgeneric.WithCreationTimestamp(time.Now()),
+				).Build(testclaim.WithPool(testLeasePoolName), testclaim.WithPriority(10)),
+			},
+			expectedTotalClusters:      4,
+			expectedObservedSize:       2,
+			expectedObservedReady:      1,
+			expectedAssignedClaims:     1,
+			expectedUnassignedClaims:   1,
+			expectedAssignedClaimNames: []string{"urgent-claim"},
+		},
+		{
+			name: "keep cluster held for lower-priority reserved claim",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(2)),
+				unclaimedCDBuilder("c1").Build(testcd.Installed()),
+				unclaimedCDBuilder("c2").Build(),
+				testclaim.FullBuilder(testNamespace, "reserved-claim", scheme).GenericOptions(
+					testgeneric.WithCreationTimestamp(time.Now().Add(-time.Hour)),
+				).Build(
+					testclaim.WithPool(testLeasePoolName),
+					testclaim.WithActivationTime(time.Now().Add(10*time.Minute)),
+				),
+				testclaim.FullBuilder(testNamespace, "urgent-claim", scheme).GenericOptions(
+					testgeneric.WithCreationTimestamp(time.Now()),
+				).Build(testclaim.WithPool(testLeasePoolName), testclaim.WithPriority(10)),
+			},
+			expectedTotalClusters:    4,
+			expectedObservedSize:     2,
+			expectedObservedReady:    1,
+			expectedAssignedClaims:   0,
+			expectedUnassignedClaims: 2,
+			expectedRunningClusters:  []string{"c1"},
+			expectedRequeueAfter:     10 * time.Minute,
+		},
+		{
+			name: "preempt lower-priority reserved claim",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(2), testcp.WithPreemptReservedClaims()),
+				unclaimedCDBuilder("c1").Build(testcd.Installed()),
+				unclaimedCDBuilder("c2").Build(),
+				testclaim.FullBuilder(testNamespace, "reserved-claim", scheme).GenericOptions(
+					testgeneric.WithCreationTimestamp(time.Now().Add(-time.Hour)),
+				).Build(
+					testclaim.WithPool(testLeasePoolName),
+					testclaim.WithActivationTime(time.Now().Add(10*time.Minute)),
+				),
+				testclaim.FullBuilder(testNamespace, "urgent-claim", scheme).GenericOptions(
+					testgeneric.WithCreationTimestamp(time.Now()),
+				).Build(testclaim.WithPool(testLeasePoolName), testclaim.WithPriority(10)),
+			},
+			expectedTotalClusters:      4,
+			expectedObservedSize:       2,
+			expectedObservedReady:      1,
+			expectedAssignedClaims:     1,
+			expectedUnassignedClaims:   1,
+			expectedAssignedClaimNames: []string{"urgent-claim"},
+			expectedRequeueAfter:       10 * time.Minute,
+		},
+		{
+			name: "do not preempt reserved claim of the same priority",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(2), testcp.WithPreemptReservedClaims()),
+				unclaimedCDBuilder("c1").Build(testcd.Installed()),
+				unclaimedCDBuilder("c2").Build(),
+				testclaim.FullBuilder(testNamespace, "reserved-claim", scheme).GenericOptions(
+					testgeneric.WithCreationTimestamp(time.Now().Add(-time.Hour)),
+				).Build(
+					testclaim.WithPool(testLeasePoolName),
+					testclaim.WithActivationTime(time.Now().Add(10*time.Minute)),
+					testclaim.WithPriority(10),
+				),
+				testclaim.FullBuilder(testNamespace, "urgent-claim", scheme).GenericOptions(
+					testgeneric.WithCreationTimestamp(time.Now()),
+				).Build(testclaim.WithPool(testLeasePoolName), testclaim.WithPriority(10)),
+			},
+			expectedTotalClusters:    4,
+			expectedObservedSize:     2,
+			expectedObservedReady:    1,
+			expectedAssignedClaims:   0,
+			expectedUnassignedClaims: 2,
+			expectedRunningClusters:  []string{"c1"},
+			expectedRequeueAfter:     10 * time.Minute,
+		},
 		{
 			name: "do not assign to claims for other pools",
 			existing: []runtime.Object{
@@ -868,6 +1283,395 @@ func TestReconcileClusterPool(t *testing.T) {
 			expectedObservedReady:   2,
 			expectedDeletedClusters: []string{"c4"},
 		},
+		{
+			name: "paused pool does not scale up",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(5), testcp.WithMode(hivev1.ClusterPoolModePaused)),
+				unclaimedCDBuilder("c1").Build(testcd.Installed()),
+				unclaimedCDBuilder("c2").Build(testcd.Installed()),
+				unclaimedCDBuilder("c3").Build(),
+			},
+			expectedTotalClusters: 3,
+			expectedObservedSize:  3,
+			expectedObservedReady: 2,
+			expectedActiveReason:  "Paused",
+		},
+		{
+			name: "paused pool assigns to claims",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(3), testcp.WithMode(hivev1.ClusterPoolModePaused)),
+				unclaimedCDBuilder("c1").Build(testcd.Installed()),
+				unclaimedCDBuilder("c2").Build(testcd.Installed()),
+				unclaimedCDBuilder("c3").Build(),
+				testclaim.FullBuilder(testNamespace, "test-claim", scheme).Build(testclaim.WithPool(testLeasePoolName)),
+			},
+			expectedTotalClusters:  3,
+			expectedObservedSize:   3,
+			expectedObservedReady:  2,
+			expectedAssignedClaims: 1,
+			expectedActiveReason:   "Paused",
+		},
+		{
+			name: "paused pool scales down",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(1), testcp.WithMode(hivev1.ClusterPoolModePaused)),
+				unclaimedCDBuilder("c1").Build(testcd.Installed()),
+				unclaimedCDBuilder("c2").Build(testcd.Installed()),
+			},
+			expectedTotalClusters: 1,
+			expectedObservedSize:  2,
+			expectedObservedReady: 2,
+			expectedActiveReason:  "Paused",
+		},
+		{
+			name: "draining pool deletes unclaimed clusters",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(3), testcp.WithMode(hivev1.ClusterPoolModeDraining)),
+				unclaimedCDBuilder("c1").Build(testcd.Installed()),
+				unclaimedCDBuilder("c2").Build(testcd.Installed()),
+				unclaimedCDBuilder("c3").Build(),
+			},
+			expectedTotalClusters: 0,
+			expectedObservedSize:  3,
+			expectedObservedReady: 2,
+			expectedActiveReason:  "Draining",
+		},
+		{
+			name: "draining pool assigns to claims before deleting",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(3), testcp.WithMode(hivev1.ClusterPoolModeDraining)),
+				unclaimedCDBuilder("c1").Build(testcd.Installed()),
+				unclaimedCDBuilder("c2").Build(testcd.Installed()),
+				unclaimedCDBuilder("c3").Build(),
+				testclaim.FullBuilder(testNamespace, "test-claim", scheme).Build(testclaim.WithPool(testLeasePoolName)),
+			},
+			expectedTotalClusters:   1,
+			expectedObservedSize:    3,
+			expectedObservedReady:   2,
+			expectedDeletedClusters: []string{"c3"},
+			expectedAssignedClaims:  1,
+			expectedActiveReason:    "Draining",
+		},
+		{
+			name: "draining pool keeps installing clusters for pending claims",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(3), testcp.WithMode(hivev1.ClusterPoolModeDraining)),
+				unclaimedCDBuilder("c1").Build(testcd.Installed()),
+				unclaimedCDBuilder("c2").Build(),
+				testclaim.FullBuilder(testNamespace, "test-claim-1", scheme).Build(testclaim.WithPool(testLeasePoolName)),
+				testclaim.FullBuilder(testNamespace, "test-claim-2", scheme).Build(testclaim.WithPool(testLeasePoolName)),
+			},
+			expectedTotalClusters:    2,
+			expectedObservedSize:     2,
+			expectedObservedReady:    1,
+			expectedAssignedClaims:   1,
+			expectedUnassignedClaims: 1,
+			expectedActiveReason:     "Draining",
+		},
+		{
+			name: "drained pool",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(3), testcp.WithMode(hivev1.ClusterPoolModeDraining)),
+			},
+			expectedTotalClusters: 0,
+			expectedActiveReason:  "Drained",
+		},
+		{
+			name: "resumed pool",
+			existing: []runtime.Object{
+				poolBuilder.Build(
+					testcp.WithSize(2),
+					testcp.WithMode(hivev1.ClusterPoolModeActive),
+					testcp.WithCondition(hivev1.ClusterPoolCondition{
+						Type:   hivev1.ClusterPoolActiveCondition,
+						Status: corev1.ConditionFalse,
+						Reason: "Paused",
+					}),
+				),
+			},
+			expectedTotalClusters: 2,
+			expectedActiveReason:  "Active",
+		},
+		{
+			name: "install failure budget exceeded",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(3), testcp.WithInstallFailureBudget(2, time.Hour)),
+				unclaimedCDBuilder("c1").Build(),
+				failedProvision("c1", 0, 30*time.Minute),
+				failedProvision("c1", 1, 20*time.Minute),
+				failedProvision("c1", 2, 10*time.Minute),
+			},
+			expectedTotalClusters:             1,
+			expectedObservedSize:              1,
+			expectedBudgetExceededStatus:      corev1.ConditionTrue,
+			expectedInstallFailures:           []string{"c1/c1-0", "c1/c1-1", "c1/c1-2"},
+			expectedProvisioningBlockedStatus: corev1.ConditionTrue,
+			expectedProvisioningBlockedReason: "InstallFailureBudgetExceeded",
+			expectedRequeueAfter:              30 * time.Minute,
+		},
+		{
+			name: "install failure budget exceeded by failures of claimed clusters",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(3), testcp.WithInstallFailureBudget(1, time.Hour)),
+				unclaimedCDBuilder("c1").Build(),
+				cdBuilder("c2").Build(testcd.WithClusterPoolReference(testNamespace, testLeasePoolName, "test-claim")),
+				failedProvision("c1", 0, 50*time.Minute),
+				failedProvision("c2", 0, 40*time.Minute),
+				failedProvision("c2", 1, 5*time.Minute),
+			},
+			expectedTotalClusters:        2,
+			expectedObservedSize:         1,
+			expectedBudgetExceededStatus: corev1.ConditionTrue,
+			expectedRequeueAfter:         20 * time.Minute,
+		},
+		{
+			name: "install failure budget exceeded by recorded failures of deleted provisions",
+			existing: []runtime.Object{
+				poolBuilder.Build(
+					testcp.WithSize(3),
+					testcp.WithInstallFailureBudget(2, time.Hour),
+					testcp.WithInstallFailure("c1/c1-0", time.Now().Add(-30*time.Minute), "AWSInsufficientCapacity"),
+					testcp.WithInstallFailure("gone/gone-0", time.Now().Add(-20*time.Minute), "AWSInsufficientCapacity"),
+				),
+				unclaimedCDBuilder("c1").Build(),
+				failedProvision("c1", 0, 30*time.Minute),
+				failedProvision("c1", 2, 10*time.Minute),
+			},
+			expectedTotalClusters:             1,
+			expectedObservedSize:              1,
+			expectedBudgetExceededStatus:      corev1.ConditionTrue,
+			expectedProvisioningBlockedStatus: corev1.ConditionTrue,
+			expectedProvisioningBlockedReason: "InstallFailureBudgetExceeded",
+			expectedInstallFailures:           []string{"c1/c1-0", "gone/gone-0", "c1/c1-2"},
+			expectedRequeueAfter:              30 * time.Minute,
+		},
+		{
+			name: "recorded install failures dropped once older than the window",
+			existing: []runtime.Object{
+				poolBuilder.Build(
+					testcp.WithSize(3),
+					testcp.WithInstallFailureBudget(0, time.Hour),
+					testcp.WithInstallFailure("gone/gone-0", time.Now().Add(-2*time.Hour), "AWSInsufficientCapacity"),
+				),
+				unclaimedCDBuilder("c1").Build(),
+			},
+			expectedTotalClusters:        3,
+			expectedObservedSize:         1,
+			expectedBudgetExceededStatus: corev1.ConditionFalse,
+			expectedInstallFailures:      []string{},
+		},
+		{
+			name: "install failures within budget",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(3), testcp.WithInstallFailureBudget(2, time.Hour)),
+				unclaimedCDBuilder("c1").Build(),
+				failedProvision("c1", 0, 2*time.Hour),
+				failedProvision("c1", 1, 20*time.Minute),
+				failedProvision("c1", 2, 10*time.Minute),
+			},
+			expectedTotalClusters:        3,
+			expectedObservedSize:         1,
+			expectedBudgetExceededStatus: corev1.ConditionFalse,
+		},
+		{
+			name: "install failures of other clusters not counted",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(3), testcp.WithInstallFailureBudget(0, time.Hour)),
+				unclaimedCDBuilder("c1").Build(),
+				cdBuilder("other").Build(),
+				failedProvision("other", 0, 10*time.Minute),
+			},
+			expectedTotalClusters:        4,
+			expectedObservedSize:         1,
+			expectedBudgetExceededStatus: corev1.ConditionFalse,
+		},
+		{
+			name: "provisioning blocked by install failures",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(2)),
+				unclaimedCDBuilder("c1").Build(),
+				unclaimedCDBuilder("c2").Build(),
+				failedProvisionWithReason("c1", 0, 50*time.Minute, "AWSInsufficientCapacity"),
+				failedProvisionWithReason("c2", 0, 40*time.Minute, "AWSInsufficientCapacity"),
+				failedProvisionWithReason("c1", 1, 10*time.Minute, "KubeAPIWaitFailed"),
+			},
+			expectedTotalClusters:             2,
+			expectedObservedSize:              2,
+			expectedProvisioningBlockedStatus: corev1.ConditionTrue,
+			expectedProvisioningBlockedReason: "AWSInsufficientCapacity",
+		},
+		{
+			name: "provisioning blocked by install failures without reason",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(1)),
+				unclaimedCDBuilder("c1").Build(),
+				failedProvision("c1", 0, 10*time.Minute),
+			},
+			expectedTotalClusters:             1,
+			expectedObservedSize:              1,
+			expectedProvisioningBlockedStatus: corev1.ConditionTrue,
+			expectedProvisioningBlockedReason: "UnknownError",
+		},
+		{
+			name: "provisioning not blocked by old install failures",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(1)),
+				unclaimedCDBuilder("c1").Build(),
+				failedProvisionWithReason("c1", 0, 3*time.Hour, "AWSInsufficientCapacity"),
+			},
+			expectedTotalClusters:             1,
+			expectedObservedSize:              1,
+			expectedProvisioningBlockedStatus: corev1.ConditionFalse,
+			expectedProvisioningBlockedReason: "NoRecentFailures",
+		},
+		{
+			name: "provisioning not blocked while installs succeed",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(2)),
+				unclaimedCDBuilder("c1").Build(),
+				unclaimedCDBuilder("c2").Build(testcd.Installed(), testcd.InstalledTimestamp(time.Now().Add(-10*time.Minute))),
+				failedProvisionWithReason("c1", 0, 30*time.Minute, "AWSInsufficientCapacity"),
+			},
+			expectedTotalClusters:             2,
+			expectedObservedSize:              2,
+			expectedObservedReady:             1,
+			expectedProvisioningBlockedStatus: corev1.ConditionFalse,
+			expectedProvisioningBlockedReason: "InstallsSucceeding",
+		},
+		{
+			name: "provisioning blocked by missing dependencies",
+			existing: []runtime.Object{
+				poolBuilder.Build(
+					testcp.WithSize(1),
+					testcp.WithCondition(hivev1.ClusterPoolCondition{
+						Type:    hivev1.ClusterPoolMissingDependenciesCondition,
+						Status:  corev1.ConditionTrue,
+						Reason:  "Missing",
+						Message: "cluster image set: clusterimagesets.hive.openshift.io \"test-image-set\" not found",
+					}),
+				),
+			},
+			noClusterImageSet:                  true,
+			expectError:                        true,
+			expectedMissingDependenciesStatus:  pointer.BoolPtr(true),
+			expectedMissingDependenciesMessage: `cluster image set: clusterimagesets.hive.openshift.io "test-image-set" not found`,
+			expectedProvisioningBlockedStatus:  corev1.ConditionTrue,
+			expectedProvisioningBlockedReason:  "MissingDependencies",
+		},
+		{
+			name: "clusters of earlier pool spec not current",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(2)),
+				unclaimedCDBuilder("c1").
+					GenericOptions(testgeneric.WithAnnotation(constants.ClusterPoolSpecHashAnnotation, "earlier")).
+					Build(testcd.Installed()),
+				unclaimedCDBuilder("c2").Build(testcd.Installed()),
+			},
+			expectedTotalClusters:            2,
+			expectedObservedSize:             2,
+			expectedObservedReady:            2,
+			expectedAllClustersCurrentReason: "ClustersOutdated",
+		},
+		{
+			name: "running count resumes ready clusters",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(3), testcp.WithRunningCount(2)),
+				unclaimedCDBuilder("c1").Build(testcd.Installed()),
+				unclaimedCDBuilder("c2").Build(testcd.Installed()),
+				unclaimedCDBuilder("c3").Build(testcd.Installed()),
+			},
+			expectedTotalClusters:   3,
+			expectedObservedSize:    3,
+			expectedObservedReady:   3,
+			expectedRunningClusters: []string{"c1", "c2"},
+		},
+		{
+			name: "running count keeps running clusters",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(3), testcp.WithRunningCount(1)),
+				unclaimedCDBuilder("c1").Build(testcd.Installed()),
+				unclaimedCDBuilder("c2").Build(testcd.Installed(), testcd.WithPowerState(hivev1.RunningClusterPowerState)),
+				unclaimedCDBuilder("c3").Build(testcd.Installed()),
+			},
+			expectedTotalClusters:   3,
+			expectedObservedSize:    3,
+			expectedObservedReady:   3,
+			expectedObservedRunning: 1,
+			expectedRunningClusters: []string{"c2"},
+		},
+		{
+			name: "running count hibernates extra running clusters",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(3), testcp.WithRunningCount(1)),
+				unclaimedCDBuilder("c1").Build(testcd.Installed(), testcd.WithPowerState(hivev1.RunningClusterPowerState)),
+				unclaimedCDBuilder("c2").Build(testcd.Installed(), testcd.WithPowerState(hivev1.RunningClusterPowerState)),
+				unclaimedCDBuilder("c3").Build(testcd.Installed()),
+			},
+			expectedTotalClusters:   3,
+			expectedObservedSize:    3,
+			expectedObservedReady:   3,
+			expectedObservedRunning: 2,
+			expectedRunningClusters: []string{"c1"},
+		},
+		{
+			name: "running count filled by installing clusters",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(3), testcp.WithRunningCount(2)),
+				unclaimedCDBuilder("c1").Build(testcd.Installed()),
+				unclaimedCDBuilder("c2").Build(),
+				unclaimedCDBuilder("c3").Build(),
+			},
+			expectedTotalClusters:   3,
+			expectedObservedSize:    3,
+			expectedObservedReady:   1,
+			expectedRunningClusters: []string{"c1", "c2"},
+		},
+		{
+			name: "claims assigned running clusters first",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(3), testcp.WithRunningCount(1)),
+				unclaimedCDBuilder("c1").Build(testcd.Installed()),
+				unclaimedCDBuilder("c2").Build(testcd.Installed(), testcd.WithPowerState(hivev1.RunningClusterPowerState)),
+				unclaimedCDBuilder("c3").Build(testcd.Installed()),
+				testclaim.FullBuilder(testNamespace, "test-claim", scheme).Build(testclaim.WithPool(testLeasePoolName)),
+			},
+			expectedTotalClusters:   4,
+			expectedObservedSize:    3,
+			expectedObservedReady:   3,
+			expectedObservedRunning: 1,
+			expectedAssignedClaims:  1,
+			expectedClaimedClusters: []string{"c2"},
+			expectedRunningClusters: []string{"c1", "c2"},
+		},
+		{
+			name: "scale down deletes hibernating clusters first",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(1), testcp.WithRunningCount(1)),
+				unclaimedCDBuilder("c1").Build(testcd.Installed()),
+				unclaimedCDBuilder("c2").Build(testcd.Installed(), testcd.WithPowerState(hivev1.RunningClusterPowerState)),
+			},
+			expectedTotalClusters:   1,
+			expectedObservedSize:    2,
+			expectedObservedReady:   2,
+			expectedObservedRunning: 1,
+			expectedDeletedClusters: []string{"c1"},
+			expectedRunningClusters: []string{"c2"},
+		},
+		{
+			name: "install failure budget removed",
+			existing: []runtime.Object{
+				poolBuilder.Build(
+					testcp.WithSize(2),
+					testcp.WithCondition(hivev1.ClusterPoolCondition{
+						Type:   hivev1.ClusterPoolInstallFailureBudgetExceededCondition,
+						Status: corev1.ConditionTrue,
+						Reason: "BudgetExceeded",
+					}),
+				),
+			},
+			expectedTotalClusters:        2,
+			expectedBudgetExceededStatus: corev1.ConditionFalse,
+		},
 	}
 
 	for _, test := range tests {
@@ -892,9 +1696,10 @@ func TestReconcileClusterPool(t *testing.T) {
 			logger.SetLevel(log.DebugLevel)
 			controllerExpectations := controllerutils.NewExpectations(logger)
 			rcp := &ReconcileClusterPool{
-				Client:       fakeClient,
-				logger:       logger,
-				expectations: controllerExpectations,
+				Client:               fakeClient,
+				logger:               logger,
+				expectations:         controllerExpectations,
+				defaultMaxConcurrent: test.defaultMaxConcurrent,
 			}
 
 			reconcileRequest := reconcile.Request{
@@ -904,12 +1709,13 @@ func TestReconcileClusterPool(t *testing.T) {
 				},
 			}
 
-			_, err := rcp.Reconcile(reconcileRequest)
+			result, err := rcp.Reconcile(reconcileRequest)
 			if test.expectError {
 				assert.Error(t, err, "expected error from reconcile")
 			} else {
 				assert.NoError(t, err, "expected no error from reconcile")
 			}
+			assert.InDelta(t, test.expectedRequeueAfter, result.RequeueAfter, float64(time.Minute), "unexpected requeue")
 
 			cds := &hivev1.ClusterDeploymentList{}
 			err = fakeClient.List(context.Background(), cds)
@@ -924,12 +1730,22 @@ func TestReconcileClusterPool(t *testing.T) {
 			}
 
 			for _, cd := range cds.Items {
-				assert.Equal(t, hivev1.HibernatingClusterPowerState, cd.Spec.PowerState, "expected cluster to be hibernating")
+				if sets.NewString(test.expectedRunningClusters...).Has(cd.Name) {
+					assert.Equal(t, hivev1.RunningClusterPowerState, cd.Spec.PowerState, "expected cluster %s to be running", cd.Name)
+				} else {
+					assert.Equal(t, hivev1.HibernatingClusterPowerState, cd.Spec.PowerState, "expected cluster %s to be hibernating", cd.Name)
+				}
 				if test.expectedLabels != nil {
 					for k, v := range test.expectedLabels {
 						assert.Equal(t, v, cd.Labels[k])
 					}
 				}
+				if test.expectedReadinessGates != nil {
+					assert.Equal(t, test.expectedReadinessGates, cd.Spec.ReadinessGates, "unexpected readiness gates")
+				}
+				if test.expectedAllClustersCurrentReason == "ClustersCurrent" {
+					assert.NotEmpty(t, cd.Annotations[constants.ClusterPoolSpecHashAnnotation], "expected pool spec hash on cluster %s", cd.Name)
+				}
 			}
 
 			pool := &hivev1.ClusterPool{}
@@ -942,6 +1758,7 @@ func TestReconcileClusterPool(t *testing.T) {
 				assert.Contains(t, pool.Finalizers, finalizer, "expect finalizer on clusterpool")
 				assert.Equal(t, test.expectedObservedSize, pool.Status.Size, "unexpected observed size")
 				assert.Equal(t, test.expectedObservedReady, pool.Status.Ready, "unexpected observed ready count")
+				assert.Equal(t, test.expectedObservedRunning, pool.Status.Running, "unexpected observed running count")
 			}
 
 			missingDependentsCondition := controllerutils.FindClusterPoolCondition(pool.Status.Conditions, hivev1.ClusterPoolMissingDependenciesCondition)
@@ -964,25 +1781,108 @@ func TestReconcileClusterPool(t *testing.T) {
 				}
 				assert.Equal(t, expectedStatus, capacityAvailableCondition.Status, "expected CapacityAvailable condition to be true")
 			}
+			activeCondition := controllerutils.FindClusterPoolCondition(pool.Status.Conditions, hivev1.ClusterPoolActiveCondition)
+			if test.expectedActiveReason != "" {
+				require.NotNil(t, activeCondition, "expected Active condition")
+				assert.Equal(t, test.expectedActiveReason, activeCondition.Reason, "unexpected Active condition reason")
+			} else {
+				assert.Nil(t, activeCondition, "unexpected Active condition")
+			}
+			if test.expectedProvisioningBlockedReason != "" {
+				blockedCondition := controllerutils.FindClusterPoolCondition(pool.Status.Conditions, hivev1.ClusterPoolProvisioningBlockedCondition)
+				require.NotNil(t, blockedCondition, "expected ProvisioningBlocked condition")
+				assert.Equal(t, test.expectedProvisioningBlockedStatus, blockedCondition.Status, "unexpected ProvisioningBlocked condition status")
+				assert.Equal(t, test.expectedProvisioningBlockedReason, blockedCondition.Reason, "unexpected ProvisioningBlocked condition reason")
+			}
+			if test.expectedAllClustersCurrentReason != "" {
+				currentCondition := controllerutils.FindClusterPoolCondition(pool.Status.Conditions, hivev1.ClusterPoolAllClustersCurrentCondition)
+				require.NotNil(t, currentCondition, "expected AllClustersCurrent condition")
+				assert.Equal(t, test.expectedAllClustersCurrentReason, currentCondition.Reason, "unexpected AllClustersCurrent condition reason")
+			}
+			actualInstallFailures := []string{}
+			for _, failure := range pool.Status.InstallFailures {
+				actualInstallFailures = append(actualInstallFailures, failure.ClusterProvision)
+			}
+			if test.expectedInstallFailures != nil {
+				assert.Equal(t, test.expectedInstallFailures, actualInstallFailures, "unexpected install failures")
+			}
+			budgetCondition := controllerutils.FindClusterPoolCondition(pool.Status.Conditions, hivev1.ClusterPoolInstallFailureBudgetExceededCondition)
+			if test.expectedBudgetExceededStatus != "" {
+				require.NotNil(t, budgetCondition, "expected InstallFailureBudgetExceeded condition")
+				assert.Equal(t, test.expectedBudgetExceededStatus, budgetCondition.Status, "unexpected InstallFailureBudgetExceeded condition status")
+			} else {
+				assert.Nil(t, budgetCondition, "unexpected InstallFailureBudgetExceeded condition")
+			}
+			actualRebaseliningClusters := 0
+			for _, cd := range cds.Items {
+				if isRebaselining(&cd) {
+					actualRebaseliningClusters++
+				}
+			}
+			assert.Equal(t, test.expectedRebaseliningClusters, actualRebaseliningClusters, "unexpected number of rebaselining clusters")
+			syncSets := &hivev1.SyncSetList{}
+			require.NoError(t, fakeClient.List(context.Background(), syncSets))
+			assert.Len(t, syncSets.Items, test.expectedRebaselineSyncSets, "unexpected number of rebaseline SyncSets")
+
 			claims := &hivev1.ClusterClaimList{}
 			err = fakeClient.List(context.Background(), claims)
 			require.NoError(t, err)
 
 			actualAssignedClaims := 0
 			actualUnassignedClaims := 0
+			var actualAssignedClaimNames, actualClaimedClusters []string
 			for _, claim := range claims.Items {
 				if claim.Spec.Namespace == "" {
 					actualUnassignedClaims++
 				} else {
 					actualAssignedClaims++
+					actualAssignedClaimNames = append(actualAssignedClaimNames, claim.Name)
+					actualClaimedClusters = append(actualClaimedClusters, claim.Spec.Namespace)
 				}
 			}
 			assert.Equal(t, test.expectedAssignedClaims, actualAssignedClaims, "unexpected number of assigned claims")
 			assert.Equal(t, test.expectedUnassignedClaims, actualUnassignedClaims, "unexpected number of unassigned claims")
+			if test.expectedAssignedClaimNames != nil {
+				assert.ElementsMatch(t, test.expectedAssignedClaimNames, actualAssignedClaimNames, "unexpected assigned claims")
+			}
+			if test.expectedClaimedClusters != nil {
+				assert.ElementsMatch(t, test.expectedClaimedClusters, actualClaimedClusters, "unexpected claimed clusters")
+			}
 		})
 	}
 }
 
+func rebaselineSyncSetName(cdName string) string {
+	return cdName + "-rebaseline-wipe"
+}
+
+func rebaselineSyncSet(cdName string, generation int64) *hivev1.SyncSet {
+	return &hivev1.SyncSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:  cdName,
+			Name:       rebaselineSyncSetName(cdName),
+			Generation: generation,
+			Labels: map[string]string{
+				constants.RebaselineSyncSetLabel:     "true",
+				constants.ClusterDeploymentNameLabel: cdName,
+			},
+		},
+	}
+}
+
+func clusterSync(cdName, syncSetName string, observedGeneration int64) *hiveintv1alpha1.ClusterSync {
+	return &hiveintv1alpha1.ClusterSync{
+		ObjectMeta: metav1.ObjectMeta{Namespace: cdName, Name: cdName},
+		Status: hiveintv1alpha1.ClusterSyncStatus{
+			SyncSets: []hiveintv1alpha1.SyncStatus{{
+				Name:               syncSetName,
+				ObservedGeneration: observedGeneration,
+				Result:             hiveintv1alpha1.SuccessSyncSetResult,
+			}},
+		},
+	}
+}
+
 func TestReconcileRBAC(t *testing.T) {
 	scheme := runtime.NewScheme()
 	hivev1.AddToScheme(scheme)
@@ -0,0 +1,213 @@
+package clusterpool
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	// defaultInstallFailureWindow is how far back the install failures of a pool without an install failure budget are
+	// considered when reporting whether its provisioning is blocked.
+	defaultInstallFailureWindow = 2 * time.Hour
+
+	// unknownInstallFailureReason is the reason of install failures whose ClusterProvision does not report one.
+	unknownInstallFailureReason = "UnknownError"
+)
+
+// setProvisioningBlockedCondition reports with the ProvisioningBlocked condition whether the pool is failing to
+// provision clusters: its install failure budget is exceeded, its dependencies are missing, or installs of its
+// clusters failed within the window and none succeeded. The reason of the condition is the most common reason of the
+// recent install failures, and its message counts the failures by reason.
+func (r *ReconcileClusterPool) setProvisioningBlockedCondition(pool *hivev1.ClusterPool, failures []installFailure, cds []*hivev1.ClusterDeployment, budgetExceeded bool, logger log.FieldLogger) error {
+	window := installFailureWindow(pool)
+	windowStart := time.Now().Add(-window)
+	installed := 0
+	for _, cd := range cds {
+		if cd.Status.InstalledTimestamp != nil && cd.Status.InstalledTimestamp.After(windowStart) {
+			installed++
+		}
+	}
+
+	status := corev1.ConditionFalse
+	reason := "NoRecentFailures"
+	message := fmt.Sprintf("No install of the pool failed in the last %s", window)
+	if len(failures) > 0 {
+		reason = "InstallsSucceeding"
+		message = fmt.Sprintf("%d installs of the pool succeeded and %d failed in the last %s: %s",
+			installed, len(failures), window, summarizeInstallFailures(failures))
+	}
+	missingDependencies := controllerutils.FindClusterPoolCondition(pool.Status.Conditions, hivev1.ClusterPoolMissingDependenciesCondition)
+	switch {
+	case budgetExceeded:
+		status = corev1.ConditionTrue
+		reason = "InstallFailureBudgetExceeded"
+		message = fmt.Sprintf("The install failure budget of the pool is exceeded, %d installs failed in the last %s: %s",
+			len(failures), window, summarizeInstallFailures(failures))
+	case missingDependencies != nil && missingDependencies.Status == corev1.ConditionTrue:
+		status = corev1.ConditionTrue
+		reason = "MissingDependencies"
+		message = missingDependencies.Message
+	case len(failures) > 0 && installed == 0:
+		status = corev1.ConditionTrue
+		reason = mostCommonInstallFailureReason(failures)
+		message = fmt.Sprintf("No install of the pool succeeded and %d failed in the last %s: %s",
+			len(failures), window, summarizeInstallFailures(failures))
+	}
+	if status == corev1.ConditionTrue {
+		logger.WithField("reason", reason).Info("provisioning of the pool is blocked")
+	}
+
+	conds, changed := controllerutils.SetClusterPoolConditionWithChangeCheck(
+		pool.Status.Conditions,
+		hivev1.ClusterPoolProvisioningBlockedCondition,
+		status,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	if changed {
+		pool.Status.Conditions = conds
+		if err := r.Status().Update(context.Background(), pool); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update ClusterPool conditions")
+			return errors.Wrap(err, "could not update ClusterPool conditions")
+		}
+	}
+	return nil
+}
+
+// installFailureReasonCounts returns the reasons of the install failures with how many failed for each, the most
+// common first and then by reason.
+func installFailureReasonCounts(failures []installFailure) ([]string, map[string]int) {
+	counts := map[string]int{}
+	for _, f := range failures {
+		counts[f.reason]++
+	}
+	reasons := make([]string, 0, len(counts))
+	for reason := range counts {
+		reasons = append(reasons, reason)
+	}
+	sort.Slice(reasons, func(i, j int) bool {
+		if counts[reasons[i]] != counts[reasons[j]] {
+			return counts[reasons[i]] > counts[reasons[j]]
+		}
+		return reasons[i] < reasons[j]
+	})
+	return reasons, counts
+}
+
+// mostCommonInstallFailureReason returns the reason of the most of the install failures.
+func mostCommonInstallFailureReason(failures []installFailure) string {
+	reasons, _ := installFailureReasonCounts(failures)
+	if len(reasons) == 0 {
+		return unknownInstallFailureReason
+	}
+	return reasons[0]
+}
+
+// summarizeInstallFailures returns the reasons of the install failures with their counts, such as
+// "AWSInsufficientCapacity (2), UnknownError (1)".
+func summarizeInstallFailures(failures []installFailure) string {
+	reasons, counts := installFailureReasonCounts(failures)
+	summary := make([]string, len(reasons))
+	for i, reason := range reasons {
+		summary[i] = fmt.Sprintf("%s (%d)", reason, counts[reason])
+	}
+	return strings.Join(summary, ", ")
+}
+
+// poolSpecHash returns a hash of the fields of the spec of the pool which shape the clusters it creates, so that the
+// clusters created before the fields changed can be told apart. Other fields, such as the size of the pool, do not
+// change the hash.
+func poolSpecHash(pool *hivev1.ClusterPool) (string, error) {
+	spec := pool.Spec
+	fields := []interface{}{
+		spec.Platform,
+		spec.Platforms,
+		spec.PullSecretRef,
+		spec.BaseDomain,
+		spec.BaseDomainPoolRef,
+		spec.ImageSetRef,
+		spec.Labels,
+		spec.InstallConfigSecretTemplateRef,
+		spec.HibernateAfter,
+		spec.SkipMachinePools,
+		spec.ReadinessGates,
+	}
+	// Fields added after the hash was introduced only take part in it when set, so that the hash of the pools which
+	// do not use them is unchanged.
+	if spec.ResumeReadiness != nil {
+		fields = append(fields, spec.ResumeReadiness)
+	}
+	b, err := json.Marshal(fields)
+	if err != nil {
+		return "", err
+	}
+	sum := md5.Sum(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// setAllClustersCurrentCondition reports with the AllClustersCurrent condition whether the unclaimed clusters of the
+// pool match its current spec: none were created before the fields of the spec shaping its clusters changed, and none
+// are older than its max cluster age. Clusters created before the pool recorded its spec on them are assumed current.
+func (r *ReconcileClusterPool) setAllClustersCurrentCondition(pool *hivev1.ClusterPool, cds []*hivev1.ClusterDeployment, stale int, logger log.FieldLogger) error {
+	hash, err := poolSpecHash(pool)
+	if err != nil {
+		return errors.Wrap(err, "could not compute hash of ClusterPool spec")
+	}
+	outdated := 0
+	for _, cd := range cds {
+		if cdHash, ok := cd.Annotations[constants.ClusterPoolSpecHashAnnotation]; ok && cdHash != hash {
+			outdated++
+		}
+	}
+
+	status := corev1.ConditionTrue
+	reason := "ClustersCurrent"
+	message := "All unclaimed clusters of the pool match its current spec"
+	var notCurrent []string
+	if outdated > 0 {
+		reason = "ClustersOutdated"
+		notCurrent = append(notCurrent, fmt.Sprintf("%d unclaimed clusters were created from an earlier spec of the pool", outdated))
+	}
+	if stale > 0 {
+		if outdated == 0 {
+			reason = "ClustersStale"
+		}
+		notCurrent = append(notCurrent, fmt.Sprintf("%d unclaimed clusters are older than the max cluster age of the pool", stale))
+	}
+	if len(notCurrent) > 0 {
+		status = corev1.ConditionFalse
+		message = strings.Join(notCurrent, "; ")
+	}
+
+	conds, changed := controllerutils.SetClusterPoolConditionWithChangeCheck(
+		pool.Status.Conditions,
+		hivev1.ClusterPoolAllClustersCurrentCondition,
+		status,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	if changed {
+		pool.Status.Conditions = conds
+		if err := r.Status().Update(context.Background(), pool); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update ClusterPool conditions")
+			return errors.Wrap(err, "could not update ClusterPool conditions")
+		}
+	}
+	return nil
+}
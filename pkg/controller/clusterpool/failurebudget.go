@@ -0,0 +1,160 @@
+package clusterpool
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+// installFailure is a failed install of a cluster of the pool.
+type installFailure struct {
+	time time.Time
+	// reason is the reason of the failure of the ClusterProvision, such as AWSInsufficientCapacity.
+	reason string
+	// platform is the name of the platform of the pool the cluster was created on.
+	platform string
+}
+
+// installFailureWindow returns how far back the install failures of the pool are considered: the window of its install
+// failure budget, or defaultInstallFailureWindow when it has no budget.
+func installFailureWindow(pool *hivev1.ClusterPool) time.Duration {
+	if budget := pool.Spec.InstallFailureBudget; budget != nil {
+		return budget.Window.Duration
+	}
+	return defaultInstallFailureWindow
+}
+
+// recordInstallFailures records the install failures of the given clusters of the pool in its status, drops those
+// which are older than the window, and returns the remaining failures sorted from the earliest. Failures are recorded
+// from the failed ClusterProvisions of the clusters and kept in the status, so they are still counted once old
+// ClusterProvisions are pruned or the clusters are deleted.
+func (r *ReconcileClusterPool) recordInstallFailures(pool *hivev1.ClusterPool, window time.Duration, cds []*hivev1.ClusterDeployment, logger log.FieldLogger) ([]installFailure, error) {
+	// poolCDs maps the clusters of the pool to the name of the platform they were created on.
+	poolCDs := make(map[types.NamespacedName]string, len(cds))
+	for _, cd := range cds {
+		platform := ""
+		if cd.Spec.ClusterPoolRef != nil {
+			platform = cd.Spec.ClusterPoolRef.PlatformName
+		}
+		poolCDs[types.NamespacedName{Namespace: cd.Namespace, Name: cd.Name}] = platform
+	}
+	provisions := &hivev1.ClusterProvisionList{}
+	if err := r.Client.List(context.Background(), provisions); err != nil {
+		logger.WithError(err).Error("error listing ClusterProvisions")
+		return nil, err
+	}
+	windowStart := time.Now().Add(-window)
+
+	recorded := make(map[string]bool, len(pool.Status.InstallFailures))
+	var records []hivev1.ClusterPoolInstallFailure
+	changed := false
+	for _, record := range pool.Status.InstallFailures {
+		if !record.Time.After(windowStart) {
+			changed = true
+			continue
+		}
+		recorded[record.ClusterProvision] = true
+		records = append(records, record)
+	}
+	for _, provision := range provisions.Items {
+		key := types.NamespacedName{Namespace: provision.Namespace, Name: provision.Name}.String()
+		platform, ok := poolCDs[types.NamespacedName{Namespace: provision.Namespace, Name: provision.Spec.ClusterDeploymentRef.Name}]
+		if provision.Spec.Stage != hivev1.ClusterProvisionStageFailed || !ok || recorded[key] {
+			continue
+		}
+		record := hivev1.ClusterPoolInstallFailure{
+			ClusterProvision: key,
+			Time:             provision.CreationTimestamp,
+			Reason:           unknownInstallFailureReason,
+			Platform:         platform,
+		}
+		if cond := controllerutils.FindClusterProvisionCondition(provision.Status.Conditions, hivev1.ClusterProvisionFailedCondition); cond != nil &&
+			cond.Status == corev1.ConditionTrue {
+			record.Time = cond.LastTransitionTime
+			if cond.Reason != "" {
+				record.Reason = cond.Reason
+			}
+		}
+		if !record.Time.After(windowStart) {
+			continue
+		}
+		logger.WithField("clusterProvision", key).WithField("reason", record.Reason).Info("recording install failure of the pool")
+		records = append(records, record)
+		changed = true
+	}
+	sort.Slice(records, func(i, j int) bool { return records[i].Time.Before(&records[j].Time) })
+
+	if changed {
+		pool.Status.InstallFailures = records
+		if err := r.Status().Update(context.Background(), pool); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update install failures of ClusterPool")
+			return nil, errors.Wrap(err, "could not update install failures of ClusterPool")
+		}
+	}
+
+	failures := make([]installFailure, len(records))
+	for i, record := range records {
+		failures[i] = installFailure{time: record.Time.Time, reason: record.Reason, platform: record.Platform}
+	}
+	return failures, nil
+}
+
+// reconcileInstallFailureBudget reports with the InstallFailureBudgetExceeded condition whether more of the given
+// recent install failures of the clusters of the pool are within the window of its budget than allowed. It returns whether the budget is
+// exceeded, and when it is, how long until enough of the failures leave the window for the pool to create clusters
+// again. Pools without a budget do not have the condition.
+func (r *ReconcileClusterPool) reconcileInstallFailureBudget(pool *hivev1.ClusterPool, failures []installFailure, logger log.FieldLogger) (bool, time.Duration, error) {
+	budget := pool.Spec.InstallFailureBudget
+	if budget == nil &&
+		controllerutils.FindClusterPoolCondition(pool.Status.Conditions, hivev1.ClusterPoolInstallFailureBudgetExceededCondition) == nil {
+		return false, 0, nil
+	}
+
+	exceeded := false
+	var retryAfter time.Duration
+	status := corev1.ConditionFalse
+	reason := "WithinBudget"
+	message := "Install failures are within the budget of the pool"
+	if budget == nil {
+		reason = "NoBudget"
+		message = "The pool has no install failure budget"
+	} else {
+		if len(failures) > int(budget.MaxFailures) {
+			exceeded = true
+			// The pool is within its budget again once all but MaxFailures of the failures have left the window.
+			retryAt := failures[len(failures)-int(budget.MaxFailures)-1].time.Add(budget.Window.Duration)
+			retryAfter = time.Until(retryAt)
+			status = corev1.ConditionTrue
+			reason = "BudgetExceeded"
+			message = fmt.Sprintf("%d installs failed in the last %s, more than the budget of %d; not creating clusters until %s",
+				len(failures), budget.Window.Duration, budget.MaxFailures, retryAt.UTC().Format(time.RFC3339))
+			logger.WithField("failures", len(failures)).WithField("retryAt", retryAt).Info("install failure budget of the pool exceeded")
+		}
+	}
+
+	conds, changed := controllerutils.SetClusterPoolConditionWithChangeCheck(
+		pool.Status.Conditions,
+		hivev1.ClusterPoolInstallFailureBudgetExceededCondition,
+		status,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	if changed {
+		pool.Status.Conditions = conds
+		if err := r.Status().Update(context.Background(), pool); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update ClusterPool conditions")
+			return false, 0, errors.Wrap(err, "could not update ClusterPool conditions")
+		}
+	}
+	return exceeded, retryAfter, nil
+}
@@ -0,0 +1,232 @@
+package clusterpool
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+// invalidCustomizationError is returned when a ClusterDeploymentCustomization cannot be applied to the resources of a
+// new cluster.
+type invalidCustomizationError struct {
+	name string
+	err  error
+}
+
+func (e *invalidCustomizationError) Error() string {
+	return fmt.Sprintf("could not apply ClusterDeploymentCustomization %s: %v", e.name, e.err)
+}
+
+// requestsForInventory enqueues the pools of the namespace of a ClusterDeploymentCustomization whose inventory
+// references it.
+func requestsForInventory(c client.Client, logger log.FieldLogger) handler.ToRequestsFunc {
+	return func(o handler.MapObject) []reconcile.Request {
+		cpList := &hivev1.ClusterPoolList{}
+		if err := c.List(context.Background(), cpList, client.InNamespace(o.Meta.GetNamespace())); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to list cluster pools for ClusterDeploymentCustomization")
+			return nil
+		}
+		var requests []reconcile.Request
+		for _, clp := range cpList.Items {
+			for _, entry := range clp.Spec.Inventory {
+				if entry.Name != o.Meta.GetName() {
+					continue
+				}
+				requests = append(requests, reconcile.Request{
+					NamespacedName: types.NamespacedName{Namespace: clp.Namespace, Name: clp.Name},
+				})
+				break
+			}
+		}
+		return requests
+	}
+}
+
+// reconcileInventory returns the ClusterDeploymentCustomizations of the inventory of the pool which can be applied to
+// new clusters, in the order of the inventory. The status of the customizations is updated to reference the clusters
+// of the pool they are applied to, and cleared once those clusters are gone. It also returns the names of the entries
+// which do not exist.
+func (r *ReconcileClusterPool) reconcileInventory(
+	clp *hivev1.ClusterPool,
+	cds []*hivev1.ClusterDeployment,
+	logger log.FieldLogger,
+) (available []*hivev1.ClusterDeploymentCustomization, missing []string, err error) {
+	inUse := map[string]string{}
+	for _, cd := range cds {
+		if ref := cd.Spec.ClusterPoolRef; ref != nil && ref.CustomizationRef != nil {
+			inUse[ref.CustomizationRef.Name] = cd.Name
+		}
+	}
+
+	for _, entry := range clp.Spec.Inventory {
+		cdcLog := logger.WithField("customization", entry.Name)
+		cdc := &hivev1.ClusterDeploymentCustomization{}
+		switch err := r.Get(context.Background(), client.ObjectKey{Namespace: clp.Namespace, Name: entry.Name}, cdc); {
+		case apierrors.IsNotFound(err):
+			cdcLog.Info("ClusterDeploymentCustomization of the inventory not found")
+			missing = append(missing, entry.Name)
+			continue
+		case err != nil:
+			cdcLog.WithError(err).Error("error getting ClusterDeploymentCustomization")
+			return nil, nil, err
+		}
+
+		status := hivev1.ClusterDeploymentCustomizationStatus{}
+		cdName, used := inUse[cdc.Name]
+		switch {
+		case used:
+			status.ClusterPoolRef = &corev1.LocalObjectReference{Name: clp.Name}
+			status.ClusterDeploymentRef = &corev1.LocalObjectReference{Name: cdName}
+		case cdc.Status.ClusterPoolRef != nil && cdc.Status.ClusterPoolRef.Name != clp.Name:
+			cdcLog.WithField("pool", cdc.Status.ClusterPoolRef.Name).Debug("ClusterDeploymentCustomization is used by another pool")
+			continue
+		}
+		if !equalCustomizationStatus(cdc.Status, status) {
+			cdcLog.WithField("cluster", cdName).Info("updating status of ClusterDeploymentCustomization")
+			cdc.Status = status
+			if err := r.Status().Update(context.Background(), cdc); err != nil {
+				cdcLog.WithError(err).Log(controllerutils.LogLevel(err), "could not update status of ClusterDeploymentCustomization")
+				return nil, nil, err
+			}
+		}
+		if !used {
+			available = append(available, cdc)
+		}
+	}
+	return available, missing, nil
+}
+
+func equalCustomizationStatus(a, b hivev1.ClusterDeploymentCustomizationStatus) bool {
+	equalRef := func(x, y *corev1.LocalObjectReference) bool {
+		if x == nil || y == nil {
+			return x == y
+		}
+		return x.Name == y.Name
+	}
+	return equalRef(a.ClusterPoolRef, b.ClusterPoolRef) && equalRef(a.ClusterDeploymentRef, b.ClusterDeploymentRef)
+}
+
+// reserveCustomization marks the ClusterDeploymentCustomization as applied to the cluster about to be created, so that
+// it is not applied to another cluster. The reservation is released by reconcileInventory if the cluster is not created.
+func (r *ReconcileClusterPool) reserveCustomization(clp *hivev1.ClusterPool, cdc *hivev1.ClusterDeploymentCustomization, cdName string, logger log.FieldLogger) error {
+	cdc.Status.ClusterPoolRef = &corev1.LocalObjectReference{Name: clp.Name}
+	cdc.Status.ClusterDeploymentRef = &corev1.LocalObjectReference{Name: cdName}
+	if err := r.Status().Update(context.Background(), cdc); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not reserve ClusterDeploymentCustomization")
+		return err
+	}
+	return nil
+}
+
+// applyCustomization applies the ClusterDeploymentCustomization to the resources built for a new cluster: the cluster
+// name of the ClusterDeployment, and the install-config, to which the JSON patches of the customization are applied.
+// The base domain is customized by the builder.
+func applyCustomization(objs []runtime.Object, cdc *hivev1.ClusterDeploymentCustomization) error {
+	var cd *hivev1.ClusterDeployment
+	secrets := map[string]*corev1.Secret{}
+	for _, obj := range objs {
+		switch o := obj.(type) {
+		case *hivev1.ClusterDeployment:
+			cd = o
+		case *corev1.Secret:
+			secrets[o.Name] = o
+		}
+	}
+	if cd == nil || cd.Spec.Provisioning == nil || cd.Spec.Provisioning.InstallConfigSecretRef == nil {
+		return errors.New("no install-config to customize")
+	}
+	icSecret, ok := secrets[cd.Spec.Provisioning.InstallConfigSecretRef.Name]
+	if !ok {
+		return errors.New("no install-config to customize")
+	}
+
+	var patches []hivev1.PatchEntity
+	if name := cdc.Spec.ClusterName; name != "" {
+		cd.Spec.ClusterName = name
+		for i := range cd.Spec.Ingress {
+			cd.Spec.Ingress[i].Domain = fmt.Sprintf("apps.%s.%s", name, cd.Spec.BaseDomain)
+		}
+		patches = append(patches, hivev1.PatchEntity{Op: "replace", Path: "/metadata/name", Value: name})
+	}
+	patches = append(patches, cdc.Spec.InstallConfigPatches...)
+	if len(patches) == 0 {
+		return nil
+	}
+
+	rawPatch, err := json.Marshal(patches)
+	if err != nil {
+		return err
+	}
+	patch, err := jsonpatch.DecodePatch(rawPatch)
+	if err != nil {
+		return errors.Wrap(err, "invalid install-config patches")
+	}
+	installConfig, err := yaml.YAMLToJSON([]byte(icSecret.StringData["install-config.yaml"]))
+	if err != nil {
+		return errors.Wrap(err, "could not parse install-config")
+	}
+	installConfig, err = patch.Apply(installConfig)
+	if err != nil {
+		return errors.Wrap(err, "could not patch install-config")
+	}
+	installConfig, err = yaml.JSONToYAML(installConfig)
+	if err != nil {
+		return err
+	}
+	icSecret.StringData["install-config.yaml"] = string(installConfig)
+	return nil
+}
+
+// setInventoryValidCondition reports whether all of the entries of the inventory of the pool exist and could be applied
+// to its clusters. Pools without an inventory do not have the condition.
+func (r *ReconcileClusterPool) setInventoryValidCondition(pool *hivev1.ClusterPool, missing, invalid []string, logger log.FieldLogger) error {
+	if len(pool.Spec.Inventory) == 0 &&
+		controllerutils.FindClusterPoolCondition(pool.Status.Conditions, hivev1.ClusterPoolInventoryValidCondition) == nil {
+		return nil
+	}
+	status := corev1.ConditionTrue
+	reason := "Valid"
+	message := "All entries of the inventory are valid"
+	switch {
+	case len(missing) > 0:
+		status = corev1.ConditionFalse
+		reason = "Missing"
+		message = fmt.Sprintf("ClusterDeploymentCustomizations of the inventory not found: %s", strings.Join(missing, ", "))
+	case len(invalid) > 0:
+		status = corev1.ConditionFalse
+		reason = "Invalid"
+		message = fmt.Sprintf("ClusterDeploymentCustomizations of the inventory could not be applied: %s", strings.Join(invalid, ", "))
+	}
+	conds, changed := controllerutils.SetClusterPoolConditionWithChangeCheck(
+		pool.Status.Conditions,
+		hivev1.ClusterPoolInventoryValidCondition,
+		status,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	if changed {
+		pool.Status.Conditions = conds
+		if err := r.Status().Update(context.Background(), pool); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update ClusterPool conditions")
+			return errors.Wrap(err, "could not update ClusterPool conditions")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,237 @@
+package clusterpool
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ghodss/yaml"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+	testcp "github.com/openshift/hive/pkg/test/clusterpool"
+	testgeneric "github.com/openshift/hive/pkg/test/generic"
+	testsecret "github.com/openshift/hive/pkg/test/secret"
+)
+
+func TestReconcileInventory(t *testing.T) {
+	scheme := runtime.NewScheme()
+	hivev1.AddToScheme(scheme)
+	corev1.AddToScheme(scheme)
+	rbacv1.AddToScheme(scheme)
+
+	poolBuilder := testcp.FullBuilder(testNamespace, testLeasePoolName, scheme).
+		GenericOptions(
+			testgeneric.WithFinalizer(finalizer),
+		).
+		Options(
+			testcp.ForAWS(credsSecretName, "us-east-1"),
+			testcp.WithBaseDomain("test-domain"),
+			testcp.WithImageSet(imageSetName),
+			func(pool *hivev1.ClusterPool) {
+				pool.Spec.Inventory = []hivev1.InventoryEntry{{Name: "cdc1"}, {Name: "cdc2"}}
+			},
+		)
+	cdc := func(name string, opts ...func(*hivev1.ClusterDeploymentCustomization)) *hivev1.ClusterDeploymentCustomization {
+		cdc := &hivev1.ClusterDeploymentCustomization{
+			ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: name},
+			Spec:       hivev1.ClusterDeploymentCustomizationSpec{ClusterName: name + "-cluster"},
+		}
+		for _, o := range opts {
+			o(cdc)
+		}
+		return cdc
+	}
+	usedBy := func(pool, cd string) func(*hivev1.ClusterDeploymentCustomization) {
+		return func(cdc *hivev1.ClusterDeploymentCustomization) {
+			cdc.Status.ClusterPoolRef = &corev1.LocalObjectReference{Name: pool}
+			cdc.Status.ClusterDeploymentRef = &corev1.LocalObjectReference{Name: cd}
+		}
+	}
+	customizedCD := func(name, customization string) *hivev1.ClusterDeployment {
+		return testcd.FullBuilder(name, name, scheme).Build(
+			testcd.WithPowerState(hivev1.HibernatingClusterPowerState),
+			func(cd *hivev1.ClusterDeployment) {
+				cd.Spec.ClusterPoolRef = &hivev1.ClusterPoolReference{
+					Namespace:        testNamespace,
+					PoolName:         testLeasePoolName,
+					CustomizationRef: &corev1.LocalObjectReference{Name: customization},
+				}
+			},
+		)
+	}
+
+	tests := []struct {
+		name                  string
+		existing              []runtime.Object
+		expectedNewClusters   map[string]string
+		expectedCustomization map[string]string
+		expectedValidReason   string
+		expectedNamespaces    int
+	}{
+		{
+			name: "create clusters for available entries",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(3)),
+				cdc("cdc1", func(cdc *hivev1.ClusterDeploymentCustomization) {
+					cdc.Spec.BaseDomain = "sub.test-domain"
+					cdc.Spec.InstallConfigPatches = []hivev1.PatchEntity{{Op: "add", Path: "/sshKey", Value: "ssh-rsa test"}}
+				}),
+				cdc("cdc2"),
+			},
+			expectedNewClusters: map[string]string{"cdc1": "cdc1-cluster", "cdc2": "cdc2-cluster"},
+			expectedValidReason: "Valid",
+			expectedNamespaces:  2,
+		},
+		{
+			name: "entry in use",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(2)),
+				customizedCD("c1", "cdc1"),
+				cdc("cdc1"),
+				cdc("cdc2"),
+			},
+			expectedNewClusters:   map[string]string{"cdc2": "cdc2-cluster"},
+			expectedCustomization: map[string]string{"cdc1": "c1"},
+			expectedValidReason:   "Valid",
+			expectedNamespaces:    1,
+		},
+		{
+			name: "entry released once its cluster is gone",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(1)),
+				cdc("cdc1", usedBy(testLeasePoolName, "gone")),
+				cdc("cdc2", usedBy(testLeasePoolName, "gone")),
+			},
+			expectedNewClusters: map[string]string{"cdc1": "cdc1-cluster"},
+			expectedValidReason: "Valid",
+			expectedNamespaces:  1,
+		},
+		{
+			name: "entry used by another pool",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(2)),
+				cdc("cdc1", usedBy("other-pool", "other-cluster")),
+				cdc("cdc2"),
+			},
+			expectedNewClusters:   map[string]string{"cdc2": "cdc2-cluster"},
+			expectedCustomization: map[string]string{"cdc1": "other-cluster"},
+			expectedValidReason:   "Valid",
+			expectedNamespaces:    1,
+		},
+		{
+			name: "missing entry",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(2)),
+				cdc("cdc2"),
+			},
+			expectedNewClusters: map[string]string{"cdc2": "cdc2-cluster"},
+			expectedValidReason: "Missing",
+			expectedNamespaces:  1,
+		},
+		{
+			name: "invalid entry",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(2)),
+				cdc("cdc1", func(cdc *hivev1.ClusterDeploymentCustomization) {
+					cdc.Spec.InstallConfigPatches = []hivev1.PatchEntity{{Op: "remove", Path: "/does/not/exist"}}
+				}),
+				cdc("cdc2"),
+			},
+			expectedNewClusters: map[string]string{"cdc2": "cdc2-cluster"},
+			expectedValidReason: "Invalid",
+			expectedNamespaces:  1,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.existing = append(
+				test.existing,
+				&hivev1.ClusterImageSet{
+					ObjectMeta: metav1.ObjectMeta{Name: imageSetName},
+					Spec:       hivev1.ClusterImageSetSpec{ReleaseImage: "test-release-image"},
+				},
+				testsecret.FullBuilder(testNamespace, credsSecretName, scheme).
+					Build(testsecret.WithDataKeyValue("dummykey", []byte("dummyval"))),
+			)
+			fakeClient := fake.NewFakeClientWithScheme(scheme, test.existing...)
+			logger := log.New()
+			logger.SetLevel(log.DebugLevel)
+			rcp := &ReconcileClusterPool{
+				Client:       fakeClient,
+				logger:       logger,
+				expectations: controllerutils.NewExpectations(logger),
+			}
+
+			_, err := rcp.Reconcile(reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: testLeasePoolName},
+			})
+			require.NoError(t, err, "unexpected error from reconcile")
+
+			cds := &hivev1.ClusterDeploymentList{}
+			require.NoError(t, fakeClient.List(context.Background(), cds))
+			newClusters := map[string]string{}
+			for _, cd := range cds.Items {
+				if cd.Spec.ClusterPoolRef == nil || cd.Spec.ClusterPoolRef.CustomizationRef == nil {
+					t.Errorf("cluster %s is not customized", cd.Name)
+					continue
+				}
+				customization := cd.Spec.ClusterPoolRef.CustomizationRef.Name
+				if test.expectedCustomization[customization] == cd.Name {
+					continue
+				}
+				newClusters[customization] = cd.Spec.ClusterName
+
+				cdc := &hivev1.ClusterDeploymentCustomization{}
+				require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKey{Namespace: testNamespace, Name: customization}, cdc))
+				if assert.NotNil(t, cdc.Status.ClusterDeploymentRef, "expected customization to reference its cluster") {
+					assert.Equal(t, cd.Name, cdc.Status.ClusterDeploymentRef.Name, "unexpected cluster of customization")
+				}
+
+				secret := &corev1.Secret{}
+				require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKey{Namespace: cd.Namespace, Name: cd.Spec.Provisioning.InstallConfigSecretRef.Name}, secret))
+				installConfig := map[string]interface{}{}
+				require.NoError(t, yaml.Unmarshal([]byte(secret.StringData["install-config.yaml"]), &installConfig))
+				assert.Equal(t, cd.Spec.ClusterName, installConfig["metadata"].(map[string]interface{})["name"], "unexpected install-config cluster name")
+				assert.Equal(t, cd.Spec.BaseDomain, installConfig["baseDomain"], "unexpected install-config base domain")
+				for _, patch := range cdc.Spec.InstallConfigPatches {
+					assert.Equal(t, patch.Value, installConfig[patch.Path[1:]], "expected install-config to be patched")
+				}
+				if cdc.Spec.BaseDomain != "" {
+					assert.Equal(t, cdc.Spec.BaseDomain, cd.Spec.BaseDomain, "unexpected base domain")
+				}
+			}
+			assert.Equal(t, test.expectedNewClusters, newClusters, "unexpected new clusters")
+
+			for name, cdName := range test.expectedCustomization {
+				cdc := &hivev1.ClusterDeploymentCustomization{}
+				require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKey{Namespace: testNamespace, Name: name}, cdc))
+				if assert.NotNil(t, cdc.Status.ClusterDeploymentRef, "expected customization to reference its cluster") {
+					assert.Equal(t, cdName, cdc.Status.ClusterDeploymentRef.Name, "unexpected cluster of customization")
+				}
+			}
+
+			namespaces := &corev1.NamespaceList{}
+			require.NoError(t, fakeClient.List(context.Background(), namespaces))
+			assert.Len(t, namespaces.Items, test.expectedNamespaces, "unexpected number of namespaces")
+
+			pool := &hivev1.ClusterPool{}
+			require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKey{Namespace: testNamespace, Name: testLeasePoolName}, pool))
+			cond := controllerutils.FindClusterPoolCondition(pool.Status.Conditions, hivev1.ClusterPoolInventoryValidCondition)
+			if assert.NotNil(t, cond, "expected InventoryValid condition") {
+				assert.Equal(t, test.expectedValidReason, cond.Reason, "unexpected InventoryValid reason")
+			}
+		})
+	}
+}
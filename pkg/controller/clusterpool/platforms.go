@@ -0,0 +1,117 @@
+package clusterpool
+
+import (
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/clusterresource"
+)
+
+// platformFailoverFailures is the number of recent install failures of the clusters of a platform of the pool after
+// which new clusters are created on its other platforms instead.
+const platformFailoverFailures = 3
+
+// poolPlatform is a platform new clusters of a pool are created on.
+type poolPlatform struct {
+	// name is the name of the entry of the platforms of the pool, empty for the platform of a pool with a single one.
+	name         string
+	weight       int
+	cloudBuilder clusterresource.CloudBuilder
+}
+
+// createPoolPlatforms returns the platforms new clusters of the pool are created on: the platform of the pool, or the
+// entries of its platforms with a weight above 0.
+func (r *ReconcileClusterPool) createPoolPlatforms(pool *hivev1.ClusterPool, logger log.FieldLogger) ([]*poolPlatform, error) {
+	if len(pool.Spec.Platforms) == 0 {
+		cloudBuilder, err := r.createCloudBuilder(pool, pool.Spec.Platform, logger)
+		if err != nil {
+			return nil, err
+		}
+		return []*poolPlatform{{weight: 1, cloudBuilder: cloudBuilder}}, nil
+	}
+	var platforms []*poolPlatform
+	var errs []error
+	for _, entry := range pool.Spec.Platforms {
+		weight := 1
+		if entry.Weight != nil {
+			weight = int(*entry.Weight)
+		}
+		if weight <= 0 {
+			continue
+		}
+		cloudBuilder, err := r.createCloudBuilder(pool, entry.Platform, logger.WithField("platform", entry.Name))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("platform %s: %w", entry.Name, err))
+			continue
+		}
+		platforms = append(platforms, &poolPlatform{name: entry.Name, weight: weight, cloudBuilder: cloudBuilder})
+	}
+	if err := utilerrors.NewAggregate(errs); err != nil {
+		return nil, err
+	}
+	return platforms, nil
+}
+
+// countClustersByPlatform returns the number of clusters on each platform of the pool, ignoring the clusters being
+// deleted.
+func countClustersByPlatform(cds []*hivev1.ClusterDeployment) map[string]int {
+	counts := map[string]int{}
+	for _, cd := range cds {
+		if cd.DeletionTimestamp != nil || cd.Spec.ClusterPoolRef == nil {
+			continue
+		}
+		counts[cd.Spec.ClusterPoolRef.PlatformName]++
+	}
+	return counts
+}
+
+// nextPlatform returns the platform of the next new cluster, the one furthest below its share of the clusters of the
+// pool given their weights, preferring the earlier platforms of the pool. It returns nil when there is no platform.
+func nextPlatform(platforms []*poolPlatform, counts map[string]int) *poolPlatform {
+	var next *poolPlatform
+	for _, p := range platforms {
+		// Compare (count+1)/weight between platforms without dividing.
+		if next == nil || (counts[p.name]+1)*next.weight < (counts[next.name]+1)*p.weight {
+			next = p
+		}
+	}
+	return next
+}
+
+// failingPlatforms returns the names of the platforms of the pool with at least platformFailoverFailures of the given
+// recent install failures.
+func failingPlatforms(failures []installFailure) sets.String {
+	counts := map[string]int{}
+	failing := sets.NewString()
+	for _, f := range failures {
+		counts[f.platform]++
+		if counts[f.platform] >= platformFailoverFailures {
+			failing.Insert(f.platform)
+		}
+	}
+	return failing
+}
+
+// healthyPlatforms returns the platforms which are not failing, so that new clusters fail over to the other platforms of
+// the pool. It returns all the platforms when they are all failing, as the pool has nowhere else to create clusters.
+func healthyPlatforms(platforms []*poolPlatform, failing sets.String, logger log.FieldLogger) []*poolPlatform {
+	var healthy []*poolPlatform
+	for _, p := range platforms {
+		if failing.Has(p.name) {
+			logger.WithField("platform", p.name).Info("skipping platform with recent install failures for new clusters")
+			continue
+		}
+		healthy = append(healthy, p)
+	}
+	if len(healthy) == 0 {
+		if len(platforms) > 0 {
+			logger.Info("all platforms of the pool have recent install failures, using them all for new clusters")
+		}
+		return platforms
+	}
+	return healthy
+}
@@ -0,0 +1,318 @@
+package clusterpool
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+	testcp "github.com/openshift/hive/pkg/test/clusterpool"
+	testgeneric "github.com/openshift/hive/pkg/test/generic"
+	testsecret "github.com/openshift/hive/pkg/test/secret"
+)
+
+func TestNextPlatform(t *testing.T) {
+	tests := []struct {
+		name     string
+		weights  []int
+		existing map[string]int
+		count    int
+		expected map[string]int
+	}{
+		{
+			name:     "single platform",
+			weights:  []int{1},
+			count:    3,
+			expected: map[string]int{"p0": 3},
+		},
+		{
+			name:     "equal weights",
+			weights:  []int{1, 1, 1},
+			count:    4,
+			expected: map[string]int{"p0": 2, "p1": 1, "p2": 1},
+		},
+		{
+			name:     "weighted",
+			weights:  []int{3, 1},
+			count:    8,
+			expected: map[string]int{"p0": 6, "p1": 2},
+		},
+		{
+			name:     "existing clusters",
+			weights:  []int{1, 1},
+			existing: map[string]int{"p0": 3},
+			count:    3,
+			expected: map[string]int{"p0": 3, "p1": 3},
+		},
+		{
+			name:     "no platforms",
+			count:    1,
+			expected: map[string]int{},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var platforms []*poolPlatform
+			for i, w := range test.weights {
+				platforms = append(platforms, &poolPlatform{name: fmt.Sprintf("p%d", i), weight: w})
+			}
+			counts := map[string]int{}
+			for k, v := range test.existing {
+				counts[k] = v
+			}
+			for i := 0; i < test.count; i++ {
+				p := nextPlatform(platforms, counts)
+				if p == nil {
+					break
+				}
+				counts[p.name]++
+			}
+			assert.Equal(t, test.expected, counts, "unexpected spread of clusters")
+		})
+	}
+}
+
+func TestReconcileClusterPoolPlatforms(t *testing.T) {
+	scheme := runtime.NewScheme()
+	hivev1.AddToScheme(scheme)
+	corev1.AddToScheme(scheme)
+	rbacv1.AddToScheme(scheme)
+
+	awsPlatform := func(name, region string, weight *int32) hivev1.ClusterPoolPlatform {
+		return hivev1.ClusterPoolPlatform{
+			Name:   name,
+			Weight: weight,
+			Platform: hivev1.Platform{
+				AWS: &hivev1aws.Platform{
+					CredentialsSecretRef: corev1.LocalObjectReference{Name: credsSecretName},
+					Region:               region,
+				},
+			},
+		}
+	}
+	poolBuilder := testcp.FullBuilder(testNamespace, testLeasePoolName, scheme).
+		GenericOptions(
+			testgeneric.WithFinalizer(finalizer),
+		).
+		Options(
+			testcp.WithBaseDomain("test-domain"),
+			testcp.WithImageSet(imageSetName),
+		)
+	unclaimedCD := func(name, platform string) *hivev1.ClusterDeployment {
+		return testcd.FullBuilder(name, name, scheme).Build(
+			testcd.WithPowerState(hivev1.HibernatingClusterPowerState),
+			func(cd *hivev1.ClusterDeployment) {
+				cd.Spec.ClusterPoolRef = &hivev1.ClusterPoolReference{
+					Namespace:    testNamespace,
+					PoolName:     testLeasePoolName,
+					PlatformName: platform,
+				}
+			},
+		)
+	}
+
+	recentFailures := func(platform string, count int) []hivev1.ClusterPoolInstallFailure {
+		var failures []hivev1.ClusterPoolInstallFailure
+		for i := 0; i < count; i++ {
+			failures = append(failures, hivev1.ClusterPoolInstallFailure{
+				ClusterProvision: fmt.Sprintf("%s-%d/%s-%d-0", platform, i, platform, i),
+				Time:             metav1.Now(),
+				Reason:           "AWSInsufficientCapacity",
+				Platform:         platform,
+			})
+		}
+		return failures
+	}
+	failedProvision := func(cdName string, attempt int) *hivev1.ClusterProvision {
+		return &hivev1.ClusterProvision{
+			ObjectMeta: metav1.ObjectMeta{Namespace: cdName, Name: fmt.Sprintf("%s-%d", cdName, attempt)},
+			Spec: hivev1.ClusterProvisionSpec{
+				ClusterDeploymentRef: corev1.LocalObjectReference{Name: cdName},
+				Attempt:              attempt,
+				Stage:                hivev1.ClusterProvisionStageFailed,
+			},
+			Status: hivev1.ClusterProvisionStatus{
+				Conditions: []hivev1.ClusterProvisionCondition{{
+					Type:               hivev1.ClusterProvisionFailedCondition,
+					Status:             corev1.ConditionTrue,
+					LastTransitionTime: metav1.Now(),
+				}},
+			},
+		}
+	}
+
+	tests := []struct {
+		name              string
+		existing          []runtime.Object
+		expectedRegions   map[string]int
+		expectedPlatforms []string
+	}{
+		{
+			name: "spread by weight",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(6), func(pool *hivev1.ClusterPool) {
+					pool.Spec.Platforms = []hivev1.ClusterPoolPlatform{
+						awsPlatform("east", "us-east-1", pointer.Int32Ptr(2)),
+						awsPlatform("west", "us-west-2", nil),
+					}
+				}),
+			},
+			expectedRegions: map[string]int{"us-east-1": 4, "us-west-2": 2},
+		},
+		{
+			name: "platform without weight not used",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(2), func(pool *hivev1.ClusterPool) {
+					pool.Spec.Platforms = []hivev1.ClusterPoolPlatform{
+						awsPlatform("east", "us-east-1", pointer.Int32Ptr(0)),
+						awsPlatform("west", "us-west-2", nil),
+					}
+				}),
+			},
+			expectedRegions: map[string]int{"us-west-2": 2},
+		},
+		{
+			name: "rebalance with existing clusters",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(4), func(pool *hivev1.ClusterPool) {
+					pool.Spec.Platforms = []hivev1.ClusterPoolPlatform{
+						awsPlatform("east", "us-east-1", nil),
+						awsPlatform("west", "us-west-2", nil),
+					}
+				}),
+				unclaimedCD("c1", "east"),
+				unclaimedCD("c2", "east"),
+			},
+			expectedRegions: map[string]int{"us-west-2": 2},
+		},
+		{
+			name: "fail over from platform with recent install failures",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(4), func(pool *hivev1.ClusterPool) {
+					pool.Spec.Platforms = []hivev1.ClusterPoolPlatform{
+						awsPlatform("east", "us-east-1", nil),
+						awsPlatform("west", "us-west-2", nil),
+					}
+					pool.Status.InstallFailures = recentFailures("east", platformFailoverFailures)
+				}),
+			},
+			expectedRegions: map[string]int{"us-west-2": 4},
+		},
+		{
+			name: "fail over from platform with failed provisions",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(4), func(pool *hivev1.ClusterPool) {
+					pool.Spec.Platforms = []hivev1.ClusterPoolPlatform{
+						awsPlatform("east", "us-east-1", nil),
+						awsPlatform("west", "us-west-2", nil),
+					}
+				}),
+				unclaimedCD("c1", "east"),
+				failedProvision("c1", 0),
+				failedProvision("c1", 1),
+				failedProvision("c1", 2),
+			},
+			expectedRegions:   map[string]int{"us-west-2": 3},
+			expectedPlatforms: []string{"east", "east", "east"},
+		},
+		{
+			name: "no fail over below the failure threshold",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(4), func(pool *hivev1.ClusterPool) {
+					pool.Spec.Platforms = []hivev1.ClusterPoolPlatform{
+						awsPlatform("east", "us-east-1", nil),
+						awsPlatform("west", "us-west-2", nil),
+					}
+					pool.Status.InstallFailures = recentFailures("east", platformFailoverFailures-1)
+				}),
+			},
+			expectedRegions: map[string]int{"us-east-1": 2, "us-west-2": 2},
+		},
+		{
+			name: "all platforms failing",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(4), func(pool *hivev1.ClusterPool) {
+					pool.Spec.Platforms = []hivev1.ClusterPoolPlatform{
+						awsPlatform("east", "us-east-1", nil),
+						awsPlatform("west", "us-west-2", nil),
+					}
+					pool.Status.InstallFailures = append(
+						recentFailures("east", platformFailoverFailures),
+						recentFailures("west", platformFailoverFailures)...,
+					)
+				}),
+			},
+			expectedRegions: map[string]int{"us-east-1": 2, "us-west-2": 2},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.existing = append(
+				test.existing,
+				&hivev1.ClusterImageSet{
+					ObjectMeta: metav1.ObjectMeta{Name: imageSetName},
+					Spec:       hivev1.ClusterImageSetSpec{ReleaseImage: "test-release-image"},
+				},
+				testsecret.FullBuilder(testNamespace, credsSecretName, scheme).
+					Build(testsecret.WithDataKeyValue("dummykey", []byte("dummyval"))),
+			)
+			fakeClient := fake.NewFakeClientWithScheme(scheme, test.existing...)
+			logger := log.New()
+			logger.SetLevel(log.DebugLevel)
+			rcp := &ReconcileClusterPool{
+				Client:       fakeClient,
+				logger:       logger,
+				expectations: controllerutils.NewExpectations(logger),
+			}
+
+			_, err := rcp.Reconcile(reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: testLeasePoolName},
+			})
+			require.NoError(t, err, "unexpected error from reconcile")
+
+			pool := &hivev1.ClusterPool{}
+			require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKey{Namespace: testNamespace, Name: testLeasePoolName}, pool))
+			platformNames := map[string]string{}
+			for _, p := range pool.Spec.Platforms {
+				platformNames[p.AWS.Region] = p.Name
+			}
+
+			cds := &hivev1.ClusterDeploymentList{}
+			require.NoError(t, fakeClient.List(context.Background(), cds))
+			regions := map[string]int{}
+			for _, cd := range cds.Items {
+				if cd.Spec.Platform.AWS == nil {
+					// Pre-existing clusters of the test.
+					continue
+				}
+				region := cd.Spec.Platform.AWS.Region
+				regions[region]++
+				assert.Equal(t, platformNames[region], cd.Spec.ClusterPoolRef.PlatformName, "unexpected platform recorded on cluster")
+			}
+			assert.Equal(t, test.expectedRegions, regions, "unexpected regions of new clusters")
+			if test.expectedPlatforms != nil {
+				var platforms []string
+				for _, failure := range pool.Status.InstallFailures {
+					platforms = append(platforms, failure.Platform)
+				}
+				assert.Equal(t, test.expectedPlatforms, platforms, "unexpected platforms of install failures")
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package clusterpool
+
+import (
+	"context"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hiveintv1alpha1 "github.com/openshift/hive/apis/hiveinternal/v1alpha1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+// rebaselinePollInterval is how often the ClusterSyncs of clusters being rebaselined are checked.
+const rebaselinePollInterval = time.Minute
+
+func isRebaselining(cd *hivev1.ClusterDeployment) bool {
+	return cd.Annotations[constants.ClusterRebaseliningAnnotation] == "true"
+}
+
+// reconcileRebaseliningClusters returns the clusters released to the pool to be claimed again once they have been
+// sanitized, their admin credentials have been rotated and their rebaseline SyncSets have been applied, deleting the
+// SyncSets and hibernating the clusters. It returns whether any cluster is still being rebaselined.
+func (r *ReconcileClusterPool) reconcileRebaseliningClusters(cds []*hivev1.ClusterDeployment, logger log.FieldLogger) (bool, error) {
+	pending := false
+	for _, cd := range cds {
+		cdLog := logger.WithField("cluster", cd.Name)
+		done, err := r.reconcileRebaseliningCluster(cd, cdLog)
+		if err != nil {
+			return false, err
+		}
+		if !done {
+			cdLog.Debug("waiting for cluster to be sanitized and rebaseline SyncSets to be applied")
+			pending = true
+		}
+	}
+	return pending, nil
+}
+
+func (r *ReconcileClusterPool) reconcileRebaseliningCluster(cd *hivev1.ClusterDeployment, logger log.FieldLogger) (bool, error) {
+	if cd.Annotations[constants.ClusterSanitizingAnnotation] == "true" {
+		// The sanitization controller removes the annotation once the cluster is sanitized.
+		return false, nil
+	}
+	if cd.Annotations[constants.ClusterCredentialsRotatingAnnotation] == "true" {
+		// The kubeadmin controller removes the annotation once the admin credentials are rotated.
+		return false, nil
+	}
+
+	syncSets := &hivev1.SyncSetList{}
+	if err := r.List(
+		context.Background(),
+		syncSets,
+		client.InNamespace(cd.Namespace),
+		client.MatchingLabels{
+			constants.RebaselineSyncSetLabel:     "true",
+			constants.ClusterDeploymentNameLabel: cd.Name,
+		},
+	); err != nil {
+		logger.WithError(err).Error("error listing rebaseline SyncSets")
+		return false, err
+	}
+
+	if len(syncSets.Items) > 0 {
+		clusterSync := &hiveintv1alpha1.ClusterSync{}
+		switch err := r.Get(context.Background(), client.ObjectKey{Namespace: cd.Namespace, Name: cd.Name}, clusterSync); {
+		case apierrors.IsNotFound(err):
+			return false, nil
+		case err != nil:
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "error getting ClusterSync")
+			return false, err
+		}
+		for i := range syncSets.Items {
+			if !syncSetApplied(&syncSets.Items[i], clusterSync) {
+				return false, nil
+			}
+		}
+		for i := range syncSets.Items {
+			ss := &syncSets.Items[i]
+			logger.WithField("syncSet", ss.Name).Info("deleting applied rebaseline SyncSet")
+			if err := r.Delete(context.Background(), ss); err != nil && !apierrors.IsNotFound(err) {
+				logger.WithError(err).Log(controllerutils.LogLevel(err), "error deleting rebaseline SyncSet")
+				return false, err
+			}
+		}
+	}
+
+	logger.Info("cluster rebaselined, hibernating it")
+	delete(cd.Annotations, constants.ClusterRebaseliningAnnotation)
+	cd.Spec.PowerState = hivev1.HibernatingClusterPowerState
+	if err := r.Update(context.Background(), cd); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "error updating rebaselined ClusterDeployment")
+		return false, err
+	}
+	return true, nil
+}
+
+func syncSetApplied(ss *hivev1.SyncSet, clusterSync *hiveintv1alpha1.ClusterSync) bool {
+	for _, status := range clusterSync.Status.SyncSets {
+		if status.Name == ss.Name {
+			return status.ObservedGeneration == ss.Generation && status.Result == hiveintv1alpha1.SuccessSyncSetResult
+		}
+	}
+	return false
+}
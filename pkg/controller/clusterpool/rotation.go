@@ -0,0 +1,63 @@
+package clusterpool
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+// clusterInstalledTime returns when the cluster was installed, or created when the time of its install is not known.
+func clusterInstalledTime(cd *hivev1.ClusterDeployment) time.Time {
+	if cd.Status.InstalledTimestamp != nil {
+		return cd.Status.InstalledTimestamp.Time
+	}
+	return cd.CreationTimestamp.Time
+}
+
+// staleClusters returns the given clusters which were installed longer ago than the max cluster age of the pool,
+// oldest first, and how long until the next of the other clusters becomes stale. It returns no clusters when the pool
+// has no max cluster age.
+func staleClusters(pool *hivev1.ClusterPool, cds []*hivev1.ClusterDeployment, now time.Time) ([]*hivev1.ClusterDeployment, time.Duration) {
+	if pool.Spec.MaxClusterAge == nil {
+		return nil, 0
+	}
+	maxAge := pool.Spec.MaxClusterAge.Duration
+	var stale []*hivev1.ClusterDeployment
+	var nextStaleAfter time.Duration
+	for _, cd := range cds {
+		staleAfter := clusterInstalledTime(cd).Add(maxAge).Sub(now)
+		if staleAfter <= 0 {
+			stale = append(stale, cd)
+			continue
+		}
+		if nextStaleAfter == 0 || staleAfter < nextStaleAfter {
+			nextStaleAfter = staleAfter
+		}
+	}
+	sort.SliceStable(stale, func(i, j int) bool {
+		return clusterInstalledTime(stale[i]).Before(clusterInstalledTime(stale[j]))
+	})
+	return stale, nextStaleAfter
+}
+
+// rotateStaleClusters deletes up to max of the given stale clusters, oldest first, for the pool to replace them with
+// fresh installs.
+func (r *ReconcileClusterPool) rotateStaleClusters(pool *hivev1.ClusterPool, stale []*hivev1.ClusterDeployment, max int, logger log.FieldLogger) error {
+	if max > len(stale) {
+		max = len(stale)
+	}
+	for _, cd := range stale[:max] {
+		cdLog := logger.WithField("cluster", cd.Name)
+		cdLog.WithField("installed", clusterInstalledTime(cd)).Info("deleting stale cluster to replace it with a fresh install")
+		if err := r.Client.Delete(context.Background(), cd); err != nil {
+			cdLog.WithError(err).Error("error deleting stale cluster")
+			return err
+		}
+		metricStaleClustersRotated.WithLabelValues(pool.Namespace, pool.Name).Inc()
+	}
+	return nil
+}
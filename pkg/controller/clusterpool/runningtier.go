@@ -0,0 +1,69 @@
+package clusterpool
+
+import (
+	"context"
+	"sort"
+
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+// isRunning returns whether the cluster is meant to be running rather than hibernating.
+func isRunning(cd *hivev1.ClusterDeployment) bool {
+	return cd.Spec.PowerState != hivev1.HibernatingClusterPowerState
+}
+
+// isAwake returns whether the cluster is running now, rather than hibernating or resuming.
+func isAwake(cd *hivev1.ClusterDeployment) bool {
+	if !isRunning(cd) {
+		return false
+	}
+	cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.ClusterHibernatingCondition)
+	return cond == nil || cond.Status == corev1.ConditionFalse
+}
+
+// orderByAwake sorts the given clusters so that the running clusters come before the hibernating ones, or after them
+// when awakeFirst is false. Running clusters are assigned to claims first, and deleted last.
+func orderByAwake(cds []*hivev1.ClusterDeployment, awakeFirst bool) {
+	sort.SliceStable(cds, func(i, j int) bool {
+		return isAwake(cds[i]) == awakeFirst && isAwake(cds[j]) != awakeFirst
+	})
+}
+
+// reconcileRunningTier keeps the running count of the pool of its unclaimed clusters running and hibernates the rest.
+// Clusters already running are kept in the running tier, followed by the installed clusters and then the installing
+// ones, so that clusters which are claimed are replaced by resuming hibernating clusters and installing clusters do
+// not hibernate when their install completes only to be resumed. The given clusters are sorted in place.
+func (r *ReconcileClusterPool) reconcileRunningTier(pool *hivev1.ClusterPool, cds []*hivev1.ClusterDeployment, logger log.FieldLogger) error {
+	sort.SliceStable(cds, func(i, j int) bool {
+		a, b := cds[i], cds[j]
+		if isRunning(a) != isRunning(b) {
+			return isRunning(a)
+		}
+		if a.Spec.Installed != b.Spec.Installed {
+			return a.Spec.Installed
+		}
+		return a.CreationTimestamp.Before(&b.CreationTimestamp)
+	})
+
+	for i, cd := range cds {
+		powerState := hivev1.HibernatingClusterPowerState
+		if i < int(pool.Spec.RunningCount) {
+			powerState = hivev1.RunningClusterPowerState
+		}
+		if cd.Spec.PowerState == powerState {
+			continue
+		}
+		cdLog := logger.WithField("cluster", cd.Name).WithField("powerState", powerState)
+		cdLog.Info("changing power state of cluster for the running tier of the pool")
+		cd.Spec.PowerState = powerState
+		if err := r.Update(context.Background(), cd); err != nil {
+			cdLog.WithError(err).Log(controllerutils.LogLevel(err), "could not change power state of cluster")
+			return err
+		}
+	}
+	return nil
+}
@@ -3,6 +3,9 @@ package clusterprovision
 import (
 	"context"
 	"fmt"
+	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
@@ -14,6 +17,8 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/flowcontrol"
 	"k8s.io/client-go/util/workqueue"
 
@@ -45,6 +50,9 @@ const (
 	resultFailure = "failure"
 
 	podStatusCheckDelay = 60 * time.Second
+
+	// abortedReason is the reason of the failure of a provision aborted with the abort-provision annotation.
+	abortedReason = "Aborted"
 )
 
 var (
@@ -61,13 +69,30 @@ var (
 		Name: "hive_install_errors",
 		Help: "Counter incremented every time we observe certain errors strings in install logs.",
 	},
-		[]string{"cluster_type", "reason"},
+		[]string{"cluster_type", "reason", "category"},
+	)
+	metricProvisionSLABreachesTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "hive_cluster_provision_sla_breaches_total",
+		Help: "Counter incremented every time we observe a cluster provision breaching the provision SLA.",
+	},
+		[]string{"cluster_type", "stage"},
 	)
 )
 
 func init() {
 	metrics.Registry.MustRegister(metricInstallErrors)
 	metrics.Registry.MustRegister(metricClusterProvisionsTotal)
+	metrics.Registry.MustRegister(metricProvisionSLABreachesTotal)
+}
+
+// incrementInstallErrors increments the install errors metric for the reason of a failed provision, labelled with the
+// failure category of the reason.
+func incrementInstallErrors(instance *hivev1.ClusterProvision, reason string) {
+	category := string(controllerutils.FailureCategory(reason))
+	if category == "" {
+		category = "Unknown"
+	}
+	metricInstallErrors.WithLabelValues(hivemetrics.GetClusterDeploymentType(instance), reason, category).Inc()
 }
 
 // Add creates a new ClusterProvision Controller and adds it to the Manager with default RBAC. The Manager will set fields on the Controller
@@ -85,12 +110,68 @@ func Add(mgr manager.Manager) error {
 // newReconciler returns a new reconcile.Reconciler
 func newReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) reconcile.Reconciler {
 	logger := log.WithField("controller", ControllerName)
-	return &ReconcileClusterProvision{
-		Client:       controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
-		scheme:       mgr.GetScheme(),
-		logger:       logger,
-		expectations: controllerutils.NewExpectations(logger),
+	r := &ReconcileClusterProvision{
+		Client:        controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
+		scheme:        mgr.GetScheme(),
+		logger:        logger,
+		expectations:  controllerutils.NewExpectations(logger),
+		eventRecorder: mgr.GetEventRecorderFor(string(ControllerName)),
+	}
+	if sla := os.Getenv(constants.ProvisionSLAEnvVar); sla != "" {
+		if d, err := time.ParseDuration(sla); err != nil {
+			logger.WithError(err).WithField("sla", sla).Warn("invalid provision SLA, not enforcing it")
+		} else {
+			r.provisionSLA = d
+			r.provisionSLAAction = hivev1.ProvisionSLAAction(os.Getenv(constants.ProvisionSLAActionEnvVar))
+			logger.WithField("sla", d).WithField("action", r.provisionSLAAction).Info("enforcing provision SLA")
+		}
+	}
+	r.installPodStuckRecreateJob = os.Getenv(constants.InstallPodStuckRecreateJobEnvVar) == "true"
+	r.collectMustGather = os.Getenv(constants.FailedProvisionMustGatherEnvVar) == "true"
+	if names := os.Getenv(constants.InstallLogRegexesConfigMapsEnvVar); names != "" {
+		r.installLogRegexConfigMaps = strings.Split(names, ",")
+	}
+	if failAfter := os.Getenv(constants.InstallPodStuckFailAfterEnvVar); failAfter != "" {
+		if d, err := time.ParseDuration(failAfter); err != nil {
+			logger.WithError(err).WithField("failAfter", failAfter).Warn("invalid install pod stuck timeout, not enforcing it")
+		} else {
+			r.installPodStuckFailAfter = d
+		}
+	}
+	for stage, envVar := range map[hivev1.ClusterProvisionStage]string{
+		hivev1.ClusterProvisionStageInitializing: constants.InitializingStageTimeoutEnvVar,
+		hivev1.ClusterProvisionStageProvisioning: constants.ProvisioningStageTimeoutEnvVar,
+	} {
+		timeout := os.Getenv(envVar)
+		if timeout == "" {
+			continue
+		}
+		if d, err := time.ParseDuration(timeout); err != nil {
+			logger.WithError(err).WithField("stage", stage).WithField("timeout", timeout).Warn("invalid stage timeout, not enforcing it")
+		} else {
+			if r.stageTimeouts == nil {
+				r.stageTimeouts = map[hivev1.ClusterProvisionStage]time.Duration{}
+			}
+			r.stageTimeouts[stage] = d
+		}
 	}
+	if mode := hivev1.InstallJobSpreadMode(os.Getenv(constants.InstallJobSpreadModeEnvVar)); mode != "" {
+		r.installJobSpreadMode = mode
+		r.installJobSpreadTopologyKey = os.Getenv(constants.InstallJobSpreadTopologyKeyEnvVar)
+		logger.WithField("mode", mode).WithField("topologyKey", r.installJobSpreadTopologyKey).Info("spreading install jobs")
+	}
+	if maxSizeKB := os.Getenv(constants.PodLogSnapshotMaxSizeKBEnvVar); maxSizeKB != "" {
+		kubeClient, err := kubernetes.NewForConfig(mgr.GetConfig())
+		if err != nil {
+			logger.WithError(err).Error("could not create kube client, not taking pod log snapshots")
+		} else if kb, err := strconv.Atoi(maxSizeKB); err != nil {
+			logger.WithError(err).WithField("maxSizeKB", maxSizeKB).Warn("invalid pod log snapshot size, not taking pod log snapshots")
+		} else {
+			r.podLogTailer = controllerutils.NewPodLogTailer(kubeClient)
+			r.podLogSnapshotMaxBytes = kb * 1024
+		}
+	}
+	return r
 }
 
 // add adds a new Controller to mgr with r as the reconcile.Reconciler
@@ -139,6 +220,33 @@ type ReconcileClusterProvision struct {
 	logger log.FieldLogger
 	// A TTLCache of job creates each clusterprovision expects to see
 	expectations controllerutils.ExpectationsInterface
+	// provisionSLA is how long a provision may take before breaching the SLA. Zero when no SLA is enforced.
+	provisionSLA time.Duration
+	// provisionSLAAction is what to do with a provision breaching the SLA.
+	provisionSLAAction hivev1.ProvisionSLAAction
+	// installPodStuckRecreateJob is whether to recreate the install job once when its pod is stuck.
+	installPodStuckRecreateJob bool
+	// installPodStuckFailAfter is how long the install pod may be stuck before the provision attempt is failed. Zero
+	// when the provision keeps waiting for the install pod.
+	installPodStuckFailAfter time.Duration
+	eventRecorder            record.EventRecorder
+	// stageTimeouts are how long provisions may stay in a stage before they are aborted. Stages without a timeout are
+	// not timed out.
+	stageTimeouts map[hivev1.ClusterProvisionStage]time.Duration
+	// installJobSpreadMode is how strictly the pods of concurrent install jobs are spread. Empty when they are not.
+	installJobSpreadMode hivev1.InstallJobSpreadMode
+	// installJobSpreadTopologyKey is the node label install pods are spread across.
+	installJobSpreadTopologyKey string
+	// podLogTailer reads the logs of install pods to snapshot them. Nil when no snapshots are taken.
+	podLogTailer controllerutils.PodLogTailer
+	// podLogSnapshotMaxBytes is the size of the snapshots of the logs of install pods.
+	podLogSnapshotMaxBytes int
+	// collectMustGather is whether to collect a must-gather from the cluster of a failed provision which had
+	// completed bootstrapping.
+	collectMustGather bool
+	// installLogRegexConfigMaps are the names of the ConfigMaps holding the rule sets which classify install logs
+	// before the built-in rules, in order.
+	installLogRegexConfigMaps []string
 }
 
 // Reconcile reads that state of the cluster for a ClusterProvision object and makes changes based on the state read
@@ -180,6 +288,27 @@ func (r *ReconcileClusterProvision) Reconcile(request reconcile.Request) (reconc
 		return reconcile.Result{}, nil
 	}
 
+	var timeUntilSLABreach time.Duration
+	if instance.Spec.Stage == hivev1.ClusterProvisionStageInitializing || instance.Spec.Stage == hivev1.ClusterProvisionStageProvisioning {
+		if aborted, err := r.reconcileAbortRequest(instance, pLog); aborted || err != nil {
+			return reconcile.Result{}, err
+		}
+		aborted, untilBreach, err := r.reconcileProvisionSLA(instance, pLog)
+		if aborted || err != nil {
+			return reconcile.Result{}, err
+		}
+		timeUntilSLABreach = untilBreach
+	}
+
+	result, err := r.reconcileStage(instance, pLog)
+	if err == nil && !result.Requeue && timeUntilSLABreach > 0 &&
+		(result.RequeueAfter == 0 || timeUntilSLABreach < result.RequeueAfter) {
+		result.RequeueAfter = timeUntilSLABreach
+	}
+	return result, err
+}
+
+func (r *ReconcileClusterProvision) reconcileStage(instance *hivev1.ClusterProvision, pLog log.FieldLogger) (reconcile.Result, error) {
 	switch instance.Spec.Stage {
 	case hivev1.ClusterProvisionStageInitializing:
 		if instance.Status.JobRef != nil {
@@ -200,8 +329,8 @@ func (r *ReconcileClusterProvision) Reconcile(request reconcile.Request) (reconc
 		installJobDeletionRecheckDelay := instance.CreationTimestamp.Time.Add(24 * time.Hour).Sub(time.Now())
 		return reconcile.Result{RequeueAfter: installJobDeletionRecheckDelay}, nil
 	case hivev1.ClusterProvisionStageFailed:
-		pLog.Debugf("ClusterProvision is %s. Nothing more to do", instance.Spec.Stage)
-		return reconcile.Result{}, nil
+		pLog.Debugf("ClusterProvision is %s", instance.Spec.Stage)
+		return r.reconcileMustGather(instance, pLog)
 	default:
 		pLog.Errorf("ClusterProvision has unknown stage %q", instance.Spec.Stage)
 		return reconcile.Result{}, nil
@@ -240,6 +369,9 @@ func (r *ReconcileClusterProvision) createJob(instance *hivev1.ClusterProvision,
 		pLog.WithError(err).Error("error setting controller reference on job")
 		return reconcile.Result{}, err
 	}
+	if err := r.spreadInstallJob(job, pLog); err != nil {
+		return reconcile.Result{}, err
+	}
 
 	pLog.Infof("creating install job")
 	r.expectations.ExpectCreations(types.NamespacedName{Namespace: instance.Namespace, Name: instance.Name}.String(), 1)
@@ -254,6 +386,7 @@ func (r *ReconcileClusterProvision) createJob(instance *hivev1.ClusterProvision,
 
 func (r *ReconcileClusterProvision) adoptJob(instance *hivev1.ClusterProvision, job *batchv1.Job, pLog log.FieldLogger) (reconcile.Result, error) {
 	instance.Status.JobRef = &corev1.LocalObjectReference{Name: job.Name}
+	setStageTimestamp(instance, hivev1.ClusterProvisionStageInitializing, instance.CreationTimestamp)
 	return reconcile.Result{}, r.setCondition(instance, hivev1.ClusterProvisionJobCreated, corev1.ConditionTrue, "JobCreated", "Install job has been created", controllerutils.UpdateConditionAlways, pLog)
 }
 
@@ -271,6 +404,11 @@ func (r *ReconcileClusterProvision) reconcileRunningJob(instance *hivev1.Cluster
 			}
 		} else {
 			pLog.Info("install job from aborted provision has been deleted")
+			if cond.Reason == abortedReason {
+				if finished, err := r.reconcileAbortDeprovision(instance, pLog); !finished || err != nil {
+					return reconcile.Result{}, err
+				}
+			}
 		}
 		return reconcile.Result{}, r.setStage(instance, hivev1.ClusterProvisionStageFailed, pLog)
 	case err != nil:
@@ -280,6 +418,10 @@ func (r *ReconcileClusterProvision) reconcileRunningJob(instance *hivev1.Cluster
 
 	pLog = pLog.WithField("job", job.Name)
 
+	if controllerutils.IsFinished(job) {
+		r.snapshotInstallPodLogs(instance, job, pLog)
+	}
+
 	switch {
 	case controllerutils.IsSuccessful(job):
 		if instance.Spec.Stage == hivev1.ClusterProvisionStageInitializing {
@@ -293,25 +435,56 @@ func (r *ReconcileClusterProvision) reconcileRunningJob(instance *hivev1.Cluster
 
 	pLog.Debug("install job still running")
 
+	timedOut, timeUntilTimeout, err := r.reconcileInstallTimeout(instance, job, pLog)
+	if timedOut || err != nil {
+		return reconcile.Result{}, err
+	}
+	stageTimedOut, timeUntilStageTimeout, err := r.reconcileStageTimeout(instance, job, pLog)
+	if stageTimedOut || err != nil {
+		return reconcile.Result{}, err
+	}
+	if timeUntilStageTimeout > 0 && (timeUntilTimeout == 0 || timeUntilStageTimeout < timeUntilTimeout) {
+		timeUntilTimeout = timeUntilStageTimeout
+	}
+
+	result, err := r.reconcileUnfinishedJob(instance, job, pLog)
+	if err == nil && !result.Requeue && timeUntilTimeout > 0 &&
+		(result.RequeueAfter == 0 || timeUntilTimeout < result.RequeueAfter) {
+		result.RequeueAfter = timeUntilTimeout
+	}
+	return result, err
+}
+
+// reconcileUnfinishedJob checks on the install pod of a running install job, and moves the provision on to the
+// provisioning stage once the installer has initialized.
+func (r *ReconcileClusterProvision) reconcileUnfinishedJob(instance *hivev1.ClusterProvision, job *batchv1.Job, pLog log.FieldLogger) (reconcile.Result, error) {
 	if time.Since(job.CreationTimestamp.Time) > podStatusCheckDelay {
 		installPod, err := r.getInstallPod(job, pLog)
 		if err != nil {
 			pLog.WithError(err).Error("could not get install pod")
-			if err := r.setCondition(instance, hivev1.InstallPodStuckCondition, corev1.ConditionTrue, "InstallPodMissing", err.Error(), controllerutils.UpdateConditionIfReasonOrMessageChange, pLog); err != nil {
-				return reconcile.Result{}, err
+			remediated, result, stuckErr := r.reconcileStuckInstallPod(instance, job, nil, "InstallPodMissing", err.Error(), pLog)
+			if remediated || stuckErr != nil {
+				return result, stuckErr
+			}
+			if result.RequeueAfter > 0 {
+				// The provision attempt is failed once the pod has been missing for too long, so wait for that rather
+				// than erroring until the pod shows up.
+				return result, nil
 			}
 			return reconcile.Result{}, err
 		}
 
+		if err := r.recordInstallPodStarted(instance, installPod, pLog); err != nil {
+			return reconcile.Result{}, err
+		}
+
 		if installPod.Status.Phase == "Pending" {
 			pLog.WithField("pod", installPod.Name).Error("install pod is stuck")
-			if err := r.setCondition(instance, hivev1.InstallPodStuckCondition, corev1.ConditionTrue, "PodInPendingPhase", "pod is in pending phase", controllerutils.UpdateConditionIfReasonOrMessageChange, pLog); err != nil {
-				return reconcile.Result{}, err
-			}
 			// Since this controller is not watching pods, the ClusterProvision will not be re-synced if the pod does
 			// transition to the running phase later. However, if the pod does start running, then soon after either the
 			// install manager will set the InfraID on the ClusterProvision or the pod will fail.
-			return reconcile.Result{}, nil
+			_, result, err := r.reconcileStuckInstallPod(instance, job, installPod, "PodInPendingPhase", "pod is in pending phase", pLog)
+			return result, err
 		}
 		if cond := controllerutils.FindClusterProvisionCondition(instance.Status.Conditions, hivev1.InstallPodStuckCondition); cond != nil && cond.Status == corev1.ConditionTrue {
 			if err := r.setCondition(instance, hivev1.InstallPodStuckCondition, corev1.ConditionFalse, "PodInRunningPhase", "pod is in running phase", controllerutils.UpdateConditionNever, pLog); err != nil {
@@ -388,34 +561,180 @@ func (r *ReconcileClusterProvision) reconcileFailedJob(instance *hivev1.ClusterP
 	result, err := r.transitionStage(instance, hivev1.ClusterProvisionStageFailed, reason, message, pLog)
 	if err == nil {
 		// Increment a counter metric for this cluster type and error reason:
-		metricInstallErrors.WithLabelValues(hivemetrics.GetClusterDeploymentType(instance), reason).Inc()
+		incrementInstallErrors(instance, reason)
 		metricClusterProvisionsTotal.WithLabelValues(hivemetrics.GetClusterDeploymentType(instance), resultFailure).Inc()
 	}
 	return result, err
 }
 
+// snapshotInstallPodLogs stores the end of the logs of the install pod of the job in a ConfigMap, and references it from
+// the status of the provision. Snapshots are best effort, so failures are only logged.
+func (r *ReconcileClusterProvision) snapshotInstallPodLogs(instance *hivev1.ClusterProvision, job *batchv1.Job, pLog log.FieldLogger) {
+	if r.podLogTailer == nil {
+		return
+	}
+	installPod, err := r.getInstallPod(job, pLog)
+	if err != nil {
+		pLog.WithError(err).Warn("could not get install pod to snapshot its logs")
+		return
+	}
+	ref, err := controllerutils.SnapshotPodLogs(r, r.podLogTailer, instance, installPod, r.podLogSnapshotMaxBytes, r.scheme, pLog)
+	if err != nil {
+		pLog.WithError(err).Warn("could not snapshot install pod logs")
+		return
+	}
+	instance.Status.LogSnapshotRef = ref
+}
+
 func (r *ReconcileClusterProvision) startProvisioning(instance *hivev1.ClusterProvision, pLog log.FieldLogger) (reconcile.Result, error) {
 	pLog.Info("provision initialization complete")
 	return r.transitionStage(instance, hivev1.ClusterProvisionStageProvisioning, "InitializationComplete", "Install job has completed its initialization. Provisioning started.", pLog)
 }
 
+// reconcileProvisionSLA sets the ProvisionSLABreached condition once the provision has been running for longer than
+// the provision SLA, and aborts the provision when configured to. It returns whether the provision was aborted, and
+// how long until the SLA is breached.
+func (r *ReconcileClusterProvision) reconcileProvisionSLA(instance *hivev1.ClusterProvision, pLog log.FieldLogger) (bool, time.Duration, error) {
+	if r.provisionSLA <= 0 {
+		return false, 0, nil
+	}
+	if elapsed := time.Since(instance.CreationTimestamp.Time); elapsed < r.provisionSLA {
+		return false, r.provisionSLA - elapsed, nil
+	}
+	message := fmt.Sprintf("Provision did not complete within the SLA of %s", r.provisionSLA)
+	if cond := controllerutils.FindClusterProvisionCondition(instance.Status.Conditions, hivev1.ClusterProvisionSLABreachedCondition); cond == nil || cond.Status != corev1.ConditionTrue {
+		pLog.WithField("sla", r.provisionSLA).WithField("stage", instance.Spec.Stage).Warn("provision breached the SLA")
+		metricProvisionSLABreachesTotal.WithLabelValues(hivemetrics.GetClusterDeploymentType(instance), string(instance.Spec.Stage)).Inc()
+		if err := r.setCondition(instance, hivev1.ClusterProvisionSLABreachedCondition, corev1.ConditionTrue, "SLABreached", message, controllerutils.UpdateConditionIfReasonOrMessageChange, pLog); err != nil {
+			return false, 0, err
+		}
+	}
+	if r.provisionSLAAction != hivev1.ProvisionSLAActionAbort {
+		return false, 0, nil
+	}
+	// Once aborted, the provision is failed as soon as its install job is gone.
+	if cond := controllerutils.FindClusterProvisionCondition(instance.Status.Conditions, hivev1.ClusterProvisionFailedCondition); cond != nil && cond.Status == corev1.ConditionTrue {
+		return false, 0, nil
+	}
+	_, err := r.abortProvision(instance, "ProvisionSLABreached", message, pLog)
+	return true, 0, err
+}
+
+// reconcileAbortRequest aborts the provision when requested with the abort-provision annotation. It returns whether
+// the provision was aborted.
+func (r *ReconcileClusterProvision) reconcileAbortRequest(instance *hivev1.ClusterProvision, pLog log.FieldLogger) (bool, error) {
+	if instance.Annotations[constants.AbortProvisionAnnotation] != "true" {
+		return false, nil
+	}
+	// Once aborted, the provision is failed as soon as its install job is gone.
+	if cond := controllerutils.FindClusterProvisionCondition(instance.Status.Conditions, hivev1.ClusterProvisionFailedCondition); cond != nil && cond.Status == corev1.ConditionTrue {
+		return false, nil
+	}
+	message := fmt.Sprintf("Provision aborted with the %s annotation", constants.AbortProvisionAnnotation)
+	if r.eventRecorder != nil {
+		r.eventRecorder.Event(instance, corev1.EventTypeWarning, abortedReason, message)
+	}
+	_, err := r.abortProvision(instance, abortedReason, message, pLog)
+	return true, err
+}
+
+// reconcileAbortDeprovision deprovisions the cloud resources created by a provision aborted with the abort-provision
+// annotation before the provision is failed, so that they are not left behind when no further attempts are made. It
+// returns whether the deprovision has finished.
+func (r *ReconcileClusterProvision) reconcileAbortDeprovision(instance *hivev1.ClusterProvision, pLog log.FieldLogger) (bool, error) {
+	if instance.Spec.InfraID == nil {
+		pLog.Debug("aborted provision has no infra ID, no cloud resources to deprovision")
+		return true, nil
+	}
+	job := &batchv1.Job{}
+	switch err := r.Get(context.TODO(), types.NamespacedName{Namespace: instance.Namespace, Name: install.GetUninstallJobName(instance.Name)}, job); {
+	case apierrors.IsNotFound(err):
+		created, err := r.createAbortDeprovisionJob(instance, pLog)
+		return !created && err == nil, err
+	case err != nil:
+		pLog.WithError(err).Log(controllerutils.LogLevel(err), "could not get deprovision job of aborted provision")
+		return false, err
+	case !controllerutils.IsFinished(job):
+		pLog.Debug("deprovision job of aborted provision is still running")
+		return false, nil
+	case controllerutils.IsFailed(job):
+		pLog.Warn("deprovision job of aborted provision failed, its cloud resources are deprovisioned by the next provision attempt or once the cluster deployment is deleted")
+		if r.eventRecorder != nil {
+			r.eventRecorder.Event(instance, corev1.EventTypeWarning, "AbortDeprovisionFailed", "Deprovision job failed to deprovision the cloud resources of the aborted provision")
+		}
+	default:
+		pLog.Info("deprovision job of aborted provision completed")
+	}
+	return true, nil
+}
+
+// createAbortDeprovisionJob creates the job deprovisioning the cloud resources of an aborted provision. It returns
+// whether a job was created, which is not the case when the platform of the cluster has nothing to deprovision.
+func (r *ReconcileClusterProvision) createAbortDeprovisionJob(instance *hivev1.ClusterProvision, pLog log.FieldLogger) (bool, error) {
+	cd := &hivev1.ClusterDeployment{}
+	if err := r.Get(context.TODO(), types.NamespacedName{Namespace: instance.Namespace, Name: instance.Spec.ClusterDeploymentRef.Name}, cd); err != nil {
+		pLog.WithError(err).Log(controllerutils.LogLevel(err), "could not get cluster deployment of aborted provision")
+		return false, err
+	}
+	if cd.Spec.Platform.None != nil && cd.Spec.Platform.None.Destroyer == nil {
+		pLog.Info("skipping deprovision of aborted provision on externally managed infrastructure")
+		return false, nil
+	}
+	clusterID := ""
+	if instance.Spec.ClusterID != nil {
+		clusterID = *instance.Spec.ClusterID
+	}
+	request, err := install.GenerateDeprovision(cd, instance.Name, *instance.Spec.InfraID, clusterID)
+	if err != nil {
+		pLog.WithError(err).Error("error generating deprovision request for aborted provision")
+		return false, err
+	}
+	job, err := install.GenerateUninstallerJobForDeprovision(request)
+	if err != nil {
+		pLog.WithError(err).Error("error generating deprovision job for aborted provision")
+		return false, err
+	}
+	pLog = pLog.WithField("job", job.Name)
+
+	pLog.WithField("derivedObject", job.Name).Debug("Setting labels on derived object")
+	job.Labels = k8slabels.AddLabel(job.Labels, constants.ClusterDeploymentNameLabel, cd.Name)
+	job.Labels = k8slabels.AddLabel(job.Labels, constants.ClusterProvisionNameLabel, instance.Name)
+	job.Labels = k8slabels.AddLabel(job.Labels, constants.JobTypeLabel, constants.JobTypeDeprovision)
+	job.Spec.Template.Labels = k8slabels.AddLabel(job.Spec.Template.Labels, constants.ClusterDeploymentNameLabel, cd.Name)
+	if err := controllerutil.SetControllerReference(instance, job, r.scheme); err != nil {
+		pLog.WithError(err).Error("error setting controller reference on deprovision job")
+		return false, err
+	}
+
+	pLog.Info("creating deprovision job for aborted provision")
+	provisionKey := types.NamespacedName{Namespace: instance.Namespace, Name: instance.Name}.String()
+	r.expectations.ExpectCreations(provisionKey, 1)
+	if err := r.Create(context.TODO(), job); err != nil {
+		r.expectations.CreationObserved(provisionKey)
+		pLog.WithError(err).Error("error creating deprovision job for aborted provision")
+		return false, err
+	}
+	return true, nil
+}
+
 func (r *ReconcileClusterProvision) abortProvision(instance *hivev1.ClusterProvision, reason string, message string, pLog log.FieldLogger) (reconcile.Result, error) {
 	pLog.Infof("aborting provision (%s): %s", reason, message)
 	if instance.Status.JobRef == nil {
 		return r.transitionStage(instance, hivev1.ClusterProvisionStageFailed, reason, message, pLog)
 	}
-	if err := r.setCondition(instance, hivev1.ClusterProvisionFailedCondition, corev1.ConditionTrue, reason, message, controllerutils.UpdateConditionAlways, pLog); err != nil {
-		return reconcile.Result{}, err
-	}
 	job := &batchv1.Job{}
 	switch err := r.Get(context.TODO(), client.ObjectKey{Namespace: instance.Namespace, Name: instance.Status.JobRef.Name}, job); {
 	case apierrors.IsNotFound(err):
 		pLog.Warn("install job for aborted provision already gone before it was deleted")
-		return reconcile.Result{}, nil
+		return reconcile.Result{}, r.setCondition(instance, hivev1.ClusterProvisionFailedCondition, corev1.ConditionTrue, reason, message, controllerutils.UpdateConditionAlways, pLog)
 	case err != nil:
 		pLog.WithError(err).Error("could not get install job")
 		return reconcile.Result{}, err
 	}
+	r.snapshotInstallPodLogs(instance, job, pLog)
+	if err := r.setCondition(instance, hivev1.ClusterProvisionFailedCondition, corev1.ConditionTrue, reason, message, controllerutils.UpdateConditionAlways, pLog); err != nil {
+		return reconcile.Result{}, err
+	}
 	if err := r.Delete(context.TODO(), job, client.PropagationPolicy(metav1.DeletePropagationForeground)); err != nil {
 		pLog.WithError(err).Log(controllerutils.LogLevel(err), "could not delete install job")
 		return reconcile.Result{}, err
@@ -478,11 +797,72 @@ func (r *ReconcileClusterProvision) setCondition(
 }
 
 func (r *ReconcileClusterProvision) setStage(instance *hivev1.ClusterProvision, stage hivev1.ClusterProvisionStage, pLog log.FieldLogger) error {
+	previousStage := instance.Spec.Stage
+	now := metav1.Now()
+	if setStageTimestamp(instance, stage, now) {
+		if err := r.Status().Update(context.TODO(), instance); err != nil {
+			pLog.WithError(err).Error("cannot update stage timestamps")
+			return err
+		}
+	}
 	instance.Spec.Stage = stage
 	if err := r.Update(context.TODO(), instance); err != nil {
 		pLog.WithError(err).Error("cannot update provision stage")
 		return err
 	}
+	if entered := stageEnteredTime(instance, previousStage); previousStage != stage && entered != nil {
+		hivemetrics.MetricClusterProvisionStageDurationSeconds.WithLabelValues(
+			hivemetrics.GetClusterDeploymentType(instance), string(previousStage)).Observe(now.Sub(entered.Time).Seconds())
+	}
+	return nil
+}
+
+// setStageTimestamp records when the provision entered the stage, unless already recorded. It returns whether the
+// timestamp was recorded.
+func setStageTimestamp(instance *hivev1.ClusterProvision, stage hivev1.ClusterProvisionStage, enteredTime metav1.Time) bool {
+	for _, ts := range instance.Status.StageTimestamps {
+		if ts.Stage == stage {
+			return false
+		}
+	}
+	instance.Status.StageTimestamps = append(instance.Status.StageTimestamps, hivev1.ClusterProvisionStageTimestamp{
+		Stage:       stage,
+		EnteredTime: enteredTime,
+	})
+	return true
+}
+
+// stageEnteredTime returns when the provision entered the stage, or nil if that has not been recorded.
+func stageEnteredTime(instance *hivev1.ClusterProvision, stage hivev1.ClusterProvisionStage) *metav1.Time {
+	for i, ts := range instance.Status.StageTimestamps {
+		if ts.Stage == stage {
+			return &instance.Status.StageTimestamps[i].EnteredTime
+		}
+	}
+	return nil
+}
+
+// recordInstallPodStarted records when the install pod was started on a node, once it has been, along with how long
+// the provision waited for the pod to be scheduled.
+func (r *ReconcileClusterProvision) recordInstallPodStarted(instance *hivev1.ClusterProvision, installPod *corev1.Pod, pLog log.FieldLogger) error {
+	if instance.Status.InstallPodStartedTime != nil || installPod.Status.StartTime == nil {
+		return nil
+	}
+	startTime := *installPod.Status.StartTime
+	instance.Status.InstallPodStartedTime = &startTime
+	if err := r.Status().Update(context.TODO(), instance); err != nil {
+		pLog.WithError(err).Error("cannot update install pod started time")
+		return err
+	}
+	if entered := stageEnteredTime(instance, hivev1.ClusterProvisionStageInitializing); entered != nil {
+		wait := startTime.Sub(entered.Time)
+		if wait < 0 {
+			wait = 0
+		}
+		pLog.WithField("pod", installPod.Name).WithField("wait", wait).Info("install pod started")
+		hivemetrics.MetricClusterProvisionInstallPodSchedulingSeconds.WithLabelValues(
+			hivemetrics.GetClusterDeploymentType(instance)).Observe(wait.Seconds())
+	}
 	return nil
 }
 
@@ -497,9 +877,14 @@ func (r *ReconcileClusterProvision) existingJobs(provision *hivev1.ClusterProvis
 		pLog.WithError(err).Warn("could not list jobs for clusterprovision")
 		return nil, errors.Wrap(err, "could not list jobs")
 	}
-	jobs := make([]*batchv1.Job, len(jobList.Items))
+	jobs := make([]*batchv1.Job, 0, len(jobList.Items))
 	for i := range jobList.Items {
-		jobs[i] = &jobList.Items[i]
+		job := &jobList.Items[i]
+		if uid := provision.Annotations[constants.RecreatedInstallJobUIDAnnotation]; uid != "" && string(job.UID) == uid {
+			pLog.WithField("job", job.Name).Debug("ignoring install job deleted to recreate it")
+			continue
+		}
+		jobs = append(jobs, job)
 	}
 	return jobs, nil
 }
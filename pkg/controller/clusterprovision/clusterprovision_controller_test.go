@@ -16,6 +16,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -26,6 +27,7 @@ import (
 	routev1 "github.com/openshift/api/route/v1"
 	"github.com/openshift/hive/apis"
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
 	"github.com/openshift/hive/pkg/constants"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 	"github.com/openshift/hive/pkg/install"
@@ -37,6 +39,7 @@ const (
 	testDeploymentName    = "test-deployment-name"
 	testProvisionName     = "test-provision-name"
 	installJobName        = "test-provision-name-provision"
+	mustGatherJobName     = "test-provision-name-must-gather"
 	testNamespace         = "test-namespace"
 	controllerUidLabelKey = "controller-uid"
 	testControllerUid     = "test-controller-uid"
@@ -55,6 +58,14 @@ func TestClusterProvisionReconcile(t *testing.T) {
 		name                  string
 		existing              []runtime.Object
 		pendingCreation       bool
+		provisionSLA          time.Duration
+		provisionSLAAction    hivev1.ProvisionSLAAction
+		recreateStuckJob      bool
+		stuckFailAfter        time.Duration
+		stageTimeouts         map[hivev1.ClusterProvisionStage]time.Duration
+		podLogSnapshots       bool
+		collectMustGather     bool
+		installJobSpreadMode  hivev1.InstallJobSpreadMode
 		expectErr             bool
 		expectedStage         hivev1.ClusterProvisionStage
 		expectedFailReason    string
@@ -63,6 +74,7 @@ func TestClusterProvisionReconcile(t *testing.T) {
 		expectPendingCreation bool
 		validateRequeueAfter  func(time.Duration, client.Client, *testing.T)
 		validate              func(client.Client, *testing.T)
+		expectedEvents        int
 	}{
 		{
 			name: "create job",
@@ -80,6 +92,53 @@ func TestClusterProvisionReconcile(t *testing.T) {
 				assert.Equal(t, constants.JobTypeProvision, job.Labels[constants.JobTypeLabel], "incorrect job type label")
 			},
 		},
+		{
+			name: "create job preferring to spread from running install jobs",
+			existing: []runtime.Object{
+				testProvision(),
+				testJob(testjob.Generic(testgeneric.WithNamespace("running-namespace"))),
+				testJob(testjob.Generic(testgeneric.WithNamespace("completed-namespace")), completed()),
+			},
+			installJobSpreadMode:  hivev1.InstallJobSpreadModePreferred,
+			expectedStage:         hivev1.ClusterProvisionStageInitializing,
+			expectNoJobReference:  true,
+			expectPendingCreation: true,
+			validate: func(c client.Client, t *testing.T) {
+				job := getJob(c)
+				require.NotNil(t, job, "expected job")
+				affinity := job.Spec.Template.Spec.Affinity
+				require.NotNil(t, affinity, "expected affinity")
+				require.NotNil(t, affinity.PodAntiAffinity, "expected pod anti-affinity")
+				assert.Empty(t, affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution, "unexpected required anti-affinity")
+				if assert.Len(t, affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution, 1, "expected preferred anti-affinity") {
+					term := affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution[0].PodAffinityTerm
+					assert.Equal(t, []string{"running-namespace", testNamespace}, term.Namespaces, "unexpected anti-affinity namespaces")
+					assert.Equal(t, "kubernetes.io/hostname", term.TopologyKey, "unexpected anti-affinity topology key")
+					assert.Equal(t, map[string]string{constants.InstallJobLabel: "true"}, term.LabelSelector.MatchLabels, "unexpected anti-affinity selector")
+				}
+			},
+		},
+		{
+			name: "create job required to spread from running install jobs",
+			existing: []runtime.Object{
+				testProvision(),
+			},
+			installJobSpreadMode:  hivev1.InstallJobSpreadModeRequired,
+			expectedStage:         hivev1.ClusterProvisionStageInitializing,
+			expectNoJobReference:  true,
+			expectPendingCreation: true,
+			validate: func(c client.Client, t *testing.T) {
+				job := getJob(c)
+				require.NotNil(t, job, "expected job")
+				affinity := job.Spec.Template.Spec.Affinity
+				require.NotNil(t, affinity, "expected affinity")
+				require.NotNil(t, affinity.PodAntiAffinity, "expected pod anti-affinity")
+				assert.Empty(t, affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution, "unexpected preferred anti-affinity")
+				if assert.Len(t, affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution, 1, "expected required anti-affinity") {
+					assert.Equal(t, []string{testNamespace}, affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution[0].Namespaces, "unexpected anti-affinity namespaces")
+				}
+			},
+		},
 		{
 			name: "job not created when pending create",
 			existing: []runtime.Object{
@@ -108,6 +167,35 @@ func TestClusterProvisionReconcile(t *testing.T) {
 			},
 			expectedStage: hivev1.ClusterProvisionStageInitializing,
 		},
+		{
+			name: "record install pod started time",
+			existing: []runtime.Object{
+				testProvision(withJob()),
+				testJob(withCreationTimestamp(time.Now().Add(-podStatusCheckDelay))),
+				testPod("foo", running(), startedAt(time.Now().Add(-30*time.Second))),
+			},
+			expectedStage: hivev1.ClusterProvisionStageInitializing,
+			validate: func(c client.Client, t *testing.T) {
+				provision := getProvision(c)
+				require.NotNil(t, provision, "could not get ClusterProvision")
+				assert.NotNil(t, provision.Status.InstallPodStartedTime, "expected install pod started time")
+			},
+		},
+		{
+			name: "install pod started time not recorded before pod starts",
+			existing: []runtime.Object{
+				testProvision(withJob()),
+				testJob(withCreationTimestamp(time.Now().Add(-podStatusCheckDelay))),
+				testPod("foo", pending()),
+			},
+			expectedStage:  hivev1.ClusterProvisionStageInitializing,
+			expectedEvents: 1,
+			validate: func(c client.Client, t *testing.T) {
+				provision := getProvision(c)
+				require.NotNil(t, provision, "could not get ClusterProvision")
+				assert.Nil(t, provision.Status.InstallPodStartedTime, "expected no install pod started time")
+			},
+		},
 		{
 			name: "completed job",
 			existing: []runtime.Object{
@@ -116,6 +204,14 @@ func TestClusterProvisionReconcile(t *testing.T) {
 				testPod("foo", success()),
 			},
 			expectedStage: hivev1.ClusterProvisionStageComplete,
+			validate: func(c client.Client, t *testing.T) {
+				provision := getProvision(c)
+				require.NotNil(t, provision, "could not get ClusterProvision")
+				if assert.Len(t, provision.Status.StageTimestamps, 1, "expected stage timestamp") {
+					assert.Equal(t, hivev1.ClusterProvisionStageComplete, provision.Status.StageTimestamps[0].Stage, "unexpected stage")
+					assert.False(t, provision.Status.StageTimestamps[0].EnteredTime.IsZero(), "expected entered time")
+				}
+			},
 		},
 		{
 			name: "completed job while initializing",
@@ -203,7 +299,8 @@ func TestClusterProvisionReconcile(t *testing.T) {
 				testProvision(withJob()),
 				testJob(withCreationTimestamp(time.Now().Add(-podStatusCheckDelay))),
 			},
-			expectedStage: hivev1.ClusterProvisionStageInitializing,
+			expectedStage:  hivev1.ClusterProvisionStageInitializing,
+			expectedEvents: 1,
 			validate: func(c client.Client, t *testing.T) {
 				provision := getProvision(c)
 				require.NotNil(t, provision, "could not get ClusterProvision")
@@ -212,6 +309,30 @@ func TestClusterProvisionReconcile(t *testing.T) {
 			},
 			expectErr: true,
 		},
+		{
+			name: "no install pod running with stuck timeout",
+			existing: []runtime.Object{
+				testProvision(withJob(), withInstallPodStuckCondition("InstallPodMissing", time.Now().Add(-10*time.Minute))),
+				testJob(withCreationTimestamp(time.Now().Add(-podStatusCheckDelay))),
+			},
+			stuckFailAfter: time.Hour,
+			expectedStage:  hivev1.ClusterProvisionStageInitializing,
+			validateRequeueAfter: func(requeueAfter time.Duration, c client.Client, t *testing.T) {
+				assert.Greater(t, requeueAfter.Nanoseconds(), 49*time.Minute.Nanoseconds(), "unexpected requeue after duration")
+				assert.LessOrEqual(t, requeueAfter.Nanoseconds(), 50*time.Minute.Nanoseconds(), "unexpected requeue after duration")
+			},
+		},
+		{
+			name: "fail provision of install pod missing for too long",
+			existing: []runtime.Object{
+				testProvision(withJob(), withInstallPodStuckCondition("InstallPodMissing", time.Now().Add(-2*time.Hour))),
+				testJob(withCreationTimestamp(time.Now().Add(-podStatusCheckDelay))),
+			},
+			stuckFailAfter:     time.Hour,
+			expectedStage:      hivev1.ClusterProvisionStageInitializing,
+			expectedFailReason: "SchedulingStuck",
+			expectNoJob:        true,
+		},
 		{
 			name: "multiple install pods running after starting install job",
 			existing: []runtime.Object{
@@ -220,7 +341,8 @@ func TestClusterProvisionReconcile(t *testing.T) {
 				testPod("foo", running()),
 				testPod("bar", running()),
 			},
-			expectedStage: hivev1.ClusterProvisionStageInitializing,
+			expectedStage:  hivev1.ClusterProvisionStageInitializing,
+			expectedEvents: 1,
 			validate: func(c client.Client, t *testing.T) {
 				provision := getProvision(c)
 				require.NotNil(t, provision, "could not get ClusterProvision")
@@ -236,7 +358,8 @@ func TestClusterProvisionReconcile(t *testing.T) {
 				testJob(withCreationTimestamp(time.Now().Add(-podStatusCheckDelay))),
 				testPod("foo", pending()),
 			},
-			expectedStage: hivev1.ClusterProvisionStageInitializing,
+			expectedStage:  hivev1.ClusterProvisionStageInitializing,
+			expectedEvents: 1,
 			validate: func(c client.Client, t *testing.T) {
 				provision := getProvision(c)
 				require.NotNil(t, provision, "could not get ClusterProvision")
@@ -244,6 +367,462 @@ func TestClusterProvisionReconcile(t *testing.T) {
 				assertConditionReason(t, provision, hivev1.InstallPodStuckCondition, "PodInPendingPhase")
 			},
 		},
+		{
+			name: "event emitted for newly stuck install pod",
+			existing: []runtime.Object{
+				testProvision(withJob()),
+				testJob(withCreationTimestamp(time.Now().Add(-podStatusCheckDelay))),
+				testPod("foo", pending(), unschedulable("0/1 nodes are available: 1 Insufficient memory.")),
+				testNode("node-a"),
+			},
+			stuckFailAfter: time.Hour,
+			expectedStage:  hivev1.ClusterProvisionStageInitializing,
+			expectedEvents: 1,
+			validateRequeueAfter: func(requeueAfter time.Duration, c client.Client, t *testing.T) {
+				assert.Greater(t, requeueAfter.Nanoseconds(), 59*time.Minute.Nanoseconds(), "unexpected requeue after duration")
+				assert.LessOrEqual(t, requeueAfter.Nanoseconds(), time.Hour.Nanoseconds(), "unexpected requeue after duration")
+			},
+		},
+		{
+			name: "no event for install pod already stuck",
+			existing: []runtime.Object{
+				testProvision(withJob(), withInstallPodStuckCondition("PodInPendingPhase", time.Now())),
+				testJob(withCreationTimestamp(time.Now().Add(-podStatusCheckDelay))),
+				testPod("foo", pending()),
+			},
+			expectedStage: hivev1.ClusterProvisionStageInitializing,
+		},
+		{
+			name: "recreate job of stuck install pod",
+			existing: []runtime.Object{
+				testProvision(withJob()),
+				testJob(withCreationTimestamp(time.Now().Add(-podStatusCheckDelay)), withUID("old-job")),
+				testPod("foo", pending()),
+			},
+			recreateStuckJob:     true,
+			expectedStage:        hivev1.ClusterProvisionStageInitializing,
+			expectNoJob:          true,
+			expectNoJobReference: true,
+			expectedEvents:       1,
+			validate: func(c client.Client, t *testing.T) {
+				provision := getProvision(c)
+				require.NotNil(t, provision, "could not get ClusterProvision")
+				assert.Equal(t, "old-job", provision.Annotations[constants.RecreatedInstallJobUIDAnnotation], "unexpected recreated job annotation")
+			},
+		},
+		{
+			name: "job of stuck install pod recreated only once",
+			existing: []runtime.Object{
+				testProvision(withJob(), withRecreatedJob("old-job")),
+				testJob(withCreationTimestamp(time.Now().Add(-podStatusCheckDelay)), withUID("new-job")),
+				testPod("foo", pending()),
+			},
+			recreateStuckJob: true,
+			expectedStage:    hivev1.ClusterProvisionStageInitializing,
+			expectedEvents:   1,
+		},
+		{
+			name: "recreated job not adopted",
+			existing: []runtime.Object{
+				testProvision(withRecreatedJob("old-job")),
+				testJob(withUID("old-job")),
+			},
+			expectErr:            true,
+			expectedStage:        hivev1.ClusterProvisionStageInitializing,
+			expectNoJobReference: true,
+		},
+		{
+			name: "fail provision of install pod stuck for too long",
+			existing: []runtime.Object{
+				testProvision(withJob(), withInstallPodStuckCondition("PodInPendingPhase", time.Now().Add(-2*time.Hour))),
+				testJob(withCreationTimestamp(time.Now().Add(-podStatusCheckDelay))),
+				testPod("foo", pending()),
+			},
+			recreateStuckJob:   true,
+			stuckFailAfter:     time.Hour,
+			expectedStage:      hivev1.ClusterProvisionStageInitializing,
+			expectedFailReason: "SchedulingStuck",
+			expectNoJob:        true,
+		},
+		{
+			name: "abort provision with annotation",
+			existing: []runtime.Object{
+				testProvision(withJob(), provisioning(), withAbortAnnotation()),
+				testJob(),
+				testPod("foo", running()),
+			},
+			expectedStage:      hivev1.ClusterProvisionStageProvisioning,
+			expectedFailReason: "Aborted",
+			expectNoJob:        true,
+			expectedEvents:     1,
+		},
+		{
+			name: "abort provision with annotation before install job",
+			existing: []runtime.Object{
+				testProvision(withAbortAnnotation()),
+			},
+			expectedStage:        hivev1.ClusterProvisionStageFailed,
+			expectedFailReason:   "Aborted",
+			expectNoJob:          true,
+			expectNoJobReference: true,
+			expectedEvents:       1,
+		},
+		{
+			name: "fail aborted provision once install job is gone",
+			existing: []runtime.Object{
+				testProvision(withJob(), provisioning(), withAbortAnnotation(), withFailedCondition("Aborted")),
+			},
+			expectedStage:      hivev1.ClusterProvisionStageFailed,
+			expectedFailReason: "Aborted",
+			expectNoJob:        true,
+		},
+		{
+			name: "deprovision aborted provision once install job is gone",
+			existing: []runtime.Object{
+				testProvision(withJob(), provisioning(), withAbortAnnotation(), withFailedCondition("Aborted"), withInfraID()),
+				testClusterDeployment(),
+			},
+			expectedStage:         hivev1.ClusterProvisionStageProvisioning,
+			expectedFailReason:    "Aborted",
+			expectNoJob:           true,
+			expectPendingCreation: true,
+			validate: func(c client.Client, t *testing.T) {
+				job := getAbortDeprovisionJob(c)
+				if assert.NotNil(t, job, "expected deprovision job") {
+					assert.Equal(t, constants.JobTypeDeprovision, job.Labels[constants.JobTypeLabel], "unexpected job type")
+					assert.Equal(t, testDeploymentName, job.Labels[constants.ClusterDeploymentNameLabel], "unexpected cluster deployment label")
+					assert.Contains(t, job.Spec.Template.Spec.Containers[0].Args, "kubernetes.io/cluster/test-infra-id=owned", "unexpected deprovision filter")
+				}
+			},
+		},
+		{
+			name: "wait for deprovision of aborted provision",
+			existing: []runtime.Object{
+				testProvision(withJob(), provisioning(), withAbortAnnotation(), withFailedCondition("Aborted"), withInfraID()),
+				testClusterDeployment(),
+				testAbortDeprovisionJob(),
+			},
+			expectedStage:      hivev1.ClusterProvisionStageProvisioning,
+			expectedFailReason: "Aborted",
+			expectNoJob:        true,
+		},
+		{
+			name: "fail aborted provision once deprovisioned",
+			existing: []runtime.Object{
+				testProvision(withJob(), provisioning(), withAbortAnnotation(), withFailedCondition("Aborted"), withInfraID()),
+				testClusterDeployment(),
+				testAbortDeprovisionJob(completed()),
+			},
+			expectedStage:      hivev1.ClusterProvisionStageFailed,
+			expectedFailReason: "Aborted",
+			expectNoJob:        true,
+		},
+		{
+			name: "abort annotation ignored for completed provision",
+			existing: []runtime.Object{
+				testProvision(withJob(), succeeded(), withCreationTime(time.Now()), withAbortAnnotation()),
+			},
+			expectedStage: hivev1.ClusterProvisionStageComplete,
+			expectNoJob:   true,
+		},
+		{
+			name: "provision within SLA",
+			existing: []runtime.Object{
+				testProvision(withJob(), withCreationTime(time.Now().Add(-time.Hour))),
+				testJob(),
+				testPod("foo", running()),
+			},
+			provisionSLA:  90 * time.Minute,
+			expectedStage: hivev1.ClusterProvisionStageInitializing,
+			validateRequeueAfter: func(requeueAfter time.Duration, c client.Client, t *testing.T) {
+				assert.Greater(t, requeueAfter.Nanoseconds(), 29*time.Minute.Nanoseconds(), "unexpected requeue after duration")
+				assert.LessOrEqual(t, requeueAfter.Nanoseconds(), 30*time.Minute.Nanoseconds(), "unexpected requeue after duration")
+			},
+			validate: func(c client.Client, t *testing.T) {
+				provision := getProvision(c)
+				require.NotNil(t, provision, "could not get ClusterProvision")
+				assert.Nil(t, controllerutils.FindClusterProvisionCondition(provision.Status.Conditions, hivev1.ClusterProvisionSLABreachedCondition), "unexpected SLA breached condition")
+			},
+		},
+		{
+			name: "provision breaching SLA",
+			existing: []runtime.Object{
+				testProvision(withJob(), provisioning(), withCreationTime(time.Now().Add(-2*time.Hour))),
+				testJob(),
+				testPod("foo", running()),
+			},
+			provisionSLA:  90 * time.Minute,
+			expectedStage: hivev1.ClusterProvisionStageProvisioning,
+			validate: func(c client.Client, t *testing.T) {
+				provision := getProvision(c)
+				require.NotNil(t, provision, "could not get ClusterProvision")
+				assertConditionStatus(t, provision, hivev1.ClusterProvisionSLABreachedCondition, corev1.ConditionTrue)
+				assertConditionReason(t, provision, hivev1.ClusterProvisionSLABreachedCondition, "SLABreached")
+			},
+		},
+		{
+			name: "abort provision breaching SLA",
+			existing: []runtime.Object{
+				testProvision(withJob(), provisioning(), withCreationTime(time.Now().Add(-2*time.Hour))),
+				testJob(),
+				testPod("foo", running()),
+			},
+			provisionSLA:       90 * time.Minute,
+			provisionSLAAction: hivev1.ProvisionSLAActionAbort,
+			expectedStage:      hivev1.ClusterProvisionStageProvisioning,
+			expectedFailReason: "ProvisionSLABreached",
+			expectNoJob:        true,
+			validate: func(c client.Client, t *testing.T) {
+				provision := getProvision(c)
+				require.NotNil(t, provision, "could not get ClusterProvision")
+				assertConditionStatus(t, provision, hivev1.ClusterProvisionSLABreachedCondition, corev1.ConditionTrue)
+			},
+		},
+		{
+			name: "install job within install timeout",
+			existing: []runtime.Object{
+				testProvision(withJob(), provisioning(), withInstallTimeout(2*time.Hour)),
+				testJob(withCreationTimestamp(time.Now().Add(-90 * time.Minute))),
+				testPod("foo", running()),
+			},
+			expectedStage: hivev1.ClusterProvisionStageProvisioning,
+			validateRequeueAfter: func(requeueAfter time.Duration, c client.Client, t *testing.T) {
+				assert.Greater(t, requeueAfter.Nanoseconds(), 29*time.Minute.Nanoseconds(), "unexpected requeue after duration")
+				assert.LessOrEqual(t, requeueAfter.Nanoseconds(), 30*time.Minute.Nanoseconds(), "unexpected requeue after duration")
+			},
+		},
+		{
+			name: "abort provision of timed out install job",
+			existing: []runtime.Object{
+				testProvision(withJob(), provisioning(), withInstallTimeout(2*time.Hour)),
+				testJob(withCreationTimestamp(time.Now().Add(-3 * time.Hour))),
+				testPod("foo", running()),
+			},
+			expectedStage:      hivev1.ClusterProvisionStageProvisioning,
+			expectedFailReason: "InstallTimedOut",
+			expectNoJob:        true,
+			expectedEvents:     1,
+		},
+		{
+			name: "initializing stage within timeout",
+			existing: []runtime.Object{
+				testProvision(withJob()),
+				testJob(withCreationTimestamp(time.Now().Add(-10 * time.Minute))),
+				testPod("foo", running()),
+			},
+			stageTimeouts: map[hivev1.ClusterProvisionStage]time.Duration{
+				hivev1.ClusterProvisionStageInitializing: 30 * time.Minute,
+			},
+			expectedStage: hivev1.ClusterProvisionStageInitializing,
+			validateRequeueAfter: func(requeueAfter time.Duration, c client.Client, t *testing.T) {
+				assert.Greater(t, requeueAfter.Nanoseconds(), 19*time.Minute.Nanoseconds(), "unexpected requeue after duration")
+				assert.LessOrEqual(t, requeueAfter.Nanoseconds(), 20*time.Minute.Nanoseconds(), "unexpected requeue after duration")
+			},
+		},
+		{
+			name: "abort provision timed out in initializing stage",
+			existing: []runtime.Object{
+				testProvision(withJob()),
+				testJob(withCreationTimestamp(time.Now().Add(-time.Hour))),
+			},
+			stageTimeouts: map[hivev1.ClusterProvisionStage]time.Duration{
+				hivev1.ClusterProvisionStageInitializing: 30 * time.Minute,
+			},
+			expectedStage:      hivev1.ClusterProvisionStageInitializing,
+			expectedFailReason: "StageTimeout_Initializing",
+			expectNoJob:        true,
+			expectedEvents:     1,
+		},
+		{
+			name: "provisioning stage timed from initialization",
+			existing: []runtime.Object{
+				testProvision(withJob(), provisioning(), withInitializedCondition(time.Now().Add(-30*time.Minute))),
+				testJob(withCreationTimestamp(time.Now().Add(-2 * time.Hour))),
+				testPod("foo", running()),
+			},
+			stageTimeouts: map[hivev1.ClusterProvisionStage]time.Duration{
+				hivev1.ClusterProvisionStageInitializing: 30 * time.Minute,
+				hivev1.ClusterProvisionStageProvisioning: time.Hour,
+			},
+			expectedStage: hivev1.ClusterProvisionStageProvisioning,
+			validateRequeueAfter: func(requeueAfter time.Duration, c client.Client, t *testing.T) {
+				assert.Greater(t, requeueAfter.Nanoseconds(), 29*time.Minute.Nanoseconds(), "unexpected requeue after duration")
+				assert.LessOrEqual(t, requeueAfter.Nanoseconds(), 30*time.Minute.Nanoseconds(), "unexpected requeue after duration")
+			},
+		},
+		{
+			name: "abort provision timed out in provisioning stage",
+			existing: []runtime.Object{
+				testProvision(withJob(), provisioning(), withInitializedCondition(time.Now().Add(-90*time.Minute))),
+				testJob(withCreationTimestamp(time.Now().Add(-2 * time.Hour))),
+				testPod("foo", running()),
+			},
+			stageTimeouts: map[hivev1.ClusterProvisionStage]time.Duration{
+				hivev1.ClusterProvisionStageProvisioning: time.Hour,
+			},
+			expectedStage:      hivev1.ClusterProvisionStageProvisioning,
+			expectedFailReason: "StageTimeout_Provisioning",
+			expectNoJob:        true,
+			expectedEvents:     1,
+		},
+		{
+			name: "fail timed out provision once install job is gone",
+			existing: []runtime.Object{
+				testProvision(withJob(), provisioning(), withInstallTimeout(2*time.Hour), withFailedCondition("InstallTimedOut")),
+			},
+			expectedStage:      hivev1.ClusterProvisionStageFailed,
+			expectedFailReason: "InstallTimedOut",
+			expectNoJob:        true,
+		},
+		{
+			name: "snapshot logs of completed install pod",
+			existing: []runtime.Object{
+				testProvision(withJob(), provisioning()),
+				testJob(completed()),
+				testPod("foo", success(), withContainer("hive")),
+			},
+			podLogSnapshots: true,
+			expectedStage:   hivev1.ClusterProvisionStageComplete,
+			validate: func(c client.Client, t *testing.T) {
+				provision := getProvision(c)
+				require.NotNil(t, provision, "could not get ClusterProvision")
+				if assert.NotNil(t, provision.Status.LogSnapshotRef, "expected reference to pod log snapshot") {
+					assertPodLogSnapshot(t, c, provision.Status.LogSnapshotRef.Name, map[string]string{"hive.log": "logs of test-provision-name-provision-foo/hive"})
+				}
+			},
+		},
+		{
+			name: "snapshot logs of install pod of aborted provision",
+			existing: []runtime.Object{
+				testProvision(withJob(), provisioning(), withCreationTime(time.Now().Add(-2*time.Hour))),
+				testJob(),
+				testPod("foo", running(), withContainer("hive")),
+			},
+			podLogSnapshots:    true,
+			provisionSLA:       90 * time.Minute,
+			provisionSLAAction: hivev1.ProvisionSLAActionAbort,
+			expectedStage:      hivev1.ClusterProvisionStageProvisioning,
+			expectedFailReason: "ProvisionSLABreached",
+			expectNoJob:        true,
+			validate: func(c client.Client, t *testing.T) {
+				provision := getProvision(c)
+				require.NotNil(t, provision, "could not get ClusterProvision")
+				if assert.NotNil(t, provision.Status.LogSnapshotRef, "expected reference to pod log snapshot") {
+					assertPodLogSnapshot(t, c, provision.Status.LogSnapshotRef.Name, map[string]string{"hive.log": "logs of test-provision-name-provision-foo/hive"})
+				}
+			},
+		},
+		{
+			name: "no log snapshot when disabled",
+			existing: []runtime.Object{
+				testProvision(withJob(), provisioning()),
+				testJob(completed()),
+				testPod("foo", success(), withContainer("hive")),
+			},
+			expectedStage: hivev1.ClusterProvisionStageComplete,
+			validate: func(c client.Client, t *testing.T) {
+				provision := getProvision(c)
+				require.NotNil(t, provision, "could not get ClusterProvision")
+				assert.Nil(t, provision.Status.LogSnapshotRef, "unexpected reference to pod log snapshot")
+			},
+		},
+		{
+			name: "create must-gather job for failed provision which completed bootstrapping",
+			existing: []runtime.Object{
+				testProvision(failed(), withJob(), withInstallPodSpec(), withAdminKubeconfig(), withBootstrapComplete()),
+				testJob(failedJob()),
+			},
+			collectMustGather:     true,
+			expectedStage:         hivev1.ClusterProvisionStageFailed,
+			expectPendingCreation: true,
+			validate: func(c client.Client, t *testing.T) {
+				assert.NotNil(t, getMustGatherJob(c), "expected must-gather job")
+				provision := getProvision(c)
+				require.NotNil(t, provision, "could not get ClusterProvision")
+				if assert.NotNil(t, provision.Status.MustGather, "expected must-gather status") {
+					assert.Equal(t, mustGatherJobName, provision.Status.MustGather.JobRef.Name, "unexpected must-gather job reference")
+					assert.Nil(t, provision.Status.MustGather.CompletionTime, "unexpected must-gather completion time")
+				}
+			},
+		},
+		{
+			name: "no must-gather job when disabled",
+			existing: []runtime.Object{
+				testProvision(failed(), withJob(), withInstallPodSpec(), withAdminKubeconfig(), withBootstrapComplete()),
+				testJob(failedJob()),
+			},
+			expectedStage: hivev1.ClusterProvisionStageFailed,
+			validate: func(c client.Client, t *testing.T) {
+				assert.Nil(t, getMustGatherJob(c), "unexpected must-gather job")
+				provision := getProvision(c)
+				require.NotNil(t, provision, "could not get ClusterProvision")
+				assert.Nil(t, provision.Status.MustGather, "unexpected must-gather status")
+			},
+		},
+		{
+			name: "no must-gather job when bootstrapping did not complete",
+			existing: []runtime.Object{
+				testProvision(failed(), withJob(), withInstallPodSpec(), withAdminKubeconfig()),
+				testJob(failedJob()),
+			},
+			collectMustGather: true,
+			expectedStage:     hivev1.ClusterProvisionStageFailed,
+			validate: func(c client.Client, t *testing.T) {
+				assert.Nil(t, getMustGatherJob(c), "unexpected must-gather job")
+			},
+		},
+		{
+			name: "must-gather job still running",
+			existing: []runtime.Object{
+				testProvision(failed(), withJob(), withInstallPodSpec(), withAdminKubeconfig(), withBootstrapComplete(), withMustGather()),
+				testJob(failedJob()),
+				testMustGatherJob(),
+			},
+			collectMustGather: true,
+			expectedStage:     hivev1.ClusterProvisionStageFailed,
+			validate: func(c client.Client, t *testing.T) {
+				provision := getProvision(c)
+				require.NotNil(t, provision, "could not get ClusterProvision")
+				if assert.NotNil(t, provision.Status.MustGather, "expected must-gather status") {
+					assert.Nil(t, provision.Status.MustGather.CompletionTime, "unexpected must-gather completion time")
+				}
+			},
+		},
+		{
+			name: "must-gather job completed",
+			existing: []runtime.Object{
+				testProvision(failed(), withJob(), withInstallPodSpec(), withAdminKubeconfig(), withBootstrapComplete(), withMustGather()),
+				testJob(failedJob()),
+				testMustGatherJob(completed()),
+			},
+			collectMustGather: true,
+			expectedStage:     hivev1.ClusterProvisionStageFailed,
+			validate: func(c client.Client, t *testing.T) {
+				provision := getProvision(c)
+				require.NotNil(t, provision, "could not get ClusterProvision")
+				if assert.NotNil(t, provision.Status.MustGather, "expected must-gather status") {
+					assert.NotNil(t, provision.Status.MustGather.CompletionTime, "expected must-gather completion time")
+				}
+			},
+		},
+		{
+			name: "must-gather job failed",
+			existing: []runtime.Object{
+				testProvision(failed(), withJob(), withInstallPodSpec(), withAdminKubeconfig(), withBootstrapComplete(), withMustGather()),
+				testJob(failedJob()),
+				testMustGatherJob(failedJob()),
+			},
+			collectMustGather: true,
+			expectedStage:     hivev1.ClusterProvisionStageFailed,
+			expectedEvents:    1,
+			validate: func(c client.Client, t *testing.T) {
+				provision := getProvision(c)
+				require.NotNil(t, provision, "could not get ClusterProvision")
+				if assert.NotNil(t, provision.Status.MustGather, "expected must-gather status") {
+					assert.NotNil(t, provision.Status.MustGather.CompletionTime, "expected must-gather completion time")
+				}
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -251,11 +830,27 @@ func TestClusterProvisionReconcile(t *testing.T) {
 			logger := log.WithField("controller", "clusterProvision")
 			fakeClient := fake.NewFakeClient(test.existing...)
 			controllerExpectations := controllerutils.NewExpectations(logger)
+			eventRecorder := record.NewFakeRecorder(10)
 			rcp := &ReconcileClusterProvision{
 				Client:       fakeClient,
 				scheme:       scheme.Scheme,
 				logger:       logger,
 				expectations: controllerExpectations,
+
+				provisionSLA:       test.provisionSLA,
+				provisionSLAAction: test.provisionSLAAction,
+
+				installPodStuckRecreateJob: test.recreateStuckJob,
+				installPodStuckFailAfter:   test.stuckFailAfter,
+				stageTimeouts:              test.stageTimeouts,
+				eventRecorder:              eventRecorder,
+
+				installJobSpreadMode: test.installJobSpreadMode,
+				collectMustGather:    test.collectMustGather,
+			}
+			if test.podLogSnapshots {
+				rcp.podLogTailer = &fakePodLogTailer{}
+				rcp.podLogSnapshotMaxBytes = 1024
 			}
 
 			reconcileRequest := reconcile.Request{
@@ -311,6 +906,8 @@ func TestClusterProvisionReconcile(t *testing.T) {
 			actualPendingCreation := !controllerExpectations.SatisfiedExpectations(reconcileRequest.String())
 			assert.Equal(t, test.expectPendingCreation, actualPendingCreation, "unexpected pending creation")
 
+			assert.Len(t, eventRecorder.Events, test.expectedEvents, "unexpected events")
+
 			if test.validate != nil {
 				test.validate(fakeClient, t)
 			}
@@ -376,6 +973,12 @@ func withCreationTime(creationTime time.Time) provisionOption {
 	}
 }
 
+func withInstallTimeout(timeout time.Duration) provisionOption {
+	return func(p *hivev1.ClusterProvision) {
+		p.Spec.InstallTimeout = &metav1.Duration{Duration: timeout}
+	}
+}
+
 func withFailedCondition(reason string) provisionOption {
 	return func(p *hivev1.ClusterProvision) {
 		p.Status.Conditions = append(
@@ -389,6 +992,95 @@ func withFailedCondition(reason string) provisionOption {
 	}
 }
 
+func withInstallPodStuckCondition(reason string, since time.Time) provisionOption {
+	return func(p *hivev1.ClusterProvision) {
+		p.Status.Conditions = append(
+			p.Status.Conditions,
+			hivev1.ClusterProvisionCondition{
+				Type:               hivev1.InstallPodStuckCondition,
+				Status:             corev1.ConditionTrue,
+				Reason:             reason,
+				LastTransitionTime: metav1.NewTime(since),
+			},
+		)
+	}
+}
+
+func withInitializedCondition(since time.Time) provisionOption {
+	return func(p *hivev1.ClusterProvision) {
+		p.Status.Conditions = append(
+			p.Status.Conditions,
+			hivev1.ClusterProvisionCondition{
+				Type:               hivev1.ClusterProvisionInitializedCondition,
+				Status:             corev1.ConditionTrue,
+				Reason:             "Initialized",
+				LastTransitionTime: metav1.NewTime(since),
+			},
+		)
+	}
+}
+
+func withAbortAnnotation() provisionOption {
+	return func(p *hivev1.ClusterProvision) {
+		if p.Annotations == nil {
+			p.Annotations = map[string]string{}
+		}
+		p.Annotations[constants.AbortProvisionAnnotation] = "true"
+	}
+}
+
+func withInfraID() provisionOption {
+	return func(p *hivev1.ClusterProvision) {
+		infraID := "test-infra-id"
+		p.Spec.InfraID = &infraID
+	}
+}
+
+func withInstallPodSpec() provisionOption {
+	return func(p *hivev1.ClusterProvision) {
+		p.Spec.PodSpec = corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Name: "installer"},
+				{Name: "cli"},
+				{Name: "hive", Args: []string{"/usr/bin/hiveutil install-manager --work-dir /output " + testNamespace + " " + testProvisionName}},
+			},
+		}
+	}
+}
+
+func withAdminKubeconfig() provisionOption {
+	return func(p *hivev1.ClusterProvision) {
+		p.Spec.AdminKubeconfigSecretRef = &corev1.LocalObjectReference{Name: "admin-kubeconfig"}
+	}
+}
+
+func withBootstrapComplete() provisionOption {
+	return func(p *hivev1.ClusterProvision) {
+		p.Status.Conditions = append(
+			p.Status.Conditions,
+			hivev1.ClusterProvisionCondition{
+				Type:   hivev1.ClusterProvisionBootstrapCompleteCondition,
+				Status: corev1.ConditionTrue,
+				Reason: "BootstrapComplete",
+			},
+		)
+	}
+}
+
+func withMustGather() provisionOption {
+	return func(p *hivev1.ClusterProvision) {
+		p.Status.MustGather = &hivev1.ClusterProvisionMustGather{
+			JobRef: corev1.LocalObjectReference{Name: mustGatherJobName},
+		}
+	}
+}
+
+func withRecreatedJob(uid string) provisionOption {
+	return func(p *hivev1.ClusterProvision) {
+		p.Annotations = map[string]string{constants.RecreatedInstallJobUIDAnnotation: uid}
+	}
+}
+
 func testJob(opts ...testjob.Option) *batchv1.Job {
 	provision := testProvision()
 	job, err := install.GenerateInstallerJob(provision)
@@ -409,6 +1101,63 @@ func testJob(opts ...testjob.Option) *batchv1.Job {
 	return job
 }
 
+func testClusterDeployment() *hivev1.ClusterDeployment {
+	return &hivev1.ClusterDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testDeploymentName,
+			Namespace: testNamespace,
+		},
+		Spec: hivev1.ClusterDeploymentSpec{
+			Platform: hivev1.Platform{
+				AWS: &hivev1aws.Platform{
+					Region:               "us-east-1",
+					CredentialsSecretRef: corev1.LocalObjectReference{Name: "aws-creds"},
+				},
+			},
+		},
+	}
+}
+
+func testAbortDeprovisionJob(opts ...testjob.Option) *batchv1.Job {
+	provision := testProvision()
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      install.GetUninstallJobName(provision.Name),
+			Namespace: testNamespace,
+		},
+	}
+	controllerutil.SetControllerReference(provision, job, scheme.Scheme)
+
+	for _, o := range opts {
+		o(job)
+	}
+
+	return job
+}
+
+func getAbortDeprovisionJob(c client.Client) *batchv1.Job {
+	job := &batchv1.Job{}
+	if err := c.Get(context.TODO(), client.ObjectKey{Namespace: testNamespace, Name: install.GetUninstallJobName(testProvisionName)}, job); err != nil {
+		return nil
+	}
+	return job
+}
+
+func testMustGatherJob(opts ...testjob.Option) *batchv1.Job {
+	provision := testProvision(withInstallPodSpec())
+	job, err := install.GenerateMustGatherJob(provision)
+	if err != nil {
+		panic("should not error while generating test must-gather job")
+	}
+	controllerutil.SetControllerReference(provision, job, scheme.Scheme)
+
+	for _, o := range opts {
+		o(job)
+	}
+
+	return job
+}
+
 func completed() testjob.Option {
 	return func(job *batchv1.Job) {
 		job.Status.Conditions = append(job.Status.Conditions,
@@ -435,6 +1184,10 @@ func withCreationTimestamp(time time.Time) testjob.Option {
 	return testjob.Generic(testgeneric.WithCreationTimestamp(time))
 }
 
+func withUID(uid string) testjob.Option {
+	return testjob.Generic(testgeneric.WithUID(uid))
+}
+
 func getJob(c client.Client) *batchv1.Job {
 	job := &batchv1.Job{}
 	err := c.Get(context.TODO(), client.ObjectKey{Name: installJobName, Namespace: testNamespace}, job)
@@ -444,6 +1197,14 @@ func getJob(c client.Client) *batchv1.Job {
 	return nil
 }
 
+func getMustGatherJob(c client.Client) *batchv1.Job {
+	job := &batchv1.Job{}
+	if err := c.Get(context.TODO(), client.ObjectKey{Name: mustGatherJobName, Namespace: testNamespace}, job); err != nil {
+		return nil
+	}
+	return job
+}
+
 func getProvision(c client.Client) *hivev1.ClusterProvision {
 	provision := &hivev1.ClusterProvision{}
 	if err := c.Get(context.TODO(), client.ObjectKey{Name: testProvisionName, Namespace: testNamespace}, provision); err != nil {
@@ -478,18 +1239,65 @@ func pending() podOption {
 	}
 }
 
+func unschedulable(message string) podOption {
+	return func(pod *corev1.Pod) {
+		pod.Status.Conditions = append(pod.Status.Conditions, corev1.PodCondition{
+			Type:    corev1.PodScheduled,
+			Status:  corev1.ConditionFalse,
+			Reason:  corev1.PodReasonUnschedulable,
+			Message: message,
+		})
+	}
+}
+
+func withContainer(name string) podOption {
+	return func(pod *corev1.Pod) {
+		pod.Spec.Containers = append(pod.Spec.Containers, corev1.Container{Name: name})
+	}
+}
+
 func running() podOption {
 	return func(pod *corev1.Pod) {
 		pod.Status.Phase = "Running"
 	}
 }
 
+func startedAt(startTime time.Time) podOption {
+	return func(pod *corev1.Pod) {
+		pod.Status.StartTime = &metav1.Time{Time: startTime}
+	}
+}
+
 func success() podOption {
 	return func(pod *corev1.Pod) {
 		pod.Status.Phase = "Succeeded"
 	}
 }
 
+func testNode(name string) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Spec: corev1.NodeSpec{
+			Taints: []corev1.Taint{{Key: "node-role.kubernetes.io/master", Effect: corev1.TaintEffectNoSchedule}},
+		},
+	}
+}
+
+type fakePodLogTailer struct{}
+
+func (t *fakePodLogTailer) TailLogs(namespace, pod, container string, maxBytes int) (string, error) {
+	return fmt.Sprintf("logs of %s/%s", pod, container), nil
+}
+
+func assertPodLogSnapshot(t *testing.T, c client.Client, name string, expectedData map[string]string) {
+	cm := &corev1.ConfigMap{}
+	if err := c.Get(context.TODO(), client.ObjectKey{Namespace: testNamespace, Name: name}, cm); assert.NoError(t, err, "could not get pod log snapshot") {
+		assert.Equal(t, expectedData, cm.Data, "unexpected pod log snapshot")
+	}
+}
+
 func assertConditionStatus(t *testing.T, provision *hivev1.ClusterProvision, condType hivev1.ClusterProvisionConditionType, status corev1.ConditionStatus) {
 	for _, cond := range provision.Status.Conditions {
 		if cond.Type == condType {
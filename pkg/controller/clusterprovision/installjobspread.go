@@ -0,0 +1,76 @@
+package clusterprovision
+
+import (
+	"context"
+
+	log "github.com/sirupsen/logrus"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	// defaultInstallJobSpreadTopologyKey is the node label install pods are spread across when none is configured.
+	defaultInstallJobSpreadTopologyKey = corev1.LabelHostname
+
+	// installJobSpreadWeight is the weight of the preferred anti-affinity of install pods.
+	installJobSpreadWeight = 100
+)
+
+// spreadInstallJob adds an anti-affinity to the pod of the install job against the pods of the other install jobs which
+// are still running, so that concurrent install pods are spread across topology domains. Topology spread constraints
+// only consider pods in the namespace of the pod, while each install job runs in the namespace of its cluster, so the
+// anti-affinity term lists the namespaces of the running install jobs instead.
+func (r *ReconcileClusterProvision) spreadInstallJob(job *batchv1.Job, pLog log.FieldLogger) error {
+	if r.installJobSpreadMode == "" {
+		return nil
+	}
+	jobs := &batchv1.JobList{}
+	if err := r.List(context.TODO(), jobs, client.MatchingLabels{constants.InstallJobLabel: "true"}); err != nil {
+		pLog.WithError(err).Log(controllerutils.LogLevel(err), "could not list install jobs to spread the install job")
+		return err
+	}
+	namespaces := sets.NewString(job.Namespace)
+	for _, j := range jobs.Items {
+		if !controllerutils.IsFinished(&j) {
+			namespaces.Insert(j.Namespace)
+		}
+	}
+	pLog.WithField("installJobs", namespaces.Len()-1).Debug("spreading install job from the running install jobs")
+
+	term := corev1.PodAffinityTerm{
+		LabelSelector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{constants.InstallJobLabel: "true"},
+		},
+		Namespaces:  namespaces.List(),
+		TopologyKey: r.installJobSpreadTopologyKey,
+	}
+	if term.TopologyKey == "" {
+		term.TopologyKey = defaultInstallJobSpreadTopologyKey
+	}
+
+	podSpec := &job.Spec.Template.Spec
+	if podSpec.Affinity == nil {
+		podSpec.Affinity = &corev1.Affinity{}
+	}
+	if podSpec.Affinity.PodAntiAffinity == nil {
+		podSpec.Affinity.PodAntiAffinity = &corev1.PodAntiAffinity{}
+	}
+	antiAffinity := podSpec.Affinity.PodAntiAffinity
+	switch r.installJobSpreadMode {
+	case hivev1.InstallJobSpreadModeRequired:
+		antiAffinity.RequiredDuringSchedulingIgnoredDuringExecution = append(antiAffinity.RequiredDuringSchedulingIgnoredDuringExecution, term)
+	default:
+		antiAffinity.PreferredDuringSchedulingIgnoredDuringExecution = append(antiAffinity.PreferredDuringSchedulingIgnoredDuringExecution, corev1.WeightedPodAffinityTerm{
+			Weight:          installJobSpreadWeight,
+			PodAffinityTerm: term,
+		})
+	}
+	return nil
+}
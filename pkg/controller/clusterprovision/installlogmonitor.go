@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 
 	corev1 "k8s.io/api/core/v1"
@@ -31,43 +32,33 @@ func (r *ReconcileClusterProvision) parseInstallLog(log *string, pLog log.FieldL
 	}
 
 	// Load the regex configmap, if we don't have one, there's not much point proceeding here.
-	regexCM := &corev1.ConfigMap{}
-	if err := r.Get(context.TODO(), types.NamespacedName{Name: regexConfigMapName, Namespace: controllerutils.GetHiveNamespace()}, regexCM); err != nil {
-		pLog.WithError(err).Errorf("error loading %s configmap", regexConfigMapName)
+	regexes, err := r.loadInstallLogRegexes(regexConfigMapName)
+	if err != nil {
 		// Even if the error was a transient error in fetching the configmap, we should not block
 		// the continuation of deploying the cluster just so that we can potentially get a
 		// better failure message.
+		pLog.WithError(err).Error("cannot load install log regexes")
 		return unknownReason, regexBadMessage
 	}
 
-	regexesRaw, ok := regexCM.Data[regexDataEntryName]
-	if !ok {
-		pLog.Errorf("%s configmap does not have a %q data entry", regexConfigMapName, regexDataEntryName)
-		return unknownReason, regexBadMessage
-	}
-
-	regexes := []installLogRegex{}
-	if err := yaml.Unmarshal([]byte(regexesRaw), &regexes); err != nil {
-		pLog.WithError(err).Errorf("cannot unmarshal data from %s configmap", regexConfigMapName)
-		return unknownReason, regexBadMessage
+	// Load the rule sets configured in HiveConfig, which take precedence over the built-in regexes. Continue anyway
+	// if one cannot be loaded.
+	combinedRegexes := []installLogRegex{}
+	for _, name := range r.installLogRegexConfigMaps {
+		ruleSet, err := r.loadInstallLogRegexes(name)
+		if err != nil {
+			pLog.WithError(err).Error("cannot load install log regexes rule set")
+			continue
+		}
+		combinedRegexes = append(combinedRegexes, ruleSet...)
 	}
+	combinedRegexes = append(combinedRegexes, regexes...)
 
 	// Load additional regex configmap, continue anyway if configmap isn't present
-	additionalRegexes := []installLogRegex{}
-	additionalRegexCM := &corev1.ConfigMap{}
-	if additionalRegexCMErr := r.Get(context.TODO(), types.NamespacedName{Name: additionalRegexConfigMapName, Namespace: controllerutils.GetHiveNamespace()}, additionalRegexCM); additionalRegexCMErr != nil {
-		pLog.WithError(additionalRegexCMErr).Errorf("error loading %s configmap", additionalRegexConfigMapName)
+	if additionalRegexes, err := r.loadInstallLogRegexes(additionalRegexConfigMapName); err != nil {
+		pLog.WithError(err).Error("cannot load additional install log regexes")
 	} else {
-		additionalRegexesRaw, ok := additionalRegexCM.Data[regexDataEntryName]
-		if !ok {
-			pLog.Errorf("%s configmap does not have a %q data entry", additionalRegexConfigMapName, regexDataEntryName)
-		} else {
-			if additionalRegexesRaw != "" {
-				if err := yaml.Unmarshal([]byte(additionalRegexesRaw), &additionalRegexes); err != nil {
-					pLog.WithError(err).Errorf("cannot unmarshal data from %s configmap", regexConfigMapName)
-				}
-			}
-		}
+		combinedRegexes = append(combinedRegexes, additionalRegexes...)
 	}
 
 	pLog.Info("processing new install log")
@@ -79,22 +70,71 @@ func (r *ReconcileClusterProvision) parseInstallLog(log *string, pLog log.FieldL
 	}
 
 	// Scan log contents for known errors
-	combinedRegexes := append(regexes, additionalRegexes...)
 	for _, ilr := range combinedRegexes {
 		ilrLog := pLog.WithField("regexName", ilr.Name)
 		ilrLog.Debug("parsing regex entry")
 		for _, ss := range ilr.SearchRegexStrings {
 			ssLog := ilrLog.WithField("searchString", ss)
 			ssLog.Debug("matching search string")
-			switch match, err := regexp.Match(ss, []byte(*log)); {
-			case err != nil:
+			re, err := regexp.Compile(ss)
+			if err != nil {
 				ssLog.WithError(err).Error("unable to compile regex")
-			case match:
+				continue
+			}
+			if match := re.FindStringSubmatchIndex(*log); match != nil {
 				pLog.WithField("reason", ilr.InstallFailingReason).Info("found known install failure string")
-				return ilr.InstallFailingReason, ilr.InstallFailingMessage
+				return ilr.InstallFailingReason, installFailingMessage(re, ilr.InstallFailingMessage, *log, match)
 			}
 		}
 	}
 
 	return unknownReason, unknownMessage
 }
+
+// loadInstallLogRegexes loads the install log regexes of the configmap in the hive namespace.
+func (r *ReconcileClusterProvision) loadInstallLogRegexes(name string) ([]installLogRegex, error) {
+	cm := &corev1.ConfigMap{}
+	if err := r.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: controllerutils.GetHiveNamespace()}, cm); err != nil {
+		return nil, errors.Wrapf(err, "error loading %s configmap", name)
+	}
+	raw, ok := cm.Data[regexDataEntryName]
+	if !ok {
+		return nil, errors.Errorf("%s configmap does not have a %q data entry", name, regexDataEntryName)
+	}
+	regexes := []installLogRegex{}
+	if raw == "" {
+		return regexes, nil
+	}
+	if err := yaml.Unmarshal([]byte(raw), &regexes); err != nil {
+		return nil, errors.Wrapf(err, "cannot unmarshal data from %s configmap", name)
+	}
+	return regexes, nil
+}
+
+// messageGroupRefRegex matches the references to named capture groups in install failing messages.
+var messageGroupRefRegex = regexp.MustCompile(`\$\{(\w+)\}`)
+
+// installFailingMessage expands the references to the named capture groups of the matching search string in the
+// message of the install log regex. Any other text of the message, including other uses of $, is kept as is.
+func installFailingMessage(re *regexp.Regexp, message, log string, match []int) string {
+	groups := map[string]string{}
+	for i, name := range re.SubexpNames() {
+		if name == "" {
+			continue
+		}
+		// Groups which did not participate in the match expand to the empty string.
+		groups[name] = ""
+		if match[2*i] >= 0 {
+			groups[name] = log[match[2*i]:match[2*i+1]]
+		}
+	}
+	if len(groups) == 0 {
+		return message
+	}
+	return messageGroupRefRegex.ReplaceAllStringFunc(message, func(ref string) string {
+		if value, ok := groups[messageGroupRefRegex.FindStringSubmatch(ref)[1]]; ok {
+			return value
+		}
+		return ref
+	})
+}
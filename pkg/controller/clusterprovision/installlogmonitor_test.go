@@ -37,10 +37,12 @@ const (
 func TestParseInstallLog(t *testing.T) {
 	apis.AddToScheme(scheme.Scheme)
 	tests := []struct {
-		name           string
-		log            *string
-		existing       []runtime.Object
-		expectedReason string
+		name            string
+		log             *string
+		existing        []runtime.Object
+		ruleSets        []string
+		expectedReason  string
+		expectedMessage string
 	}{
 		{
 			name:           "DNS already exists",
@@ -158,6 +160,74 @@ func TestParseInstallLog(t *testing.T) {
 			},
 			expectedReason: "KubeAPIWaitTimeoutRegexes",
 		},
+		{
+			name: "rule sets take precedence over regexes in order",
+			log:  pointer.StringPtr(genericLimitExceeded),
+			existing: []runtime.Object{
+				buildRegexConfigMap(),
+				buildRuleSetConfigMap("org-rules", `
+- name: GenericLimit
+  searchRegexStrings:
+  - "Generic: (?P<code>[A-Za-z]+LimitExceeded)"
+  installFailingReason: OrgGenericLimitExceeded
+  installFailingMessage: Generic limit exceeded (${code}), request an increase from the cloud team
+`),
+				buildRuleSetConfigMap("other-rules", `
+- name: GenericLimit
+  searchRegexStrings:
+  - "GenericLimitExceeded"
+  installFailingReason: OtherGenericLimitExceeded
+  installFailingMessage: Generic limit exceeded
+`),
+			},
+			ruleSets:        []string{"org-rules", "other-rules"},
+			expectedReason:  "OrgGenericLimitExceeded",
+			expectedMessage: "Generic limit exceeded (GenericLimitExceeded), request an increase from the cloud team",
+		},
+		{
+			name: "missing rule set",
+			log:  pointer.StringPtr(genericLimitExceeded),
+			existing: []runtime.Object{
+				buildRegexConfigMap(),
+			},
+			ruleSets:        []string{"missing-rules"},
+			expectedReason:  "ResourceLimitExceeded",
+			expectedMessage: "Resource limit exceeded",
+		},
+		{
+			name: "message without named capture groups is not expanded",
+			log:  pointer.StringPtr(gcpSSDQUotaLog),
+			existing: []runtime.Object{
+				buildRuleSetConfigMap("org-rules", `
+- name: GCPQuota
+  searchRegexStrings:
+  - "Quota '(\\w+)' exceeded"
+  installFailingReason: GCPQuotaExceeded
+  installFailingMessage: GCP quota $1 exceeded
+`),
+				buildRegexConfigMap(),
+			},
+			ruleSets:        []string{"org-rules"},
+			expectedReason:  "GCPQuotaExceeded",
+			expectedMessage: "GCP quota $1 exceeded",
+		},
+		{
+			name: "only references to named capture groups are expanded",
+			log:  pointer.StringPtr(genericLimitExceeded),
+			existing: []runtime.Object{
+				buildRuleSetConfigMap("org-rules", `
+- name: GenericLimit
+  searchRegexStrings:
+  - "Generic: (?P<code>[A-Za-z]+LimitExceeded)"
+  installFailingReason: OrgGenericLimitExceeded
+  installFailingMessage: ${code} costs $100, see ${docs} and $1 or $code
+`),
+				buildRegexConfigMap(),
+			},
+			ruleSets:        []string{"org-rules"},
+			expectedReason:  "OrgGenericLimitExceeded",
+			expectedMessage: "GenericLimitExceeded costs $100, see ${docs} and $1 or $code",
+		},
 		{
 			name:           "no log",
 			existing:       []runtime.Object{buildRegexConfigMap()},
@@ -245,12 +315,16 @@ func TestParseInstallLog(t *testing.T) {
 		t.Run(test.name, func(t *testing.T) {
 			fakeClient := fake.NewFakeClient(test.existing...)
 			r := &ReconcileClusterProvision{
-				Client: fakeClient,
-				scheme: scheme.Scheme,
+				Client:                    fakeClient,
+				scheme:                    scheme.Scheme,
+				installLogRegexConfigMaps: test.ruleSets,
 			}
 			reason, message := r.parseInstallLog(test.log, log.WithFields(log.Fields{}))
 			assert.Equal(t, test.expectedReason, reason, "unexpected reason")
 			assert.NotEmpty(t, message, "expected message to be not empty")
+			if test.expectedMessage != "" {
+				assert.Equal(t, test.expectedMessage, message, "unexpected message")
+			}
 		})
 	}
 }
@@ -304,3 +378,15 @@ func buildRegexConfigMap() *corev1.ConfigMap {
 	}
 	return cm
 }
+
+func buildRuleSetConfigMap(name, regexes string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: constants.DefaultHiveNamespace,
+		},
+		Data: map[string]string{
+			"regexes": regexes,
+		},
+	}
+}
@@ -2,7 +2,7 @@ package clusterprovision
 
 // installLogRegex is a struct that represents all the data we use to scan for certain
 // search strings in install logs. These structs are serialized as yaml and stored/read from
-// the install-log-regexes ConfigMap.
+// the install-log-regexes ConfigMap, and from the rule set ConfigMaps configured in HiveConfig.
 type installLogRegex struct {
 	// Name is the name of the regex.
 	Name string `json:"name"`
@@ -14,5 +14,6 @@ type installLogRegex struct {
 	InstallFailingReason string `json:"installFailingReason"`
 
 	// InstallFailingMessage is the user friendly sentence we report for this failure and conditions, metrics and logs.
+	// Named capture groups of the matching search string can be referenced as ${name}.
 	InstallFailingMessage string `json:"installFailingMessage"`
 }
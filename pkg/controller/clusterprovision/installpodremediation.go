@@ -0,0 +1,152 @@
+package clusterprovision
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	// schedulingStuckReason is the reason of the failure of a provision attempt whose install pod was stuck for too
+	// long.
+	schedulingStuckReason = "SchedulingStuck"
+
+	// maxDiagnosedNodes is the maximum number of nodes described in the scheduling diagnostics of a stuck install pod.
+	maxDiagnosedNodes = 10
+)
+
+// reconcileStuckInstallPod sets the InstallPodStuck condition for an install pod which is missing or stuck in the
+// pending phase, emitting an event with scheduling diagnostics when the pod is newly stuck, and remediates it. The
+// provision attempt fails once the pod has been stuck for longer than installPodStuckFailAfter. Before then, the
+// install job is recreated once while the provision is initializing, if configured to. It returns whether the stuck
+// install pod was remediated.
+func (r *ReconcileClusterProvision) reconcileStuckInstallPod(
+	instance *hivev1.ClusterProvision,
+	job *batchv1.Job,
+	pod *corev1.Pod,
+	reason string,
+	message string,
+	pLog log.FieldLogger,
+) (bool, reconcile.Result, error) {
+	newlyStuck := true
+	if cond := controllerutils.FindClusterProvisionCondition(instance.Status.Conditions, hivev1.InstallPodStuckCondition); cond != nil && cond.Status == corev1.ConditionTrue && cond.Reason == reason {
+		newlyStuck = false
+	}
+	if err := r.setCondition(instance, hivev1.InstallPodStuckCondition, corev1.ConditionTrue, reason, message, controllerutils.UpdateConditionIfReasonOrMessageChange, pLog); err != nil {
+		return false, reconcile.Result{}, err
+	}
+	if newlyStuck && r.eventRecorder != nil {
+		r.eventRecorder.Event(instance, corev1.EventTypeWarning, reason, fmt.Sprintf("Install pod is stuck: %s. %s", message, r.schedulingDiagnostics(pod, pLog)))
+	}
+
+	var stuckFor time.Duration
+	if cond := controllerutils.FindClusterProvisionCondition(instance.Status.Conditions, hivev1.InstallPodStuckCondition); cond != nil {
+		stuckFor = time.Since(cond.LastTransitionTime.Time)
+	}
+	if r.installPodStuckFailAfter > 0 && stuckFor >= r.installPodStuckFailAfter {
+		pLog.WithField("stuckFor", stuckFor).Error("install pod has been stuck for too long, failing the provision attempt")
+		result, err := r.abortProvision(
+			instance,
+			schedulingStuckReason,
+			fmt.Sprintf("Install pod was stuck for longer than %s: %s", r.installPodStuckFailAfter, message),
+			pLog,
+		)
+		return true, result, err
+	}
+	if r.installPodStuckRecreateJob &&
+		instance.Spec.Stage == hivev1.ClusterProvisionStageInitializing &&
+		instance.Annotations[constants.RecreatedInstallJobUIDAnnotation] == "" {
+		result, err := r.recreateInstallJob(instance, job, pLog)
+		return true, result, err
+	}
+	if r.installPodStuckFailAfter > 0 {
+		return false, reconcile.Result{RequeueAfter: r.installPodStuckFailAfter - stuckFor}, nil
+	}
+	return false, reconcile.Result{}, nil
+}
+
+// recreateInstallJob deletes the install job of the provision and clears the reference to it, so that a new install
+// job is created. The UID of the deleted job is recorded in an annotation so that it is only recreated once, and so
+// that the deleted job is not adopted again.
+func (r *ReconcileClusterProvision) recreateInstallJob(instance *hivev1.ClusterProvision, job *batchv1.Job, pLog log.FieldLogger) (reconcile.Result, error) {
+	pLog.Info("recreating install job since its pod is stuck")
+	if instance.Annotations == nil {
+		instance.Annotations = map[string]string{}
+	}
+	instance.Annotations[constants.RecreatedInstallJobUIDAnnotation] = string(job.UID)
+	if err := r.Update(context.TODO(), instance); err != nil {
+		pLog.WithError(err).Log(controllerutils.LogLevel(err), "could not record the install job to recreate")
+		return reconcile.Result{}, err
+	}
+	if err := r.Delete(context.TODO(), job, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil && !apierrors.IsNotFound(err) {
+		pLog.WithError(err).Log(controllerutils.LogLevel(err), "could not delete install job to recreate it")
+		return reconcile.Result{}, err
+	}
+	instance.Status.JobRef = nil
+	if err := r.Status().Update(context.TODO(), instance); err != nil {
+		pLog.WithError(err).Log(controllerutils.LogLevel(err), "could not clear the reference to the deleted install job")
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{}, nil
+}
+
+// schedulingDiagnostics describes why the install pod may not be scheduled: the last message of the scheduler about
+// the pod, the resources requested by the pod, and the allocatable capacity and taints of the nodes.
+func (r *ReconcileClusterProvision) schedulingDiagnostics(pod *corev1.Pod, pLog log.FieldLogger) string {
+	var diagnostics []string
+	if pod != nil {
+		for _, cond := range pod.Status.Conditions {
+			if cond.Type == corev1.PodScheduled && cond.Status == corev1.ConditionFalse && cond.Message != "" {
+				diagnostics = append(diagnostics, fmt.Sprintf("Scheduler: %s", strings.TrimSuffix(cond.Message, ".")))
+			}
+		}
+		cpu, memory := resource.Quantity{}, resource.Quantity{}
+		for _, container := range pod.Spec.Containers {
+			cpu.Add(*container.Resources.Requests.Cpu())
+			memory.Add(*container.Resources.Requests.Memory())
+		}
+		diagnostics = append(diagnostics, fmt.Sprintf("Pod requests cpu=%s memory=%s", cpu.String(), memory.String()))
+	}
+
+	nodes := &corev1.NodeList{}
+	if err := r.List(context.TODO(), nodes); err != nil {
+		pLog.WithError(err).Warn("could not list nodes for scheduling diagnostics")
+		return strings.Join(diagnostics, ". ")
+	}
+	for i, node := range nodes.Items {
+		if i == maxDiagnosedNodes {
+			diagnostics = append(diagnostics, fmt.Sprintf("%d more nodes", len(nodes.Items)-maxDiagnosedNodes))
+			break
+		}
+		description := fmt.Sprintf("Node %s allocatable cpu=%s memory=%s", node.Name, node.Status.Allocatable.Cpu(), node.Status.Allocatable.Memory())
+		if node.Spec.Unschedulable {
+			description += ", unschedulable"
+		}
+		if len(node.Spec.Taints) > 0 {
+			taints := make([]string, len(node.Spec.Taints))
+			for j, taint := range node.Spec.Taints {
+				taints[j] = taint.ToString()
+			}
+			description += fmt.Sprintf(", taints %s", strings.Join(taints, ","))
+		}
+		diagnostics = append(diagnostics, description)
+	}
+	if len(nodes.Items) == 0 {
+		diagnostics = append(diagnostics, "No nodes found")
+	}
+	return strings.Join(diagnostics, ". ")
+}
@@ -0,0 +1,86 @@
+package clusterprovision
+
+import (
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestSchedulingDiagnostics(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing []runtime.Object
+		pod      *corev1.Pod
+		expected string
+	}{
+		{
+			name: "missing pod",
+			existing: []runtime.Object{
+				testNode("node-a"),
+			},
+			expected: "Node node-a allocatable cpu=0 memory=0, taints node-role.kubernetes.io/master:NoSchedule",
+		},
+		{
+			name: "unschedulable pod",
+			existing: []runtime.Object{
+				withAllocatable(unschedulableNode(testNode("node-a")), "4", "16Gi"),
+			},
+			pod: withRequests(
+				testPod("foo", pending(), unschedulable("0/1 nodes are available: 1 Insufficient memory.")),
+				"1", "32Gi",
+			),
+			expected: "Scheduler: 0/1 nodes are available: 1 Insufficient memory. " +
+				"Pod requests cpu=1 memory=32Gi. " +
+				"Node node-a allocatable cpu=4 memory=16Gi, unschedulable, taints node-role.kubernetes.io/master:NoSchedule",
+		},
+		{
+			name:     "no nodes",
+			pod:      testPod("foo", pending()),
+			expected: "Pod requests cpu=0 memory=0. No nodes found",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rcp := &ReconcileClusterProvision{
+				Client: fake.NewFakeClientWithScheme(scheme.Scheme, test.existing...),
+				scheme: scheme.Scheme,
+			}
+			actual := rcp.schedulingDiagnostics(test.pod, log.WithField("controller", "clusterProvision"))
+			assert.Equal(t, test.expected, actual, "unexpected scheduling diagnostics")
+		})
+	}
+}
+
+func unschedulableNode(node *corev1.Node) *corev1.Node {
+	node.Spec.Unschedulable = true
+	return node
+}
+
+func withAllocatable(node *corev1.Node, cpu, memory string) *corev1.Node {
+	node.Status.Allocatable = corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse(cpu),
+		corev1.ResourceMemory: resource.MustParse(memory),
+	}
+	return node
+}
+
+func withRequests(pod *corev1.Pod, cpu, memory string) *corev1.Pod {
+	pod.Spec.Containers = []corev1.Container{{
+		Name: "installer",
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse(cpu),
+				corev1.ResourceMemory: resource.MustParse(memory),
+			},
+		},
+	}}
+	return pod
+}
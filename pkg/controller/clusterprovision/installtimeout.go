@@ -0,0 +1,91 @@
+package clusterprovision
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+// installTimedOutReason is the reason of the failure of a provision whose install job ran for longer than the install
+// timeout.
+const installTimedOutReason = "InstallTimedOut"
+
+// stageTimeoutReasonPrefix prefixes the stage in the reason of the failure of a provision which stayed in the stage for
+// longer than the stage timeout, for example StageTimeout_Provisioning.
+const stageTimeoutReasonPrefix = "StageTimeout_"
+
+// reconcileInstallTimeout aborts the provision once its install job has been running for longer than the install
+// timeout of the provision. It returns whether the provision was aborted, and how long until the install job times out.
+func (r *ReconcileClusterProvision) reconcileInstallTimeout(instance *hivev1.ClusterProvision, job *batchv1.Job, pLog log.FieldLogger) (bool, time.Duration, error) {
+	if instance.Spec.InstallTimeout == nil || instance.Spec.InstallTimeout.Duration <= 0 {
+		return false, 0, nil
+	}
+	// Once aborted, the provision is failed as soon as its install job is gone.
+	if cond := controllerutils.FindClusterProvisionCondition(instance.Status.Conditions, hivev1.ClusterProvisionFailedCondition); cond != nil && cond.Status == corev1.ConditionTrue {
+		return false, 0, nil
+	}
+	timeout := instance.Spec.InstallTimeout.Duration
+	if elapsed := time.Since(job.CreationTimestamp.Time); elapsed < timeout {
+		return false, timeout - elapsed, nil
+	}
+	pLog.WithField("timeout", timeout).WithField("stage", instance.Spec.Stage).Warn("install job timed out")
+	message := fmt.Sprintf("Install job did not complete within the install timeout of %s", timeout)
+	if r.eventRecorder != nil {
+		r.eventRecorder.Event(instance, corev1.EventTypeWarning, installTimedOutReason, message)
+	}
+	_, err := r.abortProvision(instance, installTimedOutReason, message, pLog)
+	if err == nil {
+		incrementInstallErrors(instance, installTimedOutReason)
+		metricClusterProvisionsTotal.WithLabelValues(hivemetrics.GetClusterDeploymentType(instance), resultFailure).Inc()
+	}
+	return true, 0, err
+}
+
+// reconcileStageTimeout aborts the provision once it has been in its current stage for longer than the timeout of the
+// stage. The initializing stage starts with the install job, and the provisioning stage once the provision is
+// initialized. It returns whether the provision was aborted, and how long until the stage times out.
+func (r *ReconcileClusterProvision) reconcileStageTimeout(instance *hivev1.ClusterProvision, job *batchv1.Job, pLog log.FieldLogger) (bool, time.Duration, error) {
+	timeout := r.stageTimeouts[instance.Spec.Stage]
+	if timeout <= 0 {
+		return false, 0, nil
+	}
+	// Once aborted, the provision is failed as soon as its install job is gone.
+	if cond := controllerutils.FindClusterProvisionCondition(instance.Status.Conditions, hivev1.ClusterProvisionFailedCondition); cond != nil && cond.Status == corev1.ConditionTrue {
+		return false, 0, nil
+	}
+	start := job.CreationTimestamp.Time
+	if instance.Spec.Stage == hivev1.ClusterProvisionStageProvisioning {
+		if cond := controllerutils.FindClusterProvisionCondition(instance.Status.Conditions, hivev1.ClusterProvisionInitializedCondition); cond != nil && cond.Status == corev1.ConditionTrue && cond.LastTransitionTime.Time.After(start) {
+			start = cond.LastTransitionTime.Time
+		}
+	}
+	if elapsed := time.Since(start); elapsed < timeout {
+		return false, timeout - elapsed, nil
+	}
+	pLog.WithField("timeout", timeout).WithField("stage", instance.Spec.Stage).Warn("provision stage timed out")
+	reason := stageTimeoutReason(instance.Spec.Stage)
+	message := fmt.Sprintf("Provision did not complete the %s stage within the stage timeout of %s", instance.Spec.Stage, timeout)
+	if r.eventRecorder != nil {
+		r.eventRecorder.Event(instance, corev1.EventTypeWarning, reason, message)
+	}
+	_, err := r.abortProvision(instance, reason, message, pLog)
+	if err == nil {
+		incrementInstallErrors(instance, reason)
+		metricClusterProvisionsTotal.WithLabelValues(hivemetrics.GetClusterDeploymentType(instance), resultFailure).Inc()
+	}
+	return true, 0, err
+}
+
+// stageTimeoutReason returns the reason of the failure of a provision which timed out in the stage.
+func stageTimeoutReason(stage hivev1.ClusterProvisionStage) string {
+	name := string(stage)
+	return stageTimeoutReasonPrefix + strings.ToUpper(name[:1]) + name[1:]
+}
@@ -0,0 +1,105 @@
+package clusterprovision
+
+import (
+	"context"
+
+	log "github.com/sirupsen/logrus"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/install"
+)
+
+// reconcileMustGather collects a must-gather from the cluster of a failed provision which had completed
+// bootstrapping, by running a must-gather job, and records when the job finishes. The ClusterDeployment waits for
+// the job to finish before starting the next provision, whose install pod destroys the cluster of this one.
+func (r *ReconcileClusterProvision) reconcileMustGather(instance *hivev1.ClusterProvision, pLog log.FieldLogger) (reconcile.Result, error) {
+	if mustGather := instance.Status.MustGather; mustGather != nil {
+		if mustGather.CompletionTime != nil {
+			pLog.Debug("must-gather has been collected")
+			return reconcile.Result{}, nil
+		}
+		return r.reconcileMustGatherJob(instance, pLog)
+	}
+
+	if !r.collectMustGather || instance.Spec.AdminKubeconfigSecretRef == nil {
+		return reconcile.Result{}, nil
+	}
+	cond := controllerutils.FindClusterProvisionCondition(instance.Status.Conditions, hivev1.ClusterProvisionBootstrapCompleteCondition)
+	if cond == nil || cond.Status != corev1.ConditionTrue {
+		pLog.Debug("failed provision had not completed bootstrapping, not collecting a must-gather")
+		return reconcile.Result{}, nil
+	}
+	return r.createMustGatherJob(instance, pLog)
+}
+
+func (r *ReconcileClusterProvision) createMustGatherJob(instance *hivev1.ClusterProvision, pLog log.FieldLogger) (reconcile.Result, error) {
+	job, err := install.GenerateMustGatherJob(instance)
+	if err != nil {
+		pLog.WithError(err).Error("error generating must-gather job")
+		return reconcile.Result{}, err
+	}
+	pLog = pLog.WithField("job", job.Name)
+	if err := controllerutil.SetControllerReference(instance, job, r.scheme); err != nil {
+		pLog.WithError(err).Error("error setting controller reference on must-gather job")
+		return reconcile.Result{}, err
+	}
+
+	pLog.Info("creating must-gather job")
+	provisionKey := types.NamespacedName{Namespace: instance.Namespace, Name: instance.Name}.String()
+	r.expectations.ExpectCreations(provisionKey, 1)
+	switch err := r.Create(context.TODO(), job); {
+	case apierrors.IsAlreadyExists(err):
+		// The job was created before the status of the provision could be updated.
+		r.expectations.CreationObserved(provisionKey)
+		pLog.Info("must-gather job already exists")
+	case err != nil:
+		r.expectations.CreationObserved(provisionKey)
+		pLog.WithError(err).Error("error creating must-gather job")
+		return reconcile.Result{}, err
+	}
+
+	instance.Status.MustGather = &hivev1.ClusterProvisionMustGather{
+		JobRef: corev1.LocalObjectReference{Name: job.Name},
+	}
+	if err := r.Status().Update(context.TODO(), instance); err != nil {
+		pLog.WithError(err).Log(controllerutils.LogLevel(err), "error recording must-gather job")
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{}, nil
+}
+
+func (r *ReconcileClusterProvision) reconcileMustGatherJob(instance *hivev1.ClusterProvision, pLog log.FieldLogger) (reconcile.Result, error) {
+	pLog = pLog.WithField("job", instance.Status.MustGather.JobRef.Name)
+	job := &batchv1.Job{}
+	switch err := r.Get(context.TODO(), types.NamespacedName{Namespace: instance.Namespace, Name: instance.Status.MustGather.JobRef.Name}, job); {
+	case apierrors.IsNotFound(err):
+		pLog.Warn("must-gather job has been deleted")
+	case err != nil:
+		pLog.WithError(err).Log(controllerutils.LogLevel(err), "could not get must-gather job")
+		return reconcile.Result{}, err
+	case !controllerutils.IsFinished(job):
+		pLog.Debug("must-gather job is still running")
+		return reconcile.Result{}, nil
+	case controllerutils.IsFailed(job):
+		pLog.Warn("must-gather job failed")
+		r.eventRecorder.Event(instance, corev1.EventTypeWarning, "MustGatherFailed", "Must-gather job failed to collect a must-gather from the cluster")
+	default:
+		pLog.WithField("location", instance.Status.MustGather.Location).Info("must-gather job completed")
+	}
+
+	now := metav1.Now()
+	instance.Status.MustGather.CompletionTime = &now
+	if err := r.Status().Update(context.TODO(), instance); err != nil {
+		pLog.WithError(err).Log(controllerutils.LogLevel(err), "error recording must-gather completion")
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{}, nil
+}
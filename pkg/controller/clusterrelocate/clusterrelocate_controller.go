@@ -27,6 +27,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
 	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 	"github.com/openshift/hive/pkg/remoteclient"
@@ -511,6 +512,9 @@ func (r *ReconcileClusterRelocate) copy(cd *hivev1.ClusterDeployment, destClient
 	switch err := destClient.Create(context.Background(), &corev1.Namespace{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: cd.Namespace,
+			Labels: map[string]string{
+				constants.DedicatedNamespaceLabel: "true",
+			},
 		},
 	}); {
 	case apierrors.IsAlreadyExists(err):
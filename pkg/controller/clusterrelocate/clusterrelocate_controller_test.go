@@ -85,6 +85,9 @@ func TestReconcileClusterRelocate_Reconcile_Movement(t *testing.T) {
 	)
 	jobBuilder := testjob.FullBuilder(namespace, "test-job", scheme)
 	namespaceBuilder := testnamespace.FullBuilder(namespace, scheme)
+	createdNamespaceBuilder := namespaceBuilder.GenericOptions(
+		testgeneric.WithLabel(constants.DedicatedNamespaceLabel, "true"),
+	)
 
 	cases := []struct {
 		name              string
@@ -104,7 +107,7 @@ func TestReconcileClusterRelocate_Reconcile_Movement(t *testing.T) {
 				crBuilder.Build(),
 			},
 			expectedResources: []runtime.Object{
-				namespaceBuilder.Build(),
+				createdNamespaceBuilder.Build(),
 				cdBuilder.Build(
 					testcd.Generic(withRelocateAnnotation(crName, hivev1.RelocateIncoming)),
 				),
@@ -202,7 +205,7 @@ func TestReconcileClusterRelocate_Reconcile_Movement(t *testing.T) {
 				secretBuilder.Build(testsecret.WithDataKeyValue("test-key", []byte("test-data"))),
 			},
 			expectedResources: []runtime.Object{
-				namespaceBuilder.Build(),
+				createdNamespaceBuilder.Build(),
 				cdBuilder.Build(
 					testcd.Generic(withRelocateAnnotation(crName, hivev1.RelocateIncoming)),
 				),
@@ -224,7 +227,7 @@ func TestReconcileClusterRelocate_Reconcile_Movement(t *testing.T) {
 				),
 			},
 			expectedResources: []runtime.Object{
-				namespaceBuilder.Build(),
+				createdNamespaceBuilder.Build(),
 				cdBuilder.Build(
 					testcd.Generic(withRelocateAnnotation(crName, hivev1.RelocateIncoming)),
 				),
@@ -254,7 +257,7 @@ func TestReconcileClusterRelocate_Reconcile_Movement(t *testing.T) {
 				),
 			},
 			expectedResources: []runtime.Object{
-				namespaceBuilder.Build(),
+				createdNamespaceBuilder.Build(),
 				cdBuilder.Build(
 					testcd.Generic(withRelocateAnnotation(crName, hivev1.RelocateIncoming)),
 				),
@@ -275,7 +278,7 @@ func TestReconcileClusterRelocate_Reconcile_Movement(t *testing.T) {
 				secretBuilder.Build(testsecret.WithDataKeyValue("test-key", []byte("test-data"))),
 			},
 			expectedResources: []runtime.Object{
-				namespaceBuilder.Build(),
+				createdNamespaceBuilder.Build(),
 				cdBuilder.Build(
 					testcd.Generic(withRelocateAnnotation(crName, hivev1.RelocateIncoming)),
 				),
@@ -293,7 +296,7 @@ func TestReconcileClusterRelocate_Reconcile_Movement(t *testing.T) {
 				secretBuilder.Build(testsecret.WithDataKeyValue("test-key", []byte("other-data"))),
 			},
 			expectedResources: []runtime.Object{
-				namespaceBuilder.Build(),
+				createdNamespaceBuilder.Build(),
 				cdBuilder.Build(
 					testcd.Generic(withRelocateAnnotation(crName, hivev1.RelocateIncoming)),
 				),
@@ -315,7 +318,7 @@ func TestReconcileClusterRelocate_Reconcile_Movement(t *testing.T) {
 				mpBuilder.Build(),
 			},
 			expectedResources: []runtime.Object{
-				namespaceBuilder.Build(),
+				createdNamespaceBuilder.Build(),
 				cdBuilder.Build(
 					testcd.Generic(withRelocateAnnotation(crName, hivev1.RelocateIncoming)),
 				),
@@ -338,7 +341,7 @@ func TestReconcileClusterRelocate_Reconcile_Movement(t *testing.T) {
 				),
 			},
 			expectedResources: []runtime.Object{
-				namespaceBuilder.Build(),
+				createdNamespaceBuilder.Build(),
 				cdBuilder.Build(
 					testcd.Generic(withRelocateAnnotation(crName, hivev1.RelocateIncoming)),
 				),
@@ -359,7 +362,7 @@ func TestReconcileClusterRelocate_Reconcile_Movement(t *testing.T) {
 				),
 			},
 			expectedResources: []runtime.Object{
-				namespaceBuilder.Build(),
+				createdNamespaceBuilder.Build(),
 				cdBuilder.Build(
 					testcd.Generic(withRelocateAnnotation(crName, hivev1.RelocateIncoming)),
 				),
@@ -387,7 +390,7 @@ func TestReconcileClusterRelocate_Reconcile_Movement(t *testing.T) {
 				),
 			},
 			expectedResources: []runtime.Object{
-				namespaceBuilder.Build(),
+				createdNamespaceBuilder.Build(),
 				cdBuilder.Build(
 					testcd.Generic(withRelocateAnnotation(crName, hivev1.RelocateIncoming)),
 				),
@@ -401,7 +404,7 @@ func TestReconcileClusterRelocate_Reconcile_Movement(t *testing.T) {
 				cmBuilder.Build(testcm.WithDataKeyValue("test-key", "test-data")),
 			},
 			expectedResources: []runtime.Object{
-				namespaceBuilder.Build(),
+				createdNamespaceBuilder.Build(),
 				cdBuilder.Build(
 					testcd.Generic(withRelocateAnnotation(crName, hivev1.RelocateIncoming)),
 				),
@@ -416,7 +419,7 @@ func TestReconcileClusterRelocate_Reconcile_Movement(t *testing.T) {
 				mpBuilder.Build(),
 			},
 			expectedResources: []runtime.Object{
-				namespaceBuilder.Build(),
+				createdNamespaceBuilder.Build(),
 				cdBuilder.Build(
 					testcd.Generic(withRelocateAnnotation(crName, hivev1.RelocateIncoming)),
 				),
@@ -431,7 +434,7 @@ func TestReconcileClusterRelocate_Reconcile_Movement(t *testing.T) {
 				ssBuilder.Build(),
 			},
 			expectedResources: []runtime.Object{
-				namespaceBuilder.Build(),
+				createdNamespaceBuilder.Build(),
 				cdBuilder.Build(
 					testcd.Generic(withRelocateAnnotation(crName, hivev1.RelocateIncoming)),
 				),
@@ -446,7 +449,7 @@ func TestReconcileClusterRelocate_Reconcile_Movement(t *testing.T) {
 				sipBuilder.Build(),
 			},
 			expectedResources: []runtime.Object{
-				namespaceBuilder.Build(),
+				createdNamespaceBuilder.Build(),
 				cdBuilder.Build(
 					testcd.Generic(withRelocateAnnotation(crName, hivev1.RelocateIncoming)),
 				),
@@ -461,7 +464,7 @@ func TestReconcileClusterRelocate_Reconcile_Movement(t *testing.T) {
 				dnsZoneBuilder.Build(),
 			},
 			expectedResources: []runtime.Object{
-				namespaceBuilder.Build(),
+				createdNamespaceBuilder.Build(),
 				cdBuilder.Build(
 					testcd.Generic(withRelocateAnnotation(crName, hivev1.RelocateIncoming)),
 				),
@@ -478,7 +481,7 @@ func TestReconcileClusterRelocate_Reconcile_Movement(t *testing.T) {
 				dnsZoneBuilder.Build(testdnszone.Generic(testgeneric.WithName("other-dnszone"))),
 			},
 			expectedResources: []runtime.Object{
-				namespaceBuilder.Build(),
+				createdNamespaceBuilder.Build(),
 				cdBuilder.Build(
 					testcd.Generic(withRelocateAnnotation(crName, hivev1.RelocateIncoming)),
 				),
@@ -492,7 +495,7 @@ func TestReconcileClusterRelocate_Reconcile_Movement(t *testing.T) {
 				jobBuilder.Build(),
 			},
 			expectedResources: []runtime.Object{
-				namespaceBuilder.Build(),
+				createdNamespaceBuilder.Build(),
 				cdBuilder.Build(
 					testcd.Generic(withRelocateAnnotation(crName, hivev1.RelocateIncoming)),
 				),
@@ -634,7 +637,7 @@ func TestReconcileClusterRelocate_Reconcile_Movement(t *testing.T) {
 				),
 			},
 			expectedResources: []runtime.Object{
-				namespaceBuilder.Build(),
+				createdNamespaceBuilder.Build(),
 				cdBuilder.Build(
 					testcd.Generic(withRelocateAnnotation(crName, hivev1.RelocateIncoming)),
 				),
@@ -0,0 +1,396 @@
+package clustersanitization
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	configv1 "github.com/openshift/api/config/v1"
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/remoteclient"
+)
+
+const (
+	ControllerName = hivev1.ClusterSanitizationControllerName
+
+	// deletionPollInterval is how often a cluster is checked while what was created in it is being deleted.
+	deletionPollInterval = 30 * time.Second
+
+	// checkRetryInterval is how often the checks of a cluster are retried once they have failed.
+	checkRetryInterval = 1 * time.Minute
+
+	sanitizedReason          = "Sanitized"
+	deletionFailedReason     = "DeletionFailed"
+	checksFailedReason       = "ChecksFailed"
+	remoteClusterUnreachable = "RemoteClusterUnreachable"
+)
+
+var (
+	// alwaysPreservedNamespaces are the namespaces of the platform, which are never deleted.
+	alwaysPreservedNamespaces = []string{"default", "openshift", "openshift-*", "kube-*"}
+)
+
+// Add creates a new ClusterSanitization Controller and adds it to the Manager with default RBAC. The Manager will set
+// fields on the Controller and Start it when the Manager is Started.
+func Add(mgr manager.Manager) error {
+	logger := log.WithField("controller", ControllerName)
+	concurrentReconciles, clientRateLimiter, queueRateLimiter, err := controllerutils.GetControllerConfig(mgr.GetClient(), ControllerName)
+	if err != nil {
+		logger.WithError(err).Error("could not get controller configurations")
+		return err
+	}
+	return AddToManager(mgr, NewReconciler(mgr, clientRateLimiter), concurrentReconciles, queueRateLimiter)
+}
+
+// NewReconciler returns a new reconcile.Reconciler
+func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) reconcile.Reconciler {
+	r := &ReconcileClusterSanitization{
+		Client: controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
+	}
+	// Sanitizing deletes namespaces and CustomResourceDefinitions, which the scoped remote access does not allow.
+	r.remoteClusterAPIClientBuilder = func(cd *hivev1.ClusterDeployment) remoteclient.Builder {
+		return remoteclient.NewAdminBuilder(r.Client, cd, ControllerName)
+	}
+	return r
+}
+
+// AddToManager adds a new Controller to mgr with r as the reconcile.Reconciler
+func AddToManager(mgr manager.Manager, r reconcile.Reconciler, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
+	c, err := controller.New(
+		fmt.Sprintf("%s-controller", ControllerName),
+		mgr,
+		controller.Options{
+			Reconciler:              r,
+			MaxConcurrentReconciles: concurrentReconciles,
+			RateLimiter:             rateLimiter,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to ClusterDeployments
+	if err := c.Watch(&source.Kind{Type: &hivev1.ClusterDeployment{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var _ reconcile.Reconciler = &ReconcileClusterSanitization{}
+
+// ReconcileClusterSanitization reconciles a ClusterDeployment released to its ClusterPool, deleting what was created
+// in the cluster while it was claimed and checking its state, so that it can be claimed again.
+type ReconcileClusterSanitization struct {
+	client.Client
+
+	// remoteClusterAPIClientBuilder is a function pointer to the function that gets a builder for building a client
+	// for the remote cluster's API server
+	remoteClusterAPIClientBuilder func(cd *hivev1.ClusterDeployment) remoteclient.Builder
+}
+
+// Reconcile sanitizes a ClusterDeployment marked for sanitization by the cluster claim controller.
+func (r *ReconcileClusterSanitization) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	logger := controllerutils.BuildControllerLogger(ControllerName, "clusterDeployment", request.NamespacedName)
+	logger.Debug("reconciling cluster deployment")
+	recobsrv := hivemetrics.NewReconcileObserver(ControllerName, logger)
+	defer recobsrv.ObserveControllerReconcileTime()
+
+	cd := &hivev1.ClusterDeployment{}
+	if err := r.Get(context.Background(), request.NamespacedName, cd); err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Debug("cluster deployment not found")
+			return reconcile.Result{}, nil
+		}
+		logger.WithError(err).Error("error getting cluster deployment")
+		return reconcile.Result{}, err
+	}
+	if cd.DeletionTimestamp != nil || cd.Annotations[constants.ClusterSanitizingAnnotation] != "true" {
+		return reconcile.Result{}, nil
+	}
+	if !cd.Spec.Installed || cd.Spec.ClusterPoolRef == nil {
+		logger.Debug("cluster deployment is not an installed pool cluster")
+		return reconcile.Result{}, nil
+	}
+
+	pool := &hivev1.ClusterPool{}
+	switch err := r.Get(
+		context.Background(),
+		client.ObjectKey{Namespace: cd.Spec.ClusterPoolRef.Namespace, Name: cd.Spec.ClusterPoolRef.PoolName},
+		pool,
+	); {
+	case apierrors.IsNotFound(err):
+		logger.Info("cluster pool does not exist, nothing to sanitize")
+		return reconcile.Result{}, r.setSanitized(cd, logger)
+	case err != nil:
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "error getting cluster pool")
+		return reconcile.Result{}, err
+	}
+	if pool.Spec.Sanitization == nil {
+		logger.Info("cluster pool has no sanitization policy, nothing to sanitize")
+		return reconcile.Result{}, r.setSanitized(cd, logger)
+	}
+
+	if unreachable, _ := remoteclient.Unreachable(cd); unreachable {
+		logger.Debug("waiting for cluster to be reachable")
+		if err := r.setSanitizationFailedCondition(cd, remoteClusterUnreachable, "Waiting for the cluster to be reachable", logger); err != nil {
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{RequeueAfter: checkRetryInterval}, nil
+	}
+	remoteClient, err := r.remoteClusterAPIClientBuilder(cd).Build()
+	if err != nil {
+		logger.WithError(err).Error("error building remote cluster client")
+		return reconcile.Result{}, err
+	}
+
+	claimedAt := claimedTimestamp(cd)
+	remaining, err := deleteCreatedNamespaces(remoteClient, pool.Spec.Sanitization, claimedAt, logger)
+	if err != nil {
+		return reconcile.Result{}, r.deletionFailed(cd, err, logger)
+	}
+	if pool.Spec.Sanitization.DeleteCustomResourceDefinitions {
+		remainingCRDs, err := deleteCreatedCRDs(remoteClient, claimedAt, logger)
+		if err != nil {
+			return reconcile.Result{}, r.deletionFailed(cd, err, logger)
+		}
+		remaining += remainingCRDs
+	}
+	if remaining > 0 {
+		logger.WithField("remaining", remaining).Info("waiting for resources created while the cluster was claimed to be deleted")
+		return reconcile.Result{RequeueAfter: deletionPollInterval}, nil
+	}
+
+	var failures []string
+	for _, check := range pool.Spec.Sanitization.Checks {
+		failure, err := runCheck(remoteClient, check)
+		if err != nil {
+			logger.WithError(err).WithField("check", check).Error("error running sanitization check")
+			return reconcile.Result{}, err
+		}
+		if failure != "" {
+			failures = append(failures, fmt.Sprintf("%s: %s", check, failure))
+		}
+	}
+	if len(failures) > 0 {
+		logger.WithField("failures", failures).Info("sanitization checks failed")
+		if err := r.setSanitizationFailedCondition(cd, checksFailedReason, strings.Join(failures, "; "), logger); err != nil {
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{RequeueAfter: checkRetryInterval}, nil
+	}
+
+	logger.Info("cluster sanitized")
+	return reconcile.Result{}, r.setSanitized(cd, logger)
+}
+
+// claimedTimestamp returns the time at which the cluster was claimed. What was created after that time is deleted.
+// Clusters claimed before the time was recorded fall back to the time they were installed.
+func claimedTimestamp(cd *hivev1.ClusterDeployment) time.Time {
+	if t, err := time.Parse(time.RFC3339, cd.Annotations[constants.ClaimedTimestampAnnotation]); err == nil {
+		return t
+	}
+	if cd.Status.InstalledTimestamp != nil {
+		return cd.Status.InstalledTimestamp.Time
+	}
+	return cd.CreationTimestamp.Time
+}
+
+func createdSince(obj metav1.Object, t time.Time) bool {
+	return !obj.GetCreationTimestamp().Time.Before(t)
+}
+
+func isPreservedNamespace(name string, preserved []string) bool {
+	for _, pattern := range append(alwaysPreservedNamespaces, preserved...) {
+		if matched, _ := path.Match(pattern, name); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// deleteCreatedNamespaces deletes the namespaces created since the cluster was claimed, other than the preserved
+// ones. It returns the number of those namespaces still existing.
+func deleteCreatedNamespaces(c client.Client, sanitization *hivev1.ClusterPoolSanitization, since time.Time, logger log.FieldLogger) (int, error) {
+	namespaces := &corev1.NamespaceList{}
+	if err := c.List(context.Background(), namespaces); err != nil {
+		return 0, fmt.Errorf("could not list namespaces: %v", err)
+	}
+	remaining := 0
+	for i := range namespaces.Items {
+		ns := &namespaces.Items[i]
+		if !createdSince(ns, since) || isPreservedNamespace(ns.Name, sanitization.PreservedNamespaces) {
+			continue
+		}
+		remaining++
+		if ns.DeletionTimestamp != nil {
+			continue
+		}
+		logger.WithField("namespace", ns.Name).Info("deleting namespace created while the cluster was claimed")
+		if err := c.Delete(context.Background(), ns); err != nil && !apierrors.IsNotFound(err) {
+			return 0, fmt.Errorf("could not delete namespace %s: %v", ns.Name, err)
+		}
+	}
+	return remaining, nil
+}
+
+// deleteCreatedCRDs deletes the CustomResourceDefinitions created since the cluster was claimed. It returns the number
+// of those CustomResourceDefinitions still existing.
+func deleteCreatedCRDs(c client.Client, since time.Time, logger log.FieldLogger) (int, error) {
+	crds := &apiextv1.CustomResourceDefinitionList{}
+	if err := c.List(context.Background(), crds); err != nil {
+		return 0, fmt.Errorf("could not list CustomResourceDefinitions: %v", err)
+	}
+	remaining := 0
+	for i := range crds.Items {
+		crd := &crds.Items[i]
+		if !createdSince(crd, since) {
+			continue
+		}
+		remaining++
+		if crd.DeletionTimestamp != nil {
+			continue
+		}
+		logger.WithField("crd", crd.Name).Info("deleting CustomResourceDefinition created while the cluster was claimed")
+		if err := c.Delete(context.Background(), crd); err != nil && !apierrors.IsNotFound(err) {
+			return 0, fmt.Errorf("could not delete CustomResourceDefinition %s: %v", crd.Name, err)
+		}
+	}
+	return remaining, nil
+}
+
+// runCheck runs a sanitization check against the cluster. It returns a description of the failure of the check, if
+// it failed.
+func runCheck(c client.Client, check hivev1.ClusterSanitizationCheck) (string, error) {
+	switch check {
+	case hivev1.ClusterOperatorsAvailableSanitizationCheck:
+		cos := &configv1.ClusterOperatorList{}
+		if err := c.List(context.Background(), cos); err != nil {
+			return "", err
+		}
+		var unavailable []string
+		for _, co := range cos.Items {
+			if !clusterOperatorConditionIs(co.Status.Conditions, configv1.OperatorAvailable, configv1.ConditionTrue) ||
+				clusterOperatorConditionIs(co.Status.Conditions, configv1.OperatorDegraded, configv1.ConditionTrue) {
+				unavailable = append(unavailable, co.Name)
+			}
+		}
+		if len(unavailable) > 0 {
+			sort.Strings(unavailable)
+			return fmt.Sprintf("cluster operators unavailable or degraded: %s", strings.Join(unavailable, ", ")), nil
+		}
+	case hivev1.NodesReadySanitizationCheck:
+		nodes := &corev1.NodeList{}
+		if err := c.List(context.Background(), nodes); err != nil {
+			return "", err
+		}
+		var notReady []string
+		for _, node := range nodes.Items {
+			if !isNodeReady(&node) {
+				notReady = append(notReady, node.Name)
+			}
+		}
+		if len(notReady) > 0 {
+			sort.Strings(notReady)
+			return fmt.Sprintf("nodes not ready: %s", strings.Join(notReady, ", ")), nil
+		}
+	default:
+		return fmt.Sprintf("unknown check %q", check), nil
+	}
+	return "", nil
+}
+
+func clusterOperatorConditionIs(conditions []configv1.ClusterOperatorStatusCondition, conditionType configv1.ClusterStatusConditionType, status configv1.ConditionStatus) bool {
+	for _, c := range conditions {
+		if c.Type == conditionType {
+			return c.Status == status
+		}
+	}
+	return false
+}
+
+func isNodeReady(node *corev1.Node) bool {
+	for _, c := range node.Status.Conditions {
+		if c.Type == corev1.NodeReady {
+			return c.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+func (r *ReconcileClusterSanitization) setSanitizationFailedCondition(cd *hivev1.ClusterDeployment, reason, message string, logger log.FieldLogger) error {
+	conds, changed := controllerutils.SetClusterDeploymentConditionWithChangeCheck(
+		cd.Status.Conditions,
+		hivev1.SanitizationFailedCondition,
+		corev1.ConditionTrue,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	if !changed {
+		return nil
+	}
+	cd.Status.Conditions = conds
+	if err := r.Status().Update(context.Background(), cd); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update SanitizationFailed condition")
+		return err
+	}
+	return nil
+}
+
+// deletionFailed reports the failure to delete what was created in the cluster, returning the error so that the
+// deletion is retried.
+func (r *ReconcileClusterSanitization) deletionFailed(cd *hivev1.ClusterDeployment, err error, logger log.FieldLogger) error {
+	logger.WithError(err).Error("error deleting resources created while the cluster was claimed")
+	if condErr := r.setSanitizationFailedCondition(cd, deletionFailedReason, err.Error(), logger); condErr != nil {
+		return condErr
+	}
+	return err
+}
+
+// setSanitized clears the SanitizationFailed condition and removes the sanitizing annotation, leaving the pool
+// controller to return the cluster to the pool.
+func (r *ReconcileClusterSanitization) setSanitized(cd *hivev1.ClusterDeployment, logger log.FieldLogger) error {
+	conds, changed := controllerutils.SetClusterDeploymentConditionWithChangeCheck(
+		cd.Status.Conditions,
+		hivev1.SanitizationFailedCondition,
+		corev1.ConditionFalse,
+		sanitizedReason,
+		"Cluster sanitized",
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	if changed {
+		cd.Status.Conditions = conds
+		if err := r.Status().Update(context.Background(), cd); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update SanitizationFailed condition")
+			return err
+		}
+	}
+	delete(cd.Annotations, constants.ClusterSanitizingAnnotation)
+	delete(cd.Annotations, constants.ClaimedTimestampAnnotation)
+	if err := r.Update(context.Background(), cd); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not remove sanitizing annotation")
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,257 @@
+package clustersanitization
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	configv1 "github.com/openshift/api/config/v1"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/remoteclient"
+	remoteclientmock "github.com/openshift/hive/pkg/remoteclient/mock"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+	testcp "github.com/openshift/hive/pkg/test/clusterpool"
+	testgeneric "github.com/openshift/hive/pkg/test/generic"
+)
+
+const (
+	testNamespace = "test-namespace"
+	testName      = "test-cluster"
+	testPoolName  = "test-pool"
+)
+
+func TestReconcileClusterSanitization(t *testing.T) {
+	scheme := runtime.NewScheme()
+	hivev1.AddToScheme(scheme)
+	remoteScheme := runtime.NewScheme()
+	corev1.AddToScheme(remoteScheme)
+	apiextv1.AddToScheme(remoteScheme)
+	configv1.Install(remoteScheme)
+
+	claimedAt := time.Now().Add(-time.Hour).Truncate(time.Second)
+	before := claimedAt.Add(-time.Hour)
+	after := claimedAt.Add(time.Minute)
+
+	cdBuilder := testcd.FullBuilder(testNamespace, testName, scheme).GenericOptions(
+		testgeneric.WithAnnotation(constants.ClusterSanitizingAnnotation, "true"),
+		testgeneric.WithAnnotation(constants.ClusterRebaseliningAnnotation, "true"),
+		testgeneric.WithAnnotation(constants.ClaimedTimestampAnnotation, claimedAt.UTC().Format(time.RFC3339)),
+	).Options(
+		testcd.Installed(),
+		testcd.WithUnclaimedClusterPoolReference(testNamespace, testPoolName),
+		testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+			Type:   hivev1.UnreachableCondition,
+			Status: corev1.ConditionFalse,
+		}),
+	)
+	poolBuilder := testcp.FullBuilder(testNamespace, testPoolName, scheme)
+	sanitization := func(s hivev1.ClusterPoolSanitization) testcp.Option {
+		return func(pool *hivev1.ClusterPool) {
+			pool.Spec.Sanitization = &s
+		}
+	}
+	namespace := func(name string, created time.Time) *corev1.Namespace {
+		return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, CreationTimestamp: metav1.NewTime(created)}}
+	}
+	crd := func(name string, created time.Time) *apiextv1.CustomResourceDefinition {
+		return &apiextv1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: name, CreationTimestamp: metav1.NewTime(created)}}
+	}
+	node := func(name string, ready corev1.ConditionStatus) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: corev1.NodeStatus{
+				Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: ready}},
+			},
+		}
+	}
+
+	tests := []struct {
+		name                     string
+		cd                       *hivev1.ClusterDeployment
+		pool                     *hivev1.ClusterPool
+		remoteExisting           []runtime.Object
+		expectSanitized          bool
+		expectDeletedNamespaces  []string
+		expectRemainingNames     []string
+		expectDeletedCRDs        []string
+		expectRemainingCRDs      []string
+		expectFailedReason       string
+		expectRequeue            bool
+		expectNoRemoteClientUsed bool
+	}{
+		{
+			name:                     "not sanitizing",
+			cd:                       testcd.FullBuilder(testNamespace, testName, scheme).Build(testcd.Installed()),
+			pool:                     poolBuilder.Build(sanitization(hivev1.ClusterPoolSanitization{})),
+			expectNoRemoteClientUsed: true,
+		},
+		{
+			name:                     "pool without sanitization",
+			cd:                       cdBuilder.Build(),
+			pool:                     poolBuilder.Build(),
+			expectSanitized:          true,
+			expectNoRemoteClientUsed: true,
+		},
+		{
+			name: "delete namespaces created while claimed",
+			cd:   cdBuilder.Build(),
+			pool: poolBuilder.Build(sanitization(hivev1.ClusterPoolSanitization{PreservedNamespaces: []string{"keep-*"}})),
+			remoteExisting: []runtime.Object{
+				namespace("baseline", before),
+				namespace("openshift-new", after),
+				namespace("keep-me", after),
+				namespace("consumer", after),
+			},
+			expectDeletedNamespaces: []string{"consumer"},
+			expectRemainingNames:    []string{"baseline", "openshift-new", "keep-me"},
+			expectRequeue:           true,
+		},
+		{
+			name: "delete CRDs created while claimed",
+			cd:   cdBuilder.Build(),
+			pool: poolBuilder.Build(sanitization(hivev1.ClusterPoolSanitization{DeleteCustomResourceDefinitions: true})),
+			remoteExisting: []runtime.Object{
+				crd("baseline.example.com", before),
+				crd("consumer.example.com", after),
+			},
+			expectDeletedCRDs:   []string{"consumer.example.com"},
+			expectRemainingCRDs: []string{"baseline.example.com"},
+			expectRequeue:       true,
+		},
+		{
+			name: "keep CRDs when not configured",
+			cd:   cdBuilder.Build(),
+			pool: poolBuilder.Build(sanitization(hivev1.ClusterPoolSanitization{})),
+			remoteExisting: []runtime.Object{
+				crd("consumer.example.com", after),
+			},
+			expectRemainingCRDs: []string{"consumer.example.com"},
+			expectSanitized:     true,
+		},
+		{
+			name: "checks fail",
+			cd:   cdBuilder.Build(),
+			pool: poolBuilder.Build(sanitization(hivev1.ClusterPoolSanitization{
+				Checks: []hivev1.ClusterSanitizationCheck{hivev1.NodesReadySanitizationCheck, hivev1.ClusterOperatorsAvailableSanitizationCheck},
+			})),
+			remoteExisting: []runtime.Object{
+				node("ready", corev1.ConditionTrue),
+				node("not-ready", corev1.ConditionFalse),
+				&configv1.ClusterOperator{
+					ObjectMeta: metav1.ObjectMeta{Name: "ingress"},
+					Status: configv1.ClusterOperatorStatus{
+						Conditions: []configv1.ClusterOperatorStatusCondition{
+							{Type: configv1.OperatorAvailable, Status: configv1.ConditionTrue},
+							{Type: configv1.OperatorDegraded, Status: configv1.ConditionTrue},
+						},
+					},
+				},
+			},
+			expectFailedReason: checksFailedReason,
+			expectRequeue:      true,
+		},
+		{
+			name: "checks pass",
+			cd:   cdBuilder.Build(),
+			pool: poolBuilder.Build(sanitization(hivev1.ClusterPoolSanitization{
+				Checks: []hivev1.ClusterSanitizationCheck{hivev1.NodesReadySanitizationCheck, hivev1.ClusterOperatorsAvailableSanitizationCheck},
+			})),
+			remoteExisting: []runtime.Object{
+				node("ready", corev1.ConditionTrue),
+				&configv1.ClusterOperator{
+					ObjectMeta: metav1.ObjectMeta{Name: "ingress"},
+					Status: configv1.ClusterOperatorStatus{
+						Conditions: []configv1.ClusterOperatorStatusCondition{
+							{Type: configv1.OperatorAvailable, Status: configv1.ConditionTrue},
+							{Type: configv1.OperatorDegraded, Status: configv1.ConditionFalse},
+						},
+					},
+				},
+			},
+			expectSanitized: true,
+		},
+		{
+			name: "unreachable",
+			cd: cdBuilder.Build(testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+				Type:   hivev1.UnreachableCondition,
+				Status: corev1.ConditionTrue,
+			})),
+			pool:                     poolBuilder.Build(sanitization(hivev1.ClusterPoolSanitization{})),
+			expectFailedReason:       remoteClusterUnreachable,
+			expectRequeue:            true,
+			expectNoRemoteClientUsed: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			c := fake.NewFakeClientWithScheme(scheme, test.cd, test.pool)
+			remoteClient := fake.NewFakeClientWithScheme(remoteScheme, test.remoteExisting...)
+			mockRemoteClientBuilder := remoteclientmock.NewMockBuilder(mockCtrl)
+			if test.expectNoRemoteClientUsed {
+				mockRemoteClientBuilder.EXPECT().Build().Times(0)
+			} else {
+				mockRemoteClientBuilder.EXPECT().Build().Return(remoteClient, nil)
+			}
+			r := &ReconcileClusterSanitization{
+				Client: c,
+				remoteClusterAPIClientBuilder: func(*hivev1.ClusterDeployment) remoteclient.Builder {
+					return mockRemoteClientBuilder
+				},
+			}
+			log.SetLevel(log.DebugLevel)
+
+			result, err := r.Reconcile(reconcile.Request{NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: testName}})
+			require.NoError(t, err, "unexpected error from Reconcile")
+			assert.Equal(t, test.expectRequeue, result.RequeueAfter > 0, "unexpected requeue")
+
+			cd := &hivev1.ClusterDeployment{}
+			require.NoError(t, c.Get(context.Background(), client.ObjectKey{Namespace: testNamespace, Name: testName}, cd))
+			_, sanitizing := cd.Annotations[constants.ClusterSanitizingAnnotation]
+			assert.Equal(t, test.expectSanitized, !sanitizing && cd.Annotations[constants.ClusterRebaseliningAnnotation] == "true", "unexpected sanitized state")
+			if test.expectSanitized {
+				assert.NotContains(t, cd.Annotations, constants.ClaimedTimestampAnnotation, "expected claimed timestamp to be removed")
+			}
+			cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.SanitizationFailedCondition)
+			if test.expectFailedReason != "" {
+				require.NotNil(t, cond, "expected SanitizationFailed condition")
+				assert.Equal(t, corev1.ConditionTrue, cond.Status, "unexpected SanitizationFailed status")
+				assert.Equal(t, test.expectFailedReason, cond.Reason, "unexpected SanitizationFailed reason")
+			} else if cond != nil {
+				assert.Equal(t, corev1.ConditionFalse, cond.Status, "unexpected SanitizationFailed status")
+			}
+
+			for _, name := range test.expectDeletedNamespaces {
+				err := remoteClient.Get(context.Background(), client.ObjectKey{Name: name}, &corev1.Namespace{})
+				assert.True(t, apierrors.IsNotFound(err), "expected namespace %s to be deleted", name)
+			}
+			for _, name := range test.expectRemainingNames {
+				assert.NoError(t, remoteClient.Get(context.Background(), client.ObjectKey{Name: name}, &corev1.Namespace{}), "expected namespace %s to remain", name)
+			}
+			for _, name := range test.expectDeletedCRDs {
+				err := remoteClient.Get(context.Background(), client.ObjectKey{Name: name}, &apiextv1.CustomResourceDefinition{})
+				assert.True(t, apierrors.IsNotFound(err), "expected CRD %s to be deleted", name)
+			}
+			for _, name := range test.expectRemainingCRDs {
+				assert.NoError(t, remoteClient.Get(context.Background(), client.ObjectKey{Name: name}, &apiextv1.CustomResourceDefinition{}), "expected CRD %s to remain", name)
+			}
+		})
+	}
+}
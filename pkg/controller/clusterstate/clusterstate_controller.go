@@ -3,15 +3,19 @@ package clusterstate
 import (
 	"context"
 	"fmt"
+	"os"
 	"reflect"
 	"time"
 
 	log "github.com/sirupsen/logrus"
 
 	k8slabels "github.com/openshift/hive/pkg/util/labels"
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/util/flowcontrol"
 	"k8s.io/client-go/util/workqueue"
@@ -34,8 +38,8 @@ import (
 )
 
 const (
-	ControllerName       = hivev1.ClusterStateControllerName
-	statusUpdateInterval = 10 * time.Minute
+	ControllerName              = hivev1.ClusterStateControllerName
+	defaultStatusUpdateInterval = 10 * time.Minute
 )
 
 // Add creates a new ClusterState controller and adds it to the manager with default RBAC.
@@ -56,6 +60,12 @@ func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) rec
 		scheme:       mgr.GetScheme(),
 		logger:       log.WithField("controller", ControllerName),
 		updateStatus: updateClusterStateStatus,
+		statusUpdateInterval: controllerutils.PeriodicSyncIntervalFromEnv(
+			constants.ClusterStatePollIntervalEnvVar,
+			defaultStatusUpdateInterval,
+		),
+		jitterPercent:   controllerutils.PeriodicSyncJitterPercentFromEnv(),
+		collectNodeInfo: os.Getenv(constants.ClusterStateCollectNodeInfoEnvVar) == "true",
 	}
 	r.remoteClusterAPIClientBuilder = func(cd *hivev1.ClusterDeployment) remoteclient.Builder {
 		return remoteclient.NewBuilder(r.Client, cd, ControllerName)
@@ -97,6 +107,13 @@ type ReconcileClusterState struct {
 
 	// updateStatus updates a given cluster state's status, exposed for testing
 	updateStatus func(client.Client, *hivev1.ClusterState) error
+
+	// statusUpdateInterval is the base interval at which the states of the cluster operators are polled.
+	statusUpdateInterval time.Duration
+	// jitterPercent is the maximum jitter of the polls of each cluster, as a percentage of the base interval.
+	jitterPercent int
+	// collectNodeInfo is whether the operating systems of the nodes are polled along with the cluster operators.
+	collectNodeInfo bool
 }
 
 // Reconcile ensures that a given ClusterState resource exists and reflects the state of cluster operators from its target cluster
@@ -181,8 +198,8 @@ func (r *ReconcileClusterState) Reconcile(request reconcile.Request) (reconcile.
 	}
 	if st.Status.LastUpdated != nil {
 		timeSinceLastUpdate := time.Since(st.Status.LastUpdated.Time)
-		if timeSinceLastUpdate < statusUpdateInterval {
-			nextUpdateWait := statusUpdateInterval - timeSinceLastUpdate
+		if pollInterval := r.pollInterval(st); timeSinceLastUpdate < pollInterval {
+			nextUpdateWait := pollInterval - timeSinceLastUpdate
 			logger.Debugf("Waiting to fetch clusteroperator status in %v", nextUpdateWait)
 			return reconcile.Result{RequeueAfter: nextUpdateWait}, nil
 		}
@@ -205,10 +222,20 @@ func (r *ReconcileClusterState) Reconcile(request reconcile.Request) (reconcile.
 		logger.WithError(err).Error("failed to list target cluster operators")
 		return reconcile.Result{}, err
 	}
-	return r.syncOperatorStates(clusterOperators.Items, st, logger)
+
+	var nodeStates []hivev1.NodeOSState
+	if r.collectNodeInfo {
+		nodes := &corev1.NodeList{}
+		if err := remoteClient.List(context.TODO(), nodes); err != nil {
+			logger.WithError(err).Error("failed to list target cluster nodes")
+			return reconcile.Result{}, err
+		}
+		nodeStates = nodeOSStates(nodes.Items)
+	}
+	return r.syncStates(clusterOperators.Items, nodeStates, st, logger)
 }
 
-func (r *ReconcileClusterState) syncOperatorStates(operators []configv1.ClusterOperator, st *hivev1.ClusterState, logger log.FieldLogger) (reconcile.Result, error) {
+func (r *ReconcileClusterState) syncStates(operators []configv1.ClusterOperator, nodeStates []hivev1.NodeOSState, st *hivev1.ClusterState, logger log.FieldLogger) (reconcile.Result, error) {
 	operatorStates := make([]hivev1.ClusterOperatorState, len(operators))
 	for i, clusterOperator := range operators {
 		operatorStates[i] = hivev1.ClusterOperatorState{
@@ -216,8 +243,14 @@ func (r *ReconcileClusterState) syncOperatorStates(operators []configv1.ClusterO
 			Conditions: clusterOperator.Status.Conditions,
 		}
 	}
-	if operatorStatesChanged(logger, st.Status.ClusterOperators, operatorStates) {
+	changed := operatorStatesChanged(logger, st.Status.ClusterOperators, operatorStates)
+	if !apiequality.Semantic.DeepEqual(st.Status.Nodes, nodeStates) {
+		logger.Info("node operating systems changed")
+		changed = true
+	}
+	if changed {
 		st.Status.ClusterOperators = operatorStates
+		st.Status.Nodes = nodeStates
 		now := metav1.Now()
 		st.Status.LastUpdated = &now
 		if err := r.updateStatus(r, st); err != nil {
@@ -228,10 +261,20 @@ func (r *ReconcileClusterState) syncOperatorStates(operators []configv1.ClusterO
 		return reconcile.Result{}, nil
 	}
 	return reconcile.Result{
-		RequeueAfter: statusUpdateInterval,
+		RequeueAfter: r.pollInterval(st),
 	}, nil
 }
 
+// pollInterval returns the interval at which the states of the cluster operators of the cluster are polled, which is
+// spread out from the intervals of the other clusters.
+func (r *ReconcileClusterState) pollInterval(st *hivev1.ClusterState) time.Duration {
+	return controllerutils.JitteredInterval(
+		r.statusUpdateInterval,
+		r.jitterPercent,
+		types.NamespacedName{Namespace: st.Namespace, Name: st.Name},
+	)
+}
+
 func operatorStatesChanged(logger log.FieldLogger, existing, updated []hivev1.ClusterOperatorState) bool {
 	changed := false
 	existingNames := sets.NewString()
@@ -25,6 +25,7 @@ import (
 	"github.com/openshift/hive/apis"
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 	"github.com/openshift/hive/pkg/remoteclient"
 	remoteclientmock "github.com/openshift/hive/pkg/remoteclient/mock"
 )
@@ -56,12 +57,13 @@ func TestClusterStateReconcile(t *testing.T) {
 	uco := unavailableClusterOperator
 
 	tests := []struct {
-		name         string
-		existing     []runtime.Object
-		remote       []runtime.Object
-		noRemoteCall bool
-		validate     func(*testing.T, client.Client, reconcile.Result)
-		noUpdate     bool
+		name            string
+		existing        []runtime.Object
+		remote          []runtime.Object
+		noRemoteCall    bool
+		collectNodeInfo bool
+		validate        func(*testing.T, client.Client, reconcile.Result)
+		noUpdate        bool
 	}{
 		{
 			name: "create cluster state",
@@ -99,7 +101,8 @@ func TestClusterStateReconcile(t *testing.T) {
 			validate: func(t *testing.T, c client.Client, result reconcile.Result) {
 				st := cs(t, c)
 				validateStatus(t, st.Status, co("d"), co("e"))
-				assert.Equal(t, result.RequeueAfter, statusUpdateInterval)
+				assert.GreaterOrEqual(t, result.RequeueAfter.Seconds(), defaultStatusUpdateInterval.Seconds(), "requeue after too small")
+				assert.LessOrEqual(t, result.RequeueAfter.Seconds(), defaultStatusUpdateInterval.Seconds()*1.1, "requeue after too large")
 			},
 			noUpdate: true,
 		},
@@ -155,6 +158,65 @@ func TestClusterStateReconcile(t *testing.T) {
 				validateStatus(t, st.Status, co("a"), removeCond(co("b")))
 			},
 		},
+		{
+			name: "collect node info",
+			existing: []runtime.Object{
+				testClusterStateWithStatus(co("a")),
+				testClusterDeployment(),
+				testKubeconfigSecret(),
+			},
+			remote:          []runtime.Object{co("a"), testNode("worker", rhcosImage), testNode("master", rhcosImage)},
+			collectNodeInfo: true,
+			validate: func(t *testing.T, c client.Client, result reconcile.Result) {
+				st := cs(t, c)
+				validateStatus(t, st.Status, co("a"))
+				if assert.Len(t, st.Status.Nodes, 2, "unexpected number of nodes") {
+					assert.Equal(t, "master", st.Status.Nodes[0].Name, "unexpected node name")
+					assert.Equal(t, "46.82.202011260640-0", st.Status.Nodes[0].RHCOSBuild, "unexpected RHCOS build")
+					assert.Equal(t, "worker", st.Status.Nodes[1].Name, "unexpected node name")
+				}
+			},
+		},
+		{
+			name: "steady node info",
+			existing: []runtime.Object{
+				testClusterStateWithNodes(testClusterStateWithStatus(co("a")), testNode("master", rhcosImage)),
+				testClusterDeployment(),
+				testKubeconfigSecret(),
+			},
+			remote:          []runtime.Object{co("a"), testNode("master", rhcosImage)},
+			collectNodeInfo: true,
+			noUpdate:        true,
+		},
+		{
+			name: "changed node info",
+			existing: []runtime.Object{
+				testClusterStateWithNodes(testClusterStateWithStatus(co("a")), testNode("master", rhcosImage)),
+				testClusterDeployment(),
+				testKubeconfigSecret(),
+			},
+			remote:          []runtime.Object{co("a"), testNode("master", "Red Hat Enterprise Linux CoreOS 47.83.202103251640-0 (Ootpa)")},
+			collectNodeInfo: true,
+			validate: func(t *testing.T, c client.Client, result reconcile.Result) {
+				st := cs(t, c)
+				if assert.Len(t, st.Status.Nodes, 1, "unexpected number of nodes") {
+					assert.Equal(t, "47.83.202103251640-0", st.Status.Nodes[0].RHCOSBuild, "unexpected RHCOS build")
+				}
+			},
+		},
+		{
+			name: "node info not collected",
+			existing: []runtime.Object{
+				testClusterStateWithNodes(testClusterStateWithStatus(co("a")), testNode("master", rhcosImage)),
+				testClusterDeployment(),
+				testKubeconfigSecret(),
+			},
+			remote: []runtime.Object{co("a"), testNode("master", rhcosImage)},
+			validate: func(t *testing.T, c client.Client, result reconcile.Result) {
+				st := cs(t, c)
+				assert.Empty(t, st.Status.Nodes, "expected node info to be cleared")
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -172,6 +234,9 @@ func TestClusterStateReconcile(t *testing.T) {
 				scheme:                        scheme.Scheme,
 				logger:                        log.WithField("controller", "clusterState"),
 				remoteClusterAPIClientBuilder: func(*hivev1.ClusterDeployment) remoteclient.Builder { return mockRemoteClientBuilder },
+				statusUpdateInterval:          defaultStatusUpdateInterval,
+				jitterPercent:                 controllerutils.DefaultPeriodicSyncJitterPercent,
+				collectNodeInfo:               test.collectNodeInfo,
 				updateStatus: func(c client.Client, st *hivev1.ClusterState) error {
 					updateCalled = true
 					return updateClusterStateStatus(c, st)
@@ -219,6 +284,13 @@ func testClusterStateWithStatus(operators ...*configv1.ClusterOperator) *hivev1.
 	return cs
 }
 
+func testClusterStateWithNodes(cs *hivev1.ClusterState, nodes ...*corev1.Node) *hivev1.ClusterState {
+	for _, node := range nodes {
+		cs.Status.Nodes = append(cs.Status.Nodes, nodeOSStates([]corev1.Node{*node})...)
+	}
+	return cs
+}
+
 func testClusterDeployment() *hivev1.ClusterDeployment {
 	return &hivev1.ClusterDeployment{
 		ObjectMeta: metav1.ObjectMeta{
@@ -254,6 +326,23 @@ func testKubeconfigSecret() *corev1.Secret {
 	}
 }
 
+const rhcosImage = "Red Hat Enterprise Linux CoreOS 46.82.202011260640-0 (Ootpa)"
+
+func testNode(name, osImage string) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Status: corev1.NodeStatus{
+			NodeInfo: corev1.NodeSystemInfo{
+				OSImage:                 osImage,
+				KernelVersion:           "4.18.0-193.29.1.el8_2.x86_64",
+				ContainerRuntimeVersion: "cri-o://1.19.0-26.rhaos4.6.git8a05a29.el8",
+			},
+		},
+	}
+}
+
 func clusterOperator(name string) *configv1.ClusterOperator {
 	return &configv1.ClusterOperator{
 		ObjectMeta: metav1.ObjectMeta{
@@ -0,0 +1,53 @@
+package clusterstate
+
+import (
+	"regexp"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+// rhcosBuildRegexp matches the RHCOS build in the OS image of a node, such as 46.82.202011260640-0 in
+// "Red Hat Enterprise Linux CoreOS 46.82.202011260640-0 (Ootpa)". The third part of the build is the time at which it
+// was created.
+var rhcosBuildRegexp = regexp.MustCompile(`Red Hat Enterprise Linux CoreOS (\d+\.\d+\.(\d{12})-\d+)`)
+
+const rhcosBuildTimeLayout = "200601021504"
+
+// nodeOSStates returns the operating systems of the nodes, sorted by node name.
+func nodeOSStates(nodes []corev1.Node) []hivev1.NodeOSState {
+	states := make([]hivev1.NodeOSState, len(nodes))
+	for i, node := range nodes {
+		info := node.Status.NodeInfo
+		states[i] = hivev1.NodeOSState{
+			Name:                    node.Name,
+			OSImage:                 info.OSImage,
+			KernelVersion:           info.KernelVersion,
+			ContainerRuntimeVersion: info.ContainerRuntimeVersion,
+		}
+		states[i].RHCOSBuild, states[i].RHCOSBuildTime = parseRHCOSBuild(info.OSImage)
+	}
+	sort.Slice(states, func(i, j int) bool {
+		return states[i].Name < states[j].Name
+	})
+	return states
+}
+
+// parseRHCOSBuild returns the RHCOS build in the OS image of a node and the time at which the build was created, or
+// an empty build and nil time if the node does not run RHCOS.
+func parseRHCOSBuild(osImage string) (string, *metav1.Time) {
+	match := rhcosBuildRegexp.FindStringSubmatch(osImage)
+	if match == nil {
+		return "", nil
+	}
+	buildTime, err := time.Parse(rhcosBuildTimeLayout, match[2])
+	if err != nil {
+		return match[1], nil
+	}
+	t := metav1.NewTime(buildTime)
+	return match[1], &t
+}
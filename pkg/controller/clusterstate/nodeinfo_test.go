@@ -0,0 +1,49 @@
+package clusterstate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRHCOSBuild(t *testing.T) {
+	cases := []struct {
+		name          string
+		osImage       string
+		expectedBuild string
+		expectedTime  time.Time
+	}{
+		{
+			name:          "RHCOS",
+			osImage:       "Red Hat Enterprise Linux CoreOS 46.82.202011260640-0 (Ootpa)",
+			expectedBuild: "46.82.202011260640-0",
+			expectedTime:  time.Date(2020, 11, 26, 6, 40, 0, 0, time.UTC),
+		},
+		{
+			name:          "RHCOS with three digit version",
+			osImage:       "Red Hat Enterprise Linux CoreOS 410.84.202205191234-0 (Ootpa)",
+			expectedBuild: "410.84.202205191234-0",
+			expectedTime:  time.Date(2022, 5, 19, 12, 34, 0, 0, time.UTC),
+		},
+		{
+			name:    "RHEL",
+			osImage: "Red Hat Enterprise Linux Server 7.9 (Maipo)",
+		},
+		{
+			name:    "Fedora CoreOS",
+			osImage: "Fedora CoreOS 33.20210104.3.0",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			build, buildTime := parseRHCOSBuild(tc.osImage)
+			assert.Equal(t, tc.expectedBuild, build, "unexpected build")
+			if tc.expectedTime.IsZero() {
+				assert.Nil(t, buildTime, "unexpected build time")
+			} else if assert.NotNil(t, buildTime, "expected build time") {
+				assert.True(t, tc.expectedTime.Equal(buildTime.Time), "unexpected build time: %v", buildTime)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package clustersync
+
+import (
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"k8s.io/apiserver/pkg/authentication/serviceaccount"
+	"k8s.io/client-go/rest"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/resource"
+)
+
+// applyAsHelperBuilder builds the resource helper used to apply a SyncSet as a ServiceAccount of the target cluster.
+type applyAsHelperBuilder func(sa *hivev1.ServiceAccountReference) (resource.Helper, error)
+
+// impersonatingHelperBuilder returns an applyAsHelperBuilder whose resource helpers impersonate the ServiceAccount
+// using the given REST config. The helpers are cached, so that SyncSets applied as the same ServiceAccount share one.
+func (r *ReconcileClusterSync) impersonatingHelperBuilder(restConfig *rest.Config, fakeCluster bool, logger log.FieldLogger) applyAsHelperBuilder {
+	helpers := map[string]resource.Helper{}
+	return func(sa *hivev1.ServiceAccountReference) (resource.Helper, error) {
+		userName := serviceaccount.MakeUsername(sa.Namespace, sa.Name)
+		if helper, ok := helpers[userName]; ok {
+			return helper, nil
+		}
+		config := rest.CopyConfig(restConfig)
+		config.Impersonate = rest.ImpersonationConfig{UserName: userName}
+		helper, err := r.resourceHelperBuilder(config, fakeCluster, logger.WithField("applyAs", userName))
+		if err != nil {
+			return nil, err
+		}
+		helpers[userName] = helper
+		return helper, nil
+	}
+}
+
+// unavailableApplyAsHelperBuilder returns an applyAsHelperBuilder for when SyncSets cannot be applied as a
+// ServiceAccount, whose resource helpers fail every operation with the given error.
+func unavailableApplyAsHelperBuilder(err error) applyAsHelperBuilder {
+	return func(*hivev1.ServiceAccountReference) (resource.Helper, error) {
+		return &unavailableResourceHelper{err: err}, nil
+	}
+}
+
+// applyAsResourceHelper returns the resource helper with which to apply a SyncSet which is applied as the given
+// ServiceAccount. Failures to build the helper are reported as failures to apply the SyncSet.
+func applyAsResourceHelper(sa *hivev1.ServiceAccountReference, buildHelper applyAsHelperBuilder, logger log.FieldLogger) resource.Helper {
+	helper, err := buildHelper(sa)
+	if err != nil {
+		logger.WithError(err).Error("cannot create helper to apply as service account")
+		return &unavailableResourceHelper{
+			err: errors.Wrapf(err, "could not apply as service account %s/%s", sa.Namespace, sa.Name),
+		}
+	}
+	return helper
+}
+
+// syncSetApplyAs returns the ServiceAccount which the syncset is applied as, or nil if it is applied as Hive. Only
+// SyncSets can be applied as a ServiceAccount.
+func syncSetApplyAs(syncSet CommonSyncSet) *hivev1.ServiceAccountReference {
+	if ss, ok := syncSet.(*SyncSetAsCommon); ok {
+		return ss.Spec.ApplyAs
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package clustersync
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	"k8s.io/client-go/rest"
+
+	hiveintv1alpha1 "github.com/openshift/hive/apis/hiveinternal/v1alpha1"
+	"github.com/openshift/hive/pkg/resource"
+	resourcemock "github.com/openshift/hive/pkg/resource/mock"
+	teststatefulset "github.com/openshift/hive/pkg/test/statefulset"
+	testsyncset "github.com/openshift/hive/pkg/test/syncset"
+)
+
+func TestReconcileClusterSync_ApplyAs(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	scheme := newScheme()
+	resourceToApply := testConfigMap("dest-namespace", "dest-name")
+	otherResourceToApply := testConfigMap("dest-namespace", "other-name")
+	rt := newReconcileTest(t, mockCtrl, scheme,
+		cdBuilder(scheme).Build(),
+		clusterSyncBuilder(scheme).Build(),
+		teststatefulset.FullBuilder("hive", stsName, scheme).Build(
+			teststatefulset.WithCurrentReplicas(3),
+			teststatefulset.WithReplicas(3),
+		),
+		testsyncset.FullBuilder(testNamespace, "applied-as-sa", scheme).Build(
+			testsyncset.ForClusterDeployments(testCDName),
+			testsyncset.WithGeneration(1),
+			testsyncset.WithApplyAs("team-ns", "team-sa"),
+			testsyncset.WithResources(resourceToApply),
+		),
+		testsyncset.FullBuilder(testNamespace, "applied-as-hive", scheme).Build(
+			testsyncset.ForClusterDeployments(testCDName),
+			testsyncset.WithGeneration(1),
+			testsyncset.WithResources(otherResourceToApply),
+		),
+	)
+	impersonatingResourceHelper := resourcemock.NewMockHelper(mockCtrl)
+	var impersonatedUserNames []string
+	rt.r.resourceHelperBuilder = func(rc *rest.Config, _ bool, _ log.FieldLogger) (resource.Helper, error) {
+		if rc.Impersonate.UserName == "" {
+			return rt.mockResourceHelper, nil
+		}
+		impersonatedUserNames = append(impersonatedUserNames, rc.Impersonate.UserName)
+		return impersonatingResourceHelper, nil
+	}
+	impersonatingResourceHelper.EXPECT().Apply(newApplyMatcher(resourceToApply)).Return(resource.CreatedApplyResult, nil)
+	rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(otherResourceToApply)).Return(resource.CreatedApplyResult, nil)
+	rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{
+		buildSyncStatus("applied-as-hive"),
+		buildSyncStatus("applied-as-sa"),
+	}
+	rt.run(t)
+	assert.Equal(t, []string{"system:serviceaccount:team-ns:team-sa"}, impersonatedUserNames, "unexpected impersonated users")
+}
+
+func TestReconcileClusterSync_ApplyAsHelperError(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	scheme := newScheme()
+	rt := newReconcileTest(t, mockCtrl, scheme,
+		cdBuilder(scheme).Build(),
+		clusterSyncBuilder(scheme).Build(),
+		teststatefulset.FullBuilder("hive", stsName, scheme).Build(
+			teststatefulset.WithCurrentReplicas(3),
+			teststatefulset.WithReplicas(3),
+		),
+		testsyncset.FullBuilder(testNamespace, "test-syncset", scheme).Build(
+			testsyncset.ForClusterDeployments(testCDName),
+			testsyncset.WithGeneration(1),
+			testsyncset.WithApplyAs("team-ns", "team-sa"),
+			testsyncset.WithResources(testConfigMap("dest-namespace", "dest-name")),
+		),
+	)
+	rt.r.resourceHelperBuilder = func(rc *rest.Config, _ bool, _ log.FieldLogger) (resource.Helper, error) {
+		if rc.Impersonate.UserName == "" {
+			return rt.mockResourceHelper, nil
+		}
+		return nil, errors.New("test helper error")
+	}
+	rt.expectedFailedMessage = "SyncSet test-syncset is failing"
+	rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{buildSyncStatus("test-syncset",
+		withFailureResult("failed to apply resource 0: could not apply as service account team-ns/team-sa: test helper error"),
+		withNoFirstSuccessTime(),
+	)}
+	rt.expectRequeue = true
+	rt.run(t)
+}
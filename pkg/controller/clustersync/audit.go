@@ -0,0 +1,84 @@
+package clustersync
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/openshift/hive/pkg/constants"
+)
+
+// syncSetAudit configures the annotations, and optionally the ownership label, which record on the resources applied
+// from syncsets where they came from.
+type syncSetAudit struct {
+	// hubName identifies the hub cluster on the resources. The hub annotation and the ownership label are omitted
+	// when it is empty.
+	hubName string
+	// ownershipLabel is whether the resources are labeled with the hub which applies them.
+	ownershipLabel bool
+	// now returns the time at which the resources are applied.
+	now func() time.Time
+}
+
+// syncSetAuditFromEnv returns the audit configured by the environment of the controller, or nil if the resources
+// applied from syncsets are not stamped.
+func syncSetAuditFromEnv() (*syncSetAudit, error) {
+	if os.Getenv(constants.SyncSetAuditEnvVar) != "true" {
+		return nil, nil
+	}
+	audit := &syncSetAudit{
+		hubName:        os.Getenv(constants.SyncSetAuditHubNameEnvVar),
+		ownershipLabel: os.Getenv(constants.SyncSetAuditOwnershipLabelEnvVar) == "true",
+		now:            time.Now,
+	}
+	if audit.ownershipLabel {
+		if errs := validation.IsValidLabelValue(audit.hubName); len(errs) > 0 {
+			return nil, fmt.Errorf("hub name %q is not a valid label value: %v", audit.hubName, errs)
+		}
+	}
+	return audit, nil
+}
+
+// stamper returns a function which stamps the objects applied from the syncset with the audit annotations, and the
+// ownership label if enabled. All the objects applied together are stamped with the same apply time. The returned
+// function does nothing when there is no audit.
+func (a *syncSetAudit) stamper(syncSet CommonSyncSet) func(obj metav1.Object) {
+	if a == nil {
+		return func(metav1.Object) {}
+	}
+	meta := syncSet.AsMetaObject()
+	source := "SelectorSyncSet/" + meta.GetName()
+	if _, ok := syncSet.(*SyncSetAsCommon); ok {
+		source = fmt.Sprintf("SyncSet/%s/%s", meta.GetNamespace(), meta.GetName())
+	}
+	stamps := map[string]string{
+		constants.SyncSetSourceAnnotation:     source,
+		constants.SyncSetGenerationAnnotation: strconv.FormatInt(meta.GetGeneration(), 10),
+		constants.SyncSetAppliedAtAnnotation:  a.now().UTC().Format(time.RFC3339),
+	}
+	if a.hubName != "" {
+		stamps[constants.SyncSetHubAnnotation] = a.hubName
+	}
+	return func(obj metav1.Object) {
+		annotations := make(map[string]string, len(obj.GetAnnotations())+len(stamps))
+		for k, v := range obj.GetAnnotations() {
+			annotations[k] = v
+		}
+		for k, v := range stamps {
+			annotations[k] = v
+		}
+		obj.SetAnnotations(annotations)
+		if a.ownershipLabel && a.hubName != "" {
+			labels := make(map[string]string, len(obj.GetLabels())+1)
+			for k, v := range obj.GetLabels() {
+				labels[k] = v
+			}
+			labels[constants.SyncSetHubLabel] = a.hubName
+			obj.SetLabels(labels)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package clustersync
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	hiveintv1alpha1 "github.com/openshift/hive/apis/hiveinternal/v1alpha1"
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/resource"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+	testgeneric "github.com/openshift/hive/pkg/test/generic"
+	testsecret "github.com/openshift/hive/pkg/test/secret"
+	testselectorsyncset "github.com/openshift/hive/pkg/test/selectorsyncset"
+	teststatefulset "github.com/openshift/hive/pkg/test/statefulset"
+	testsyncset "github.com/openshift/hive/pkg/test/syncset"
+)
+
+func TestReconcileClusterSync_Audit(t *testing.T) {
+	appliedAt := time.Date(2021, time.March, 4, 5, 6, 7, 0, time.UTC)
+	cases := []struct {
+		name                string
+		audit               *syncSetAudit
+		expectedHub         string
+		expectedHubLabel    string
+		expectedAnnotations bool
+	}{
+		{
+			name: "no audit",
+		},
+		{
+			name:                "audit without hub name",
+			audit:               &syncSetAudit{ownershipLabel: true},
+			expectedAnnotations: true,
+		},
+		{
+			name:                "audit with hub name",
+			audit:               &syncSetAudit{hubName: "test-hub"},
+			expectedHub:         "test-hub",
+			expectedAnnotations: true,
+		},
+		{
+			name:                "audit with ownership label",
+			audit:               &syncSetAudit{hubName: "test-hub", ownershipLabel: true},
+			expectedHub:         "test-hub",
+			expectedHubLabel:    "test-hub",
+			expectedAnnotations: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scheme := newScheme()
+			rt := newReconcileTest(t, mockCtrl, scheme,
+				cdBuilder(scheme).Build(testcd.WithLabel("test-label-key", "test-label-value")),
+				clusterSyncBuilder(scheme).Build(),
+				teststatefulset.FullBuilder("hive", stsName, scheme).Build(
+					teststatefulset.WithCurrentReplicas(3),
+					teststatefulset.WithReplicas(3),
+				),
+				testsyncset.FullBuilder(testNamespace, "test-syncset", scheme).Build(
+					testsyncset.ForClusterDeployments(testCDName),
+					testsyncset.WithGeneration(2),
+					testsyncset.WithResources(testConfigMap("dest-namespace", "resource-from-syncset")),
+					testsyncset.WithSecrets(testSecretMapping("test-secret", "dest-namespace", "dest-name")),
+				),
+				testselectorsyncset.FullBuilder("test-selectorsyncset", scheme).Build(
+					testselectorsyncset.WithLabelSelector("test-label-key", "test-label-value"),
+					testselectorsyncset.WithGeneration(3),
+					testselectorsyncset.WithResources(testConfigMap("dest-namespace", "resource-from-selectorsyncset")),
+				),
+				testsecret.FullBuilder(testNamespace, "test-secret", scheme).Build(
+					testsecret.WithDataKeyValue("test-key", []byte("test-data")),
+				),
+			)
+			if tc.audit != nil {
+				tc.audit.now = func() time.Time { return appliedAt }
+			}
+			rt.r.audit = tc.audit
+
+			stamp := func(obj metav1.Object, source, generation string) {
+				if !tc.expectedAnnotations {
+					return
+				}
+				annotations := map[string]string{
+					constants.SyncSetSourceAnnotation:     source,
+					constants.SyncSetGenerationAnnotation: generation,
+					constants.SyncSetAppliedAtAnnotation:  "2021-03-04T05:06:07Z",
+				}
+				if tc.expectedHub != "" {
+					annotations[constants.SyncSetHubAnnotation] = tc.expectedHub
+				}
+				obj.SetAnnotations(annotations)
+				if tc.expectedHubLabel != "" {
+					obj.SetLabels(map[string]string{constants.SyncSetHubLabel: tc.expectedHubLabel})
+				}
+			}
+			syncSetResource := testConfigMap("dest-namespace", "resource-from-syncset")
+			stamp(syncSetResource, "SyncSet/"+testNamespace+"/test-syncset", "2")
+			secret := testsecret.BasicBuilder().GenericOptions(
+				testgeneric.WithNamespace("dest-namespace"),
+				testgeneric.WithName("dest-name"),
+				testgeneric.WithTypeMeta(scheme),
+			).Build(
+				testsecret.WithDataKeyValue("test-key", []byte("test-data")),
+			)
+			stamp(secret, "SyncSet/"+testNamespace+"/test-syncset", "2")
+			selectorSyncSetResource := testConfigMap("dest-namespace", "resource-from-selectorsyncset")
+			stamp(selectorSyncSetResource, "SelectorSyncSet/test-selectorsyncset", "3")
+
+			rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(syncSetResource)).Return(resource.CreatedApplyResult, nil)
+			rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(secret)).Return(resource.CreatedApplyResult, nil)
+			rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(selectorSyncSetResource)).Return(resource.CreatedApplyResult, nil)
+			rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{buildSyncStatus("test-syncset", withObservedGeneration(2))}
+			rt.expectedSelectorSyncSetStatuses = []hiveintv1alpha1.SyncStatus{buildSyncStatus("test-selectorsyncset", withObservedGeneration(3))}
+			rt.run(t)
+		})
+	}
+}
+
+func TestSyncSetAuditFromEnv(t *testing.T) {
+	defer os.Unsetenv(constants.SyncSetAuditEnvVar)
+	defer os.Unsetenv(constants.SyncSetAuditHubNameEnvVar)
+	defer os.Unsetenv(constants.SyncSetAuditOwnershipLabelEnvVar)
+
+	audit, err := syncSetAuditFromEnv()
+	require.NoError(t, err)
+	assert.Nil(t, audit, "expected no audit")
+
+	os.Setenv(constants.SyncSetAuditEnvVar, "true")
+	os.Setenv(constants.SyncSetAuditHubNameEnvVar, "test-hub")
+	os.Setenv(constants.SyncSetAuditOwnershipLabelEnvVar, "true")
+	audit, err = syncSetAuditFromEnv()
+	require.NoError(t, err)
+	if assert.NotNil(t, audit, "expected audit") {
+		assert.Equal(t, "test-hub", audit.hubName, "unexpected hub name")
+		assert.True(t, audit.ownershipLabel, "expected ownership label")
+	}
+
+	os.Setenv(constants.SyncSetAuditHubNameEnvVar, "not a label value")
+	_, err = syncSetAuditFromEnv()
+	assert.Error(t, err, "expected error for hub name which is not a label value")
+}
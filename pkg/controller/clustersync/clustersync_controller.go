@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"math/big"
-	"math/rand"
 	"os"
 	"reflect"
 	"sort"
@@ -41,6 +40,7 @@ import (
 	"github.com/openshift/hive/pkg/constants"
 	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/ociartifact"
 	"github.com/openshift/hive/pkg/remoteclient"
 	"github.com/openshift/hive/pkg/resource"
 )
@@ -49,7 +49,6 @@ const (
 	ControllerName         = hivev1.ClustersyncControllerName
 	defaultReapplyInterval = 2 * time.Hour
 	reapplyIntervalEnvKey  = "SYNCSET_REAPPLY_INTERVAL"
-	reapplyIntervalJitter  = 0.1
 	secretAPIVersion       = "v1"
 	secretKind             = "Secret"
 	labelApply             = "apply"
@@ -58,6 +57,10 @@ const (
 	metricResultSuccess    = "success"
 	metricResultError      = "error"
 	stsName                = "hive-clustersync"
+
+	// controllerKubeconfigRecheckInterval is how often syncsets are retried while the controller kubeconfig of
+	// the restricted remote access RBAC mode is not available.
+	controllerKubeconfigRecheckInterval = time.Minute
 )
 
 var (
@@ -160,16 +163,32 @@ func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) (*R
 		}
 	}
 	log.WithField("reapplyInterval", reapplyInterval).Info("Reapply interval set")
+	audit, err := syncSetAuditFromEnv()
+	if err != nil {
+		log.WithError(err).Error("unable to configure syncset auditing")
+		return nil, err
+	}
 	c := controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter)
-	return &ReconcileClusterSync{
+	r := &ReconcileClusterSync{
 		Client:                c,
 		logger:                logger,
 		reapplyInterval:       reapplyInterval,
+		reapplyJitterPercent:  controllerutils.PeriodicSyncJitterPercentFromEnv(),
 		resourceHelperBuilder: resourceHelperBuilderFunc,
 		remoteClusterAPIClientBuilder: func(cd *hivev1.ClusterDeployment) remoteclient.Builder {
-			return remoteclient.NewBuilder(c, cd, ControllerName)
+			return remoteclient.NewAdminBuilder(c, cd, ControllerName)
 		},
-	}, nil
+		fetchArtifact: ociartifact.NewFetcher(nil).Fetch,
+		audit:         audit,
+	}
+	if restricted, syncSetAPIGroups := remoteclient.RestrictedRemoteAccess(); restricted {
+		log.WithField("syncSetAPIGroups", syncSetAPIGroups).Info("syncsets are applied with the restricted remote access RBAC mode")
+		r.restrictedSyncSetAPIGroups = syncSetAPIGroups
+		r.restrictedRemoteClusterAPIClientBuilder = func(cd *hivev1.ClusterDeployment) remoteclient.Builder {
+			return remoteclient.NewRestrictedBuilder(c, cd, ControllerName)
+		}
+	}
+	return r, nil
 }
 
 func resourceHelperBuilderFunc(restConfig *rest.Config, fakeCluster bool, logger log.FieldLogger) (resource.Helper, error) {
@@ -216,6 +235,26 @@ func AddToManager(mgr manager.Manager, r *ReconcileClusterSync, concurrentReconc
 		return err
 	}
 
+	// Watch for changes to the ConfigMaps holding manifests referenced by SyncSets and SelectorSyncSets
+	if err := c.Watch(
+		&source.Kind{Type: &corev1.ConfigMap{}},
+		&handler.EnqueueRequestsFromMapFunc{
+			ToRequests: requestsForConfigMap(r.Client, r.logger),
+		},
+	); err != nil {
+		return err
+	}
+
+	// Watch for changes to ChangeFreezes
+	if err := c.Watch(
+		&source.Kind{Type: &hivev1.ChangeFreeze{}},
+		&handler.EnqueueRequestsFromMapFunc{
+			ToRequests: requestsForChangeFreeze(r.Client, r.logger),
+		},
+	); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -258,6 +297,59 @@ func requestsForSelectorSyncSet(c client.Client, logger log.FieldLogger) handler
 	}
 }
 
+func requestsForConfigMap(c client.Client, logger log.FieldLogger) handler.ToRequestsFunc {
+	requestsForSelectorSyncSet := requestsForSelectorSyncSet(c, logger)
+	return func(o handler.MapObject) []reconcile.Request {
+		cm, ok := o.Object.(*corev1.ConfigMap)
+		if !ok {
+			return nil
+		}
+		logger := logger.WithField("configMap", cm.Namespace+"/"+cm.Name)
+		var requests []reconcile.Request
+		syncSets := &hivev1.SyncSetList{}
+		if err := c.List(context.Background(), syncSets, client.InNamespace(cm.Namespace)); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not list SyncSets")
+			return nil
+		}
+		for i := range syncSets.Items {
+			if referencesConfigMap((*SyncSetAsCommon)(&syncSets.Items[i]), cm.Namespace, cm.Name) {
+				requests = append(requests, requestsForSyncSet(handler.MapObject{Object: &syncSets.Items[i]})...)
+			}
+		}
+		selectorSyncSets := &hivev1.SelectorSyncSetList{}
+		if err := c.List(context.Background(), selectorSyncSets); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not list SelectorSyncSets")
+			return requests
+		}
+		for i := range selectorSyncSets.Items {
+			if referencesConfigMap((*SelectorSyncSetAsCommon)(&selectorSyncSets.Items[i]), cm.Namespace, cm.Name) {
+				requests = append(requests, requestsForSelectorSyncSet(handler.MapObject{Object: &selectorSyncSets.Items[i]})...)
+			}
+		}
+		return requests
+	}
+}
+
+func requestsForChangeFreeze(c client.Client, logger log.FieldLogger) handler.ToRequestsFunc {
+	return func(o handler.MapObject) []reconcile.Request {
+		freeze, ok := o.Object.(*hivev1.ChangeFreeze)
+		if !ok {
+			return nil
+		}
+		cds, err := controllerutils.ClusterDeploymentsForChangeFreeze(c, freeze)
+		if err != nil {
+			logger.WithField("changeFreeze", freeze.Name).WithError(err).Log(controllerutils.LogLevel(err), "could not list ClusterDeployments matching ChangeFreeze")
+			return nil
+		}
+		requests := make([]reconcile.Request, len(cds))
+		for i, cd := range cds {
+			requests[i].Namespace = cd.Namespace
+			requests[i].Name = cd.Name
+		}
+		return requests
+	}
+}
+
 var _ reconcile.Reconciler = &ReconcileClusterSync{}
 
 // ReconcileClusterSync reconciles a ClusterDeployment object to apply its SyncSets and SelectorSyncSets
@@ -265,6 +357,9 @@ type ReconcileClusterSync struct {
 	client.Client
 	logger          log.FieldLogger
 	reapplyInterval time.Duration
+	// reapplyJitterPercent is the maximum jitter of the full reapply of each cluster, as a percentage of the reapply
+	// interval.
+	reapplyJitterPercent int
 
 	resourceHelperBuilder func(*rest.Config, bool, log.FieldLogger) (resource.Helper, error)
 
@@ -272,6 +367,19 @@ type ReconcileClusterSync struct {
 	// for the remote cluster's API server
 	remoteClusterAPIClientBuilder func(cd *hivev1.ClusterDeployment) remoteclient.Builder
 
+	// restrictedRemoteClusterAPIClientBuilder, when set, gets a builder for building a client with the permissions of
+	// the restricted remote access RBAC mode. All syncsets except the remote access syncset generated by Hive are
+	// applied with that client.
+	restrictedRemoteClusterAPIClientBuilder func(cd *hivev1.ClusterDeployment) remoteclient.Builder
+	// restrictedSyncSetAPIGroups are the API groups that syncsets can manage in the restricted remote access RBAC mode.
+	restrictedSyncSetAPIGroups []string
+
+	// fetchArtifact fetches the manifests held in an OCI artifact referenced by a syncset.
+	fetchArtifact func(image string, pullSecret []byte) ([][]byte, error)
+
+	// audit, when set, configures the annotations stamped on the resources applied from syncsets.
+	audit *syncSetAudit
+
 	ordinalID int64
 }
 
@@ -381,6 +489,12 @@ func (r *ReconcileClusterSync) Reconcile(request reconcile.Request) (reconcile.R
 		return reconcile.Result{}, nil
 	}
 
+	if cd.Spec.SyncAgent != nil {
+		logger.Debug("syncsets are applied by the sync agent running in the cluster")
+		recobsrv.SetOutcome(hivemetrics.ReconcileOutcomeSkippedSync)
+		return r.publishSyncAgentState(cd, logger)
+	}
+
 	if unreachable, _ := remoteclient.Unreachable(cd); unreachable {
 		logger.Debug("cluster is unreachable")
 		return reconcile.Result{}, nil
@@ -401,9 +515,57 @@ func (r *ReconcileClusterSync) Reconcile(request reconcile.Request) (reconcile.R
 		return reconcile.Result{}, err
 	}
 
+	if r.restrictedRemoteClusterAPIClientBuilder == nil {
+		applyAsHelper := r.impersonatingHelperBuilder(restConfig, fakeCluster, logger)
+		return r.syncClusterDeployment(cd, resourceHelper, resourceHelper, applyAsHelper, recobsrv, logger)
+	}
+
+	adminResourceHelper := resourceHelper
+	var applyAsHelper applyAsHelperBuilder
+	controllerKubeconfigAvailable := true
+	switch restConfig, err := r.restrictedRemoteClusterAPIClientBuilder(cd).RESTConfig(); {
+	case err == remoteclient.ErrControllerKubeconfigUnavailable:
+		logger.Info("syncsets cannot be applied until the controller kubeconfig is available")
+		controllerKubeconfigAvailable = false
+		resourceHelper = &unavailableResourceHelper{err: err}
+		applyAsHelper = unavailableApplyAsHelperBuilder(err)
+	case err != nil:
+		logger.WithError(err).Error("unable to get restricted REST config")
+		return reconcile.Result{}, err
+	default:
+		resourceHelper, err = r.resourceHelperBuilder(restConfig, fakeCluster, logger)
+		if err != nil {
+			log.WithError(err).Error("cannot create restricted helper")
+			return reconcile.Result{}, err
+		}
+		// SyncSets are applied as a ServiceAccount with the restricted kubeconfig, so that they cannot impersonate
+		// more than the restricted user is allowed to.
+		applyAsHelper = r.impersonatingHelperBuilder(restConfig, fakeCluster, logger)
+	}
+
+	result, err := r.syncClusterDeployment(cd, resourceHelper, adminResourceHelper, applyAsHelper, recobsrv, logger)
+	if err == nil && !controllerKubeconfigAvailable && result.RequeueAfter > controllerKubeconfigRecheckInterval {
+		result.RequeueAfter = controllerKubeconfigRecheckInterval
+	}
+	return result, err
+}
+
+// syncClusterDeployment applies the SyncSets and SelectorSyncSets of the ClusterDeployment to the cluster using the
+// resource helper, and records the results in the ClusterSync of the ClusterDeployment. The admin resource helper is
+// only used for the remote access syncset generated by Hive, and the helpers built by the apply as helper builder for
+// SyncSets applied as a ServiceAccount of the cluster.
+func (r *ReconcileClusterSync) syncClusterDeployment(
+	cd *hivev1.ClusterDeployment,
+	resourceHelper resource.Helper,
+	adminResourceHelper resource.Helper,
+	applyAsHelper applyAsHelperBuilder,
+	recobsrv *hivemetrics.ReconcileObserver,
+	logger log.FieldLogger,
+) (reconcile.Result, error) {
+	cdKey := types.NamespacedName{Namespace: cd.Namespace, Name: cd.Name}
 	needToCreateClusterSync := false
 	clusterSync := &hiveintv1alpha1.ClusterSync{}
-	switch err := r.Get(context.Background(), request.NamespacedName, clusterSync); {
+	switch err := r.Get(context.Background(), cdKey, clusterSync); {
 	case apierrors.IsNotFound(err):
 		logger.Info("creating ClusterSync as it does not exist")
 		clusterSync.Namespace = cd.Namespace
@@ -425,7 +587,7 @@ func (r *ReconcileClusterSync) Reconcile(request reconcile.Request) (reconcile.R
 
 	needToCreateLease := false
 	lease := &hiveintv1alpha1.ClusterSyncLease{}
-	switch err := r.Get(context.Background(), request.NamespacedName, lease); {
+	switch err := r.Get(context.Background(), cdKey, lease); {
 	case apierrors.IsNotFound(err):
 		logger.Info("Lease for ClusterSync does not exist; will need to create")
 		needToCreateLease = true
@@ -444,6 +606,30 @@ func (r *ReconcileClusterSync) Reconcile(request reconcile.Request) (reconcile.R
 	if err != nil {
 		return reconcile.Result{}, err
 	}
+	selectorSyncSets, selectorSyncSetStatuses, excludedSelectorSyncSetStatuses := excludeSelectorSyncSets(
+		cd,
+		selectorSyncSets,
+		clusterSync.Status.SelectorSyncSets,
+		logger,
+	)
+
+	// Only the emergency syncsets are applied to a frozen cluster. The statuses of the other syncsets are kept until
+	// the freeze ends.
+	freeze, err := controllerutils.ActiveChangeFreeze(r.Client, cd, time.Now(), logger)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	syncSetStatuses := clusterSync.Status.SyncSets
+	var frozenSyncSetStatuses, frozenSelectorSyncSetStatuses []hiveintv1alpha1.SyncStatus
+	if freeze != nil {
+		logger.WithField("changeFreeze", freeze.Name).Info("cluster is frozen, only applying emergency syncsets")
+		syncSets, syncSetStatuses, frozenSyncSetStatuses = freezeSyncSets(syncSets, syncSetStatuses, logger)
+		selectorSyncSets, selectorSyncSetStatuses, frozenSelectorSyncSetStatuses = freezeSyncSets(
+			selectorSyncSets,
+			selectorSyncSetStatuses,
+			logger,
+		)
+	}
 
 	needToDoFullReapply := needToCreateClusterSync || r.timeUntilFullReapply(lease) <= 0
 	if needToDoFullReapply {
@@ -456,32 +642,40 @@ func (r *ReconcileClusterSync) Reconcile(request reconcile.Request) (reconcile.R
 		cd,
 		"SyncSet",
 		syncSets,
-		clusterSync.Status.SyncSets,
+		syncSetStatuses,
 		needToDoFullReapply,
 		false, // no need to report SelectorSyncSet metrics if we're reconciling non-selector SyncSets
 		resourceHelper,
+		adminResourceHelper,
+		applyAsHelper,
 		logger,
 	)
-	clusterSync.Status.SyncSets = syncStatusesForSyncSets
+	clusterSync.Status.SyncSets = append(syncStatusesForSyncSets, frozenSyncSetStatuses...)
 
 	// Apply SelectorSyncSets
 	syncStatusesForSelectorSyncSets, selectorSyncSetsNeedRequeue := r.applySyncSets(
 		cd,
 		"SelectorSyncSet",
 		selectorSyncSets,
-		clusterSync.Status.SelectorSyncSets,
+		selectorSyncSetStatuses,
 		needToDoFullReapply,
 		clusterSync.Status.FirstSuccessTime == nil, // only report SelectorSyncSet metrics if we haven't reached first success
 		resourceHelper,
+		adminResourceHelper,
+		applyAsHelper,
 		logger,
 	)
-	clusterSync.Status.SelectorSyncSets = syncStatusesForSelectorSyncSets
+	// The statuses of the excluded SelectorSyncSets are kept until they are no longer excluded.
+	clusterSync.Status.SelectorSyncSets = append(
+		append(syncStatusesForSelectorSyncSets, frozenSelectorSyncSetStatuses...),
+		excludedSelectorSyncSetStatuses...,
+	)
 
 	setFailedCondition(clusterSync)
 
-	// Set clusterSync.Status.FirstSyncSetsSuccessTime
-	syncStatuses := append(syncStatusesForSyncSets, syncStatusesForSelectorSyncSets...)
-	if clusterSync.Status.FirstSuccessTime == nil {
+	// Set clusterSync.Status.FirstSyncSetsSuccessTime, which is not reached while syncsets are held back by a freeze
+	syncStatuses := append(clusterSync.Status.SyncSets, clusterSync.Status.SelectorSyncSets...)
+	if clusterSync.Status.FirstSuccessTime == nil && freeze == nil {
 		r.setFirstSuccessTime(syncStatuses, cd, clusterSync, logger)
 	}
 
@@ -518,6 +712,12 @@ func (r *ReconcileClusterSync) Reconcile(request reconcile.Request) (reconcile.R
 	}
 
 	result := reconcile.Result{Requeue: true, RequeueAfter: r.timeUntilFullReapply(lease)}
+	if freeze != nil {
+		// Apply the held syncsets once the freeze ends.
+		if untilEnd := time.Until(freeze.Spec.End.Time); untilEnd < result.RequeueAfter {
+			result.RequeueAfter = untilEnd
+		}
+	}
 	if syncSetsNeedRequeue || selectorSyncSetsNeedRequeue {
 		result.RequeueAfter = 0
 	}
@@ -532,6 +732,8 @@ func (r *ReconcileClusterSync) applySyncSets(
 	needToDoFullReapply bool,
 	reportSelectorSyncSetMetrics bool,
 	resourceHelper resource.Helper,
+	adminResourceHelper resource.Helper,
+	applyAsHelper applyAsHelperBuilder,
 	logger log.FieldLogger,
 ) (newSyncStatuses []hiveintv1alpha1.SyncStatus, requeue bool) {
 	// Sort the syncsets to a consistent ordering. This prevents thrashing in the ClusterSync status due to the order
@@ -551,6 +753,8 @@ func (r *ReconcileClusterSync) applySyncSets(
 			syncStatuses = syncStatuses[:last]
 		}
 
+		refsDigest, refsDigestErr := r.resourceRefsDigest(syncSet)
+
 		// Determine if the syncset needs to be applied
 		switch {
 		case needToDoFullReapply:
@@ -561,17 +765,27 @@ func (r *ReconcileClusterSync) applySyncSets(
 			logger.Debug("applying syncset because the last attempt to apply failed")
 		case oldSyncStatus.ObservedGeneration != syncSet.AsMetaObject().GetGeneration():
 			logger.Debug("applying syncset because the syncset generation has changed")
+		case refsDigestErr != nil || oldSyncStatus.ResourceRefsDigest != refsDigest:
+			logger.Debug("applying syncset because the configmaps of its resource refs have changed")
 		default:
 			logger.Debug("skipping apply of syncset since it is up-to-date and it is not time to do a full re-apply")
 			newSyncStatuses = append(newSyncStatuses, oldSyncStatus)
 			continue
 		}
 
+		syncSetResourceHelper := resourceHelper
+		if r.isRemoteAccessSyncSet(cd, syncSet) {
+			syncSetResourceHelper = adminResourceHelper
+		} else if applyAs := syncSetApplyAs(syncSet); applyAs != nil {
+			syncSetResourceHelper = applyAsResourceHelper(applyAs, applyAsHelper, logger)
+		}
+
 		// Apply the syncset
-		resourcesApplied, resourcesInSyncSet, syncSetNeedsRequeue, err := r.applySyncSet(syncSet, resourceHelper, logger)
+		resourcesApplied, resourcesInSyncSet, syncSetNeedsRequeue, err := r.applySyncSet(syncSet, syncSetResourceHelper, logger)
 		newSyncStatus := hiveintv1alpha1.SyncStatus{
 			Name:               syncSet.AsMetaObject().GetName(),
 			ObservedGeneration: syncSet.AsMetaObject().GetGeneration(),
+			ResourceRefsDigest: refsDigest,
 			Result:             hiveintv1alpha1.SuccessSyncSetResult,
 		}
 		if syncSet.GetSpec().ResourceApplyMode == hivev1.SyncResourceApplyMode {
@@ -585,6 +799,10 @@ func (r *ReconcileClusterSync) applySyncSets(
 			newSyncStatus.Result = hiveintv1alpha1.FailureSyncSetResult
 			newSyncStatus.FailureMessage = err.Error()
 		}
+		if _, ok := err.(*resourceRefsError); ok {
+			// The objects held in the resource refs are unknown, so keep all of the objects previously synced.
+			resourcesInSyncSet = append(resourcesInSyncSet, oldSyncStatus.ResourcesToDelete...)
+		}
 		if syncSetNeedsRequeue {
 			requeue = true
 		}
@@ -596,7 +814,7 @@ func (r *ReconcileClusterSync) applySyncSets(
 				func(r hiveintv1alpha1.SyncResourceReference) bool {
 					return !containsResource(resourcesInSyncSet, r)
 				},
-				resourceHelper,
+				syncSetResourceHelper,
 				logger,
 			)
 			if err != nil {
@@ -706,8 +924,16 @@ func (r *ReconcileClusterSync) applySyncSet(
 	returnErr error,
 ) {
 	resources, referencesToResources, decodeErr := decodeResources(syncSet, logger)
+	refResources, referencesToRefResources, refsErr := r.decodeResourceRefs(syncSet, logger)
+	resources = append(resources, refResources...)
+	referencesToResources = append(referencesToResources, referencesToRefResources...)
 	referencesToSecrets := referencesToSecrets(syncSet)
 	resourcesInSyncSet = append(referencesToResources, referencesToSecrets...)
+	if refsErr != nil {
+		returnErr = refsErr
+		requeue = true
+		return
+	}
 	if decodeErr != nil {
 		returnErr = decodeErr
 		return
@@ -724,8 +950,11 @@ func (r *ReconcileClusterSync) applySyncSet(
 		applyFnMetricsLabel = labelCreateOnly
 	}
 
+	stamp := r.audit.stamper(syncSet)
+
 	// Apply Resources
 	for i, resource := range resources {
+		stamp(resource)
 		returnErr, requeue = r.applyResource(i, resource, referencesToResources[i], applyFn, applyFnMetricsLabel, logger)
 		if returnErr != nil {
 			resourcesApplied = referencesToResources[:i]
@@ -736,7 +965,7 @@ func (r *ReconcileClusterSync) applySyncSet(
 
 	// Apply Secrets
 	for i, secretMapping := range syncSet.GetSpec().Secrets {
-		returnErr, requeue = r.applySecret(syncSet, i, secretMapping, referencesToSecrets[i], applyFn, applyFnMetricsLabel, logger)
+		returnErr, requeue = r.applySecret(syncSet, i, secretMapping, referencesToSecrets[i], applyFn, applyFnMetricsLabel, stamp, logger)
 		if returnErr != nil {
 			resourcesApplied = append(resourcesApplied, referencesToSecrets[:i]...)
 			return
@@ -819,6 +1048,7 @@ func (r *ReconcileClusterSync) applySecret(
 	reference hiveintv1alpha1.SyncResourceReference,
 	applyFn func(obj []byte) (resource.ApplyResult, error),
 	applyFnMetricsLabel string,
+	stamp func(obj metav1.Object),
 	logger log.FieldLogger,
 ) (returnErr error, requeue bool) {
 	logger = logger.WithField("secretIndex", secretIndex).
@@ -853,6 +1083,7 @@ func (r *ReconcileClusterSync) applySecret(
 		Annotations: secret.Annotations,
 		Labels:      secret.Labels,
 	}
+	stamp(secret)
 	logger.Debug("applying secret")
 	if err := applyToTargetCluster(secret, applyFnMetricsLabel, applyFn, logger); err != nil {
 		return errors.Wrapf(err, "failed to apply secret %d", secretIndex), true
@@ -1001,11 +1232,15 @@ func setFailedCondition(clusterSync *hiveintv1alpha1.ClusterSync) {
 	status := corev1.ConditionFalse
 	reason := "Success"
 	message := "All SyncSets and SelectorSyncSets have been applied to the cluster"
+	var category hivev1.FailureCategory
 	failingSyncSets := getFailingSyncSets(clusterSync.Status.SyncSets)
 	failingSelectorSyncSets := getFailingSyncSets(clusterSync.Status.SelectorSyncSets)
 	if len(failingSyncSets)+len(failingSelectorSyncSets) != 0 {
 		status = corev1.ConditionTrue
 		reason = "Failure"
+		// SyncSets fail to apply when their resources are invalid or conflict with the cluster, which their owners
+		// need to fix.
+		category = hivev1.FailureCategoryUserConfig
 		var failureNames []string
 		if len(failingSyncSets) != 0 {
 			failureNames = append(failureNames, namesForFailureMessage("SyncSet", failingSyncSets))
@@ -1032,6 +1267,7 @@ func setFailedCondition(clusterSync *hiveintv1alpha1.ClusterSync) {
 		Status:             status,
 		Reason:             reason,
 		Message:            message,
+		Category:           category,
 		LastProbeTime:      metav1.Now(),
 		LastTransitionTime: metav1.Now(),
 	}}
@@ -1114,8 +1350,12 @@ func orderResources(a, b hiveintv1alpha1.SyncResourceReference) bool {
 }
 
 func (r *ReconcileClusterSync) timeUntilFullReapply(lease *hiveintv1alpha1.ClusterSyncLease) time.Duration {
-	timeUntilNext := r.reapplyInterval - time.Since(lease.Spec.RenewTime.Time) +
-		time.Duration(reapplyIntervalJitter*rand.Float64()*r.reapplyInterval.Seconds())*time.Second
+	interval := controllerutils.JitteredInterval(
+		r.reapplyInterval,
+		r.reapplyJitterPercent,
+		types.NamespacedName{Namespace: lease.Namespace, Name: lease.Name},
+	)
+	timeUntilNext := interval - time.Since(lease.Spec.RenewTime.Time)
 	if timeUntilNext < 0 {
 		return 0
 	}
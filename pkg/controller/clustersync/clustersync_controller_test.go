@@ -30,6 +30,7 @@ import (
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	hiveintv1alpha1 "github.com/openshift/hive/apis/hiveinternal/v1alpha1"
 	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 	"github.com/openshift/hive/pkg/remoteclient"
 	remoteclientmock "github.com/openshift/hive/pkg/remoteclient/mock"
 	"github.com/openshift/hive/pkg/resource"
@@ -87,10 +88,11 @@ func newReconcileTest(t *testing.T, mockCtrl *gomock.Controller, scheme *runtime
 	mockRemoteClientBuilder := remoteclientmock.NewMockBuilder(mockCtrl)
 
 	r := &ReconcileClusterSync{
-		ordinalID:       0,
-		Client:          c,
-		logger:          logger,
-		reapplyInterval: defaultReapplyInterval,
+		ordinalID:            0,
+		Client:               c,
+		logger:               logger,
+		reapplyInterval:      defaultReapplyInterval,
+		reapplyJitterPercent: controllerutils.DefaultPeriodicSyncJitterPercent,
 		resourceHelperBuilder: func(rc *rest.Config, fakeCluster bool, _ log.FieldLogger) (resource.Helper, error) {
 			return mockResourceHelper, nil
 		},
@@ -151,6 +153,7 @@ func (rt *reconcileTest) run(t *testing.T) {
 		assert.Zero(t, result.RequeueAfter, "unexpected requeue after")
 	} else {
 		var minRequeueAfter, maxRequeueAfter float64
+		reapplyIntervalJitter := float64(controllerutils.DefaultPeriodicSyncJitterPercent) / 100
 		if rt.expectUnchangedLeaseRenewTime {
 			minRequeueAfter = (defaultReapplyInterval - timeSinceOrigLeaseRenewTime).Seconds()
 			maxRequeueAfter = minRequeueAfter + defaultReapplyInterval.Seconds()*reapplyIntervalJitter + endTime.Sub(startTime).Seconds()
@@ -2554,6 +2557,12 @@ func withObservedGeneration(observedGeneration int64) syncStatusOption {
 	}
 }
 
+func withResourceRefsDigest(digest string) syncStatusOption {
+	return func(syncStatus *hiveintv1alpha1.SyncStatus) {
+		syncStatus.ResourceRefsDigest = digest
+	}
+}
+
 func withFailureResult(message string) syncStatusOption {
 	return func(syncStatus *hiveintv1alpha1.SyncStatus) {
 		syncStatus.Result = hiveintv1alpha1.FailureSyncSetResult
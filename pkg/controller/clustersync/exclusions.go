@@ -0,0 +1,42 @@
+package clustersync
+
+import (
+	log "github.com/sirupsen/logrus"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hiveintv1alpha1 "github.com/openshift/hive/apis/hiveinternal/v1alpha1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+// excludeSelectorSyncSets drops the SelectorSyncSets excluded from the cluster. It also sets apart the sync statuses of
+// the excluded SelectorSyncSets, which are kept as they are so that the resources they track are neither reapplied
+// nor deleted, from the sync statuses of the SelectorSyncSets to apply.
+func excludeSelectorSyncSets(
+	cd *hivev1.ClusterDeployment,
+	selectorSyncSets []CommonSyncSet,
+	syncStatuses []hiveintv1alpha1.SyncStatus,
+	logger log.FieldLogger,
+) (
+	includedSelectorSyncSets []CommonSyncSet,
+	includedSyncStatuses []hiveintv1alpha1.SyncStatus,
+	excludedSyncStatuses []hiveintv1alpha1.SyncStatus,
+) {
+	if len(cd.Spec.SelectorSyncSetExclusions) == 0 {
+		return selectorSyncSets, syncStatuses, nil
+	}
+	for _, sss := range selectorSyncSets {
+		if controllerutils.IsSelectorSyncSetExcluded(cd, sss.AsMetaObject().GetName()) {
+			logger.WithField("SelectorSyncSet", sss.AsMetaObject().GetName()).Info("SelectorSyncSet is excluded from the cluster")
+			continue
+		}
+		includedSelectorSyncSets = append(includedSelectorSyncSets, sss)
+	}
+	for _, status := range syncStatuses {
+		if controllerutils.IsSelectorSyncSetExcluded(cd, status.Name) {
+			excludedSyncStatuses = append(excludedSyncStatuses, status)
+			continue
+		}
+		includedSyncStatuses = append(includedSyncStatuses, status)
+	}
+	return
+}
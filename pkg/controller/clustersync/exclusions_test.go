@@ -0,0 +1,118 @@
+package clustersync
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+
+	"k8s.io/apimachinery/pkg/runtime"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hiveintv1alpha1 "github.com/openshift/hive/apis/hiveinternal/v1alpha1"
+	"github.com/openshift/hive/pkg/resource"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+	testcs "github.com/openshift/hive/pkg/test/clustersync"
+	testselectorsyncset "github.com/openshift/hive/pkg/test/selectorsyncset"
+	teststatefulset "github.com/openshift/hive/pkg/test/statefulset"
+)
+
+func TestReconcileClusterSync_SelectorSyncSetExclusions(t *testing.T) {
+	cases := []struct {
+		name               string
+		exclusions         []string
+		frozenDeleted      bool
+		expectFrozenApply  bool
+		expectOtherApply   bool
+		expectFrozenDelete bool
+	}{
+		{
+			name:              "no exclusions",
+			expectFrozenApply: true,
+			expectOtherApply:  true,
+		},
+		{
+			name:             "excluded by name",
+			exclusions:       []string{"frozen-selectorsyncset"},
+			expectOtherApply: true,
+		},
+		{
+			name:       "all excluded",
+			exclusions: []string{"*"},
+		},
+		{
+			name:               "deleted selectorsyncset not excluded",
+			frozenDeleted:      true,
+			expectOtherApply:   true,
+			expectFrozenDelete: true,
+		},
+		{
+			name:             "deleted selectorsyncset excluded",
+			exclusions:       []string{"frozen-selectorsyncset"},
+			frozenDeleted:    true,
+			expectOtherApply: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scheme := newScheme()
+			frozenResource := testConfigMap("dest-namespace", "frozen-resource")
+			otherResource := testConfigMap("dest-namespace", "other-resource")
+			existingFrozenStatus := buildSyncStatus("frozen-selectorsyncset",
+				withResourcesToDelete(testConfigMapRef("dest-namespace", "frozen-resource")),
+				withTransitionInThePast(),
+				withFirstSuccessTimeInThePast(),
+			)
+			existing := []runtime.Object{
+				cdBuilder(scheme).Build(
+					testcd.WithLabel("test-label-key", "test-label-value"),
+					testcd.WithSelectorSyncSetExclusions(tc.exclusions...),
+				),
+				clusterSyncBuilder(scheme).Build(testcs.WithSelectorSyncSetStatus(existingFrozenStatus)),
+				buildSyncLease(time.Now().Add(-1 * time.Hour)),
+				teststatefulset.FullBuilder("hive", stsName, scheme).Build(
+					teststatefulset.WithCurrentReplicas(3),
+					teststatefulset.WithReplicas(3),
+				),
+				testselectorsyncset.FullBuilder("other-selectorsyncset", scheme).Build(
+					testselectorsyncset.WithLabelSelector("test-label-key", "test-label-value"),
+					testselectorsyncset.WithGeneration(1),
+					testselectorsyncset.WithResources(otherResource),
+				),
+			}
+			if !tc.frozenDeleted {
+				existing = append(existing, testselectorsyncset.FullBuilder("frozen-selectorsyncset", scheme).Build(
+					testselectorsyncset.WithLabelSelector("test-label-key", "test-label-value"),
+					testselectorsyncset.WithGeneration(2),
+					testselectorsyncset.WithApplyMode(hivev1.SyncResourceApplyMode),
+					testselectorsyncset.WithResources(frozenResource),
+				))
+			}
+			rt := newReconcileTest(t, mockCtrl, scheme, existing...)
+			var expectedStatuses []hiveintv1alpha1.SyncStatus
+			if tc.expectFrozenApply {
+				rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(frozenResource)).Return(resource.CreatedApplyResult, nil)
+				expectedStatuses = append(expectedStatuses, buildSyncStatus("frozen-selectorsyncset",
+					withObservedGeneration(2),
+					withResourcesToDelete(testConfigMapRef("dest-namespace", "frozen-resource")),
+					withFirstSuccessTimeInThePast(),
+				))
+			}
+			if tc.expectOtherApply {
+				rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(otherResource)).Return(resource.CreatedApplyResult, nil)
+				expectedStatuses = append(expectedStatuses, buildSyncStatus("other-selectorsyncset"))
+			}
+			if tc.expectFrozenDelete {
+				rt.mockResourceHelper.EXPECT().Delete("v1", "ConfigMap", "dest-namespace", "frozen-resource").Return(nil)
+			}
+			if !tc.expectFrozenApply && !tc.expectFrozenDelete {
+				expectedStatuses = append(expectedStatuses, existingFrozenStatus)
+			}
+			rt.expectedSelectorSyncSetStatuses = expectedStatuses
+			rt.expectUnchangedLeaseRenewTime = true
+			rt.run(t)
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package clustersync
+
+import (
+	log "github.com/sirupsen/logrus"
+
+	hiveintv1alpha1 "github.com/openshift/hive/apis/hiveinternal/v1alpha1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+// freezeSyncSets drops the syncsets which are not applied to a frozen cluster, which are all but the emergency
+// syncsets. It also sets apart the sync statuses of the frozen syncsets, which are kept as they are until the freeze
+// ends so that the resources they track are neither reapplied nor deleted, from the sync statuses of the emergency
+// syncsets to apply.
+func freezeSyncSets(
+	syncSets []CommonSyncSet,
+	syncStatuses []hiveintv1alpha1.SyncStatus,
+	logger log.FieldLogger,
+) (
+	emergencySyncSets []CommonSyncSet,
+	emergencySyncStatuses []hiveintv1alpha1.SyncStatus,
+	frozenSyncStatuses []hiveintv1alpha1.SyncStatus,
+) {
+	emergency := map[string]bool{}
+	for _, syncSet := range syncSets {
+		meta := syncSet.AsMetaObject()
+		if !controllerutils.IsEmergencyChange(meta) {
+			continue
+		}
+		logger.WithField("syncSet", meta.GetName()).Info("applying emergency syncset to frozen cluster")
+		emergency[meta.GetName()] = true
+		emergencySyncSets = append(emergencySyncSets, syncSet)
+	}
+	for _, status := range syncStatuses {
+		if emergency[status.Name] {
+			emergencySyncStatuses = append(emergencySyncStatuses, status)
+			continue
+		}
+		frozenSyncStatuses = append(frozenSyncStatuses, status)
+	}
+	return
+}
@@ -0,0 +1,149 @@
+package clustersync
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hiveintv1alpha1 "github.com/openshift/hive/apis/hiveinternal/v1alpha1"
+	"github.com/openshift/hive/pkg/resource"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+	testcs "github.com/openshift/hive/pkg/test/clustersync"
+	testgeneric "github.com/openshift/hive/pkg/test/generic"
+	testselectorsyncset "github.com/openshift/hive/pkg/test/selectorsyncset"
+	teststatefulset "github.com/openshift/hive/pkg/test/statefulset"
+)
+
+func TestReconcileClusterSync_ChangeFreeze(t *testing.T) {
+	cases := []struct {
+		name               string
+		freezeStart        time.Duration
+		freezeEnd          time.Duration
+		freezeLabelValue   string
+		noFreeze           bool
+		frozenDeleted      bool
+		expectFrozenApply  bool
+		expectFrozenDelete bool
+	}{
+		{
+			name:              "no freeze",
+			noFreeze:          true,
+			expectFrozenApply: true,
+		},
+		{
+			name:        "active freeze",
+			freezeStart: -time.Hour,
+			freezeEnd:   3 * time.Hour,
+		},
+		{
+			name:          "active freeze holds deleted selectorsyncset",
+			freezeStart:   -time.Hour,
+			freezeEnd:     3 * time.Hour,
+			frozenDeleted: true,
+		},
+		{
+			name:              "active freeze not selecting cluster",
+			freezeStart:       -time.Hour,
+			freezeEnd:         3 * time.Hour,
+			freezeLabelValue:  "other-label-value",
+			expectFrozenApply: true,
+		},
+		{
+			name:              "past freeze",
+			freezeStart:       -3 * time.Hour,
+			freezeEnd:         -time.Hour,
+			expectFrozenApply: true,
+		},
+		{
+			name:              "future freeze",
+			freezeStart:       time.Hour,
+			freezeEnd:         3 * time.Hour,
+			expectFrozenApply: true,
+		},
+		{
+			name:               "past freeze deletes deleted selectorsyncset",
+			freezeStart:        -3 * time.Hour,
+			freezeEnd:          -time.Hour,
+			frozenDeleted:      true,
+			expectFrozenDelete: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scheme := newScheme()
+			frozenResource := testConfigMap("dest-namespace", "frozen-resource")
+			emergencyResource := testConfigMap("dest-namespace", "emergency-resource")
+			existingFrozenStatus := buildSyncStatus("frozen-selectorsyncset",
+				withResourcesToDelete(testConfigMapRef("dest-namespace", "frozen-resource")),
+				withTransitionInThePast(),
+				withFirstSuccessTimeInThePast(),
+			)
+			existing := []runtime.Object{
+				cdBuilder(scheme).Build(testcd.WithLabel("test-label-key", "test-label-value")),
+				clusterSyncBuilder(scheme).Build(testcs.WithSelectorSyncSetStatus(existingFrozenStatus)),
+				buildSyncLease(time.Now().Add(-1 * time.Hour)),
+				teststatefulset.FullBuilder("hive", stsName, scheme).Build(
+					teststatefulset.WithCurrentReplicas(3),
+					teststatefulset.WithReplicas(3),
+				),
+				testselectorsyncset.FullBuilder("emergency-selectorsyncset", scheme).GenericOptions(
+					testgeneric.WithLabel(hivev1.ChangeFreezeEmergencyLabel, "true"),
+				).Build(
+					testselectorsyncset.WithLabelSelector("test-label-key", "test-label-value"),
+					testselectorsyncset.WithGeneration(1),
+					testselectorsyncset.WithResources(emergencyResource),
+				),
+			}
+			if !tc.noFreeze {
+				labelValue := tc.freezeLabelValue
+				if labelValue == "" {
+					labelValue = "test-label-value"
+				}
+				existing = append(existing, &hivev1.ChangeFreeze{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-freeze"},
+					Spec: hivev1.ChangeFreezeSpec{
+						ClusterDeploymentSelector: metav1.LabelSelector{
+							MatchLabels: map[string]string{"test-label-key": labelValue},
+						},
+						Start: metav1.NewTime(time.Now().Add(tc.freezeStart)),
+						End:   metav1.NewTime(time.Now().Add(tc.freezeEnd)),
+					},
+				})
+			}
+			if !tc.frozenDeleted {
+				existing = append(existing, testselectorsyncset.FullBuilder("frozen-selectorsyncset", scheme).Build(
+					testselectorsyncset.WithLabelSelector("test-label-key", "test-label-value"),
+					testselectorsyncset.WithGeneration(2),
+					testselectorsyncset.WithApplyMode(hivev1.SyncResourceApplyMode),
+					testselectorsyncset.WithResources(frozenResource),
+				))
+			}
+			rt := newReconcileTest(t, mockCtrl, scheme, existing...)
+			rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(emergencyResource)).Return(resource.CreatedApplyResult, nil)
+			expectedStatuses := []hiveintv1alpha1.SyncStatus{buildSyncStatus("emergency-selectorsyncset")}
+			switch {
+			case tc.expectFrozenApply:
+				rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(frozenResource)).Return(resource.CreatedApplyResult, nil)
+				expectedStatuses = append(expectedStatuses, buildSyncStatus("frozen-selectorsyncset",
+					withObservedGeneration(2),
+					withResourcesToDelete(testConfigMapRef("dest-namespace", "frozen-resource")),
+					withFirstSuccessTimeInThePast(),
+				))
+			case tc.expectFrozenDelete:
+				rt.mockResourceHelper.EXPECT().Delete("v1", "ConfigMap", "dest-namespace", "frozen-resource").Return(nil)
+			default:
+				expectedStatuses = append(expectedStatuses, existingFrozenStatus)
+			}
+			rt.expectedSelectorSyncSetStatuses = expectedStatuses
+			rt.expectUnchangedLeaseRenewTime = true
+			rt.run(t)
+		})
+	}
+}
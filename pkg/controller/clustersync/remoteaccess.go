@@ -0,0 +1,100 @@
+package clustersync
+
+import (
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/json"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/controller/remoteaccess"
+	"github.com/openshift/hive/pkg/resource"
+)
+
+// isRemoteAccessSyncSet returns whether the syncset is the remote access syncset that Hive generates for the
+// ClusterDeployment in the restricted remote access RBAC mode. Only that syncset is applied with the admin kubeconfig,
+// so the contents of the syncset must match what Hive generates rather than just its name.
+func (r *ReconcileClusterSync) isRemoteAccessSyncSet(cd *hivev1.ClusterDeployment, syncSet CommonSyncSet) bool {
+	if r.restrictedRemoteClusterAPIClientBuilder == nil {
+		return false
+	}
+	ss, ok := syncSet.(*SyncSetAsCommon)
+	if !ok || ss.Name != remoteaccess.GenerateRemoteAccessSyncSetName(cd.Name) {
+		return false
+	}
+	if len(ss.Spec.Patches) > 0 || len(ss.Spec.Secrets) > 0 {
+		return false
+	}
+	expected, err := remoteaccess.GenerateRemoteAccessSyncSet(cd, true, r.restrictedSyncSetAPIGroups)
+	if err != nil {
+		r.logger.WithError(err).Error("could not generate remote access syncset")
+		return false
+	}
+	if ss.Spec.ResourceApplyMode != expected.Spec.ResourceApplyMode ||
+		len(ss.Spec.Resources) != len(expected.Spec.Resources) {
+		return false
+	}
+	for i := range expected.Spec.Resources {
+		if !sameResource(ss.Spec.Resources[i], expected.Spec.Resources[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// sameResource returns whether the two raw resources hold the same content, regardless of field ordering.
+func sameResource(a, b runtime.RawExtension) bool {
+	var aContent, bContent interface{}
+	if err := json.Unmarshal(a.Raw, &aContent); err != nil {
+		return false
+	}
+	if err := json.Unmarshal(b.Raw, &bContent); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(aContent, bContent)
+}
+
+// unavailableResourceHelper is a resource helper that fails every operation, used in place of the restricted resource
+// helper while the controller kubeconfig is not available.
+type unavailableResourceHelper struct {
+	err error
+}
+
+var _ resource.Helper = (*unavailableResourceHelper)(nil)
+
+func (h *unavailableResourceHelper) Apply([]byte) (resource.ApplyResult, error) {
+	return "", h.err
+}
+
+func (h *unavailableResourceHelper) ApplyRuntimeObject(runtime.Object, *runtime.Scheme) (resource.ApplyResult, error) {
+	return "", h.err
+}
+
+func (h *unavailableResourceHelper) CreateOrUpdate([]byte) (resource.ApplyResult, error) {
+	return "", h.err
+}
+
+func (h *unavailableResourceHelper) CreateOrUpdateRuntimeObject(runtime.Object, *runtime.Scheme) (resource.ApplyResult, error) {
+	return "", h.err
+}
+
+func (h *unavailableResourceHelper) Create([]byte) (resource.ApplyResult, error) {
+	return "", h.err
+}
+
+func (h *unavailableResourceHelper) CreateRuntimeObject(runtime.Object, *runtime.Scheme) (resource.ApplyResult, error) {
+	return "", h.err
+}
+
+func (h *unavailableResourceHelper) Info([]byte) (*resource.Info, error) {
+	return nil, h.err
+}
+
+func (h *unavailableResourceHelper) Patch(types.NamespacedName, string, string, []byte, string) error {
+	return h.err
+}
+
+func (h *unavailableResourceHelper) Delete(string, string, string, string) error {
+	return h.err
+}
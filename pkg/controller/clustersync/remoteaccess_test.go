@@ -0,0 +1,96 @@
+package clustersync
+
+import (
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apimachinery/pkg/runtime"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/controller/remoteaccess"
+	"github.com/openshift/hive/pkg/remoteclient"
+)
+
+func TestIsRemoteAccessSyncSet(t *testing.T) {
+	cd := &hivev1.ClusterDeployment{}
+	cd.Namespace = testNamespace
+	cd.Name = testCDName
+	syncSetAPIGroups := []string{"config.openshift.io"}
+
+	cases := []struct {
+		name         string
+		unrestricted bool
+		mutate       func(*hivev1.SyncSet)
+		expected     bool
+	}{
+		{
+			name:     "generated",
+			expected: true,
+		},
+		{
+			name:         "not restricted",
+			unrestricted: true,
+		},
+		{
+			name:   "different name",
+			mutate: func(ss *hivev1.SyncSet) { ss.Name = "other" },
+		},
+		{
+			name: "forged resource",
+			mutate: func(ss *hivev1.SyncSet) {
+				ss.Spec.Resources[2] = runtime.RawExtension{Raw: []byte(`{"apiVersion":"rbac.authorization.k8s.io/v1","kind":"ClusterRole","metadata":{"name":"hive-controller"},"rules":[{"apiGroups":["*"],"resources":["*"],"verbs":["*"]}]}`)}
+			},
+		},
+		{
+			name: "additional resource",
+			mutate: func(ss *hivev1.SyncSet) {
+				ss.Spec.Resources = append(ss.Spec.Resources, ss.Spec.Resources[0])
+			},
+		},
+		{
+			name: "secret mapping",
+			mutate: func(ss *hivev1.SyncSet) {
+				ss.Spec.Secrets = []hivev1.SecretMapping{{
+					SourceRef: hivev1.SecretReference{Namespace: testNamespace, Name: "src"},
+					TargetRef: hivev1.SecretReference{Namespace: "kube-system", Name: "dest"},
+				}}
+			},
+		},
+		{
+			name:   "upsert apply mode",
+			mutate: func(ss *hivev1.SyncSet) { ss.Spec.ResourceApplyMode = hivev1.UpsertResourceApplyMode },
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := &ReconcileClusterSync{
+				logger:                     log.New(),
+				restrictedSyncSetAPIGroups: syncSetAPIGroups,
+			}
+			if !tc.unrestricted {
+				r.restrictedRemoteClusterAPIClientBuilder = func(*hivev1.ClusterDeployment) remoteclient.Builder { return nil }
+			}
+			ss, err := remoteaccess.GenerateRemoteAccessSyncSet(cd, true, syncSetAPIGroups)
+			require.NoError(t, err, "unexpected error generating remote access syncset")
+			if tc.mutate != nil {
+				tc.mutate(ss)
+			}
+			assert.Equal(t, tc.expected, r.isRemoteAccessSyncSet(cd, (*SyncSetAsCommon)(ss)), "unexpected result")
+		})
+	}
+}
+
+func TestIsRemoteAccessSyncSetSelectorSyncSet(t *testing.T) {
+	r := &ReconcileClusterSync{
+		logger:                                  log.New(),
+		restrictedRemoteClusterAPIClientBuilder: func(*hivev1.ClusterDeployment) remoteclient.Builder { return nil },
+	}
+	cd := &hivev1.ClusterDeployment{}
+	cd.Name = testCDName
+	sss := &hivev1.SelectorSyncSet{}
+	sss.Name = remoteaccess.GenerateRemoteAccessSyncSetName(testCDName)
+	assert.False(t, r.isRemoteAccessSyncSet(cd, (*SelectorSyncSetAsCommon)(sss)), "selectorsyncset must not be the remote access syncset")
+}
@@ -0,0 +1,187 @@
+package clustersync
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/yaml"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hiveintv1alpha1 "github.com/openshift/hive/apis/hiveinternal/v1alpha1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/ociartifact"
+)
+
+// resourceRefsError is an error loading the resource refs of a syncset. The objects of the resource refs are not
+// known when it occurs, so none of the objects previously synced by the syncset are deleted.
+type resourceRefsError struct {
+	error
+}
+
+func (e *resourceRefsError) Cause() error {
+	return e.error
+}
+
+// decodeResourceRefs loads and decodes the objects held in the ConfigMaps and OCI artifacts referenced by the syncset.
+func (r *ReconcileClusterSync) decodeResourceRefs(syncSet CommonSyncSet, logger log.FieldLogger) (
+	resources []*unstructured.Unstructured, references []hiveintv1alpha1.SyncResourceReference, returnErr error,
+) {
+	for i, ref := range syncSet.GetSpec().ResourceRefs {
+		logger := logger.WithField("resourceRefIndex", i)
+		var manifests [][]byte
+		var err error
+		switch {
+		case ref.ConfigMap != nil:
+			manifests, err = r.loadConfigMapResourceSource(syncSet, ref.ConfigMap)
+		case ref.OCIArtifact != nil:
+			manifests, err = r.loadOCIArtifactResourceSource(syncSet, ref.OCIArtifact)
+		default:
+			err = errors.New("neither configMap nor ociArtifact is set")
+		}
+		if err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not load resource ref")
+			return nil, nil, &resourceRefsError{errors.Wrapf(err, "failed to load resource ref %d", i)}
+		}
+		for j, manifest := range manifests {
+			u := &unstructured.Unstructured{}
+			if err := yaml.Unmarshal(manifest, u); err != nil {
+				logger.WithField("manifestIndex", j).WithError(err).Warn("error decoding unstructured object")
+				return nil, nil, &resourceRefsError{errors.Wrapf(err, "failed to decode manifest %d of resource ref %d", j, i)}
+			}
+			resources = append(resources, u)
+			references = append(references, hiveintv1alpha1.SyncResourceReference{
+				APIVersion: u.GetAPIVersion(),
+				Kind:       u.GetKind(),
+				Namespace:  u.GetNamespace(),
+				Name:       u.GetName(),
+			})
+		}
+	}
+	return
+}
+
+// resourceRefsDigest returns the digest of the data of the ConfigMaps referenced by the resource refs of the syncset,
+// or "" if it references no ConfigMap. The OCI artifacts are pinned by digest, so any change to them changes the
+// syncset itself.
+func (r *ReconcileClusterSync) resourceRefsDigest(syncSet CommonSyncSet) (string, error) {
+	hash := sha256.New()
+	found := false
+	for _, ref := range syncSet.GetSpec().ResourceRefs {
+		if ref.ConfigMap == nil {
+			continue
+		}
+		namespace, err := sourceNamespace(syncSet, ref.ConfigMap.Namespace)
+		if err != nil {
+			return "", errors.Wrapf(err, "invalid namespace for configmap %s", ref.ConfigMap.Name)
+		}
+		cm := &corev1.ConfigMap{}
+		if err := r.Get(context.Background(), types.NamespacedName{Namespace: namespace, Name: ref.ConfigMap.Name}, cm); err != nil {
+			return "", errors.Wrapf(err, "failed to read configmap %s/%s", namespace, ref.ConfigMap.Name)
+		}
+		found = true
+		keys := make([]string, 0, len(cm.Data))
+		for key := range cm.Data {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		fmt.Fprintf(hash, "%s/%s\x00", namespace, cm.Name)
+		for _, key := range keys {
+			fmt.Fprintf(hash, "%s\x00%s\x00", key, cm.Data[key])
+		}
+	}
+	if !found {
+		return "", nil
+	}
+	return "sha256:" + hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// referencesConfigMap returns whether the syncset holds manifests in the ConfigMap with the given namespace and name.
+func referencesConfigMap(syncSet CommonSyncSet, namespace, name string) bool {
+	for _, ref := range syncSet.GetSpec().ResourceRefs {
+		if ref.ConfigMap == nil || ref.ConfigMap.Name != name {
+			continue
+		}
+		if refNamespace, err := sourceNamespace(syncSet, ref.ConfigMap.Namespace); err == nil && refNamespace == namespace {
+			return true
+		}
+	}
+	return false
+}
+
+// loadConfigMapResourceSource returns the manifests held in the data of the ConfigMap, in the order of its keys,
+// after verifying them against the digest of the source.
+func (r *ReconcileClusterSync) loadConfigMapResourceSource(syncSet CommonSyncSet, source *hivev1.ConfigMapResourceSource) ([][]byte, error) {
+	namespace, err := sourceNamespace(syncSet, source.Namespace)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid namespace for configmap %s", source.Name)
+	}
+	cm := &corev1.ConfigMap{}
+	if err := r.Get(context.Background(), types.NamespacedName{Namespace: namespace, Name: source.Name}, cm); err != nil {
+		return nil, errors.Wrapf(err, "failed to read configmap %s/%s", namespace, source.Name)
+	}
+	keys := make([]string, 0, len(cm.Data))
+	for key := range cm.Data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	hash := sha256.New()
+	var manifests [][]byte
+	for _, key := range keys {
+		hash.Write([]byte(cm.Data[key]))
+		keyManifests, err := ociartifact.SplitManifests([]byte(cm.Data[key]))
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid manifests in key %s of configmap %s/%s", key, namespace, source.Name)
+		}
+		manifests = append(manifests, keyManifests...)
+	}
+	if source.Digest != "" {
+		if digest := "sha256:" + hex.EncodeToString(hash.Sum(nil)); digest != source.Digest {
+			return nil, fmt.Errorf("configmap %s/%s has digest %s instead of %s", namespace, source.Name, digest, source.Digest)
+		}
+	}
+	return manifests, nil
+}
+
+// loadOCIArtifactResourceSource returns the manifests held in the layers of the OCI artifact.
+func (r *ReconcileClusterSync) loadOCIArtifactResourceSource(syncSet CommonSyncSet, source *hivev1.OCIArtifactResourceSource) ([][]byte, error) {
+	var pullSecret []byte
+	if ref := source.PullSecretRef; ref != nil {
+		namespace, err := sourceNamespace(syncSet, ref.Namespace)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid namespace for pull secret %s", ref.Name)
+		}
+		secret := &corev1.Secret{}
+		if err := r.Get(context.Background(), types.NamespacedName{Namespace: namespace, Name: ref.Name}, secret); err != nil {
+			return nil, errors.Wrapf(err, "failed to read pull secret %s/%s", namespace, ref.Name)
+		}
+		pullSecret = secret.Data[corev1.DockerConfigJsonKey]
+		if len(pullSecret) == 0 {
+			return nil, fmt.Errorf("pull secret %s/%s has no %s key", namespace, ref.Name, corev1.DockerConfigJsonKey)
+		}
+	}
+	return r.fetchArtifact(source.Image, pullSecret)
+}
+
+// sourceNamespace returns the namespace of an object on the management cluster referenced by the syncset. The
+// namespace is required for SelectorSyncSets, and defaults to and must match the namespace of SyncSets.
+func sourceNamespace(syncSet CommonSyncSet, namespace string) (string, error) {
+	syncSetNamespace := syncSet.AsMetaObject().GetNamespace()
+	switch {
+	case syncSetNamespace == "" && namespace == "":
+		return "", errors.New("namespace must be specified")
+	case syncSetNamespace == "":
+		return namespace, nil
+	case namespace == "" || namespace == syncSetNamespace:
+		return syncSetNamespace, nil
+	default:
+		return "", errors.New("must be in same namespace as SyncSet")
+	}
+}
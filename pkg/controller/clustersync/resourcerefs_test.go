@@ -0,0 +1,278 @@
+package clustersync
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/json"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hiveintv1alpha1 "github.com/openshift/hive/apis/hiveinternal/v1alpha1"
+	"github.com/openshift/hive/pkg/resource"
+	testcs "github.com/openshift/hive/pkg/test/clustersync"
+	teststatefulset "github.com/openshift/hive/pkg/test/statefulset"
+	testsyncset "github.com/openshift/hive/pkg/test/syncset"
+)
+
+const testImage = "quay.io/example/manifests@sha256:aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+
+func TestReconcileClusterSync_ApplyResourceRefs(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	scheme := newScheme()
+	data := map[string]string{
+		"b.yaml": testManifests(t, testConfigMap("dest-namespace", "from-key-b")),
+		"a.yaml": "# comment only\n---\n" + testManifests(t, testConfigMap("dest-namespace", "from-key-a-1"), testConfigMap("dest-namespace", "from-key-a-2")),
+	}
+	syncSet := testsyncset.FullBuilder(testNamespace, "test-syncset", scheme).Build(
+		testsyncset.ForClusterDeployments(testCDName),
+		testsyncset.WithGeneration(1),
+		testsyncset.WithApplyMode(hivev1.SyncResourceApplyMode),
+		testsyncset.WithResources(testConfigMap("dest-namespace", "inline")),
+		testsyncset.WithResourceRefs(
+			hivev1.SyncSetResourceRef{ConfigMap: &hivev1.ConfigMapResourceSource{
+				Name:   "manifests",
+				Digest: testDigest(data["a.yaml"] + data["b.yaml"]),
+			}},
+			hivev1.SyncSetResourceRef{OCIArtifact: &hivev1.OCIArtifactResourceSource{
+				Image:         testImage,
+				PullSecretRef: &hivev1.SecretReference{Name: "pull-secret"},
+			}},
+		),
+	)
+	rt := newReconcileTest(t, mockCtrl, scheme,
+		cdBuilder(scheme).Build(),
+		clusterSyncBuilder(scheme).Build(),
+		teststatefulset.FullBuilder("hive", stsName, scheme).Build(
+			teststatefulset.WithCurrentReplicas(3),
+			teststatefulset.WithReplicas(3),
+		),
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "manifests"},
+			Data:       data,
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "pull-secret"},
+			Type:       corev1.SecretTypeDockerConfigJson,
+			Data:       map[string][]byte{corev1.DockerConfigJsonKey: []byte(`{"auths": {}}`)},
+		},
+		syncSet)
+	rt.r.fetchArtifact = func(image string, pullSecret []byte) ([][]byte, error) {
+		assert.Equal(t, testImage, image, "unexpected image")
+		assert.Equal(t, `{"auths": {}}`, string(pullSecret), "unexpected pull secret")
+		return [][]byte{[]byte(testManifests(t, testConfigMap("dest-namespace", "from-artifact")))}, nil
+	}
+	var calls []*gomock.Call
+	for _, name := range []string{"inline", "from-key-a-1", "from-key-a-2", "from-key-b", "from-artifact"} {
+		calls = append(calls, rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(testConfigMap("dest-namespace", name))).
+			Return(resource.CreatedApplyResult, nil))
+	}
+	gomock.InOrder(calls...)
+	rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{buildSyncStatus("test-syncset",
+		withResourcesToDelete(
+			testConfigMapRef("dest-namespace", "from-artifact"),
+			testConfigMapRef("dest-namespace", "from-key-a-1"),
+			testConfigMapRef("dest-namespace", "from-key-a-2"),
+			testConfigMapRef("dest-namespace", "from-key-b"),
+			testConfigMapRef("dest-namespace", "inline"),
+		),
+		withResourceRefsDigest(testRefsDigest(testNamespace, "manifests", data)),
+	)}
+	rt.run(t)
+}
+
+func TestReconcileClusterSync_ResourceRefDigestMismatch(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	scheme := newScheme()
+	manifests := testManifests(t, testConfigMap("dest-namespace", "dest-name"))
+	syncSet := testsyncset.FullBuilder(testNamespace, "test-syncset", scheme).Build(
+		testsyncset.ForClusterDeployments(testCDName),
+		testsyncset.WithGeneration(1),
+		testsyncset.WithResourceRefs(hivev1.SyncSetResourceRef{ConfigMap: &hivev1.ConfigMapResourceSource{
+			Name:   "manifests",
+			Digest: testDigest("something else"),
+		}}),
+	)
+	rt := newReconcileTest(t, mockCtrl, scheme,
+		cdBuilder(scheme).Build(),
+		clusterSyncBuilder(scheme).Build(),
+		teststatefulset.FullBuilder("hive", stsName, scheme).Build(
+			teststatefulset.WithCurrentReplicas(3),
+			teststatefulset.WithReplicas(3),
+		),
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "manifests"},
+			Data:       map[string]string{"manifests.yaml": manifests},
+		},
+		syncSet)
+	rt.expectedFailedMessage = "SyncSet test-syncset is failing"
+	rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{buildSyncStatus("test-syncset",
+		withFailureResult(fmt.Sprintf("failed to load resource ref 0: configmap test-namespace/manifests has digest %s instead of %s",
+			testDigest(manifests), testDigest("something else"))),
+		withNoFirstSuccessTime(),
+		withResourceRefsDigest(testRefsDigest(testNamespace, "manifests", map[string]string{"manifests.yaml": manifests})),
+	)}
+	rt.expectRequeue = true
+	rt.run(t)
+}
+
+func TestReconcileClusterSync_ResourceRefErrorKeepsSyncedResources(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	scheme := newScheme()
+	syncSet := testsyncset.FullBuilder(testNamespace, "test-syncset", scheme).Build(
+		testsyncset.ForClusterDeployments(testCDName),
+		testsyncset.WithGeneration(2),
+		testsyncset.WithApplyMode(hivev1.SyncResourceApplyMode),
+		testsyncset.WithResourceRefs(hivev1.SyncSetResourceRef{OCIArtifact: &hivev1.OCIArtifactResourceSource{Image: testImage}}),
+	)
+	existingSyncStatus := buildSyncStatus("test-syncset",
+		withTransitionInThePast(),
+		withFirstSuccessTimeInThePast(),
+		withResourcesToDelete(testConfigMapRef("dest-namespace", "from-artifact")),
+	)
+	rt := newReconcileTest(t, mockCtrl, scheme,
+		cdBuilder(scheme).Build(),
+		clusterSyncBuilder(scheme).Build(testcs.WithSyncSetStatus(existingSyncStatus)),
+		teststatefulset.FullBuilder("hive", stsName, scheme).Build(
+			teststatefulset.WithCurrentReplicas(3),
+			teststatefulset.WithReplicas(3),
+		),
+		buildSyncLease(time.Now().Add(-time.Hour)),
+		syncSet)
+	rt.r.fetchArtifact = func(string, []byte) ([][]byte, error) {
+		return nil, errors.New("registry unavailable")
+	}
+	// No resources are expected to be deleted from the target cluster.
+	rt.expectedFailedMessage = "SyncSet test-syncset is failing"
+	rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{buildSyncStatus("test-syncset",
+		withObservedGeneration(2),
+		withFailureResult("failed to load resource ref 0: registry unavailable"),
+		withFirstSuccessTimeInThePast(),
+		withResourcesToDelete(testConfigMapRef("dest-namespace", "from-artifact")),
+	)}
+	rt.expectUnchangedLeaseRenewTime = true
+	rt.expectRequeue = true
+	rt.run(t)
+}
+
+func TestReconcileClusterSync_ResourceRefConfigMapChanged(t *testing.T) {
+	cases := []struct {
+		name          string
+		changed       bool
+		expectApplied bool
+	}{
+		{
+			name: "configmap unchanged",
+		},
+		{
+			name:          "configmap changed",
+			changed:       true,
+			expectApplied: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scheme := newScheme()
+			data := map[string]string{"manifests.yaml": testManifests(t, testConfigMap("dest-namespace", "dest-name"))}
+			syncSet := testsyncset.FullBuilder(testNamespace, "test-syncset", scheme).Build(
+				testsyncset.ForClusterDeployments(testCDName),
+				testsyncset.WithGeneration(1),
+				testsyncset.WithResourceRefs(hivev1.SyncSetResourceRef{ConfigMap: &hivev1.ConfigMapResourceSource{Name: "manifests"}}),
+			)
+			appliedDigest := testRefsDigest(testNamespace, "manifests", data)
+			if tc.changed {
+				appliedDigest = testRefsDigest(testNamespace, "manifests", map[string]string{"manifests.yaml": "previous"})
+			}
+			existingSyncStatus := buildSyncStatus("test-syncset",
+				withTransitionInThePast(),
+				withFirstSuccessTimeInThePast(),
+				withResourceRefsDigest(appliedDigest),
+			)
+			rt := newReconcileTest(t, mockCtrl, scheme,
+				cdBuilder(scheme).Build(),
+				clusterSyncBuilder(scheme).Build(testcs.WithSyncSetStatus(existingSyncStatus)),
+				teststatefulset.FullBuilder("hive", stsName, scheme).Build(
+					teststatefulset.WithCurrentReplicas(3),
+					teststatefulset.WithReplicas(3),
+				),
+				buildSyncLease(time.Now().Add(-time.Minute)),
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "manifests"},
+					Data:       data,
+				},
+				syncSet)
+			expectedSyncStatus := existingSyncStatus
+			if tc.expectApplied {
+				rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(testConfigMap("dest-namespace", "dest-name"))).
+					Return(resource.CreatedApplyResult, nil)
+				expectedSyncStatus = buildSyncStatus("test-syncset",
+					withFirstSuccessTimeInThePast(),
+					withResourceRefsDigest(testRefsDigest(testNamespace, "manifests", data)),
+				)
+			}
+			rt.expectUnchangedLeaseRenewTime = true
+			rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{expectedSyncStatus}
+			rt.run(t)
+		})
+	}
+}
+
+func TestReferencesConfigMap(t *testing.T) {
+	ref := hivev1.SyncSetResourceRef{ConfigMap: &hivev1.ConfigMapResourceSource{Name: "manifests"}}
+	syncSet := &hivev1.SyncSet{}
+	syncSet.Namespace = testNamespace
+	syncSet.Spec.ResourceRefs = []hivev1.SyncSetResourceRef{ref}
+	assert.True(t, referencesConfigMap((*SyncSetAsCommon)(syncSet), testNamespace, "manifests"), "expected configmap in namespace of syncset")
+	assert.False(t, referencesConfigMap((*SyncSetAsCommon)(syncSet), "other-namespace", "manifests"), "unexpected configmap in other namespace")
+	assert.False(t, referencesConfigMap((*SyncSetAsCommon)(syncSet), testNamespace, "other"), "unexpected other configmap")
+
+	selectorSyncSet := &hivev1.SelectorSyncSet{}
+	selectorSyncSet.Spec.ResourceRefs = []hivev1.SyncSetResourceRef{ref}
+	assert.False(t, referencesConfigMap((*SelectorSyncSetAsCommon)(selectorSyncSet), testNamespace, "manifests"), "unexpected configmap without namespace")
+	ref.ConfigMap.Namespace = testNamespace
+	assert.True(t, referencesConfigMap((*SelectorSyncSetAsCommon)(selectorSyncSet), testNamespace, "manifests"), "expected configmap in namespace of ref")
+}
+
+func testManifests(t *testing.T, objs ...*corev1.ConfigMap) string {
+	var manifests []string
+	for _, obj := range objs {
+		manifest, err := json.Marshal(obj)
+		require.NoError(t, err, "could not marshal manifest")
+		manifests = append(manifests, string(manifest))
+	}
+	return strings.Join(manifests, "\n---\n") + "\n"
+}
+
+func testDigest(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+func testRefsDigest(namespace, name string, data map[string]string) string {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	content := namespace + "/" + name + "\x00"
+	for _, key := range keys {
+		content += key + "\x00" + data[key] + "\x00"
+	}
+	return testDigest(content)
+}
@@ -0,0 +1,382 @@
+package clustersync
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hiveintv1alpha1 "github.com/openshift/hive/apis/hiveinternal/v1alpha1"
+	"github.com/openshift/hive/pkg/constants"
+	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/resource"
+)
+
+const (
+	// defaultSyncAgentPollInterval is how often the sync agent checks Hive for changes when the ClusterDeployment
+	// does not say otherwise.
+	defaultSyncAgentPollInterval = 2 * time.Minute
+
+	// syncAgentStateShardSize is the maximum size of the shard of the state held by each sync agent state secret,
+	// which keeps the secrets well below the size limit of objects once their data is base64 encoded.
+	syncAgentStateShardSize = 512 * 1024
+)
+
+// SyncAgentState is the state published by Hive for the sync agent of a cluster. It holds everything the agent
+// needs from Hive to apply the SyncSets and SelectorSyncSets of the cluster.
+type SyncAgentState struct {
+	// ClusterDeployment is the ClusterDeployment of the cluster.
+	ClusterDeployment *hivev1.ClusterDeployment `json:"clusterDeployment"`
+	// SyncSets are the SyncSets which apply to the cluster.
+	SyncSets []hivev1.SyncSet `json:"syncSets,omitempty"`
+	// SelectorSyncSets are the SelectorSyncSets which apply to the cluster.
+	SelectorSyncSets []hivev1.SelectorSyncSet `json:"selectorSyncSets,omitempty"`
+	// Secrets are the source secrets referenced by the secret mappings of the SyncSets and SelectorSyncSets.
+	Secrets []corev1.Secret `json:"secrets,omitempty"`
+	// ReapplyInterval is how often all the SyncSets and SelectorSyncSets are reapplied.
+	ReapplyInterval metav1.Duration `json:"reapplyInterval"`
+}
+
+// publishSyncAgentState publishes the state pulled by the sync agent of the cluster, grants the agent access to that
+// state and to the ClusterSync in which it reports the results, and deploys the agent to the cluster.
+func (r *ReconcileClusterSync) publishSyncAgentState(cd *hivev1.ClusterDeployment, logger log.FieldLogger) (reconcile.Result, error) {
+	if err := r.ensureClusterSync(cd, logger); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	state := &SyncAgentState{
+		ClusterDeployment: cd,
+		ReapplyInterval:   metav1.Duration{Duration: r.reapplyInterval},
+	}
+	syncSets, err := r.getSyncSetsForClusterDeployment(cd, logger)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	selectorSyncSets, err := r.getSelectorSyncSetsForClusterDeployment(cd, logger)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	secrets := map[types.NamespacedName]bool{}
+	for _, syncSet := range append(syncSets, selectorSyncSets...) {
+		switch ss := syncSet.(type) {
+		case *SyncSetAsCommon:
+			state.SyncSets = append(state.SyncSets, *(*hivev1.SyncSet)(ss))
+		case *SelectorSyncSetAsCommon:
+			state.SelectorSyncSets = append(state.SelectorSyncSets, *(*hivev1.SelectorSyncSet)(ss))
+		}
+		for _, secretMapping := range syncSet.GetSpec().Secrets {
+			srcNamespace := secretMapping.SourceRef.Namespace
+			if srcNamespace == "" {
+				srcNamespace = syncSet.AsMetaObject().GetNamespace()
+			}
+			// The state is published in the namespace of the clusterdeployment, so only the source secrets of that
+			// namespace are published, for SelectorSyncSets as well as SyncSets. The agent reports the secrets of
+			// other namespaces as not found.
+			if srcNamespace != cd.Namespace {
+				continue
+			}
+			key := types.NamespacedName{Namespace: srcNamespace, Name: secretMapping.SourceRef.Name}
+			if secrets[key] {
+				continue
+			}
+			secrets[key] = true
+			secret := &corev1.Secret{}
+			switch err := r.Get(context.Background(), key, secret); {
+			case apierrors.IsNotFound(err):
+				continue
+			case err != nil:
+				logger.WithError(err).WithField("secret", key).Log(controllerutils.LogLevel(err), "could not get secret")
+				return reconcile.Result{}, err
+			}
+			state.Secrets = append(state.Secrets, corev1.Secret{
+				TypeMeta: metav1.TypeMeta{APIVersion: secretAPIVersion, Kind: secretKind},
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   secret.Namespace,
+					Name:        secret.Name,
+					Labels:      secret.Labels,
+					Annotations: secret.Annotations,
+				},
+				Type: secret.Type,
+				Data: secret.Data,
+			})
+		}
+	}
+	stateData, err := json.Marshal(state)
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "could not marshal sync agent state")
+	}
+	shardNames, err := r.publishSyncAgentStateShards(cd, stateData, logger)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	name := constants.GetSyncAgentName(cd)
+	serviceAccount := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Namespace: cd.Namespace, Name: name}}
+	if err := r.ensureOwnedByClusterDeployment(cd, serviceAccount, func() {}, logger); err != nil {
+		return reconcile.Result{}, err
+	}
+	tokenSecret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: cd.Namespace, Name: constants.GetSyncAgentTokenSecretName(cd)}}
+	switch err := r.Get(context.Background(), types.NamespacedName{Namespace: cd.Namespace, Name: tokenSecret.Name}, tokenSecret); {
+	case apierrors.IsNotFound(err):
+		// The token is filled in by the token controller. The type of the secret cannot be changed once created.
+		tokenSecret.Type = corev1.SecretTypeServiceAccountToken
+		tokenSecret.Annotations = map[string]string{corev1.ServiceAccountNameKey: name}
+		tokenSecret.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(cd, hivev1.SchemeGroupVersion.WithKind("ClusterDeployment"))}
+		logger.WithField("secret", tokenSecret.Name).Info("creating sync agent token secret")
+		if err := r.Create(context.Background(), tokenSecret); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not create sync agent token secret")
+			return reconcile.Result{}, err
+		}
+	case err != nil:
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not get sync agent token secret")
+		return reconcile.Result{}, err
+	}
+
+	role := &rbacv1.Role{ObjectMeta: metav1.ObjectMeta{Namespace: cd.Namespace, Name: name}}
+	if err := r.ensureOwnedByClusterDeployment(cd, role, func() {
+		role.Rules = []rbacv1.PolicyRule{
+			{
+				APIGroups:     []string{""},
+				Resources:     []string{"secrets"},
+				ResourceNames: shardNames,
+				Verbs:         []string{"get"},
+			},
+			{
+				APIGroups:     []string{hiveintv1alpha1.SchemeGroupVersion.Group},
+				Resources:     []string{"clustersyncs", "clustersyncs/status"},
+				ResourceNames: []string{cd.Name},
+				Verbs:         []string{"get", "update"},
+			},
+			{
+				APIGroups:     []string{hiveintv1alpha1.SchemeGroupVersion.Group},
+				Resources:     []string{"clustersyncleases"},
+				ResourceNames: []string{cd.Name},
+				Verbs:         []string{"get", "update"},
+			},
+		}
+	}, logger); err != nil {
+		return reconcile.Result{}, err
+	}
+	roleBinding := &rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{Namespace: cd.Namespace, Name: name}}
+	if err := r.ensureOwnedByClusterDeployment(cd, roleBinding, func() {
+		roleBinding.Subjects = []rbacv1.Subject{{
+			Kind:      rbacv1.ServiceAccountKind,
+			Namespace: cd.Namespace,
+			Name:      name,
+		}}
+		roleBinding.RoleRef = rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "Role",
+			Name:     name,
+		}
+	}, logger); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	return r.deploySyncAgent(cd, serviceAccount, tokenSecret, logger)
+}
+
+// publishSyncAgentStateShards publishes the state of the sync agent split into secrets of at most
+// syncAgentStateShardSize bytes, and returns the names of the secrets. The first secret holds the number of shards
+// and the hash of the whole state, and is updated last, so that the agent can tell when it read shards of different
+// states and retry. The secrets of shards which are no longer needed are deleted.
+func (r *ReconcileClusterSync) publishSyncAgentStateShards(cd *hivev1.ClusterDeployment, stateData []byte, logger log.FieldLogger) ([]string, error) {
+	previousShards := 0
+	first := &corev1.Secret{}
+	switch err := r.Get(context.Background(), types.NamespacedName{Namespace: cd.Namespace, Name: constants.GetSyncAgentName(cd)}, first); {
+	case apierrors.IsNotFound(err):
+	case err != nil:
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not get sync agent state secret")
+		return nil, err
+	default:
+		previousShards, _ = strconv.Atoi(string(first.Data[constants.SyncAgentStateShardsSecretKey]))
+	}
+
+	hash := sha256.Sum256(stateData)
+	var shards [][]byte
+	for len(stateData) > syncAgentStateShardSize {
+		shards = append(shards, stateData[:syncAgentStateShardSize])
+		stateData = stateData[syncAgentStateShardSize:]
+	}
+	shards = append(shards, stateData)
+
+	names := make([]string, len(shards))
+	for i := len(shards) - 1; i >= 0; i-- {
+		names[i] = constants.GetSyncAgentStateShardName(cd, i)
+		stateSecret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: cd.Namespace, Name: names[i]}}
+		shard, first := shards[i], i == 0
+		if err := r.ensureOwnedByClusterDeployment(cd, stateSecret, func() {
+			if stateSecret.Labels == nil {
+				stateSecret.Labels = map[string]string{}
+			}
+			stateSecret.Labels[constants.SecretTypeLabel] = constants.SecretTypeSyncAgentState
+			stateSecret.Data = map[string][]byte{constants.SyncAgentStateSecretKey: shard}
+			if first {
+				stateSecret.Data[constants.SyncAgentStateShardsSecretKey] = []byte(strconv.Itoa(len(shards)))
+				stateSecret.Data[constants.SyncAgentStateHashSecretKey] = []byte(hex.EncodeToString(hash[:]))
+			}
+		}, logger); err != nil {
+			return nil, err
+		}
+	}
+
+	for i := len(shards); i < previousShards; i++ {
+		stale := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: cd.Namespace, Name: constants.GetSyncAgentStateShardName(cd, i)}}
+		logger.WithField("secret", stale.Name).Info("deleting stale sync agent state secret")
+		if err := r.Delete(context.Background(), stale); err != nil && !apierrors.IsNotFound(err) {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not delete stale sync agent state secret")
+			return nil, err
+		}
+	}
+	return names, nil
+}
+
+// ensureClusterSync creates the ClusterSync of the cluster and its lease if they do not exist, since the sync agent
+// is not allowed to create them.
+func (r *ReconcileClusterSync) ensureClusterSync(cd *hivev1.ClusterDeployment, logger log.FieldLogger) error {
+	clusterSync := &hiveintv1alpha1.ClusterSync{}
+	switch err := r.Get(context.Background(), types.NamespacedName{Namespace: cd.Namespace, Name: cd.Name}, clusterSync); {
+	case apierrors.IsNotFound(err):
+		logger.Info("creating ClusterSync as it does not exist")
+		clusterSync.Namespace = cd.Namespace
+		clusterSync.Name = cd.Name
+		ownerRef := metav1.NewControllerRef(cd, hivev1.SchemeGroupVersion.WithKind("ClusterDeployment"))
+		ownerRef.Controller = nil
+		clusterSync.OwnerReferences = []metav1.OwnerReference{*ownerRef}
+		if err := r.Create(context.Background(), clusterSync); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not create ClusterSync")
+			return err
+		}
+	case err != nil:
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not get ClusterSync")
+		return err
+	}
+
+	lease := &hiveintv1alpha1.ClusterSyncLease{}
+	switch err := r.Get(context.Background(), types.NamespacedName{Namespace: cd.Namespace, Name: cd.Name}, lease); {
+	case apierrors.IsNotFound(err):
+		// A lease which was never renewed has the agent reapply all the syncsets on its first sync.
+		logger.Info("creating lease for ClusterSync as it does not exist")
+		lease.Namespace = cd.Namespace
+		lease.Name = cd.Name
+		ownerRef := metav1.NewControllerRef(clusterSync, hiveintv1alpha1.SchemeGroupVersion.WithKind("ClusterSync"))
+		ownerRef.Controller = nil
+		lease.OwnerReferences = []metav1.OwnerReference{*ownerRef}
+		if err := r.Create(context.Background(), lease); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not create lease for ClusterSync")
+			return err
+		}
+	case err != nil:
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not get lease for ClusterSync")
+		return err
+	}
+	return nil
+}
+
+// ensureOwnedByClusterDeployment creates or updates the object, controlled by the ClusterDeployment, with the
+// contents set by the mutate function.
+func (r *ReconcileClusterSync) ensureOwnedByClusterDeployment(cd *hivev1.ClusterDeployment, obj controllerutil.Object, mutate func(), logger log.FieldLogger) error {
+	result, err := controllerutil.CreateOrUpdate(context.Background(), r.Client, obj, func() error {
+		obj.SetOwnerReferences([]metav1.OwnerReference{*metav1.NewControllerRef(cd, hivev1.SchemeGroupVersion.WithKind("ClusterDeployment"))})
+		mutate()
+		return nil
+	})
+	if err != nil {
+		logger.WithError(err).WithField("name", obj.GetName()).Log(controllerutils.LogLevel(err), "could not create or update sync agent resource")
+		return err
+	}
+	if result != controllerutil.OperationResultNone {
+		logger.WithField("name", obj.GetName()).WithField("operation", result).Info("sync agent resource reconciled")
+	}
+	return nil
+}
+
+// syncAgentClient is the hub client of the sync agent. The SyncSets, SelectorSyncSets and source secrets are served
+// from the state published for the agent, which the agent is not allowed to read from the hub directly.
+type syncAgentClient struct {
+	client.Client
+	state *SyncAgentState
+}
+
+// Get serves secrets from the published state and gets everything else from the hub.
+func (c *syncAgentClient) Get(ctx context.Context, key client.ObjectKey, obj runtime.Object) error {
+	secret, ok := obj.(*corev1.Secret)
+	if !ok {
+		return c.Client.Get(ctx, key, obj)
+	}
+	for i := range c.state.Secrets {
+		if c.state.Secrets[i].Namespace == key.Namespace && c.state.Secrets[i].Name == key.Name {
+			c.state.Secrets[i].DeepCopyInto(secret)
+			return nil
+		}
+	}
+	return apierrors.NewNotFound(corev1.Resource("secrets"), key.Name)
+}
+
+// List serves SyncSets and SelectorSyncSets from the published state and lists everything else from the hub.
+func (c *syncAgentClient) List(ctx context.Context, list runtime.Object, opts ...client.ListOption) error {
+	switch l := list.(type) {
+	case *hivev1.SyncSetList:
+		l.Items = nil
+		for i := range c.state.SyncSets {
+			l.Items = append(l.Items, *c.state.SyncSets[i].DeepCopy())
+		}
+		return nil
+	case *hivev1.SelectorSyncSetList:
+		l.Items = nil
+		for i := range c.state.SelectorSyncSets {
+			l.Items = append(l.Items, *c.state.SelectorSyncSets[i].DeepCopy())
+		}
+		return nil
+	}
+	return c.Client.List(ctx, list, opts...)
+}
+
+// ApplySyncAgentState applies the SyncSets and SelectorSyncSets in the state published for the sync agent of a
+// cluster using the resource helper of the cluster, and reports the results in the ClusterSync of the cluster
+// through the hub client.
+func ApplySyncAgentState(hubClient client.Client, state *SyncAgentState, resourceHelper resource.Helper, logger log.FieldLogger) (reconcile.Result, error) {
+	if state.ClusterDeployment == nil {
+		return reconcile.Result{}, errors.New("sync agent state is missing the clusterdeployment")
+	}
+	reapplyInterval := state.ReapplyInterval.Duration
+	if reapplyInterval <= 0 {
+		reapplyInterval = defaultReapplyInterval
+	}
+	r := &ReconcileClusterSync{
+		Client:          &syncAgentClient{Client: hubClient, state: state},
+		logger:          logger,
+		reapplyInterval: reapplyInterval,
+	}
+	recobsrv := hivemetrics.NewReconcileObserver(ControllerName, logger)
+	defer recobsrv.ObserveControllerReconcileTime()
+	// The sync agent applies with its own service account, which is not allowed to impersonate others.
+	applyAsHelper := unavailableApplyAsHelperBuilder(errors.New("syncsets cannot be applied as a service account by the sync agent"))
+	return r.syncClusterDeployment(state.ClusterDeployment, resourceHelper, resourceHelper, applyAsHelper, recobsrv, logger)
+}
+
+// SyncAgentPollInterval returns how often the sync agent of the cluster checks Hive for changes.
+func SyncAgentPollInterval(cd *hivev1.ClusterDeployment) time.Duration {
+	if cd.Spec.SyncAgent != nil && cd.Spec.SyncAgent.PollInterval != nil {
+		return cd.Spec.SyncAgent.PollInterval.Duration
+	}
+	return defaultSyncAgentPollInterval
+}
@@ -0,0 +1,249 @@
+package clustersync
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hiveintv1alpha1 "github.com/openshift/hive/apis/hiveinternal/v1alpha1"
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/resource"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+	testgeneric "github.com/openshift/hive/pkg/test/generic"
+	testsecret "github.com/openshift/hive/pkg/test/secret"
+	testselectorsyncset "github.com/openshift/hive/pkg/test/selectorsyncset"
+	teststatefulset "github.com/openshift/hive/pkg/test/statefulset"
+	testsyncset "github.com/openshift/hive/pkg/test/syncset"
+)
+
+func TestReconcileClusterSync_SyncAgent(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	scheme := newScheme()
+	rbacv1.AddToScheme(scheme)
+	cd := cdBuilder(scheme).Build(
+		testcd.WithLabel("test-label-key", "test-label-value"),
+		func(cd *hivev1.ClusterDeployment) {
+			cd.Spec.SyncAgent = &hivev1.SyncAgent{
+				HubAPIURL:    "https://api.hub.example.com:6443",
+				PollInterval: &metav1.Duration{Duration: time.Minute},
+			}
+		},
+	)
+	syncSet := testsyncset.FullBuilder(testNamespace, "test-syncset", scheme).Build(
+		testsyncset.ForClusterDeployments(testCDName),
+		testsyncset.WithGeneration(1),
+		testsyncset.WithResources(testConfigMap("dest-namespace", "dest-name")),
+	)
+	selectorSyncSet := testselectorsyncset.FullBuilder("test-selectorsyncset", scheme).Build(
+		testselectorsyncset.WithLabelSelector("test-label-key", "test-label-value"),
+		testselectorsyncset.WithGeneration(1),
+		testselectorsyncset.WithSecrets(
+			hivev1.SecretMapping{
+				SourceRef: hivev1.SecretReference{Namespace: testNamespace, Name: "src-name"},
+				TargetRef: hivev1.SecretReference{Namespace: "dest-namespace", Name: "dest-name"},
+			},
+			hivev1.SecretMapping{
+				SourceRef: hivev1.SecretReference{Namespace: "other-namespace", Name: "other-name"},
+				TargetRef: hivev1.SecretReference{Namespace: "dest-namespace", Name: "other-name"},
+			},
+		),
+	)
+	srcSecret := testsecret.FullBuilder(testNamespace, "src-name", scheme).Build(
+		testsecret.WithDataKeyValue("test-key", []byte("test-data")),
+	)
+	// Secrets of other namespaces are not published in the namespace of the clusterdeployment.
+	otherSecret := testsecret.FullBuilder("other-namespace", "other-name", scheme).Build(
+		testsecret.WithDataKeyValue("test-key", []byte("other-data")),
+	)
+	rt := newReconcileTest(t, mockCtrl, scheme,
+		cd,
+		teststatefulset.FullBuilder("hive", stsName, scheme).Build(
+			teststatefulset.WithCurrentReplicas(3),
+			teststatefulset.WithReplicas(3),
+		),
+		syncSet,
+		selectorSyncSet,
+		srcSecret,
+		otherSecret,
+	)
+
+	// Hive publishes the state for the agent rather than applying the syncsets itself, and waits for the token of the
+	// agent to deploy it.
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: testCDName}}
+	result, err := rt.r.Reconcile(request)
+	require.NoError(t, err, "unexpected error from Reconcile")
+	assert.Equal(t, reconcile.Result{RequeueAfter: syncAgentTokenWaitInterval}, result, "unexpected result")
+
+	name := constants.GetSyncAgentName(cd)
+	stateSecret := &corev1.Secret{}
+	require.NoError(t, rt.c.Get(context.Background(), types.NamespacedName{Namespace: testNamespace, Name: name}, stateSecret), "expected state secret")
+	assert.Equal(t, constants.SecretTypeSyncAgentState, stateSecret.Labels[constants.SecretTypeLabel], "unexpected secret type label")
+	assert.Equal(t, "1", string(stateSecret.Data[constants.SyncAgentStateShardsSecretKey]), "unexpected number of shards")
+	state := &SyncAgentState{}
+	require.NoError(t, json.Unmarshal(stateSecret.Data[constants.SyncAgentStateSecretKey], state), "could not unmarshal state")
+	assert.Equal(t, testCDName, state.ClusterDeployment.Name, "unexpected clusterdeployment in state")
+	if assert.Len(t, state.SyncSets, 1, "unexpected syncsets in state") {
+		assert.Equal(t, "test-syncset", state.SyncSets[0].Name, "unexpected syncset in state")
+	}
+	if assert.Len(t, state.SelectorSyncSets, 1, "unexpected selectorsyncsets in state") {
+		assert.Equal(t, "test-selectorsyncset", state.SelectorSyncSets[0].Name, "unexpected selectorsyncset in state")
+	}
+	if assert.Len(t, state.Secrets, 1, "unexpected secrets in state") {
+		assert.Equal(t, "src-name", state.Secrets[0].Name, "unexpected secret in state")
+		assert.Equal(t, []byte("test-data"), state.Secrets[0].Data["test-key"], "unexpected secret data in state")
+	}
+	assert.Equal(t, time.Minute, SyncAgentPollInterval(state.ClusterDeployment), "unexpected poll interval")
+
+	require.NoError(t, rt.c.Get(context.Background(), types.NamespacedName{Namespace: testNamespace, Name: name}, &corev1.ServiceAccount{}), "expected service account")
+	tokenSecret := &corev1.Secret{}
+	require.NoError(t, rt.c.Get(context.Background(), types.NamespacedName{Namespace: testNamespace, Name: constants.GetSyncAgentTokenSecretName(cd)}, tokenSecret), "expected token secret")
+	assert.Equal(t, corev1.SecretTypeServiceAccountToken, tokenSecret.Type, "unexpected token secret type")
+	assert.Equal(t, name, tokenSecret.Annotations[corev1.ServiceAccountNameKey], "unexpected token secret service account")
+	role := &rbacv1.Role{}
+	require.NoError(t, rt.c.Get(context.Background(), types.NamespacedName{Namespace: testNamespace, Name: name}, role), "expected role")
+	if assert.Len(t, role.Rules, 3, "unexpected role rules") {
+		assert.Equal(t, []string{name}, role.Rules[0].ResourceNames, "unexpected secrets of role")
+		assert.Equal(t, []string{testCDName}, role.Rules[2].ResourceNames, "unexpected leases of role")
+		assert.NotContains(t, role.Rules[2].Verbs, "create", "unexpected lease verbs of role")
+	}
+	roleBinding := &rbacv1.RoleBinding{}
+	require.NoError(t, rt.c.Get(context.Background(), types.NamespacedName{Namespace: testNamespace, Name: name}, roleBinding), "expected role binding")
+	assert.Equal(t, name, roleBinding.RoleRef.Name, "unexpected role binding role")
+	clusterSync := &hiveintv1alpha1.ClusterSync{}
+	require.NoError(t, rt.c.Get(context.Background(), types.NamespacedName{Namespace: testNamespace, Name: testClusterSyncName}, clusterSync), "expected clustersync")
+	assert.Empty(t, clusterSync.Status.SyncSets, "unexpected syncset statuses")
+	lease := &hiveintv1alpha1.ClusterSyncLease{}
+	require.NoError(t, rt.c.Get(context.Background(), types.NamespacedName{Namespace: testNamespace, Name: testLeaseName}, lease), "expected lease")
+
+	// Once the token is issued, the agent is deployed to the cluster through the admin kubeconfig, only once.
+	tokenSecret.Data = map[string][]byte{
+		corev1.ServiceAccountTokenKey:  []byte("test-token"),
+		corev1.ServiceAccountRootCAKey: []byte("test-ca"),
+	}
+	require.NoError(t, rt.c.Update(context.Background(), tokenSecret), "could not issue token")
+	rt.mockRemoteClientBuilder.EXPECT().RESTConfig().Return(&rest.Config{}, nil)
+	var deployed []string
+	rt.mockResourceHelper.EXPECT().Apply(gomock.Any()).DoAndReturn(func(raw []byte) (resource.ApplyResult, error) {
+		obj := &unstructured.Unstructured{}
+		require.NoError(t, obj.UnmarshalJSON(raw), "could not unmarshal sync agent resource")
+		deployed = append(deployed, obj.GetKind())
+		return resource.CreatedApplyResult, nil
+	}).Times(5)
+	result, err = rt.r.Reconcile(request)
+	require.NoError(t, err, "unexpected error from Reconcile")
+	assert.Equal(t, reconcile.Result{}, result, "unexpected result")
+	assert.Equal(t, []string{"Namespace", "ServiceAccount", "ClusterRoleBinding", "Secret", "Deployment"}, deployed, "unexpected sync agent resources")
+	result, err = rt.r.Reconcile(request)
+	require.NoError(t, err, "unexpected error from Reconcile")
+	assert.Equal(t, reconcile.Result{}, result, "unexpected result")
+
+	// The agent applies the state and reports the results in the ClusterSync on the hub. The hub client of the agent
+	// only has access to the ClusterSync and its lease.
+	hubClient := &clientWrapper{fake.NewFakeClientWithScheme(scheme, clusterSync, lease)}
+	rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(testConfigMap("dest-namespace", "dest-name"))).Return(resource.CreatedApplyResult, nil)
+	secretToApply := testsecret.BasicBuilder().GenericOptions(
+		testgeneric.WithNamespace("dest-namespace"),
+		testgeneric.WithName("dest-name"),
+		testgeneric.WithTypeMeta(scheme),
+	).Build(
+		testsecret.WithDataKeyValue("test-key", []byte("test-data")),
+	)
+	rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(secretToApply)).Return(resource.CreatedApplyResult, nil)
+	_, err = ApplySyncAgentState(hubClient, state, rt.mockResourceHelper, log.WithField("test", t.Name()))
+	require.NoError(t, err, "unexpected error applying state")
+
+	clusterSync = &hiveintv1alpha1.ClusterSync{}
+	require.NoError(t, hubClient.Get(context.Background(), types.NamespacedName{Namespace: testNamespace, Name: testClusterSyncName}, clusterSync), "expected clustersync")
+	if assert.Len(t, clusterSync.Status.SyncSets, 1, "unexpected syncset statuses") {
+		assert.Equal(t, hiveintv1alpha1.SuccessSyncSetResult, clusterSync.Status.SyncSets[0].Result, "unexpected syncset result")
+	}
+	if assert.Len(t, clusterSync.Status.SelectorSyncSets, 1, "unexpected selectorsyncset statuses") {
+		assert.Equal(t, hiveintv1alpha1.FailureSyncSetResult, clusterSync.Status.SelectorSyncSets[0].Result, "unexpected selectorsyncset result")
+		assert.Contains(t, clusterSync.Status.SelectorSyncSets[0].FailureMessage, "other-name", "unexpected selectorsyncset failure")
+	}
+	require.NoError(t, hubClient.Get(context.Background(), types.NamespacedName{Namespace: testNamespace, Name: testLeaseName}, &hiveintv1alpha1.ClusterSyncLease{}), "expected lease")
+}
+
+func TestReconcileClusterSync_SyncAgentStateShards(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	scheme := newScheme()
+	rbacv1.AddToScheme(scheme)
+	cd := cdBuilder(scheme).Build(
+		func(cd *hivev1.ClusterDeployment) {
+			cd.Spec.SyncAgent = &hivev1.SyncAgent{HubAPIURL: "https://api.hub.example.com:6443"}
+		},
+	)
+	syncSet := testsyncset.FullBuilder(testNamespace, "test-syncset", scheme).Build(
+		testsyncset.ForClusterDeployments(testCDName),
+		testsyncset.WithSecrets(
+			hivev1.SecretMapping{
+				SourceRef: hivev1.SecretReference{Name: "src-name"},
+				TargetRef: hivev1.SecretReference{Namespace: "dest-namespace", Name: "dest-name"},
+			},
+		),
+	)
+	srcSecret := testsecret.FullBuilder(testNamespace, "src-name", scheme).Build(
+		testsecret.WithDataKeyValue("test-key", bytes.Repeat([]byte("a"), syncAgentStateShardSize)),
+	)
+	rt := newReconcileTest(t, mockCtrl, scheme,
+		cd,
+		teststatefulset.FullBuilder("hive", stsName, scheme).Build(
+			teststatefulset.WithCurrentReplicas(3),
+			teststatefulset.WithReplicas(3),
+		),
+		syncSet,
+		srcSecret,
+	)
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: testCDName}}
+
+	// The state is split into shards small enough for secrets
+	_, err := rt.r.Reconcile(request)
+	require.NoError(t, err, "unexpected error from Reconcile")
+	first := &corev1.Secret{}
+	require.NoError(t, rt.c.Get(context.Background(), types.NamespacedName{Namespace: testNamespace, Name: constants.GetSyncAgentStateShardName(cd, 0)}, first), "expected first shard")
+	require.Equal(t, "2", string(first.Data[constants.SyncAgentStateShardsSecretKey]), "unexpected number of shards")
+	second := &corev1.Secret{}
+	require.NoError(t, rt.c.Get(context.Background(), types.NamespacedName{Namespace: testNamespace, Name: constants.GetSyncAgentStateShardName(cd, 1)}, second), "expected second shard")
+	stateData := append(first.Data[constants.SyncAgentStateSecretKey], second.Data[constants.SyncAgentStateSecretKey]...)
+	sum := sha256.Sum256(stateData)
+	assert.Equal(t, hex.EncodeToString(sum[:]), string(first.Data[constants.SyncAgentStateHashSecretKey]), "unexpected state hash")
+	state := &SyncAgentState{}
+	require.NoError(t, json.Unmarshal(stateData, state), "could not unmarshal state")
+	if assert.Len(t, state.Secrets, 1, "unexpected secrets in state") {
+		assert.Len(t, state.Secrets[0].Data["test-key"], syncAgentStateShardSize, "unexpected secret data in state")
+	}
+	role := &rbacv1.Role{}
+	require.NoError(t, rt.c.Get(context.Background(), types.NamespacedName{Namespace: testNamespace, Name: constants.GetSyncAgentName(cd)}, role), "expected role")
+	assert.Equal(t, []string{first.Name, second.Name}, role.Rules[0].ResourceNames, "unexpected secrets of role")
+
+	// Shards which are no longer needed are deleted
+	srcSecret.Data["test-key"] = []byte("test-data")
+	require.NoError(t, rt.c.Update(context.Background(), srcSecret), "could not update source secret")
+	_, err = rt.r.Reconcile(request)
+	require.NoError(t, err, "unexpected error from Reconcile")
+	require.NoError(t, rt.c.Get(context.Background(), types.NamespacedName{Namespace: testNamespace, Name: first.Name}, first), "expected first shard")
+	assert.Equal(t, "1", string(first.Data[constants.SyncAgentStateShardsSecretKey]), "unexpected number of shards")
+	assert.True(t, apierrors.IsNotFound(rt.c.Get(context.Background(), types.NamespacedName{Namespace: testNamespace, Name: second.Name}, &corev1.Secret{})), "expected second shard to be deleted")
+}
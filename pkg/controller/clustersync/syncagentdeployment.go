@@ -0,0 +1,212 @@
+package clustersync
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientcmdv1 "k8s.io/client-go/tools/clientcmd/api/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/yaml"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/controller/images"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/remoteclient"
+)
+
+const (
+	// syncAgentNamespace is the namespace of the sync agent in the cluster.
+	syncAgentNamespace = "openshift-hive-sync-agent"
+
+	// syncAgentResourceName is the name of the deployment, service account and cluster role binding of the sync
+	// agent in the cluster, and of the secret holding its kubeconfig for the Hive API.
+	syncAgentResourceName = "hive-sync-agent"
+
+	// syncAgentHubKubeconfigKey is the key of the kubeconfig for the Hive API in the secret of the sync agent.
+	syncAgentHubKubeconfigKey = "kubeconfig"
+
+	// syncAgentHubKubeconfigDir is the directory the secret of the kubeconfig for the Hive API is mounted in.
+	syncAgentHubKubeconfigDir = "/etc/hub"
+
+	// syncAgentTokenWaitInterval is how long to wait for the token controller to issue the token of the sync agent.
+	syncAgentTokenWaitInterval = 10 * time.Second
+)
+
+// deploySyncAgent deploys the sync agent to the cluster through the admin kubeconfig, with a kubeconfig for the Hive
+// API which authenticates with the token of the service account of the agent. The agent is deployed once the cluster
+// is installed and reachable, and redeployed whenever its resources change, for example when Hive is upgraded. The
+// checksum of the resources last deployed is recorded on the service account of the agent.
+func (r *ReconcileClusterSync) deploySyncAgent(cd *hivev1.ClusterDeployment, serviceAccount *corev1.ServiceAccount, tokenSecret *corev1.Secret, logger log.FieldLogger) (reconcile.Result, error) {
+	if !cd.Spec.Installed {
+		logger.Debug("cluster is not installed, the sync agent is deployed once it is")
+		return reconcile.Result{}, nil
+	}
+	if unreachable, _ := remoteclient.Unreachable(cd); unreachable {
+		logger.Debug("cluster is unreachable, the sync agent is deployed once it is reachable")
+		return reconcile.Result{}, nil
+	}
+	token := tokenSecret.Data[corev1.ServiceAccountTokenKey]
+	if len(token) == 0 {
+		logger.Info("waiting for the token of the sync agent to be issued")
+		return reconcile.Result{RequeueAfter: syncAgentTokenWaitInterval}, nil
+	}
+
+	hubKubeconfig, err := syncAgentHubKubeconfig(cd.Spec.SyncAgent.HubAPIURL, tokenSecret.Data[corev1.ServiceAccountRootCAKey], string(token))
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	objs := syncAgentResources(cd, hubKubeconfig)
+	checksum, err := controllerutils.GetChecksumOfObject(objs)
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "could not compute checksum of sync agent resources")
+	}
+	if serviceAccount.Annotations[constants.SyncAgentDeployedAnnotation] == checksum {
+		logger.Debug("sync agent is deployed")
+		return reconcile.Result{}, nil
+	}
+
+	restConfig, err := r.remoteClusterAPIClientBuilder(cd).RESTConfig()
+	if err != nil {
+		logger.WithError(err).Error("unable to get REST config")
+		return reconcile.Result{}, err
+	}
+	resourceHelper, err := r.resourceHelperBuilder(restConfig, controllerutils.IsFakeCluster(cd), logger)
+	if err != nil {
+		logger.WithError(err).Error("cannot create helper")
+		return reconcile.Result{}, err
+	}
+	for _, obj := range objs {
+		raw, err := json.Marshal(obj)
+		if err != nil {
+			return reconcile.Result{}, errors.Wrap(err, "could not render sync agent resource")
+		}
+		if _, err := resourceHelper.Apply(raw); err != nil {
+			logger.WithError(err).Error("could not deploy sync agent")
+			return reconcile.Result{}, err
+		}
+	}
+
+	logger.Info("sync agent deployed")
+	if serviceAccount.Annotations == nil {
+		serviceAccount.Annotations = map[string]string{}
+	}
+	serviceAccount.Annotations[constants.SyncAgentDeployedAnnotation] = checksum
+	if err := r.Update(context.Background(), serviceAccount); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not record sync agent deployment")
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{}, nil
+}
+
+// syncAgentHubKubeconfig returns the kubeconfig for the Hive API used by the sync agent, which authenticates with the
+// token of the service account of the agent.
+func syncAgentHubKubeconfig(server string, caData []byte, token string) ([]byte, error) {
+	const name = "hub"
+	config := clientcmdv1.Config{
+		Clusters: []clientcmdv1.NamedCluster{{
+			Name: name,
+			Cluster: clientcmdv1.Cluster{
+				Server:                   server,
+				CertificateAuthorityData: caData,
+			},
+		}},
+		AuthInfos: []clientcmdv1.NamedAuthInfo{{
+			Name:     name,
+			AuthInfo: clientcmdv1.AuthInfo{Token: token},
+		}},
+		Contexts: []clientcmdv1.NamedContext{{
+			Name:    name,
+			Context: clientcmdv1.Context{Cluster: name, AuthInfo: name},
+		}},
+		CurrentContext: name,
+	}
+	kubeconfig, err := yaml.Marshal(config)
+	return kubeconfig, errors.Wrap(err, "could not render sync agent kubeconfig")
+}
+
+// syncAgentResources returns the resources of the sync agent in the cluster. The agent manages the resources of the
+// syncsets with cluster-admin permissions, like Hive does through the admin kubeconfig.
+func syncAgentResources(cd *hivev1.ClusterDeployment, hubKubeconfig []byte) []runtime.Object {
+	labels := map[string]string{"app": syncAgentResourceName}
+	return []runtime.Object{
+		&corev1.Namespace{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Namespace"},
+			ObjectMeta: metav1.ObjectMeta{Name: syncAgentNamespace},
+		},
+		&corev1.ServiceAccount{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ServiceAccount"},
+			ObjectMeta: metav1.ObjectMeta{Namespace: syncAgentNamespace, Name: syncAgentResourceName},
+		},
+		&rbacv1.ClusterRoleBinding{
+			TypeMeta:   metav1.TypeMeta{APIVersion: rbacv1.SchemeGroupVersion.String(), Kind: "ClusterRoleBinding"},
+			ObjectMeta: metav1.ObjectMeta{Name: syncAgentResourceName},
+			Subjects: []rbacv1.Subject{{
+				Kind:      rbacv1.ServiceAccountKind,
+				Namespace: syncAgentNamespace,
+				Name:      syncAgentResourceName,
+			}},
+			RoleRef: rbacv1.RoleRef{
+				APIGroup: rbacv1.GroupName,
+				Kind:     "ClusterRole",
+				Name:     "cluster-admin",
+			},
+		},
+		&corev1.Secret{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+			ObjectMeta: metav1.ObjectMeta{Namespace: syncAgentNamespace, Name: syncAgentResourceName},
+			Type:       corev1.SecretTypeOpaque,
+			Data:       map[string][]byte{syncAgentHubKubeconfigKey: hubKubeconfig},
+		},
+		&appsv1.Deployment{
+			TypeMeta:   metav1.TypeMeta{APIVersion: appsv1.SchemeGroupVersion.String(), Kind: "Deployment"},
+			ObjectMeta: metav1.ObjectMeta{Namespace: syncAgentNamespace, Name: syncAgentResourceName},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: pointer.Int32Ptr(1),
+				Selector: &metav1.LabelSelector{MatchLabels: labels},
+				// A single agent applies the syncsets at a time.
+				Strategy: appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType},
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{Labels: labels},
+					Spec: corev1.PodSpec{
+						ServiceAccountName: syncAgentResourceName,
+						Containers: []corev1.Container{{
+							Name:            "sync-agent",
+							Image:           images.GetHiveImage(),
+							ImagePullPolicy: images.GetHiveImagePullPolicy(),
+							Command: []string{
+								"/usr/bin/hiveutil",
+								"sync-agent",
+								fmt.Sprintf("--hub-kubeconfig=%s/%s", syncAgentHubKubeconfigDir, syncAgentHubKubeconfigKey),
+								fmt.Sprintf("--cluster-deployment-namespace=%s", cd.Namespace),
+								fmt.Sprintf("--cluster-deployment-name=%s", cd.Name),
+							},
+							VolumeMounts: []corev1.VolumeMount{{
+								Name:      "hub-kubeconfig",
+								MountPath: syncAgentHubKubeconfigDir,
+								ReadOnly:  true,
+							}},
+						}},
+						Volumes: []corev1.Volume{{
+							Name: "hub-kubeconfig",
+							VolumeSource: corev1.VolumeSource{
+								Secret: &corev1.SecretVolumeSource{SecretName: syncAgentResourceName},
+							},
+						}},
+					},
+				},
+			},
+		},
+	}
+}
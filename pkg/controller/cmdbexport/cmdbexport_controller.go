@@ -0,0 +1,333 @@
+package cmdbexport
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/cmdb"
+	"github.com/openshift/hive/pkg/constants"
+	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	ControllerName = hivev1.CMDBExportControllerName
+
+	// deletedExportTimeout is how long the export of the deletion of a cluster is retried before its
+	// ClusterDeployment is released without it.
+	deletedExportTimeout = time.Hour
+
+	// statusReportInterval is how often the exports of the connectors are reported in the status of the HiveConfig.
+	statusReportInterval = time.Minute
+)
+
+// exportedRecord is the last record of a cluster exported by a connector.
+type exportedRecord struct {
+	Event   hivev1.CMDBLifecycleEvent `json:"event"`
+	Version string                    `json:"version,omitempty"`
+}
+
+// Add creates a new CMDBExport controller and adds it to the manager with default RBAC.
+func Add(mgr manager.Manager) error {
+	logger := log.WithField("controller", ControllerName)
+	concurrentReconciles, clientRateLimiter, queueRateLimiter, err := controllerutils.GetControllerConfig(mgr.GetClient(), ControllerName)
+	if err != nil {
+		logger.WithError(err).Error("could not get controller configurations")
+		return err
+	}
+	r, err := NewReconciler(mgr, clientRateLimiter)
+	if err != nil {
+		logger.WithError(err).Error("could not create reconciler")
+		return err
+	}
+	if err := mgr.Add(r.status); err != nil {
+		logger.WithError(err).Error("could not add connector status reporter")
+		return err
+	}
+	return AddToManager(mgr, r, concurrentReconciles, queueRateLimiter)
+}
+
+// AddDisabled adds the CMDBExport controller to the manager while it is disabled. It runs without connectors, so it
+// only removes the cmdb export finalizers which would otherwise block the deletion of the ClusterDeployments.
+func AddDisabled(mgr manager.Manager) error {
+	logger := log.WithField("controller", ControllerName)
+	concurrentReconciles, clientRateLimiter, queueRateLimiter, err := controllerutils.GetControllerConfig(mgr.GetClient(), ControllerName)
+	if err != nil {
+		logger.WithError(err).Error("could not get controller configurations")
+		return err
+	}
+	return AddToManager(mgr, newDisabledReconciler(controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &clientRateLimiter)), concurrentReconciles, queueRateLimiter)
+}
+
+// newDisabledReconciler returns a ReconcileCMDBExport without connectors, which removes the cmdb export finalizers.
+func newDisabledReconciler(c client.Client) *ReconcileCMDBExport {
+	return &ReconcileCMDBExport{
+		Client: c,
+		now:    time.Now,
+	}
+}
+
+// NewReconciler returns a new ReconcileCMDBExport
+func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) (*ReconcileCMDBExport, error) {
+	config, err := ReadCMDBExportConfigFile()
+	if err != nil {
+		return nil, err
+	}
+	c := controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter)
+	var connectors []hivev1.CMDBConnector
+	if config != nil {
+		connectors = config.Connectors
+	}
+	return &ReconcileCMDBExport{
+		Client:     c,
+		connectors: connectors,
+		status:     newConnectorStatusReporter(c, connectors, statusReportInterval),
+		exporterFn: cmdb.NewExporter,
+		now:        time.Now,
+	}, nil
+}
+
+// AddToManager adds a new Controller to mgr with r as the reconcile.Reconciler
+func AddToManager(mgr manager.Manager, r reconcile.Reconciler, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
+	c, err := controller.New("cmdbexport-controller", mgr, controller.Options{
+		Reconciler:              r,
+		MaxConcurrentReconciles: concurrentReconciles,
+		RateLimiter:             rateLimiter,
+	})
+	if err != nil {
+		log.WithField("controller", ControllerName).WithError(err).Error("Error creating new cmdbexport controller")
+		return err
+	}
+
+	// Watch for changes to ClusterDeployment
+	if err := c.Watch(&source.Kind{Type: &hivev1.ClusterDeployment{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		log.WithField("controller", ControllerName).WithError(err).Error("Error watching cluster deployment")
+		return err
+	}
+	return nil
+}
+
+// ReadCMDBExportConfigFile reads the configuration of the CMDB connectors from the file set in the env, returning
+// nil if the env is not set or the file does not exist.
+func ReadCMDBExportConfigFile() (*hivev1.CMDBExportConfig, error) {
+	fPath := os.Getenv(constants.CMDBExportConfigFileEnvVar)
+	if len(fPath) == 0 {
+		return nil, nil
+	}
+	fileBytes, err := ioutil.ReadFile(fPath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read the cmdb export config file")
+	}
+	config := &hivev1.CMDBExportConfig{}
+	if err := json.Unmarshal(fileBytes, config); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal the cmdb export config file")
+	}
+	return config, nil
+}
+
+var _ reconcile.Reconciler = &ReconcileCMDBExport{}
+
+// ReconcileCMDBExport exports the lifecycle records of the clusters of ClusterDeployments to the configured CMDB
+// connectors. Records which fail to export are retried with the backoff of the work queue.
+type ReconcileCMDBExport struct {
+	client.Client
+
+	connectors []hivev1.CMDBConnector
+	// status reports the exports of the connectors. It is nil while the controller is disabled.
+	status *connectorStatusReporter
+
+	// exporterFn is the function to build the exporter of a connector. Can be stubbed out for testing.
+	exporterFn func(connector *hivev1.CMDBConnector, credentials *corev1.Secret, client *http.Client) (cmdb.Exporter, error)
+	// now is the function to get the current time. Can be stubbed out for testing.
+	now func() time.Time
+}
+
+// Reconcile exports the records of the lifecycle events of the cluster of a ClusterDeployment which the connectors
+// have not exported yet.
+func (r *ReconcileCMDBExport) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	logger := controllerutils.BuildControllerLogger(ControllerName, "clusterDeployment", request.NamespacedName)
+	logger.Info("reconciling cluster deployment")
+	recobsrv := hivemetrics.NewReconcileObserver(ControllerName, logger)
+	defer recobsrv.ObserveControllerReconcileTime()
+
+	cd := &hivev1.ClusterDeployment{}
+	if err := r.Get(context.TODO(), request.NamespacedName, cd); err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Debug("cluster deployment not found")
+			if r.status != nil {
+				r.status.forget(request.String())
+			}
+			return reconcile.Result{}, nil
+		}
+		logger.WithError(err).Error("Error getting cluster deployment")
+		return reconcile.Result{}, err
+	}
+
+	hasFinalizer := controllerutils.HasFinalizer(cd, hivev1.FinalizerCMDBExport)
+	if len(r.connectors) == 0 {
+		if !hasFinalizer {
+			return reconcile.Result{}, nil
+		}
+		logger.Info("removing cmdb export finalizer as no connectors are configured")
+		controllerutils.DeleteFinalizer(cd, hivev1.FinalizerCMDBExport)
+		err := r.Update(context.TODO(), cd)
+		if err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to remove cmdb export finalizer")
+		}
+		return reconcile.Result{}, err
+	}
+
+	deleting := !cd.DeletionTimestamp.IsZero()
+	if !deleting && !hasFinalizer {
+		logger.Info("adding cmdb export finalizer")
+		controllerutils.AddFinalizer(cd, hivev1.FinalizerCMDBExport)
+		err := r.Update(context.TODO(), cd)
+		if err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to add cmdb export finalizer")
+		}
+		return reconcile.Result{}, err
+	}
+
+	exported := map[string]exportedRecord{}
+	if value := cd.Annotations[constants.CMDBExportedAnnotation]; value != "" {
+		if err := json.Unmarshal([]byte(value), &exported); err != nil {
+			logger.WithError(err).Warn("could not parse the exported records annotation, exporting the current records again")
+			exported = map[string]exportedRecord{}
+		}
+	}
+
+	version := cmdb.ClusterVersion(cd)
+	var errs []error
+	changed := false
+	for i := range r.connectors {
+		connector := &r.connectors[i]
+		event := nextEvent(cd, exported[connector.Name], version)
+		if event == "" {
+			continue
+		}
+		cLog := logger.WithFields(log.Fields{"connector": connector.Name, "event": event})
+		if exportsEvent(connector, event) {
+			if err := r.export(connector, cd, event); err != nil {
+				cLog.WithError(err).Error("failed to export cluster lifecycle record")
+				r.status.recordFailure(connector.Name, request.String(), err, r.now())
+				errs = append(errs, errors.Wrapf(err, "connector %s", connector.Name))
+				continue
+			}
+			cLog.Info("exported cluster lifecycle record")
+			r.status.recordExport(connector.Name, request.String(), r.now())
+		}
+		exported[connector.Name] = exportedRecord{Event: event, Version: version}
+		changed = true
+	}
+
+	if deleting && hasFinalizer {
+		switch {
+		case len(errs) == 0:
+			logger.Info("deletion exported to all connectors, removing cmdb export finalizer")
+		case r.now().Sub(cd.DeletionTimestamp.Time) > deletedExportTimeout:
+			logger.WithError(utilerrors.NewAggregate(errs)).Warnf("could not export the deletion for %s, removing cmdb export finalizer", deletedExportTimeout)
+			r.status.forget(request.String())
+			errs = nil
+		}
+		if len(errs) == 0 {
+			controllerutils.DeleteFinalizer(cd, hivev1.FinalizerCMDBExport)
+			changed = true
+		}
+	}
+
+	if changed {
+		value, err := json.Marshal(exported)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		if cd.Annotations == nil {
+			cd.Annotations = map[string]string{}
+		}
+		cd.Annotations[constants.CMDBExportedAnnotation] = string(value)
+		if err := r.Update(context.TODO(), cd); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to update exported records")
+			return reconcile.Result{}, err
+		}
+	}
+	if len(errs) > 0 {
+		return reconcile.Result{}, utilerrors.NewAggregate(errs)
+	}
+	return reconcile.Result{}, nil
+}
+
+// export exports the record of the event of the cluster to the connector.
+func (r *ReconcileCMDBExport) export(connector *hivev1.CMDBConnector, cd *hivev1.ClusterDeployment, event hivev1.CMDBLifecycleEvent) error {
+	var credentials *corev1.Secret
+	if ref := connector.CredentialsSecretRef; ref != nil {
+		credentials = &corev1.Secret{}
+		if err := r.Get(context.TODO(), types.NamespacedName{Namespace: controllerutils.GetHiveNamespace(), Name: ref.Name}, credentials); err != nil {
+			return errors.Wrap(err, "could not get credentials secret")
+		}
+	}
+	exporter, err := r.exporterFn(connector, credentials, nil)
+	if err != nil {
+		return err
+	}
+	return exporter.Export(cmdb.NewRecord(cd, event, r.now()))
+}
+
+// nextEvent returns the lifecycle event of the cluster which follows the last record exported by a connector, or an
+// empty event if the connector is up to date.
+func nextEvent(cd *hivev1.ClusterDeployment, last exportedRecord, version string) hivev1.CMDBLifecycleEvent {
+	switch {
+	case !cd.DeletionTimestamp.IsZero():
+		if last.Event != hivev1.CMDBLifecycleEventDeleted {
+			return hivev1.CMDBLifecycleEventDeleted
+		}
+	case !cd.Spec.Installed:
+		if last.Event == "" {
+			return hivev1.CMDBLifecycleEventCreated
+		}
+	case last.Event == "" || last.Event == hivev1.CMDBLifecycleEventCreated:
+		return hivev1.CMDBLifecycleEventInstalled
+	case version == "" || version == last.Version:
+	case last.Version == "":
+		// The version was not known yet when the installation was exported.
+		return hivev1.CMDBLifecycleEventInstalled
+	default:
+		return hivev1.CMDBLifecycleEventVersionChanged
+	}
+	return ""
+}
+
+// exportsEvent returns whether the connector exports the records of the event.
+func exportsEvent(connector *hivev1.CMDBConnector, event hivev1.CMDBLifecycleEvent) bool {
+	if len(connector.Events) == 0 {
+		return true
+	}
+	for _, e := range connector.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,309 @@
+package cmdbexport
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/openshift/hive/apis"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/cmdb"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+const (
+	testName      = "cluster1"
+	testNamespace = "cluster1namespace"
+)
+
+var testNow = time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+
+type fakeExporter struct {
+	err     error
+	records []*cmdb.Record
+}
+
+func (e *fakeExporter) Export(record *cmdb.Record) error {
+	if e.err != nil {
+		return e.err
+	}
+	e.records = append(e.records, record)
+	return nil
+}
+
+func TestCMDBExportReconcile(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+	log.SetLevel(log.DebugLevel)
+
+	tests := []struct {
+		name            string
+		cd              *hivev1.ClusterDeployment
+		connectors      []hivev1.CMDBConnector
+		exportErr       error
+		expectErr       bool
+		expectEvents    []hivev1.CMDBLifecycleEvent
+		expectExported  map[string]exportedRecord
+		expectFinalizer bool
+		expectPending   int
+	}{
+		{
+			name:            "add finalizer",
+			cd:              testClusterDeployment(),
+			connectors:      []hivev1.CMDBConnector{testConnector("cmdb")},
+			expectFinalizer: true,
+		},
+		{
+			name:            "export created",
+			cd:              withFinalizer(testClusterDeployment()),
+			connectors:      []hivev1.CMDBConnector{testConnector("cmdb")},
+			expectEvents:    []hivev1.CMDBLifecycleEvent{hivev1.CMDBLifecycleEventCreated},
+			expectExported:  map[string]exportedRecord{"cmdb": {Event: hivev1.CMDBLifecycleEventCreated}},
+			expectFinalizer: true,
+		},
+		{
+			name: "export installed",
+			cd: withExported(withFinalizer(installed(testClusterDeployment(), "4.9.0")),
+				map[string]exportedRecord{"cmdb": {Event: hivev1.CMDBLifecycleEventCreated}}),
+			connectors:      []hivev1.CMDBConnector{testConnector("cmdb")},
+			expectEvents:    []hivev1.CMDBLifecycleEvent{hivev1.CMDBLifecycleEventInstalled},
+			expectExported:  map[string]exportedRecord{"cmdb": {Event: hivev1.CMDBLifecycleEventInstalled, Version: "4.9.0"}},
+			expectFinalizer: true,
+		},
+		{
+			name: "export version changed",
+			cd: withExported(withFinalizer(installed(testClusterDeployment(), "4.9.1")),
+				map[string]exportedRecord{"cmdb": {Event: hivev1.CMDBLifecycleEventInstalled, Version: "4.9.0"}}),
+			connectors:      []hivev1.CMDBConnector{testConnector("cmdb")},
+			expectEvents:    []hivev1.CMDBLifecycleEvent{hivev1.CMDBLifecycleEventVersionChanged},
+			expectExported:  map[string]exportedRecord{"cmdb": {Event: hivev1.CMDBLifecycleEventVersionChanged, Version: "4.9.1"}},
+			expectFinalizer: true,
+		},
+		{
+			name: "up to date",
+			cd: withExported(withFinalizer(installed(testClusterDeployment(), "4.9.0")),
+				map[string]exportedRecord{"cmdb": {Event: hivev1.CMDBLifecycleEventInstalled, Version: "4.9.0"}}),
+			connectors:      []hivev1.CMDBConnector{testConnector("cmdb")},
+			expectExported:  map[string]exportedRecord{"cmdb": {Event: hivev1.CMDBLifecycleEventInstalled, Version: "4.9.0"}},
+			expectFinalizer: true,
+		},
+		{
+			name: "event not exported by connector",
+			cd:   withFinalizer(installed(testClusterDeployment(), "4.9.0")),
+			connectors: []hivev1.CMDBConnector{
+				testConnector("cmdb", hivev1.CMDBLifecycleEventDeleted),
+				testConnector("other"),
+			},
+			expectEvents: []hivev1.CMDBLifecycleEvent{hivev1.CMDBLifecycleEventInstalled},
+			expectExported: map[string]exportedRecord{
+				"cmdb":  {Event: hivev1.CMDBLifecycleEventInstalled, Version: "4.9.0"},
+				"other": {Event: hivev1.CMDBLifecycleEventInstalled, Version: "4.9.0"},
+			},
+			expectFinalizer: true,
+		},
+		{
+			name:            "export fails",
+			cd:              withFinalizer(testClusterDeployment()),
+			connectors:      []hivev1.CMDBConnector{testConnector("cmdb")},
+			exportErr:       errors.New("unavailable"),
+			expectErr:       true,
+			expectFinalizer: true,
+			expectPending:   1,
+		},
+		{
+			name: "export deleted",
+			cd: deleting(withExported(withFinalizer(installed(testClusterDeployment(), "4.9.0")),
+				map[string]exportedRecord{"cmdb": {Event: hivev1.CMDBLifecycleEventInstalled, Version: "4.9.0"}}), time.Minute),
+			connectors:     []hivev1.CMDBConnector{testConnector("cmdb")},
+			expectEvents:   []hivev1.CMDBLifecycleEvent{hivev1.CMDBLifecycleEventDeleted},
+			expectExported: map[string]exportedRecord{"cmdb": {Event: hivev1.CMDBLifecycleEventDeleted, Version: "4.9.0"}},
+		},
+		{
+			name:            "export deleted fails",
+			cd:              deleting(withFinalizer(installed(testClusterDeployment(), "4.9.0")), time.Minute),
+			connectors:      []hivev1.CMDBConnector{testConnector("cmdb")},
+			exportErr:       errors.New("unavailable"),
+			expectErr:       true,
+			expectFinalizer: true,
+			expectPending:   1,
+		},
+		{
+			name:           "export deleted times out",
+			cd:             deleting(withFinalizer(installed(testClusterDeployment(), "4.9.0")), 2*time.Hour),
+			connectors:     []hivev1.CMDBConnector{testConnector("cmdb")},
+			exportErr:      errors.New("unavailable"),
+			expectExported: map[string]exportedRecord{},
+		},
+		{
+			name: "no connectors",
+			cd:   withFinalizer(testClusterDeployment()),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := fake.NewFakeClientWithScheme(scheme.Scheme, test.cd)
+			exporter := &fakeExporter{err: test.exportErr}
+			r := &ReconcileCMDBExport{
+				Client:     c,
+				connectors: test.connectors,
+				status:     newConnectorStatusReporter(c, test.connectors, time.Minute),
+				exporterFn: func(*hivev1.CMDBConnector, *corev1.Secret, *http.Client) (cmdb.Exporter, error) {
+					return exporter, nil
+				},
+				now: func() time.Time { return testNow },
+			}
+
+			_, err := r.Reconcile(reconcile.Request{NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: testName}})
+			if test.expectErr {
+				assert.Error(t, err, "expected error from reconcile")
+			} else {
+				assert.NoError(t, err, "unexpected error from reconcile")
+			}
+
+			var events []hivev1.CMDBLifecycleEvent
+			for _, record := range exporter.records {
+				events = append(events, record.Event)
+				assert.Equal(t, "test-uid", record.UID, "unexpected record UID")
+			}
+			assert.Equal(t, test.expectEvents, events, "unexpected exported events")
+			assert.Equal(t, test.expectPending, r.status.pending["cmdb"].Len(), "unexpected pending clusters")
+
+			cd := &hivev1.ClusterDeployment{}
+			err = c.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: testName}, cd)
+			require.NoError(t, err, "unexpected error getting cluster deployment")
+			assert.Equal(t, test.expectFinalizer, hasCMDBExportFinalizer(cd), "unexpected finalizer")
+			var exported map[string]exportedRecord
+			if value, ok := cd.Annotations[constants.CMDBExportedAnnotation]; ok {
+				require.NoError(t, json.Unmarshal([]byte(value), &exported), "could not parse exported records")
+			}
+			assert.Equal(t, test.expectExported, exported, "unexpected exported records")
+		})
+	}
+}
+
+func TestCMDBExportDisabled(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+	for _, cd := range []*hivev1.ClusterDeployment{
+		withFinalizer(testClusterDeployment()),
+		deleting(withFinalizer(installed(testClusterDeployment(), "4.9.0")), time.Minute),
+	} {
+		c := fake.NewFakeClientWithScheme(scheme.Scheme, cd)
+		r := newDisabledReconciler(c)
+		_, err := r.Reconcile(reconcile.Request{NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: testName}})
+		require.NoError(t, err, "unexpected error from reconcile")
+		result := &hivev1.ClusterDeployment{}
+		require.NoError(t, c.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: testName}, result), "unexpected error getting cluster deployment")
+		assert.False(t, hasCMDBExportFinalizer(result), "unexpected finalizer")
+	}
+
+	r := newDisabledReconciler(fake.NewFakeClientWithScheme(scheme.Scheme))
+	_, err := r.Reconcile(reconcile.Request{NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: testName}})
+	assert.NoError(t, err, "unexpected error from reconcile of missing cluster deployment")
+}
+
+func TestConnectorStatusReporter(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+
+	earlier := metav1.NewTime(testNow.Add(-time.Hour))
+	hc := &hivev1.HiveConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: constants.HiveConfigName},
+		Status: hivev1.HiveConfigStatus{
+			CMDBConnectors: []hivev1.CMDBConnectorStatus{
+				{Name: "cmdb", LastExportTime: &earlier},
+				{Name: "removed", LastExportTime: &earlier},
+			},
+		},
+	}
+	c := fake.NewFakeClientWithScheme(scheme.Scheme, hc)
+	s := newConnectorStatusReporter(c, []hivev1.CMDBConnector{testConnector("cmdb"), testConnector("other")}, time.Minute)
+	s.recordFailure("cmdb", "ns/a", errors.New("unavailable"), testNow)
+	s.recordFailure("cmdb", "ns/b", errors.New("unavailable"), testNow)
+	s.recordExport("other", "ns/a", testNow)
+	s.forget("ns/b")
+	require.NoError(t, s.report(), "unexpected error reporting status")
+
+	require.NoError(t, c.Get(context.TODO(), types.NamespacedName{Name: constants.HiveConfigName}, hc), "unexpected error getting hiveconfig")
+	now := metav1.NewTime(testNow)
+	expected := []hivev1.CMDBConnectorStatus{
+		{Name: "cmdb", LastExportTime: &earlier, LastFailureTime: &now, LastError: "unavailable", PendingClusters: 1},
+		{Name: "other", LastExportTime: &now},
+	}
+	if assert.Len(t, hc.Status.CMDBConnectors, len(expected), "unexpected connector statuses") {
+		for i, status := range hc.Status.CMDBConnectors {
+			assert.Equal(t, expected[i].Name, status.Name, "unexpected connector name")
+			assert.True(t, expected[i].LastExportTime.Equal(status.LastExportTime), "unexpected last export time")
+			assert.True(t, expected[i].LastFailureTime.Equal(status.LastFailureTime), "unexpected last failure time")
+			assert.Equal(t, expected[i].LastError, status.LastError, "unexpected last error")
+			assert.Equal(t, expected[i].PendingClusters, status.PendingClusters, "unexpected pending clusters")
+		}
+	}
+	assert.False(t, s.dirty, "expected reported statuses to be clean")
+}
+
+func testConnector(name string, events ...hivev1.CMDBLifecycleEvent) hivev1.CMDBConnector {
+	return hivev1.CMDBConnector{
+		Name:   name,
+		Type:   hivev1.CMDBConnectorTypeREST,
+		URL:    "https://cmdb.example.com/clusters",
+		Events: events,
+	}
+}
+
+func testClusterDeployment() *hivev1.ClusterDeployment {
+	return &hivev1.ClusterDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testName,
+			Namespace: testNamespace,
+			UID:       "test-uid",
+		},
+		Spec: hivev1.ClusterDeploymentSpec{
+			ClusterName: testName,
+		},
+	}
+}
+
+func installed(cd *hivev1.ClusterDeployment, version string) *hivev1.ClusterDeployment {
+	cd.Spec.Installed = true
+	cd.Spec.ClusterMetadata = &hivev1.ClusterMetadata{InfraID: "cluster1-abcde"}
+	cd.Labels = map[string]string{constants.VersionMajorMinorPatchLabel: version}
+	return cd
+}
+
+func withFinalizer(cd *hivev1.ClusterDeployment) *hivev1.ClusterDeployment {
+	cd.Finalizers = append(cd.Finalizers, hivev1.FinalizerCMDBExport)
+	return cd
+}
+
+func withExported(cd *hivev1.ClusterDeployment, exported map[string]exportedRecord) *hivev1.ClusterDeployment {
+	value, _ := json.Marshal(exported)
+	cd.Annotations = map[string]string{constants.CMDBExportedAnnotation: string(value)}
+	return cd
+}
+
+func deleting(cd *hivev1.ClusterDeployment, since time.Duration) *hivev1.ClusterDeployment {
+	deletionTimestamp := metav1.NewTime(testNow.Add(-since))
+	cd.DeletionTimestamp = &deletionTimestamp
+	return cd
+}
+
+func hasCMDBExportFinalizer(cd *hivev1.ClusterDeployment) bool {
+	for _, f := range cd.Finalizers {
+		if f == hivev1.FinalizerCMDBExport {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,145 @@
+package cmdbexport
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+// connectorStatusReporter tracks the exports of the connectors and periodically reports them in the status of the
+// HiveConfig, so that a burst of exports results in a single update of the HiveConfig.
+type connectorStatusReporter struct {
+	client   client.Client
+	interval time.Duration
+
+	mutex    sync.Mutex
+	names    []string
+	statuses map[string]*hivev1.CMDBConnectorStatus
+	pending  map[string]sets.String
+	// dirty is set when the statuses changed since they were last reported. It starts set so that connectors which
+	// are no longer configured are removed from the status of the HiveConfig.
+	dirty bool
+}
+
+func newConnectorStatusReporter(c client.Client, connectors []hivev1.CMDBConnector, interval time.Duration) *connectorStatusReporter {
+	s := &connectorStatusReporter{
+		client:   c,
+		interval: interval,
+		statuses: map[string]*hivev1.CMDBConnectorStatus{},
+		pending:  map[string]sets.String{},
+		dirty:    true,
+	}
+	for _, connector := range connectors {
+		s.names = append(s.names, connector.Name)
+		s.statuses[connector.Name] = &hivev1.CMDBConnectorStatus{Name: connector.Name}
+		s.pending[connector.Name] = sets.NewString()
+	}
+	return s
+}
+
+// recordExport records that the connector exported a record of the cluster.
+func (s *connectorStatusReporter) recordExport(connector, cluster string, now time.Time) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	t := metav1.NewTime(now)
+	s.statuses[connector].LastExportTime = &t
+	s.pending[connector].Delete(cluster)
+	s.dirty = true
+}
+
+// recordFailure records that the connector failed to export a record of the cluster.
+func (s *connectorStatusReporter) recordFailure(connector, cluster string, err error, now time.Time) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	t := metav1.NewTime(now)
+	s.statuses[connector].LastFailureTime = &t
+	s.statuses[connector].LastError = err.Error()
+	s.pending[connector].Insert(cluster)
+	s.dirty = true
+}
+
+// forget stops counting the records of the cluster which are pending, as they will not be retried.
+func (s *connectorStatusReporter) forget(cluster string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for _, pending := range s.pending {
+		if pending.Has(cluster) {
+			pending.Delete(cluster)
+			s.dirty = true
+		}
+	}
+}
+
+// Start reports the statuses of the connectors periodically until the stop channel is closed.
+func (s *connectorStatusReporter) Start(stopCh <-chan struct{}) error {
+	wait.Until(func() {
+		if err := s.report(); err != nil {
+			log.WithField("controller", ControllerName).WithError(err).Error("failed to report cmdb connector status")
+		}
+	}, s.interval, stopCh)
+	return nil
+}
+
+// report updates the status of the HiveConfig with the statuses of the connectors, if they changed. Times which
+// were reported before the controller started are kept.
+func (s *connectorStatusReporter) report() error {
+	s.mutex.Lock()
+	if !s.dirty {
+		s.mutex.Unlock()
+		return nil
+	}
+	var statuses []hivev1.CMDBConnectorStatus
+	for _, name := range s.names {
+		status := s.statuses[name].DeepCopy()
+		status.PendingClusters = s.pending[name].Len()
+		statuses = append(statuses, *status)
+	}
+	s.dirty = false
+	s.mutex.Unlock()
+
+	if err := s.update(statuses); err != nil {
+		s.mutex.Lock()
+		s.dirty = true
+		s.mutex.Unlock()
+		return err
+	}
+	return nil
+}
+
+func (s *connectorStatusReporter) update(statuses []hivev1.CMDBConnectorStatus) error {
+	hc := &hivev1.HiveConfig{}
+	if err := s.client.Get(context.TODO(), types.NamespacedName{Name: constants.HiveConfigName}, hc); err != nil {
+		return err
+	}
+	for i := range statuses {
+		for _, reported := range hc.Status.CMDBConnectors {
+			if reported.Name != statuses[i].Name {
+				continue
+			}
+			if statuses[i].LastExportTime == nil {
+				statuses[i].LastExportTime = reported.LastExportTime
+			}
+			if statuses[i].LastFailureTime == nil {
+				statuses[i].LastFailureTime = reported.LastFailureTime
+				statuses[i].LastError = reported.LastError
+			}
+		}
+	}
+	if reflect.DeepEqual(hc.Status.CMDBConnectors, statuses) {
+		return nil
+	}
+	hc.Status.CMDBConnectors = statuses
+	return s.client.Status().Update(context.TODO(), hc)
+}
@@ -15,7 +15,9 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -30,8 +32,11 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
+	configv1 "github.com/openshift/api/config/v1"
+
 	apihelpers "github.com/openshift/hive/apis/helpers"
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hiveintv1alpha1 "github.com/openshift/hive/apis/hiveinternal/v1alpha1"
 	"github.com/openshift/hive/pkg/constants"
 	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
@@ -49,11 +54,27 @@ const (
 	certsFoundReason     = "ControlPlaneCertificatesFound"
 	certsFoundMessage    = "Control plane certificates are present"
 
+	certsSyncedReason       = "ControlPlaneCertificatesRolledOut"
+	certsSyncedMessage      = "Control plane certificates have been synced to the cluster and rolled out to the kube-apiserver"
+	certsRollingOutReason   = "ControlPlaneCertificatesRollingOut"
+	certsRollingOutMessage  = "Control plane certificates have been synced to the cluster and are being rolled out to the kube-apiserver"
+	certsSyncPendingReason  = "ControlPlaneCertificatesSyncPending"
+	certsSyncPendingMessage = "Control plane certificates have not been synced to the cluster yet"
+	certsSyncFailedReason   = "ControlPlaneCertificatesSyncFailed"
+
+	certManagerAPIVersion  = "cert-manager.io/v1"
+	certManagerGroup       = "cert-manager.io"
+	certManagerIssuerKind  = "Issuer"
+	certManagerCertificate = "Certificate"
+
 	kubeAPIServerPatchTemplate = `[ {"op": "replace", "path": "/spec/forceRedeploymentReason", "value": %q } ]`
+
+	kubeAPIServerOperatorName = "kube-apiserver"
 )
 
 var (
-	secretCheckInterval = 2 * time.Minute
+	secretCheckInterval  = 2 * time.Minute
+	rolloutCheckInterval = time.Minute
 )
 
 type applier interface {
@@ -85,6 +106,9 @@ func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) rec
 		scheme:  mgr.GetScheme(),
 		applier: helper,
 	}
+	r.remoteClusterAPIClientBuilder = func(cd *hivev1.ClusterDeployment) remoteclient.Builder {
+		return remoteclient.NewBuilder(r.Client, cd, ControllerName)
+	}
 
 	return r
 }
@@ -107,9 +131,63 @@ func AddToManager(mgr manager.Manager, r reconcile.Reconciler, concurrentReconci
 		return err
 	}
 
+	// Watch for changes to the secrets of the certificate bundles served by the control plane, so that renewed
+	// certificates are synced to the cluster
+	err = c.Watch(&source.Kind{Type: &corev1.Secret{}}, &handler.EnqueueRequestsFromMapFunc{
+		ToRequests: requestsForCertificateBundleSecret(mgr.GetClient()),
+	})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to ClusterSyncs to track whether the control plane certificates have been synced
+	err = c.Watch(&source.Kind{Type: &hiveintv1alpha1.ClusterSync{}}, &handler.EnqueueRequestsFromMapFunc{
+		ToRequests: handler.ToRequestsFunc(requestsForClusterSync),
+	})
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
+// requestsForClusterSync enqueues the cluster deployment of a ClusterSync only when the ClusterSync tracks the control
+// plane certificates syncset, so that the syncs of clusters without control plane certificates are ignored.
+func requestsForClusterSync(o handler.MapObject) []reconcile.Request {
+	clusterSync, ok := o.Object.(*hiveintv1alpha1.ClusterSync)
+	if !ok {
+		return nil
+	}
+	syncSetName := GenerateControlPlaneCertsSyncSetName(clusterSync.Name)
+	for _, syncStatus := range clusterSync.Status.SyncSets {
+		if syncStatus.Name == syncSetName {
+			return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: clusterSync.Namespace, Name: clusterSync.Name}}}
+		}
+	}
+	return nil
+}
+
+func requestsForCertificateBundleSecret(c client.Client) handler.ToRequestsFunc {
+	return func(o handler.MapObject) []reconcile.Request {
+		cds := &hivev1.ClusterDeploymentList{}
+		if err := c.List(context.TODO(), cds, client.InNamespace(o.Meta.GetNamespace())); err != nil {
+			log.WithField("controller", ControllerName).WithError(err).Error("error listing cluster deployments")
+			return nil
+		}
+		var requests []reconcile.Request
+		for i := range cds.Items {
+			cd := &cds.Items[i]
+			for _, name := range controlPlaneCertificateBundleNames(cd) {
+				if bundle := certificateBundle(cd, name); bundle != nil && bundle.CertificateSecretRef.Name == o.Meta.GetName() {
+					requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: cd.Namespace, Name: cd.Name}})
+					break
+				}
+			}
+		}
+		return requests
+	}
+}
+
 var _ reconcile.Reconciler = &ReconcileControlPlaneCerts{}
 
 // ReconcileControlPlaneCerts reconciles a ClusterDeployment object
@@ -117,6 +195,10 @@ type ReconcileControlPlaneCerts struct {
 	client.Client
 	scheme  *runtime.Scheme
 	applier applier
+
+	// remoteClusterAPIClientBuilder is a function pointer to the function that gets a builder for building a client
+	// for the remote cluster's API server
+	remoteClusterAPIClientBuilder func(cd *hivev1.ClusterDeployment) remoteclient.Builder
 }
 
 // Reconcile reads that state of the cluster for a ClusterDeployment object and makes changes based on the state read
@@ -164,6 +246,11 @@ func (r *ReconcileControlPlaneCerts) Reconcile(request reconcile.Request) (recon
 		existingSyncSet = nil
 	}
 
+	if err := r.reconcileCertManagerCertificates(cd, existingSyncSet != nil, cdLog); err != nil {
+		cdLog.WithError(err).Error("failed to reconcile cert-manager certificates")
+		return reconcile.Result{}, err
+	}
+
 	secrets, secretsAvailable, err := r.getControlPlaneSecrets(cd, cdLog)
 	if err != nil {
 		cdLog.WithError(err).Error("failed to check cert secret availability")
@@ -196,14 +283,131 @@ func (r *ReconcileControlPlaneCerts) Reconcile(request reconcile.Request) (recon
 		return reconcile.Result{}, err
 	}
 
-	if _, err = r.applier.ApplyRuntimeObject(desiredSyncSet, r.scheme); err != nil {
+	applyResult, err := r.applier.ApplyRuntimeObject(desiredSyncSet, r.scheme)
+	if err != nil {
 		cdLog.WithError(err).Error("failed to apply control plane certificates syncset")
 		return reconcile.Result{}, err
 	}
 
+	// A changed syncset has not been synced to the cluster yet. The ClusterSync of the cluster deployment will be
+	// updated once it has, which triggers another reconcile.
+	var syncedSyncSet *hivev1.SyncSet
+	if applyResult == resource.UnchangedApplyResult {
+		syncedSyncSet = existingSyncSet
+	}
+	rollingOut, err := r.setCertsSyncedCondition(cd, syncedSyncSet, cdLog)
+	if err != nil {
+		cdLog.WithError(err).Log(controllerutils.LogLevel(err), "cannot update cluster deployment certificates synced condition")
+		return reconcile.Result{}, err
+	}
+	if rollingOut {
+		cdLog.Debugf("control plane certificates are being rolled out, requeueing clusterdeployment for %s", rolloutCheckInterval)
+		return reconcile.Result{RequeueAfter: rolloutCheckInterval}, nil
+	}
+
 	return reconcile.Result{}, nil
 }
 
+// reconcileCertManagerCertificates applies the cert-manager Certificates of the certificate bundles which are served
+// by the control plane and issued by cert-manager. cert-manager stores the issued certificates in the secrets of the
+// bundles, and renews them in place. Certificates of bundles which are no longer served by the control plane are
+// deleted. They are only looked for when the control plane has served certificates, as indicated by existingSyncSet.
+func (r *ReconcileControlPlaneCerts) reconcileCertManagerCertificates(cd *hivev1.ClusterDeployment, existingSyncSet bool, cdLog log.FieldLogger) error {
+	bundleDomains := map[string][]string{}
+	if name := cd.Spec.ControlPlaneConfig.ServingCertificates.Default; name != "" {
+		if bundle := certificateBundle(cd, name); bundle != nil && bundle.CertManager != nil {
+			apidomain, err := r.defaultControlPlaneDomain(cd)
+			if err != nil {
+				return err
+			}
+			bundleDomains[name] = append(bundleDomains[name], apidomain)
+		}
+	}
+	for _, additional := range cd.Spec.ControlPlaneConfig.ServingCertificates.Additional {
+		bundleDomains[additional.Name] = append(bundleDomains[additional.Name], additional.Domain)
+	}
+
+	desired := sets.NewString()
+	for i := range cd.Spec.CertificateBundles {
+		bundle := &cd.Spec.CertificateBundles[i]
+		domains := bundleDomains[bundle.Name]
+		if bundle.CertManager == nil || len(domains) == 0 {
+			continue
+		}
+		cert := generateCertManagerCertificate(cd, bundle, sets.NewString(domains...).List())
+		desired.Insert(cert.GetName())
+		if err := controllerutil.SetControllerReference(cd, cert, r.scheme); err != nil {
+			return errors.Wrap(err, "error setting owner reference")
+		}
+		cdLog.WithField("certificate", cert.GetName()).Debug("applying cert-manager certificate")
+		if _, err := r.applier.ApplyRuntimeObject(cert, r.scheme); err != nil {
+			return errors.Wrapf(err, "failed to apply cert-manager certificate %s", cert.GetName())
+		}
+	}
+
+	if !existingSyncSet && desired.Len() == 0 {
+		return nil
+	}
+	certs := &unstructured.UnstructuredList{}
+	certs.SetAPIVersion(certManagerAPIVersion)
+	certs.SetKind(certManagerCertificate + "List")
+	err := r.List(context.TODO(), certs,
+		client.InNamespace(cd.Namespace),
+		client.MatchingLabels{constants.ClusterDeploymentNameLabel: cd.Name})
+	if err != nil {
+		if meta.IsNoMatchError(err) {
+			// cert-manager is not installed, so there cannot be any certificates to clean up
+			return nil
+		}
+		return errors.Wrap(err, "failed to list cert-manager certificates")
+	}
+	for i := range certs.Items {
+		cert := &certs.Items[i]
+		if desired.Has(cert.GetName()) || !metav1.IsControlledBy(cert, cd) {
+			continue
+		}
+		cdLog.WithField("certificate", cert.GetName()).Info("deleting cert-manager certificate which is no longer served by the control plane")
+		if err := r.Delete(context.TODO(), cert); err != nil && !apierrors.IsNotFound(err) {
+			return errors.Wrapf(err, "failed to delete cert-manager certificate %s", cert.GetName())
+		}
+	}
+	return nil
+}
+
+func generateCertManagerCertificate(cd *hivev1.ClusterDeployment, bundle *hivev1.CertificateBundleSpec, domains []string) *unstructured.Unstructured {
+	issuerRef := bundle.CertManager.IssuerRef
+	kind := issuerRef.Kind
+	if kind == "" {
+		kind = certManagerIssuerKind
+	}
+	group := issuerRef.Group
+	if group == "" {
+		group = certManagerGroup
+	}
+	dnsNames := make([]interface{}, len(domains))
+	for i, domain := range domains {
+		dnsNames[i] = domain
+	}
+
+	cert := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"secretName": bundle.CertificateSecretRef.Name,
+			"dnsNames":   dnsNames,
+			"issuerRef": map[string]interface{}{
+				"name":  issuerRef.Name,
+				"kind":  kind,
+				"group": group,
+			},
+		},
+	}}
+	cert.SetAPIVersion(certManagerAPIVersion)
+	cert.SetKind(certManagerCertificate)
+	cert.SetNamespace(cd.Namespace)
+	cert.SetName(apihelpers.GetResourceName(cd.Name, bundle.Name))
+	cert.SetLabels(map[string]string{constants.ClusterDeploymentNameLabel: cd.Name})
+	return cert
+}
+
 func (r *ReconcileControlPlaneCerts) getControlPlaneSecrets(cd *hivev1.ClusterDeployment, cdLog log.FieldLogger) ([]*corev1.Secret, bool, error) {
 	secretsNeeded, err := getControlPlaneSecretNames(cd, cdLog)
 	if err != nil {
@@ -232,7 +436,8 @@ func (r *ReconcileControlPlaneCerts) getControlPlaneSecrets(cd *hivev1.ClusterDe
 	return secrets, true, nil
 }
 
-func getControlPlaneSecretNames(cd *hivev1.ClusterDeployment, cdLog log.FieldLogger) ([]string, error) {
+// controlPlaneCertificateBundleNames returns the names of the certificate bundles served by the control plane.
+func controlPlaneCertificateBundleNames(cd *hivev1.ClusterDeployment) []string {
 	certs := sets.NewString()
 	if cd.Spec.ControlPlaneConfig.ServingCertificates.Default != "" {
 		certs.Insert(cd.Spec.ControlPlaneConfig.ServingCertificates.Default)
@@ -241,13 +446,18 @@ func getControlPlaneSecretNames(cd *hivev1.ClusterDeployment, cdLog log.FieldLog
 	for _, additional := range cd.Spec.ControlPlaneConfig.ServingCertificates.Additional {
 		certs.Insert(additional.Name)
 	}
-	if certs.Len() == 0 {
+	return certs.List()
+}
+
+func getControlPlaneSecretNames(cd *hivev1.ClusterDeployment, cdLog log.FieldLogger) ([]string, error) {
+	certs := controlPlaneCertificateBundleNames(cd)
+	if len(certs) == 0 {
 		return nil, nil
 	}
-	cdLog.WithField("certbundles", certs.List()).Debug("cert bundles used by the control plane")
+	cdLog.WithField("certbundles", certs).Debug("cert bundles used by the control plane")
 
 	secretsNeeded := sets.NewString()
-	for _, cert := range certs.List() {
+	for _, cert := range certs {
 		bundle := certificateBundle(cd, cert)
 		if bundle == nil {
 			// should not happen if clusterdeployment was validated
@@ -269,7 +479,9 @@ func (r *ReconcileControlPlaneCerts) generateControlPlaneCertsSyncSet(cd *hivev1
 		},
 		Spec: hivev1.SyncSetSpec{
 			SyncSetCommonSpec: hivev1.SyncSetCommonSpec{
-				ResourceApplyMode: hivev1.UpsertResourceApplyMode,
+				// Sync mode deletes the secrets of certificate bundles from the cluster once they are no longer
+				// served by the control plane.
+				ResourceApplyMode: hivev1.SyncResourceApplyMode,
 			},
 			ClusterDeploymentRefs: []corev1.LocalObjectReference{
 				{
@@ -397,6 +609,101 @@ func (r *ReconcileControlPlaneCerts) setCertsNotFoundCondition(cd *hivev1.Cluste
 	return true, r.Status().Update(context.TODO(), cd)
 }
 
+// setCertsSyncedCondition sets the certificates synced condition from the result of syncing the control plane
+// certificates syncset to the cluster, and of rolling out the synced certificates to the kube-apiserver. The syncset
+// is nil when it has just been changed. Returns whether the certificates are being rolled out.
+func (r *ReconcileControlPlaneCerts) setCertsSyncedCondition(cd *hivev1.ClusterDeployment, syncSet *hivev1.SyncSet, cdLog log.FieldLogger) (bool, error) {
+	status := corev1.ConditionFalse
+	reason := certsSyncPendingReason
+	message := certsSyncPendingMessage
+	rollingOut := false
+	if syncSet != nil {
+		clusterSync := &hiveintv1alpha1.ClusterSync{}
+		err := r.Get(context.TODO(), types.NamespacedName{Namespace: cd.Namespace, Name: cd.Name}, clusterSync)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return false, err
+		}
+		for _, syncStatus := range clusterSync.Status.SyncSets {
+			if syncStatus.Name != syncSet.Name || syncStatus.ObservedGeneration != syncSet.Generation {
+				continue
+			}
+			switch syncStatus.Result {
+			case hiveintv1alpha1.SuccessSyncSetResult:
+				rolledOut, err := r.kubeAPIServerRolledOut(cd, syncStatus.LastTransitionTime, cdLog)
+				if err != nil {
+					return false, err
+				}
+				if rolledOut {
+					status = corev1.ConditionTrue
+					reason = certsSyncedReason
+					message = certsSyncedMessage
+				} else {
+					rollingOut = true
+					reason = certsRollingOutReason
+					message = certsRollingOutMessage
+				}
+			case hiveintv1alpha1.FailureSyncSetResult:
+				reason = certsSyncFailedReason
+				message = syncStatus.FailureMessage
+			}
+		}
+	}
+
+	conds, changed := controllerutils.SetClusterDeploymentConditionWithChangeCheck(
+		cd.Status.Conditions,
+		hivev1.ControlPlaneCertificatesSyncedCondition,
+		status,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	if !changed {
+		return rollingOut, nil
+	}
+	cdLog.WithField("reason", reason).Info("control plane certificates synced condition changed")
+	cd.Status.Conditions = conds
+	return rollingOut, r.Status().Update(context.TODO(), cd)
+}
+
+// kubeAPIServerRolledOut returns whether the kube-apiserver of the cluster has finished rolling out a new revision
+// since the control plane certificates were synced at the specified time. The patches of the control plane
+// certificates syncset always cause a new revision, during which the kube-apiserver cluster operator is progressing.
+func (r *ReconcileControlPlaneCerts) kubeAPIServerRolledOut(cd *hivev1.ClusterDeployment, syncedAt metav1.Time, cdLog log.FieldLogger) (bool, error) {
+	if controllerutils.IsFakeCluster(cd) {
+		return true, nil
+	}
+	remoteClient, unreachable, _ := remoteclient.ConnectToRemoteCluster(
+		cd,
+		r.remoteClusterAPIClientBuilder(cd),
+		r.Client,
+		cdLog,
+	)
+	if unreachable {
+		cdLog.Debug("cluster is unreachable, cannot check the rollout of the control plane certificates")
+		return false, nil
+	}
+	operator := &configv1.ClusterOperator{}
+	switch err := remoteClient.Get(context.TODO(), types.NamespacedName{Name: kubeAPIServerOperatorName}, operator); {
+	case apierrors.IsNotFound(err):
+		cdLog.Warn("kube-apiserver cluster operator not found")
+		return false, nil
+	case err != nil:
+		return false, errors.Wrap(err, "failed to get kube-apiserver cluster operator")
+	}
+	var available, progressing *configv1.ClusterOperatorStatusCondition
+	for i, cond := range operator.Status.Conditions {
+		switch cond.Type {
+		case configv1.OperatorAvailable:
+			available = &operator.Status.Conditions[i]
+		case configv1.OperatorProgressing:
+			progressing = &operator.Status.Conditions[i]
+		}
+	}
+	return available != nil && available.Status == configv1.ConditionTrue &&
+		progressing != nil && progressing.Status == configv1.ConditionFalse &&
+		progressing.LastTransitionTime.After(syncedAt.Time), nil
+}
+
 // defaultControlPlaneDomain will attempt to return the domain/hostname for the secondary API URL
 // for the cluster based on the contents of the clusterDeployment's adminKubeConfig secret.
 func (r *ReconcileControlPlaneCerts) defaultControlPlaneDomain(cd *hivev1.ClusterDeployment) (string, error) {
@@ -5,6 +5,7 @@ import (
 	"crypto/rand"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	log "github.com/sirupsen/logrus"
@@ -12,22 +13,29 @@ import (
 	"github.com/stretchr/testify/require"
 
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/kubernetes/scheme"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	openshiftapiv1 "github.com/openshift/api/config/v1"
 
 	"github.com/openshift/hive/apis"
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hiveintv1alpha1 "github.com/openshift/hive/apis/hiveinternal/v1alpha1"
 	"github.com/openshift/hive/pkg/constants"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/remoteclient"
+	remoteclientmock "github.com/openshift/hive/pkg/remoteclient/mock"
 	"github.com/openshift/hive/pkg/resource"
 	testsecret "github.com/openshift/hive/pkg/test/secret"
 )
@@ -39,6 +47,7 @@ const (
 	fakeAPIURL           = "https://test-api-url:6443"
 	fakeAPIURLDomain     = "test-api-url"
 	kubeconfigSecretName = "test-kubeconfig"
+	syncedAt             = 10 * time.Minute
 	adminKubeconfig      = `clusters:
 - cluster:
     server: https://test-api-url:6443
@@ -51,6 +60,8 @@ current-context: admin
 `
 )
 
+var testTime = time.Date(2021, 3, 1, 8, 0, 0, 0, time.UTC)
+
 func init() {
 	log.SetLevel(log.DebugLevel)
 }
@@ -58,15 +69,24 @@ func init() {
 func TestReconcileControlPlaneCerts(t *testing.T) {
 	apis.AddToScheme(scheme.Scheme)
 	openshiftapiv1.Install(scheme.Scheme)
+	certManagerGV := schema.GroupVersion{Group: certManagerGroup, Version: "v1"}
+	scheme.Scheme.AddKnownTypeWithName(certManagerGV.WithKind(certManagerCertificate), &unstructured.Unstructured{})
+	scheme.Scheme.AddKnownTypeWithName(certManagerGV.WithKind(certManagerCertificate+"List"), &unstructured.UnstructuredList{})
 
 	tests := []struct {
-		name     string
-		existing []runtime.Object
+		name        string
+		existing    []runtime.Object
+		remote      []runtime.Object
+		applyResult resource.ApplyResult
 
 		expectNoSyncSet        bool
 		expectedPatch          string
 		expectedSecrets        []string
 		expectedNotFoundStatus corev1.ConditionStatus
+		expectedSyncedReason   string
+		expectedRequeue        bool
+		expectedCertificates   map[string][]string
+		expectedDeleted        []string
 	}{
 		{
 			name: "no control plane certs",
@@ -142,6 +162,140 @@ func TestReconcileControlPlaneCerts(t *testing.T) {
 			expectNoSyncSet:        true,
 			expectedNotFoundStatus: corev1.ConditionFalse,
 		},
+		{
+			name: "unchanged syncset synced",
+			existing: []runtime.Object{
+				fakeClusterDeployment().defaultCert("default-cert", "default-secret").obj(),
+				fakeCertSecret("default-secret"),
+				fakeSyncSet(),
+				fakeClusterSync(hiveintv1alpha1.SuccessSyncSetResult, 0),
+			},
+			remote:               []runtime.Object{fakeKubeAPIServerOperator(openshiftapiv1.ConditionFalse, syncedAt+time.Minute)},
+			applyResult:          resource.UnchangedApplyResult,
+			expectedPatch:        `[ { "op": "add", "path": "/spec/servingCerts", "value": {} }, { "op": "add", "path": "/spec/servingCerts/namedCertificates", "value": [  ] }, { "op": "replace", "path": "/spec/servingCerts/namedCertificates", "value": [  { "names": [ "test-api-url" ], "servingCertificate": { "name": "fake-cluster-default-secret" } } ] } ]`,
+			expectedSecrets:      []string{"default-secret"},
+			expectedSyncedReason: certsSyncedReason,
+		},
+		{
+			name: "unchanged syncset synced, kube-apiserver rolling out",
+			existing: []runtime.Object{
+				fakeClusterDeployment().defaultCert("default-cert", "default-secret").withSyncedCondition().obj(),
+				fakeCertSecret("default-secret"),
+				fakeSyncSet(),
+				fakeClusterSync(hiveintv1alpha1.SuccessSyncSetResult, 0),
+			},
+			remote:               []runtime.Object{fakeKubeAPIServerOperator(openshiftapiv1.ConditionTrue, syncedAt+time.Minute)},
+			applyResult:          resource.UnchangedApplyResult,
+			expectedPatch:        `[ { "op": "add", "path": "/spec/servingCerts", "value": {} }, { "op": "add", "path": "/spec/servingCerts/namedCertificates", "value": [  ] }, { "op": "replace", "path": "/spec/servingCerts/namedCertificates", "value": [  { "names": [ "test-api-url" ], "servingCertificate": { "name": "fake-cluster-default-secret" } } ] } ]`,
+			expectedSecrets:      []string{"default-secret"},
+			expectedSyncedReason: certsRollingOutReason,
+			expectedRequeue:      true,
+		},
+		{
+			name: "unchanged syncset synced, kube-apiserver rollout not started",
+			existing: []runtime.Object{
+				fakeClusterDeployment().defaultCert("default-cert", "default-secret").withSyncedCondition().obj(),
+				fakeCertSecret("default-secret"),
+				fakeSyncSet(),
+				fakeClusterSync(hiveintv1alpha1.SuccessSyncSetResult, 0),
+			},
+			remote:               []runtime.Object{fakeKubeAPIServerOperator(openshiftapiv1.ConditionFalse, syncedAt-time.Minute)},
+			applyResult:          resource.UnchangedApplyResult,
+			expectedPatch:        `[ { "op": "add", "path": "/spec/servingCerts", "value": {} }, { "op": "add", "path": "/spec/servingCerts/namedCertificates", "value": [  ] }, { "op": "replace", "path": "/spec/servingCerts/namedCertificates", "value": [  { "names": [ "test-api-url" ], "servingCertificate": { "name": "fake-cluster-default-secret" } } ] } ]`,
+			expectedSecrets:      []string{"default-secret"},
+			expectedSyncedReason: certsRollingOutReason,
+			expectedRequeue:      true,
+		},
+		{
+			name: "unchanged syncset failed to sync",
+			existing: []runtime.Object{
+				fakeClusterDeployment().defaultCert("default-cert", "default-secret").withSyncedCondition().obj(),
+				fakeCertSecret("default-secret"),
+				fakeSyncSet(),
+				fakeClusterSync(hiveintv1alpha1.FailureSyncSetResult, 0),
+			},
+			applyResult:          resource.UnchangedApplyResult,
+			expectedPatch:        `[ { "op": "add", "path": "/spec/servingCerts", "value": {} }, { "op": "add", "path": "/spec/servingCerts/namedCertificates", "value": [  ] }, { "op": "replace", "path": "/spec/servingCerts/namedCertificates", "value": [  { "names": [ "test-api-url" ], "servingCertificate": { "name": "fake-cluster-default-secret" } } ] } ]`,
+			expectedSecrets:      []string{"default-secret"},
+			expectedSyncedReason: certsSyncFailedReason,
+		},
+		{
+			name: "unchanged syncset not synced at current generation",
+			existing: []runtime.Object{
+				fakeClusterDeployment().defaultCert("default-cert", "default-secret").withSyncedCondition().obj(),
+				fakeCertSecret("default-secret"),
+				fakeSyncSet(),
+				fakeClusterSync(hiveintv1alpha1.SuccessSyncSetResult, -1),
+			},
+			applyResult:          resource.UnchangedApplyResult,
+			expectedPatch:        `[ { "op": "add", "path": "/spec/servingCerts", "value": {} }, { "op": "add", "path": "/spec/servingCerts/namedCertificates", "value": [  ] }, { "op": "replace", "path": "/spec/servingCerts/namedCertificates", "value": [  { "names": [ "test-api-url" ], "servingCertificate": { "name": "fake-cluster-default-secret" } } ] } ]`,
+			expectedSecrets:      []string{"default-secret"},
+			expectedSyncedReason: certsSyncPendingReason,
+		},
+		{
+			name: "changed syncset previously synced",
+			existing: []runtime.Object{
+				fakeClusterDeployment().defaultCert("default-cert", "default-secret").withSyncedCondition().obj(),
+				fakeCertSecret("default-secret"),
+				fakeSyncSet(),
+				fakeClusterSync(hiveintv1alpha1.SuccessSyncSetResult, 0),
+			},
+			applyResult:          resource.ConfiguredApplyResult,
+			expectedPatch:        `[ { "op": "add", "path": "/spec/servingCerts", "value": {} }, { "op": "add", "path": "/spec/servingCerts/namedCertificates", "value": [  ] }, { "op": "replace", "path": "/spec/servingCerts/namedCertificates", "value": [  { "names": [ "test-api-url" ], "servingCertificate": { "name": "fake-cluster-default-secret" } } ] } ]`,
+			expectedSecrets:      []string{"default-secret"},
+			expectedSyncedReason: certsSyncPendingReason,
+		},
+		{
+			name: "cert-manager certificates",
+			existing: []runtime.Object{
+				fakeClusterDeployment().
+					defaultCert("default", "secret0").
+					namedCert("cert1", "foo.com", "secret1").
+					additionalDomain("cert1", "bar.com").
+					withCertManager("default", "cert1").
+					obj(),
+				fakeCertSecret("secret0"),
+				fakeCertSecret("secret1"),
+			},
+			expectedPatch:   `[ { "op": "add", "path": "/spec/servingCerts", "value": {} }, { "op": "add", "path": "/spec/servingCerts/namedCertificates", "value": [  ] }, { "op": "replace", "path": "/spec/servingCerts/namedCertificates", "value": [  { "names": [ "test-api-url" ], "servingCertificate": { "name": "fake-cluster-secret0" } }, { "names": [ "foo.com" ], "servingCertificate": { "name": "fake-cluster-secret1" } }, { "names": [ "bar.com" ], "servingCertificate": { "name": "fake-cluster-secret1" } } ] } ]`,
+			expectedSecrets: []string{"secret0", "secret1"},
+			expectedCertificates: map[string][]string{
+				"fake-cluster-default": {"test-api-url"},
+				"fake-cluster-cert1":   {"bar.com", "foo.com"},
+			},
+		},
+		{
+			name: "stale cert-manager certificate deleted",
+			existing: []runtime.Object{
+				fakeClusterDeployment().
+					namedCert("cert1", "foo.com", "secret1").
+					withCertManager("cert1").
+					obj(),
+				fakeCertSecret("secret1"),
+				fakeCertManagerCertificate("fake-cluster-cert1"),
+				fakeCertManagerCertificate("fake-cluster-removed"),
+			},
+			expectedPatch:   `[ { "op": "add", "path": "/spec/servingCerts", "value": {} }, { "op": "add", "path": "/spec/servingCerts/namedCertificates", "value": [  ] }, { "op": "replace", "path": "/spec/servingCerts/namedCertificates", "value": [  { "names": [ "foo.com" ], "servingCertificate": { "name": "fake-cluster-secret1" } } ] } ]`,
+			expectedSecrets: []string{"secret1"},
+			expectedCertificates: map[string][]string{
+				"fake-cluster-cert1": {"foo.com"},
+			},
+			expectedDeleted: []string{"fake-cluster-removed"},
+		},
+		{
+			name: "cert-manager certificate not issued yet",
+			existing: []runtime.Object{
+				fakeClusterDeployment().
+					namedCert("cert1", "foo.com", "secret1").
+					withCertManager("cert1").
+					obj(),
+			},
+			expectNoSyncSet:        true,
+			expectedNotFoundStatus: corev1.ConditionTrue,
+			expectedCertificates: map[string][]string{
+				"fake-cluster-cert1": {"foo.com"},
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -158,14 +312,18 @@ func TestReconcileControlPlaneCerts(t *testing.T) {
 			mockController := gomock.NewController(t)
 			defer mockController.Finish()
 
-			applier := &fakeApplier{}
+			mockRemoteClientBuilder := remoteclientmock.NewMockBuilder(mockController)
+			mockRemoteClientBuilder.EXPECT().Build().Return(fake.NewFakeClient(test.remote...), nil).AnyTimes()
+
+			applier := &fakeApplier{result: test.applyResult}
 			r := &ReconcileControlPlaneCerts{
-				Client:  fakeClient,
-				scheme:  scheme.Scheme,
-				applier: applier,
+				Client:                        fakeClient,
+				scheme:                        scheme.Scheme,
+				applier:                       applier,
+				remoteClusterAPIClientBuilder: func(*hivev1.ClusterDeployment) remoteclient.Builder { return mockRemoteClientBuilder },
 			}
 
-			_, err := r.Reconcile(reconcile.Request{
+			result, err := r.Reconcile(reconcile.Request{
 				NamespacedName: types.NamespacedName{
 					Name:      fakeName,
 					Namespace: fakeNamespace,
@@ -173,15 +331,44 @@ func TestReconcileControlPlaneCerts(t *testing.T) {
 			})
 
 			assert.Nil(t, err)
+			assert.Equal(t, test.expectedRequeue, result.RequeueAfter > 0, "unexpected requeue")
 
 			cd := getFakeClusterDeployment(t, fakeClient)
 
+			var syncSets []*hivev1.SyncSet
+			certificates := map[string][]string{}
+			for _, obj := range applier.appliedObjects {
+				switch o := obj.(type) {
+				case *hivev1.SyncSet:
+					syncSets = append(syncSets, o)
+				case *unstructured.Unstructured:
+					assert.Equal(t, "Certificate", o.GetKind(), "unexpected kind of applied object")
+					issuer, _, _ := unstructured.NestedString(o.Object, "spec", "issuerRef", "name")
+					assert.Equal(t, "test-issuer", issuer, "unexpected issuer")
+					dnsNames, _, _ := unstructured.NestedStringSlice(o.Object, "spec", "dnsNames")
+					certificates[o.GetName()] = dnsNames
+				default:
+					t.Errorf("unexpected applied object %T", obj)
+				}
+			}
+			if test.expectedCertificates == nil {
+				test.expectedCertificates = map[string][]string{}
+			}
+			assert.Equal(t, test.expectedCertificates, certificates, "unexpected cert-manager certificates")
+			for _, name := range test.expectedDeleted {
+				cert := &unstructured.Unstructured{}
+				cert.SetAPIVersion(certManagerAPIVersion)
+				cert.SetKind(certManagerCertificate)
+				err := fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: fakeNamespace, Name: name}, cert)
+				assert.True(t, apierrors.IsNotFound(err), "expected certificate %s to be deleted", name)
+			}
+
 			if test.expectNoSyncSet {
-				assert.Len(t, applier.appliedObjects, 0, "unexpected syncset apply")
+				assert.Len(t, syncSets, 0, "unexpected syncset apply")
 			} else {
-				require.Len(t, applier.appliedObjects, 1, "single apply expected")
-				require.IsType(t, &hivev1.SyncSet{}, applier.appliedObjects[0], "syncset apply expected")
-				ss := applier.appliedObjects[0].(*hivev1.SyncSet)
+				require.Len(t, syncSets, 1, "single apply expected")
+				ss := syncSets[0]
+				assert.Equal(t, hivev1.SyncResourceApplyMode, ss.Spec.ResourceApplyMode, "unexpected resource apply mode")
 
 				// Resources should have been removed from the SyncSet
 				assert.Equal(t, 0, len(ss.Spec.Resources))
@@ -214,6 +401,15 @@ func TestReconcileControlPlaneCerts(t *testing.T) {
 				assert.Nil(t, notFoundCondition, "test did not specify an expectedNotFoundStatus but condition was present")
 			}
 
+			syncedCondition := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.ControlPlaneCertificatesSyncedCondition)
+			if test.expectedSyncedReason != "" {
+				if assert.NotNil(t, syncedCondition, "expected a synced condition") {
+					assert.Equal(t, test.expectedSyncedReason, syncedCondition.Reason, "unexpected synced reason")
+				}
+			} else {
+				assert.Nil(t, syncedCondition, "test did not specify an expectedSyncedReason but condition was present")
+			}
+
 		})
 	}
 }
@@ -261,6 +457,32 @@ func TestGetControlPlaneSecretNames(t *testing.T) {
 	}
 }
 
+func TestRequestsForCertificateBundleSecret(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+	withCert := fakeClusterDeployment().namedCert("cert1", "foo.com", "secret1").obj()
+	withoutControlPlaneCert := fakeClusterDeployment().namedCert("cert1", "foo.com", "secret1").obj()
+	withoutControlPlaneCert.Name = "without-control-plane-cert"
+	withoutControlPlaneCert.Spec.ControlPlaneConfig.ServingCertificates.Additional = nil
+	c := fake.NewFakeClient(withCert, withoutControlPlaneCert)
+
+	secret := fakeCertSecret("secret1")
+	requests := requestsForCertificateBundleSecret(c)(handler.MapObject{Meta: secret, Object: secret})
+	assert.Equal(t, []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: fakeNamespace, Name: fakeName}}}, requests, "unexpected requests")
+
+	unrelated := fakeCertSecret("unrelated")
+	assert.Empty(t, requestsForCertificateBundleSecret(c)(handler.MapObject{Meta: unrelated, Object: unrelated}), "unexpected requests")
+}
+
+func TestRequestsForClusterSync(t *testing.T) {
+	withCerts := fakeClusterSync(hiveintv1alpha1.SuccessSyncSetResult, 0)
+	assert.Equal(t, []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: fakeNamespace, Name: fakeName}}},
+		requestsForClusterSync(handler.MapObject{Meta: withCerts, Object: withCerts}), "unexpected requests")
+
+	withoutCerts := fakeClusterSync(hiveintv1alpha1.SuccessSyncSetResult, 0)
+	withoutCerts.Status.SyncSets[0].Name = "other-syncset"
+	assert.Empty(t, requestsForClusterSync(handler.MapObject{Meta: withoutCerts, Object: withoutCerts}), "unexpected requests")
+}
+
 func TestSecretsHash(t *testing.T) {
 	s1 := testSecret("secret1")
 	s2 := testSecret("secret2")
@@ -303,11 +525,12 @@ func getFakeClusterDeployment(t *testing.T, c client.Client) *hivev1.ClusterDepl
 
 type fakeApplier struct {
 	appliedObjects []runtime.Object
+	result         resource.ApplyResult
 }
 
 func (a *fakeApplier) ApplyRuntimeObject(obj runtime.Object, scheme *runtime.Scheme) (resource.ApplyResult, error) {
 	a.appliedObjects = append(a.appliedObjects, obj)
-	return "", nil
+	return a.result, nil
 }
 
 type fakeClusterDeploymentWrapper struct {
@@ -368,6 +591,39 @@ func (f *fakeClusterDeploymentWrapper) namedCert(name, domain, secretName string
 	return f
 }
 
+func (f *fakeClusterDeploymentWrapper) additionalDomain(name, domain string) *fakeClusterDeploymentWrapper {
+	f.cd.Spec.ControlPlaneConfig.ServingCertificates.Additional = append(f.cd.Spec.ControlPlaneConfig.ServingCertificates.Additional, hivev1.ControlPlaneAdditionalCertificate{
+		Domain: domain,
+		Name:   name,
+	})
+	return f
+}
+
+func (f *fakeClusterDeploymentWrapper) withCertManager(names ...string) *fakeClusterDeploymentWrapper {
+	for _, name := range names {
+		for i := range f.cd.Spec.CertificateBundles {
+			if f.cd.Spec.CertificateBundles[i].Name == name {
+				f.cd.Spec.CertificateBundles[i].CertManager = &hivev1.CertManagerCertificate{
+					IssuerRef: hivev1.CertManagerIssuerReference{Name: "test-issuer"},
+				}
+			}
+		}
+	}
+	return f
+}
+
+func (f *fakeClusterDeploymentWrapper) withSyncedCondition() *fakeClusterDeploymentWrapper {
+	f.cd.Status.Conditions = controllerutils.SetClusterDeploymentCondition(
+		f.cd.Status.Conditions,
+		hivev1.ControlPlaneCertificatesSyncedCondition,
+		corev1.ConditionTrue,
+		certsSyncedReason,
+		certsSyncedMessage,
+		controllerutils.UpdateConditionNever,
+	)
+	return f
+}
+
 func (f *fakeClusterDeploymentWrapper) withNotFoundCondition() *fakeClusterDeploymentWrapper {
 	f.cd.Status.Conditions = controllerutils.SetClusterDeploymentCondition(
 		f.cd.Status.Conditions,
@@ -420,6 +676,54 @@ func fakeSyncSet() *hivev1.SyncSet {
 	return ss
 }
 
+func fakeClusterSync(result hiveintv1alpha1.SyncSetResult, observedGeneration int64) *hiveintv1alpha1.ClusterSync {
+	return &hiveintv1alpha1.ClusterSync{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: fakeNamespace,
+			Name:      fakeName,
+		},
+		Status: hiveintv1alpha1.ClusterSyncStatus{
+			SyncSets: []hiveintv1alpha1.SyncStatus{{
+				Name:               GenerateControlPlaneCertsSyncSetName(fakeName),
+				ObservedGeneration: observedGeneration,
+				Result:             result,
+				LastTransitionTime: metav1.NewTime(testTime.Add(syncedAt)),
+			}},
+		},
+	}
+}
+
+func fakeKubeAPIServerOperator(progressing openshiftapiv1.ConditionStatus, progressingSince time.Duration) *openshiftapiv1.ClusterOperator {
+	return &openshiftapiv1.ClusterOperator{
+		ObjectMeta: metav1.ObjectMeta{Name: kubeAPIServerOperatorName},
+		Status: openshiftapiv1.ClusterOperatorStatus{
+			Conditions: []openshiftapiv1.ClusterOperatorStatusCondition{
+				{
+					Type:   openshiftapiv1.OperatorAvailable,
+					Status: openshiftapiv1.ConditionTrue,
+				},
+				{
+					Type:               openshiftapiv1.OperatorProgressing,
+					Status:             progressing,
+					LastTransitionTime: metav1.NewTime(testTime.Add(progressingSince)),
+				},
+			},
+		},
+	}
+}
+
+func fakeCertManagerCertificate(name string) *unstructured.Unstructured {
+	cd := fakeClusterDeployment().obj()
+	cert := &unstructured.Unstructured{}
+	cert.SetAPIVersion(certManagerAPIVersion)
+	cert.SetKind(certManagerCertificate)
+	cert.SetNamespace(fakeNamespace)
+	cert.SetName(name)
+	cert.SetLabels(map[string]string{constants.ClusterDeploymentNameLabel: fakeName})
+	cert.SetOwnerReferences([]metav1.OwnerReference{*metav1.NewControllerRef(cd, hivev1.SchemeGroupVersion.WithKind("ClusterDeployment"))})
+	return cert
+}
+
 func findSecret(ss []hivev1.SecretMapping, name string) string {
 	for _, s := range ss {
 		if s.TargetRef.Name == name {
@@ -294,7 +294,10 @@ func createNameServerQuery(c client.Client, logger log.FieldLogger, managedDomai
 		if region == "" {
 			region = constants.AWSRoute53Region
 		}
-		return nameserver.NewAWSQuery(c, secretName, region)
+		if assumeRole := managedDomain.AWS.AssumeRole; assumeRole != nil {
+			logger.Infof("assuming role %q for managed domains", assumeRole.RoleARN)
+		}
+		return nameserver.NewAWSQuery(c, secretName, region, managedDomain.AWS.AssumeRole)
 	}
 	if managedDomain.GCP != nil {
 		secretName := managedDomain.GCP.CredentialsSecretRef.Name
@@ -495,6 +495,19 @@ func TestMultiCloudDNSSetup(t *testing.T) {
 				}(),
 			},
 		},
+		{
+			name: "managed domain in another account",
+			managedDomains: []hivev1.ManageDNSConfig{
+				func() hivev1.ManageDNSConfig {
+					md := testManagedDomain()
+					md.AWS.AssumeRole = &hivev1.AWSAssumeRole{
+						RoleARN:    "arn:aws:iam::123456789012:role/hive-dns",
+						ExternalID: "hive",
+					}
+					return md
+				}(),
+			},
+		},
 		{
 			name:           "no manged domain entries",
 			managedDomains: []hivev1.ManageDNSConfig{},
@@ -10,14 +10,20 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	awsclient "github.com/openshift/hive/pkg/awsclient"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 )
 
-// NewAWSQuery creates a new name server query for AWS.
-func NewAWSQuery(c client.Client, credsSecretName string, region string) Query {
+// NewAWSQuery creates a new name server query for AWS. When assumeRole is not nil, the role is assumed with the
+// credentials for the queries.
+func NewAWSQuery(c client.Client, credsSecretName string, region string, assumeRole *hivev1.AWSAssumeRole) Query {
 	return &awsQuery{
 		getAWSClient: func() (awsclient.Client, error) {
+			if assumeRole != nil {
+				awsClient, err := awsclient.NewClientAssumingRole(c, credsSecretName, controllerutils.GetHiveNamespace(), region, assumeRole.RoleARN, assumeRole.ExternalID)
+				return awsClient, errors.Wrap(err, "error creating AWS client assuming role")
+			}
 			awsClient, err := awsclient.NewClient(c, credsSecretName, controllerutils.GetHiveNamespace(), region)
 			return awsClient, errors.Wrap(err, "error creating AWS client")
 		},
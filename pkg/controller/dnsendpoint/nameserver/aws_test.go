@@ -1,17 +1,23 @@
 package nameserver
 
 import (
+	"os"
 	"testing"
 
 	"github.com/aws/aws-sdk-go/service/route53"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	"github.com/openshift/hive/pkg/awsclient"
 	"github.com/openshift/hive/pkg/awsclient/mock"
+	"github.com/openshift/hive/pkg/constants"
 )
 
 func TestAWSGet(t *testing.T) {
@@ -220,6 +226,26 @@ func TestAWSGet(t *testing.T) {
 
 type listHostedZonesOutputOption func(*route53.ListHostedZonesByNameOutput)
 
+func TestNewAWSQueryAssumingRole(t *testing.T) {
+	os.Setenv(constants.HiveNamespaceEnvVar, "hive")
+	defer os.Unsetenv(constants.HiveNamespaceEnvVar)
+	assumeRole := &hivev1.AWSAssumeRole{RoleARN: "arn:aws:iam::123456789012:role/hive-dns", ExternalID: "hive"}
+
+	c := fake.NewFakeClient(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "hive", Name: "dns-creds"},
+		Data: map[string][]byte{
+			constants.AWSAccessKeyIDSecretKey:     []byte("key-id"),
+			constants.AWSSecretAccessKeySecretKey: []byte("secret-key"),
+		},
+	})
+	awsClient, err := NewAWSQuery(c, "dns-creds", constants.AWSRoute53Region, assumeRole).(*awsQuery).getAWSClient()
+	assert.NoError(t, err, "unexpected error creating client assuming role")
+	assert.NotNil(t, awsClient, "expected client")
+
+	_, err = NewAWSQuery(c, "missing-creds", constants.AWSRoute53Region, assumeRole).(*awsQuery).getAWSClient()
+	assert.Error(t, err, "expected error for missing credentials secret")
+}
+
 func testListHostedZonesOutput(opts ...listHostedZonesOutputOption) *route53.ListHostedZonesByNameOutput {
 	out := &route53.ListHostedZonesByNameOutput{}
 	for _, o := range opts {
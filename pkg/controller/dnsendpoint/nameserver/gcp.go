@@ -126,7 +126,7 @@ func (q *gcpQuery) queryZoneName(gcpClient gcpclient.Client, domain string) (str
 		DNSName: controllerutils.Dotted(domain),
 	}
 	for {
-		listOutput, err := gcpClient.ListManagedZones(listOpts)
+		listOutput, err := gcpClient.ListManagedZones(context.TODO(), listOpts)
 		if err != nil {
 			return "", err
 		}
@@ -148,7 +148,7 @@ func (q *gcpQuery) queryNameServers(gcpClient gcpclient.Client, managedZone stri
 	nameServers := map[string]sets.String{}
 	listOpts := gcpclient.ListResourceRecordSetsOptions{}
 	for {
-		listOutput, err := gcpClient.ListResourceRecordSets(managedZone, listOpts)
+		listOutput, err := gcpClient.ListResourceRecordSets(context.TODO(), managedZone, listOpts)
 		if err != nil {
 			return nil, err
 		}
@@ -172,6 +172,7 @@ func (q *gcpQuery) queryNameServers(gcpClient gcpclient.Client, managedZone stri
 // queryNameServer queries GCP for the name servers for the specified domain in the specified managed zone.
 func (q *gcpQuery) queryNameServer(gcpClient gcpclient.Client, managedZone string, domain string) (sets.String, error) {
 	listOutput, err := gcpClient.ListResourceRecordSets(
+		context.TODO(),
 		managedZone,
 		gcpclient.ListResourceRecordSetsOptions{
 			MaxResults: 1,
@@ -195,7 +196,7 @@ func (q *gcpQuery) queryNameServer(gcpClient gcpclient.Client, managedZone strin
 // createNameServers creates the name servers for the specified domain in the specified managed zone.
 func (q *gcpQuery) createNameServers(gcpClient gcpclient.Client, managedZone string, domain string, values sets.String) error {
 
-	err := gcpClient.AddResourceRecordSet(managedZone, q.resourceRecordSet(domain, values))
+	err := gcpClient.AddResourceRecordSet(context.TODO(), managedZone, q.resourceRecordSet(domain, values))
 	if gcpErr, ok := err.(*googleapi.Error); ok && gcpErr.Code == http.StatusConflict {
 		// this means there is already an existing resource record, so we need
 		// to fall through to the update path
@@ -207,7 +208,7 @@ func (q *gcpQuery) createNameServers(gcpClient gcpclient.Client, managedZone str
 
 	// An update using the GCP API involves listing the current set of records
 	// for removal, and adding the new desired set of records as additions.
-	response, err := gcpClient.ListResourceRecordSets(managedZone, gcpclient.ListResourceRecordSetsOptions{
+	response, err := gcpClient.ListResourceRecordSets(context.TODO(), managedZone, gcpclient.ListResourceRecordSetsOptions{
 		Name: controllerutils.Dotted(domain),
 		Type: "NS",
 	})
@@ -222,7 +223,7 @@ func (q *gcpQuery) createNameServers(gcpClient gcpclient.Client, managedZone str
 		addRRSet := q.resourceRecordSet(domain, values)
 		removeRRSet := q.resourceRecordSet(domain, currentNSValues)
 
-		err := gcpClient.UpdateResourceRecordSet(managedZone, addRRSet, removeRRSet)
+		err := gcpClient.UpdateResourceRecordSet(context.TODO(), managedZone, addRRSet, removeRRSet)
 		return errors.Wrap(err, "failed to update existing NS entry")
 	default:
 		return fmt.Errorf("unexpected response when querying domain")
@@ -231,7 +232,7 @@ func (q *gcpQuery) createNameServers(gcpClient gcpclient.Client, managedZone str
 
 // deleteNameServers deletes the name servers for the specified domain in the specified managed zone.
 func (q *gcpQuery) deleteNameServers(gcpClient gcpclient.Client, managedZone string, domain string, values sets.String) error {
-	return gcpClient.DeleteResourceRecordSet(managedZone, q.resourceRecordSet(domain, values))
+	return gcpClient.DeleteResourceRecordSet(context.TODO(), managedZone, q.resourceRecordSet(domain, values))
 }
 
 func (q *gcpQuery) resourceRecordSet(domain string, values sets.String) *dns.ResourceRecordSet {
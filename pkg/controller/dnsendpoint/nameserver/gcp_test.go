@@ -182,7 +182,7 @@ func TestGCPGet(t *testing.T) {
 					opts.PageToken = "next-page-token"
 				}
 				mockGCPClient.EXPECT().
-					ListManagedZones(gomock.Eq(opts)).
+					ListManagedZones(gomock.Any(), gomock.Eq(opts)).
 					Return(out, nil)
 			}
 			for i, out := range tc.listResourceRecordSetsResponses {
@@ -191,7 +191,7 @@ func TestGCPGet(t *testing.T) {
 					opts.PageToken = "next-page-token"
 				}
 				mockGCPClient.EXPECT().
-					ListResourceRecordSets(gomock.Eq("test-zone-name"), gomock.Eq(opts)).
+					ListResourceRecordSets(gomock.Any(), gomock.Eq("test-zone-name"), gomock.Eq(opts)).
 					Return(out, nil)
 			}
 			actualNameServers, err := gcpQuery.Get("test-domain")
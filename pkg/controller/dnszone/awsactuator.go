@@ -22,6 +22,10 @@ import (
 )
 
 const (
+	// hiveDNSZoneIDAWSTag is the tag of hosted zones with the ID of their DNSZone.
+	hiveDNSZoneIDAWSTag = "hive.openshift.io/dnszone-id"
+	// hiveDNSZoneAWSTag is the tag of hosted zones with the namespace and name of their DNSZone. It is only used to
+	// find the hosted zones created before they were tagged with the ID of their DNSZone.
 	hiveDNSZoneAWSTag = "hive.openshift.io/dnszone"
 )
 
@@ -252,12 +256,37 @@ func (a *AWSActuator) Refresh() error {
 	return nil
 }
 
+// findZoneIDsByTag returns the IDs of the hosted zones tagged with the ID of the DNSZone. When there are none, it
+// returns those tagged with the namespace and name of the DNSZone but not with the ID of any DNSZone, which were
+// created before hosted zones were tagged with the ID. The hosted zone of an earlier DNSZone with the same namespace
+// and name is not adopted.
 func (a *AWSActuator) findZoneIDsByTag() ([]string, error) {
-	var ids []string
-	tagFilter := &resourcegroupstaggingapi.TagFilter{
+	if id := a.dnsZone.Annotations[constants.DNSZoneIDAnnotation]; id != "" {
+		ids, err := a.findZoneIDsByTagFilter(&resourcegroupstaggingapi.TagFilter{
+			Key:    aws.String(hiveDNSZoneIDAWSTag),
+			Values: []*string{aws.String(id)},
+		}, nil)
+		if err != nil || len(ids) > 0 {
+			return ids, err
+		}
+	}
+	return a.findZoneIDsByTagFilter(&resourcegroupstaggingapi.TagFilter{
 		Key:    aws.String(hiveDNSZoneAWSTag),
 		Values: []*string{aws.String(fmt.Sprintf("%s/%s", a.dnsZone.Namespace, a.dnsZone.Name))},
-	}
+	}, func(tags []*resourcegroupstaggingapi.Tag) bool {
+		for _, tag := range tags {
+			if aws.StringValue(tag.Key) == hiveDNSZoneIDAWSTag {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// findZoneIDsByTagFilter returns the IDs of the hosted zones matching the tag filter whose tags are accepted, all of
+// them when accept is nil.
+func (a *AWSActuator) findZoneIDsByTagFilter(tagFilter *resourcegroupstaggingapi.TagFilter, accept func([]*resourcegroupstaggingapi.Tag) bool) ([]string, error) {
+	var ids []string
 	filterString := fmt.Sprintf("%s=%s", aws.StringValue(tagFilter.Key), aws.StringValue(tagFilter.Values[0]))
 	a.logger.WithField("filter", filterString).Debug("Searching for zone by tag")
 	id := ""
@@ -268,6 +297,10 @@ func (a *AWSActuator) findZoneIDsByTag() ([]string, error) {
 		for _, zone := range resp.ResourceTagMappingList {
 			logger := a.logger.WithField("arn", aws.StringValue(zone.ResourceARN))
 			logger.Debug("Processing search result")
+			if accept != nil && !accept(zone.Tags) {
+				logger.Debug("Hosted zone belongs to another DNSZone")
+				continue
+			}
 			zoneARN, err := arn.Parse(aws.StringValue(zone.ResourceARN))
 			if err != nil {
 				logger.WithError(err).Error("Failed to parse hostedzone ARN")
@@ -288,12 +321,22 @@ func (a *AWSActuator) findZoneIDsByTag() ([]string, error) {
 }
 
 func (a *AWSActuator) expectedTags() []*route53.Tag {
+	// Hosted zones are tagged with the ID of their DNSZone. The namespace and name of the DNSZone are only used until
+	// the DNSZone has an ID.
 	tags := []*route53.Tag{
 		{
 			Key:   aws.String(hiveDNSZoneAWSTag),
 			Value: aws.String(fmt.Sprintf("%s/%s", a.dnsZone.Namespace, a.dnsZone.Name)),
 		},
 	}
+	if id := a.dnsZone.Annotations[constants.DNSZoneIDAnnotation]; id != "" {
+		tags = []*route53.Tag{
+			{
+				Key:   aws.String(hiveDNSZoneIDAWSTag),
+				Value: aws.String(id),
+			},
+		}
+	}
 	if a.dnsZone.Spec.AWS != nil {
 		for _, tag := range a.dnsZone.Spec.AWS.AdditionalTags {
 			tags = append(tags, &route53.Tag{
@@ -187,3 +187,22 @@ func mockGetResourcePages(expect *mock.MockClientMockRecorder) {
 		f(getResourcesOutput, true)
 	})
 }
+
+// mockFindAWSZonesByTag expects a search for hosted zones by the tag key, finding the zone with the ID and tags, or
+// none when the ID is empty.
+func mockFindAWSZonesByTag(expect *mock.MockClientMockRecorder, key, id string, tags []*resourcegroupstaggingapi.Tag) *gomock.Call {
+	return expect.GetResourcesPages(gomock.Any(), gomock.Any()).
+		Do(func(input *resourcegroupstaggingapi.GetResourcesInput, f func(*resourcegroupstaggingapi.GetResourcesOutput, bool) bool) {
+			if aws.StringValue(input.TagFilters[0].Key) != key {
+				return
+			}
+			output := &resourcegroupstaggingapi.GetResourcesOutput{}
+			if id != "" {
+				output.ResourceTagMappingList = []*resourcegroupstaggingapi.ResourceTagMapping{{
+					ResourceARN: aws.String("arn:aws:route53:::hostedzone/" + id),
+					Tags:        tags,
+				}}
+			}
+			f(output, true)
+		}).Return(nil).Times(1)
+}
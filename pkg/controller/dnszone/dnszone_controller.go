@@ -8,7 +8,6 @@ import (
 	"strings"
 	"time"
 
-	"github.com/miekg/dns"
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
 	log "github.com/sirupsen/logrus"
@@ -16,9 +15,11 @@ import (
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	awsclient "github.com/openshift/hive/pkg/awsclient"
 	"github.com/openshift/hive/pkg/azureclient"
+	"github.com/openshift/hive/pkg/constants"
 	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 	gcpclient "github.com/openshift/hive/pkg/gcpclient"
+	"github.com/openshift/hive/pkg/ociclient"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -39,13 +40,17 @@ const (
 	ControllerName                  = hivev1.DNSZoneControllerName
 	zoneResyncDuration              = 2 * time.Hour
 	domainAvailabilityCheckInterval = 30 * time.Second
+	defaultMaxCheckInterval         = 5 * time.Minute
 	dnsClientTimeout                = 30 * time.Second
 	resolverConfigFile              = "/etc/resolv.conf"
-	zoneCheckDNSServersEnvVar       = "ZONE_CHECK_DNS_SERVERS"
 	accessDeniedReason              = "AccessDenied"
 	accessGrantedReason             = "AccessGranted"
 	authenticationFailedReason      = "AuthenticationFailed"
 	authenticationSucceededReason   = "AuthenticationSucceeded"
+	delegatedReason                 = "Delegated"
+	lookupFailedReason              = "LookupFailed"
+	noNameServersReason             = "NoNameServers"
+	nameServerMismatchReason        = "NameServerMismatch"
 )
 
 var (
@@ -76,13 +81,27 @@ func Add(mgr manager.Manager) error {
 // newReconciler returns a new reconcile.Reconciler
 func newReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) *ReconcileDNSZone {
 	return &ReconcileDNSZone{
-		Client:    controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
-		scheme:    mgr.GetScheme(),
-		logger:    log.WithField("controller", ControllerName),
-		soaLookup: lookupSOARecord,
+		Client:           controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
+		scheme:           mgr.GetScheme(),
+		logger:           log.WithField("controller", ControllerName),
+		soaLookup:        lookupSOARecord,
+		nsLookup:         lookupNSRecords,
+		maxCheckInterval: maxCheckIntervalFromEnv(),
 	}
 }
 
+// maxCheckIntervalFromEnv returns the maximum interval between checks of a zone which is not yet resolvable.
+func maxCheckIntervalFromEnv() time.Duration {
+	if interval := os.Getenv(constants.ZoneCheckMaxIntervalEnvVar); interval != "" {
+		d, err := time.ParseDuration(interval)
+		if err == nil {
+			return d
+		}
+		log.WithError(err).WithField("interval", interval).Warn("invalid maximum zone check interval, using default")
+	}
+	return defaultMaxCheckInterval
+}
+
 // add adds a new Controller to mgr with r as the reconcile.Reconciler
 func add(mgr manager.Manager, r *ReconcileDNSZone, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
 	// Create a new controller
@@ -122,6 +141,12 @@ type ReconcileDNSZone struct {
 
 	// soaLookup is a function that looks up a zone's SOA record
 	soaLookup func(string, log.FieldLogger) (bool, error)
+
+	// nsLookup is a function that looks up the name servers a zone is delegated to
+	nsLookup func(string, log.FieldLogger) ([]string, error)
+
+	// maxCheckInterval caps the interval between checks of a zone which is not yet resolvable
+	maxCheckInterval time.Duration
 }
 
 // Reconcile reads that state of the cluster for a DNSZone object and makes changes based on the state read
@@ -152,6 +177,17 @@ func (r *ReconcileDNSZone) Reconcile(request reconcile.Request) (reconcile.Resul
 		return *result, nil
 	}
 
+	if desiredState.DeletionTimestamp == nil && desiredState.Annotations[constants.DNSZoneIDAnnotation] == "" {
+		if desiredState.Annotations == nil {
+			desiredState.Annotations = map[string]string{}
+		}
+		desiredState.Annotations[constants.DNSZoneIDAnnotation] = string(desiredState.UID)
+		if err := r.Update(context.TODO(), desiredState); err != nil {
+			dnsLog.WithError(err).Log(controllerutils.LogLevel(err), "failed to set the ID of the DNSZone")
+			return reconcile.Result{}, err
+		}
+	}
+
 	// See if we need to sync. This is what rate limits our dns provider API usage, but allows for immediate syncing
 	// on spec changes and deletes.
 	shouldSync, delta := shouldSync(desiredState)
@@ -294,12 +330,61 @@ func (r *ReconcileDNSZone) reconcileDNSProvider(actuator Actuator, dnsZone *hive
 	}
 
 	reconcileResult := reconcile.Result{}
+	var delegatedNameServers []string
+	var nsLookupErr error
 	if !isZoneSOAAvailable {
 		r.logger.Info("SOA record for DNS zone not available")
-		reconcileResult.RequeueAfter = domainAvailabilityCheckInterval
+		delegatedNameServers, nsLookupErr = r.nsLookup(dnsZone.Spec.Zone, r.logger)
+		if nsLookupErr != nil {
+			r.logger.WithError(nsLookupErr).Error("error looking up NS records for zone")
+		}
+		reconcileResult.RequeueAfter = r.checkInterval(dnsZone)
 	}
 
-	return reconcileResult, r.updateStatus(nameServers, isZoneSOAAvailable, dnsZone)
+	return reconcileResult, r.updateStatus(nameServers, isZoneSOAAvailable, delegatedNameServers, nsLookupErr, dnsZone)
+}
+
+// checkInterval returns the interval before the next check of a zone which is not yet resolvable. The interval
+// grows with the time the zone has been unavailable, doubling between checks, up to the maximum interval.
+func (r *ReconcileDNSZone) checkInterval(dnsZone *hivev1.DNSZone) time.Duration {
+	waitingSince := dnsZone.CreationTimestamp.Time
+	if cond := controllerutils.FindDNSZoneCondition(dnsZone.Status.Conditions, hivev1.ZoneAvailableDNSZoneCondition); cond != nil && cond.Status == corev1.ConditionFalse {
+		waitingSince = cond.LastTransitionTime.Time
+	}
+	interval := time.Since(waitingSince)
+	maxInterval := r.maxCheckInterval
+	if maxInterval <= 0 {
+		maxInterval = defaultMaxCheckInterval
+	}
+	switch {
+	case interval < domainAvailabilityCheckInterval:
+		return domainAvailabilityCheckInterval
+	case interval > maxInterval:
+		return maxInterval
+	default:
+		return interval
+	}
+}
+
+// delegationCondition returns the status, reason and message of the DNSNotDelegated condition given the name
+// servers hosting the zone and the name servers the resolvers return for it.
+func delegationCondition(isSOAAvailable bool, nameServers, delegatedNameServers []string, lookupErr error) (corev1.ConditionStatus, string, string) {
+	expected := normalizeNameServers(nameServers)
+	switch {
+	case isSOAAvailable:
+		return corev1.ConditionFalse, delegatedReason, "Zone is delegated and its SOA record is reachable"
+	case lookupErr != nil:
+		return corev1.ConditionTrue, lookupFailedReason, fmt.Sprintf("NS lookup for zone failed: %v", lookupErr)
+	case len(delegatedNameServers) == 0:
+		return corev1.ConditionTrue, noNameServersReason,
+			fmt.Sprintf("Resolvers returned no NS records for zone, expected %s", strings.Join(expected, ", "))
+	case !reflect.DeepEqual(expected, delegatedNameServers):
+		return corev1.ConditionTrue, nameServerMismatchReason,
+			fmt.Sprintf("Resolvers returned name servers %s for zone, expected %s",
+				strings.Join(delegatedNameServers, ", "), strings.Join(expected, ", "))
+	default:
+		return corev1.ConditionFalse, delegatedReason, "Zone is delegated but its SOA record is not yet reachable"
+	}
 }
 
 func shouldSync(desiredState *hivev1.DNSZone) (bool, time.Duration) {
@@ -382,10 +467,25 @@ func (r *ReconcileDNSZone) getActuator(dnsZone *hivev1.DNSZone, dnsLog log.Field
 		return NewAzureActuator(dnsLog, secret, dnsZone, azureclient.NewClientFromSecret)
 	}
 
+	if dnsZone.Spec.OCI != nil {
+		secret := &corev1.Secret{}
+		err := r.Get(context.TODO(),
+			types.NamespacedName{
+				Name:      dnsZone.Spec.OCI.CredentialsSecretRef.Name,
+				Namespace: dnsZone.Namespace,
+			},
+			secret)
+		if err != nil {
+			return nil, err
+		}
+
+		return NewOCIActuator(dnsLog, secret, dnsZone, ociclient.NewClientFromSecret)
+	}
+
 	return nil, errors.New("unable to determine which actuator to use")
 }
 
-func (r *ReconcileDNSZone) updateStatus(nameServers []string, isSOAAvailable bool, dnsZone *hivev1.DNSZone) error {
+func (r *ReconcileDNSZone) updateStatus(nameServers []string, isSOAAvailable bool, delegatedNameServers []string, nsLookupErr error, dnsZone *hivev1.DNSZone) error {
 	orig := dnsZone.DeepCopy()
 	r.logger.Debug("Updating DNSZone status")
 
@@ -415,6 +515,15 @@ func (r *ReconcileDNSZone) updateStatus(nameServers []string, isSOAAvailable boo
 		availableMessage,
 		controllerutils.UpdateConditionNever)
 
+	notDelegatedStatus, notDelegatedReason, notDelegatedMessage := delegationCondition(isSOAAvailable, nameServers, delegatedNameServers, nsLookupErr)
+	dnsZone.Status.Conditions = controllerutils.SetDNSZoneCondition(
+		dnsZone.Status.Conditions,
+		hivev1.DNSNotDelegatedCondition,
+		notDelegatedStatus,
+		notDelegatedReason,
+		notDelegatedMessage,
+		controllerutils.UpdateConditionIfReasonOrMessageChange)
+
 	if !reflect.DeepEqual(orig.Status, dnsZone.Status) {
 		err := r.Client.Status().Update(context.TODO(), dnsZone)
 		if err != nil {
@@ -424,55 +533,3 @@ func (r *ReconcileDNSZone) updateStatus(nameServers []string, isSOAAvailable boo
 	}
 	return nil
 }
-
-func lookupSOARecord(zone string, logger log.FieldLogger) (bool, error) {
-	// TODO: determine if there's a better way to obtain resolver endpoints
-	clientConfig, _ := dns.ClientConfigFromFile(resolverConfigFile)
-	client := dns.Client{Timeout: dnsClientTimeout}
-
-	dnsServers := []string{}
-	serversFromEnv := os.Getenv(zoneCheckDNSServersEnvVar)
-	if len(serversFromEnv) > 0 {
-		dnsServers = strings.Split(serversFromEnv, ",")
-		// Add port to servers with unspecified port
-		for i := range dnsServers {
-			if !strings.Contains(dnsServers[i], ":") {
-				dnsServers[i] = dnsServers[i] + ":53"
-			}
-		}
-	} else {
-		for _, s := range clientConfig.Servers {
-			dnsServers = append(dnsServers, fmt.Sprintf("%s:%s", s, clientConfig.Port))
-		}
-	}
-	logger.WithField("servers", dnsServers).Info("looking up domain SOA record")
-
-	m := &dns.Msg{}
-	m.SetQuestion(zone+".", dns.TypeSOA)
-	for _, s := range dnsServers {
-		in, rtt, err := client.Exchange(m, s)
-		if err != nil {
-			logger.WithError(err).WithField("server", s).Info("query for SOA record failed")
-			continue
-		}
-		log.WithField("server", s).Infof("SOA query duration: %v", rtt)
-		if len(in.Answer) > 0 {
-			for _, rr := range in.Answer {
-				soa, ok := rr.(*dns.SOA)
-				if !ok {
-					logger.Info("Record returned is not an SOA record: %#v", rr)
-					continue
-				}
-				if soa.Hdr.Name != controllerutils.Dotted(zone) {
-					logger.WithField("zone", soa.Hdr.Name).Info("SOA record returned but it does not match the lookup zone")
-					return false, nil
-				}
-				logger.WithField("zone", soa.Hdr.Name).Info("SOA record returned, zone is reachable")
-				return true, nil
-			}
-		}
-		logger.WithField("server", s).Info("no answer for SOA record returned")
-		return false, nil
-	}
-	return false, nil
-}
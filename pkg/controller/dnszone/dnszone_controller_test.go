@@ -4,14 +4,19 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
+	"github.com/aws/aws-sdk-go/service/route53"
 	"github.com/golang/mock/gomock"
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
 
@@ -151,6 +156,60 @@ func TestReconcileDNSProviderForAWS(t *testing.T) {
 				assert.False(t, controllerutils.HasFinalizer(zone, hivev1.FinalizerDNSZone))
 			},
 		},
+		{
+			name:    "Adopt existing zone by ID, No ID Set",
+			dnsZone: validDNSZoneWithDNSZoneID(),
+			setupAWSMock: func(expect *mock.MockClientMockRecorder) {
+				mockFindAWSZonesByTag(expect, hiveDNSZoneIDAWSTag, "1234", nil)
+				mockAWSZoneExists(expect, validDNSZone())
+				mockNoExistingAWSTags(expect)
+				mockSyncAWSTags(expect)
+				mockAWSGetNSRecord(expect)
+			},
+			validateZone: func(t *testing.T, zone *hivev1.DNSZone) {
+				if assert.NotNil(t, zone.Status.AWS) {
+					assert.Equal(t, "1234", aws.StringValue(zone.Status.AWS.ZoneID))
+				}
+			},
+		},
+		{
+			name:    "Do not adopt zone of earlier DNSZone with same name, No ID Set",
+			dnsZone: validDNSZoneWithDNSZoneID(),
+			setupAWSMock: func(expect *mock.MockClientMockRecorder) {
+				gomock.InOrder(
+					mockFindAWSZonesByTag(expect, hiveDNSZoneIDAWSTag, "", nil),
+					mockFindAWSZonesByTag(expect, hiveDNSZoneAWSTag, "5678", []*resourcegroupstaggingapi.Tag{{
+						Key:   aws.String(hiveDNSZoneIDAWSTag),
+						Value: aws.String("earlier-dnszone-id"),
+					}}),
+				)
+				mockCreateAWSZone(expect)
+				mockNoExistingAWSTags(expect)
+				mockSyncAWSTags(expect)
+				mockAWSGetNSRecord(expect)
+			},
+			validateZone: func(t *testing.T, zone *hivev1.DNSZone) {
+				if assert.NotNil(t, zone.Status.AWS) {
+					assert.Equal(t, "1234", aws.StringValue(zone.Status.AWS.ZoneID), "expected a new hosted zone")
+				}
+			},
+		},
+		{
+			name:    "Existing zone, replace namespace and name tag with ID tag",
+			dnsZone: validDNSZoneWithDNSZoneID(),
+			setupAWSMock: func(expect *mock.MockClientMockRecorder) {
+				mockFindAWSZonesByTag(expect, hiveDNSZoneIDAWSTag, "1234", nil)
+				mockAWSZoneExists(expect, validDNSZone())
+				mockExistingAWSTags(expect)
+				expect.ChangeTagsForResource(gomock.Any()).
+					Do(func(input *route53.ChangeTagsForResourceInput) {
+						assert.Equal(t, []*string{aws.String(hiveDNSZoneAWSTag)}, input.RemoveTagKeys, "unexpected tags removed")
+						assert.Equal(t, []*route53.Tag{{Key: aws.String(hiveDNSZoneIDAWSTag), Value: aws.String(testDNSZoneID)}}, input.AddTags, "unexpected tags added")
+					}).
+					Return(&route53.ChangeTagsForResourceOutput{}, nil).Times(1)
+				mockAWSGetNSRecord(expect)
+			},
+		},
 		{
 			name:            "Existing zone, link to parent, reachable SOA",
 			dnsZone:         validDNSZoneWithLinkToParent(),
@@ -188,6 +247,9 @@ func TestReconcileDNSProviderForAWS(t *testing.T) {
 			r.soaLookup = func(string, log.FieldLogger) (bool, error) {
 				return tc.soaLookupResult, nil
 			}
+			r.nsLookup = func(string, log.FieldLogger) ([]string, error) {
+				return nil, nil
+			}
 
 			// This is necessary for the mocks to report failures like methods not being called an expected number of times.
 			defer mocks.mockCtrl.Finish()
@@ -336,6 +398,9 @@ func TestReconcileDNSProviderForGCP(t *testing.T) {
 			r.soaLookup = func(string, log.FieldLogger) (bool, error) {
 				return tc.soaLookupResult, nil
 			}
+			r.nsLookup = func(string, log.FieldLogger) ([]string, error) {
+				return nil, nil
+			}
 
 			// This is necessary for the mocks to report failures like methods not being called an expected number of times.
 			defer mocks.mockCtrl.Finish()
@@ -470,6 +535,9 @@ func TestReconcileDNSProviderForAzure(t *testing.T) {
 			r.soaLookup = func(string, log.FieldLogger) (bool, error) {
 				return tc.soaLookupResult, nil
 			}
+			r.nsLookup = func(string, log.FieldLogger) ([]string, error) {
+				return nil, nil
+			}
 
 			// This is necessary for the mocks to report failures like methods not being called an expected number of times.
 			defer mocks.mockCtrl.Finish()
@@ -603,3 +671,105 @@ func testInvalidSignatureExceptionError() error {
 		fmt.Errorf("The request signature we calculated does not match the signature you provided. Check your AWS Secret Access Key and signing method. Consult the service documentation for details"))
 	return invalidSignatureErr
 }
+
+func TestDelegationCondition(t *testing.T) {
+	nameServers := []string{"ns-1.example.net.", "NS-2.example.net"}
+	cases := []struct {
+		name                 string
+		isSOAAvailable       bool
+		delegatedNameServers []string
+		lookupErr            error
+		expectedStatus       corev1.ConditionStatus
+		expectedReason       string
+		expectedMessage      string
+	}{
+		{
+			name:           "SOA available",
+			isSOAAvailable: true,
+			expectedStatus: corev1.ConditionFalse,
+			expectedReason: delegatedReason,
+		},
+		{
+			name:            "lookup failed",
+			lookupErr:       fmt.Errorf("i/o timeout"),
+			expectedStatus:  corev1.ConditionTrue,
+			expectedReason:  lookupFailedReason,
+			expectedMessage: "NS lookup for zone failed: i/o timeout",
+		},
+		{
+			name:            "not delegated",
+			expectedStatus:  corev1.ConditionTrue,
+			expectedReason:  noNameServersReason,
+			expectedMessage: "Resolvers returned no NS records for zone, expected ns-1.example.net, ns-2.example.net",
+		},
+		{
+			name:                 "stale delegation",
+			delegatedNameServers: []string{"ns-3.example.net", "ns-4.example.net"},
+			expectedStatus:       corev1.ConditionTrue,
+			expectedReason:       nameServerMismatchReason,
+			expectedMessage:      "Resolvers returned name servers ns-3.example.net, ns-4.example.net for zone, expected ns-1.example.net, ns-2.example.net",
+		},
+		{
+			name:                 "delegated without SOA",
+			delegatedNameServers: []string{"ns-1.example.net", "ns-2.example.net"},
+			expectedStatus:       corev1.ConditionFalse,
+			expectedReason:       delegatedReason,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			status, reason, message := delegationCondition(tc.isSOAAvailable, nameServers, tc.delegatedNameServers, tc.lookupErr)
+			assert.Equal(t, tc.expectedStatus, status, "unexpected condition status")
+			assert.Equal(t, tc.expectedReason, reason, "unexpected condition reason")
+			if tc.expectedMessage != "" {
+				assert.Equal(t, tc.expectedMessage, message, "unexpected condition message")
+			}
+		})
+	}
+}
+
+func TestCheckInterval(t *testing.T) {
+	r := &ReconcileDNSZone{maxCheckInterval: 4 * time.Minute}
+	cases := []struct {
+		name             string
+		created          time.Duration
+		unavailableSince *time.Duration
+		expected         time.Duration
+	}{
+		{
+			name:     "new zone",
+			created:  5 * time.Second,
+			expected: domainAvailabilityCheckInterval,
+		},
+		{
+			name:     "waiting zone",
+			created:  2 * time.Minute,
+			expected: 2 * time.Minute,
+		},
+		{
+			name:     "capped",
+			created:  time.Hour,
+			expected: 4 * time.Minute,
+		},
+		{
+			name:             "zone became unavailable",
+			created:          24 * time.Hour,
+			unavailableSince: func() *time.Duration { d := time.Minute; return &d }(),
+			expected:         time.Minute,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			zone := validDNSZone()
+			zone.CreationTimestamp = metav1.NewTime(time.Now().Add(-tc.created))
+			if tc.unavailableSince != nil {
+				zone.Status.Conditions = []hivev1.DNSZoneCondition{{
+					Type:               hivev1.ZoneAvailableDNSZoneCondition,
+					Status:             corev1.ConditionFalse,
+					LastTransitionTime: metav1.NewTime(time.Now().Add(-*tc.unavailableSince)),
+				}}
+			}
+			assert.InDelta(t, tc.expected.Seconds(), r.checkInterval(zone).Seconds(), 1, "unexpected check interval")
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package dnszone
 
 import (
+	"context"
 	"net/http"
 	"strings"
 
@@ -71,6 +72,7 @@ func (a *GCPActuator) Create() error {
 
 	zone := a.dnsZone.Spec.Zone
 	managedZone, err := a.gcpClient.CreateManagedZone(
+		context.TODO(),
 		&dns.ManagedZone{
 			Name:        generateManagedZoneName(zone),
 			Description: managedByHiveDescription,
@@ -111,7 +113,7 @@ func (a *GCPActuator) Delete() error {
 	}
 
 	logger.Info("Deleting managed zone")
-	err := a.gcpClient.DeleteManagedZone(zoneName)
+	err := a.gcpClient.DeleteManagedZone(context.TODO(), zoneName)
 	if err != nil {
 		logLevel := log.ErrorLevel
 		if gcpErr, ok := err.(*googleapi.Error); ok && gcpErr.Code == http.StatusBadRequest {
@@ -131,7 +133,7 @@ func (a *GCPActuator) Delete() error {
 func DeleteGCPRecordSets(gcpClient gcpclient.Client, dnsZone *hivev1.DNSZone, logger log.FieldLogger) error {
 	listOpts := gcpclient.ListResourceRecordSetsOptions{}
 	for {
-		listOutput, err := gcpClient.ListResourceRecordSets(*dnsZone.Status.GCP.ZoneName, listOpts)
+		listOutput, err := gcpClient.ListResourceRecordSets(context.TODO(), *dnsZone.Status.GCP.ZoneName, listOpts)
 		if err != nil {
 			return err
 		}
@@ -146,7 +148,7 @@ func DeleteGCPRecordSets(gcpClient gcpclient.Client, dnsZone *hivev1.DNSZone, lo
 		}
 		if len(recordSetsToDelete) > 0 {
 			logger.WithField("count", len(recordSetsToDelete)).Info("deleting recordsets")
-			if err := gcpClient.DeleteResourceRecordSets(*dnsZone.Status.GCP.ZoneName, recordSetsToDelete); err != nil {
+			if err := gcpClient.DeleteResourceRecordSets(context.TODO(), *dnsZone.Status.GCP.ZoneName, recordSetsToDelete); err != nil {
 				return err
 			}
 		}
@@ -210,7 +212,7 @@ func (a *GCPActuator) Refresh() error {
 	// Fetch the managed zone
 	logger := a.logger.WithField("zoneName", zoneName)
 	logger.Debug("Fetching managed zone by zone name")
-	resp, err := a.gcpClient.GetManagedZone(zoneName)
+	resp, err := a.gcpClient.GetManagedZone(context.TODO(), zoneName)
 	if err != nil {
 		if gerr, ok := err.(*googleapi.Error); ok {
 			if gerr.Code == http.StatusNotFound {
@@ -54,7 +54,7 @@ func TestNewGCPActuator(t *testing.T) {
 }
 
 func mockGCPZoneExists(expect *mock.MockClientMockRecorder) {
-	expect.GetManagedZone(gomock.Any()).Return(&dns.ManagedZone{
+	expect.GetManagedZone(gomock.Any(), gomock.Any()).Return(&dns.ManagedZone{
 		DnsName:     "blah.example.com",
 		Name:        "hive-blah-example-com",
 		NameServers: []string{"ns1.example.com", "ns2.example.com"},
@@ -62,13 +62,13 @@ func mockGCPZoneExists(expect *mock.MockClientMockRecorder) {
 }
 
 func mockGCPZoneDoesntExist(expect *mock.MockClientMockRecorder) {
-	expect.GetManagedZone(gomock.Any()).
+	expect.GetManagedZone(gomock.Any(), gomock.Any()).
 		Return(nil, &googleapi.Error{Code: 404}).
 		Times(1)
 }
 
 func mockCreateGCPZone(expect *mock.MockClientMockRecorder) {
-	expect.CreateManagedZone(gomock.Any()).Return(&dns.ManagedZone{
+	expect.CreateManagedZone(gomock.Any(), gomock.Any()).Return(&dns.ManagedZone{
 		DnsName:     "blah.example.com",
 		Name:        "hive-blah-example-com",
 		NameServers: []string{"ns1.example.com", "ns2.example.com"},
@@ -76,6 +76,6 @@ func mockCreateGCPZone(expect *mock.MockClientMockRecorder) {
 }
 
 func mockDeleteGCPZone(expect *mock.MockClientMockRecorder) {
-	expect.ListResourceRecordSets(gomock.Any(), gomock.Any()).Return(&dns.ResourceRecordSetsListResponse{}, nil)
-	expect.DeleteManagedZone(gomock.Any()).Return(nil).Times(1)
+	expect.ListResourceRecordSets(gomock.Any(), gomock.Any(), gomock.Any()).Return(&dns.ResourceRecordSetsListResponse{}, nil)
+	expect.DeleteManagedZone(gomock.Any(), gomock.Any()).Return(nil).Times(1)
 }
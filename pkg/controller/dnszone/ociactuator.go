@@ -0,0 +1,222 @@
+package dnszone
+
+import (
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/ociclient"
+)
+
+const (
+	ociZoneStateActive = "ACTIVE"
+
+	// ociManagedByTag is the freeform tag set on zones created by hive.
+	ociManagedByTag = "hive.openshift.io_managed"
+)
+
+// OCIActuator attempts to make the current state reflect the given desired state.
+type OCIActuator struct {
+	// logger is the logger used for this controller
+	logger log.FieldLogger
+
+	// ociClient is a utility for making it easy for controllers to interface with OCI
+	ociClient ociclient.Client
+
+	// dnsZone is the DNSZone that represents the desired state.
+	dnsZone *hivev1.DNSZone
+
+	// zone is the OCI DNS zone object.
+	zone *ociclient.Zone
+}
+
+type ociClientBuilderType func(secret *corev1.Secret, region string) (ociclient.Client, error)
+
+// NewOCIActuator creates a new OCIActuator object. A new OCIActuator is expected to be created for each controller sync.
+func NewOCIActuator(
+	logger log.FieldLogger,
+	secret *corev1.Secret,
+	dnsZone *hivev1.DNSZone,
+	ociClientBuilder ociClientBuilderType,
+) (*OCIActuator, error) {
+	ociClient, err := ociClientBuilder(secret, dnsZone.Spec.OCI.Region)
+	if err != nil {
+		logger.WithError(err).Error("Error creating OCIClient")
+		return nil, err
+	}
+
+	ociActuator := &OCIActuator{
+		logger:    logger,
+		ociClient: ociClient,
+		dnsZone:   dnsZone,
+	}
+
+	return ociActuator, nil
+}
+
+// Ensure OCIActuator implements the Actuator interface. This will fail at compile time when false.
+var _ Actuator = &OCIActuator{}
+
+// Create implements the Create call of the actuator interface
+func (a *OCIActuator) Create() error {
+	logger := a.logger.WithField("zone", a.dnsZone.Spec.Zone)
+	logger.Info("Creating zone")
+
+	zone, err := a.ociClient.CreateZone(
+		a.dnsZone.Spec.OCI.CompartmentID,
+		a.dnsZone.Spec.Zone,
+		a.desiredTags(),
+	)
+	if err != nil {
+		logger.WithError(err).Error("Error creating zone")
+		return err
+	}
+
+	logger.Debug("Zone successfully created")
+	a.zone = zone
+	if err := a.modifyStatus(); err != nil {
+		logger.WithError(err).Error("failed to sync DNSZone status fields")
+		return err
+	}
+
+	return nil
+}
+
+// Delete implements the Delete call of the actuator interface. Deleting an OCI zone also removes all of
+// the records in it.
+func (a *OCIActuator) Delete() error {
+	if a.dnsZone.Status.OCI == nil {
+		return errors.New("deleting non-OCI DNSZone with OCI actuator")
+	}
+	if a.dnsZone.Status.OCI.ZoneID == nil {
+		return errors.New("zone ID not found in DNSZone status")
+	}
+	zoneID := *a.dnsZone.Status.OCI.ZoneID
+
+	logger := a.logger.WithField("zone", a.dnsZone.Spec.Zone).WithField("zoneID", zoneID)
+	logger.Info("Deleting zone")
+	if err := a.ociClient.DeleteZone(zoneID); err != nil && !ociclient.IsNotFound(err) {
+		logger.WithError(err).Error("Cannot delete zone")
+		return err
+	}
+	return nil
+}
+
+// Exists implements the Exists call of the actuator interface
+func (a *OCIActuator) Exists() (bool, error) {
+	return a.zone != nil, nil
+}
+
+// UpdateMetadata ensures that the freeform tags of the OCI zone include those of the DNSZone. Tags set on the zone
+// by others are kept.
+func (a *OCIActuator) UpdateMetadata() error {
+	if a.zone == nil {
+		return errors.New("zone is unpopulated")
+	}
+
+	logger := a.logger.WithField("zone", a.dnsZone.Spec.Zone).WithField("zoneID", a.zone.ID)
+	tags := make(map[string]string, len(a.zone.FreeformTags))
+	for k, v := range a.zone.FreeformTags {
+		tags[k] = v
+	}
+	changed := false
+	for k, v := range a.desiredTags() {
+		if existing, ok := tags[k]; !ok || existing != v {
+			tags[k] = v
+			changed = true
+		}
+	}
+	if !changed {
+		logger.Debug("zone tags are up to date")
+		return nil
+	}
+
+	logger.WithField("tags", tags).Info("Updating zone tags")
+	zone, err := a.ociClient.UpdateZoneTags(a.zone.ID, tags)
+	if err != nil {
+		logger.WithError(err).Error("Cannot update zone tags")
+		return err
+	}
+	a.zone = zone
+	return nil
+}
+
+// desiredTags returns the freeform tags which the OCI zone should have.
+func (a *OCIActuator) desiredTags() map[string]string {
+	tags := make(map[string]string, len(a.dnsZone.Spec.OCI.FreeformTags)+1)
+	for k, v := range a.dnsZone.Spec.OCI.FreeformTags {
+		tags[k] = v
+	}
+	tags[ociManagedByTag] = "true"
+	return tags
+}
+
+// modifyStatus updates the DnsZone's status with OCI specific information.
+func (a *OCIActuator) modifyStatus() error {
+	if a.zone == nil {
+		return errors.New("zone is unpopulated")
+	}
+
+	a.dnsZone.Status.OCI = &hivev1.OCIDNSZoneStatus{
+		ZoneID: &a.zone.ID,
+	}
+
+	return nil
+}
+
+// GetNameServers implements the GetNameServers call of the actuator interface
+func (a *OCIActuator) GetNameServers() ([]string, error) {
+	if a.zone == nil {
+		return nil, errors.New("zone is unpopulated")
+	}
+
+	logger := a.logger.WithField("zone", a.dnsZone.Spec.Zone)
+	result := make([]string, 0, len(a.zone.Nameservers))
+	for _, ns := range a.zone.Nameservers {
+		result = append(result, ns.Hostname)
+	}
+	logger.WithField("nameservers", result).Debug("found zone name servers")
+	return result, nil
+}
+
+// Refresh implements the Refresh call of the actuator interface
+func (a *OCIActuator) Refresh() error {
+	// OCI accepts either the zone OCID or the zone name when fetching a zone.
+	zoneID := a.dnsZone.Spec.Zone
+	if a.dnsZone.Status.OCI != nil && a.dnsZone.Status.OCI.ZoneID != nil {
+		a.logger.Debug("ZoneID is set in status, will retrieve by that ID")
+		zoneID = *a.dnsZone.Status.OCI.ZoneID
+	}
+
+	logger := a.logger.WithField("zoneID", zoneID)
+	logger.Debug("Fetching zone")
+	zone, err := a.ociClient.GetZone(zoneID)
+	if err != nil {
+		if ociclient.IsNotFound(err) {
+			logger.Debug("Zone not found, clearing out the cached object")
+			a.zone = nil
+			return nil
+		}
+		logger.WithError(err).Error("Cannot get zone")
+		return err
+	}
+	if zone.LifecycleState != "" && zone.LifecycleState != ociZoneStateActive {
+		logger.WithField("state", zone.LifecycleState).Debug("Zone is not active yet")
+	}
+
+	logger.Debug("Found zone")
+	a.zone = zone
+	if err := a.modifyStatus(); err != nil {
+		logger.WithError(err).Error("failed to sync DNSZone status fields")
+		return err
+	}
+
+	return nil
+}
+
+// SetConditionsForError sets conditions on the dnszone given a specific error. Returns true if conditions changed.
+func (a *OCIActuator) SetConditionsForError(err error) bool {
+	return false // Not implemented for OCI yet.
+}
@@ -0,0 +1,241 @@
+package dnszone
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/ociclient"
+	mockoci "github.com/openshift/hive/pkg/ociclient/mock"
+)
+
+const testOCIZoneID = "ocid1.dns-zone.oc1..fake"
+
+func TestOCIActuator(t *testing.T) {
+	cases := []struct {
+		name                string
+		dnsZone             *hivev1.DNSZone
+		setupMocks          func(*mockoci.MockClientMockRecorder)
+		run                 func(*testing.T, *OCIActuator)
+		expectedZoneID      *string
+		expectedNameServers []string
+	}{
+		{
+			name:    "refresh existing zone by name",
+			dnsZone: validOCIDNSZone(),
+			setupMocks: func(expect *mockoci.MockClientMockRecorder) {
+				expect.GetZone("blah.example.com").Return(testOCIZone(), nil)
+			},
+			run: func(t *testing.T, a *OCIActuator) {
+				require.NoError(t, a.Refresh())
+				exists, err := a.Exists()
+				require.NoError(t, err)
+				assert.True(t, exists, "expected zone to exist")
+			},
+			expectedZoneID:      pointer.StringPtr(testOCIZoneID),
+			expectedNameServers: []string{"ns1.example.com", "ns2.example.com"},
+		},
+		{
+			name: "refresh existing zone by ID",
+			dnsZone: func() *hivev1.DNSZone {
+				z := validOCIDNSZone()
+				z.Status.OCI = &hivev1.OCIDNSZoneStatus{ZoneID: pointer.StringPtr(testOCIZoneID)}
+				return z
+			}(),
+			setupMocks: func(expect *mockoci.MockClientMockRecorder) {
+				expect.GetZone(testOCIZoneID).Return(testOCIZone(), nil)
+			},
+			run: func(t *testing.T, a *OCIActuator) {
+				require.NoError(t, a.Refresh())
+			},
+			expectedZoneID:      pointer.StringPtr(testOCIZoneID),
+			expectedNameServers: []string{"ns1.example.com", "ns2.example.com"},
+		},
+		{
+			name:    "zone does not exist",
+			dnsZone: validOCIDNSZone(),
+			setupMocks: func(expect *mockoci.MockClientMockRecorder) {
+				expect.GetZone(gomock.Any()).Return(nil, &ociclient.Error{StatusCode: http.StatusNotFound})
+			},
+			run: func(t *testing.T, a *OCIActuator) {
+				require.NoError(t, a.Refresh())
+				exists, err := a.Exists()
+				require.NoError(t, err)
+				assert.False(t, exists, "expected zone to not exist")
+			},
+		},
+		{
+			name:    "create zone",
+			dnsZone: validOCIDNSZone(),
+			setupMocks: func(expect *mockoci.MockClientMockRecorder) {
+				expect.CreateZone("ocid1.compartment.oc1..fake", "blah.example.com", map[string]string{ociManagedByTag: "true"}).
+					Return(testOCIZone(), nil)
+			},
+			run: func(t *testing.T, a *OCIActuator) {
+				require.NoError(t, a.Create())
+			},
+			expectedZoneID:      pointer.StringPtr(testOCIZoneID),
+			expectedNameServers: []string{"ns1.example.com", "ns2.example.com"},
+		},
+		{
+			name: "create zone with freeform tags",
+			dnsZone: func() *hivev1.DNSZone {
+				z := validOCIDNSZone()
+				z.Spec.OCI.FreeformTags = map[string]string{"team": "fleet"}
+				return z
+			}(),
+			setupMocks: func(expect *mockoci.MockClientMockRecorder) {
+				expect.CreateZone("ocid1.compartment.oc1..fake", "blah.example.com", map[string]string{ociManagedByTag: "true", "team": "fleet"}).
+					Return(testOCIZone(), nil)
+			},
+			run: func(t *testing.T, a *OCIActuator) {
+				require.NoError(t, a.Create())
+			},
+			expectedZoneID: pointer.StringPtr(testOCIZoneID),
+		},
+		{
+			name: "update missing zone tags",
+			dnsZone: func() *hivev1.DNSZone {
+				z := validOCIDNSZone()
+				z.Spec.OCI.FreeformTags = map[string]string{"team": "fleet"}
+				return z
+			}(),
+			setupMocks: func(expect *mockoci.MockClientMockRecorder) {
+				zone := testOCIZone()
+				zone.FreeformTags = map[string]string{ociManagedByTag: "true", "team": "old", "owner": "someone"}
+				expect.GetZone("blah.example.com").Return(zone, nil)
+				expect.UpdateZoneTags(testOCIZoneID, map[string]string{ociManagedByTag: "true", "team": "fleet", "owner": "someone"}).
+					Return(testOCIZone(), nil)
+			},
+			run: func(t *testing.T, a *OCIActuator) {
+				require.NoError(t, a.Refresh())
+				require.NoError(t, a.UpdateMetadata())
+			},
+			expectedZoneID: pointer.StringPtr(testOCIZoneID),
+		},
+		{
+			name:    "zone tags up to date",
+			dnsZone: validOCIDNSZone(),
+			setupMocks: func(expect *mockoci.MockClientMockRecorder) {
+				zone := testOCIZone()
+				zone.FreeformTags = map[string]string{ociManagedByTag: "true", "owner": "someone"}
+				expect.GetZone("blah.example.com").Return(zone, nil)
+			},
+			run: func(t *testing.T, a *OCIActuator) {
+				require.NoError(t, a.Refresh())
+				require.NoError(t, a.UpdateMetadata())
+			},
+			expectedZoneID: pointer.StringPtr(testOCIZoneID),
+		},
+		{
+			name: "delete zone",
+			dnsZone: func() *hivev1.DNSZone {
+				z := validOCIDNSZone()
+				z.Status.OCI = &hivev1.OCIDNSZoneStatus{ZoneID: pointer.StringPtr(testOCIZoneID)}
+				return z
+			}(),
+			setupMocks: func(expect *mockoci.MockClientMockRecorder) {
+				expect.DeleteZone(testOCIZoneID).Return(nil)
+			},
+			run: func(t *testing.T, a *OCIActuator) {
+				require.NoError(t, a.Delete())
+			},
+			expectedZoneID: pointer.StringPtr(testOCIZoneID),
+		},
+		{
+			name: "delete already deleted zone",
+			dnsZone: func() *hivev1.DNSZone {
+				z := validOCIDNSZone()
+				z.Status.OCI = &hivev1.OCIDNSZoneStatus{ZoneID: pointer.StringPtr(testOCIZoneID)}
+				return z
+			}(),
+			setupMocks: func(expect *mockoci.MockClientMockRecorder) {
+				expect.DeleteZone(testOCIZoneID).Return(&ociclient.Error{StatusCode: http.StatusNotFound})
+			},
+			run: func(t *testing.T, a *OCIActuator) {
+				require.NoError(t, a.Delete())
+			},
+			expectedZoneID: pointer.StringPtr(testOCIZoneID),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			mockClient := mockoci.NewMockClient(mockCtrl)
+			tc.setupMocks(mockClient.EXPECT())
+
+			actuator, err := NewOCIActuator(
+				log.WithField("controller", ControllerName),
+				validOCISecret(),
+				tc.dnsZone,
+				func(*corev1.Secret, string) (ociclient.Client, error) { return mockClient, nil },
+			)
+			require.NoError(t, err)
+
+			tc.run(t, actuator)
+
+			if tc.expectedZoneID != nil {
+				if assert.NotNil(t, tc.dnsZone.Status.OCI, "expected OCI status") {
+					assert.Equal(t, *tc.expectedZoneID, *tc.dnsZone.Status.OCI.ZoneID, "unexpected zone ID")
+				}
+			}
+			if tc.expectedNameServers != nil {
+				nameServers, err := actuator.GetNameServers()
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedNameServers, nameServers, "unexpected name servers")
+			}
+		})
+	}
+}
+
+func testOCIZone() *ociclient.Zone {
+	return &ociclient.Zone{
+		ID:             testOCIZoneID,
+		Name:           "blah.example.com",
+		LifecycleState: ociZoneStateActive,
+		Nameservers: []ociclient.Nameserver{
+			{Hostname: "ns1.example.com"},
+			{Hostname: "ns2.example.com"},
+		},
+	}
+}
+
+func validOCIDNSZone() *hivev1.DNSZone {
+	return &hivev1.DNSZone{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "dnszoneobject",
+			Namespace:  "ns",
+			Finalizers: []string{hivev1.FinalizerDNSZone},
+		},
+		Spec: hivev1.DNSZoneSpec{
+			Zone: "blah.example.com",
+			OCI: &hivev1.OCIDNSZoneSpec{
+				CredentialsSecretRef: corev1.LocalObjectReference{
+					Name: "somesecret",
+				},
+				Region:        "us-ashburn-1",
+				CompartmentID: "ocid1.compartment.oc1..fake",
+			},
+		},
+	}
+}
+
+func validOCISecret() *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "somesecret",
+			Namespace: "ns",
+		},
+	}
+}
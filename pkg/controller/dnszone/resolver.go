@@ -0,0 +1,220 @@
+package dnszone
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	dnsOverTLSScheme   = "tls://"
+	dnsOverHTTPSScheme = "https://"
+	dnsOverUDPScheme   = "udp://"
+	dnsMessageType     = "application/dns-message"
+	maxDNSMessageSize  = 65535
+)
+
+// resolverConfig is the configuration of the DNS queries checking that zones are delegated.
+type resolverConfig struct {
+	// servers are the resolvers to query, as "host:port" addresses queried over UDP, "tls://host:port" addresses
+	// queried with DNS over TLS, or "https://" URLs queried with DNS over HTTPS.
+	servers []string
+	timeout time.Duration
+	// httpClient is the client of DNS over HTTPS queries. A client with the query timeout is used when nil.
+	httpClient *http.Client
+}
+
+// loadResolverConfig loads the resolvers configured in the environment, falling back to the resolvers in
+// /etc/resolv.conf.
+func loadResolverConfig(logger log.FieldLogger) resolverConfig {
+	rc := resolverConfig{timeout: dnsClientTimeout}
+	if timeout := os.Getenv(constants.ZoneCheckQueryTimeoutEnvVar); timeout != "" {
+		if d, err := time.ParseDuration(timeout); err != nil {
+			logger.WithError(err).WithField("timeout", timeout).Warn("invalid DNS query timeout, using default")
+		} else {
+			rc.timeout = d
+		}
+	}
+	if serversFromEnv := os.Getenv(constants.ZoneCheckDNSServersEnvVar); len(serversFromEnv) > 0 {
+		for _, s := range strings.Split(serversFromEnv, ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				rc.servers = append(rc.servers, normalizeResolver(s))
+			}
+		}
+		return rc
+	}
+	// TODO: determine if there's a better way to obtain resolver endpoints
+	clientConfig, err := dns.ClientConfigFromFile(resolverConfigFile)
+	if err != nil {
+		logger.WithError(err).Warn("could not read resolver configuration")
+		return rc
+	}
+	for _, s := range clientConfig.Servers {
+		rc.servers = append(rc.servers, net.JoinHostPort(s, clientConfig.Port))
+	}
+	return rc
+}
+
+// normalizeResolver adds the default port to resolvers which do not specify one.
+func normalizeResolver(server string) string {
+	switch {
+	case strings.HasPrefix(server, dnsOverHTTPSScheme):
+		return server
+	case strings.HasPrefix(server, dnsOverTLSScheme):
+		return dnsOverTLSScheme + withDefaultPort(strings.TrimPrefix(server, dnsOverTLSScheme), "853")
+	default:
+		return withDefaultPort(strings.TrimPrefix(server, dnsOverUDPScheme), "53")
+	}
+}
+
+func withDefaultPort(host, port string) string {
+	if _, _, err := net.SplitHostPort(host); err == nil {
+		return host
+	}
+	return net.JoinHostPort(strings.Trim(host, "[]"), port)
+}
+
+// exchange sends the query to the resolver and returns its answer.
+func (rc resolverConfig) exchange(m *dns.Msg, server string) (*dns.Msg, time.Duration, error) {
+	switch {
+	case strings.HasPrefix(server, dnsOverHTTPSScheme):
+		return rc.exchangeHTTPS(m, server)
+	case strings.HasPrefix(server, dnsOverTLSScheme):
+		client := dns.Client{Net: "tcp-tls", Timeout: rc.timeout}
+		return client.Exchange(m, strings.TrimPrefix(server, dnsOverTLSScheme))
+	default:
+		client := dns.Client{Timeout: rc.timeout}
+		return client.Exchange(m, server)
+	}
+}
+
+// exchangeHTTPS sends the query to a DNS over HTTPS resolver as described in RFC 8484.
+func (rc resolverConfig) exchangeHTTPS(m *dns.Msg, url string) (*dns.Msg, time.Duration, error) {
+	packed, err := m.Pack()
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "could not pack DNS query")
+	}
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(packed))
+	if err != nil {
+		return nil, 0, err
+	}
+	req.Header.Set("Content-Type", dnsMessageType)
+	req.Header.Set("Accept", dnsMessageType)
+	start := time.Now()
+	httpClient := rc.httpClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: rc.timeout}
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, 0, fmt.Errorf("unexpected response status %s", resp.Status)
+	}
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxDNSMessageSize))
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "could not read DNS response")
+	}
+	in := &dns.Msg{}
+	if err := in.Unpack(body); err != nil {
+		return nil, 0, errors.Wrap(err, "could not unpack DNS response")
+	}
+	return in, time.Since(start), nil
+}
+
+func lookupSOARecord(zone string, logger log.FieldLogger) (bool, error) {
+	return loadResolverConfig(logger).lookupSOARecord(zone, logger)
+}
+
+// lookupSOARecord returns whether the first resolver which answers returns the SOA record of the zone.
+func (rc resolverConfig) lookupSOARecord(zone string, logger log.FieldLogger) (bool, error) {
+	logger.WithField("servers", rc.servers).Info("looking up domain SOA record")
+
+	m := &dns.Msg{}
+	m.SetQuestion(controllerutils.Dotted(zone), dns.TypeSOA)
+	for _, s := range rc.servers {
+		in, rtt, err := rc.exchange(m, s)
+		if err != nil {
+			logger.WithError(err).WithField("server", s).Info("query for SOA record failed")
+			continue
+		}
+		logger.WithField("server", s).Infof("SOA query duration: %v", rtt)
+		if len(in.Answer) > 0 {
+			for _, rr := range in.Answer {
+				soa, ok := rr.(*dns.SOA)
+				if !ok {
+					logger.Infof("Record returned is not an SOA record: %#v", rr)
+					continue
+				}
+				if soa.Hdr.Name != controllerutils.Dotted(zone) {
+					logger.WithField("zone", soa.Hdr.Name).Info("SOA record returned but it does not match the lookup zone")
+					return false, nil
+				}
+				logger.WithField("zone", soa.Hdr.Name).Info("SOA record returned, zone is reachable")
+				return true, nil
+			}
+		}
+		logger.WithField("server", s).Info("no answer for SOA record returned")
+		return false, nil
+	}
+	return false, nil
+}
+
+func lookupNSRecords(zone string, logger log.FieldLogger) ([]string, error) {
+	return loadResolverConfig(logger).lookupNSRecords(zone, logger)
+}
+
+// lookupNSRecords returns the name servers the resolvers return for the zone, as seen from the first resolver
+// which answers.
+func (rc resolverConfig) lookupNSRecords(zone string, logger log.FieldLogger) ([]string, error) {
+	logger.WithField("servers", rc.servers).Info("looking up domain NS records")
+
+	m := &dns.Msg{}
+	m.SetQuestion(controllerutils.Dotted(zone), dns.TypeNS)
+	var lastErr error
+	for _, s := range rc.servers {
+		in, _, err := rc.exchange(m, s)
+		if err != nil {
+			logger.WithError(err).WithField("server", s).Info("query for NS records failed")
+			lastErr = err
+			continue
+		}
+		var nameServers []string
+		for _, rr := range in.Answer {
+			if ns, ok := rr.(*dns.NS); ok && strings.EqualFold(ns.Hdr.Name, controllerutils.Dotted(zone)) {
+				nameServers = append(nameServers, ns.Ns)
+			}
+		}
+		return normalizeNameServers(nameServers), nil
+	}
+	if lastErr == nil {
+		lastErr = errors.New("no DNS resolvers configured")
+	}
+	return nil, lastErr
+}
+
+// normalizeNameServers returns the sorted, lower-case name servers without trailing dots.
+func normalizeNameServers(nameServers []string) []string {
+	normalized := make([]string, 0, len(nameServers))
+	for _, ns := range nameServers {
+		normalized = append(normalized, strings.ToLower(strings.TrimSuffix(ns, ".")))
+	}
+	sort.Strings(normalized)
+	return normalized
+}
@@ -0,0 +1,91 @@
+package dnszone
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openshift/hive/pkg/constants"
+)
+
+func TestNormalizeResolver(t *testing.T) {
+	cases := map[string]string{
+		"8.8.8.8":                        "8.8.8.8:53",
+		"8.8.8.8:5353":                   "8.8.8.8:5353",
+		"udp://8.8.8.8":                  "8.8.8.8:53",
+		"2001:4860:4860::8888":           "[2001:4860:4860::8888]:53",
+		"[2001:4860:4860::8888]:53":      "[2001:4860:4860::8888]:53",
+		"tls://dns.google":               "tls://dns.google:853",
+		"tls://1.1.1.1:8853":             "tls://1.1.1.1:8853",
+		"https://dns.google/dns-query":   "https://dns.google/dns-query",
+		"https://1.1.1.1:8443/dns-query": "https://1.1.1.1:8443/dns-query",
+	}
+	for server, expected := range cases {
+		assert.Equal(t, expected, normalizeResolver(server), "unexpected resolver for %s", server)
+	}
+}
+
+func TestLookupOverHTTPS(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, dnsMessageType, req.Header.Get("Content-Type"), "unexpected content type")
+		body, err := ioutil.ReadAll(req.Body)
+		require.NoError(t, err)
+		query := &dns.Msg{}
+		require.NoError(t, query.Unpack(body))
+		resp := &dns.Msg{}
+		resp.SetReply(query)
+		if query.Question[0].Qtype == dns.TypeNS {
+			for _, ns := range []string{"ns-2.example.net.", "NS-1.example.net."} {
+				rr, err := dns.NewRR("blah.example.com. 300 IN NS " + ns)
+				require.NoError(t, err)
+				resp.Answer = append(resp.Answer, rr)
+			}
+		}
+		packed, err := resp.Pack()
+		require.NoError(t, err)
+		w.Header().Set("Content-Type", dnsMessageType)
+		w.Write(packed)
+	}))
+	defer server.Close()
+
+	rc := resolverConfig{servers: []string{server.URL}, httpClient: server.Client()}
+	logger := log.WithField("test", "TestLookupOverHTTPS")
+
+	nameServers, err := rc.lookupNSRecords("blah.example.com", logger)
+	require.NoError(t, err, "unexpected error looking up NS records")
+	assert.Equal(t, []string{"ns-1.example.net", "ns-2.example.net"}, nameServers, "unexpected name servers")
+
+	available, err := rc.lookupSOARecord("blah.example.com", logger)
+	require.NoError(t, err, "unexpected error looking up SOA record")
+	assert.False(t, available, "expected SOA record to be unavailable")
+}
+
+func TestLookupNSRecordsFailure(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	rc := resolverConfig{servers: []string{server.URL}, httpClient: server.Client()}
+	_, err := rc.lookupNSRecords("blah.example.com", log.WithField("test", "TestLookupNSRecordsFailure"))
+	assert.Error(t, err, "expected error when resolvers fail")
+}
+
+func TestLoadResolverConfig(t *testing.T) {
+	os.Setenv(constants.ZoneCheckDNSServersEnvVar, "8.8.8.8, tls://1.1.1.1,https://dns.google/dns-query")
+	defer os.Unsetenv(constants.ZoneCheckDNSServersEnvVar)
+	os.Setenv(constants.ZoneCheckQueryTimeoutEnvVar, "5s")
+	defer os.Unsetenv(constants.ZoneCheckQueryTimeoutEnvVar)
+
+	rc := loadResolverConfig(log.WithField("test", "TestLoadResolverConfig"))
+	assert.Equal(t, []string{"8.8.8.8:53", "tls://1.1.1.1:853", "https://dns.google/dns-query"}, rc.servers, "unexpected resolvers")
+	assert.Equal(t, 5*time.Second, rc.timeout, "unexpected query timeout")
+}
@@ -24,6 +24,10 @@ import (
 	mockgcp "github.com/openshift/hive/pkg/gcpclient/mock"
 )
 
+const (
+	testDNSZoneID = "abcdef"
+)
+
 var (
 	kubeTimeNow = func() *metav1.Time {
 		t := metav1.NewTime(time.Now())
@@ -149,6 +153,12 @@ var (
 		return zone
 	}
 
+	validDNSZoneWithDNSZoneID = func() *hivev1.DNSZone {
+		zone := validDNSZoneWithoutID()
+		zone.Annotations = map[string]string{constants.DNSZoneIDAnnotation: testDNSZoneID}
+		return zone
+	}
+
 	validDNSZoneWithAdditionalTags = func() *hivev1.DNSZone {
 		zone := validDNSZone()
 		zone.Spec.AWS.AdditionalTags = append(zone.Spec.AWS.AdditionalTags, []hivev1.AWSResourceTag{
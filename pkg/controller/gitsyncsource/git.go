@@ -0,0 +1,202 @@
+package gitsyncsource
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+const (
+	// gitTimeout is how long a single git command may run.
+	gitTimeout = 5 * time.Minute
+
+	// askPassScript answers the username and password prompts of git from the environment, which keeps the
+	// credentials out of the command line and the repository configuration.
+	askPassScript = `#!/bin/sh
+case "$1" in
+Username*) echo "$HIVE_GIT_USERNAME" ;;
+*) echo "$HIVE_GIT_PASSWORD" ;;
+esac
+`
+)
+
+// gitCredentials are the credentials used to authenticate with a repository.
+type gitCredentials struct {
+	username string
+	password string
+}
+
+// gitClient reads the contents of remote Git repositories.
+type gitClient interface {
+	// resolve returns the commit the ref points to in the repository.
+	resolve(url string, ref hivev1.GitReference, creds *gitCredentials) (string, error)
+	// readFiles returns the contents of the regular files in the directory of the commit, keyed by their path in
+	// the repository. The ref is the ref the commit was resolved from.
+	readFiles(url string, ref hivev1.GitReference, commit, dir string, creds *gitCredentials) (map[string][]byte, error)
+}
+
+// execGitClient is a gitClient which runs the git binary.
+type execGitClient struct{}
+
+var _ gitClient = execGitClient{}
+
+func (c execGitClient) resolve(url string, ref hivev1.GitReference, creds *gitCredentials) (string, error) {
+	var refName string
+	switch {
+	case ref.Commit != "":
+		return ref.Commit, nil
+	case ref.Branch != "":
+		refName = "refs/heads/" + ref.Branch
+	case ref.Tag != "":
+		refName = "refs/tags/" + ref.Tag
+	default:
+		return "", errors.New("no branch, tag or commit specified")
+	}
+	workDir, err := ioutil.TempDir("", "gitsyncsource")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(workDir)
+	out, err := c.run(workDir, creds, "ls-remote", url, refName, refName+"^{}")
+	if err != nil {
+		return "", err
+	}
+	// Annotated tags are listed a second time, peeled to the commit they point to.
+	commits := map[string]string{}
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		if fields := strings.Fields(line); len(fields) == 2 {
+			commits[fields[1]] = fields[0]
+		}
+	}
+	if commit := commits[refName+"^{}"]; commit != "" {
+		return commit, nil
+	}
+	if commit := commits[refName]; commit != "" {
+		return commit, nil
+	}
+	return "", fmt.Errorf("%s not found in repository", refName)
+}
+
+func (c execGitClient) readFiles(url string, ref hivev1.GitReference, commit, dir string, creds *gitCredentials) (map[string][]byte, error) {
+	workDir, err := ioutil.TempDir("", "gitsyncsource")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(workDir)
+	repoDir := filepath.Join(workDir, "repo")
+	if _, err := c.run(workDir, nil, "init", "-q", repoDir); err != nil {
+		return nil, err
+	}
+	switch {
+	case ref.Branch != "":
+		_, err = c.run(repoDir, creds, "fetch", "-q", "--depth", "1", url, "refs/heads/"+ref.Branch)
+	case ref.Tag != "":
+		_, err = c.run(repoDir, creds, "fetch", "-q", "--depth", "1", url, "refs/tags/"+ref.Tag)
+	default:
+		// Not every server allows fetching a commit by its hash, so fall back to fetching all branches and tags.
+		if _, err = c.run(repoDir, creds, "fetch", "-q", "--depth", "1", url, commit); err != nil {
+			_, err = c.run(repoDir, creds, "fetch", "-q", "--tags", url, "+refs/heads/*:refs/remotes/origin/*")
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	if ref.Commit == "" {
+		fetched, err := c.run(repoDir, nil, "rev-parse", "FETCH_HEAD^{commit}")
+		if err != nil {
+			return nil, err
+		}
+		if fetched = strings.TrimSpace(fetched); fetched != commit {
+			return nil, fmt.Errorf("fetched commit %s instead of %s, the ref has moved", fetched, commit)
+		}
+	}
+	if _, err := c.run(repoDir, nil, "checkout", "-q", "--detach", commit); err != nil {
+		return nil, err
+	}
+	return readDir(repoDir, dir)
+}
+
+// run runs git in the directory and returns its standard output.
+func (c execGitClient) run(dir string, creds *gitCredentials, args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), gitTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	if creds != nil {
+		askPass := filepath.Join(dir, ".askpass")
+		if err := ioutil.WriteFile(askPass, []byte(askPassScript), 0700); err != nil {
+			return "", err
+		}
+		defer os.Remove(askPass)
+		cmd.Env = append(cmd.Env,
+			"GIT_ASKPASS="+askPass,
+			"HIVE_GIT_USERNAME="+creds.username,
+			"HIVE_GIT_PASSWORD="+creds.password,
+		)
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", errors.Wrapf(err, "git %s failed: %s", args[0], strings.TrimSpace(stderr.String()))
+	}
+	return stdout.String(), nil
+}
+
+// readDir returns the contents of the regular files in the directory of the repository, keyed by their path in the
+// repository. Symbolic links are not followed so that the repository cannot expose files outside of it.
+func readDir(repoDir, dir string) (map[string][]byte, error) {
+	files := map[string][]byte{}
+	fullDir := repoDir
+	for _, element := range strings.Split(filepath.Clean(dir), string(filepath.Separator)) {
+		switch element {
+		case ".", "":
+			continue
+		case "..":
+			return nil, fmt.Errorf("path %s is outside of the repository", dir)
+		}
+		fullDir = filepath.Join(fullDir, element)
+		info, err := os.Lstat(fullDir)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not read path %s", dir)
+		}
+		if !info.IsDir() {
+			return nil, fmt.Errorf("path %s is not a directory", dir)
+		}
+	}
+	infos, err := ioutil.ReadDir(fullDir)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not read path %s", dir)
+	}
+	for _, info := range infos {
+		if !info.Mode().IsRegular() {
+			continue
+		}
+		switch filepath.Ext(info.Name()) {
+		case ".yaml", ".yml", ".json":
+		default:
+			continue
+		}
+		content, err := ioutil.ReadFile(filepath.Join(fullDir, info.Name()))
+		if err != nil {
+			return nil, err
+		}
+		path, err := filepath.Rel(repoDir, filepath.Join(fullDir, info.Name()))
+		if err != nil {
+			return nil, err
+		}
+		files[filepath.ToSlash(path)] = content
+	}
+	return files, nil
+}
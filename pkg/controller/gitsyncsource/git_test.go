@@ -0,0 +1,93 @@
+package gitsyncsource
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+func TestExecGitClient(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	dir, err := ioutil.TempDir("", "gitsyncsource-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	git := func(args ...string) string {
+		cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		cmd.Dir = dir
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, "git %v failed: %s", args, out)
+		return strings.TrimSpace(string(out))
+	}
+	writeFile := func(path, content string) {
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, path)), 0755))
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, path), []byte(content), 0644))
+	}
+	git("init", "-q")
+	git("checkout", "-q", "-b", "main")
+	writeFile("fleet/a.yaml", "first")
+	writeFile("fleet/README.md", "not a manifest")
+	writeFile("fleet/nested/c.yaml", "not read")
+	require.NoError(t, os.Symlink("/etc/hostname", filepath.Join(dir, "fleet", "link.yaml")))
+	git("add", "-A")
+	git("commit", "-q", "-m", "first")
+	first := git("rev-parse", "HEAD")
+	git("tag", "-a", "v1", "-m", "v1")
+	writeFile("fleet/a.yaml", "second")
+	writeFile("fleet/b.yml", "added")
+	git("add", "-A")
+	git("commit", "-q", "-m", "second")
+	second := git("rev-parse", "HEAD")
+
+	c := execGitClient{}
+	url := "file://" + dir
+
+	commit, err := c.resolve(url, hivev1.GitReference{Branch: "main"}, nil)
+	require.NoError(t, err, "could not resolve branch")
+	assert.Equal(t, second, commit, "unexpected branch commit")
+	files, err := c.readFiles(url, hivev1.GitReference{Branch: "main"}, commit, "fleet", nil)
+	require.NoError(t, err, "could not read branch")
+	assert.Equal(t, map[string][]byte{
+		"fleet/a.yaml": []byte("second"),
+		"fleet/b.yml":  []byte("added"),
+	}, files, "unexpected files of branch")
+
+	commit, err = c.resolve(url, hivev1.GitReference{Tag: "v1"}, nil)
+	require.NoError(t, err, "could not resolve tag")
+	assert.Equal(t, first, commit, "expected annotated tag to be peeled")
+	files, err = c.readFiles(url, hivev1.GitReference{Tag: "v1"}, commit, "fleet/", nil)
+	require.NoError(t, err, "could not read tag")
+	assert.Equal(t, map[string][]byte{"fleet/a.yaml": []byte("first")}, files, "unexpected files of tag")
+
+	files, err = c.readFiles(url, hivev1.GitReference{Commit: first}, first, "", nil)
+	require.NoError(t, err, "could not read commit")
+	assert.Empty(t, files, "unexpected files at root of repository")
+
+	_, err = c.readFiles(url, hivev1.GitReference{Branch: "main"}, first, "fleet", nil)
+	assert.Error(t, err, "expected error when the branch has moved")
+
+	_, err = c.resolve(url, hivev1.GitReference{Branch: "missing"}, nil)
+	assert.Error(t, err, "expected error resolving missing branch")
+}
+
+func TestReadDirOutsideRepository(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gitsyncsource-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	require.NoError(t, os.Symlink(os.TempDir(), filepath.Join(dir, "link")))
+
+	_, err = readDir(dir, "../")
+	assert.Error(t, err, "expected error reading parent directory")
+	_, err = readDir(dir, "link")
+	assert.Error(t, err, "expected error reading through symbolic link")
+}
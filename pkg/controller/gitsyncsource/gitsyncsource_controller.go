@@ -0,0 +1,460 @@
+package gitsyncsource
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/ociartifact"
+)
+
+const (
+	ControllerName = hivev1.GitSyncSourceControllerName
+
+	// defaultPollInterval is how often branches and tags are resolved when the GitSyncSource does not specify it.
+	defaultPollInterval = 5 * time.Minute
+
+	// credentialsUsernameKey and credentialsPasswordKey are the keys of the credentials secret.
+	credentialsUsernameKey = "username"
+	credentialsPasswordKey = "password"
+
+	syncSucceededReason = "SyncSucceeded"
+	resolveFailedReason = "ResolveFailed"
+	fetchFailedReason   = "FetchFailed"
+	invalidReason       = "InvalidManifests"
+	applyFailedReason   = "ApplyFailed"
+)
+
+// Add creates a new GitSyncSource controller and adds it to the manager with default RBAC.
+func Add(mgr manager.Manager) error {
+	logger := log.WithField("controller", ControllerName)
+	concurrentReconciles, clientRateLimiter, queueRateLimiter, err := controllerutils.GetControllerConfig(mgr.GetClient(), ControllerName)
+	if err != nil {
+		logger.WithError(err).Error("could not get controller configurations")
+		return err
+	}
+	return AddToManager(mgr, NewReconciler(mgr, clientRateLimiter), concurrentReconciles, queueRateLimiter)
+}
+
+// NewReconciler returns a new reconcile.Reconciler
+func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) reconcile.Reconciler {
+	return &ReconcileGitSyncSource{
+		Client: controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
+		scheme: mgr.GetScheme(),
+		git:    execGitClient{},
+	}
+}
+
+// AddToManager adds a new Controller to mgr with r as the reconcile.Reconciler
+func AddToManager(mgr manager.Manager, r reconcile.Reconciler, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
+	c, err := controller.New("gitsyncsource-controller", mgr, controller.Options{
+		Reconciler:              r,
+		MaxConcurrentReconciles: concurrentReconciles,
+		RateLimiter:             rateLimiter,
+	})
+	if err != nil {
+		log.WithField("controller", ControllerName).WithError(err).Error("Error creating new gitsyncsource controller")
+		return err
+	}
+
+	// Watch for changes to GitSyncSource
+	if err := c.Watch(&source.Kind{Type: &hivev1.GitSyncSource{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		log.WithField("controller", ControllerName).WithError(err).Error("Error watching git sync source")
+		return err
+	}
+
+	// Watch for changes to the rendered SelectorSyncSets, so that changes made outside of the repository are reverted
+	if err := c.Watch(&source.Kind{Type: &hivev1.SelectorSyncSet{}}, &handler.EnqueueRequestForOwner{
+		IsController: true,
+		OwnerType:    &hivev1.GitSyncSource{},
+	}); err != nil {
+		log.WithField("controller", ControllerName).WithError(err).Error("Error watching selector sync sets")
+		return err
+	}
+	return nil
+}
+
+var _ reconcile.Reconciler = &ReconcileGitSyncSource{}
+
+// ReconcileGitSyncSource reconciles a GitSyncSource, rendering the SelectorSyncSets held in its repository.
+type ReconcileGitSyncSource struct {
+	client.Client
+	scheme *runtime.Scheme
+	git    gitClient
+}
+
+// syncError is an error syncing a GitSyncSource, with the reason reported in its SyncFailed condition.
+type syncError struct {
+	reason string
+	err    error
+}
+
+// Reconcile renders the SelectorSyncSets of the commit the GitSyncSource points to.
+func (r *ReconcileGitSyncSource) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	logger := controllerutils.BuildControllerLogger(ControllerName, "gitSyncSource", request.NamespacedName)
+	logger.Info("reconciling git sync source")
+	recobsrv := hivemetrics.NewReconcileObserver(ControllerName, logger)
+	defer recobsrv.ObserveControllerReconcileTime()
+
+	gss := &hivev1.GitSyncSource{}
+	if err := r.Get(context.TODO(), request.NamespacedName, gss); err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Debug("git sync source not found")
+			return reconcile.Result{}, nil
+		}
+		logger.WithError(err).Error("error getting git sync source")
+		return reconcile.Result{}, err
+	}
+	if gss.DeletionTimestamp != nil {
+		// The rendered SelectorSyncSets are owned by the GitSyncSource and are garbage collected with it.
+		return reconcile.Result{}, nil
+	}
+
+	pollInterval := defaultPollInterval
+	if gss.Spec.PollInterval != nil && gss.Spec.PollInterval.Duration > 0 {
+		pollInterval = gss.Spec.PollInterval.Duration
+	}
+	// A pinned commit never changes, so there is nothing to poll once it is synced.
+	result := reconcile.Result{RequeueAfter: pollInterval}
+	if gss.Spec.Ref.Commit != "" {
+		result = reconcile.Result{}
+	}
+
+	commit, names, syncErr := r.sync(gss, logger)
+	if syncErr != nil {
+		logger.WithField("reason", syncErr.reason).WithError(syncErr.err).Warn("failed to sync git sync source")
+		conds, changed := controllerutils.SetGitSyncSourceConditionWithChangeCheck(
+			gss.Status.Conditions,
+			hivev1.GitSyncSourceSyncFailedCondition,
+			corev1.ConditionTrue,
+			syncErr.reason,
+			syncErr.err.Error(),
+			controllerutils.UpdateConditionIfReasonOrMessageChange,
+		)
+		if changed {
+			gss.Status.Conditions = conds
+			if err := r.Status().Update(context.TODO(), gss); err != nil {
+				logger.WithError(err).Log(controllerutils.LogLevel(err), "error updating git sync source status")
+				return reconcile.Result{}, err
+			}
+		}
+		return reconcile.Result{RequeueAfter: pollInterval}, nil
+	}
+	if names == nil {
+		logger.WithField("commit", commit).Debug("commit already synced")
+		return result, nil
+	}
+
+	now := metav1.Now()
+	gss.Status.ObservedGeneration = gss.Generation
+	gss.Status.Commit = commit
+	gss.Status.LastSyncTime = &now
+	gss.Status.SelectorSyncSets = names
+	gss.Status.Conditions = controllerutils.SetGitSyncSourceCondition(
+		gss.Status.Conditions,
+		hivev1.GitSyncSourceSyncFailedCondition,
+		corev1.ConditionFalse,
+		syncSucceededReason,
+		fmt.Sprintf("SelectorSyncSets rendered from commit %s", commit),
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	if err := r.Status().Update(context.TODO(), gss); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "error updating git sync source status")
+		return reconcile.Result{}, err
+	}
+	logger.WithField("commit", commit).WithField("selectorSyncSets", len(names)).Info("synced git sync source")
+	return result, nil
+}
+
+// sync renders the SelectorSyncSets of the commit the GitSyncSource points to. It returns the commit and the names of
+// the rendered SelectorSyncSets, which are nil when the commit was already synced and none of its SelectorSyncSets
+// were changed since.
+func (r *ReconcileGitSyncSource) sync(gss *hivev1.GitSyncSource, logger log.FieldLogger) (string, []string, *syncError) {
+	if err := validateSpec(&gss.Spec); err != nil {
+		return "", nil, &syncError{reason: invalidReason, err: err}
+	}
+	creds, err := r.credentials(gss)
+	if err != nil {
+		return "", nil, &syncError{reason: fetchFailedReason, err: err}
+	}
+	commit, err := r.git.resolve(gss.Spec.URL, gss.Spec.Ref, creds)
+	if err != nil {
+		return "", nil, &syncError{reason: resolveFailedReason, err: err}
+	}
+	failed := controllerutils.FindGitSyncSourceCondition(gss.Status.Conditions, hivev1.GitSyncSourceSyncFailedCondition)
+	if commit == gss.Status.Commit && gss.Generation == gss.Status.ObservedGeneration &&
+		(failed == nil || failed.Status != corev1.ConditionTrue) {
+		drifted, err := r.drifted(gss)
+		if err != nil {
+			return "", nil, &syncError{reason: applyFailedReason, err: err}
+		}
+		if drifted == "" {
+			return commit, nil, nil
+		}
+		logger.WithField("selectorSyncSet", drifted).Info("selector sync set changed outside of the repository")
+	}
+
+	logger = logger.WithField("commit", commit)
+	logger.Info("rendering selector sync sets")
+	files, err := r.git.readFiles(gss.Spec.URL, gss.Spec.Ref, commit, gss.Spec.Path, creds)
+	if err != nil {
+		return "", nil, &syncError{reason: fetchFailedReason, err: err}
+	}
+	rendered, err := render(gss, commit, files)
+	if err != nil {
+		return "", nil, &syncError{reason: invalidReason, err: err}
+	}
+	if err := r.apply(gss, rendered, logger); err != nil {
+		return "", nil, &syncError{reason: applyFailedReason, err: err}
+	}
+	names := make([]string, len(rendered))
+	for i, sss := range rendered {
+		names[i] = sss.Name
+	}
+	sort.Strings(names)
+	return commit, names, nil
+}
+
+// drifted returns the name of the first SelectorSyncSet of the synced commit which was deleted or changed since it was
+// rendered, if any. Changes are detected through the hash of the rendered SelectorSyncSet, so the repository does not
+// need to be fetched again.
+func (r *ReconcileGitSyncSource) drifted(gss *hivev1.GitSyncSource) (string, error) {
+	for _, name := range gss.Status.SelectorSyncSets {
+		sss := &hivev1.SelectorSyncSet{}
+		switch err := r.Get(context.TODO(), types.NamespacedName{Name: name}, sss); {
+		case apierrors.IsNotFound(err):
+			return name, nil
+		case err != nil:
+			return "", errors.Wrapf(err, "could not read selectorsyncset %s", name)
+		}
+		hash, err := renderedHash(sss)
+		if err != nil {
+			return "", err
+		}
+		if !metav1.IsControlledBy(sss, gss) || sss.Annotations[hivev1.GitSyncSourceHashAnnotation] != hash {
+			return name, nil
+		}
+	}
+	return "", nil
+}
+
+// credentials reads the credentials of the repository from the secret referenced by the GitSyncSource, if any.
+func (r *ReconcileGitSyncSource) credentials(gss *hivev1.GitSyncSource) (*gitCredentials, error) {
+	if gss.Spec.CredentialsSecretRef == nil {
+		return nil, nil
+	}
+	secret := &corev1.Secret{}
+	key := types.NamespacedName{Namespace: controllerutils.GetHiveNamespace(), Name: gss.Spec.CredentialsSecretRef.Name}
+	if err := r.Get(context.TODO(), key, secret); err != nil {
+		return nil, errors.Wrapf(err, "could not read credentials secret %s", key)
+	}
+	creds := &gitCredentials{
+		username: string(secret.Data[credentialsUsernameKey]),
+		password: string(secret.Data[credentialsPasswordKey]),
+	}
+	if creds.password == "" {
+		return nil, fmt.Errorf("credentials secret %s has no %s key", key, credentialsPasswordKey)
+	}
+	return creds, nil
+}
+
+// apply creates or updates the rendered SelectorSyncSets and deletes the ones rendered from an earlier commit that
+// are no longer in the repository. Every write is first run as a dry run so that a SelectorSyncSet rejected by the
+// API server leaves the SelectorSyncSets of the earlier commit unchanged.
+func (r *ReconcileGitSyncSource) apply(gss *hivev1.GitSyncSource, rendered []*hivev1.SelectorSyncSet, logger log.FieldLogger) error {
+	var creates, updates []*hivev1.SelectorSyncSet
+	for _, sss := range rendered {
+		if err := controllerutil.SetControllerReference(gss, sss, r.scheme); err != nil {
+			return err
+		}
+		existing := &hivev1.SelectorSyncSet{}
+		switch err := r.Get(context.TODO(), types.NamespacedName{Name: sss.Name}, existing); {
+		case apierrors.IsNotFound(err):
+			if err := r.Create(context.TODO(), sss.DeepCopy(), client.DryRunAll); err != nil {
+				return errors.Wrapf(err, "selectorsyncset %s is invalid", sss.Name)
+			}
+			creates = append(creates, sss)
+		case err != nil:
+			return errors.Wrapf(err, "could not read selectorsyncset %s", sss.Name)
+		case !metav1.IsControlledBy(existing, gss):
+			return fmt.Errorf("selectorsyncset %s already exists and is not managed by this git sync source", sss.Name)
+		case reflect.DeepEqual(existing.Spec, sss.Spec) &&
+			reflect.DeepEqual(existing.Labels, sss.Labels) &&
+			reflect.DeepEqual(existing.Annotations, sss.Annotations):
+		default:
+			existing.Labels = sss.Labels
+			existing.Annotations = sss.Annotations
+			existing.Spec = sss.Spec
+			if err := r.Update(context.TODO(), existing.DeepCopy(), client.DryRunAll); err != nil {
+				return errors.Wrapf(err, "selectorsyncset %s is invalid", sss.Name)
+			}
+			updates = append(updates, existing)
+		}
+	}
+
+	for _, sss := range creates {
+		logger.WithField("selectorSyncSet", sss.Name).Info("creating selector sync set")
+		if err := r.Create(context.TODO(), sss); err != nil {
+			return errors.Wrapf(err, "could not create selectorsyncset %s", sss.Name)
+		}
+	}
+	for _, sss := range updates {
+		logger.WithField("selectorSyncSet", sss.Name).Info("updating selector sync set")
+		if err := r.Update(context.TODO(), sss); err != nil {
+			return errors.Wrapf(err, "could not update selectorsyncset %s", sss.Name)
+		}
+	}
+
+	names := sets.NewString()
+	for _, sss := range rendered {
+		names.Insert(sss.Name)
+	}
+	existing := &hivev1.SelectorSyncSetList{}
+	if err := r.List(context.TODO(), existing, client.MatchingLabels{hivev1.GitSyncSourceLabel: gss.Name}); err != nil {
+		return errors.Wrap(err, "could not list selectorsyncsets")
+	}
+	for i := range existing.Items {
+		sss := &existing.Items[i]
+		if names.Has(sss.Name) || !metav1.IsControlledBy(sss, gss) {
+			continue
+		}
+		logger.WithField("selectorSyncSet", sss.Name).Info("deleting selector sync set no longer in repository")
+		if err := r.Delete(context.TODO(), sss); err != nil && !apierrors.IsNotFound(err) {
+			return errors.Wrapf(err, "could not delete selectorsyncset %s", sss.Name)
+		}
+	}
+	return nil
+}
+
+// render decodes the SelectorSyncSets held in the files of the repository, adding the labels and annotations which
+// record where they were rendered from. Any invalid manifest fails the whole commit.
+func render(gss *hivev1.GitSyncSource, commit string, files map[string][]byte) ([]*hivev1.SelectorSyncSet, error) {
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	var rendered []*hivev1.SelectorSyncSet
+	names := sets.NewString()
+	for _, path := range paths {
+		manifests, err := ociartifact.SplitManifests(files[path])
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid manifests in %s", path)
+		}
+		for i, manifest := range manifests {
+			typeMeta := &metav1.TypeMeta{}
+			if err := yaml.Unmarshal(manifest, typeMeta); err != nil {
+				return nil, errors.Wrapf(err, "invalid manifest %d in %s", i, path)
+			}
+			if typeMeta.APIVersion != hivev1.SchemeGroupVersion.String() || typeMeta.Kind != "SelectorSyncSet" {
+				return nil, fmt.Errorf("manifest %d in %s is a %s %s, only %s SelectorSyncSets are supported",
+					i, path, typeMeta.APIVersion, typeMeta.Kind, hivev1.SchemeGroupVersion)
+			}
+			decoded := &hivev1.SelectorSyncSet{}
+			if err := yaml.UnmarshalStrict(manifest, decoded); err != nil {
+				return nil, errors.Wrapf(err, "invalid selectorsyncset in manifest %d in %s", i, path)
+			}
+			switch name := decoded.Name; {
+			case name == "":
+				return nil, fmt.Errorf("selectorsyncset in manifest %d in %s has no name", i, path)
+			case names.Has(name):
+				return nil, fmt.Errorf("selectorsyncset %s in %s is defined more than once", name, path)
+			}
+			names.Insert(decoded.Name)
+			sss := &hivev1.SelectorSyncSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        decoded.Name,
+					Labels:      decoded.Labels,
+					Annotations: decoded.Annotations,
+				},
+				Spec: decoded.Spec,
+			}
+			if sss.Labels == nil {
+				sss.Labels = map[string]string{}
+			}
+			sss.Labels[hivev1.GitSyncSourceLabel] = gss.Name
+			if sss.Annotations == nil {
+				sss.Annotations = map[string]string{}
+			}
+			sss.Annotations[hivev1.GitSyncSourceURLAnnotation] = gss.Spec.URL
+			sss.Annotations[hivev1.GitSyncSourceCommitAnnotation] = commit
+			sss.Annotations[hivev1.GitSyncSourcePathAnnotation] = path
+			hash, err := renderedHash(sss)
+			if err != nil {
+				return nil, errors.Wrapf(err, "could not hash selectorsyncset %s", sss.Name)
+			}
+			sss.Annotations[hivev1.GitSyncSourceHashAnnotation] = hash
+			rendered = append(rendered, sss)
+		}
+	}
+	return rendered, nil
+}
+
+// renderedHash returns a hash of the labels, annotations and spec of a SelectorSyncSet, leaving out the annotation
+// holding the hash itself.
+func renderedHash(sss *hivev1.SelectorSyncSet) (string, error) {
+	annotations := map[string]string{}
+	for k, v := range sss.Annotations {
+		if k != hivev1.GitSyncSourceHashAnnotation {
+			annotations[k] = v
+		}
+	}
+	b, err := json.Marshal(struct {
+		Labels      map[string]string
+		Annotations map[string]string
+		Spec        hivev1.SelectorSyncSetSpec
+	}{sss.Labels, annotations, sss.Spec})
+	if err != nil {
+		return "", err
+	}
+	sum := md5.Sum(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// validateSpec checks the parts of the spec the schema of the CRD cannot.
+func validateSpec(spec *hivev1.GitSyncSourceSpec) error {
+	set := 0
+	for _, ref := range []string{spec.Ref.Branch, spec.Ref.Tag, spec.Ref.Commit} {
+		if ref != "" {
+			set++
+		}
+	}
+	if set != 1 {
+		return errors.New("exactly one of branch, tag or commit must be specified")
+	}
+	if path := filepath.Clean(spec.Path); filepath.IsAbs(path) || path == ".." || strings.HasPrefix(path, "../") {
+		return fmt.Errorf("path %s must be relative to the root of the repository", spec.Path)
+	}
+	return nil
+}
@@ -0,0 +1,433 @@
+package gitsyncsource
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/pointer"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/openshift/hive/apis"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	testName    = "fleet-config"
+	testURL     = "https://git.example.com/fleet-config.git"
+	testCommit  = "1111111111111111111111111111111111111111"
+	otherCommit = "2222222222222222222222222222222222222222"
+
+	sssA = `apiVersion: hive.openshift.io/v1
+kind: SelectorSyncSet
+metadata:
+  name: sss-a
+  labels:
+    team: a
+spec:
+  clusterDeploymentSelector:
+    matchLabels:
+      env: prod
+  resourceApplyMode: Sync
+`
+	sssB = `apiVersion: hive.openshift.io/v1
+kind: SelectorSyncSet
+metadata:
+  name: sss-b
+spec:
+  clusterDeploymentSelector:
+    matchLabels:
+      env: dev
+`
+)
+
+type fakeGitClient struct {
+	commit     string
+	files      map[string][]byte
+	resolveErr error
+	readCalls  int
+	creds      *gitCredentials
+}
+
+func (c *fakeGitClient) resolve(url string, ref hivev1.GitReference, creds *gitCredentials) (string, error) {
+	c.creds = creds
+	return c.commit, c.resolveErr
+}
+
+func (c *fakeGitClient) readFiles(url string, ref hivev1.GitReference, commit, dir string, creds *gitCredentials) (map[string][]byte, error) {
+	c.readCalls++
+	return c.files, nil
+}
+
+func TestGitSyncSourceReconcile(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+	log.SetLevel(log.DebugLevel)
+
+	tests := []struct {
+		name              string
+		gss               *hivev1.GitSyncSource
+		git               *fakeGitClient
+		existing          []runtime.Object
+		expectedNames     []string
+		expectedCommit    string
+		expectedReason    string
+		expectedReadCalls int
+		expectedResult    reconcile.Result
+		expectedCreds     *gitCredentials
+		expectedDeleted   []string
+		validate          func(t *testing.T, c client.Client)
+	}{
+		{
+			name: "render selector sync sets",
+			gss:  testGitSyncSource(),
+			git: &fakeGitClient{commit: testCommit, files: map[string][]byte{
+				"fleet/a.yaml": []byte(sssA),
+				"fleet/b.yaml": []byte("# comment\n---\n" + sssB),
+			}},
+			expectedNames:     []string{"sss-a", "sss-b"},
+			expectedCommit:    testCommit,
+			expectedReadCalls: 1,
+			expectedResult:    reconcile.Result{RequeueAfter: defaultPollInterval},
+			validate: func(t *testing.T, c client.Client) {
+				sss := getSelectorSyncSet(t, c, "sss-a")
+				assert.Equal(t, map[string]string{"team": "a", hivev1.GitSyncSourceLabel: testName}, sss.Labels, "unexpected labels")
+				assert.NotEmpty(t, sss.Annotations[hivev1.GitSyncSourceHashAnnotation], "expected hash annotation")
+				delete(sss.Annotations, hivev1.GitSyncSourceHashAnnotation)
+				assert.Equal(t, map[string]string{
+					hivev1.GitSyncSourceURLAnnotation:    testURL,
+					hivev1.GitSyncSourceCommitAnnotation: testCommit,
+					hivev1.GitSyncSourcePathAnnotation:   "fleet/a.yaml",
+				}, sss.Annotations, "unexpected annotations")
+				assert.Equal(t, hivev1.SyncResourceApplyMode, sss.Spec.ResourceApplyMode, "unexpected spec")
+				if assert.Len(t, sss.OwnerReferences, 1, "expected owner reference") {
+					assert.Equal(t, testName, sss.OwnerReferences[0].Name, "unexpected owner")
+					assert.True(t, *sss.OwnerReferences[0].Controller, "expected controller owner reference")
+				}
+			},
+		},
+		{
+			name: "update and prune selector sync sets",
+			gss: func() *hivev1.GitSyncSource {
+				gss := testGitSyncSource()
+				gss.Status.Commit = otherCommit
+				gss.Status.ObservedGeneration = 1
+				gss.Status.Conditions = []hivev1.GitSyncSourceCondition{{
+					Type:   hivev1.GitSyncSourceSyncFailedCondition,
+					Status: corev1.ConditionTrue,
+					Reason: fetchFailedReason,
+				}}
+				return gss
+			}(),
+			git: &fakeGitClient{commit: testCommit, files: map[string][]byte{
+				"fleet/a.yaml": []byte(sssA),
+			}},
+			existing: []runtime.Object{
+				testRenderedSelectorSyncSet("sss-a", true),
+				testRenderedSelectorSyncSet("sss-old", true),
+				testRenderedSelectorSyncSet("sss-unowned", false),
+			},
+			expectedNames:     []string{"sss-a"},
+			expectedCommit:    testCommit,
+			expectedReason:    syncSucceededReason,
+			expectedReadCalls: 1,
+			expectedResult:    reconcile.Result{RequeueAfter: defaultPollInterval},
+			expectedDeleted:   []string{"sss-old"},
+			validate: func(t *testing.T, c client.Client) {
+				sss := getSelectorSyncSet(t, c, "sss-a")
+				assert.Equal(t, testCommit, sss.Annotations[hivev1.GitSyncSourceCommitAnnotation], "unexpected commit annotation")
+				assert.Equal(t, "prod", sss.Spec.ClusterDeploymentSelector.MatchLabels["env"], "spec not updated")
+				getSelectorSyncSet(t, c, "sss-unowned")
+			},
+		},
+		{
+			name: "commit already synced",
+			gss: func() *hivev1.GitSyncSource {
+				gss := testGitSyncSource()
+				gss.Status.Commit = testCommit
+				gss.Status.ObservedGeneration = 1
+				gss.Status.SelectorSyncSets = []string{"sss-a"}
+				return gss
+			}(),
+			git:            &fakeGitClient{commit: testCommit},
+			existing:       testSyncedSelectorSyncSets(map[string][]byte{"fleet/a.yaml": []byte(sssA)}),
+			expectedCommit: testCommit,
+			expectedResult: reconcile.Result{RequeueAfter: defaultPollInterval},
+		},
+		{
+			name: "changed selector sync set is reverted",
+			gss: func() *hivev1.GitSyncSource {
+				gss := testGitSyncSource()
+				gss.Status.Commit = testCommit
+				gss.Status.ObservedGeneration = 1
+				gss.Status.SelectorSyncSets = []string{"sss-a"}
+				return gss
+			}(),
+			git: &fakeGitClient{commit: testCommit, files: map[string][]byte{
+				"fleet/a.yaml": []byte(sssA),
+			}},
+			existing: func() []runtime.Object {
+				existing := testSyncedSelectorSyncSets(map[string][]byte{"fleet/a.yaml": []byte(sssA)})
+				existing[0].(*hivev1.SelectorSyncSet).Spec.ClusterDeploymentSelector.MatchLabels["env"] = "dev"
+				return existing
+			}(),
+			expectedNames:     []string{"sss-a"},
+			expectedCommit:    testCommit,
+			expectedReadCalls: 1,
+			expectedResult:    reconcile.Result{RequeueAfter: defaultPollInterval},
+			validate: func(t *testing.T, c client.Client) {
+				sss := getSelectorSyncSet(t, c, "sss-a")
+				assert.Equal(t, "prod", sss.Spec.ClusterDeploymentSelector.MatchLabels["env"], "spec not reverted")
+			},
+		},
+		{
+			name: "deleted selector sync set is recreated",
+			gss: func() *hivev1.GitSyncSource {
+				gss := testGitSyncSource()
+				gss.Status.Commit = testCommit
+				gss.Status.ObservedGeneration = 1
+				gss.Status.SelectorSyncSets = []string{"sss-a", "sss-b"}
+				return gss
+			}(),
+			git: &fakeGitClient{commit: testCommit, files: map[string][]byte{
+				"fleet/a.yaml": []byte(sssA),
+				"fleet/b.yaml": []byte(sssB),
+			}},
+			existing:          testSyncedSelectorSyncSets(map[string][]byte{"fleet/a.yaml": []byte(sssA)}),
+			expectedNames:     []string{"sss-a", "sss-b"},
+			expectedCommit:    testCommit,
+			expectedReadCalls: 1,
+			expectedResult:    reconcile.Result{RequeueAfter: defaultPollInterval},
+		},
+		{
+			name: "pinned commit is not polled",
+			gss: func() *hivev1.GitSyncSource {
+				gss := testGitSyncSource()
+				gss.Spec.Ref = hivev1.GitReference{Commit: testCommit}
+				return gss
+			}(),
+			git: &fakeGitClient{commit: testCommit, files: map[string][]byte{
+				"fleet/a.yaml": []byte(sssA),
+			}},
+			expectedNames:     []string{"sss-a"},
+			expectedCommit:    testCommit,
+			expectedReadCalls: 1,
+		},
+		{
+			name: "credentials from secret",
+			gss: func() *hivev1.GitSyncSource {
+				gss := testGitSyncSource()
+				gss.Spec.CredentialsSecretRef = &corev1.LocalObjectReference{Name: "git-creds"}
+				gss.Spec.PollInterval = &metav1.Duration{Duration: time.Minute}
+				return gss
+			}(),
+			git: &fakeGitClient{commit: testCommit, files: map[string][]byte{}},
+			existing: []runtime.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Namespace: constants.DefaultHiveNamespace, Name: "git-creds"},
+					Data:       map[string][]byte{"username": []byte("user"), "password": []byte("token")},
+				},
+			},
+			expectedCommit:    testCommit,
+			expectedReadCalls: 1,
+			expectedResult:    reconcile.Result{RequeueAfter: time.Minute},
+			expectedCreds:     &gitCredentials{username: "user", password: "token"},
+		},
+		{
+			name: "invalid manifest keeps previous commit",
+			gss: func() *hivev1.GitSyncSource {
+				gss := testGitSyncSource()
+				gss.Status.Commit = otherCommit
+				gss.Status.ObservedGeneration = 1
+				return gss
+			}(),
+			git: &fakeGitClient{commit: testCommit, files: map[string][]byte{
+				"fleet/a.yaml": []byte(sssA),
+				"fleet/b.yaml": []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: cm\n"),
+			}},
+			existing: []runtime.Object{
+				testRenderedSelectorSyncSet("sss-old", true),
+			},
+			expectedCommit:    otherCommit,
+			expectedReason:    invalidReason,
+			expectedReadCalls: 1,
+			expectedResult:    reconcile.Result{RequeueAfter: defaultPollInterval},
+			validate: func(t *testing.T, c client.Client) {
+				getSelectorSyncSet(t, c, "sss-old")
+				err := c.Get(context.TODO(), types.NamespacedName{Name: "sss-a"}, &hivev1.SelectorSyncSet{})
+				assert.True(t, apierrors.IsNotFound(err), "expected no selector sync set to be created")
+			},
+		},
+		{
+			name: "duplicate selector sync set",
+			gss:  testGitSyncSource(),
+			git: &fakeGitClient{commit: testCommit, files: map[string][]byte{
+				"fleet/a.yaml": []byte(sssA),
+				"fleet/b.yaml": []byte(sssA),
+			}},
+			expectedReason:    invalidReason,
+			expectedReadCalls: 1,
+			expectedResult:    reconcile.Result{RequeueAfter: defaultPollInterval},
+		},
+		{
+			name: "unowned selector sync set is not overwritten",
+			gss:  testGitSyncSource(),
+			git: &fakeGitClient{commit: testCommit, files: map[string][]byte{
+				"fleet/a.yaml": []byte(sssA),
+				"fleet/b.yaml": []byte(sssB),
+			}},
+			existing: []runtime.Object{
+				&hivev1.SelectorSyncSet{ObjectMeta: metav1.ObjectMeta{Name: "sss-b"}},
+			},
+			expectedReason:    applyFailedReason,
+			expectedReadCalls: 1,
+			expectedResult:    reconcile.Result{RequeueAfter: defaultPollInterval},
+			validate: func(t *testing.T, c client.Client) {
+				err := c.Get(context.TODO(), types.NamespacedName{Name: "sss-a"}, &hivev1.SelectorSyncSet{})
+				assert.True(t, apierrors.IsNotFound(err), "expected no selector sync set to be created")
+				assert.Empty(t, getSelectorSyncSet(t, c, "sss-b").Labels, "expected unowned selector sync set to be unchanged")
+			},
+		},
+		{
+			name:           "resolve failure",
+			gss:            testGitSyncSource(),
+			git:            &fakeGitClient{resolveErr: errors.New("repository not found")},
+			expectedReason: resolveFailedReason,
+			expectedResult: reconcile.Result{RequeueAfter: defaultPollInterval},
+		},
+		{
+			name: "branch and tag",
+			gss: func() *hivev1.GitSyncSource {
+				gss := testGitSyncSource()
+				gss.Spec.Ref.Tag = "v1"
+				return gss
+			}(),
+			git:            &fakeGitClient{commit: testCommit},
+			expectedReason: invalidReason,
+			expectedResult: reconcile.Result{RequeueAfter: defaultPollInterval},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := fake.NewFakeClientWithScheme(scheme.Scheme, append(test.existing, test.gss)...)
+			r := &ReconcileGitSyncSource{
+				Client: c,
+				scheme: scheme.Scheme,
+				git:    test.git,
+			}
+			result, err := r.Reconcile(reconcile.Request{NamespacedName: types.NamespacedName{Name: testName}})
+			require.NoError(t, err, "unexpected error from reconcile")
+			assert.Equal(t, test.expectedResult, result, "unexpected reconcile result")
+			assert.Equal(t, test.expectedReadCalls, test.git.readCalls, "unexpected number of reads")
+			assert.Equal(t, test.expectedCreds, test.git.creds, "unexpected credentials")
+
+			gss := &hivev1.GitSyncSource{}
+			require.NoError(t, c.Get(context.TODO(), types.NamespacedName{Name: testName}, gss))
+			assert.Equal(t, test.expectedCommit, gss.Status.Commit, "unexpected commit")
+			if test.expectedNames != nil {
+				assert.Equal(t, test.expectedNames, gss.Status.SelectorSyncSets, "unexpected selector sync sets in status")
+				for _, name := range test.expectedNames {
+					getSelectorSyncSet(t, c, name)
+				}
+			}
+			cond := controllerutils.FindGitSyncSourceCondition(gss.Status.Conditions, hivev1.GitSyncSourceSyncFailedCondition)
+			if test.expectedReason == "" {
+				assert.Nil(t, cond, "unexpected SyncFailed condition")
+			} else if assert.NotNil(t, cond, "expected SyncFailed condition") {
+				assert.Equal(t, test.expectedReason, cond.Reason, "unexpected condition reason")
+				expectedStatus := corev1.ConditionTrue
+				if test.expectedReason == syncSucceededReason {
+					expectedStatus = corev1.ConditionFalse
+				}
+				assert.Equal(t, expectedStatus, cond.Status, "unexpected condition status")
+			}
+			for _, name := range test.expectedDeleted {
+				err := c.Get(context.TODO(), types.NamespacedName{Name: name}, &hivev1.SelectorSyncSet{})
+				assert.True(t, apierrors.IsNotFound(err), "expected selector sync set %s to be deleted", name)
+			}
+			if test.validate != nil {
+				test.validate(t, c)
+			}
+		})
+	}
+}
+
+func getSelectorSyncSet(t *testing.T, c client.Client, name string) *hivev1.SelectorSyncSet {
+	sss := &hivev1.SelectorSyncSet{}
+	require.NoError(t, c.Get(context.TODO(), types.NamespacedName{Name: name}, sss), "could not get selector sync set %s", name)
+	return sss
+}
+
+func testGitSyncSource() *hivev1.GitSyncSource {
+	return &hivev1.GitSyncSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       testName,
+			UID:        types.UID(testName + "-uid"),
+			Generation: 1,
+		},
+		Spec: hivev1.GitSyncSourceSpec{
+			URL:  testURL,
+			Ref:  hivev1.GitReference{Branch: "main"},
+			Path: "fleet",
+		},
+	}
+}
+
+// testSyncedSelectorSyncSets returns the SelectorSyncSets rendered from the files at testCommit.
+func testSyncedSelectorSyncSets(files map[string][]byte) []runtime.Object {
+	gss := testGitSyncSource()
+	rendered, err := render(gss, testCommit, files)
+	if err != nil {
+		panic(err)
+	}
+	objs := make([]runtime.Object, len(rendered))
+	for i, sss := range rendered {
+		if err := controllerutil.SetControllerReference(gss, sss, scheme.Scheme); err != nil {
+			panic(err)
+		}
+		objs[i] = sss
+	}
+	return objs
+}
+
+func testRenderedSelectorSyncSet(name string, owned bool) *hivev1.SelectorSyncSet {
+	sss := &hivev1.SelectorSyncSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{hivev1.GitSyncSourceLabel: testName},
+			Annotations: map[string]string{
+				hivev1.GitSyncSourceURLAnnotation:    testURL,
+				hivev1.GitSyncSourceCommitAnnotation: otherCommit,
+				hivev1.GitSyncSourcePathAnnotation:   "fleet/" + name + ".yaml",
+			},
+		},
+	}
+	if owned {
+		sss.OwnerReferences = []metav1.OwnerReference{{
+			APIVersion:         hivev1.SchemeGroupVersion.String(),
+			Kind:               "GitSyncSource",
+			Name:               testName,
+			UID:                types.UID(testName + "-uid"),
+			Controller:         pointer.BoolPtr(true),
+			BlockOwnerDeletion: pointer.BoolPtr(true),
+		}}
+	}
+	return sss
+}
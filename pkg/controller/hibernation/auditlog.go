@@ -0,0 +1,263 @@
+package hibernation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubeclient "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/remoteclient"
+)
+
+const (
+	// auditLogPath is the path of the audit log of the kube-apiserver in the logs of the control plane nodes.
+	auditLogPath = "kube-apiserver/audit.log"
+
+	// auditLogChunkSize is the number of bytes of an audit log read at once.
+	auditLogChunkSize = 8 * 1024 * 1024
+
+	// maxAuditLogRead is the maximum number of bytes read from the audit log of each control plane node per check of
+	// the activity on a cluster. A cluster whose API servers logged more since the previous check is considered active.
+	maxAuditLogRead = 64 * 1024 * 1024
+
+	// masterNodeLabel is the label of the control plane nodes.
+	masterNodeLabel = "node-role.kubernetes.io/master"
+)
+
+// auditLogReader reads the audit logs of the API servers of a cluster.
+type auditLogReader interface {
+	// ControlPlaneNodes returns the names of the control plane nodes of the cluster, where the API servers run.
+	ControlPlaneNodes() ([]string, error)
+
+	// Read reads at most limit bytes of the audit log of the control plane node starting at offset, or the last limit
+	// bytes of the log when offset is negative. It returns the bytes read, the offset at which they start and the size
+	// of the log. No bytes are read when offset is beyond the size of the log.
+	Read(node string, offset, limit int64) (data []byte, start, size int64, err error)
+}
+
+// newRemoteAuditLogReader returns a reader of the audit logs of the cluster, which reads them through the node log
+// proxy of the API server with the admin kubeconfig of the cluster.
+func newRemoteAuditLogReader(builder remoteclient.Builder) (auditLogReader, error) {
+	config, err := builder.RESTConfig()
+	if err != nil {
+		return nil, err
+	}
+	kubeClient, err := kubeclient.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	transport, err := rest.TransportFor(config)
+	if err != nil {
+		return nil, err
+	}
+	return &remoteAuditLogReader{
+		kubeClient: kubeClient,
+		httpClient: &http.Client{Transport: transport, Timeout: time.Minute},
+		host:       strings.TrimSuffix(config.Host, "/"),
+	}, nil
+}
+
+type remoteAuditLogReader struct {
+	kubeClient kubeclient.Interface
+	httpClient *http.Client
+	host       string
+}
+
+func (r *remoteAuditLogReader) ControlPlaneNodes() ([]string, error) {
+	nodes, err := r.kubeClient.CoreV1().Nodes().List(context.TODO(), metav1.ListOptions{LabelSelector: masterNodeLabel})
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, len(nodes.Items))
+	for i, node := range nodes.Items {
+		names[i] = node.Name
+	}
+	return names, nil
+}
+
+func (r *remoteAuditLogReader) Read(node string, offset, limit int64) ([]byte, int64, int64, error) {
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/api/v1/nodes/%s/proxy/logs/%s", r.host, node, auditLogPath), nil)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	if offset < 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=-%d", limit))
+	} else {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-%d", offset, offset+limit-1))
+	}
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusRequestedRangeNotSatisfiable:
+		// The Content-Range of the response is "bytes */<size>".
+		_, _, size, err := parseContentRange(resp.Header.Get("Content-Range"))
+		return nil, 0, size, err
+	case http.StatusPartialContent:
+	default:
+		return nil, 0, 0, fmt.Errorf("unexpected status reading audit log of node %s: %s", node, resp.Status)
+	}
+	start, _, size, err := parseContentRange(resp.Header.Get("Content-Range"))
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, 0, 0, errors.Wrapf(err, "could not read audit log of node %s", node)
+	}
+	return data, start, size, nil
+}
+
+// parseContentRange parses the Content-Range header of a response to a range request, "bytes <start>-<end>/<size>" or
+// "bytes */<size>".
+func parseContentRange(header string) (start, end, size int64, err error) {
+	invalid := fmt.Errorf("invalid Content-Range %q", header)
+	parts := strings.SplitN(strings.TrimPrefix(header, "bytes "), "/", 2)
+	if len(parts) != 2 {
+		return 0, 0, 0, invalid
+	}
+	if size, err = strconv.ParseInt(parts[1], 10, 64); err != nil {
+		return 0, 0, 0, invalid
+	}
+	if parts[0] == "*" {
+		return 0, 0, size, nil
+	}
+	bounds := strings.SplitN(parts[0], "-", 2)
+	if len(bounds) != 2 {
+		return 0, 0, 0, invalid
+	}
+	if start, err = strconv.ParseInt(bounds[0], 10, 64); err != nil {
+		return 0, 0, 0, invalid
+	}
+	if end, err = strconv.ParseInt(bounds[1], 10, 64); err != nil {
+		return 0, 0, 0, invalid
+	}
+	return start, end, size, nil
+}
+
+// auditEvent holds the fields of the audit events of the API server used to check the activity on a cluster.
+type auditEvent struct {
+	User struct {
+		Username string `json:"username"`
+	} `json:"user"`
+	RequestReceivedTimestamp metav1.MicroTime `json:"requestReceivedTimestamp"`
+}
+
+// isUserRequest returns whether the request of the audit event was made by a user, rather than by the platform. The
+// requests of the platform, including those of service accounts, nodes and Hive itself with the admin kubeconfig, are
+// made by system users.
+func (e *auditEvent) isUserRequest() bool {
+	return e.User.Username != "" && !strings.HasPrefix(e.User.Username, "system:")
+}
+
+// lastUserRequest returns the time of the latest request made by a user in the complete lines of the audit log data,
+// or the zero time when there is none, and the number of bytes of the complete lines.
+func lastUserRequest(data []byte) (time.Time, int64) {
+	var last time.Time
+	complete := bytes.LastIndexByte(data, '\n') + 1
+	for _, line := range bytes.Split(data[:complete], []byte{'\n'}) {
+		if len(line) == 0 {
+			continue
+		}
+		event := &auditEvent{}
+		if err := json.Unmarshal(line, event); err != nil {
+			continue
+		}
+		if event.isUserRequest() && event.RequestReceivedTimestamp.Time.After(last) {
+			last = event.RequestReceivedTimestamp.Time
+		}
+	}
+	return last, int64(complete)
+}
+
+// lastUserActivity returns the time of the latest request made by a user to the API servers of the cluster since the
+// audit log positions, or the zero time when there is none, and the new audit log positions. The logs of the control
+// plane nodes which have no position yet, or which have been rotated, are read from their last auditLogChunkSize bytes.
+// When more than maxAuditLogRead bytes have been logged on a node since its position, the rest of its log is skipped
+// and the current time is returned, since the cluster is busy. An error is returned when the activity cannot be
+// determined.
+func lastUserActivity(reader auditLogReader, positions []hivev1.AuditLogPosition) (time.Time, []hivev1.AuditLogPosition, error) {
+	offsets := map[string]int64{}
+	for _, position := range positions {
+		offsets[position.Node] = position.Offset
+	}
+	nodes, err := reader.ControlPlaneNodes()
+	if err != nil {
+		return time.Time{}, nil, errors.Wrap(err, "could not list control plane nodes")
+	}
+	if len(nodes) == 0 {
+		return time.Time{}, nil, errors.New("no control plane nodes found")
+	}
+
+	var last time.Time
+	newPositions := make([]hivev1.AuditLogPosition, 0, len(nodes))
+	for _, node := range nodes {
+		nodeLast, offset, err := lastNodeUserRequest(reader, node, offsets)
+		if err != nil {
+			return time.Time{}, nil, errors.Wrapf(err, "could not read audit log of node %s", node)
+		}
+		if nodeLast.After(last) {
+			last = nodeLast
+		}
+		newPositions = append(newPositions, hivev1.AuditLogPosition{Node: node, Offset: offset})
+	}
+	return last, newPositions, nil
+}
+
+// lastNodeUserRequest returns the time of the latest request made by a user in the audit log of the control plane
+// node since its offset, and the offset up to which the log has been read.
+func lastNodeUserRequest(reader auditLogReader, node string, offsets map[string]int64) (time.Time, int64, error) {
+	offset, ok := offsets[node]
+	if !ok {
+		offset = -1
+	}
+	data, start, size, err := reader.Read(node, offset, auditLogChunkSize)
+	if err == nil && offset > size {
+		// The audit log has been rotated since it was last read.
+		offset = -1
+		data, start, size, err = reader.Read(node, offset, auditLogChunkSize)
+	}
+	var last time.Time
+	for total := int64(0); ; {
+		if err != nil {
+			return time.Time{}, 0, err
+		}
+		if len(data) == 0 {
+			if offset < 0 {
+				offset = size
+			}
+			return last, offset, nil
+		}
+		chunkLast, read := lastUserRequest(data)
+		if chunkLast.After(last) {
+			last = chunkLast
+		}
+		if read == 0 && len(data) >= auditLogChunkSize {
+			// A line longer than a chunk is skipped.
+			read = int64(len(data))
+		}
+		offset = start + read
+		total += int64(len(data))
+		switch {
+		case offset >= size || read == 0:
+			return last, offset, nil
+		case total >= maxAuditLogRead:
+			return time.Now(), size, nil
+		}
+		data, start, size, err = reader.Read(node, offset, auditLogChunkSize)
+	}
+}
@@ -0,0 +1,116 @@
+package hibernation
+
+import (
+	"crypto/x509"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	certsv1beta1 "k8s.io/api/certificates/v1beta1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+const (
+	// csrApprovedReason is the reason of the event recorded on a ClusterDeployment for each CSR approved.
+	csrApprovedReason = "CSRApproved"
+	// csrDeniedReason is the reason of the event recorded on a ClusterDeployment for each CSR denied by the CSR
+	// approval policy.
+	csrDeniedReason = "CSRApprovalDenied"
+	// csrApprovalLimitReachedReason is the reason of the event recorded on a ClusterDeployment for each CSR left
+	// pending because the maximum number of approvals for the resume has been reached.
+	csrApprovalLimitReachedReason = "CSRApprovalLimitReached"
+)
+
+// csrApprovalPolicy restricts the CSRs approved while clusters resume. The nil policy approves all CSRs.
+type csrApprovalPolicy struct {
+	allowedNodeNames      []*regexp.Regexp
+	maxApprovalsPerResume *int32
+	denyByDefault         bool
+}
+
+// readCSRApprovalPolicyFile reads the CSR approval policy from the file set in the env, returning nil if the env is not
+// set or the file does not exist.
+func readCSRApprovalPolicyFile() (*hivev1.CSRApprovalPolicyConfig, error) {
+	fPath := os.Getenv(constants.CSRApprovalPolicyConfigFileEnvVar)
+	if len(fPath) == 0 {
+		return nil, nil
+	}
+	fileBytes, err := ioutil.ReadFile(fPath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read the csr approval policy file")
+	}
+	config := &hivev1.CSRApprovalPolicyConfig{}
+	if err := json.Unmarshal(fileBytes, config); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal the csr approval policy file")
+	}
+	return config, nil
+}
+
+// newCSRApprovalPolicy returns the CSR approval policy for the config, which may be nil.
+func newCSRApprovalPolicy(config *hivev1.CSRApprovalPolicyConfig) (*csrApprovalPolicy, error) {
+	if config == nil {
+		return nil, nil
+	}
+	policy := &csrApprovalPolicy{
+		maxApprovalsPerResume: config.MaxApprovalsPerResume,
+		denyByDefault:         config.DenyByDefault,
+	}
+	for _, pattern := range config.AllowedNodeNamePatterns {
+		re, err := regexp.Compile("^(?:" + pattern + ")$")
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid allowed node name pattern %q", pattern)
+		}
+		policy.allowedNodeNames = append(policy.allowedNodeNames, re)
+	}
+	return policy, nil
+}
+
+// allowsNode returns whether CSRs for the node may be approved.
+func (p *csrApprovalPolicy) allowsNode(nodeName string) bool {
+	if p == nil {
+		return true
+	}
+	if len(p.allowedNodeNames) == 0 {
+		return !p.denyByDefault
+	}
+	for _, re := range p.allowedNodeNames {
+		if re.MatchString(nodeName) {
+			return true
+		}
+	}
+	return false
+}
+
+// allowsApproval returns whether another CSR may be approved after the given number of approvals for the resume.
+func (p *csrApprovalPolicy) allowsApproval(approved int) bool {
+	return p == nil || p.maxApprovalsPerResume == nil || approved < int(*p.maxApprovalsPerResume)
+}
+
+// csrNodeName returns the name of the node which the parsed CSR is for.
+func csrNodeName(parsedCSR *x509.CertificateRequest) string {
+	if parsedCSR == nil {
+		return ""
+	}
+	return strings.TrimPrefix(parsedCSR.Subject.CommonName, nodeUserPrefix)
+}
+
+// approvedByHiveSince returns whether the CSR was approved by Hive at or after the given time.
+func approvedByHiveSince(csr *certsv1beta1.CertificateSigningRequest, since time.Time) bool {
+	for _, condition := range csr.Status.Conditions {
+		if condition.Type == certsv1beta1.CertificateApproved && condition.Message == csrApprovedMessage &&
+			!condition.LastUpdateTime.Time.Before(since) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,196 @@
+package hibernation
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	certsv1beta1 "k8s.io/api/certificates/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakekubeclient "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	machineapi "github.com/openshift/machine-api-operator/pkg/apis/machine/v1beta1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/controller/hibernation/mock"
+	"github.com/openshift/hive/pkg/remoteclient"
+	remoteclientmock "github.com/openshift/hive/pkg/remoteclient/mock"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+)
+
+func TestCSRApprovalPolicy(t *testing.T) {
+	tests := []struct {
+		name             string
+		config           *hivev1.CSRApprovalPolicyConfig
+		existingCSRs     []runtime.Object
+		expectInvalid    bool
+		expectedApproved []string
+		expectedEvents   map[string]int
+	}{
+		{
+			name:             "no policy",
+			expectedApproved: []string{"csr-0", "csr-1", "csr-2", "csr-3", "csr-4"},
+			expectedEvents:   map[string]int{csrApprovedReason: 5},
+		},
+		{
+			name: "allowed node name patterns",
+			config: &hivev1.CSRApprovalPolicyConfig{
+				AllowedNodeNamePatterns: []string{"node-[01]", "other-.*"},
+			},
+			expectedApproved: []string{"csr-0", "csr-1"},
+			expectedEvents:   map[string]int{csrApprovedReason: 2, csrDeniedReason: 3},
+		},
+		{
+			name: "patterns match whole node names",
+			config: &hivev1.CSRApprovalPolicyConfig{
+				AllowedNodeNamePatterns: []string{"node"},
+			},
+			expectedEvents: map[string]int{csrDeniedReason: 5},
+		},
+		{
+			name: "deny by default without patterns",
+			config: &hivev1.CSRApprovalPolicyConfig{
+				DenyByDefault: true,
+			},
+			expectedEvents: map[string]int{csrDeniedReason: 5},
+		},
+		{
+			name: "deny by default with patterns",
+			config: &hivev1.CSRApprovalPolicyConfig{
+				AllowedNodeNamePatterns: []string{"node-4"},
+				DenyByDefault:           true,
+			},
+			expectedApproved: []string{"csr-4"},
+			expectedEvents:   map[string]int{csrApprovedReason: 1, csrDeniedReason: 4},
+		},
+		{
+			name: "max approvals per resume",
+			config: &hivev1.CSRApprovalPolicyConfig{
+				MaxApprovalsPerResume: pointer.Int32Ptr(2),
+			},
+			expectedApproved: []string{"csr-0", "csr-1"},
+			expectedEvents:   map[string]int{csrApprovedReason: 2, csrApprovalLimitReachedReason: 3},
+		},
+		{
+			name: "max approvals per resume counts earlier approvals",
+			config: &hivev1.CSRApprovalPolicyConfig{
+				MaxApprovalsPerResume: pointer.Int32Ptr(2),
+			},
+			existingCSRs: []runtime.Object{
+				approvedCSR("csr-approved-now", csrApprovedMessage, time.Now()),
+				approvedCSR("csr-approved-before-resume", csrApprovedMessage, time.Now().Add(-2*time.Hour)),
+				approvedCSR("csr-approved-by-other", "Approved by someone else", time.Now()),
+			},
+			expectedApproved: []string{"csr-0"},
+			expectedEvents:   map[string]int{csrApprovedReason: 1, csrApprovalLimitReachedReason: 4},
+		},
+		{
+			name: "invalid pattern",
+			config: &hivev1.CSRApprovalPolicyConfig{
+				AllowedNodeNamePatterns: []string{"node-("},
+			},
+			expectInvalid: true,
+		},
+	}
+
+	scheme := runtime.NewScheme()
+	corev1.AddToScheme(scheme)
+	hivev1.AddToScheme(scheme)
+	machineapi.AddToScheme(scheme)
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			policy, err := newCSRApprovalPolicy(test.config)
+			if test.expectInvalid {
+				assert.Error(t, err, "expected error for invalid policy")
+				return
+			}
+			require.NoError(t, err, "unexpected error creating policy")
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			csrObjs := append(csrs(), test.existingCSRs...)
+			mockBuilder := remoteclientmock.NewMockBuilder(ctrl)
+			mockBuilder.EXPECT().BuildKubeClient().Return(fakekubeclient.NewSimpleClientset(csrObjs...), nil)
+
+			var approved []string
+			mockCSRHelper := mock.NewMockcsrHelper(ctrl)
+			mockCSRHelper.EXPECT().IsApproved(gomock.Any()).AnyTimes().DoAndReturn(
+				func(csr *certsv1beta1.CertificateSigningRequest) bool {
+					return (&csrUtility{}).IsApproved(csr)
+				})
+			mockCSRHelper.EXPECT().Parse(gomock.Any()).AnyTimes().DoAndReturn(
+				func(csr *certsv1beta1.CertificateSigningRequest) (*x509.CertificateRequest, error) {
+					nodeName := strings.Replace(csr.Name, "csr", "node", 1)
+					return &x509.CertificateRequest{Subject: pkix.Name{CommonName: nodeUserPrefix + nodeName}}, nil
+				})
+			mockCSRHelper.EXPECT().Authorize(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().Return(nil)
+			mockCSRHelper.EXPECT().Approve(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(
+				func(_ interface{}, csr *certsv1beta1.CertificateSigningRequest) error {
+					approved = append(approved, csr.Name)
+					return nil
+				})
+
+			recorder := record.NewFakeRecorder(20)
+			reconciler := hibernationReconciler{
+				logger: log.WithField("controller", "hibernation"),
+				remoteClientBuilder: func(cd *hivev1.ClusterDeployment) remoteclient.Builder {
+					return mockBuilder
+				},
+				csrUtil:           mockCSRHelper,
+				csrApprovalPolicy: policy,
+				eventRecorder:     recorder,
+			}
+			cond := hibernatingCondition(corev1.ConditionTrue, hivev1.ResumingHibernationReason, time.Hour)
+			cond.LastProbeTime = cond.LastTransitionTime
+			cd := testcd.FullBuilder(namespace, cdName, scheme).Build(testcd.WithCondition(cond))
+
+			result, err := reconciler.checkCSRs(cd, fake.NewFakeClientWithScheme(scheme), reconciler.logger)
+			require.NoError(t, err, "unexpected error checking CSRs")
+			assert.Equal(t, csrCheckInterval, result.RequeueAfter, "unexpected requeue")
+			assert.ElementsMatch(t, test.expectedApproved, approved, "unexpected approved CSRs")
+
+			eventReasons := map[string]int{}
+			close(recorder.Events)
+			for event := range recorder.Events {
+				eventReasons[strings.Fields(event)[1]]++
+			}
+			if test.expectedEvents == nil {
+				test.expectedEvents = map[string]int{}
+			}
+			assert.Equal(t, test.expectedEvents, eventReasons, "unexpected events")
+		})
+	}
+}
+
+func approvedCSR(name, message string, approvedAt time.Time) *certsv1beta1.CertificateSigningRequest {
+	csr := &certsv1beta1.CertificateSigningRequest{}
+	csr.Name = name
+	csr.Status.Conditions = []certsv1beta1.CertificateSigningRequestCondition{{
+		Type:           certsv1beta1.CertificateApproved,
+		Message:        message,
+		LastUpdateTime: metav1.NewTime(approvedAt),
+	}}
+	return csr
+}
+
+func TestCSRNodeName(t *testing.T) {
+	assert.Equal(t, "", csrNodeName(nil), "unexpected node name for missing CSR")
+	assert.Equal(t, "ip-10-0-1-2.ec2.internal", csrNodeName(&x509.CertificateRequest{
+		Subject: pkix.Name{CommonName: fmt.Sprintf("%sip-10-0-1-2.ec2.internal", nodeUserPrefix)},
+	}), "unexpected node name")
+}
@@ -27,6 +27,9 @@ const (
 	nodeUserPrefix = nodeUser + ":"
 
 	nodeBootstrapperUsername = "system:serviceaccount:openshift-machine-config-operator:node-bootstrapper"
+
+	// csrApprovedMessage is the message of the Approved condition of the CSRs approved by Hive.
+	csrApprovedMessage = "This CSR was automatically approved by Hive"
 )
 
 var workerNodeBootstrapperGroups = sets.NewString(
@@ -82,7 +85,7 @@ func (u *csrUtility) Approve(client kubeclient.Interface, csr *certificatesv1bet
 	csr.Status.Conditions = append(csr.Status.Conditions, certificatesv1beta1.CertificateSigningRequestCondition{
 		Type:           certificatesv1beta1.CertificateApproved,
 		Reason:         "KubectlApprove",
-		Message:        "This CSR was automatically approved by Hive",
+		Message:        csrApprovedMessage,
 		LastUpdateTime: metav1.Now(),
 	})
 	_, err := client.CertificatesV1beta1().CertificateSigningRequests().UpdateApproval(context.TODO(), csr, metav1.UpdateOptions{})
@@ -60,7 +60,7 @@ func (a *gcpActuator) StopMachines(cd *hivev1.ClusterDeployment, hiveClient clie
 	var errs []error
 	for _, instance := range instances {
 		logger.WithField("instance", instance.Name).Info("Stopping instance")
-		err = gcpClient.StopInstance(instance)
+		err = gcpClient.StopInstance(context.TODO(), instance)
 		if err != nil {
 			errs = append(errs, err)
 		}
@@ -86,7 +86,7 @@ func (a *gcpActuator) StartMachines(cd *hivev1.ClusterDeployment, hiveClient cli
 	var errs []error
 	for _, instance := range instances {
 		logger.WithField("instance", instance.Name).Info("Starting instance")
-		err = gcpClient.StartInstance(instance)
+		err = gcpClient.StartInstance(context.TODO(), instance)
 		if err != nil {
 			errs = append(errs, err)
 		}
@@ -144,7 +144,7 @@ func instanceFilter(cd *hivev1.ClusterDeployment) string {
 func gcpListComputeInstances(gcpClient gcpclient.Client, cd *hivev1.ClusterDeployment, statuses sets.String, logger log.FieldLogger) ([]*compute.Instance, error) {
 	var instances []*compute.Instance
 	logger.Debug("listing client instances")
-	err := gcpClient.ListComputeInstances(gcpclient.ListComputeInstancesOptions{
+	err := gcpClient.ListComputeInstances(context.TODO(), gcpclient.ListComputeInstancesOptions{
 		Filter: instanceFilter(cd),
 		Fields: instanceFields,
 	}, func(list *compute.InstanceAggregatedList) error {
@@ -1,6 +1,7 @@
 package hibernation
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
@@ -47,8 +48,8 @@ func TestGCPStopAndStartMachines(t *testing.T) {
 			testFunc:  "StopMachines",
 			instances: map[string]int{"TERMINATED": 5, "RUNNING": 2},
 			setupClient: func(t *testing.T, c *mockgcpclient.MockClient) {
-				c.EXPECT().StopInstance(gomock.Any()).Times(2).Do(
-					func(instance *compute.Instance) {
+				c.EXPECT().StopInstance(gomock.Any(), gomock.Any()).Times(2).Do(
+					func(ctx context.Context, instance *compute.Instance) {
 						assert.True(t, instance.Status == "RUNNING")
 					},
 				)
@@ -59,8 +60,8 @@ func TestGCPStopAndStartMachines(t *testing.T) {
 			testFunc:  "StopMachines",
 			instances: map[string]int{"TERMINATED": 5, "STOPPING": 3, "STOPPED": 4, "STAGING": 7, "RUNNING": 3},
 			setupClient: func(t *testing.T, c *mockgcpclient.MockClient) {
-				c.EXPECT().StopInstance(gomock.Any()).Times(10).Do(
-					func(instance *compute.Instance) {
+				c.EXPECT().StopInstance(gomock.Any(), gomock.Any()).Times(10).Do(
+					func(ctx context.Context, instance *compute.Instance) {
 						assert.True(t, instance.Status == "STAGING" || instance.Status == "RUNNING")
 					},
 				)
@@ -76,8 +77,8 @@ func TestGCPStopAndStartMachines(t *testing.T) {
 			testFunc:  "StartMachines",
 			instances: map[string]int{"STOPPED": 3, "TERMINATED": 2, "RUNNING": 4},
 			setupClient: func(t *testing.T, c *mockgcpclient.MockClient) {
-				c.EXPECT().StartInstance(gomock.Any()).Times(5).Do(
-					func(instance *compute.Instance) {
+				c.EXPECT().StartInstance(gomock.Any(), gomock.Any()).Times(5).Do(
+					func(ctx context.Context, instance *compute.Instance) {
 						assert.True(t, instance.Status == "STOPPED" || instance.Status == "TERMINATED")
 					},
 				)
@@ -88,8 +89,8 @@ func TestGCPStopAndStartMachines(t *testing.T) {
 			testFunc:  "StartMachines",
 			instances: map[string]int{"STOPPED": 3, "STOPPING": 1, "TERMINATED": 7},
 			setupClient: func(t *testing.T, c *mockgcpclient.MockClient) {
-				c.EXPECT().StartInstance(gomock.Any()).Times(11).Do(
-					func(instance *compute.Instance) {
+				c.EXPECT().StartInstance(gomock.Any(), gomock.Any()).Times(11).Do(
+					func(ctx context.Context, instance *compute.Instance) {
 						assert.True(t, instance.Status == "STOPPED" || instance.Status == "STOPPING" || instance.Status == "TERMINATED")
 					},
 				)
@@ -211,8 +212,8 @@ func setupGCPClientInstances(gcpClient *mockgcpclient.MockClient, statuses map[s
 			})
 		}
 	}
-	gcpClient.EXPECT().ListComputeInstances(gomock.Any(), gomock.Any()).Times(1).Do(
-		func(opts gcpclient.ListComputeInstancesOptions, f func(*compute.InstanceAggregatedList) error) {
+	gcpClient.EXPECT().ListComputeInstances(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Do(
+		func(ctx context.Context, opts gcpclient.ListComputeInstancesOptions, f func(*compute.InstanceAggregatedList) error) {
 			aggregatedList := &compute.InstanceAggregatedList{
 				Items: map[string]compute.InstancesScopedList{
 					"result": {
@@ -0,0 +1,95 @@
+package hibernation
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+// hibernatedTimeInterval is the time interval at which the time of a hibernating cluster is added to its hibernated
+// time, so that the hibernated time is reported while clusters stay hibernating.
+const hibernatedTimeInterval = time.Hour
+
+var (
+	metricClusterHibernatedSeconds = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "hive_cluster_hibernated_seconds_total",
+		Help: "Cumulative time during which the machines of clusters have been stopped by hibernation.",
+	}, []string{"platform", "region"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(metricClusterHibernatedSeconds)
+}
+
+// isHibernated returns whether the Hibernating condition reports that the machines of the cluster are stopped.
+func isHibernated(cond *hivev1.ClusterDeploymentCondition) bool {
+	return cond != nil && cond.Status == corev1.ConditionTrue && cond.Reason == hivev1.HibernatingHibernationReason
+}
+
+// accountHibernatedTime adds the time of the current hibernation of the cluster which has not been accounted yet to its
+// hibernated time, and returns the time added. When the cluster is still hibernated, the rest of the hibernation is
+// accounted from now on.
+func accountHibernatedTime(cd *hivev1.ClusterDeployment, hibernatedSince time.Time, stillHibernated bool, now time.Time) time.Duration {
+	hs := cd.Status.Hibernation
+	if hs == nil {
+		hs = &hivev1.ClusterHibernationStatus{}
+		cd.Status.Hibernation = hs
+	}
+	if hs.LastAccountedTime != nil {
+		hibernatedSince = hs.LastAccountedTime.Time
+	}
+	var added time.Duration
+	if !hibernatedSince.IsZero() && now.After(hibernatedSince) {
+		added = now.Sub(hibernatedSince)
+		hs.HibernatedTime.Duration += added
+	}
+	if stillHibernated {
+		t := metav1.NewTime(now)
+		hs.LastAccountedTime = &t
+	} else {
+		hs.LastAccountedTime = nil
+	}
+	return added
+}
+
+// observeHibernatedTime reports the time added to the hibernated time of the cluster in the metrics.
+func observeHibernatedTime(cd *hivev1.ClusterDeployment, added time.Duration) {
+	if added <= 0 {
+		return
+	}
+	metricClusterHibernatedSeconds.WithLabelValues(
+		cd.Labels[hivev1.HiveClusterPlatformLabel],
+		cd.Labels[hivev1.HiveClusterRegionLabel],
+	).Add(added.Seconds())
+}
+
+// updateHibernatedTime adds the time of a hibernating cluster to its hibernated time once hibernatedTimeInterval has
+// passed since it was last accounted, and requeues the cluster for the next time. The hibernation of a cluster which is
+// not accounted yet, such as one which was hibernated before the hibernated time was reported, is accounted from the
+// time its machines were stopped.
+func (r *hibernationReconciler) updateHibernatedTime(cd *hivev1.ClusterDeployment, hibernatingCondition *hivev1.ClusterDeploymentCondition, logger log.FieldLogger) (reconcile.Result, error) {
+	if hs := cd.Status.Hibernation; hs != nil && hs.LastAccountedTime != nil {
+		if untilNext := hibernatedTimeInterval - time.Since(hs.LastAccountedTime.Time); untilNext > 0 {
+			return reconcile.Result{RequeueAfter: untilNext}, nil
+		}
+	}
+	added := accountHibernatedTime(cd, hibernatingCondition.LastProbeTime.Time, true, time.Now())
+	if err := r.Status().Update(context.TODO(), cd); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "Failed to update hibernated time")
+		return reconcile.Result{}, errors.Wrap(err, "failed to update hibernated time")
+	}
+	observeHibernatedTime(cd, added)
+	logger.WithField("hibernatedTime", cd.Status.Hibernation.HibernatedTime.Duration).Debug("updated hibernated time")
+	return reconcile.Result{RequeueAfter: hibernatedTimeInterval}, nil
+}
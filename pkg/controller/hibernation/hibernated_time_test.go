@@ -0,0 +1,205 @@
+package hibernation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hiveintv1alpha1 "github.com/openshift/hive/apis/hiveinternal/v1alpha1"
+	"github.com/openshift/hive/pkg/controller/hibernation/mock"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+	testcs "github.com/openshift/hive/pkg/test/clustersync"
+)
+
+func TestHibernatedTime(t *testing.T) {
+	scheme := runtime.NewScheme()
+	corev1.AddToScheme(scheme)
+	hivev1.AddToScheme(scheme)
+	hiveintv1alpha1.AddToScheme(scheme)
+
+	cdBuilder := testcd.FullBuilder(namespace, cdName, scheme).Options(
+		testcd.Installed(),
+		testcd.WithClusterVersion("4.4.9"),
+		testcd.InstalledTimestamp(time.Now().Add(-24*time.Hour)),
+		testcd.WithLabel(hivev1.HiveClusterPlatformLabel, "aws"),
+		testcd.WithLabel(hivev1.HiveClusterRegionLabel, "us-east-1"),
+	)
+	csBuilder := testcs.FullBuilder(namespace, cdName, scheme).Options(
+		testcs.WithFirstSuccessTime(time.Now().Add(-24 * time.Hour)),
+	)
+
+	tests := []struct {
+		name          string
+		cd            *hivev1.ClusterDeployment
+		setupActuator func(actuator *mock.MockHibernationActuator)
+
+		expectRequeueAfter       time.Duration
+		expectedHibernatedTime   time.Duration
+		expectLastAccountedTime  bool
+		expectedMetricIncrement  time.Duration
+		expectedConditionReason  string
+		expectNoHibernatedStatus bool
+	}{
+		{
+			name: "machines stopped",
+			cd: cdBuilder.Build(
+				testcd.WithPowerState(hivev1.HibernatingClusterPowerState),
+				testcd.WithCondition(hibernatingCondition(corev1.ConditionTrue, hivev1.StoppingHibernationReason, time.Hour)),
+			),
+			setupActuator: func(actuator *mock.MockHibernationActuator) {
+				actuator.EXPECT().MachinesStopped(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(true, nil)
+			},
+			expectLastAccountedTime: true,
+			expectedConditionReason: hivev1.HibernatingHibernationReason,
+		},
+		{
+			name: "hibernated time accounted recently",
+			cd: cdBuilder.Build(
+				testcd.WithPowerState(hivev1.HibernatingClusterPowerState),
+				testcd.WithCondition(hibernatedCondition(5*time.Hour)),
+				withHibernatedTime(time.Hour, 10*time.Minute),
+			),
+			expectRequeueAfter:      50 * time.Minute,
+			expectedHibernatedTime:  time.Hour,
+			expectLastAccountedTime: true,
+			expectedConditionReason: hivev1.HibernatingHibernationReason,
+		},
+		{
+			name: "hibernated time due",
+			cd: cdBuilder.Build(
+				testcd.WithPowerState(hivev1.HibernatingClusterPowerState),
+				testcd.WithCondition(hibernatedCondition(5*time.Hour)),
+				withHibernatedTime(time.Hour, 2*time.Hour),
+			),
+			expectRequeueAfter:      hibernatedTimeInterval,
+			expectedHibernatedTime:  3 * time.Hour,
+			expectLastAccountedTime: true,
+			expectedMetricIncrement: 2 * time.Hour,
+			expectedConditionReason: hivev1.HibernatingHibernationReason,
+		},
+		{
+			name: "hibernated time not accounted yet",
+			cd: cdBuilder.Build(
+				testcd.WithPowerState(hivev1.HibernatingClusterPowerState),
+				testcd.WithCondition(hibernatedCondition(5*time.Hour)),
+			),
+			expectRequeueAfter:      hibernatedTimeInterval,
+			expectedHibernatedTime:  5 * time.Hour,
+			expectLastAccountedTime: true,
+			expectedMetricIncrement: 5 * time.Hour,
+			expectedConditionReason: hivev1.HibernatingHibernationReason,
+		},
+		{
+			name: "resuming",
+			cd: cdBuilder.Build(
+				testcd.WithPowerState(hivev1.RunningClusterPowerState),
+				testcd.WithCondition(hibernatedCondition(5*time.Hour)),
+				withHibernatedTime(time.Hour, 30*time.Minute),
+			),
+			setupActuator: func(actuator *mock.MockHibernationActuator) {
+				actuator.EXPECT().StartMachines(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
+			},
+			expectedHibernatedTime:  90 * time.Minute,
+			expectedMetricIncrement: 30 * time.Minute,
+			expectedConditionReason: hivev1.ResumingHibernationReason,
+		},
+		{
+			name: "never hibernated",
+			cd: cdBuilder.Build(
+				testcd.WithPowerState(hivev1.HibernatingClusterPowerState),
+			),
+			setupActuator: func(actuator *mock.MockHibernationActuator) {
+				actuator.EXPECT().StopMachines(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
+			},
+			expectNoHibernatedStatus: true,
+			expectedConditionReason:  hivev1.StoppingHibernationReason,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockActuator := mock.NewMockHibernationActuator(ctrl)
+			mockActuator.EXPECT().CanHandle(gomock.Any()).AnyTimes().Return(true)
+			if test.setupActuator != nil {
+				test.setupActuator(mockActuator)
+			}
+			actuators = []HibernationActuator{mockActuator}
+			c := fake.NewFakeClientWithScheme(scheme, test.cd, csBuilder.Build())
+			metric := metricClusterHibernatedSeconds.WithLabelValues("aws", "us-east-1")
+			metricBefore := testutil.ToFloat64(metric)
+
+			reconciler := hibernationReconciler{
+				Client: c,
+				logger: log.WithField("controller", "hibernation"),
+			}
+			result, err := reconciler.Reconcile(reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: namespace, Name: cdName},
+			})
+			require.NoError(t, err, "unexpected error from reconcile")
+
+			if test.expectRequeueAfter == 0 {
+				assert.Zero(t, result.RequeueAfter, "unexpected requeue after")
+			} else {
+				assert.InDelta(t, test.expectRequeueAfter.Seconds(), result.RequeueAfter.Seconds(), 10, "unexpected requeue after")
+			}
+
+			cd := &hivev1.ClusterDeployment{}
+			err = c.Get(context.TODO(), client.ObjectKey{Namespace: namespace, Name: cdName}, cd)
+			require.NoError(t, err, "error looking up ClusterDeployment")
+			cond := getHibernatingCondition(cd)
+			if assert.NotNil(t, cond, "missing hibernating condition") {
+				assert.Equal(t, test.expectedConditionReason, cond.Reason, "unexpected hibernating condition reason")
+			}
+			assert.InDelta(t, test.expectedMetricIncrement.Seconds(), testutil.ToFloat64(metric)-metricBefore, 10, "unexpected metric increment")
+			if test.expectNoHibernatedStatus {
+				assert.Nil(t, cd.Status.Hibernation, "unexpected hibernation status")
+				return
+			}
+			if !assert.NotNil(t, cd.Status.Hibernation, "missing hibernation status") {
+				return
+			}
+			assert.InDelta(t, test.expectedHibernatedTime.Seconds(), cd.Status.Hibernation.HibernatedTime.Seconds(), 10, "unexpected hibernated time")
+			if test.expectLastAccountedTime {
+				if assert.NotNil(t, cd.Status.Hibernation.LastAccountedTime, "missing last accounted time") &&
+					test.expectedMetricIncrement > 0 {
+					assert.WithinDuration(t, time.Now(), cd.Status.Hibernation.LastAccountedTime.Time, time.Minute, "unexpected last accounted time")
+				}
+			} else {
+				assert.Nil(t, cd.Status.Hibernation.LastAccountedTime, "unexpected last accounted time")
+			}
+		})
+	}
+}
+
+func hibernatedCondition(stoppedAgo time.Duration) hivev1.ClusterDeploymentCondition {
+	cond := hibernatingCondition(corev1.ConditionTrue, hivev1.HibernatingHibernationReason, stoppedAgo)
+	cond.LastProbeTime = cond.LastTransitionTime
+	return cond
+}
+
+func withHibernatedTime(hibernatedTime, lastAccountedAgo time.Duration) testcd.Option {
+	return func(cd *hivev1.ClusterDeployment) {
+		lastAccounted := metav1.NewTime(time.Now().Add(-lastAccountedAgo))
+		cd.Status.Hibernation = &hivev1.ClusterHibernationStatus{
+			HibernatedTime:    metav1.Duration{Duration: hibernatedTime},
+			LastAccountedTime: &lastAccounted,
+		}
+	}
+}
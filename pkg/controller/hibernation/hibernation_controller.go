@@ -13,6 +13,7 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/flowcontrol"
 	"k8s.io/client-go/util/workqueue"
 
@@ -30,6 +31,7 @@ import (
 	"github.com/openshift/hive/pkg/constants"
 	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/providerplugin"
 	"github.com/openshift/hive/pkg/remoteclient"
 )
 
@@ -51,6 +53,14 @@ const (
 	// soon after the cluster is ready
 	nodeCheckWaitTime = 4 * time.Minute
 
+	// csrApprovalAfterResumePeriod is how long after a cluster is running again the CSRs of its nodes which are not
+	// ready yet keep being approved, when the cluster was considered running before all of its nodes were ready.
+	csrApprovalAfterResumePeriod = time.Hour
+
+	// restrictedHibernationMessage is the message of the Hibernating condition of clusters which cannot hibernate
+	// because of the Restricted remote access RBAC mode.
+	restrictedHibernationMessage = "Hibernation is disabled by the Restricted remote access RBAC mode"
+
 	// hibernateAfterSyncSetsNotApplied is the amount of time to wait
 	// before hibernating when SyncSets have not been applied
 	hibernateAfterSyncSetsNotApplied = 10 * time.Minute
@@ -90,20 +100,49 @@ type hibernationReconciler struct {
 	logger  log.FieldLogger
 	csrUtil csrHelper
 
+	// csrApprovalPolicy restricts the CSRs approved while clusters resume.
+	csrApprovalPolicy *csrApprovalPolicy
+	eventRecorder     record.EventRecorder
+
 	remoteClientBuilder func(cd *hivev1.ClusterDeployment) remoteclient.Builder
+
+	// auditLogReaderBuilder returns a reader of the audit logs of the API servers of the cluster, from which the user
+	// activity on the cluster is checked for HibernateAfterIdle.
+	auditLogReaderBuilder func(cd *hivev1.ClusterDeployment) (auditLogReader, error)
+
+	// providerPlugins are the provider plugins which hibernate the clusters naming them, in place of the actuators.
+	providerPlugins providerplugin.Plugins
 }
 
 // NewReconciler returns a new Reconciler
 func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) *hibernationReconciler {
 	logger := log.WithField("controller", ControllerName)
 	r := &hibernationReconciler{
-		Client:  controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
-		logger:  logger,
-		csrUtil: &csrUtility{},
+		Client:        controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
+		logger:        logger,
+		csrUtil:       &csrUtility{},
+		eventRecorder: mgr.GetEventRecorderFor(ControllerName.String()),
+	}
+	plugins, err := providerplugin.Load()
+	if err != nil {
+		logger.WithError(err).Error("could not load provider plugins")
+	}
+	r.providerPlugins = plugins
+	policyConfig, err := readCSRApprovalPolicyFile()
+	if err == nil {
+		r.csrApprovalPolicy, err = newCSRApprovalPolicy(policyConfig)
+	}
+	if err != nil {
+		// Deny all CSRs rather than approving CSRs which the policy may not allow.
+		logger.WithError(err).Error("could not load csr approval policy, no CSRs will be approved")
+		r.csrApprovalPolicy = &csrApprovalPolicy{denyByDefault: true}
 	}
 	r.remoteClientBuilder = func(cd *hivev1.ClusterDeployment) remoteclient.Builder {
 		return remoteclient.NewBuilder(r.Client, cd, ControllerName)
 	}
+	r.auditLogReaderBuilder = func(cd *hivev1.ClusterDeployment) (auditLogReader, error) {
+		return newRemoteAuditLogReader(remoteclient.NewAdminBuilder(r.Client, cd, ControllerName))
+	}
 	return r
 }
 
@@ -163,9 +202,22 @@ func (r *hibernationReconciler) Reconcile(request reconcile.Request) (result rec
 	shouldHibernate := cd.Spec.PowerState == hivev1.HibernatingClusterPowerState
 	hibernatingCondition := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.ClusterHibernatingCondition)
 
+	// Hive holds none of the permissions needed to hibernate and resume clusters in the Restricted remote access RBAC
+	// mode, so the machines of the cluster are left as they are.
+	if remoteclient.FeatureDisabled(remoteclient.HibernationFeature) {
+		if hibernatingCondition != nil && hibernatingCondition.Status == corev1.ConditionTrue {
+			cdLog.Warn("cannot resume hibernating cluster in the restricted remote access RBAC mode")
+			return reconcile.Result{}, nil
+		}
+		if shouldHibernate || cd.Spec.HibernateAfter != nil || cd.Spec.HibernateAfterIdle != nil || cd.Spec.PowerStateSchedule != nil {
+			return r.setHibernatingCondition(cd, hivev1.UnsupportedHibernationReason, restrictedHibernationMessage, corev1.ConditionFalse, cdLog)
+		}
+		return reconcile.Result{}, nil
+	}
+
 	// Signal a problem if we should be hibernating or have requested hibernate after and the cluster does not support it or
 	// SyncSets have not yet been applied.
-	if shouldHibernate || cd.Spec.HibernateAfter != nil {
+	if shouldHibernate || cd.Spec.HibernateAfter != nil || cd.Spec.HibernateAfterIdle != nil || cd.Spec.PowerStateSchedule != nil {
 		if supported, msg := r.hibernationSupported(cd); !supported {
 			return r.setHibernatingCondition(cd, hivev1.UnsupportedHibernationReason, msg, corev1.ConditionFalse, cdLog)
 		}
@@ -193,8 +245,52 @@ func (r *hibernationReconciler) Reconcile(request reconcile.Request) (result rec
 		}
 	}
 
+	// Apply the power state schedule when one of its times has passed since it was last applied. A power state set in
+	// between, by hand or by HibernateAfter, is kept until the next scheduled time. The power state of the unclaimed
+	// clusters of a ClusterPool is managed by the pool.
+	if cd.Spec.PowerStateSchedule != nil && !isUnclaimedPoolCluster(cd) {
+		state, last, next, err := scheduledPowerState(cd.Spec.PowerStateSchedule, time.Now())
+		schedLog := cdLog.WithFields(log.Fields{
+			"scheduledPowerState": state,
+			"scheduledAt":         last,
+		})
+		switch {
+		case err != nil:
+			cdLog.WithError(err).Error("cannot apply invalid power state schedule")
+		case !last.IsZero() && powerStateScheduleApplied(cd).Before(last):
+			if cd.Annotations == nil {
+				cd.Annotations = map[string]string{}
+			}
+			cd.Annotations[constants.PowerStateScheduleAppliedAnnotation] = last.UTC().Format(time.RFC3339)
+			if powerState(cd) != state {
+				schedLog.Info("setting scheduled power state")
+				cd.Spec.PowerState = state
+			}
+			err := r.Update(context.TODO(), cd)
+			if err != nil {
+				schedLog.WithError(err).Log(controllerutils.LogLevel(err), "error applying power state schedule")
+			}
+			return reconcile.Result{}, err
+		case !next.IsZero():
+			defer func() {
+				requeueNow := result.Requeue && result.RequeueAfter <= 0
+				if returnErr == nil && !requeueNow {
+					// Requeue the cluster for the next time of the schedule
+					requeueAfter := time.Until(next)
+					if requeueAfter < result.RequeueAfter || result.RequeueAfter <= 0 {
+						cdLog.Infof("cluster will reconcile due to power state schedule in: %v", requeueAfter)
+						result.RequeueAfter = requeueAfter
+						result.Requeue = true
+					}
+				}
+			}()
+		}
+	}
+
 	// Check if HibernateAfter is set, and if the cluster has been in running state for longer than this duration, put it to sleep.
-	if cd.Spec.HibernateAfter != nil && cd.Spec.PowerState != hivev1.HibernatingClusterPowerState {
+	// The power state of the unclaimed clusters of a ClusterPool is managed by the pool, which keeps its running count of
+	// them running.
+	if cd.Spec.HibernateAfter != nil && cd.Spec.PowerState != hivev1.HibernatingClusterPowerState && !isUnclaimedPoolCluster(cd) {
 		hibernateAfterDur := cd.Spec.HibernateAfter.Duration
 		runningSince := cd.Status.InstalledTimestamp.Time
 		hibLog := cdLog.WithFields(log.Fields{
@@ -244,8 +340,50 @@ func (r *hibernationReconciler) Reconcile(request reconcile.Request) (result rec
 
 	}
 
+	// Check if HibernateAfterIdle is set, and if no user activity has been observed on the running cluster for
+	// longer than this duration, put it to sleep. Like for HibernateAfter, the power state of the unclaimed clusters of
+	// a ClusterPool is managed by the pool.
+	if cd.Spec.HibernateAfterIdle != nil && cd.Spec.PowerState != hivev1.HibernatingClusterPowerState && !isUnclaimedPoolCluster(cd) &&
+		(hibernatingCondition == nil || hibernatingCondition.Status == corev1.ConditionFalse) {
+		runningSince := cd.CreationTimestamp.Time
+		if hibernatingCondition != nil {
+			runningSince = hibernatingCondition.LastTransitionTime.Time
+		} else if cd.Status.InstalledTimestamp != nil {
+			runningSince = cd.Status.InstalledTimestamp.Time
+		}
+		idleLog := cdLog.WithField("hibernateAfterIdle", cd.Spec.HibernateAfterIdle.Duration)
+		idle, requeueAfter, err := r.checkIdle(cd, runningSince, idleLog)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		if idle {
+			idleLog.Info("cluster has been idle longer than hibernate-after-idle duration, moving to hibernating powerState")
+			cd.Spec.PowerState = hivev1.HibernatingClusterPowerState
+			err := r.Update(context.TODO(), cd)
+			if err != nil {
+				idleLog.WithError(err).Log(controllerutils.LogLevel(err), "error hibernating idle cluster")
+			}
+			return reconcile.Result{}, err
+		}
+		defer func() {
+			requeueNow := result.Requeue && result.RequeueAfter <= 0
+			if returnErr == nil && !requeueNow {
+				// Requeue the cluster to check its activity again
+				if requeueAfter < result.RequeueAfter || result.RequeueAfter <= 0 {
+					idleLog.Infof("cluster will reconcile due to hibernate-after-idle check in: %v", requeueAfter)
+					result.RequeueAfter = requeueAfter
+					result.Requeue = true
+				}
+			}
+		}()
+	}
+
 	if !shouldHibernate {
 		if hibernatingCondition == nil || hibernatingCondition.Status == corev1.ConditionFalse {
+			if hibernatingCondition != nil && hibernatingCondition.Reason == hivev1.RunningHibernationReason &&
+				time.Since(hibernatingCondition.LastTransitionTime.Time) < csrApprovalAfterResumePeriod {
+				return r.checkCSRsOfNotReadyNodes(cd, cdLog)
+			}
 			return reconcile.Result{}, nil
 		}
 		switch hibernatingCondition.Reason {
@@ -265,6 +403,9 @@ func (r *hibernationReconciler) Reconcile(request reconcile.Request) (result rec
 	if hibernatingCondition.Reason == hivev1.StoppingHibernationReason {
 		return r.checkClusterStopped(cd, false, cdLog)
 	}
+	if isHibernated(hibernatingCondition) {
+		return r.updateHibernatedTime(cd, hibernatingCondition, cdLog)
+	}
 	return reconcile.Result{}, nil
 }
 
@@ -343,34 +484,71 @@ func (r *hibernationReconciler) checkClusterResumed(cd *hivev1.ClusterDeployment
 		logger.WithError(err).Log(controllerutils.LogLevel(err), "Failed to connect to target cluster")
 		return reconcile.Result{}, err
 	}
-	ready, err := r.nodesReady(cd, remoteClient, logger)
+	ready, allReady, msg, err := r.nodesReady(cd, remoteClient, logger)
 	if err != nil {
 		logger.WithError(err).Log(controllerutils.LogLevel(err), "Failed to check whether nodes are ready")
 		return reconcile.Result{}, err
 	}
 	if !ready {
+		if msg != "" {
+			if err := r.setResumeReadinessTimedOutCondition(cd, nodesResumeCheck(cd), hivev1.NodesNotReadyResumeReason, msg, logger); err != nil {
+				return reconcile.Result{}, err
+			}
+		}
 		logger.Info("Nodes are not ready, checking for CSRs to approve")
 		return r.checkCSRs(cd, remoteClient, logger)
 	}
+	if !allReady {
+		// The nodes which are not ready yet may be waiting for their CSRs to be approved, which keeps being done once
+		// the cluster is running until they are all ready.
+		logger.Info("Some nodes are not ready, checking for CSRs to approve")
+		if _, err := r.checkCSRs(cd, remoteClient, logger); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+	check, reason, msg, err := r.checkResumeReadiness(cd, remoteClient, logger)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if reason != "" {
+		return r.resumeCheckFailed(cd, check, reason, msg, logger)
+	}
+	if err := r.clearResumeReadinessTimedOutCondition(cd, logger); err != nil {
+		return reconcile.Result{}, err
+	}
 	logger.Info("Cluster has started and is in Running state")
 	return r.setHibernatingCondition(cd, hivev1.RunningHibernationReason, "All machines are started and nodes are ready", corev1.ConditionFalse, logger)
 }
 
 func (r *hibernationReconciler) setHibernatingCondition(cd *hivev1.ClusterDeployment, reason, message string, status corev1.ConditionStatus, logger log.FieldLogger) (result reconcile.Result, returnErr error) {
 	changed := false
-	if status == corev1.ConditionFalse && controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.ClusterHibernatingCondition) == nil {
+	// Account the hibernated time of the cluster when its machines get stopped or stop being stopped.
+	var hibernatedTimeAdded time.Duration
+	previousCondition := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.ClusterHibernatingCondition)
+	wasHibernated := isHibernated(previousCondition)
+	if hibernated := status == corev1.ConditionTrue && reason == hivev1.HibernatingHibernationReason; hibernated != wasHibernated {
+		var hibernatedSince time.Time
+		if wasHibernated {
+			hibernatedSince = previousCondition.LastProbeTime.Time
+		}
+		hibernatedTimeAdded = accountHibernatedTime(cd, hibernatedSince, hibernated, time.Now())
+		changed = true
+	}
+	if status == corev1.ConditionFalse && previousCondition == nil {
 		now := metav1.Now()
 		cd.Status.Conditions = append(cd.Status.Conditions, hivev1.ClusterDeploymentCondition{
 			Type:               hivev1.ClusterHibernatingCondition,
 			Status:             corev1.ConditionFalse,
 			Reason:             reason,
 			Message:            message,
+			Category:           controllerutils.FailureCategory(reason),
 			LastProbeTime:      now,
 			LastTransitionTime: now,
 		})
 		changed = true
 	} else {
-		cd.Status.Conditions, changed = controllerutils.SetClusterDeploymentConditionWithChangeCheck(
+		var conditionChanged bool
+		cd.Status.Conditions, conditionChanged = controllerutils.SetClusterDeploymentConditionWithChangeCheck(
 			cd.Status.Conditions,
 			hivev1.ClusterHibernatingCondition,
 			status,
@@ -378,6 +556,7 @@ func (r *hibernationReconciler) setHibernatingCondition(cd *hivev1.ClusterDeploy
 			message,
 			controllerutils.UpdateConditionIfReasonOrMessageChange,
 		)
+		changed = changed || conditionChanged
 	}
 
 	if reason == hivev1.SyncSetsNotAppliedReason {
@@ -396,12 +575,26 @@ func (r *hibernationReconciler) setHibernatingCondition(cd *hivev1.ClusterDeploy
 			logger.WithError(err).Log(controllerutils.LogLevel(err), "Failed to update hibernating condition")
 			return reconcile.Result{}, errors.Wrap(err, "failed to update hibernating condition")
 		}
+		observeHibernatedTime(cd, hibernatedTimeAdded)
 		logger.WithField("reason", reason).Info("Hibernating condition updated on cluster deployment.")
 	}
 	return reconcile.Result{}, nil
 }
 
 func (r *hibernationReconciler) getActuator(cd *hivev1.ClusterDeployment) HibernationActuator {
+	return GetActuator(r.providerPlugins, cd)
+}
+
+// GetActuator returns the actuator which powers the machines of the given ClusterDeployment on and off, or nil if
+// hibernation is not supported on its platform. The provider plugin naming the ClusterDeployment, if any, takes
+// precedence over the registered actuators.
+func GetActuator(plugins providerplugin.Plugins, cd *hivev1.ClusterDeployment) HibernationActuator {
+	if plugin, ok := plugins.For(cd); ok {
+		if plugin == nil {
+			return nil
+		}
+		return &pluginActuator{plugin: plugin}
+	}
 	for _, a := range actuators {
 		if a.CanHandle(cd) {
 			return a
@@ -428,34 +621,75 @@ func (r *hibernationReconciler) hibernationSupported(cd *hivev1.ClusterDeploymen
 	return true, "Hibernation capable"
 }
 
-func (r *hibernationReconciler) nodesReady(cd *hivev1.ClusterDeployment, remoteClient client.Client, logger log.FieldLogger) (bool, error) {
+// nodesReady returns whether enough of the nodes of the cluster are ready, and whether all of them are, with a message
+// describing the nodes when not enough of them are.
+func (r *hibernationReconciler) nodesReady(cd *hivev1.ClusterDeployment, remoteClient client.Client, logger log.FieldLogger) (bool, bool, string, error) {
 
 	hibernatingCondition := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.ClusterHibernatingCondition)
 	if hibernatingCondition == nil {
-		return false, errors.New("cannot find hibernating condition")
+		return false, false, "", errors.New("cannot find hibernating condition")
 	}
 	if time.Since(hibernatingCondition.LastProbeTime.Time) < nodeCheckWaitTime {
-		return false, nil
+		return false, false, "", nil
 	}
 	nodeList := &corev1.NodeList{}
 	err := remoteClient.List(context.TODO(), nodeList)
 	if err != nil {
 		logger.WithError(err).Log(controllerutils.LogLevel(err), "Failed to fetch cluster nodes")
 		err = errors.Wrap(err, "failed to fetch cluster nodes")
-		return false, err
+		return false, false, "", err
 	}
 	if len(nodeList.Items) == 0 {
 		logger.Info("Cluster is not reporting any nodes, waiting")
-		return false, nil
+		return false, false, "Cluster is not reporting any nodes", nil
+	}
+	readyCount := 0
+	for i := range nodeList.Items {
+		if isNodeReady(&nodeList.Items[i]) {
+			readyCount++
+		} else {
+			logger.WithField("node", nodeList.Items[i].Name).Info("Node is not yet ready")
+		}
+	}
+	readyPercent := nodesReadyPercent(cd)
+	if readyCount*100 < int(readyPercent)*len(nodeList.Items) {
+		logger.WithField("ready", readyCount).WithField("count", len(nodeList.Items)).Info("Not enough nodes are ready, waiting")
+		return false, false, fmt.Sprintf("%d of %d nodes are ready, need %d%%", readyCount, len(nodeList.Items), readyPercent), nil
+	}
+	logger.WithField("ready", readyCount).WithField("count", len(nodeList.Items)).Info("Enough cluster nodes are ready")
+	return true, readyCount == len(nodeList.Items), "", nil
+}
+
+// checkCSRsOfNotReadyNodes approves the pending CSRs of a running cluster which has nodes that are not ready yet. A
+// cluster is considered running once enough of its nodes are ready, as configured by its resume readiness checks, and
+// the CSRs of its other nodes keep being approved until they are all ready. Errors are logged rather than returned,
+// since the cluster is already running.
+func (r *hibernationReconciler) checkCSRsOfNotReadyNodes(cd *hivev1.ClusterDeployment, logger log.FieldLogger) (reconcile.Result, error) {
+	if unreachable, _ := remoteclient.Unreachable(cd); unreachable {
+		return reconcile.Result{}, nil
+	}
+	remoteClient, err := r.remoteClientBuilder(cd).Build()
+	if err != nil {
+		logger.WithError(err).Warn("Failed to connect to target cluster to check nodes")
+		return reconcile.Result{}, nil
+	}
+	nodeList := &corev1.NodeList{}
+	if err := remoteClient.List(context.TODO(), nodeList); err != nil {
+		logger.WithError(err).Warn("Failed to fetch cluster nodes")
+		return reconcile.Result{}, nil
 	}
 	for i := range nodeList.Items {
 		if !isNodeReady(&nodeList.Items[i]) {
-			logger.WithField("node", nodeList.Items[i].Name).Info("Node is not yet ready, waiting")
-			return false, nil
+			logger.WithField("node", nodeList.Items[i].Name).Info("Node of running cluster is not yet ready, checking for CSRs to approve")
+			result, err := r.checkCSRs(cd, remoteClient, logger)
+			if err != nil {
+				logger.WithError(err).Warn("Failed to check CSRs")
+				return reconcile.Result{RequeueAfter: csrCheckInterval}, nil
+			}
+			return result, nil
 		}
 	}
-	logger.WithField("count", len(nodeList.Items)).Info("All cluster nodes are ready")
-	return true, nil
+	return reconcile.Result{}, nil
 }
 
 func (r *hibernationReconciler) checkCSRs(cd *hivev1.ClusterDeployment, remoteClient client.Client, logger log.FieldLogger) (reconcile.Result, error) {
@@ -475,6 +709,17 @@ func (r *hibernationReconciler) checkCSRs(cd *hivev1.ClusterDeployment, remoteCl
 		logger.WithError(err).Log(controllerutils.LogLevel(err), "Failed to list CSRs")
 		return reconcile.Result{}, errors.Wrap(err, "failed to list CSRs")
 	}
+	// The CSRs approved since the cluster started resuming count towards the maximum number of approvals.
+	var resumingSince time.Time
+	if cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.ClusterHibernatingCondition); cond != nil {
+		resumingSince = cond.LastProbeTime.Time
+	}
+	approved := 0
+	for i := range csrList.Items {
+		if approvedByHiveSince(&csrList.Items[i], resumingSince) {
+			approved++
+		}
+	}
 	for i := range csrList.Items {
 		csr := &csrList.Items[i]
 		csrLogger := logger.WithField("csr", csr.Name)
@@ -495,16 +740,39 @@ func (r *hibernationReconciler) checkCSRs(cd *hivev1.ClusterDeployment, remoteCl
 			csrLogger.WithError(err).Log(controllerutils.LogLevel(err), "CSR authorization failed")
 			continue
 		}
+		nodeName := csrNodeName(parsedCSR)
+		csrLogger = csrLogger.WithField("node", nodeName).WithField("username", csr.Spec.Username)
+		if !r.csrApprovalPolicy.allowsNode(nodeName) {
+			csrLogger.Warn("CSR denied by the CSR approval policy")
+			r.recordEvent(cd, corev1.EventTypeWarning, csrDeniedReason,
+				fmt.Sprintf("CSR %s from %s for node %s is not allowed by the CSR approval policy", csr.Name, csr.Spec.Username, nodeName))
+			continue
+		}
+		if !r.csrApprovalPolicy.allowsApproval(approved) {
+			csrLogger.WithField("approved", approved).Warn("CSR left pending, maximum number of approvals for the resume reached")
+			r.recordEvent(cd, corev1.EventTypeWarning, csrApprovalLimitReachedReason,
+				fmt.Sprintf("CSR %s from %s for node %s left pending, %d CSRs have already been approved for this resume", csr.Name, csr.Spec.Username, nodeName, approved))
+			continue
+		}
 		if err = r.csrUtil.Approve(kubeClient, &csrList.Items[i]); err != nil {
 			csrLogger.WithError(err).Log(controllerutils.LogLevel(err), "Failed to approve CSR")
 			continue
 		}
+		approved++
 		csrLogger.Info("CSR approved")
+		r.recordEvent(cd, corev1.EventTypeNormal, csrApprovedReason,
+			fmt.Sprintf("Approved CSR %s from %s for node %s", csr.Name, csr.Spec.Username, nodeName))
 	}
 	// Requeue quickly after so we can recheck whether more CSRs need to be approved
 	return reconcile.Result{RequeueAfter: csrCheckInterval}, nil
 }
 
+func (r *hibernationReconciler) recordEvent(cd *hivev1.ClusterDeployment, eventType, reason, message string) {
+	if r.eventRecorder != nil {
+		r.eventRecorder.Event(cd, eventType, reason, message)
+	}
+}
+
 func isNodeReady(node *corev1.Node) bool {
 	for _, c := range node.Status.Conditions {
 		if c.Type == corev1.NodeReady {
@@ -513,3 +781,8 @@ func isNodeReady(node *corev1.Node) bool {
 	}
 	return false
 }
+
+// isUnclaimedPoolCluster returns whether the cluster belongs to a ClusterPool and has not been claimed.
+func isUnclaimedPoolCluster(cd *hivev1.ClusterDeployment) bool {
+	return cd.Spec.ClusterPoolRef != nil && cd.Spec.ClusterPoolRef.ClaimName == ""
+}
@@ -3,6 +3,7 @@ package hibernation
 import (
 	"context"
 	"fmt"
+	"os"
 	"testing"
 	"time"
 
@@ -18,15 +19,19 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	fakekubeclient "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	configv1 "github.com/openshift/api/config/v1"
 	machineapi "github.com/openshift/machine-api-operator/pkg/apis/machine/v1beta1"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	hiveintv1alpha1 "github.com/openshift/hive/apis/hiveinternal/v1alpha1"
+	"github.com/openshift/hive/pkg/constants"
 	"github.com/openshift/hive/pkg/controller/hibernation/mock"
+	"github.com/openshift/hive/pkg/providerplugin"
 	"github.com/openshift/hive/pkg/remoteclient"
 	remoteclientmock "github.com/openshift/hive/pkg/remoteclient/mock"
 	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
@@ -49,6 +54,7 @@ func TestReconcile(t *testing.T) {
 	hivev1.AddToScheme(scheme)
 	hiveintv1alpha1.AddToScheme(scheme)
 	machineapi.AddToScheme(scheme)
+	configv1.AddToScheme(scheme)
 
 	cdBuilder := testcd.FullBuilder(namespace, cdName, scheme).Options(
 		testcd.Installed(),
@@ -68,6 +74,7 @@ func TestReconcile(t *testing.T) {
 		setupRemote    func(builder *remoteclientmock.MockBuilder)
 		validate       func(t *testing.T, cd *hivev1.ClusterDeployment)
 		expectError    bool
+		restricted     bool
 	}{
 		{
 			name: "cluster deleted",
@@ -98,6 +105,31 @@ func TestReconcile(t *testing.T) {
 				assert.Equal(t, hivev1.UnsupportedHibernationReason, cond.Reason)
 			},
 		},
+		{
+			name:       "start hibernating, restricted remote access",
+			cd:         cdBuilder.Options(o.shouldHibernate).Build(),
+			cs:         csBuilder.Build(),
+			restricted: true,
+			validate: func(t *testing.T, cd *hivev1.ClusterDeployment) {
+				cond := getHibernatingCondition(cd)
+				require.NotNil(t, cond)
+				assert.Equal(t, corev1.ConditionFalse, cond.Status)
+				assert.Equal(t, hivev1.UnsupportedHibernationReason, cond.Reason)
+				assert.Equal(t, restrictedHibernationMessage, cond.Message)
+			},
+		},
+		{
+			name:       "resume, restricted remote access",
+			cd:         cdBuilder.Options(o.hibernating, o.shouldRun).Build(),
+			cs:         csBuilder.Build(),
+			restricted: true,
+			validate: func(t *testing.T, cd *hivev1.ClusterDeployment) {
+				cond := getHibernatingCondition(cd)
+				require.NotNil(t, cond)
+				assert.Equal(t, corev1.ConditionTrue, cond.Status)
+				assert.Equal(t, hivev1.HibernatingHibernationReason, cond.Reason)
+			},
+		},
 		{
 			name: "start hibernating, syncsets not applied",
 			cd:   cdBuilder.Options(o.shouldHibernate, testcd.InstalledTimestamp(time.Now())).Build(),
@@ -331,6 +363,180 @@ func TestReconcile(t *testing.T) {
 				assert.Equal(t, hivev1.ResumingHibernationReason, cond.Reason)
 			},
 		},
+		{
+			name: "starting, machines running, enough nodes ready",
+			cd: cdBuilder.Options(o.resuming, withResumeReadiness(&hivev1.ResumeReadiness{
+				Nodes: &hivev1.NodesResumeCheck{ReadyPercent: pointer.Int32Ptr(80)},
+			})).Build(),
+			cs: csBuilder.Build(),
+			setupActuator: func(actuator *mock.MockHibernationActuator) {
+				actuator.EXPECT().MachinesRunning(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(true, nil)
+			},
+			setupRemote: func(builder *remoteclientmock.MockBuilder) {
+				c := fake.NewFakeClientWithScheme(scheme, unreadyNode()...)
+				builder.EXPECT().Build().Times(1).Return(c, nil)
+				builder.EXPECT().BuildKubeClient().Times(1).Return(fakekubeclient.NewSimpleClientset(csrs()...), nil)
+			},
+			setupCSRHelper: func(helper *mock.MockcsrHelper) {
+				count := len(csrs())
+				helper.EXPECT().IsApproved(gomock.Any()).Times(count).Return(false)
+				helper.EXPECT().Parse(gomock.Any()).Times(count).Return(nil, nil)
+				helper.EXPECT().Authorize(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(count).Return(nil)
+				helper.EXPECT().Approve(gomock.Any(), gomock.Any()).Times(count).Return(nil)
+			},
+			validate: func(t *testing.T, cd *hivev1.ClusterDeployment) {
+				cond := getHibernatingCondition(cd)
+				require.NotNil(t, cond)
+				assert.Equal(t, corev1.ConditionFalse, cond.Status)
+				assert.Equal(t, hivev1.RunningHibernationReason, cond.Reason)
+				assert.Nil(t, getResumeReadinessTimedOutCondition(cd))
+			},
+		},
+		{
+			name: "running after resume, unready node, csrs to approve",
+			cd:   cdBuilder.Options(o.runningSinceResume(10 * time.Minute)).Build(),
+			cs:   csBuilder.Build(),
+			setupRemote: func(builder *remoteclientmock.MockBuilder) {
+				builder.EXPECT().Build().Times(1).Return(fake.NewFakeClientWithScheme(scheme, unreadyNode()...), nil)
+				builder.EXPECT().BuildKubeClient().Times(1).Return(fakekubeclient.NewSimpleClientset(csrs()...), nil)
+			},
+			setupCSRHelper: func(helper *mock.MockcsrHelper) {
+				count := len(csrs())
+				helper.EXPECT().IsApproved(gomock.Any()).Times(count).Return(false)
+				helper.EXPECT().Parse(gomock.Any()).Times(count).Return(nil, nil)
+				helper.EXPECT().Authorize(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(count).Return(nil)
+				helper.EXPECT().Approve(gomock.Any(), gomock.Any()).Times(count).Return(nil)
+			},
+			validate: func(t *testing.T, cd *hivev1.ClusterDeployment) {
+				cond := getHibernatingCondition(cd)
+				require.NotNil(t, cond)
+				assert.Equal(t, corev1.ConditionFalse, cond.Status)
+				assert.Equal(t, hivev1.RunningHibernationReason, cond.Reason)
+			},
+		},
+		{
+			name: "running after resume, all nodes ready",
+			cd:   cdBuilder.Options(o.runningSinceResume(10 * time.Minute)).Build(),
+			cs:   csBuilder.Build(),
+			setupRemote: func(builder *remoteclientmock.MockBuilder) {
+				builder.EXPECT().Build().Times(1).Return(fake.NewFakeClientWithScheme(scheme, readyNodes()...), nil)
+			},
+			validate: func(t *testing.T, cd *hivev1.ClusterDeployment) {
+				cond := getHibernatingCondition(cd)
+				require.NotNil(t, cond)
+				assert.Equal(t, hivev1.RunningHibernationReason, cond.Reason)
+			},
+		},
+		{
+			name: "running long after resume, unready node",
+			cd:   cdBuilder.Options(o.runningSinceResume(2 * time.Hour)).Build(),
+			cs:   csBuilder.Build(),
+			validate: func(t *testing.T, cd *hivev1.ClusterDeployment) {
+				cond := getHibernatingCondition(cd)
+				require.NotNil(t, cond)
+				assert.Equal(t, hivev1.RunningHibernationReason, cond.Reason)
+			},
+		},
+		{
+			name: "starting, machines running, unready node, nodes check timed out",
+			cd: cdBuilder.Options(o.resuming, withResumeReadiness(&hivev1.ResumeReadiness{
+				Nodes: &hivev1.NodesResumeCheck{ResumeCheck: hivev1.ResumeCheck{Timeout: &metav1.Duration{Duration: time.Hour}}},
+			})).Build(),
+			cs: csBuilder.Build(),
+			setupActuator: func(actuator *mock.MockHibernationActuator) {
+				actuator.EXPECT().MachinesRunning(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(true, nil)
+			},
+			setupRemote: func(builder *remoteclientmock.MockBuilder) {
+				fakeClient := fake.NewFakeClientWithScheme(scheme, unreadyNode()...)
+				fakeKubeClient := fakekubeclient.NewSimpleClientset()
+				builder.EXPECT().Build().Times(1).Return(fakeClient, nil)
+				builder.EXPECT().BuildKubeClient().Times(1).Return(fakeKubeClient, nil)
+			},
+			validate: func(t *testing.T, cd *hivev1.ClusterDeployment) {
+				cond := getHibernatingCondition(cd)
+				require.NotNil(t, cond)
+				assert.Equal(t, hivev1.ResumingHibernationReason, cond.Reason)
+				cond = getResumeReadinessTimedOutCondition(cd)
+				require.NotNil(t, cond)
+				assert.Equal(t, corev1.ConditionTrue, cond.Status)
+				assert.Equal(t, hivev1.NodesNotReadyResumeReason, cond.Reason)
+				assert.Equal(t, "5 of 6 nodes are ready, need 100%", cond.Message)
+			},
+		},
+		{
+			name: "starting, nodes ready, cluster operators not available",
+			cd: cdBuilder.Options(o.resuming, withResumeReadiness(&hivev1.ResumeReadiness{
+				ClusterOperators: &hivev1.ResumeCheck{},
+			})).Build(),
+			cs: csBuilder.Build(),
+			setupActuator: func(actuator *mock.MockHibernationActuator) {
+				actuator.EXPECT().MachinesRunning(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(true, nil)
+			},
+			setupRemote: func(builder *remoteclientmock.MockBuilder) {
+				c := fake.NewFakeClientWithScheme(scheme, append(readyNodes(), clusterOperator("ingress", false))...)
+				builder.EXPECT().Build().Times(1).Return(c, nil)
+			},
+			validate: func(t *testing.T, cd *hivev1.ClusterDeployment) {
+				cond := getHibernatingCondition(cd)
+				require.NotNil(t, cond)
+				assert.Equal(t, hivev1.ResumingHibernationReason, cond.Reason)
+				assert.Nil(t, getResumeReadinessTimedOutCondition(cd), "check without timeout should not be reported")
+			},
+		},
+		{
+			name: "starting, nodes ready, cluster operators check timed out",
+			cd: cdBuilder.Options(o.resuming, withResumeReadiness(&hivev1.ResumeReadiness{
+				ClusterOperators: &hivev1.ResumeCheck{Timeout: &metav1.Duration{Duration: time.Hour}},
+			})).Build(),
+			cs: csBuilder.Build(),
+			setupActuator: func(actuator *mock.MockHibernationActuator) {
+				actuator.EXPECT().MachinesRunning(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(true, nil)
+			},
+			setupRemote: func(builder *remoteclientmock.MockBuilder) {
+				c := fake.NewFakeClientWithScheme(scheme, append(readyNodes(), clusterOperator("dns", true), clusterOperator("ingress", false))...)
+				builder.EXPECT().Build().Times(1).Return(c, nil)
+			},
+			validate: func(t *testing.T, cd *hivev1.ClusterDeployment) {
+				cond := getHibernatingCondition(cd)
+				require.NotNil(t, cond)
+				assert.Equal(t, hivev1.ResumingHibernationReason, cond.Reason)
+				cond = getResumeReadinessTimedOutCondition(cd)
+				require.NotNil(t, cond)
+				assert.Equal(t, corev1.ConditionTrue, cond.Status)
+				assert.Equal(t, hivev1.ClusterOperatorsNotAvailableResumeReason, cond.Reason)
+				assert.Equal(t, "Cluster operators not available or degraded: ingress", cond.Message)
+			},
+		},
+		{
+			name: "starting, all readiness checks passed",
+			cd: cdBuilder.Options(o.resuming, withResumeReadiness(&hivev1.ResumeReadiness{
+				ClusterOperators: &hivev1.ResumeCheck{Timeout: &metav1.Duration{Duration: time.Hour}},
+			}), func(cd *hivev1.ClusterDeployment) {
+				cd.Status.Conditions = append(cd.Status.Conditions, hivev1.ClusterDeploymentCondition{
+					Type:   hivev1.ResumeReadinessTimedOutCondition,
+					Status: corev1.ConditionTrue,
+					Reason: hivev1.ClusterOperatorsNotAvailableResumeReason,
+				})
+			}).Build(),
+			cs: csBuilder.Build(),
+			setupActuator: func(actuator *mock.MockHibernationActuator) {
+				actuator.EXPECT().MachinesRunning(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(true, nil)
+			},
+			setupRemote: func(builder *remoteclientmock.MockBuilder) {
+				c := fake.NewFakeClientWithScheme(scheme, append(readyNodes(), clusterOperator("dns", true), clusterOperator("ingress", true))...)
+				builder.EXPECT().Build().Times(1).Return(c, nil)
+			},
+			validate: func(t *testing.T, cd *hivev1.ClusterDeployment) {
+				cond := getHibernatingCondition(cd)
+				require.NotNil(t, cond)
+				assert.Equal(t, corev1.ConditionFalse, cond.Status)
+				assert.Equal(t, hivev1.RunningHibernationReason, cond.Reason)
+				cond = getResumeReadinessTimedOutCondition(cd)
+				require.NotNil(t, cond)
+				assert.Equal(t, corev1.ConditionFalse, cond.Status)
+				assert.Equal(t, hivev1.ResumeReadinessChecksPassedReason, cond.Reason)
+			},
+		},
 		{
 			name: "previously unsupported hibernation, now supported",
 			cd:   cdBuilder.Options(o.unsupported, testcd.WithHibernateAfter(8*time.Hour)).Build(),
@@ -362,6 +568,12 @@ func TestReconcile(t *testing.T) {
 			}
 			actuators = []HibernationActuator{mockActuator}
 			c := fake.NewFakeClientWithScheme(scheme, test.cd, test.cs)
+			if test.restricted {
+				os.Setenv(constants.ScopedRemoteAccessEnvVar, "true")
+				os.Setenv(constants.RemoteAccessRBACModeEnvVar, string(hivev1.RemoteAccessRBACModeRestricted))
+				defer os.Unsetenv(constants.ScopedRemoteAccessEnvVar)
+				defer os.Unsetenv(constants.RemoteAccessRBACModeEnvVar)
+			}
 
 			reconciler := hibernationReconciler{
 				Client: c,
@@ -392,6 +604,25 @@ func TestReconcile(t *testing.T) {
 
 }
 
+func TestGetActuator(t *testing.T) {
+	mockActuator := mock.NewMockHibernationActuator(gomock.NewController(t))
+	mockActuator.EXPECT().CanHandle(gomock.Any()).Return(true).AnyTimes()
+	actuators = []HibernationActuator{mockActuator}
+	plugin := &providerplugin.Plugin{Name: "test-plugin"}
+	reconciler := hibernationReconciler{
+		providerPlugins: providerplugin.Plugins{plugin.Name: plugin},
+	}
+
+	cdBuilder := testcd.BasicBuilder()
+	assert.Equal(t, mockActuator, reconciler.getActuator(cdBuilder.Build()), "expected registered actuator")
+	assert.Equal(t, &pluginActuator{plugin: plugin},
+		reconciler.getActuator(cdBuilder.Build(testcd.Generic(testgeneric.WithAnnotation(constants.ProviderPluginAnnotation, "test-plugin")))),
+		"expected plugin actuator")
+	assert.Nil(t,
+		reconciler.getActuator(cdBuilder.Build(testcd.Generic(testgeneric.WithAnnotation(constants.ProviderPluginAnnotation, "missing-plugin")))),
+		"expected no actuator for unconfigured plugin")
+}
+
 func TestHibernateAfter(t *testing.T) {
 	logger := log.New()
 	logger.SetLevel(log.DebugLevel)
@@ -529,6 +760,28 @@ func TestHibernateAfter(t *testing.T) {
 			).Build(),
 			expectedPowerState: hivev1.HibernatingClusterPowerState,
 		},
+		{
+			name: "unclaimed pool cluster not hibernated",
+			cd: cdBuilder.Build(
+				testcd.WithHibernateAfter(8*time.Hour),
+				testcd.WithUnclaimedClusterPoolReference(namespace, "test-pool"),
+				testcd.WithPowerState(hivev1.RunningClusterPowerState),
+				testcd.WithCondition(hibernatingCondition(corev1.ConditionFalse, hivev1.RunningHibernationReason, 9*time.Hour)),
+				testcd.InstalledTimestamp(time.Now().Add(-10*time.Hour))),
+			cs:                 csBuilder.Build(),
+			expectedPowerState: hivev1.RunningClusterPowerState,
+		},
+		{
+			name: "claimed pool cluster due for hibernate",
+			cd: cdBuilder.Build(
+				testcd.WithHibernateAfter(8*time.Hour),
+				testcd.WithClusterPoolReference(namespace, "test-pool", "test-claim"),
+				testcd.WithPowerState(hivev1.RunningClusterPowerState),
+				testcd.WithCondition(hibernatingCondition(corev1.ConditionFalse, hivev1.RunningHibernationReason, 9*time.Hour)),
+				testcd.InstalledTimestamp(time.Now().Add(-10*time.Hour))),
+			cs:                 csBuilder.Build(),
+			expectedPowerState: hivev1.HibernatingClusterPowerState,
+		},
 	}
 
 	for _, test := range tests {
@@ -622,6 +875,19 @@ func (*clusterDeploymentOptions) resuming(cd *hivev1.ClusterDeployment) {
 		Status: corev1.ConditionTrue,
 	})
 }
+func (*clusterDeploymentOptions) runningSinceResume(d time.Duration) testcd.Option {
+	return func(cd *hivev1.ClusterDeployment) {
+		cd.Status.Conditions = append(cd.Status.Conditions, hivev1.ClusterDeploymentCondition{
+			Type:               hivev1.ClusterHibernatingCondition,
+			Reason:             hivev1.RunningHibernationReason,
+			Status:             corev1.ConditionFalse,
+			LastTransitionTime: metav1.NewTime(time.Now().Add(-d)),
+		}, hivev1.ClusterDeploymentCondition{
+			Type:   hivev1.UnreachableCondition,
+			Status: corev1.ConditionFalse,
+		})
+	}
+}
 func (*clusterDeploymentOptions) unsupported(cd *hivev1.ClusterDeployment) {
 	cd.Status.Conditions = append(cd.Status.Conditions, hivev1.ClusterDeploymentCondition{
 		Type:   hivev1.ClusterHibernatingCondition,
@@ -639,6 +905,35 @@ func getHibernatingCondition(cd *hivev1.ClusterDeployment) *hivev1.ClusterDeploy
 	return nil
 }
 
+func withResumeReadiness(rr *hivev1.ResumeReadiness) testcd.Option {
+	return func(cd *hivev1.ClusterDeployment) {
+		cd.Spec.ResumeReadiness = rr
+	}
+}
+
+func getResumeReadinessTimedOutCondition(cd *hivev1.ClusterDeployment) *hivev1.ClusterDeploymentCondition {
+	for i := range cd.Status.Conditions {
+		if cd.Status.Conditions[i].Type == hivev1.ResumeReadinessTimedOutCondition {
+			return &cd.Status.Conditions[i]
+		}
+	}
+	return nil
+}
+
+func clusterOperator(name string, available bool) runtime.Object {
+	co := &configv1.ClusterOperator{}
+	co.Name = name
+	status := configv1.ConditionFalse
+	if available {
+		status = configv1.ConditionTrue
+	}
+	co.Status.Conditions = []configv1.ClusterOperatorStatusCondition{{
+		Type:   configv1.OperatorAvailable,
+		Status: status,
+	}}
+	return co
+}
+
 func readyNodes() []runtime.Object {
 	nodes := make([]runtime.Object, 5)
 	for i := 0; i < len(nodes); i++ {
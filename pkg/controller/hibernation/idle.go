@@ -0,0 +1,97 @@
+package hibernation
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/remoteclient"
+)
+
+// idleCheckInterval is the minimum time interval between checks of the user activity on a cluster with
+// HibernateAfterIdle set.
+const idleCheckInterval = 10 * time.Minute
+
+// checkIdle observes the user activity on a running cluster with HibernateAfterIdle set when it has not been checked for
+// idleCheckInterval, and returns whether the cluster has been idle for the HibernateAfterIdle duration. When it has not,
+// it also returns the time after which the cluster should be checked again. A cluster whose activity cannot be
+// determined is not idle.
+func (r *hibernationReconciler) checkIdle(cd *hivev1.ClusterDeployment, runningSince time.Time, logger log.FieldLogger) (bool, time.Duration, error) {
+	lastActive := runningSince
+	activity := cd.Status.Activity
+	if activity != nil && activity.LastActivityTime != nil && activity.LastActivityTime.Time.After(lastActive) {
+		lastActive = activity.LastActivityTime.Time
+	}
+
+	nextCheck := idleCheckInterval
+	if activity == nil || time.Since(activity.LastCheckTime.Time) >= idleCheckInterval {
+		// The activity on an unreachable cluster is unknown, so it is not considered idle until it can be checked.
+		if unreachable, _ := remoteclient.Unreachable(cd); unreachable {
+			logger.Debug("cluster is unreachable, cannot check user activity")
+			return false, idleCheckInterval, nil
+		}
+		var positions []hivev1.AuditLogPosition
+		if activity != nil {
+			positions = activity.AuditLogPositions
+		}
+		observed, positions, err := r.lastUserActivity(cd, positions)
+		if err != nil {
+			// The check is not recorded, so that the cluster is not considered idle until it succeeds.
+			logger.WithError(err).Warn("could not check user activity, cluster is not considered idle")
+			return false, idleCheckInterval, nil
+		}
+		if err := r.updateActivityStatus(cd, observed, positions, logger); err != nil {
+			return false, 0, err
+		}
+		if observed.After(lastActive) {
+			lastActive = observed
+		}
+	} else {
+		nextCheck = idleCheckInterval - time.Since(activity.LastCheckTime.Time)
+	}
+
+	expiry := lastActive.Add(cd.Spec.HibernateAfterIdle.Duration)
+	logger.WithField("lastActive", lastActive).Debugf("cluster should be hibernating when idle after: %s", expiry)
+	if time.Now().After(expiry) {
+		return true, 0, nil
+	}
+	if untilExpiry := time.Until(expiry); untilExpiry < nextCheck {
+		nextCheck = untilExpiry
+	}
+	return false, nextCheck, nil
+}
+
+// lastUserActivity returns the time of the latest request made by a user to the API servers of the cluster since the
+// audit log positions, and the new audit log positions.
+func (r *hibernationReconciler) lastUserActivity(cd *hivev1.ClusterDeployment, positions []hivev1.AuditLogPosition) (time.Time, []hivev1.AuditLogPosition, error) {
+	reader, err := r.auditLogReaderBuilder(cd)
+	if err != nil {
+		return time.Time{}, nil, errors.Wrap(err, "could not connect to target cluster")
+	}
+	return lastUserActivity(reader, positions)
+}
+
+// updateActivityStatus records the check of the user activity on the cluster with the audit log positions it read up
+// to, and the activity observed when it is later than the activity observed previously.
+func (r *hibernationReconciler) updateActivityStatus(cd *hivev1.ClusterDeployment, observed time.Time, positions []hivev1.AuditLogPosition, logger log.FieldLogger) error {
+	activity := &hivev1.ClusterActivityStatus{LastCheckTime: metav1.Now(), AuditLogPositions: positions}
+	if cd.Status.Activity != nil {
+		activity.LastActivityTime = cd.Status.Activity.LastActivityTime
+	}
+	if !observed.IsZero() && (activity.LastActivityTime == nil || observed.After(activity.LastActivityTime.Time)) {
+		t := metav1.NewTime(observed)
+		activity.LastActivityTime = &t
+	}
+	cd.Status.Activity = activity
+	if err := r.Status().Update(context.TODO(), cd); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "Failed to update cluster activity status")
+		return errors.Wrap(err, "failed to update cluster activity status")
+	}
+	return nil
+}
@@ -0,0 +1,335 @@
+package hibernation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hiveintv1alpha1 "github.com/openshift/hive/apis/hiveinternal/v1alpha1"
+	"github.com/openshift/hive/pkg/controller/hibernation/mock"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+	testcs "github.com/openshift/hive/pkg/test/clustersync"
+)
+
+func TestHibernateAfterIdle(t *testing.T) {
+	scheme := runtime.NewScheme()
+	corev1.AddToScheme(scheme)
+	hivev1.AddToScheme(scheme)
+	hiveintv1alpha1.AddToScheme(scheme)
+
+	cdBuilder := testcd.FullBuilder(namespace, cdName, scheme).Options(
+		testcd.Installed(),
+		testcd.WithClusterVersion("4.4.9"),
+		testcd.InstalledTimestamp(time.Now().Add(-10*time.Hour)),
+		withHibernateAfterIdle(2*time.Hour),
+		testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+			Type:   hivev1.UnreachableCondition,
+			Status: corev1.ConditionFalse,
+		}),
+	)
+	csBuilder := testcs.FullBuilder(namespace, cdName, scheme).Options(
+		testcs.WithFirstSuccessTime(time.Now().Add(-10 * time.Hour)),
+	)
+
+	tests := []struct {
+		name         string
+		cd           *hivev1.ClusterDeployment
+		auditLogs    map[string]string
+		readerErr    error
+		expectRemote bool
+
+		expectRequeueAfter   time.Duration
+		expectedPowerState   hivev1.ClusterPowerState
+		expectedLastActivity time.Duration
+		expectNoCheck        bool
+	}{
+		{
+			name: "no user activity",
+			cd:   cdBuilder.Build(),
+			auditLogs: map[string]string{
+				"master-0": auditLogLine("system:serviceaccount:openshift-monitoring:prometheus-k8s", time.Minute),
+				"master-1": auditLogLine("system:admin", time.Minute),
+			},
+			expectRemote:       true,
+			expectedPowerState: hivev1.HibernatingClusterPowerState,
+		},
+		{
+			name: "recent user request",
+			cd:   cdBuilder.Build(),
+			auditLogs: map[string]string{
+				"master-0": auditLogLine("kube:admin", time.Hour) + auditLogLine("system:node:master-0", time.Minute),
+				"master-1": auditLogLine("developer", 3*time.Hour),
+			},
+			expectRemote:         true,
+			expectRequeueAfter:   idleCheckInterval,
+			expectedLastActivity: time.Hour,
+		},
+		{
+			name: "user request close to idle timeout",
+			cd:   cdBuilder.Build(),
+			auditLogs: map[string]string{
+				"master-0": auditLogLine("developer", 115*time.Minute),
+			},
+			expectRemote:         true,
+			expectRequeueAfter:   5 * time.Minute,
+			expectedLastActivity: 115 * time.Minute,
+		},
+		{
+			name: "user request before audit log position",
+			cd: cdBuilder.Build(func(cd *hivev1.ClusterDeployment) {
+				cd.Status.Activity = &hivev1.ClusterActivityStatus{
+					LastCheckTime: metav1.NewTime(time.Now().Add(-time.Hour)),
+					AuditLogPositions: []hivev1.AuditLogPosition{{
+						Node:   "master-0",
+						Offset: int64(len(auditLogLine("developer", time.Hour))),
+					}},
+				}
+			}),
+			auditLogs: map[string]string{
+				"master-0": auditLogLine("developer", time.Hour) + auditLogLine("system:admin", time.Minute),
+			},
+			expectRemote:       true,
+			expectedPowerState: hivev1.HibernatingClusterPowerState,
+		},
+		{
+			name: "recently resumed",
+			cd: cdBuilder.Build(
+				testcd.WithCondition(hibernatingCondition(corev1.ConditionFalse, hivev1.RunningHibernationReason, time.Hour)),
+			),
+			auditLogs:          map[string]string{"master-0": ""},
+			expectRemote:       true,
+			expectRequeueAfter: idleCheckInterval,
+		},
+		{
+			name: "recently checked activity",
+			cd: cdBuilder.Build(
+				withActivity(3*time.Hour, 5*time.Minute),
+			),
+			expectedPowerState:   hivev1.HibernatingClusterPowerState,
+			expectedLastActivity: 3 * time.Hour,
+		},
+		{
+			name: "recently checked recent activity",
+			cd: cdBuilder.Build(
+				withActivity(time.Hour, 5*time.Minute),
+			),
+			expectRequeueAfter:   5 * time.Minute,
+			expectedLastActivity: time.Hour,
+		},
+		{
+			name: "unreachable cluster",
+			cd: cdBuilder.Build(
+				testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+					Type:   hivev1.UnreachableCondition,
+					Status: corev1.ConditionTrue,
+				}),
+			),
+			expectRequeueAfter: idleCheckInterval,
+			expectNoCheck:      true,
+		},
+		{
+			name:               "activity cannot be determined",
+			cd:                 cdBuilder.Build(),
+			readerErr:          errors.New("forbidden"),
+			expectRequeueAfter: idleCheckInterval,
+			expectNoCheck:      true,
+		},
+		{
+			name:               "no control plane nodes",
+			cd:                 cdBuilder.Build(),
+			auditLogs:          map[string]string{},
+			expectRequeueAfter: idleCheckInterval,
+			expectNoCheck:      true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockActuator := mock.NewMockHibernationActuator(ctrl)
+			mockActuator.EXPECT().CanHandle(gomock.Any()).AnyTimes().Return(true)
+			actuators = []HibernationActuator{mockActuator}
+			c := fake.NewFakeClientWithScheme(scheme, test.cd, csBuilder.Build())
+
+			reconciler := hibernationReconciler{
+				Client: c,
+				logger: log.WithField("controller", "hibernation"),
+				auditLogReaderBuilder: func(cd *hivev1.ClusterDeployment) (auditLogReader, error) {
+					if test.readerErr != nil {
+						return nil, test.readerErr
+					}
+					return fakeAuditLogReader(test.auditLogs), nil
+				},
+			}
+			result, err := reconciler.Reconcile(reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: namespace, Name: cdName},
+			})
+			require.NoError(t, err, "unexpected error from reconcile")
+
+			if test.expectRequeueAfter == 0 {
+				assert.Zero(t, result.RequeueAfter)
+			} else {
+				assert.InDelta(t, test.expectRequeueAfter.Seconds(), result.RequeueAfter.Seconds(), 10, "unexpected requeue after")
+			}
+
+			cd := &hivev1.ClusterDeployment{}
+			err = c.Get(context.TODO(), client.ObjectKey{Namespace: namespace, Name: cdName}, cd)
+			require.NoError(t, err, "error looking up ClusterDeployment")
+			assert.Equal(t, test.expectedPowerState, cd.Spec.PowerState, "unexpected PowerState")
+			if test.expectRemote && assert.NotNil(t, cd.Status.Activity, "expected activity to be recorded") {
+				assert.WithinDuration(t, time.Now(), cd.Status.Activity.LastCheckTime.Time, time.Minute, "unexpected last check time")
+				assert.Len(t, cd.Status.Activity.AuditLogPositions, len(test.auditLogs), "unexpected audit log positions")
+				for _, position := range cd.Status.Activity.AuditLogPositions {
+					assert.Equal(t, int64(len(test.auditLogs[position.Node])), position.Offset, "unexpected audit log position of %s", position.Node)
+				}
+			}
+			if test.expectNoCheck {
+				assert.Nil(t, cd.Status.Activity, "unexpected activity check")
+			}
+			if test.expectedLastActivity == 0 {
+				if cd.Status.Activity != nil {
+					assert.Nil(t, cd.Status.Activity.LastActivityTime, "unexpected last activity time")
+				}
+			} else if assert.NotNil(t, cd.Status.Activity, "expected activity to be recorded") &&
+				assert.NotNil(t, cd.Status.Activity.LastActivityTime, "expected last activity time") {
+				assert.WithinDuration(t, time.Now().Add(-test.expectedLastActivity), cd.Status.Activity.LastActivityTime.Time, time.Minute, "unexpected last activity time")
+			}
+		})
+	}
+}
+
+func TestLastUserActivity(t *testing.T) {
+	tests := []struct {
+		name              string
+		auditLogs         map[string]string
+		positions         []hivev1.AuditLogPosition
+		expectedLast      time.Duration
+		expectedPositions []hivev1.AuditLogPosition
+	}{
+		{
+			name:              "new node",
+			auditLogs:         map[string]string{"master-0": auditLogLine("developer", time.Hour)},
+			expectedLast:      time.Hour,
+			expectedPositions: []hivev1.AuditLogPosition{{Node: "master-0", Offset: int64(len(auditLogLine("developer", time.Hour)))}},
+		},
+		{
+			name: "incomplete line is read next time",
+			auditLogs: map[string]string{
+				"master-0": auditLogLine("system:admin", time.Hour) + strings.TrimSuffix(auditLogLine("developer", time.Minute), "\n"),
+			},
+			expectedPositions: []hivev1.AuditLogPosition{{Node: "master-0", Offset: int64(len(auditLogLine("system:admin", time.Hour)))}},
+		},
+		{
+			name:              "rotated audit log",
+			auditLogs:         map[string]string{"master-0": auditLogLine("developer", time.Minute)},
+			positions:         []hivev1.AuditLogPosition{{Node: "master-0", Offset: 1000}},
+			expectedLast:      time.Minute,
+			expectedPositions: []hivev1.AuditLogPosition{{Node: "master-0", Offset: int64(len(auditLogLine("developer", time.Minute)))}},
+		},
+		{
+			name:              "replaced node",
+			auditLogs:         map[string]string{"master-1": ""},
+			positions:         []hivev1.AuditLogPosition{{Node: "master-0", Offset: 10}},
+			expectedPositions: []hivev1.AuditLogPosition{{Node: "master-1", Offset: 0}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			last, positions, err := lastUserActivity(fakeAuditLogReader(test.auditLogs), test.positions)
+			require.NoError(t, err, "unexpected error")
+			if test.expectedLast == 0 {
+				assert.True(t, last.IsZero(), "unexpected last user activity")
+			} else {
+				assert.WithinDuration(t, time.Now().Add(-test.expectedLast), last, time.Second, "unexpected last user activity")
+			}
+			assert.Equal(t, test.expectedPositions, positions, "unexpected audit log positions")
+		})
+	}
+}
+
+func TestParseContentRange(t *testing.T) {
+	start, end, size, err := parseContentRange("bytes 100-199/1000")
+	if assert.NoError(t, err) {
+		assert.Equal(t, []int64{100, 199, 1000}, []int64{start, end, size}, "unexpected range")
+	}
+	_, _, size, err = parseContentRange("bytes */1000")
+	if assert.NoError(t, err) {
+		assert.Equal(t, int64(1000), size, "unexpected size")
+	}
+	_, _, _, err = parseContentRange("bytes 100-199")
+	assert.Error(t, err, "expected error for missing size")
+}
+
+func withHibernateAfterIdle(d time.Duration) testcd.Option {
+	return func(cd *hivev1.ClusterDeployment) {
+		cd.Spec.HibernateAfterIdle = &metav1.Duration{Duration: d}
+	}
+}
+
+func withActivity(lastActivityAgo, lastCheckAgo time.Duration) testcd.Option {
+	return func(cd *hivev1.ClusterDeployment) {
+		lastActivity := metav1.NewTime(time.Now().Add(-lastActivityAgo))
+		cd.Status.Activity = &hivev1.ClusterActivityStatus{
+			LastActivityTime: &lastActivity,
+			LastCheckTime:    metav1.NewTime(time.Now().Add(-lastCheckAgo)),
+		}
+	}
+}
+
+// fakeAuditLogReader reads audit logs from a map of the names of the control plane nodes to their audit log.
+type fakeAuditLogReader map[string]string
+
+func (r fakeAuditLogReader) ControlPlaneNodes() ([]string, error) {
+	nodes := []string{}
+	for node := range r {
+		nodes = append(nodes, node)
+	}
+	sort.Strings(nodes)
+	return nodes, nil
+}
+
+func (r fakeAuditLogReader) Read(node string, offset, limit int64) ([]byte, int64, int64, error) {
+	auditLog, ok := r[node]
+	if !ok {
+		return nil, 0, 0, fmt.Errorf("no node %s", node)
+	}
+	size := int64(len(auditLog))
+	if offset < 0 {
+		offset = size - limit
+		if offset < 0 {
+			offset = 0
+		}
+	}
+	if offset >= size {
+		return nil, 0, size, nil
+	}
+	end := offset + limit
+	if end > size {
+		end = size
+	}
+	return []byte(auditLog[offset:end]), offset, size, nil
+}
+
+func auditLogLine(username string, age time.Duration) string {
+	return fmt.Sprintf(`{"kind":"Event","apiVersion":"audit.k8s.io/v1","user":{"username":%q},"requestReceivedTimestamp":%q}`+"\n",
+		username, time.Now().Add(-age).UTC().Format(metav1.RFC3339Micro))
+}
@@ -0,0 +1,170 @@
+package hibernation
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/openstackclient"
+)
+
+var (
+	// The status of a server only changes once it has powered on or off, so the task states of those are used as
+	// its status in the meantime.
+	openstackRunningStatuses           = sets.NewString("ACTIVE")
+	openstackStoppedStatuses           = sets.NewString("SHUTOFF")
+	openstackPendingStatuses           = sets.NewString("BUILD", "REBOOT", "HARD_REBOOT", "powering-on")
+	openstackStoppingStatuses          = sets.NewString("powering-off")
+	openstackRunningOrPendingStatuses  = openstackRunningStatuses.Union(openstackPendingStatuses)
+	openstackStoppedOrStoppingStatuses = openstackStoppedStatuses.Union(openstackStoppingStatuses)
+	openstackNotRunningStatuses        = openstackStoppedOrStoppingStatuses.Union(openstackPendingStatuses)
+	openstackNotStoppedStatuses        = openstackRunningOrPendingStatuses.Union(openstackStoppingStatuses)
+)
+
+func init() {
+	RegisterActuator(&openstackActuator{getOpenStackClientFn: getOpenStackClient})
+}
+
+type openstackActuator struct {
+	getOpenStackClientFn func(*hivev1.ClusterDeployment, client.Client, log.FieldLogger) (openstackclient.Client, error)
+}
+
+// CanHandle returns true if the actuator can handle a particular ClusterDeployment
+func (a *openstackActuator) CanHandle(cd *hivev1.ClusterDeployment) bool {
+	return cd.Spec.Platform.OpenStack != nil
+}
+
+// StopMachines will stop machines belonging to the given ClusterDeployment
+func (a *openstackActuator) StopMachines(cd *hivev1.ClusterDeployment, hiveClient client.Client, logger log.FieldLogger) error {
+	logger = logger.WithField("cloud", "OpenStack")
+	openstackClient, err := a.getOpenStackClientFn(cd, hiveClient, logger)
+	if err != nil {
+		return err
+	}
+	servers, err := openstackListServers(openstackClient, cd, openstackRunningOrPendingStatuses, logger)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, server := range servers {
+		logger.WithField("server", server.Name).Info("Stopping server")
+		if err := openstackClient.StopServer(server.ID); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// StartMachines will start machines belonging to the given ClusterDeployment
+func (a *openstackActuator) StartMachines(cd *hivev1.ClusterDeployment, hiveClient client.Client, logger log.FieldLogger) error {
+	logger = logger.WithField("cloud", "OpenStack")
+	openstackClient, err := a.getOpenStackClientFn(cd, hiveClient, logger)
+	if err != nil {
+		return err
+	}
+	// A server which is still powering off cannot be started yet, so only the stopped ones are.
+	servers, err := openstackListServers(openstackClient, cd, openstackStoppedStatuses, logger)
+	if err != nil {
+		return err
+	}
+	if len(servers) == 0 {
+		logger.Info("No servers were found to start")
+		return nil
+	}
+	var errs []error
+	for _, server := range servers {
+		logger.WithField("server", server.Name).Info("Starting server")
+		if err := openstackClient.StartServer(server.ID); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// MachinesRunning will return true if the machines associated with the given
+// ClusterDeployment are in a running state.
+func (a *openstackActuator) MachinesRunning(cd *hivev1.ClusterDeployment, hiveClient client.Client, logger log.FieldLogger) (bool, error) {
+	logger = logger.WithField("cloud", "OpenStack")
+	openstackClient, err := a.getOpenStackClientFn(cd, hiveClient, logger)
+	if err != nil {
+		return false, err
+	}
+	servers, err := openstackListServers(openstackClient, cd, openstackNotRunningStatuses, logger)
+	if err != nil {
+		return false, err
+	}
+	return len(servers) == 0, nil
+}
+
+// MachinesStopped will return true if the machines associated with the given
+// ClusterDeployment are in a stopped state.
+func (a *openstackActuator) MachinesStopped(cd *hivev1.ClusterDeployment, hiveClient client.Client, logger log.FieldLogger) (bool, error) {
+	logger = logger.WithField("cloud", "OpenStack")
+	openstackClient, err := a.getOpenStackClientFn(cd, hiveClient, logger)
+	if err != nil {
+		return false, err
+	}
+	servers, err := openstackListServers(openstackClient, cd, openstackNotStoppedStatuses, logger)
+	if err != nil {
+		return false, err
+	}
+	return len(servers) == 0, nil
+}
+
+func getOpenStackClient(cd *hivev1.ClusterDeployment, c client.Client, logger log.FieldLogger) (openstackclient.Client, error) {
+	if cd.Spec.Platform.OpenStack == nil {
+		return nil, errors.New("OpenStack platform is not set in ClusterDeployment")
+	}
+	secret := &corev1.Secret{}
+	err := c.Get(context.TODO(), client.ObjectKey{Name: cd.Spec.Platform.OpenStack.CredentialsSecretRef.Name, Namespace: cd.Namespace}, secret)
+	if err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "Failed to fetch OpenStack credentials secret")
+		return nil, errors.Wrap(err, "failed to fetch OpenStack credentials secret")
+	}
+	var trustBundle []byte
+	if ref := cd.Spec.Platform.OpenStack.CertificatesSecretRef; ref != nil {
+		buf := &bytes.Buffer{}
+		if err := controllerutils.TrustBundleFromSecretToWriter(c, cd.Namespace, ref.Name, buf); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "Failed to load OpenStack trust bundle")
+			return nil, errors.Wrap(err, "failed to load trust bundle from CertificatesSecretRef")
+		}
+		trustBundle = buf.Bytes()
+	}
+	return openstackclient.NewClientFromSecret(secret, cd.Spec.Platform.OpenStack.Cloud, trustBundle)
+}
+
+// openstackServerStatus returns the status of the server, or its task state while it powers on or off.
+func openstackServerStatus(server openstackclient.Server) string {
+	if openstackPendingStatuses.Has(server.TaskState) || openstackStoppingStatuses.Has(server.TaskState) {
+		return server.TaskState
+	}
+	return server.Status
+}
+
+func openstackListServers(openstackClient openstackclient.Client, cd *hivev1.ClusterDeployment, statuses sets.String, logger log.FieldLogger) ([]openstackclient.Server, error) {
+	logger.Debug("listing servers")
+	// The installer names the servers of a cluster after its infra ID.
+	servers, err := openstackClient.ListServers(fmt.Sprintf("^%s-", cd.Spec.ClusterMetadata.InfraID))
+	if err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "Failed to list servers")
+		return nil, err
+	}
+	var result []openstackclient.Server
+	for _, server := range servers {
+		if statuses.Has(openstackServerStatus(server)) {
+			result = append(result, server)
+		}
+	}
+	logger.WithField("count", len(result)).WithField("statuses", statuses.List()).Debug("found servers")
+	return result, nil
+}
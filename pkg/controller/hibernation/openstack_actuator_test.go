@@ -0,0 +1,194 @@
+package hibernation
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1openstack "github.com/openshift/hive/apis/hive/v1/openstack"
+	"github.com/openshift/hive/pkg/openstackclient"
+	mockopenstackclient "github.com/openshift/hive/pkg/openstackclient/mock"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+)
+
+func TestOpenStackCanHandle(t *testing.T) {
+	cd := testcd.BasicBuilder().Options(func(cd *hivev1.ClusterDeployment) {
+		cd.Spec.Platform.OpenStack = &hivev1openstack.Platform{}
+	}).Build()
+	actuator := openstackActuator{}
+	assert.True(t, actuator.CanHandle(cd))
+
+	cd = testcd.BasicBuilder().Build()
+	assert.False(t, actuator.CanHandle(cd))
+}
+
+func TestOpenStackStopAndStartMachines(t *testing.T) {
+	tests := []struct {
+		name        string
+		testFunc    string
+		servers     map[string]int
+		setupClient func(*testing.T, *mockopenstackclient.MockClient)
+	}{
+		{
+			name:     "stop no running servers",
+			testFunc: "StopMachines",
+			servers:  map[string]int{"SHUTOFF": 2, "powering-off": 1},
+		},
+		{
+			name:     "stop running and pending servers",
+			testFunc: "StopMachines",
+			servers:  map[string]int{"SHUTOFF": 3, "powering-off": 1, "ACTIVE": 2, "BUILD": 1, "powering-on": 1},
+			setupClient: func(t *testing.T, c *mockopenstackclient.MockClient) {
+				c.EXPECT().StopServer(gomock.Any()).Times(4).Do(
+					func(id string) {
+						assert.True(t, strings.HasPrefix(id, "ACTIVE") || strings.HasPrefix(id, "BUILD") || strings.HasPrefix(id, "powering-on"))
+					},
+				)
+			},
+		},
+		{
+			name:     "start no stopped servers",
+			testFunc: "StartMachines",
+			servers:  map[string]int{"ACTIVE": 3, "BUILD": 1},
+		},
+		{
+			name:     "start stopped servers",
+			testFunc: "StartMachines",
+			servers:  map[string]int{"SHUTOFF": 3, "powering-off": 2, "ACTIVE": 1},
+			setupClient: func(t *testing.T, c *mockopenstackclient.MockClient) {
+				c.EXPECT().StartServer(gomock.Any()).Times(3).Do(
+					func(id string) {
+						assert.True(t, strings.HasPrefix(id, "SHUTOFF"))
+					},
+				)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			openstackClient := mockopenstackclient.NewMockClient(ctrl)
+			setupOpenStackClientServers(openstackClient, test.servers)
+			if test.setupClient != nil {
+				test.setupClient(t, openstackClient)
+			}
+			actuator := testOpenStackActuator(openstackClient)
+			var err error
+			switch test.testFunc {
+			case "StopMachines":
+				err = actuator.StopMachines(testClusterDeployment(), nil, log.New())
+			case "StartMachines":
+				err = actuator.StartMachines(testClusterDeployment(), nil, log.New())
+			default:
+				t.Fatal("Invalid function to test")
+			}
+			assert.Nil(t, err)
+			ctrl.Finish()
+		})
+	}
+}
+
+func TestOpenStackMachinesStoppedAndRunning(t *testing.T) {
+	tests := []struct {
+		name     string
+		testFunc string
+		expected bool
+		servers  map[string]int
+	}{
+		{
+			name:     "Stopped - All machines stopped",
+			testFunc: "MachinesStopped",
+			expected: true,
+			servers:  map[string]int{"SHUTOFF": 3},
+		},
+		{
+			name:     "Stopped - Some machines powering off",
+			testFunc: "MachinesStopped",
+			expected: false,
+			servers:  map[string]int{"SHUTOFF": 3, "powering-off": 1},
+		},
+		{
+			name:     "Stopped - machines running",
+			testFunc: "MachinesStopped",
+			expected: false,
+			servers:  map[string]int{"ACTIVE": 3, "SHUTOFF": 2},
+		},
+		{
+			name:     "Running - All machines running",
+			testFunc: "MachinesRunning",
+			expected: true,
+			servers:  map[string]int{"ACTIVE": 3},
+		},
+		{
+			name:     "Running - Some machines powering on",
+			testFunc: "MachinesRunning",
+			expected: false,
+			servers:  map[string]int{"ACTIVE": 3, "powering-on": 1},
+		},
+		{
+			name:     "Running - Some machines stopped",
+			testFunc: "MachinesRunning",
+			expected: false,
+			servers:  map[string]int{"ACTIVE": 3, "SHUTOFF": 2},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			openstackClient := mockopenstackclient.NewMockClient(ctrl)
+			setupOpenStackClientServers(openstackClient, test.servers)
+			actuator := testOpenStackActuator(openstackClient)
+			var err error
+			var result bool
+			switch test.testFunc {
+			case "MachinesStopped":
+				result, err = actuator.MachinesStopped(testClusterDeployment(), nil, log.New())
+			case "MachinesRunning":
+				result, err = actuator.MachinesRunning(testClusterDeployment(), nil, log.New())
+			default:
+				t.Fatal("Invalid function to test")
+			}
+			require.Nil(t, err)
+			assert.Equal(t, test.expected, result)
+		})
+	}
+}
+
+func testOpenStackActuator(openstackClient openstackclient.Client) *openstackActuator {
+	return &openstackActuator{
+		getOpenStackClientFn: func(*hivev1.ClusterDeployment, client.Client, log.FieldLogger) (openstackclient.Client, error) {
+			return openstackClient, nil
+		},
+	}
+}
+
+// setupOpenStackClientServers lists servers with the given statuses, where a status which is a task state is that of
+// an active server.
+func setupOpenStackClientServers(openstackClient *mockopenstackclient.MockClient, statuses map[string]int) {
+	servers := []openstackclient.Server{}
+	for status, count := range statuses {
+		for i := 0; i < count; i++ {
+			server := openstackclient.Server{
+				ID:     fmt.Sprintf("%s-%d", status, i),
+				Name:   fmt.Sprintf("abcd1234-%s-%d", status, i),
+				Status: status,
+			}
+			if strings.HasPrefix(status, "powering-") {
+				server.Status = "ACTIVE"
+				server.TaskState = status
+			}
+			servers = append(servers, server)
+		}
+	}
+	openstackClient.EXPECT().ListServers("^abcd1234-").Times(1).Return(servers, nil)
+}
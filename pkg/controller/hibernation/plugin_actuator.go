@@ -0,0 +1,53 @@
+package hibernation
+
+import (
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/providerplugin"
+)
+
+// pluginActuator hibernates the clusters handled by a provider plugin. It is not registered, as it handles the
+// clusters which name its plugin rather than those of a platform.
+type pluginActuator struct {
+	plugin *providerplugin.Plugin
+}
+
+// CanHandle returns true if the actuator can handle a particular ClusterDeployment
+func (a *pluginActuator) CanHandle(cd *hivev1.ClusterDeployment) bool {
+	return true
+}
+
+// StopMachines will stop machines belonging to the given ClusterDeployment
+func (a *pluginActuator) StopMachines(cd *hivev1.ClusterDeployment, hiveClient client.Client, logger log.FieldLogger) error {
+	_, err := a.plugin.Call(providerplugin.OperationStopMachines, &providerplugin.Request{ClusterDeployment: cd}, logger)
+	return err
+}
+
+// StartMachines will start machines belonging to the given ClusterDeployment
+func (a *pluginActuator) StartMachines(cd *hivev1.ClusterDeployment, hiveClient client.Client, logger log.FieldLogger) error {
+	_, err := a.plugin.Call(providerplugin.OperationStartMachines, &providerplugin.Request{ClusterDeployment: cd}, logger)
+	return err
+}
+
+// MachinesRunning will return true if the machines associated with the given
+// ClusterDeployment are in a running state.
+func (a *pluginActuator) MachinesRunning(cd *hivev1.ClusterDeployment, hiveClient client.Client, logger log.FieldLogger) (bool, error) {
+	response, err := a.plugin.Call(providerplugin.OperationMachinesRunning, &providerplugin.Request{ClusterDeployment: cd}, logger)
+	if err != nil {
+		return false, err
+	}
+	return response.Running, nil
+}
+
+// MachinesStopped will return true if the machines associated with the given
+// ClusterDeployment are in a stopped state.
+func (a *pluginActuator) MachinesStopped(cd *hivev1.ClusterDeployment, hiveClient client.Client, logger log.FieldLogger) (bool, error) {
+	response, err := a.plugin.Call(providerplugin.OperationMachinesStopped, &providerplugin.Request{ClusterDeployment: cd}, logger)
+	if err != nil {
+		return false, err
+	}
+	return response.Stopped, nil
+}
@@ -0,0 +1,63 @@
+package hibernation
+
+import (
+	"fmt"
+	"time"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/util/cron"
+)
+
+// scheduledPowerState returns the power state last set by the schedule and the time it was set, which is zero if the
+// schedule never fired, along with the next time the schedule sets a power state. When the cluster is both hibernated
+// and resumed at the same time, it is resumed.
+func scheduledPowerState(schedule *hivev1.PowerStateSchedule, now time.Time) (state hivev1.ClusterPowerState, last, next time.Time, err error) {
+	location := time.UTC
+	if schedule.TimeZone != "" {
+		if location, err = time.LoadLocation(schedule.TimeZone); err != nil {
+			return "", time.Time{}, time.Time{}, fmt.Errorf("invalid time zone: %v", err)
+		}
+	}
+	hibernate, err := cron.Parse(schedule.Hibernate)
+	if err != nil {
+		return "", time.Time{}, time.Time{}, fmt.Errorf("invalid hibernate schedule: %v", err)
+	}
+	resume, err := cron.Parse(schedule.Resume)
+	if err != nil {
+		return "", time.Time{}, time.Time{}, fmt.Errorf("invalid resume schedule: %v", err)
+	}
+
+	now = now.In(location)
+	lastHibernate, lastResume := hibernate.Prev(now), resume.Prev(now)
+	switch {
+	case lastHibernate.After(lastResume):
+		state, last = hivev1.HibernatingClusterPowerState, lastHibernate
+	case !lastResume.IsZero():
+		state, last = hivev1.RunningClusterPowerState, lastResume
+	}
+
+	next = hibernate.Next(now)
+	if nextResume := resume.Next(now); !nextResume.IsZero() && (next.IsZero() || nextResume.Before(next)) {
+		next = nextResume
+	}
+	return state, last, next, nil
+}
+
+// powerStateScheduleApplied returns the last time of the power state schedule of the cluster which was applied, or
+// the zero time if none was.
+func powerStateScheduleApplied(cd *hivev1.ClusterDeployment) time.Time {
+	applied, err := time.Parse(time.RFC3339, cd.Annotations[constants.PowerStateScheduleAppliedAnnotation])
+	if err != nil {
+		return time.Time{}
+	}
+	return applied
+}
+
+// powerState returns the power state of the cluster, which defaults to running.
+func powerState(cd *hivev1.ClusterDeployment) hivev1.ClusterPowerState {
+	if cd.Spec.PowerState == "" {
+		return hivev1.RunningClusterPowerState
+	}
+	return cd.Spec.PowerState
+}
@@ -0,0 +1,236 @@
+package hibernation
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hiveintv1alpha1 "github.com/openshift/hive/apis/hiveinternal/v1alpha1"
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/controller/hibernation/mock"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+	testcs "github.com/openshift/hive/pkg/test/clustersync"
+	testgeneric "github.com/openshift/hive/pkg/test/generic"
+)
+
+func TestScheduledPowerState(t *testing.T) {
+	// Wednesday
+	now := time.Date(2026, 10, 14, 12, 30, 0, 0, time.UTC)
+	tests := []struct {
+		name          string
+		schedule      hivev1.PowerStateSchedule
+		expectedState hivev1.ClusterPowerState
+		expectedLast  time.Time
+		expectedNext  time.Time
+		expectError   bool
+	}{
+		{
+			name:          "running during the day",
+			schedule:      hivev1.PowerStateSchedule{Hibernate: "0 19 * * 1-5", Resume: "0 7 * * 1-5"},
+			expectedState: hivev1.RunningClusterPowerState,
+			expectedLast:  time.Date(2026, 10, 14, 7, 0, 0, 0, time.UTC),
+			expectedNext:  time.Date(2026, 10, 14, 19, 0, 0, 0, time.UTC),
+		},
+		{
+			name:          "hibernating at night",
+			schedule:      hivev1.PowerStateSchedule{Hibernate: "0 9 * * *", Resume: "0 17 * * *"},
+			expectedState: hivev1.HibernatingClusterPowerState,
+			expectedLast:  time.Date(2026, 10, 14, 9, 0, 0, 0, time.UTC),
+			expectedNext:  time.Date(2026, 10, 14, 17, 0, 0, 0, time.UTC),
+		},
+		{
+			name:          "time zone",
+			schedule:      hivev1.PowerStateSchedule{Hibernate: "0 19 * * *", Resume: "0 7 * * *", TimeZone: "Asia/Tokyo"},
+			expectedState: hivev1.HibernatingClusterPowerState,
+			expectedLast:  time.Date(2026, 10, 14, 10, 0, 0, 0, time.UTC),
+			expectedNext:  time.Date(2026, 10, 14, 22, 0, 0, 0, time.UTC),
+		},
+		{
+			name:          "resumed when hibernated at the same time",
+			schedule:      hivev1.PowerStateSchedule{Hibernate: "0 12 * * *", Resume: "0 12 * * *"},
+			expectedState: hivev1.RunningClusterPowerState,
+			expectedLast:  time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC),
+			expectedNext:  time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			name:        "invalid hibernate schedule",
+			schedule:    hivev1.PowerStateSchedule{Hibernate: "0 25 * * *", Resume: "0 7 * * *"},
+			expectError: true,
+		},
+		{
+			name:        "invalid time zone",
+			schedule:    hivev1.PowerStateSchedule{Hibernate: "0 19 * * *", Resume: "0 7 * * *", TimeZone: "Nowhere/Special"},
+			expectError: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			state, last, next, err := scheduledPowerState(&test.schedule, now)
+			if test.expectError {
+				assert.Error(t, err, "expected error")
+				return
+			}
+			require.NoError(t, err, "unexpected error")
+			assert.Equal(t, test.expectedState, state, "unexpected power state")
+			assert.True(t, test.expectedLast.Equal(last), "unexpected last time: %v", last)
+			assert.True(t, test.expectedNext.Equal(next), "unexpected next time: %v", next)
+		})
+	}
+}
+
+func TestPowerStateSchedule(t *testing.T) {
+	logger := log.New()
+	logger.SetLevel(log.DebugLevel)
+
+	scheme := runtime.NewScheme()
+	corev1.AddToScheme(scheme)
+	hivev1.AddToScheme(scheme)
+	hiveintv1alpha1.AddToScheme(scheme)
+
+	// The schedules fire at the start of the hours one and two hours ago, so that the latest scheduled time is the one
+	// of the hour before.
+	now := time.Now().UTC()
+	lastHour := now.Add(-time.Hour).Truncate(time.Hour)
+	hourBefore := now.Add(-2 * time.Hour).Truncate(time.Hour)
+	schedule := func(hibernateHour, resumeHour time.Time) testcd.Option {
+		return func(cd *hivev1.ClusterDeployment) {
+			cd.Spec.PowerStateSchedule = &hivev1.PowerStateSchedule{
+				Hibernate: fmt.Sprintf("0 %d * * *", hibernateHour.Hour()),
+				Resume:    fmt.Sprintf("0 %d * * *", resumeHour.Hour()),
+			}
+		}
+	}
+	applied := func(at time.Time) testcd.Option {
+		return testcd.Generic(testgeneric.WithAnnotation(constants.PowerStateScheduleAppliedAnnotation, at.Format(time.RFC3339)))
+	}
+
+	cdBuilder := testcd.FullBuilder(namespace, cdName, scheme).Options(
+		testcd.Installed(),
+		testcd.WithClusterVersion("4.4.9"),
+		testcd.InstalledTimestamp(now.Add(-10*24*time.Hour)),
+	)
+	csBuilder := testcs.FullBuilder(namespace, cdName, scheme).Options(
+		testcs.WithFirstSuccessTime(now.Add(-10 * 24 * time.Hour)),
+	)
+
+	tests := []struct {
+		name               string
+		cd                 *hivev1.ClusterDeployment
+		expectedPowerState hivev1.ClusterPowerState
+		expectedApplied    time.Time
+		expectRequeue      bool
+	}{
+		{
+			name: "schedule hibernates cluster",
+			cd: cdBuilder.Build(
+				schedule(lastHour, hourBefore),
+				testcd.WithCondition(hibernatingCondition(corev1.ConditionFalse, hivev1.RunningHibernationReason, 24*time.Hour)),
+			),
+			expectedPowerState: hivev1.HibernatingClusterPowerState,
+			expectedApplied:    lastHour,
+		},
+		{
+			name: "schedule resumes cluster",
+			cd: cdBuilder.Build(
+				schedule(hourBefore, lastHour),
+				testcd.WithPowerState(hivev1.HibernatingClusterPowerState),
+				applied(hourBefore),
+			),
+			expectedPowerState: hivev1.RunningClusterPowerState,
+			expectedApplied:    lastHour,
+		},
+		{
+			name: "schedule already applied",
+			cd: cdBuilder.Build(
+				schedule(lastHour, hourBefore),
+				testcd.WithPowerState(hivev1.HibernatingClusterPowerState),
+				applied(hourBefore),
+			),
+			expectedPowerState: hivev1.HibernatingClusterPowerState,
+			expectedApplied:    lastHour,
+		},
+		{
+			name: "power state set since schedule applied is kept",
+			cd: cdBuilder.Build(
+				schedule(lastHour, hourBefore),
+				testcd.WithPowerState(hivev1.RunningClusterPowerState),
+				testcd.WithCondition(hibernatingCondition(corev1.ConditionFalse, hivev1.RunningHibernationReason, 24*time.Hour)),
+				applied(lastHour),
+			),
+			expectedPowerState: hivev1.RunningClusterPowerState,
+			expectedApplied:    lastHour,
+			expectRequeue:      true,
+		},
+		{
+			name: "unclaimed pool cluster not scheduled",
+			cd: cdBuilder.Build(
+				schedule(lastHour, hourBefore),
+				testcd.WithUnclaimedClusterPoolReference(namespace, "test-pool"),
+				testcd.WithPowerState(hivev1.RunningClusterPowerState),
+				testcd.WithCondition(hibernatingCondition(corev1.ConditionFalse, hivev1.RunningHibernationReason, 24*time.Hour)),
+			),
+			expectedPowerState: hivev1.RunningClusterPowerState,
+		},
+		{
+			name: "invalid schedule ignored",
+			cd: cdBuilder.Build(
+				func(cd *hivev1.ClusterDeployment) {
+					cd.Spec.PowerStateSchedule = &hivev1.PowerStateSchedule{Hibernate: "never", Resume: "0 7 * * *"}
+				},
+				testcd.WithPowerState(hivev1.RunningClusterPowerState),
+				testcd.WithCondition(hibernatingCondition(corev1.ConditionFalse, hivev1.RunningHibernationReason, 24*time.Hour)),
+			),
+			expectedPowerState: hivev1.RunningClusterPowerState,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockActuator := mock.NewMockHibernationActuator(ctrl)
+			mockActuator.EXPECT().CanHandle(gomock.Any()).AnyTimes().Return(true)
+			actuators = []HibernationActuator{mockActuator}
+			c := fake.NewFakeClientWithScheme(scheme, test.cd, csBuilder.Build())
+
+			reconciler := hibernationReconciler{
+				Client:  c,
+				logger:  logger,
+				csrUtil: mock.NewMockcsrHelper(ctrl),
+			}
+			result, err := reconciler.Reconcile(reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: namespace, Name: cdName},
+			})
+			require.NoError(t, err, "unexpected error from reconcile")
+
+			if test.expectRequeue {
+				assert.Greater(t, result.RequeueAfter.Hours(), 20.0, "requeue after too small")
+				assert.LessOrEqual(t, result.RequeueAfter.Hours(), 24.0, "requeue after too large")
+			}
+
+			cd := &hivev1.ClusterDeployment{}
+			err = c.Get(context.TODO(), client.ObjectKey{Namespace: namespace, Name: cdName}, cd)
+			require.NoError(t, err, "error looking up ClusterDeployment")
+			assert.Equal(t, test.expectedPowerState, cd.Spec.PowerState, "unexpected PowerState")
+			if test.expectedApplied.IsZero() {
+				assert.NotContains(t, cd.Annotations, constants.PowerStateScheduleAppliedAnnotation, "unexpected applied annotation")
+			} else {
+				assert.Equal(t, test.expectedApplied.Format(time.RFC3339), cd.Annotations[constants.PowerStateScheduleAppliedAnnotation], "unexpected applied annotation")
+			}
+		})
+	}
+}
@@ -0,0 +1,187 @@
+package hibernation
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	configv1 "github.com/openshift/api/config/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+// certificateDialTimeout is the time to wait for the API server of a cluster when checking its serving certificate.
+const certificateDialTimeout = 30 * time.Second
+
+// nodesReadyPercent returns the percentage of the nodes of the cluster which must be ready for it to have resumed.
+func nodesReadyPercent(cd *hivev1.ClusterDeployment) int32 {
+	if rr := cd.Spec.ResumeReadiness; rr != nil && rr.Nodes != nil && rr.Nodes.ReadyPercent != nil {
+		return *rr.Nodes.ReadyPercent
+	}
+	return 100
+}
+
+// nodesResumeCheck returns the resume check of the nodes of the cluster, or nil when it is not configured.
+func nodesResumeCheck(cd *hivev1.ClusterDeployment) *hivev1.ResumeCheck {
+	if rr := cd.Spec.ResumeReadiness; rr != nil && rr.Nodes != nil {
+		return &rr.Nodes.ResumeCheck
+	}
+	return nil
+}
+
+// checkResumeReadiness runs the cluster operators and certificates checks of the cluster which are configured, and
+// returns the reason and message of the first one which does not pass, or an empty reason when they all pass.
+func (r *hibernationReconciler) checkResumeReadiness(cd *hivev1.ClusterDeployment, remoteClient client.Client, logger log.FieldLogger) (*hivev1.ResumeCheck, string, string, error) {
+	rr := cd.Spec.ResumeReadiness
+	if rr == nil {
+		return nil, "", "", nil
+	}
+	if rr.ClusterOperators != nil {
+		msg, err := clusterOperatorsAvailable(remoteClient)
+		if err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "Failed to check whether cluster operators are available")
+			return nil, "", "", err
+		}
+		if msg != "" {
+			return rr.ClusterOperators, hivev1.ClusterOperatorsNotAvailableResumeReason, msg, nil
+		}
+	}
+	if rr.Certificates != nil {
+		cfg, err := r.remoteClientBuilder(cd).RESTConfig()
+		if err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "Failed to get REST config of target cluster")
+			return nil, "", "", err
+		}
+		if err := apiServerCertificateValid(cfg); err != nil {
+			return rr.Certificates, hivev1.CertificatesNotValidResumeReason, fmt.Sprintf("API server certificate is not valid: %v", err), nil
+		}
+	}
+	return nil, "", "", nil
+}
+
+// setResumeReadinessTimedOutCondition sets the ResumeReadinessTimedOut condition to true with the given reason and
+// message when the check has a timeout which has passed since the cluster started resuming.
+func (r *hibernationReconciler) setResumeReadinessTimedOutCondition(cd *hivev1.ClusterDeployment, check *hivev1.ResumeCheck, reason, message string, logger log.FieldLogger) error {
+	if check == nil || check.Timeout == nil {
+		return nil
+	}
+	hibernatingCondition := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.ClusterHibernatingCondition)
+	if hibernatingCondition == nil || time.Since(hibernatingCondition.LastProbeTime.Time) < check.Timeout.Duration {
+		return nil
+	}
+	logger.WithField("reason", reason).Warn("resume readiness check timed out")
+	return r.updateResumeReadinessTimedOutCondition(cd, corev1.ConditionTrue, reason, message, logger)
+}
+
+// clearResumeReadinessTimedOutCondition sets the ResumeReadinessTimedOut condition to false once all the checks passed.
+func (r *hibernationReconciler) clearResumeReadinessTimedOutCondition(cd *hivev1.ClusterDeployment, logger log.FieldLogger) error {
+	return r.updateResumeReadinessTimedOutCondition(cd, corev1.ConditionFalse, hivev1.ResumeReadinessChecksPassedReason, "All resume readiness checks passed", logger)
+}
+
+func (r *hibernationReconciler) updateResumeReadinessTimedOutCondition(cd *hivev1.ClusterDeployment, status corev1.ConditionStatus, reason, message string, logger log.FieldLogger) error {
+	conditions, changed := controllerutils.SetClusterDeploymentConditionWithChangeCheck(
+		cd.Status.Conditions,
+		hivev1.ResumeReadinessTimedOutCondition,
+		status,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	if !changed {
+		return nil
+	}
+	cd.Status.Conditions = conditions
+	if err := r.Status().Update(context.TODO(), cd); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "Failed to update ResumeReadinessTimedOut condition")
+		return errors.Wrap(err, "failed to update ResumeReadinessTimedOut condition")
+	}
+	return nil
+}
+
+// resumeCheckFailed reports a check which did not pass yet, and requeues the cluster to run the checks again.
+func (r *hibernationReconciler) resumeCheckFailed(cd *hivev1.ClusterDeployment, check *hivev1.ResumeCheck, reason, message string, logger log.FieldLogger) (reconcile.Result, error) {
+	logger.WithField("reason", reason).Infof("Cluster is not ready yet, waiting: %s", message)
+	if err := r.setResumeReadinessTimedOutCondition(cd, check, reason, message, logger); err != nil {
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{RequeueAfter: stateCheckInterval}, nil
+}
+
+// clusterOperatorsAvailable returns a message listing the cluster operators which are not available or are degraded,
+// or an empty message when all of them are available.
+func clusterOperatorsAvailable(remoteClient client.Client) (string, error) {
+	clusterOperators := &configv1.ClusterOperatorList{}
+	if err := remoteClient.List(context.TODO(), clusterOperators); err != nil {
+		return "", errors.Wrap(err, "failed to list cluster operators")
+	}
+	if len(clusterOperators.Items) == 0 {
+		return "Cluster is not reporting any cluster operators", nil
+	}
+	var unavailable []string
+	for _, co := range clusterOperators.Items {
+		if !clusterOperatorConditionIs(co, configv1.OperatorAvailable, configv1.ConditionTrue) ||
+			clusterOperatorConditionIs(co, configv1.OperatorDegraded, configv1.ConditionTrue) {
+			unavailable = append(unavailable, co.Name)
+		}
+	}
+	if len(unavailable) > 0 {
+		return fmt.Sprintf("Cluster operators not available or degraded: %s", strings.Join(unavailable, ", ")), nil
+	}
+	return "", nil
+}
+
+func clusterOperatorConditionIs(co configv1.ClusterOperator, conditionType configv1.ClusterStatusConditionType, status configv1.ConditionStatus) bool {
+	for _, c := range co.Status.Conditions {
+		if c.Type == conditionType {
+			return c.Status == status
+		}
+	}
+	return false
+}
+
+// apiServerCertificateValid connects to the API server of a cluster and returns an error when its serving certificate
+// is not trusted by the config or is not valid at the current time.
+func apiServerCertificateValid(cfg *rest.Config) error {
+	tlsConfig, err := rest.TLSConfigFor(cfg)
+	if err != nil {
+		return errors.Wrap(err, "could not build TLS config")
+	}
+	if tlsConfig == nil {
+		tlsConfig = &tls.Config{}
+	}
+	apiURL, err := url.Parse(cfg.Host)
+	if err != nil {
+		return errors.Wrap(err, "could not parse API URL")
+	}
+	addr := apiURL.Host
+	if apiURL.Port() == "" {
+		addr = net.JoinHostPort(apiURL.Hostname(), "443")
+	}
+	conn, err := tls.DialWithDialer(&net.Dialer{Timeout: certificateDialTimeout}, "tcp", addr, tlsConfig)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	certs := conn.ConnectionState().PeerCertificates
+	if len(certs) == 0 {
+		return errors.New("no certificate presented")
+	}
+	now := time.Now()
+	if now.Before(certs[0].NotBefore) || now.After(certs[0].NotAfter) {
+		return fmt.Errorf("certificate is only valid from %s to %s", certs[0].NotBefore, certs[0].NotAfter)
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package hibernation
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"k8s.io/client-go/rest"
+)
+
+func TestAPIServerCertificateValid(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	caData := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	tests := []struct {
+		name        string
+		cfg         *rest.Config
+		expectValid bool
+	}{
+		{
+			name:        "trusted certificate",
+			cfg:         &rest.Config{Host: server.URL, TLSClientConfig: rest.TLSClientConfig{CAData: caData}},
+			expectValid: true,
+		},
+		{
+			name: "untrusted certificate",
+			cfg:  &rest.Config{Host: server.URL},
+		},
+		{
+			name: "unreachable API server",
+			cfg:  &rest.Config{Host: "https://127.0.0.1:1", TLSClientConfig: rest.TLSClientConfig{CAData: caData}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := apiServerCertificateValid(test.cfg)
+			if test.expectValid {
+				assert.NoError(t, err, "expected certificate to be valid")
+			} else {
+				assert.Error(t, err, "expected certificate to be reported invalid")
+			}
+		})
+	}
+}
@@ -0,0 +1,169 @@
+package hibernation
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/vmware/govmomi/vim25/types"
+
+	corev1 "k8s.io/api/core/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/vsphereclient"
+)
+
+var (
+	// A virtual machine stays powered on while its guest shuts down, so there are no pending or stopping states.
+	vsphereRunningStates = sets.NewString(string(types.VirtualMachinePowerStatePoweredOn))
+	vsphereStoppedStates = sets.NewString(
+		string(types.VirtualMachinePowerStatePoweredOff),
+		string(types.VirtualMachinePowerStateSuspended),
+	)
+)
+
+func init() {
+	RegisterActuator(&vsphereActuator{getVSphereClientFn: getVSphereClient})
+}
+
+type vsphereActuator struct {
+	getVSphereClientFn func(*hivev1.ClusterDeployment, client.Client, log.FieldLogger) (vsphereclient.Client, error)
+}
+
+// CanHandle returns true if the actuator can handle a particular ClusterDeployment
+func (a *vsphereActuator) CanHandle(cd *hivev1.ClusterDeployment) bool {
+	return cd.Spec.Platform.VSphere != nil
+}
+
+// StopMachines will stop machines belonging to the given ClusterDeployment
+func (a *vsphereActuator) StopMachines(cd *hivev1.ClusterDeployment, hiveClient client.Client, logger log.FieldLogger) error {
+	logger = logger.WithField("cloud", "vSphere")
+	vsphereClient, err := a.getVSphereClientFn(cd, hiveClient, logger)
+	if err != nil {
+		return err
+	}
+	defer vsphereLogout(vsphereClient, logger)
+	vms, err := vsphereListVirtualMachines(vsphereClient, cd, vsphereRunningStates, logger)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, vm := range vms {
+		logger.WithField("vm", vm.Name).Info("Powering off virtual machine")
+		if err := vsphereClient.PowerOffVirtualMachine(vm); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// StartMachines will start machines belonging to the given ClusterDeployment
+func (a *vsphereActuator) StartMachines(cd *hivev1.ClusterDeployment, hiveClient client.Client, logger log.FieldLogger) error {
+	logger = logger.WithField("cloud", "vSphere")
+	vsphereClient, err := a.getVSphereClientFn(cd, hiveClient, logger)
+	if err != nil {
+		return err
+	}
+	defer vsphereLogout(vsphereClient, logger)
+	vms, err := vsphereListVirtualMachines(vsphereClient, cd, vsphereStoppedStates, logger)
+	if err != nil {
+		return err
+	}
+	if len(vms) == 0 {
+		logger.Info("No virtual machines were found to start")
+		return nil
+	}
+	var errs []error
+	for _, vm := range vms {
+		logger.WithField("vm", vm.Name).Info("Powering on virtual machine")
+		if err := vsphereClient.PowerOnVirtualMachine(vm); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// MachinesRunning will return true if the machines associated with the given
+// ClusterDeployment are in a running state.
+func (a *vsphereActuator) MachinesRunning(cd *hivev1.ClusterDeployment, hiveClient client.Client, logger log.FieldLogger) (bool, error) {
+	logger = logger.WithField("cloud", "vSphere")
+	vsphereClient, err := a.getVSphereClientFn(cd, hiveClient, logger)
+	if err != nil {
+		return false, err
+	}
+	defer vsphereLogout(vsphereClient, logger)
+	vms, err := vsphereListVirtualMachines(vsphereClient, cd, vsphereStoppedStates, logger)
+	if err != nil {
+		return false, err
+	}
+	return len(vms) == 0, nil
+}
+
+// MachinesStopped will return true if the machines associated with the given
+// ClusterDeployment are in a stopped state.
+func (a *vsphereActuator) MachinesStopped(cd *hivev1.ClusterDeployment, hiveClient client.Client, logger log.FieldLogger) (bool, error) {
+	logger = logger.WithField("cloud", "vSphere")
+	vsphereClient, err := a.getVSphereClientFn(cd, hiveClient, logger)
+	if err != nil {
+		return false, err
+	}
+	defer vsphereLogout(vsphereClient, logger)
+	vms, err := vsphereListVirtualMachines(vsphereClient, cd, vsphereRunningStates, logger)
+	if err != nil {
+		return false, err
+	}
+	return len(vms) == 0, nil
+}
+
+func getVSphereClient(cd *hivev1.ClusterDeployment, c client.Client, logger log.FieldLogger) (vsphereclient.Client, error) {
+	if cd.Spec.Platform.VSphere == nil {
+		return nil, errors.New("vSphere platform is not set in ClusterDeployment")
+	}
+	credsSecret := &corev1.Secret{}
+	err := c.Get(context.TODO(), client.ObjectKey{Name: cd.Spec.Platform.VSphere.CredentialsSecretRef.Name, Namespace: cd.Namespace}, credsSecret)
+	if err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "Failed to fetch vSphere credentials secret")
+		return nil, errors.Wrap(err, "failed to fetch vSphere credentials secret")
+	}
+	var certificatesSecret *corev1.Secret
+	if name := cd.Spec.Platform.VSphere.CertificatesSecretRef.Name; name != "" {
+		certificatesSecret = &corev1.Secret{}
+		err := c.Get(context.TODO(), client.ObjectKey{Name: name, Namespace: cd.Namespace}, certificatesSecret)
+		if err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "Failed to fetch vSphere certificates secret")
+			return nil, errors.Wrap(err, "failed to fetch vSphere certificates secret")
+		}
+	}
+	return vsphereclient.NewClientFromSecrets(cd.Spec.Platform.VSphere.VCenter, credsSecret, certificatesSecret)
+}
+
+func vsphereLogout(vsphereClient vsphereclient.Client, logger log.FieldLogger) {
+	if err := vsphereClient.Logout(); err != nil {
+		logger.WithError(err).Warn("Failed to log out of vSphere")
+	}
+}
+
+func vsphereListVirtualMachines(vsphereClient vsphereclient.Client, cd *hivev1.ClusterDeployment, states sets.String, logger log.FieldLogger) ([]vsphereclient.VirtualMachine, error) {
+	logger.Debug("listing virtual machines")
+	// The installer attaches a tag named after the infra ID, in a category of its own, to the virtual machines of a
+	// cluster.
+	infraID := cd.Spec.ClusterMetadata.InfraID
+	vms, err := vsphereClient.ListVirtualMachines(fmt.Sprintf("openshift-%s", infraID), infraID)
+	if err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "Failed to list virtual machines")
+		return nil, err
+	}
+	var result []vsphereclient.VirtualMachine
+	for _, vm := range vms {
+		if states.Has(string(vm.PowerState)) {
+			result = append(result, vm)
+		}
+	}
+	logger.WithField("count", len(result)).WithField("states", states.List()).Debug("found virtual machines")
+	return result, nil
+}
@@ -0,0 +1,257 @@
+package kubeadmin
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdv1 "k8s.io/client-go/tools/clientcmd/api/v1"
+	certutil "k8s.io/client-go/util/cert"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	apihelpers "github.com/openshift/hive/apis/helpers"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	// adminKubeconfigCANamespace and adminKubeconfigCAName identify the config map in the cluster holding the CAs
+	// trusted for the client certificate of the admin kubeconfig, under the adminKubeconfigCAKey key.
+	adminKubeconfigCANamespace = "openshift-config"
+	adminKubeconfigCAName      = "admin-kubeconfig-client-ca"
+	adminKubeconfigCAKey       = "ca-bundle.crt"
+
+	// pendingKubeconfigCAKey is the key of the secret of a pending admin kubeconfig holding its CA.
+	pendingKubeconfigCAKey = "ca.crt"
+
+	// credentialsRotationPollInterval is how often the cluster is checked for the new CA of the admin kubeconfig to
+	// be rolled out by the kube-apiserver.
+	credentialsRotationPollInterval = time.Minute
+
+	// adminKubeconfigValidity is how long the certificates of rotated admin kubeconfigs are valid, like those created by
+	// the installer.
+	adminKubeconfigValidity = 10 * 365 * 24 * time.Hour
+)
+
+func isRotatingCredentials(cd *hivev1.ClusterDeployment) bool {
+	return cd.Annotations[constants.ClusterCredentialsRotatingAnnotation] == "true"
+}
+
+// setCredentialsRotated removes the annotation marking the rotation of the admin credentials of a cluster released to
+// its pool.
+func (r *ReconcileKubeadmin) setCredentialsRotated(cd *hivev1.ClusterDeployment, logger log.FieldLogger) error {
+	logger.Info("admin credentials rotated for released cluster")
+	delete(cd.Annotations, constants.ClusterCredentialsRotatingAnnotation)
+	if err := r.Update(context.Background(), cd); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not record admin credentials rotation")
+		return err
+	}
+	return nil
+}
+
+// rotateAdminKubeconfig replaces the admin kubeconfig of the cluster with one whose client certificate is signed by a
+// new CA, without losing access to the cluster along the way:
+// 1. The new kubeconfig is saved in a pending secret, and its CA is added to the CAs trusted by the cluster.
+// 2. Once the kube-apiserver has rolled out the new CA and accepts the new kubeconfig, it replaces the admin kubeconfig.
+// 3. All the other CAs are removed from the CAs trusted by the cluster, and the pending secret is deleted.
+// It returns whether the rotation is done.
+func (r *ReconcileKubeadmin) rotateAdminKubeconfig(cd *hivev1.ClusterDeployment, remoteClient client.Client, logger log.FieldLogger) (bool, error) {
+	if cd.Spec.ClusterMetadata == nil || cd.Spec.ClusterMetadata.AdminKubeconfigSecretRef.Name == "" {
+		return false, errors.New("cluster deployment has no admin kubeconfig secret")
+	}
+	adminKubeconfigSecret := &corev1.Secret{}
+	if err := r.Get(context.Background(), client.ObjectKey{Namespace: cd.Namespace, Name: cd.Spec.ClusterMetadata.AdminKubeconfigSecretRef.Name}, adminKubeconfigSecret); err != nil {
+		return false, errors.Wrap(err, "could not get admin kubeconfig secret")
+	}
+
+	pendingSecret := &corev1.Secret{}
+	switch err := r.Get(context.Background(), client.ObjectKey{Namespace: cd.Namespace, Name: pendingKubeconfigSecretName(cd)}, pendingSecret); {
+	case apierrors.IsNotFound(err):
+		pendingSecret, err = newPendingKubeconfigSecret(cd, adminKubeconfigSecret)
+		if err != nil {
+			return false, errors.Wrap(err, "could not generate admin kubeconfig")
+		}
+		logger.Info("creating pending admin kubeconfig")
+		if err := r.Create(context.Background(), pendingSecret); err != nil {
+			return false, errors.Wrap(err, "could not create pending admin kubeconfig secret")
+		}
+	case err != nil:
+		return false, errors.Wrap(err, "could not get pending admin kubeconfig secret")
+	}
+	newCA := pendingSecret.Data[pendingKubeconfigCAKey]
+
+	caConfigMap := &corev1.ConfigMap{}
+	if err := remoteClient.Get(context.Background(), client.ObjectKey{Namespace: adminKubeconfigCANamespace, Name: adminKubeconfigCAName}, caConfigMap); err != nil {
+		return false, errors.Wrap(err, "could not get admin kubeconfig CA config map")
+	}
+	if !bytes.Contains([]byte(caConfigMap.Data[adminKubeconfigCAKey]), newCA) {
+		logger.Info("adding new admin kubeconfig CA to the cluster")
+		if caConfigMap.Data == nil {
+			caConfigMap.Data = map[string]string{}
+		}
+		caConfigMap.Data[adminKubeconfigCAKey] = string(newCA) + caConfigMap.Data[adminKubeconfigCAKey]
+		if err := remoteClient.Update(context.Background(), caConfigMap); err != nil {
+			return false, errors.Wrap(err, "could not add new CA to admin kubeconfig CA config map")
+		}
+	}
+
+	newRemoteClient, err := r.kubeconfigClientBuilder(pendingSecret.Data[constants.KubeconfigSecretKey])
+	if err != nil {
+		return false, errors.Wrap(err, "could not build client for the pending admin kubeconfig")
+	}
+	if err := newRemoteClient.Get(context.Background(), client.ObjectKey{Namespace: adminKubeconfigCANamespace, Name: adminKubeconfigCAName}, caConfigMap); err != nil {
+		logger.WithError(err).Debug("waiting for the new admin kubeconfig CA to be rolled out")
+		return false, nil
+	}
+
+	if !bytes.Equal(adminKubeconfigSecret.Data[constants.KubeconfigSecretKey], pendingSecret.Data[constants.KubeconfigSecretKey]) {
+		logger.Info("replacing admin kubeconfig")
+		for _, key := range []string{constants.KubeconfigSecretKey, constants.RawKubeconfigSecretKey} {
+			if data, ok := pendingSecret.Data[key]; ok {
+				adminKubeconfigSecret.Data[key] = data
+			}
+		}
+		if err := r.Update(context.Background(), adminKubeconfigSecret); err != nil {
+			return false, errors.Wrap(err, "could not update admin kubeconfig secret")
+		}
+	}
+
+	if caConfigMap.Data[adminKubeconfigCAKey] != string(newCA) {
+		logger.Info("removing previous admin kubeconfig CAs from the cluster")
+		caConfigMap.Data[adminKubeconfigCAKey] = string(newCA)
+		if err := newRemoteClient.Update(context.Background(), caConfigMap); err != nil {
+			return false, errors.Wrap(err, "could not remove previous CAs from admin kubeconfig CA config map")
+		}
+	}
+
+	if err := r.Delete(context.Background(), pendingSecret); err != nil && !apierrors.IsNotFound(err) {
+		return false, errors.Wrap(err, "could not delete pending admin kubeconfig secret")
+	}
+	return true, nil
+}
+
+// remoteClientForKubeconfig builds a client for the cluster of the kubeconfig.
+func remoteClientForKubeconfig(kubeconfig []byte) (client.Client, error) {
+	config, err := clientcmd.RESTConfigFromKubeConfig(kubeconfig)
+	if err != nil {
+		return nil, err
+	}
+	return client.New(config, client.Options{})
+}
+
+func pendingKubeconfigSecretName(cd *hivev1.ClusterDeployment) string {
+	return apihelpers.GetResourceName(cd.Name, "admin-kubeconfig-rotation")
+}
+
+// newPendingKubeconfigSecret returns the secret of a copy of the admin kubeconfig whose client certificate is signed by
+// a new CA.
+func newPendingKubeconfigSecret(cd *hivev1.ClusterDeployment, adminKubeconfigSecret *corev1.Secret) (*corev1.Secret, error) {
+	caPEM, certPEM, keyPEM, err := generateAdminClientCertificate()
+	if err != nil {
+		return nil, err
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: cd.Namespace,
+			Name:      pendingKubeconfigSecretName(cd),
+			Labels: map[string]string{
+				constants.ClusterDeploymentNameLabel: cd.Name,
+			},
+			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(cd, hivev1.SchemeGroupVersion.WithKind("ClusterDeployment"))},
+		},
+		Data: map[string][]byte{pendingKubeconfigCAKey: caPEM},
+	}
+	for _, key := range []string{constants.KubeconfigSecretKey, constants.RawKubeconfigSecretKey} {
+		data, ok := adminKubeconfigSecret.Data[key]
+		if !ok {
+			continue
+		}
+		if secret.Data[key], err = replaceClientCertificate(data, certPEM, keyPEM); err != nil {
+			return nil, errors.Wrapf(err, "could not replace client certificate of %s", key)
+		}
+	}
+	if _, ok := secret.Data[constants.KubeconfigSecretKey]; !ok {
+		return nil, fmt.Errorf("admin kubeconfig secret does not contain %q data", constants.KubeconfigSecretKey)
+	}
+	return secret, nil
+}
+
+// replaceClientCertificate replaces the client certificates of all the users of the kubeconfig.
+func replaceClientCertificate(data, certPEM, keyPEM []byte) ([]byte, error) {
+	cfg := &clientcmdv1.Config{}
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, err
+	}
+	replaced := false
+	for i := range cfg.AuthInfos {
+		authInfo := &cfg.AuthInfos[i].AuthInfo
+		if len(authInfo.ClientCertificateData) == 0 {
+			continue
+		}
+		authInfo.ClientCertificateData = certPEM
+		authInfo.ClientKeyData = keyPEM
+		replaced = true
+	}
+	if !replaced {
+		return nil, errors.New("kubeconfig has no client certificate")
+	}
+	return yaml.Marshal(cfg)
+}
+
+// generateAdminClientCertificate generates a CA and a client certificate of the system:admin user signed by it, like
+// those of the admin kubeconfig created by the installer.
+func generateAdminClientCertificate() (caPEM, certPEM, keyPEM []byte, err error) {
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	caCert, err := certutil.NewSelfSignedCACert(certutil.Config{CommonName: "admin-kubeconfig-signer", Organization: []string{"openshift"}}, caKey)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: "system:admin", Organization: []string{"system:masters"}},
+		NotBefore:    now.Add(-time.Minute),
+		NotAfter:     now.Add(adminKubeconfigValidity),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, caCert, key.Public(), caKey)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	caPEM = pem.EncodeToMemory(&pem.Block{Type: certutil.CertificateBlockType, Bytes: caCert.Raw})
+	certPEM = pem.EncodeToMemory(&pem.Block{Type: certutil.CertificateBlockType, Bytes: certDER})
+	keyPEM = pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	return caPEM, certPEM, keyPEM, nil
+}
@@ -0,0 +1,136 @@
+package kubeadmin
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/remoteclient"
+	remoteclientmock "github.com/openshift/hive/pkg/remoteclient/mock"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+	testgeneric "github.com/openshift/hive/pkg/test/generic"
+	testsecret "github.com/openshift/hive/pkg/test/secret"
+)
+
+const (
+	testAdminKubeconfigSecretName = "test-admin-kubeconfig"
+	testOldCA                     = "-----BEGIN CERTIFICATE-----\nold\n-----END CERTIFICATE-----\n"
+	testAdminKubeconfig           = `apiVersion: v1
+kind: Config
+clusters:
+- name: cluster
+  cluster:
+    server: https://api.test-cluster.example.com:6443
+contexts:
+- name: admin
+  context:
+    cluster: cluster
+    user: admin
+current-context: admin
+users:
+- name: admin
+  user:
+    client-certificate-data: b2xkLWNlcnQ=
+    client-key-data: b2xkLWtleQ==
+`
+)
+
+func TestRotateAdminCredentials(t *testing.T) {
+	scheme := runtime.NewScheme()
+	hivev1.AddToScheme(scheme)
+	corev1.AddToScheme(scheme)
+
+	cd := testcd.FullBuilder(testNamespace, testName, scheme).GenericOptions(
+		testgeneric.WithAnnotation(constants.ClusterCredentialsRotatingAnnotation, "true"),
+	).Build(
+		testcd.Installed(),
+		func(cd *hivev1.ClusterDeployment) {
+			cd.Spec.ClusterMetadata = &hivev1.ClusterMetadata{
+				AdminKubeconfigSecretRef: corev1.LocalObjectReference{Name: testAdminKubeconfigSecretName},
+			}
+		},
+		testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+			Type:   hivev1.UnreachableCondition,
+			Status: corev1.ConditionFalse,
+		}),
+	)
+	adminKubeconfigSecret := testsecret.FullBuilder(testNamespace, testAdminKubeconfigSecretName, scheme).Build(
+		testsecret.WithDataKeyValue(constants.KubeconfigSecretKey, []byte(testAdminKubeconfig)),
+		testsecret.WithDataKeyValue(constants.RawKubeconfigSecretKey, []byte(testAdminKubeconfig)),
+	)
+	caConfigMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: adminKubeconfigCANamespace, Name: adminKubeconfigCAName},
+		Data:       map[string]string{adminKubeconfigCAKey: testOldCA},
+	}
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	c := fake.NewFakeClientWithScheme(scheme, cd, adminKubeconfigSecret)
+	remoteClient := fake.NewFakeClientWithScheme(scheme, caConfigMap)
+	mockRemoteClientBuilder := remoteclientmock.NewMockBuilder(mockCtrl)
+	mockRemoteClientBuilder.EXPECT().Build().Return(remoteClient, nil).Times(2)
+	newCARolledOut := false
+	var builtKubeconfig []byte
+	r := &ReconcileKubeadmin{
+		Client: c,
+		remoteClusterAPIClientBuilder: func(*hivev1.ClusterDeployment) remoteclient.Builder {
+			return mockRemoteClientBuilder
+		},
+		kubeconfigClientBuilder: func(kubeconfig []byte) (client.Client, error) {
+			builtKubeconfig = kubeconfig
+			if !newCARolledOut {
+				// The kube-apiserver does not accept the new client certificate yet.
+				return fake.NewFakeClientWithScheme(scheme), nil
+			}
+			return remoteClient, nil
+		},
+	}
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: testName}}
+	pendingKey := client.ObjectKey{Namespace: testNamespace, Name: pendingKubeconfigSecretName(cd)}
+
+	// The new CA is trusted by the cluster, and the admin kubeconfig is kept until the new kubeconfig is accepted.
+	result, err := r.Reconcile(request)
+	require.NoError(t, err, "unexpected error from Reconcile")
+	assert.Equal(t, credentialsRotationPollInterval, result.RequeueAfter, "unexpected requeue")
+	pendingSecret := &corev1.Secret{}
+	require.NoError(t, c.Get(context.Background(), pendingKey, pendingSecret), "expected pending admin kubeconfig secret")
+	newCA := string(pendingSecret.Data[pendingKubeconfigCAKey])
+	require.NoError(t, remoteClient.Get(context.Background(), client.ObjectKey{Namespace: adminKubeconfigCANamespace, Name: adminKubeconfigCAName}, caConfigMap))
+	assert.Equal(t, newCA+testOldCA, caConfigMap.Data[adminKubeconfigCAKey], "expected new CA to be added")
+	secret := &corev1.Secret{}
+	require.NoError(t, c.Get(context.Background(), client.ObjectKey{Namespace: testNamespace, Name: testAdminKubeconfigSecretName}, secret))
+	assert.Equal(t, testAdminKubeconfig, string(secret.Data[constants.KubeconfigSecretKey]), "unexpected change to admin kubeconfig")
+	newKubeconfig, err := clientcmd.Load(builtKubeconfig)
+	require.NoError(t, err, "could not load pending admin kubeconfig")
+	assert.NotEqual(t, "old-cert", string(newKubeconfig.AuthInfos["admin"].ClientCertificateData), "expected new client certificate")
+	assert.Equal(t, "https://api.test-cluster.example.com:6443", newKubeconfig.Clusters["cluster"].Server, "unexpected server")
+
+	// Once the new kubeconfig is accepted, it replaces the admin kubeconfig and the previous CA is no longer trusted.
+	newCARolledOut = true
+	result, err = r.Reconcile(request)
+	require.NoError(t, err, "unexpected error from Reconcile")
+	assert.Zero(t, result.RequeueAfter, "unexpected requeue")
+	require.NoError(t, c.Get(context.Background(), client.ObjectKey{Namespace: testNamespace, Name: testAdminKubeconfigSecretName}, secret))
+	assert.Equal(t, builtKubeconfig, secret.Data[constants.KubeconfigSecretKey], "expected admin kubeconfig to be replaced")
+	assert.Equal(t, builtKubeconfig, secret.Data[constants.RawKubeconfigSecretKey], "expected raw admin kubeconfig to be replaced")
+	require.NoError(t, remoteClient.Get(context.Background(), client.ObjectKey{Namespace: adminKubeconfigCANamespace, Name: adminKubeconfigCAName}, caConfigMap))
+	assert.Equal(t, newCA, caConfigMap.Data[adminKubeconfigCAKey], "expected previous CA to be removed")
+	assert.True(t, apierrors.IsNotFound(c.Get(context.Background(), pendingKey, &corev1.Secret{})), "expected pending admin kubeconfig secret to be deleted")
+	actual := &hivev1.ClusterDeployment{}
+	require.NoError(t, c.Get(context.Background(), client.ObjectKey{Namespace: testNamespace, Name: testName}, actual))
+	assert.NotContains(t, actual.Annotations, constants.ClusterCredentialsRotatingAnnotation, "expected credentials rotation to be recorded")
+}
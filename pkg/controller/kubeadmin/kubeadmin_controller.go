@@ -0,0 +1,393 @@
+package kubeadmin
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/crypto/bcrypt"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/remoteclient"
+)
+
+const (
+	ControllerName = hivev1.KubeadminControllerName
+
+	// identityPollInterval is how often the cluster is checked for a user having logged in through the identity
+	// provider after which the kubeadmin user is removed.
+	identityPollInterval = 5 * time.Minute
+
+	// kubeadminSecretNamespace and kubeadminSecretName identify the secret in the cluster holding the bcrypt hash of
+	// the password of the kubeadmin user, under the kubeadminSecretKey key.
+	kubeadminSecretNamespace = "kube-system"
+	kubeadminSecretName      = "kubeadmin"
+	kubeadminSecretKey       = "kubeadmin"
+
+	// kubeadminUserName is the name of the user that logs in with the kubeadmin password.
+	kubeadminUserName = "kube:admin"
+
+	removedReason            = "Removed"
+	rotatedReason            = "Rotated"
+	rotationFailedReason     = "RotationFailed"
+	remoteClusterUnreachable = "RemoteClusterUnreachable"
+
+	// passwordChars are the characters of generated passwords, excluding those easily mistaken for one another.
+	passwordChars = "23456789abcdefghijkmnopqrstuvwxyzABCDEFGHJKLMNPQRSTUVWXYZ"
+)
+
+var (
+	identityListGVK         = schema.GroupVersionKind{Group: "user.openshift.io", Version: "v1", Kind: "IdentityList"}
+	oauthAccessTokenListGVK = schema.GroupVersionKind{Group: "oauth.openshift.io", Version: "v1", Kind: "OAuthAccessTokenList"}
+)
+
+// Add creates a new Kubeadmin Controller and adds it to the Manager with default RBAC. The Manager will set fields on
+// the Controller and Start it when the Manager is Started.
+func Add(mgr manager.Manager) error {
+	logger := log.WithField("controller", ControllerName)
+	concurrentReconciles, clientRateLimiter, queueRateLimiter, err := controllerutils.GetControllerConfig(mgr.GetClient(), ControllerName)
+	if err != nil {
+		logger.WithError(err).Error("could not get controller configurations")
+		return err
+	}
+	return AddToManager(mgr, NewReconciler(mgr, clientRateLimiter), concurrentReconciles, queueRateLimiter)
+}
+
+// NewReconciler returns a new reconcile.Reconciler
+func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) reconcile.Reconciler {
+	r := &ReconcileKubeadmin{
+		Client: controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
+	}
+	r.remoteClusterAPIClientBuilder = func(cd *hivev1.ClusterDeployment) remoteclient.Builder {
+		return remoteclient.NewAdminBuilder(r.Client, cd, ControllerName)
+	}
+	r.kubeconfigClientBuilder = remoteClientForKubeconfig
+	return r
+}
+
+// AddToManager adds a new Controller to mgr with r as the reconcile.Reconciler
+func AddToManager(mgr manager.Manager, r reconcile.Reconciler, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
+	c, err := controller.New(
+		fmt.Sprintf("%s-controller", ControllerName),
+		mgr,
+		controller.Options{
+			Reconciler:              r,
+			MaxConcurrentReconciles: concurrentReconciles,
+			RateLimiter:             rateLimiter,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to ClusterDeployments
+	if err := c.Watch(&source.Kind{Type: &hivev1.ClusterDeployment{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var _ reconcile.Reconciler = &ReconcileKubeadmin{}
+
+// ReconcileKubeadmin reconciles the kubeadmin user of a ClusterDeployment, removing it from the cluster once an
+// alternate identity provider is confirmed to work, or rotating its password. It also rotates the admin credentials
+// of the clusters released to their pool.
+type ReconcileKubeadmin struct {
+	client.Client
+
+	// remoteClusterAPIClientBuilder is a function pointer to the function that gets a builder for building a client
+	// for the remote cluster's API server
+	remoteClusterAPIClientBuilder func(cd *hivev1.ClusterDeployment) remoteclient.Builder
+
+	// kubeconfigClientBuilder builds a client for the remote cluster from a kubeconfig which is not the admin
+	// kubeconfig of the ClusterDeployment yet.
+	kubeconfigClientBuilder func(kubeconfig []byte) (client.Client, error)
+}
+
+// Reconcile manages the kubeadmin user of an installed ClusterDeployment as configured in spec.kubeadmin.
+func (r *ReconcileKubeadmin) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	logger := controllerutils.BuildControllerLogger(ControllerName, "clusterDeployment", request.NamespacedName)
+	logger.Debug("reconciling cluster deployment")
+	recobsrv := hivemetrics.NewReconcileObserver(ControllerName, logger)
+	defer recobsrv.ObserveControllerReconcileTime()
+
+	cd := &hivev1.ClusterDeployment{}
+	if err := r.Get(context.Background(), request.NamespacedName, cd); err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Debug("cluster deployment not found")
+			return reconcile.Result{}, nil
+		}
+		logger.WithError(err).Error("error getting cluster deployment")
+		return reconcile.Result{}, err
+	}
+	if cd.DeletionTimestamp != nil || !cd.Spec.Installed {
+		return reconcile.Result{}, nil
+	}
+
+	var removeAfter, rotation string
+	if cd.Spec.Kubeadmin != nil {
+		removeAfter = cd.Spec.Kubeadmin.RemoveAfterIdentityProvider
+		rotation = cd.Spec.Kubeadmin.PasswordRotation
+	}
+	if cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.KubeadminRemovedCondition); cond != nil && cond.Status == corev1.ConditionTrue {
+		logger.Debug("kubeadmin user has been removed")
+		removeAfter, rotation = "", ""
+	}
+	rotationPending := rotation != "" && rotation != cd.Status.KubeadminPasswordRotation
+	rotatingCredentials := isRotatingCredentials(cd)
+	if removeAfter == "" && !rotationPending && !rotatingCredentials {
+		return reconcile.Result{}, nil
+	}
+
+	if unreachable, _ := remoteclient.Unreachable(cd); unreachable {
+		logger.Debug("waiting for cluster to be reachable")
+		if rotationPending {
+			if err := r.setRotationFailedCondition(cd, remoteClusterUnreachable, "Waiting for the cluster to be reachable", logger); err != nil {
+				return reconcile.Result{}, err
+			}
+		}
+		return reconcile.Result{RequeueAfter: identityPollInterval}, nil
+	}
+	remoteClient, err := r.remoteClusterAPIClientBuilder(cd).Build()
+	if err != nil {
+		logger.WithError(err).Error("error building remote cluster client")
+		return reconcile.Result{}, err
+	}
+
+	result := reconcile.Result{}
+	if removeAfter != "" {
+		confirmed, err := identityProviderConfirmed(remoteClient, removeAfter)
+		if err != nil {
+			logger.WithError(err).Error("error checking for identities of the identity provider")
+			return reconcile.Result{}, err
+		}
+		if confirmed {
+			return reconcile.Result{}, r.removeKubeadmin(cd, remoteClient, logger)
+		}
+		logger.WithField("identityProvider", removeAfter).Debug("waiting for a user to log in through the identity provider")
+		result.RequeueAfter = identityPollInterval
+	}
+
+	if rotationPending {
+		if err := r.rotatePassword(cd, remoteClient); err != nil {
+			logger.WithError(err).Error("error rotating kubeadmin password")
+			if condErr := r.setRotationFailedCondition(cd, rotationFailedReason, err.Error(), logger); condErr != nil {
+				return reconcile.Result{}, condErr
+			}
+			return reconcile.Result{}, err
+		}
+		logger.Info("kubeadmin password rotated")
+		if err := r.setRotated(cd, logger); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	if rotatingCredentials {
+		done, err := r.rotateAdminKubeconfig(cd, remoteClient, logger)
+		if err != nil {
+			logger.WithError(err).Error("error rotating admin kubeconfig")
+			return reconcile.Result{}, err
+		}
+		if !done {
+			return reconcile.Result{RequeueAfter: credentialsRotationPollInterval}, nil
+		}
+		if err := r.setCredentialsRotated(cd, logger); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+	return result, nil
+}
+
+// identityProviderConfirmed returns whether a user has logged in to the cluster through the identity provider, which
+// is the case once the cluster has an identity of that provider.
+func identityProviderConfirmed(c client.Client, provider string) (bool, error) {
+	identities := &unstructured.UnstructuredList{}
+	identities.SetGroupVersionKind(identityListGVK)
+	if err := c.List(context.Background(), identities); err != nil {
+		return false, err
+	}
+	for _, identity := range identities.Items {
+		if name, _, _ := unstructured.NestedString(identity.Object, "providerName"); name == provider {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *ReconcileKubeadmin) removeKubeadmin(cd *hivev1.ClusterDeployment, remoteClient client.Client, logger log.FieldLogger) error {
+	secret := &corev1.Secret{}
+	secret.Namespace = kubeadminSecretNamespace
+	secret.Name = kubeadminSecretName
+	if err := remoteClient.Delete(context.Background(), secret); err != nil && !apierrors.IsNotFound(err) {
+		logger.WithError(err).Error("error deleting kubeadmin secret")
+		return err
+	}
+	logger.Info("kubeadmin user removed")
+	conds, changed := controllerutils.SetClusterDeploymentConditionWithChangeCheck(
+		cd.Status.Conditions,
+		hivev1.KubeadminRemovedCondition,
+		corev1.ConditionTrue,
+		removedReason,
+		fmt.Sprintf("Kubeadmin user removed after a user logged in through identity provider %s", cd.Spec.Kubeadmin.RemoveAfterIdentityProvider),
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	if !changed {
+		return nil
+	}
+	cd.Status.Conditions = conds
+	if err := r.Status().Update(context.Background(), cd); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update KubeadminRemoved condition")
+		return err
+	}
+	return nil
+}
+
+// rotatePassword generates a new password for the kubeadmin user and sets it in the cluster, revoking the sessions
+// of the kubeadmin user, before storing it in the admin password secret of the ClusterDeployment. The password
+// previously in the secret must not keep working once it has been replaced, and a failure to store the new password is
+// retried with another password set in the cluster, which the admin kubeconfig does not depend on.
+func (r *ReconcileKubeadmin) rotatePassword(cd *hivev1.ClusterDeployment, remoteClient client.Client) error {
+	if cd.Spec.ClusterMetadata == nil || cd.Spec.ClusterMetadata.AdminPasswordSecretRef.Name == "" {
+		return fmt.Errorf("cluster deployment has no admin password secret")
+	}
+	adminPasswordSecret := &corev1.Secret{}
+	if err := r.Get(context.Background(), client.ObjectKey{Namespace: cd.Namespace, Name: cd.Spec.ClusterMetadata.AdminPasswordSecretRef.Name}, adminPasswordSecret); err != nil {
+		return fmt.Errorf("could not get admin password secret: %v", err)
+	}
+
+	// The kubeadmin user is only valid while its secret is the one created when the cluster was installed, so the
+	// secret is updated rather than recreated.
+	kubeadminSecret := &corev1.Secret{}
+	if err := remoteClient.Get(context.Background(), client.ObjectKey{Namespace: kubeadminSecretNamespace, Name: kubeadminSecretName}, kubeadminSecret); err != nil {
+		if apierrors.IsNotFound(err) {
+			return fmt.Errorf("kubeadmin user does not exist in the cluster")
+		}
+		return fmt.Errorf("could not get kubeadmin secret: %v", err)
+	}
+
+	password, err := generatePassword()
+	if err != nil {
+		return fmt.Errorf("could not generate password: %v", err)
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return fmt.Errorf("could not hash password: %v", err)
+	}
+
+	if kubeadminSecret.Data == nil {
+		kubeadminSecret.Data = map[string][]byte{}
+	}
+	kubeadminSecret.Data[kubeadminSecretKey] = hash
+	if err := remoteClient.Update(context.Background(), kubeadminSecret); err != nil {
+		return fmt.Errorf("could not update kubeadmin secret: %v", err)
+	}
+	if err := revokeKubeadminSessions(remoteClient); err != nil {
+		return fmt.Errorf("could not revoke kubeadmin sessions: %v", err)
+	}
+
+	if adminPasswordSecret.Data == nil {
+		adminPasswordSecret.Data = map[string][]byte{}
+	}
+	adminPasswordSecret.Data[constants.UsernameSecretKey] = []byte(kubeadminSecretName)
+	adminPasswordSecret.Data[constants.PasswordSecretKey] = []byte(password)
+	if err := r.Update(context.Background(), adminPasswordSecret); err != nil {
+		return fmt.Errorf("could not update admin password secret: %v", err)
+	}
+	return nil
+}
+
+// revokeKubeadminSessions deletes the OAuth access tokens of the kubeadmin user, which stay valid after its password
+// changes.
+func revokeKubeadminSessions(c client.Client) error {
+	tokens := &unstructured.UnstructuredList{}
+	tokens.SetGroupVersionKind(oauthAccessTokenListGVK)
+	if err := c.List(context.Background(), tokens); err != nil {
+		return err
+	}
+	for i := range tokens.Items {
+		token := &tokens.Items[i]
+		if userName, _, _ := unstructured.NestedString(token.Object, "userName"); userName != kubeadminUserName {
+			continue
+		}
+		if err := c.Delete(context.Background(), token); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// generatePassword generates a password in the format of the kubeadmin passwords generated by the installer: four
+// groups of five random characters separated by dashes.
+func generatePassword() (string, error) {
+	groups := make([]string, 4)
+	for i := range groups {
+		group := make([]byte, 5)
+		for j := range group {
+			n, err := rand.Int(rand.Reader, big.NewInt(int64(len(passwordChars))))
+			if err != nil {
+				return "", err
+			}
+			group[j] = passwordChars[n.Int64()]
+		}
+		groups[i] = string(group)
+	}
+	return strings.Join(groups, "-"), nil
+}
+
+func (r *ReconcileKubeadmin) setRotationFailedCondition(cd *hivev1.ClusterDeployment, reason, message string, logger log.FieldLogger) error {
+	conds, changed := controllerutils.SetClusterDeploymentConditionWithChangeCheck(
+		cd.Status.Conditions,
+		hivev1.KubeadminPasswordRotationFailedCondition,
+		corev1.ConditionTrue,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	if !changed {
+		return nil
+	}
+	cd.Status.Conditions = conds
+	if err := r.Status().Update(context.Background(), cd); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update KubeadminPasswordRotationFailed condition")
+		return err
+	}
+	return nil
+}
+
+// setRotated records the rotation of the kubeadmin password and clears the KubeadminPasswordRotationFailed condition.
+func (r *ReconcileKubeadmin) setRotated(cd *hivev1.ClusterDeployment, logger log.FieldLogger) error {
+	cd.Status.KubeadminPasswordRotation = cd.Spec.Kubeadmin.PasswordRotation
+	cd.Status.Conditions, _ = controllerutils.SetClusterDeploymentConditionWithChangeCheck(
+		cd.Status.Conditions,
+		hivev1.KubeadminPasswordRotationFailedCondition,
+		corev1.ConditionFalse,
+		rotatedReason,
+		"Kubeadmin password rotated",
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	if err := r.Status().Update(context.Background(), cd); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not record kubeadmin password rotation")
+		return err
+	}
+	return nil
+}
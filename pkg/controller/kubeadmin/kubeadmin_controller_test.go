@@ -0,0 +1,264 @@
+package kubeadmin
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/remoteclient"
+	remoteclientmock "github.com/openshift/hive/pkg/remoteclient/mock"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+	testsecret "github.com/openshift/hive/pkg/test/secret"
+)
+
+const (
+	testNamespace               = "test-namespace"
+	testName                    = "test-cluster"
+	testAdminPasswordSecretName = "test-admin-password"
+	testIdentityProvider        = "corp-sso"
+)
+
+func TestReconcileKubeadmin(t *testing.T) {
+	scheme := runtime.NewScheme()
+	hivev1.AddToScheme(scheme)
+	corev1.AddToScheme(scheme)
+	remoteScheme := runtime.NewScheme()
+	corev1.AddToScheme(remoteScheme)
+	remoteScheme.AddKnownTypeWithName(identityListGVK.GroupVersion().WithKind("Identity"), &unstructured.Unstructured{})
+	remoteScheme.AddKnownTypeWithName(identityListGVK, &unstructured.UnstructuredList{})
+	remoteScheme.AddKnownTypeWithName(oauthAccessTokenListGVK.GroupVersion().WithKind("OAuthAccessToken"), &unstructured.Unstructured{})
+	remoteScheme.AddKnownTypeWithName(oauthAccessTokenListGVK, &unstructured.UnstructuredList{})
+
+	cdBuilder := testcd.FullBuilder(testNamespace, testName, scheme).Options(
+		testcd.Installed(),
+		func(cd *hivev1.ClusterDeployment) {
+			cd.Spec.ClusterMetadata = &hivev1.ClusterMetadata{
+				AdminPasswordSecretRef: corev1.LocalObjectReference{Name: testAdminPasswordSecretName},
+			}
+		},
+		testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+			Type:   hivev1.UnreachableCondition,
+			Status: corev1.ConditionFalse,
+		}),
+	)
+	kubeadmin := func(k hivev1.KubeadminManagement) testcd.Option {
+		return func(cd *hivev1.ClusterDeployment) {
+			cd.Spec.Kubeadmin = &k
+		}
+	}
+	adminPasswordSecret := testsecret.FullBuilder(testNamespace, testAdminPasswordSecretName, scheme).Build(
+		testsecret.WithDataKeyValue(constants.UsernameSecretKey, []byte("kubeadmin")),
+		testsecret.WithDataKeyValue(constants.PasswordSecretKey, []byte("old-password")),
+	)
+	kubeadminSecret := func() *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: kubeadminSecretNamespace, Name: kubeadminSecretName},
+			Data:       map[string][]byte{kubeadminSecretKey: []byte("old-hash")},
+		}
+	}
+	identity := func(provider string) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(schema.GroupVersionKind{Group: "user.openshift.io", Version: "v1", Kind: "Identity"})
+		obj.SetName(provider + ":user")
+		unstructured.SetNestedField(obj.Object, provider, "providerName")
+		return obj
+	}
+	accessToken := func(name, userName string) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(oauthAccessTokenListGVK.GroupVersion().WithKind("OAuthAccessToken"))
+		obj.SetName(name)
+		unstructured.SetNestedField(obj.Object, userName, "userName")
+		return obj
+	}
+
+	tests := []struct {
+		name                     string
+		cd                       *hivev1.ClusterDeployment
+		remoteExisting           []runtime.Object
+		expectRemoved            bool
+		expectRotated            bool
+		expectRotationFailed     string
+		expectRequeue            bool
+		expectError              bool
+		expectNoRemoteClientUsed bool
+		expectRemainingTokens    []string
+	}{
+		{
+			name:                     "no kubeadmin management",
+			cd:                       cdBuilder.Build(),
+			expectNoRemoteClientUsed: true,
+		},
+		{
+			name:                     "not installed",
+			cd:                       testcd.FullBuilder(testNamespace, testName, scheme).Build(kubeadmin(hivev1.KubeadminManagement{PasswordRotation: "1"})),
+			expectNoRemoteClientUsed: true,
+		},
+		{
+			name: "already removed",
+			cd: cdBuilder.Build(
+				kubeadmin(hivev1.KubeadminManagement{RemoveAfterIdentityProvider: testIdentityProvider, PasswordRotation: "1"}),
+				testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+					Type:   hivev1.KubeadminRemovedCondition,
+					Status: corev1.ConditionTrue,
+				}),
+			),
+			expectRemoved:            true,
+			expectNoRemoteClientUsed: true,
+		},
+		{
+			name:           "waiting for identity provider",
+			cd:             cdBuilder.Build(kubeadmin(hivev1.KubeadminManagement{RemoveAfterIdentityProvider: testIdentityProvider})),
+			remoteExisting: []runtime.Object{kubeadminSecret(), identity("other-provider")},
+			expectRequeue:  true,
+		},
+		{
+			name:           "remove after identity provider confirmed",
+			cd:             cdBuilder.Build(kubeadmin(hivev1.KubeadminManagement{RemoveAfterIdentityProvider: testIdentityProvider})),
+			remoteExisting: []runtime.Object{kubeadminSecret(), identity(testIdentityProvider)},
+			expectRemoved:  true,
+		},
+		{
+			name:           "remove when kubeadmin secret already deleted",
+			cd:             cdBuilder.Build(kubeadmin(hivev1.KubeadminManagement{RemoveAfterIdentityProvider: testIdentityProvider})),
+			remoteExisting: []runtime.Object{identity(testIdentityProvider)},
+			expectRemoved:  true,
+		},
+		{
+			name:           "rotate password",
+			cd:             cdBuilder.Build(kubeadmin(hivev1.KubeadminManagement{PasswordRotation: "1"})),
+			remoteExisting: []runtime.Object{kubeadminSecret()},
+			expectRotated:  true,
+		},
+		{
+			name: "rotate password revokes kubeadmin sessions",
+			cd:   cdBuilder.Build(kubeadmin(hivev1.KubeadminManagement{PasswordRotation: "1"})),
+			remoteExisting: []runtime.Object{
+				kubeadminSecret(),
+				accessToken("kubeadmin-token", kubeadminUserName),
+				accessToken("other-token", "other-user"),
+			},
+			expectRotated:         true,
+			expectRemainingTokens: []string{"other-token"},
+		},
+		{
+			name:           "rotate password while waiting for identity provider",
+			cd:             cdBuilder.Build(kubeadmin(hivev1.KubeadminManagement{RemoveAfterIdentityProvider: testIdentityProvider, PasswordRotation: "1"})),
+			remoteExisting: []runtime.Object{kubeadminSecret()},
+			expectRotated:  true,
+			expectRequeue:  true,
+		},
+		{
+			name: "password already rotated",
+			cd: cdBuilder.Build(
+				kubeadmin(hivev1.KubeadminManagement{PasswordRotation: "1"}),
+				func(cd *hivev1.ClusterDeployment) { cd.Status.KubeadminPasswordRotation = "1" },
+			),
+			expectNoRemoteClientUsed: true,
+		},
+		{
+			name:                 "rotate password without kubeadmin user",
+			cd:                   cdBuilder.Build(kubeadmin(hivev1.KubeadminManagement{PasswordRotation: "1"})),
+			expectRotationFailed: rotationFailedReason,
+			expectError:          true,
+		},
+		{
+			name: "rotate password of unreachable cluster",
+			cd: cdBuilder.Build(
+				kubeadmin(hivev1.KubeadminManagement{PasswordRotation: "1"}),
+				testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+					Type:   hivev1.UnreachableCondition,
+					Status: corev1.ConditionTrue,
+				}),
+			),
+			expectRotationFailed:     remoteClusterUnreachable,
+			expectRequeue:            true,
+			expectNoRemoteClientUsed: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			c := fake.NewFakeClientWithScheme(scheme, test.cd, adminPasswordSecret.DeepCopy())
+			remoteClient := fake.NewFakeClientWithScheme(remoteScheme, test.remoteExisting...)
+			mockRemoteClientBuilder := remoteclientmock.NewMockBuilder(mockCtrl)
+			if test.expectNoRemoteClientUsed {
+				mockRemoteClientBuilder.EXPECT().Build().Times(0)
+			} else {
+				mockRemoteClientBuilder.EXPECT().Build().Return(remoteClient, nil)
+			}
+			r := &ReconcileKubeadmin{
+				Client: c,
+				remoteClusterAPIClientBuilder: func(*hivev1.ClusterDeployment) remoteclient.Builder {
+					return mockRemoteClientBuilder
+				},
+			}
+			log.SetLevel(log.DebugLevel)
+
+			result, err := r.Reconcile(reconcile.Request{NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: testName}})
+			if test.expectError {
+				assert.Error(t, err, "expected error from Reconcile")
+			} else {
+				require.NoError(t, err, "unexpected error from Reconcile")
+			}
+			assert.Equal(t, test.expectRequeue, result.RequeueAfter > 0, "unexpected requeue")
+
+			cd := &hivev1.ClusterDeployment{}
+			require.NoError(t, c.Get(context.Background(), client.ObjectKey{Namespace: testNamespace, Name: testName}, cd))
+			removed := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.KubeadminRemovedCondition)
+			assert.Equal(t, test.expectRemoved, removed != nil && removed.Status == corev1.ConditionTrue, "unexpected KubeadminRemoved condition")
+			if test.expectRemoved && !test.expectNoRemoteClientUsed {
+				err := remoteClient.Get(context.Background(), client.ObjectKey{Namespace: kubeadminSecretNamespace, Name: kubeadminSecretName}, &corev1.Secret{})
+				assert.True(t, apierrors.IsNotFound(err), "expected kubeadmin secret to be deleted")
+			}
+
+			failed := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.KubeadminPasswordRotationFailedCondition)
+			if test.expectRotationFailed != "" {
+				require.NotNil(t, failed, "expected KubeadminPasswordRotationFailed condition")
+				assert.Equal(t, corev1.ConditionTrue, failed.Status, "unexpected KubeadminPasswordRotationFailed status")
+				assert.Equal(t, test.expectRotationFailed, failed.Reason, "unexpected KubeadminPasswordRotationFailed reason")
+			} else if failed != nil {
+				assert.Equal(t, corev1.ConditionFalse, failed.Status, "unexpected KubeadminPasswordRotationFailed status")
+			}
+
+			passwordSecret := &corev1.Secret{}
+			require.NoError(t, c.Get(context.Background(), client.ObjectKey{Namespace: testNamespace, Name: testAdminPasswordSecretName}, passwordSecret))
+			password := passwordSecret.Data[constants.PasswordSecretKey]
+			if !test.expectRotated {
+				assert.Equal(t, "old-password", string(password), "unexpected change to admin password")
+				return
+			}
+			assert.Equal(t, cd.Spec.Kubeadmin.PasswordRotation, cd.Status.KubeadminPasswordRotation, "expected rotation to be recorded")
+			assert.Regexp(t, `^[a-zA-Z0-9]{5}-[a-zA-Z0-9]{5}-[a-zA-Z0-9]{5}-[a-zA-Z0-9]{5}$`, string(password), "unexpected generated password")
+			secret := &corev1.Secret{}
+			require.NoError(t, remoteClient.Get(context.Background(), client.ObjectKey{Namespace: kubeadminSecretNamespace, Name: kubeadminSecretName}, secret))
+			assert.NoError(t, bcrypt.CompareHashAndPassword(secret.Data[kubeadminSecretKey], password), "kubeadmin secret does not match the admin password")
+			tokens := &unstructured.UnstructuredList{}
+			tokens.SetGroupVersionKind(oauthAccessTokenListGVK)
+			require.NoError(t, remoteClient.List(context.Background(), tokens))
+			var remainingTokens []string
+			for _, token := range tokens.Items {
+				remainingTokens = append(remainingTokens, token.GetName())
+			}
+			assert.Equal(t, test.expectRemainingTokens, remainingTokens, "unexpected remaining OAuth access tokens")
+		})
+	}
+}
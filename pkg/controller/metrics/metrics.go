@@ -88,6 +88,28 @@ var (
 		},
 		[]string{"cluster_deployment", "namespace", "cluster_type"},
 	)
+	// MetricClusterProvisionStageDurationSeconds is a prometheus metric for the length of time a ClusterProvision
+	// spent in a stage before moving on to the next one. It is observed by the clusterprovision controller when the
+	// provision leaves the stage.
+	MetricClusterProvisionStageDurationSeconds = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "hive_cluster_provision_stage_duration_seconds",
+			Help:    "Distribution of the length of time cluster provisions spend in each stage.",
+			Buckets: []float64{60, 300, 600, 1200, 1800, 2700, 3600, 5400, 7200, 10800},
+		},
+		[]string{"cluster_type", "stage"},
+	)
+	// MetricClusterProvisionInstallPodSchedulingSeconds is a prometheus metric for the length of time between a
+	// ClusterProvision entering the initializing stage and its install pod being started on a node. This separates
+	// the time spent waiting for the pod to be scheduled from the time spent running the installer.
+	MetricClusterProvisionInstallPodSchedulingSeconds = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "hive_cluster_provision_install_pod_scheduling_seconds",
+			Help:    "Distribution of the length of time cluster provisions wait for their install pod to be scheduled.",
+			Buckets: []float64{5, 15, 30, 60, 120, 300, 600, 1800},
+		},
+		[]string{"cluster_type"},
+	)
 	// metricControllerReconcileTime tracks the length of time our reconcile loops take. controller-runtime
 	// technically tracks this for us, but due to bugs currently also includes time in the queue, which leads to
 	// extremely strange results. For now, track our own metric.
@@ -110,6 +132,15 @@ var (
 		},
 		[]string{"cluster_deployment", "namespace", "cluster_type"},
 	)
+	// metricClusterDeploymentClaimInfo tracks the claim of claimed ClusterDeployments for chargeback to the
+	// consuming teams. The value is always "1".
+	metricClusterDeploymentClaimInfo = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "hive_cluster_deployment_claim_info",
+			Help: "Claim metadata of claimed clusters",
+		},
+		[]string{"cluster_deployment", "namespace", "cluster_type", "claim_namespace", "claim_name", "claim_subjects", "ticket_id"},
+	)
 )
 
 // ReconcileOutcome is used in controller "reconcile complete" log entries, and the metricControllerReconcileTime
@@ -142,7 +173,10 @@ func init() {
 	metrics.Registry.MustRegister(metricControllerReconcileTime)
 
 	metrics.Registry.MustRegister(MetricClusterDeploymentDeprovisioningUnderwaySeconds)
+	metrics.Registry.MustRegister(MetricClusterProvisionStageDurationSeconds)
+	metrics.Registry.MustRegister(MetricClusterProvisionInstallPodSchedulingSeconds)
 	metrics.Registry.MustRegister(metricClusterDeploymentSyncsetPaused)
+	metrics.Registry.MustRegister(metricClusterDeploymentClaimInfo)
 }
 
 // Add creates a new metrics Calculator and adds it to the Manager.
@@ -195,10 +229,23 @@ func (mc *Calculator) Start(stopCh <-chan struct{}) error {
 				mcLog.WithError(err).Error("unable to calculate metrics")
 				return
 			}
+			// Claims may have moved on or changed since the last calculation.
+			metricClusterDeploymentClaimInfo.Reset()
 			for _, cd := range clusterDeployments.Items {
 				clusterType := GetClusterDeploymentType(&cd)
 				accumulator.processCluster(&cd)
 
+				if claimName := cd.Annotations[constants.ClaimNameAnnotation]; claimName != "" {
+					metricClusterDeploymentClaimInfo.WithLabelValues(
+						cd.Name,
+						cd.Namespace,
+						clusterType,
+						cd.Annotations[constants.ClaimNamespaceAnnotation],
+						claimName,
+						cd.Annotations[constants.ClaimSubjectsAnnotation],
+						cd.Annotations[constants.ClaimTicketIDAnnotation]).Set(1.0)
+				}
+
 				if cd.DeletionTimestamp != nil {
 
 					// For deprovisioning clusters we report the seconds since
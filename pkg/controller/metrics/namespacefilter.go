@@ -0,0 +1,71 @@
+package metrics
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	dto "github.com/prometheus/client_model/go"
+)
+
+const (
+	// NamespaceMetricsPath is the path under which the metrics of each namespace are served, as
+	// /metrics/namespaces/<namespace>.
+	NamespaceMetricsPath = "/metrics/namespaces/"
+
+	namespaceLabel = "namespace"
+)
+
+// NewNamespaceMetricsHandler returns a handler serving, for the namespace at the end of the path of each request, the
+// metrics of the gatherer labeled with that namespace.
+func NewNamespaceMetricsHandler(gatherer prometheus.Gatherer) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		namespace := strings.TrimPrefix(req.URL.Path, NamespaceMetricsPath)
+		if namespace == "" || strings.Contains(namespace, "/") {
+			http.NotFound(w, req)
+			return
+		}
+		promhttp.HandlerFor(
+			&namespaceGatherer{gatherer: gatherer, namespace: namespace},
+			promhttp.HandlerOpts{ErrorHandling: promhttp.HTTPErrorOnError},
+		).ServeHTTP(w, req)
+	})
+}
+
+// namespaceGatherer gathers the metrics of a gatherer labeled with a namespace, leaving out the metrics of other
+// namespaces and the metrics of no namespace.
+type namespaceGatherer struct {
+	gatherer  prometheus.Gatherer
+	namespace string
+}
+
+func (g *namespaceGatherer) Gather() ([]*dto.MetricFamily, error) {
+	families, err := g.gatherer.Gather()
+	if err != nil {
+		return nil, err
+	}
+	var filtered []*dto.MetricFamily
+	for _, family := range families {
+		var metrics []*dto.Metric
+		for _, metric := range family.Metric {
+			if hasLabel(metric, namespaceLabel, g.namespace) {
+				metrics = append(metrics, metric)
+			}
+		}
+		if len(metrics) > 0 {
+			family.Metric = metrics
+			filtered = append(filtered, family)
+		}
+	}
+	return filtered, nil
+}
+
+func hasLabel(metric *dto.Metric, name, value string) bool {
+	for _, label := range metric.Label {
+		if label.GetName() == name {
+			return label.GetValue() == value
+		}
+	}
+	return false
+}
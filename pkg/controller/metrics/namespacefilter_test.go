@@ -0,0 +1,80 @@
+package metrics
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNamespaceMetricsHandler(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	perCluster := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "hive_test_cluster_seconds",
+		Help: "Test metric labeled with the namespace of a cluster.",
+	}, []string{"cluster_deployment", "namespace"})
+	perCluster.WithLabelValues("cluster-a", "team-a").Set(1)
+	perCluster.WithLabelValues("cluster-b", "team-b").Set(2)
+	otherTeamOnly := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "hive_test_other_team_total",
+		Help: "Test metric of another namespace only.",
+	}, []string{"namespace"})
+	otherTeamOnly.WithLabelValues("team-b").Set(3)
+	global := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "hive_test_global_total",
+		Help: "Test metric without a namespace.",
+	}, []string{"cluster_type"})
+	global.WithLabelValues("managed").Set(4)
+	registry.MustRegister(perCluster, otherTeamOnly, global)
+
+	tests := []struct {
+		name           string
+		path           string
+		expectStatus   int
+		expectContains []string
+		expectExcludes []string
+	}{
+		{
+			name:           "namespace",
+			path:           "/metrics/namespaces/team-a",
+			expectStatus:   http.StatusOK,
+			expectContains: []string{`hive_test_cluster_seconds{cluster_deployment="cluster-a",namespace="team-a"} 1`},
+			expectExcludes: []string{"cluster-b", "hive_test_other_team_total", "hive_test_global_total"},
+		},
+		{
+			name:           "namespace without metrics",
+			path:           "/metrics/namespaces/team-c",
+			expectStatus:   http.StatusOK,
+			expectExcludes: []string{"hive_test_"},
+		},
+		{
+			name:         "no namespace",
+			path:         "/metrics/namespaces/",
+			expectStatus: http.StatusNotFound,
+		},
+		{
+			name:         "nested path",
+			path:         "/metrics/namespaces/team-a/extra",
+			expectStatus: http.StatusNotFound,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			NewNamespaceMetricsHandler(registry).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, test.path, nil))
+			assert.Equal(t, test.expectStatus, rec.Code, "unexpected status")
+			body, err := ioutil.ReadAll(rec.Body)
+			require.NoError(t, err)
+			for _, s := range test.expectContains {
+				assert.Contains(t, string(body), s, "expected metrics to be served")
+			}
+			for _, s := range test.expectExcludes {
+				assert.NotContains(t, string(body), s, "expected metrics to be filtered out")
+			}
+		})
+	}
+}
@@ -22,6 +22,7 @@ var (
 		hivev1.InstallLaunchErrorCondition,
 		hivev1.ProvisionFailedCondition,
 		hivev1.AuthenticationFailureClusterDeploymentCondition,
+		hivev1.CredentialsMalformedClusterDeploymentCondition,
 		hivev1.InstallImagesNotResolvedCondition,
 	}
 )
@@ -61,7 +62,7 @@ func (cc provisioningUnderwayCollector) Collect(ch chan<- prometheus.Metric) {
 		}
 
 		// Add install failure details for stuck provision
-		condition, reason := getKnownConditions(cd.Status.Conditions)
+		condition, reason, category := getKnownConditions(cd.Status.Conditions)
 
 		platform := cd.Labels[hivev1.HiveClusterPlatformLabel]
 		imageSet := "none"
@@ -84,6 +85,7 @@ func (cc provisioningUnderwayCollector) Collect(ch chan<- prometheus.Metric) {
 			GetClusterDeploymentType(&cd),
 			condition,
 			reason,
+			category,
 			platform,
 			imageSet,
 		)
@@ -100,7 +102,7 @@ var (
 	metricClusterDeploymentProvisionUnderwaySecondsDesc = prometheus.NewDesc(
 		"hive_cluster_deployment_provision_underway_seconds",
 		"Length of time a cluster has been provisioning.",
-		[]string{"cluster_deployment", "namespace", "cluster_type", "condition", "reason", "platform", "image_set"},
+		[]string{"cluster_deployment", "namespace", "cluster_type", "condition", "reason", "category", "platform", "image_set"},
 		nil,
 	)
 )
@@ -148,7 +150,7 @@ func (cc provisioningUnderwayInstallRestartsCollector) Collect(ch chan<- prometh
 		}
 
 		// Add install failure details for stuck provision
-		condition, reason := getKnownConditions(cd.Status.Conditions)
+		condition, reason, category := getKnownConditions(cd.Status.Conditions)
 
 		platform := cd.Labels[hivev1.HiveClusterPlatformLabel]
 		imageSet := "none"
@@ -174,6 +176,7 @@ func (cc provisioningUnderwayInstallRestartsCollector) Collect(ch chan<- prometh
 			GetClusterDeploymentType(&cd),
 			condition,
 			reason,
+			category,
 			platform,
 			imageSet,
 		)
@@ -190,7 +193,7 @@ var (
 	provisioningUnderwayInstallRestartsCollectorDesc = prometheus.NewDesc(
 		"hive_cluster_deployment_provision_underway_install_restarts",
 		"Number install restarts for a cluster that has been provisioning.",
-		[]string{"cluster_deployment", "namespace", "cluster_type", "condition", "reason", "platform", "image_set"},
+		[]string{"cluster_deployment", "namespace", "cluster_type", "condition", "reason", "category", "platform", "image_set"},
 		nil,
 	)
 )
@@ -203,17 +206,20 @@ func newProvisioningUnderwayInstallRestartsCollector(client client.Client, minim
 	}
 }
 
-func getKnownConditions(conditions []hivev1.ClusterDeploymentCondition) (condition, reason string) {
-	condition, reason = "Unknown", "Unknown"
+func getKnownConditions(conditions []hivev1.ClusterDeploymentCondition) (condition, reason, category string) {
+	condition, reason, category = "Unknown", "Unknown", "Unknown"
 	for _, delayCondition := range provisioningDelayCondition {
 		if cdCondition := controllerutils.FindClusterDeploymentCondition(conditions,
 			delayCondition); cdCondition != nil {
 			if cdCondition.Status == corev1.ConditionTrue && cdCondition.Reason != "" {
 				condition = string(delayCondition)
 				reason = cdCondition.Reason
+				if c := controllerutils.FailureCategory(reason); c != "" {
+					category = string(c)
+				}
 			}
 			break
 		}
 	}
-	return condition, reason
+	return condition, reason, category
 }
@@ -56,7 +56,7 @@ func TestProvisioningUnderwayCollector(t *testing.T) {
 			cdBuilder("cd-2").Build(),
 		},
 		expected: []string{
-			"cluster_deployment = cd-2 cluster_type = unspecified condition = Unknown image_set = none namespace = cd-2 platform =  reason = Unknown",
+			"category = Unknown cluster_deployment = cd-2 cluster_type = unspecified condition = Unknown image_set = none namespace = cd-2 platform =  reason = Unknown",
 		},
 	}, {
 		name: "provisioning with other conditions",
@@ -68,7 +68,7 @@ func TestProvisioningUnderwayCollector(t *testing.T) {
 			})),
 		},
 		expected: []string{
-			"cluster_deployment = cd-2 cluster_type = unspecified condition = Unknown image_set = none namespace = cd-2 platform =  reason = Unknown",
+			"category = Unknown cluster_deployment = cd-2 cluster_type = unspecified condition = Unknown image_set = none namespace = cd-2 platform =  reason = Unknown",
 		},
 	}, {
 		name: "provisioning with ProvisionFailed condition",
@@ -81,7 +81,20 @@ func TestProvisioningUnderwayCollector(t *testing.T) {
 			})),
 		},
 		expected: []string{
-			"cluster_deployment = cd-2 cluster_type = unspecified condition = ProvisionFailed image_set = none namespace = cd-2 platform =  reason = FailedDueToQuotas",
+			"category = Quota cluster_deployment = cd-2 cluster_type = unspecified condition = ProvisionFailed image_set = none namespace = cd-2 platform =  reason = FailedDueToQuotas",
+		},
+	}, {
+		name: "provisioning with ProvisionFailed condition of known category",
+		existing: []runtime.Object{
+			cdBuilder("cd-1").Build(testcd.Installed()),
+			cdBuilder("cd-2").Build(testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+				Type:   hivev1.ProvisionFailedCondition,
+				Status: corev1.ConditionTrue,
+				Reason: "AuthenticationFailed",
+			})),
+		},
+		expected: []string{
+			"category = Authn cluster_deployment = cd-2 cluster_type = unspecified condition = ProvisionFailed image_set = none namespace = cd-2 platform =  reason = AuthenticationFailed",
 		},
 	}, {
 		name: "provisioning with ProvisionFailed, DNSNotReadyCondition condition",
@@ -99,8 +112,8 @@ func TestProvisioningUnderwayCollector(t *testing.T) {
 			})),
 		},
 		expected: []string{
-			"cluster_deployment = cd-2 cluster_type = unspecified condition = ProvisionFailed image_set = none namespace = cd-2 platform =  reason = FailedDueToQuotas",
-			"cluster_deployment = cd-3 cluster_type = unspecified condition = DNSNotReady image_set = none namespace = cd-3 platform =  reason = FailedDueToQuotas",
+			"category = Quota cluster_deployment = cd-2 cluster_type = unspecified condition = ProvisionFailed image_set = none namespace = cd-2 platform =  reason = FailedDueToQuotas",
+			"category = Quota cluster_deployment = cd-3 cluster_type = unspecified condition = DNSNotReady image_set = none namespace = cd-3 platform =  reason = FailedDueToQuotas",
 		},
 	}, {
 		name: "provisioning with no conditions and duration more than min duration",
@@ -110,7 +123,7 @@ func TestProvisioningUnderwayCollector(t *testing.T) {
 		},
 		min: 1 * time.Hour,
 		expected: []string{
-			"cluster_deployment = cd-2 cluster_type = unspecified condition = Unknown image_set = none namespace = cd-2 platform =  reason = Unknown",
+			"category = Unknown cluster_deployment = cd-2 cluster_type = unspecified condition = Unknown image_set = none namespace = cd-2 platform =  reason = Unknown",
 		},
 	}, {
 		name: "provisioning with other conditions and duration more than min duration",
@@ -123,7 +136,7 @@ func TestProvisioningUnderwayCollector(t *testing.T) {
 		},
 		min: 1 * time.Hour,
 		expected: []string{
-			"cluster_deployment = cd-2 cluster_type = unspecified condition = Unknown image_set = none namespace = cd-2 platform =  reason = Unknown",
+			"category = Unknown cluster_deployment = cd-2 cluster_type = unspecified condition = Unknown image_set = none namespace = cd-2 platform =  reason = Unknown",
 		},
 	}, {
 		name: "provisioning with ProvisionFailed condition and duration more than min duration",
@@ -137,7 +150,7 @@ func TestProvisioningUnderwayCollector(t *testing.T) {
 		},
 		min: 1 * time.Hour,
 		expected: []string{
-			"cluster_deployment = cd-2 cluster_type = unspecified condition = ProvisionFailed image_set = none namespace = cd-2 platform =  reason = FailedDueToQuotas",
+			"category = Quota cluster_deployment = cd-2 cluster_type = unspecified condition = ProvisionFailed image_set = none namespace = cd-2 platform =  reason = FailedDueToQuotas",
 		},
 	}, {
 		name: "provisioning with ProvisionFailed, DNSNotReadyCondition condition and duration more than min duration",
@@ -156,8 +169,8 @@ func TestProvisioningUnderwayCollector(t *testing.T) {
 		},
 		min: 1 * time.Hour,
 		expected: []string{
-			"cluster_deployment = cd-2 cluster_type = unspecified condition = ProvisionFailed image_set = none namespace = cd-2 platform =  reason = FailedDueToQuotas",
-			"cluster_deployment = cd-3 cluster_type = unspecified condition = DNSNotReady image_set = none namespace = cd-3 platform =  reason = FailedDueToQuotas",
+			"category = Quota cluster_deployment = cd-2 cluster_type = unspecified condition = ProvisionFailed image_set = none namespace = cd-2 platform =  reason = FailedDueToQuotas",
+			"category = Quota cluster_deployment = cd-3 cluster_type = unspecified condition = DNSNotReady image_set = none namespace = cd-3 platform =  reason = FailedDueToQuotas",
 		},
 	}, {
 		name: "provisioning with no conditions and duration less than min duration",
@@ -210,7 +223,7 @@ func TestProvisioningUnderwayCollector(t *testing.T) {
 		},
 		min: 1 * time.Hour,
 		expected: []string{
-			"cluster_deployment = cd-3 cluster_type = unspecified condition = DNSNotReady image_set = none namespace = cd-3 platform =  reason = FailedDueToQuotas",
+			"category = Quota cluster_deployment = cd-3 cluster_type = unspecified condition = DNSNotReady image_set = none namespace = cd-3 platform =  reason = FailedDueToQuotas",
 		},
 	}}
 	for _, test := range cases {
@@ -318,7 +331,7 @@ func TestProvisioningUnderwayInstallRestartsCollector(t *testing.T) {
 			cdBuilder("cd-2").Build(testcd.InstallRestarts(2)),
 		},
 		expected: []string{
-			"cluster_deployment = cd-2 cluster_type = unspecified condition = Unknown image_set = none namespace = cd-2 platform =  reason = Unknown 2",
+			"category = Unknown cluster_deployment = cd-2 cluster_type = unspecified condition = Unknown image_set = none namespace = cd-2 platform =  reason = Unknown 2",
 		},
 	}, {
 		name: "provisioning with other conditions",
@@ -330,7 +343,7 @@ func TestProvisioningUnderwayInstallRestartsCollector(t *testing.T) {
 			})),
 		},
 		expected: []string{
-			"cluster_deployment = cd-2 cluster_type = unspecified condition = Unknown image_set = none namespace = cd-2 platform =  reason = Unknown 2",
+			"category = Unknown cluster_deployment = cd-2 cluster_type = unspecified condition = Unknown image_set = none namespace = cd-2 platform =  reason = Unknown 2",
 		},
 	}, {
 		name: "provisioning with ProvisionFailed condition, non-zero restarts",
@@ -343,7 +356,7 @@ func TestProvisioningUnderwayInstallRestartsCollector(t *testing.T) {
 			})),
 		},
 		expected: []string{
-			"cluster_deployment = cd-2 cluster_type = unspecified condition = ProvisionFailed image_set = none namespace = cd-2 platform =  reason = FailedDueToQuotas 2",
+			"category = Quota cluster_deployment = cd-2 cluster_type = unspecified condition = ProvisionFailed image_set = none namespace = cd-2 platform =  reason = FailedDueToQuotas 2",
 		},
 	}, {
 		name: "provisioning with ProvisionFailed, DNSNotReadyCondition condition, non-zero restarts",
@@ -361,8 +374,8 @@ func TestProvisioningUnderwayInstallRestartsCollector(t *testing.T) {
 			})),
 		},
 		expected: []string{
-			"cluster_deployment = cd-2 cluster_type = unspecified condition = ProvisionFailed image_set = none namespace = cd-2 platform =  reason = FailedDueToQuotas 2",
-			"cluster_deployment = cd-3 cluster_type = unspecified condition = DNSNotReady image_set = none namespace = cd-3 platform =  reason = FailedDueToQuotas 2",
+			"category = Quota cluster_deployment = cd-2 cluster_type = unspecified condition = ProvisionFailed image_set = none namespace = cd-2 platform =  reason = FailedDueToQuotas 2",
+			"category = Quota cluster_deployment = cd-3 cluster_type = unspecified condition = DNSNotReady image_set = none namespace = cd-3 platform =  reason = FailedDueToQuotas 2",
 		},
 	}, {
 		name: "provisioning with no conditions and restarts more than min restarts",
@@ -372,7 +385,7 @@ func TestProvisioningUnderwayInstallRestartsCollector(t *testing.T) {
 		},
 		min: 1,
 		expected: []string{
-			"cluster_deployment = cd-2 cluster_type = unspecified condition = Unknown image_set = none namespace = cd-2 platform =  reason = Unknown 2",
+			"category = Unknown cluster_deployment = cd-2 cluster_type = unspecified condition = Unknown image_set = none namespace = cd-2 platform =  reason = Unknown 2",
 		},
 	}, {
 		name: "provisioning with other conditions and restarts more than min restarts",
@@ -385,7 +398,7 @@ func TestProvisioningUnderwayInstallRestartsCollector(t *testing.T) {
 		},
 		min: 1,
 		expected: []string{
-			"cluster_deployment = cd-2 cluster_type = unspecified condition = Unknown image_set = none namespace = cd-2 platform =  reason = Unknown 2",
+			"category = Unknown cluster_deployment = cd-2 cluster_type = unspecified condition = Unknown image_set = none namespace = cd-2 platform =  reason = Unknown 2",
 		},
 	}, {
 		name: "provisioning with ProvisionFailed condition and restarts more than min restarts",
@@ -399,7 +412,7 @@ func TestProvisioningUnderwayInstallRestartsCollector(t *testing.T) {
 		},
 		min: 1,
 		expected: []string{
-			"cluster_deployment = cd-2 cluster_type = unspecified condition = ProvisionFailed image_set = none namespace = cd-2 platform =  reason = FailedDueToQuotas 2",
+			"category = Quota cluster_deployment = cd-2 cluster_type = unspecified condition = ProvisionFailed image_set = none namespace = cd-2 platform =  reason = FailedDueToQuotas 2",
 		},
 	}, {
 		name: "provisioning with ProvisionFailed, DNSNotReadyCondition condition and restarts more than min restarts",
@@ -418,8 +431,8 @@ func TestProvisioningUnderwayInstallRestartsCollector(t *testing.T) {
 		},
 		min: 1,
 		expected: []string{
-			"cluster_deployment = cd-2 cluster_type = unspecified condition = ProvisionFailed image_set = none namespace = cd-2 platform =  reason = FailedDueToQuotas 2",
-			"cluster_deployment = cd-3 cluster_type = unspecified condition = DNSNotReady image_set = none namespace = cd-3 platform =  reason = FailedDueToQuotas 2",
+			"category = Quota cluster_deployment = cd-2 cluster_type = unspecified condition = ProvisionFailed image_set = none namespace = cd-2 platform =  reason = FailedDueToQuotas 2",
+			"category = Quota cluster_deployment = cd-3 cluster_type = unspecified condition = DNSNotReady image_set = none namespace = cd-3 platform =  reason = FailedDueToQuotas 2",
 		},
 	}, {
 		name: "provisioning with no conditions and restarts less than min restarts",
@@ -470,7 +483,7 @@ func TestProvisioningUnderwayInstallRestartsCollector(t *testing.T) {
 		},
 		min: 2,
 		expected: []string{
-			"cluster_deployment = cd-3 cluster_type = unspecified condition = DNSNotReady image_set = none namespace = cd-3 platform =  reason = FailedDueToQuotas 2",
+			"category = Quota cluster_deployment = cd-3 cluster_type = unspecified condition = DNSNotReady image_set = none namespace = cd-3 platform =  reason = FailedDueToQuotas 2",
 		},
 	}}
 	for _, test := range cases {
@@ -0,0 +1,250 @@
+package namespacecleanup
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/client-go/util/workqueue"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	ControllerName = hivev1.NamespaceCleanupControllerName
+
+	// minimumLifetime is how long a namespace is left alone after its creation, giving Hive time to populate it.
+	minimumLifetime = 5 * time.Minute
+
+	// durationBetweenPendingDeletionChecks is how often a namespace is checked while its contents are being deleted.
+	durationBetweenPendingDeletionChecks = 1 * time.Minute
+
+	// durationBetweenNonEmptyChecks is how often a namespace which still has contents is checked. Changes to most
+	// of the contents are not watched.
+	durationBetweenNonEmptyChecks = 1 * time.Hour
+)
+
+var (
+	// ignoredObjects are the objects created automatically in every namespace, by kind.
+	ignoredObjects = map[string]sets.String{
+		"ConfigMap":      sets.NewString("kube-root-ca.crt", "openshift-service-ca.crt"),
+		"ServiceAccount": sets.NewString("default", "builder", "deployer"),
+		"RoleBinding":    sets.NewString("system:image-pullers", "system:image-builders", "system:deployers"),
+	}
+
+	// ignoredResources are the resources which do not keep a namespace from being empty.
+	ignoredResources = sets.NewString("events", "events.events.k8s.io")
+)
+
+// Add creates a new NamespaceCleanup Controller and adds it to the Manager with default RBAC. The Manager will set
+// fields on the Controller and Start it when the Manager is Started. The controller is only added when namespace
+// cleanup has been enabled.
+func Add(mgr manager.Manager) error {
+	logger := log.WithField("controller", ControllerName)
+	if os.Getenv(constants.NamespaceCleanupEnvVar) != "true" {
+		logger.Debug("namespace cleanup is not enabled")
+		return nil
+	}
+	concurrentReconciles, clientRateLimiter, queueRateLimiter, err := controllerutils.GetControllerConfig(mgr.GetClient(), ControllerName)
+	if err != nil {
+		logger.WithError(err).Error("could not get controller configurations")
+		return err
+	}
+	r, err := NewReconciler(mgr, clientRateLimiter)
+	if err != nil {
+		logger.WithError(err).Error("could not create reconciler")
+		return err
+	}
+	return AddToManager(mgr, r, concurrentReconciles, queueRateLimiter)
+}
+
+// NewReconciler returns a new reconcile.Reconciler
+func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) (reconcile.Reconciler, error) {
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(mgr.GetConfig())
+	if err != nil {
+		return nil, err
+	}
+	return &ReconcileNamespaceCleanup{
+		Client:          controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
+		discoveryClient: discoveryClient,
+	}, nil
+}
+
+// AddToManager adds a new Controller to mgr with r as the reconcile.Reconciler
+func AddToManager(mgr manager.Manager, r reconcile.Reconciler, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
+	c, err := controller.New(
+		fmt.Sprintf("%s-controller", ControllerName),
+		mgr,
+		controller.Options{
+			Reconciler:              r,
+			MaxConcurrentReconciles: concurrentReconciles,
+			RateLimiter:             rateLimiter,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to Namespaces
+	if err := c.Watch(&source.Kind{Type: &corev1.Namespace{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		return err
+	}
+
+	// Watch for changes to ClusterDeployments, whose removal usually leaves the namespace empty
+	if err := c.Watch(
+		&source.Kind{Type: &hivev1.ClusterDeployment{}},
+		&handler.EnqueueRequestsFromMapFunc{
+			ToRequests: handler.ToRequestsFunc(func(a handler.MapObject) []reconcile.Request {
+				return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: a.Meta.GetNamespace()}}}
+			}),
+		},
+	); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var _ reconcile.Reconciler = &ReconcileNamespaceCleanup{}
+
+// ReconcileNamespaceCleanup reconciles a Namespace object for the purpose of reaping the namespaces created by Hive
+// to house a single ClusterDeployment once they are empty.
+type ReconcileNamespaceCleanup struct {
+	client.Client
+
+	// discoveryClient discovers the namespaced resources listed to check whether a namespace is empty.
+	discoveryClient discovery.DiscoveryInterface
+}
+
+// Reconcile deletes a Namespace created by Hive if it is empty.
+func (r *ReconcileNamespaceCleanup) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	logger := controllerutils.BuildControllerLogger(ControllerName, "namespace", request.NamespacedName)
+	logger.Debug("reconciling namespace")
+	recobsrv := hivemetrics.NewReconcileObserver(ControllerName, logger)
+	defer recobsrv.ObserveControllerReconcileTime()
+
+	namespace := &corev1.Namespace{}
+	switch err := r.Get(context.Background(), request.NamespacedName, namespace); {
+	case apierrors.IsNotFound(err):
+		return reconcile.Result{}, nil
+	case err != nil:
+		return reconcile.Result{}, err
+	}
+
+	// If the Namespace is deleted, do not reconcile.
+	if namespace.DeletionTimestamp != nil {
+		return reconcile.Result{}, nil
+	}
+
+	// Only namespaces created by Hive for a single cluster are cleaned up
+	if namespace.Labels[constants.DedicatedNamespaceLabel] != "true" {
+		return reconcile.Result{}, nil
+	}
+
+	if lifetime := time.Since(namespace.CreationTimestamp.Time); lifetime < minimumLifetime {
+		logger.WithField("lifetime", lifetime).Debug("namespace is not old enough to delete")
+		return reconcile.Result{RequeueAfter: minimumLifetime - lifetime}, nil
+	}
+
+	remaining, pendingDeletion, err := r.namespaceContents(namespace.Name)
+	if err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not list namespace contents")
+		return reconcile.Result{}, err
+	}
+	switch {
+	case len(remaining) > 0:
+		logger.WithField("remaining", remaining).Debug("namespace is not empty")
+		return reconcile.Result{RequeueAfter: durationBetweenNonEmptyChecks}, nil
+	case pendingDeletion:
+		logger.Debug("waiting for the contents of the namespace to be removed from storage")
+		return reconcile.Result{RequeueAfter: durationBetweenPendingDeletionChecks}, nil
+	}
+
+	logger.Info("deleting empty namespace")
+	if err := r.Delete(context.Background(), namespace); err != nil && !apierrors.IsNotFound(err) {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "error deleting namespace")
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{}, nil
+}
+
+// namespaceContents lists the contents of the namespace which keep it from being empty, across all the namespaced
+// resources served by the API server which can be listed and deleted. Contents which are being deleted, or which are
+// owned by Hive resources and will be garbage collected, do not keep the namespace from being empty but are reported
+// as pending deletion. The namespace is not considered empty when the resources cannot all be discovered.
+func (r *ReconcileNamespaceCleanup) namespaceContents(namespace string) (remaining []string, pendingDeletion bool, returnErr error) {
+	resourceLists, err := discovery.ServerPreferredNamespacedResources(r.discoveryClient)
+	if err != nil {
+		return nil, false, errors.Wrap(err, "could not discover namespaced resources")
+	}
+	for _, resourceList := range resourceLists {
+		gv, err := schema.ParseGroupVersion(resourceList.GroupVersion)
+		if err != nil {
+			return nil, false, err
+		}
+		for _, resource := range resourceList.APIResources {
+			if !sets.NewString(resource.Verbs...).HasAll("list", "delete") ||
+				ignoredResources.Has(gv.WithResource(resource.Name).GroupResource().String()) {
+				continue
+			}
+			list := &unstructured.UnstructuredList{}
+			list.SetGroupVersionKind(gv.WithKind(resource.Kind + "List"))
+			if err := r.List(context.Background(), list, client.InNamespace(namespace)); err != nil {
+				return nil, false, errors.Wrapf(err, "could not list %s", gv.WithResource(resource.Name).GroupResource())
+			}
+			for i := range list.Items {
+				obj := &list.Items[i]
+				if isIgnored(resource.Kind, obj) {
+					continue
+				}
+				if obj.GetDeletionTimestamp() != nil || isOwnedByHive(obj) {
+					pendingDeletion = true
+					continue
+				}
+				remaining = append(remaining, fmt.Sprintf("%s/%s", resource.Kind, obj.GetName()))
+			}
+		}
+	}
+	return remaining, pendingDeletion, nil
+}
+
+// isIgnored returns whether the object is created automatically in every namespace.
+func isIgnored(kind string, obj metav1.Object) bool {
+	if kind == "Secret" {
+		_, forServiceAccount := obj.GetAnnotations()[corev1.ServiceAccountNameKey]
+		return forServiceAccount
+	}
+	return ignoredObjects[kind].Has(obj.GetName())
+}
+
+// isOwnedByHive returns whether the object is owned by a Hive resource, and so will be garbage collected.
+func isOwnedByHive(obj metav1.Object) bool {
+	for _, ref := range obj.GetOwnerReferences() {
+		if ref.APIVersion == hivev1.SchemeGroupVersion.String() {
+			return true
+		}
+	}
+	return false
+}
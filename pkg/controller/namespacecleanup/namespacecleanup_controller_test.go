@@ -0,0 +1,191 @@
+package namespacecleanup
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	clienttesting "k8s.io/client-go/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+	testgeneric "github.com/openshift/hive/pkg/test/generic"
+	testnamespace "github.com/openshift/hive/pkg/test/namespace"
+	testsecret "github.com/openshift/hive/pkg/test/secret"
+)
+
+const (
+	namespaceName = "test-namespace"
+)
+
+func TestReconcileNamespaceCleanup(t *testing.T) {
+	scheme := runtime.NewScheme()
+	corev1.AddToScheme(scheme)
+	appsv1.AddToScheme(scheme)
+	rbacv1.AddToScheme(scheme)
+	hivev1.AddToScheme(scheme)
+
+	namespaceWithoutLabelBuilder := testnamespace.FullBuilder(namespaceName, scheme).GenericOptions(
+		testgeneric.WithCreationTimestamp(time.Now().Add(-2 * minimumLifetime)),
+	)
+	namespaceBuilder := namespaceWithoutLabelBuilder.GenericOptions(
+		testgeneric.WithLabel(constants.DedicatedNamespaceLabel, "true"),
+	)
+	cdOwner := testgeneric.WithOwnerReference(testcd.FullBuilder(namespaceName, "test-cd", scheme).Build())
+
+	cases := []struct {
+		name                string
+		namespace           *corev1.Namespace
+		resources           []runtime.Object
+		expectDeleted       bool
+		expectedRequeueTime time.Duration
+	}{
+		{
+			name:          "empty namespace",
+			namespace:     namespaceBuilder.Build(),
+			expectDeleted: true,
+		},
+		{
+			name:      "namespace not created by hive",
+			namespace: namespaceWithoutLabelBuilder.Build(),
+		},
+		{
+			name: "namespace too young",
+			namespace: namespaceBuilder.Build(func(ns *corev1.Namespace) {
+				ns.CreationTimestamp = metav1.Now()
+			}),
+			expectedRequeueTime: minimumLifetime,
+		},
+		{
+			name:      "clusterdeployment",
+			namespace: namespaceBuilder.Build(),
+			resources: []runtime.Object{
+				testcd.FullBuilder(namespaceName, "test-cd", scheme).Build(),
+			},
+			expectedRequeueTime: durationBetweenNonEmptyChecks,
+		},
+		{
+			name:      "user secret",
+			namespace: namespaceBuilder.Build(),
+			resources: []runtime.Object{
+				testsecret.FullBuilder(namespaceName, "pull-secret", scheme).Build(),
+			},
+			expectedRequeueTime: durationBetweenNonEmptyChecks,
+		},
+		{
+			name:      "secret owned by clusterdeployment",
+			namespace: namespaceBuilder.Build(),
+			resources: []runtime.Object{
+				testsecret.FullBuilder(namespaceName, "admin-kubeconfig", scheme).GenericOptions(cdOwner).Build(),
+			},
+			expectedRequeueTime: durationBetweenPendingDeletionChecks,
+		},
+		{
+			name:      "injected contents",
+			namespace: namespaceBuilder.Build(),
+			resources: []runtime.Object{
+				testsecret.FullBuilder(namespaceName, "default-token-abcde", scheme).GenericOptions(
+					testgeneric.WithAnnotation(corev1.ServiceAccountNameKey, "default"),
+				).Build(),
+				&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: namespaceName, Name: "kube-root-ca.crt"}},
+			},
+			expectDeleted: true,
+		},
+		{
+			name:      "injected service accounts and role bindings",
+			namespace: namespaceBuilder.Build(),
+			resources: []runtime.Object{
+				&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Namespace: namespaceName, Name: "default"}},
+				&rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{Namespace: namespaceName, Name: "system:image-pullers"}},
+			},
+			expectDeleted: true,
+		},
+		{
+			name:      "events",
+			namespace: namespaceBuilder.Build(),
+			resources: []runtime.Object{
+				&corev1.Event{ObjectMeta: metav1.ObjectMeta{Namespace: namespaceName, Name: "event"}},
+			},
+			expectDeleted: true,
+		},
+		{
+			name:      "user deployment",
+			namespace: namespaceBuilder.Build(),
+			resources: []runtime.Object{
+				&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: namespaceName, Name: "app"}},
+			},
+			expectedRequeueTime: durationBetweenNonEmptyChecks,
+		},
+		{
+			name:      "user service account",
+			namespace: namespaceBuilder.Build(),
+			resources: []runtime.Object{
+				&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Namespace: namespaceName, Name: "app"}},
+			},
+			expectedRequeueTime: durationBetweenNonEmptyChecks,
+		},
+	}
+	discoveryClient := &fakediscovery.FakeDiscovery{Fake: &clienttesting.Fake{Resources: []*metav1.APIResourceList{
+		{
+			GroupVersion: "v1",
+			APIResources: []metav1.APIResource{
+				testResource("configmaps", "ConfigMap"),
+				testResource("events", "Event"),
+				testResource("pods", "Pod"),
+				testResource("secrets", "Secret"),
+				testResource("serviceaccounts", "ServiceAccount"),
+				{Name: "bindings", Kind: "Binding", Namespaced: true, Verbs: []string{"create"}},
+			},
+		},
+		{
+			GroupVersion: "apps/v1",
+			APIResources: []metav1.APIResource{testResource("deployments", "Deployment")},
+		},
+		{
+			GroupVersion: "rbac.authorization.k8s.io/v1",
+			APIResources: []metav1.APIResource{testResource("rolebindings", "RoleBinding")},
+		},
+		{
+			GroupVersion: "hive.openshift.io/v1",
+			APIResources: []metav1.APIResource{
+				testResource("clusterdeployments", "ClusterDeployment"),
+				testResource("machinepools", "MachinePool"),
+				{Name: "clusterimagesets", Kind: "ClusterImageSet", Verbs: []string{"list", "delete"}},
+			},
+		},
+	}}}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := fake.NewFakeClientWithScheme(scheme, append(tc.resources, tc.namespace)...)
+			reconciler := &ReconcileNamespaceCleanup{Client: c, discoveryClient: discoveryClient}
+
+			result, err := reconciler.Reconcile(reconcile.Request{NamespacedName: types.NamespacedName{Name: namespaceName}})
+			require.NoError(t, err, "unexpected error from Reconcile")
+			assert.InDelta(t, tc.expectedRequeueTime, result.RequeueAfter, float64(time.Second), "unexpected requeue after")
+
+			err = c.Get(context.Background(), types.NamespacedName{Name: namespaceName}, &corev1.Namespace{})
+			if tc.expectDeleted {
+				assert.True(t, apierrors.IsNotFound(err), "expected namespace to be deleted")
+			} else {
+				assert.NoError(t, err, "expected namespace to exist")
+			}
+		})
+	}
+}
+
+func testResource(name, kind string) metav1.APIResource {
+	return metav1.APIResource{Name: name, Kind: kind, Namespaced: true, Verbs: []string{"get", "list", "watch", "delete"}}
+}
@@ -0,0 +1,426 @@
+package nodetuning
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/client-go/util/workqueue"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	apihelpers "github.com/openshift/hive/apis/helpers"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/resource"
+	k8slabels "github.com/openshift/hive/pkg/util/labels"
+)
+
+const (
+	ControllerName = hivev1.NodeTuningControllerName
+
+	// nodeTuningName is the name of the KubeletConfig synced to the cluster.
+	nodeTuningName = "hive-node-tuning"
+	// sysctlMachineConfigName is the name of the MachineConfig synced to the cluster. The 99 prefix orders it after
+	// the MachineConfigs rendered by the cluster.
+	sysctlMachineConfigName = "99-worker-hive-node-tuning"
+	sysctlConfPath          = "/etc/sysctl.d/99-hive-node-tuning.conf"
+	// poolSysctlConfPath is the sysctl.d file of the sysctls of a MachinePool. It sorts after sysctlConfPath, which
+	// the machine config pool of the MachinePool inherits from the worker machine config pool, so that its sysctls
+	// override those of all MachinePools.
+	poolSysctlConfPath = "/etc/sysctl.d/99-hive-pool-node-tuning.conf"
+	// machinePoolRolePrefix prefixes the names of the machine config pools of MachinePools, and their roles.
+	machinePoolRolePrefix = "hive-"
+	nodeRoleLabelPrefix   = "node-role.kubernetes.io/"
+
+	machineConfigAPIVersion = "machineconfiguration.openshift.io/v1"
+	workerPoolSelectorLabel = "pools.operator.machineconfiguration.openshift.io/worker"
+	machineConfigRoleLabel  = "machineconfiguration.openshift.io/role"
+	ignitionVersion         = "3.2.0"
+)
+
+var (
+	sysctlNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9_.\-/]+$`)
+)
+
+type applier interface {
+	ApplyRuntimeObject(obj runtime.Object, scheme *runtime.Scheme) (resource.ApplyResult, error)
+}
+
+// Add creates a new NodeTuning controller and adds it to the manager with default RBAC.
+func Add(mgr manager.Manager) error {
+	logger := log.WithField("controller", ControllerName)
+	concurrentReconciles, clientRateLimiter, queueRateLimiter, err := controllerutils.GetControllerConfig(mgr.GetClient(), ControllerName)
+	if err != nil {
+		logger.WithError(err).Error("could not get controller configurations")
+		return err
+	}
+	return AddToManager(mgr, NewReconciler(mgr, clientRateLimiter), concurrentReconciles, queueRateLimiter)
+}
+
+// NewReconciler returns a new reconcile.Reconciler
+func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) reconcile.Reconciler {
+	logger := log.WithField("controller", ControllerName)
+	helper, err := resource.NewHelperWithMetricsFromRESTConfig(mgr.GetConfig(), ControllerName, logger)
+	if err != nil {
+		// Hard exit if we can't create this controller
+		logger.WithError(err).Fatal("unable to create resource helper")
+	}
+	return &ReconcileNodeTuning{
+		Client:  controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
+		scheme:  mgr.GetScheme(),
+		applier: helper,
+	}
+}
+
+// AddToManager adds a new Controller to mgr with r as the reconcile.Reconciler
+func AddToManager(mgr manager.Manager, r reconcile.Reconciler, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
+	c, err := controller.New("nodetuning-controller", mgr, controller.Options{
+		Reconciler:              r,
+		MaxConcurrentReconciles: concurrentReconciles,
+		RateLimiter:             rateLimiter,
+	})
+	if err != nil {
+		log.WithField("controller", ControllerName).WithError(err).Error("Error creating new nodetuning controller")
+		return err
+	}
+
+	// Watch for changes to ClusterDeployment
+	if err := c.Watch(&source.Kind{Type: &hivev1.ClusterDeployment{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		log.WithField("controller", ControllerName).WithError(err).Error("Error watching cluster deployments")
+		return err
+	}
+
+	// Watch for changes to the sysctl profiles referenced by ClusterDeployments
+	if err := c.Watch(&source.Kind{Type: &corev1.ConfigMap{}}, &handler.EnqueueRequestsFromMapFunc{
+		ToRequests: requestsForSysctlProfile(mgr.GetClient()),
+	}); err != nil {
+		log.WithField("controller", ControllerName).WithError(err).Error("Error watching config maps")
+		return err
+	}
+	return nil
+}
+
+// requestsForSysctlProfile returns a mapper of ConfigMaps to the ClusterDeployments which reference them as sysctl
+// profiles.
+func requestsForSysctlProfile(c client.Client) handler.ToRequestsFunc {
+	return func(o handler.MapObject) []reconcile.Request {
+		cds := &hivev1.ClusterDeploymentList{}
+		if err := c.List(context.TODO(), cds, client.InNamespace(o.Meta.GetNamespace())); err != nil {
+			log.WithField("controller", ControllerName).WithError(err).Error("error listing cluster deployments")
+			return nil
+		}
+		var requests []reconcile.Request
+		for _, cd := range cds.Items {
+			if cd.Spec.NodeTuning == nil {
+				continue
+			}
+			refs := cd.Spec.NodeTuning.SysctlProfileRefs
+			for _, pool := range cd.Spec.NodeTuning.MachinePools {
+				refs = append(refs, pool.SysctlProfileRefs...)
+			}
+			for _, ref := range refs {
+				if ref.Name == o.Meta.GetName() {
+					requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: cd.Namespace, Name: cd.Name}})
+					break
+				}
+			}
+		}
+		return requests
+	}
+}
+
+// MachinePoolNodeRoleLabel returns the label of the role of the nodes of a MachinePool with its own node tuning
+// defaults, which selects them into the machine config pool of the MachinePool.
+func MachinePoolNodeRoleLabel(poolName string) string {
+	return nodeRoleLabelPrefix + machinePoolRolePrefix + poolName
+}
+
+// HasMachinePoolNodeTuning returns whether the clusterdeployment has node tuning defaults of the MachinePool with the
+// given name.
+func HasMachinePoolNodeTuning(cd *hivev1.ClusterDeployment, poolName string) bool {
+	if cd.Spec.NodeTuning == nil {
+		return false
+	}
+	for _, pool := range cd.Spec.NodeTuning.MachinePools {
+		if pool.Name == poolName {
+			return true
+		}
+	}
+	return false
+}
+
+var _ reconcile.Reconciler = &ReconcileNodeTuning{}
+
+// ReconcileNodeTuning reconciles the node tuning defaults of a ClusterDeployment into a SyncSet
+type ReconcileNodeTuning struct {
+	client.Client
+	scheme  *runtime.Scheme
+	applier applier
+}
+
+// Reconcile renders the node tuning defaults of a ClusterDeployment into a SyncSet of a KubeletConfig and a
+// MachineConfig for the worker machine config pool, and a machine config pool and MachineConfig for each MachinePool
+// with its own sysctl profiles.
+func (r *ReconcileNodeTuning) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	cdLog := controllerutils.BuildControllerLogger(ControllerName, "clusterDeployment", request.NamespacedName)
+	cdLog.Info("reconciling cluster deployment")
+	recobsrv := hivemetrics.NewReconcileObserver(ControllerName, cdLog)
+	defer recobsrv.ObserveControllerReconcileTime()
+
+	cd := &hivev1.ClusterDeployment{}
+	if err := r.Get(context.TODO(), request.NamespacedName, cd); err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		cdLog.WithError(err).Error("error getting cluster deployment")
+		return reconcile.Result{}, err
+	}
+
+	// If the clusterdeployment is deleted, do not reconcile.
+	if cd.DeletionTimestamp != nil {
+		return reconcile.Result{}, nil
+	}
+
+	if !cd.Spec.Installed {
+		return reconcile.Result{}, nil
+	}
+
+	nodeTuning := cd.Spec.NodeTuning
+	if nodeTuning == nil ||
+		(nodeTuning.KubeletConfig == nil && len(nodeTuning.SysctlProfileRefs) == 0 && len(nodeTuning.MachinePools) == 0) {
+		return reconcile.Result{}, r.deleteSyncSet(cd, cdLog)
+	}
+
+	sysctls, err := r.loadSysctls(cd.Namespace, nodeTuning.SysctlProfileRefs)
+	if err != nil {
+		cdLog.WithError(err).Error("error loading sysctl profiles")
+		return reconcile.Result{}, err
+	}
+	poolSysctls := make(map[string]map[string]string, len(nodeTuning.MachinePools))
+	for _, pool := range nodeTuning.MachinePools {
+		poolSysctls[pool.Name], err = r.loadSysctls(cd.Namespace, pool.SysctlProfileRefs)
+		if err != nil {
+			cdLog.WithError(err).WithField("machinePool", pool.Name).Error("error loading sysctl profiles of machine pool")
+			return reconcile.Result{}, err
+		}
+	}
+
+	syncSet, err := generateNodeTuningSyncSet(cd, sysctls, poolSysctls)
+	if err != nil {
+		cdLog.WithError(err).Error("error generating node tuning syncset")
+		return reconcile.Result{}, err
+	}
+	if err := controllerutil.SetControllerReference(cd, syncSet, r.scheme); err != nil {
+		cdLog.WithError(err).Error("error setting owner reference")
+		return reconcile.Result{}, err
+	}
+	result, err := r.applier.ApplyRuntimeObject(syncSet, r.scheme)
+	if err != nil {
+		cdLog.WithError(err).Error("error applying node tuning syncset")
+		return reconcile.Result{}, err
+	}
+	cdLog.WithField("syncSet", syncSet.Name).Debugf("node tuning syncset applied (%s)", result)
+	return reconcile.Result{}, nil
+}
+
+// deleteSyncSet deletes the node tuning syncset of a clusterdeployment which no longer has node tuning defaults.
+// The syncset syncs its resources in Sync mode, so they are deleted from the cluster too.
+func (r *ReconcileNodeTuning) deleteSyncSet(cd *hivev1.ClusterDeployment, cdLog log.FieldLogger) error {
+	syncSet := &hivev1.SyncSet{}
+	switch err := r.Get(context.TODO(), types.NamespacedName{Namespace: cd.Namespace, Name: GenerateNodeTuningSyncSetName(cd.Name)}, syncSet); {
+	case apierrors.IsNotFound(err):
+		return nil
+	case err != nil:
+		cdLog.WithError(err).Error("error getting node tuning syncset")
+		return err
+	}
+	if err := r.Delete(context.TODO(), syncSet); err != nil && !apierrors.IsNotFound(err) {
+		cdLog.WithError(err).Log(controllerutils.LogLevel(err), "error deleting node tuning syncset")
+		return err
+	}
+	cdLog.WithField("syncSet", syncSet.Name).Info("deleted node tuning syncset")
+	return nil
+}
+
+// loadSysctls merges the given sysctl profiles in the namespace, later profiles overriding earlier ones.
+func (r *ReconcileNodeTuning) loadSysctls(namespace string, refs []corev1.LocalObjectReference) (map[string]string, error) {
+	sysctls := map[string]string{}
+	for _, ref := range refs {
+		profile := &corev1.ConfigMap{}
+		if err := r.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: ref.Name}, profile); err != nil {
+			return nil, errors.Wrapf(err, "could not get sysctl profile %s", ref.Name)
+		}
+		for name, value := range profile.Data {
+			if !sysctlNameRegexp.MatchString(name) {
+				return nil, fmt.Errorf("sysctl profile %s has invalid sysctl name %q", ref.Name, name)
+			}
+			if strings.ContainsAny(value, "\r\n") {
+				return nil, fmt.Errorf("sysctl profile %s has a multi-line value for sysctl %s", ref.Name, name)
+			}
+			sysctls[name] = strings.TrimSpace(value)
+		}
+	}
+	return sysctls, nil
+}
+
+// generateNodeTuningSyncSet generates the syncset of the node tuning defaults of the clusterdeployment, given the
+// sysctls of all MachinePools and those of individual MachinePools by name.
+func generateNodeTuningSyncSet(cd *hivev1.ClusterDeployment, sysctls map[string]string, poolSysctls map[string]map[string]string) (*hivev1.SyncSet, error) {
+	var resources []runtime.RawExtension
+	if kubeletConfig := cd.Spec.NodeTuning.KubeletConfig; kubeletConfig != nil {
+		raw, err := json.Marshal(map[string]interface{}{
+			"apiVersion": machineConfigAPIVersion,
+			"kind":       "KubeletConfig",
+			"metadata": map[string]interface{}{
+				"name": nodeTuningName,
+			},
+			"spec": map[string]interface{}{
+				"machineConfigPoolSelector": map[string]interface{}{
+					"matchLabels": map[string]interface{}{workerPoolSelectorLabel: ""},
+				},
+				"kubeletConfig": json.RawMessage(kubeletConfig.Raw),
+			},
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "could not render KubeletConfig")
+		}
+		resources = append(resources, runtime.RawExtension{Raw: raw})
+	}
+	if len(sysctls) > 0 {
+		raw, err := renderSysctlMachineConfig(sysctlMachineConfigName, "worker", sysctlConfPath, sysctls)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, runtime.RawExtension{Raw: raw})
+	}
+	for _, pool := range cd.Spec.NodeTuning.MachinePools {
+		role := machinePoolRolePrefix + pool.Name
+		// The machine config pool selects the MachineConfigs of the worker role as well as its own, and has the
+		// label of the worker machine config pool so that the KubeletConfig applies to it too.
+		raw, err := json.Marshal(map[string]interface{}{
+			"apiVersion": machineConfigAPIVersion,
+			"kind":       "MachineConfigPool",
+			"metadata": map[string]interface{}{
+				"name":   role,
+				"labels": map[string]interface{}{workerPoolSelectorLabel: ""},
+			},
+			"spec": map[string]interface{}{
+				"machineConfigSelector": map[string]interface{}{
+					"matchExpressions": []interface{}{
+						map[string]interface{}{
+							"key":      machineConfigRoleLabel,
+							"operator": "In",
+							"values":   []interface{}{"worker", role},
+						},
+					},
+				},
+				"nodeSelector": map[string]interface{}{
+					"matchLabels": map[string]interface{}{MachinePoolNodeRoleLabel(pool.Name): ""},
+				},
+			},
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not render MachineConfigPool of machine pool %s", pool.Name)
+		}
+		resources = append(resources, runtime.RawExtension{Raw: raw})
+		if len(poolSysctls[pool.Name]) == 0 {
+			continue
+		}
+		raw, err = renderSysctlMachineConfig(fmt.Sprintf("99-%s-node-tuning", role), role, poolSysctlConfPath, poolSysctls[pool.Name])
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, runtime.RawExtension{Raw: raw})
+	}
+
+	syncSet := &hivev1.SyncSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        GenerateNodeTuningSyncSetName(cd.Name),
+			Namespace:   cd.Namespace,
+			Annotations: map[string]string{constants.SyncSetMetricsGroupAnnotation: "node-tuning"},
+		},
+		Spec: hivev1.SyncSetSpec{
+			SyncSetCommonSpec: hivev1.SyncSetCommonSpec{
+				// Sync mode deletes the resources which are no longer rendered from the cluster.
+				ResourceApplyMode: hivev1.SyncResourceApplyMode,
+				Resources:         resources,
+			},
+			ClusterDeploymentRefs: []corev1.LocalObjectReference{{Name: cd.Name}},
+		},
+	}
+	syncSet.Labels = k8slabels.AddLabel(syncSet.Labels, constants.ClusterDeploymentNameLabel, cd.Name)
+	syncSet.Labels = k8slabels.AddLabel(syncSet.Labels, constants.SyncSetTypeLabel, constants.SyncSetTypeNodeTuning)
+	return syncSet, nil
+}
+
+// renderSysctlMachineConfig renders a MachineConfig of the role which writes the sysctls to the sysctl.d file at path.
+func renderSysctlMachineConfig(name, role, path string, sysctls map[string]string) ([]byte, error) {
+	raw, err := json.Marshal(map[string]interface{}{
+		"apiVersion": machineConfigAPIVersion,
+		"kind":       "MachineConfig",
+		"metadata": map[string]interface{}{
+			"name":   name,
+			"labels": map[string]interface{}{machineConfigRoleLabel: role},
+		},
+		"spec": map[string]interface{}{
+			"config": map[string]interface{}{
+				"ignition": map[string]interface{}{"version": ignitionVersion},
+				"storage": map[string]interface{}{
+					"files": []interface{}{
+						map[string]interface{}{
+							"path":      path,
+							"mode":      0644,
+							"overwrite": true,
+							"contents": map[string]interface{}{
+								"source": "data:text/plain;charset=utf-8;base64," + base64.StdEncoding.EncodeToString([]byte(sysctlConf(sysctls))),
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+	return raw, errors.Wrapf(err, "could not render MachineConfig %s", name)
+}
+
+// sysctlConf renders the sysctls in the sysctl.d format, sorted by name.
+func sysctlConf(sysctls map[string]string) string {
+	names := make([]string, 0, len(sysctls))
+	for name := range sysctls {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var b strings.Builder
+	b.WriteString("# Managed by Hive\n")
+	for _, name := range names {
+		fmt.Fprintf(&b, "%s = %s\n", name, sysctls[name])
+	}
+	return b.String()
+}
+
+// GenerateNodeTuningSyncSetName returns the name of the node tuning syncset of a clusterdeployment.
+func GenerateNodeTuningSyncSetName(name string) string {
+	return apihelpers.GetResourceName(name, "node-tuning")
+}
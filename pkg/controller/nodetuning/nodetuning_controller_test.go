@@ -0,0 +1,264 @@
+package nodetuning
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/openshift/hive/apis"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/resource"
+)
+
+const (
+	testName      = "test-cluster"
+	testNamespace = "test-namespace"
+)
+
+func init() {
+	log.SetLevel(log.DebugLevel)
+}
+
+func TestReconcileNodeTuning(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+
+	tests := []struct {
+		name                  string
+		cd                    *hivev1.ClusterDeployment
+		existing              []runtime.Object
+		expectErr             bool
+		expectApply           bool
+		expectKubeletConfig   string
+		expectSysctlConf      string
+		expectPoolSysctlConf  string
+		expectMachinePool     string
+		expectSyncSetDeleted  bool
+		expectedResourceCount int
+	}{
+		{
+			name: "no node tuning",
+			cd:   testClusterDeployment(nil),
+		},
+		{
+			name: "not installed",
+			cd: func() *hivev1.ClusterDeployment {
+				cd := testClusterDeployment(&hivev1.NodeTuning{KubeletConfig: &runtime.RawExtension{Raw: []byte(`{"maxPods":500}`)}})
+				cd.Spec.Installed = false
+				return cd
+			}(),
+		},
+		{
+			name:                  "kubelet config",
+			cd:                    testClusterDeployment(&hivev1.NodeTuning{KubeletConfig: &runtime.RawExtension{Raw: []byte(`{"maxPods":500}`)}}),
+			expectApply:           true,
+			expectKubeletConfig:   `{"maxPods":500}`,
+			expectedResourceCount: 1,
+		},
+		{
+			name: "sysctl profiles",
+			cd: testClusterDeployment(&hivev1.NodeTuning{
+				KubeletConfig:     &runtime.RawExtension{Raw: []byte(`{"podPidsLimit":4096}`)},
+				SysctlProfileRefs: []corev1.LocalObjectReference{{Name: "base"}, {Name: "override"}},
+			}),
+			existing: []runtime.Object{
+				testSysctlProfile("base", map[string]string{"net.core.somaxconn": "1024", "vm.max_map_count": "262144"}),
+				testSysctlProfile("override", map[string]string{"net.core.somaxconn": "4096"}),
+			},
+			expectApply:           true,
+			expectKubeletConfig:   `{"podPidsLimit":4096}`,
+			expectSysctlConf:      "# Managed by Hive\nnet.core.somaxconn = 4096\nvm.max_map_count = 262144\n",
+			expectedResourceCount: 2,
+		},
+		{
+			name: "machine pool sysctl profiles",
+			cd: testClusterDeployment(&hivev1.NodeTuning{
+				SysctlProfileRefs: []corev1.LocalObjectReference{{Name: "base"}},
+				MachinePools: []hivev1.MachinePoolNodeTuning{{
+					Name:              "infra",
+					SysctlProfileRefs: []corev1.LocalObjectReference{{Name: "infra"}},
+				}},
+			}),
+			existing: []runtime.Object{
+				testSysctlProfile("base", map[string]string{"net.core.somaxconn": "1024"}),
+				testSysctlProfile("infra", map[string]string{"net.core.somaxconn": "4096", "vm.max_map_count": "262144"}),
+			},
+			expectApply:           true,
+			expectSysctlConf:      "# Managed by Hive\nnet.core.somaxconn = 1024\n",
+			expectPoolSysctlConf:  "# Managed by Hive\nnet.core.somaxconn = 4096\nvm.max_map_count = 262144\n",
+			expectMachinePool:     "infra",
+			expectedResourceCount: 3,
+		},
+		{
+			name: "missing machine pool sysctl profile",
+			cd: testClusterDeployment(&hivev1.NodeTuning{
+				MachinePools: []hivev1.MachinePoolNodeTuning{{
+					Name:              "infra",
+					SysctlProfileRefs: []corev1.LocalObjectReference{{Name: "missing"}},
+				}},
+			}),
+			expectErr: true,
+		},
+		{
+			name: "missing sysctl profile",
+			cd: testClusterDeployment(&hivev1.NodeTuning{
+				SysctlProfileRefs: []corev1.LocalObjectReference{{Name: "missing"}},
+			}),
+			expectErr: true,
+		},
+		{
+			name: "invalid sysctl value",
+			cd: testClusterDeployment(&hivev1.NodeTuning{
+				SysctlProfileRefs: []corev1.LocalObjectReference{{Name: "bad"}},
+			}),
+			existing: []runtime.Object{
+				testSysctlProfile("bad", map[string]string{"vm.swappiness": "10\n[main]"}),
+			},
+			expectErr: true,
+		},
+		{
+			name: "delete syncset when node tuning removed",
+			cd:   testClusterDeployment(nil),
+			existing: []runtime.Object{
+				&hivev1.SyncSet{ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: GenerateNodeTuningSyncSetName(testName)}},
+			},
+			expectSyncSetDeleted: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := fake.NewFakeClientWithScheme(scheme.Scheme, append(test.existing, test.cd)...)
+			applier := &fakeApplier{}
+			r := &ReconcileNodeTuning{
+				Client:  c,
+				scheme:  scheme.Scheme,
+				applier: applier,
+			}
+			_, err := r.Reconcile(reconcile.Request{NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: testName}})
+			if test.expectErr {
+				assert.Error(t, err, "expected error from reconcile")
+			} else {
+				require.NoError(t, err, "unexpected error from reconcile")
+			}
+
+			if test.expectSyncSetDeleted {
+				err := c.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: GenerateNodeTuningSyncSetName(testName)}, &hivev1.SyncSet{})
+				assert.True(t, apierrors.IsNotFound(err), "expected syncset to be deleted")
+			}
+
+			if !test.expectApply {
+				assert.Empty(t, applier.appliedObjects, "unexpected syncset apply")
+				return
+			}
+			require.Len(t, applier.appliedObjects, 1, "single apply expected")
+			ss, ok := applier.appliedObjects[0].(*hivev1.SyncSet)
+			require.True(t, ok, "syncset apply expected")
+			assert.Equal(t, GenerateNodeTuningSyncSetName(testName), ss.Name, "unexpected syncset name")
+			assert.Equal(t, hivev1.SyncResourceApplyMode, ss.Spec.ResourceApplyMode, "unexpected resource apply mode")
+			assert.Equal(t, constants.SyncSetTypeNodeTuning, ss.Labels[constants.SyncSetTypeLabel], "unexpected syncset type label")
+			assert.Len(t, ss.Spec.Resources, test.expectedResourceCount, "unexpected number of resources")
+			for _, raw := range ss.Spec.Resources {
+				obj := &unstructured.Unstructured{}
+				require.NoError(t, json.Unmarshal(raw.Raw, &obj.Object), "unexpected error unmarshalling resource")
+				switch obj.GetKind() {
+				case "KubeletConfig":
+					kubeletConfig, _, _ := unstructured.NestedMap(obj.Object, "spec", "kubeletConfig")
+					actual, _ := json.Marshal(kubeletConfig)
+					assert.JSONEq(t, test.expectKubeletConfig, string(actual), "unexpected kubelet config")
+				case "MachineConfig":
+					files, _, _ := unstructured.NestedSlice(obj.Object, "spec", "config", "storage", "files")
+					require.Len(t, files, 1, "expected a single file")
+					source, _, _ := unstructured.NestedString(files[0].(map[string]interface{}), "contents", "source")
+					data, err := base64.StdEncoding.DecodeString(source[strings.Index(source, ",")+1:])
+					require.NoError(t, err, "unexpected error decoding file contents")
+					switch role := obj.GetLabels()[machineConfigRoleLabel]; role {
+					case "worker":
+						assert.Equal(t, test.expectSysctlConf, string(data), "unexpected sysctl conf")
+					case "hive-" + test.expectMachinePool:
+						assert.Equal(t, test.expectPoolSysctlConf, string(data), "unexpected machine pool sysctl conf")
+					default:
+						t.Errorf("unexpected machine config role %s", role)
+					}
+				case "MachineConfigPool":
+					assert.Equal(t, "hive-"+test.expectMachinePool, obj.GetName(), "unexpected machine config pool name")
+					nodeSelector, _, _ := unstructured.NestedStringMap(obj.Object, "spec", "nodeSelector", "matchLabels")
+					assert.Equal(t, map[string]string{MachinePoolNodeRoleLabel(test.expectMachinePool): ""}, nodeSelector, "unexpected node selector")
+				default:
+					t.Errorf("unexpected resource kind %s", obj.GetKind())
+				}
+			}
+		})
+	}
+}
+
+func TestRequestsForSysctlProfile(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+	profile := testSysctlProfile("base", nil)
+	referencing := testClusterDeployment(&hivev1.NodeTuning{SysctlProfileRefs: []corev1.LocalObjectReference{{Name: "base"}}})
+	poolReferencing := testClusterDeployment(&hivev1.NodeTuning{MachinePools: []hivev1.MachinePoolNodeTuning{{
+		Name:              "infra",
+		SysctlProfileRefs: []corev1.LocalObjectReference{{Name: "base"}},
+	}}})
+	poolReferencing.Name = "pool-cluster"
+	other := testClusterDeployment(&hivev1.NodeTuning{SysctlProfileRefs: []corev1.LocalObjectReference{{Name: "other"}}})
+	other.Name = "other-cluster"
+	c := fake.NewFakeClientWithScheme(scheme.Scheme, referencing, poolReferencing, other)
+
+	requests := requestsForSysctlProfile(c)(handler.MapObject{Meta: profile, Object: profile})
+	assert.ElementsMatch(t, []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: testName}},
+		{NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: "pool-cluster"}},
+	}, requests)
+}
+
+func testClusterDeployment(nodeTuning *hivev1.NodeTuning) *hivev1.ClusterDeployment {
+	return &hivev1.ClusterDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testName,
+			Namespace: testNamespace,
+			UID:       types.UID("1234"),
+		},
+		Spec: hivev1.ClusterDeploymentSpec{
+			ClusterName: testName,
+			Installed:   true,
+			NodeTuning:  nodeTuning,
+		},
+	}
+}
+
+func testSysctlProfile(name string, sysctls map[string]string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: testNamespace,
+		},
+		Data: sysctls,
+	}
+}
+
+type fakeApplier struct {
+	appliedObjects []runtime.Object
+}
+
+func (a *fakeApplier) ApplyRuntimeObject(obj runtime.Object, scheme *runtime.Scheme) (resource.ApplyResult, error) {
+	a.appliedObjects = append(a.appliedObjects, obj)
+	return "", nil
+}
@@ -0,0 +1,458 @@
+package remoteaccess
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdv1 "k8s.io/client-go/tools/clientcmd/api/v1"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/client-go/util/workqueue"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+	"sigs.k8s.io/yaml"
+
+	apihelpers "github.com/openshift/hive/apis/helpers"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/remoteclient"
+	"github.com/openshift/hive/pkg/resource"
+	k8slabels "github.com/openshift/hive/pkg/util/labels"
+)
+
+const (
+	ControllerName = hivev1.RemoteAccessControllerName
+
+	// tokenLifetime is how long the tokens minted for the controllers are valid.
+	tokenLifetime = 1 * time.Hour
+
+	// tokenRefreshBefore is how long before its expiration a token is replaced with a new one.
+	tokenRefreshBefore = 15 * time.Minute
+
+	// durationBetweenServiceAccountChecks is how often Hive checks whether the service account has been synced to
+	// the cluster.
+	durationBetweenServiceAccountChecks = 1 * time.Minute
+
+	// contextName is the name of the context, cluster and user in the controller kubeconfig.
+	contextName = "hive-controller"
+
+	// remoteAccessRoleName is the name of the ClusterRole and ClusterRoleBinding granting the remote access service
+	// account its permissions.
+	remoteAccessRoleName = "hive-controller"
+)
+
+var (
+	// statusRules are the permissions needed to report the status of the cluster.
+	statusRules = []rbacv1.PolicyRule{
+		{
+			APIGroups: []string{"config.openshift.io"},
+			Resources: []string{"clusterversions", "clusteroperators", "infrastructures"},
+			Verbs:     []string{"get", "list", "watch"},
+		},
+		{
+			APIGroups:     []string{"route.openshift.io"},
+			Resources:     []string{"routes"},
+			ResourceNames: []string{"console"},
+			Verbs:         []string{"get"},
+		},
+	}
+
+	// machineAPIRules are the permissions needed to manage the machine pools of the cluster.
+	machineAPIRules = []rbacv1.PolicyRule{
+		{
+			APIGroups: []string{"machine.openshift.io"},
+			Resources: []string{"machines"},
+			Verbs:     []string{"get", "list", "watch"},
+		},
+		{
+			APIGroups: []string{"machine.openshift.io"},
+			Resources: []string{"machinesets"},
+			Verbs:     []string{"get", "list", "watch", "create", "update", "patch", "delete"},
+		},
+		{
+			APIGroups: []string{"autoscaling.openshift.io"},
+			Resources: []string{"machineautoscalers", "clusterautoscalers"},
+			Verbs:     []string{"get", "list", "watch", "create", "update", "patch", "delete"},
+		},
+	}
+
+	// hibernationRules are the permissions needed to hibernate and resume the cluster.
+	hibernationRules = []rbacv1.PolicyRule{
+		{
+			APIGroups: []string{""},
+			Resources: []string{"nodes"},
+			Verbs:     []string{"get", "list", "watch"},
+		},
+		{
+			APIGroups: []string{"certificates.k8s.io"},
+			Resources: []string{"certificatesigningrequests"},
+			Verbs:     []string{"get", "list", "watch"},
+		},
+		{
+			APIGroups: []string{"certificates.k8s.io"},
+			Resources: []string{"certificatesigningrequests/approval"},
+			Verbs:     []string{"update"},
+		},
+		{
+			APIGroups:     []string{"certificates.k8s.io"},
+			Resources:     []string{"signers"},
+			ResourceNames: []string{"kubernetes.io/kube-apiserver-client-kubelet", "kubernetes.io/kubelet-serving"},
+			Verbs:         []string{"approve"},
+		},
+	}
+)
+
+type applier interface {
+	ApplyRuntimeObject(obj runtime.Object, scheme *runtime.Scheme) (resource.ApplyResult, error)
+}
+
+// Add creates a new RemoteAccess controller and adds it to the manager with default RBAC. The controller is only
+// added when scoped remote access has been enabled.
+func Add(mgr manager.Manager) error {
+	logger := log.WithField("controller", ControllerName)
+	if os.Getenv(constants.ScopedRemoteAccessEnvVar) != "true" {
+		logger.Debug("scoped remote access is not enabled")
+		return nil
+	}
+	concurrentReconciles, clientRateLimiter, queueRateLimiter, err := controllerutils.GetControllerConfig(mgr.GetClient(), ControllerName)
+	if err != nil {
+		logger.WithError(err).Error("could not get controller configurations")
+		return err
+	}
+	return AddToManager(mgr, NewReconciler(mgr, clientRateLimiter), concurrentReconciles, queueRateLimiter)
+}
+
+// NewReconciler returns a new reconcile.Reconciler
+func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) reconcile.Reconciler {
+	logger := log.WithField("controller", ControllerName)
+	helper, err := resource.NewHelperWithMetricsFromRESTConfig(mgr.GetConfig(), ControllerName, logger)
+	if err != nil {
+		// Hard exit if we can't create this controller
+		logger.WithError(err).Fatal("unable to create resource helper")
+	}
+	restricted, syncSetAPIGroups := remoteclient.RestrictedRemoteAccess()
+	r := &ReconcileRemoteAccess{
+		Client:           controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
+		scheme:           mgr.GetScheme(),
+		applier:          helper,
+		restricted:       restricted,
+		syncSetAPIGroups: syncSetAPIGroups,
+	}
+	r.remoteClusterAPIClientBuilder = func(cd *hivev1.ClusterDeployment) remoteclient.Builder {
+		return remoteclient.NewAdminBuilder(r.Client, cd, ControllerName)
+	}
+	return r
+}
+
+// AddToManager adds a new Controller to mgr with r as the reconcile.Reconciler
+func AddToManager(mgr manager.Manager, r reconcile.Reconciler, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
+	c, err := controller.New("remoteaccess-controller", mgr, controller.Options{
+		Reconciler:              r,
+		MaxConcurrentReconciles: concurrentReconciles,
+		RateLimiter:             rateLimiter,
+	})
+	if err != nil {
+		log.WithField("controller", ControllerName).WithError(err).Error("Error creating new remoteaccess controller")
+		return err
+	}
+
+	// Watch for changes to ClusterDeployment
+	if err := c.Watch(&source.Kind{Type: &hivev1.ClusterDeployment{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		log.WithField("controller", ControllerName).WithError(err).Error("Error watching cluster deployments")
+		return err
+	}
+
+	// Watch for changes to the controller kubeconfig secrets
+	if err := c.Watch(&source.Kind{Type: &corev1.Secret{}}, &handler.EnqueueRequestForOwner{
+		IsController: true,
+		OwnerType:    &hivev1.ClusterDeployment{},
+	}); err != nil {
+		log.WithField("controller", ControllerName).WithError(err).Error("Error watching secrets")
+		return err
+	}
+	return nil
+}
+
+var _ reconcile.Reconciler = &ReconcileRemoteAccess{}
+
+// ReconcileRemoteAccess reconciles the least-privilege service account used by the controllers to access the
+// cluster of a ClusterDeployment, and the short-lived tokens minted for it.
+type ReconcileRemoteAccess struct {
+	client.Client
+	scheme  *runtime.Scheme
+	applier applier
+
+	// restricted is whether Hive is limited to the Restricted remote access RBAC mode.
+	restricted bool
+	// syncSetAPIGroups are the API groups of the resources which SyncSets can manage in the Restricted mode.
+	syncSetAPIGroups []string
+
+	// remoteClusterAPIClientBuilder is a function pointer to the function that gets a builder for building a client
+	// for the remote cluster's API server
+	remoteClusterAPIClientBuilder func(cd *hivev1.ClusterDeployment) remoteclient.Builder
+}
+
+// Reconcile syncs the remote access service account to the cluster of a ClusterDeployment, and refreshes the
+// controller kubeconfig holding a short-lived token of the service account before the token expires.
+func (r *ReconcileRemoteAccess) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	cdLog := controllerutils.BuildControllerLogger(ControllerName, "clusterDeployment", request.NamespacedName)
+	cdLog.Info("reconciling cluster deployment")
+	recobsrv := hivemetrics.NewReconcileObserver(ControllerName, cdLog)
+	defer recobsrv.ObserveControllerReconcileTime()
+
+	cd := &hivev1.ClusterDeployment{}
+	if err := r.Get(context.TODO(), request.NamespacedName, cd); err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		cdLog.WithError(err).Error("error getting cluster deployment")
+		return reconcile.Result{}, err
+	}
+
+	// If the clusterdeployment is deleted, do not reconcile.
+	if cd.DeletionTimestamp != nil {
+		return reconcile.Result{}, nil
+	}
+
+	if !cd.Spec.Installed || cd.Spec.ClusterMetadata == nil || controllerutils.IsFakeCluster(cd) {
+		return reconcile.Result{}, nil
+	}
+
+	syncSet, err := GenerateRemoteAccessSyncSet(cd, r.restricted, r.syncSetAPIGroups)
+	if err != nil {
+		cdLog.WithError(err).Error("error generating remote access syncset")
+		return reconcile.Result{}, err
+	}
+	if err := controllerutil.SetControllerReference(cd, syncSet, r.scheme); err != nil {
+		cdLog.WithError(err).Error("error setting owner reference")
+		return reconcile.Result{}, err
+	}
+	result, err := r.applier.ApplyRuntimeObject(syncSet, r.scheme)
+	if err != nil {
+		cdLog.WithError(err).Error("error applying remote access syncset")
+		return reconcile.Result{}, err
+	}
+	cdLog.WithField("syncSet", syncSet.Name).Debugf("remote access syncset applied (%s)", result)
+
+	kubeconfigSecret := &corev1.Secret{}
+	switch err := r.Get(context.TODO(), types.NamespacedName{Namespace: cd.Namespace, Name: constants.GetControllerKubeconfigSecretName(cd)}, kubeconfigSecret); {
+	case apierrors.IsNotFound(err):
+	case err != nil:
+		cdLog.WithError(err).Error("error getting controller kubeconfig secret")
+		return reconcile.Result{}, err
+	default:
+		if expiration, err := time.Parse(time.RFC3339, kubeconfigSecret.Annotations[constants.TokenExpirationAnnotation]); err == nil {
+			if refresh := time.Until(expiration) - tokenRefreshBefore; refresh > 0 {
+				cdLog.WithField("expiration", expiration).Debug("controller token is still valid")
+				return reconcile.Result{RequeueAfter: refresh}, nil
+			}
+		}
+	}
+
+	if unreachable, _ := remoteclient.Unreachable(cd); unreachable {
+		cdLog.Debug("cluster is unreachable, controllers will use the admin kubeconfig")
+		return reconcile.Result{}, nil
+	}
+
+	kubeClient, err := r.remoteClusterAPIClientBuilder(cd).BuildKubeClient()
+	if err != nil {
+		cdLog.WithError(err).Error("error building remote kube client")
+		return reconcile.Result{}, err
+	}
+	expirationSeconds := int64(tokenLifetime.Seconds())
+	tokenRequest, err := kubeClient.CoreV1().ServiceAccounts(constants.RemoteAccessNamespace).CreateToken(
+		context.TODO(),
+		constants.RemoteAccessServiceAccountName,
+		&authenticationv1.TokenRequest{Spec: authenticationv1.TokenRequestSpec{ExpirationSeconds: &expirationSeconds}},
+		metav1.CreateOptions{},
+	)
+	switch {
+	case apierrors.IsNotFound(err):
+		cdLog.Info("remote access service account has not been synced yet, controllers will use the admin kubeconfig")
+		return reconcile.Result{RequeueAfter: durationBetweenServiceAccountChecks}, nil
+	case err != nil:
+		cdLog.WithError(err).Error("error minting controller token")
+		return reconcile.Result{}, err
+	}
+
+	if err := r.saveControllerKubeconfig(cd, tokenRequest.Status.Token, tokenRequest.Status.ExpirationTimestamp.Time, cdLog); err != nil {
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{RequeueAfter: time.Until(tokenRequest.Status.ExpirationTimestamp.Time) - tokenRefreshBefore}, nil
+}
+
+// saveControllerKubeconfig saves the controller kubeconfig with the token, built from the admin kubeconfig of the
+// clusterdeployment.
+func (r *ReconcileRemoteAccess) saveControllerKubeconfig(cd *hivev1.ClusterDeployment, token string, expiration time.Time, cdLog log.FieldLogger) error {
+	adminKubeconfigSecret := &corev1.Secret{}
+	if err := r.Get(context.TODO(), types.NamespacedName{Namespace: cd.Namespace, Name: cd.Spec.ClusterMetadata.AdminKubeconfigSecretRef.Name}, adminKubeconfigSecret); err != nil {
+		cdLog.WithError(err).Error("error getting admin kubeconfig secret")
+		return err
+	}
+	kubeconfig, err := controllerKubeconfig(adminKubeconfigSecret.Data[constants.KubeconfigSecretKey], token)
+	if err != nil {
+		cdLog.WithError(err).Error("error building controller kubeconfig")
+		return err
+	}
+
+	kubeconfigSecret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: cd.Namespace, Name: constants.GetControllerKubeconfigSecretName(cd)}}
+	if _, err := controllerutil.CreateOrUpdate(context.TODO(), r.Client, kubeconfigSecret, func() error {
+		kubeconfigSecret.Labels = k8slabels.AddLabel(kubeconfigSecret.Labels, constants.ClusterDeploymentNameLabel, cd.Name)
+		kubeconfigSecret.Labels = k8slabels.AddLabel(kubeconfigSecret.Labels, constants.SecretTypeLabel, constants.SecretTypeControllerKubeconfig)
+		if kubeconfigSecret.Annotations == nil {
+			kubeconfigSecret.Annotations = map[string]string{}
+		}
+		kubeconfigSecret.Annotations[constants.TokenExpirationAnnotation] = expiration.UTC().Format(time.RFC3339)
+		kubeconfigSecret.Data = map[string][]byte{constants.KubeconfigSecretKey: kubeconfig}
+		return controllerutil.SetControllerReference(cd, kubeconfigSecret, r.scheme)
+	}); err != nil {
+		cdLog.WithError(err).Log(controllerutils.LogLevel(err), "error saving controller kubeconfig secret")
+		return err
+	}
+	cdLog.WithField("expiration", expiration).Info("refreshed controller token")
+	return nil
+}
+
+// controllerKubeconfig returns a kubeconfig for the cluster of the admin kubeconfig which authenticates with the
+// token.
+func controllerKubeconfig(adminKubeconfig []byte, token string) ([]byte, error) {
+	adminConfig, err := clientcmd.Load(adminKubeconfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not load admin kubeconfig")
+	}
+	adminContextName, _ := controllerutils.AdminKubeconfigContext(adminConfig)
+	adminContext, ok := adminConfig.Contexts[adminContextName]
+	if !ok {
+		return nil, errors.Errorf("admin kubeconfig is missing context %q", adminContextName)
+	}
+	cluster, ok := adminConfig.Clusters[adminContext.Cluster]
+	if !ok {
+		return nil, errors.Errorf("admin kubeconfig is missing cluster %q", adminContext.Cluster)
+	}
+	config := clientcmdv1.Config{
+		Clusters: []clientcmdv1.NamedCluster{{
+			Name: contextName,
+			Cluster: clientcmdv1.Cluster{
+				Server:                   cluster.Server,
+				TLSServerName:            cluster.TLSServerName,
+				InsecureSkipTLSVerify:    cluster.InsecureSkipTLSVerify,
+				CertificateAuthorityData: cluster.CertificateAuthorityData,
+				ProxyURL:                 cluster.ProxyURL,
+			},
+		}},
+		AuthInfos: []clientcmdv1.NamedAuthInfo{{
+			Name:     contextName,
+			AuthInfo: clientcmdv1.AuthInfo{Token: token},
+		}},
+		Contexts: []clientcmdv1.NamedContext{{
+			Name:    contextName,
+			Context: clientcmdv1.Context{Cluster: contextName, AuthInfo: contextName},
+		}},
+		CurrentContext: contextName,
+	}
+	return yaml.Marshal(config)
+}
+
+// remoteAccessRules returns the permissions granted to the remote access service account. In the Restricted mode,
+// the service account also applies the SyncSets, and can manage any resource of the API groups allowed for SyncSets.
+func remoteAccessRules(restricted bool, syncSetAPIGroups []string) []rbacv1.PolicyRule {
+	rules := append(append([]rbacv1.PolicyRule{}, statusRules...), machineAPIRules...)
+	if !restricted {
+		return append(rules, hibernationRules...)
+	}
+	for _, group := range syncSetAPIGroups {
+		rules = append(rules, rbacv1.PolicyRule{
+			APIGroups: []string{group},
+			Resources: []string{"*"},
+			Verbs:     []string{"*"},
+		})
+	}
+	return rules
+}
+
+// GenerateRemoteAccessSyncSet generates the syncset of the remote access service account of the clusterdeployment.
+func GenerateRemoteAccessSyncSet(cd *hivev1.ClusterDeployment, restricted bool, syncSetAPIGroups []string) (*hivev1.SyncSet, error) {
+	objs := []runtime.Object{
+		&corev1.Namespace{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Namespace"},
+			ObjectMeta: metav1.ObjectMeta{Name: constants.RemoteAccessNamespace},
+		},
+		&corev1.ServiceAccount{
+			TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "ServiceAccount"},
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: constants.RemoteAccessNamespace,
+				Name:      constants.RemoteAccessServiceAccountName,
+			},
+		},
+		&rbacv1.ClusterRole{
+			TypeMeta:   metav1.TypeMeta{APIVersion: rbacv1.SchemeGroupVersion.String(), Kind: "ClusterRole"},
+			ObjectMeta: metav1.ObjectMeta{Name: remoteAccessRoleName},
+			Rules:      remoteAccessRules(restricted, syncSetAPIGroups),
+		},
+		&rbacv1.ClusterRoleBinding{
+			TypeMeta:   metav1.TypeMeta{APIVersion: rbacv1.SchemeGroupVersion.String(), Kind: "ClusterRoleBinding"},
+			ObjectMeta: metav1.ObjectMeta{Name: remoteAccessRoleName},
+			Subjects: []rbacv1.Subject{{
+				Kind:      rbacv1.ServiceAccountKind,
+				Namespace: constants.RemoteAccessNamespace,
+				Name:      constants.RemoteAccessServiceAccountName,
+			}},
+			RoleRef: rbacv1.RoleRef{
+				APIGroup: rbacv1.GroupName,
+				Kind:     "ClusterRole",
+				Name:     remoteAccessRoleName,
+			},
+		},
+	}
+	resources := make([]runtime.RawExtension, 0, len(objs))
+	for _, obj := range objs {
+		raw, err := json.Marshal(obj)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not render remote access resource")
+		}
+		resources = append(resources, runtime.RawExtension{Raw: raw})
+	}
+
+	syncSet := &hivev1.SyncSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        GenerateRemoteAccessSyncSetName(cd.Name),
+			Namespace:   cd.Namespace,
+			Annotations: map[string]string{constants.SyncSetMetricsGroupAnnotation: "remote-access"},
+		},
+		Spec: hivev1.SyncSetSpec{
+			SyncSetCommonSpec: hivev1.SyncSetCommonSpec{
+				ResourceApplyMode: hivev1.SyncResourceApplyMode,
+				Resources:         resources,
+			},
+			ClusterDeploymentRefs: []corev1.LocalObjectReference{{Name: cd.Name}},
+		},
+	}
+	syncSet.Labels = k8slabels.AddLabel(syncSet.Labels, constants.ClusterDeploymentNameLabel, cd.Name)
+	syncSet.Labels = k8slabels.AddLabel(syncSet.Labels, constants.SyncSetTypeLabel, constants.SyncSetTypeRemoteAccess)
+	return syncSet, nil
+}
+
+// GenerateRemoteAccessSyncSetName returns the name of the remote access syncset of a clusterdeployment.
+func GenerateRemoteAccessSyncSetName(name string) string {
+	return apihelpers.GetResourceName(name, "remote-access")
+}
@@ -0,0 +1,270 @@
+package remoteaccess
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	clienttesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/openshift/hive/apis"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/remoteclient"
+	remoteclientmock "github.com/openshift/hive/pkg/remoteclient/mock"
+	"github.com/openshift/hive/pkg/resource"
+)
+
+const (
+	testName                 = "test-cluster"
+	testNamespace            = "test-namespace"
+	testKubeconfigSecretName = "test-kubeconfig"
+)
+
+func init() {
+	log.SetLevel(log.DebugLevel)
+}
+
+func TestReconcileRemoteAccess(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+
+	tests := []struct {
+		name               string
+		cd                 *hivev1.ClusterDeployment
+		existingExpiration *time.Time
+		serviceAccountGone bool
+		expectApply        bool
+		expectTokenRequest bool
+		expectToken        string
+		expectRequeue      bool
+	}{
+		{
+			name: "not installed",
+			cd: func() *hivev1.ClusterDeployment {
+				cd := testClusterDeployment()
+				cd.Spec.Installed = false
+				return cd
+			}(),
+		},
+		{
+			name:               "mint token",
+			cd:                 testClusterDeployment(),
+			expectApply:        true,
+			expectTokenRequest: true,
+			expectToken:        "minted-token",
+			expectRequeue:      true,
+		},
+		{
+			name:               "token still valid",
+			cd:                 testClusterDeployment(),
+			existingExpiration: func() *time.Time { t := time.Now().Add(tokenLifetime); return &t }(),
+			expectApply:        true,
+			expectToken:        "existing-token",
+			expectRequeue:      true,
+		},
+		{
+			name:               "refresh expiring token",
+			cd:                 testClusterDeployment(),
+			existingExpiration: func() *time.Time { t := time.Now().Add(tokenRefreshBefore / 2); return &t }(),
+			expectApply:        true,
+			expectTokenRequest: true,
+			expectToken:        "minted-token",
+			expectRequeue:      true,
+		},
+		{
+			name:               "service account not synced yet",
+			cd:                 testClusterDeployment(),
+			serviceAccountGone: true,
+			expectApply:        true,
+			expectTokenRequest: true,
+			expectRequeue:      true,
+		},
+		{
+			name: "unreachable",
+			cd: func() *hivev1.ClusterDeployment {
+				cd := testClusterDeployment()
+				cd.Status.Conditions[0].Status = corev1.ConditionTrue
+				return cd
+			}(),
+			expectApply: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			existing := []runtime.Object{test.cd, testAdminKubeconfigSecret(t)}
+			if test.existingExpiration != nil {
+				existing = append(existing, testControllerKubeconfigSecret(t, "existing-token", *test.existingExpiration))
+			}
+			c := fake.NewFakeClientWithScheme(scheme.Scheme, existing...)
+			applier := &fakeApplier{}
+
+			kubeClient := kubefake.NewSimpleClientset()
+			tokenRequested := false
+			kubeClient.PrependReactor("create", "serviceaccounts", func(action clienttesting.Action) (bool, runtime.Object, error) {
+				if action.GetSubresource() != "token" {
+					return false, nil, nil
+				}
+				tokenRequested = true
+				assert.Equal(t, constants.RemoteAccessNamespace, action.GetNamespace(), "unexpected service account namespace")
+				if test.serviceAccountGone {
+					return true, nil, apierrors.NewNotFound(corev1.Resource("serviceaccounts"), constants.RemoteAccessServiceAccountName)
+				}
+				return true, &authenticationv1.TokenRequest{
+					Status: authenticationv1.TokenRequestStatus{
+						Token:               "minted-token",
+						ExpirationTimestamp: metav1.NewTime(time.Now().Add(tokenLifetime)),
+					},
+				}, nil
+			})
+			builder := remoteclientmock.NewMockBuilder(mockCtrl)
+			if test.expectTokenRequest {
+				builder.EXPECT().BuildKubeClient().Return(kubeClient, nil)
+			}
+
+			r := &ReconcileRemoteAccess{
+				Client:  c,
+				scheme:  scheme.Scheme,
+				applier: applier,
+				remoteClusterAPIClientBuilder: func(*hivev1.ClusterDeployment) remoteclient.Builder {
+					return builder
+				},
+			}
+			result, err := r.Reconcile(reconcile.Request{NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: testName}})
+			require.NoError(t, err, "unexpected error from reconcile")
+			assert.Equal(t, test.expectRequeue, result.RequeueAfter > 0, "unexpected requeue")
+			assert.Equal(t, test.expectTokenRequest, tokenRequested, "unexpected token request")
+
+			if test.expectApply {
+				require.Len(t, applier.appliedObjects, 1, "single apply expected")
+				ss, ok := applier.appliedObjects[0].(*hivev1.SyncSet)
+				require.True(t, ok, "syncset apply expected")
+				assert.Equal(t, GenerateRemoteAccessSyncSetName(testName), ss.Name, "unexpected syncset name")
+				assert.Equal(t, constants.SyncSetTypeRemoteAccess, ss.Labels[constants.SyncSetTypeLabel], "unexpected syncset type label")
+				assert.Len(t, ss.Spec.Resources, 4, "unexpected number of resources")
+			} else {
+				assert.Empty(t, applier.appliedObjects, "unexpected syncset apply")
+			}
+
+			secret := &corev1.Secret{}
+			err = c.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: constants.GetControllerKubeconfigSecretName(test.cd)}, secret)
+			if test.expectToken == "" {
+				assert.True(t, apierrors.IsNotFound(err), "expected no controller kubeconfig secret")
+				return
+			}
+			require.NoError(t, err, "unexpected error getting controller kubeconfig secret")
+			config, err := clientcmd.Load(secret.Data[constants.KubeconfigSecretKey])
+			require.NoError(t, err, "unexpected error loading controller kubeconfig")
+			assert.Equal(t, test.expectToken, config.AuthInfos[contextName].Token, "unexpected token")
+			assert.Equal(t, "https://api.hive-cluster.example.com:6443", config.Clusters[contextName].Server, "unexpected server")
+			expiration, err := time.Parse(time.RFC3339, secret.Annotations[constants.TokenExpirationAnnotation])
+			require.NoError(t, err, "unexpected error parsing token expiration")
+			assert.True(t, expiration.After(time.Now().Add(tokenRefreshBefore)), "unexpected token expiration")
+		})
+	}
+}
+
+func testClusterDeployment() *hivev1.ClusterDeployment {
+	return &hivev1.ClusterDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testName,
+			Namespace: testNamespace,
+			UID:       types.UID("1234"),
+		},
+		Spec: hivev1.ClusterDeploymentSpec{
+			ClusterName: testName,
+			Installed:   true,
+			ClusterMetadata: &hivev1.ClusterMetadata{
+				AdminKubeconfigSecretRef: corev1.LocalObjectReference{Name: testKubeconfigSecretName},
+			},
+		},
+		Status: hivev1.ClusterDeploymentStatus{
+			Conditions: []hivev1.ClusterDeploymentCondition{{
+				Type:   hivev1.UnreachableCondition,
+				Status: corev1.ConditionFalse,
+			}},
+		},
+	}
+}
+
+func testAdminKubeconfigSecret(t *testing.T) *corev1.Secret {
+	kubeconfig, err := ioutil.ReadFile(filepath.Join("..", "..", "remoteclient", "testdata", "kubeconfig.sample"))
+	require.NoError(t, err, "unexpected error reading kubeconfig")
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNamespace,
+			Name:      testKubeconfigSecretName,
+		},
+		Data: map[string][]byte{constants.KubeconfigSecretKey: kubeconfig},
+	}
+}
+
+func testControllerKubeconfigSecret(t *testing.T, token string, expiration time.Time) *corev1.Secret {
+	kubeconfig, err := controllerKubeconfig(testAdminKubeconfigSecret(t).Data[constants.KubeconfigSecretKey], token)
+	require.NoError(t, err, "unexpected error building controller kubeconfig")
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   testNamespace,
+			Name:        constants.GetControllerKubeconfigSecretName(testClusterDeployment()),
+			Annotations: map[string]string{constants.TokenExpirationAnnotation: expiration.Format(time.RFC3339)},
+		},
+		Data: map[string][]byte{constants.KubeconfigSecretKey: kubeconfig},
+	}
+}
+
+type fakeApplier struct {
+	appliedObjects []runtime.Object
+}
+
+func (a *fakeApplier) ApplyRuntimeObject(obj runtime.Object, scheme *runtime.Scheme) (resource.ApplyResult, error) {
+	a.appliedObjects = append(a.appliedObjects, obj)
+	return "", nil
+}
+
+func TestRemoteAccessRules(t *testing.T) {
+	hasGroup := func(rules []rbacv1.PolicyRule, group string) bool {
+		for _, rule := range rules {
+			for _, g := range rule.APIGroups {
+				if g == group {
+					return true
+				}
+			}
+		}
+		return false
+	}
+
+	defaultRules := remoteAccessRules(false, []string{"operator.openshift.io"})
+	assert.True(t, hasGroup(defaultRules, "certificates.k8s.io"), "expected hibernation rules in the Default mode")
+	assert.False(t, hasGroup(defaultRules, "operator.openshift.io"), "unexpected syncset rules in the Default mode")
+
+	restrictedRules := remoteAccessRules(true, []string{"operator.openshift.io"})
+	assert.False(t, hasGroup(restrictedRules, "certificates.k8s.io"), "unexpected hibernation rules in the Restricted mode")
+	assert.True(t, hasGroup(restrictedRules, "machine.openshift.io"), "expected machine API rules in the Restricted mode")
+	assert.Contains(t, restrictedRules, rbacv1.PolicyRule{
+		APIGroups: []string{"operator.openshift.io"},
+		Resources: []string{"*"},
+		Verbs:     []string{"*"},
+	}, "expected syncset rules in the Restricted mode")
+}
@@ -54,6 +54,10 @@ const (
 	// requeueAfter2 is just a static 2 minute delay for when to requeue
 	// for the case when a necessary secret is missing
 	requeueAfter2 = time.Minute * 2
+
+	// servingCertificateHashAnnotation is set on the IngressController objects to a hash of their serving
+	// certificate, so that the SyncSet changes, and the certificate is synced again, when the secret is rotated.
+	servingCertificateHashAnnotation = "hive.openshift.io/serving-certificate-hash"
 )
 
 // kubeCLIApplier knows how to ApplyRuntimeObject.
@@ -107,9 +111,42 @@ func AddToManager(mgr manager.Manager, r reconcile.Reconciler, concurrentReconci
 		return err
 	}
 
+	// Watch for changes to the secrets of the certificate bundles served by the ingress controllers
+	err = c.Watch(&source.Kind{Type: &corev1.Secret{}}, &handler.EnqueueRequestsFromMapFunc{
+		ToRequests: requestsForCertificateBundleSecret(mgr.GetClient()),
+	})
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
+// requestsForCertificateBundleSecret returns a mapper of Secrets to the ClusterDeployments with ingress controllers
+// serving them as certificates.
+func requestsForCertificateBundleSecret(c client.Client) handler.ToRequestsFunc {
+	return func(o handler.MapObject) []reconcile.Request {
+		cds := &hivev1.ClusterDeploymentList{}
+		if err := c.List(context.TODO(), cds, client.InNamespace(o.Meta.GetNamespace())); err != nil {
+			log.WithField("controller", ControllerName).WithError(err).Error("error listing cluster deployments")
+			return nil
+		}
+		var requests []reconcile.Request
+		for _, cd := range cds.Items {
+			if len(cd.Spec.Ingress) == 0 {
+				continue
+			}
+			for _, cb := range cd.Spec.CertificateBundles {
+				if cb.CertificateSecretRef.Name == o.Meta.GetName() {
+					requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: cd.Namespace, Name: cd.Name}})
+					break
+				}
+			}
+		}
+		return requests
+	}
+}
+
 type reconcileContext struct {
 	clusterDeployment *hivev1.ClusterDeployment
 	certBundleSecrets []*corev1.Secret
@@ -323,9 +360,25 @@ func createIngressController(cd *hivev1.ClusterDeployment, ingress hivev1.Cluste
 			Domain:            ingress.Domain,
 			RouteSelector:     ingress.RouteSelector,
 			NamespaceSelector: ingress.NamespaceSelector,
+			Replicas:          ingress.Replicas,
 		},
 	}
 
+	if ingress.Scope != "" {
+		newIngress.Spec.EndpointPublishingStrategy = &ingresscontroller.EndpointPublishingStrategy{
+			Type: ingresscontroller.LoadBalancerServiceStrategyType,
+			LoadBalancer: &ingresscontroller.LoadBalancerStrategy{
+				Scope: ingresscontroller.LoadBalancerScope(ingress.Scope),
+			},
+		}
+	}
+
+	if ingress.NodeSelector != nil {
+		newIngress.Spec.NodePlacement = &ingresscontroller.NodePlacement{
+			NodeSelector: ingress.NodeSelector,
+		}
+	}
+
 	// if the ingress entry references a certBundle, make sure to put the appropriate looking
 	// entry in the ingressController object
 	if ingress.ServingCertificate != "" {
@@ -335,6 +388,12 @@ func createIngressController(cd *hivev1.ClusterDeployment, ingress hivev1.Cluste
 				newIngress.Spec.DefaultCertificate = &corev1.LocalObjectReference{
 					Name: remoteSecretNameForCertificateBundleSecret(cb.CertificateSecretRef.Name, cd),
 				}
+				for _, secret := range secrets {
+					if secret.Name == cb.CertificateSecretRef.Name {
+						newIngress.Annotations = map[string]string{servingCertificateHashAnnotation: secretHash(secret)}
+						break
+					}
+				}
 				break
 			}
 		}
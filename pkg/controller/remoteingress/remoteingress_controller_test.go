@@ -16,6 +16,7 @@ import (
 	"k8s.io/client-go/kubernetes/scheme"
 
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	ingresscontroller "github.com/openshift/api/operator/v1"
@@ -720,3 +721,58 @@ func TestSecretHash(t *testing.T) {
 		t.Errorf("hashes expected to be equal")
 	}
 }
+
+func TestCreateIngressControllerForShard(t *testing.T) {
+	cd := testClusterDeploymentWithManualCertificate()
+	replicas := int32(3)
+	nodeSelector := &metav1.LabelSelector{MatchLabels: map[string]string{"node-role.kubernetes.io/infra": ""}}
+	cd.Spec.Ingress[0].Domain = "apps.custom.example.org"
+	cd.Spec.Ingress[0].Replicas = &replicas
+	cd.Spec.Ingress[0].Scope = hivev1.IngressScopeInternal
+	cd.Spec.Ingress[0].NodeSelector = nodeSelector
+	secret := testSecretForCertificateBundle(cd.Spec.CertificateBundles[0])
+
+	ic := createIngressController(cd, cd.Spec.Ingress[0], []*corev1.Secret{&secret})
+
+	assert.Equal(t, "apps.custom.example.org", ic.Spec.Domain, "unexpected domain")
+	assert.Equal(t, &replicas, ic.Spec.Replicas, "unexpected replicas")
+	if assert.NotNil(t, ic.Spec.EndpointPublishingStrategy, "expected endpoint publishing strategy") {
+		assert.Equal(t, ingresscontroller.LoadBalancerServiceStrategyType, ic.Spec.EndpointPublishingStrategy.Type, "unexpected endpoint publishing strategy")
+		if assert.NotNil(t, ic.Spec.EndpointPublishingStrategy.LoadBalancer, "expected load balancer strategy") {
+			assert.Equal(t, ingresscontroller.InternalLoadBalancer, ic.Spec.EndpointPublishingStrategy.LoadBalancer.Scope, "unexpected load balancer scope")
+		}
+	}
+	if assert.NotNil(t, ic.Spec.NodePlacement, "expected node placement") {
+		assert.Equal(t, nodeSelector, ic.Spec.NodePlacement.NodeSelector, "unexpected node selector")
+	}
+	assert.Equal(t, secretHash(&secret), ic.Annotations[servingCertificateHashAnnotation], "unexpected serving certificate hash")
+
+	// Rotating the certificate changes the IngressController, so that the SyncSet is applied again.
+	secret.Data[constants.TLSCrtSecretKey] = []byte("SOME_ROTATED_CERTIFICATE_DATA")
+	rotated := createIngressController(cd, cd.Spec.Ingress[0], []*corev1.Secret{&secret})
+	assert.NotEqual(t, ic.Annotations[servingCertificateHashAnnotation], rotated.Annotations[servingCertificateHashAnnotation], "expected serving certificate hash to change")
+
+	plain := createIngressController(cd, hivev1.ClusterIngress{Name: "plain", Domain: testIngressDomain}, nil)
+	assert.Nil(t, plain.Spec.Replicas, "unexpected replicas")
+	assert.Nil(t, plain.Spec.EndpointPublishingStrategy, "unexpected endpoint publishing strategy")
+	assert.Nil(t, plain.Spec.NodePlacement, "unexpected node placement")
+	assert.Empty(t, plain.Annotations, "unexpected annotations")
+}
+
+func TestRequestsForCertificateBundleSecret(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+	withCert := testClusterDeploymentWithManualCertificate()
+	withoutIngress := testClusterDeploymentWithoutIngress()
+	withoutIngress.Name = "without-ingress"
+	withoutIngress.Spec.CertificateBundles = withCert.Spec.CertificateBundles
+	otherNamespace := testClusterDeploymentWithManualCertificate()
+	otherNamespace.Namespace = "other"
+	c := fake.NewFakeClient(withCert, withoutIngress, otherNamespace)
+
+	secret := testSecretForCertificateBundle(withCert.Spec.CertificateBundles[0])
+	requests := requestsForCertificateBundleSecret(c)(handler.MapObject{Meta: &secret, Object: &secret})
+	assert.Equal(t, []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: testClusterName}}}, requests, "unexpected requests")
+
+	unrelated := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "unrelated"}}
+	assert.Empty(t, requestsForCertificateBundleSecret(c)(handler.MapObject{Meta: unrelated, Object: unrelated}), "unexpected requests")
+}
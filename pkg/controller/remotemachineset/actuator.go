@@ -20,3 +20,37 @@ type Actuator interface {
 	// to wait before we can proceed with reconciling. (e.g. obtaining a pool name lease)
 	GenerateMachineSets(*hivev1.ClusterDeployment, *hivev1.MachinePool, log.FieldLogger) (msets []*machineapi.MachineSet, proceed bool, genError error)
 }
+
+// machineObservingActuator is implemented by the Actuators whose MachineSets depend on the state of the Machines of
+// the MachinePool in the cluster.
+type machineObservingActuator interface {
+	// ObserveMachines is given the Machines of the cluster before the MachineSets are generated.
+	ObserveMachines(machines []machineapi.Machine)
+}
+
+// newActuatorFunc creates the Actuator for a ClusterDeployment.
+type newActuatorFunc func(
+	r *ReconcileRemoteMachineSet,
+	cd *hivev1.ClusterDeployment,
+	pool *hivev1.MachinePool,
+	masterMachine *machineapi.Machine,
+	remoteMachineSets []machineapi.MachineSet,
+	logger log.FieldLogger,
+) (Actuator, error)
+
+// actuatorProvider creates the Actuators of the ClusterDeployments on a platform.
+type actuatorProvider struct {
+	// canHandle returns true if the provider can handle a particular ClusterDeployment
+	canHandle   func(cd *hivev1.ClusterDeployment) bool
+	newActuator newActuatorFunc
+}
+
+// actuatorProviders is a list of available actuator providers for this controller.
+// It is populated via the registerActuatorProvider function by the actuator of each platform.
+var actuatorProviders []actuatorProvider
+
+// registerActuatorProvider registers an actuator provider with this controller. The provider determines whether it
+// can handle a particular cluster deployment via the canHandle function.
+func registerActuatorProvider(canHandle func(cd *hivev1.ClusterDeployment) bool, newActuator newActuatorFunc) {
+	actuatorProviders = append(actuatorProviders, actuatorProvider{canHandle: canHandle, newActuator: newActuator})
+}
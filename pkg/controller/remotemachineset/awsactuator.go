@@ -37,6 +37,9 @@ type AWSActuator struct {
 	logger    log.FieldLogger
 	region    string
 	amiID     string
+	scheme    *runtime.Scheme
+	// machines are the Machines of the cluster, used to find the instance types which lack capacity.
+	machines []machineapi.Machine
 }
 
 var (
@@ -48,10 +51,34 @@ var (
 	versionsSupportingSpotInstances = semver.MustParseRange(">=4.5.0")
 )
 
+const (
+	// machineSetLabel is the label of a Machine with the name of its MachineSet.
+	machineSetLabel = "machine.openshift.io/cluster-api-machineset"
+
+	// insufficientInstanceCapacityError is the code of the error of AWS for an instance type which lacks capacity in
+	// an availability zone, which is reported in the error message of the Machine.
+	insufficientInstanceCapacityError = "InsufficientInstanceCapacity"
+)
+
 func addAWSProviderToScheme(scheme *runtime.Scheme) error {
 	return awsprovider.AddToScheme(scheme)
 }
 
+func init() {
+	registerActuatorProvider(
+		func(cd *hivev1.ClusterDeployment) bool {
+			return cd.Spec.Platform.AWS != nil
+		},
+		func(r *ReconcileRemoteMachineSet, cd *hivev1.ClusterDeployment, pool *hivev1.MachinePool, masterMachine *machineapi.Machine, remoteMachineSets []machineapi.MachineSet, logger log.FieldLogger) (Actuator, error) {
+			creds, err := r.getCredentialsSecret(cd, cd.Spec.Platform.AWS.CredentialsSecretRef.Name)
+			if err != nil {
+				return nil, err
+			}
+			return NewAWSActuator(r.Client, creds, cd.Spec.Platform.AWS.Region, pool, masterMachine, r.scheme, logger)
+		},
+	)
+}
+
 // NewAWSActuator is the constructor for building a AWSActuator
 func NewAWSActuator(
 	client client.Client,
@@ -83,10 +110,16 @@ func NewAWSActuator(
 		logger:    logger,
 		region:    region,
 		amiID:     amiID,
+		scheme:    scheme,
 	}
 	return actuator, nil
 }
 
+// ObserveMachines satisfies the machineObservingActuator interface.
+func (a *AWSActuator) ObserveMachines(machines []machineapi.Machine) {
+	a.machines = machines
+}
+
 // GenerateMachineSets satisfies the Actuator interface and will take a clusterDeployment and return a list of MachineSets
 // to sync to the remote cluster.
 func (a *AWSActuator) GenerateMachineSets(cd *hivev1.ClusterDeployment, pool *hivev1.MachinePool, logger log.FieldLogger) ([]*machineapi.MachineSet, bool, error) {
@@ -144,6 +177,10 @@ func (a *AWSActuator) GenerateMachineSets(cd *hivev1.ClusterDeployment, pool *hi
 		Zones: pool.Spec.Platform.AWS.Zones,
 	}
 
+	if len(computePool.Platform.AWS.Zones) == 0 && len(pool.Spec.Platform.AWS.ZoneSubnets) > 0 {
+		computePool.Platform.AWS.Zones = zonesFromZoneSubnets(pool.Spec.Platform.AWS.ZoneSubnets)
+	}
+
 	if len(computePool.Platform.AWS.Zones) == 0 {
 		zones, err := a.fetchAvailabilityZones()
 		if err != nil {
@@ -164,6 +201,13 @@ func (a *AWSActuator) GenerateMachineSets(cd *hivev1.ClusterDeployment, pool *hi
 		}
 		subnets = subnetsByAvailabilityZone
 	}
+	// Using the explicit mapping of availability zones to subnets from the machinepool
+	if len(pool.Spec.Platform.AWS.ZoneSubnets) > 0 {
+		if err := a.validateZoneSubnets(pool); err != nil {
+			return nil, false, errors.Wrap(err, "validating zone subnets")
+		}
+		subnets = pool.Spec.Platform.AWS.ZoneSubnets
+	}
 	// userTags are settings available in the installconfig that we are choosing
 	// to ignore for the timebeing. These empty settings should be updated to feed
 	// from the machinepool / installconfig in the future.
@@ -219,9 +263,136 @@ func (a *AWSActuator) GenerateMachineSets(cd *hivev1.ClusterDeployment, pool *hi
 		a.updateProviderConfig(ms, cd.Spec.ClusterMetadata.InfraID, pool)
 	}
 
+	if len(pool.Spec.Platform.AWS.InstanceTypes) > 0 {
+		if err := a.selectInstanceTypes(installerMachineSets, pool, logger); err != nil {
+			return nil, false, errors.Wrap(err, "failed to select instance types")
+		}
+	}
+
 	return installerMachineSets, true, nil
 }
 
+// selectInstanceTypes sets the instance type of each MachineSet to the first of the pool's instance type and fallback
+// instance types which is offered in the availability zone of the MachineSet, and with which its Machines have not
+// failed for lack of capacity in the zone. The instance types which lacked capacity are recorded in the status of the
+// pool, until all of the offered instance types have, when they are retried. MachineSets in zones where none of the
+// instance types are offered keep the pool's instance type.
+func (a *AWSActuator) selectInstanceTypes(machineSets []*machineapi.MachineSet, pool *hivev1.MachinePool, logger log.FieldLogger) error {
+	instanceTypes := append([]string{pool.Spec.Platform.AWS.InstanceType}, pool.Spec.Platform.AWS.InstanceTypes...)
+	offerings, err := a.fetchInstanceTypeOfferings(instanceTypes)
+	if err != nil {
+		return err
+	}
+	insufficientCapacity := a.insufficientCapacityInstanceTypes(logger)
+	statusChanged := false
+	for _, ms := range machineSets {
+		providerConfig := ms.Spec.Template.Spec.ProviderSpec.Value.Object.(*awsproviderv1beta1.AWSMachineProviderConfig)
+		zone := providerConfig.Placement.AvailabilityZone
+		msLog := logger.WithField("machineset", ms.Name).WithField("zone", zone)
+
+		var msStatus *hivev1.MachineSetStatus
+		for i := range pool.Status.MachineSets {
+			if pool.Status.MachineSets[i].Name == ms.Name {
+				msStatus = &pool.Status.MachineSets[i]
+			}
+		}
+		exhausted := sets.NewString().Union(insufficientCapacity[ms.Name])
+		if msStatus != nil {
+			exhausted.Insert(msStatus.InsufficientCapacityInstanceTypes...)
+		}
+		var offered []string
+		for _, instanceType := range instanceTypes {
+			if offerings[zone].Has(instanceType) {
+				offered = append(offered, instanceType)
+			}
+		}
+		if len(offered) > 0 && exhausted.HasAll(offered...) {
+			msLog.Warn("all the instance types of the machine pool lacked capacity in the zone, retrying them")
+			exhausted = sets.NewString()
+		}
+
+		selected := ""
+		for _, instanceType := range offered {
+			if !exhausted.Has(instanceType) {
+				selected = instanceType
+				break
+			}
+		}
+		switch selected {
+		case "":
+			msLog.Warn("none of the instance types of the machine pool are offered in the zone, using the primary instance type")
+		case providerConfig.InstanceType:
+		default:
+			msLog.WithField("instanceType", selected).Info("primary instance type is not offered or lacks capacity in the zone, using fallback instance type")
+			providerConfig.InstanceType = selected
+		}
+
+		if msStatus != nil && !sets.NewString(msStatus.InsufficientCapacityInstanceTypes...).Equal(exhausted) {
+			msStatus.InsufficientCapacityInstanceTypes = exhausted.List()
+			if len(msStatus.InsufficientCapacityInstanceTypes) == 0 {
+				msStatus.InsufficientCapacityInstanceTypes = nil
+			}
+			statusChanged = true
+		}
+	}
+	if statusChanged {
+		return a.client.Status().Update(context.Background(), pool)
+	}
+	return nil
+}
+
+// insufficientCapacityInstanceTypes returns, by name of MachineSet, the instance types with which the Machines of the
+// cluster failed for lack of capacity in their availability zone.
+func (a *AWSActuator) insufficientCapacityInstanceTypes(logger log.FieldLogger) map[string]sets.String {
+	insufficientCapacity := map[string]sets.String{}
+	for _, machine := range a.machines {
+		msName := machine.Labels[machineSetLabel]
+		if msName == "" || machine.Status.ErrorMessage == nil || !strings.Contains(*machine.Status.ErrorMessage, insufficientInstanceCapacityError) {
+			continue
+		}
+		providerConfig, err := decodeAWSMachineProviderSpec(machine.Spec.ProviderSpec.Value, a.scheme)
+		if err != nil {
+			logger.WithError(err).WithField("machine", machine.Name).Warn("cannot decode AWSMachineProviderConfig from failed machine")
+			continue
+		}
+		if insufficientCapacity[msName] == nil {
+			insufficientCapacity[msName] = sets.NewString()
+		}
+		insufficientCapacity[msName].Insert(providerConfig.InstanceType)
+	}
+	return insufficientCapacity
+}
+
+// fetchInstanceTypeOfferings fetches which of the given instance types are offered in each availability zone of the
+// AWS region.
+func (a *AWSActuator) fetchInstanceTypeOfferings(instanceTypes []string) (map[string]sets.String, error) {
+	req := &ec2.DescribeInstanceTypeOfferingsInput{
+		LocationType: aws.String(ec2.LocationTypeAvailabilityZone),
+		Filters: []*ec2.Filter{{
+			Name:   aws.String("instance-type"),
+			Values: aws.StringSlice(instanceTypes),
+		}},
+	}
+	offerings := map[string]sets.String{}
+	for {
+		resp, err := a.awsClient.DescribeInstanceTypeOfferings(req)
+		if err != nil {
+			return nil, err
+		}
+		for _, offering := range resp.InstanceTypeOfferings {
+			zone := aws.StringValue(offering.Location)
+			if offerings[zone] == nil {
+				offerings[zone] = sets.NewString()
+			}
+			offerings[zone].Insert(aws.StringValue(offering.InstanceType))
+		}
+		if aws.StringValue(resp.NextToken) == "" {
+			return offerings, nil
+		}
+		req.NextToken = resp.NextToken
+	}
+}
+
 // Get the AMI ID from an existing master machine.
 func getAWSAMIID(masterMachine *machineapi.Machine, scheme *runtime.Scheme, logger log.FieldLogger) (string, error) {
 	providerSpec, err := decodeAWSMachineProviderSpec(masterMachine.Spec.ProviderSpec.Value, scheme)
@@ -473,6 +644,51 @@ func isSubnetPublic(rt []*ec2.RouteTable, subnetID string, logger log.FieldLogge
 	return false, nil
 }
 
+// validateZoneSubnets ensures that the subnets mapped to availability zones by the machinepool exist and are in those
+// availability zones.
+func (a *AWSActuator) validateZoneSubnets(pool *hivev1.MachinePool) error {
+	zoneSubnets := pool.Spec.Platform.AWS.ZoneSubnets
+	zones := zonesFromZoneSubnets(zoneSubnets)
+	idPointers := make([]*string, len(zones))
+	for i, zone := range zones {
+		idPointers[i] = aws.String(zoneSubnets[zone])
+	}
+
+	results, err := a.awsClient.DescribeSubnets(&ec2.DescribeSubnetsInput{SubnetIds: idPointers})
+	if err != nil {
+		if strings.Contains(err.Error(), "InvalidSubnetID.NotFound") {
+			conditionMessage := err.Error()
+			if submatches := reg.FindStringSubmatch(err.Error()); submatches != nil {
+				conditionMessage = submatches[1]
+			}
+			if err := setInvalidSubnetsCondition(a.client, pool, zoneSubnetsNotFoundReason, conditionMessage); err != nil {
+				return err
+			}
+		}
+		return err
+	}
+
+	subnetZones := make(map[string]string, len(results.Subnets))
+	for _, subnet := range results.Subnets {
+		subnetZones[aws.StringValue(subnet.SubnetId)] = aws.StringValue(subnet.AvailabilityZone)
+	}
+	var mismatches []string
+	for _, zone := range zones {
+		subnetID := zoneSubnets[zone]
+		if subnetZone := subnetZones[subnetID]; subnetZone != zone {
+			mismatches = append(mismatches, fmt.Sprintf("%s is in %q, not %q", subnetID, subnetZone, zone))
+		}
+	}
+	if len(mismatches) > 0 {
+		message := fmt.Sprintf("subnets are not in the availability zones they are mapped to: %s", strings.Join(mismatches, ", "))
+		if err := setInvalidSubnetsCondition(a.client, pool, zoneSubnetsMismatchReason, message); err != nil {
+			return err
+		}
+		return errors.New(message)
+	}
+	return nil
+}
+
 // validateSubnets ensures there's only one public or private subnet per availability zone, and returns
 // the mapping of subnets by availability zone
 func (a *AWSActuator) validateSubnets(subnets map[string]ec2.Subnet, pool *hivev1.MachinePool) (map[string]string, error) {
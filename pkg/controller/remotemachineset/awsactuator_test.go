@@ -39,8 +39,11 @@ func TestAWSActuator(t *testing.T) {
 		existing                     []runtime.Object
 		expectedMachineSetReplicas   map[string]int64
 		expectedSubnetIDInMachineSet bool
+		expectedInstanceTypes        map[string]string
 		expectedErr                  bool
 		expectedCondition            *hivev1.MachinePoolCondition
+		machines                     []machineapi.Machine
+		expectedInsufficientCapacity map[string][]string
 	}{
 		{
 			name:              "generate single machineset for single zone",
@@ -122,6 +125,77 @@ func TestAWSActuator(t *testing.T) {
 			},
 			expectedSubnetIDInMachineSet: true,
 		},
+		{
+			name:              "generate machinesets for zone subnets",
+			clusterDeployment: testClusterDeployment(),
+			poolName:          testMachinePool().Name,
+			existing: []runtime.Object{
+				func() *hivev1.MachinePool {
+					pool := testMachinePool()
+					pool.Spec.Platform.AWS.ZoneSubnets = map[string]string{
+						"zone1": "subnet-zone1",
+						"zone2": "subnet-zone2",
+					}
+					return pool
+				}(),
+			},
+			mockAWSClient: func(client *mockaws.MockClient) {
+				mockDescribeSubnets(client, []string{"zone1", "zone2"}, []string{"subnet-zone1", "subnet-zone2"}, nil, "vpc-1")
+			},
+			expectedMachineSetReplicas: map[string]int64{
+				generateAWSMachineSetName("zone1"): 2,
+				generateAWSMachineSetName("zone2"): 1,
+			},
+			expectedSubnetIDInMachineSet: true,
+		},
+		{
+			name:              "zone subnets in other availability zones",
+			clusterDeployment: testClusterDeployment(),
+			poolName:          testMachinePool().Name,
+			existing: []runtime.Object{
+				func() *hivev1.MachinePool {
+					pool := testMachinePool()
+					pool.Spec.Platform.AWS.ZoneSubnets = map[string]string{
+						"zone1": "subnet-a",
+						"zone2": "subnet-b",
+					}
+					return pool
+				}(),
+			},
+			mockAWSClient: func(client *mockaws.MockClient) {
+				mockDescribeSubnets(client, []string{"zone2", "zone1"}, []string{"subnet-a", "subnet-b"}, nil, "vpc-1")
+			},
+			expectedErr: true,
+			expectedCondition: &hivev1.MachinePoolCondition{
+				Type:   hivev1.InvalidSubnetsMachinePoolCondition,
+				Status: corev1.ConditionTrue,
+				Reason: "ZoneSubnetsMismatch",
+			},
+		},
+		{
+			name:              "zone subnets do not exist",
+			clusterDeployment: testClusterDeployment(),
+			poolName:          testMachinePool().Name,
+			existing: []runtime.Object{
+				func() *hivev1.MachinePool {
+					pool := testMachinePool()
+					pool.Spec.Platform.AWS.ZoneSubnets = map[string]string{
+						"zone1": "missing-subnet1",
+					}
+					return pool
+				}(),
+			},
+			mockAWSClient: func(client *mockaws.MockClient) {
+				client.EXPECT().DescribeSubnets(&ec2.DescribeSubnetsInput{SubnetIds: []*string{aws.String("missing-subnet1")}}).
+					Return(nil, fmt.Errorf("InvalidSubnetID.NotFound: The subnet ID 'missing-subnet1' does not exist\tstatus code: 400"))
+			},
+			expectedErr: true,
+			expectedCondition: &hivev1.MachinePoolCondition{
+				Type:   hivev1.InvalidSubnetsMachinePoolCondition,
+				Status: corev1.ConditionTrue,
+				Reason: "ZoneSubnetsNotFound",
+			},
+		},
 		{
 			name:              "list zones returns zero",
 			clusterDeployment: testClusterDeployment(),
@@ -292,6 +366,130 @@ func TestAWSActuator(t *testing.T) {
 				Reason: "ConfigurationSupported",
 			},
 		},
+		{
+			name:              "fallback instance types",
+			clusterDeployment: testClusterDeployment(),
+			poolName:          testMachinePool().Name,
+			existing: []runtime.Object{
+				func() *hivev1.MachinePool {
+					pool := testMachinePool()
+					pool.Spec.Platform.AWS.InstanceTypes = []string{"fallback-type-1", "fallback-type-2"}
+					return pool
+				}(),
+			},
+			mockAWSClient: func(client *mockaws.MockClient) {
+				mockDescribeAvailabilityZones(client, []string{"zone1", "zone2", "zone3"})
+				mockDescribeInstanceTypeOfferings(client, map[string][]string{
+					"zone1": {testInstanceType, "fallback-type-1"},
+					"zone2": {"fallback-type-2", "fallback-type-1"},
+				})
+			},
+			expectedMachineSetReplicas: map[string]int64{
+				generateAWSMachineSetName("zone1"): 1,
+				generateAWSMachineSetName("zone2"): 1,
+				generateAWSMachineSetName("zone3"): 1,
+			},
+			expectedInstanceTypes: map[string]string{
+				generateAWSMachineSetName("zone2"): "fallback-type-1",
+			},
+		},
+		{
+			name:              "insufficient capacity falls through instance types",
+			clusterDeployment: testClusterDeployment(),
+			poolName:          testMachinePool().Name,
+			existing: []runtime.Object{
+				withInsufficientCapacity(testMachinePool(), map[string][]string{generateAWSMachineSetName("zone2"): nil}),
+			},
+			machines: []machineapi.Machine{
+				*testInsufficientCapacityMachine(generateAWSMachineSetName("zone2"), testInstanceType),
+			},
+			mockAWSClient: func(client *mockaws.MockClient) {
+				mockDescribeAvailabilityZones(client, []string{"zone1", "zone2", "zone3"})
+				mockDescribeInstanceTypeOfferings(client, map[string][]string{
+					"zone1": {testInstanceType, "fallback-type-1", "fallback-type-2"},
+					"zone2": {testInstanceType, "fallback-type-1", "fallback-type-2"},
+					"zone3": {testInstanceType, "fallback-type-1", "fallback-type-2"},
+				})
+			},
+			expectedMachineSetReplicas: map[string]int64{
+				generateAWSMachineSetName("zone1"): 1,
+				generateAWSMachineSetName("zone2"): 1,
+				generateAWSMachineSetName("zone3"): 1,
+			},
+			expectedInstanceTypes: map[string]string{
+				generateAWSMachineSetName("zone2"): "fallback-type-1",
+			},
+			expectedInsufficientCapacity: map[string][]string{
+				generateAWSMachineSetName("zone2"): {testInstanceType},
+			},
+		},
+		{
+			name:              "recorded insufficient capacity",
+			clusterDeployment: testClusterDeployment(),
+			poolName:          testMachinePool().Name,
+			existing: []runtime.Object{
+				withInsufficientCapacity(testMachinePool(), map[string][]string{
+					generateAWSMachineSetName("zone1"): {testInstanceType, "fallback-type-1"},
+				}),
+			},
+			mockAWSClient: func(client *mockaws.MockClient) {
+				mockDescribeAvailabilityZones(client, []string{"zone1"})
+				mockDescribeInstanceTypeOfferings(client, map[string][]string{
+					"zone1": {testInstanceType, "fallback-type-1", "fallback-type-2"},
+				})
+			},
+			expectedMachineSetReplicas: map[string]int64{
+				generateAWSMachineSetName("zone1"): 3,
+			},
+			expectedInstanceTypes: map[string]string{
+				generateAWSMachineSetName("zone1"): "fallback-type-2",
+			},
+			expectedInsufficientCapacity: map[string][]string{
+				generateAWSMachineSetName("zone1"): {testInstanceType, "fallback-type-1"},
+			},
+		},
+		{
+			name:              "all instance types lacked capacity",
+			clusterDeployment: testClusterDeployment(),
+			poolName:          testMachinePool().Name,
+			existing: []runtime.Object{
+				withInsufficientCapacity(testMachinePool(), map[string][]string{
+					generateAWSMachineSetName("zone1"): {testInstanceType, "fallback-type-1"},
+				}),
+			},
+			machines: []machineapi.Machine{
+				*testInsufficientCapacityMachine(generateAWSMachineSetName("zone1"), "fallback-type-2"),
+			},
+			mockAWSClient: func(client *mockaws.MockClient) {
+				mockDescribeAvailabilityZones(client, []string{"zone1"})
+				mockDescribeInstanceTypeOfferings(client, map[string][]string{
+					"zone1": {testInstanceType, "fallback-type-1", "fallback-type-2"},
+				})
+			},
+			expectedMachineSetReplicas: map[string]int64{
+				generateAWSMachineSetName("zone1"): 3,
+			},
+			expectedInsufficientCapacity: map[string][]string{
+				generateAWSMachineSetName("zone1"): nil,
+			},
+		},
+		{
+			name:              "instance type offerings error",
+			clusterDeployment: testClusterDeployment(),
+			poolName:          testMachinePool().Name,
+			existing: []runtime.Object{
+				func() *hivev1.MachinePool {
+					pool := testMachinePool()
+					pool.Spec.Platform.AWS.InstanceTypes = []string{"fallback-type-1"}
+					return pool
+				}(),
+			},
+			mockAWSClient: func(client *mockaws.MockClient) {
+				mockDescribeAvailabilityZones(client, []string{"zone1"})
+				client.EXPECT().DescribeInstanceTypeOfferings(gomock.Any()).Return(nil, fmt.Errorf("offerings error"))
+			},
+			expectedErr: true,
+		},
 		{
 			name:              "malformed cluster version",
 			clusterDeployment: withClusterVersion(testClusterDeployment(), "bad-version"),
@@ -322,13 +520,17 @@ func TestAWSActuator(t *testing.T) {
 				test.mockAWSClient(awsClient)
 			}
 
+			providerScheme := runtime.NewScheme()
+			awsprovider.SchemeBuilder.AddToScheme(providerScheme)
 			actuator := &AWSActuator{
 				client:    fakeClient,
 				awsClient: awsClient,
 				logger:    log.WithField("actuator", "awsactuator"),
 				region:    testRegion,
 				amiID:     testAMI,
+				scheme:    providerScheme,
 			}
+			actuator.ObserveMachines(test.machines)
 
 			pool := &hivev1.MachinePool{}
 			err := fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: test.poolName}, pool)
@@ -338,7 +540,14 @@ func TestAWSActuator(t *testing.T) {
 			if test.expectedErr {
 				assert.Error(t, err, "expected error for test case")
 			} else {
-				validateAWSMachineSets(t, generatedMachineSets, test.expectedMachineSetReplicas, test.expectedSubnetIDInMachineSet)
+				validateAWSMachineSets(t, generatedMachineSets, test.expectedMachineSetReplicas, test.expectedSubnetIDInMachineSet, test.expectedInstanceTypes)
+			}
+			if test.expectedInsufficientCapacity != nil {
+				savedPool := &hivev1.MachinePool{}
+				require.NoError(t, fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: test.poolName}, savedPool))
+				for _, ms := range savedPool.Status.MachineSets {
+					assert.Equal(t, test.expectedInsufficientCapacity[ms.Name], ms.InsufficientCapacityInstanceTypes, "unexpected insufficient capacity instance types for %s", ms.Name)
+				}
 			}
 			if test.expectedCondition != nil {
 				for _, cond := range pool.Status.Conditions {
@@ -391,7 +600,7 @@ func TestGetAWSAMIID(t *testing.T) {
 	}
 }
 
-func validateAWSMachineSets(t *testing.T, mSets []*machineapi.MachineSet, expectedMSReplicas map[string]int64, expectedSubnetID bool) {
+func validateAWSMachineSets(t *testing.T, mSets []*machineapi.MachineSet, expectedMSReplicas map[string]int64, expectedSubnetID bool, expectedInstanceTypes map[string]string) {
 	assert.Equal(t, len(expectedMSReplicas), len(mSets), "different number of machine sets generated than expected")
 
 	for _, ms := range mSets {
@@ -403,7 +612,11 @@ func validateAWSMachineSets(t *testing.T, mSets []*machineapi.MachineSet, expect
 		awsProvider, ok := ms.Spec.Template.Spec.ProviderSpec.Value.Object.(*awsprovider.AWSMachineProviderConfig)
 		assert.True(t, ok, "failed to convert to AWSMachineProviderConfig")
 
-		assert.Equal(t, testInstanceType, awsProvider.InstanceType, "unexpected instance type")
+		expectedInstanceType, ok := expectedInstanceTypes[ms.Name]
+		if !ok {
+			expectedInstanceType = testInstanceType
+		}
+		assert.Equal(t, expectedInstanceType, awsProvider.InstanceType, "unexpected instance type")
 
 		if assert.NotNil(t, awsProvider.AMI.ID, "missing AMI ID") {
 			assert.Equal(t, testAMI, *awsProvider.AMI.ID, "unexpected AMI ID")
@@ -436,6 +649,27 @@ func mockDescribeAvailabilityZones(client *mockaws.MockClient, zones []string) {
 	client.EXPECT().DescribeAvailabilityZones(input).Return(output, nil)
 }
 
+func mockDescribeInstanceTypeOfferings(client *mockaws.MockClient, offerings map[string][]string) {
+	input := &ec2.DescribeInstanceTypeOfferingsInput{
+		LocationType: pointer.StringPtr(ec2.LocationTypeAvailabilityZone),
+		Filters: []*ec2.Filter{{
+			Name:   pointer.StringPtr("instance-type"),
+			Values: []*string{pointer.StringPtr(testInstanceType), pointer.StringPtr("fallback-type-1"), pointer.StringPtr("fallback-type-2")},
+		}},
+	}
+	output := &ec2.DescribeInstanceTypeOfferingsOutput{}
+	for zone, instanceTypes := range offerings {
+		for _, instanceType := range instanceTypes {
+			output.InstanceTypeOfferings = append(output.InstanceTypeOfferings, &ec2.InstanceTypeOffering{
+				InstanceType: pointer.StringPtr(instanceType),
+				Location:     pointer.StringPtr(zone),
+				LocationType: pointer.StringPtr(ec2.LocationTypeAvailabilityZone),
+			})
+		}
+	}
+	client.EXPECT().DescribeInstanceTypeOfferings(input).Return(output, nil)
+}
+
 func mockDescribeSubnets(client *mockaws.MockClient, zones []string, privateSubnetIDs []string, pubSubnetIDs []string, vpcID string) {
 	idPointers := make([]*string, 0, len(privateSubnetIDs)+len(pubSubnetIDs))
 	for _, id := range privateSubnetIDs {
@@ -553,3 +787,28 @@ func withSpotMarketOptions(pool *hivev1.MachinePool) *hivev1.MachinePool {
 	pool.Spec.Platform.AWS.SpotMarketOptions = &awshivev1.SpotMarketOptions{}
 	return pool
 }
+
+func withInsufficientCapacity(pool *hivev1.MachinePool, instanceTypes map[string][]string) *hivev1.MachinePool {
+	pool.Spec.Platform.AWS.InstanceTypes = []string{"fallback-type-1", "fallback-type-2"}
+	for name, types := range instanceTypes {
+		pool.Status.MachineSets = append(pool.Status.MachineSets, hivev1.MachineSetStatus{
+			Name:                              name,
+			InsufficientCapacityInstanceTypes: types,
+		})
+	}
+	return pool
+}
+
+func testInsufficientCapacityMachine(machineSetName, instanceType string) *machineapi.Machine {
+	machine := testMachine(machineSetName+"-abcde", "worker")
+	providerSpec := testAWSProviderSpec()
+	providerSpec.InstanceType = instanceType
+	rawProviderSpec, err := encodeAWSMachineProviderSpec(providerSpec, scheme.Scheme)
+	if err != nil {
+		log.WithError(err).Fatal("error encoding AWS machine provider spec")
+	}
+	machine.Spec.ProviderSpec.Value = rawProviderSpec
+	machine.Labels = map[string]string{machineSetLabel: machineSetName}
+	machine.Status.ErrorMessage = pointer.StringPtr("error launching instance: InsufficientInstanceCapacity: We currently do not have sufficient capacity in the Availability Zone you requested.")
+	return machine
+}
@@ -2,7 +2,9 @@ package remotemachineset
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
 	"strings"
 	"time"
 
@@ -11,6 +13,8 @@ import (
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 	corev1 "k8s.io/api/core/v1"
+	azureprovider "sigs.k8s.io/cluster-api-provider-azure/pkg/apis/azureprovider/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	installazure "github.com/openshift/installer/pkg/asset/machines/azure"
 	installertypes "github.com/openshift/installer/pkg/types"
@@ -23,22 +27,50 @@ import (
 // AzureActuator encapsulates the pieces necessary to be able to generate
 // a list of MachineSets to sync to the remote cluster.
 type AzureActuator struct {
-	client azureclient.Client
-	logger log.FieldLogger
+	client      client.Client
+	azureClient azureclient.Client
+	logger      log.FieldLogger
+	// vnet and networkResourceGroup are the virtual network of the cluster and its resource group, when they can be
+	// determined from the existing machinesets on the remote cluster.
+	vnet                 string
+	networkResourceGroup string
 }
 
 var _ Actuator = &AzureActuator{}
 
+func init() {
+	registerActuatorProvider(
+		func(cd *hivev1.ClusterDeployment) bool {
+			return cd.Spec.Platform.Azure != nil
+		},
+		func(r *ReconcileRemoteMachineSet, cd *hivev1.ClusterDeployment, pool *hivev1.MachinePool, masterMachine *machineapi.Machine, remoteMachineSets []machineapi.MachineSet, logger log.FieldLogger) (Actuator, error) {
+			creds, err := r.getCredentialsSecret(cd, cd.Spec.Platform.Azure.CredentialsSecretRef.Name)
+			if err != nil {
+				return nil, err
+			}
+			return NewAzureActuator(r.Client, creds, remoteMachineSets, logger)
+		},
+	)
+}
+
 // NewAzureActuator is the constructor for building a AzureActuator
-func NewAzureActuator(azureCreds *corev1.Secret, logger log.FieldLogger) (*AzureActuator, error) {
+func NewAzureActuator(client client.Client, azureCreds *corev1.Secret, remoteMachineSets []machineapi.MachineSet, logger log.FieldLogger) (*AzureActuator, error) {
 	azureClient, err := azureclient.NewClientFromSecret(azureCreds)
 	if err != nil {
 		logger.WithError(err).Warn("failed to create Azure client with creds in clusterDeployment's secret")
 		return nil, err
 	}
+	vnet, networkResourceGroup, err := getAzureNetwork(remoteMachineSets, logger)
+	if err != nil {
+		logger.WithError(err).Error("error getting network information from remote machines")
+		return nil, err
+	}
 	actuator := &AzureActuator{
-		client: azureClient,
-		logger: logger,
+		client:               client,
+		azureClient:          azureClient,
+		logger:               logger,
+		vnet:                 vnet,
+		networkResourceGroup: networkResourceGroup,
 	}
 	return actuator, nil
 }
@@ -73,6 +105,10 @@ func (a *AzureActuator) GenerateMachineSets(cd *hivev1.ClusterDeployment, pool *
 		},
 	}
 
+	if len(computePool.Platform.Azure.Zones) == 0 && len(pool.Spec.Platform.Azure.ZoneSubnets) > 0 {
+		computePool.Platform.Azure.Zones = zonesFromZoneSubnets(pool.Spec.Platform.Azure.ZoneSubnets)
+	}
+
 	if len(computePool.Platform.Azure.Zones) == 0 {
 		zones, err := a.getZones(cd.Spec.Platform.Azure.Region, pool.Spec.Platform.Azure.InstanceType)
 		if err != nil {
@@ -95,7 +131,58 @@ func (a *AzureActuator) GenerateMachineSets(cd *hivev1.ClusterDeployment, pool *
 		workerRole,
 		workerUserDataName,
 	)
-	return installerMachineSets, err == nil, errors.Wrap(err, "failed to generate machinesets")
+	if err != nil {
+		return nil, false, errors.Wrap(err, "failed to generate machinesets")
+	}
+
+	if len(pool.Spec.Platform.Azure.ZoneSubnets) > 0 {
+		if err := a.setZoneSubnets(installerMachineSets, pool, logger); err != nil {
+			return nil, false, errors.Wrap(err, "setting zone subnets")
+		}
+	}
+	return installerMachineSets, true, nil
+}
+
+// setZoneSubnets sets the subnets mapped to availability zones by the machinepool in the machinesets of those zones,
+// after ensuring that the subnets exist in the virtual network of the cluster.
+func (a *AzureActuator) setZoneSubnets(machineSets []*machineapi.MachineSet, pool *hivev1.MachinePool, logger log.FieldLogger) error {
+	ctx, cancel := context.WithTimeout(context.TODO(), 30*time.Second)
+	defer cancel()
+
+	var notFound []string
+	for _, ms := range machineSets {
+		providerSpec, ok := ms.Spec.Template.Spec.ProviderSpec.Value.Object.(*azureprovider.AzureMachineProviderSpec)
+		if !ok {
+			return errors.New("failed to convert to AzureMachineProviderSpec")
+		}
+		subnet, ok := pool.Spec.Platform.Azure.ZoneSubnets[to.String(providerSpec.Zone)]
+		if !ok {
+			continue
+		}
+		if a.vnet != "" {
+			providerSpec.Vnet = a.vnet
+			providerSpec.NetworkResourceGroup = a.networkResourceGroup
+		}
+		resp, err := a.azureClient.GetSubnet(ctx, providerSpec.NetworkResourceGroup, providerSpec.Vnet, subnet)
+		if err != nil {
+			if resp.Response.Response != nil && resp.StatusCode == http.StatusNotFound {
+				notFound = append(notFound, subnet)
+				continue
+			}
+			logger.WithError(err).WithField("subnet", subnet).Error("failed to get subnet")
+			return err
+		}
+		logger.WithField("zone", to.String(providerSpec.Zone)).WithField("subnet", subnet).Debug("using subnet mapped to zone")
+		providerSpec.Subnet = subnet
+	}
+	if len(notFound) > 0 {
+		message := fmt.Sprintf("subnets not found in the virtual network of the cluster: %s", strings.Join(notFound, ", "))
+		if err := setInvalidSubnetsCondition(a.client, pool, zoneSubnetsNotFoundReason, message); err != nil {
+			return err
+		}
+		return errors.New(message)
+	}
+	return setValidSubnetsCondition(a.client, pool)
 }
 
 func (a *AzureActuator) getZones(region string, instanceType string) ([]string, error) {
@@ -104,7 +191,7 @@ func (a *AzureActuator) getZones(region string, instanceType string) ([]string,
 
 	var res azureclient.ResourceSKUsPage
 	var err error
-	for res, err = a.client.ListResourceSKUs(ctx, ""); err == nil && res.NotDone(); err = res.NextWithContext(ctx) {
+	for res, err = a.azureClient.ListResourceSKUs(ctx, ""); err == nil && res.NotDone(); err = res.NextWithContext(ctx) {
 		for _, resSku := range res.Values() {
 			if strings.EqualFold(to.String(resSku.Name), instanceType) {
 				for _, locationInfo := range *resSku.LocationInfo {
@@ -118,3 +205,24 @@ func (a *AzureActuator) getZones(region string, instanceType string) ([]string,
 
 	return nil, err
 }
+
+// getAzureNetwork retrieves the virtual network of the cluster and its resource group from existing machinesets on the
+// remote cluster.
+func getAzureNetwork(remoteMachineSets []machineapi.MachineSet, logger log.FieldLogger) (string, string, error) {
+	if len(remoteMachineSets) == 0 {
+		return "", "", nil
+	}
+	rawExt := remoteMachineSets[0].Spec.Template.Spec.ProviderSpec.Value
+	if rawExt == nil {
+		return "", "", nil
+	}
+	providerSpec, ok := rawExt.Object.(*azureprovider.AzureMachineProviderSpec)
+	if !ok {
+		providerSpec = &azureprovider.AzureMachineProviderSpec{}
+		if err := json.Unmarshal(rawExt.Raw, providerSpec); err != nil {
+			logger.WithError(err).Warn("cannot decode AzureMachineProviderSpec from remote machinesets")
+			return "", "", errors.Wrap(err, "cannot decode AzureMachineProviderSpec from remote machinesets")
+		}
+	}
+	return providerSpec.Vnet, providerSpec.NetworkResourceGroup, nil
+}
@@ -2,21 +2,28 @@ package remotemachineset
 
 import (
 	"fmt"
+	"net/http"
 	"testing"
 
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2019-12-01/compute"
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-12-01/network"
+	"github.com/Azure/go-autorest/autorest"
 	"github.com/golang/mock/gomock"
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	machineapi "github.com/openshift/machine-api-operator/pkg/apis/machine/v1beta1"
 	azureprovider "sigs.k8s.io/cluster-api-provider-azure/pkg/apis/azureprovider/v1beta1"
 
+	"github.com/openshift/hive/apis"
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	hivev1azure "github.com/openshift/hive/apis/hive/v1/azure"
 	mockazure "github.com/openshift/hive/pkg/azureclient/mock"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 )
 
 func TestAzureActuator(t *testing.T) {
@@ -25,8 +32,12 @@ func TestAzureActuator(t *testing.T) {
 		mockAzureClient            func(*gomock.Controller, *mockazure.MockClient)
 		clusterDeployment          *hivev1.ClusterDeployment
 		pool                       *hivev1.MachinePool
+		vnet                       string
+		networkResourceGroup       string
 		expectedMachineSetReplicas map[string]int64
+		expectedSubnets            map[string]string
 		expectedErr                bool
+		expectedCondition          *hivev1.MachinePoolCondition
 	}{
 		{
 			name:              "generate single machineset for single zone",
@@ -99,6 +110,74 @@ func TestAzureActuator(t *testing.T) {
 				generateAzureMachineSetName("zone5"): 0,
 			},
 		},
+		{
+			name:              "generate machinesets for zone subnets",
+			clusterDeployment: testAzureClusterDeployment(),
+			pool: func() *hivev1.MachinePool {
+				p := testAzurePool()
+				p.Spec.Platform.Azure.ZoneSubnets = map[string]string{
+					"zone1": "subnet-1",
+					"zone2": "subnet-2",
+				}
+				return p
+			}(),
+			mockAzureClient: func(mockCtrl *gomock.Controller, client *mockazure.MockClient) {
+				mockGetSubnet(client, testInfraID+"-rg", testInfraID+"-vnet", "subnet-1", http.StatusOK)
+				mockGetSubnet(client, testInfraID+"-rg", testInfraID+"-vnet", "subnet-2", http.StatusOK)
+			},
+			expectedMachineSetReplicas: map[string]int64{
+				generateAzureMachineSetName("zone1"): 2,
+				generateAzureMachineSetName("zone2"): 1,
+			},
+			expectedSubnets: map[string]string{
+				"zone1": "subnet-1",
+				"zone2": "subnet-2",
+			},
+		},
+		{
+			name:                 "generate machinesets for zone subnets in existing virtual network",
+			clusterDeployment:    testAzureClusterDeployment(),
+			vnet:                 "existing-vnet",
+			networkResourceGroup: "existing-network-rg",
+			pool: func() *hivev1.MachinePool {
+				p := testAzurePool()
+				p.Spec.Platform.Azure.Zones = []string{"zone1", "zone2"}
+				p.Spec.Platform.Azure.ZoneSubnets = map[string]string{
+					"zone2": "subnet-2",
+				}
+				return p
+			}(),
+			mockAzureClient: func(mockCtrl *gomock.Controller, client *mockazure.MockClient) {
+				mockGetSubnet(client, "existing-network-rg", "existing-vnet", "subnet-2", http.StatusOK)
+			},
+			expectedMachineSetReplicas: map[string]int64{
+				generateAzureMachineSetName("zone1"): 2,
+				generateAzureMachineSetName("zone2"): 1,
+			},
+			expectedSubnets: map[string]string{
+				"zone2": "subnet-2",
+			},
+		},
+		{
+			name:              "zone subnet not found",
+			clusterDeployment: testAzureClusterDeployment(),
+			pool: func() *hivev1.MachinePool {
+				p := testAzurePool()
+				p.Spec.Platform.Azure.ZoneSubnets = map[string]string{
+					"zone1": "missing-subnet",
+				}
+				return p
+			}(),
+			mockAzureClient: func(mockCtrl *gomock.Controller, client *mockazure.MockClient) {
+				mockGetSubnet(client, testInfraID+"-rg", testInfraID+"-vnet", "missing-subnet", http.StatusNotFound)
+			},
+			expectedErr: true,
+			expectedCondition: &hivev1.MachinePoolCondition{
+				Type:   hivev1.InvalidSubnetsMachinePoolCondition,
+				Status: corev1.ConditionTrue,
+				Reason: "ZoneSubnetsNotFound",
+			},
+		},
 		{
 			name:              "list zones returns zero",
 			clusterDeployment: testAzureClusterDeployment(),
@@ -111,6 +190,7 @@ func TestAzureActuator(t *testing.T) {
 	}
 
 	for _, test := range tests {
+		apis.AddToScheme(scheme.Scheme)
 		t.Run(test.name, func(t *testing.T) {
 
 			mockCtrl := gomock.NewController(t)
@@ -121,9 +201,14 @@ func TestAzureActuator(t *testing.T) {
 			// set up mock expectations
 			test.mockAzureClient(mockCtrl, aClient)
 
+			fakeClient := fake.NewFakeClient(test.pool)
+
 			actuator := &AzureActuator{
-				client: aClient,
-				logger: log.WithField("actuator", "azureactuator"),
+				client:               fakeClient,
+				azureClient:          aClient,
+				logger:               log.WithField("actuator", "azureactuator"),
+				vnet:                 test.vnet,
+				networkResourceGroup: test.networkResourceGroup,
 			}
 
 			generatedMachineSets, _, err := actuator.GenerateMachineSets(test.clusterDeployment, test.pool, actuator.logger)
@@ -131,13 +216,20 @@ func TestAzureActuator(t *testing.T) {
 			if test.expectedErr {
 				assert.Error(t, err, "expected error for test case")
 			} else {
-				validateAzureMachineSets(t, generatedMachineSets, test.expectedMachineSetReplicas)
+				validateAzureMachineSets(t, generatedMachineSets, test.expectedMachineSetReplicas, test.expectedSubnets)
+			}
+			if test.expectedCondition != nil {
+				cond := controllerutils.FindMachinePoolCondition(test.pool.Status.Conditions, test.expectedCondition.Type)
+				if assert.NotNil(t, cond, "missing condition") {
+					assert.Equal(t, test.expectedCondition.Status, cond.Status, "unexpected condition status")
+					assert.Equal(t, test.expectedCondition.Reason, cond.Reason, "unexpected condition reason")
+				}
 			}
 		})
 	}
 }
 
-func validateAzureMachineSets(t *testing.T, mSets []*machineapi.MachineSet, expectedMSReplicas map[string]int64) {
+func validateAzureMachineSets(t *testing.T, mSets []*machineapi.MachineSet, expectedMSReplicas map[string]int64, expectedSubnets map[string]string) {
 	assert.Equal(t, len(expectedMSReplicas), len(mSets), "different number of machine sets generated than expected")
 
 	for _, ms := range mSets {
@@ -149,6 +241,11 @@ func validateAzureMachineSets(t *testing.T, mSets []*machineapi.MachineSet, expe
 		azureProvider, ok := ms.Spec.Template.Spec.ProviderSpec.Value.Object.(*azureprovider.AzureMachineProviderSpec)
 		if assert.True(t, ok, "failed to convert to azureProviderSpec") {
 			assert.Equal(t, testInstanceType, azureProvider.VMSize, "unexpected instance type")
+			expectedSubnet, ok := expectedSubnets[*azureProvider.Zone]
+			if !ok {
+				expectedSubnet = testInfraID + "-worker-subnet"
+			}
+			assert.Equal(t, expectedSubnet, azureProvider.Subnet, "unexpected subnet")
 		}
 	}
 }
@@ -172,6 +269,20 @@ func mockListResourceSKUs(mockCtrl *gomock.Controller, client *mockazure.MockCli
 	)
 }
 
+func mockGetSubnet(client *mockazure.MockClient, resourceGroup, vnet, subnet string, statusCode int) {
+	var err error
+	if statusCode != http.StatusOK {
+		err = fmt.Errorf("subnet %s returned status code %d", subnet, statusCode)
+	}
+	client.EXPECT().GetSubnet(gomock.Any(), resourceGroup, vnet, subnet).Return(
+		network.Subnet{
+			Response: autorest.Response{Response: &http.Response{StatusCode: statusCode}},
+			Name:     pointer.StringPtr(subnet),
+		},
+		err,
+	)
+}
+
 func generateAzureMachineSetName(zone string) string {
 	return fmt.Sprintf("%s-%s-%s%s", testInfraID, testPoolName, testRegion, zone)
 }
@@ -0,0 +1,69 @@
+package remotemachineset
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/pkg/errors"
+	"github.com/pmezard/go-difflib/difflib"
+
+	"sigs.k8s.io/yaml"
+
+	machineapi "github.com/openshift/machine-api-operator/pkg/apis/machine/v1beta1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+// isDryRun returns whether the changes to the MachineSets of the pool are to be recorded in its status instead of
+// being applied. Pools being deleted always have their MachineSets deleted.
+func isDryRun(pool *hivev1.MachinePool) bool {
+	return pool.Spec.DryRun && pool.DeletionTimestamp == nil
+}
+
+// machineSetChange returns the change from the MachineSet on the remote cluster to the MachineSet which Hive would
+// apply. from is nil for a MachineSet which would be created, and to is nil for one which would be deleted.
+func machineSetChange(action hivev1.MachineSetChangeAction, from, to *machineapi.MachineSet) (hivev1.MachineSetChange, error) {
+	change := hivev1.MachineSetChange{Action: action}
+	var fromYAML, toYAML string
+	if from != nil {
+		change.Name = from.Name
+		b, err := yaml.Marshal(from)
+		if err != nil {
+			return change, errors.Wrapf(err, "failed to render machineset %s", from.Name)
+		}
+		fromYAML = string(b)
+	}
+	if to != nil {
+		change.Name = to.Name
+		b, err := yaml.Marshal(to)
+		if err != nil {
+			return change, errors.Wrapf(err, "failed to render machineset %s", to.Name)
+		}
+		toYAML = string(b)
+	}
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(fromYAML),
+		B:        difflib.SplitLines(toYAML),
+		FromFile: "remote",
+		ToFile:   "hive",
+		Context:  3,
+	})
+	if err != nil {
+		return change, errors.Wrapf(err, "failed to diff machineset %s", change.Name)
+	}
+	change.Diff = diff
+	return change, nil
+}
+
+// updatePoolStatusForDryRun records the changes Hive would make to the MachineSets of the pool in its status.
+func (r *ReconcileRemoteMachineSet) updatePoolStatusForDryRun(pool *hivev1.MachinePool, changes []hivev1.MachineSetChange) error {
+	dryRun := &hivev1.MachinePoolDryRunStatus{
+		ObservedGeneration: pool.Generation,
+		MachineSets:        changes,
+	}
+	if reflect.DeepEqual(pool.Status.DryRun, dryRun) {
+		return nil
+	}
+	pool.Status.DryRun = dryRun
+	return errors.Wrap(r.Status().Update(context.Background(), pool), "failed to update pool status")
+}
@@ -4,11 +4,14 @@ import (
 	"context"
 	"fmt"
 	"math/rand"
+	"net/http"
+	"path"
 	"strings"
 
 	"github.com/blang/semver/v4"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
+	"google.golang.org/api/googleapi"
 
 	gcpprovider "github.com/openshift/cluster-api-provider-gcp/pkg/apis"
 	gcpproviderv1beta1 "github.com/openshift/cluster-api-provider-gcp/pkg/apis/gcpprovider/v1beta1"
@@ -67,6 +70,25 @@ func addGCPProviderToScheme(scheme *runtime.Scheme) error {
 	return gcpprovider.AddToScheme(scheme)
 }
 
+func init() {
+	registerActuatorProvider(
+		func(cd *hivev1.ClusterDeployment) bool {
+			return cd.Spec.Platform.GCP != nil
+		},
+		func(r *ReconcileRemoteMachineSet, cd *hivev1.ClusterDeployment, pool *hivev1.MachinePool, masterMachine *machineapi.Machine, remoteMachineSets []machineapi.MachineSet, logger log.FieldLogger) (Actuator, error) {
+			creds, err := r.getCredentialsSecret(cd, cd.Spec.Platform.GCP.CredentialsSecretRef.Name)
+			if err != nil {
+				return nil, err
+			}
+			clusterVersion, err := getClusterVersion(cd)
+			if err != nil {
+				return nil, err
+			}
+			return NewGCPActuator(r.Client, creds, clusterVersion, masterMachine, remoteMachineSets, r.scheme, r.expectations, logger)
+		},
+	)
+}
+
 // NewGCPActuator is the constructor for building a GCPActuator
 func NewGCPActuator(
 	client client.Client,
@@ -216,6 +238,10 @@ func (a *GCPActuator) GenerateMachineSets(cd *hivev1.ClusterDeployment, pool *hi
 		}
 	}
 
+	if len(computePool.Platform.GCP.Zones) == 0 && len(poolGCP.ZoneSubnets) > 0 {
+		computePool.Platform.GCP.Zones = zonesFromZoneSubnets(poolGCP.ZoneSubnets)
+	}
+
 	if len(computePool.Platform.GCP.Zones) == 0 {
 		zones, err := a.getZones(cd.Spec.Platform.GCP.Region)
 		if err != nil {
@@ -236,7 +262,65 @@ func (a *GCPActuator) GenerateMachineSets(cd *hivev1.ClusterDeployment, pool *hi
 		workerRole,
 		workerUserDataName,
 	)
-	return installerMachineSets, err == nil, errors.Wrap(err, "failed to generate machinesets")
+	if err != nil {
+		return nil, false, errors.Wrap(err, "failed to generate machinesets")
+	}
+
+	if len(poolGCP.ZoneSubnets) > 0 {
+		if err := a.setZoneSubnets(installerMachineSets, pool, cd.Spec.Platform.GCP.Region, logger); err != nil {
+			return nil, false, errors.Wrap(err, "setting zone subnets")
+		}
+	}
+	return installerMachineSets, true, nil
+}
+
+// setZoneSubnets sets the subnetworks mapped to zones by the machinepool in the machinesets of those zones, after
+// ensuring that the subnetworks exist in the region and the network of the cluster.
+func (a *GCPActuator) setZoneSubnets(machineSets []*machineapi.MachineSet, pool *hivev1.MachinePool, region string, logger log.FieldLogger) error {
+	var notFound, mismatches []string
+	for _, ms := range machineSets {
+		providerSpec, ok := ms.Spec.Template.Spec.ProviderSpec.Value.Object.(*gcpproviderv1beta1.GCPMachineProviderSpec)
+		if !ok {
+			return errors.New("failed to convert to GCPMachineProviderSpec")
+		}
+		subnet, ok := pool.Spec.Platform.GCP.ZoneSubnets[providerSpec.Zone]
+		if !ok {
+			continue
+		}
+		subnetwork, err := a.gcpClient.GetSubnetwork(context.TODO(), region, subnet)
+		if err != nil {
+			if gcpErr, ok := err.(*googleapi.Error); ok && gcpErr.Code == http.StatusNotFound {
+				notFound = append(notFound, subnet)
+				continue
+			}
+			logger.WithError(err).WithField("subnetwork", subnet).Error("failed to get subnetwork")
+			return err
+		}
+		if len(providerSpec.NetworkInterfaces) == 0 {
+			return errors.New("machineset does not have any network interfaces")
+		}
+		if network := providerSpec.NetworkInterfaces[0].Network; network != "" && path.Base(subnetwork.Network) != network {
+			mismatches = append(mismatches, fmt.Sprintf("%s is in network %q, not %q", subnet, path.Base(subnetwork.Network), network))
+			continue
+		}
+		logger.WithField("zone", providerSpec.Zone).WithField("subnetwork", subnet).Debug("using subnetwork mapped to zone")
+		providerSpec.NetworkInterfaces[0].Subnetwork = subnet
+	}
+	switch {
+	case len(notFound) > 0:
+		message := fmt.Sprintf("subnetworks not found in region %s: %s", region, strings.Join(notFound, ", "))
+		if err := setInvalidSubnetsCondition(a.client, pool, zoneSubnetsNotFoundReason, message); err != nil {
+			return err
+		}
+		return errors.New(message)
+	case len(mismatches) > 0:
+		message := fmt.Sprintf("subnetworks are not in the network of the cluster: %s", strings.Join(mismatches, ", "))
+		if err := setInvalidSubnetsCondition(a.client, pool, zoneSubnetsMismatchReason, message); err != nil {
+			return err
+		}
+		return errors.New(message)
+	}
+	return setValidSubnetsCondition(a.client, pool)
 }
 
 func (a *GCPActuator) getZones(region string) ([]string, error) {
@@ -248,7 +332,7 @@ func (a *GCPActuator) getZones(region string) ([]string, error) {
 	pageToken := ""
 
 	for {
-		zoneList, err := a.gcpClient.ListComputeZones(gcpclient.ListComputeZonesOptions{
+		zoneList, err := a.gcpClient.ListComputeZones(context.TODO(), gcpclient.ListComputeZonesOptions{
 			Filter:    zoneFilter,
 			PageToken: pageToken,
 		})
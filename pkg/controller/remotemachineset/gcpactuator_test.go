@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"net/http"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -11,6 +12,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	compute "google.golang.org/api/compute/v1"
+	"google.golang.org/api/googleapi"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -48,6 +50,7 @@ func TestGCPActuator(t *testing.T) {
 		setupPendingCreationExpectation bool
 
 		expectedMachineSetReplicas map[string]int64
+		expectedSubnets            map[string]string
 		expectedErr                bool
 	}{
 		{
@@ -171,6 +174,79 @@ func TestGCPActuator(t *testing.T) {
 				generateGCPMachineSetName("worker", "zone1"): 3,
 			},
 		},
+		{
+			name: "generate machinesets with zone subnets",
+			pool: func() *hivev1.MachinePool {
+				pool := testGCPPool(testPoolName)
+				pool.Spec.Platform.GCP.ZoneSubnets = map[string]string{
+					"zone1": "subnet-a",
+					"zone2": "subnet-b",
+				}
+				return pool
+			}(),
+			mockGCPClient: func(client *mockgcp.MockClient) {
+				mockGetSubnetwork(client, "subnet-a", testNetworkID)
+				mockGetSubnetwork(client, "subnet-b", testNetworkID)
+			},
+			expectedMachineSetReplicas: map[string]int64{
+				generateGCPMachineSetName("worker", "zone1"): 2,
+				generateGCPMachineSetName("worker", "zone2"): 1,
+			},
+			expectedSubnets: map[string]string{
+				"zone1": "subnet-a",
+				"zone2": "subnet-b",
+			},
+		},
+		{
+			name: "generate machinesets with some zones mapped to subnets",
+			pool: func() *hivev1.MachinePool {
+				pool := testGCPPool(testPoolName)
+				pool.Spec.Platform.GCP.Zones = []string{"zone1", "zone2", "zone3"}
+				pool.Spec.Platform.GCP.ZoneSubnets = map[string]string{
+					"zone2": "subnet-b",
+				}
+				return pool
+			}(),
+			mockGCPClient: func(client *mockgcp.MockClient) {
+				mockGetSubnetwork(client, "subnet-b", testNetworkID)
+			},
+			expectedMachineSetReplicas: map[string]int64{
+				generateGCPMachineSetName("worker", "zone1"): 1,
+				generateGCPMachineSetName("worker", "zone2"): 1,
+				generateGCPMachineSetName("worker", "zone3"): 1,
+			},
+			expectedSubnets: map[string]string{
+				"zone2": "subnet-b",
+			},
+		},
+		{
+			name: "zone subnet not found",
+			pool: func() *hivev1.MachinePool {
+				pool := testGCPPool(testPoolName)
+				pool.Spec.Platform.GCP.ZoneSubnets = map[string]string{
+					"zone1": "subnet-a",
+				}
+				return pool
+			}(),
+			mockGCPClient: func(client *mockgcp.MockClient) {
+				client.EXPECT().GetSubnetwork(gomock.Any(), testRegion, "subnet-a").Return(nil, &googleapi.Error{Code: http.StatusNotFound})
+			},
+			expectedErr: true,
+		},
+		{
+			name: "zone subnet in another network",
+			pool: func() *hivev1.MachinePool {
+				pool := testGCPPool(testPoolName)
+				pool.Spec.Platform.GCP.ZoneSubnets = map[string]string{
+					"zone1": "subnet-a",
+				}
+				return pool
+			}(),
+			mockGCPClient: func(client *mockgcp.MockClient) {
+				mockGetSubnetwork(client, "subnet-a", "other-network")
+			},
+			expectedErr: true,
+		},
 	}
 
 	for _, test := range tests {
@@ -232,7 +308,11 @@ func TestGCPActuator(t *testing.T) {
 
 					// Ensure network details are propagated correctly.
 					assert.Equal(t, ga.network, gcpProvider.NetworkInterfaces[0].Network)
-					assert.Equal(t, ga.subnet, gcpProvider.NetworkInterfaces[0].Subnetwork)
+					expectedSubnet := ga.subnet
+					if subnet, ok := test.expectedSubnets[gcpProvider.Zone]; ok {
+						expectedSubnet = subnet
+					}
+					assert.Equal(t, expectedSubnet, gcpProvider.NetworkInterfaces[0].Subnetwork)
 
 					// Ensure GCP disk type and size was correctly set or defaulted and made it to the resulting MachineSets:
 					expectedDiskType := test.pool.Spec.Platform.GCP.OSDisk.DiskType
@@ -825,11 +905,21 @@ func mockListComputeZones(gClient *mockgcp.MockClient, zones []string, region st
 	filter := gcpclient.ListComputeZonesOptions{
 		Filter: fmt.Sprintf("(region eq '.*%s.*') (status eq UP)", region),
 	}
-	gClient.EXPECT().ListComputeZones(gomock.Eq(filter)).Return(
+	gClient.EXPECT().ListComputeZones(gomock.Any(), gomock.Eq(filter)).Return(
 		zoneList, nil,
 	)
 }
 
+func mockGetSubnetwork(gClient *mockgcp.MockClient, name, network string) {
+	gClient.EXPECT().GetSubnetwork(gomock.Any(), testRegion, name).Return(
+		&compute.Subnetwork{
+			Name:    name,
+			Network: fmt.Sprintf("https://www.googleapis.com/compute/v1/projects/%s/global/networks/%s", testProjectID, network),
+		},
+		nil,
+	)
+}
+
 func mockListComputeImage(gClient *mockgcp.MockClient, images []string, infraID string) {
 	computeImages := &compute.ImageList{}
 	for _, image := range images {
@@ -842,7 +932,7 @@ func mockListComputeImage(gClient *mockgcp.MockClient, images []string, infraID
 	filter := gcpclient.ListComputeImagesOptions{
 		Filter: fmt.Sprintf("name eq \"%s-.*\"", infraID),
 	}
-	gClient.EXPECT().ListComputeImages(gomock.Eq(filter)).Return(
+	gClient.EXPECT().ListComputeImages(gomock.Any(), gomock.Eq(filter)).Return(
 		computeImages, nil,
 	)
 }
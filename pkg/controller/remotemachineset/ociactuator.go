@@ -0,0 +1,210 @@
+package remotemachineset
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/pointer"
+
+	machineapi "github.com/openshift/machine-api-operator/pkg/apis/machine/v1beta1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+const (
+	ociProviderSpecAPIVersion = "machine.openshift.io/v1beta1"
+	ociProviderSpecKind       = "OCIMachineProviderSpec"
+)
+
+// ociMachineProviderSpec is the provider spec consumed by the OCI machine controller. There is no vendored OCI
+// machine provider, so we only model the fields hive needs to read from the master machine or set for workers.
+type ociMachineProviderSpec struct {
+	metav1.TypeMeta `json:",inline"`
+
+	AvailabilityDomain string            `json:"availabilityDomain,omitempty"`
+	CompartmentID      string            `json:"compartmentId,omitempty"`
+	ImageID            string            `json:"imageId,omitempty"`
+	Shape              string            `json:"shape,omitempty"`
+	ShapeConfig        *ociShapeConfig   `json:"shapeConfig,omitempty"`
+	BootVolume         ociBootVolume     `json:"bootVolume,omitempty"`
+	SubnetID           string            `json:"subnetId,omitempty"`
+	FreeformTags       map[string]string `json:"freeformTags,omitempty"`
+	UserDataSecret     string            `json:"userDataSecret,omitempty"`
+}
+
+type ociShapeConfig struct {
+	OCPUs     *int32 `json:"ocpus,omitempty"`
+	MemoryGBs *int32 `json:"memoryInGBs,omitempty"`
+}
+
+type ociBootVolume struct {
+	SizeGBs   int64  `json:"sizeInGBs,omitempty"`
+	VPUsPerGB *int64 `json:"vpusPerGB,omitempty"`
+}
+
+// OCIActuator encapsulates the pieces necessary to be able to generate
+// a list of MachineSets to sync to the remote cluster
+type OCIActuator struct {
+	logger log.FieldLogger
+	// master is the provider spec of an existing master machine, used for the image, subnet and default
+	// availability domain of new machinesets.
+	master *ociMachineProviderSpec
+}
+
+var _ Actuator = &OCIActuator{}
+
+func init() {
+	registerActuatorProvider(
+		func(cd *hivev1.ClusterDeployment) bool {
+			return cd.Spec.Platform.OCI != nil
+		},
+		func(r *ReconcileRemoteMachineSet, cd *hivev1.ClusterDeployment, pool *hivev1.MachinePool, masterMachine *machineapi.Machine, remoteMachineSets []machineapi.MachineSet, logger log.FieldLogger) (Actuator, error) {
+			return NewOCIActuator(masterMachine, logger)
+		},
+	)
+}
+
+// NewOCIActuator is the constructor for building an OCIActuator
+func NewOCIActuator(masterMachine *machineapi.Machine, logger log.FieldLogger) (*OCIActuator, error) {
+	master, err := decodeOCIMachineProviderSpec(masterMachine.Spec.ProviderSpec.Value)
+	if err != nil {
+		logger.WithError(err).Error("error decoding provider spec from master machine")
+		return nil, err
+	}
+	logger.WithField("image", master.ImageID).Debug("resolved image to use for new machinesets")
+	return &OCIActuator{
+		logger: logger,
+		master: master,
+	}, nil
+}
+
+// GenerateMachineSets satisfies the Actuator interface and will take a clusterDeployment and return a list of MachineSets
+// to sync to the remote cluster.
+func (a *OCIActuator) GenerateMachineSets(cd *hivev1.ClusterDeployment, pool *hivev1.MachinePool, logger log.FieldLogger) ([]*machineapi.MachineSet, bool, error) {
+	if cd.Spec.ClusterMetadata == nil {
+		return nil, false, errors.New("ClusterDeployment does not have cluster metadata")
+	}
+	if cd.Spec.Platform.OCI == nil {
+		return nil, false, errors.New("ClusterDeployment is not for OCI")
+	}
+	if pool.Spec.Platform.OCI == nil {
+		return nil, false, errors.New("MachinePool is not for OCI")
+	}
+
+	domains := pool.Spec.Platform.OCI.AvailabilityDomains
+	if len(domains) == 0 {
+		if a.master.AvailabilityDomain == "" {
+			return nil, false, errors.New("no availability domains specified and none found on master machine")
+		}
+		domains = []string{a.master.AvailabilityDomain}
+	}
+
+	infraID := cd.Spec.ClusterMetadata.InfraID
+	total := int64(0)
+	if pool.Spec.Replicas != nil {
+		total = *pool.Spec.Replicas
+	}
+	numDomains := int64(len(domains))
+
+	machineSets := make([]*machineapi.MachineSet, 0, len(domains))
+	for idx, domain := range domains {
+		replicas := int32(total / numDomains)
+		if int64(idx) < total%numDomains {
+			replicas++
+		}
+
+		raw, err := json.Marshal(a.providerSpec(cd, pool, domain))
+		if err != nil {
+			return nil, false, errors.Wrap(err, "failed to encode provider spec")
+		}
+
+		name := fmt.Sprintf("%s-%s-%d", infraID, pool.Spec.Name, idx)
+		machineSets = append(machineSets, &machineapi.MachineSet{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: "machine.openshift.io/v1beta1",
+				Kind:       "MachineSet",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "openshift-machine-api",
+				Name:      name,
+				Labels: map[string]string{
+					"machine.openshift.io/cluster-api-cluster":      infraID,
+					"machine.openshift.io/cluster-api-machine-role": workerRole,
+					"machine.openshift.io/cluster-api-machine-type": workerRole,
+				},
+			},
+			Spec: machineapi.MachineSetSpec{
+				Replicas: pointer.Int32Ptr(replicas),
+				Selector: metav1.LabelSelector{
+					MatchLabels: map[string]string{
+						"machine.openshift.io/cluster-api-machineset": name,
+						"machine.openshift.io/cluster-api-cluster":    infraID,
+					},
+				},
+				Template: machineapi.MachineTemplateSpec{
+					ObjectMeta: machineapi.ObjectMeta{
+						Labels: map[string]string{
+							"machine.openshift.io/cluster-api-machineset":   name,
+							"machine.openshift.io/cluster-api-cluster":      infraID,
+							"machine.openshift.io/cluster-api-machine-role": workerRole,
+							"machine.openshift.io/cluster-api-machine-type": workerRole,
+						},
+					},
+					Spec: machineapi.MachineSpec{
+						ProviderSpec: machineapi.ProviderSpec{
+							Value: &runtime.RawExtension{Raw: raw},
+						},
+					},
+				},
+			},
+		})
+	}
+	return machineSets, true, nil
+}
+
+func (a *OCIActuator) providerSpec(cd *hivev1.ClusterDeployment, pool *hivev1.MachinePool, domain string) *ociMachineProviderSpec {
+	poolOCI := pool.Spec.Platform.OCI
+	spec := &ociMachineProviderSpec{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: ociProviderSpecAPIVersion,
+			Kind:       ociProviderSpecKind,
+		},
+		AvailabilityDomain: domain,
+		CompartmentID:      cd.Spec.Platform.OCI.CompartmentID,
+		ImageID:            a.master.ImageID,
+		Shape:              poolOCI.Shape,
+		BootVolume: ociBootVolume{
+			SizeGBs:   poolOCI.BootVolume.SizeGBs,
+			VPUsPerGB: poolOCI.BootVolume.VPUsPerGB,
+		},
+		SubnetID:       a.master.SubnetID,
+		FreeformTags:   cd.Spec.Platform.OCI.FreeformTags,
+		UserDataSecret: workerUserDataName,
+	}
+	if poolOCI.OCPUs != nil || poolOCI.MemoryGBs != nil {
+		spec.ShapeConfig = &ociShapeConfig{
+			OCPUs:     poolOCI.OCPUs,
+			MemoryGBs: poolOCI.MemoryGBs,
+		}
+	}
+	return spec
+}
+
+func decodeOCIMachineProviderSpec(rawExt *runtime.RawExtension) (*ociMachineProviderSpec, error) {
+	if rawExt == nil {
+		return nil, fmt.Errorf("MachineSet has no ProviderSpec")
+	}
+	spec := &ociMachineProviderSpec{}
+	if err := json.Unmarshal(rawExt.Raw, spec); err != nil {
+		return nil, fmt.Errorf("could not decode OCI ProviderSpec: %v", err)
+	}
+	if spec.Kind != ociProviderSpecKind {
+		return nil, fmt.Errorf("unexpected provider spec kind: %q", spec.Kind)
+	}
+	return spec, nil
+}
@@ -0,0 +1,148 @@
+package remotemachineset
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/pointer"
+
+	machineapi "github.com/openshift/machine-api-operator/pkg/apis/machine/v1beta1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1oci "github.com/openshift/hive/apis/hive/v1/oci"
+)
+
+const (
+	testOCIImage       = "ocid1.image.oc1..fake"
+	testOCISubnet      = "ocid1.subnet.oc1..fake"
+	testOCICompartment = "ocid1.compartment.oc1..fake"
+	testOCIShape       = "VM.Standard.E4.Flex"
+)
+
+func TestOCIActuator(t *testing.T) {
+	tests := []struct {
+		name                       string
+		masterAD                   string
+		availabilityDomains        []string
+		expectedMachineSetReplicas map[string]int64
+		expectedADs                map[string]string
+		expectedErr                bool
+	}{
+		{
+			name:                "generate machinesets across availability domains",
+			availabilityDomains: []string{"AD-1", "AD-2"},
+			expectedMachineSetReplicas: map[string]int64{
+				fmt.Sprintf("%s-worker-0", testInfraID): 2,
+				fmt.Sprintf("%s-worker-1", testInfraID): 1,
+			},
+			expectedADs: map[string]string{
+				fmt.Sprintf("%s-worker-0", testInfraID): "AD-1",
+				fmt.Sprintf("%s-worker-1", testInfraID): "AD-2",
+			},
+		},
+		{
+			name:     "default to master availability domain",
+			masterAD: "AD-3",
+			expectedMachineSetReplicas: map[string]int64{
+				fmt.Sprintf("%s-worker-0", testInfraID): 3,
+			},
+			expectedADs: map[string]string{
+				fmt.Sprintf("%s-worker-0", testInfraID): "AD-3",
+			},
+		},
+		{
+			name:        "no availability domains",
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			logger := log.WithField("actuator", "ociactuator_test")
+			actuator, err := NewOCIActuator(testOCIMasterMachine(t, test.masterAD), logger)
+			require.NoError(t, err, "unexpected error creating actuator")
+
+			pool := testOCIPool()
+			pool.Spec.Platform.OCI.AvailabilityDomains = test.availabilityDomains
+
+			generatedMachineSets, _, err := actuator.GenerateMachineSets(testOCIClusterDeployment(), pool, logger)
+			if test.expectedErr {
+				assert.Error(t, err, "expected error for test case")
+				return
+			}
+			require.NoError(t, err, "unexpected error for test case")
+			assert.Equal(t, len(test.expectedMachineSetReplicas), len(generatedMachineSets), "different number of machine sets generated than expected")
+			for _, ms := range generatedMachineSets {
+				expectedReplicas, ok := test.expectedMachineSetReplicas[ms.Name]
+				if !assert.True(t, ok, "unexpected machine set") {
+					continue
+				}
+				assert.Equal(t, expectedReplicas, int64(*ms.Spec.Replicas), "replica mismatch")
+
+				spec, err := decodeOCIMachineProviderSpec(ms.Spec.Template.Spec.ProviderSpec.Value)
+				require.NoError(t, err, "failed to decode provider spec")
+				assert.Equal(t, test.expectedADs[ms.Name], spec.AvailabilityDomain, "unexpected availability domain")
+				assert.Equal(t, testOCIImage, spec.ImageID, "unexpected image")
+				assert.Equal(t, testOCISubnet, spec.SubnetID, "unexpected subnet")
+				assert.Equal(t, testOCICompartment, spec.CompartmentID, "unexpected compartment")
+				assert.Equal(t, testOCIShape, spec.Shape, "unexpected shape")
+				assert.Equal(t, int64(120), spec.BootVolume.SizeGBs, "unexpected boot volume size")
+				if assert.NotNil(t, spec.ShapeConfig, "expected shape config") {
+					assert.Equal(t, int32(4), *spec.ShapeConfig.OCPUs, "unexpected OCPUs")
+				}
+			}
+		})
+	}
+}
+
+func testOCIMasterMachine(t *testing.T, availabilityDomain string) *machineapi.Machine {
+	raw, err := json.Marshal(&ociMachineProviderSpec{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: ociProviderSpecAPIVersion,
+			Kind:       ociProviderSpecKind,
+		},
+		AvailabilityDomain: availabilityDomain,
+		ImageID:            testOCIImage,
+		SubnetID:           testOCISubnet,
+	})
+	require.NoError(t, err)
+	m := &machineapi.Machine{}
+	m.Spec.ProviderSpec.Value = &runtime.RawExtension{Raw: raw}
+	return m
+}
+
+func testOCIPool() *hivev1.MachinePool {
+	p := testMachinePool()
+	p.Spec.Platform = hivev1.MachinePoolPlatform{
+		OCI: &hivev1oci.MachinePool{
+			Shape: testOCIShape,
+			OCPUs: pointer.Int32Ptr(4),
+			BootVolume: hivev1oci.BootVolume{
+				SizeGBs: 120,
+			},
+		},
+	}
+	return p
+}
+
+func testOCIClusterDeployment() *hivev1.ClusterDeployment {
+	cd := testClusterDeployment()
+	cd.Spec.Platform = hivev1.Platform{
+		OCI: &hivev1oci.Platform{
+			CredentialsSecretRef: corev1.LocalObjectReference{
+				Name: "oci-credentials",
+			},
+			Region:        "us-ashburn-1",
+			CompartmentID: testOCICompartment,
+		},
+	}
+	return cd
+}
@@ -42,6 +42,17 @@ func addOpenStackProviderToScheme(scheme *runtime.Scheme) error {
 	return openstackprovider.AddToScheme(scheme)
 }
 
+func init() {
+	registerActuatorProvider(
+		func(cd *hivev1.ClusterDeployment) bool {
+			return cd.Spec.Platform.OpenStack != nil
+		},
+		func(r *ReconcileRemoteMachineSet, cd *hivev1.ClusterDeployment, pool *hivev1.MachinePool, masterMachine *machineapi.Machine, remoteMachineSets []machineapi.MachineSet, logger log.FieldLogger) (Actuator, error) {
+			return NewOpenStackActuator(masterMachine, r.scheme, r.Client, logger)
+		},
+	)
+}
+
 // NewOpenStackActuator is the constructor for building a OpenStackActuator
 func NewOpenStackActuator(masterMachine *machineapi.Machine, scheme *runtime.Scheme, kubeClient client.Client, logger log.FieldLogger) (*OpenStackActuator, error) {
 	osImage, err := getOpenStackOSImage(masterMachine, scheme, logger)
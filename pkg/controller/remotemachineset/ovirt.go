@@ -32,6 +32,17 @@ func addOvirtProviderToScheme(scheme *runtime.Scheme) error {
 	return ovirtprovider.AddToScheme(scheme)
 }
 
+func init() {
+	registerActuatorProvider(
+		func(cd *hivev1.ClusterDeployment) bool {
+			return cd.Spec.Platform.Ovirt != nil
+		},
+		func(r *ReconcileRemoteMachineSet, cd *hivev1.ClusterDeployment, pool *hivev1.MachinePool, masterMachine *machineapi.Machine, remoteMachineSets []machineapi.MachineSet, logger log.FieldLogger) (Actuator, error) {
+			return NewOvirtActuator(masterMachine, r.scheme, logger)
+		},
+	)
+}
+
 // NewOvirtActuator is the constructor for building a OvirtActuator
 func NewOvirtActuator(masterMachine *machineapi.Machine, scheme *runtime.Scheme, logger log.FieldLogger) (*OvirtActuator, error) {
 	osImage, err := getOvirtOSImage(masterMachine, scheme, logger)
@@ -0,0 +1,39 @@
+package remotemachineset
+
+import (
+	log "github.com/sirupsen/logrus"
+
+	machineapi "github.com/openshift/machine-api-operator/pkg/apis/machine/v1beta1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/providerplugin"
+)
+
+// PluginActuator generates the MachineSets of the clusters handled by a provider plugin.
+type PluginActuator struct {
+	plugin *providerplugin.Plugin
+}
+
+var _ Actuator = &PluginActuator{}
+
+// NewPluginActuator is the constructor for building a PluginActuator
+func NewPluginActuator(plugin *providerplugin.Plugin) *PluginActuator {
+	return &PluginActuator{plugin: plugin}
+}
+
+// GenerateMachineSets satisfies the Actuator interface and returns the MachineSets generated by the provider plugin.
+func (a *PluginActuator) GenerateMachineSets(cd *hivev1.ClusterDeployment, pool *hivev1.MachinePool, logger log.FieldLogger) ([]*machineapi.MachineSet, bool, error) {
+	response, err := a.plugin.Call(
+		providerplugin.OperationGenerateMachineSets,
+		&providerplugin.Request{ClusterDeployment: cd, MachinePool: pool},
+		logger,
+	)
+	if err != nil {
+		return nil, false, err
+	}
+	if response.Wait {
+		logger.Info("provider plugin is not ready to generate machine sets")
+		return nil, false, nil
+	}
+	return response.MachineSets, true, nil
+}
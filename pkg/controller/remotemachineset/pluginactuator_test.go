@@ -0,0 +1,51 @@
+package remotemachineset
+
+import (
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openshift/hive/pkg/providerplugin"
+)
+
+func TestPluginActuator(t *testing.T) {
+	tests := []struct {
+		name                string
+		response            string
+		expectedMachineSets []string
+		expectedProceed     bool
+	}{
+		{
+			name:                "generate machinesets",
+			response:            `{"machineSets": [{"metadata": {"name": "worker-a"}}, {"metadata": {"name": "worker-b"}}]}`,
+			expectedMachineSets: []string{"worker-a", "worker-b"},
+			expectedProceed:     true,
+		},
+		{
+			name:     "wait",
+			response: `{"wait": true}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			plugin := &providerplugin.Plugin{
+				Name:    "test",
+				Command: []string{"sh", "-c", `test "$1" = generate-machinesets && echo "$0"`, test.response},
+				Timeout: time.Minute,
+			}
+			actuator := NewPluginActuator(plugin)
+			machineSets, proceed, err := actuator.GenerateMachineSets(testClusterDeployment(), testMachinePool(), log.New())
+			require.NoError(t, err, "unexpected error")
+			assert.Equal(t, test.expectedProceed, proceed, "unexpected proceed")
+			names := []string{}
+			for _, ms := range machineSets {
+				names = append(names, ms.Name)
+			}
+			assert.ElementsMatch(t, test.expectedMachineSets, names, "unexpected machine sets")
+		})
+	}
+}
@@ -0,0 +1,202 @@
+package remotemachineset
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/pointer"
+
+	machineapi "github.com/openshift/machine-api-operator/pkg/apis/machine/v1beta1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+const (
+	powerVSProviderSpecAPIVersion = "machine.openshift.io/v1"
+	powerVSProviderSpecKind       = "PowerVSMachineProviderConfig"
+)
+
+// powerVSMachineProviderConfig is the provider spec consumed by the PowerVS machine controller. There is no vendored
+// PowerVS machine provider, so we only model the fields hive needs to read from the master machine or set for workers.
+type powerVSMachineProviderConfig struct {
+	metav1.TypeMeta `json:",inline"`
+
+	ServiceInstance   powerVSResource         `json:"serviceInstance"`
+	Image             powerVSResource         `json:"image"`
+	Network           powerVSResource         `json:"network"`
+	KeyPairName       string                  `json:"keyPairName,omitempty"`
+	SystemType        string                  `json:"systemType,omitempty"`
+	ProcessorType     string                  `json:"processorType,omitempty"`
+	Processors        *intstr.IntOrString     `json:"processors,omitempty"`
+	MemoryMiB         int32                   `json:"memoryMiB,omitempty"`
+	UserDataSecret    *powerVSSecretReference `json:"userDataSecret,omitempty"`
+	CredentialsSecret *powerVSSecretReference `json:"credentialsSecret,omitempty"`
+}
+
+type powerVSResource struct {
+	Type  string  `json:"type"`
+	ID    *string `json:"id,omitempty"`
+	Name  *string `json:"name,omitempty"`
+	RegEx *string `json:"regex,omitempty"`
+}
+
+type powerVSSecretReference struct {
+	Name string `json:"name"`
+}
+
+// PowerVSActuator encapsulates the pieces necessary to be able to generate
+// a list of MachineSets to sync to the remote cluster
+type PowerVSActuator struct {
+	logger log.FieldLogger
+	// master is the provider spec of an existing master machine, used for the service instance, image, network
+	// and defaults of new machinesets.
+	master *powerVSMachineProviderConfig
+}
+
+var _ Actuator = &PowerVSActuator{}
+
+func init() {
+	registerActuatorProvider(
+		func(cd *hivev1.ClusterDeployment) bool {
+			return cd.Spec.Platform.PowerVS != nil
+		},
+		func(r *ReconcileRemoteMachineSet, cd *hivev1.ClusterDeployment, pool *hivev1.MachinePool, masterMachine *machineapi.Machine, remoteMachineSets []machineapi.MachineSet, logger log.FieldLogger) (Actuator, error) {
+			return NewPowerVSActuator(masterMachine, logger)
+		},
+	)
+}
+
+// NewPowerVSActuator is the constructor for building a PowerVSActuator
+func NewPowerVSActuator(masterMachine *machineapi.Machine, logger log.FieldLogger) (*PowerVSActuator, error) {
+	master, err := decodePowerVSMachineProviderConfig(masterMachine.Spec.ProviderSpec.Value)
+	if err != nil {
+		logger.WithError(err).Error("error decoding provider spec from master machine")
+		return nil, err
+	}
+	return &PowerVSActuator{
+		logger: logger,
+		master: master,
+	}, nil
+}
+
+// GenerateMachineSets satisfies the Actuator interface and will take a clusterDeployment and return a list of MachineSets
+// to sync to the remote cluster. PowerVS service instances live in a single zone, so a single MachineSet is generated.
+func (a *PowerVSActuator) GenerateMachineSets(cd *hivev1.ClusterDeployment, pool *hivev1.MachinePool, logger log.FieldLogger) ([]*machineapi.MachineSet, bool, error) {
+	if cd.Spec.ClusterMetadata == nil {
+		return nil, false, errors.New("ClusterDeployment does not have cluster metadata")
+	}
+	if cd.Spec.Platform.PowerVS == nil {
+		return nil, false, errors.New("ClusterDeployment is not for PowerVS")
+	}
+	if pool.Spec.Platform.PowerVS == nil {
+		return nil, false, errors.New("MachinePool is not for PowerVS")
+	}
+
+	raw, err := json.Marshal(a.providerSpec(pool))
+	if err != nil {
+		return nil, false, errors.Wrap(err, "failed to encode provider spec")
+	}
+
+	infraID := cd.Spec.ClusterMetadata.InfraID
+	replicas := int32(0)
+	if pool.Spec.Replicas != nil {
+		replicas = int32(*pool.Spec.Replicas)
+	}
+	name := fmt.Sprintf("%s-%s-%d", infraID, pool.Spec.Name, 0)
+	machineSet := &machineapi.MachineSet{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "machine.openshift.io/v1beta1",
+			Kind:       "MachineSet",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "openshift-machine-api",
+			Name:      name,
+			Labels: map[string]string{
+				"machine.openshift.io/cluster-api-cluster":      infraID,
+				"machine.openshift.io/cluster-api-machine-role": workerRole,
+				"machine.openshift.io/cluster-api-machine-type": workerRole,
+			},
+		},
+		Spec: machineapi.MachineSetSpec{
+			Replicas: pointer.Int32Ptr(replicas),
+			Selector: metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"machine.openshift.io/cluster-api-machineset": name,
+					"machine.openshift.io/cluster-api-cluster":    infraID,
+				},
+			},
+			Template: machineapi.MachineTemplateSpec{
+				ObjectMeta: machineapi.ObjectMeta{
+					Labels: map[string]string{
+						"machine.openshift.io/cluster-api-machineset":   name,
+						"machine.openshift.io/cluster-api-cluster":      infraID,
+						"machine.openshift.io/cluster-api-machine-role": workerRole,
+						"machine.openshift.io/cluster-api-machine-type": workerRole,
+					},
+				},
+				Spec: machineapi.MachineSpec{
+					ProviderSpec: machineapi.ProviderSpec{
+						Value: &runtime.RawExtension{Raw: raw},
+					},
+				},
+			},
+		},
+	}
+	return []*machineapi.MachineSet{machineSet}, true, nil
+}
+
+func (a *PowerVSActuator) providerSpec(pool *hivev1.MachinePool) *powerVSMachineProviderConfig {
+	poolPowerVS := pool.Spec.Platform.PowerVS
+	spec := &powerVSMachineProviderConfig{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: powerVSProviderSpecAPIVersion,
+			Kind:       powerVSProviderSpecKind,
+		},
+		ServiceInstance:   a.master.ServiceInstance,
+		Image:             a.master.Image,
+		Network:           a.master.Network,
+		KeyPairName:       a.master.KeyPairName,
+		SystemType:        a.master.SystemType,
+		ProcessorType:     a.master.ProcessorType,
+		Processors:        a.master.Processors,
+		MemoryMiB:         a.master.MemoryMiB,
+		UserDataSecret:    &powerVSSecretReference{Name: workerUserDataName},
+		CredentialsSecret: a.master.CredentialsSecret,
+	}
+	if poolPowerVS.SysType != "" {
+		spec.SystemType = poolPowerVS.SysType
+	}
+	if poolPowerVS.ProcType != "" {
+		// The machine API expects the capitalized form, eg. Dedicated.
+		spec.ProcessorType = strings.Title(poolPowerVS.ProcType)
+	}
+	if poolPowerVS.Processors != "" {
+		processors := intstr.FromString(poolPowerVS.Processors)
+		spec.Processors = &processors
+	}
+	if poolPowerVS.MemoryGiB > 0 {
+		spec.MemoryMiB = poolPowerVS.MemoryGiB * 1024
+	}
+	return spec
+}
+
+func decodePowerVSMachineProviderConfig(rawExt *runtime.RawExtension) (*powerVSMachineProviderConfig, error) {
+	if rawExt == nil {
+		return nil, fmt.Errorf("MachineSet has no ProviderSpec")
+	}
+	spec := &powerVSMachineProviderConfig{}
+	if err := json.Unmarshal(rawExt.Raw, spec); err != nil {
+		return nil, fmt.Errorf("could not decode PowerVS ProviderSpec: %v", err)
+	}
+	if spec.Kind != powerVSProviderSpecKind {
+		return nil, fmt.Errorf("unexpected provider spec kind: %q", spec.Kind)
+	}
+	return spec, nil
+}
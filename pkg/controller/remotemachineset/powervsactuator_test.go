@@ -0,0 +1,127 @@
+package remotemachineset
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/pointer"
+
+	machineapi "github.com/openshift/machine-api-operator/pkg/apis/machine/v1beta1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1powervs "github.com/openshift/hive/apis/hive/v1/powervs"
+)
+
+const (
+	testPowerVSServiceInstance = "fake-service-instance"
+	testPowerVSImage           = "rhcos-fake"
+	testPowerVSNetwork         = "pvs-net-fake"
+)
+
+func TestPowerVSActuator(t *testing.T) {
+	tests := []struct {
+		name               string
+		pool               *hivev1powervs.MachinePool
+		expectedSysType    string
+		expectedProcType   string
+		expectedProcessors string
+		expectedMemoryMiB  int32
+	}{
+		{
+			name:               "defaults from master machine",
+			pool:               &hivev1powervs.MachinePool{},
+			expectedSysType:    "s922",
+			expectedProcType:   "Shared",
+			expectedProcessors: "0.5",
+			expectedMemoryMiB:  32768,
+		},
+		{
+			name: "pool overrides",
+			pool: &hivev1powervs.MachinePool{
+				SysType:    "e980",
+				ProcType:   "dedicated",
+				Processors: "2",
+				MemoryGiB:  64,
+			},
+			expectedSysType:    "e980",
+			expectedProcType:   "Dedicated",
+			expectedProcessors: "2",
+			expectedMemoryMiB:  65536,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			logger := log.WithField("actuator", "powervsactuator_test")
+			actuator, err := NewPowerVSActuator(testPowerVSMasterMachine(t), logger)
+			require.NoError(t, err, "unexpected error creating actuator")
+
+			pool := testMachinePool()
+			pool.Spec.Platform = hivev1.MachinePoolPlatform{PowerVS: test.pool}
+
+			generatedMachineSets, _, err := actuator.GenerateMachineSets(testPowerVSClusterDeployment(), pool, logger)
+			require.NoError(t, err, "unexpected error for test case")
+			require.Len(t, generatedMachineSets, 1, "expected a single machine set")
+			ms := generatedMachineSets[0]
+			assert.Equal(t, fmt.Sprintf("%s-worker-0", testInfraID), ms.Name, "unexpected machine set name")
+			assert.Equal(t, int32(3), *ms.Spec.Replicas, "replica mismatch")
+
+			spec, err := decodePowerVSMachineProviderConfig(ms.Spec.Template.Spec.ProviderSpec.Value)
+			require.NoError(t, err, "failed to decode provider spec")
+			assert.Equal(t, testPowerVSServiceInstance, *spec.ServiceInstance.ID, "unexpected service instance")
+			assert.Equal(t, testPowerVSImage, *spec.Image.Name, "unexpected image")
+			assert.Equal(t, testPowerVSNetwork, *spec.Network.Name, "unexpected network")
+			assert.Equal(t, test.expectedSysType, spec.SystemType, "unexpected system type")
+			assert.Equal(t, test.expectedProcType, spec.ProcessorType, "unexpected processor type")
+			assert.Equal(t, test.expectedProcessors, spec.Processors.String(), "unexpected processors")
+			assert.Equal(t, test.expectedMemoryMiB, spec.MemoryMiB, "unexpected memory")
+			assert.Equal(t, workerUserDataName, spec.UserDataSecret.Name, "unexpected user data secret")
+		})
+	}
+}
+
+func testPowerVSMasterMachine(t *testing.T) *machineapi.Machine {
+	processors := intstr.FromString("0.5")
+	raw, err := json.Marshal(&powerVSMachineProviderConfig{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: powerVSProviderSpecAPIVersion,
+			Kind:       powerVSProviderSpecKind,
+		},
+		ServiceInstance: powerVSResource{Type: "ID", ID: pointer.StringPtr(testPowerVSServiceInstance)},
+		Image:           powerVSResource{Type: "Name", Name: pointer.StringPtr(testPowerVSImage)},
+		Network:         powerVSResource{Type: "Name", Name: pointer.StringPtr(testPowerVSNetwork)},
+		SystemType:      "s922",
+		ProcessorType:   "Shared",
+		Processors:      &processors,
+		MemoryMiB:       32768,
+		UserDataSecret:  &powerVSSecretReference{Name: "master-user-data"},
+	})
+	require.NoError(t, err)
+	m := &machineapi.Machine{}
+	m.Spec.ProviderSpec.Value = &runtime.RawExtension{Raw: raw}
+	return m
+}
+
+func testPowerVSClusterDeployment() *hivev1.ClusterDeployment {
+	cd := testClusterDeployment()
+	cd.Spec.Platform = hivev1.Platform{
+		PowerVS: &hivev1powervs.Platform{
+			CredentialsSecretRef: corev1.LocalObjectReference{
+				Name: "powervs-credentials",
+			},
+			Region:            "dal",
+			Zone:              "dal12",
+			ServiceInstanceID: testPowerVSServiceInstance,
+		},
+	}
+	return cd
+}
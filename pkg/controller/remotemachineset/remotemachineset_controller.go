@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
@@ -30,7 +31,9 @@ import (
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	"github.com/openshift/hive/pkg/constants"
 	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
+	"github.com/openshift/hive/pkg/controller/nodetuning"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/providerplugin"
 	"github.com/openshift/hive/pkg/remoteclient"
 )
 
@@ -39,6 +42,8 @@ const (
 	machinePoolNameLabel       = "hive.openshift.io/machine-pool"
 	finalizer                  = "hive.openshift.io/remotemachineset"
 	masterMachineLabelSelector = "machine.openshift.io/cluster-api-machine-type=master"
+
+	defaultResyncInterval = 2 * time.Hour
 )
 
 // controllerKind contains the schema.GroupVersionKind for this controller type.
@@ -76,6 +81,14 @@ func Add(mgr manager.Manager) error {
 		scheme:       mgr.GetScheme(),
 		logger:       logger,
 		expectations: controllerutils.NewExpectations(logger),
+		resyncInterval: controllerutils.PeriodicSyncIntervalFromEnv(
+			constants.MachineSetResyncIntervalEnvVar,
+			defaultResyncInterval,
+		),
+		resyncJitterPercent: controllerutils.PeriodicSyncJitterPercentFromEnv(),
+	}
+	if r.providerPlugins, err = providerplugin.Load(); err != nil {
+		logger.WithError(err).Error("could not load provider plugins")
 	}
 	r.actuatorBuilder = func(cd *hivev1.ClusterDeployment, pool *hivev1.MachinePool, masterMachine *machineapi.Machine, remoteMachineSets []machineapi.MachineSet, logger log.FieldLogger) (Actuator, error) {
 		return r.createActuator(cd, pool, masterMachine, remoteMachineSets, logger)
@@ -113,6 +126,14 @@ func Add(mgr manager.Manager) error {
 		return err
 	}
 
+	// Watch for changes to ChangeFreezes, to sync the MachinePools of the clusters once they are no longer frozen
+	err = c.Watch(&source.Kind{Type: &hivev1.ChangeFreeze{}}, &handler.EnqueueRequestsFromMapFunc{
+		ToRequests: handler.ToRequestsFunc(r.changeFreezeWatchHandler),
+	})
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -145,6 +166,37 @@ func (r *ReconcileRemoteMachineSet) clusterDeploymentWatchHandler(a handler.MapO
 	return retval
 }
 
+func (r *ReconcileRemoteMachineSet) changeFreezeWatchHandler(a handler.MapObject) []reconcile.Request {
+	freeze, ok := a.Object.(*hivev1.ChangeFreeze)
+	if !ok {
+		return nil
+	}
+
+	cds, err := controllerutils.ClusterDeploymentsForChangeFreeze(r, freeze)
+	if err != nil {
+		r.logger.WithField("changeFreeze", freeze.Name).WithError(err).Error("could not list cluster deployments for change freeze")
+		return nil
+	}
+
+	var retval []reconcile.Request
+	for _, cd := range cds {
+		pools := &hivev1.MachinePoolList{}
+		if err := r.List(context.TODO(), pools, client.InNamespace(cd.Namespace)); err != nil {
+			r.logger.WithField("namespace", cd.Namespace).WithError(err).Error("could not list machine pools")
+			continue
+		}
+		for _, pool := range pools.Items {
+			if pool.Spec.ClusterDeploymentRef.Name != cd.Name {
+				continue
+			}
+			key := client.ObjectKey{Namespace: pool.Namespace, Name: pool.Name}
+			retval = append(retval, reconcile.Request{NamespacedName: key})
+		}
+	}
+
+	return retval
+}
+
 var _ reconcile.Reconciler = &ReconcileRemoteMachineSet{}
 
 // ReconcileRemoteMachineSet reconciles the MachineSets generated from a ClusterDeployment object
@@ -167,9 +219,20 @@ type ReconcileRemoteMachineSet struct {
 		logger log.FieldLogger,
 	) (Actuator, error)
 
+	// providerPlugins are the provider plugins which generate the MachineSets of the clusters naming them, in place
+	// of the actuators.
+	providerPlugins providerplugin.Plugins
+
 	// A TTLCache of machinepoolnamelease creates each machinepool expects to see. Note that not all actuators make use
 	// of expectations.
 	expectations controllerutils.ExpectationsInterface
+
+	// resyncInterval is the base interval at which the MachineSets of each MachinePool are resynced to the cluster.
+	// The MachinePools are not resynced periodically when it is zero.
+	resyncInterval time.Duration
+	// resyncJitterPercent is the maximum jitter of the resyncs of each MachinePool, as a percentage of the base
+	// interval.
+	resyncJitterPercent int
 }
 
 // Reconcile reads that state of the cluster for a MachinePool object and makes changes to the
@@ -253,6 +316,15 @@ func (r *ReconcileRemoteMachineSet) Reconcile(request reconcile.Request) (reconc
 		return reconcile.Result{}, nil
 	}
 
+	// Changes to the machine pools of a frozen cluster are made once the freeze ends.
+	switch freeze, err := controllerutils.ActiveChangeFreeze(r.Client, cd, time.Now(), logger); {
+	case err != nil:
+		return reconcile.Result{}, err
+	case freeze != nil:
+		logger.WithField("changeFreeze", freeze.Name).Info("cluster is frozen, deferring machine pool changes")
+		return reconcile.Result{RequeueAfter: time.Until(freeze.Spec.End.Time)}, nil
+	}
+
 	remoteClusterAPIClient, unreachable, requeue := remoteclient.ConnectToRemoteCluster(
 		cd,
 		r.remoteClusterAPIClientBuilder(cd),
@@ -276,7 +348,7 @@ func (r *ReconcileRemoteMachineSet) Reconcile(request reconcile.Request) (reconc
 		return reconcile.Result{}, err
 	}
 
-	generatedMachineSets, proceed, err := r.generateMachineSets(pool, cd, masterMachine, remoteMachineSets, logger)
+	generatedMachineSets, proceed, err := r.generateMachineSets(pool, cd, masterMachine, remoteMachineSets, remoteClusterAPIClient, logger)
 	if err != nil {
 		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not generateMachineSets")
 		return reconcile.Result{}, err
@@ -293,12 +365,24 @@ func (r *ReconcileRemoteMachineSet) Reconcile(request reconcile.Request) (reconc
 		return *result, nil
 	}
 
+	// Resync the MachineSets periodically, as changes to them in the cluster are not watched. The resyncs of the
+	// MachinePools are spread out so that they do not all happen at the same time.
+	result := reconcile.Result{}
+	if r.resyncInterval > 0 {
+		result.RequeueAfter = controllerutils.JitteredInterval(r.resyncInterval, r.resyncJitterPercent, request.NamespacedName)
+	}
+
 	machineSets, err := r.syncMachineSets(pool, cd, generatedMachineSets, remoteMachineSets, remoteClusterAPIClient, logger)
 	if err != nil {
 		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not syncMachineSets")
 		return reconcile.Result{}, err
 	}
 
+	// The autoscalers depend on the MachineSets, which are left unchanged in dry run mode.
+	if isDryRun(pool) {
+		return result, nil
+	}
+
 	if err := r.syncMachineAutoscalers(pool, cd, machineSets, remoteClusterAPIClient, logger); err != nil {
 		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not syncMachineAutoscalers")
 		return reconcile.Result{}, err
@@ -313,7 +397,11 @@ func (r *ReconcileRemoteMachineSet) Reconcile(request reconcile.Request) (reconc
 		return r.removeFinalizer(pool, logger)
 	}
 
-	return reconcile.Result{}, r.updatePoolStatusForMachineSets(pool, machineSets, logger)
+	if err := r.updatePoolStatusForMachineSets(pool, machineSets, logger); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	return result, nil
 }
 
 func (r *ReconcileRemoteMachineSet) getMasterMachine(
@@ -368,6 +456,7 @@ func (r *ReconcileRemoteMachineSet) generateMachineSets(
 	cd *hivev1.ClusterDeployment,
 	masterMachine *machineapi.Machine,
 	remoteMachineSets *machineapi.MachineSetList,
+	remoteClusterAPIClient client.Client,
 	logger log.FieldLogger,
 ) ([]*machineapi.MachineSet, bool, error) {
 	if pool.DeletionTimestamp != nil {
@@ -379,6 +468,20 @@ func (r *ReconcileRemoteMachineSet) generateMachineSets(
 		logger.WithError(err).Error("unable to create actuator")
 		return nil, false, err
 	}
+	if observer, ok := actuator.(machineObservingActuator); ok {
+		remoteMachines := &machineapi.MachineList{}
+		tm := metav1.TypeMeta{}
+		tm.SetGroupVersionKind(machineapi.SchemeGroupVersion.WithKind("Machine"))
+		if err := remoteClusterAPIClient.List(
+			context.Background(),
+			remoteMachines,
+			&client.ListOptions{Raw: &metav1.ListOptions{TypeMeta: tm}},
+		); err != nil {
+			logger.WithError(err).Error("unable to fetch remote machines")
+			return nil, false, err
+		}
+		observer.ObserveMachines(remoteMachines.Items)
+	}
 
 	// Generate expected MachineSets for Platform from InstallConfig
 	generatedMachineSets, proceed, err := actuator.GenerateMachineSets(cd, pool, logger)
@@ -407,6 +510,10 @@ func (r *ReconcileRemoteMachineSet) generateMachineSets(
 		for key, value := range pool.Spec.Labels {
 			ms.Spec.Template.Spec.ObjectMeta.Labels[key] = value
 		}
+		// Select the nodes of pools with their own node tuning defaults into the machine config pool of the pool.
+		if nodetuning.HasMachinePoolNodeTuning(cd, pool.Spec.Name) {
+			ms.Spec.Template.Spec.ObjectMeta.Labels[nodetuning.MachinePoolNodeRoleLabel(pool.Spec.Name)] = ""
+		}
 
 		// Apply hive MachinePool taints to MachineSet MachineSpec.
 		ms.Spec.Template.Spec.Taints = pool.Spec.Taints
@@ -486,6 +593,8 @@ func (r *ReconcileRemoteMachineSet) syncMachineSets(
 	machineSetsToDelete := []*machineapi.MachineSet{}
 	machineSetsToCreate := []*machineapi.MachineSet{}
 	machineSetsToUpdate := []*machineapi.MachineSet{}
+	// The MachineSets on the remote cluster before their update, to record the changes in dry run mode.
+	remoteMachineSetsToUpdate := map[string]*machineapi.MachineSet{}
 
 	// Find MachineSets that need updating/creating
 	for i, ms := range generatedMachineSets {
@@ -493,6 +602,7 @@ func (r *ReconcileRemoteMachineSet) syncMachineSets(
 		for _, rMS := range remoteMachineSets.Items {
 			if ms.Name == rMS.Name {
 				found = true
+				origMS := rMS.DeepCopy()
 				objectModified := false
 				objectMetaModified := false
 				resourcemerge.EnsureObjectMeta(&objectMetaModified, &rMS.ObjectMeta, ms.ObjectMeta)
@@ -550,6 +660,7 @@ func (r *ReconcileRemoteMachineSet) syncMachineSets(
 				if objectMetaModified || objectModified {
 					rMS.Generation++
 					machineSetsToUpdate = append(machineSetsToUpdate, &rMS)
+					remoteMachineSetsToUpdate[rMS.Name] = origMS
 				}
 
 				result[i] = &rMS
@@ -582,6 +693,40 @@ func (r *ReconcileRemoteMachineSet) syncMachineSets(
 		}
 	}
 
+	if isDryRun(pool) {
+		var changes []hivev1.MachineSetChange
+		for _, ms := range machineSetsToCreate {
+			change, err := machineSetChange(hivev1.CreateMachineSetChangeAction, nil, ms)
+			if err != nil {
+				return nil, err
+			}
+			changes = append(changes, change)
+		}
+		for _, ms := range machineSetsToUpdate {
+			from := remoteMachineSetsToUpdate[ms.Name]
+			// The generation is bumped for the update, which is not a change to review.
+			to := ms.DeepCopy()
+			to.Generation = from.Generation
+			change, err := machineSetChange(hivev1.UpdateMachineSetChangeAction, from, to)
+			if err != nil {
+				return nil, err
+			}
+			changes = append(changes, change)
+		}
+		for _, ms := range machineSetsToDelete {
+			change, err := machineSetChange(hivev1.DeleteMachineSetChangeAction, ms, nil)
+			if err != nil {
+				return nil, err
+			}
+			changes = append(changes, change)
+		}
+		logger.WithField("changes", len(changes)).Info("machine pool is in dry run mode, recording machineset changes")
+		if err := r.updatePoolStatusForDryRun(pool, changes); err != nil {
+			return nil, err
+		}
+		return result, nil
+	}
+
 	for _, ms := range machineSetsToCreate {
 		logger.WithField("machineset", ms.Name).Info("creating machineset")
 		if err := remoteClusterAPIClient.Create(context.Background(), ms); err != nil {
@@ -808,6 +953,12 @@ func (r *ReconcileRemoteMachineSet) updatePoolStatusForMachineSets(
 ) error {
 	origPool := pool.DeepCopy()
 
+	pool.Status.ObservedGeneration = pool.Generation
+	pool.Status.DryRun = nil
+	insufficientCapacity := map[string][]string{}
+	for _, ms := range origPool.Status.MachineSets {
+		insufficientCapacity[ms.Name] = ms.InsufficientCapacityInstanceTypes
+	}
 	pool.Status.MachineSets = make([]hivev1.MachineSetStatus, len(machineSets))
 	pool.Status.Replicas = 0
 	for i, ms := range machineSets {
@@ -823,11 +974,14 @@ func (r *ReconcileRemoteMachineSet) updatePoolStatusForMachineSets(
 			Replicas:    *ms.Spec.Replicas,
 			MinReplicas: min,
 			MaxReplicas: max,
+
+			InsufficientCapacityInstanceTypes: insufficientCapacity[ms.Name],
 		}
 		pool.Status.Replicas += *ms.Spec.Replicas
 	}
 
-	if (len(origPool.Status.MachineSets) == 0 && len(pool.Status.MachineSets) == 0) ||
+	if (len(origPool.Status.MachineSets) == 0 && len(pool.Status.MachineSets) == 0 &&
+		origPool.Status.ObservedGeneration == pool.Status.ObservedGeneration && origPool.Status.DryRun == nil) ||
 		reflect.DeepEqual(origPool.Status, pool.Status) {
 		return nil
 	}
@@ -842,59 +996,34 @@ func (r *ReconcileRemoteMachineSet) createActuator(
 	remoteMachineSets []machineapi.MachineSet,
 	logger log.FieldLogger,
 ) (Actuator, error) {
-	switch {
-	case cd.Spec.Platform.AWS != nil:
-		creds := &corev1.Secret{}
-		if err := r.Get(
-			context.TODO(),
-			types.NamespacedName{
-				Name:      cd.Spec.Platform.AWS.CredentialsSecretRef.Name,
-				Namespace: cd.Namespace,
-			},
-			creds,
-		); err != nil {
-			return nil, err
+	if plugin, ok := r.providerPlugins.For(cd); ok {
+		if plugin == nil {
+			return nil, errors.Errorf("provider plugin %s is not configured", cd.Annotations[constants.ProviderPluginAnnotation])
 		}
-		return NewAWSActuator(r.Client, creds, cd.Spec.Platform.AWS.Region, pool, masterMachine, r.scheme, logger)
-	case cd.Spec.Platform.GCP != nil:
-		creds := &corev1.Secret{}
-		if err := r.Get(
-			context.TODO(),
-			types.NamespacedName{
-				Name:      cd.Spec.Platform.GCP.CredentialsSecretRef.Name,
-				Namespace: cd.Namespace,
-			},
-			creds,
-		); err != nil {
-			return nil, err
-		}
-		clusterVersion, err := getClusterVersion(cd)
-		if err != nil {
-			return nil, err
-		}
-		return NewGCPActuator(r.Client, creds, clusterVersion, masterMachine, remoteMachineSets, r.scheme, r.expectations, logger)
-	case cd.Spec.Platform.Azure != nil:
-		creds := &corev1.Secret{}
-		if err := r.Get(
-			context.TODO(),
-			types.NamespacedName{
-				Name:      cd.Spec.Platform.Azure.CredentialsSecretRef.Name,
-				Namespace: cd.Namespace,
-			},
-			creds,
-		); err != nil {
-			return nil, err
+		return NewPluginActuator(plugin), nil
+	}
+	for _, p := range actuatorProviders {
+		if p.canHandle(cd) {
+			return p.newActuator(r, cd, pool, masterMachine, remoteMachineSets, logger)
 		}
-		return NewAzureActuator(creds, logger)
-	case cd.Spec.Platform.OpenStack != nil:
-		return NewOpenStackActuator(masterMachine, r.scheme, r.Client, logger)
-	case cd.Spec.Platform.VSphere != nil:
-		return NewVSphereActuator(masterMachine, r.scheme, logger)
-	case cd.Spec.Platform.Ovirt != nil:
-		return NewOvirtActuator(masterMachine, r.scheme, logger)
-	default:
-		return nil, errors.New("unsupported platform")
 	}
+	return nil, errors.New("unsupported platform")
+}
+
+// getCredentialsSecret returns the platform credentials secret of the ClusterDeployment.
+func (r *ReconcileRemoteMachineSet) getCredentialsSecret(cd *hivev1.ClusterDeployment, name string) (*corev1.Secret, error) {
+	creds := &corev1.Secret{}
+	if err := r.Get(
+		context.TODO(),
+		types.NamespacedName{
+			Name:      name,
+			Namespace: cd.Namespace,
+		},
+		creds,
+	); err != nil {
+		return nil, err
+	}
+	return creds, nil
 }
 
 func baseMachinePool(pool *hivev1.MachinePool) *installertypes.MachinePool {
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 
@@ -108,14 +109,17 @@ func TestRemoteMachineSetReconcile(t *testing.T) {
 		name                             string
 		clusterDeployment                *hivev1.ClusterDeployment
 		machinePool                      *hivev1.MachinePool
+		changeFreeze                     *hivev1.ChangeFreeze
 		remoteExisting                   []runtime.Object
 		generatedMachineSets             []*machineapi.MachineSet
 		actuatorDoNotProceed             bool
 		expectErr                        bool
+		expectResync                     bool
 		expectNoFinalizer                bool
 		expectedRemoteMachineSets        []*machineapi.MachineSet
 		expectedRemoteMachineAutoscalers []autoscalingv1beta1.MachineAutoscaler
 		expectedRemoteClusterAutoscalers []autoscalingv1.ClusterAutoscaler
+		expectedDryRunChanges            map[string]hivev1.MachineSetChangeAction
 	}{
 		{
 			name: "Cluster not installed yet",
@@ -130,6 +134,7 @@ func TestRemoteMachineSetReconcile(t *testing.T) {
 			name:              "No-op",
 			clusterDeployment: testClusterDeployment(),
 			machinePool:       testMachinePool(),
+			expectResync:      true,
 			remoteExisting: []runtime.Object{
 				testMachine("master1", "master"),
 				testMachineSet("foo-12345-worker-us-east-1a", "worker", true, 1, 0),
@@ -190,6 +195,57 @@ func TestRemoteMachineSetReconcile(t *testing.T) {
 				testMachineSet("foo-12345-worker-us-east-1c", "worker", true, 1, 1),
 			},
 		},
+		{
+			name: "Label nodes of machine pool with node tuning defaults",
+			clusterDeployment: func() *hivev1.ClusterDeployment {
+				cd := testClusterDeployment()
+				cd.Spec.NodeTuning = &hivev1.NodeTuning{
+					MachinePools: []hivev1.MachinePoolNodeTuning{{
+						Name:              testPoolName,
+						SysctlProfileRefs: []corev1.LocalObjectReference{{Name: "worker-sysctls"}},
+					}},
+				}
+				return cd
+			}(),
+			machinePool: testMachinePool(),
+			remoteExisting: []runtime.Object{
+				testMachine("master1", "master"),
+				testMachineSet("foo-12345-worker-us-east-1a", "worker", true, 1, 0),
+			},
+			generatedMachineSets: []*machineapi.MachineSet{
+				testMachineSet("foo-12345-worker-us-east-1a", "worker", false, 1, 0),
+			},
+			expectedRemoteMachineSets: []*machineapi.MachineSet{
+				func() *machineapi.MachineSet {
+					ms := testMachineSet("foo-12345-worker-us-east-1a", "worker", true, 1, 1)
+					ms.Spec.Template.Spec.Labels["node-role.kubernetes.io/hive-worker"] = ""
+					return ms
+				}(),
+			},
+		},
+		{
+			name:              "Machine set replicas not updated for frozen cluster",
+			clusterDeployment: testClusterDeployment(),
+			machinePool:       testMachinePool(),
+			changeFreeze: &hivev1.ChangeFreeze{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-freeze"},
+				Spec: hivev1.ChangeFreezeSpec{
+					Start: metav1.NewTime(time.Now().Add(-time.Hour)),
+					End:   metav1.NewTime(time.Now().Add(time.Hour)),
+				},
+			},
+			remoteExisting: []runtime.Object{
+				testMachine("master1", "master"),
+				testMachineSet("foo-12345-worker-us-east-1a", "worker", true, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1b", "worker", true, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1c", "worker", true, 0, 0),
+			},
+			expectedRemoteMachineSets: []*machineapi.MachineSet{
+				testMachineSet("foo-12345-worker-us-east-1a", "worker", true, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1b", "worker", true, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1c", "worker", true, 0, 0),
+			},
+		},
 		{
 			name:              "Create missing machine set",
 			clusterDeployment: testClusterDeployment(),
@@ -210,6 +266,69 @@ func TestRemoteMachineSetReconcile(t *testing.T) {
 				testMachineSet("foo-12345-worker-us-east-1c", "worker", false, 1, 0),
 			},
 		},
+		{
+			name:              "Dry run records machine set changes",
+			clusterDeployment: testClusterDeployment(),
+			machinePool: func() *hivev1.MachinePool {
+				pool := testMachinePool()
+				pool.Spec.DryRun = true
+				return pool
+			}(),
+			expectResync: true,
+			remoteExisting: []runtime.Object{
+				testMachine("master1", "master"),
+				testMachineSet("foo-12345-worker-us-east-1a", "worker", true, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1b", "worker", true, 0, 0),
+				testMachineSet("foo-12345-worker-us-east-1d", "worker", true, 1, 0),
+			},
+			generatedMachineSets: []*machineapi.MachineSet{
+				testMachineSet("foo-12345-worker-us-east-1a", "worker", false, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1b", "worker", false, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1c", "worker", false, 1, 0),
+			},
+			expectedRemoteMachineSets: []*machineapi.MachineSet{
+				testMachineSet("foo-12345-worker-us-east-1a", "worker", true, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1b", "worker", true, 0, 0),
+				testMachineSet("foo-12345-worker-us-east-1d", "worker", true, 1, 0),
+			},
+			expectedDryRunChanges: map[string]hivev1.MachineSetChangeAction{
+				"foo-12345-worker-us-east-1b": hivev1.UpdateMachineSetChangeAction,
+				"foo-12345-worker-us-east-1c": hivev1.CreateMachineSetChangeAction,
+				"foo-12345-worker-us-east-1d": hivev1.DeleteMachineSetChangeAction,
+			},
+		},
+		{
+			name:              "Dry run status cleared",
+			clusterDeployment: testClusterDeployment(),
+			machinePool: func() *hivev1.MachinePool {
+				pool := testMachinePool()
+				pool.Status.DryRun = &hivev1.MachinePoolDryRunStatus{
+					MachineSets: []hivev1.MachineSetChange{{
+						Name:   "foo-12345-worker-us-east-1c",
+						Action: hivev1.UpdateMachineSetChangeAction,
+						Diff:   "-replicas: 0\n+replicas: 1\n",
+					}},
+				}
+				return pool
+			}(),
+			expectResync: true,
+			remoteExisting: []runtime.Object{
+				testMachine("master1", "master"),
+				testMachineSet("foo-12345-worker-us-east-1a", "worker", true, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1b", "worker", true, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1c", "worker", true, 0, 0),
+			},
+			generatedMachineSets: []*machineapi.MachineSet{
+				testMachineSet("foo-12345-worker-us-east-1a", "worker", false, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1b", "worker", false, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1c", "worker", false, 1, 0),
+			},
+			expectedRemoteMachineSets: []*machineapi.MachineSet{
+				testMachineSet("foo-12345-worker-us-east-1a", "worker", true, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1b", "worker", true, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1c", "worker", true, 1, 1),
+			},
+		},
 		{
 			name: "Skip create missing machine set when clusterDeployment has annotation hive.openshift.io/syncset-pause: true ",
 			clusterDeployment: func() *hivev1.ClusterDeployment {
@@ -631,6 +750,9 @@ func TestRemoteMachineSetReconcile(t *testing.T) {
 			if test.machinePool != nil {
 				localExisting = append(localExisting, test.machinePool)
 			}
+			if test.changeFreeze != nil {
+				localExisting = append(localExisting, test.changeFreeze)
+			}
 			fakeClient := fake.NewFakeClient(localExisting...)
 			remoteFakeClient := fake.NewFakeClient(test.remoteExisting...)
 
@@ -658,9 +780,11 @@ func TestRemoteMachineSetReconcile(t *testing.T) {
 				actuatorBuilder: func(cd *hivev1.ClusterDeployment, pool *hivev1.MachinePool, masterMachine *machineapi.Machine, remoteMachineSets []machineapi.MachineSet, cdLog log.FieldLogger) (Actuator, error) {
 					return mockActuator, nil
 				},
-				expectations: controllerExpectations,
+				expectations:        controllerExpectations,
+				resyncInterval:      defaultResyncInterval,
+				resyncJitterPercent: controllerutils.DefaultPeriodicSyncJitterPercent,
 			}
-			_, err := rcd.Reconcile(reconcile.Request{
+			result, err := rcd.Reconcile(reconcile.Request{
 				NamespacedName: types.NamespacedName{
 					Name:      fmt.Sprintf("%s-worker", testName),
 					Namespace: testNamespace,
@@ -679,12 +803,27 @@ func TestRemoteMachineSetReconcile(t *testing.T) {
 				return
 			}
 
+			if test.expectResync {
+				assert.GreaterOrEqual(t, result.RequeueAfter.Seconds(), defaultResyncInterval.Seconds(), "resync too early")
+				assert.LessOrEqual(t, result.RequeueAfter.Seconds(), defaultResyncInterval.Seconds()*1.1, "resync too late")
+			}
+
 			if pool := getPool(fakeClient, "worker"); assert.NotNil(t, pool, "missing machinepool") {
 				if test.expectNoFinalizer {
 					assert.NotContains(t, pool.Finalizers, finalizer, "unexpected finalizer")
 				} else {
 					assert.Contains(t, pool.Finalizers, finalizer, "missing finalizer")
 				}
+				if test.expectedDryRunChanges == nil {
+					assert.Nil(t, pool.Status.DryRun, "unexpected dry run status")
+				} else if assert.NotNil(t, pool.Status.DryRun, "missing dry run status") {
+					actions := map[string]hivev1.MachineSetChangeAction{}
+					for _, change := range pool.Status.DryRun.MachineSets {
+						actions[change.Name] = change.Action
+						assert.NotEmpty(t, change.Diff, "missing diff for machineset %s", change.Name)
+					}
+					assert.Equal(t, test.expectedDryRunChanges, actions, "unexpected dry run changes")
+				}
 			}
 
 			rMSL, err := getRMSL(remoteFakeClient)
@@ -32,6 +32,17 @@ func addVSphereProviderToScheme(scheme *runtime.Scheme) error {
 	return vsphereprovider.AddToScheme(scheme)
 }
 
+func init() {
+	registerActuatorProvider(
+		func(cd *hivev1.ClusterDeployment) bool {
+			return cd.Spec.Platform.VSphere != nil
+		},
+		func(r *ReconcileRemoteMachineSet, cd *hivev1.ClusterDeployment, pool *hivev1.MachinePool, masterMachine *machineapi.Machine, remoteMachineSets []machineapi.MachineSet, logger log.FieldLogger) (Actuator, error) {
+			return NewVSphereActuator(masterMachine, r.scheme, logger)
+		},
+	)
+}
+
 // NewVSphereActuator is the constructor for building a VSphereActuator
 func NewVSphereActuator(masterMachine *machineapi.Machine, scheme *runtime.Scheme, logger log.FieldLogger) (*VSphereActuator, error) {
 	osImage, err := getVSphereOSImage(masterMachine, scheme, logger)
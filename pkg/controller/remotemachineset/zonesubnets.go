@@ -0,0 +1,66 @@
+package remotemachineset
+
+import (
+	"context"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	// zoneSubnetsNotFoundReason is the reason of the InvalidSubnets condition when subnets mapped to zones by a
+	// MachinePool do not exist in the network of the cluster.
+	zoneSubnetsNotFoundReason = "ZoneSubnetsNotFound"
+	// zoneSubnetsMismatchReason is the reason of the InvalidSubnets condition when subnets mapped to zones by a
+	// MachinePool are not in those zones.
+	zoneSubnetsMismatchReason = "ZoneSubnetsMismatch"
+)
+
+// zonesFromZoneSubnets returns the zones mapped to subnets by a MachinePool, in order.
+func zonesFromZoneSubnets(zoneSubnets map[string]string) []string {
+	zones := make([]string, 0, len(zoneSubnets))
+	for zone := range zoneSubnets {
+		zones = append(zones, zone)
+	}
+	sort.Strings(zones)
+	return zones
+}
+
+// setInvalidSubnetsCondition sets the InvalidSubnets condition of the MachinePool to true with the given reason and
+// message.
+func setInvalidSubnetsCondition(c client.Client, pool *hivev1.MachinePool, reason, message string) error {
+	conds, changed := controllerutils.SetMachinePoolConditionWithChangeCheck(
+		pool.Status.Conditions,
+		hivev1.InvalidSubnetsMachinePoolCondition,
+		corev1.ConditionTrue,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	if !changed {
+		return nil
+	}
+	pool.Status.Conditions = conds
+	return c.Status().Update(context.Background(), pool)
+}
+
+// setValidSubnetsCondition sets the InvalidSubnets condition of the MachinePool to false.
+func setValidSubnetsCondition(c client.Client, pool *hivev1.MachinePool) error {
+	conds, changed := controllerutils.SetMachinePoolConditionWithChangeCheck(
+		pool.Status.Conditions,
+		hivev1.InvalidSubnetsMachinePoolCondition,
+		corev1.ConditionFalse,
+		"ValidSubnets",
+		"Subnets are valid",
+		controllerutils.UpdateConditionNever,
+	)
+	if !changed {
+		return nil
+	}
+	pool.Status.Conditions = conds
+	return c.Status().Update(context.Background(), pool)
+}
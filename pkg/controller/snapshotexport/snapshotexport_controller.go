@@ -0,0 +1,248 @@
+package snapshotexport
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hiveintv1alpha1 "github.com/openshift/hive/apis/hiveinternal/v1alpha1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/objectstorage"
+)
+
+const (
+	ControllerName = hivev1.SnapshotExportControllerName
+
+	defaultExportInterval = time.Hour
+
+	// listPageSize is how many resources are listed at a time, to bound the memory used to list large fleets.
+	listPageSize = 500
+)
+
+var (
+	metricLastSuccessfulExport = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "hive_snapshot_export_last_success_timestamp_seconds",
+		Help: "Time of the last snapshot of the Hive resources successfully exported to object storage.",
+	})
+	metricExportFailures = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "hive_snapshot_export_failures_total",
+		Help: "Counter incremented every time the export of a snapshot of the Hive resources fails.",
+	})
+)
+
+func init() {
+	metrics.Registry.MustRegister(metricLastSuccessfulExport)
+	metrics.Registry.MustRegister(metricExportFailures)
+}
+
+// snapshotResource is a kind of resource included in the snapshots.
+type snapshotResource struct {
+	// name is the plural name of the resource, used in the names of the snapshot objects.
+	name string
+	// listGVK is the kind of the list of the resources.
+	listGVK schema.GroupVersionKind
+	// redact removes the fields which must not leave the hub from a resource, in addition to the annotations and
+	// managed fields removed from all resources.
+	redact func(obj *unstructured.Unstructured)
+}
+
+var snapshotResources = []snapshotResource{
+	{
+		name:    "clusterdeployments",
+		listGVK: hivev1.SchemeGroupVersion.WithKind("ClusterDeploymentList"),
+	},
+	{
+		name:    "clustersyncs",
+		listGVK: hiveintv1alpha1.SchemeGroupVersion.WithKind("ClusterSyncList"),
+		// The failure messages of syncsets can hold the contents of the resources they failed to apply.
+		redact: func(obj *unstructured.Unstructured) {
+			for _, field := range []string{"syncSets", "selectorSyncSets"} {
+				statuses, found, _ := unstructured.NestedSlice(obj.Object, "status", field)
+				if !found {
+					continue
+				}
+				for _, status := range statuses {
+					if status, ok := status.(map[string]interface{}); ok {
+						delete(status, "failureMessage")
+					}
+				}
+				unstructured.SetNestedSlice(obj.Object, statuses, "status", field)
+			}
+		},
+	},
+	{
+		name:    "clusterstates",
+		listGVK: hivev1.SchemeGroupVersion.WithKind("ClusterStateList"),
+	},
+}
+
+// snapshotRecord is a line of a snapshot object.
+type snapshotRecord struct {
+	SnapshotTime time.Time              `json:"snapshotTime"`
+	Object       map[string]interface{} `json:"object"`
+}
+
+// Add creates a new snapshot exporter and adds it to the manager, if the export of snapshots is configured.
+func Add(mgr manager.Manager) error {
+	logger := log.WithField("controller", ControllerName)
+	_, clientRateLimiter, _, err := controllerutils.GetControllerConfig(mgr.GetClient(), ControllerName)
+	if err != nil {
+		logger.WithError(err).Error("could not get controller configurations")
+		return err
+	}
+	exporter, err := NewExporter(controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &clientRateLimiter), logger)
+	if err != nil {
+		logger.WithError(err).Error("could not create snapshot exporter")
+		return err
+	}
+	if exporter == nil {
+		logger.Debug("snapshot export is not configured")
+		return nil
+	}
+	return mgr.Add(exporter)
+}
+
+// NewExporter returns a new Exporter configured from the environment, or nil if the export of snapshots is not
+// configured.
+func NewExporter(c client.Client, logger log.FieldLogger) (*Exporter, error) {
+	config := objectstorage.ConfigFromEnv(objectstorage.NewEnvVarNames(constants.SnapshotExportEnvVarPrefix))
+	if config == nil {
+		return nil, nil
+	}
+	interval := defaultExportInterval
+	if value := os.Getenv(constants.SnapshotExportIntervalEnvVar); value != "" {
+		d, err := time.ParseDuration(value)
+		if err != nil || d <= 0 {
+			return nil, errors.Errorf("invalid snapshot export interval %q", value)
+		}
+		interval = d
+	}
+
+	if config.Destination != objectstorage.DestinationS3 && config.Destination != objectstorage.DestinationGCS {
+		return nil, errors.Errorf("unsupported snapshot export destination %q", config.Destination)
+	}
+	return &Exporter{
+		Client:   c,
+		interval: interval,
+		prefix:   os.Getenv(constants.SnapshotExportPrefixEnvVar),
+		uploaderFn: func() (objectstorage.Uploader, error) {
+			return objectstorage.NewUploader(c, controllerutils.GetHiveNamespace(), config)
+		},
+		now:    time.Now,
+		logger: logger,
+	}, nil
+}
+
+// Exporter periodically exports snapshots of the ClusterDeployments, ClusterSyncs and ClusterStates of the hub to
+// object storage. Each snapshot writes an object per kind of resource, holding a line of JSON per resource.
+type Exporter struct {
+	client.Client
+
+	interval time.Duration
+	prefix   string
+
+	// uploaderFn is the function to build the uploader to object storage. Can be stubbed out for testing.
+	uploaderFn func() (objectstorage.Uploader, error)
+	// now is the function to get the current time. Can be stubbed out for testing.
+	now    func() time.Time
+	logger log.FieldLogger
+}
+
+// Start exports snapshots until the stop channel is closed.
+func (e *Exporter) Start(stopCh <-chan struct{}) error {
+	e.logger.WithField("interval", e.interval).Info("started snapshot exporter")
+	wait.Until(func() {
+		if err := e.exportSnapshot(); err != nil {
+			e.logger.WithError(err).Error("failed to export snapshot")
+			metricExportFailures.Inc()
+		}
+	}, e.interval, stopCh)
+	return nil
+}
+
+// exportSnapshot exports a snapshot of the resources as of now.
+func (e *Exporter) exportSnapshot() error {
+	snapshotTime := e.now().UTC().Truncate(time.Second)
+	up, err := e.uploaderFn()
+	if err != nil {
+		return err
+	}
+	for _, resource := range snapshotResources {
+		body, count, err := e.snapshot(resource, snapshotTime)
+		if err != nil {
+			return errors.Wrapf(err, "could not snapshot %s", resource.name)
+		}
+		key := snapshotObjectKey(e.prefix, resource.name, snapshotTime)
+		if err := up.Upload(key, bytes.NewReader(body)); err != nil {
+			return errors.Wrapf(err, "could not upload snapshot of %s", resource.name)
+		}
+		e.logger.WithFields(log.Fields{"key": key, "count": count}).Info("exported snapshot")
+	}
+	metricLastSuccessfulExport.Set(float64(snapshotTime.Unix()))
+	return nil
+}
+
+// snapshot returns the redacted resources of a kind as lines of JSON, along with the number of resources.
+func (e *Exporter) snapshot(resource snapshotResource, snapshotTime time.Time) ([]byte, int, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	count := 0
+	continueToken := ""
+	for {
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(resource.listGVK)
+		if err := e.List(context.TODO(), list, client.Limit(listPageSize), client.Continue(continueToken)); err != nil {
+			return nil, 0, err
+		}
+		for i := range list.Items {
+			obj := &list.Items[i]
+			redact(obj)
+			if resource.redact != nil {
+				resource.redact(obj)
+			}
+			if err := encoder.Encode(snapshotRecord{SnapshotTime: snapshotTime, Object: obj.Object}); err != nil {
+				return nil, 0, err
+			}
+			count++
+		}
+		continueToken = list.GetContinue()
+		if continueToken == "" {
+			return buf.Bytes(), count, nil
+		}
+	}
+}
+
+// redact removes the annotations and managed fields of a resource. Annotations are free-form and can hold
+// credentials, such as the last applied configuration of a resource.
+func redact(obj *unstructured.Unstructured) {
+	obj.SetAnnotations(nil)
+	obj.SetManagedFields(nil)
+}
+
+// snapshotObjectKey returns the name of the snapshot object of a kind of resource. The objects are partitioned by
+// the date of the snapshot, for the ingestion into data warehouses.
+func snapshotObjectKey(prefix, resourceName string, snapshotTime time.Time) string {
+	return fmt.Sprintf("%s%s/dt=%s/%s-%s.jsonl",
+		prefix,
+		resourceName,
+		snapshotTime.Format("2006-01-02"),
+		resourceName,
+		snapshotTime.Format("20060102T150405Z"),
+	)
+}
@@ -0,0 +1,149 @@
+package snapshotexport
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hiveintv1alpha1 "github.com/openshift/hive/apis/hiveinternal/v1alpha1"
+	"github.com/openshift/hive/pkg/objectstorage"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+	testgeneric "github.com/openshift/hive/pkg/test/generic"
+)
+
+const (
+	testNamespace = "test-namespace"
+	testName      = "test-cluster"
+)
+
+type fakeUploader struct {
+	objects map[string][]byte
+	err     error
+}
+
+func (u *fakeUploader) Upload(key string, body io.Reader) error {
+	if u.err != nil {
+		return u.err
+	}
+	data, err := ioutil.ReadAll(body)
+	if err != nil {
+		return err
+	}
+	u.objects[key] = data
+	return nil
+}
+
+func TestExportSnapshot(t *testing.T) {
+	scheme := runtime.NewScheme()
+	hivev1.AddToScheme(scheme)
+	hiveintv1alpha1.AddToScheme(scheme)
+	snapshotTime := time.Date(2021, time.March, 4, 5, 6, 7, 0, time.UTC)
+
+	cd := testcd.FullBuilder(testNamespace, testName, scheme).Build(
+		testcd.Installed(),
+		testcd.Generic(testgeneric.WithAnnotation("kubectl.kubernetes.io/last-applied-configuration", "secret-stuff")),
+		testcd.Generic(testgeneric.WithLabel("team", "a")),
+	)
+	clusterSync := &hiveintv1alpha1.ClusterSync{}
+	clusterSync.Namespace = testNamespace
+	clusterSync.Name = testName
+	clusterSync.Status.SyncSets = []hiveintv1alpha1.SyncStatus{{
+		Name:           "test-syncset",
+		Result:         hiveintv1alpha1.FailureSyncSetResult,
+		FailureMessage: "failed to apply secret with data password=hunter2",
+	}}
+	clusterState := &hivev1.ClusterState{}
+	clusterState.Namespace = testNamespace
+	clusterState.Name = testName
+
+	tests := []struct {
+		name          string
+		prefix        string
+		uploadErr     error
+		expectObjects map[string]int
+		expectError   bool
+	}{
+		{
+			name: "export",
+			expectObjects: map[string]int{
+				"clusterdeployments/dt=2021-03-04/clusterdeployments-20210304T050607Z.jsonl": 1,
+				"clustersyncs/dt=2021-03-04/clustersyncs-20210304T050607Z.jsonl":             1,
+				"clusterstates/dt=2021-03-04/clusterstates-20210304T050607Z.jsonl":           1,
+			},
+		},
+		{
+			name:   "export with prefix",
+			prefix: "hive/prod/",
+			expectObjects: map[string]int{
+				"hive/prod/clusterdeployments/dt=2021-03-04/clusterdeployments-20210304T050607Z.jsonl": 1,
+				"hive/prod/clustersyncs/dt=2021-03-04/clustersyncs-20210304T050607Z.jsonl":             1,
+				"hive/prod/clusterstates/dt=2021-03-04/clusterstates-20210304T050607Z.jsonl":           1,
+			},
+		},
+		{
+			name:          "upload failure",
+			uploadErr:     errors.New("access denied"),
+			expectObjects: map[string]int{},
+			expectError:   true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			up := &fakeUploader{objects: map[string][]byte{}, err: test.uploadErr}
+			e := &Exporter{
+				Client:     fake.NewFakeClientWithScheme(scheme, cd.DeepCopy(), clusterSync.DeepCopy(), clusterState.DeepCopy()),
+				prefix:     test.prefix,
+				uploaderFn: func() (objectstorage.Uploader, error) { return up, nil },
+				now:        func() time.Time { return snapshotTime },
+				logger:     log.WithField("controller", ControllerName),
+			}
+
+			err := e.exportSnapshot()
+			if test.expectError {
+				assert.Error(t, err, "expected error exporting snapshot")
+			} else {
+				require.NoError(t, err, "unexpected error exporting snapshot")
+			}
+			require.Len(t, up.objects, len(test.expectObjects), "unexpected number of snapshot objects")
+			for key, count := range test.expectObjects {
+				body, ok := up.objects[key]
+				require.True(t, ok, "missing snapshot object %s", key)
+				records := readRecords(t, body)
+				require.Len(t, records, count, "unexpected number of records in %s", key)
+				for _, record := range records {
+					assert.Equal(t, snapshotTime, record.SnapshotTime.UTC(), "unexpected snapshot time")
+					metadata := record.Object["metadata"].(map[string]interface{})
+					assert.Equal(t, testName, metadata["name"], "unexpected object name")
+					assert.NotContains(t, metadata, "annotations", "expected annotations to be redacted")
+				}
+				assert.NotContains(t, string(body), "hunter2", "expected failure messages to be redacted")
+				assert.NotContains(t, string(body), "secret-stuff", "expected annotations to be redacted")
+			}
+		})
+	}
+}
+
+func readRecords(t *testing.T, body []byte) []snapshotRecord {
+	var records []snapshotRecord
+	scanner := bufio.NewScanner(bytes.NewReader(body))
+	for scanner.Scan() {
+		record := snapshotRecord{}
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &record), "could not parse snapshot record")
+		records = append(records, record)
+	}
+	require.NoError(t, scanner.Err())
+	return records
+}
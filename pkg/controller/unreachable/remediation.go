@@ -0,0 +1,196 @@
+package unreachable
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	defaultRemediationThreshold = 30 * time.Minute
+)
+
+// remediate attempts the next remediation step of a cluster which has been unreachable for longer than the
+// remediation threshold, recording the attempt in the status of the cluster. One step is attempted per reconcile, so
+// that the connectivity to the cluster is checked again before the following step. Once every step has been attempted
+// and the cluster is still unreachable, the UnreachableRemediationFailed condition is set.
+func (r *ReconcileRemoteMachineSet) remediate(cd *hivev1.ClusterDeployment, logger log.FieldLogger) (changed bool) {
+	remediation := cd.Spec.UnreachableRemediation
+	if remediation == nil {
+		return false
+	}
+	unreachableCond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.UnreachableCondition)
+	if unreachableCond == nil || unreachableCond.Status != corev1.ConditionTrue {
+		return setRemediationFailedCond(cd, corev1.ConditionFalse, "ClusterReachable", "cluster is reachable")
+	}
+	// A hibernating cluster is expected to be unreachable.
+	if cd.Spec.PowerState == hivev1.HibernatingClusterPowerState {
+		return false
+	}
+
+	status := cd.Status.UnreachableRemediation
+	if status == nil || !status.UnreachableSince.Equal(&unreachableCond.LastTransitionTime) {
+		logger.WithField("unreachableSince", unreachableCond.LastTransitionTime).Debug("tracking remediation of new outage")
+		status = &hivev1.UnreachableRemediationStatus{UnreachableSince: unreachableCond.LastTransitionTime}
+		cd.Status.UnreachableRemediation = status
+		changed = true
+	}
+
+	threshold := defaultRemediationThreshold
+	if remediation.Threshold != nil {
+		threshold = remediation.Threshold.Duration
+	}
+	if unreachableFor := time.Since(status.UnreachableSince.Time); unreachableFor < threshold {
+		logger.WithField("unreachableFor", unreachableFor).Debug("cluster has not been unreachable long enough for remediation")
+		return changed
+	}
+
+	if len(status.Steps) >= len(remediation.Steps) {
+		logger.Debug("all unreachable remediation steps have been attempted")
+		return setRemediationFailedCond(
+			cd,
+			corev1.ConditionTrue,
+			"RemediationStepsExhausted",
+			fmt.Sprintf("cluster is still unreachable after attempting %d remediation steps", len(status.Steps)),
+		) || changed
+	}
+
+	step := remediation.Steps[len(status.Steps)]
+	stepLog := logger.WithField("remediationStep", step.Type)
+	stepLog.Info("attempting unreachable remediation step")
+	var message string
+	var err error
+	switch step.Type {
+	case hivev1.RotateKubeconfigRemediationStep:
+		message, err = r.rotateKubeconfig(cd, step)
+	case hivev1.AlternateAPIURLRemediationStep:
+		message, err = r.useAlternateAPIURL(cd, step)
+	case hivev1.ResumeHibernationRemediationStep:
+		message, err = r.resumeMachines(cd, stepLog)
+	default:
+		err = fmt.Errorf("unknown remediation step type %q", step.Type)
+	}
+	result := hivev1.AppliedRemediationStepResult
+	if err != nil {
+		stepLog.WithError(err).Warn("unreachable remediation step failed")
+		result = hivev1.FailedRemediationStepResult
+		message = err.Error()
+	}
+	status.Steps = append(status.Steps, hivev1.UnreachableRemediationStepStatus{
+		Type:        step.Type,
+		AttemptTime: metav1.Now(),
+		Result:      result,
+		Message:     message,
+	})
+	return true
+}
+
+// rotateKubeconfig replaces the admin kubeconfig of the cluster with the kubeconfig saved in the secret of the step.
+// When the cluster is not reachable with the saved kubeconfig either, the previous admin kubeconfig is restored.
+func (r *ReconcileRemoteMachineSet) rotateKubeconfig(cd *hivev1.ClusterDeployment, step hivev1.UnreachableRemediationStep) (string, error) {
+	if step.KubeconfigSecretRef == nil || step.KubeconfigSecretRef.Name == "" {
+		return "", errors.New("no kubeconfig secret to rotate to")
+	}
+	savedSecret := &corev1.Secret{}
+	if err := r.Get(context.TODO(), types.NamespacedName{Namespace: cd.Namespace, Name: step.KubeconfigSecretRef.Name}, savedSecret); err != nil {
+		return "", errors.Wrap(err, "could not get the saved kubeconfig secret")
+	}
+	rawData, ok := savedSecret.Data[constants.KubeconfigSecretKey]
+	if !ok {
+		return "", fmt.Errorf("saved kubeconfig secret %s has no %s key", savedSecret.Name, constants.KubeconfigSecretKey)
+	}
+	data, err := controllerutils.AddAdditionalKubeconfigCAs(rawData)
+	if err != nil {
+		return "", errors.Wrap(err, "could not add additional CAs to the saved kubeconfig")
+	}
+	data, err = controllerutils.AddInternalKubeconfigContext(data)
+	if err != nil {
+		return "", errors.Wrap(err, "could not add the internal API context to the saved kubeconfig")
+	}
+
+	adminKubeconfigSecret := &corev1.Secret{}
+	if err := r.Get(context.TODO(), types.NamespacedName{Namespace: cd.Namespace, Name: cd.Spec.ClusterMetadata.AdminKubeconfigSecretRef.Name}, adminKubeconfigSecret); err != nil {
+		return "", errors.Wrap(err, "could not get the admin kubeconfig secret")
+	}
+	previousData := adminKubeconfigSecret.DeepCopy().Data
+	if adminKubeconfigSecret.Data == nil {
+		adminKubeconfigSecret.Data = map[string][]byte{}
+	}
+	adminKubeconfigSecret.Data[constants.RawKubeconfigSecretKey] = rawData
+	adminKubeconfigSecret.Data[constants.KubeconfigSecretKey] = data
+	if err := r.Update(context.TODO(), adminKubeconfigSecret); err != nil {
+		return "", errors.Wrap(err, "could not update the admin kubeconfig secret")
+	}
+
+	// The remote client builder reads the admin kubeconfig from the secret, so it connects with the saved kubeconfig.
+	if _, connectErr := r.remoteClusterAPIClientBuilder(cd).UsePrimaryAPIURL().Build(); connectErr != nil {
+		adminKubeconfigSecret.Data = previousData
+		if err := r.Update(context.TODO(), adminKubeconfigSecret); err != nil {
+			return "", errors.Wrapf(err, "cluster is not reachable with the kubeconfig of secret %s, and the previous admin kubeconfig could not be restored", savedSecret.Name)
+		}
+		return "", errors.Wrapf(connectErr, "cluster is not reachable with the kubeconfig of secret %s, restored the previous admin kubeconfig", savedSecret.Name)
+	}
+	return fmt.Sprintf("replaced the admin kubeconfig with the kubeconfig of secret %s", savedSecret.Name), nil
+}
+
+// useAlternateAPIURL checks whether the cluster is reachable through the alternate API URL of the step and, if it is,
+// makes the alternate API URL the API URL override of the cluster.
+func (r *ReconcileRemoteMachineSet) useAlternateAPIURL(cd *hivev1.ClusterDeployment, step hivev1.UnreachableRemediationStep) (string, error) {
+	if step.APIURL == "" {
+		return "", errors.New("no alternate API URL to connect through")
+	}
+	alternateCD := cd.DeepCopy()
+	alternateCD.Spec.ControlPlaneConfig.APIURLOverride = step.APIURL
+	if _, err := r.remoteClusterAPIClientBuilder(alternateCD).UsePrimaryAPIURL().Build(); err != nil {
+		return "", errors.Wrapf(err, "cluster is not reachable through %s", step.APIURL)
+	}
+	if err := r.Update(context.TODO(), alternateCD); err != nil {
+		return "", errors.Wrap(err, "could not set the API URL override")
+	}
+	cd.ResourceVersion = alternateCD.ResourceVersion
+	cd.Spec.ControlPlaneConfig.APIURLOverride = step.APIURL
+	return fmt.Sprintf("cluster is reachable through %s, which is now the API URL override", step.APIURL), nil
+}
+
+// resumeMachines powers on the machines of the cluster through the hibernation actuator of its platform, as the
+// hibernation controller does when the cluster resumes from hibernation.
+func (r *ReconcileRemoteMachineSet) resumeMachines(cd *hivev1.ClusterDeployment, logger log.FieldLogger) (string, error) {
+	actuator := r.hibernationActuator(cd)
+	if actuator == nil {
+		return "", errors.New("cannot power on the machines of the cluster: hibernation is not supported on its platform")
+	}
+	running, err := actuator.MachinesRunning(cd, r.Client, logger)
+	if err != nil {
+		return "", errors.Wrap(err, "could not check whether the machines of the cluster are running")
+	}
+	if running {
+		return "", errors.New("the machines of the cluster are already running")
+	}
+	if err := actuator.StartMachines(cd, r.Client, logger); err != nil {
+		return "", errors.Wrap(err, "could not start the machines of the cluster")
+	}
+	return "started the machines of the cluster", nil
+}
+
+func setRemediationFailedCond(cd *hivev1.ClusterDeployment, status corev1.ConditionStatus, reason, message string) (condsChanged bool) {
+	cd.Status.Conditions, condsChanged = controllerutils.SetClusterDeploymentConditionWithChangeCheck(
+		cd.Status.Conditions,
+		hivev1.UnreachableRemediationFailedCondition,
+		status,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	return
+}
@@ -16,7 +16,8 @@ limitations under the License.
 
 // Package unreachable provides a controller which periodically checks if a remote cluster is reachable
 // and maintains a condition on the cluster as a result. If the unreachable condition is true, other controllers
-// can skip attempts to reach the cluster which require a 30 second timeout.
+// can skip attempts to reach the cluster which require a 30 second timeout. When the cluster stays unreachable for
+// longer than a threshold, the controller attempts the unreachable remediation steps configured for the cluster.
 package unreachable
 
 import (
@@ -40,8 +41,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/controller/hibernation"
 	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/providerplugin"
 	"github.com/openshift/hive/pkg/remoteclient"
 )
 
@@ -73,6 +76,13 @@ func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) rec
 	r.remoteClusterAPIClientBuilder = func(cd *hivev1.ClusterDeployment) remoteclient.Builder {
 		return remoteclient.NewBuilder(r.Client, cd, ControllerName)
 	}
+	plugins, err := providerplugin.Load()
+	if err != nil {
+		r.logger.WithError(err).Error("could not load provider plugins")
+	}
+	r.hibernationActuator = func(cd *hivev1.ClusterDeployment) hibernation.HibernationActuator {
+		return hibernation.GetActuator(plugins, cd)
+	}
 	return r
 }
 
@@ -109,6 +119,10 @@ type ReconcileRemoteMachineSet struct {
 	// remoteClusterAPIClientBuilder is a function pointer to the function that gets a builder for building a client
 	// for the remote cluster's API server
 	remoteClusterAPIClientBuilder func(cd *hivev1.ClusterDeployment) remoteclient.Builder
+
+	// hibernationActuator returns the hibernation actuator which powers on the machines of the cluster for the
+	// ResumeHibernation remediation step, or nil if the platform of the cluster does not support hibernation.
+	hibernationActuator func(cd *hivev1.ClusterDeployment) hibernation.HibernationActuator
 }
 
 // Reconcile checks if we can establish an API client connection to the remote cluster and maintains the unreachable condition as a result.
@@ -201,6 +215,8 @@ func (r *ReconcileRemoteMachineSet) Reconcile(request reconcile.Request) (reconc
 		unreachableChanged = setUnreachableCond(cd, unreachableError)
 	}
 	overrideChanged := setActiveAPIURLOverrideCond(cd, primaryErr)
+	// Attempt the next remediation step if the cluster has been unreachable for too long.
+	remediationChanged := r.remediate(cd, cdLog)
 
 	// Determine when to requeue the ClusterDeployment. If there is no connectivity to the remote cluster via the
 	// preferred API URL, then requeue the ClusterDeployment using the backoff. If there is connectivity via the
@@ -210,8 +226,9 @@ func (r *ReconcileRemoteMachineSet) Reconcile(request reconcile.Request) (reconc
 		result.RequeueAfter = maxUnreachableDuration
 	}
 
-	// If none of the conditions have changed, stop the reconciliation now without updating the ClusterDeployment.
-	if !unreachableChanged && !overrideChanged {
+	// If none of the conditions nor the remediation have changed, stop the reconciliation now without updating the
+	// ClusterDeployment.
+	if !unreachableChanged && !overrideChanged && !remediationChanged {
 		return result, nil
 	}
 
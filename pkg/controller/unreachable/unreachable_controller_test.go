@@ -5,7 +5,7 @@ Licensed under the Apache License, Version 2.0 (the "License");
 you may not use this file except in compliance with the License.
 You may obtain a copy of the License at
 
-    http://www.apache.org/licenses/LICENSE-2.0
+	http://www.apache.org/licenses/LICENSE-2.0
 
 Unless required by applicable law or agreed to in writing, software
 distributed under the License is distributed on an "AS IS" BASIS,
@@ -30,14 +30,20 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/controller/hibernation"
+	hibernationmock "github.com/openshift/hive/pkg/controller/hibernation/mock"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 	"github.com/openshift/hive/pkg/remoteclient"
 	remoteclientmock "github.com/openshift/hive/pkg/remoteclient/mock"
 	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+	testsecret "github.com/openshift/hive/pkg/test/secret"
 )
 
 const (
@@ -261,6 +267,327 @@ func TestReconcile(t *testing.T) {
 	}
 }
 
+const savedKubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: test-cluster
+  cluster:
+    server: https://api.test-cluster.example.com:6443
+contexts:
+- name: admin
+  context:
+    cluster: test-cluster
+    user: admin
+current-context: admin
+users:
+- name: admin
+  user:
+    client-certificate-data: cmVuZXdlZA==
+`
+
+func TestRemediation(t *testing.T) {
+	allSteps := []hivev1.UnreachableRemediationStep{
+		{Type: hivev1.RotateKubeconfigRemediationStep, KubeconfigSecretRef: &corev1.LocalObjectReference{Name: "saved-kubeconfig"}},
+		{Type: hivev1.AlternateAPIURLRemediationStep, APIURL: "https://alternate-api-url:6443"},
+		{Type: hivev1.ResumeHibernationRemediationStep},
+	}
+	outageStart := time.Now().Add(-time.Hour)
+	tests := []struct {
+		name                   string
+		cd                     *hivev1.ClusterDeployment
+		existing               []runtime.Object
+		reachable              bool
+		reachableAfterStep     *bool
+		hibernationUnsupported bool
+		machinesRunning        bool
+		expectStartMachines    bool
+		expectedResults        []hivev1.UnreachableRemediationStepResult
+		expectedFailedStatus   corev1.ConditionStatus
+		validate               func(*testing.T, *hivev1.ClusterDeployment, client.Client)
+	}{
+		{
+			name: "unreachable for less than threshold",
+			cd: buildClusterDeployment(
+				withUnreachableSince(time.Now().Add(-time.Minute)),
+				withUnreachableRemediation(allSteps...),
+			),
+			validate: func(t *testing.T, cd *hivev1.ClusterDeployment, _ client.Client) {
+				if assert.NotNil(t, cd.Status.UnreachableRemediation, "expected remediation status") {
+					assert.Empty(t, cd.Status.UnreachableRemediation.Steps, "expected no attempted steps")
+				}
+			},
+		},
+		{
+			name: "rotate kubeconfig",
+			cd: buildClusterDeployment(
+				withUnreachableSince(outageStart),
+				withUnreachableRemediation(allSteps...),
+				func(cd *hivev1.ClusterDeployment) {
+					cd.Spec.ClusterMetadata.AdminKubeconfigSecretRef.Name = "admin-kubeconfig"
+				},
+			),
+			existing: []runtime.Object{
+				testsecret.FullBuilder(testNamespace, "saved-kubeconfig", scheme.Scheme).Build(
+					testsecret.WithDataKeyValue(constants.KubeconfigSecretKey, []byte(savedKubeconfig)),
+				),
+				testsecret.FullBuilder(testNamespace, "admin-kubeconfig", scheme.Scheme).Build(
+					testsecret.WithDataKeyValue(constants.KubeconfigSecretKey, []byte("expired")),
+					testsecret.WithDataKeyValue(constants.RawKubeconfigSecretKey, []byte("expired")),
+				),
+			},
+			reachableAfterStep: pointer.BoolPtr(true),
+			expectedResults:    []hivev1.UnreachableRemediationStepResult{hivev1.AppliedRemediationStepResult},
+			validate: func(t *testing.T, cd *hivev1.ClusterDeployment, c client.Client) {
+				secret := &corev1.Secret{}
+				if err := c.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: "admin-kubeconfig"}, secret); assert.NoError(t, err, "missing admin kubeconfig secret") {
+					assert.Equal(t, savedKubeconfig, string(secret.Data[constants.RawKubeconfigSecretKey]), "unexpected raw kubeconfig")
+					assert.Contains(t, string(secret.Data[constants.KubeconfigSecretKey]), "api-int.test-cluster.example.com", "expected internal API context in kubeconfig")
+				}
+			},
+		},
+		{
+			name: "rotate kubeconfig rolled back",
+			cd: buildClusterDeployment(
+				withUnreachableSince(outageStart),
+				withUnreachableRemediation(allSteps...),
+				func(cd *hivev1.ClusterDeployment) {
+					cd.Spec.ClusterMetadata.AdminKubeconfigSecretRef.Name = "admin-kubeconfig"
+				},
+			),
+			existing: []runtime.Object{
+				testsecret.FullBuilder(testNamespace, "saved-kubeconfig", scheme.Scheme).Build(
+					testsecret.WithDataKeyValue(constants.KubeconfigSecretKey, []byte(savedKubeconfig)),
+				),
+				testsecret.FullBuilder(testNamespace, "admin-kubeconfig", scheme.Scheme).Build(
+					testsecret.WithDataKeyValue(constants.KubeconfigSecretKey, []byte("previous")),
+					testsecret.WithDataKeyValue(constants.RawKubeconfigSecretKey, []byte("previous-raw")),
+				),
+			},
+			reachableAfterStep: pointer.BoolPtr(false),
+			expectedResults:    []hivev1.UnreachableRemediationStepResult{hivev1.FailedRemediationStepResult},
+			validate: func(t *testing.T, cd *hivev1.ClusterDeployment, c client.Client) {
+				secret := &corev1.Secret{}
+				if err := c.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: "admin-kubeconfig"}, secret); assert.NoError(t, err, "missing admin kubeconfig secret") {
+					assert.Equal(t, "previous-raw", string(secret.Data[constants.RawKubeconfigSecretKey]), "expected previous raw kubeconfig")
+					assert.Equal(t, "previous", string(secret.Data[constants.KubeconfigSecretKey]), "expected previous kubeconfig")
+				}
+			},
+		},
+		{
+			name: "rotate kubeconfig without saved secret",
+			cd: buildClusterDeployment(
+				withUnreachableSince(outageStart),
+				withUnreachableRemediation(allSteps...),
+			),
+			expectedResults: []hivev1.UnreachableRemediationStepResult{hivev1.FailedRemediationStepResult},
+		},
+		{
+			name: "alternate API URL reachable",
+			cd: buildClusterDeployment(
+				withUnreachableSince(outageStart),
+				withUnreachableRemediation(allSteps...),
+				withUnreachableRemediationStatus(outageStart, hivev1.FailedRemediationStepResult),
+			),
+			reachableAfterStep: pointer.BoolPtr(true),
+			expectedResults: []hivev1.UnreachableRemediationStepResult{
+				hivev1.FailedRemediationStepResult,
+				hivev1.AppliedRemediationStepResult,
+			},
+			validate: func(t *testing.T, cd *hivev1.ClusterDeployment, _ client.Client) {
+				assert.Equal(t, "https://alternate-api-url:6443", cd.Spec.ControlPlaneConfig.APIURLOverride, "expected API URL override")
+			},
+		},
+		{
+			name: "alternate API URL unreachable",
+			cd: buildClusterDeployment(
+				withUnreachableSince(outageStart),
+				withUnreachableRemediation(allSteps...),
+				withUnreachableRemediationStatus(outageStart, hivev1.FailedRemediationStepResult),
+			),
+			reachableAfterStep: pointer.BoolPtr(false),
+			expectedResults: []hivev1.UnreachableRemediationStepResult{
+				hivev1.FailedRemediationStepResult,
+				hivev1.FailedRemediationStepResult,
+			},
+			validate: func(t *testing.T, cd *hivev1.ClusterDeployment, _ client.Client) {
+				assert.Empty(t, cd.Spec.ControlPlaneConfig.APIURLOverride, "expected no API URL override")
+			},
+		},
+		{
+			name: "resume hibernation",
+			cd: buildClusterDeployment(
+				withUnreachableSince(outageStart),
+				withUnreachableRemediation(allSteps...),
+				withUnreachableRemediationStatus(outageStart, hivev1.FailedRemediationStepResult, hivev1.FailedRemediationStepResult),
+			),
+			expectStartMachines: true,
+			expectedResults: []hivev1.UnreachableRemediationStepResult{
+				hivev1.FailedRemediationStepResult,
+				hivev1.FailedRemediationStepResult,
+				hivev1.AppliedRemediationStepResult,
+			},
+			validate: func(t *testing.T, cd *hivev1.ClusterDeployment, _ client.Client) {
+				assert.Nil(t, controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.ClusterHibernatingCondition), "unexpected hibernating condition")
+			},
+		},
+		{
+			name: "resume hibernation with running machines",
+			cd: buildClusterDeployment(
+				withUnreachableSince(outageStart),
+				withUnreachableRemediation(allSteps...),
+				withUnreachableRemediationStatus(outageStart, hivev1.FailedRemediationStepResult, hivev1.FailedRemediationStepResult),
+			),
+			machinesRunning: true,
+			expectedResults: []hivev1.UnreachableRemediationStepResult{
+				hivev1.FailedRemediationStepResult,
+				hivev1.FailedRemediationStepResult,
+				hivev1.FailedRemediationStepResult,
+			},
+		},
+		{
+			name: "resume hibernation unsupported",
+			cd: buildClusterDeployment(
+				withUnreachableSince(outageStart),
+				withUnreachableRemediation(allSteps...),
+				withUnreachableRemediationStatus(outageStart, hivev1.FailedRemediationStepResult, hivev1.FailedRemediationStepResult),
+			),
+			hibernationUnsupported: true,
+			expectedResults: []hivev1.UnreachableRemediationStepResult{
+				hivev1.FailedRemediationStepResult,
+				hivev1.FailedRemediationStepResult,
+				hivev1.FailedRemediationStepResult,
+			},
+		},
+		{
+			name: "all steps attempted",
+			cd: buildClusterDeployment(
+				withUnreachableSince(outageStart),
+				withUnreachableRemediation(allSteps...),
+				withUnreachableRemediationStatus(outageStart, hivev1.FailedRemediationStepResult, hivev1.FailedRemediationStepResult, hivev1.AppliedRemediationStepResult),
+			),
+			expectedResults: []hivev1.UnreachableRemediationStepResult{
+				hivev1.FailedRemediationStepResult,
+				hivev1.FailedRemediationStepResult,
+				hivev1.AppliedRemediationStepResult,
+			},
+			expectedFailedStatus: corev1.ConditionTrue,
+		},
+		{
+			name: "new outage",
+			cd: buildClusterDeployment(
+				withUnreachableSince(outageStart),
+				withUnreachableRemediation(allSteps...),
+				withUnreachableRemediationStatus(outageStart.Add(-24*time.Hour), hivev1.FailedRemediationStepResult, hivev1.FailedRemediationStepResult, hivev1.AppliedRemediationStepResult),
+			),
+			expectedResults: []hivev1.UnreachableRemediationStepResult{hivev1.FailedRemediationStepResult},
+		},
+		{
+			name: "hibernating cluster",
+			cd: buildClusterDeployment(
+				withUnreachableSince(outageStart),
+				withUnreachableRemediation(allSteps...),
+				func(cd *hivev1.ClusterDeployment) {
+					cd.Spec.PowerState = hivev1.HibernatingClusterPowerState
+				},
+			),
+			validate: func(t *testing.T, cd *hivev1.ClusterDeployment, _ client.Client) {
+				assert.Nil(t, cd.Status.UnreachableRemediation, "expected no remediation status")
+			},
+		},
+		{
+			name: "reachable after remediation",
+			cd: buildClusterDeployment(
+				withUnreachableSince(outageStart),
+				withUnreachableRemediation(allSteps...),
+				withUnreachableRemediationStatus(outageStart, hivev1.FailedRemediationStepResult, hivev1.FailedRemediationStepResult, hivev1.AppliedRemediationStepResult),
+				testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+					Type:   hivev1.UnreachableRemediationFailedCondition,
+					Status: corev1.ConditionTrue,
+					Reason: "RemediationStepsExhausted",
+				}),
+			),
+			reachable: true,
+			expectedResults: []hivev1.UnreachableRemediationStepResult{
+				hivev1.FailedRemediationStepResult,
+				hivev1.FailedRemediationStepResult,
+				hivev1.AppliedRemediationStepResult,
+			},
+			expectedFailedStatus: corev1.ConditionFalse,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			hivev1.AddToScheme(scheme)
+			corev1.AddToScheme(scheme)
+			fakeClient := fake.NewFakeClientWithScheme(scheme, append(test.existing, test.cd)...)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			mockRemoteClientBuilder := remoteclientmock.NewMockBuilder(mockCtrl)
+			mockRemoteClientBuilder.EXPECT().UsePrimaryAPIURL().Return(mockRemoteClientBuilder)
+			var buildError error
+			if !test.reachable {
+				buildError = errors.New("cluster not reachable")
+			}
+			mockRemoteClientBuilder.EXPECT().Build().Return(nil, buildError)
+			if test.reachableAfterStep != nil {
+				mockRemoteClientBuilder.EXPECT().UsePrimaryAPIURL().Return(mockRemoteClientBuilder)
+				var alternateError error
+				if !*test.reachableAfterStep {
+					alternateError = errors.New("cluster not reachable")
+				}
+				mockRemoteClientBuilder.EXPECT().Build().Return(nil, alternateError)
+			}
+			mockActuator := hibernationmock.NewMockHibernationActuator(mockCtrl)
+			mockActuator.EXPECT().MachinesRunning(gomock.Any(), gomock.Any(), gomock.Any()).Return(test.machinesRunning, nil).AnyTimes()
+			if test.expectStartMachines {
+				mockActuator.EXPECT().StartMachines(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			}
+			rcd := &ReconcileRemoteMachineSet{
+				Client:                        fakeClient,
+				scheme:                        scheme,
+				logger:                        log.WithField("controller", "unreachable"),
+				remoteClusterAPIClientBuilder: func(*hivev1.ClusterDeployment) remoteclient.Builder { return mockRemoteClientBuilder },
+				hibernationActuator: func(*hivev1.ClusterDeployment) hibernation.HibernationActuator {
+					if test.hibernationUnsupported {
+						return nil
+					}
+					return mockActuator
+				},
+			}
+
+			namespacedName := types.NamespacedName{
+				Name:      testName,
+				Namespace: testNamespace,
+			}
+
+			_, err := rcd.Reconcile(reconcile.Request{NamespacedName: namespacedName})
+			assert.NoError(t, err, "unexpected error during reconcile")
+
+			cd := &hivev1.ClusterDeployment{}
+			if err := fakeClient.Get(context.TODO(), namespacedName, cd); assert.NoError(t, err, "missing clusterdeployment") {
+				var results []hivev1.UnreachableRemediationStepResult
+				if cd.Status.UnreachableRemediation != nil {
+					for _, step := range cd.Status.UnreachableRemediation.Steps {
+						results = append(results, step.Result)
+					}
+				}
+				assert.Equal(t, test.expectedResults, results, "unexpected remediation step results")
+				cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.UnreachableRemediationFailedCondition)
+				if test.expectedFailedStatus == "" {
+					assert.Nil(t, cond, "expected no remediation failed condition")
+				} else if assert.NotNil(t, cond, "missing remediation failed condition") {
+					assert.Equal(t, test.expectedFailedStatus, cond.Status, "unexpected status on remediation failed condition")
+				}
+				if test.validate != nil {
+					test.validate(t, cd, fakeClient)
+				}
+			}
+		})
+	}
+}
+
 func buildClusterDeployment(options ...testcd.Option) *hivev1.ClusterDeployment {
 	options = append(
 		[]testcd.Option{
@@ -295,6 +622,39 @@ func withActiveAPIURLOverrideCondition(status corev1.ConditionStatus) testcd.Opt
 	)
 }
 
+func withUnreachableSince(since time.Time) testcd.Option {
+	return testcd.WithCondition(
+		hivev1.ClusterDeploymentCondition{
+			Type:               hivev1.UnreachableCondition,
+			Status:             corev1.ConditionTrue,
+			Reason:             "ErrorConnectingToCluster",
+			Message:            "cluster not reachable",
+			LastTransitionTime: metav1.NewTime(since),
+			LastProbeTime:      metav1.NewTime(since),
+		},
+	)
+}
+
+func withUnreachableRemediation(steps ...hivev1.UnreachableRemediationStep) testcd.Option {
+	return func(cd *hivev1.ClusterDeployment) {
+		cd.Spec.UnreachableRemediation = &hivev1.UnreachableRemediation{Steps: steps}
+	}
+}
+
+func withUnreachableRemediationStatus(since time.Time, results ...hivev1.UnreachableRemediationStepResult) testcd.Option {
+	return func(cd *hivev1.ClusterDeployment) {
+		status := &hivev1.UnreachableRemediationStatus{UnreachableSince: metav1.NewTime(since)}
+		for i, result := range results {
+			status.Steps = append(status.Steps, hivev1.UnreachableRemediationStepStatus{
+				Type:        cd.Spec.UnreachableRemediation.Steps[i].Type,
+				AttemptTime: metav1.NewTime(since),
+				Result:      result,
+			})
+		}
+		cd.Status.UnreachableRemediation = status
+	}
+}
+
 func withAPIURLOverride() testcd.Option {
 	return func(clusterDeployment *hivev1.ClusterDeployment) {
 		clusterDeployment.Spec.ControlPlaneConfig.APIURLOverride = "some-api-url"
@@ -3,6 +3,7 @@ package utils
 import (
 	"bytes"
 	"context"
+	"encoding/pem"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -74,3 +75,25 @@ func TrustBundleFromSecretToWriter(c client.Client, secretNamespace, secretName
 	}
 	return nil
 }
+
+// MergeTrustBundles merges PEM trust bundles into a single bundle, dropping the certificates which appear more than
+// once. The certificates keep the order in which they first appear.
+func MergeTrustBundles(bundles ...[]byte) []byte {
+	merged := &bytes.Buffer{}
+	seen := map[string]bool{}
+	for _, bundle := range bundles {
+		for {
+			var block *pem.Block
+			block, bundle = pem.Decode(bundle)
+			if block == nil {
+				break
+			}
+			if seen[string(block.Bytes)] {
+				continue
+			}
+			seen[string(block.Bytes)] = true
+			pem.Encode(merged, block)
+		}
+	}
+	return merged.Bytes()
+}
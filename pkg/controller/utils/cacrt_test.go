@@ -0,0 +1,55 @@
+package utils
+
+import (
+	"encoding/pem"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeTrustBundles(t *testing.T) {
+	ca1 := testPEM("ca1")
+	ca2 := testPEM("ca2")
+	ca3 := testPEM("ca3")
+	cases := []struct {
+		name     string
+		bundles  [][]byte
+		expected string
+	}{
+		{
+			name: "no bundles",
+		},
+		{
+			name:     "single bundle",
+			bundles:  [][]byte{concat(ca1, ca2)},
+			expected: string(concat(ca1, ca2)),
+		},
+		{
+			name:     "duplicate certificates dropped",
+			bundles:  [][]byte{concat(ca1, ca2), concat(ca2, ca3, ca1)},
+			expected: string(concat(ca1, ca2, ca3)),
+		},
+		{
+			name:     "content outside of PEM blocks dropped",
+			bundles:  [][]byte{[]byte("# corporate proxy\n"), ca1, []byte("\n\n"), ca2},
+			expected: string(concat(ca1, ca2)),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, string(MergeTrustBundles(tc.bundles...)))
+		})
+	}
+}
+
+func testPEM(content string) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte(content)})
+}
+
+func concat(bundles ...[]byte) []byte {
+	var b []byte
+	for _, bundle := range bundles {
+		b = append(b, bundle...)
+	}
+	return b
+}
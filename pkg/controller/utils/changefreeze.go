@@ -0,0 +1,69 @@
+package utils
+
+import (
+	"context"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+// IsChangeFreezeActive returns whether the time is within the window of the change freeze.
+func IsChangeFreezeActive(freeze *hivev1.ChangeFreeze, now time.Time) bool {
+	return !now.Before(freeze.Spec.Start.Time) && now.Before(freeze.Spec.End.Time)
+}
+
+// ChangeFreezeSelectsClusterDeployment returns whether the change freeze selects the cluster deployment. A freeze
+// with an invalid selector selects no clusters.
+func ChangeFreezeSelectsClusterDeployment(freeze *hivev1.ChangeFreeze, cd *hivev1.ClusterDeployment, logger log.FieldLogger) bool {
+	selector, err := metav1.LabelSelectorAsSelector(&freeze.Spec.ClusterDeploymentSelector)
+	if err != nil {
+		logger.WithField("changeFreeze", freeze.Name).WithError(err).Warn("cannot parse ClusterDeployment selector")
+		return false
+	}
+	return selector.Matches(labels.Set(cd.Labels))
+}
+
+// ActiveChangeFreeze returns the active change freeze selecting the cluster deployment, or nil if the cluster is not
+// frozen. When several freezes are active, the one which ends last is returned.
+func ActiveChangeFreeze(c client.Client, cd *hivev1.ClusterDeployment, now time.Time, logger log.FieldLogger) (*hivev1.ChangeFreeze, error) {
+	freezes := &hivev1.ChangeFreezeList{}
+	if err := c.List(context.Background(), freezes); err != nil {
+		logger.WithError(err).Log(LogLevel(err), "could not list ChangeFreezes")
+		return nil, err
+	}
+	var active *hivev1.ChangeFreeze
+	for i := range freezes.Items {
+		freeze := &freezes.Items[i]
+		if !IsChangeFreezeActive(freeze, now) || !ChangeFreezeSelectsClusterDeployment(freeze, cd, logger) {
+			continue
+		}
+		if active == nil || freeze.Spec.End.After(active.Spec.End.Time) {
+			active = freeze
+		}
+	}
+	return active, nil
+}
+
+// IsEmergencyChange returns whether the object is labeled as an emergency change, which is made even to frozen
+// clusters.
+func IsEmergencyChange(obj metav1.Object) bool {
+	return obj.GetLabels()[hivev1.ChangeFreezeEmergencyLabel] == "true"
+}
+
+// ClusterDeploymentsForChangeFreeze returns the cluster deployments selected by the change freeze.
+func ClusterDeploymentsForChangeFreeze(c client.Client, freeze *hivev1.ChangeFreeze) ([]hivev1.ClusterDeployment, error) {
+	selector, err := metav1.LabelSelectorAsSelector(&freeze.Spec.ClusterDeploymentSelector)
+	if err != nil {
+		return nil, err
+	}
+	cds := &hivev1.ClusterDeploymentList{}
+	if err := c.List(context.Background(), cds, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, err
+	}
+	return cds.Items, nil
+}
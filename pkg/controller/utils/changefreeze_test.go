@@ -0,0 +1,101 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+func TestActiveChangeFreeze(t *testing.T) {
+	scheme := runtime.NewScheme()
+	hivev1.AddToScheme(scheme)
+
+	now := time.Now()
+	freeze := func(name, labelValue string, start, end time.Duration) *hivev1.ChangeFreeze {
+		return &hivev1.ChangeFreeze{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: hivev1.ChangeFreezeSpec{
+				ClusterDeploymentSelector: metav1.LabelSelector{
+					MatchLabels: map[string]string{"freeze": labelValue},
+				},
+				Start: metav1.NewTime(now.Add(start)),
+				End:   metav1.NewTime(now.Add(end)),
+			},
+		}
+	}
+	cd := &hivev1.ClusterDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test-namespace",
+			Name:      "test-cd",
+			Labels:    map[string]string{"freeze": "yes"},
+		},
+	}
+
+	cases := []struct {
+		name           string
+		freezes        []runtime.Object
+		expectedFreeze string
+	}{
+		{
+			name: "no freezes",
+		},
+		{
+			name:           "active freeze",
+			freezes:        []runtime.Object{freeze("active", "yes", -time.Hour, time.Hour)},
+			expectedFreeze: "active",
+		},
+		{
+			name:           "freeze starting now",
+			freezes:        []runtime.Object{freeze("starting", "yes", 0, time.Hour)},
+			expectedFreeze: "starting",
+		},
+		{
+			name:    "freeze ending now",
+			freezes: []runtime.Object{freeze("ending", "yes", -time.Hour, 0)},
+		},
+		{
+			name:    "future freeze",
+			freezes: []runtime.Object{freeze("future", "yes", time.Hour, 2*time.Hour)},
+		},
+		{
+			name:    "freeze not selecting cluster",
+			freezes: []runtime.Object{freeze("other", "no", -time.Hour, time.Hour)},
+		},
+		{
+			name: "freeze ending last",
+			freezes: []runtime.Object{
+				freeze("short", "yes", -time.Hour, time.Hour),
+				freeze("long", "yes", -time.Minute, 2*time.Hour),
+			},
+			expectedFreeze: "long",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := fake.NewFakeClientWithScheme(scheme, tc.freezes...)
+			active, err := ActiveChangeFreeze(c, cd, now, logrus.New())
+			require.NoError(t, err, "unexpected error")
+			if tc.expectedFreeze == "" {
+				assert.Nil(t, active, "expected cluster not to be frozen")
+				return
+			}
+			if assert.NotNil(t, active, "expected cluster to be frozen") {
+				assert.Equal(t, tc.expectedFreeze, active.Name, "unexpected freeze")
+			}
+		})
+	}
+}
+
+func TestIsEmergencyChange(t *testing.T) {
+	assert.True(t, IsEmergencyChange(&metav1.ObjectMeta{Labels: map[string]string{hivev1.ChangeFreezeEmergencyLabel: "true"}}))
+	assert.False(t, IsEmergencyChange(&metav1.ObjectMeta{Labels: map[string]string{hivev1.ChangeFreezeEmergencyLabel: "false"}}))
+	assert.False(t, IsEmergencyChange(&metav1.ObjectMeta{}))
+}
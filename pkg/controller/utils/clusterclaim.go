@@ -1,8 +1,12 @@
 package utils
 
 import (
+	"fmt"
+	"os"
 	"strconv"
 
+	log "github.com/sirupsen/logrus"
+
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	"github.com/openshift/hive/pkg/constants"
 )
@@ -18,3 +22,90 @@ func IsClaimedClusterMarkedForRemoval(cd *hivev1.ClusterDeployment) bool {
 	}
 	return toRemove
 }
+
+// ClaimQuotaExceededReason is the reason of the Pending condition of a ClusterClaim which is not assigned a cluster
+// because its namespace or its requester has reached its claim quota.
+const ClaimQuotaExceededReason = "QuotaExceeded"
+
+// ClaimQuota limits the number of ClusterClaims which may exist at once in each namespace and for each requester,
+// across all the pools.
+type ClaimQuota struct {
+	// PerNamespace is the maximum number of claims in each namespace, or nil if unlimited.
+	PerNamespace *int
+	// PerRequester is the maximum number of claims of each requester, or nil if unlimited.
+	PerRequester *int
+}
+
+// ClaimQuotaFromEnv returns the claim quota configured by the environment. Limits which cannot be parsed are ignored.
+func ClaimQuotaFromEnv(logger log.FieldLogger) ClaimQuota {
+	limit := func(envVar string) *int {
+		value, ok := os.LookupEnv(envVar)
+		if !ok || value == "" {
+			return nil
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			logger.WithField(envVar, value).Warn("ignoring invalid claim quota")
+			return nil
+		}
+		return &n
+	}
+	return ClaimQuota{
+		PerNamespace: limit(constants.ClusterClaimQuotaPerNamespaceEnvVar),
+		PerRequester: limit(constants.ClusterClaimQuotaPerRequesterEnvVar),
+	}
+}
+
+// IsLimited returns whether the quota limits the number of claims.
+func (q ClaimQuota) IsLimited() bool {
+	return q.PerNamespace != nil || q.PerRequester != nil
+}
+
+// ExceededClaimQuota returns a message describing the quota which the claim exceeds, or "" if the claim is within its
+// quotas. The claims counted against the quotas of a claim are the claims which are not being deleted and which are
+// either assigned a cluster or were created before it, so that the claims are let through in the order they were
+// created. A claim being created therefore counts all the claims, and a claim assigned a cluster never exceeds its
+// quotas.
+func (q ClaimQuota) ExceededClaimQuota(claim *hivev1.ClusterClaim, claims []hivev1.ClusterClaim) string {
+	if claim.Spec.Namespace != "" {
+		return ""
+	}
+	requester := claim.Annotations[constants.ClaimRequesterAnnotation]
+	inNamespace, ofRequester := 0, 0
+	for i := range claims {
+		other := &claims[i]
+		if other.Namespace == claim.Namespace && other.Name == claim.Name {
+			continue
+		}
+		if other.DeletionTimestamp != nil || !countsAgainstClaimQuota(other, claim) {
+			continue
+		}
+		if other.Namespace == claim.Namespace {
+			inNamespace++
+		}
+		if requester != "" && other.Annotations[constants.ClaimRequesterAnnotation] == requester {
+			ofRequester++
+		}
+	}
+	if q.PerNamespace != nil && inNamespace >= *q.PerNamespace {
+		return fmt.Sprintf("Namespace %s has reached its quota of %d ClusterClaims", claim.Namespace, *q.PerNamespace)
+	}
+	if q.PerRequester != nil && requester != "" && ofRequester >= *q.PerRequester {
+		return fmt.Sprintf("Requester %s has reached its quota of %d ClusterClaims", requester, *q.PerRequester)
+	}
+	return ""
+}
+
+// countsAgainstClaimQuota returns whether the other claim counts against the quotas of the claim.
+func countsAgainstClaimQuota(other, claim *hivev1.ClusterClaim) bool {
+	if other.Spec.Namespace != "" || claim.CreationTimestamp.IsZero() {
+		return true
+	}
+	if !other.CreationTimestamp.Equal(&claim.CreationTimestamp) {
+		return other.CreationTimestamp.Before(&claim.CreationTimestamp)
+	}
+	if other.Namespace != claim.Namespace {
+		return other.Namespace < claim.Namespace
+	}
+	return other.Name < claim.Name
+}
@@ -0,0 +1,172 @@
+package utils
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+func TestClaimQuotaFromEnv(t *testing.T) {
+	defer os.Unsetenv(constants.ClusterClaimQuotaPerNamespaceEnvVar)
+	defer os.Unsetenv(constants.ClusterClaimQuotaPerRequesterEnvVar)
+
+	quota := ClaimQuotaFromEnv(logrus.New())
+	assert.False(t, quota.IsLimited(), "expected no quota")
+
+	os.Setenv(constants.ClusterClaimQuotaPerNamespaceEnvVar, "3")
+	os.Setenv(constants.ClusterClaimQuotaPerRequesterEnvVar, "not a number")
+	quota = ClaimQuotaFromEnv(logrus.New())
+	assert.True(t, quota.IsLimited(), "expected quota")
+	if assert.NotNil(t, quota.PerNamespace, "expected quota per namespace") {
+		assert.Equal(t, 3, *quota.PerNamespace, "unexpected quota per namespace")
+	}
+	assert.Nil(t, quota.PerRequester, "expected invalid quota per requester to be ignored")
+}
+
+func TestExceededClaimQuota(t *testing.T) {
+	now := time.Now()
+	limit := func(n int) *int {
+		return &n
+	}
+	claim := func(namespace, name, requester string, created time.Time, opts ...func(*hivev1.ClusterClaim)) hivev1.ClusterClaim {
+		claim := hivev1.ClusterClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:         namespace,
+				Name:              name,
+				Annotations:       map[string]string{constants.ClaimRequesterAnnotation: requester},
+				CreationTimestamp: metav1.NewTime(created),
+			},
+		}
+		for _, o := range opts {
+			o(&claim)
+		}
+		return claim
+	}
+	assigned := func(claim *hivev1.ClusterClaim) {
+		claim.Spec.Namespace = "test-cluster"
+	}
+	deleted := func(claim *hivev1.ClusterClaim) {
+		claim.DeletionTimestamp = &metav1.Time{Time: now}
+	}
+
+	cases := []struct {
+		name           string
+		quota          ClaimQuota
+		claim          hivev1.ClusterClaim
+		claims         []hivev1.ClusterClaim
+		expectExceeded bool
+	}{
+		{
+			name:  "no quota",
+			claim: claim("ns", "claim", "user", now),
+			claims: []hivev1.ClusterClaim{
+				claim("ns", "older", "user", now.Add(-time.Hour)),
+			},
+		},
+		{
+			name:           "zero quota",
+			quota:          ClaimQuota{PerNamespace: limit(0)},
+			claim:          claim("ns", "claim", "user", now),
+			expectExceeded: true,
+		},
+		{
+			name:  "claim itself not counted",
+			quota: ClaimQuota{PerNamespace: limit(1)},
+			claim: claim("ns", "claim", "user", now),
+			claims: []hivev1.ClusterClaim{
+				claim("ns", "claim", "user", now),
+			},
+		},
+		{
+			name:  "older claims counted",
+			quota: ClaimQuota{PerNamespace: limit(1)},
+			claim: claim("ns", "claim", "user", now),
+			claims: []hivev1.ClusterClaim{
+				claim("ns", "older", "other-user", now.Add(-time.Hour)),
+			},
+			expectExceeded: true,
+		},
+		{
+			name:  "newer claims not counted",
+			quota: ClaimQuota{PerNamespace: limit(1)},
+			claim: claim("ns", "claim", "user", now),
+			claims: []hivev1.ClusterClaim{
+				claim("ns", "newer", "other-user", now.Add(time.Hour)),
+			},
+		},
+		{
+			name:  "newer assigned claims counted",
+			quota: ClaimQuota{PerNamespace: limit(1)},
+			claim: claim("ns", "claim", "user", now),
+			claims: []hivev1.ClusterClaim{
+				claim("ns", "newer", "other-user", now.Add(time.Hour), assigned),
+			},
+			expectExceeded: true,
+		},
+		{
+			name:  "claims created at the same time ordered by name",
+			quota: ClaimQuota{PerNamespace: limit(1)},
+			claim: claim("ns", "claim-b", "user", now),
+			claims: []hivev1.ClusterClaim{
+				claim("ns", "claim-a", "other-user", now),
+			},
+			expectExceeded: true,
+		},
+		{
+			name:  "deleted claims not counted",
+			quota: ClaimQuota{PerNamespace: limit(1)},
+			claim: claim("ns", "claim", "user", now),
+			claims: []hivev1.ClusterClaim{
+				claim("ns", "older", "other-user", now.Add(-time.Hour), deleted),
+			},
+		},
+		{
+			name:  "claim being created counts all claims",
+			quota: ClaimQuota{PerNamespace: limit(1)},
+			claim: claim("ns", "claim", "user", time.Time{}),
+			claims: []hivev1.ClusterClaim{
+				claim("ns", "newer", "other-user", now.Add(time.Hour)),
+			},
+			expectExceeded: true,
+		},
+		{
+			name:  "assigned claim never exceeds",
+			quota: ClaimQuota{PerNamespace: limit(0)},
+			claim: claim("ns", "claim", "user", now, assigned),
+		},
+		{
+			name:  "requester quota across namespaces",
+			quota: ClaimQuota{PerRequester: limit(1)},
+			claim: claim("ns", "claim", "user", now),
+			claims: []hivev1.ClusterClaim{
+				claim("other-ns", "older", "user", now.Add(-time.Hour)),
+			},
+			expectExceeded: true,
+		},
+		{
+			name:  "requester quota ignores other requesters",
+			quota: ClaimQuota{PerRequester: limit(1)},
+			claim: claim("ns", "claim", "user", now),
+			claims: []hivev1.ClusterClaim{
+				claim("ns", "older", "other-user", now.Add(-time.Hour)),
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			message := tc.quota.ExceededClaimQuota(&tc.claim, tc.claims)
+			if tc.expectExceeded {
+				assert.NotEmpty(t, message, "expected claim to exceed its quota")
+			} else {
+				assert.Empty(t, message, "expected claim to be within its quota")
+			}
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
@@ -90,11 +91,49 @@ func CredentialsSecretName(cd *hivev1.ClusterDeployment) string {
 		return cd.Spec.Platform.OpenStack.CredentialsSecretRef.Name
 	case p.Ovirt != nil:
 		return cd.Spec.Platform.Ovirt.CredentialsSecretRef.Name
+	case p.OCI != nil:
+		return cd.Spec.Platform.OCI.CredentialsSecretRef.Name
+	case p.PowerVS != nil:
+		return cd.Spec.Platform.PowerVS.CredentialsSecretRef.Name
 	case p.BareMetal != nil:
 		return ""
+	case p.None != nil:
+		return ""
 	case p.AgentBareMetal != nil:
 		return ""
 	default:
 		return ""
 	}
 }
+
+// UnmetReadinessGates returns the condition types of the readiness gates of the ClusterDeployment whose condition is
+// not true.
+func UnmetReadinessGates(cd *hivev1.ClusterDeployment) []hivev1.ClusterDeploymentConditionType {
+	var unmet []hivev1.ClusterDeploymentConditionType
+	for _, gate := range cd.Spec.ReadinessGates {
+		cond := FindClusterDeploymentCondition(cd.Status.Conditions, gate.ConditionType)
+		if cond == nil || cond.Status != corev1.ConditionTrue {
+			unmet = append(unmet, gate.ConditionType)
+		}
+	}
+	return unmet
+}
+
+// IsClusterDeploymentReady returns true when the cluster is installed and all the readiness gates of the
+// ClusterDeployment are true.
+func IsClusterDeploymentReady(cd *hivev1.ClusterDeployment) bool {
+	return cd.Spec.Installed && len(UnmetReadinessGates(cd)) == 0
+}
+
+// allSelectorSyncSetsExclusion is the SelectorSyncSet exclusion which excludes all SelectorSyncSets from the cluster.
+const allSelectorSyncSetsExclusion = "*"
+
+// IsSelectorSyncSetExcluded returns whether the SelectorSyncSet with the given name is excluded from the cluster.
+func IsSelectorSyncSetExcluded(cd *hivev1.ClusterDeployment, name string) bool {
+	for _, exclusion := range cd.Spec.SelectorSyncSetExclusions {
+		if exclusion == name || exclusion == allSelectorSyncSetsExclusion {
+			return true
+		}
+	}
+	return false
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	"github.com/openshift/hive/pkg/constants"
@@ -220,3 +221,51 @@ func TestClearRelocateAnnotation(t *testing.T) {
 		})
 	}
 }
+
+func TestUnmetReadinessGates(t *testing.T) {
+	const (
+		scanned  hivev1.ClusterDeploymentConditionType = "example.com/Scanned"
+		approved hivev1.ClusterDeploymentConditionType = "example.com/Approved"
+	)
+	cases := []struct {
+		name          string
+		cd            *hivev1.ClusterDeployment
+		expectedUnmet []hivev1.ClusterDeploymentConditionType
+		expectedReady bool
+	}{
+		{
+			name:          "no gates",
+			cd:            clusterdeployment.Build(clusterdeployment.Installed()),
+			expectedReady: true,
+		},
+		{
+			name: "not installed",
+			cd:   clusterdeployment.Build(),
+		},
+		{
+			name: "gates met",
+			cd: clusterdeployment.Build(
+				clusterdeployment.Installed(),
+				clusterdeployment.WithReadinessGates(scanned, approved),
+				clusterdeployment.WithCondition(hivev1.ClusterDeploymentCondition{Type: scanned, Status: corev1.ConditionTrue}),
+				clusterdeployment.WithCondition(hivev1.ClusterDeploymentCondition{Type: approved, Status: corev1.ConditionTrue}),
+			),
+			expectedReady: true,
+		},
+		{
+			name: "gates not met",
+			cd: clusterdeployment.Build(
+				clusterdeployment.Installed(),
+				clusterdeployment.WithReadinessGates(scanned, approved),
+				clusterdeployment.WithCondition(hivev1.ClusterDeploymentCondition{Type: scanned, Status: corev1.ConditionFalse}),
+			),
+			expectedUnmet: []hivev1.ClusterDeploymentConditionType{scanned, approved},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedUnmet, UnmetReadinessGates(tc.cd), "unexpected unmet readiness gates")
+			assert.Equal(t, tc.expectedReady, IsClusterDeploymentReady(tc.cd), "unexpected readiness")
+		})
+	}
+}
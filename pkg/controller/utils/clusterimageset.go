@@ -0,0 +1,86 @@
+package utils
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+const (
+	// ClusterDeploymentImageSetIndex is the field index of ClusterDeployments by the name of their ClusterImageSet.
+	ClusterDeploymentImageSetIndex = "spec.provisioning.imageSetRef.name"
+
+	// ClusterPoolImageSetIndex is the field index of ClusterPools by the name of their ClusterImageSet.
+	ClusterPoolImageSetIndex = "spec.imageSetRef.name"
+)
+
+// IndexClusterImageSetUsers adds the field indexes of ClusterDeployments and ClusterPools by the name of their
+// ClusterImageSet, used by IndexedClusterImageSetUsers.
+func IndexClusterImageSetUsers(indexer client.FieldIndexer) error {
+	if err := indexer.IndexField(context.Background(), &hivev1.ClusterDeployment{}, ClusterDeploymentImageSetIndex, func(o runtime.Object) []string {
+		if name := clusterDeploymentImageSetName(o.(*hivev1.ClusterDeployment)); name != "" {
+			return []string{name}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	return indexer.IndexField(context.Background(), &hivev1.ClusterPool{}, ClusterPoolImageSetIndex, func(o runtime.Object) []string {
+		if name := o.(*hivev1.ClusterPool).Spec.ImageSetRef.Name; name != "" {
+			return []string{name}
+		}
+		return nil
+	})
+}
+
+// ClusterImageSetUsers returns the ClusterDeployments and ClusterPools that reference the ClusterImageSet with the
+// given name.
+func ClusterImageSetUsers(c client.Client, imageSetName string) ([]*hivev1.ClusterDeployment, []*hivev1.ClusterPool, error) {
+	return clusterImageSetUsers(c, imageSetName, nil, nil)
+}
+
+// IndexedClusterImageSetUsers returns the ClusterDeployments and ClusterPools that reference the ClusterImageSet with
+// the given name, listing only those through the indexes added by IndexClusterImageSetUsers to the cache of the client.
+func IndexedClusterImageSetUsers(c client.Client, imageSetName string) ([]*hivev1.ClusterDeployment, []*hivev1.ClusterPool, error) {
+	return clusterImageSetUsers(c, imageSetName,
+		[]client.ListOption{client.MatchingFields{ClusterDeploymentImageSetIndex: imageSetName}},
+		[]client.ListOption{client.MatchingFields{ClusterPoolImageSetIndex: imageSetName}},
+	)
+}
+
+func clusterImageSetUsers(c client.Client, imageSetName string, cdOpts, poolOpts []client.ListOption) ([]*hivev1.ClusterDeployment, []*hivev1.ClusterPool, error) {
+	cdList := &hivev1.ClusterDeploymentList{}
+	if err := c.List(context.Background(), cdList, cdOpts...); err != nil {
+		return nil, nil, err
+	}
+	var cds []*hivev1.ClusterDeployment
+	for i := range cdList.Items {
+		cd := &cdList.Items[i]
+		if clusterDeploymentImageSetName(cd) == imageSetName {
+			cds = append(cds, cd)
+		}
+	}
+
+	poolList := &hivev1.ClusterPoolList{}
+	if err := c.List(context.Background(), poolList, poolOpts...); err != nil {
+		return nil, nil, err
+	}
+	var pools []*hivev1.ClusterPool
+	for i := range poolList.Items {
+		pool := &poolList.Items[i]
+		if pool.Spec.ImageSetRef.Name == imageSetName {
+			pools = append(pools, pool)
+		}
+	}
+	return cds, pools, nil
+}
+
+func clusterDeploymentImageSetName(cd *hivev1.ClusterDeployment) string {
+	if cd.Spec.Provisioning == nil || cd.Spec.Provisioning.ImageSetRef == nil {
+		return ""
+	}
+	return cd.Spec.Provisioning.ImageSetRef.Name
+}
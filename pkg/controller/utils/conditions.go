@@ -84,6 +84,7 @@ func SetClusterDeploymentConditionWithChangeCheck(
 					Status:             status,
 					Reason:             reason,
 					Message:            message,
+					Category:           FailureCategory(reason),
 					LastTransitionTime: now,
 					LastProbeTime:      now,
 				},
@@ -102,6 +103,7 @@ func SetClusterDeploymentConditionWithChangeCheck(
 			existingCondition.Status = status
 			existingCondition.Reason = reason
 			existingCondition.Message = message
+			existingCondition.Category = FailureCategory(reason)
 			existingCondition.LastProbeTime = now
 			changed = true
 		}
@@ -151,6 +153,54 @@ func SetClusterClaimConditionWithChangeCheck(
 		conditions = append(
 			conditions,
 			hivev1.ClusterClaimCondition{
+				Type:               conditionType,
+				Status:             status,
+				Reason:             reason,
+				Message:            message,
+				Category:           FailureCategory(reason),
+				LastTransitionTime: now,
+				LastProbeTime:      now,
+			},
+		)
+		changed = true
+	} else {
+		if shouldUpdateCondition(
+			existingCondition.Status, existingCondition.Reason, existingCondition.Message,
+			status, reason, message,
+			updateConditionCheck,
+		) {
+			if existingCondition.Status != status {
+				existingCondition.LastTransitionTime = now
+			}
+			existingCondition.Status = status
+			existingCondition.Reason = reason
+			existingCondition.Message = message
+			existingCondition.Category = FailureCategory(reason)
+			existingCondition.LastProbeTime = now
+			changed = true
+		}
+	}
+	return conditions, changed
+}
+
+// SetClusterImageSetConditionWithChangeCheck sets a condition on a ClusterImageSet resource's status.
+// It returns the conditions as well a boolean indicating whether there was a change made
+// to the conditions. Like ClusterClaim conditions, a new condition is added whatever its status.
+func SetClusterImageSetConditionWithChangeCheck(
+	conditions []hivev1.ClusterImageSetCondition,
+	conditionType hivev1.ClusterImageSetConditionType,
+	status corev1.ConditionStatus,
+	reason string,
+	message string,
+	updateConditionCheck UpdateConditionCheck,
+) ([]hivev1.ClusterImageSetCondition, bool) {
+	changed := false
+	now := metav1.Now()
+	existingCondition := FindClusterImageSetCondition(conditions, conditionType)
+	if existingCondition == nil {
+		conditions = append(
+			conditions,
+			hivev1.ClusterImageSetCondition{
 				Type:               conditionType,
 				Status:             status,
 				Reason:             reason,
@@ -225,6 +275,7 @@ func SetClusterPoolConditionWithChangeCheck(
 				Status:             status,
 				Reason:             reason,
 				Message:            message,
+				Category:           FailureCategory(reason),
 				LastTransitionTime: now,
 				LastProbeTime:      now,
 			},
@@ -242,6 +293,7 @@ func SetClusterPoolConditionWithChangeCheck(
 			existingCondition.Status = status
 			existingCondition.Reason = reason
 			existingCondition.Message = message
+			existingCondition.Category = FailureCategory(reason)
 			existingCondition.LastProbeTime = now
 			changed = true
 		}
@@ -269,6 +321,7 @@ func SetClusterProvisionCondition(
 					Status:             status,
 					Reason:             reason,
 					Message:            message,
+					Category:           FailureCategory(reason),
 					LastTransitionTime: now,
 					LastProbeTime:      now,
 				},
@@ -286,6 +339,7 @@ func SetClusterProvisionCondition(
 			existingCondition.Status = status
 			existingCondition.Reason = reason
 			existingCondition.Message = message
+			existingCondition.Category = FailureCategory(reason)
 			existingCondition.LastProbeTime = now
 		}
 	}
@@ -378,6 +432,7 @@ func SetDNSZoneConditionWithChangeCheck(
 					Status:             status,
 					Reason:             reason,
 					Message:            message,
+					Category:           FailureCategory(reason),
 					LastTransitionTime: now,
 					LastProbeTime:      now,
 				},
@@ -396,6 +451,7 @@ func SetDNSZoneConditionWithChangeCheck(
 			existingCondition.Status = status
 			existingCondition.Reason = reason
 			existingCondition.Message = message
+			existingCondition.Category = FailureCategory(reason)
 			existingCondition.LastProbeTime = now
 			changed = true
 		}
@@ -446,6 +502,7 @@ func SetMachinePoolConditionWithChangeCheck(
 					Status:             status,
 					Reason:             reason,
 					Message:            message,
+					Category:           FailureCategory(reason),
 					LastTransitionTime: now,
 					LastProbeTime:      now,
 				},
@@ -464,6 +521,7 @@ func SetMachinePoolConditionWithChangeCheck(
 			existingCondition.Status = status
 			existingCondition.Reason = reason
 			existingCondition.Message = message
+			existingCondition.Category = FailureCategory(reason)
 			existingCondition.LastProbeTime = now
 			changed = true
 		}
@@ -510,6 +568,76 @@ func SetClusterDeprovisionConditionWithChangeCheck(
 			conditions = append(
 				conditions,
 				hivev1.ClusterDeprovisionCondition{
+					Type:               conditionType,
+					Status:             status,
+					Reason:             reason,
+					Message:            message,
+					Category:           FailureCategory(reason),
+					LastTransitionTime: now,
+					LastProbeTime:      now,
+				},
+			)
+			changed = true
+		}
+	} else {
+		if shouldUpdateCondition(
+			existingCondition.Status, existingCondition.Reason, existingCondition.Message,
+			status, reason, message,
+			updateConditionCheck,
+		) {
+			if existingCondition.Status != status {
+				existingCondition.LastTransitionTime = now
+			}
+			existingCondition.Status = status
+			existingCondition.Reason = reason
+			existingCondition.Message = message
+			existingCondition.Category = FailureCategory(reason)
+			existingCondition.LastProbeTime = now
+			changed = true
+		}
+	}
+	return conditions, changed
+}
+
+// SetGitSyncSourceCondition sets a condition on a GitSyncSource resource's status
+func SetGitSyncSourceCondition(
+	conditions []hivev1.GitSyncSourceCondition,
+	conditionType hivev1.GitSyncSourceConditionType,
+	status corev1.ConditionStatus,
+	reason string,
+	message string,
+	updateConditionCheck UpdateConditionCheck,
+) []hivev1.GitSyncSourceCondition {
+	newConditions, _ := SetGitSyncSourceConditionWithChangeCheck(
+		conditions,
+		conditionType,
+		status,
+		reason,
+		message,
+		updateConditionCheck,
+	)
+	return newConditions
+}
+
+// SetGitSyncSourceConditionWithChangeCheck sets a condition on a GitSyncSource resource's status
+// It returns the conditions as well a boolean indicating whether there was a change made
+// to the conditions.
+func SetGitSyncSourceConditionWithChangeCheck(
+	conditions []hivev1.GitSyncSourceCondition,
+	conditionType hivev1.GitSyncSourceConditionType,
+	status corev1.ConditionStatus,
+	reason string,
+	message string,
+	updateConditionCheck UpdateConditionCheck,
+) ([]hivev1.GitSyncSourceCondition, bool) {
+	changed := false
+	now := metav1.Now()
+	existingCondition := FindGitSyncSourceCondition(conditions, conditionType)
+	if existingCondition == nil {
+		if status == corev1.ConditionTrue {
+			conditions = append(
+				conditions,
+				hivev1.GitSyncSourceCondition{
 					Type:               conditionType,
 					Status:             status,
 					Reason:             reason,
@@ -561,6 +689,17 @@ func FindClusterClaimCondition(conditions []hivev1.ClusterClaimCondition, condit
 	return nil
 }
 
+// FindClusterImageSetCondition finds in the condition that has the
+// specified condition type in the given list. If none exists, then returns nil.
+func FindClusterImageSetCondition(conditions []hivev1.ClusterImageSetCondition, conditionType hivev1.ClusterImageSetConditionType) *hivev1.ClusterImageSetCondition {
+	for i, condition := range conditions {
+		if condition.Type == conditionType {
+			return &conditions[i]
+		}
+	}
+	return nil
+}
+
 // FindClusterPoolCondition finds in the condition that has the
 // specified condition type in the given list. If none exists, then returns nil.
 func FindClusterPoolCondition(conditions []hivev1.ClusterPoolCondition, conditionType hivev1.ClusterPoolConditionType) *hivev1.ClusterPoolCondition {
@@ -626,3 +765,14 @@ func FindClusterDeprovisionCondition(conditions []hivev1.ClusterDeprovisionCondi
 	}
 	return nil
 }
+
+// FindGitSyncSourceCondition finds in the condition that has the
+// specified condition type in the given list. If none exists, then returns nil.
+func FindGitSyncSourceCondition(conditions []hivev1.GitSyncSourceCondition, conditionType hivev1.GitSyncSourceConditionType) *hivev1.GitSyncSourceCondition {
+	for i, condition := range conditions {
+		if condition.Type == conditionType {
+			return &conditions[i]
+		}
+	}
+	return nil
+}
@@ -21,12 +21,15 @@ import (
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/powervsclient"
 )
 
 // ValidateCredentialsForClusterDeployment will attempt to verify that the platform/cloud credentials
 // for the given ClusterDeployment are valid.
 // Note: It simply checks that the username/password (or equivalent) can authenticate,
 // not that the credentials have any specific permissions.
+// For PowerVS, the service instance and network the install depends on are also checked, as those must be
+// created ahead of time and the API key is scoped to them.
 func ValidateCredentialsForClusterDeployment(kubeClient client.Client, cd *hivev1.ClusterDeployment, logger log.FieldLogger) (bool, error) {
 	secret := &corev1.Secret{}
 
@@ -66,6 +69,14 @@ func ValidateCredentialsForClusterDeployment(kubeClient client.Client, cd *hivev
 			string(secret.Data[constants.PasswordSecretKey]),
 			rootCAFiles,
 			logger)
+	case constants.PlatformPowerVS:
+		secretKey := types.NamespacedName{Name: cd.Spec.Platform.PowerVS.CredentialsSecretRef.Name, Namespace: cd.Namespace}
+		if err := kubeClient.Get(context.TODO(), secretKey, secret); err != nil {
+			logger.WithError(err).Error("failed to read in ClusterDeployment's platform creds")
+			return false, err
+		}
+		return validatePowerVSPrerequisites(secret, cd.Spec.Platform.PowerVS.Region, cd.Spec.Platform.PowerVS.Zone,
+			cd.Spec.Platform.PowerVS.ServiceInstanceID, cd.Spec.Platform.PowerVS.NetworkName, logger)
 	default:
 		// If we have no platform-specific credentials verification
 		// assume the creds are valid.
@@ -135,6 +146,27 @@ func validateVSphereCredentials(vcenter, username, password string, rootCAFiles
 	return err == nil, nil
 }
 
+func validatePowerVSPrerequisites(secret *corev1.Secret, region, zone, serviceInstanceID, networkName string, logger log.FieldLogger) (bool, error) {
+	client, err := powervsclient.NewClientFromSecret(secret, region, serviceInstanceID)
+	if err != nil {
+		logger.WithError(err).Warn("failed to create PowerVS client")
+		return false, nil
+	}
+	switch err := powervsclient.ValidatePrerequisites(client, zone, networkName); {
+	case err == nil:
+		return true, nil
+	case powervsclient.IsUnauthorized(err), powervsclient.IsNotFound(err):
+		logger.WithError(err).Warn("failed to authenticate into PowerVS")
+		return false, nil
+	case powervsclient.IsPrerequisiteError(err):
+		logger.WithError(err).Warn("PowerVS prerequisites are not met")
+		return false, nil
+	default:
+		logger.WithError(err).Error("failed to validate PowerVS prerequisites")
+		return false, err
+	}
+}
+
 // getClusterPlatform returns the platform of a given ClusterDeployment
 func getClusterPlatform(cd *hivev1.ClusterDeployment) string {
 	switch {
@@ -150,6 +182,8 @@ func getClusterPlatform(cd *hivev1.ClusterDeployment) string {
 		return constants.PlatformVSphere
 	case cd.Spec.Platform.BareMetal != nil:
 		return constants.PlatformBaremetal
+	case cd.Spec.Platform.PowerVS != nil:
+		return constants.PlatformPowerVS
 	}
 	return constants.PlatformUnknown
 }
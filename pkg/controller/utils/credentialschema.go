@@ -0,0 +1,218 @@
+package utils
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/azureclient"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+// credentialsExpiryTimeout bounds the request checking the expiry of platform credentials.
+const credentialsExpiryTimeout = 30 * time.Second
+
+// CheckCredentialsSecretSchema checks that the platform credentials secret of the ClusterDeployment has the structure
+// the installer and the Hive controllers expect: the keys of its platform are set, and JSON, YAML and PEM values
+// parse. It returns a message describing the first problem found, or an empty message when the secret is well formed
+// or does not exist yet.
+func CheckCredentialsSecretSchema(kubeClient client.Client, cd *hivev1.ClusterDeployment) (string, error) {
+	var secretName string
+	var check func(secret *corev1.Secret) string
+	switch p := cd.Spec.Platform; {
+	case p.AWS != nil:
+		secretName = p.AWS.CredentialsSecretRef.Name
+		check = func(secret *corev1.Secret) string {
+			return requireSecretKeys(secret, constants.AWSAccessKeyIDSecretKey, constants.AWSSecretAccessKeySecretKey)
+		}
+	case p.Azure != nil:
+		secretName = p.Azure.CredentialsSecretRef.Name
+		check = checkAzureCredentials
+	case p.GCP != nil:
+		secretName = p.GCP.CredentialsSecretRef.Name
+		check = checkGCPCredentials
+	case p.OpenStack != nil:
+		secretName = p.OpenStack.CredentialsSecretRef.Name
+		check = func(secret *corev1.Secret) string {
+			return checkOpenStackCredentials(secret, p.OpenStack.Cloud)
+		}
+	case p.VSphere != nil:
+		secretName = p.VSphere.CredentialsSecretRef.Name
+		check = func(secret *corev1.Secret) string {
+			return requireSecretKeys(secret, constants.UsernameSecretKey, constants.PasswordSecretKey)
+		}
+	case p.OCI != nil:
+		secretName = p.OCI.CredentialsSecretRef.Name
+		check = checkOCICredentials
+	case p.PowerVS != nil:
+		secretName = p.PowerVS.CredentialsSecretRef.Name
+		check = func(secret *corev1.Secret) string {
+			return requireSecretKeys(secret, constants.PowerVSAPIKeySecretKey)
+		}
+	}
+	// Platforms such as bare metal have no credentials secret to check.
+	if secretName == "" {
+		return "", nil
+	}
+
+	secret := &corev1.Secret{}
+	switch err := kubeClient.Get(context.TODO(), types.NamespacedName{Namespace: cd.Namespace, Name: secretName}, secret); {
+	case apierrors.IsNotFound(err):
+		return "", nil
+	case err != nil:
+		return "", err
+	}
+	return check(secret), nil
+}
+
+// CheckCredentialsExpiry checks that the platform credentials of the ClusterDeployment have not expired, which the
+// structure of their secret does not tell. The service principal of Azure credentials is checked by requesting a token
+// for it. It returns a message describing the expiry, or an empty message when the credentials have not expired, cannot
+// be checked, or their secret does not exist yet.
+func CheckCredentialsExpiry(kubeClient client.Client, cd *hivev1.ClusterDeployment, logger log.FieldLogger) (string, error) {
+	if cd.Spec.Platform.Azure == nil {
+		return "", nil
+	}
+	secret := &corev1.Secret{}
+	switch err := kubeClient.Get(context.TODO(), types.NamespacedName{Namespace: cd.Namespace, Name: cd.Spec.Platform.Azure.CredentialsSecretRef.Name}, secret); {
+	case apierrors.IsNotFound(err):
+		return "", nil
+	case err != nil:
+		return "", err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), credentialsExpiryTimeout)
+	defer cancel()
+	switch err := azureclient.ValidateServicePrincipal(ctx, secret); {
+	case err == nil:
+		return "", nil
+	case azureclient.IsExpiredServicePrincipal(err):
+		return fmt.Sprintf("credentials secret %q has a service principal whose client secret has expired", secret.Name), nil
+	default:
+		// Other failures are reported when the credentials are used, and Azure AD being unreachable must not
+		// block provisioning.
+		logger.WithError(err).Warn("could not check the expiry of the Azure service principal")
+		return "", nil
+	}
+}
+
+// requireSecretKeys returns a message naming the first of the keys which the secret is missing or has empty.
+func requireSecretKeys(secret *corev1.Secret, keys ...string) string {
+	for _, key := range keys {
+		if len(secret.Data[key]) == 0 {
+			return fmt.Sprintf("credentials secret %q is missing the %q key", secret.Name, key)
+		}
+	}
+	return ""
+}
+
+// requireJSONFields returns a message naming the first of the fields which the JSON object in the key of the secret
+// is missing or has empty.
+func requireJSONFields(secret *corev1.Secret, key string, object map[string]interface{}, fields ...string) string {
+	for _, field := range fields {
+		if value, _ := object[field].(string); value == "" {
+			return fmt.Sprintf("credentials secret %q is missing the %q field in the %q key", secret.Name, field, key)
+		}
+	}
+	return ""
+}
+
+// unmarshalSecretJSON parses the JSON object in the key of the secret, returning a message when it cannot.
+func unmarshalSecretJSON(secret *corev1.Secret, key string) (map[string]interface{}, string) {
+	if msg := requireSecretKeys(secret, key); msg != "" {
+		return nil, msg
+	}
+	object := map[string]interface{}{}
+	if err := json.Unmarshal(secret.Data[key], &object); err != nil {
+		return nil, fmt.Sprintf("credentials secret %q has invalid JSON in the %q key: %v", secret.Name, key, err)
+	}
+	return object, ""
+}
+
+// checkPrivateKey returns a message when the value is not a PEM encoded private key.
+func checkPrivateKey(secret *corev1.Secret, location string, value []byte) string {
+	block, _ := pem.Decode(value)
+	if block == nil {
+		return fmt.Sprintf("credentials secret %q has no PEM encoded private key in %s", secret.Name, location)
+	}
+	// Encrypted keys can only be parsed with their passphrase.
+	if block.Type == "ENCRYPTED PRIVATE KEY" || strings.Contains(block.Headers["Proc-Type"], "ENCRYPTED") {
+		return ""
+	}
+	if _, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+		return ""
+	}
+	if _, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return ""
+	}
+	if _, err := x509.ParseECPrivateKey(block.Bytes); err == nil {
+		return ""
+	}
+	return fmt.Sprintf("credentials secret %q has an invalid private key in %s", secret.Name, location)
+}
+
+func checkAzureCredentials(secret *corev1.Secret) string {
+	sp, msg := unmarshalSecretJSON(secret, constants.AzureCredentialsName)
+	if msg != "" {
+		return msg
+	}
+	return requireJSONFields(secret, constants.AzureCredentialsName, sp, "clientId", "clientSecret", "tenantId", "subscriptionId")
+}
+
+func checkGCPCredentials(secret *corev1.Secret) string {
+	sa, msg := unmarshalSecretJSON(secret, constants.GCPCredentialsName)
+	if msg != "" {
+		return msg
+	}
+	if msg := requireJSONFields(secret, constants.GCPCredentialsName, sa, "type"); msg != "" {
+		return msg
+	}
+	// Other types of credentials, such as external accounts, have their own structure.
+	if sa["type"] != "service_account" {
+		return ""
+	}
+	if msg := requireJSONFields(secret, constants.GCPCredentialsName, sa, "project_id", "client_email", "private_key"); msg != "" {
+		return msg
+	}
+	privateKey, _ := sa["private_key"].(string)
+	return checkPrivateKey(secret, fmt.Sprintf("the private_key field of the %q key", constants.GCPCredentialsName), []byte(privateKey))
+}
+
+func checkOpenStackCredentials(secret *corev1.Secret, cloud string) string {
+	if msg := requireSecretKeys(secret, constants.OpenStackCredentialsName); msg != "" {
+		return msg
+	}
+	clouds := struct {
+		Clouds map[string]interface{} `json:"clouds"`
+	}{}
+	if err := yaml.Unmarshal(secret.Data[constants.OpenStackCredentialsName], &clouds); err != nil {
+		return fmt.Sprintf("credentials secret %q has invalid YAML in the %q key: %v", secret.Name, constants.OpenStackCredentialsName, err)
+	}
+	if _, ok := clouds.Clouds[cloud]; !ok {
+		return fmt.Sprintf("credentials secret %q has no %q cloud in the %q key", secret.Name, cloud, constants.OpenStackCredentialsName)
+	}
+	return ""
+}
+
+func checkOCICredentials(secret *corev1.Secret) string {
+	if msg := requireSecretKeys(secret,
+		constants.OCITenancySecretKey,
+		constants.OCIUserSecretKey,
+		constants.OCIFingerprintSecretKey,
+		constants.OCIPrivateKeySecretKey,
+	); msg != "" {
+		return msg
+	}
+	return checkPrivateKey(secret, fmt.Sprintf("the %q key", constants.OCIPrivateKeySecretKey), secret.Data[constants.OCIPrivateKeySecretKey])
+}
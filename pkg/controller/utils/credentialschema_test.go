@@ -0,0 +1,163 @@
+package utils
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
+	hivev1azure "github.com/openshift/hive/apis/hive/v1/azure"
+	hivev1gcp "github.com/openshift/hive/apis/hive/v1/gcp"
+	hivev1openstack "github.com/openshift/hive/apis/hive/v1/openstack"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+func TestCheckCredentialsSecretSchema(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err, "could not generate private key")
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err, "could not marshal private key")
+	privateKey := string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}))
+
+	cases := []struct {
+		name            string
+		platform        hivev1.Platform
+		secretData      map[string]string
+		expectMalformed string
+	}{
+		{
+			name:     "missing secret",
+			platform: hivev1.Platform{AWS: &hivev1aws.Platform{CredentialsSecretRef: corev1.LocalObjectReference{Name: "creds"}}},
+		},
+		{
+			name:     "aws",
+			platform: hivev1.Platform{AWS: &hivev1aws.Platform{CredentialsSecretRef: corev1.LocalObjectReference{Name: "creds"}}},
+			secretData: map[string]string{
+				constants.AWSAccessKeyIDSecretKey:     "key-id",
+				constants.AWSSecretAccessKeySecretKey: "secret-key",
+			},
+		},
+		{
+			name:     "aws missing key",
+			platform: hivev1.Platform{AWS: &hivev1aws.Platform{CredentialsSecretRef: corev1.LocalObjectReference{Name: "creds"}}},
+			secretData: map[string]string{
+				constants.AWSAccessKeyIDSecretKey: "key-id",
+			},
+			expectMalformed: `credentials secret "creds" is missing the "aws_secret_access_key" key`,
+		},
+		{
+			name:     "azure",
+			platform: hivev1.Platform{Azure: &hivev1azure.Platform{CredentialsSecretRef: corev1.LocalObjectReference{Name: "creds"}}},
+			secretData: map[string]string{
+				constants.AzureCredentialsName: `{"clientId":"id","clientSecret":"secret","tenantId":"tenant","subscriptionId":"sub"}`,
+			},
+		},
+		{
+			name:     "azure invalid json",
+			platform: hivev1.Platform{Azure: &hivev1azure.Platform{CredentialsSecretRef: corev1.LocalObjectReference{Name: "creds"}}},
+			secretData: map[string]string{
+				constants.AzureCredentialsName: `{"clientId":`,
+			},
+			expectMalformed: `credentials secret "creds" has invalid JSON in the "osServicePrincipal.json" key: unexpected end of JSON input`,
+		},
+		{
+			name:     "azure missing field",
+			platform: hivev1.Platform{Azure: &hivev1azure.Platform{CredentialsSecretRef: corev1.LocalObjectReference{Name: "creds"}}},
+			secretData: map[string]string{
+				constants.AzureCredentialsName: `{"clientId":"id","clientSecret":"secret","subscriptionId":"sub"}`,
+			},
+			expectMalformed: `credentials secret "creds" is missing the "tenantId" field in the "osServicePrincipal.json" key`,
+		},
+		{
+			name:     "gcp",
+			platform: hivev1.Platform{GCP: &hivev1gcp.Platform{CredentialsSecretRef: corev1.LocalObjectReference{Name: "creds"}}},
+			secretData: map[string]string{
+				constants.GCPCredentialsName: testGCPServiceAccount(t, privateKey),
+			},
+		},
+		{
+			name:     "gcp invalid private key",
+			platform: hivev1.Platform{GCP: &hivev1gcp.Platform{CredentialsSecretRef: corev1.LocalObjectReference{Name: "creds"}}},
+			secretData: map[string]string{
+				constants.GCPCredentialsName: testGCPServiceAccount(t, "not a key"),
+			},
+			expectMalformed: `credentials secret "creds" has no PEM encoded private key in the private_key field of the "osServiceAccount.json" key`,
+		},
+		{
+			name:     "gcp external account",
+			platform: hivev1.Platform{GCP: &hivev1gcp.Platform{CredentialsSecretRef: corev1.LocalObjectReference{Name: "creds"}}},
+			secretData: map[string]string{
+				constants.GCPCredentialsName: `{"type":"external_account","audience":"aud"}`,
+			},
+		},
+		{
+			name: "openstack",
+			platform: hivev1.Platform{OpenStack: &hivev1openstack.Platform{
+				CredentialsSecretRef: corev1.LocalObjectReference{Name: "creds"},
+				Cloud:                "shiftstack",
+			}},
+			secretData: map[string]string{
+				constants.OpenStackCredentialsName: "clouds:\n  shiftstack:\n    auth: {}\n",
+			},
+		},
+		{
+			name: "openstack missing cloud",
+			platform: hivev1.Platform{OpenStack: &hivev1openstack.Platform{
+				CredentialsSecretRef: corev1.LocalObjectReference{Name: "creds"},
+				Cloud:                "other",
+			}},
+			secretData: map[string]string{
+				constants.OpenStackCredentialsName: "clouds:\n  shiftstack:\n    auth: {}\n",
+			},
+			expectMalformed: `credentials secret "creds" has no "other" cloud in the "clouds.yaml" key`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cd := &hivev1.ClusterDeployment{
+				ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "test-cd"},
+				Spec:       hivev1.ClusterDeploymentSpec{Platform: tc.platform},
+			}
+			existing := []runtime.Object{}
+			if tc.secretData != nil {
+				secret := &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "creds"},
+					Data:       map[string][]byte{},
+				}
+				for k, v := range tc.secretData {
+					secret.Data[k] = []byte(v)
+				}
+				existing = append(existing, secret)
+			}
+			c := fake.NewFakeClientWithScheme(scheme.Scheme, existing...)
+			malformed, err := CheckCredentialsSecretSchema(c, cd)
+			require.NoError(t, err, "unexpected error")
+			assert.Equal(t, tc.expectMalformed, malformed, "unexpected malformed message")
+		})
+	}
+}
+
+func testGCPServiceAccount(t *testing.T, privateKey string) string {
+	sa, err := json.Marshal(map[string]string{
+		"type":         "service_account",
+		"project_id":   "project",
+		"client_email": "installer@project.iam.gserviceaccount.com",
+		"private_key":  privateKey,
+	})
+	require.NoError(t, err, "could not marshal service account")
+	return string(sa)
+}
@@ -0,0 +1,97 @@
+package utils
+
+import (
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+// failureCategories maps the reasons of the failure conditions set by the Hive controllers, and the reasons of the
+// built-in install log regexes, onto the shared failure taxonomy.
+var failureCategories = map[string]hivev1.FailureCategory{
+	// Credentials and access.
+	"PlatformAuthError":             hivev1.FailureCategoryAuthn,
+	"CredentialsMalformed":          hivev1.FailureCategoryAuthn,
+	"AuthenticationFailed":          hivev1.FailureCategoryAuthn,
+	"AccessDenied":                  hivev1.FailureCategoryAuthn,
+	"PendingVerification":           hivev1.FailureCategoryAuthn,
+	"LibvirtSSHKeyPermissionDenied": hivev1.FailureCategoryAuthn,
+
+	// Limits of the cloud account.
+	"AWSNATGatewayLimitExceeded": hivev1.FailureCategoryQuota,
+	"AWSVPCLimitExceeded":        hivev1.FailureCategoryQuota,
+	"AWSAPIRateLimitExceeded":    hivev1.FailureCategoryQuota,
+	"ResourceLimitExceeded":      hivev1.FailureCategoryQuota,
+	"GCPQuotaSSDTotalGBExceeded": hivev1.FailureCategoryQuota,
+	"GeneralQuotaExceeded":       hivev1.FailureCategoryQuota,
+	"FailedDueToQuotas":          hivev1.FailureCategoryQuota,
+	ClaimQuotaExceededReason:     hivev1.FailureCategoryQuota,
+
+	// Connectivity and DNS.
+	"DNSNotReady":              hivev1.FailureCategoryNetwork,
+	"DNSNotReadyTimedOut":      hivev1.FailureCategoryNetwork,
+	"LookupFailed":             hivev1.FailureCategoryNetwork,
+	"NoNameServers":            hivev1.FailureCategoryNetwork,
+	"NameServerMismatch":       hivev1.FailureCategoryNetwork,
+	"ErrorConnectingToCluster": hivev1.FailureCategoryNetwork,
+	"RemoteClusterUnreachable": hivev1.FailureCategoryNetwork,
+	"KubeAPIWaitTimeout":       hivev1.FailureCategoryNetwork,
+	"LibvirtConnectionFailed":  hivev1.FailureCategoryNetwork,
+
+	// Configuration of the resources.
+	"ClusterImageSetNotFound":           hivev1.FailureCategoryUserConfig,
+	"ClusterImageSetInvalid":            hivev1.FailureCategoryUserConfig,
+	"DNSZoneResourceConflict":           hivev1.FailureCategoryUserConfig,
+	"DNSUnsupportedPlatform":            hivev1.FailureCategoryUserConfig,
+	"DNSAlreadyExists":                  hivev1.FailureCategoryUserConfig,
+	"NoMatchingRoute53Zone":             hivev1.FailureCategoryUserConfig,
+	"AWSUnableToFindMatchingRouteTable": hivev1.FailureCategoryUserConfig,
+	"GCPInvalidProjectID":               hivev1.FailureCategoryUserConfig,
+	"GCPInstanceTypeNotFound":           hivev1.FailureCategoryUserConfig,
+	"GCPPreconditionFailed":             hivev1.FailureCategoryUserConfig,
+	"FeatureSetNotSupported":            hivev1.FailureCategoryUserConfig,
+	"InvalidManifests":                  hivev1.FailureCategoryUserConfig,
+	"BackupNotFound":                    hivev1.FailureCategoryUserConfig,
+	"VeleroNotInstalled":                hivev1.FailureCategoryUserConfig,
+	hivev1.UnsupportedHibernationReason: hivev1.FailureCategoryUserConfig,
+	hivev1.SyncSetsNotAppliedReason:     hivev1.FailureCategoryUserConfig,
+	"Missing":                           hivev1.FailureCategoryUserConfig,
+	"MissingDependencies":               hivev1.FailureCategoryUserConfig,
+	"Invalid":                           hivev1.FailureCategoryUserConfig,
+	"UnsupportedSpotMarketOptions":      hivev1.FailureCategoryUserConfig,
+	"NoSubnetForAvailabilityZone":       hivev1.FailureCategoryUserConfig,
+	"SubnetsNotFound":                   hivev1.FailureCategoryUserConfig,
+	"InsufficientPublicSubnets":         hivev1.FailureCategoryUserConfig,
+	"MoreThanOneSubnetForZone":          hivev1.FailureCategoryUserConfig,
+	"ZoneSubnetsNotFound":               hivev1.FailureCategoryUserConfig,
+	"ZoneSubnetsMismatch":               hivev1.FailureCategoryUserConfig,
+	"MinReplicasTooSmall":               hivev1.FailureCategoryUserConfig,
+	"OutOfMachinePoolNames":             hivev1.FailureCategoryUserConfig,
+
+	// Failures of the cloud or of the installed cluster.
+	hivev1.FailedToStopHibernationReason:   hivev1.FailureCategoryCloudOutage,
+	hivev1.FailedToStartHibernationReason:  hivev1.FailureCategoryCloudOutage,
+	"DestroyerFailed":                      hivev1.FailureCategoryCloudOutage,
+	"MonitoringOperatorStillUpdating":      hivev1.FailureCategoryCloudOutage,
+	"AuthenticationOperatorDegraded":       hivev1.FailureCategoryCloudOutage,
+	"GeneralOperatorDegraded":              hivev1.FailureCategoryCloudOutage,
+	"GeneralClusterOperatorsStillUpdating": hivev1.FailureCategoryCloudOutage,
+	"TaggingFailed":                        hivev1.FailureCategoryCloudOutage,
+
+	// Failures of Hive, and failures whose cause is not known.
+	"UnknownError":                   hivev1.FailureCategoryHiveInternal,
+	"InstallTimedOut":                hivev1.FailureCategoryHiveInternal,
+	"StageTimeout_Initializing":      hivev1.FailureCategoryHiveInternal,
+	"StageTimeout_Provisioning":      hivev1.FailureCategoryHiveInternal,
+	"JobNotFound":                    hivev1.FailureCategoryHiveInternal,
+	"SchedulingStuck":                hivev1.FailureCategoryHiveInternal,
+	"InstallerImageResolutionFailed": hivev1.FailureCategoryHiveInternal,
+	"BackupFailed":                   hivev1.FailureCategoryHiveInternal,
+	"InstallFailureBudgetExceeded":   hivev1.FailureCategoryHiveInternal,
+	"BudgetExceeded":                 hivev1.FailureCategoryHiveInternal,
+	"AssignmentConflict":             hivev1.FailureCategoryHiveInternal,
+}
+
+// FailureCategory returns the category of the failure with the given condition reason, or an empty category when the
+// reason is not a known failure.
+func FailureCategory(reason string) hivev1.FailureCategory {
+	return failureCategories[reason]
+}
@@ -0,0 +1,59 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/operator/assets"
+)
+
+func TestFailureCategoryOfInstallLogRegexes(t *testing.T) {
+	cm := &corev1.ConfigMap{}
+	require.NoError(t, yaml.Unmarshal(assets.MustAsset("config/configmaps/install-log-regexes-configmap.yaml"), cm))
+	regexes := []struct {
+		Name                 string `json:"name"`
+		InstallFailingReason string `json:"installFailingReason"`
+	}{}
+	require.NoError(t, yaml.Unmarshal([]byte(cm.Data["regexes"]), &regexes))
+	require.NotEmpty(t, regexes)
+	for _, r := range regexes {
+		assert.NotEmpty(t, FailureCategory(r.InstallFailingReason), "no failure category for the reason of install log regex %s", r.Name)
+	}
+}
+
+func TestSetConditionFailureCategory(t *testing.T) {
+	conds := SetClusterDeploymentCondition(nil, hivev1.AuthenticationFailureClusterDeploymentCondition, corev1.ConditionTrue,
+		"PlatformAuthError", "bad credentials", UpdateConditionIfReasonOrMessageChange)
+	require.Len(t, conds, 1)
+	assert.Equal(t, hivev1.FailureCategoryAuthn, conds[0].Category, "unexpected category of failed condition")
+
+	conds = SetClusterDeploymentCondition(conds, hivev1.AuthenticationFailureClusterDeploymentCondition, corev1.ConditionFalse,
+		"PlatformAuthSuccess", "good credentials", UpdateConditionIfReasonOrMessageChange)
+	assert.Empty(t, conds[0].Category, "expected category to be cleared once the failure is resolved")
+
+	provisionConds := SetClusterProvisionCondition(nil, hivev1.ClusterProvisionFailedCondition, corev1.ConditionTrue,
+		"AWSVPCLimitExceeded", "too many VPCs", UpdateConditionAlways)
+	require.Len(t, provisionConds, 1)
+	assert.Equal(t, hivev1.FailureCategoryQuota, provisionConds[0].Category, "unexpected category of failed provision")
+
+	poolConds, _ := SetClusterPoolConditionWithChangeCheck(nil, hivev1.ClusterPoolMissingDependenciesCondition, corev1.ConditionTrue,
+		"Missing", "secret not found", UpdateConditionIfReasonOrMessageChange)
+	require.Len(t, poolConds, 1)
+	assert.Equal(t, hivev1.FailureCategoryUserConfig, poolConds[0].Category, "unexpected category of failed pool")
+
+	claimConds, _ := SetClusterClaimConditionWithChangeCheck(nil, hivev1.ClusterClaimPendingCondition, corev1.ConditionTrue,
+		ClaimQuotaExceededReason, "too many claims", UpdateConditionIfReasonOrMessageChange)
+	require.Len(t, claimConds, 1)
+	assert.Equal(t, hivev1.FailureCategoryQuota, claimConds[0].Category, "unexpected category of failed claim")
+
+	machinePoolConds, _ := SetMachinePoolConditionWithChangeCheck(nil, hivev1.InvalidSubnetsMachinePoolCondition, corev1.ConditionTrue,
+		"SubnetsNotFound", "subnets not found", UpdateConditionIfReasonOrMessageChange)
+	require.Len(t, machinePoolConds, 1)
+	assert.Equal(t, hivev1.FailureCategoryUserConfig, machinePoolConds[0].Category, "unexpected category of failed machine pool")
+}
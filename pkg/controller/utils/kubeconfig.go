@@ -0,0 +1,105 @@
+package utils
+
+import (
+	"net"
+	"net/url"
+	"os"
+	"strings"
+
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	clientcmdv1 "k8s.io/client-go/tools/clientcmd/api/v1"
+	"sigs.k8s.io/yaml"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+// AddInternalKubeconfigContext adds a context for the internal API URL of the cluster to a given admin kubeconfig.
+// The internal context uses the user of the current context with a copy of its cluster whose server is on the api-int
+// host name of the cluster. The kubeconfig is returned unchanged when the server of the current context is not on the
+// api host name of the cluster. The current context of the kubeconfig is not changed.
+func AddInternalKubeconfigContext(data []byte) ([]byte, error) {
+	cfg := &clientcmdv1.Config{}
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, err
+	}
+	var current *clientcmdv1.Context
+	for i := range cfg.Contexts {
+		if cfg.Contexts[i].Name == cfg.CurrentContext {
+			current = &cfg.Contexts[i].Context
+		}
+	}
+	if current == nil {
+		return data, nil
+	}
+	var cluster *clientcmdv1.Cluster
+	for i := range cfg.Clusters {
+		if cfg.Clusters[i].Name == current.Cluster {
+			cluster = &cfg.Clusters[i].Cluster
+		}
+	}
+	if cluster == nil {
+		return data, nil
+	}
+	internalServer, ok := internalAPIURL(cluster.Server)
+	if !ok {
+		return data, nil
+	}
+	internalClusterName := current.Cluster + "-internal"
+	internalCluster := *cluster.DeepCopy()
+	internalCluster.Server = internalServer
+	internalContext := *current.DeepCopy()
+	internalContext.Cluster = internalClusterName
+
+	clusters := []clientcmdv1.NamedCluster{}
+	for _, c := range cfg.Clusters {
+		if c.Name != internalClusterName {
+			clusters = append(clusters, c)
+		}
+	}
+	cfg.Clusters = append(clusters, clientcmdv1.NamedCluster{Name: internalClusterName, Cluster: internalCluster})
+	contexts := []clientcmdv1.NamedContext{}
+	for _, c := range cfg.Contexts {
+		if c.Name != constants.AdminKubeconfigInternalContextName {
+			contexts = append(contexts, c)
+		}
+	}
+	cfg.Contexts = append(contexts, clientcmdv1.NamedContext{
+		Name:    constants.AdminKubeconfigInternalContextName,
+		Context: internalContext,
+	})
+	return yaml.Marshal(cfg)
+}
+
+// internalAPIURL returns the internal API URL of the cluster for a given API URL on the api host name of the cluster.
+func internalAPIURL(server string) (string, bool) {
+	u, err := url.Parse(server)
+	if err != nil {
+		return "", false
+	}
+	host, port := u.Host, ""
+	if h, p, err := net.SplitHostPort(u.Host); err == nil {
+		host, port = h, p
+	}
+	if !strings.HasPrefix(host, "api.") {
+		return "", false
+	}
+	host = "api-int." + strings.TrimPrefix(host, "api.")
+	if port != "" {
+		host = net.JoinHostPort(host, port)
+	}
+	u.Host = host
+	return u.String(), true
+}
+
+// AdminKubeconfigContext returns the name and the type of the context of a given admin kubeconfig with which the
+// controllers connect to the cluster. The internal context is used when it is configured for the controllers and the
+// kubeconfig has it, otherwise the current context of the kubeconfig is used.
+func AdminKubeconfigContext(cfg *clientcmdapi.Config) (string, hivev1.AdminKubeconfigContextType) {
+	if os.Getenv(constants.AdminKubeconfigContextEnvVar) == string(hivev1.AdminKubeconfigContextInternal) {
+		if _, ok := cfg.Contexts[constants.AdminKubeconfigInternalContextName]; ok {
+			return constants.AdminKubeconfigInternalContextName, hivev1.AdminKubeconfigContextInternal
+		}
+	}
+	return cfg.CurrentContext, hivev1.AdminKubeconfigContextExternal
+}
@@ -0,0 +1,124 @@
+package utils
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/tools/clientcmd"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+const testAdminKubeconfig = `apiVersion: v1
+clusters:
+- cluster:
+    certificate-authority-data: SlVOSw==
+    server: https://api.test-cluster.example.com:6443
+  name: test-cluster
+contexts:
+- context:
+    cluster: test-cluster
+    user: admin
+  name: admin
+current-context: admin
+kind: Config
+users:
+- name: admin
+  user:
+    token: test-token
+`
+
+func TestAddInternalKubeconfigContext(t *testing.T) {
+	cases := []struct {
+		name                   string
+		server                 string
+		expectedInternalServer string
+	}{
+		{
+			name:                   "api host name",
+			server:                 "https://api.test-cluster.example.com:6443",
+			expectedInternalServer: "https://api-int.test-cluster.example.com:6443",
+		},
+		{
+			name:                   "api host name without port",
+			server:                 "https://api.test-cluster.example.com",
+			expectedInternalServer: "https://api-int.test-cluster.example.com",
+		},
+		{
+			name:   "other host name",
+			server: "https://test-cluster-api.example.com:6443",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			kubeconfig := strings.Replace(testAdminKubeconfig, "https://api.test-cluster.example.com:6443", tc.server, 1)
+			data, err := AddInternalKubeconfigContext([]byte(kubeconfig))
+			require.NoError(t, err)
+			cfg, err := clientcmd.Load(data)
+			require.NoError(t, err)
+			assert.Equal(t, "admin", cfg.CurrentContext, "unexpected current context")
+			assert.Equal(t, tc.server, cfg.Clusters["test-cluster"].Server, "unexpected server")
+			internalContext := cfg.Contexts[constants.AdminKubeconfigInternalContextName]
+			if tc.expectedInternalServer == "" {
+				assert.Nil(t, internalContext, "unexpected internal context")
+				return
+			}
+			require.NotNil(t, internalContext, "missing internal context")
+			assert.Equal(t, "admin", internalContext.AuthInfo, "unexpected internal context user")
+			internalCluster := cfg.Clusters[internalContext.Cluster]
+			require.NotNil(t, internalCluster, "missing internal cluster")
+			assert.Equal(t, tc.expectedInternalServer, internalCluster.Server, "unexpected internal server")
+			assert.Equal(t, []byte("JUNK"), internalCluster.CertificateAuthorityData, "unexpected internal CA")
+		})
+	}
+}
+
+func TestAdminKubeconfigContext(t *testing.T) {
+	withInternal, err := AddInternalKubeconfigContext([]byte(testAdminKubeconfig))
+	require.NoError(t, err)
+	cases := []struct {
+		name            string
+		kubeconfig      string
+		internal        bool
+		expectedContext string
+		expectedType    hivev1.AdminKubeconfigContextType
+	}{
+		{
+			name:            "external",
+			kubeconfig:      string(withInternal),
+			expectedContext: "admin",
+			expectedType:    hivev1.AdminKubeconfigContextExternal,
+		},
+		{
+			name:            "internal",
+			kubeconfig:      string(withInternal),
+			internal:        true,
+			expectedContext: constants.AdminKubeconfigInternalContextName,
+			expectedType:    hivev1.AdminKubeconfigContextInternal,
+		},
+		{
+			name:            "internal without internal context",
+			kubeconfig:      testAdminKubeconfig,
+			internal:        true,
+			expectedContext: "admin",
+			expectedType:    hivev1.AdminKubeconfigContextExternal,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.internal {
+				os.Setenv(constants.AdminKubeconfigContextEnvVar, string(hivev1.AdminKubeconfigContextInternal))
+				defer os.Unsetenv(constants.AdminKubeconfigContextEnvVar)
+			}
+			cfg, err := clientcmd.Load([]byte(tc.kubeconfig))
+			require.NoError(t, err)
+			contextName, contextType := AdminKubeconfigContext(cfg)
+			assert.Equal(t, tc.expectedContext, contextName, "unexpected context name")
+			assert.Equal(t, tc.expectedType, contextType, "unexpected context type")
+		})
+	}
+}
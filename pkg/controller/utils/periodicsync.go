@@ -0,0 +1,55 @@
+package utils
+
+import (
+	"hash/fnv"
+	"math"
+	"os"
+	"strconv"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/openshift/hive/pkg/constants"
+)
+
+// DefaultPeriodicSyncJitterPercent is the default maximum jitter of the periodic syncs of each cluster, as a
+// percentage of their base interval.
+const DefaultPeriodicSyncJitterPercent = 10
+
+// PeriodicSyncIntervalFromEnv returns the base interval of a periodic sync set in the given environment variable, or
+// the default interval when the environment variable is unset or invalid.
+func PeriodicSyncIntervalFromEnv(envVar string, defaultInterval time.Duration) time.Duration {
+	if interval := os.Getenv(envVar); interval != "" {
+		d, err := time.ParseDuration(interval)
+		if err == nil && d > 0 {
+			return d
+		}
+		log.WithError(err).WithField(envVar, interval).Warn("invalid periodic sync interval, using default")
+	}
+	return defaultInterval
+}
+
+// PeriodicSyncJitterPercentFromEnv returns the maximum jitter of the periodic syncs of each cluster, as a percentage
+// of their base interval.
+func PeriodicSyncJitterPercentFromEnv() int {
+	if percent := os.Getenv(constants.PeriodicSyncJitterPercentEnvVar); percent != "" {
+		p, err := strconv.Atoi(percent)
+		if err == nil && p >= 0 && p <= 100 {
+			return p
+		}
+		log.WithError(err).WithField("jitterPercent", percent).Warn("invalid periodic sync jitter percentage, using default")
+	}
+	return DefaultPeriodicSyncJitterPercent
+}
+
+// JitteredInterval extends the base interval of a periodic sync by a jitter of up to jitterPercent percent of the base
+// interval. The jitter is derived from the given cluster, so that each cluster keeps a stable schedule while the
+// schedules of all the clusters are spread out across the jitter window rather than aligned.
+func JitteredInterval(base time.Duration, jitterPercent int, cluster types.NamespacedName) time.Duration {
+	h := fnv.New32a()
+	h.Write([]byte(cluster.String()))
+	fraction := float64(h.Sum32()) / math.MaxUint32
+	return base + time.Duration(float64(base)*float64(jitterPercent)/100*fraction)
+}
@@ -0,0 +1,49 @@
+package utils
+
+import (
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/openshift/hive/pkg/constants"
+)
+
+func TestJitteredInterval(t *testing.T) {
+	base := 2 * time.Hour
+	cluster := types.NamespacedName{Namespace: "test-namespace", Name: "test-cluster"}
+
+	assert.Equal(t, base, JitteredInterval(base, 0, cluster), "expected no jitter")
+	assert.Equal(t, JitteredInterval(base, 10, cluster), JitteredInterval(base, 10, cluster), "expected a stable interval for the cluster")
+
+	intervals := map[time.Duration]bool{}
+	for i := 0; i < 20; i++ {
+		interval := JitteredInterval(base, 10, types.NamespacedName{Namespace: "test-namespace", Name: fmt.Sprintf("cluster-%d", i)})
+		assert.GreaterOrEqual(t, int64(interval), int64(base), "interval shorter than base interval")
+		assert.LessOrEqual(t, int64(interval), int64(base+12*time.Minute), "interval longer than jitter window")
+		intervals[interval] = true
+	}
+	assert.Greater(t, len(intervals), 1, "expected intervals to be spread out")
+}
+
+func TestPeriodicSyncFromEnv(t *testing.T) {
+	defer os.Unsetenv(constants.ClusterStatePollIntervalEnvVar)
+	defer os.Unsetenv(constants.PeriodicSyncJitterPercentEnvVar)
+
+	assert.Equal(t, time.Minute, PeriodicSyncIntervalFromEnv(constants.ClusterStatePollIntervalEnvVar, time.Minute))
+	assert.Equal(t, DefaultPeriodicSyncJitterPercent, PeriodicSyncJitterPercentFromEnv())
+
+	os.Setenv(constants.ClusterStatePollIntervalEnvVar, "20m")
+	os.Setenv(constants.PeriodicSyncJitterPercentEnvVar, "25")
+	assert.Equal(t, 20*time.Minute, PeriodicSyncIntervalFromEnv(constants.ClusterStatePollIntervalEnvVar, time.Minute))
+	assert.Equal(t, 25, PeriodicSyncJitterPercentFromEnv())
+
+	os.Setenv(constants.ClusterStatePollIntervalEnvVar, "-20m")
+	os.Setenv(constants.PeriodicSyncJitterPercentEnvVar, "250")
+	assert.Equal(t, time.Minute, PeriodicSyncIntervalFromEnv(constants.ClusterStatePollIntervalEnvVar, time.Minute))
+	assert.Equal(t, DefaultPeriodicSyncJitterPercent, PeriodicSyncJitterPercentFromEnv())
+}
@@ -0,0 +1,153 @@
+package utils
+
+import (
+	"bytes"
+	"context"
+	"io"
+
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+// PodLogTailer reads the end of the logs of the containers of pods.
+type PodLogTailer interface {
+	// TailLogs returns at most the last maxBytes bytes of the logs of the container of the pod.
+	TailLogs(namespace, pod, container string, maxBytes int) (string, error)
+}
+
+// NewPodLogTailer returns a PodLogTailer reading the logs of pods through the kube API.
+func NewPodLogTailer(kubeClient kubernetes.Interface) PodLogTailer {
+	return &podLogTailer{pods: kubeClient.CoreV1()}
+}
+
+type podLogTailer struct {
+	pods corev1client.PodsGetter
+}
+
+func (t *podLogTailer) TailLogs(namespace, pod, container string, maxBytes int) (string, error) {
+	stream, err := t.pods.Pods(namespace).GetLogs(pod, &corev1.PodLogOptions{Container: container}).Stream(context.TODO())
+	if err != nil {
+		return "", err
+	}
+	defer stream.Close()
+	return tailBytes(stream, maxBytes)
+}
+
+// tailBytes reads r to the end, and returns at most its last maxBytes bytes. When the content is cut, the first
+// partial line is dropped.
+func tailBytes(r io.Reader, maxBytes int) (string, error) {
+	var tail []byte
+	chunk := make([]byte, 32*1024)
+	truncated := false
+	for {
+		n, err := r.Read(chunk)
+		tail = append(tail, chunk[:n]...)
+		if len(tail) > 2*maxBytes {
+			tail = append([]byte(nil), tail[len(tail)-maxBytes:]...)
+			truncated = true
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+	if len(tail) > maxBytes {
+		tail = tail[len(tail)-maxBytes:]
+		truncated = true
+	}
+	if truncated {
+		if i := bytes.IndexByte(tail, '\n'); i >= 0 {
+			tail = tail[i+1:]
+		}
+	}
+	return string(tail), nil
+}
+
+// PodLogSnapshotName returns the name of the ConfigMap holding the snapshot of the logs of the pods of the owner.
+func PodLogSnapshotName(ownerName string) string {
+	return ownerName + "-pod-logs"
+}
+
+// SnapshotPodLogs stores the end of the logs of the containers of the pod in a ConfigMap controlled by the owner, with
+// one key per container. At most maxBytes of logs are kept, shared evenly between the containers. The snapshot replaces
+// the snapshot of any previous pod of the owner, but is not taken again for the same pod.
+func SnapshotPodLogs(
+	c client.Client,
+	tailer PodLogTailer,
+	owner hivev1.MetaRuntimeObject,
+	pod *corev1.Pod,
+	maxBytes int,
+	scheme *runtime.Scheme,
+	logger log.FieldLogger,
+) (*corev1.LocalObjectReference, error) {
+	ref := &corev1.LocalObjectReference{Name: PodLogSnapshotName(owner.GetName())}
+	logger = logger.WithField("pod", pod.Name).WithField("configMap", ref.Name)
+
+	existing := &corev1.ConfigMap{}
+	switch err := c.Get(context.TODO(), types.NamespacedName{Namespace: owner.GetNamespace(), Name: ref.Name}, existing); {
+	case apierrors.IsNotFound(err):
+		existing = nil
+	case err != nil:
+		logger.WithError(err).Log(LogLevel(err), "could not get pod log snapshot")
+		return nil, err
+	case existing.Annotations[constants.PodLogSnapshotPodAnnotation] == pod.Name:
+		return ref, nil
+	}
+
+	data := map[string]string{}
+	if len(pod.Spec.Containers) > 0 {
+		containerMaxBytes := maxBytes / len(pod.Spec.Containers)
+		for _, container := range pod.Spec.Containers {
+			logs, err := tailer.TailLogs(pod.Namespace, pod.Name, container.Name, containerMaxBytes)
+			if err != nil {
+				logger.WithError(err).WithField("container", container.Name).Warn("could not read container logs")
+				return nil, err
+			}
+			data[container.Name+".log"] = logs
+		}
+	}
+
+	if existing != nil {
+		if existing.Annotations == nil {
+			existing.Annotations = map[string]string{}
+		}
+		existing.Annotations[constants.PodLogSnapshotPodAnnotation] = pod.Name
+		existing.Data = data
+		if err := c.Update(context.TODO(), existing); err != nil {
+			logger.WithError(err).Log(LogLevel(err), "could not update pod log snapshot")
+			return nil, err
+		}
+		logger.Info("updated pod log snapshot")
+		return ref, nil
+	}
+
+	cm := &corev1.ConfigMap{}
+	cm.Namespace = owner.GetNamespace()
+	cm.Name = ref.Name
+	cm.Labels = map[string]string{constants.PodLogSnapshotLabel: "true"}
+	cm.Annotations = map[string]string{constants.PodLogSnapshotPodAnnotation: pod.Name}
+	cm.Data = data
+	if err := controllerutil.SetControllerReference(owner, cm, scheme); err != nil {
+		logger.WithError(err).Error("could not set controller reference on pod log snapshot")
+		return nil, err
+	}
+	if err := c.Create(context.TODO(), cm); err != nil {
+		logger.WithError(err).Log(LogLevel(err), "could not create pod log snapshot")
+		return nil, err
+	}
+	logger.Info("created pod log snapshot")
+	return ref, nil
+}
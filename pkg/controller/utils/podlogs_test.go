@@ -0,0 +1,179 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+func TestTailBytes(t *testing.T) {
+	cases := []struct {
+		name     string
+		content  string
+		maxBytes int
+		expected string
+	}{
+		{
+			name:     "short",
+			content:  "line 1\nline 2\n",
+			maxBytes: 100,
+			expected: "line 1\nline 2\n",
+		},
+		{
+			name:     "cut",
+			content:  "line 1\nline 2\nline 3\n",
+			maxBytes: 10,
+			expected: "line 3\n",
+		},
+		{
+			name:     "long",
+			content:  strings.Repeat("0123456789\n", 100000),
+			maxBytes: 25,
+			expected: "0123456789\n0123456789\n",
+		},
+		{
+			name:     "no newline",
+			content:  "0123456789",
+			maxBytes: 4,
+			expected: "6789",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := tailBytes(strings.NewReader(tc.content), tc.maxBytes)
+			require.NoError(t, err, "unexpected error")
+			assert.Equal(t, tc.expected, actual, "unexpected tail")
+		})
+	}
+}
+
+func TestSnapshotPodLogs(t *testing.T) {
+	hivev1.AddToScheme(scheme.Scheme)
+	cases := []struct {
+		name         string
+		existing     []runtime.Object
+		tailerErr    error
+		expectErr    bool
+		expectedData map[string]string
+	}{
+		{
+			name: "new snapshot",
+			expectedData: map[string]string{
+				"main.log":    "test-pod/main/50",
+				"sidecar.log": "test-pod/sidecar/50",
+			},
+		},
+		{
+			name: "replace snapshot of previous pod",
+			existing: []runtime.Object{
+				testPodLogSnapshot("previous-pod"),
+			},
+			expectedData: map[string]string{
+				"main.log":    "test-pod/main/50",
+				"sidecar.log": "test-pod/sidecar/50",
+			},
+		},
+		{
+			name: "snapshot of same pod kept",
+			existing: []runtime.Object{
+				testPodLogSnapshot("test-pod"),
+			},
+			tailerErr: errors.New("logs should not be read again"),
+			expectedData: map[string]string{
+				"main.log": "previous logs",
+			},
+		},
+		{
+			name:      "logs unavailable",
+			tailerErr: errors.New("pod not found"),
+			expectErr: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			owner := &hivev1.ClusterProvision{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: testNamespace,
+					Name:      "test-provision",
+				},
+			}
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: testNamespace,
+					Name:      "test-pod",
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "main"}, {Name: "sidecar"}},
+				},
+			}
+			c := fake.NewFakeClientWithScheme(scheme.Scheme, tc.existing...)
+			ref, err := SnapshotPodLogs(c, &fakePodLogTailer{err: tc.tailerErr}, owner, pod, 100, scheme.Scheme, log.StandardLogger())
+			if tc.expectErr {
+				assert.Error(t, err, "expected error")
+				return
+			}
+			require.NoError(t, err, "unexpected error")
+			require.NotNil(t, ref, "expected reference to snapshot")
+			cm := &corev1.ConfigMap{}
+			require.NoError(t, c.Get(context.TODO(), client.ObjectKey{Namespace: testNamespace, Name: ref.Name}, cm), "could not get snapshot")
+			assert.Equal(t, "test-provision-pod-logs", cm.Name, "unexpected snapshot name")
+			assert.Equal(t, tc.expectedData, cm.Data, "unexpected snapshot data")
+			assert.Equal(t, "test-pod", cm.Annotations[constants.PodLogSnapshotPodAnnotation], "unexpected snapshot pod")
+			if owner := metav1.GetControllerOf(cm); assert.NotNil(t, owner, "expected snapshot owner") {
+				assert.Equal(t, "test-provision", owner.Name, "unexpected snapshot owner")
+			}
+		})
+	}
+}
+
+func testPodLogSnapshot(podName string) *corev1.ConfigMap {
+	owner := &hivev1.ClusterProvision{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNamespace,
+			Name:      "test-provision",
+		},
+	}
+	isController := true
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   testNamespace,
+			Name:        PodLogSnapshotName(owner.Name),
+			Annotations: map[string]string{constants.PodLogSnapshotPodAnnotation: podName},
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: hivev1.SchemeGroupVersion.String(),
+				Kind:       "ClusterProvision",
+				Name:       owner.Name,
+				Controller: &isController,
+			}},
+		},
+		Data: map[string]string{"main.log": "previous logs"},
+	}
+}
+
+type fakePodLogTailer struct {
+	err error
+}
+
+func (t *fakePodLogTailer) TailLogs(namespace, pod, container string, maxBytes int) (string, error) {
+	if t.err != nil {
+		return "", t.err
+	}
+	return strings.Join([]string{pod, container, strconv.Itoa(maxBytes)}, "/"), nil
+}
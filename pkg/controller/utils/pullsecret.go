@@ -0,0 +1,86 @@
+package utils
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+// PullSecretLayer is one of the pull secrets merged into the pull secret of a cluster.
+type PullSecretLayer struct {
+	// Name describes the layer in logs and errors, such as "global pull secret".
+	Name string
+	// PullSecret is the docker config JSON of the layer.
+	PullSecret string
+}
+
+// ValidatePullSecret checks that the pull secret is a docker config JSON object whose auths are objects keyed by
+// registry, and returns its auths.
+func ValidatePullSecret(pullSecret string) (map[string]interface{}, error) {
+	config := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(pullSecret), &config); err != nil {
+		return nil, errors.Wrap(err, "invalid JSON")
+	}
+	rawAuths, ok := config["auths"]
+	if !ok || rawAuths == nil {
+		return map[string]interface{}{}, nil
+	}
+	auths, ok := rawAuths.(map[string]interface{})
+	if !ok {
+		return nil, errors.New("auths is not an object")
+	}
+	for registry, auth := range auths {
+		if registry == "" {
+			return nil, errors.New("auths has an empty registry")
+		}
+		if _, ok := auth.(map[string]interface{}); !ok {
+			return nil, errors.Errorf("auth of registry %s is not an object", registry)
+		}
+	}
+	return auths, nil
+}
+
+// MergePullSecretLayers merges the auths of the layers, ordered from the least to the most specific, into a single
+// pull secret. Registries with different auths in several layers are resolved according to the policy. A single
+// layer is returned unchanged once validated.
+func MergePullSecretLayers(layers []PullSecretLayer, policy hivev1.PullSecretConflictPolicy, logger log.FieldLogger) (string, error) {
+	merged := map[string]interface{}{}
+	owners := map[string]string{}
+	for _, layer := range layers {
+		auths, err := ValidatePullSecret(layer.PullSecret)
+		if err != nil {
+			return "", errors.Wrapf(err, "%s is not a valid pull secret", layer.Name)
+		}
+		for registry, auth := range auths {
+			owner, conflict := owners[registry]
+			if conflict && reflect.DeepEqual(merged[registry], auth) {
+				continue
+			}
+			if conflict {
+				switch policy {
+				case hivev1.PullSecretConflictPolicyReject:
+					return "", errors.Errorf("%s and %s have different auths for registry %s", owner, layer.Name, registry)
+				case hivev1.PullSecretConflictPolicyPreferGlobal:
+					logger.Infof("The auth for %s from the %s is used instead of the %s", registry, owner, layer.Name)
+					continue
+				default:
+					logger.Infof("The auth for %s from the %s is used instead of the %s", registry, layer.Name, owner)
+				}
+			}
+			merged[registry] = auth
+			owners[registry] = layer.Name
+		}
+	}
+	if len(layers) == 1 {
+		return layers[0].PullSecret, nil
+	}
+	pullSecret, err := json.Marshal(map[string]interface{}{"auths": merged})
+	if err != nil {
+		return "", errors.Wrap(err, "could not marshal merged pull secret")
+	}
+	return string(pullSecret), nil
+}
@@ -17,7 +17,7 @@ import (
 
 const (
 	// ServiceAccountName will be a service account that can run the installer and then
-	// upload artifacts to the cluster's namespace.
+	// upload artifacts to the cluster's namespace, and that can report the progress of the uninstaller.
 	ServiceAccountName = "cluster-installer"
 	roleName           = "cluster-installer"
 	roleBindingName    = "cluster-installer"
@@ -45,6 +45,11 @@ var (
 			Resources: []string{"clusterprovisions", "clusterprovisions/finalizers", "clusterprovisions/status"},
 			Verbs:     []string{"get", "list", "update", "watch"},
 		},
+		{
+			APIGroups: []string{"hive.openshift.io"},
+			Resources: []string{"clusterdeprovisions/status"},
+			Verbs:     []string{"patch"},
+		},
 	}
 )
 
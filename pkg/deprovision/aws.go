@@ -0,0 +1,176 @@
+package deprovision
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/elb"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/openshift/hive/pkg/awsclient"
+)
+
+// The types of the AWS resources deleted in parallel, named after the service and the resource type of their ARNs.
+// These are the resources of which large clusters have the most, while the networking resources which they live in
+// are left to the destroyer of the installer.
+const (
+	awsInstanceType     = "ec2:instance"
+	awsVolumeType       = "ec2:volume"
+	awsNATGatewayType   = "ec2:natgateway"
+	awsElasticIPType    = "ec2:elastic-ip"
+	awsLoadBalancerType = "elasticloadbalancing:loadbalancer"
+	awsTargetGroupType  = "elasticloadbalancing:targetgroup"
+)
+
+// NewAWSParallelDestroyer returns a ParallelDestroyer which deletes the instances, volumes, NAT gateways, elastic IPs,
+// load balancers and target groups of an AWS cluster in parallel before running the sweep. The resources of the
+// cluster are those whose tags match any of the filters, as found through the tagging API of the region of the client.
+func NewAWSParallelDestroyer(awsClient awsclient.Client, filters []map[string]string, sweep func(ctx context.Context) error, logger log.FieldLogger) *ParallelDestroyer {
+	return &ParallelDestroyer{
+		Types: []ResourceType{
+			{
+				Name:   awsInstanceType,
+				Delete: awsDeleter(awsClient, terminateAWSInstance),
+			},
+			{
+				Name:      awsVolumeType,
+				DependsOn: []string{awsInstanceType},
+				Delete:    awsDeleter(awsClient, deleteAWSVolume),
+			},
+			{
+				Name:   awsNATGatewayType,
+				Delete: awsDeleter(awsClient, deleteAWSNATGateway),
+			},
+			{
+				Name:      awsElasticIPType,
+				DependsOn: []string{awsInstanceType, awsNATGatewayType},
+				Delete:    awsDeleter(awsClient, releaseAWSElasticIP),
+			},
+			{
+				Name:   awsLoadBalancerType,
+				Delete: awsDeleter(awsClient, deleteAWSLoadBalancer),
+			},
+			{
+				Name:      awsTargetGroupType,
+				DependsOn: []string{awsLoadBalancerType},
+				Delete:    awsDeleter(awsClient, deleteAWSTargetGroup),
+			},
+		},
+		List:   awsResourceLister(awsClient, filters),
+		Sweep:  sweep,
+		Logger: logger,
+	}
+}
+
+// awsResourceLister lists the ARNs of the resources whose tags match any of the filters, by type of resource.
+func awsResourceLister(awsClient awsclient.Client, filters []map[string]string) ResourceLister {
+	return func() (map[string][]string, error) {
+		arns := sets.NewString()
+		for _, filter := range filters {
+			input := &resourcegroupstaggingapi.GetResourcesInput{}
+			for key, value := range filter {
+				input.TagFilters = append(input.TagFilters, &resourcegroupstaggingapi.TagFilter{
+					Key:    aws.String(key),
+					Values: aws.StringSlice([]string{value}),
+				})
+			}
+			if err := awsClient.GetResourcesPages(input, func(page *resourcegroupstaggingapi.GetResourcesOutput, lastPage bool) bool {
+				for _, resource := range page.ResourceTagMappingList {
+					arns.Insert(aws.StringValue(resource.ResourceARN))
+				}
+				return !lastPage
+			}); err != nil {
+				return nil, err
+			}
+		}
+		remaining := map[string][]string{}
+		for _, a := range arns.List() {
+			parsed, err := arn.Parse(a)
+			if err != nil {
+				continue
+			}
+			t := awsResourceType(parsed)
+			remaining[t] = append(remaining[t], a)
+		}
+		return remaining, nil
+	}
+}
+
+// awsResourceType returns the type of the resource of an ARN, such as ec2:instance for
+// arn:aws:ec2:us-east-1:123456789012:instance/i-0123456789abcdef0.
+func awsResourceType(a arn.ARN) string {
+	resourceType := a.Resource
+	if i := strings.IndexAny(resourceType, "/:"); i >= 0 {
+		resourceType = resourceType[:i]
+	}
+	return a.Service + ":" + resourceType
+}
+
+// awsResourceID returns the ID of the resource of an ARN, which follows its type.
+func awsResourceID(a arn.ARN) string {
+	if i := strings.IndexAny(a.Resource, "/:"); i >= 0 {
+		return a.Resource[i+1:]
+	}
+	return a.Resource
+}
+
+func awsDeleter(awsClient awsclient.Client, deleteFunc func(awsclient.Client, arn.ARN) error) func(string) error {
+	return func(id string) error {
+		parsed, err := arn.Parse(id)
+		if err != nil {
+			return err
+		}
+		return deleteFunc(awsClient, parsed)
+	}
+}
+
+func terminateAWSInstance(awsClient awsclient.Client, a arn.ARN) error {
+	_, err := awsClient.TerminateInstances(&ec2.TerminateInstancesInput{
+		InstanceIds: aws.StringSlice([]string{awsResourceID(a)}),
+	})
+	return err
+}
+
+func deleteAWSVolume(awsClient awsclient.Client, a arn.ARN) error {
+	_, err := awsClient.DeleteVolume(&ec2.DeleteVolumeInput{VolumeId: aws.String(awsResourceID(a))})
+	return err
+}
+
+func deleteAWSNATGateway(awsClient awsclient.Client, a arn.ARN) error {
+	_, err := awsClient.DeleteNatGateway(&ec2.DeleteNatGatewayInput{NatGatewayId: aws.String(awsResourceID(a))})
+	return err
+}
+
+func releaseAWSElasticIP(awsClient awsclient.Client, a arn.ARN) error {
+	_, err := awsClient.ReleaseAddress(&ec2.ReleaseAddressInput{AllocationId: aws.String(awsResourceID(a))})
+	return err
+}
+
+// deleteAWSLoadBalancer deletes either a classic load balancer, whose ARN ends with loadbalancer/NAME, or a network or
+// application load balancer, whose ARN ends with loadbalancer/net/NAME/ID or loadbalancer/app/NAME/ID.
+func deleteAWSLoadBalancer(awsClient awsclient.Client, a arn.ARN) error {
+	name := awsResourceID(a)
+	if !strings.Contains(name, "/") {
+		_, err := awsClient.DeleteClassicLoadBalancer(&elb.DeleteLoadBalancerInput{LoadBalancerName: aws.String(name)})
+		return err
+	}
+	if !strings.HasPrefix(name, "net/") && !strings.HasPrefix(name, "app/") {
+		return fmt.Errorf("unsupported load balancer %s", a)
+	}
+	_, err := awsClient.DeleteLoadBalancer(&elbv2.DeleteLoadBalancerInput{LoadBalancerArn: aws.String(a.String())})
+	return err
+}
+
+func deleteAWSTargetGroup(awsClient awsclient.Client, a arn.ARN) error {
+	_, err := awsClient.DeleteTargetGroup(&elbv2.DeleteTargetGroupInput{TargetGroupArn: aws.String(a.String())})
+	return err
+}
@@ -0,0 +1,194 @@
+package deprovision
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/s3"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/openshift/hive/pkg/awsclient"
+)
+
+const (
+	// awsSTSOwnedTagValue is the value of the kubernetes.io/cluster/<name> tag of the resources created by ccoctl.
+	awsSTSOwnedTagValue = "owned"
+
+	// awsMaxDeletedObjects is the maximum number of objects deleted by a single DeleteObjects request.
+	awsMaxDeletedObjects = 1000
+)
+
+// DeleteAWSSTSResources deletes the IAM roles, the OIDC provider and the S3 bucket which ccoctl creates for the AWS
+// Security Token Service with the given name, the infra ID of the cluster. The roles are those named after the name
+// and tagged as owned by it, the OIDC provider is the one federated by the roles or served from the bucket, and the
+// bucket is the <name>-oidc bucket. Resources which are already gone are skipped, so the deletion can be retried.
+func DeleteAWSSTSResources(awsClient awsclient.Client, name, region string, logger log.FieldLogger) error {
+	logger = logger.WithField("stsName", name)
+	providers, err := deleteAWSSTSRoles(awsClient, name, logger)
+	if err != nil {
+		return err
+	}
+	if err := deleteAWSSTSOIDCProviders(awsClient, name, region, providers, logger); err != nil {
+		return err
+	}
+	return deleteAWSSTSBucket(awsClient, name+"-oidc", logger)
+}
+
+// deleteAWSSTSRoles deletes the IAM roles created by ccoctl with their inline policies, and returns the ARNs of the
+// OIDC providers federated by their trust policies.
+func deleteAWSSTSRoles(awsClient awsclient.Client, name string, logger log.FieldLogger) (sets.String, error) {
+	var roles []*iam.Role
+	if err := awsClient.ListRolesPages(&iam.ListRolesInput{}, func(page *iam.ListRolesOutput, lastPage bool) bool {
+		for _, role := range page.Roles {
+			if strings.HasPrefix(aws.StringValue(role.RoleName), name+"-") {
+				roles = append(roles, role)
+			}
+		}
+		return !lastPage
+	}); err != nil {
+		return nil, errors.Wrap(err, "could not list IAM roles")
+	}
+
+	ownerTag := "kubernetes.io/cluster/" + name
+	providers := sets.NewString()
+	for _, role := range roles {
+		roleName := aws.StringValue(role.RoleName)
+		roleLog := logger.WithField("role", roleName)
+		tags, err := awsClient.ListRoleTags(&iam.ListRoleTagsInput{RoleName: role.RoleName})
+		if isAWSNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not list tags of IAM role %s", roleName)
+		}
+		owned := false
+		for _, tag := range tags.Tags {
+			owned = owned || aws.StringValue(tag.Key) == ownerTag && aws.StringValue(tag.Value) == awsSTSOwnedTagValue
+		}
+		if !owned {
+			roleLog.Debug("skipping IAM role which is not owned by the cluster")
+			continue
+		}
+		providers.Insert(federatedPrincipals(aws.StringValue(role.AssumeRolePolicyDocument))...)
+
+		policies, err := awsClient.ListRolePolicies(&iam.ListRolePoliciesInput{RoleName: role.RoleName})
+		if err != nil && !isAWSNotFound(err) {
+			return nil, errors.Wrapf(err, "could not list policies of IAM role %s", roleName)
+		}
+		if policies != nil {
+			for _, policy := range policies.PolicyNames {
+				if _, err := awsClient.DeleteRolePolicy(&iam.DeleteRolePolicyInput{RoleName: role.RoleName, PolicyName: policy}); err != nil && !isAWSNotFound(err) {
+					return nil, errors.Wrapf(err, "could not delete policy %s of IAM role %s", aws.StringValue(policy), roleName)
+				}
+			}
+		}
+		if _, err := awsClient.DeleteRole(&iam.DeleteRoleInput{RoleName: role.RoleName}); err != nil && !isAWSNotFound(err) {
+			return nil, errors.Wrapf(err, "could not delete IAM role %s", roleName)
+		}
+		roleLog.Info("deleted IAM role")
+	}
+	return providers, nil
+}
+
+// federatedPrincipals returns the federated principals of the URL-encoded trust policy of an IAM role.
+func federatedPrincipals(document string) []string {
+	decoded, err := url.QueryUnescape(document)
+	if err != nil {
+		return nil
+	}
+	policy := struct {
+		Statement []struct {
+			Principal struct {
+				Federated string
+			}
+		}
+	}{}
+	if err := json.Unmarshal([]byte(decoded), &policy); err != nil {
+		return nil
+	}
+	var principals []string
+	for _, statement := range policy.Statement {
+		if statement.Principal.Federated != "" {
+			principals = append(principals, statement.Principal.Federated)
+		}
+	}
+	return principals
+}
+
+// deleteAWSSTSOIDCProviders deletes the OIDC providers federated by the roles of the cluster, and the OIDC provider of
+// the issuer served from the public bucket of the cluster, which is found even once the roles are gone.
+func deleteAWSSTSOIDCProviders(awsClient awsclient.Client, name, region string, federated sets.String, logger log.FieldLogger) error {
+	providers, err := awsClient.ListOpenIDConnectProviders(&iam.ListOpenIDConnectProvidersInput{})
+	if err != nil {
+		return errors.Wrap(err, "could not list OIDC providers")
+	}
+	bucketIssuer := fmt.Sprintf("oidc-provider/%s-oidc.s3.%s.amazonaws.com", name, region)
+	for _, provider := range providers.OpenIDConnectProviderList {
+		providerARN := aws.StringValue(provider.Arn)
+		if !federated.Has(providerARN) && !strings.HasSuffix(providerARN, bucketIssuer) {
+			continue
+		}
+		if _, err := awsClient.DeleteOpenIDConnectProvider(&iam.DeleteOpenIDConnectProviderInput{OpenIDConnectProviderArn: provider.Arn}); err != nil && !isAWSNotFound(err) {
+			return errors.Wrapf(err, "could not delete OIDC provider %s", providerARN)
+		}
+		logger.WithField("oidcProvider", providerARN).Info("deleted OIDC provider")
+	}
+	return nil
+}
+
+// deleteAWSSTSBucket deletes the objects of the bucket serving the OIDC configuration of the cluster, then the bucket.
+func deleteAWSSTSBucket(awsClient awsclient.Client, bucket string, logger log.FieldLogger) error {
+	bucketLog := logger.WithField("bucket", bucket)
+	var keys []*s3.ObjectIdentifier
+	err := awsClient.ListObjectsV2Pages(&s3.ListObjectsV2Input{Bucket: aws.String(bucket)}, func(page *s3.ListObjectsV2Output, lastPage bool) bool {
+		for _, object := range page.Contents {
+			keys = append(keys, &s3.ObjectIdentifier{Key: object.Key})
+		}
+		return !lastPage
+	})
+	if isAWSNotFound(err) {
+		bucketLog.Debug("bucket is already deleted")
+		return nil
+	}
+	if err != nil {
+		return errors.Wrapf(err, "could not list objects of bucket %s", bucket)
+	}
+	for len(keys) > 0 {
+		batch := keys
+		if len(batch) > awsMaxDeletedObjects {
+			batch = batch[:awsMaxDeletedObjects]
+		}
+		keys = keys[len(batch):]
+		if _, err := awsClient.DeleteObjects(&s3.DeleteObjectsInput{
+			Bucket: aws.String(bucket),
+			Delete: &s3.Delete{Objects: batch, Quiet: aws.Bool(true)},
+		}); err != nil && !isAWSNotFound(err) {
+			return errors.Wrapf(err, "could not delete objects of bucket %s", bucket)
+		}
+	}
+	if _, err := awsClient.DeleteBucket(&s3.DeleteBucketInput{Bucket: aws.String(bucket)}); err != nil && !isAWSNotFound(err) {
+		return errors.Wrapf(err, "could not delete bucket %s", bucket)
+	}
+	bucketLog.Info("deleted bucket")
+	return nil
+}
+
+// isAWSNotFound returns whether the error is returned by AWS for an IAM entity or an S3 bucket which does not exist.
+func isAWSNotFound(err error) bool {
+	if aerr, ok := err.(awserr.Error); ok {
+		switch aerr.Code() {
+		case iam.ErrCodeNoSuchEntityException, s3.ErrCodeNoSuchBucket:
+			return true
+		}
+	}
+	return false
+}
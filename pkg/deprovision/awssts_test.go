@@ -0,0 +1,121 @@
+package deprovision
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/golang/mock/gomock"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	mockaws "github.com/openshift/hive/pkg/awsclient/mock"
+)
+
+const (
+	testSTSName          = "test-infra-id"
+	testSTSRegion        = "us-east-1"
+	testFederatedARN     = "arn:aws:iam::123456789012:oidc-provider/d123.cloudfront.net"
+	testBucketIssuerARN  = "arn:aws:iam::123456789012:oidc-provider/test-infra-id-oidc.s3.us-east-1.amazonaws.com"
+	testOtherProviderARN = "arn:aws:iam::123456789012:oidc-provider/other-oidc.s3.us-east-1.amazonaws.com"
+)
+
+func testRole(name, provider string) *iam.Role {
+	return &iam.Role{
+		RoleName: aws.String(name),
+		AssumeRolePolicyDocument: aws.String(url.QueryEscape(
+			`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":{"Federated":"` + provider + `"},"Action":"sts:AssumeRoleWithWebIdentity"}]}`)),
+	}
+}
+
+func TestDeleteAWSSTSResources(t *testing.T) {
+	ownedTags := &iam.ListRoleTagsOutput{Tags: []*iam.Tag{{Key: aws.String("kubernetes.io/cluster/test-infra-id"), Value: aws.String("owned")}}}
+	tests := []struct {
+		name      string
+		setup     func(*mockaws.MockClient)
+		expectErr bool
+	}{
+		{
+			name: "delete all resources",
+			setup: func(c *mockaws.MockClient) {
+				c.EXPECT().ListRolesPages(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ *iam.ListRolesInput, fn func(*iam.ListRolesOutput, bool) bool) error {
+						fn(&iam.ListRolesOutput{Roles: []*iam.Role{
+							testRole("test-infra-id-openshift-image-registry-installer-cloud-credentials", testFederatedARN),
+							testRole("test-infra-id-2-openshift-ingress-operator-cloud-credentials", testOtherProviderARN),
+							testRole("other-role", testOtherProviderARN),
+						}}, true)
+						return nil
+					})
+				c.EXPECT().ListRoleTags(&iam.ListRoleTagsInput{RoleName: aws.String("test-infra-id-openshift-image-registry-installer-cloud-credentials")}).Return(ownedTags, nil)
+				// A role of another cluster whose name starts with the name is not owned by the cluster.
+				c.EXPECT().ListRoleTags(&iam.ListRoleTagsInput{RoleName: aws.String("test-infra-id-2-openshift-ingress-operator-cloud-credentials")}).Return(
+					&iam.ListRoleTagsOutput{Tags: []*iam.Tag{{Key: aws.String("kubernetes.io/cluster/test-infra-id-2"), Value: aws.String("owned")}}}, nil)
+				c.EXPECT().ListRolePolicies(gomock.Any()).Return(&iam.ListRolePoliciesOutput{PolicyNames: aws.StringSlice([]string{"policy"})}, nil)
+				c.EXPECT().DeleteRolePolicy(&iam.DeleteRolePolicyInput{
+					RoleName:   aws.String("test-infra-id-openshift-image-registry-installer-cloud-credentials"),
+					PolicyName: aws.String("policy"),
+				}).Return(&iam.DeleteRolePolicyOutput{}, nil)
+				c.EXPECT().DeleteRole(&iam.DeleteRoleInput{RoleName: aws.String("test-infra-id-openshift-image-registry-installer-cloud-credentials")}).Return(&iam.DeleteRoleOutput{}, nil)
+				c.EXPECT().ListOpenIDConnectProviders(gomock.Any()).Return(&iam.ListOpenIDConnectProvidersOutput{
+					OpenIDConnectProviderList: []*iam.OpenIDConnectProviderListEntry{
+						{Arn: aws.String(testFederatedARN)},
+						{Arn: aws.String(testBucketIssuerARN)},
+						{Arn: aws.String(testOtherProviderARN)},
+					},
+				}, nil)
+				c.EXPECT().DeleteOpenIDConnectProvider(&iam.DeleteOpenIDConnectProviderInput{OpenIDConnectProviderArn: aws.String(testFederatedARN)}).Return(&iam.DeleteOpenIDConnectProviderOutput{}, nil)
+				c.EXPECT().DeleteOpenIDConnectProvider(&iam.DeleteOpenIDConnectProviderInput{OpenIDConnectProviderArn: aws.String(testBucketIssuerARN)}).Return(&iam.DeleteOpenIDConnectProviderOutput{}, nil)
+				c.EXPECT().ListObjectsV2Pages(&s3.ListObjectsV2Input{Bucket: aws.String("test-infra-id-oidc")}, gomock.Any()).DoAndReturn(
+					func(_ *s3.ListObjectsV2Input, fn func(*s3.ListObjectsV2Output, bool) bool) error {
+						fn(&s3.ListObjectsV2Output{Contents: []*s3.Object{{Key: aws.String(".well-known/openid-configuration")}, {Key: aws.String("keys.json")}}}, true)
+						return nil
+					})
+				c.EXPECT().DeleteObjects(gomock.Any()).DoAndReturn(func(input *s3.DeleteObjectsInput) (*s3.DeleteObjectsOutput, error) {
+					assert.Len(t, input.Delete.Objects, 2, "unexpected number of deleted objects")
+					return &s3.DeleteObjectsOutput{}, nil
+				})
+				c.EXPECT().DeleteBucket(&s3.DeleteBucketInput{Bucket: aws.String("test-infra-id-oidc")}).Return(&s3.DeleteBucketOutput{}, nil)
+			},
+		},
+		{
+			name: "resources already deleted",
+			setup: func(c *mockaws.MockClient) {
+				c.EXPECT().ListRolesPages(gomock.Any(), gomock.Any()).Return(nil)
+				c.EXPECT().ListOpenIDConnectProviders(gomock.Any()).Return(&iam.ListOpenIDConnectProvidersOutput{}, nil)
+				c.EXPECT().ListObjectsV2Pages(gomock.Any(), gomock.Any()).Return(awserr.New(s3.ErrCodeNoSuchBucket, "no such bucket", nil))
+			},
+		},
+		{
+			name: "role deletion fails",
+			setup: func(c *mockaws.MockClient) {
+				c.EXPECT().ListRolesPages(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ *iam.ListRolesInput, fn func(*iam.ListRolesOutput, bool) bool) error {
+						fn(&iam.ListRolesOutput{Roles: []*iam.Role{testRole("test-infra-id-role", testFederatedARN)}}, true)
+						return nil
+					})
+				c.EXPECT().ListRoleTags(gomock.Any()).Return(ownedTags, nil)
+				c.EXPECT().ListRolePolicies(gomock.Any()).Return(&iam.ListRolePoliciesOutput{}, nil)
+				c.EXPECT().DeleteRole(gomock.Any()).Return(nil, awserr.New(iam.ErrCodeDeleteConflictException, "conflict", nil))
+			},
+			expectErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			awsClient := mockaws.NewMockClient(mockCtrl)
+			test.setup(awsClient)
+			err := DeleteAWSSTSResources(awsClient, testSTSName, testSTSRegion, log.WithField("test", test.name))
+			if test.expectErr {
+				assert.Error(t, err, "expected error")
+			} else {
+				assert.NoError(t, err, "unexpected error")
+			}
+		})
+	}
+}
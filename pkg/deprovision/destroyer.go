@@ -0,0 +1,251 @@
+package deprovision
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	defaultWorkersPerType   = 10
+	defaultPollInterval     = 10 * time.Second
+	defaultMaxStalledRounds = 12
+)
+
+// ResourceType is a type of cloud resource which the ParallelDestroyer deletes.
+type ResourceType struct {
+	// Name identifies the type of the resources, as listed by the ResourceLister.
+	Name string
+
+	// DependsOn is the names of the types whose resources must all be deleted before the resources of this type are
+	// deleted.
+	DependsOn []string
+
+	// Delete deletes the resource with the given ID.
+	Delete func(id string) error
+}
+
+// ResourceLister lists the IDs of the resources of the cluster remaining to be deleted, by type of resource. Types of
+// resources which the ParallelDestroyer does not delete may be listed, in which case they are only reported.
+type ResourceLister func() (map[string][]string, error)
+
+// ProgressReporter reports the number of resources of the cluster remaining to be deleted, by type of resource.
+type ProgressReporter interface {
+	Report(remaining map[string]int, final bool)
+}
+
+// ParallelDestroyer deletes the resources of a cluster with concurrent workers for each type of resource, deleting the
+// resources of a type once the resources of the types it depends on have been deleted. Once no more resources can be
+// deleted in parallel, the remaining resources are left to the Sweep, which is typically the destroyer of the
+// installer and which remains responsible for deleting everything the ParallelDestroyer does not know about.
+type ParallelDestroyer struct {
+	// Types is the types of resources deleted in parallel.
+	Types []ResourceType
+
+	// List lists the resources remaining to be deleted.
+	List ResourceLister
+
+	// Sweep deletes the resources remaining after the parallel deletion.
+	Sweep func(ctx context.Context) error
+
+	// Reporter, when set, receives the number of resources remaining to be deleted after each listing.
+	Reporter ProgressReporter
+
+	// WorkersPerType is the maximum number of resources of each type deleted concurrently.
+	WorkersPerType int
+
+	// PollInterval is the interval between listings of the resources remaining to be deleted.
+	PollInterval time.Duration
+
+	// MaxStalledRounds is the number of consecutive listings without any resource deleted after which the parallel
+	// deletion gives way to the Sweep.
+	MaxStalledRounds int
+
+	Logger log.FieldLogger
+
+	// deleted is the IDs of the resources deleted so far, which are ignored when still listed. Some resources, such as
+	// terminated EC2 instances, keep being listed for a while after they have been deleted. The deletions and the
+	// listings of the parallel deletion never overlap, so the set needs no locking outside of the workers.
+	deleted sets.String
+}
+
+// Run deletes the resources of the cluster in parallel, then runs the Sweep while reporting the progress of the
+// deletion.
+func (d *ParallelDestroyer) Run(ctx context.Context) error {
+	d.setDefaults()
+	if err := d.deleteInParallel(ctx); err != nil {
+		return err
+	}
+	if d.Sweep != nil {
+		d.Logger.Info("deleting the remaining resources")
+		sweepCtx, cancel := context.WithCancel(ctx)
+		go wait.Until(func() { d.listRemaining() }, d.PollInterval, sweepCtx.Done())
+		err := d.Sweep(ctx)
+		cancel()
+		if err != nil {
+			return err
+		}
+	}
+	if d.Reporter != nil {
+		remaining, err := d.listRemaining()
+		if err != nil {
+			remaining = map[string][]string{}
+		}
+		d.Reporter.Report(countByType(remaining), true)
+	}
+	return nil
+}
+
+func (d *ParallelDestroyer) setDefaults() {
+	if d.WorkersPerType <= 0 {
+		d.WorkersPerType = defaultWorkersPerType
+	}
+	if d.PollInterval <= 0 {
+		d.PollInterval = defaultPollInterval
+	}
+	if d.MaxStalledRounds <= 0 {
+		d.MaxStalledRounds = defaultMaxStalledRounds
+	}
+	if d.Logger == nil {
+		d.Logger = log.StandardLogger()
+	}
+	if d.deleted == nil {
+		d.deleted = sets.NewString()
+	}
+}
+
+// deleteInParallel deletes the resources of the types of the ParallelDestroyer until none remain, or until no
+// resources have been deleted for MaxStalledRounds listings.
+func (d *ParallelDestroyer) deleteInParallel(ctx context.Context) error {
+	stalledRounds := 0
+	err := wait.PollImmediateUntil(
+		d.PollInterval,
+		func() (bool, error) {
+			remaining, err := d.listRemaining()
+			if err != nil {
+				d.Logger.WithError(err).Info("error while listing resources to delete")
+				return false, nil
+			}
+			var ready []ResourceType
+			pending := 0
+			for _, t := range d.Types {
+				if len(remaining[t.Name]) == 0 {
+					continue
+				}
+				pending++
+				if blocking := blockingTypes(t, remaining); len(blocking) > 0 {
+					d.Logger.WithField("type", t.Name).WithField("waitingFor", blocking).Debug("waiting for dependencies to be deleted")
+					continue
+				}
+				ready = append(ready, t)
+			}
+			if pending == 0 {
+				d.Logger.Debug("no resources left to delete in parallel")
+				return true, nil
+			}
+			if d.deleteTypes(ctx, ready, remaining) > 0 {
+				stalledRounds = 0
+				return false, nil
+			}
+			stalledRounds++
+			if stalledRounds >= d.MaxStalledRounds {
+				d.Logger.WithField("pendingTypes", pending).Info("could not delete the remaining resources in parallel")
+				return true, nil
+			}
+			return false, nil
+		},
+		ctx.Done(),
+	)
+	if err == wait.ErrWaitTimeout {
+		return ctx.Err()
+	}
+	return err
+}
+
+// listRemaining lists the resources remaining to be deleted, ignoring those already deleted, and reports their count.
+func (d *ParallelDestroyer) listRemaining() (map[string][]string, error) {
+	listed, err := d.List()
+	if err != nil {
+		return nil, err
+	}
+	remaining := make(map[string][]string, len(listed))
+	for t, ids := range listed {
+		for _, id := range ids {
+			if !d.deleted.Has(id) {
+				remaining[t] = append(remaining[t], id)
+			}
+		}
+	}
+	if d.Reporter != nil {
+		d.Reporter.Report(countByType(remaining), false)
+	}
+	return remaining, nil
+}
+
+// deleteTypes deletes the remaining resources of the given types, with WorkersPerType workers for each type, and
+// returns the number of resources deleted.
+func (d *ParallelDestroyer) deleteTypes(ctx context.Context, types []ResourceType, remaining map[string][]string) int {
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	deletedCount := 0
+	for _, t := range types {
+		ids := make(chan string)
+		typeLog := d.Logger.WithField("type", t.Name)
+		for i := 0; i < d.WorkersPerType; i++ {
+			wg.Add(1)
+			go func(t ResourceType) {
+				defer wg.Done()
+				for id := range ids {
+					if err := t.Delete(id); err != nil {
+						typeLog.WithError(err).WithField("id", id).Debug("could not delete resource")
+						continue
+					}
+					typeLog.WithField("id", id).Info("deleted")
+					mu.Lock()
+					d.deleted.Insert(id)
+					deletedCount++
+					mu.Unlock()
+				}
+			}(t)
+		}
+		go func(resources []string) {
+			defer close(ids)
+			for _, id := range resources {
+				select {
+				case ids <- id:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}(remaining[t.Name])
+	}
+	wg.Wait()
+	return deletedCount
+}
+
+// blockingTypes returns the types of resources which the given type depends on and which still have resources
+// remaining to be deleted.
+func blockingTypes(t ResourceType, remaining map[string][]string) []string {
+	var blocking []string
+	for _, dep := range t.DependsOn {
+		if len(remaining[dep]) > 0 {
+			blocking = append(blocking, dep)
+		}
+	}
+	return blocking
+}
+
+func countByType(remaining map[string][]string) map[string]int {
+	counts := make(map[string]int, len(remaining))
+	for t, ids := range remaining {
+		if len(ids) > 0 {
+			counts[t] = len(ids)
+		}
+	}
+	return counts
+}
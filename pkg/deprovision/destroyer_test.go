@@ -0,0 +1,188 @@
+package deprovision
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeCloud holds the resources of a cluster by type, recording the order in which they are deleted.
+type fakeCloud struct {
+	mu sync.Mutex
+	// resources is the IDs of the resources by type.
+	resources map[string][]string
+	// undeletable is the IDs of the resources which cannot be deleted.
+	undeletable map[string]bool
+	// lingering is the IDs of the resources which are still listed after they have been deleted.
+	lingering map[string]bool
+	deleted   []string
+}
+
+func (c *fakeCloud) list() (map[string][]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	listed := map[string][]string{}
+	for t, ids := range c.resources {
+		listed[t] = append([]string{}, ids...)
+	}
+	return listed, nil
+}
+
+func (c *fakeCloud) deleter(t string) func(string) error {
+	return func(id string) error {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if c.undeletable[id] {
+			return errors.New("cannot delete")
+		}
+		c.deleted = append(c.deleted, id)
+		if c.lingering[id] {
+			return nil
+		}
+		var ids []string
+		for _, existing := range c.resources[t] {
+			if existing != id {
+				ids = append(ids, existing)
+			}
+		}
+		c.resources[t] = ids
+		return nil
+	}
+}
+
+func (c *fakeCloud) sweep(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for t, ids := range c.resources {
+		for _, id := range ids {
+			c.deleted = append(c.deleted, "swept:"+id)
+		}
+		delete(c.resources, t)
+	}
+	return nil
+}
+
+type fakeReporter struct {
+	mu      sync.Mutex
+	reports []map[string]int
+	final   map[string]int
+}
+
+func (r *fakeReporter) Report(remaining map[string]int, final bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.reports = append(r.reports, remaining)
+	if final {
+		r.final = remaining
+	}
+}
+
+func TestParallelDestroyer(t *testing.T) {
+	tests := []struct {
+		name          string
+		resources     map[string][]string
+		undeletable   []string
+		lingering     []string
+		expectDeleted []string
+		expectSwept   []string
+	}{
+		{
+			name: "delete dependencies first",
+			resources: map[string][]string{
+				"instance": {"i-1", "i-2", "i-3"},
+				"volume":   {"vol-1", "vol-2"},
+			},
+			expectDeleted: []string{"i-1", "i-2", "i-3", "vol-1", "vol-2"},
+		},
+		{
+			name: "ignore deleted resources still listed",
+			resources: map[string][]string{
+				"instance": {"i-1", "i-2"},
+				"volume":   {"vol-1"},
+			},
+			lingering:     []string{"i-1", "i-2"},
+			expectDeleted: []string{"i-1", "i-2", "vol-1"},
+			expectSwept:   []string{"i-1", "i-2"},
+		},
+		{
+			name: "sweep undeletable resources and their dependents",
+			resources: map[string][]string{
+				"instance": {"i-1", "i-2"},
+				"volume":   {"vol-1"},
+			},
+			undeletable:   []string{"i-2"},
+			expectDeleted: []string{"i-1"},
+			expectSwept:   []string{"i-2", "vol-1"},
+		},
+		{
+			name: "sweep unknown types",
+			resources: map[string][]string{
+				"instance": {"i-1"},
+				"vpc":      {"vpc-1"},
+			},
+			expectDeleted: []string{"i-1"},
+			expectSwept:   []string{"vpc-1"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resources := map[string][]string{}
+			for resourceType, ids := range test.resources {
+				resources[resourceType] = append([]string{}, ids...)
+			}
+			cloud := &fakeCloud{
+				resources:   resources,
+				undeletable: map[string]bool{},
+				lingering:   map[string]bool{},
+			}
+			for _, id := range test.undeletable {
+				cloud.undeletable[id] = true
+			}
+			for _, id := range test.lingering {
+				cloud.lingering[id] = true
+			}
+			reporter := &fakeReporter{}
+			d := &ParallelDestroyer{
+				Types: []ResourceType{
+					{Name: "instance", Delete: cloud.deleter("instance")},
+					{Name: "volume", DependsOn: []string{"instance"}, Delete: cloud.deleter("volume")},
+				},
+				List:             cloud.list,
+				Sweep:            cloud.sweep,
+				Reporter:         reporter,
+				WorkersPerType:   2,
+				PollInterval:     time.Millisecond,
+				MaxStalledRounds: 3,
+				Logger:           log.WithField("test", test.name),
+			}
+			require.NoError(t, d.Run(context.Background()), "unexpected error")
+
+			var deleted, swept []string
+			for _, id := range cloud.deleted {
+				if len(id) > 6 && id[:6] == "swept:" {
+					swept = append(swept, id[6:])
+				} else {
+					deleted = append(deleted, id)
+				}
+			}
+			assert.ElementsMatch(t, test.expectDeleted, deleted, "unexpected resources deleted in parallel")
+			assert.ElementsMatch(t, test.expectSwept, swept, "unexpected resources swept")
+			for i, id := range deleted {
+				if id[:2] == "i-" {
+					for _, previous := range deleted[:i] {
+						assert.NotEqual(t, "vol", previous[:3], "volume deleted before instance %s", id)
+					}
+				}
+			}
+			require.NotEmpty(t, reporter.reports, "expected progress reports")
+			assert.Equal(t, len(test.resources), len(reporter.reports[0]), "unexpected types in first report")
+			assert.Empty(t, reporter.final, "expected no resources remaining in final report")
+		})
+	}
+}
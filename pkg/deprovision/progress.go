@@ -0,0 +1,75 @@
+package deprovision
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+const defaultReportInterval = 30 * time.Second
+
+// StatusProgressReporter reports the progress of the deletion of the resources of a cluster in the status of its
+// ClusterDeprovision. The status is patched at most once per Interval, unless the progress is final.
+type StatusProgressReporter struct {
+	Client      client.Client
+	Deprovision types.NamespacedName
+	Interval    time.Duration
+	Logger      log.FieldLogger
+
+	mu         sync.Mutex
+	lastReport time.Time
+}
+
+var _ ProgressReporter = &StatusProgressReporter{}
+
+// Report patches the remaining resources into the status of the ClusterDeprovision.
+func (r *StatusProgressReporter) Report(remaining map[string]int, final bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	interval := r.Interval
+	if interval <= 0 {
+		interval = defaultReportInterval
+	}
+	if !final && time.Since(r.lastReport) < interval {
+		return
+	}
+
+	counts := []hivev1.DeprovisionResourceCount{}
+	for t, count := range remaining {
+		counts = append(counts, hivev1.DeprovisionResourceCount{Type: t, Count: count})
+	}
+	sort.Slice(counts, func(i, j int) bool { return counts[i].Type < counts[j].Type })
+	// The remaining resources are always set, even when empty, so that the merge patch replaces the previous list.
+	patch, err := json.Marshal(map[string]interface{}{
+		"status": map[string]interface{}{
+			"progress": map[string]interface{}{
+				"lastUpdateTime":     metav1.Now(),
+				"remainingResources": counts,
+			},
+		},
+	})
+	if err != nil {
+		r.Logger.WithError(err).Warn("could not marshal deprovision progress")
+		return
+	}
+	cdr := &hivev1.ClusterDeprovision{}
+	cdr.Namespace = r.Deprovision.Namespace
+	cdr.Name = r.Deprovision.Name
+	if err := r.Client.Status().Patch(context.TODO(), cdr, client.RawPatch(types.MergePatchType, patch)); err != nil {
+		r.Logger.WithError(err).Warn("could not report deprovision progress")
+		return
+	}
+	r.Logger.WithField("remaining", remaining).Debug("reported deprovision progress")
+	r.lastReport = time.Now()
+}
@@ -0,0 +1,136 @@
+// Package externaldestroyer invokes the site-specific teardown automation of clusters installed on
+// infrastructure created outside of Hive.
+package externaldestroyer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// Request is the body POSTed to the destroyer webhook.
+type Request struct {
+	InfraID                    string `json:"infraID"`
+	ClusterID                  string `json:"clusterID,omitempty"`
+	ClusterDeploymentName      string `json:"clusterDeploymentName"`
+	ClusterDeploymentNamespace string `json:"clusterDeploymentNamespace"`
+}
+
+// WebhookDestroyer calls a destroyer webhook and waits for it to report that teardown is done.
+type WebhookDestroyer struct {
+	URL     string
+	Token   string
+	Request Request
+	Logger  log.FieldLogger
+
+	// Client is the HTTP client used to call the webhook. Defaults to a client with a 1 minute timeout.
+	Client *http.Client
+	// PollInterval is how often to poll the status URL of asynchronous teardowns. Defaults to 30 seconds.
+	PollInterval time.Duration
+	// Timeout is how long to wait for an asynchronous teardown to finish. Defaults to 2 hours.
+	Timeout time.Duration
+}
+
+// Run calls the webhook. A 200 or 204 response means the teardown is done. A 202 response means the teardown
+// is in progress, and the URL in the Location header is polled until it responds with 200.
+func (d *WebhookDestroyer) Run() error {
+	client, interval, timeout := d.Client, d.PollInterval, d.Timeout
+	if client == nil {
+		client = &http.Client{Timeout: time.Minute}
+	}
+	if interval == 0 {
+		interval = 30 * time.Second
+	}
+	if timeout == 0 {
+		timeout = 2 * time.Hour
+	}
+
+	body, err := json.Marshal(d.Request)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, d.URL, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "error creating webhook request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	d.Logger.WithField("url", d.URL).Info("calling destroyer webhook")
+	resp, err := d.do(client, req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNoContent:
+		d.Logger.Info("destroyer webhook reported teardown complete")
+		return nil
+	case http.StatusAccepted:
+	default:
+		return unexpectedResponse(resp)
+	}
+
+	location := resp.Header.Get("Location")
+	if location == "" {
+		return errors.New("destroyer webhook accepted the request without a Location header to poll")
+	}
+	statusURL, err := req.URL.Parse(location)
+	if err != nil {
+		return errors.Wrap(err, "error parsing Location header")
+	}
+	d.Logger.WithField("statusURL", statusURL.String()).Info("waiting for destroyer webhook to complete teardown")
+	err = wait.PollImmediate(interval, timeout, func() (bool, error) {
+		return d.pollStatus(client, statusURL)
+	})
+	if err == wait.ErrWaitTimeout {
+		return fmt.Errorf("timed out after %s waiting for destroyer webhook to complete teardown", timeout)
+	}
+	return err
+}
+
+func (d *WebhookDestroyer) pollStatus(client *http.Client, statusURL *url.URL) (bool, error) {
+	req, err := http.NewRequest(http.MethodGet, statusURL.String(), nil)
+	if err != nil {
+		return false, err
+	}
+	resp, err := d.do(client, req)
+	if err != nil {
+		// Transient errors are retried until the timeout.
+		d.Logger.WithError(err).Warn("error polling destroyer webhook status")
+		return false, nil
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNoContent:
+		d.Logger.Info("destroyer webhook reported teardown complete")
+		return true, nil
+	case http.StatusAccepted:
+		d.Logger.Info("teardown still in progress")
+		return false, nil
+	default:
+		return false, unexpectedResponse(resp)
+	}
+}
+
+func (d *WebhookDestroyer) do(client *http.Client, req *http.Request) (*http.Response, error) {
+	if d.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+d.Token)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "error calling destroyer webhook")
+	}
+	return resp, nil
+}
+
+func unexpectedResponse(resp *http.Response) error {
+	body, _ := ioutil.ReadAll(resp.Body)
+	return fmt.Errorf("destroyer webhook failed: %s: %s", resp.Status, string(body))
+}
@@ -0,0 +1,115 @@
+package externaldestroyer
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookDestroyer(t *testing.T) {
+	cases := []struct {
+		name         string
+		postStatus   int
+		location     string
+		statusPolls  []int
+		expectErr    bool
+		expectedGets int
+	}{
+		{
+			name:       "synchronous teardown",
+			postStatus: http.StatusOK,
+		},
+		{
+			name:       "synchronous teardown no content",
+			postStatus: http.StatusNoContent,
+		},
+		{
+			name:         "asynchronous teardown",
+			postStatus:   http.StatusAccepted,
+			location:     "/status/1",
+			statusPolls:  []int{http.StatusAccepted, http.StatusAccepted, http.StatusOK},
+			expectedGets: 3,
+		},
+		{
+			name:         "asynchronous teardown fails",
+			postStatus:   http.StatusAccepted,
+			location:     "/status/1",
+			statusPolls:  []int{http.StatusAccepted, http.StatusInternalServerError},
+			expectErr:    true,
+			expectedGets: 2,
+		},
+		{
+			name:        "asynchronous teardown times out",
+			postStatus:  http.StatusAccepted,
+			location:    "/status/1",
+			statusPolls: []int{http.StatusAccepted, http.StatusAccepted, http.StatusAccepted, http.StatusAccepted, http.StatusAccepted, http.StatusAccepted},
+			expectErr:   true,
+		},
+		{
+			name:       "accepted without location",
+			postStatus: http.StatusAccepted,
+			expectErr:  true,
+		},
+		{
+			name:       "rejected",
+			postStatus: http.StatusForbidden,
+			expectErr:  true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			gets := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "Bearer fake-token", r.Header.Get("Authorization"), "unexpected authorization header")
+				switch r.Method {
+				case http.MethodPost:
+					req := &Request{}
+					if assert.NoError(t, json.NewDecoder(r.Body).Decode(req)) {
+						assert.Equal(t, "fake-infra-id", req.InfraID, "unexpected infra ID")
+						assert.Equal(t, "mycluster", req.ClusterDeploymentName, "unexpected cluster deployment name")
+					}
+					if tc.location != "" {
+						w.Header().Set("Location", tc.location)
+					}
+					w.WriteHeader(tc.postStatus)
+				case http.MethodGet:
+					assert.Equal(t, tc.location, r.URL.Path, "unexpected status URL")
+					status := http.StatusAccepted
+					if gets < len(tc.statusPolls) {
+						status = tc.statusPolls[gets]
+					}
+					gets++
+					w.WriteHeader(status)
+				}
+			}))
+			defer server.Close()
+
+			destroyer := &WebhookDestroyer{
+				URL:   server.URL + "/destroy",
+				Token: "fake-token",
+				Request: Request{
+					InfraID:                    "fake-infra-id",
+					ClusterDeploymentName:      "mycluster",
+					ClusterDeploymentNamespace: "mynamespace",
+				},
+				Logger:       log.WithField("test", tc.name),
+				PollInterval: 10 * time.Millisecond,
+				Timeout:      50 * time.Millisecond,
+			}
+			err := destroyer.Run()
+			if tc.expectErr {
+				assert.Error(t, err, "expected error from destroyer webhook")
+			} else {
+				assert.NoError(t, err, "unexpected error from destroyer webhook")
+			}
+			if tc.expectedGets > 0 {
+				assert.Equal(t, tc.expectedGets, gets, "unexpected number of status polls")
+			}
+		})
+	}
+}
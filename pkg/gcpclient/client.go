@@ -2,10 +2,10 @@ package gcpclient
 
 import (
 	"context"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"strings"
-	"time"
 
 	"github.com/openshift/hive/pkg/constants"
 	"github.com/pkg/errors"
@@ -16,6 +16,7 @@ import (
 	"google.golang.org/api/googleapi"
 	"google.golang.org/api/option"
 	serviceusage "google.golang.org/api/serviceusage/v1"
+	storage "google.golang.org/api/storage/v1"
 	corev1 "k8s.io/api/core/v1"
 )
 
@@ -23,33 +24,39 @@ import (
 
 // Client is a wrapper object for actual GCP libraries to allow for easier mocking/testing.
 type Client interface {
-	ListManagedZones(opts ListManagedZonesOptions) (*dns.ManagedZonesListResponse, error)
+	ListManagedZones(ctx context.Context, opts ListManagedZonesOptions) (*dns.ManagedZonesListResponse, error)
 
-	ListResourceRecordSets(managedZone string, opts ListResourceRecordSetsOptions) (*dns.ResourceRecordSetsListResponse, error)
+	ListResourceRecordSets(ctx context.Context, managedZone string, opts ListResourceRecordSetsOptions) (*dns.ResourceRecordSetsListResponse, error)
 
-	AddResourceRecordSet(managedZone string, recordSet *dns.ResourceRecordSet) error
+	AddResourceRecordSet(ctx context.Context, managedZone string, recordSet *dns.ResourceRecordSet) error
 
-	DeleteResourceRecordSet(managedZone string, recordSet *dns.ResourceRecordSet) error
+	DeleteResourceRecordSet(ctx context.Context, managedZone string, recordSet *dns.ResourceRecordSet) error
 
-	DeleteResourceRecordSets(managedZone string, recordSet []*dns.ResourceRecordSet) error
+	DeleteResourceRecordSets(ctx context.Context, managedZone string, recordSet []*dns.ResourceRecordSet) error
 
-	UpdateResourceRecordSet(managedZone string, addRecordSet, removeRecordSet *dns.ResourceRecordSet) error
+	UpdateResourceRecordSet(ctx context.Context, managedZone string, addRecordSet, removeRecordSet *dns.ResourceRecordSet) error
 
-	GetManagedZone(managedZone string) (*dns.ManagedZone, error)
+	GetManagedZone(ctx context.Context, managedZone string) (*dns.ManagedZone, error)
 
-	CreateManagedZone(managedZone *dns.ManagedZone) (*dns.ManagedZone, error)
+	CreateManagedZone(ctx context.Context, managedZone *dns.ManagedZone) (*dns.ManagedZone, error)
 
-	DeleteManagedZone(managedZone string) error
+	DeleteManagedZone(ctx context.Context, managedZone string) error
 
-	ListComputeZones(ListComputeZonesOptions) (*compute.ZoneList, error)
+	ListComputeZones(ctx context.Context, opts ListComputeZonesOptions) (*compute.ZoneList, error)
 
-	ListComputeImages(ListComputeImagesOptions) (*compute.ImageList, error)
+	ListComputeImages(ctx context.Context, opts ListComputeImagesOptions) (*compute.ImageList, error)
 
-	ListComputeInstances(ListComputeInstancesOptions, func(*compute.InstanceAggregatedList) error) error
+	GetSubnetwork(ctx context.Context, region, name string) (*compute.Subnetwork, error)
 
-	StopInstance(*compute.Instance) error
+	ListComputeInstances(ctx context.Context, opts ListComputeInstancesOptions, pagesFn func(*compute.InstanceAggregatedList) error) error
 
-	StartInstance(*compute.Instance) error
+	StopInstance(ctx context.Context, instance *compute.Instance) error
+
+	StartInstance(ctx context.Context, instance *compute.Instance) error
+
+	GetSerialPortOutput(ctx context.Context, instance *compute.Instance) (string, error)
+
+	UploadObject(ctx context.Context, bucket, name string, body io.Reader) error
 }
 
 // ListManagedZonesOptions are the options for listing managed zones.
@@ -79,73 +86,75 @@ type gcpClient struct {
 	computeClient              *compute.Service
 	serviceUsageClient         *serviceusage.Service
 	dnsClient                  *dns.Service
+	storageClient              *storage.Service
 }
 
-const (
-	defaultCallTimeout = 2 * time.Minute
-)
-
-func contextWithTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
-	return context.WithTimeout(ctx, defaultCallTimeout)
-}
-
-func (c *gcpClient) GetManagedZone(managedZone string) (*dns.ManagedZone, error) {
-	ctx, cancel := contextWithTimeout(context.TODO())
-	defer cancel()
-
-	return c.dnsClient.ManagedZones.Get(c.projectName, managedZone).Context(ctx).Do()
+func (c *gcpClient) GetManagedZone(ctx context.Context, managedZone string) (zone *dns.ManagedZone, err error) {
+	err = call(ctx, "GetManagedZone", func(ctx context.Context) error {
+		zone, err = c.dnsClient.ManagedZones.Get(c.projectName, managedZone).Context(ctx).Do()
+		return err
+	})
+	return
 }
 
-func (c *gcpClient) ListManagedZones(opts ListManagedZonesOptions) (*dns.ManagedZonesListResponse, error) {
-	ctx, cancel := contextWithTimeout(context.TODO())
-	defer cancel()
-	call := c.dnsClient.ManagedZones.List(c.projectName).Context(ctx)
-
-	if opts.MaxResults > 0 {
-		call.MaxResults(opts.MaxResults)
-	}
-	if opts.PageToken != "" {
-		call.PageToken(opts.PageToken)
-	}
-	if opts.DNSName != "" {
-		call.DnsName(opts.DNSName)
-	}
-	return call.Do()
+func (c *gcpClient) ListManagedZones(ctx context.Context, opts ListManagedZonesOptions) (resp *dns.ManagedZonesListResponse, err error) {
+	err = call(ctx, "ListManagedZones", func(ctx context.Context) error {
+		req := c.dnsClient.ManagedZones.List(c.projectName).Context(ctx)
+		if opts.MaxResults > 0 {
+			req.MaxResults(opts.MaxResults)
+		}
+		if opts.PageToken != "" {
+			req.PageToken(opts.PageToken)
+		}
+		if opts.DNSName != "" {
+			req.DnsName(opts.DNSName)
+		}
+		resp, err = req.Do()
+		return err
+	})
+	return
 }
 
-func (c *gcpClient) CreateManagedZone(managedZone *dns.ManagedZone) (*dns.ManagedZone, error) {
-	ctx, cancel := contextWithTimeout(context.TODO())
-	defer cancel()
-	return c.dnsClient.ManagedZones.Create(c.projectName, managedZone).Context(ctx).Do()
+// CreateManagedZone creates the managed zone. It is not retried, since a retry of a create which took effect would fail
+// because the zone already exists.
+func (c *gcpClient) CreateManagedZone(ctx context.Context, managedZone *dns.ManagedZone) (zone *dns.ManagedZone, err error) {
+	err = callOnce(ctx, "CreateManagedZone", func(ctx context.Context) error {
+		zone, err = c.dnsClient.ManagedZones.Create(c.projectName, managedZone).Context(ctx).Do()
+		return err
+	})
+	return
 }
 
-func (c *gcpClient) DeleteManagedZone(managedZone string) error {
-	ctx, cancel := contextWithTimeout(context.TODO())
-	defer cancel()
-	return c.dnsClient.ManagedZones.Delete(c.projectName, managedZone).Context(ctx).Do()
+func (c *gcpClient) DeleteManagedZone(ctx context.Context, managedZone string) error {
+	return call(ctx, "DeleteManagedZone", func(ctx context.Context) error {
+		return c.dnsClient.ManagedZones.Delete(c.projectName, managedZone).Context(ctx).Do()
+	})
 }
 
-func (c *gcpClient) ListResourceRecordSets(managedZone string, opts ListResourceRecordSetsOptions) (*dns.ResourceRecordSetsListResponse, error) {
-	ctx, cancel := contextWithTimeout(context.TODO())
-	defer cancel()
-	call := c.dnsClient.ResourceRecordSets.List(c.projectName, managedZone).Context(ctx)
-	if opts.MaxResults > 0 {
-		call.MaxResults(opts.MaxResults)
-	}
-	if opts.PageToken != "" {
-		call.PageToken(opts.PageToken)
-	}
-	if opts.Name != "" {
-		call.Name(opts.Name)
-	}
-	if opts.Type != "" {
-		call.Type(opts.Type)
-	}
-	return call.Do()
+func (c *gcpClient) ListResourceRecordSets(ctx context.Context, managedZone string, opts ListResourceRecordSetsOptions) (resp *dns.ResourceRecordSetsListResponse, err error) {
+	err = call(ctx, "ListResourceRecordSets", func(ctx context.Context) error {
+		req := c.dnsClient.ResourceRecordSets.List(c.projectName, managedZone).Context(ctx)
+		if opts.MaxResults > 0 {
+			req.MaxResults(opts.MaxResults)
+		}
+		if opts.PageToken != "" {
+			req.PageToken(opts.PageToken)
+		}
+		if opts.Name != "" {
+			req.Name(opts.Name)
+		}
+		if opts.Type != "" {
+			req.Type(opts.Type)
+		}
+		resp, err = req.Do()
+		return err
+	})
+	return
 }
 
-func (c *gcpClient) AddResourceRecordSet(managedZone string, recordSet *dns.ResourceRecordSet) error {
+func (c *gcpClient) AddResourceRecordSet(ctx context.Context, managedZone string, recordSet *dns.ResourceRecordSet) error {
 	return c.changeResourceRecordSet(
+		ctx,
 		managedZone,
 		&dns.Change{
 			Additions: []*dns.ResourceRecordSet{recordSet},
@@ -153,7 +162,7 @@ func (c *gcpClient) AddResourceRecordSet(managedZone string, recordSet *dns.Reso
 	)
 }
 
-func (c *gcpClient) UpdateResourceRecordSet(managedZone string, addRecordSet, removeRecordSet *dns.ResourceRecordSet) error {
+func (c *gcpClient) UpdateResourceRecordSet(ctx context.Context, managedZone string, addRecordSet, removeRecordSet *dns.ResourceRecordSet) error {
 	change := &dns.Change{}
 	if addRecordSet != nil && len(addRecordSet.Rrdatas) > 0 {
 		change.Additions = []*dns.ResourceRecordSet{addRecordSet}
@@ -162,17 +171,19 @@ func (c *gcpClient) UpdateResourceRecordSet(managedZone string, addRecordSet, re
 		change.Deletions = []*dns.ResourceRecordSet{removeRecordSet}
 	}
 	return c.changeResourceRecordSet(
+		ctx,
 		managedZone,
 		change,
 	)
 }
 
-func (c *gcpClient) DeleteResourceRecordSet(managedZone string, recordSet *dns.ResourceRecordSet) error {
-	return c.DeleteResourceRecordSets(managedZone, []*dns.ResourceRecordSet{recordSet})
+func (c *gcpClient) DeleteResourceRecordSet(ctx context.Context, managedZone string, recordSet *dns.ResourceRecordSet) error {
+	return c.DeleteResourceRecordSets(ctx, managedZone, []*dns.ResourceRecordSet{recordSet})
 }
 
-func (c *gcpClient) DeleteResourceRecordSets(managedZone string, recordSets []*dns.ResourceRecordSet) error {
+func (c *gcpClient) DeleteResourceRecordSets(ctx context.Context, managedZone string, recordSets []*dns.ResourceRecordSet) error {
 	return c.changeResourceRecordSet(
+		ctx,
 		managedZone,
 		&dns.Change{
 			Deletions: recordSets,
@@ -180,11 +191,13 @@ func (c *gcpClient) DeleteResourceRecordSets(managedZone string, recordSets []*d
 	)
 }
 
-func (c *gcpClient) changeResourceRecordSet(managedZone string, change *dns.Change) error {
-	ctx, cancel := contextWithTimeout(context.TODO())
-	defer cancel()
-	_, err := c.dnsClient.Changes.Create(c.projectName, managedZone, change).Context(ctx).Do()
-	return err
+// changeResourceRecordSet makes the change to the resource record sets of the managed zone. It is not retried, since
+// a retry of a change which took effect would fail because its additions already exist or its deletions are gone.
+func (c *gcpClient) changeResourceRecordSet(ctx context.Context, managedZone string, change *dns.Change) error {
+	return callOnce(ctx, "ChangeResourceRecordSets", func(ctx context.Context) error {
+		_, err := c.dnsClient.Changes.Create(c.projectName, managedZone, change).Context(ctx).Do()
+		return err
+	})
 }
 
 // ListComputeZonesOptions are the options for listing compute zones.
@@ -194,20 +207,19 @@ type ListComputeZonesOptions struct {
 	Filter     string
 }
 
-func (c *gcpClient) ListComputeZones(opts ListComputeZonesOptions) (*compute.ZoneList, error) {
-	ctx, cancel := contextWithTimeout(context.TODO())
-	defer cancel()
-
-	call := c.computeClient.Zones.List(c.projectName).Filter(opts.Filter).Context(ctx)
-
-	if opts.MaxResults > 0 {
-		call.MaxResults(opts.MaxResults)
-	}
-	if opts.PageToken != "" {
-		call.PageToken(opts.PageToken)
-	}
-
-	return call.Do()
+func (c *gcpClient) ListComputeZones(ctx context.Context, opts ListComputeZonesOptions) (zones *compute.ZoneList, err error) {
+	err = call(ctx, "ListComputeZones", func(ctx context.Context) error {
+		req := c.computeClient.Zones.List(c.projectName).Filter(opts.Filter).Context(ctx)
+		if opts.MaxResults > 0 {
+			req.MaxResults(opts.MaxResults)
+		}
+		if opts.PageToken != "" {
+			req.PageToken(opts.PageToken)
+		}
+		zones, err = req.Do()
+		return err
+	})
+	return
 }
 
 // ListComputeImagesOptions are the options for listing compute images.
@@ -217,22 +229,34 @@ type ListComputeImagesOptions struct {
 	Filter     string
 }
 
-func (c *gcpClient) ListComputeImages(opts ListComputeImagesOptions) (*compute.ImageList, error) {
-	ctx, cancel := contextWithTimeout(context.TODO())
-	defer cancel()
-
-	call := c.computeClient.Images.List(c.projectName).Filter(opts.Filter).Context(ctx)
+func (c *gcpClient) ListComputeImages(ctx context.Context, opts ListComputeImagesOptions) (images *compute.ImageList, err error) {
+	err = call(ctx, "ListComputeImages", func(ctx context.Context) error {
+		req := c.computeClient.Images.List(c.projectName).Filter(opts.Filter).Context(ctx)
+		if opts.MaxResults > 0 {
+			req.MaxResults(opts.MaxResults)
+		}
+		if opts.PageToken != "" {
+			req.PageToken(opts.PageToken)
+		}
+		images, err = req.Do()
+		return err
+	})
+	return
+}
 
-	if opts.MaxResults > 0 {
-		call.MaxResults(opts.MaxResults)
-	}
-	if opts.PageToken != "" {
-		call.PageToken(opts.PageToken)
-	}
-	return call.Do()
+// GetSubnetwork returns the subnetwork with the given name in the region.
+func (c *gcpClient) GetSubnetwork(ctx context.Context, region, name string) (subnetwork *compute.Subnetwork, err error) {
+	err = call(ctx, "GetSubnetwork", func(ctx context.Context) error {
+		subnetwork, err = c.computeClient.Subnetworks.Get(c.projectName, region, name).Context(ctx).Do()
+		return err
+	})
+	return
 }
 
-func (c *gcpClient) ListComputeInstances(opts ListComputeInstancesOptions, pagesFn func(*compute.InstanceAggregatedList) error) error {
+// ListComputeInstances lists the compute instances page by page. It is not retried, so that pagesFn does not see the
+// same instances twice, and it is only bounded by the given context, since it can span many pages.
+func (c *gcpClient) ListComputeInstances(ctx context.Context, opts ListComputeInstancesOptions, pagesFn func(*compute.InstanceAggregatedList) error) error {
+	metricGCPAPICalls.WithLabelValues("ListComputeInstances").Inc()
 	req := c.computeClient.Instances.AggregatedList(c.projectName)
 	if len(opts.Fields) > 0 {
 		req.Fields(googleapi.Field(opts.Fields))
@@ -240,31 +264,64 @@ func (c *gcpClient) ListComputeInstances(opts ListComputeInstancesOptions, pages
 	if len(opts.Filter) > 0 {
 		req = req.Filter(opts.Filter)
 	}
-	err := req.Pages(context.TODO(), pagesFn)
+	err := req.Pages(ctx, pagesFn)
 	if err != nil {
 		return errors.Wrapf(err, "failed to fetch compute instances")
 	}
 	return nil
 }
 
-func (c *gcpClient) StopInstance(instance *compute.Instance) error {
+// StopInstance stops the instance. Stopping an instance which is already stopped, or being stopped, takes no effect,
+// so the call is retried.
+func (c *gcpClient) StopInstance(ctx context.Context, instance *compute.Instance) error {
 	zone := instanceZone(instance)
-	_, err := c.computeClient.Instances.Stop(c.projectName, zone, instance.Name).Do()
+	err := call(ctx, "StopInstance", func(ctx context.Context) error {
+		_, err := c.computeClient.Instances.Stop(c.projectName, zone, instance.Name).Context(ctx).Do()
+		return err
+	})
 	if err != nil && !isNotModified(err) {
 		return errors.Wrapf(err, "failed to stop instance %s in zone %s", instance.Name, zone)
 	}
 	return nil
 }
 
-func (c *gcpClient) StartInstance(instance *compute.Instance) error {
+// StartInstance starts the instance. Starting an instance which is already running, or being started, takes no
+// effect, so the call is retried.
+func (c *gcpClient) StartInstance(ctx context.Context, instance *compute.Instance) error {
 	zone := instanceZone(instance)
-	_, err := c.computeClient.Instances.Start(c.projectName, zone, instance.Name).Do()
+	err := call(ctx, "StartInstance", func(ctx context.Context) error {
+		_, err := c.computeClient.Instances.Start(c.projectName, zone, instance.Name).Context(ctx).Do()
+		return err
+	})
 	if err != nil && !isNotModified(err) {
 		return errors.Wrapf(err, "failed to start instance %s in zone %s", instance.Name, zone)
 	}
 	return nil
 }
 
+// GetSerialPortOutput returns the output of the first serial port of the instance.
+func (c *gcpClient) GetSerialPortOutput(ctx context.Context, instance *compute.Instance) (string, error) {
+	zone := instanceZone(instance)
+	var output string
+	err := call(ctx, "GetSerialPortOutput", func(ctx context.Context) error {
+		resp, err := c.computeClient.Instances.GetSerialPortOutput(c.projectName, zone, instance.Name).Context(ctx).Do()
+		if err == nil {
+			output = resp.Contents
+		}
+		return err
+	})
+	return output, errors.Wrapf(err, "failed to get serial port output of instance %s in zone %s", instance.Name, zone)
+}
+
+// UploadObject uploads the body to an object in the bucket. It is not retried, since the body can only be read once.
+func (c *gcpClient) UploadObject(ctx context.Context, bucket, name string, body io.Reader) error {
+	err := callOnce(ctx, "UploadObject", func(ctx context.Context) error {
+		_, err := c.storageClient.Objects.Insert(bucket, &storage.Object{Name: name}).Media(body).Context(ctx).Do()
+		return err
+	})
+	return errors.Wrapf(err, "failed to upload object %s to bucket %s", name, bucket)
+}
+
 // NewClient creates our client wrapper object for interacting with GCP. The supplied byte slice contains the GCP creds.
 func NewClient(authJSON []byte) (Client, error) {
 	return newClient(authJSONPassthroughSource(authJSON))
@@ -348,6 +405,11 @@ func newClient(authJSONSource func() ([]byte, error)) (*gcpClient, error) {
 		return nil, err
 	}
 
+	storageClient, err := storage.NewService(ctx, options...)
+	if err != nil {
+		return nil, err
+	}
+
 	return &gcpClient{
 		projectName:                creds.ProjectID,
 		creds:                      creds,
@@ -355,6 +417,7 @@ func newClient(authJSONSource func() ([]byte, error)) (*gcpClient, error) {
 		computeClient:              computeClient,
 		serviceUsageClient:         serviceUsageClient,
 		dnsClient:                  dnsClient,
+		storageClient:              storageClient,
 	}, nil
 }
 
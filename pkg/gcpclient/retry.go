@@ -0,0 +1,108 @@
+package gcpclient
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/api/googleapi"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	defaultCallTimeout = 2 * time.Minute
+
+	// maxRetries is the maximum number of times a call failing with a transient error is retried.
+	maxRetries = 5
+)
+
+var (
+	// retryBackoff is the backoff between the retries of a call failing with a transient error.
+	retryBackoff = wait.Backoff{
+		Duration: 500 * time.Millisecond,
+		Factor:   2,
+		Jitter:   0.1,
+		Cap:      30 * time.Second,
+	}
+
+	metricGCPAPICalls = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "hive_gcp_api_calls_total",
+			Help: "Number of API calls made to GCP, partitioned by function.",
+		},
+		[]string{"function"},
+	)
+	metricGCPAPIRetries = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "hive_gcp_api_retries_total",
+			Help: "Number of API calls to GCP which were retried after a transient error, partitioned by function.",
+		},
+		[]string{"function"},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(metricGCPAPICalls)
+	metrics.Registry.MustRegister(metricGCPAPIRetries)
+}
+
+func contextWithTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, defaultCallTimeout)
+}
+
+// call makes an idempotent call to the GCP API, counting it in the API call metrics. The call is bounded by the
+// default call timeout, and retried with exponential backoff while it fails with a transient error, until the timeout
+// or the given context expires.
+func call(ctx context.Context, function string, fn func(ctx context.Context) error) error {
+	metricGCPAPICalls.WithLabelValues(function).Inc()
+	ctx, cancel := contextWithTimeout(ctx)
+	defer cancel()
+	delay := retryBackoff.Duration
+	for retries := 0; ; retries++ {
+		err := fn(ctx)
+		if !isTransient(err) || retries == maxRetries {
+			return err
+		}
+		metricGCPAPIRetries.WithLabelValues(function).Inc()
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(wait.Jitter(delay, retryBackoff.Jitter)):
+		}
+		if delay = time.Duration(float64(delay) * retryBackoff.Factor); delay > retryBackoff.Cap {
+			delay = retryBackoff.Cap
+		}
+	}
+}
+
+// callOnce makes a call to the GCP API which is not idempotent, counting it in the API call metrics. The call is
+// bounded by the default call timeout, and it is not retried, since a call failing with a transient error may still
+// have taken effect.
+func callOnce(ctx context.Context, function string, fn func(ctx context.Context) error) error {
+	metricGCPAPICalls.WithLabelValues(function).Inc()
+	ctx, cancel := contextWithTimeout(ctx)
+	defer cancel()
+	return fn(ctx)
+}
+
+// isTransient returns true if the error is one which GCP may not return when the call is retried, such as rate
+// limiting or a temporary server error.
+func isTransient(err error) bool {
+	ae, ok := err.(*googleapi.Error)
+	if !ok {
+		return false
+	}
+	switch ae.Code {
+	case http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
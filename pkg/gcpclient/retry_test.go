@@ -0,0 +1,102 @@
+package gcpclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/googleapi"
+)
+
+func TestCall(t *testing.T) {
+	retryBackoff.Duration = time.Millisecond
+	retryBackoff.Cap = time.Millisecond
+
+	tests := []struct {
+		name          string
+		errs          []error
+		expectedCalls int
+		expectErr     bool
+	}{
+		{
+			name:          "success",
+			expectedCalls: 1,
+		},
+		{
+			name:          "retried after transient errors",
+			errs:          []error{&googleapi.Error{Code: http.StatusTooManyRequests}, &googleapi.Error{Code: http.StatusServiceUnavailable}},
+			expectedCalls: 3,
+		},
+		{
+			name:          "not retried after other errors",
+			errs:          []error{&googleapi.Error{Code: http.StatusNotFound}},
+			expectedCalls: 1,
+			expectErr:     true,
+		},
+		{
+			name:          "not retried after non-API errors",
+			errs:          []error{errors.New("boom")},
+			expectedCalls: 1,
+			expectErr:     true,
+		},
+		{
+			name: "retries exhausted",
+			errs: func() []error {
+				errs := make([]error, maxRetries+1)
+				for i := range errs {
+					errs[i] = &googleapi.Error{Code: http.StatusInternalServerError}
+				}
+				return errs
+			}(),
+			expectedCalls: maxRetries + 1,
+			expectErr:     true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			calls := 0
+			err := call(context.TODO(), "Test", func(ctx context.Context) error {
+				_, hasDeadline := ctx.Deadline()
+				assert.True(t, hasDeadline, "expected the call to have a deadline")
+				calls++
+				if calls <= len(test.errs) {
+					return test.errs[calls-1]
+				}
+				return nil
+			})
+			if test.expectErr {
+				assert.Error(t, err, "expected error from call")
+			} else {
+				assert.NoError(t, err, "unexpected error from call")
+			}
+			assert.Equal(t, test.expectedCalls, calls, "unexpected number of calls")
+		})
+	}
+}
+
+func TestCallOnce(t *testing.T) {
+	calls := 0
+	err := callOnce(context.TODO(), "Test", func(ctx context.Context) error {
+		_, hasDeadline := ctx.Deadline()
+		assert.True(t, hasDeadline, "expected the call to have a deadline")
+		calls++
+		return &googleapi.Error{Code: http.StatusServiceUnavailable}
+	})
+	assert.Error(t, err, "expected error from call")
+	assert.Equal(t, 1, calls, "expected the call not to be retried")
+}
+
+func TestCallCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	err := call(ctx, "Test", func(ctx context.Context) error {
+		calls++
+		cancel()
+		return &googleapi.Error{Code: http.StatusServiceUnavailable}
+	})
+	assert.Error(t, err, "expected error from call")
+	assert.Equal(t, 1, calls, "expected the call not to be retried after the context was canceled")
+}
@@ -19,6 +19,10 @@ import (
 const (
 	// ImagesetJobLabel is the label used for counting the number of imageset jobs in Hive
 	ImagesetJobLabel = "hive.openshift.io/imageset"
+
+	// ImageSetReleaseImageAnnotation is the annotation on the validation job of a ClusterImageSet with the release
+	// image being validated.
+	ImageSetReleaseImageAnnotation = "hive.openshift.io/release-image"
 )
 
 // GenerateImageSetJob creates a job to determine the installer image for a ClusterImageSet
@@ -127,3 +131,95 @@ func GenerateImageSetJob(cd *hivev1.ClusterDeployment, releaseImage, serviceAcco
 func GetImageSetJobName(cdName string) string {
 	return apihelpers.GetResourceName(cdName, "imageset")
 }
+
+// GenerateImageSetValidationJob creates a job in the given namespace to validate the release image of a
+// ClusterImageSet. The job reports the result of the validation as an ImageSetValidationResult in the termination
+// message of its hiveutil container.
+func GenerateImageSetValidationJob(imageSet *hivev1.ClusterImageSet, namespace, pullSecretName string) *batchv1.Job {
+	log.WithField("clusterimageset", imageSet.Name).Debug("generating cluster image set validation job")
+
+	volumeMounts := []corev1.VolumeMount{
+		{
+			Name:      "common",
+			MountPath: "/common",
+		},
+	}
+
+	podSpec := corev1.PodSpec{
+		RestartPolicy: corev1.RestartPolicyNever,
+		InitContainers: []corev1.Container{
+			{
+				Name:            "release",
+				Image:           imageSet.Spec.ReleaseImage,
+				ImagePullPolicy: corev1.PullIfNotPresent,
+				Command:         []string{"/bin/sh", "-c"},
+				Args:            []string{"cp -v /release-manifests/image-references /release-manifests/release-metadata  /common/"},
+				VolumeMounts:    volumeMounts,
+			},
+		},
+		Containers: []corev1.Container{
+			{
+				Name:            "hiveutil",
+				Image:           images.GetHiveImage(),
+				ImagePullPolicy: images.GetHiveImagePullPolicy(),
+				Command:         []string{"/usr/bin/hiveutil"},
+				Args: []string{
+					"validate-image-set",
+					"--work-dir",
+					"/common",
+					"--log-level",
+					"debug",
+				},
+				VolumeMounts:             volumeMounts,
+				TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+			},
+		},
+		Volumes: []corev1.Volume{
+			{
+				Name: "common",
+				VolumeSource: corev1.VolumeSource{
+					EmptyDir: &corev1.EmptyDirVolumeSource{},
+				},
+			},
+		},
+	}
+	if pullSecretName != "" {
+		podSpec.ImagePullSecrets = []corev1.LocalObjectReference{{Name: pullSecretName}}
+	}
+
+	completions := int32(1)
+	// Unlike the imageset job of a ClusterDeployment, the pods of a failed validation are kept so that the cause of
+	// the failure can be reported, so only retry a couple of times.
+	deadline := int64((5 * time.Minute).Seconds())
+	backoffLimit := int32(2)
+	labels := map[string]string{
+		ImagesetJobLabel: "true",
+	}
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      GetImageSetValidationJobName(imageSet.Name),
+			Namespace: namespace,
+			Labels:    labels,
+			Annotations: map[string]string{
+				ImageSetReleaseImageAnnotation: imageSet.Spec.ReleaseImage,
+			},
+		},
+		Spec: batchv1.JobSpec{
+			Completions:           &completions,
+			ActiveDeadlineSeconds: &deadline,
+			BackoffLimit:          &backoffLimit,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: podSpec,
+			},
+		},
+	}
+}
+
+// GetImageSetValidationJobName returns the expected name of the validation job for a ClusterImageSet.
+func GetImageSetValidationJobName(imageSetName string) string {
+	return apihelpers.GetResourceName(imageSetName, "validate")
+}
@@ -53,3 +53,28 @@ func hasVolume(job *batchv1.Job, name string) bool {
 	}
 	return false
 }
+
+func TestGenerateImageSetValidationJob(t *testing.T) {
+	job := GenerateImageSetValidationJob(testImageSet(), "hive", "global-pull-secret")
+	if job.Name != GetImageSetValidationJobName(testImageSet().Name) {
+		t.Errorf("unexpected job name: %s", job.Name)
+	}
+	if job.Namespace != "hive" {
+		t.Errorf("unexpected job namespace: %s", job.Namespace)
+	}
+	if job.Annotations[ImageSetReleaseImageAnnotation] != testImageSet().Spec.ReleaseImage {
+		t.Errorf("unexpected release image annotation: %s", job.Annotations[ImageSetReleaseImageAnnotation])
+	}
+	if len(job.Spec.Template.Spec.InitContainers) != 1 || job.Spec.Template.Spec.InitContainers[0].Image != testImageSet().Spec.ReleaseImage {
+		t.Errorf("unexpected init containers")
+	}
+	if len(job.Spec.Template.Spec.Containers) != 1 {
+		t.Errorf("unexpected number of containers")
+	}
+	if ps := job.Spec.Template.Spec.ImagePullSecrets; len(ps) != 1 || ps[0].Name != "global-pull-secret" {
+		t.Errorf("unexpected image pull secrets: %v", ps)
+	}
+	if !hasVolume(job, "common") {
+		t.Errorf("missing common volume")
+	}
+}
@@ -279,6 +279,8 @@ type cincinnatiMetadata struct {
 	Kind string `json:"kind"`
 
 	Version string `json:"version"`
+
+	Metadata map[string]string `json:"metadata,omitempty"`
 }
 
 func getReleaseVersion(releaseMetadata *cincinnatiMetadata, is *imageapi.ImageStream) string {
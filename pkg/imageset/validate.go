@@ -0,0 +1,203 @@
+package imageset
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+
+	imageapi "github.com/openshift/api/image/v1"
+)
+
+const (
+	// MultiArchitecture is the architecture reported for release images of several architectures.
+	MultiArchitecture = "multi"
+
+	releaseArchitectureMetadataKey = "release.openshift.io/architecture"
+)
+
+// requiredImageTags are the tags of the images of a release image that are needed to install a cluster.
+var requiredImageTags = []string{"installer", "cli"}
+
+// ImageSetValidationResult is the result of the validation of the release image of a ClusterImageSet, as reported
+// in the termination message of the validation job.
+type ImageSetValidationResult struct {
+	Version        string `json:"version,omitempty"`
+	Architecture   string `json:"architecture,omitempty"`
+	InstallerImage string `json:"installerImage,omitempty"`
+	CLIImage       string `json:"cliImage,omitempty"`
+	// Error is why the release image is not valid. It is empty for a valid release image.
+	Error string `json:"error,omitempty"`
+}
+
+// ValidateImageSetOptions contains options for running the command to validate the release image of a
+// ClusterImageSet.
+type ValidateImageSetOptions struct {
+	LogLevel               string
+	WorkDir                string
+	TerminationMessagePath string
+	log                    log.FieldLogger
+}
+
+// NewValidateImageSetCommand returns a command to validate the release manifests extracted from the release image of
+// a ClusterImageSet.
+func NewValidateImageSetCommand() *cobra.Command {
+	opt := &ValidateImageSetOptions{}
+	cmd := &cobra.Command{
+		Use:   "validate-image-set OPTIONS",
+		Short: "Validates the release manifests of a clusterimageset and reports the result in the termination message",
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := opt.Complete(); err != nil {
+				log.WithError(err).Fatal("cannot complete command")
+				return
+			}
+
+			if err := opt.Validate(); err != nil {
+				log.WithError(err).Fatal("invalid command options")
+				return
+			}
+
+			if err := opt.Run(); err != nil {
+				log.WithError(err).Fatal("failed to validate image set")
+			}
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&opt.LogLevel, "log-level", "info", "log level, one of: debug, info, warn, error, fatal, panic")
+	flags.StringVar(&opt.WorkDir, "work-dir", "/common", "directory containing the release manifests")
+	flags.StringVar(&opt.TerminationMessagePath, "termination-message-path", "/dev/termination-log", "file to write the result of the validation to")
+	return cmd
+}
+
+// Complete sets remaining fields on the ValidateImageSetOptions based on command options and arguments.
+func (o *ValidateImageSetOptions) Complete() error {
+	level, err := log.ParseLevel(o.LogLevel)
+	if err != nil {
+		log.WithError(err).Error("cannot parse log level")
+		return err
+	}
+
+	o.log = log.NewEntry(&log.Logger{
+		Out: os.Stdout,
+		Formatter: &log.TextFormatter{
+			FullTimestamp: true,
+		},
+		Hooks: make(log.LevelHooks),
+		Level: level,
+	})
+	return nil
+}
+
+// Validate ensures the given options and arguments are valid.
+func (o *ValidateImageSetOptions) Validate() error {
+	if len(o.WorkDir) == 0 {
+		return errors.New("--work-dir is required")
+	}
+	if len(o.TerminationMessagePath) == 0 {
+		return errors.New("--termination-message-path is required")
+	}
+	fi, err := os.Stat(o.WorkDir)
+	if err != nil {
+		return errors.New("could not access workdir")
+	}
+	if !fi.IsDir() {
+		return errors.New("workdir is not a directory")
+	}
+	return nil
+}
+
+// Run validates the release manifests in the work dir and writes the result to the termination message. An invalid
+// release image is a result rather than an error, so that it is not retried.
+func (o *ValidateImageSetOptions) Run() error {
+	result := validateReleaseManifests(o.WorkDir)
+	if result.Error != "" {
+		o.log.WithField("error", result.Error).Info("release image is not valid")
+	} else {
+		o.log.WithFields(log.Fields{
+			"version":        result.Version,
+			"architecture":   result.Architecture,
+			"installerImage": result.InstallerImage,
+			"cliImage":       result.CLIImage,
+		}).Info("release image is valid")
+	}
+	resultRaw, err := json.Marshal(result)
+	if err != nil {
+		return errors.Wrap(err, "could not marshal validation result")
+	}
+	return errors.Wrap(
+		ioutil.WriteFile(o.TerminationMessagePath, resultRaw, 0644),
+		"could not write validation result",
+	)
+}
+
+// validateReleaseManifests checks that the release manifests in the given dir have a version and the images required
+// for installs.
+func validateReleaseManifests(dir string) *ImageSetValidationResult {
+	result := &ImageSetValidationResult{}
+
+	imageStreamData, err := ioutil.ReadFile(filepath.Join(dir, imageReferencesFilename))
+	if err != nil {
+		result.Error = fmt.Sprintf("could not read %s file of the release image", imageReferencesFilename)
+		return result
+	}
+	is := &imageapi.ImageStream{}
+	if err := yaml.Unmarshal(imageStreamData, &is); err != nil ||
+		is.Kind != "ImageStream" || is.APIVersion != "image.openshift.io/v1" {
+		result.Error = "unrecognized image-references in release payload"
+		return result
+	}
+
+	releaseMetadataRaw, err := ioutil.ReadFile(filepath.Join(dir, releaseMetadataFilename))
+	if err != nil {
+		result.Error = fmt.Sprintf("could not read %s file of the release image", releaseMetadataFilename)
+		return result
+	}
+	releaseMetadata := &cincinnatiMetadata{}
+	if err := json.Unmarshal(releaseMetadataRaw, releaseMetadata); err != nil ||
+		releaseMetadata.Kind != "cincinnati-metadata-v0" {
+		result.Error = "unrecognized release-metadata in release payload"
+		return result
+	}
+
+	result.Version = getReleaseVersion(releaseMetadata, is)
+	// The release manifests were extracted by running the release image on this node, so a release image of a single
+	// architecture is of the architecture of the node.
+	result.Architecture = runtime.GOARCH
+	if releaseMetadata.Metadata[releaseArchitectureMetadataKey] == MultiArchitecture {
+		result.Architecture = MultiArchitecture
+	}
+
+	var missing []string
+	for _, tag := range requiredImageTags {
+		image, err := findImageSpec(is, tag)
+		if err != nil {
+			missing = append(missing, tag)
+			continue
+		}
+		switch tag {
+		case "installer":
+			result.InstallerImage = image
+		case "cli":
+			result.CLIImage = image
+		}
+	}
+
+	var problems []string
+	if result.Version == "" {
+		problems = append(problems, "no release version set in the release payload")
+	}
+	if len(missing) > 0 {
+		problems = append(problems, fmt.Sprintf("release payload is missing the images %s", strings.Join(missing, ", ")))
+	}
+	result.Error = strings.Join(problems, "; ")
+	return result
+}
@@ -0,0 +1,104 @@
+package imageset
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateImageSetCommand(t *testing.T) {
+	tests := []struct {
+		name             string
+		images           map[string]string
+		version          string
+		metadata         map[string]string
+		noImageRefs      bool
+		expectedResult   ImageSetValidationResult
+		expectedErrorMsg string
+	}{
+		{
+			name: "valid",
+			images: map[string]string{
+				"installer": testInstallerImage,
+				"cli":       testCLIImage,
+			},
+			version: testReleaseVersion,
+			expectedResult: ImageSetValidationResult{
+				Version:        testReleaseVersion,
+				Architecture:   runtime.GOARCH,
+				InstallerImage: testInstallerImage,
+				CLIImage:       testCLIImage,
+			},
+		},
+		{
+			name: "valid multi-arch",
+			images: map[string]string{
+				"installer": testInstallerImage,
+				"cli":       testCLIImage,
+			},
+			version:  testReleaseVersion,
+			metadata: map[string]string{releaseArchitectureMetadataKey: MultiArchitecture},
+			expectedResult: ImageSetValidationResult{
+				Version:        testReleaseVersion,
+				Architecture:   MultiArchitecture,
+				InstallerImage: testInstallerImage,
+				CLIImage:       testCLIImage,
+			},
+		},
+		{
+			name:             "missing images",
+			images:           map[string]string{"installer": testInstallerImage},
+			version:          testReleaseVersion,
+			expectedErrorMsg: "release payload is missing the images cli",
+		},
+		{
+			name:             "missing image references",
+			noImageRefs:      true,
+			version:          testReleaseVersion,
+			expectedErrorMsg: "could not read image-references file of the release image",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			workDir, err := ioutil.TempDir("", "test-validate")
+			require.NoError(t, err, "error creating test directory")
+			defer os.RemoveAll(workDir)
+			if !test.noImageRefs {
+				writeImageReferencesFile(t, workDir, test.images)
+			}
+			rm := &cincinnatiMetadata{
+				Kind:     "cincinnati-metadata-v0",
+				Version:  test.version,
+				Metadata: test.metadata,
+			}
+			rmRaw, err := json.Marshal(rm)
+			require.NoError(t, err, "failed to marshal release metadata")
+			require.NoError(t, ioutil.WriteFile(filepath.Join(workDir, releaseMetadataFilename), rmRaw, 0644))
+
+			terminationMessagePath := filepath.Join(workDir, "termination-log")
+			opt := ValidateImageSetOptions{
+				WorkDir:                workDir,
+				TerminationMessagePath: terminationMessagePath,
+				log:                    log.WithField("test", test.name),
+			}
+			require.NoError(t, opt.Run(), "unexpected error running command")
+
+			resultRaw, err := ioutil.ReadFile(terminationMessagePath)
+			require.NoError(t, err, "could not read termination message")
+			result := ImageSetValidationResult{}
+			require.NoError(t, json.Unmarshal(resultRaw, &result), "could not unmarshal termination message")
+			if test.expectedErrorMsg != "" {
+				assert.Equal(t, test.expectedErrorMsg, result.Error, "unexpected validation error")
+				return
+			}
+			assert.Equal(t, test.expectedResult, result, "unexpected validation result")
+		})
+	}
+}
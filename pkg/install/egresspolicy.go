@@ -0,0 +1,203 @@
+package install
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+const (
+	// InstallEgressNetworkPolicyName is the name of the NetworkPolicy restricting the egress of the install and
+	// deprovision pods of a ClusterDeployment.
+	InstallEgressNetworkPolicyName = "hive-install-egress"
+
+	// InstallEgressFirewallName is the name of the EgressFirewall restricting the egress of the pods in the namespace
+	// of a ClusterDeployment. OVN-Kubernetes only honors the EgressFirewall with this name.
+	InstallEgressFirewallName = "default"
+
+	regionPlaceholder = "{region}"
+)
+
+// EgressFirewallGVK is the kind of the OVN-Kubernetes EgressFirewall.
+var EgressFirewallGVK = schema.GroupVersionKind{Group: "k8s.ovn.org", Version: "v1", Kind: "EgressFirewall"}
+
+// platformEgressEndpoints are the DNS names of the cloud APIs install and deprovision pods reach for each platform.
+// The region of the cluster replaces the {region} placeholder.
+var platformEgressEndpoints = map[string][]string{
+	constants.PlatformAWS: {
+		"ec2." + regionPlaceholder + ".amazonaws.com",
+		"elasticloadbalancing." + regionPlaceholder + ".amazonaws.com",
+		"iam.amazonaws.com",
+		"route53.amazonaws.com",
+		"s3.amazonaws.com",
+		"s3." + regionPlaceholder + ".amazonaws.com",
+		"servicequotas." + regionPlaceholder + ".amazonaws.com",
+		"sts.amazonaws.com",
+		"sts." + regionPlaceholder + ".amazonaws.com",
+		"tagging." + regionPlaceholder + ".amazonaws.com",
+		"tagging.us-east-1.amazonaws.com",
+	},
+	constants.PlatformAzure: {
+		"graph.microsoft.com",
+		"graph.windows.net",
+		"login.microsoftonline.com",
+		"management.azure.com",
+	},
+	constants.PlatformGCP: {
+		"cloudresourcemanager.googleapis.com",
+		"compute.googleapis.com",
+		"dns.googleapis.com",
+		"iam.googleapis.com",
+		"oauth2.googleapis.com",
+		"serviceusage.googleapis.com",
+		"storage.googleapis.com",
+		"www.googleapis.com",
+	},
+}
+
+// installEgressPorts are the ports install and deprovision pods reach outside of the hub when the egress is restricted
+// with a NetworkPolicy: HTTPS for cloud APIs and registries, the API of the installed cluster, and SSH for gathering
+// logs from the bootstrap node.
+var installEgressPorts = []int{22, 443, 6443}
+
+// InstallEgressEndpoints returns the sorted DNS names and CIDRs install and deprovision pods of the ClusterDeployment
+// need to reach: the cloud APIs of its platform, the API of the installed cluster, the registry of the release image,
+// and the additional endpoints.
+func InstallEgressEndpoints(cd *hivev1.ClusterDeployment, platform, region, releaseImage string, additionalEndpoints []string) []string {
+	endpoints := sets.NewString(additionalEndpoints...)
+	for _, endpoint := range platformEgressEndpoints[platform] {
+		endpoints.Insert(strings.ReplaceAll(endpoint, regionPlaceholder, region))
+	}
+	if cd.Spec.Platform.VSphere != nil && cd.Spec.Platform.VSphere.VCenter != "" {
+		endpoints.Insert(cd.Spec.Platform.VSphere.VCenter)
+	}
+	if cd.Spec.BaseDomain != "" {
+		endpoints.Insert(fmt.Sprintf("api.%s.%s", cd.Spec.ClusterName, cd.Spec.BaseDomain))
+	}
+	if registry := imageRegistryHost(releaseImage); registry != "" {
+		endpoints.Insert(registry)
+	}
+	return endpoints.List()
+}
+
+// imageRegistryHost returns the host of the registry of the image, without any port.
+func imageRegistryHost(image string) string {
+	i := strings.Index(image, "/")
+	if i < 0 {
+		return ""
+	}
+	host := image[:i]
+	if !strings.ContainsAny(host, ".:") && host != "localhost" {
+		// The image is on the default registry, such as "library/busybox".
+		return ""
+	}
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	return host
+}
+
+// GenerateInstallEgressNetworkPolicy generates a NetworkPolicy restricting the egress of the install and deprovision
+// pods of the ClusterDeployment to DNS, to the ports they need outside of the hub, and to the CIDRs among the
+// endpoints. NetworkPolicies cannot match DNS names, so the other endpoints are not reflected in the policy.
+func GenerateInstallEgressNetworkPolicy(cd *hivev1.ClusterDeployment, endpoints []string) *networkingv1.NetworkPolicy {
+	tcp, udp := corev1.ProtocolTCP, corev1.ProtocolUDP
+	dnsPorts := []networkingv1.NetworkPolicyPort{}
+	for _, port := range []int{53, 5353} {
+		for _, protocol := range []*corev1.Protocol{&tcp, &udp} {
+			p := intstr.FromInt(port)
+			dnsPorts = append(dnsPorts, networkingv1.NetworkPolicyPort{Protocol: protocol, Port: &p})
+		}
+	}
+	egressPorts := []networkingv1.NetworkPolicyPort{}
+	for _, port := range installEgressPorts {
+		p := intstr.FromInt(port)
+		egressPorts = append(egressPorts, networkingv1.NetworkPolicyPort{Protocol: &tcp, Port: &p})
+	}
+	rules := []networkingv1.NetworkPolicyEgressRule{
+		{
+			To:    []networkingv1.NetworkPolicyPeer{{NamespaceSelector: &metav1.LabelSelector{}}},
+			Ports: dnsPorts,
+		},
+		{
+			To:    []networkingv1.NetworkPolicyPeer{{IPBlock: &networkingv1.IPBlock{CIDR: "0.0.0.0/0"}}},
+			Ports: egressPorts,
+		},
+	}
+	var cidrPeers []networkingv1.NetworkPolicyPeer
+	for _, endpoint := range endpoints {
+		if _, _, err := net.ParseCIDR(endpoint); err == nil {
+			cidrPeers = append(cidrPeers, networkingv1.NetworkPolicyPeer{IPBlock: &networkingv1.IPBlock{CIDR: endpoint}})
+		}
+	}
+	if len(cidrPeers) > 0 {
+		rules = append(rules, networkingv1.NetworkPolicyEgressRule{To: cidrPeers})
+	}
+	return &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: cd.Namespace,
+			Name:      InstallEgressNetworkPolicyName,
+			Labels: map[string]string{
+				constants.ClusterDeploymentNameLabel: cd.Name,
+			},
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{{
+					Key:      constants.JobTypeLabel,
+					Operator: metav1.LabelSelectorOpIn,
+					Values:   []string{constants.JobTypeDeprovision, constants.JobTypeProvision},
+				}},
+			},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeEgress},
+			Egress:      rules,
+		},
+	}
+}
+
+// GenerateInstallEgressFirewall generates an EgressFirewall allowing the pods in the namespace of the ClusterDeployment
+// to reach only the endpoints, and denying any other egress out of the hub.
+func GenerateInstallEgressFirewall(cd *hivev1.ClusterDeployment, endpoints []string) *unstructured.Unstructured {
+	sorted := append([]string(nil), endpoints...)
+	sort.Strings(sorted)
+	rules := []interface{}{}
+	for _, endpoint := range sorted {
+		to := map[string]interface{}{"dnsName": endpoint}
+		if _, _, err := net.ParseCIDR(endpoint); err == nil {
+			to = map[string]interface{}{"cidrSelector": endpoint}
+		} else if ip := net.ParseIP(endpoint); ip != nil {
+			bits := 32
+			if ip.To4() == nil {
+				bits = 128
+			}
+			to = map[string]interface{}{"cidrSelector": fmt.Sprintf("%s/%d", endpoint, bits)}
+		}
+		rules = append(rules, map[string]interface{}{"type": "Allow", "to": to})
+	}
+	rules = append(rules, map[string]interface{}{
+		"type": "Deny",
+		"to":   map[string]interface{}{"cidrSelector": "0.0.0.0/0"},
+	})
+
+	firewall := &unstructured.Unstructured{}
+	firewall.SetGroupVersionKind(EgressFirewallGVK)
+	firewall.SetNamespace(cd.Namespace)
+	firewall.SetName(InstallEgressFirewallName)
+	firewall.SetLabels(map[string]string{
+		constants.ClusterDeploymentNameLabel: cd.Name,
+	})
+	firewall.Object["spec"] = map[string]interface{}{"egress": rules}
+	return firewall
+}
@@ -0,0 +1,95 @@
+package install
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1vsphere "github.com/openshift/hive/apis/hive/v1/vsphere"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+func TestInstallEgressEndpoints(t *testing.T) {
+	tests := []struct {
+		name         string
+		platform     string
+		region       string
+		releaseImage string
+		vCenter      string
+		expected     []string
+		unexpected   []string
+	}{
+		{
+			name:         "aws",
+			platform:     constants.PlatformAWS,
+			region:       "eu-west-1",
+			releaseImage: "quay.io/openshift-release-dev/ocp-release:4.6.0",
+			expected: []string{
+				"api.test-cluster.example.com",
+				"ec2.eu-west-1.amazonaws.com",
+				"extra.example.com",
+				"iam.amazonaws.com",
+				"quay.io",
+			},
+			unexpected: []string{"ec2.{region}.amazonaws.com"},
+		},
+		{
+			name:         "mirror registry with port",
+			platform:     constants.PlatformGCP,
+			releaseImage: "mirror.example.com:5000/ocp/release@sha256:abcd",
+			expected: []string{
+				"compute.googleapis.com",
+				"mirror.example.com",
+			},
+			unexpected: []string{"mirror.example.com:5000"},
+		},
+		{
+			name:     "vsphere",
+			platform: constants.PlatformVSphere,
+			vCenter:  "vcenter.example.com",
+			expected: []string{"vcenter.example.com"},
+		},
+		{
+			name:         "image on default registry",
+			platform:     constants.PlatformNone,
+			releaseImage: "library/busybox",
+			unexpected:   []string{"library"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cd := &hivev1.ClusterDeployment{
+				Spec: hivev1.ClusterDeploymentSpec{
+					ClusterName: "test-cluster",
+					BaseDomain:  "example.com",
+				},
+			}
+			if test.vCenter != "" {
+				cd.Spec.Platform.VSphere = &hivev1vsphere.Platform{VCenter: test.vCenter}
+			}
+			endpoints := InstallEgressEndpoints(cd, test.platform, test.region, test.releaseImage, []string{"extra.example.com"})
+			assert.Subset(t, endpoints, test.expected, "missing endpoints")
+			for _, endpoint := range test.unexpected {
+				assert.NotContains(t, endpoints, endpoint, "unexpected endpoint")
+			}
+		})
+	}
+}
+
+func TestGenerateInstallEgressFirewall(t *testing.T) {
+	cd := &hivev1.ClusterDeployment{}
+	cd.Namespace = "test-namespace"
+	cd.Name = "test-cd"
+	firewall := GenerateInstallEgressFirewall(cd, []string{"quay.io", "10.0.0.0/8", "192.168.1.10"})
+	assert.Equal(t, "test-namespace", firewall.GetNamespace(), "unexpected namespace")
+	assert.Equal(t, InstallEgressFirewallName, firewall.GetName(), "unexpected name")
+	assert.Equal(t, "test-cd", firewall.GetLabels()[constants.ClusterDeploymentNameLabel], "unexpected cluster deployment label")
+	expected := []interface{}{
+		map[string]interface{}{"type": "Allow", "to": map[string]interface{}{"cidrSelector": "10.0.0.0/8"}},
+		map[string]interface{}{"type": "Allow", "to": map[string]interface{}{"cidrSelector": "192.168.1.10/32"}},
+		map[string]interface{}{"type": "Allow", "to": map[string]interface{}{"dnsName": "quay.io"}},
+		map[string]interface{}{"type": "Deny", "to": map[string]interface{}{"cidrSelector": "0.0.0.0/0"}},
+	}
+	assert.Equal(t, expected, firewall.Object["spec"].(map[string]interface{})["egress"], "unexpected egress rules")
+}
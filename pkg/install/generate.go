@@ -3,6 +3,7 @@ package install
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
@@ -15,6 +16,7 @@ import (
 
 	apihelpers "github.com/openshift/hive/apis/helpers"
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
 	"github.com/openshift/hive/pkg/constants"
 	"github.com/openshift/hive/pkg/controller/images"
 	"github.com/openshift/hive/pkg/controller/utils"
@@ -38,6 +40,9 @@ const (
 
 	// LibvirtSSHPrivateKeyDir is the directory where the generated Job will mount the libvirt ssh secret to
 	LibvirtSSHPrivateKeyDir = "/libvirtsshkeys"
+
+	// mustGatherJobDeadline is how long a must-gather job may run before it is stopped.
+	mustGatherJobDeadline = 30 * time.Minute
 )
 
 var (
@@ -108,6 +113,17 @@ func InstallerPodSpec(
 				},
 			},
 		},
+		{
+			// The additional trust bundle only exists when the HiveConfig configures additional certificate
+			// authorities for installed clusters.
+			Name: "additionaltrustbundle",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: constants.GetAdditionalTrustBundleName(cd),
+					Optional:   pointer.BoolPtr(true),
+				},
+			},
+		},
 	}
 	volumeMounts := []corev1.VolumeMount{
 		{
@@ -126,6 +142,10 @@ func InstallerPodSpec(
 			Name:      "pullsecret",
 			MountPath: "/pullsecret",
 		},
+		{
+			Name:      "additionaltrustbundle",
+			MountPath: "/additionaltrustbundle",
+		},
 	}
 
 	switch {
@@ -283,6 +303,10 @@ func InstallerPodSpec(
 			},
 		)
 		env = append(env, oVirtCredsEnvVars(cd.Spec.Platform.Ovirt.CredentialsSecretRef.Name)...)
+	case cd.Spec.Platform.OCI != nil:
+		env = append(env, ociCredsEnvVars(cd.Spec.Platform.OCI.CredentialsSecretRef.Name, cd.Spec.Platform.OCI.Region)...)
+	case cd.Spec.Platform.PowerVS != nil:
+		env = append(env, powerVSCredsEnvVars(cd.Spec.Platform.PowerVS.CredentialsSecretRef.Name)...)
 	}
 
 	if releaseImage != "" {
@@ -433,14 +457,35 @@ func InstallerPodSpec(
 		},
 	}
 
-	return &corev1.PodSpec{
+	podSpec := &corev1.PodSpec{
 		DNSPolicy:          corev1.DNSClusterFirst,
 		RestartPolicy:      corev1.RestartPolicyNever,
 		Containers:         containers,
 		Volumes:            volumes,
 		ServiceAccountName: serviceAccountName,
 		ImagePullSecrets:   []corev1.LocalObjectReference{{Name: constants.GetMergedPullSecretName(cd)}},
-	}, nil
+	}
+
+	if installPod := cd.Spec.Provisioning.InstallPod; installPod != nil {
+		if installPod.Resources != nil {
+			for i := range podSpec.Containers {
+				// The hive container runs the installer.
+				if podSpec.Containers[i].Name == "hive" {
+					podSpec.Containers[i].Resources = *installPod.Resources.DeepCopy()
+				}
+			}
+		}
+		for k, v := range installPod.NodeSelector {
+			if podSpec.NodeSelector == nil {
+				podSpec.NodeSelector = map[string]string{}
+			}
+			podSpec.NodeSelector[k] = v
+		}
+		podSpec.Tolerations = append(podSpec.Tolerations, installPod.Tolerations...)
+		podSpec.PriorityClassName = installPod.PriorityClassName
+	}
+
+	return podSpec, nil
 }
 
 // GenerateInstallerJob creates a job to install an OpenShift cluster
@@ -486,11 +531,150 @@ func GetInstallJobName(provision *hivev1.ClusterProvision) string {
 	return apihelpers.GetResourceName(provision.Name, "provision")
 }
 
+// GenerateMustGatherJob creates a job to collect a must-gather from the cluster of a failed cluster provision. The
+// pod of the job is derived from the pod of the install job, with the installer container removed and the install
+// manager replaced by the must-gather command, so that it has the same volumes, environment and CA trust.
+func GenerateMustGatherJob(provision *hivev1.ClusterProvision) (*batchv1.Job, error) {
+
+	pLog := log.WithFields(log.Fields{
+		"clusterProvision": provision.Name,
+		"namespace":        provision.Namespace,
+	})
+
+	pLog.Debug("generating must-gather job")
+
+	podSpec := provision.Spec.PodSpec.DeepCopy()
+	containers := []corev1.Container{}
+	foundHive := false
+	for _, c := range podSpec.Containers {
+		switch c.Name {
+		case "installer":
+			continue
+		case "hive":
+			for i, arg := range c.Args {
+				c.Args[i] = strings.Replace(arg, "hiveutil install-manager", "hiveutil must-gather", 1)
+			}
+			foundHive = true
+		}
+		containers = append(containers, c)
+	}
+	if !foundHive {
+		return nil, fmt.Errorf("install pod spec has no hive container")
+	}
+	podSpec.Containers = containers
+
+	labels := map[string]string{
+		constants.ClusterProvisionNameLabel: provision.Name,
+		constants.MustGatherJobLabel:        "true",
+	}
+	if cdName, ok := provision.Labels[constants.ClusterDeploymentNameLabel]; ok {
+		labels[constants.ClusterDeploymentNameLabel] = cdName
+	}
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      GetMustGatherJobName(provision),
+			Namespace: provision.Namespace,
+			Labels:    labels,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:          pointer.Int32Ptr(0),
+			Completions:           pointer.Int32Ptr(1),
+			ActiveDeadlineSeconds: pointer.Int64Ptr(int64(mustGatherJobDeadline.Seconds())),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: *podSpec,
+			},
+		},
+	}
+
+	return job, nil
+}
+
+// GetMustGatherJobName returns the expected name of the must-gather job for a cluster provision.
+func GetMustGatherJobName(provision *hivev1.ClusterProvision) string {
+	return apihelpers.GetResourceName(provision.Name, "must-gather")
+}
+
 // GetUninstallJobName returns the expected name of the deprovision job for a cluster deployment.
 func GetUninstallJobName(name string) string {
 	return apihelpers.GetResourceName(name, "uninstall")
 }
 
+// GenerateDeprovision generates a deprovision request with the given name for the cloud resources of the cluster
+// deployment tagged with the given infra ID and cluster ID.
+func GenerateDeprovision(cd *hivev1.ClusterDeployment, name, infraID, clusterID string) (*hivev1.ClusterDeprovision, error) {
+	req := &hivev1.ClusterDeprovision{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: cd.Namespace,
+		},
+		Spec: hivev1.ClusterDeprovisionSpec{
+			InfraID:   infraID,
+			ClusterID: clusterID,
+		},
+	}
+
+	switch {
+	case cd.Spec.Platform.AWS != nil:
+		req.Spec.Platform.AWS = &hivev1.AWSClusterDeprovision{
+			Region:               cd.Spec.Platform.AWS.Region,
+			CredentialsSecretRef: &cd.Spec.Platform.AWS.CredentialsSecretRef,
+			STS:                  cd.Spec.Platform.AWS.STS != nil && cd.Spec.Platform.AWS.CredentialsMode == hivev1aws.ManualCredentialsMode,
+		}
+	case cd.Spec.Platform.Azure != nil:
+		req.Spec.Platform.Azure = &hivev1.AzureClusterDeprovision{
+			CredentialsSecretRef: &cd.Spec.Platform.Azure.CredentialsSecretRef,
+		}
+	case cd.Spec.Platform.GCP != nil:
+		req.Spec.Platform.GCP = &hivev1.GCPClusterDeprovision{
+			Region:               cd.Spec.Platform.GCP.Region,
+			CredentialsSecretRef: &cd.Spec.Platform.GCP.CredentialsSecretRef,
+		}
+	case cd.Spec.Platform.OpenStack != nil:
+		req.Spec.Platform.OpenStack = &hivev1.OpenStackClusterDeprovision{
+			Cloud:                 cd.Spec.Platform.OpenStack.Cloud,
+			CredentialsSecretRef:  &cd.Spec.Platform.OpenStack.CredentialsSecretRef,
+			CertificatesSecretRef: cd.Spec.Platform.OpenStack.CertificatesSecretRef,
+		}
+	case cd.Spec.Platform.VSphere != nil:
+		req.Spec.Platform.VSphere = &hivev1.VSphereClusterDeprovision{
+			CredentialsSecretRef:  cd.Spec.Platform.VSphere.CredentialsSecretRef,
+			CertificatesSecretRef: cd.Spec.Platform.VSphere.CertificatesSecretRef,
+			VCenter:               cd.Spec.Platform.VSphere.VCenter,
+		}
+	case cd.Spec.Platform.Ovirt != nil:
+		req.Spec.Platform.Ovirt = &hivev1.OvirtClusterDeprovision{
+			CredentialsSecretRef:  cd.Spec.Platform.Ovirt.CredentialsSecretRef,
+			CertificatesSecretRef: cd.Spec.Platform.Ovirt.CertificatesSecretRef,
+			ClusterID:             cd.Spec.Platform.Ovirt.ClusterID,
+		}
+	case cd.Spec.Platform.OCI != nil:
+		req.Spec.Platform.OCI = &hivev1.OCIClusterDeprovision{
+			Region:               cd.Spec.Platform.OCI.Region,
+			CompartmentID:        cd.Spec.Platform.OCI.CompartmentID,
+			CredentialsSecretRef: cd.Spec.Platform.OCI.CredentialsSecretRef,
+		}
+	case cd.Spec.Platform.PowerVS != nil:
+		req.Spec.Platform.PowerVS = &hivev1.PowerVSClusterDeprovision{
+			Region:               cd.Spec.Platform.PowerVS.Region,
+			Zone:                 cd.Spec.Platform.PowerVS.Zone,
+			ServiceInstanceID:    cd.Spec.Platform.PowerVS.ServiceInstanceID,
+			CredentialsSecretRef: cd.Spec.Platform.PowerVS.CredentialsSecretRef,
+		}
+	case cd.Spec.Platform.None != nil && cd.Spec.Platform.None.Destroyer != nil:
+		req.Spec.Platform.None = &hivev1.NoneClusterDeprovision{
+			Destroyer: *cd.Spec.Platform.None.Destroyer,
+		}
+	default:
+		return nil, errors.New("unsupported cloud provider for deprovision")
+	}
+
+	return req, nil
+}
+
 // GenerateUninstallerJobForDeprovision generates an uninstaller job for a given deprovision request
 func GenerateUninstallerJobForDeprovision(
 	req *hivev1.ClusterDeprovision) (*batchv1.Job, error) {
@@ -538,6 +722,12 @@ func GenerateUninstallerJobForDeprovision(
 		completeVSphereDeprovisionJob(req, job)
 	case req.Spec.Platform.Ovirt != nil:
 		completeOvirtDeprovisionJob(req, job)
+	case req.Spec.Platform.OCI != nil:
+		completeOCIDeprovisionJob(req, job)
+	case req.Spec.Platform.PowerVS != nil:
+		completePowerVSDeprovisionJob(req, job)
+	case req.Spec.Platform.None != nil:
+		completeNoneDeprovisionJob(req, job)
 	default:
 		return nil, errors.New("deprovision requests currently not supported for platform")
 	}
@@ -562,15 +752,25 @@ func completeAWSDeprovisionJob(req *hivev1.ClusterDeprovision, job *batchv1.Job)
 				"debug",
 				"--region",
 				req.Spec.Platform.AWS.Region,
+				"--clusterdeprovision",
+				req.Name,
+				"--clusterdeprovision-namespace",
+				req.Namespace,
 				fmt.Sprintf("kubernetes.io/cluster/%s=owned", req.Spec.InfraID),
 			},
 		},
 	}
+	if req.Spec.Platform.AWS.STS {
+		// Also cleanup the resources created by ccoctl for the AWS Security Token Service, named after the infra ID.
+		containers[0].Args = append(containers[0].Args, "--sts-name", req.Spec.InfraID)
+	}
 	if len(req.Spec.ClusterID) > 0 {
 		// Also cleanup anything with the tag for the legacy cluster ID (credentials still using this for example)
 		containers[0].Args = append(containers[0].Args, fmt.Sprintf("openshiftClusterID=%s", req.Spec.ClusterID))
 	}
 	job.Spec.Template.Spec.Containers = containers
+	// The service account lets the uninstall pod report its progress in the status of the ClusterDeprovision.
+	job.Spec.Template.Spec.ServiceAccountName = utils.ServiceAccountName
 	if len(credentialsSecret) > 0 {
 		containers[0].VolumeMounts = []corev1.VolumeMount{
 			{
@@ -860,6 +1060,119 @@ func completeOvirtDeprovisionJob(req *hivev1.ClusterDeprovision, job *batchv1.Jo
 	job.Spec.Template.Spec.Volumes = volumes
 }
 
+func completeOCIDeprovisionJob(req *hivev1.ClusterDeprovision, job *batchv1.Job) {
+	containers := []corev1.Container{
+		{
+			Name:            "deprovision",
+			Image:           images.GetHiveImage(),
+			ImagePullPolicy: images.GetHiveImagePullPolicy(),
+			Env:             ociCredsEnvVars(req.Spec.Platform.OCI.CredentialsSecretRef.Name, req.Spec.Platform.OCI.Region),
+			Command:         []string{"/usr/bin/hiveutil"},
+			Args: []string{
+				"deprovision",
+				"oci",
+				"--loglevel",
+				"debug",
+				"--region",
+				req.Spec.Platform.OCI.Region,
+				"--compartment-id",
+				req.Spec.Platform.OCI.CompartmentID,
+				req.Spec.InfraID,
+			},
+		},
+	}
+	job.Spec.Template.Spec.Containers = containers
+}
+
+func completePowerVSDeprovisionJob(req *hivev1.ClusterDeprovision, job *batchv1.Job) {
+	containers := []corev1.Container{
+		{
+			Name:            "deprovision",
+			Image:           images.GetHiveImage(),
+			ImagePullPolicy: images.GetHiveImagePullPolicy(),
+			Env:             powerVSCredsEnvVars(req.Spec.Platform.PowerVS.CredentialsSecretRef.Name),
+			Command:         []string{"/usr/bin/hiveutil"},
+			Args: []string{
+				"deprovision",
+				"powervs",
+				"--loglevel",
+				"debug",
+				"--region",
+				req.Spec.Platform.PowerVS.Region,
+				"--zone",
+				req.Spec.Platform.PowerVS.Zone,
+				"--service-instance-id",
+				req.Spec.Platform.PowerVS.ServiceInstanceID,
+				req.Spec.InfraID,
+			},
+		},
+	}
+	job.Spec.Template.Spec.Containers = containers
+}
+
+func completeNoneDeprovisionJob(req *hivev1.ClusterDeprovision, job *batchv1.Job) {
+	destroyer := req.Spec.Platform.None.Destroyer
+	env := []corev1.EnvVar{
+		{Name: constants.DestroyerInfraIDEnvVar, Value: req.Spec.InfraID},
+		{Name: constants.DestroyerClusterIDEnvVar, Value: req.Spec.ClusterID},
+		{Name: constants.DestroyerClusterDeploymentNameEnvVar, Value: req.Name},
+		{Name: constants.DestroyerClusterDeploymentNamespaceEnvVar, Value: req.Namespace},
+	}
+
+	if destroyerJob := destroyer.Job; destroyerJob != nil {
+		job.Spec.Template.Spec.ServiceAccountName = destroyerJob.ServiceAccountName
+		job.Spec.Template.Spec.Containers = []corev1.Container{
+			{
+				Name:    "deprovision",
+				Image:   destroyerJob.Image,
+				Command: destroyerJob.Command,
+				Args:    destroyerJob.Args,
+				Env:     append(env, destroyerJob.Env...),
+			},
+		}
+		return
+	}
+
+	if webhook := destroyer.Webhook; webhook != nil {
+		if webhook.TokenSecretRef != nil {
+			env = append(env, corev1.EnvVar{
+				Name: constants.DestroyerWebhookTokenEnvVar,
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: *webhook.TokenSecretRef,
+						Key:                  constants.DestroyerWebhookTokenSecretKey,
+					},
+				},
+			})
+		}
+		args := []string{
+			"deprovision",
+			"external-webhook",
+			"--loglevel",
+			"debug",
+			"--url",
+			webhook.URL,
+			"--cluster-deployment-name",
+			req.Name,
+			"--cluster-deployment-namespace",
+			req.Namespace,
+		}
+		if req.Spec.ClusterID != "" {
+			args = append(args, "--cluster-id", req.Spec.ClusterID)
+		}
+		job.Spec.Template.Spec.Containers = []corev1.Container{
+			{
+				Name:            "deprovision",
+				Image:           images.GetHiveImage(),
+				ImagePullPolicy: images.GetHiveImagePullPolicy(),
+				Env:             env,
+				Command:         []string{"/usr/bin/hiveutil"},
+				Args:            append(args, req.Spec.InfraID),
+			},
+		}
+	}
+}
+
 func vSphereCredsEnvVars(credentialsSecret string) []corev1.EnvVar {
 	env := []corev1.EnvVar{}
 	env = append(
@@ -897,3 +1210,48 @@ func oVirtCredsEnvVars(credentialsSecret string) []corev1.EnvVar {
 	)
 	return env
 }
+
+func ociCredsEnvVars(credentialsSecret, region string) []corev1.EnvVar {
+	env := []corev1.EnvVar{}
+	for _, v := range []struct {
+		name     string
+		key      string
+		optional bool
+	}{
+		{name: constants.OCITenancyEnvVar, key: constants.OCITenancySecretKey},
+		{name: constants.OCIUserEnvVar, key: constants.OCIUserSecretKey},
+		{name: constants.OCIFingerprintEnvVar, key: constants.OCIFingerprintSecretKey},
+		{name: constants.OCIPrivateKeyEnvVar, key: constants.OCIPrivateKeySecretKey},
+		// The passphrase is only needed for encrypted signing keys.
+		{name: constants.OCIPassphraseEnvVar, key: constants.OCIPassphraseSecretKey, optional: true},
+	} {
+		env = append(env, corev1.EnvVar{
+			Name: v.name,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: credentialsSecret},
+					Key:                  v.key,
+					Optional:             pointer.BoolPtr(v.optional),
+				},
+			},
+		})
+	}
+	return append(env, corev1.EnvVar{
+		Name:  constants.OCIRegionEnvVar,
+		Value: region,
+	})
+}
+
+func powerVSCredsEnvVars(credentialsSecret string) []corev1.EnvVar {
+	return []corev1.EnvVar{
+		{
+			Name: constants.PowerVSAPIKeyEnvVar,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: credentialsSecret},
+					Key:                  constants.PowerVSAPIKeySecretKey,
+				},
+			},
+		},
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1none "github.com/openshift/hive/apis/hive/v1/none"
+	"github.com/openshift/hive/pkg/constants"
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
@@ -27,6 +29,86 @@ func TestGenerateDeprovision(t *testing.T) {
 	assert.NotNil(t, job)
 }
 
+func TestGenerateNoneDeprovision(t *testing.T) {
+	tests := []struct {
+		name            string
+		destroyer       hivev1none.ExternalDestroyer
+		expectedImage   string
+		expectedEnvVars []string
+		expectedArgs    []string
+	}{
+		{
+			name: "job destroyer",
+			destroyer: hivev1none.ExternalDestroyer{
+				Job: &hivev1none.DestroyerJob{
+					Image:   "example.com/destroyer:latest",
+					Command: []string{"/destroy"},
+					Env:     []corev1.EnvVar{{Name: "EXTRA", Value: "value"}},
+				},
+			},
+			expectedImage: "example.com/destroyer:latest",
+			expectedEnvVars: []string{
+				constants.DestroyerInfraIDEnvVar,
+				constants.DestroyerClusterIDEnvVar,
+				constants.DestroyerClusterDeploymentNameEnvVar,
+				constants.DestroyerClusterDeploymentNamespaceEnvVar,
+				"EXTRA",
+			},
+		},
+		{
+			name: "webhook destroyer",
+			destroyer: hivev1none.ExternalDestroyer{
+				Webhook: &hivev1none.DestroyerWebhook{
+					URL:            "https://destroyer.example.com",
+					TokenSecretRef: &corev1.LocalObjectReference{Name: "token"},
+				},
+			},
+			expectedEnvVars: []string{
+				constants.DestroyerInfraIDEnvVar,
+				constants.DestroyerClusterIDEnvVar,
+				constants.DestroyerClusterDeploymentNameEnvVar,
+				constants.DestroyerClusterDeploymentNamespaceEnvVar,
+				constants.DestroyerWebhookTokenEnvVar,
+			},
+			expectedArgs: []string{
+				"deprovision", "external-webhook", "--loglevel", "debug",
+				"--url", "https://destroyer.example.com",
+				"--cluster-deployment-name", "foo",
+				"--cluster-deployment-namespace", "default",
+				"--cluster-id", "test-cluster-id",
+				"test-infra-id",
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dr := testClusterDeprovision()
+			dr.Spec.Platform = hivev1.ClusterDeprovisionPlatform{
+				None: &hivev1.NoneClusterDeprovision{Destroyer: test.destroyer},
+			}
+			job, err := GenerateUninstallerJobForDeprovision(dr)
+			if !assert.NoError(t, err) {
+				return
+			}
+			containers := job.Spec.Template.Spec.Containers
+			if !assert.Len(t, containers, 1) {
+				return
+			}
+			if test.expectedImage != "" {
+				assert.Equal(t, test.expectedImage, containers[0].Image, "unexpected image")
+			}
+			var envVars []string
+			for _, e := range containers[0].Env {
+				envVars = append(envVars, e.Name)
+			}
+			assert.Equal(t, test.expectedEnvVars, envVars, "unexpected env vars")
+			if test.expectedArgs != nil {
+				assert.Equal(t, test.expectedArgs, containers[0].Args, "unexpected args")
+			}
+		})
+	}
+}
+
 func testClusterDeprovision() *hivev1.ClusterDeprovision {
 	return &hivev1.ClusterDeprovision{
 		ObjectMeta: metav1.ObjectMeta{
@@ -93,6 +175,48 @@ func TestInstallerPodSpec(t *testing.T) {
 				assert.NoError(t, actualError)
 			},
 		},
+		{
+			name: "Test Provision Pod Overrides",
+			clusterDeployment: &hivev1.ClusterDeployment{
+				Spec: hivev1.ClusterDeploymentSpec{
+					Provisioning: &hivev1.Provisioning{
+						InstallConfigSecretRef: &corev1.LocalObjectReference{Name: "foo"},
+						InstallPod: &hivev1.InstallPodConfig{
+							Resources: &corev1.ResourceRequirements{
+								Requests: corev1.ResourceList{
+									corev1.ResourceCPU:    resource.MustParse("2"),
+									corev1.ResourceMemory: resource.MustParse("4Gi"),
+								},
+							},
+							NodeSelector: map[string]string{"node-role.kubernetes.io/infra": ""},
+							Tolerations: []corev1.Toleration{{
+								Key:      "node-role.kubernetes.io/infra",
+								Operator: corev1.TolerationOpExists,
+								Effect:   corev1.TaintEffectNoSchedule,
+							}},
+							PriorityClassName: "install-critical",
+						},
+					},
+				},
+				Status: hivev1.ClusterDeploymentStatus{
+					InstallerImage: &installerImage,
+					CLIImage:       &cliImage,
+				},
+			},
+			provisionName: "testprovision",
+			validate: func(t *testing.T, actualPodSpec *corev1.PodSpec, actualError error) {
+				assert.NoError(t, actualError)
+				assert.Equal(t, "hive", actualPodSpec.Containers[2].Name, "unexpected container")
+				assert.Equal(t, corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("2"),
+					corev1.ResourceMemory: resource.MustParse("4Gi"),
+				}, actualPodSpec.Containers[2].Resources.Requests, "Incorrect pod resource requests")
+				assert.Empty(t, actualPodSpec.Containers[0].Resources.Requests, "unexpected installer container resource requests")
+				assert.Equal(t, map[string]string{"node-role.kubernetes.io/infra": ""}, actualPodSpec.NodeSelector, "unexpected node selector")
+				assert.Len(t, actualPodSpec.Tolerations, 1, "unexpected tolerations")
+				assert.Equal(t, "install-critical", actualPodSpec.PriorityClassName, "unexpected priority class")
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -111,3 +235,49 @@ func TestInstallerPodSpec(t *testing.T) {
 		})
 	}
 }
+
+func TestGenerateMustGatherJob(t *testing.T) {
+	cd := &hivev1.ClusterDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"},
+		Spec: hivev1.ClusterDeploymentSpec{
+			Provisioning: &hivev1.Provisioning{
+				InstallConfigSecretRef: &corev1.LocalObjectReference{Name: "foo"},
+			},
+		},
+		Status: hivev1.ClusterDeploymentStatus{
+			InstallerImage: &installerImage,
+			CLIImage:       &cliImage,
+		},
+	}
+	podSpec, err := InstallerPodSpec(cd, "foo-0-abcde", "", "cluster-installer", nil)
+	if !assert.NoError(t, err, "unexpected error generating installer pod spec") {
+		return
+	}
+	provision := &hivev1.ClusterProvision{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo-0-abcde",
+			Namespace: "default",
+			Labels:    map[string]string{constants.ClusterDeploymentNameLabel: "foo"},
+		},
+		Spec: hivev1.ClusterProvisionSpec{PodSpec: *podSpec},
+	}
+
+	job, err := GenerateMustGatherJob(provision)
+	if !assert.NoError(t, err, "unexpected error generating must-gather job") {
+		return
+	}
+	assert.Equal(t, "foo-0-abcde-must-gather", job.Name, "unexpected job name")
+	assert.Equal(t, "true", job.Labels[constants.MustGatherJobLabel], "expected must-gather job label")
+	assert.Equal(t, "foo", job.Labels[constants.ClusterDeploymentNameLabel], "expected cluster deployment name label")
+	assert.NotContains(t, job.Labels, constants.InstallJobLabel, "unexpected install job label")
+	assert.NotContains(t, provision.Labels, constants.MustGatherJobLabel, "provision labels should not be modified")
+
+	containers := job.Spec.Template.Spec.Containers
+	if assert.Len(t, containers, 2, "expected the installer container to be removed") {
+		assert.Equal(t, "cli", containers[0].Name, "unexpected container")
+		assert.Equal(t, "hive", containers[1].Name, "unexpected container")
+		assert.Equal(t, []string{"/usr/bin/hiveutil must-gather --work-dir /output --log-level debug default foo-0-abcde"}, containers[1].Args, "unexpected hive container args")
+	}
+	assert.Equal(t, "hive", provision.Spec.PodSpec.Containers[2].Name, "provision pod spec should not be modified")
+	assert.Contains(t, provision.Spec.PodSpec.Containers[2].Args[0], "install-manager", "provision pod spec should not be modified")
+}
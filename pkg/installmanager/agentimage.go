@@ -0,0 +1,269 @@
+package installmanager
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/pkg/errors"
+
+	installertypes "github.com/openshift/installer/pkg/types"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1agent "github.com/openshift/hive/apis/hive/v1/agent"
+	awsclient "github.com/openshift/hive/pkg/awsclient"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	agentConfigFileName = "agent-config.yaml"
+
+	// installerStateFileName is the installer's asset store, from which the cluster IDs are read as the agent
+	// installer does not write a metadata.json.
+	installerStateFileName = ".openshift_install_state.json"
+	installerClusterIDKey  = "*installconfig.ClusterID"
+
+	// pxeArtifactsDir is the directory the agent installer writes PXE boot artifacts to.
+	pxeArtifactsDir = "boot-artifacts"
+
+	defaultAgentImageInstallTimeout = 24 * time.Hour
+	defaultArtifactURLExpiration    = 24 * time.Hour
+)
+
+// agentImageInstallStrategy returns the agent image install strategy of the ClusterDeployment, or nil if
+// the cluster is not installed from agent installer boot artifacts.
+func agentImageInstallStrategy(cd *hivev1.ClusterDeployment) *hivev1agent.ImageInstallStrategy {
+	if cd.Spec.Provisioning == nil || cd.Spec.Provisioning.InstallStrategy == nil {
+		return nil
+	}
+	return cd.Spec.Provisioning.InstallStrategy.AgentImage
+}
+
+// writeAgentConfig copies the agent-config.yaml for the cluster hosts into the work dir.
+func (m *InstallManager) writeAgentConfig(strategy *hivev1agent.ImageInstallStrategy) error {
+	secret := &corev1.Secret{}
+	if err := m.DynamicClient.Get(context.Background(),
+		types.NamespacedName{Namespace: m.Namespace, Name: strategy.AgentConfigSecretRef.Name}, secret); err != nil {
+		return errors.Wrap(err, "error reading agent config secret")
+	}
+	agentConfig, ok := secret.Data[agentConfigFileName]
+	if !ok {
+		return fmt.Errorf("secret %s does not contain key %s", secret.Name, agentConfigFileName)
+	}
+	return ioutil.WriteFile(filepath.Join(m.WorkDir, agentConfigFileName), agentConfig, 0644)
+}
+
+// generateAgentImageAssets runs the agent installer to generate the boot artifacts for the cluster hosts.
+func (m *InstallManager) generateAgentImageAssets(strategy *hivev1agent.ImageInstallStrategy) error {
+	command := "image"
+	if strategy.ArtifactType == hivev1agent.ImageArtifactTypePXE {
+		command = "pxe-files"
+	}
+	m.log.Infof("running openshift-install agent create %s", command)
+	if err := m.runOpenShiftInstallCommand("agent", "create", command); err != nil {
+		m.log.WithError(err).Error("error generating agent boot artifacts")
+		return err
+	}
+	if err := m.writeAgentClusterMetadata(); err != nil {
+		m.log.WithError(err).Error("error writing cluster metadata")
+		return err
+	}
+	m.log.Info("agent boot artifacts generated successfully")
+	return nil
+}
+
+// writeAgentClusterMetadata writes the metadata.json the rest of the install relies on, using the cluster IDs
+// the agent installer recorded in its asset store.
+func (m *InstallManager) writeAgentClusterMetadata() error {
+	metadataPath := filepath.Join(m.WorkDir, metadataRelativePath)
+	if _, err := os.Stat(metadataPath); err == nil {
+		return nil
+	}
+
+	stateBytes, err := ioutil.ReadFile(filepath.Join(m.WorkDir, installerStateFileName))
+	if err != nil {
+		return errors.Wrap(err, "error reading installer state")
+	}
+	state := map[string]json.RawMessage{}
+	if err := json.Unmarshal(stateBytes, &state); err != nil {
+		return errors.Wrap(err, "error unmarshalling installer state")
+	}
+	clusterID := struct {
+		UUID    string
+		InfraID string
+	}{}
+	rawClusterID, ok := state[installerClusterIDKey]
+	if !ok {
+		return fmt.Errorf("installer state does not contain %s", installerClusterIDKey)
+	}
+	if err := json.Unmarshal(rawClusterID, &clusterID); err != nil {
+		return errors.Wrap(err, "error unmarshalling cluster ID from installer state")
+	}
+
+	metadataBytes, err := json.Marshal(&installertypes.ClusterMetadata{
+		ClusterName: m.ClusterName,
+		ClusterID:   clusterID.UUID,
+		InfraID:     clusterID.InfraID,
+	})
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(metadataPath, metadataBytes, 0644)
+}
+
+// provisionAgentImageCluster publishes the agent installer boot artifacts and waits for the hosts booted
+// from them to install the cluster.
+func provisionAgentImageCluster(m *InstallManager, provision *hivev1.ClusterProvision, cd *hivev1.ClusterDeployment) error {
+	strategy := agentImageInstallStrategy(cd)
+
+	awsClient, err := getAWSClient(m.DynamicClient, strategy.S3.CredentialsSecretRef.Name, m.Namespace, strategy.S3.Region, m.log)
+	if err != nil {
+		return errors.Wrap(err, "error creating AWS client for artifact upload")
+	}
+	artifacts, err := m.uploadAgentImageArtifacts(provision, strategy, awsClient)
+	if err != nil {
+		m.log.WithError(err).Error("error uploading agent boot artifacts")
+		return errors.Wrap(err, "error uploading agent boot artifacts")
+	}
+	if err := m.setProvisionArtifacts(provision, artifacts); err != nil {
+		return err
+	}
+
+	timeout := defaultAgentImageInstallTimeout
+	if strategy.InstallTimeout != nil {
+		timeout = strategy.InstallTimeout.Duration
+	}
+	deadline := time.Now().Add(timeout)
+	m.log.WithField("timeout", timeout).Info("waiting for hosts to be booted and install to complete")
+	// The installer gives up waiting on its own long before hosts at remote sites are likely to be booted,
+	// so keep waiting until our own timeout expires.
+	err = m.runOpenShiftInstallCommand("agent", "wait-for", "install-complete")
+	for err != nil && time.Now().Before(deadline) {
+		m.log.WithError(err).Warn("waiting longer for install to complete")
+		err = m.runOpenShiftInstallCommand("agent", "wait-for", "install-complete")
+	}
+	if err != nil {
+		m.log.WithError(err).Error("error waiting for install to complete")
+		return err
+	}
+	return nil
+}
+
+// uploadAgentImageArtifacts uploads the generated boot artifacts to the S3 bucket and returns pre-signed URLs
+// they can be downloaded from.
+func (m *InstallManager) uploadAgentImageArtifacts(provision *hivev1.ClusterProvision, strategy *hivev1agent.ImageInstallStrategy, awsClient awsclient.Client) ([]hivev1.ClusterProvisionArtifact, error) {
+	paths, err := m.agentImageArtifactPaths(strategy.ArtifactType)
+	if err != nil {
+		return nil, err
+	}
+
+	expiration := defaultArtifactURLExpiration
+	if strategy.S3.URLExpiration != nil {
+		expiration = strategy.S3.URLExpiration.Duration
+	}
+
+	var artifacts []hivev1.ClusterProvisionArtifact
+	for _, path := range paths {
+		name := filepath.Base(path)
+		key := fmt.Sprintf("%s/%s/%s", provision.Namespace, provision.Name, name)
+		logger := m.log.WithField("artifact", name).WithField("bucket", strategy.S3.Bucket).WithField("key", key)
+
+		logger.Info("uploading boot artifact")
+		if err := uploadFileToS3(awsClient, strategy.S3.Bucket, key, path); err != nil {
+			return nil, errors.Wrapf(err, "error uploading %s", name)
+		}
+
+		req, _ := awsClient.GetS3API().GetObjectRequest(&s3.GetObjectInput{
+			Bucket: aws.String(strategy.S3.Bucket),
+			Key:    aws.String(key),
+		})
+		url, err := req.Presign(expiration)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error signing download URL for %s", name)
+		}
+		expirationTime := metav1.NewTime(time.Now().Add(expiration))
+		artifacts = append(artifacts, hivev1.ClusterProvisionArtifact{
+			Name:           name,
+			URL:            url,
+			ExpirationTime: &expirationTime,
+		})
+	}
+	return artifacts, nil
+}
+
+func uploadFileToS3(awsClient awsclient.Client, bucket, key, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	_, err = awsClient.Upload(&s3manager.UploadInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+		Body:   file,
+	})
+	return err
+}
+
+// agentImageArtifactPaths returns the paths of the boot artifacts generated by the agent installer.
+func (m *InstallManager) agentImageArtifactPaths(artifactType hivev1agent.ImageArtifactType) ([]string, error) {
+	var paths []string
+	if artifactType == hivev1agent.ImageArtifactTypePXE {
+		files, err := ioutil.ReadDir(filepath.Join(m.WorkDir, pxeArtifactsDir))
+		if err != nil {
+			return nil, errors.Wrap(err, "error reading PXE boot artifacts")
+		}
+		for _, f := range files {
+			if !f.IsDir() {
+				paths = append(paths, filepath.Join(m.WorkDir, pxeArtifactsDir, f.Name()))
+			}
+		}
+	} else {
+		var err error
+		paths, err = filepath.Glob(filepath.Join(m.WorkDir, "agent.*.iso"))
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(paths) == 0 {
+		return nil, errors.New("the agent installer did not generate any boot artifacts")
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// setProvisionArtifacts records the published artifacts in the ClusterProvision status.
+func (m *InstallManager) setProvisionArtifacts(provision *hivev1.ClusterProvision, artifacts []hivev1.ClusterProvisionArtifact) error {
+	err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		if err := m.loadClusterProvision(provision); err != nil {
+			return err
+		}
+		provision.Status.Artifacts = artifacts
+		provision.Status.Conditions = controllerutils.SetClusterProvisionCondition(
+			provision.Status.Conditions,
+			hivev1.ClusterProvisionArtifactsPublishedCondition,
+			corev1.ConditionTrue,
+			"ArtifactsPublished",
+			"Boot artifacts have been uploaded, boot the cluster hosts from them to install the cluster",
+			controllerutils.UpdateConditionIfReasonOrMessageChange,
+		)
+		return m.DynamicClient.Status().Update(context.Background(), provision)
+	})
+	if err != nil {
+		m.log.WithError(err).Error("error recording published artifacts on clusterprovision")
+	}
+	return err
+}
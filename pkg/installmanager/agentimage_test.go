@@ -0,0 +1,206 @@
+package installmanager
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/golang/mock/gomock"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	installertypes "github.com/openshift/installer/pkg/types"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+
+	"github.com/openshift/hive/apis"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1agent "github.com/openshift/hive/apis/hive/v1/agent"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const testAgentImageBucket = "agent-artifacts"
+
+func TestWriteAgentClusterMetadata(t *testing.T) {
+	cases := []struct {
+		name            string
+		state           string
+		existing        string
+		expectErr       bool
+		expectedInfraID string
+	}{
+		{
+			name:            "metadata from installer state",
+			state:           `{"*installconfig.ClusterID":{"UUID":"fake-uuid","InfraID":"mycluster-abcde"}}`,
+			expectedInfraID: "mycluster-abcde",
+		},
+		{
+			name:            "existing metadata is kept",
+			existing:        `{"clusterName":"mycluster","clusterID":"other-uuid","infraID":"mycluster-fghij"}`,
+			expectedInfraID: "mycluster-fghij",
+		},
+		{
+			name:      "missing cluster ID",
+			state:     `{"*installconfig.InstallConfig":{}}`,
+			expectErr: true,
+		},
+		{
+			name:      "missing installer state",
+			expectErr: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			workDir, err := ioutil.TempDir("", "agentimage")
+			require.NoError(t, err)
+			defer os.RemoveAll(workDir)
+			if tc.state != "" {
+				require.NoError(t, ioutil.WriteFile(filepath.Join(workDir, installerStateFileName), []byte(tc.state), 0644))
+			}
+			if tc.existing != "" {
+				require.NoError(t, ioutil.WriteFile(filepath.Join(workDir, metadataRelativePath), []byte(tc.existing), 0644))
+			}
+			m := &InstallManager{
+				log:         log.WithField("test", tc.name),
+				WorkDir:     workDir,
+				ClusterName: "mycluster",
+			}
+
+			err = m.writeAgentClusterMetadata()
+			if tc.expectErr {
+				assert.Error(t, err, "expected error writing cluster metadata")
+				return
+			}
+			require.NoError(t, err, "unexpected error writing cluster metadata")
+			metadataBytes, err := ioutil.ReadFile(filepath.Join(workDir, metadataRelativePath))
+			require.NoError(t, err)
+			md := &installertypes.ClusterMetadata{}
+			require.NoError(t, json.Unmarshal(metadataBytes, md))
+			assert.Equal(t, tc.expectedInfraID, md.InfraID, "unexpected infra ID")
+			assert.Equal(t, "mycluster", md.ClusterName, "unexpected cluster name")
+		})
+	}
+}
+
+func TestUploadAgentImageArtifacts(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+	cases := []struct {
+		name              string
+		artifactType      hivev1agent.ImageArtifactType
+		files             []string
+		expectErr         bool
+		expectedArtifacts []string
+	}{
+		{
+			name:              "iso",
+			files:             []string{"agent.x86_64.iso", "install-config.yaml"},
+			expectedArtifacts: []string{"agent.x86_64.iso"},
+		},
+		{
+			name:         "pxe",
+			artifactType: hivev1agent.ImageArtifactTypePXE,
+			files: []string{
+				filepath.Join(pxeArtifactsDir, "agent.x86_64-vmlinuz"),
+				filepath.Join(pxeArtifactsDir, "agent.x86_64-initrd.img"),
+				filepath.Join(pxeArtifactsDir, "agent.x86_64-rootfs.img"),
+				"agent.x86_64.iso",
+			},
+			expectedArtifacts: []string{"agent.x86_64-initrd.img", "agent.x86_64-rootfs.img", "agent.x86_64-vmlinuz"},
+		},
+		{
+			name:      "no artifacts",
+			files:     []string{"install-config.yaml"},
+			expectErr: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			workDir, err := ioutil.TempDir("", "agentimage")
+			require.NoError(t, err)
+			defer os.RemoveAll(workDir)
+			for _, f := range tc.files {
+				path := filepath.Join(workDir, f)
+				require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+				require.NoError(t, ioutil.WriteFile(path, []byte("artifact"), 0644))
+			}
+
+			provision := testClusterProvision()
+			mocks := setupDefaultMocks(t, provision)
+			defer mocks.mockCtrl.Finish()
+			// Pre-signing URLs does not make any API calls, so a real S3 client can be used.
+			s3Client := s3.New(session.Must(session.NewSession(&aws.Config{
+				Region:      aws.String("us-east-1"),
+				Credentials: credentials.NewStaticCredentials("fake-id", "fake-secret", ""),
+			})))
+			mocks.mockAWSClient.EXPECT().GetS3API().Return(s3Client).AnyTimes()
+			uploadedKeys := []string{}
+			mocks.mockAWSClient.EXPECT().Upload(gomock.Any()).DoAndReturn(func(input *s3manager.UploadInput) (*s3manager.UploadOutput, error) {
+				assert.Equal(t, testAgentImageBucket, *input.Bucket, "unexpected bucket")
+				uploadedKeys = append(uploadedKeys, *input.Key)
+				return &s3manager.UploadOutput{}, nil
+			}).Times(len(tc.expectedArtifacts))
+
+			m := &InstallManager{
+				log:     log.WithField("test", tc.name),
+				WorkDir: workDir,
+			}
+			strategy := &hivev1agent.ImageInstallStrategy{
+				ArtifactType: tc.artifactType,
+				S3: hivev1agent.S3ArtifactStore{
+					Region: "us-east-1",
+					Bucket: testAgentImageBucket,
+				},
+			}
+
+			artifacts, err := m.uploadAgentImageArtifacts(provision, strategy, mocks.mockAWSClient)
+			if tc.expectErr {
+				assert.Error(t, err, "expected error uploading artifacts")
+				return
+			}
+			require.NoError(t, err, "unexpected error uploading artifacts")
+			if assert.Len(t, artifacts, len(tc.expectedArtifacts), "unexpected number of artifacts") {
+				for i, name := range tc.expectedArtifacts {
+					assert.Equal(t, name, artifacts[i].Name, "unexpected artifact name")
+					assert.Equal(t, testNamespace+"/"+testProvisionName+"/"+name, uploadedKeys[i], "unexpected object key")
+					assert.True(t, strings.Contains(artifacts[i].URL, "X-Amz-Signature="), "expected pre-signed URL")
+					assert.NotNil(t, artifacts[i].ExpirationTime, "expected URL expiration time")
+				}
+			}
+		})
+	}
+}
+
+func TestSetProvisionArtifacts(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+	provision := testClusterProvision()
+	mocks := setupDefaultMocks(t, provision)
+	m := &InstallManager{
+		log:                  log.WithField("test", "TestSetProvisionArtifacts"),
+		Namespace:            testNamespace,
+		ClusterProvisionName: testProvisionName,
+		DynamicClient:        mocks.fakeKubeClient,
+	}
+	artifacts := []hivev1.ClusterProvisionArtifact{{Name: "agent.x86_64.iso", URL: "https://example.com/agent.x86_64.iso"}}
+
+	require.NoError(t, m.setProvisionArtifacts(provision, artifacts))
+
+	updated := &hivev1.ClusterProvision{}
+	require.NoError(t, mocks.fakeKubeClient.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: testProvisionName}, updated))
+	assert.Equal(t, artifacts, updated.Status.Artifacts, "unexpected artifacts")
+	cond := controllerutils.FindClusterProvisionCondition(updated.Status.Conditions, hivev1.ClusterProvisionArtifactsPublishedCondition)
+	if assert.NotNil(t, cond, "expected condition to be set") {
+		assert.Equal(t, corev1.ConditionTrue, cond.Status, "unexpected condition status")
+	}
+}
@@ -0,0 +1,216 @@
+package installmanager
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/objectstorage"
+)
+
+// renderedManifestsDir is where the manifests rendered by the installer are preserved for the upload of the
+// installer artifacts, as the installer consumes them when creating the ignition configs.
+const renderedManifestsDir = "rendered-manifests"
+
+// excludedInstallArtifacts are the files and directories of the installer working directory which are never
+// uploaded, as they hold credentials or are the installer binaries.
+var excludedInstallArtifacts = map[string]bool{
+	"auth":                          true,
+	"tls":                           true,
+	"install-config.yaml":           true,
+	".openshift_install_state.json": true,
+	"openshift-install":             true,
+	"oc":                            true,
+}
+
+// installArtifacts uploads the installer working directory of a provision.
+type installArtifacts struct {
+	uploader objectstorage.Uploader
+	// prefix is prepended to the paths of the artifacts in the working directory to get the names of their objects.
+	prefix string
+	// always is whether the artifacts of successful provisions are uploaded too.
+	always bool
+}
+
+// setupInstallArtifacts returns the uploader of the installer artifacts of the provision, or nil if the upload of
+// installer artifacts is not configured in the environment of the install pod.
+func (m *InstallManager) setupInstallArtifacts(cd *hivev1.ClusterDeployment, provision *hivev1.ClusterProvision) (*installArtifacts, error) {
+	config := objectstorage.ConfigFromEnv(objectstorage.NewEnvVarNames(constants.InstallArtifactsEnvVarPrefix))
+	if config == nil {
+		return nil, nil
+	}
+	uploader, err := objectstorage.NewUploader(m.DynamicClient, provision.Namespace, config)
+	if err != nil {
+		return nil, err
+	}
+	artifacts := &installArtifacts{
+		uploader: uploader,
+		prefix:   fmt.Sprintf("%v%v-%v/%v/", os.Getenv(constants.InstallArtifactsPrefixEnvVar), cd.Spec.ClusterName, provision.Namespace, provision.Name),
+		always:   os.Getenv(constants.InstallArtifactsUploadEnvVar) == string(hivev1.InstallArtifactsUploadAlways),
+	}
+	m.log.Infof("installer artifacts will be uploaded to %v%v", config, artifacts.prefix)
+	return artifacts, nil
+}
+
+// preserveRenderedManifests copies the manifests rendered by the installer into the rendered manifests directory,
+// so that they are uploaded with the installer artifacts after the installer consumed them.
+func (m *InstallManager) preserveRenderedManifests() error {
+	for _, dir := range []string{"manifests", "openshift"} {
+		src := filepath.Join(m.WorkDir, dir)
+		if _, err := os.Stat(src); os.IsNotExist(err) {
+			continue
+		}
+		dest := filepath.Join(m.WorkDir, renderedManifestsDir, dir)
+		err := filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+			if err != nil || info.IsDir() {
+				return err
+			}
+			rel, err := filepath.Rel(src, path)
+			if err != nil {
+				return err
+			}
+			data, err := ioutil.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			if err := os.MkdirAll(filepath.Dir(filepath.Join(dest, rel)), 0700); err != nil {
+				return err
+			}
+			return ioutil.WriteFile(filepath.Join(dest, rel), data, 0600)
+		})
+		if err != nil {
+			return errors.Wrapf(err, "could not preserve %s", dir)
+		}
+	}
+	return nil
+}
+
+// uploadInstallArtifacts uploads the installer working directory, if the upload is configured for provisions with
+// the outcome of the install. Failures to upload are logged, as they must not fail the provision.
+func (m *InstallManager) uploadInstallArtifacts(installFailed, scrubInstallLog bool) {
+	artifacts := m.installArtifacts
+	if artifacts == nil || (!installFailed && !artifacts.always) {
+		return
+	}
+	paths, err := installArtifactPaths(m.WorkDir, installFailed)
+	if err != nil {
+		m.log.WithError(err).Warn("could not list installer artifacts")
+		return
+	}
+	m.log.WithField("count", len(paths)).Info("uploading installer artifacts")
+	uploaded := 0
+	for _, path := range paths {
+		aLog := m.log.WithField("artifact", path)
+		body, err := m.readInstallArtifact(path, scrubInstallLog)
+		if err != nil {
+			aLog.WithError(err).Warn("could not read installer artifact")
+			continue
+		}
+		if err := artifacts.uploader.Upload(artifacts.prefix+filepath.ToSlash(path), bytes.NewReader(body)); err != nil {
+			aLog.WithError(err).Warn("could not upload installer artifact")
+			continue
+		}
+		uploaded++
+	}
+	m.log.WithFields(log.Fields{"uploaded": uploaded, "count": len(paths)}).Info("uploaded installer artifacts")
+}
+
+// readInstallArtifact reads an artifact, scrubbing the passwords of the installer log.
+func (m *InstallManager) readInstallArtifact(path string, scrubInstallLog bool) ([]byte, error) {
+	data, err := ioutil.ReadFile(filepath.Join(m.WorkDir, path))
+	if err != nil {
+		return nil, err
+	}
+	if scrubInstallLog && path == installerFullLogFile {
+		data = []byte(cleanupLogOutput(string(data)))
+	}
+	return data, nil
+}
+
+// installArtifactPaths returns the paths, relative to the working directory, of the installer artifacts to upload.
+// Credentials, ignition configs, which embed credentials, and secret manifests are left out. The terraform state is
+// only included for failed installs.
+func installArtifactPaths(workDir string, includeTerraformState bool) ([]string, error) {
+	var paths []string
+	err := filepath.Walk(workDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(workDir, path)
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			return nil
+		}
+		if excludedInstallArtifacts[rel] {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if info.IsDir() || !info.Mode().IsRegular() {
+			return nil
+		}
+		name := info.Name()
+		switch {
+		case strings.HasSuffix(name, ".ign"), strings.HasSuffix(name, ".tfvars.json"):
+			return nil
+		case strings.Contains(name, ".tfstate"):
+			if !includeTerraformState {
+				return nil
+			}
+		case strings.HasSuffix(name, ".yaml") || strings.HasSuffix(name, ".yml") || strings.HasSuffix(name, ".json"):
+			if isSecretManifest(path) {
+				return nil
+			}
+		}
+		paths = append(paths, rel)
+		return nil
+	})
+	return paths, err
+}
+
+// isSecretManifest returns whether any of the YAML documents or JSON objects of the manifest at the path is a Secret,
+// or a list holding one, or whether the manifest cannot be parsed and may hold one.
+func isSecretManifest(path string) bool {
+	f, err := os.Open(path)
+	if err != nil {
+		return true
+	}
+	defer f.Close()
+	decoder := utilyaml.NewYAMLOrJSONDecoder(f, 4096)
+	for {
+		manifest := struct {
+			Kind  string `json:"kind"`
+			Items []struct {
+				Kind string `json:"kind"`
+			} `json:"items"`
+		}{}
+		switch err := decoder.Decode(&manifest); {
+		case err == io.EOF:
+			return false
+		case err != nil:
+			return true
+		}
+		if manifest.Kind == "Secret" {
+			return true
+		}
+		for _, item := range manifest.Items {
+			if item.Kind == "Secret" {
+				return true
+			}
+		}
+	}
+}
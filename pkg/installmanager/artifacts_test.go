@@ -0,0 +1,134 @@
+package installmanager
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeInstallArtifactsUploader struct {
+	objects map[string]string
+	err     error
+}
+
+func (u *fakeInstallArtifactsUploader) Upload(key string, body io.Reader) error {
+	if u.err != nil {
+		return u.err
+	}
+	data, err := ioutil.ReadAll(body)
+	if err != nil {
+		return err
+	}
+	u.objects[key] = string(data)
+	return nil
+}
+
+func writeInstallArtifacts(t *testing.T, workDir string) {
+	files := map[string]string{
+		".openshift_install.log":                  `level=debug msg="password: \"hunter2\""`,
+		"metadata.json":                           `{"infraID":"test-infra-id"}`,
+		"terraform.tfstate":                       `{"version":4}`,
+		"terraform.tfvars.json":                   `{"password":"hunter2"}`,
+		"bootstrap.ign":                           `{"ignition":{}}`,
+		"install-config.yaml":                     "pullSecret: hunter2",
+		".openshift_install_state.json":           `{}`,
+		"openshift-install":                       "binary",
+		"auth/kubeconfig":                         "kubeconfig",
+		"tls/journal-gatewayd.key":                "key",
+		"log-bundle-20210304.tar.gz":              "bundle",
+		"rendered-manifests/manifests/dns.yaml":   "apiVersion: config.openshift.io/v1\nkind: DNS\n",
+		"rendered-manifests/openshift/creds.yaml": "apiVersion: v1\nkind: Secret\n",
+		"rendered-manifests/openshift/multi.yaml": "apiVersion: v1\nkind: ConfigMap\n---\napiVersion: v1\nkind: Secret\n",
+		"rendered-manifests/openshift/list.yaml":  "apiVersion: v1\nkind: List\nitems:\n- apiVersion: v1\n  kind: Secret\n",
+		"rendered-manifests/openshift/creds.json": `{"apiVersion":"v1","kind":"Secret"}`,
+		"rendered-manifests/openshift/cm.json":    `{"apiVersion":"v1","kind":"ConfigMap"}`,
+	}
+	for path, content := range files {
+		path = filepath.Join(workDir, path)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+		require.NoError(t, ioutil.WriteFile(path, []byte(content), 0600))
+	}
+}
+
+func TestUploadInstallArtifacts(t *testing.T) {
+	tests := []struct {
+		name          string
+		always        bool
+		installFailed bool
+		uploadErr     error
+		expectObjects []string
+	}{
+		{
+			name:          "failed install",
+			installFailed: true,
+			expectObjects: []string{
+				".openshift_install.log",
+				"log-bundle-20210304.tar.gz",
+				"metadata.json",
+				"rendered-manifests/manifests/dns.yaml",
+				"rendered-manifests/openshift/cm.json",
+				"terraform.tfstate",
+			},
+		},
+		{
+			name: "successful install",
+		},
+		{
+			name:   "successful install always uploaded",
+			always: true,
+			expectObjects: []string{
+				".openshift_install.log",
+				"log-bundle-20210304.tar.gz",
+				"metadata.json",
+				"rendered-manifests/manifests/dns.yaml",
+				"rendered-manifests/openshift/cm.json",
+			},
+		},
+		{
+			name:          "upload failure",
+			installFailed: true,
+			uploadErr:     errors.New("access denied"),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			workDir, err := ioutil.TempDir("", "installartifacts")
+			require.NoError(t, err)
+			defer os.RemoveAll(workDir)
+			writeInstallArtifacts(t, workDir)
+
+			uploader := &fakeInstallArtifactsUploader{objects: map[string]string{}, err: test.uploadErr}
+			m := &InstallManager{
+				WorkDir: workDir,
+				log:     log.WithField("test", "TestUploadInstallArtifacts"),
+				installArtifacts: &installArtifacts{
+					uploader: uploader,
+					prefix:   "hive/test-cluster-test-namespace/test-provision/",
+					always:   test.always,
+				},
+			}
+
+			m.uploadInstallArtifacts(test.installFailed, true)
+
+			keys := []string{}
+			for key := range uploader.objects {
+				keys = append(keys, key)
+			}
+			expectKeys := []string{}
+			for _, path := range test.expectObjects {
+				expectKeys = append(expectKeys, "hive/test-cluster-test-namespace/test-provision/"+path)
+			}
+			assert.ElementsMatch(t, expectKeys, keys, "unexpected uploaded artifacts")
+			for key, body := range uploader.objects {
+				assert.NotContains(t, body, "hunter2", "expected %s to be scrubbed of credentials", key)
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package installmanager
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
+)
+
+const (
+	// releaseImageEnvVar is the environment variable of the install pod with the release image being installed.
+	releaseImageEnvVar = "OPENSHIFT_INSTALL_RELEASE_IMAGE_OVERRIDE"
+
+	infrastructureManifestRelativePath = "manifests/cluster-infrastructure-02-config.yml"
+	credentialsRequestsRelativePath    = "credrequests"
+	ccoctlOutputRelativePath           = "ccoctl-output"
+	ccoctlImageComponent               = "cloud-credential-operator"
+	ccoctlImageFile                    = "/usr/bin/ccoctl"
+)
+
+// stsConfig returns the STS configuration of the clusterdeployment when the install job is to create its AWS
+// resources for the Security Token Service with ccoctl.
+func stsConfig(cd *hivev1.ClusterDeployment) *hivev1aws.STSConfig {
+	if cd.Spec.Platform.AWS == nil || cd.Spec.Platform.AWS.CredentialsMode != hivev1aws.ManualCredentialsMode {
+		return nil
+	}
+	return cd.Spec.Platform.AWS.STS
+}
+
+// generateSTSCredentials creates, with ccoctl, the IAM roles and the OIDC provider with which the components of the
+// cluster authenticate using the AWS Security Token Service, and adds the manifests and the bound service account
+// signing key which ccoctl generates to the install assets. It must run after the manifests have been created.
+// The AWS resources are named after the infra ID of the cluster.
+func (m *InstallManager) generateSTSCredentials(cd *hivev1.ClusterDeployment, sts *hivev1aws.STSConfig) error {
+	infraID, err := readInfraID(m.WorkDir)
+	if err != nil {
+		return err
+	}
+	releaseImage := os.Getenv(releaseImageEnvVar)
+	if releaseImage == "" {
+		return errors.New("release image is not set")
+	}
+
+	m.log.WithField("releaseImage", releaseImage).Info("extracting ccoctl from release image")
+	ccoctlImage, err := m.runOC("adm", "release", "info", "--image-for="+ccoctlImageComponent, "-a", m.PullSecretMountPath, releaseImage)
+	if err != nil {
+		return errors.Wrap(err, "could not get the cloud credential operator image")
+	}
+	if _, err := m.runOC("image", "extract", string(ccoctlImage), "--file="+ccoctlImageFile, "--confirm", "-a", m.PullSecretMountPath); err != nil {
+		return errors.Wrap(err, "could not extract ccoctl")
+	}
+	ccoctl := filepath.Join(m.binaryDir, "ccoctl")
+	if err := os.Chmod(ccoctl, 0755); err != nil {
+		return errors.Wrap(err, "could not make ccoctl executable")
+	}
+
+	credentialsRequestsDir := filepath.Join(m.WorkDir, credentialsRequestsRelativePath)
+	m.log.Info("extracting CredentialsRequests from release image")
+	if _, err := m.runOC("adm", "release", "extract", "--credentials-requests", "--cloud=aws", "--to="+credentialsRequestsDir, "-a", m.PullSecretMountPath, releaseImage); err != nil {
+		return errors.Wrap(err, "could not extract CredentialsRequests")
+	}
+
+	outputDir := filepath.Join(m.WorkDir, ccoctlOutputRelativePath)
+	args := []string{
+		"aws", "create-all",
+		"--name=" + infraID,
+		"--region=" + cd.Spec.Platform.AWS.Region,
+		"--credentials-requests-dir=" + credentialsRequestsDir,
+		"--output-dir=" + outputDir,
+	}
+	if sts.CreatePrivateS3Bucket {
+		args = append(args, "--create-private-s3-bucket")
+	}
+	m.log.WithField("args", args).Info("creating AWS resources for STS with ccoctl")
+	cmd := exec.Command(ccoctl, args...)
+	cmd.Dir = m.binaryDir
+	out, err := cmd.CombinedOutput()
+	m.log.Infof("ccoctl output: %s", out)
+	if err != nil {
+		return errors.Wrap(err, "ccoctl failed")
+	}
+
+	for _, dir := range []string{"manifests", "tls"} {
+		if err := m.copyDir(filepath.Join(outputDir, dir), filepath.Join(m.WorkDir, dir)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// runOC runs the oc binary from the binary dir, returning its standard output.
+func (m *InstallManager) runOC(args ...string) ([]byte, error) {
+	cmd := exec.Command(filepath.Join(m.binaryDir, "oc"), args...)
+	cmd.Dir = m.binaryDir
+	out, err := cmd.Output()
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		m.log.WithField("args", args).Errorf("oc failed: %s", exitErr.Stderr)
+	}
+	return bytes.TrimSpace(out), err
+}
+
+func (m *InstallManager) copyDir(src, dest string) error {
+	if err := os.MkdirAll(dest, 0700); err != nil {
+		return errors.Wrapf(err, "could not create %s", dest)
+	}
+	if err := exec.Command("cp", "-r", src+"/.", dest).Run(); err != nil {
+		return errors.Wrapf(err, "could not copy %s to %s", src, dest)
+	}
+	m.log.Infof("copied %s to %s", src, dest)
+	return nil
+}
+
+// readInfraID reads the infra ID of the cluster from the manifests created by the installer.
+func readInfraID(workDir string) (string, error) {
+	data, err := ioutil.ReadFile(filepath.Join(workDir, infrastructureManifestRelativePath))
+	if err != nil {
+		return "", errors.Wrap(err, "could not read the infrastructure manifest")
+	}
+	infra := struct {
+		Status struct {
+			InfrastructureName string `json:"infrastructureName"`
+		} `json:"status"`
+	}{}
+	if err := yaml.Unmarshal(data, &infra); err != nil {
+		return "", errors.Wrap(err, "could not unmarshal the infrastructure manifest")
+	}
+	if infra.Status.InfrastructureName == "" {
+		return "", errors.New("infrastructure manifest has no infrastructure name")
+	}
+	return infra.Status.InfrastructureName, nil
+}
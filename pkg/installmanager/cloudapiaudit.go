@@ -0,0 +1,559 @@
+package installmanager
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"io/ioutil"
+	stdlog "log"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	k8slabels "github.com/openshift/hive/pkg/util/labels"
+)
+
+const (
+	cloudAPICallsConfigMapSuffix = "-cloud-api-calls"
+	cloudAPICallsKey             = "calls.json"
+
+	// maxCloudAPICalls bounds the number of distinct calls recorded. Beyond it, calls are recorded without their
+	// resource so that the calls fit in the ConfigMap.
+	maxCloudAPICalls = 2000
+
+	// maxCloudAPIResourceLength bounds the length of the recorded resource of a call.
+	maxCloudAPIResourceLength = 256
+)
+
+// auditedCloudDomains are the domains of the cloud APIs whose requests the auditor decrypts to record their action
+// and resource. Connections to other hosts, such as OpenStack or vSphere endpoints with their own certificate
+// authorities, are tunneled and recorded with their host only.
+var auditedCloudDomains = []string{
+	".amazonaws.com",
+	".amazonaws.com.cn",
+	".googleapis.com",
+	".azure.com",
+	".azure.net",
+	".windows.net",
+	".microsoftonline.com",
+	".cloud.ibm.com",
+}
+
+// awsResourceParamSuffixes are the suffixes of the names of the parameters of AWS calls which identify resources.
+var awsResourceParamSuffixes = []string{"Id", "Ids", "Name", "Arn", "ARN"}
+
+// cloudAPIAuditor records the cloud API calls made by the installer. It is an HTTP proxy which the installer is
+// configured to use, and which decrypts the requests to the cloud APIs with certificates issued by a certificate
+// authority the installer is configured to trust.
+type cloudAPIAuditor struct {
+	listener net.Listener
+	server   *http.Server
+	proxy    *httputil.ReverseProxy
+	done     chan struct{}
+	certDir  string
+	ca       *x509.Certificate
+	caKey    crypto.Signer
+	// audited returns whether the requests to the host are decrypted, here for testing.
+	audited func(host string) bool
+	// dial connects to the hosts whose connections are tunneled, here for testing.
+	dial func(hostport string) (net.Conn, error)
+	log  log.FieldLogger
+
+	mutex sync.Mutex
+	calls map[hivev1.CloudAPICall]*hivev1.CloudAPICall
+	certs map[string]*tls.Certificate
+}
+
+// startCloudAPIAuditor starts the proxy recording the cloud API calls, writing the certificate of its certificate
+// authority in workDir.
+func startCloudAPIAuditor(workDir string, logger log.FieldLogger) (*cloudAPIAuditor, error) {
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "hive-cloud-api-audit"},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(7 * 24 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, caKey.Public(), caKey)
+	if err != nil {
+		return nil, err
+	}
+	ca, err := x509.ParseCertificate(caDER)
+	if err != nil {
+		return nil, err
+	}
+	certDir := filepath.Join(workDir, "cloud-api-audit")
+	if err := os.MkdirAll(certDir, 0700); err != nil {
+		return nil, err
+	}
+	if err := ioutil.WriteFile(filepath.Join(certDir, "ca.crt"), pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER}), 0600); err != nil {
+		return nil, err
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	a := &cloudAPIAuditor{
+		listener: listener,
+		done:     make(chan struct{}),
+		certDir:  certDir,
+		ca:       ca,
+		caKey:    caKey,
+		audited:  isAuditedCloudHost,
+		dial:     dialThroughProxy,
+		log:      logger,
+		calls:    map[hivev1.CloudAPICall]*hivev1.CloudAPICall{},
+		certs:    map[string]*tls.Certificate{},
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DisableCompression = true
+	a.proxy = &httputil.ReverseProxy{
+		Director: func(req *http.Request) {
+			// Requests decrypted from a tunnel only have the path in their URL.
+			if req.URL.Host == "" {
+				req.URL.Scheme = "https"
+				req.URL.Host = req.Host
+			}
+		},
+		Transport: &auditingTransport{auditor: a, base: transport},
+		ErrorLog:  stdlog.New(ioutil.Discard, "", 0),
+	}
+	a.server = &http.Server{Handler: a}
+	go func() {
+		defer close(a.done)
+		a.server.Serve(listener)
+	}()
+	return a, nil
+}
+
+// env returns the environment variables which make the installer send its requests through the auditor and trust
+// the certificates it issues.
+func (a *cloudAPIAuditor) env() []string {
+	proxyURL := "http://" + a.listener.Addr().String()
+	certDirs := []string{a.certDir}
+	if dirs := os.Getenv("SSL_CERT_DIR"); dirs != "" {
+		certDirs = append(certDirs, dirs)
+	} else {
+		// Setting SSL_CERT_DIR replaces the default directories of the system certificates.
+		certDirs = append(certDirs, "/etc/ssl/certs", "/etc/pki/tls/certs")
+	}
+	return []string{
+		"HTTP_PROXY=" + proxyURL,
+		"HTTPS_PROXY=" + proxyURL,
+		"http_proxy=" + proxyURL,
+		"https_proxy=" + proxyURL,
+		"SSL_CERT_DIR=" + strings.Join(certDirs, ":"),
+	}
+}
+
+// ServeHTTP proxies a request of the installer. CONNECT requests to the cloud APIs are decrypted and their requests
+// proxied in turn, and other CONNECT requests are tunneled.
+func (a *cloudAPIAuditor) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodConnect {
+		a.proxy.ServeHTTP(w, r)
+		return
+	}
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		http.Error(w, "hijacking not supported", http.StatusInternalServerError)
+		return
+	}
+	host := r.URL.Hostname()
+	if !a.audited(host) {
+		upstream, err := a.dial(r.Host)
+		a.record(hivev1.CloudAPICall{Service: host, Action: http.MethodConnect}, err == nil)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+		conn, _, err := hijacker.Hijack()
+		if err != nil {
+			upstream.Close()
+			return
+		}
+		conn.Write([]byte("HTTP/1.1 200 Connection established\r\n\r\n"))
+		go tunnel(conn, upstream)
+		return
+	}
+	conn, _, err := hijacker.Hijack()
+	if err != nil {
+		return
+	}
+	conn.Write([]byte("HTTP/1.1 200 Connection established\r\n\r\n"))
+	tlsConn := tls.Server(conn, &tls.Config{
+		GetCertificate: func(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+			if hello.ServerName != "" {
+				return a.certificate(hello.ServerName)
+			}
+			return a.certificate(host)
+		},
+	})
+	// Serve the requests of the connection until the installer closes it.
+	go (&http.Server{Handler: a.proxy, ErrorLog: stdlog.New(ioutil.Discard, "", 0)}).Serve(&connListener{conn: tlsConn})
+}
+
+// certificate returns the certificate issued by the certificate authority of the auditor for the host.
+func (a *cloudAPIAuditor) certificate(host string) (*tls.Certificate, error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	if cert := a.certs[host]; cert != nil {
+		return cert, nil
+	}
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, err
+	}
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: host},
+		NotBefore:    a.ca.NotBefore,
+		NotAfter:     a.ca.NotAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		template.IPAddresses = []net.IP{ip}
+	} else {
+		template.DNSNames = []string{host}
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, a.ca, key.Public(), a.caKey)
+	if err != nil {
+		return nil, err
+	}
+	cert := &tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+	a.certs[host] = cert
+	return cert, nil
+}
+
+// record counts a call.
+func (a *cloudAPIAuditor) record(key hivev1.CloudAPICall, succeeded bool) {
+	if len(key.Resource) > maxCloudAPIResourceLength {
+		key.Resource = key.Resource[:maxCloudAPIResourceLength]
+	}
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	call := a.calls[key]
+	if call == nil && len(a.calls) >= maxCloudAPICalls {
+		key.Resource = ""
+		call = a.calls[key]
+	}
+	if call == nil {
+		call = &hivev1.CloudAPICall{Service: key.Service, Action: key.Action, Region: key.Region, Resource: key.Resource}
+		a.calls[key] = call
+	}
+	call.Count++
+	if !succeeded {
+		call.Failures++
+	}
+}
+
+// stop stops recording and returns the recorded calls, sorted by service, action, region and resource.
+func (a *cloudAPIAuditor) stop() []hivev1.CloudAPICall {
+	a.server.Close()
+	<-a.done
+	if err := os.RemoveAll(a.certDir); err != nil {
+		a.log.WithError(err).Warn("could not remove the certificate authority of the cloud API audit")
+	}
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	calls := make([]hivev1.CloudAPICall, 0, len(a.calls))
+	for _, call := range a.calls {
+		calls = append(calls, *call)
+	}
+	sort.Slice(calls, func(i, j int) bool {
+		if calls[i].Service != calls[j].Service {
+			return calls[i].Service < calls[j].Service
+		}
+		if calls[i].Action != calls[j].Action {
+			return calls[i].Action < calls[j].Action
+		}
+		if calls[i].Region != calls[j].Region {
+			return calls[i].Region < calls[j].Region
+		}
+		return calls[i].Resource < calls[j].Resource
+	})
+	return calls
+}
+
+// auditingTransport records the requests it sends.
+type auditingTransport struct {
+	auditor *cloudAPIAuditor
+	base    http.RoundTripper
+}
+
+func (t *auditingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		if body, err = ioutil.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		req.Body.Close()
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+	call := describeCloudAPICall(req, body)
+	resp, err := t.base.RoundTrip(req)
+	t.auditor.record(call, err == nil && resp.StatusCode < http.StatusBadRequest)
+	return resp, err
+}
+
+// describeCloudAPICall returns the service, action, region and resource of a request to a cloud API. AWS requests
+// are described from their signature and parameters, and the requests to other clouds from their host, method and
+// path.
+func describeCloudAPICall(req *http.Request, body []byte) hivev1.CloudAPICall {
+	call := hivev1.CloudAPICall{Service: req.URL.Hostname(), Action: req.Method, Resource: req.URL.Path}
+	service, region, ok := awsCredentialScope(req)
+	if !ok {
+		return call
+	}
+	call.Service, call.Region = service, region
+	if target := req.Header.Get("X-Amz-Target"); target != "" {
+		// JSON protocol, with the action in the target and the parameters in a JSON body.
+		call.Action = target[strings.LastIndex(target, ".")+1:]
+		params := map[string]interface{}{}
+		json.Unmarshal(body, &params)
+		var resources []string
+		for k, v := range params {
+			if s, ok := v.(string); ok && isAWSResourceParam(k) {
+				resources = append(resources, s)
+			}
+		}
+		call.Resource = joinResources(resources)
+		return call
+	}
+	params := req.URL.Query()
+	if strings.HasPrefix(req.Header.Get("Content-Type"), "application/x-www-form-urlencoded") {
+		if form, err := url.ParseQuery(string(body)); err == nil {
+			for k, v := range form {
+				params[k] = append(params[k], v...)
+			}
+		}
+	}
+	if action := params.Get("Action"); action != "" {
+		// Query protocol, with the action and the parameters in the query or a form body.
+		call.Action = action
+		var resources []string
+		for k, v := range params {
+			if isAWSResourceParam(k) {
+				resources = append(resources, v...)
+			}
+		}
+		call.Resource = joinResources(resources)
+	}
+	// REST protocols keep the method as the action and the path as the resource.
+	return call
+}
+
+// awsCredentialScope returns the service and the region of the credential scope of a request signed with AWS
+// Signature Version 4.
+func awsCredentialScope(req *http.Request) (service, region string, ok bool) {
+	credential := req.URL.Query().Get("X-Amz-Credential")
+	if auth := req.Header.Get("Authorization"); strings.HasPrefix(auth, "AWS4-HMAC-SHA256 ") {
+		for _, field := range strings.Split(strings.TrimPrefix(auth, "AWS4-HMAC-SHA256 "), ",") {
+			if field = strings.TrimSpace(field); strings.HasPrefix(field, "Credential=") {
+				credential = strings.TrimPrefix(field, "Credential=")
+			}
+		}
+	}
+	// The credential is <access key>/<date>/<region>/<service>/aws4_request.
+	parts := strings.Split(credential, "/")
+	if len(parts) != 5 {
+		return "", "", false
+	}
+	return parts[3], parts[2], true
+}
+
+// isAWSResourceParam returns whether the parameter of an AWS call identifies a resource. Numbered parameters, such as
+// InstanceId.1, are matched on their name, and the names of filters and tags are not resources.
+func isAWSResourceParam(param string) bool {
+	name := strings.TrimRightFunc(param, func(r rune) bool { return r == '.' || (r >= '0' && r <= '9') })
+	if strings.Contains(name, "Filter") || strings.Contains(name, "Tag") {
+		return false
+	}
+	for _, suffix := range awsResourceParamSuffixes {
+		if strings.HasSuffix(name, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+func joinResources(resources []string) string {
+	sort.Strings(resources)
+	return strings.Join(resources, ",")
+}
+
+// isAuditedCloudHost returns whether the host is in the domain of a cloud API whose requests are recorded.
+func isAuditedCloudHost(host string) bool {
+	for _, domain := range auditedCloudDomains {
+		if strings.HasSuffix(host, domain) {
+			return true
+		}
+	}
+	return false
+}
+
+// dialThroughProxy connects to the host, through the proxy of the install manager if it has one.
+func dialThroughProxy(hostport string) (net.Conn, error) {
+	proxyURL, err := http.ProxyFromEnvironment(&http.Request{URL: &url.URL{Scheme: "https", Host: hostport}})
+	if err != nil {
+		return nil, err
+	}
+	if proxyURL == nil {
+		return net.Dial("tcp", hostport)
+	}
+	conn, err := net.Dial("tcp", proxyURL.Host)
+	if err != nil {
+		return nil, err
+	}
+	connect := &http.Request{Method: http.MethodConnect, URL: &url.URL{Opaque: hostport}, Host: hostport, Header: http.Header{}}
+	if proxyURL.User != nil {
+		password, _ := proxyURL.User.Password()
+		connect.SetBasicAuth(proxyURL.User.Username(), password)
+		connect.Header["Proxy-Authorization"] = connect.Header["Authorization"]
+		delete(connect.Header, "Authorization")
+	}
+	if err := connect.Write(conn); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	resp, err := http.ReadResponse(bufio.NewReader(conn), connect)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		conn.Close()
+		return nil, fmt.Errorf("proxy refused to connect to %s: %s", hostport, resp.Status)
+	}
+	return conn, nil
+}
+
+// tunnel copies the data between the connections until either is closed.
+func tunnel(a, b net.Conn) {
+	defer a.Close()
+	defer b.Close()
+	go io.Copy(a, b)
+	io.Copy(b, a)
+}
+
+// connListener is a net.Listener which accepts a single connection.
+type connListener struct {
+	conn net.Conn
+	once sync.Once
+}
+
+func (l *connListener) Accept() (net.Conn, error) {
+	var conn net.Conn
+	l.once.Do(func() { conn = l.conn })
+	if conn == nil {
+		return nil, io.EOF
+	}
+	return conn, nil
+}
+
+func (l *connListener) Close() error {
+	return nil
+}
+
+func (l *connListener) Addr() net.Addr {
+	return l.conn.LocalAddr()
+}
+
+// publishCloudAPICalls stops the cloud API auditor and stores the calls it recorded in a ConfigMap referenced from the
+// status of the provision. Publishing is best effort, so failures are only logged.
+func (m *InstallManager) publishCloudAPICalls(provision *hivev1.ClusterProvision) {
+	calls := m.cloudAPIAuditor.stop()
+	m.cloudAPIAuditor = nil
+	logger := m.log.WithField("calls", len(calls))
+	data, err := json.MarshalIndent(calls, "", "  ")
+	if err != nil {
+		logger.WithError(err).Warn("could not marshal cloud API calls")
+		return
+	}
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      provision.Name + cloudAPICallsConfigMapSuffix,
+			Namespace: provision.Namespace,
+		},
+		Data: map[string]string{cloudAPICallsKey: string(data)},
+	}
+	configMap.Labels = k8slabels.AddLabel(configMap.Labels, constants.ClusterProvisionNameLabel, provision.Name)
+	provisionGVK, err := apiutil.GVKForObject(provision, scheme.Scheme)
+	if err != nil {
+		logger.WithError(err).Warn("error getting GVK for provision")
+		return
+	}
+	configMap.OwnerReferences = []metav1.OwnerReference{{
+		APIVersion:         provisionGVK.GroupVersion().String(),
+		Kind:               provisionGVK.Kind,
+		Name:               provision.Name,
+		UID:                provision.UID,
+		BlockOwnerDeletion: pointer.BoolPtr(true),
+	}}
+	if err := m.deleteAnyExistingObject(types.NamespacedName{Namespace: configMap.Namespace, Name: configMap.Name}, &corev1.ConfigMap{}); err != nil {
+		logger.WithError(err).Warn("could not delete previous cloud API calls")
+		return
+	}
+	if err := createWithRetries(configMap, m); err != nil {
+		logger.WithError(err).Warn("could not create cloud API calls ConfigMap")
+		return
+	}
+
+	if err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		if err := m.loadClusterProvision(provision); err != nil {
+			return err
+		}
+		provision.Status.CloudAPICallsRef = &corev1.LocalObjectReference{Name: configMap.Name}
+		return m.DynamicClient.Status().Update(context.Background(), provision)
+	}); err != nil {
+		logger.WithError(err).Warn("could not reference cloud API calls from clusterprovision")
+		return
+	}
+	logger.WithField("configMap", configMap.Name).Info("published cloud API calls")
+}
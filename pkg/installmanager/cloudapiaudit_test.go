@@ -0,0 +1,136 @@
+package installmanager
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+
+	"github.com/openshift/hive/apis"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+func TestCloudAPIAuditor(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+	provision := testClusterProvision()
+	mocks := setupDefaultMocks(t, provision)
+	m := &InstallManager{
+		log:                  log.WithField("test", "TestCloudAPIAuditor"),
+		Namespace:            testNamespace,
+		ClusterProvisionName: testProvisionName,
+		DynamicClient:        mocks.fakeKubeClient,
+	}
+
+	auditor, err := startCloudAPIAuditor(t.TempDir(), m.log)
+	require.NoError(t, err, "unexpected error starting auditor")
+	m.cloudAPIAuditor = auditor
+	assert.Contains(t, auditor.env(), "HTTPS_PROXY=http://"+auditor.listener.Addr().String(), "expected installer to use the auditor as proxy")
+
+	cloud := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/forbidden" {
+			w.WriteHeader(http.StatusForbidden)
+		}
+	}))
+	defer cloud.Close()
+	other := httptest.NewTLSServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	defer other.Close()
+	cloudPort := cloud.Listener.Addr().(*net.TCPAddr).Port
+	otherPort := other.Listener.Addr().(*net.TCPAddr).Port
+
+	// The cloud API is served on 127.0.0.1 and the other host on localhost, which the auditor only tunnels.
+	auditor.audited = func(host string) bool { return host == "127.0.0.1" }
+	cloudRoots := x509.NewCertPool()
+	cloudRoots.AddCert(cloud.Certificate())
+	auditor.proxy.Transport.(*auditingTransport).base = &http.Transport{TLSClientConfig: &tls.Config{RootCAs: cloudRoots}}
+
+	installerRoots := x509.NewCertPool()
+	installerRoots.AddCert(auditor.ca)
+	installerRoots.AddCert(other.Certificate())
+	proxyURL, err := url.Parse("http://" + auditor.listener.Addr().String())
+	require.NoError(t, err)
+	installer := &http.Client{Transport: &http.Transport{
+		Proxy:           http.ProxyURL(proxyURL),
+		TLSClientConfig: &tls.Config{RootCAs: installerRoots},
+	}}
+	cloudURL := fmt.Sprintf("https://127.0.0.1:%d", cloudPort)
+	awsAuth := func(service string) string {
+		return fmt.Sprintf("AWS4-HMAC-SHA256 Credential=AKIAEXAMPLE/20210301/us-east-1/%s/aws4_request, SignedHeaders=host, Signature=abc", service)
+	}
+	requests := []*http.Request{}
+	for i := 0; i < 2; i++ {
+		req, _ := http.NewRequest(http.MethodPost, cloudURL+"/", strings.NewReader("Action=TerminateInstances&InstanceId.1=i-2&InstanceId.2=i-1&Filter.1.Name=tag-key"))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+		req.Header.Set("Authorization", awsAuth("ec2"))
+		requests = append(requests, req)
+	}
+	req, _ := http.NewRequest(http.MethodPost, cloudURL+"/", strings.NewReader(`{"Name":"test-lb","Scheme":"internal"}`))
+	req.Header.Set("X-Amz-Target", "ElasticLoadBalancing_v7.CreateLoadBalancer")
+	req.Header.Set("Authorization", awsAuth("elasticloadbalancing"))
+	requests = append(requests, req)
+	req, _ = http.NewRequest(http.MethodPost, cloudURL+"/forbidden", nil)
+	req.Header.Set("Authorization", awsAuth("route53"))
+	requests = append(requests, req)
+	req, _ = http.NewRequest(http.MethodDelete, cloudURL+"/compute/v1/projects/test/zones/us-east1-b/instances/test-master-0", nil)
+	requests = append(requests, req)
+	for _, req := range requests {
+		resp, err := installer.Do(req)
+		require.NoError(t, err, "unexpected error from request through auditor")
+		resp.Body.Close()
+	}
+	// The tunneled connection is verified against the certificate of the other host.
+	tunneled := &http.Client{Transport: &http.Transport{
+		Proxy:           http.ProxyURL(proxyURL),
+		TLSClientConfig: &tls.Config{RootCAs: installerRoots, ServerName: "example.com"},
+	}}
+	resp, err := tunneled.Get(fmt.Sprintf("https://localhost:%d/v3/auth/tokens", otherPort))
+	require.NoError(t, err, "unexpected error from request tunneled through auditor")
+	resp.Body.Close()
+
+	m.publishCloudAPICalls(provision)
+
+	updated := &hivev1.ClusterProvision{}
+	require.NoError(t, mocks.fakeKubeClient.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: testProvisionName}, updated))
+	if assert.NotNil(t, updated.Status.CloudAPICallsRef, "expected cloud API calls to be referenced") {
+		assert.Equal(t, testProvisionName+cloudAPICallsConfigMapSuffix, updated.Status.CloudAPICallsRef.Name, "unexpected cloud API calls ConfigMap")
+	}
+	configMap := &corev1.ConfigMap{}
+	require.NoError(t, mocks.fakeKubeClient.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: testProvisionName + cloudAPICallsConfigMapSuffix}, configMap))
+	var calls []hivev1.CloudAPICall
+	require.NoError(t, json.Unmarshal([]byte(configMap.Data[cloudAPICallsKey]), &calls))
+	assert.Equal(t, []hivev1.CloudAPICall{
+		{Service: "127.0.0.1", Action: "DELETE", Resource: "/compute/v1/projects/test/zones/us-east1-b/instances/test-master-0", Count: 1},
+		{Service: "ec2", Action: "TerminateInstances", Region: "us-east-1", Resource: "i-1,i-2", Count: 2},
+		{Service: "elasticloadbalancing", Action: "CreateLoadBalancer", Region: "us-east-1", Resource: "test-lb", Count: 1},
+		{Service: "localhost", Action: "CONNECT", Count: 1},
+		{Service: "route53", Action: "POST", Region: "us-east-1", Resource: "/forbidden", Count: 1, Failures: 1},
+	}, calls, "unexpected cloud API calls")
+}
+
+func TestIsAWSResourceParam(t *testing.T) {
+	for param, expected := range map[string]bool{
+		"InstanceId.1":                   true,
+		"HostedZoneId":                   true,
+		"RoleName":                       true,
+		"PolicyArn":                      true,
+		"Filter.1.Name":                  false,
+		"TagSpecification.1.Tag.1.Value": false,
+		"MaxResults":                     false,
+	} {
+		assert.Equal(t, expected, isAWSResourceParam(param), "unexpected result for %s", param)
+	}
+}
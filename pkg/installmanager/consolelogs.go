@@ -0,0 +1,166 @@
+package installmanager
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	compute "google.golang.org/api/compute/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	gcputils "github.com/openshift/hive/contrib/pkg/utils/gcp"
+	awsclient "github.com/openshift/hive/pkg/awsclient"
+	gcpclient "github.com/openshift/hive/pkg/gcpclient"
+)
+
+// consoleLogsDir is the directory of the working directory where the console logs of the instances of a failed
+// install are written, so that they are uploaded with the logs and the installer artifacts of the provision.
+const consoleLogsDir = "console-logs"
+
+// gatherConsoleLogs writes the serial console output of the bootstrap and control plane instances of the cluster of a
+// failed install to the console logs directory. Console logs are only gathered on AWS and GCP. Failures are logged, as
+// they must not hide the failure of the install.
+func (m *InstallManager) gatherConsoleLogs(cd *hivev1.ClusterDeployment, infraID string) {
+	var consoleLogs map[string]string
+	var err error
+	switch {
+	case cd.Spec.Platform.AWS != nil:
+		var awsClient awsclient.Client
+		awsClient, err = awsclient.NewClient(nil, "", "", cd.Spec.Platform.AWS.Region)
+		if err != nil {
+			m.log.WithError(err).Warn("could not create AWS client to gather console logs")
+			return
+		}
+		consoleLogs, err = awsConsoleLogs(awsClient, infraID, m.log)
+	case cd.Spec.Platform.GCP != nil:
+		creds, credsErr := gcputils.GetCreds("")
+		if credsErr != nil {
+			m.log.WithError(credsErr).Warn("could not get GCP creds to gather console logs")
+			return
+		}
+		var gcpClient gcpclient.Client
+		gcpClient, err = gcpclient.NewClient(creds)
+		if err != nil {
+			m.log.WithError(err).Warn("could not create GCP client to gather console logs")
+			return
+		}
+		consoleLogs, err = gcpConsoleLogs(gcpClient, infraID, m.log)
+	default:
+		m.log.Debug("console logs are not gathered on this platform")
+		return
+	}
+	if err != nil {
+		m.log.WithError(err).Warn("could not gather console logs")
+	}
+	if err := writeConsoleLogs(filepath.Join(m.WorkDir, consoleLogsDir), consoleLogs); err != nil {
+		m.log.WithError(err).Warn("could not write console logs")
+		return
+	}
+	m.log.WithField("instances", len(consoleLogs)).Info("gathered console logs")
+}
+
+// consoleLogPaths returns the paths of the console logs gathered for a failed install.
+func (m *InstallManager) consoleLogPaths() []string {
+	paths, _ := filepath.Glob(filepath.Join(m.WorkDir, consoleLogsDir, "*.log"))
+	return paths
+}
+
+// awsConsoleLogs returns the console output of the bootstrap and control plane EC2 instances of the cluster, by
+// instance name. The output of the instances which cannot be read is left out.
+func awsConsoleLogs(awsClient awsclient.Client, infraID string, logger log.FieldLogger) (map[string]string, error) {
+	input := &ec2.DescribeInstancesInput{
+		Filters: []*ec2.Filter{
+			{
+				Name:   aws.String(fmt.Sprintf("tag:%s%s", kubernetesKeyPrefix, infraID)),
+				Values: aws.StringSlice([]string{"owned"}),
+			},
+			{
+				Name:   aws.String("tag:Name"),
+				Values: aws.StringSlice([]string{infraID + "-bootstrap", infraID + "-master-*"}),
+			},
+		},
+	}
+	consoleLogs := map[string]string{}
+	for {
+		out, err := awsClient.DescribeInstances(input)
+		if err != nil {
+			return consoleLogs, errors.Wrap(err, "could not describe instances")
+		}
+		for _, reservation := range out.Reservations {
+			for _, instance := range reservation.Instances {
+				name := aws.StringValue(instance.InstanceId)
+				for _, tag := range instance.Tags {
+					if aws.StringValue(tag.Key) == "Name" {
+						name = aws.StringValue(tag.Value)
+					}
+				}
+				iLog := logger.WithField("instance", name)
+				output, err := awsClient.GetConsoleOutput(&ec2.GetConsoleOutputInput{InstanceId: instance.InstanceId})
+				if err != nil {
+					iLog.WithError(err).Warn("could not get console output")
+					continue
+				}
+				decoded, err := base64.StdEncoding.DecodeString(aws.StringValue(output.Output))
+				if err != nil {
+					iLog.WithError(err).Warn("could not decode console output")
+					continue
+				}
+				consoleLogs[name] = string(decoded)
+			}
+		}
+		if out.NextToken == nil {
+			return consoleLogs, nil
+		}
+		input.NextToken = out.NextToken
+	}
+}
+
+// gcpConsoleLogs returns the serial port output of the bootstrap and control plane compute instances of the
+// cluster, by instance name. The output of the instances which cannot be read is left out.
+func gcpConsoleLogs(gcpClient gcpclient.Client, infraID string, logger log.FieldLogger) (map[string]string, error) {
+	var instances []*compute.Instance
+	err := gcpClient.ListComputeInstances(context.TODO(), gcpclient.ListComputeInstancesOptions{
+		Filter: fmt.Sprintf(`name eq "%s-(bootstrap|master-[0-9]+)"`, infraID),
+	}, func(list *compute.InstanceAggregatedList) error {
+		for _, scopedList := range list.Items {
+			instances = append(instances, scopedList.Instances...)
+		}
+		return nil
+	})
+	consoleLogs := map[string]string{}
+	if err != nil {
+		return consoleLogs, err
+	}
+	for _, instance := range instances {
+		output, err := gcpClient.GetSerialPortOutput(context.TODO(), instance)
+		if err != nil {
+			logger.WithField("instance", instance.Name).WithError(err).Warn("could not get serial port output")
+			continue
+		}
+		consoleLogs[instance.Name] = output
+	}
+	return consoleLogs, nil
+}
+
+// writeConsoleLogs writes each console log to a file of the directory named after its instance.
+func writeConsoleLogs(dir string, consoleLogs map[string]string) error {
+	if len(consoleLogs) == 0 {
+		return nil
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+	for name, output := range consoleLogs {
+		if err := ioutil.WriteFile(filepath.Join(dir, filepath.Base(name)+".log"), []byte(output), 0600); err != nil {
+			return err
+		}
+	}
+	return nil
+}
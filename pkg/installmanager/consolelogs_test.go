@@ -0,0 +1,115 @@
+package installmanager
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	compute "google.golang.org/api/compute/v1"
+
+	"github.com/openshift/hive/pkg/gcpclient"
+	mockgcp "github.com/openshift/hive/pkg/gcpclient/mock"
+)
+
+const testInfraID = "test-infra-id"
+
+func testEC2Instance(id, name string) *ec2.Instance {
+	return &ec2.Instance{
+		InstanceId: aws.String(id),
+		Tags:       []*ec2.Tag{{Key: aws.String("Name"), Value: aws.String(name)}},
+	}
+}
+
+func TestAWSConsoleLogs(t *testing.T) {
+	mocks := setupDefaultMocks(t)
+	defer mocks.mockCtrl.Finish()
+
+	mocks.mockAWSClient.EXPECT().DescribeInstances(gomock.Any()).DoAndReturn(
+		func(input *ec2.DescribeInstancesInput) (*ec2.DescribeInstancesOutput, error) {
+			require.Len(t, input.Filters, 2, "unexpected filters")
+			assert.Equal(t, "tag:kubernetes.io/cluster/"+testInfraID, aws.StringValue(input.Filters[0].Name), "unexpected cluster filter")
+			assert.Equal(t, []string{testInfraID + "-bootstrap", testInfraID + "-master-*"}, aws.StringValueSlice(input.Filters[1].Values), "unexpected name filter")
+			return &ec2.DescribeInstancesOutput{
+				Reservations: []*ec2.Reservation{{Instances: []*ec2.Instance{
+					testEC2Instance("i-bootstrap", testInfraID+"-bootstrap"),
+					testEC2Instance("i-master-0", testInfraID+"-master-0"),
+				}}},
+				NextToken: aws.String("next"),
+			}, nil
+		})
+	mocks.mockAWSClient.EXPECT().DescribeInstances(gomock.Any()).DoAndReturn(
+		func(input *ec2.DescribeInstancesInput) (*ec2.DescribeInstancesOutput, error) {
+			assert.Equal(t, "next", aws.StringValue(input.NextToken), "expected the next page to be requested")
+			return &ec2.DescribeInstancesOutput{
+				Reservations: []*ec2.Reservation{{Instances: []*ec2.Instance{
+					testEC2Instance("i-master-1", testInfraID+"-master-1"),
+				}}},
+			}, nil
+		})
+	mocks.mockAWSClient.EXPECT().GetConsoleOutput(&ec2.GetConsoleOutputInput{InstanceId: aws.String("i-bootstrap")}).
+		Return(&ec2.GetConsoleOutputOutput{Output: aws.String(base64.StdEncoding.EncodeToString([]byte("ignition failed")))}, nil)
+	mocks.mockAWSClient.EXPECT().GetConsoleOutput(&ec2.GetConsoleOutputInput{InstanceId: aws.String("i-master-0")}).
+		Return(nil, errors.New("access denied"))
+	mocks.mockAWSClient.EXPECT().GetConsoleOutput(&ec2.GetConsoleOutputInput{InstanceId: aws.String("i-master-1")}).
+		Return(&ec2.GetConsoleOutputOutput{Output: aws.String(base64.StdEncoding.EncodeToString([]byte("waiting for bootstrap")))}, nil)
+
+	consoleLogs, err := awsConsoleLogs(mocks.mockAWSClient, testInfraID, log.WithField("test", "TestAWSConsoleLogs"))
+	require.NoError(t, err, "unexpected error gathering console logs")
+	assert.Equal(t, map[string]string{
+		testInfraID + "-bootstrap": "ignition failed",
+		testInfraID + "-master-1":  "waiting for bootstrap",
+	}, consoleLogs, "unexpected console logs")
+}
+
+func TestGCPConsoleLogs(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	gcpClient := mockgcp.NewMockClient(mockCtrl)
+
+	bootstrap := &compute.Instance{Name: testInfraID + "-bootstrap", Zone: "zones/us-east1-b"}
+	master := &compute.Instance{Name: testInfraID + "-master-0", Zone: "zones/us-east1-c"}
+	gcpClient.EXPECT().ListComputeInstances(gomock.Any(), gcpclient.ListComputeInstancesOptions{
+		Filter: `name eq "test-infra-id-(bootstrap|master-[0-9]+)"`,
+	}, gomock.Any()).DoAndReturn(func(ctx context.Context, opts gcpclient.ListComputeInstancesOptions, pagesFn func(*compute.InstanceAggregatedList) error) error {
+		return pagesFn(&compute.InstanceAggregatedList{Items: map[string]compute.InstancesScopedList{
+			"zones/us-east1-b": {Instances: []*compute.Instance{bootstrap}},
+			"zones/us-east1-c": {Instances: []*compute.Instance{master}},
+		}})
+	})
+	gcpClient.EXPECT().GetSerialPortOutput(gomock.Any(), bootstrap).Return("ignition failed", nil)
+	gcpClient.EXPECT().GetSerialPortOutput(gomock.Any(), master).Return("", errors.New("access denied"))
+
+	consoleLogs, err := gcpConsoleLogs(gcpClient, testInfraID, log.WithField("test", "TestGCPConsoleLogs"))
+	require.NoError(t, err, "unexpected error gathering console logs")
+	assert.Equal(t, map[string]string{testInfraID + "-bootstrap": "ignition failed"}, consoleLogs, "unexpected console logs")
+}
+
+func TestWriteConsoleLogs(t *testing.T) {
+	workDir, err := ioutil.TempDir("", "consolelogs")
+	require.NoError(t, err)
+	defer os.RemoveAll(workDir)
+	m := &InstallManager{WorkDir: workDir}
+
+	require.NoError(t, writeConsoleLogs(filepath.Join(workDir, consoleLogsDir), map[string]string{
+		testInfraID + "-bootstrap": "ignition failed",
+	}))
+	paths := m.consoleLogPaths()
+	require.Equal(t, []string{filepath.Join(workDir, consoleLogsDir, testInfraID+"-bootstrap.log")}, paths, "unexpected console log paths")
+	data, err := ioutil.ReadFile(paths[0])
+	require.NoError(t, err)
+	assert.Equal(t, "ignition failed", string(data), "unexpected console log")
+
+	artifacts, err := installArtifactPaths(workDir, true)
+	require.NoError(t, err)
+	assert.Contains(t, artifacts, filepath.Join(consoleLogsDir, testInfraID+"-bootstrap.log"), "expected console logs to be installer artifacts")
+}
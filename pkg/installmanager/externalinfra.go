@@ -0,0 +1,242 @@
+package installmanager
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1none "github.com/openshift/hive/apis/hive/v1/none"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	k8slabels "github.com/openshift/hive/pkg/util/labels"
+)
+
+const (
+	ignitionSecretStringTemplate = "%s-ignition"
+
+	defaultExternalInfraTimeout  = 2 * time.Hour
+	externalInfraPollInterval    = 30 * time.Second
+	ignitionUploadRequestTimeout = 2 * time.Minute
+)
+
+// ignitionFiles are the ignition configs generated by the installer that external infrastructure hooks need.
+var ignitionFiles = []string{"bootstrap.ign", "master.ign", "worker.ign"}
+
+// provisionExternalInfraCluster orchestrates an install on infrastructure created outside of Hive. Rather than
+// letting the installer create the infrastructure, the generated ignition configs are published for external
+// infrastructure hooks, and each install stage waits on the hooks (or the cluster) before moving on. Progress is
+// reported to the hooks through conditions on the ClusterProvision.
+func provisionExternalInfraCluster(m *InstallManager, provision *hivev1.ClusterProvision, cd *hivev1.ClusterDeployment) error {
+	platform := cd.Spec.Platform.None
+
+	m.log.Info("publishing ignition configs")
+	message, err := m.publishIgnition(provision, platform.IgnitionDelivery)
+	if err != nil {
+		m.log.WithError(err).Error("error publishing ignition configs")
+		return errors.Wrap(err, "error publishing ignition configs")
+	}
+	if err := m.setProvisionCondition(provision, hivev1.ClusterProvisionIgnitionPublishedCondition, "IgnitionPublished", message); err != nil {
+		return err
+	}
+
+	timeout := defaultExternalInfraTimeout
+	if platform.InfrastructureTimeout != nil {
+		timeout = platform.InfrastructureTimeout.Duration
+	}
+	m.log.WithField("timeout", timeout).Info("waiting for external infrastructure hooks to create machines")
+	if err := m.waitForExternalInfra(provision, externalInfraPollInterval, timeout); err != nil {
+		m.log.WithError(err).Error("external infrastructure was not created")
+		return err
+	}
+	if err := m.setProvisionCondition(provision, hivev1.ClusterProvisionInfrastructureReadyCondition, "InfrastructureReady",
+		"External infrastructure hooks reported that machines have been created"); err != nil {
+		return err
+	}
+
+	m.log.Info("waiting for bootstrap to complete")
+	if err := m.runOpenShiftInstallCommand("wait-for", "bootstrap-complete"); err != nil {
+		m.log.WithError(err).Error("error waiting for bootstrap to complete")
+		return err
+	}
+	if err := m.setProvisionCondition(provision, hivev1.ClusterProvisionBootstrapCompleteCondition, "BootstrapComplete",
+		"Bootstrap is complete, the bootstrap machine can be removed"); err != nil {
+		return err
+	}
+
+	m.log.Info("waiting for install to complete")
+	err = m.runOpenShiftInstallCommand("wait-for", "install-complete")
+	for i := 0; err != nil && i < m.waitForInstallCompleteExecutions; i++ {
+		m.log.WithField("waitIteration", i).WithError(err).Warn("waiting longer for install to complete")
+		err = m.runOpenShiftInstallCommand("wait-for", "install-complete")
+	}
+	if err != nil {
+		m.log.WithError(err).Error("error waiting for install to complete")
+		return err
+	}
+	return nil
+}
+
+// publishIgnition makes the generated ignition configs available to the external infrastructure hooks. It returns
+// a message describing where the ignition configs can be found.
+func (m *InstallManager) publishIgnition(provision *hivev1.ClusterProvision, delivery hivev1none.IgnitionDelivery) (string, error) {
+	ignition := map[string][]byte{}
+	for _, name := range ignitionFiles {
+		data, err := ioutil.ReadFile(filepath.Join(m.WorkDir, name))
+		if err != nil {
+			return "", errors.Wrapf(err, "error reading %s", name)
+		}
+		ignition[name] = data
+	}
+
+	switch delivery.Type {
+	case "", hivev1none.IgnitionDeliverySecret:
+		secret, err := m.uploadIgnitionSecret(provision, ignition)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Ignition configs are available in secret %s", secret.Name), nil
+	case hivev1none.IgnitionDeliveryObjectStore:
+		if delivery.ObjectStore == nil {
+			return "", errors.New("object store ignition delivery is missing objectStore configuration")
+		}
+		if err := m.uploadIgnitionToObjectStore(delivery.ObjectStore.URLsSecretRef.Name, ignition); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Ignition configs have been uploaded to the URLs in secret %s", delivery.ObjectStore.URLsSecretRef.Name), nil
+	default:
+		return "", fmt.Errorf("unsupported ignition delivery type %q", delivery.Type)
+	}
+}
+
+func (m *InstallManager) uploadIgnitionSecret(provision *hivev1.ClusterProvision, ignition map[string][]byte) (*corev1.Secret, error) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf(ignitionSecretStringTemplate, provision.Name),
+			Namespace: m.Namespace,
+		},
+		Data: ignition,
+	}
+	secret.Labels = k8slabels.AddLabel(secret.Labels, constants.ClusterProvisionNameLabel, provision.Name)
+
+	provisionGVK, err := apiutil.GVKForObject(provision, scheme.Scheme)
+	if err != nil {
+		m.log.WithError(err).Errorf("error getting GVK for provision")
+		return nil, err
+	}
+	secret.OwnerReferences = []metav1.OwnerReference{{
+		APIVersion:         provisionGVK.GroupVersion().String(),
+		Kind:               provisionGVK.Kind,
+		Name:               provision.Name,
+		UID:                provision.UID,
+		BlockOwnerDeletion: pointer.BoolPtr(true),
+	}}
+
+	// A previous run of this provision may have already published the ignition.
+	if err := m.deleteAnyExistingObject(types.NamespacedName{Namespace: secret.Namespace, Name: secret.Name}, &corev1.Secret{}); err != nil {
+		return nil, err
+	}
+	if err := createWithRetries(secret, m); err != nil {
+		return nil, err
+	}
+	return secret, nil
+}
+
+func (m *InstallManager) uploadIgnitionToObjectStore(urlsSecretName string, ignition map[string][]byte) error {
+	urlsSecret := &corev1.Secret{}
+	if err := m.DynamicClient.Get(context.Background(), types.NamespacedName{Namespace: m.Namespace, Name: urlsSecretName}, urlsSecret); err != nil {
+		return errors.Wrap(err, "error reading ignition upload URLs")
+	}
+	httpClient := &http.Client{Timeout: ignitionUploadRequestTimeout}
+	for _, name := range ignitionFiles {
+		uploadURL := string(urlsSecret.Data[name])
+		if uploadURL == "" {
+			return fmt.Errorf("secret %s is missing an upload URL for %s", urlsSecretName, name)
+		}
+		req, err := http.NewRequest(http.MethodPut, uploadURL, bytes.NewReader(ignition[name]))
+		if err != nil {
+			return errors.Wrapf(err, "error creating upload request for %s", name)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			return errors.Wrapf(err, "error uploading %s", name)
+		}
+		resp.Body.Close()
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			return fmt.Errorf("error uploading %s: %s", name, resp.Status)
+		}
+		m.log.WithField("file", name).Info("uploaded ignition config")
+	}
+	return nil
+}
+
+// waitForExternalInfra waits for the external infrastructure hooks to annotate the ClusterProvision, either to report
+// that the machines have been created or that creating them failed.
+func (m *InstallManager) waitForExternalInfra(provision *hivev1.ClusterProvision, interval, timeout time.Duration) error {
+	err := wait.PollImmediate(interval, timeout, func() (bool, error) {
+		if err := m.loadClusterProvision(provision); err != nil {
+			m.log.WithError(err).Warn("error reading in fresh clusterprovision")
+			return false, nil
+		}
+		if message, failed := provision.Annotations[constants.ExternalInfraFailedAnnotation]; failed {
+			return false, fmt.Errorf("external infrastructure hooks reported a failure: %s", message)
+		}
+		ready, _ := strconv.ParseBool(provision.Annotations[constants.ExternalInfraReadyAnnotation])
+		return ready, nil
+	})
+	if err == wait.ErrWaitTimeout {
+		return fmt.Errorf("timed out after %s waiting for external infrastructure", timeout)
+	}
+	return err
+}
+
+func (m *InstallManager) setProvisionCondition(provision *hivev1.ClusterProvision, conditionType hivev1.ClusterProvisionConditionType, reason, message string) error {
+	err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		if err := m.loadClusterProvision(provision); err != nil {
+			return err
+		}
+		provision.Status.Conditions = controllerutils.SetClusterProvisionCondition(
+			provision.Status.Conditions,
+			conditionType,
+			corev1.ConditionTrue,
+			reason,
+			message,
+			controllerutils.UpdateConditionIfReasonOrMessageChange,
+		)
+		return m.DynamicClient.Status().Update(context.Background(), provision)
+	})
+	if err != nil {
+		m.log.WithError(err).WithField("condition", conditionType).Error("error setting clusterprovision condition")
+	}
+	return err
+}
+
+// destroyerWebhookToken reads the bearer token for an external destroyer webhook, if one is configured.
+func destroyerWebhookToken(dynClient client.Client, namespace string, webhook *hivev1none.DestroyerWebhook) (string, error) {
+	if webhook.TokenSecretRef == nil {
+		return "", nil
+	}
+	secret := &corev1.Secret{}
+	if err := dynClient.Get(context.Background(), types.NamespacedName{Namespace: namespace, Name: webhook.TokenSecretRef.Name}, secret); err != nil {
+		return "", errors.Wrap(err, "error reading destroyer webhook token")
+	}
+	return string(secret.Data[constants.DestroyerWebhookTokenSecretKey]), nil
+}
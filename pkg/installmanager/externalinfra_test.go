@@ -0,0 +1,198 @@
+package installmanager
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+
+	"github.com/openshift/hive/apis"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1none "github.com/openshift/hive/apis/hive/v1/none"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+func TestPublishIgnition(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+	uploaded := map[string]string{}
+	var lock sync.Mutex
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		lock.Lock()
+		uploaded[r.URL.Path] = string(body)
+		lock.Unlock()
+	}))
+	defer server.Close()
+
+	urlsSecret := &corev1.Secret{}
+	urlsSecret.Name = "ignition-urls"
+	urlsSecret.Namespace = testNamespace
+	urlsSecret.Data = map[string][]byte{}
+	for _, name := range ignitionFiles {
+		urlsSecret.Data[name] = []byte(fmt.Sprintf("%s/upload/%s", server.URL, name))
+	}
+
+	cases := []struct {
+		name           string
+		delivery       hivev1none.IgnitionDelivery
+		existing       []runtime.Object
+		expectErr      bool
+		expectSecret   bool
+		expectUploaded bool
+	}{
+		{
+			name:         "default to secret",
+			expectSecret: true,
+		},
+		{
+			name:         "replace existing secret",
+			delivery:     hivev1none.IgnitionDelivery{Type: hivev1none.IgnitionDeliverySecret},
+			existing:     []runtime.Object{testSecret(corev1.SecretTypeOpaque, testProvisionName+"-ignition", "bootstrap.ign", "stale")},
+			expectSecret: true,
+		},
+		{
+			name: "object store",
+			delivery: hivev1none.IgnitionDelivery{
+				Type:        hivev1none.IgnitionDeliveryObjectStore,
+				ObjectStore: &hivev1none.IgnitionObjectStore{URLsSecretRef: corev1.LocalObjectReference{Name: urlsSecret.Name}},
+			},
+			existing:       []runtime.Object{urlsSecret},
+			expectUploaded: true,
+		},
+		{
+			name: "object store missing URLs secret",
+			delivery: hivev1none.IgnitionDelivery{
+				Type:        hivev1none.IgnitionDeliveryObjectStore,
+				ObjectStore: &hivev1none.IgnitionObjectStore{URLsSecretRef: corev1.LocalObjectReference{Name: urlsSecret.Name}},
+			},
+			expectErr: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			uploaded = map[string]string{}
+			workDir, err := ioutil.TempDir("", "externalinfra")
+			require.NoError(t, err)
+			defer os.RemoveAll(workDir)
+			for _, name := range ignitionFiles {
+				require.NoError(t, ioutil.WriteFile(filepath.Join(workDir, name), []byte("ignition-"+name), 0600))
+			}
+
+			provision := testClusterProvision()
+			mocks := setupDefaultMocks(t, append(tc.existing, provision)...)
+			m := &InstallManager{
+				log:           log.WithField("test", tc.name),
+				WorkDir:       workDir,
+				Namespace:     testNamespace,
+				DynamicClient: mocks.fakeKubeClient,
+			}
+
+			_, err = m.publishIgnition(provision, tc.delivery)
+			if tc.expectErr {
+				assert.Error(t, err, "expected error publishing ignition")
+				return
+			}
+			require.NoError(t, err, "unexpected error publishing ignition")
+
+			if tc.expectSecret {
+				secret := &corev1.Secret{}
+				require.NoError(t, mocks.fakeKubeClient.Get(context.TODO(),
+					types.NamespacedName{Namespace: testNamespace, Name: testProvisionName + "-ignition"}, secret))
+				for _, name := range ignitionFiles {
+					assert.Equal(t, "ignition-"+name, string(secret.Data[name]), "unexpected ignition in secret")
+				}
+				assert.Equal(t, testProvisionName, secret.Labels[constants.ClusterProvisionNameLabel], "missing provision label")
+			}
+			if tc.expectUploaded {
+				for _, name := range ignitionFiles {
+					assert.Equal(t, "ignition-"+name, uploaded["/upload/"+name], "unexpected uploaded ignition")
+				}
+			}
+		})
+	}
+}
+
+func TestWaitForExternalInfra(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+	cases := []struct {
+		name        string
+		annotations map[string]string
+		expectErr   bool
+	}{
+		{
+			name:        "infra ready",
+			annotations: map[string]string{constants.ExternalInfraReadyAnnotation: "true"},
+		},
+		{
+			name:        "infra failed",
+			annotations: map[string]string{constants.ExternalInfraFailedAnnotation: "out of capacity"},
+			expectErr:   true,
+		},
+		{
+			name:      "timed out",
+			expectErr: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			provision := testClusterProvision()
+			provision.Annotations = tc.annotations
+			mocks := setupDefaultMocks(t, provision)
+			m := &InstallManager{
+				log:                  log.WithField("test", tc.name),
+				Namespace:            testNamespace,
+				ClusterProvisionName: testProvisionName,
+				DynamicClient:        mocks.fakeKubeClient,
+			}
+
+			err := m.waitForExternalInfra(provision, 10*time.Millisecond, 50*time.Millisecond)
+			if tc.expectErr {
+				assert.Error(t, err, "expected error waiting for external infra")
+			} else {
+				assert.NoError(t, err, "unexpected error waiting for external infra")
+			}
+		})
+	}
+}
+
+func TestSetProvisionCondition(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+	provision := testClusterProvision()
+	mocks := setupDefaultMocks(t, provision)
+	m := &InstallManager{
+		log:                  log.WithField("test", "TestSetProvisionCondition"),
+		Namespace:            testNamespace,
+		ClusterProvisionName: testProvisionName,
+		DynamicClient:        mocks.fakeKubeClient,
+	}
+
+	require.NoError(t, m.setProvisionCondition(provision, hivev1.ClusterProvisionIgnitionPublishedCondition, "IgnitionPublished", "published"))
+
+	updated := &hivev1.ClusterProvision{}
+	require.NoError(t, mocks.fakeKubeClient.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: testProvisionName}, updated))
+	cond := controllerutils.FindClusterProvisionCondition(updated.Status.Conditions, hivev1.ClusterProvisionIgnitionPublishedCondition)
+	if assert.NotNil(t, cond, "expected condition to be set") {
+		assert.Equal(t, corev1.ConditionTrue, cond.Status, "unexpected condition status")
+		assert.Equal(t, "published", cond.Message, "unexpected condition message")
+	}
+}
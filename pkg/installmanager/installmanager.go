@@ -55,8 +55,16 @@ import (
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	contributils "github.com/openshift/hive/contrib/pkg/utils"
+	ociutils "github.com/openshift/hive/contrib/pkg/utils/oci"
+	powervsutils "github.com/openshift/hive/contrib/pkg/utils/powervs"
+	"github.com/openshift/hive/pkg/awsclient"
 	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	hivedeprovision "github.com/openshift/hive/pkg/deprovision"
+	"github.com/openshift/hive/pkg/externaldestroyer"
 	"github.com/openshift/hive/pkg/gcpclient"
+	"github.com/openshift/hive/pkg/ociclient"
+	"github.com/openshift/hive/pkg/powervsclient"
 	"github.com/openshift/hive/pkg/resource"
 	k8slabels "github.com/openshift/hive/pkg/util/labels"
 )
@@ -77,6 +85,7 @@ const (
 	sshCopyTempFile                     = "/tmp/ssh-privatekey"
 	defaultInstallConfigMountPath       = "/installconfig/install-config.yaml"
 	defaultPullSecretMountPath          = "/pullsecret/" + corev1.DockerConfigJsonKey
+	defaultTrustBundleMountPath         = "/additionaltrustbundle/" + constants.AdditionalTrustBundleSecretKey
 	defaultManifestsMountPath           = "/manifests"
 	defaultHomeDir                      = "/home/hive" // Used if no HOME env var set.
 )
@@ -99,6 +108,7 @@ type InstallManager struct {
 	Namespace                        string
 	InstallConfigMountPath           string
 	PullSecretMountPath              string
+	TrustBundleMountPath             string
 	ManifestsMountPath               string
 	DynamicClient                    client.Client
 	cleanupFailedProvision           func(dynamicClient client.Client, cd *hivev1.ClusterDeployment, infraID string, logger log.FieldLogger) error
@@ -108,11 +118,17 @@ type InstallManager struct {
 	uploadAdminPassword              func(*hivev1.ClusterProvision, *InstallManager) (*corev1.Secret, error)
 	loadAdminPassword                func(*InstallManager) (string, error)
 	provisionCluster                 func(*InstallManager) error
+	provisionExternalInfraCluster    func(*InstallManager, *hivev1.ClusterProvision, *hivev1.ClusterDeployment) error
+	provisionAgentImageCluster       func(*InstallManager, *hivev1.ClusterProvision, *hivev1.ClusterDeployment) error
 	readInstallerLog                 func(*hivev1.ClusterProvision, *InstallManager, bool) (string, error)
 	waitForProvisioningStage         func(*hivev1.ClusterProvision, *InstallManager) error
+	restoreCluster                   func(*InstallManager, *hivev1.ClusterDeployment) error
 	waitForInstallCompleteExecutions int
 	binaryDir                        string
 	actuator                         LogUploaderActuator
+	cloudAPIAuditor                  *cloudAPIAuditor
+	installLogStreamer               *installLogStreamer
+	installArtifacts                 *installArtifacts
 }
 
 // NewInstallManagerCommand is the entrypoint to create the 'install-manager' subcommand
@@ -138,6 +154,7 @@ func NewInstallManagerCommand() *cobra.Command {
 			im.Namespace, im.ClusterProvisionName = args[0], args[1]
 			im.InstallConfigMountPath = defaultInstallConfigMountPath
 			im.PullSecretMountPath = defaultPullSecretMountPath
+			im.TrustBundleMountPath = defaultTrustBundleMountPath
 			im.ManifestsMountPath = defaultManifestsMountPath
 			im.binaryDir = getHomeDir()
 
@@ -175,7 +192,10 @@ func (m *InstallManager) Complete(args []string) error {
 	m.readInstallerLog = readInstallerLog
 	m.cleanupFailedProvision = cleanupFailedProvision
 	m.provisionCluster = provisionCluster
+	m.provisionExternalInfraCluster = provisionExternalInfraCluster
+	m.provisionAgentImageCluster = provisionAgentImageCluster
 	m.waitForProvisioningStage = waitForProvisioningStage
+	m.restoreCluster = restoreCluster
 
 	// Set log level
 	level, err := log.ParseLevel(m.LogLevel)
@@ -213,6 +233,11 @@ func (m *InstallManager) Complete(args []string) error {
 		m.loadAdminPassword = fakeLoadAdminPassword
 		m.readClusterMetadata = fakeReadClusterMetadata
 		m.provisionCluster = fakeProvisionCluster
+		m.provisionExternalInfraCluster = func(m *InstallManager, _ *hivev1.ClusterProvision, _ *hivev1.ClusterDeployment) error {
+			return fakeProvisionCluster(m)
+		}
+		m.provisionAgentImageCluster = m.provisionExternalInfraCluster
+		m.restoreCluster = func(*InstallManager, *hivev1.ClusterDeployment) error { return nil }
 	}
 
 	return nil
@@ -299,6 +324,23 @@ func (m *InstallManager) Run() error {
 		return err
 	}
 
+	streamer, err := m.setupInstallLogStreamer(cd, provision)
+	if err != nil {
+		// Not a fatal error.
+		m.log.WithError(err).Warn("unable to stream installer log")
+	} else if streamer != nil {
+		m.installLogStreamer = streamer
+		streamer.start()
+		defer streamer.stop()
+	}
+
+	artifacts, err := m.setupInstallArtifacts(cd, provision)
+	if err != nil {
+		// Not a fatal error.
+		m.log.WithError(err).Warn("unable to upload installer artifacts")
+	}
+	m.installArtifacts = artifacts
+
 	go m.tailFullInstallLog(scrubInstallLog)
 
 	m.log.Info("copying install-config.yaml")
@@ -312,12 +354,54 @@ func (m *InstallManager) Run() error {
 		m.log.WithError(err).Error("error adding pull secret to install-config.yaml")
 		return err
 	}
+	icData, err = pasteInAdditionalTrustBundle(icData, m.TrustBundleMountPath)
+	if err != nil {
+		m.log.WithError(err).Error("error adding additional trust bundle to install-config.yaml")
+		return err
+	}
+	if mode := credentialsMode(cd); mode != "" {
+		icData, err = pasteInCredentialsMode(icData, mode)
+		if err != nil {
+			m.log.WithError(err).Error("error adding credentials mode to install-config.yaml")
+			return err
+		}
+	}
+	if cd.Spec.Provisioning != nil && cd.Spec.Provisioning.FeatureSet != nil {
+		icData, err = pasteInFeatureSet(icData, cd.Spec.Provisioning.FeatureSet)
+		if err != nil {
+			m.log.WithError(err).Error("error adding feature set to install-config.yaml")
+			return err
+		}
+	}
+	if cd.Spec.Provisioning != nil && cd.Spec.Provisioning.Proxy != nil {
+		icData, err = pasteInProxy(icData, cd.Spec.Provisioning.Proxy)
+		if err != nil {
+			m.log.WithError(err).Error("error adding proxy to install-config.yaml")
+			return err
+		}
+	}
+	if cd.Spec.BaseDomainPoolRef != nil {
+		// The base domain was allocated from the pool after the install-config was generated.
+		icData, err = pasteInBaseDomain(icData, cd.Spec.BaseDomain)
+		if err != nil {
+			m.log.WithError(err).Error("error adding base domain to install-config.yaml")
+			return err
+		}
+	}
 	destInstallConfigPath := filepath.Join(m.WorkDir, "install-config.yaml")
 	if err := ioutil.WriteFile(destInstallConfigPath, icData, 0644); err != nil {
 		m.log.WithError(err).Error("error writing install-config.yaml")
 	}
 	m.log.Infof("copied %s to %s", m.InstallConfigMountPath, destInstallConfigPath)
 
+	if strategy := agentImageInstallStrategy(cd); strategy != nil {
+		m.log.Info("copying agent-config.yaml")
+		if err := m.writeAgentConfig(strategy); err != nil {
+			m.log.WithError(err).Error("error writing agent-config.yaml")
+			return err
+		}
+	}
+
 	if cd.Spec.Provisioning != nil && len(cd.Spec.Provisioning.SSHKnownHosts) > 0 {
 		err = m.writeSSHKnownHosts(getHomeDir(), cd.Spec.Provisioning.SSHKnownHosts)
 		if err != nil {
@@ -334,9 +418,22 @@ func (m *InstallManager) Run() error {
 		return err
 	}
 
+	if cd.Annotations[constants.CloudAPIAuditAnnotation] == "true" {
+		m.log.Info("recording cloud API calls made by the installer")
+		auditor, err := startCloudAPIAuditor(m.WorkDir, m.log)
+		if err != nil {
+			m.log.WithError(err).Warn("could not start recording cloud API calls")
+		} else {
+			m.cloudAPIAuditor = auditor
+			defer m.publishCloudAPICalls(provision)
+		}
+	}
+
 	// Generate installer assets we need to modify or upload.
 	m.log.Info("generating assets")
 	if err := m.generateAssets(cd); err != nil {
+		m.uploadInstallArtifacts(true, scrubInstallLog)
+
 		m.log.Info("reading installer log")
 		installLog, readErr := m.readInstallerLog(provision, m, scrubInstallLog)
 		if readErr != nil {
@@ -415,7 +512,20 @@ func (m *InstallManager) Run() error {
 		}
 	}
 
-	installErr := m.provisionCluster(m)
+	var installErr error
+	switch {
+	case agentImageInstallStrategy(cd) != nil:
+		installErr = m.provisionAgentImageCluster(m, provision, cd)
+	case cd.Spec.Platform.None != nil:
+		installErr = m.provisionExternalInfraCluster(m, provision, cd)
+	default:
+		installErr = m.provisionCluster(m)
+	}
+	if installErr == nil && cd.Spec.RestoreRef != nil {
+		// The cluster is restored before it is reported installed. A failed restore fails the install like a failed
+		// provision, so that the retry starts from a new cluster.
+		installErr = m.restoreCluster(m, cd)
+	}
 	if installErr != nil {
 		m.log.WithError(installErr).Error("error running openshift-install, running deprovision to clean up")
 
@@ -431,11 +541,23 @@ func (m *InstallManager) Run() error {
 			}
 		}
 
+		bootstrapComplete := m.isBootstrapComplete()
+		if bootstrapComplete {
+			// Let the clusterprovision controller know that the cluster API is up, so that it can collect a
+			// must-gather from the failed cluster.
+			if err := m.setProvisionCondition(provision, hivev1.ClusterProvisionBootstrapCompleteCondition, "BootstrapComplete", "Bootstrap completed before the install failed"); err != nil {
+				// Not a fatal error.
+				m.log.WithError(err).Warn("error recording bootstrap completion of failed install")
+			}
+		}
+
+		m.gatherConsoleLogs(cd, metadata.InfraID)
+
 		// Fetch logs from all cluster machines:
 		if m.actuator == nil {
 			m.log.Debug("Unable to find log storage actuator. Disabling gathering logs.")
 		} else {
-			m.gatherLogs(provision, cd, sshKeyPath, sshAgentSetupErr)
+			m.gatherLogs(provision, cd, bootstrapComplete, sshKeyPath, sshAgentSetupErr)
 		}
 	}
 
@@ -453,6 +575,8 @@ func (m *InstallManager) Run() error {
 		m.log.WithError(err).Error("error reading installer log")
 	}
 
+	m.uploadInstallArtifacts(installErr != nil, scrubInstallLog)
+
 	if installErr != nil {
 		m.log.WithError(installErr).Error("failed due to install error")
 		return installErr
@@ -651,6 +775,58 @@ func cleanupFailedProvision(dynClient client.Client, cd *hivev1.ClusterDeploymen
 		if err != nil {
 			return err
 		}
+	case cd.Spec.Platform.OCI != nil:
+		creds, err := ociutils.GetCreds("")
+		if err != nil {
+			return errors.Wrap(err, "could not get OCI credentials")
+		}
+		ociClient, err := ociclient.NewClient(creds, cd.Spec.Platform.OCI.Region)
+		if err != nil {
+			return errors.Wrap(err, "could not create OCI client")
+		}
+		uninstaller = &ociclient.ClusterUninstaller{
+			Client:        ociClient,
+			CompartmentID: cd.Spec.Platform.OCI.CompartmentID,
+			InfraID:       infraID,
+			Logger:        logger,
+		}
+	case cd.Spec.Platform.None != nil:
+		destroyer := cd.Spec.Platform.None.Destroyer
+		if destroyer == nil || destroyer.Webhook == nil {
+			// The infrastructure belongs to the external infrastructure hooks, which are responsible for replacing
+			// the machines of a failed attempt when the next ClusterProvision publishes new ignition configs.
+			// Destroyer jobs are only run when the ClusterDeployment is deleted.
+			logger.Info("infrastructure is managed externally, skipping cleanup")
+			return nil
+		}
+		token, err := destroyerWebhookToken(dynClient, cd.Namespace, destroyer.Webhook)
+		if err != nil {
+			return err
+		}
+		uninstaller = &externaldestroyer.WebhookDestroyer{
+			URL:   destroyer.Webhook.URL,
+			Token: token,
+			Request: externaldestroyer.Request{
+				InfraID:                    infraID,
+				ClusterDeploymentName:      cd.Name,
+				ClusterDeploymentNamespace: cd.Namespace,
+			},
+			Logger: logger,
+		}
+	case cd.Spec.Platform.PowerVS != nil:
+		apiKey, err := powervsutils.GetAPIKey()
+		if err != nil {
+			return errors.Wrap(err, "could not get PowerVS API key")
+		}
+		powerVSClient, err := powervsclient.NewClient(apiKey, cd.Spec.Platform.PowerVS.Region, cd.Spec.Platform.PowerVS.ServiceInstanceID)
+		if err != nil {
+			return errors.Wrap(err, "could not create PowerVS client")
+		}
+		uninstaller = &powervsclient.ClusterUninstaller{
+			Client:  powerVSClient,
+			InfraID: infraID,
+			Logger:  logger,
+		}
 	default:
 		logger.Warn("unknown platform for re-try cleanup")
 		return errors.New("unknown platform for re-try cleanup")
@@ -659,6 +835,16 @@ func cleanupFailedProvision(dynClient client.Client, cd *hivev1.ClusterDeploymen
 	if err := uninstaller.Run(); err != nil {
 		return err
 	}
+	if stsConfig(cd) != nil {
+		// The STS resources of the failed attempt are named after its infra ID, and would leak otherwise.
+		awsClient, err := awsclient.NewClient(nil, "", "", cd.Spec.Platform.AWS.Region)
+		if err != nil {
+			return errors.Wrap(err, "could not create AWS client")
+		}
+		if err := hivedeprovision.DeleteAWSSTSResources(awsClient, infraID, cd.Spec.Platform.AWS.Region, logger); err != nil {
+			return err
+		}
+	}
 
 	return cleanupDNSZone(dynClient, cd, logger)
 }
@@ -666,6 +852,10 @@ func cleanupFailedProvision(dynClient client.Client, cd *hivev1.ClusterDeploymen
 // generateAssets runs openshift-install commands to generate on-disk assets we need to
 // upload or modify prior to provisioning resources in the cloud.
 func (m *InstallManager) generateAssets(cd *hivev1.ClusterDeployment) error {
+	if strategy := agentImageInstallStrategy(cd); strategy != nil {
+		return m.generateAgentImageAssets(strategy)
+	}
+
 	m.log.Info("running openshift-install create manifests")
 	err := m.runOpenShiftInstallCommand("create", "manifests")
 	if err != nil {
@@ -690,6 +880,14 @@ func (m *InstallManager) generateAssets(cd *hivev1.ClusterDeployment) error {
 		}
 	}
 
+	if sts := stsConfig(cd); sts != nil {
+		m.log.Info("generating STS credentials")
+		if err := m.generateSTSCredentials(cd, sts); err != nil {
+			m.log.WithError(err).Error("error generating STS credentials")
+			return err
+		}
+	}
+
 	if src := m.ManifestsMountPath; isDirNonEmpty(src) {
 		m.log.Info("copying user-provided manifests")
 		dest := filepath.Join(m.WorkDir, "manifests")
@@ -702,6 +900,14 @@ func (m *InstallManager) generateAssets(cd *hivev1.ClusterDeployment) error {
 		m.log.Infof("copied %s to %s", src, dest)
 	}
 
+	if m.installArtifacts != nil {
+		// The installer consumes the manifests when creating the ignition configs.
+		if err := m.preserveRenderedManifests(); err != nil {
+			// Not a fatal error.
+			m.log.WithError(err).Warn("unable to preserve rendered manifests")
+		}
+	}
+
 	m.log.Info("running openshift-install create ignition-configs")
 	if err := m.runOpenShiftInstallCommand("create", "ignition-configs"); err != nil {
 		m.log.WithError(err).Error("error generating installer assets")
@@ -739,6 +945,9 @@ func (m *InstallManager) runOpenShiftInstallCommand(args ...string) error {
 	m.log.WithField("args", args).Info("running openshift-install binary")
 	cmd := exec.Command(filepath.Join(m.binaryDir, "openshift-install"), args...)
 	cmd.Dir = m.WorkDir
+	if m.cloudAPIAuditor != nil {
+		cmd.Env = append(os.Environ(), m.cloudAPIAuditor.env()...)
+	}
 
 	// save the commands' stdout/stderr to a file
 	stdOutAndErrOutput, err := os.OpenFile(installerConsoleLogFilePath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0666)
@@ -816,6 +1025,10 @@ func (m *InstallManager) tailFullInstallLog(scrubInstallLog bool) {
 		} else {
 			fmt.Println(fullLine)
 		}
+		if m.installLogStreamer != nil {
+			// The installer log always leaves the pod scrubbed.
+			m.installLogStreamer.add(cleanupLogOutput(fullLine))
+		}
 		// clear out the line buffer so we can start again
 		fullLine = ""
 	}
@@ -875,8 +1088,8 @@ func (m *InstallManager) loadClusterDeployment(provision *hivev1.ClusterProvisio
 // If neither succeeds we do not consider this a fatal error,
 // we're just gathering as much information as we can and then proceeding with cleanup
 // so we can re-try.
-func (m *InstallManager) gatherLogs(provision *hivev1.ClusterProvision, cd *hivev1.ClusterDeployment, sshPrivKeyPath string, sshAgentSetupErr error) {
-	if !m.isBootstrapComplete() {
+func (m *InstallManager) gatherLogs(provision *hivev1.ClusterProvision, cd *hivev1.ClusterDeployment, bootstrapComplete bool, sshPrivKeyPath string, sshAgentSetupErr error) {
+	if !bootstrapComplete {
 		if sshAgentSetupErr != nil {
 			m.log.Warn("unable to fetch logs from bootstrap node as SSH agent was not configured")
 			return
@@ -913,6 +1126,7 @@ func (m *InstallManager) gatherLogs(provision *hivev1.ClusterProvision, cd *hive
 
 		filepaths = append(filepaths, filepath.Join(m.LogsDir, file.Name()))
 	}
+	filepaths = append(filepaths, m.consoleLogPaths()...)
 
 	uploadErr := m.actuator.UploadLogs(cd.Spec.ClusterName, provision, m.DynamicClient, m.log, filepaths...)
 	if uploadErr != nil {
@@ -1431,6 +1645,106 @@ func pasteInPullSecret(icData []byte, pullSecretFile string) ([]byte, error) {
 	return yaml.Marshal(icRaw)
 }
 
+// pasteInAdditionalTrustBundle merges the certificate authorities configured in the HiveConfig, if any, into the
+// additionalTrustBundle of the install-config.
+func pasteInAdditionalTrustBundle(icData []byte, trustBundleFile string) ([]byte, error) {
+	if trustBundleFile == "" {
+		return icData, nil
+	}
+	trustBundleData, err := ioutil.ReadFile(trustBundleFile)
+	switch {
+	case os.IsNotExist(err):
+		return icData, nil
+	case err != nil:
+		return nil, errors.Wrap(err, "could not read the additional trust bundle file")
+	}
+	icRaw := map[string]interface{}{}
+	if err := yaml.Unmarshal(icData, &icRaw); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal InstallConfig")
+	}
+	existing, _ := icRaw["additionalTrustBundle"].(string)
+	merged := controllerutils.MergeTrustBundles([]byte(existing), trustBundleData)
+	if len(merged) == 0 {
+		return icData, nil
+	}
+	icRaw["additionalTrustBundle"] = string(merged)
+	return yaml.Marshal(icRaw)
+}
+
+// credentialsMode returns the credentials mode set on the platform of the clusterdeployment, if any.
+func credentialsMode(cd *hivev1.ClusterDeployment) string {
+	switch p := cd.Spec.Platform; {
+	case p.AWS != nil:
+		return string(p.AWS.CredentialsMode)
+	case p.Azure != nil:
+		return string(p.Azure.CredentialsMode)
+	case p.GCP != nil:
+		return string(p.GCP.CredentialsMode)
+	}
+	return ""
+}
+
+func pasteInCredentialsMode(icData []byte, mode string) ([]byte, error) {
+	icRaw := map[string]interface{}{}
+	if err := yaml.Unmarshal(icData, &icRaw); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal InstallConfig")
+	}
+	icRaw["credentialsMode"] = mode
+	return yaml.Marshal(icRaw)
+}
+
+// pasteInFeatureSet renders the feature set of the clusterdeployment into the install-config, replacing any feature
+// set the install-config already has.
+func pasteInFeatureSet(icData []byte, featureSet *hivev1.FeatureSetConfig) ([]byte, error) {
+	icRaw := map[string]interface{}{}
+	if err := yaml.Unmarshal(icData, &icRaw); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal InstallConfig")
+	}
+	icRaw["featureSet"] = string(featureSet.FeatureSet)
+	if len(featureSet.FeatureGates) > 0 {
+		icRaw["featureGates"] = featureSet.FeatureGates
+	} else {
+		delete(icRaw, "featureGates")
+	}
+	return yaml.Marshal(icRaw)
+}
+
+// pasteInProxy renders the proxy of the clusterdeployment into the install-config, unless the install-config already
+// configures a proxy.
+func pasteInProxy(icData []byte, proxy *hivev1.ProxyConfig) ([]byte, error) {
+	icRaw := map[string]interface{}{}
+	if err := yaml.Unmarshal(icData, &icRaw); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal InstallConfig")
+	}
+	if _, ok := icRaw["proxy"]; ok {
+		return icData, nil
+	}
+	icProxy := map[string]interface{}{}
+	if proxy.HTTPProxy != "" {
+		icProxy["httpProxy"] = proxy.HTTPProxy
+	}
+	if proxy.HTTPSProxy != "" {
+		icProxy["httpsProxy"] = proxy.HTTPSProxy
+	}
+	if proxy.NoProxy != "" {
+		icProxy["noProxy"] = proxy.NoProxy
+	}
+	if len(icProxy) == 0 {
+		return icData, nil
+	}
+	icRaw["proxy"] = icProxy
+	return yaml.Marshal(icRaw)
+}
+
+func pasteInBaseDomain(icData []byte, baseDomain string) ([]byte, error) {
+	icRaw := map[string]interface{}{}
+	if err := yaml.Unmarshal(icData, &icRaw); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal InstallConfig")
+	}
+	icRaw["baseDomain"] = baseDomain
+	return yaml.Marshal(icRaw)
+}
+
 func getHomeDir() string {
 	home := os.Getenv("HOME")
 	if home != "" {
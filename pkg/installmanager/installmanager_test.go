@@ -2,6 +2,7 @@ package installmanager
 
 import (
 	"context"
+	"encoding/pem"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -22,11 +23,14 @@ import (
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/utils/pointer"
 
+	configv1 "github.com/openshift/api/config/v1"
 	installertypes "github.com/openshift/installer/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
 
 	"github.com/openshift/hive/apis"
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
 	awsclient "github.com/openshift/hive/pkg/awsclient"
 	"github.com/openshift/hive/pkg/constants"
 )
@@ -692,6 +696,202 @@ func TestIsBootstrapComplete(t *testing.T) {
 	}
 }
 
+func Test_pasteInBaseDomain(t *testing.T) {
+	icData, err := ioutil.ReadFile(filepath.Join("testdata", "install-config.yaml"))
+	if !assert.NoError(t, err, "unexpected error reading install-config.yaml") {
+		return
+	}
+	actual, err := pasteInBaseDomain(icData, "abcd1234.pool.example.com")
+	if !assert.NoError(t, err, "unexpected error pasting in base domain") {
+		return
+	}
+	ic := map[string]interface{}{}
+	if !assert.NoError(t, yaml.Unmarshal(actual, &ic), "unexpected error unmarshalling InstallConfig") {
+		return
+	}
+	assert.Equal(t, "abcd1234.pool.example.com", ic["baseDomain"], "unexpected base domain")
+}
+
+func Test_pasteInAdditionalTrustBundle(t *testing.T) {
+	installConfigCA := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("install-config-ca")})
+	hubCA := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("hub-ca")})
+	cases := []struct {
+		name                  string
+		installConfigBundle   string
+		trustBundle           []byte
+		expectedTrustBundle   string
+		expectNoTrustBundleIC bool
+	}{
+		{
+			name:                  "no additional trust bundle mounted",
+			expectNoTrustBundleIC: true,
+		},
+		{
+			name:                "additional trust bundle added",
+			trustBundle:         hubCA,
+			expectedTrustBundle: string(hubCA),
+		},
+		{
+			name:                "additional trust bundle merged with install-config trust bundle",
+			installConfigBundle: string(installConfigCA),
+			trustBundle:         append(append([]byte{}, hubCA...), installConfigCA...),
+			expectedTrustBundle: string(installConfigCA) + string(hubCA),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			icRaw := map[string]interface{}{"baseDomain": "example.com"}
+			if tc.installConfigBundle != "" {
+				icRaw["additionalTrustBundle"] = tc.installConfigBundle
+			}
+			icData, err := yaml.Marshal(icRaw)
+			require.NoError(t, err, "unexpected error marshalling InstallConfig")
+
+			dir, err := ioutil.TempDir("", "trustbundle")
+			require.NoError(t, err, "unexpected error creating temp dir")
+			defer os.RemoveAll(dir)
+			trustBundleFile := filepath.Join(dir, "ca.crt")
+			if tc.trustBundle != nil {
+				require.NoError(t, ioutil.WriteFile(trustBundleFile, tc.trustBundle, 0600), "unexpected error writing trust bundle")
+			}
+
+			actual, err := pasteInAdditionalTrustBundle(icData, trustBundleFile)
+			require.NoError(t, err, "unexpected error pasting in additional trust bundle")
+			ic := map[string]interface{}{}
+			require.NoError(t, yaml.Unmarshal(actual, &ic), "unexpected error unmarshalling InstallConfig")
+			assert.Equal(t, "example.com", ic["baseDomain"], "unexpected base domain")
+			if tc.expectNoTrustBundleIC {
+				assert.NotContains(t, ic, "additionalTrustBundle", "unexpected additional trust bundle")
+				return
+			}
+			assert.Equal(t, tc.expectedTrustBundle, ic["additionalTrustBundle"], "unexpected additional trust bundle")
+		})
+	}
+}
+
+func Test_pasteInCredentialsMode(t *testing.T) {
+	icData, err := ioutil.ReadFile(filepath.Join("testdata", "install-config.yaml"))
+	require.NoError(t, err, "unexpected error reading install-config.yaml")
+	cd := testClusterDeployment()
+	cd.Spec.Platform.AWS = &hivev1aws.Platform{CredentialsMode: hivev1aws.ManualCredentialsMode}
+	actual, err := pasteInCredentialsMode(icData, credentialsMode(cd))
+	require.NoError(t, err, "unexpected error pasting in credentials mode")
+	ic := map[string]interface{}{}
+	require.NoError(t, yaml.Unmarshal(actual, &ic), "unexpected error unmarshalling InstallConfig")
+	assert.Equal(t, "Manual", ic["credentialsMode"], "unexpected credentials mode")
+}
+
+func Test_readInfraID(t *testing.T) {
+	dir, err := ioutil.TempDir("", "infraid")
+	require.NoError(t, err, "unexpected error creating temp dir")
+	defer os.RemoveAll(dir)
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "manifests"), 0700), "unexpected error creating manifests dir")
+	manifest := `apiVersion: config.openshift.io/v1
+kind: Infrastructure
+metadata:
+  name: cluster
+status:
+  infrastructureName: test-cluster-abcde
+`
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, infrastructureManifestRelativePath), []byte(manifest), 0600), "unexpected error writing manifest")
+	infraID, err := readInfraID(dir)
+	require.NoError(t, err, "unexpected error reading infra ID")
+	assert.Equal(t, "test-cluster-abcde", infraID, "unexpected infra ID")
+}
+
+func Test_pasteInFeatureSet(t *testing.T) {
+	cases := []struct {
+		name                 string
+		installConfigGates   []string
+		featureSet           *hivev1.FeatureSetConfig
+		expectedFeatureGates []interface{}
+	}{
+		{
+			name:       "tech preview",
+			featureSet: &hivev1.FeatureSetConfig{FeatureSet: configv1.TechPreviewNoUpgrade},
+		},
+		{
+			name:                 "custom with feature gates",
+			featureSet:           &hivev1.FeatureSetConfig{FeatureSet: configv1.CustomNoUpgrade, FeatureGates: []string{"GatewayAPI=true", "Foo=false"}},
+			expectedFeatureGates: []interface{}{"GatewayAPI=true", "Foo=false"},
+		},
+		{
+			name:               "feature gates of install-config replaced",
+			installConfigGates: []string{"Bar=true"},
+			featureSet:         &hivev1.FeatureSetConfig{FeatureSet: configv1.TechPreviewNoUpgrade},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			icRaw := map[string]interface{}{"baseDomain": "example.com"}
+			if tc.installConfigGates != nil {
+				icRaw["featureSet"] = string(configv1.CustomNoUpgrade)
+				icRaw["featureGates"] = tc.installConfigGates
+			}
+			icData, err := yaml.Marshal(icRaw)
+			require.NoError(t, err, "unexpected error marshalling InstallConfig")
+
+			actual, err := pasteInFeatureSet(icData, tc.featureSet)
+			require.NoError(t, err, "unexpected error pasting in feature set")
+			ic := map[string]interface{}{}
+			require.NoError(t, yaml.Unmarshal(actual, &ic), "unexpected error unmarshalling InstallConfig")
+			assert.Equal(t, "example.com", ic["baseDomain"], "unexpected base domain")
+			assert.Equal(t, string(tc.featureSet.FeatureSet), ic["featureSet"], "unexpected feature set")
+			if tc.expectedFeatureGates == nil {
+				assert.NotContains(t, ic, "featureGates", "unexpected feature gates")
+				return
+			}
+			assert.Equal(t, tc.expectedFeatureGates, ic["featureGates"], "unexpected feature gates")
+		})
+	}
+}
+
+func Test_pasteInProxy(t *testing.T) {
+	cases := []struct {
+		name               string
+		installConfigProxy map[string]interface{}
+		proxy              *hivev1.ProxyConfig
+		expectedProxy      interface{}
+	}{
+		{
+			name:          "proxy added",
+			proxy:         &hivev1.ProxyConfig{HTTPProxy: "http://proxy.example.com:3128", NoProxy: ".example.com"},
+			expectedProxy: map[string]interface{}{"httpProxy": "http://proxy.example.com:3128", "noProxy": ".example.com"},
+		},
+		{
+			name:               "proxy of install-config kept",
+			installConfigProxy: map[string]interface{}{"httpsProxy": "http://other.example.com:3128"},
+			proxy:              &hivev1.ProxyConfig{HTTPProxy: "http://proxy.example.com:3128"},
+			expectedProxy:      map[string]interface{}{"httpsProxy": "http://other.example.com:3128"},
+		},
+		{
+			name:  "empty proxy ignored",
+			proxy: &hivev1.ProxyConfig{},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			icRaw := map[string]interface{}{"baseDomain": "example.com"}
+			if tc.installConfigProxy != nil {
+				icRaw["proxy"] = tc.installConfigProxy
+			}
+			icData, err := yaml.Marshal(icRaw)
+			require.NoError(t, err, "unexpected error marshalling InstallConfig")
+
+			actual, err := pasteInProxy(icData, tc.proxy)
+			require.NoError(t, err, "unexpected error pasting in proxy")
+			ic := map[string]interface{}{}
+			require.NoError(t, yaml.Unmarshal(actual, &ic), "unexpected error unmarshalling InstallConfig")
+			assert.Equal(t, "example.com", ic["baseDomain"], "unexpected base domain")
+			if tc.expectedProxy == nil {
+				assert.NotContains(t, ic, "proxy", "unexpected proxy")
+				return
+			}
+			assert.Equal(t, tc.expectedProxy, ic["proxy"], "unexpected proxy")
+		})
+	}
+}
+
 func Test_pasteInPullSecret(t *testing.T) {
 	for _, inputFile := range []string{
 		"install-config.yaml",
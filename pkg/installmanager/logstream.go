@@ -0,0 +1,221 @@
+package installmanager
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/objectstorage"
+)
+
+const (
+	defaultInstallLogStreamInterval = 30 * time.Second
+	// maxInstallLogStreamBufferBytes caps the installer log kept in memory while the sink cannot be written to.
+	maxInstallLogStreamBufferBytes = 16 * 1024 * 1024
+	installLogStreamTokenKey       = "token"
+	installLogStreamHTTPTimeout    = 30 * time.Second
+)
+
+// installLogSink is an external sink the installer log is streamed to.
+type installLogSink interface {
+	// Write stores a chunk of the installer log. Chunks are numbered from 1, in the order of the log.
+	Write(chunk int, data []byte) error
+}
+
+// installLogStreamer periodically writes the new lines of the installer log to a sink, so that the progress of the
+// install can be followed while it runs.
+type installLogStreamer struct {
+	sink     installLogSink
+	interval time.Duration
+	log      log.FieldLogger
+
+	mutex        sync.Mutex
+	buf          bytes.Buffer
+	droppedBytes int
+	chunk        int
+	stopped      bool
+
+	stopCh chan struct{}
+	done   chan struct{}
+}
+
+func newInstallLogStreamer(sink installLogSink, interval time.Duration, logger log.FieldLogger) *installLogStreamer {
+	return &installLogStreamer{
+		sink:     sink,
+		interval: interval,
+		log:      logger,
+		stopCh:   make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+func (s *installLogStreamer) start() {
+	go func() {
+		defer close(s.done)
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				s.flush()
+			case <-s.stopCh:
+				s.flush()
+				return
+			}
+		}
+	}()
+}
+
+// add buffers a line of the installer log until the next flush. Lines added once the streamer is stopped, or while
+// the buffer is full, are dropped.
+func (s *installLogStreamer) add(line string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.stopped {
+		return
+	}
+	if s.buf.Len()+len(line)+1 > maxInstallLogStreamBufferBytes {
+		s.droppedBytes += len(line) + 1
+		return
+	}
+	s.buf.WriteString(line)
+	s.buf.WriteByte('\n')
+}
+
+// flush writes the buffered lines to the sink as the next chunk. The lines are kept for the next flush when the sink
+// cannot be written to.
+func (s *installLogStreamer) flush() {
+	s.mutex.Lock()
+	buffered, dropped := s.buf.Len(), s.droppedBytes
+	if buffered == 0 && dropped == 0 {
+		s.mutex.Unlock()
+		return
+	}
+	data := append([]byte{}, s.buf.Bytes()...)
+	if dropped > 0 {
+		data = append(data, fmt.Sprintf("[%d bytes of the installer log could not be streamed]\n", dropped)...)
+	}
+	chunk := s.chunk + 1
+	s.mutex.Unlock()
+
+	if err := s.sink.Write(chunk, data); err != nil {
+		s.log.WithError(err).WithField("chunk", chunk).Warn("could not stream installer log")
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	// Lines added while the chunk was written stay buffered for the next flush.
+	s.buf.Next(buffered)
+	s.droppedBytes -= dropped
+	s.chunk = chunk
+}
+
+// stop stops the streamer after writing the remaining lines to the sink.
+func (s *installLogStreamer) stop() {
+	s.mutex.Lock()
+	s.stopped = true
+	s.mutex.Unlock()
+	close(s.stopCh)
+	<-s.done
+}
+
+// setupInstallLogStreamer returns a streamer for the installer log of the provision, when the streaming of installer
+// logs is configured in the environment of the install pod.
+func (m *InstallManager) setupInstallLogStreamer(cd *hivev1.ClusterDeployment, provision *hivev1.ClusterProvision) (*installLogStreamer, error) {
+	config := objectstorage.ConfigFromEnv(objectstorage.NewEnvVarNames(constants.InstallLogStreamEnvVarPrefix))
+	if config == nil {
+		return nil, nil
+	}
+	interval := defaultInstallLogStreamInterval
+	if value := os.Getenv(constants.InstallLogStreamIntervalEnvVar); value != "" {
+		d, err := time.ParseDuration(value)
+		if err != nil || d <= 0 {
+			return nil, errors.Errorf("invalid install log stream interval %q", value)
+		}
+		interval = d
+	}
+
+	prefix := fmt.Sprintf("%v-%v/%v/", cd.Spec.ClusterName, provision.Namespace, provision.Name)
+	var sink installLogSink
+	switch config.Destination {
+	case constants.InstallLogStreamDestinationHTTP:
+		httpSink := &httpInstallLogSink{
+			client: &http.Client{Timeout: installLogStreamHTTPTimeout},
+			url:    os.Getenv(constants.InstallLogStreamURLEnvVar),
+			header: http.Header{
+				"X-Hive-Namespace":         []string{provision.Namespace},
+				"X-Hive-ClusterDeployment": []string{cd.Name},
+				"X-Hive-ClusterProvision":  []string{provision.Name},
+			},
+		}
+		if config.CredentialsSecret != "" {
+			secret, err := objectstorage.GetCredentialsSecret(m.DynamicClient, provision.Namespace, config.CredentialsSecret)
+			if err != nil {
+				return nil, err
+			}
+			httpSink.header.Set("Authorization", "Bearer "+string(secret.Data[installLogStreamTokenKey]))
+		}
+		sink = httpSink
+		m.log.Infof("streaming installer log to %v", httpSink.url)
+	default:
+		uploader, err := objectstorage.NewUploader(m.DynamicClient, provision.Namespace, config)
+		if err != nil {
+			return nil, err
+		}
+		sink = &objectStorageInstallLogSink{uploader: uploader, prefix: prefix}
+		m.log.Infof("streaming installer log to %v%v", config, prefix)
+	}
+	return newInstallLogStreamer(sink, interval, m.log), nil
+}
+
+func installLogChunkName(prefix string, chunk int) string {
+	return fmt.Sprintf("%vinstall-log-%05d.log", prefix, chunk)
+}
+
+// objectStorageInstallLogSink stores the chunks of the installer log as objects in object storage.
+type objectStorageInstallLogSink struct {
+	uploader objectstorage.Uploader
+	prefix   string
+}
+
+func (s *objectStorageInstallLogSink) Write(chunk int, data []byte) error {
+	return s.uploader.Upload(installLogChunkName(s.prefix, chunk), bytes.NewReader(data))
+}
+
+// httpInstallLogSink POSTs the chunks of the installer log to an HTTP endpoint.
+type httpInstallLogSink struct {
+	client *http.Client
+	url    string
+	header http.Header
+}
+
+func (s *httpInstallLogSink) Write(chunk int, data []byte) error {
+	req, err := http.NewRequest(http.MethodPost, s.url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	for key, values := range s.header {
+		req.Header[key] = values
+	}
+	req.Header.Set("Content-Type", "text/plain; charset=utf-8")
+	req.Header.Set("X-Hive-Log-Chunk", strconv.Itoa(chunk))
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package installmanager
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeInstallLogSink struct {
+	chunks map[int]string
+	err    error
+}
+
+func (s *fakeInstallLogSink) Write(chunk int, data []byte) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.chunks[chunk] = string(data)
+	return nil
+}
+
+func TestInstallLogStreamer(t *testing.T) {
+	sink := &fakeInstallLogSink{chunks: map[int]string{}}
+	s := newInstallLogStreamer(sink, time.Hour, log.WithField("test", "TestInstallLogStreamer"))
+
+	s.add("first line")
+	s.add("second line")
+	s.flush()
+	assert.Equal(t, map[int]string{1: "first line\nsecond line\n"}, sink.chunks, "unexpected chunks after first flush")
+
+	s.flush()
+	assert.Len(t, sink.chunks, 1, "expected no chunk without new lines")
+
+	sink.err = errors.New("sink unavailable")
+	s.add("third line")
+	s.flush()
+	assert.Len(t, sink.chunks, 1, "expected no chunk while the sink is unavailable")
+
+	sink.err = nil
+	s.add("fourth line")
+	s.start()
+	s.stop()
+	assert.Equal(t, "third line\nfourth line\n", sink.chunks[2], "expected the lines to be kept until the sink is available")
+
+	s.add("fifth line")
+	s.flush()
+	assert.Len(t, sink.chunks, 2, "expected lines added once stopped to be dropped")
+}
+
+func TestHTTPInstallLogSink(t *testing.T) {
+	var received *http.Request
+	var body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		data, _ := ioutil.ReadAll(r.Body)
+		body = string(data)
+	}))
+	defer server.Close()
+
+	sink := &httpInstallLogSink{
+		client: server.Client(),
+		url:    server.URL,
+		header: http.Header{
+			"X-Hive-ClusterProvision": []string{testProvisionName},
+			"Authorization":           []string{"Bearer secret-token"},
+		},
+	}
+	require.NoError(t, sink.Write(3, []byte("some line\n")), "unexpected error writing chunk")
+	if assert.NotNil(t, received, "expected the chunk to be posted") {
+		assert.Equal(t, http.MethodPost, received.Method, "unexpected method")
+		assert.Equal(t, "3", received.Header.Get("X-Hive-Log-Chunk"), "unexpected chunk number")
+		assert.Equal(t, testProvisionName, received.Header.Get("X-Hive-ClusterProvision"), "unexpected provision")
+		assert.Equal(t, "Bearer secret-token", received.Header.Get("Authorization"), "unexpected authorization")
+		assert.Equal(t, "some line\n", body, "unexpected body")
+	}
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer failing.Close()
+	sink.url = failing.URL
+	assert.Error(t, sink.Write(4, []byte("other line\n")), "expected error when the endpoint fails")
+}
@@ -13,4 +13,7 @@ type LogUploaderActuator interface {
 
 	// UploadLogs uploads installer logs to the provider's storage mechanism.
 	UploadLogs(clusterName string, clusterprovision *hivev1.ClusterProvision, c client.Client, log log.FieldLogger, filenames ...string) error
+
+	// Location returns where UploadLogs uploads the file with the given name.
+	Location(clusterName string, clusterprovision *hivev1.ClusterProvision, filename string) string
 }
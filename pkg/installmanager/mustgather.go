@@ -0,0 +1,206 @@
+package installmanager
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	contributils "github.com/openshift/hive/contrib/pkg/utils"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+const (
+	mustGatherDirName     = "must-gather"
+	mustGatherArchiveName = "must-gather.tar.gz"
+)
+
+// MustGather collects a must-gather from the cluster of a failed cluster provision, uploads it with the install log
+// uploader, and records where it was uploaded to in the status of the cluster provision.
+type MustGather struct {
+	log                  log.FieldLogger
+	LogLevel             string
+	WorkDir              string
+	Namespace            string
+	ClusterProvisionName string
+	DynamicClient        client.Client
+	actuator             LogUploaderActuator
+	// runMustGather runs oc adm must-gather against the cluster of the kubeconfig into the destination directory.
+	runMustGather func(ocPath, kubeconfigPath, destDir string) error
+}
+
+// NewMustGatherCommand returns the command which collects a must-gather from the cluster of a failed cluster
+// provision.
+func NewMustGatherCommand() *cobra.Command {
+	mg := &MustGather{
+		actuator:      getActuator(),
+		runMustGather: runMustGather,
+	}
+	cmd := &cobra.Command{
+		Use:   "must-gather NAMESPACE CLUSTER_PROVISION_NAME",
+		Short: "Collects a must-gather from the cluster of a failed cluster provision.",
+		Long:  "Runs oc adm must-gather against the cluster of a failed cluster provision which had completed bootstrapping, and uploads the result alongside the install logs.",
+		Run: func(cmd *cobra.Command, args []string) {
+			level, err := log.ParseLevel(mg.LogLevel)
+			if err != nil {
+				log.WithError(err).Fatal("cannot parse log level")
+			}
+			log.SetLevel(level)
+
+			if len(args) != 2 {
+				cmd.Help()
+				log.WithField("args", args).Fatal("invalid command arguments")
+			}
+			mg.Namespace, mg.ClusterProvisionName = args[0], args[1]
+			mg.log = log.WithField("clusterprovision", fmt.Sprintf("%s/%s", mg.Namespace, mg.ClusterProvisionName))
+
+			mg.DynamicClient, err = contributils.GetClient()
+			if err != nil {
+				mg.log.WithError(err).Fatal("error creating kube clients")
+			}
+
+			if err := mg.Run(); err != nil {
+				mg.log.WithError(err).Fatal("runtime error")
+			}
+		},
+	}
+	flags := cmd.Flags()
+	flags.StringVar(&mg.LogLevel, "log-level", "info", "log level, one of: debug, info, warn, error, fatal, panic")
+	flags.StringVar(&mg.WorkDir, "work-dir", "/output", "directory to use for all input and output")
+	return cmd
+}
+
+// Run collects and uploads the must-gather.
+func (mg *MustGather) Run() error {
+	if mg.actuator == nil {
+		return errors.New("no install log uploader is configured to upload the must-gather to")
+	}
+
+	provision := &hivev1.ClusterProvision{}
+	if err := mg.DynamicClient.Get(context.Background(), types.NamespacedName{Namespace: mg.Namespace, Name: mg.ClusterProvisionName}, provision); err != nil {
+		return errors.Wrap(err, "could not get cluster provision")
+	}
+	if provision.Spec.AdminKubeconfigSecretRef == nil {
+		return errors.New("cluster provision has no admin kubeconfig")
+	}
+	cd := &hivev1.ClusterDeployment{}
+	if err := mg.DynamicClient.Get(context.Background(), types.NamespacedName{Namespace: mg.Namespace, Name: provision.Spec.ClusterDeploymentRef.Name}, cd); err != nil {
+		return errors.Wrap(err, "could not get cluster deployment")
+	}
+
+	kubeconfigSecret := &corev1.Secret{}
+	if err := mg.DynamicClient.Get(context.Background(), types.NamespacedName{Namespace: mg.Namespace, Name: provision.Spec.AdminKubeconfigSecretRef.Name}, kubeconfigSecret); err != nil {
+		return errors.Wrap(err, "could not get admin kubeconfig")
+	}
+	kubeconfigPath := filepath.Join(mg.WorkDir, "kubeconfig")
+	if err := ioutil.WriteFile(kubeconfigPath, kubeconfigSecret.Data[constants.KubeconfigSecretKey], 0600); err != nil {
+		return errors.Wrap(err, "could not write admin kubeconfig")
+	}
+
+	// oc is copied into the work dir by the cli container.
+	ocPath := filepath.Join(mg.WorkDir, "oc")
+	(&InstallManager{log: mg.log}).waitForFiles([]string{ocPath})
+
+	destDir := filepath.Join(mg.WorkDir, mustGatherDirName)
+	if err := mg.runMustGather(ocPath, kubeconfigPath, destDir); err != nil {
+		// A must-gather which partially failed is still worth uploading.
+		if _, statErr := os.Stat(destDir); statErr != nil {
+			return errors.Wrap(err, "could not run must-gather")
+		}
+		mg.log.WithError(err).Warn("must-gather failed, uploading what was gathered")
+	}
+
+	archivePath := filepath.Join(mg.WorkDir, mustGatherArchiveName)
+	if err := archiveDir(destDir, archivePath); err != nil {
+		return errors.Wrap(err, "could not archive must-gather")
+	}
+	if err := mg.actuator.UploadLogs(cd.Spec.ClusterName, provision, mg.DynamicClient, mg.log, archivePath); err != nil {
+		return errors.Wrap(err, "could not upload must-gather")
+	}
+
+	location := mg.actuator.Location(cd.Spec.ClusterName, provision, mustGatherArchiveName)
+	mg.log.WithField("location", location).Info("uploaded must-gather")
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		if err := mg.DynamicClient.Get(context.Background(), types.NamespacedName{Namespace: mg.Namespace, Name: mg.ClusterProvisionName}, provision); err != nil {
+			return err
+		}
+		if provision.Status.MustGather == nil {
+			provision.Status.MustGather = &hivev1.ClusterProvisionMustGather{}
+		}
+		provision.Status.MustGather.Location = location
+		return mg.DynamicClient.Status().Update(context.Background(), provision)
+	})
+}
+
+func runMustGather(ocPath, kubeconfigPath, destDir string) error {
+	cmd := exec.Command(ocPath, "adm", "must-gather", "--dest-dir", destDir, "--kubeconfig", kubeconfigPath)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+// archiveDir writes the contents of the directory to a gzipped tarball.
+func archiveDir(dir, archivePath string) error {
+	f, err := os.Create(archivePath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+	err = filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() && !info.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(filepath.Dir(dir), path)
+		if err != nil {
+			return err
+		}
+		header, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		header.Name = filepath.ToSlash(rel)
+		if info.IsDir() {
+			header.Name += "/"
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		src, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer src.Close()
+		_, err = io.Copy(tw, src)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
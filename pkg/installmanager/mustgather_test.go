@@ -0,0 +1,106 @@
+package installmanager
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/hive/apis"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+type fakeLogUploader struct {
+	uploaded []string
+}
+
+func (u *fakeLogUploader) IsConfigured() bool {
+	return true
+}
+
+func (u *fakeLogUploader) UploadLogs(clusterName string, clusterprovision *hivev1.ClusterProvision, c client.Client, log log.FieldLogger, filenames ...string) error {
+	u.uploaded = append(u.uploaded, filenames...)
+	return nil
+}
+
+func (u *fakeLogUploader) Location(clusterName string, clusterprovision *hivev1.ClusterProvision, filename string) string {
+	return "fake://" + clusterName + "/" + clusterprovision.Name + "-" + filename
+}
+
+func TestMustGatherRun(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+
+	workDir, err := ioutil.TempDir("", "mustgather")
+	require.NoError(t, err, "unexpected error creating work dir")
+	defer os.RemoveAll(workDir)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(workDir, "oc"), nil, 0755), "unexpected error creating oc")
+
+	provision := testClusterProvision()
+	provision.Spec.AdminKubeconfigSecretRef = &corev1.LocalObjectReference{Name: "admin-kubeconfig"}
+	provision.Status.MustGather = &hivev1.ClusterProvisionMustGather{JobRef: corev1.LocalObjectReference{Name: "must-gather-job"}}
+	cd := testClusterDeployment()
+	cd.Spec.ClusterName = "test-cluster"
+	kubeconfig := testSecret(corev1.SecretTypeOpaque, "admin-kubeconfig", constants.KubeconfigSecretKey, "test-kubeconfig")
+	mocks := setupDefaultMocks(t, provision, cd, kubeconfig)
+	defer mocks.mockCtrl.Finish()
+
+	uploader := &fakeLogUploader{}
+	mg := &MustGather{
+		log:                  log.WithField("test", "must-gather"),
+		WorkDir:              workDir,
+		Namespace:            testNamespace,
+		ClusterProvisionName: testProvisionName,
+		DynamicClient:        mocks.fakeKubeClient,
+		actuator:             uploader,
+		runMustGather: func(ocPath, kubeconfigPath, destDir string) error {
+			kubeconfig, err := ioutil.ReadFile(kubeconfigPath)
+			require.NoError(t, err, "unexpected error reading kubeconfig")
+			assert.Equal(t, "test-kubeconfig", string(kubeconfig), "unexpected kubeconfig")
+			require.NoError(t, os.MkdirAll(filepath.Join(destDir, "namespaces"), 0755), "unexpected error creating must-gather dir")
+			return ioutil.WriteFile(filepath.Join(destDir, "namespaces", "pods.yaml"), []byte("pods"), 0644)
+		},
+	}
+	require.NoError(t, mg.Run(), "unexpected error")
+
+	archivePath := filepath.Join(workDir, mustGatherArchiveName)
+	assert.Equal(t, []string{archivePath}, uploader.uploaded, "unexpected uploaded files")
+	assert.Equal(t, []string{"must-gather/", "must-gather/namespaces/", "must-gather/namespaces/pods.yaml"}, archiveNames(t, archivePath), "unexpected archive contents")
+
+	updated := &hivev1.ClusterProvision{}
+	require.NoError(t, mocks.fakeKubeClient.Get(context.Background(), types.NamespacedName{Namespace: testNamespace, Name: testProvisionName}, updated), "unexpected error getting provision")
+	if assert.NotNil(t, updated.Status.MustGather, "expected must-gather status") {
+		assert.Equal(t, "must-gather-job", updated.Status.MustGather.JobRef.Name, "unexpected job ref")
+		assert.Equal(t, "fake://test-cluster/"+testProvisionName+"-must-gather.tar.gz", updated.Status.MustGather.Location, "unexpected location")
+	}
+}
+
+func archiveNames(t *testing.T, archivePath string) []string {
+	f, err := os.Open(archivePath)
+	require.NoError(t, err, "unexpected error opening archive")
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	require.NoError(t, err, "unexpected error reading archive")
+	tr := tar.NewReader(gz)
+	names := []string{}
+	for {
+		header, err := tr.Next()
+		if err != nil {
+			break
+		}
+		names = append(names, header.Name)
+	}
+	return names
+}
@@ -0,0 +1,438 @@
+package installmanager
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	velerov1 "github.com/heptio/velero/pkg/apis/velero/v1"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apihelpers "github.com/openshift/hive/apis/helpers"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	defaultVeleroNamespace       = "velero"
+	defaultVeleroStorageLocation = "default"
+
+	// restorePollInterval is how often the progress of each step of the restore is checked.
+	restorePollInterval = 30 * time.Second
+
+	// restoreStepTimeout is how long each step of the restore is waited for.
+	restoreStepTimeout = time.Hour
+
+	// etcdNamespace is the namespace of the etcd pods of the cluster, where the exit backup saved the snapshot of etcd.
+	etcdNamespace = "openshift-etcd"
+
+	// etcdSnapshotFile is the file of the snapshot of etcd in the volume the exit backup saved it to.
+	etcdSnapshotFile = "etcd.db"
+
+	// etcdRecoveryName is the name of the pod restoring etcd on the control plane node of the snapshot, and the
+	// prefix of the names of the pods stopping etcd on the other control plane nodes.
+	etcdRecoveryName = "hive-etcd-recovery"
+
+	// etcdRecoveryDir is the directory of the control plane nodes where the etcd data and the static pods are moved
+	// aside, and where the snapshot is copied for the restore script of OpenShift.
+	etcdRecoveryDir = "/var/lib/hive-etcd-recovery"
+
+	// masterNodeLabel is the label of the control plane nodes.
+	masterNodeLabel = "node-role.kubernetes.io/master"
+
+	restoreInProgressReason = "RestoreInProgress"
+	restoreCompletedReason  = "RestoreCompleted"
+	restoreFailedReason     = "RestoreFailed"
+)
+
+// etcdRedeployedOperators are the operators which are forced to redeploy their operands once etcd is restored, as
+// required by the disaster recovery procedure of OpenShift.
+var etcdRedeployedOperators = []string{"Etcd", "KubeAPIServer", "KubeControllerManager", "KubeScheduler"}
+
+// restoreCluster restores the cluster from the backup of spec.restoreRef once the installer has bootstrapped it, and
+// before the install is reported complete, so that the cluster is only handed over once restored. A failed restore
+// fails the install, which is retried with a new cluster. The progress of the restore is recorded in the status of
+// the ClusterDeployment.
+func restoreCluster(m *InstallManager, cd *hivev1.ClusterDeployment) error {
+	ref := cd.Spec.RestoreRef
+	restConfig, err := clientcmd.BuildConfigFromFlags("", filepath.Join(m.WorkDir, "auth", "kubeconfig"))
+	if err != nil {
+		return errors.Wrap(err, "could not load admin kubeconfig")
+	}
+	scheme := runtime.NewScheme()
+	clientgoscheme.AddToScheme(scheme)
+	velerov1.AddToScheme(scheme)
+	remoteClient, err := client.New(restConfig, client.Options{Scheme: scheme})
+	if err != nil {
+		return errors.Wrap(err, "could not create client for cluster")
+	}
+	r := newClusterRestorer(remoteClient, cd, m.log)
+	status := &hivev1.RestoreStatus{
+		Name:       r.name,
+		Namespace:  r.namespace,
+		BackupName: ref.BackupName,
+	}
+	m.recordRestore(cd, status, corev1.ConditionFalse, restoreInProgressReason, "Restore of the cluster is in progress")
+	if err := r.restore(status); err != nil {
+		m.recordRestore(cd, status, corev1.ConditionTrue, restoreFailedReason, fmt.Sprintf("Restore of the cluster failed: %v", err))
+		return errors.Wrap(err, "could not restore cluster")
+	}
+	now := metav1.Now()
+	status.CompletionTime = &now
+	m.recordRestore(cd, status, corev1.ConditionFalse, restoreCompletedReason, "Restore of the cluster completed")
+	return nil
+}
+
+// recordRestore records the progress of the restore in the status of the ClusterDeployment. Failing to record it
+// does not fail the restore.
+func (m *InstallManager) recordRestore(cd *hivev1.ClusterDeployment, status *hivev1.RestoreStatus, conditionStatus corev1.ConditionStatus, reason, message string) {
+	err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		current := &hivev1.ClusterDeployment{}
+		if err := m.DynamicClient.Get(context.Background(), types.NamespacedName{Namespace: cd.Namespace, Name: cd.Name}, current); err != nil {
+			return err
+		}
+		current.Status.Restore = status.DeepCopy()
+		current.Status.Conditions = controllerutils.SetClusterDeploymentCondition(
+			current.Status.Conditions,
+			hivev1.RestoreFailedCondition,
+			conditionStatus,
+			reason,
+			message,
+			controllerutils.UpdateConditionIfReasonOrMessageChange,
+		)
+		return m.DynamicClient.Status().Update(context.Background(), current)
+	})
+	if err != nil {
+		m.log.WithError(err).Warn("could not record restore of cluster")
+	}
+}
+
+// clusterRestorer restores a cluster being installed from a Velero backup.
+type clusterRestorer struct {
+	client          client.Client
+	ref             *hivev1.RestoreReference
+	name            string
+	namespace       string
+	storageLocation string
+	pollInterval    time.Duration
+	stepTimeout     time.Duration
+	logger          log.FieldLogger
+}
+
+func newClusterRestorer(remoteClient client.Client, cd *hivev1.ClusterDeployment, logger log.FieldLogger) *clusterRestorer {
+	r := &clusterRestorer{
+		client:          remoteClient,
+		ref:             cd.Spec.RestoreRef,
+		name:            apihelpers.GetResourceName(cd.Name, "restore"),
+		namespace:       cd.Spec.RestoreRef.Namespace,
+		storageLocation: cd.Spec.RestoreRef.StorageLocation,
+		pollInterval:    restorePollInterval,
+		stepTimeout:     restoreStepTimeout,
+	}
+	if r.namespace == "" {
+		r.namespace = defaultVeleroNamespace
+	}
+	if r.storageLocation == "" {
+		r.storageLocation = defaultVeleroStorageLocation
+	}
+	r.logger = logger.WithField("backup", fmt.Sprintf("%s/%s", r.namespace, r.ref.BackupName))
+	return r
+}
+
+// restore waits for Velero to sync the backup from its storage location, then either restores the resources of the
+// backup with Velero, or restores etcd from the snapshot of etcd in the backup.
+func (r *clusterRestorer) restore(status *hivev1.RestoreStatus) error {
+	// Velero is installed by the manifests of the install, and syncs the backups of its storage locations to the
+	// cluster. A restore created before the backup has been synced fails validation.
+	if err := r.poll(r.backupAvailable); err != nil {
+		return errors.Wrap(err, "backup is not available on the cluster")
+	}
+	if !r.ref.Etcd {
+		return r.veleroRestore(velerov1.RestoreSpec{BackupName: r.ref.BackupName}, status)
+	}
+	// Only the volume of the snapshot of etcd, and the pod it is backed up from with restic, are restored by Velero.
+	if err := r.veleroRestore(velerov1.RestoreSpec{
+		BackupName:         r.ref.BackupName,
+		IncludedNamespaces: []string{etcdNamespace},
+		LabelSelector: &metav1.LabelSelector{
+			MatchExpressions: []metav1.LabelSelectorRequirement{{
+				Key:      constants.ClusterDeprovisionNameLabel,
+				Operator: metav1.LabelSelectorOpExists,
+			}},
+		},
+	}, status); err != nil {
+		return err
+	}
+	return r.recoverEtcd()
+}
+
+// poll calls the condition until it is done, fails, or the step times out.
+func (r *clusterRestorer) poll(condition wait.ConditionFunc) error {
+	return wait.PollImmediate(r.pollInterval, r.stepTimeout, condition)
+}
+
+// backupAvailable returns whether Velero has synced the backup from its storage location to the cluster. The backup
+// is waited for while Velero is not installed yet, and while the cluster API is not available.
+func (r *clusterRestorer) backupAvailable() (bool, error) {
+	backup := &velerov1.Backup{}
+	switch err := r.client.Get(context.Background(), types.NamespacedName{Namespace: r.namespace, Name: r.ref.BackupName}, backup); {
+	case meta.IsNoMatchError(err):
+		r.logger.Info("waiting for velero to be installed on the cluster")
+		return false, nil
+	case apierrors.IsNotFound(err):
+		r.logger.Info("waiting for the backup to be synced to the cluster")
+		return false, nil
+	case err != nil:
+		r.logger.WithError(err).Warn("could not get backup, retrying")
+		return false, nil
+	}
+	if location := backup.Spec.StorageLocation; location != r.storageLocation {
+		return false, fmt.Errorf("backup is in storage location %s rather than %s", location, r.storageLocation)
+	}
+	return true, nil
+}
+
+// veleroRestore creates the Velero Restore of the backup unless it exists, and waits for it to complete.
+func (r *clusterRestorer) veleroRestore(spec velerov1.RestoreSpec, status *hivev1.RestoreStatus) error {
+	restoreLog := r.logger.WithField("restore", fmt.Sprintf("%s/%s", r.namespace, r.name))
+	restore := &velerov1.Restore{}
+	switch err := r.client.Get(context.Background(), types.NamespacedName{Namespace: r.namespace, Name: r.name}, restore); {
+	case apierrors.IsNotFound(err):
+		restore.Namespace = r.namespace
+		restore.Name = r.name
+		restore.Spec = spec
+		restoreLog.Info("creating restore of cluster")
+		if err := r.client.Create(context.Background(), restore); err != nil {
+			return errors.Wrap(err, "could not create restore")
+		}
+	case err != nil:
+		return errors.Wrap(err, "could not get restore")
+	}
+	return r.poll(func() (bool, error) {
+		if err := r.client.Get(context.Background(), types.NamespacedName{Namespace: r.namespace, Name: r.name}, restore); err != nil {
+			restoreLog.WithError(err).Warn("could not get restore, retrying")
+			return false, nil
+		}
+		status.Phase = string(restore.Status.Phase)
+		switch restore.Status.Phase {
+		case velerov1.RestorePhaseCompleted:
+			restoreLog.Info("restore of cluster completed")
+			return true, nil
+		case velerov1.RestorePhaseFailed, velerov1.RestorePhasePartiallyFailed, velerov1.RestorePhaseFailedValidation:
+			message := fmt.Sprintf("restore %s/%s is %s", r.namespace, r.name, restore.Status.Phase)
+			if restore.Status.FailureReason != "" {
+				message = fmt.Sprintf("%s: %s", message, restore.Status.FailureReason)
+			}
+			return false, errors.New(message)
+		}
+		restoreLog.WithField("phase", restore.Status.Phase).Info("waiting for restore of cluster")
+		return false, nil
+	})
+}
+
+// recoverEtcd restores etcd from the snapshot restored by Velero, following the disaster recovery procedure of
+// OpenShift. The etcd and kube-apiserver static pods of the other control plane nodes are stopped and their etcd
+// data is moved aside, then the restore script of OpenShift restores the snapshot on the control plane node of the
+// snapshot. Once the API serves the restored etcd, the etcd and control plane operators are forced to redeploy, which
+// brings back the other etcd members.
+func (r *clusterRestorer) recoverEtcd() error {
+	var snapshotPod *corev1.Pod
+	if err := r.poll(func() (bool, error) {
+		pods := &corev1.PodList{}
+		if err := r.client.List(context.Background(), pods, client.InNamespace(etcdNamespace), client.HasLabels{constants.ClusterDeprovisionNameLabel}); err != nil {
+			r.logger.WithError(err).Warn("could not list restored etcd snapshot pods, retrying")
+			return false, nil
+		}
+		for i := range pods.Items {
+			if podReady(&pods.Items[i]) {
+				snapshotPod = &pods.Items[i]
+				return true, nil
+			}
+		}
+		r.logger.Info("waiting for the restored etcd snapshot to be ready")
+		return false, nil
+	}); err != nil {
+		return errors.Wrap(err, "restored etcd snapshot is not ready")
+	}
+	pods, err := newEtcdRecoveryPods(snapshotPod, r.listControlPlaneNodes)
+	if err != nil {
+		return err
+	}
+	// The pods stopping etcd are created first. All the pods wait before stopping etcd, so that they are all created
+	// while the API is still available.
+	for _, pod := range pods {
+		r.logger.WithField("pod", pod.Name).WithField("node", pod.Spec.NodeName).Info("creating etcd recovery pod")
+		if err := r.client.Create(context.Background(), pod); err != nil && !apierrors.IsAlreadyExists(err) {
+			return errors.Wrap(err, "could not create etcd recovery pod")
+		}
+	}
+
+	// The recovery pod is created after the snapshot was taken, so it is not found once the API serves the restored
+	// etcd. The API is unavailable in the meantime.
+	if err := r.poll(func() (bool, error) {
+		err := r.client.Get(context.Background(), types.NamespacedName{Namespace: etcdNamespace, Name: etcdRecoveryName}, &corev1.Pod{})
+		if apierrors.IsNotFound(err) {
+			return true, nil
+		}
+		r.logger.Info("waiting for etcd to be restored")
+		return false, nil
+	}); err != nil {
+		return errors.Wrap(err, "etcd was not restored")
+	}
+
+	reason := fmt.Sprintf("hive-etcd-recovery-%d", time.Now().Unix())
+	for _, kind := range etcdRedeployedOperators {
+		operator := &unstructured.Unstructured{}
+		operator.SetGroupVersionKind(schema.GroupVersionKind{Group: "operator.openshift.io", Version: "v1", Kind: kind})
+		operator.SetName("cluster")
+		patch := []byte(fmt.Sprintf(`{"spec":{"forceRedeploymentReason":%q}}`, reason))
+		if err := r.poll(func() (bool, error) {
+			if err := r.client.Patch(context.Background(), operator, client.RawPatch(types.MergePatchType, patch)); err != nil {
+				r.logger.WithError(err).WithField("operator", kind).Warn("could not force redeployment, retrying")
+				return false, nil
+			}
+			return true, nil
+		}); err != nil {
+			return errors.Wrapf(err, "could not force redeployment of %s", kind)
+		}
+		r.logger.WithField("operator", kind).Info("forced redeployment after etcd recovery")
+	}
+	return nil
+}
+
+// listControlPlaneNodes returns the names of the control plane nodes of the cluster.
+func (r *clusterRestorer) listControlPlaneNodes() ([]string, error) {
+	nodes := &corev1.NodeList{}
+	if err := r.client.List(context.Background(), nodes, client.HasLabels{masterNodeLabel}); err != nil {
+		return nil, errors.Wrap(err, "could not list control plane nodes")
+	}
+	names := make([]string, len(nodes.Items))
+	for i, node := range nodes.Items {
+		names[i] = node.Name
+	}
+	return names, nil
+}
+
+// newEtcdRecoveryPods returns the pods stopping etcd on the control plane nodes other than the node of the snapshot,
+// followed by the pod restoring etcd from the snapshot on the node of the snapshot. The kubelet of every control plane
+// node is restarted once the recovery is over.
+func newEtcdRecoveryPods(snapshotPod *corev1.Pod, listControlPlaneNodes func() ([]string, error)) ([]*corev1.Pod, error) {
+	claim := ""
+	for _, volume := range snapshotPod.Spec.Volumes {
+		if volume.PersistentVolumeClaim != nil {
+			claim = volume.PersistentVolumeClaim.ClaimName
+		}
+	}
+	if claim == "" || len(snapshotPod.Spec.Containers) == 0 {
+		return nil, fmt.Errorf("restored etcd snapshot pod %s has no snapshot volume", snapshotPod.Name)
+	}
+	nodes, err := listControlPlaneNodes()
+	if err != nil {
+		return nil, err
+	}
+	image := snapshotPod.Spec.Containers[0].Image
+
+	var pods []*corev1.Pod
+	for _, node := range nodes {
+		if node == snapshotPod.Spec.NodeName {
+			continue
+		}
+		pods = append(pods, newEtcdRecoveryPod(
+			fmt.Sprintf("%s-%d", etcdRecoveryName, len(pods)),
+			node,
+			image,
+			fmt.Sprintf(`set -e
+sleep 60
+mkdir -p /host%[1]s
+mv /host/etc/kubernetes/manifests/etcd-pod.yaml /host%[1]s/ || true
+mv /host/etc/kubernetes/manifests/kube-apiserver-pod.yaml /host%[1]s/ || true
+sleep 30
+rm -rf /host%[1]s/etcd
+mv /host/var/lib/etcd /host%[1]s/etcd
+chroot /host systemd-run --on-active=10m systemctl restart kubelet
+exec sleep infinity`, etcdRecoveryDir),
+			"",
+		))
+	}
+	if len(pods) == len(nodes) {
+		return nil, fmt.Errorf("node %s of the restored etcd snapshot is not a control plane node", snapshotPod.Spec.NodeName)
+	}
+	pods = append(pods, newEtcdRecoveryPod(
+		etcdRecoveryName,
+		snapshotPod.Spec.NodeName,
+		image,
+		fmt.Sprintf(`set -e
+sleep 120
+mkdir -p /host%[1]s/backup
+cp /snapshot/%[2]s /host%[1]s/backup/snapshot_hive.db
+tar -czf /host%[1]s/backup/static_kuberesources_hive.tar.gz -C /host/etc/kubernetes static-pod-resources
+chroot /host systemd-run --on-active=10m systemctl restart kubelet
+chroot /host /usr/local/bin/cluster-restore.sh %[1]s/backup
+exec sleep infinity`, etcdRecoveryDir, etcdSnapshotFile),
+		claim,
+	))
+	return pods, nil
+}
+
+// newEtcdRecoveryPod returns a privileged pod running the script on the host of the control plane node, with the
+// volume of the snapshot when a claim is given.
+func newEtcdRecoveryPod(name, node, image, script, claim string) *corev1.Pod {
+	pod := &corev1.Pod{}
+	pod.Namespace = etcdNamespace
+	pod.Name = name
+	pod.Spec = corev1.PodSpec{
+		NodeName:      node,
+		HostNetwork:   true,
+		HostPID:       true,
+		RestartPolicy: corev1.RestartPolicyNever,
+		Tolerations:   []corev1.Toleration{{Operator: corev1.TolerationOpExists}},
+		Volumes: []corev1.Volume{{
+			Name:         "host",
+			VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: "/"}},
+		}},
+		Containers: []corev1.Container{{
+			Name:            "recovery",
+			Image:           image,
+			Command:         []string{"/bin/sh", "-c", script},
+			SecurityContext: &corev1.SecurityContext{Privileged: pointer.BoolPtr(true)},
+			VolumeMounts:    []corev1.VolumeMount{{Name: "host", MountPath: "/host"}},
+		}},
+	}
+	if claim != "" {
+		pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{
+			Name: "snapshot",
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: claim},
+			},
+		})
+		pod.Spec.Containers[0].VolumeMounts = append(pod.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{Name: "snapshot", MountPath: "/snapshot"})
+	}
+	return pod
+}
+
+func podReady(pod *corev1.Pod) bool {
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == corev1.PodReady {
+			return cond.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
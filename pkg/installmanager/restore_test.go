@@ -0,0 +1,156 @@
+package installmanager
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	velerov1 "github.com/heptio/velero/pkg/apis/velero/v1"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+func TestClusterRestorer(t *testing.T) {
+	backup := func(location string) runtime.Object {
+		b := &velerov1.Backup{}
+		b.Namespace = defaultVeleroNamespace
+		b.Name = "test-backup"
+		b.Spec.StorageLocation = location
+		return b
+	}
+	restore := func(phase velerov1.RestorePhase) runtime.Object {
+		r := &velerov1.Restore{}
+		r.Namespace = defaultVeleroNamespace
+		r.Name = "test-cluster-restore"
+		r.Spec.BackupName = "test-backup"
+		r.Status.Phase = phase
+		r.Status.FailureReason = "test failure"
+		return r
+	}
+	tests := []struct {
+		name            string
+		existing        []runtime.Object
+		storageLocation string
+		expectErr       string
+		expectPhase     string
+		expectRestore   bool
+	}{
+		{
+			name:      "backup not synced",
+			expectErr: "backup is not available",
+		},
+		{
+			name:      "backup in another storage location",
+			existing:  []runtime.Object{backup("other")},
+			expectErr: "backup is in storage location other rather than default",
+		},
+		{
+			name:            "backup in configured storage location",
+			existing:        []runtime.Object{backup("other"), restore(velerov1.RestorePhaseCompleted)},
+			storageLocation: "other",
+			expectPhase:     "Completed",
+			expectRestore:   true,
+		},
+		{
+			name:          "restore completed",
+			existing:      []runtime.Object{backup("default"), restore(velerov1.RestorePhaseCompleted)},
+			expectPhase:   "Completed",
+			expectRestore: true,
+		},
+		{
+			name:          "restore failed",
+			existing:      []runtime.Object{backup("default"), restore(velerov1.RestorePhasePartiallyFailed)},
+			expectErr:     "restore velero/test-cluster-restore is PartiallyFailed: test failure",
+			expectPhase:   "PartiallyFailed",
+			expectRestore: true,
+		},
+		{
+			name:          "restore created",
+			existing:      []runtime.Object{backup("default")},
+			expectErr:     "timed out",
+			expectRestore: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			clientgoscheme.AddToScheme(scheme)
+			velerov1.AddToScheme(scheme)
+			c := fake.NewFakeClientWithScheme(scheme, test.existing...)
+			cd := &hivev1.ClusterDeployment{}
+			cd.Name = "test-cluster"
+			cd.Spec.RestoreRef = &hivev1.RestoreReference{BackupName: "test-backup", StorageLocation: test.storageLocation}
+			r := newClusterRestorer(c, cd, log.WithField("test", test.name))
+			r.pollInterval = time.Millisecond
+			r.stepTimeout = 10 * time.Millisecond
+
+			status := &hivev1.RestoreStatus{}
+			err := r.restore(status)
+			if test.expectErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.expectErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, test.expectPhase, status.Phase, "unexpected restore phase")
+
+			restore := &velerov1.Restore{}
+			err = c.Get(context.Background(), types.NamespacedName{Namespace: defaultVeleroNamespace, Name: "test-cluster-restore"}, restore)
+			if test.expectRestore {
+				require.NoError(t, err, "expected restore")
+				assert.Equal(t, "test-backup", restore.Spec.BackupName, "unexpected backup of restore")
+			} else {
+				assert.Error(t, err, "unexpected restore")
+			}
+		})
+	}
+}
+
+func TestNewEtcdRecoveryPods(t *testing.T) {
+	snapshotPod := &corev1.Pod{}
+	snapshotPod.Name = "hive-exit-etcd-snapshot"
+	snapshotPod.Spec.NodeName = "master-1"
+	snapshotPod.Spec.Containers = []corev1.Container{{Image: "etcd-image"}}
+	snapshotPod.Spec.Volumes = []corev1.Volume{{
+		Name: "snapshot",
+		VolumeSource: corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "hive-exit-etcd-snapshot"},
+		},
+	}}
+
+	pods, err := newEtcdRecoveryPods(snapshotPod, func() ([]string, error) {
+		return []string{"master-0", "master-1", "master-2"}, nil
+	})
+	require.NoError(t, err)
+	require.Len(t, pods, 3, "unexpected number of pods")
+	for i, node := range []string{"master-0", "master-2"} {
+		assert.Equal(t, node, pods[i].Spec.NodeName, "unexpected node of stop pod")
+		assert.Len(t, pods[i].Spec.Volumes, 1, "unexpected snapshot volume in stop pod")
+	}
+	recovery := pods[2]
+	assert.Equal(t, etcdRecoveryName, recovery.Name, "unexpected name of recovery pod")
+	assert.Equal(t, "master-1", recovery.Spec.NodeName, "unexpected node of recovery pod")
+	assert.Equal(t, "etcd-image", recovery.Spec.Containers[0].Image, "unexpected image of recovery pod")
+	require.Len(t, recovery.Spec.Volumes, 2, "expected snapshot volume in recovery pod")
+	assert.Equal(t, "hive-exit-etcd-snapshot", recovery.Spec.Volumes[1].PersistentVolumeClaim.ClaimName, "unexpected snapshot claim")
+
+	_, err = newEtcdRecoveryPods(snapshotPod, func() ([]string, error) {
+		return []string{"master-0"}, nil
+	})
+	assert.Error(t, err, "expected error for snapshot outside the control plane")
+
+	snapshotPod.Spec.Volumes = nil
+	_, err = newEtcdRecoveryPods(snapshotPod, func() ([]string, error) {
+		return []string{"master-1"}, nil
+	})
+	assert.Error(t, err, "expected error for snapshot pod without volume")
+}
@@ -61,7 +61,7 @@ func (a *s3LogUploaderActuator) UploadLogs(clusterName string, clusterprovision
 
 	retvalErrs := []error{}
 
-	folder := fmt.Sprintf("%v-%v", clusterName, clusterprovision.Namespace)
+	folder := s3LogFolder(clusterName, clusterprovision)
 
 	log.Infof("Uploading log(s) to S3: s3://%v/%v/", bucket, folder)
 
@@ -79,7 +79,7 @@ func (a *s3LogUploaderActuator) UploadLogs(clusterName string, clusterprovision
 			continue
 		}
 
-		logkey := fmt.Sprintf("%v/%v-%v", folder, clusterprovision.Name, stat.Name())
+		logkey := s3LogKey(clusterName, clusterprovision, stat.Name())
 
 		_, err = awsc.Upload(&s3manager.UploadInput{
 			Bucket: aws.String(bucket),
@@ -95,6 +95,19 @@ func (a *s3LogUploaderActuator) UploadLogs(clusterName string, clusterprovision
 	return utilerrors.NewAggregate(retvalErrs)
 }
 
+// Location returns where UploadLogs uploads the file with the given name.
+func (a *s3LogUploaderActuator) Location(clusterName string, clusterprovision *hivev1.ClusterProvision, filename string) string {
+	return fmt.Sprintf("s3://%v/%v", os.Getenv(constants.InstallLogsAWSS3BucketEnvVar), s3LogKey(clusterName, clusterprovision, filename))
+}
+
+func s3LogFolder(clusterName string, clusterprovision *hivev1.ClusterProvision) string {
+	return fmt.Sprintf("%v-%v", clusterName, clusterprovision.Namespace)
+}
+
+func s3LogKey(clusterName string, clusterprovision *hivev1.ClusterProvision, filename string) string {
+	return fmt.Sprintf("%v/%v-%v", s3LogFolder(clusterName, clusterprovision), clusterprovision.Name, filename)
+}
+
 func getAWSClient(c client.Client, secretName, namespace, region string, logger log.FieldLogger) (awsclient.Client, error) {
 	awsClient, err := awsclient.NewClient(c, secretName, namespace, region)
 	if err != nil {
@@ -0,0 +1,177 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+
+	log "github.com/sirupsen/logrus"
+
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+const (
+	clusterClaimResource = "clusterclaims"
+)
+
+// ClusterClaimMutatingAdmissionHook is a struct that is used to reference what code should be run by the generic-admission-server.
+type ClusterClaimMutatingAdmissionHook struct {
+	decoder *admission.Decoder
+}
+
+// NewClusterClaimMutatingAdmissionHook constructs a new ClusterClaimMutatingAdmissionHook
+func NewClusterClaimMutatingAdmissionHook(decoder *admission.Decoder) *ClusterClaimMutatingAdmissionHook {
+	return &ClusterClaimMutatingAdmissionHook{decoder: decoder}
+}
+
+// MutatingResource is called by generic-admission-server on startup to register the returned REST resource through which the
+// webhook is accessed by the kube apiserver.
+// For example, generic-admission-server uses the data below to register the webhook on the REST resource "/apis/admission.hive.openshift.io/v1/clusterclaimmutators".
+// When the kube apiserver calls this registered REST resource, the generic-admission-server calls the Admit() method below.
+func (a *ClusterClaimMutatingAdmissionHook) MutatingResource() (plural schema.GroupVersionResource, singular string) {
+	log.WithFields(log.Fields{
+		"group":    "admission.hive.openshift.io",
+		"version":  "v1",
+		"resource": "clusterclaimmutator",
+	}).Info("Registering mutation REST resource")
+	// NOTE: This GVR is meant to be different than the ClusterClaim CRD GVR which has group "hive.openshift.io".
+	return schema.GroupVersionResource{
+			Group:    "admission.hive.openshift.io",
+			Version:  "v1",
+			Resource: "clusterclaimmutators",
+		},
+		"clusterclaimmutator"
+}
+
+// Initialize is called by generic-admission-server on startup to setup any special initialization that your webhook needs.
+func (a *ClusterClaimMutatingAdmissionHook) Initialize(kubeClientConfig *rest.Config, stopCh <-chan struct{}) error {
+	log.WithFields(log.Fields{
+		"group":    "admission.hive.openshift.io",
+		"version":  "v1",
+		"resource": "clusterclaimmutator",
+	}).Info("Initializing mutation REST resource")
+	return nil
+}
+
+// Admit is called by generic-admission-server when the registered REST resource above is called with an admission request.
+// It records the user creating a ClusterClaim as its requester, replacing any requester set by the user.
+func (a *ClusterClaimMutatingAdmissionHook) Admit(admissionSpec *admissionv1beta1.AdmissionRequest) *admissionv1beta1.AdmissionResponse {
+	contextLogger := log.WithFields(log.Fields{
+		"operation": admissionSpec.Operation,
+		"group":     admissionSpec.Resource.Group,
+		"version":   admissionSpec.Resource.Version,
+		"resource":  admissionSpec.Resource.Resource,
+		"method":    "Admit",
+	})
+
+	if !a.shouldMutate(admissionSpec) {
+		contextLogger.Info("Skipping mutation for request")
+		// The request object isn't something that this mutator should mutate.
+		// Therefore, we allow it unchanged.
+		return &admissionv1beta1.AdmissionResponse{
+			Allowed: true,
+		}
+	}
+
+	contextLogger = contextLogger.WithFields(log.Fields{
+		"namespace": admissionSpec.Namespace,
+		"name":      admissionSpec.Name,
+	})
+
+	claim := &hivev1.ClusterClaim{}
+	if err := a.decoder.DecodeRaw(admissionSpec.Object, claim); err != nil {
+		contextLogger.WithError(err).Error("Failed unmarshaling Object")
+		return &admissionv1beta1.AdmissionResponse{
+			Allowed: false,
+			Result: &metav1.Status{
+				Status: metav1.StatusFailure, Code: http.StatusBadRequest, Reason: metav1.StatusReasonBadRequest,
+				Message: err.Error(),
+			},
+		}
+	}
+
+	requester := admissionSpec.UserInfo.Username
+	if claim.Annotations[constants.ClaimRequesterAnnotation] == requester {
+		return &admissionv1beta1.AdmissionResponse{
+			Allowed: true,
+		}
+	}
+	if claim.Annotations == nil {
+		claim.Annotations = map[string]string{}
+	}
+	claim.Annotations[constants.ClaimRequesterAnnotation] = requester
+
+	mutated, err := json.Marshal(claim)
+	if err != nil {
+		contextLogger.WithError(err).Error("Failed marshaling mutated object")
+		return &admissionv1beta1.AdmissionResponse{
+			Allowed: false,
+			Result: &metav1.Status{
+				Status: metav1.StatusFailure, Code: http.StatusInternalServerError, Reason: metav1.StatusReasonInternalError,
+				Message: err.Error(),
+			},
+		}
+	}
+	response := admission.PatchResponseFromRaw(admissionSpec.Object.Raw, mutated)
+	if !response.Allowed {
+		return &response.AdmissionResponse
+	}
+	patch, err := json.Marshal(response.Patches)
+	if err != nil {
+		contextLogger.WithError(err).Error("Failed marshaling patch")
+		return &admissionv1beta1.AdmissionResponse{
+			Allowed: false,
+			Result: &metav1.Status{
+				Status: metav1.StatusFailure, Code: http.StatusInternalServerError, Reason: metav1.StatusReasonInternalError,
+				Message: err.Error(),
+			},
+		}
+	}
+	response.Patch = patch
+
+	contextLogger.WithField("requester", requester).Info("Recorded requester of ClusterClaim")
+	return &response.AdmissionResponse
+}
+
+// shouldMutate explicitly checks if the request should be mutated. For example, this webhook may have accidentally been registered to
+// mutate some other type of object with a different GVR. Only creates are mutated; the validating webhook keeps the requester from
+// being changed afterwards.
+func (a *ClusterClaimMutatingAdmissionHook) shouldMutate(admissionSpec *admissionv1beta1.AdmissionRequest) bool {
+	contextLogger := log.WithFields(log.Fields{
+		"operation": admissionSpec.Operation,
+		"group":     admissionSpec.Resource.Group,
+		"version":   admissionSpec.Resource.Version,
+		"resource":  admissionSpec.Resource.Resource,
+		"method":    "shouldMutate",
+	})
+
+	if admissionSpec.Resource.Group != clusterDeploymentGroup {
+		contextLogger.Debug("Returning False, not our group")
+		return false
+	}
+
+	if admissionSpec.Resource.Version != clusterDeploymentVersion {
+		contextLogger.Debug("Returning False, it's our group, but not the right version")
+		return false
+	}
+
+	if admissionSpec.Resource.Resource != clusterClaimResource {
+		contextLogger.Debug("Returning False, it's our group and version, but not the right resource")
+		return false
+	}
+
+	if admissionSpec.Operation != admissionv1beta1.Create {
+		contextLogger.Debug("Returning False, not a create")
+		return false
+	}
+
+	// If we get here, then we're supposed to mutate the object.
+	contextLogger.Debug("Returning True, passed all prerequisites.")
+	return true
+}
@@ -0,0 +1,142 @@
+package v1
+
+import (
+	"encoding/json"
+	"testing"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+func TestClusterClaimMutatingResource(t *testing.T) {
+	// Arrange
+	data := NewClusterClaimMutatingAdmissionHook(createDecoder(t))
+	expectedPlural := schema.GroupVersionResource{
+		Group:    "admission.hive.openshift.io",
+		Version:  "v1",
+		Resource: "clusterclaimmutators",
+	}
+	expectedSingular := "clusterclaimmutator"
+
+	// Act
+	plural, singular := data.MutatingResource()
+
+	// Assert
+	assert.Equal(t, expectedPlural, plural)
+	assert.Equal(t, expectedSingular, singular)
+}
+
+func TestClusterClaimMutatingInitialize(t *testing.T) {
+	// Arrange
+	data := NewClusterClaimMutatingAdmissionHook(createDecoder(t))
+
+	// Act
+	err := data.Initialize(&rest.Config{}, nil)
+
+	// Assert
+	assert.Nil(t, err)
+}
+
+func TestClusterClaimMutate(t *testing.T) {
+	claim := func(requester string) *hivev1.ClusterClaim {
+		claim := &hivev1.ClusterClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: testNamespace,
+				Name:      "test-claim",
+			},
+			Spec: hivev1.ClusterClaimSpec{
+				ClusterPoolName: "test-pool",
+			},
+		}
+		if requester != "" {
+			claim.Annotations = map[string]string{constants.ClaimRequesterAnnotation: requester}
+		}
+		return claim
+	}
+
+	cases := []struct {
+		name              string
+		claim             *hivev1.ClusterClaim
+		operation         admissionv1beta1.Operation
+		expectPatch       bool
+		expectedRequester string
+	}{
+		{
+			name:              "requester recorded",
+			claim:             claim(""),
+			operation:         admissionv1beta1.Create,
+			expectPatch:       true,
+			expectedRequester: "test-user",
+		},
+		{
+			name:              "requester set by user replaced",
+			claim:             claim("other-user"),
+			operation:         admissionv1beta1.Create,
+			expectPatch:       true,
+			expectedRequester: "test-user",
+		},
+		{
+			name:      "requester already recorded",
+			claim:     claim("test-user"),
+			operation: admissionv1beta1.Create,
+		},
+		{
+			name:      "updates not mutated",
+			claim:     claim(""),
+			operation: admissionv1beta1.Update,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			data := NewClusterClaimMutatingAdmissionHook(createDecoder(t))
+			objectRaw, err := json.Marshal(tc.claim)
+			require.NoError(t, err, "unexpected error marshaling cluster claim")
+			request := &admissionv1beta1.AdmissionRequest{
+				Resource: metav1.GroupVersionResource{
+					Group:    "hive.openshift.io",
+					Version:  "v1",
+					Resource: "clusterclaims",
+				},
+				Operation: tc.operation,
+				Namespace: testNamespace,
+				Name:      tc.claim.Name,
+				UserInfo:  authenticationv1.UserInfo{Username: "test-user"},
+				Object:    runtime.RawExtension{Raw: objectRaw},
+			}
+
+			// Act
+			response := data.Admit(request)
+
+			// Assert
+			require.True(t, response.Allowed, "expected request to be allowed: %v", response.Result)
+			if !tc.expectPatch {
+				assert.Empty(t, response.Patch, "expected no patch")
+				return
+			}
+			require.NotEmpty(t, response.Patch, "expected a patch")
+			if assert.NotNil(t, response.PatchType, "expected a patch type") {
+				assert.Equal(t, admissionv1beta1.PatchTypeJSONPatch, *response.PatchType, "unexpected patch type")
+			}
+			patch, err := jsonpatch.DecodePatch(response.Patch)
+			require.NoError(t, err, "unexpected error decoding patch")
+			mutatedRaw, err := patch.Apply(objectRaw)
+			require.NoError(t, err, "unexpected error applying patch")
+			mutated := &hivev1.ClusterClaim{}
+			require.NoError(t, json.Unmarshal(mutatedRaw, mutated), "unexpected error unmarshaling mutated cluster claim")
+			assert.Equal(t, tc.expectedRequester, mutated.Annotations[constants.ClaimRequesterAnnotation], "unexpected requester")
+			assert.Equal(t, tc.claim.Spec, mutated.Spec, "unexpected change to spec")
+		})
+	}
+}
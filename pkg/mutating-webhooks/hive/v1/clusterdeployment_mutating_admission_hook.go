@@ -0,0 +1,277 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	log "github.com/sirupsen/logrus"
+
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	webhookutil "github.com/openshift/hive/pkg/util/webhook"
+)
+
+const (
+	clusterDeploymentGroup    = "hive.openshift.io"
+	clusterDeploymentVersion  = "v1"
+	clusterDeploymentResource = "clusterdeployments"
+)
+
+// ClusterDeploymentMutatingAdmissionHook is a struct that is used to reference what code should be run by the generic-admission-server.
+type ClusterDeploymentMutatingAdmissionHook struct {
+	decoder *admission.Decoder
+	client  client.Client
+}
+
+// NewClusterDeploymentMutatingAdmissionHook constructs a new ClusterDeploymentMutatingAdmissionHook
+func NewClusterDeploymentMutatingAdmissionHook(decoder *admission.Decoder) *ClusterDeploymentMutatingAdmissionHook {
+	return &ClusterDeploymentMutatingAdmissionHook{decoder: decoder}
+}
+
+// MutatingResource is called by generic-admission-server on startup to register the returned REST resource through which the
+// webhook is accessed by the kube apiserver.
+// For example, generic-admission-server uses the data below to register the webhook on the REST resource "/apis/admission.hive.openshift.io/v1/clusterdeploymentmutators".
+// When the kube apiserver calls this registered REST resource, the generic-admission-server calls the Admit() method below.
+func (a *ClusterDeploymentMutatingAdmissionHook) MutatingResource() (plural schema.GroupVersionResource, singular string) {
+	log.WithFields(log.Fields{
+		"group":    "admission.hive.openshift.io",
+		"version":  "v1",
+		"resource": "clusterdeploymentmutator",
+	}).Info("Registering mutation REST resource")
+	// NOTE: This GVR is meant to be different than the ClusterDeployment CRD GVR which has group "hive.openshift.io".
+	return schema.GroupVersionResource{
+			Group:    "admission.hive.openshift.io",
+			Version:  "v1",
+			Resource: "clusterdeploymentmutators",
+		},
+		"clusterdeploymentmutator"
+}
+
+// Initialize is called by generic-admission-server on startup to setup any special initialization that your webhook needs.
+func (a *ClusterDeploymentMutatingAdmissionHook) Initialize(kubeClientConfig *rest.Config, stopCh <-chan struct{}) error {
+	log.WithFields(log.Fields{
+		"group":    "admission.hive.openshift.io",
+		"version":  "v1",
+		"resource": "clusterdeploymentmutator",
+	}).Info("Initializing mutation REST resource")
+
+	scheme := runtime.NewScheme()
+	if err := hivev1.AddToScheme(scheme); err != nil {
+		return err
+	}
+	c, err := webhookutil.NewClient(kubeClientConfig, scheme)
+	if err != nil {
+		return err
+	}
+	a.client = c
+	return nil
+}
+
+// Admit is called by generic-admission-server when the registered REST resource above is called with an admission request.
+// It applies the defaults of the HiveNamespaceConfig of the namespace to a ClusterDeployment being created.
+func (a *ClusterDeploymentMutatingAdmissionHook) Admit(admissionSpec *admissionv1beta1.AdmissionRequest) *admissionv1beta1.AdmissionResponse {
+	contextLogger := log.WithFields(log.Fields{
+		"operation": admissionSpec.Operation,
+		"group":     admissionSpec.Resource.Group,
+		"version":   admissionSpec.Resource.Version,
+		"resource":  admissionSpec.Resource.Resource,
+		"method":    "Admit",
+	})
+
+	if !a.shouldMutate(admissionSpec) {
+		contextLogger.Info("Skipping mutation for request")
+		// The request object isn't something that this mutator should mutate.
+		// Therefore, we allow it unchanged.
+		return &admissionv1beta1.AdmissionResponse{
+			Allowed: true,
+		}
+	}
+
+	contextLogger = contextLogger.WithFields(log.Fields{
+		"namespace": admissionSpec.Namespace,
+		"name":      admissionSpec.Name,
+	})
+
+	cd := &hivev1.ClusterDeployment{}
+	if err := a.decoder.DecodeRaw(admissionSpec.Object, cd); err != nil {
+		contextLogger.WithError(err).Error("Failed unmarshaling Object")
+		return &admissionv1beta1.AdmissionResponse{
+			Allowed: false,
+			Result: &metav1.Status{
+				Status: metav1.StatusFailure, Code: http.StatusBadRequest, Reason: metav1.StatusReasonBadRequest,
+				Message: err.Error(),
+			},
+		}
+	}
+
+	config := &hivev1.HiveNamespaceConfig{}
+	switch err := a.client.Get(context.Background(), client.ObjectKey{Namespace: admissionSpec.Namespace, Name: hivev1.DefaultHiveNamespaceConfigName}, config); {
+	case apierrors.IsNotFound(err):
+		contextLogger.Debug("No HiveNamespaceConfig in the namespace")
+		return &admissionv1beta1.AdmissionResponse{
+			Allowed: true,
+		}
+	case err != nil:
+		contextLogger.WithError(err).Error("Failed to get HiveNamespaceConfig")
+		return &admissionv1beta1.AdmissionResponse{
+			Allowed: false,
+			Result: &metav1.Status{
+				Status: metav1.StatusFailure, Code: http.StatusInternalServerError, Reason: metav1.StatusReasonInternalError,
+				Message: "could not get the HiveNamespaceConfig of the namespace: " + err.Error(),
+			},
+		}
+	}
+
+	if !applyNamespaceDefaults(cd, &config.Spec) {
+		contextLogger.Info("No defaults to apply")
+		return &admissionv1beta1.AdmissionResponse{
+			Allowed: true,
+		}
+	}
+
+	mutated, err := json.Marshal(cd)
+	if err != nil {
+		contextLogger.WithError(err).Error("Failed marshaling mutated object")
+		return &admissionv1beta1.AdmissionResponse{
+			Allowed: false,
+			Result: &metav1.Status{
+				Status: metav1.StatusFailure, Code: http.StatusInternalServerError, Reason: metav1.StatusReasonInternalError,
+				Message: err.Error(),
+			},
+		}
+	}
+	response := admission.PatchResponseFromRaw(admissionSpec.Object.Raw, mutated)
+	if !response.Allowed {
+		return &response.AdmissionResponse
+	}
+	patch, err := json.Marshal(response.Patches)
+	if err != nil {
+		contextLogger.WithError(err).Error("Failed marshaling patch")
+		return &admissionv1beta1.AdmissionResponse{
+			Allowed: false,
+			Result: &metav1.Status{
+				Status: metav1.StatusFailure, Code: http.StatusInternalServerError, Reason: metav1.StatusReasonInternalError,
+				Message: err.Error(),
+			},
+		}
+	}
+	response.Patch = patch
+
+	contextLogger.Info("Applied defaults of the HiveNamespaceConfig")
+	return &response.AdmissionResponse
+}
+
+// shouldMutate explicitly checks if the request should be mutated. For example, this webhook may have accidentally been registered to
+// mutate some other type of object with a different GVR. Only creates are mutated, so that later changes to the defaults of a
+// namespace do not affect existing ClusterDeployments.
+func (a *ClusterDeploymentMutatingAdmissionHook) shouldMutate(admissionSpec *admissionv1beta1.AdmissionRequest) bool {
+	contextLogger := log.WithFields(log.Fields{
+		"operation": admissionSpec.Operation,
+		"group":     admissionSpec.Resource.Group,
+		"version":   admissionSpec.Resource.Version,
+		"resource":  admissionSpec.Resource.Resource,
+		"method":    "shouldMutate",
+	})
+
+	if admissionSpec.Resource.Group != clusterDeploymentGroup {
+		contextLogger.Debug("Returning False, not our group")
+		return false
+	}
+
+	if admissionSpec.Resource.Version != clusterDeploymentVersion {
+		contextLogger.Debug("Returning False, it's our group, but not the right version")
+		return false
+	}
+
+	if admissionSpec.Resource.Resource != clusterDeploymentResource {
+		contextLogger.Debug("Returning False, it's our group and version, but not the right resource")
+		return false
+	}
+
+	if admissionSpec.Operation != admissionv1beta1.Create {
+		contextLogger.Debug("Returning False, not a create")
+		return false
+	}
+
+	// If we get here, then we're supposed to mutate the object.
+	contextLogger.Debug("Returning True, passed all prerequisites.")
+	return true
+}
+
+// applyNamespaceDefaults sets the fields of the ClusterDeployment which it does not set itself to the defaults of its
+// namespace. It returns whether the ClusterDeployment was changed.
+func applyNamespaceDefaults(cd *hivev1.ClusterDeployment, defaults *hivev1.HiveNamespaceConfigSpec) bool {
+	changed := false
+
+	if cd.Spec.BaseDomain == "" && cd.Spec.BaseDomainPoolRef == nil && defaults.BaseDomain != "" {
+		cd.Spec.BaseDomain = defaults.BaseDomain
+		changed = true
+	}
+
+	// Only ClusterDeployments installed by Hive have an image set; adopted clusters do not have a Provisioning.
+	if p := cd.Spec.Provisioning; p != nil && p.ReleaseImage == "" && p.ImageSetRef == nil && defaults.ImageSetRef != nil {
+		p.ImageSetRef = defaults.ImageSetRef.DeepCopy()
+		changed = true
+	}
+
+	if p := cd.Spec.Provisioning; p != nil && p.Proxy == nil && defaults.Proxy != nil {
+		p.Proxy = defaults.Proxy.DeepCopy()
+		changed = true
+	}
+
+	if defaults.CredentialsSecretRef != nil && defaults.CredentialsSecretRef.Name != "" {
+		if ref := platformCredentialsSecretRef(&cd.Spec.Platform); ref != nil && ref.Name == "" {
+			*ref = *defaults.CredentialsSecretRef
+			changed = true
+		}
+	}
+
+	if aws := cd.Spec.Platform.AWS; aws != nil && len(defaults.UserTags) > 0 {
+		for k, v := range defaults.UserTags {
+			if _, ok := aws.UserTags[k]; ok {
+				continue
+			}
+			if aws.UserTags == nil {
+				aws.UserTags = map[string]string{}
+			}
+			aws.UserTags[k] = v
+			changed = true
+		}
+	}
+
+	return changed
+}
+
+// platformCredentialsSecretRef returns the reference to the secret with the cloud credentials of the platform of the
+// ClusterDeployment, or nil for platforms without cloud credentials.
+func platformCredentialsSecretRef(platform *hivev1.Platform) *corev1.LocalObjectReference {
+	switch {
+	case platform.AWS != nil:
+		return &platform.AWS.CredentialsSecretRef
+	case platform.Azure != nil:
+		return &platform.Azure.CredentialsSecretRef
+	case platform.GCP != nil:
+		return &platform.GCP.CredentialsSecretRef
+	case platform.OpenStack != nil:
+		return &platform.OpenStack.CredentialsSecretRef
+	case platform.VSphere != nil:
+		return &platform.VSphere.CredentialsSecretRef
+	case platform.Ovirt != nil:
+		return &platform.Ovirt.CredentialsSecretRef
+	case platform.OCI != nil:
+		return &platform.OCI.CredentialsSecretRef
+	case platform.PowerVS != nil:
+		return &platform.PowerVS.CredentialsSecretRef
+	}
+	return nil
+}
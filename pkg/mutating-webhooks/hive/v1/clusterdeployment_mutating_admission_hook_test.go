@@ -0,0 +1,274 @@
+package v1
+
+import (
+	"encoding/json"
+	"testing"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
+	hivev1gcp "github.com/openshift/hive/apis/hive/v1/gcp"
+)
+
+const testNamespace = "test-namespace"
+
+func createDecoder(t *testing.T) *admission.Decoder {
+	scheme := runtime.NewScheme()
+	hivev1.AddToScheme(scheme)
+	decoder, err := admission.NewDecoder(scheme)
+	require.NoError(t, err, "unexpected error creating decoder")
+	return decoder
+}
+
+func TestClusterDeploymentMutatingResource(t *testing.T) {
+	// Arrange
+	data := NewClusterDeploymentMutatingAdmissionHook(createDecoder(t))
+	expectedPlural := schema.GroupVersionResource{
+		Group:    "admission.hive.openshift.io",
+		Version:  "v1",
+		Resource: "clusterdeploymentmutators",
+	}
+	expectedSingular := "clusterdeploymentmutator"
+
+	// Act
+	plural, singular := data.MutatingResource()
+
+	// Assert
+	assert.Equal(t, expectedPlural, plural)
+	assert.Equal(t, expectedSingular, singular)
+}
+
+func TestClusterDeploymentMutatingInitialize(t *testing.T) {
+	// Arrange
+	data := NewClusterDeploymentMutatingAdmissionHook(createDecoder(t))
+
+	// Act
+	err := data.Initialize(&rest.Config{}, nil)
+
+	// Assert
+	assert.Nil(t, err)
+}
+
+func TestClusterDeploymentAdmit(t *testing.T) {
+	scheme := runtime.NewScheme()
+	hivev1.AddToScheme(scheme)
+
+	namespaceConfig := func(name string) *hivev1.HiveNamespaceConfig {
+		return &hivev1.HiveNamespaceConfig{
+			ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: name},
+			Spec: hivev1.HiveNamespaceConfigSpec{
+				BaseDomain:           "default.example.com",
+				CredentialsSecretRef: &corev1.LocalObjectReference{Name: "default-creds"},
+				ImageSetRef:          &hivev1.ClusterImageSetReference{Name: "default-image-set"},
+				UserTags:             map[string]string{"team": "default", "env": "dev"},
+				Proxy:                &hivev1.ProxyConfig{HTTPSProxy: "http://proxy.example.com:3128"},
+			},
+		}
+	}
+	awsClusterDeployment := func() *hivev1.ClusterDeployment {
+		return &hivev1.ClusterDeployment{
+			TypeMeta:   metav1.TypeMeta{APIVersion: hivev1.SchemeGroupVersion.String(), Kind: "ClusterDeployment"},
+			ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "test-cluster-deployment"},
+			Spec: hivev1.ClusterDeploymentSpec{
+				ClusterName: "test-cluster",
+				Platform: hivev1.Platform{
+					AWS: &hivev1aws.Platform{Region: "us-east-1"},
+				},
+				Provisioning: &hivev1.Provisioning{
+					InstallConfigSecretRef: &corev1.LocalObjectReference{Name: "install-config"},
+				},
+			},
+		}
+	}
+
+	cases := []struct {
+		name               string
+		existing           []runtime.Object
+		cd                 *hivev1.ClusterDeployment
+		operation          admissionv1beta1.Operation
+		expectPatch        bool
+		expectedBaseDomain string
+		expectedCreds      string
+		expectedImageSet   *hivev1.ClusterImageSetReference
+		expectedUserTags   map[string]string
+		expectedProxy      *hivev1.ProxyConfig
+		validateMutatedCD  func(t *testing.T, cd *hivev1.ClusterDeployment)
+	}{
+		{
+			name:      "no namespace config",
+			cd:        awsClusterDeployment(),
+			operation: admissionv1beta1.Create,
+		},
+		{
+			name:      "namespace config not named default",
+			existing:  []runtime.Object{namespaceConfig("other")},
+			cd:        awsClusterDeployment(),
+			operation: admissionv1beta1.Create,
+		},
+		{
+			name:               "defaults applied",
+			existing:           []runtime.Object{namespaceConfig(hivev1.DefaultHiveNamespaceConfigName)},
+			cd:                 awsClusterDeployment(),
+			operation:          admissionv1beta1.Create,
+			expectPatch:        true,
+			expectedBaseDomain: "default.example.com",
+			expectedCreds:      "default-creds",
+			expectedImageSet:   &hivev1.ClusterImageSetReference{Name: "default-image-set"},
+			expectedUserTags:   map[string]string{"team": "default", "env": "dev"},
+			expectedProxy:      &hivev1.ProxyConfig{HTTPSProxy: "http://proxy.example.com:3128"},
+		},
+		{
+			name:     "cluster deployment settings take precedence",
+			existing: []runtime.Object{namespaceConfig(hivev1.DefaultHiveNamespaceConfigName)},
+			cd: func() *hivev1.ClusterDeployment {
+				cd := awsClusterDeployment()
+				cd.Spec.BaseDomain = "my.example.com"
+				cd.Spec.Platform.AWS.CredentialsSecretRef.Name = "my-creds"
+				cd.Spec.Platform.AWS.UserTags = map[string]string{"team": "mine"}
+				cd.Spec.Provisioning.ImageSetRef = &hivev1.ClusterImageSetReference{Name: "my-image-set"}
+				cd.Spec.Provisioning.Proxy = &hivev1.ProxyConfig{HTTPProxy: "http://my-proxy.example.com:3128"}
+				return cd
+			}(),
+			operation:          admissionv1beta1.Create,
+			expectPatch:        true,
+			expectedBaseDomain: "my.example.com",
+			expectedCreds:      "my-creds",
+			expectedImageSet:   &hivev1.ClusterImageSetReference{Name: "my-image-set"},
+			expectedUserTags:   map[string]string{"team": "mine", "env": "dev"},
+			expectedProxy:      &hivev1.ProxyConfig{HTTPProxy: "http://my-proxy.example.com:3128"},
+		},
+		{
+			name:     "nothing to default",
+			existing: []runtime.Object{namespaceConfig(hivev1.DefaultHiveNamespaceConfigName)},
+			cd: func() *hivev1.ClusterDeployment {
+				cd := awsClusterDeployment()
+				cd.Spec.BaseDomain = "my.example.com"
+				cd.Spec.Platform.AWS.CredentialsSecretRef.Name = "my-creds"
+				cd.Spec.Platform.AWS.UserTags = map[string]string{"team": "mine", "env": "prod"}
+				cd.Spec.Provisioning.ReleaseImage = "example.com/release:latest"
+				cd.Spec.Provisioning.Proxy = &hivev1.ProxyConfig{HTTPProxy: "http://my-proxy.example.com:3128"}
+				return cd
+			}(),
+			operation: admissionv1beta1.Create,
+		},
+		{
+			name:     "no base domain with base domain pool",
+			existing: []runtime.Object{namespaceConfig(hivev1.DefaultHiveNamespaceConfigName)},
+			cd: func() *hivev1.ClusterDeployment {
+				cd := awsClusterDeployment()
+				cd.Spec.BaseDomainPoolRef = &hivev1.BaseDomainPoolReference{Name: "pool"}
+				return cd
+			}(),
+			operation:        admissionv1beta1.Create,
+			expectPatch:      true,
+			expectedCreds:    "default-creds",
+			expectedImageSet: &hivev1.ClusterImageSetReference{Name: "default-image-set"},
+			expectedUserTags: map[string]string{"team": "default", "env": "dev"},
+			expectedProxy:    &hivev1.ProxyConfig{HTTPSProxy: "http://proxy.example.com:3128"},
+		},
+		{
+			name:     "no image set for adopted cluster",
+			existing: []runtime.Object{namespaceConfig(hivev1.DefaultHiveNamespaceConfigName)},
+			cd: func() *hivev1.ClusterDeployment {
+				cd := awsClusterDeployment()
+				cd.Spec.Provisioning = nil
+				cd.Spec.Installed = true
+				return cd
+			}(),
+			operation:          admissionv1beta1.Create,
+			expectPatch:        true,
+			expectedBaseDomain: "default.example.com",
+			expectedCreds:      "default-creds",
+			expectedUserTags:   map[string]string{"team": "default", "env": "dev"},
+		},
+		{
+			name:     "credentials of other platform",
+			existing: []runtime.Object{namespaceConfig(hivev1.DefaultHiveNamespaceConfigName)},
+			cd: func() *hivev1.ClusterDeployment {
+				cd := awsClusterDeployment()
+				cd.Spec.Platform = hivev1.Platform{GCP: &hivev1gcp.Platform{Region: "us-east1"}}
+				return cd
+			}(),
+			operation:          admissionv1beta1.Create,
+			expectPatch:        true,
+			expectedBaseDomain: "default.example.com",
+			expectedImageSet:   &hivev1.ClusterImageSetReference{Name: "default-image-set"},
+			expectedProxy:      &hivev1.ProxyConfig{HTTPSProxy: "http://proxy.example.com:3128"},
+			validateMutatedCD: func(t *testing.T, cd *hivev1.ClusterDeployment) {
+				if assert.NotNil(t, cd.Spec.Platform.GCP, "expected GCP platform") {
+					assert.Equal(t, "default-creds", cd.Spec.Platform.GCP.CredentialsSecretRef.Name, "unexpected credentials")
+				}
+			},
+		},
+		{
+			name:      "updates not mutated",
+			existing:  []runtime.Object{namespaceConfig(hivev1.DefaultHiveNamespaceConfigName)},
+			cd:        awsClusterDeployment(),
+			operation: admissionv1beta1.Update,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			data := NewClusterDeploymentMutatingAdmissionHook(createDecoder(t))
+			data.client = fake.NewFakeClientWithScheme(scheme, tc.existing...)
+			objectRaw, err := json.Marshal(tc.cd)
+			require.NoError(t, err, "unexpected error marshaling cluster deployment")
+			request := &admissionv1beta1.AdmissionRequest{
+				Resource: metav1.GroupVersionResource{
+					Group:    "hive.openshift.io",
+					Version:  "v1",
+					Resource: "clusterdeployments",
+				},
+				Operation: tc.operation,
+				Namespace: testNamespace,
+				Name:      tc.cd.Name,
+				Object:    runtime.RawExtension{Raw: objectRaw},
+			}
+
+			// Act
+			response := data.Admit(request)
+
+			// Assert
+			require.True(t, response.Allowed, "expected request to be allowed: %v", response.Result)
+			if !tc.expectPatch {
+				assert.Empty(t, response.Patch, "expected no patch")
+				return
+			}
+			require.NotEmpty(t, response.Patch, "expected a patch")
+			if assert.NotNil(t, response.PatchType, "expected a patch type") {
+				assert.Equal(t, admissionv1beta1.PatchTypeJSONPatch, *response.PatchType, "unexpected patch type")
+			}
+			patch, err := jsonpatch.DecodePatch(response.Patch)
+			require.NoError(t, err, "unexpected error decoding patch")
+			mutatedRaw, err := patch.Apply(objectRaw)
+			require.NoError(t, err, "unexpected error applying patch")
+			mutated := &hivev1.ClusterDeployment{}
+			require.NoError(t, json.Unmarshal(mutatedRaw, mutated), "unexpected error unmarshaling mutated cluster deployment")
+			assert.Equal(t, tc.expectedBaseDomain, mutated.Spec.BaseDomain, "unexpected base domain")
+			if mutated.Spec.Provisioning != nil {
+				assert.Equal(t, tc.expectedImageSet, mutated.Spec.Provisioning.ImageSetRef, "unexpected image set")
+				assert.Equal(t, tc.expectedProxy, mutated.Spec.Provisioning.Proxy, "unexpected proxy")
+			}
+			if aws := mutated.Spec.Platform.AWS; aws != nil {
+				assert.Equal(t, tc.expectedCreds, aws.CredentialsSecretRef.Name, "unexpected credentials")
+				assert.Equal(t, tc.expectedUserTags, aws.UserTags, "unexpected user tags")
+			}
+			if tc.validateMutatedCD != nil {
+				tc.validateMutatedCD(t, mutated)
+			}
+		})
+	}
+}
@@ -0,0 +1,261 @@
+package objectstorage
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	awsclient "github.com/openshift/hive/pkg/awsclient"
+	gcpclient "github.com/openshift/hive/pkg/gcpclient"
+)
+
+const (
+	// DestinationS3 is the destination of objects stored in an S3 bucket.
+	DestinationS3 = "s3"
+	// DestinationGCS is the destination of objects stored in a Google Cloud Storage bucket.
+	DestinationGCS = "gcs"
+	// DestinationAzureBlob is the destination of objects stored in an Azure Blob Storage container.
+	DestinationAzureBlob = "azureblob"
+
+	azureBlobSASTokenKey = "sasToken"
+	azureBlobAPIVersion  = "2019-12-12"
+	azureBlobTimeout     = 5 * time.Minute
+)
+
+// Uploader uploads objects to object storage.
+type Uploader interface {
+	Upload(key string, body io.Reader) error
+}
+
+// Config is the object storage a feature of Hive writes objects to.
+type Config struct {
+	// Destination is the kind of object storage, one of the Destination constants.
+	Destination string
+	// Bucket is the S3 or GCS bucket, or the Azure Blob Storage container.
+	Bucket string
+	// AWSRegion is the region of the S3 bucket.
+	AWSRegion string
+	// AzureStorageAccount is the storage account of the Azure Blob Storage container.
+	AzureStorageAccount string
+	// CredentialsSecret is the name of the secret with the credentials for the object storage.
+	CredentialsSecret string
+}
+
+// NewConfig returns the Config of the first destination configured in the HiveConfig, or nil if none is.
+func NewConfig(s3 *hivev1.ObjectStorageS3Config, gcs *hivev1.ObjectStorageGCSConfig, azureBlob *hivev1.ObjectStorageAzureBlobConfig) *Config {
+	switch {
+	case s3 != nil:
+		return &Config{
+			Destination:       DestinationS3,
+			Bucket:            s3.Bucket,
+			AWSRegion:         s3.Region,
+			CredentialsSecret: s3.CredentialsSecretRef.Name,
+		}
+	case gcs != nil:
+		return &Config{
+			Destination:       DestinationGCS,
+			Bucket:            gcs.Bucket,
+			CredentialsSecret: gcs.CredentialsSecretRef.Name,
+		}
+	case azureBlob != nil:
+		return &Config{
+			Destination:         DestinationAzureBlob,
+			Bucket:              azureBlob.Container,
+			AzureStorageAccount: azureBlob.StorageAccount,
+			CredentialsSecret:   azureBlob.CredentialsSecretRef.Name,
+		}
+	}
+	return nil
+}
+
+// EnvVarNames are the names of the environment variables holding a Config.
+type EnvVarNames struct {
+	Destination         string
+	Bucket              string
+	AWSRegion           string
+	AzureStorageAccount string
+	CredentialsSecret   string
+}
+
+// NewEnvVarNames returns the names of the environment variables holding the Config of a feature, which share the
+// prefix of the feature.
+func NewEnvVarNames(prefix string) EnvVarNames {
+	return EnvVarNames{
+		Destination:         prefix + "_DESTINATION",
+		Bucket:              prefix + "_BUCKET",
+		AWSRegion:           prefix + "_AWS_REGION",
+		AzureStorageAccount: prefix + "_AZURE_STORAGE_ACCOUNT",
+		CredentialsSecret:   prefix + "_CREDENTIALS_SECRET",
+	}
+}
+
+// EnvVars returns the environment variables holding the Config, leaving out the fields which are not set.
+func (c *Config) EnvVars(names EnvVarNames) []corev1.EnvVar {
+	var envVars []corev1.EnvVar
+	for _, envVar := range []corev1.EnvVar{
+		{Name: names.Destination, Value: c.Destination},
+		{Name: names.Bucket, Value: c.Bucket},
+		{Name: names.AWSRegion, Value: c.AWSRegion},
+		{Name: names.AzureStorageAccount, Value: c.AzureStorageAccount},
+		{Name: names.CredentialsSecret, Value: c.CredentialsSecret},
+	} {
+		if envVar.Value != "" {
+			envVars = append(envVars, envVar)
+		}
+	}
+	return envVars
+}
+
+// ConfigFromEnv returns the Config held by the environment variables, or nil if no destination is set.
+func ConfigFromEnv(names EnvVarNames) *Config {
+	destination, ok := os.LookupEnv(names.Destination)
+	if !ok {
+		return nil
+	}
+	return &Config{
+		Destination:         destination,
+		Bucket:              os.Getenv(names.Bucket),
+		AWSRegion:           os.Getenv(names.AWSRegion),
+		AzureStorageAccount: os.Getenv(names.AzureStorageAccount),
+		CredentialsSecret:   os.Getenv(names.CredentialsSecret),
+	}
+}
+
+// String returns the URL of the bucket or container.
+func (c *Config) String() string {
+	switch c.Destination {
+	case DestinationS3:
+		return fmt.Sprintf("s3://%v/", c.Bucket)
+	case DestinationGCS:
+		return fmt.Sprintf("gs://%v/", c.Bucket)
+	case DestinationAzureBlob:
+		return fmt.Sprintf("%v/%v/", azureBlobEndpoint(c.AzureStorageAccount), c.Bucket)
+	}
+	return c.Destination
+}
+
+// GetCredentialsSecret reads a secret with credentials from the namespace.
+func GetCredentialsSecret(c client.Client, namespace, name string) (*corev1.Secret, error) {
+	secret := &corev1.Secret{}
+	if err := c.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: name}, secret); err != nil {
+		return nil, errors.Wrapf(err, "could not get credentials secret %v", name)
+	}
+	return secret, nil
+}
+
+// NewUploader returns an Uploader to the object storage of the Config, authenticating with the credentials secret
+// in the namespace.
+func NewUploader(c client.Client, namespace string, config *Config) (Uploader, error) {
+	switch config.Destination {
+	case DestinationS3:
+		awsClient, err := awsclient.NewClient(c, config.CredentialsSecret, namespace, config.AWSRegion)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not create AWS client")
+		}
+		return &s3Uploader{client: awsClient, bucket: config.Bucket}, nil
+	case DestinationGCS:
+		secret, err := GetCredentialsSecret(c, namespace, config.CredentialsSecret)
+		if err != nil {
+			return nil, err
+		}
+		gcpClient, err := gcpclient.NewClientFromSecret(secret)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not create GCP client")
+		}
+		return &gcsUploader{client: gcpClient, bucket: config.Bucket}, nil
+	case DestinationAzureBlob:
+		secret, err := GetCredentialsSecret(c, namespace, config.CredentialsSecret)
+		if err != nil {
+			return nil, err
+		}
+		return &azureBlobUploader{
+			client:    &http.Client{Timeout: azureBlobTimeout},
+			endpoint:  azureBlobEndpoint(config.AzureStorageAccount),
+			container: config.Bucket,
+			sasToken:  strings.TrimPrefix(strings.TrimSpace(string(secret.Data[azureBlobSASTokenKey])), "?"),
+		}, nil
+	}
+	return nil, errors.Errorf("unsupported object storage destination %q", config.Destination)
+}
+
+func azureBlobEndpoint(storageAccount string) string {
+	return fmt.Sprintf("https://%v.blob.core.windows.net", storageAccount)
+}
+
+// s3Uploader uploads objects to an S3 bucket.
+type s3Uploader struct {
+	client awsclient.Client
+	bucket string
+}
+
+func (u *s3Uploader) Upload(key string, body io.Reader) error {
+	_, err := u.client.Upload(&s3manager.UploadInput{
+		Bucket: aws.String(u.bucket),
+		Key:    aws.String(key),
+		Body:   body,
+	})
+	return err
+}
+
+// gcsUploader uploads objects to a Google Cloud Storage bucket.
+type gcsUploader struct {
+	client gcpclient.Client
+	bucket string
+}
+
+func (u *gcsUploader) Upload(key string, body io.Reader) error {
+	return u.client.UploadObject(context.TODO(), u.bucket, key, body)
+}
+
+// azureBlobUploader uploads objects as block blobs of an Azure Blob Storage container, authenticating with a shared
+// access signature.
+type azureBlobUploader struct {
+	client    *http.Client
+	endpoint  string
+	container string
+	sasToken  string
+}
+
+func (u *azureBlobUploader) Upload(key string, body io.Reader) error {
+	data, err := ioutil.ReadAll(body)
+	if err != nil {
+		return err
+	}
+	blobURL, err := url.Parse(u.endpoint)
+	if err != nil {
+		return err
+	}
+	blobURL.Path = "/" + u.container + "/" + key
+	blobURL.RawQuery = u.sasToken
+	req, err := http.NewRequest(http.MethodPut, blobURL.String(), bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("x-ms-blob-type", "BlockBlob")
+	req.Header.Set("x-ms-version", azureBlobAPIVersion)
+	resp, err := u.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		return errors.Errorf("unexpected status %v uploading blob %v", resp.Status, key)
+	}
+	return nil
+}
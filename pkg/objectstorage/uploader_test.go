@@ -0,0 +1,98 @@
+package objectstorage
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+func TestConfigEnvVars(t *testing.T) {
+	names := NewEnvVarNames("HIVE_TEST_OBJECT_STORAGE")
+	tests := []struct {
+		name     string
+		config   *Config
+		expected []corev1.EnvVar
+	}{
+		{
+			name: "s3",
+			config: NewConfig(&hivev1.ObjectStorageS3Config{
+				Bucket:               "test-bucket",
+				Region:               "us-east-1",
+				CredentialsSecretRef: corev1.LocalObjectReference{Name: "test-creds"},
+			}, nil, nil),
+			expected: []corev1.EnvVar{
+				{Name: "HIVE_TEST_OBJECT_STORAGE_DESTINATION", Value: DestinationS3},
+				{Name: "HIVE_TEST_OBJECT_STORAGE_BUCKET", Value: "test-bucket"},
+				{Name: "HIVE_TEST_OBJECT_STORAGE_AWS_REGION", Value: "us-east-1"},
+				{Name: "HIVE_TEST_OBJECT_STORAGE_CREDENTIALS_SECRET", Value: "test-creds"},
+			},
+		},
+		{
+			name: "azure blob",
+			config: NewConfig(nil, nil, &hivev1.ObjectStorageAzureBlobConfig{
+				StorageAccount:       "testaccount",
+				Container:            "test-container",
+				CredentialsSecretRef: corev1.LocalObjectReference{Name: "test-creds"},
+			}),
+			expected: []corev1.EnvVar{
+				{Name: "HIVE_TEST_OBJECT_STORAGE_DESTINATION", Value: DestinationAzureBlob},
+				{Name: "HIVE_TEST_OBJECT_STORAGE_BUCKET", Value: "test-container"},
+				{Name: "HIVE_TEST_OBJECT_STORAGE_AZURE_STORAGE_ACCOUNT", Value: "testaccount"},
+				{Name: "HIVE_TEST_OBJECT_STORAGE_CREDENTIALS_SECRET", Value: "test-creds"},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			envVars := test.config.EnvVars(names)
+			assert.Equal(t, test.expected, envVars, "unexpected environment variables")
+			for _, envVar := range envVars {
+				os.Setenv(envVar.Name, envVar.Value)
+				defer os.Unsetenv(envVar.Name)
+			}
+			assert.Equal(t, test.config, ConfigFromEnv(names), "unexpected config read from the environment")
+		})
+	}
+	assert.Nil(t, ConfigFromEnv(names), "expected no config without a destination")
+}
+
+func TestAzureBlobUploader(t *testing.T) {
+	var received *http.Request
+	var body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		data, _ := ioutil.ReadAll(r.Body)
+		body = string(data)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	u := &azureBlobUploader{
+		client:    server.Client(),
+		endpoint:  server.URL,
+		container: "artifacts",
+		sasToken:  "sv=2019-12-12&sig=test",
+	}
+	require.NoError(t, u.Upload("test-cluster-test-namespace/test-provision/metadata.json", bytes.NewReader([]byte("{}"))))
+	require.NotNil(t, received, "expected a request")
+	assert.Equal(t, http.MethodPut, received.Method, "unexpected method")
+	assert.Equal(t, "/artifacts/test-cluster-test-namespace/test-provision/metadata.json", received.URL.Path, "unexpected path")
+	assert.Equal(t, "test", received.URL.Query().Get("sig"), "expected the shared access signature")
+	assert.Equal(t, "BlockBlob", received.Header.Get("x-ms-blob-type"), "unexpected blob type")
+	assert.Equal(t, "{}", body, "unexpected body")
+
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	})
+	assert.Error(t, u.Upload("test-cluster-test-namespace/test-provision/metadata.json", bytes.NewReader([]byte("{}"))), "expected an error for a rejected upload")
+}
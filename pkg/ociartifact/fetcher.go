@@ -0,0 +1,397 @@
+package ociartifact
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/apimachinery/pkg/util/cache"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// maxArtifactSize is the maximum combined size of the manifest and layers of an artifact.
+	maxArtifactSize = 64 * 1024 * 1024
+
+	cacheSize = 64
+	cacheTTL  = 24 * time.Hour
+
+	defaultTimeout = 2 * time.Minute
+)
+
+var (
+	imageRegexp = regexp.MustCompile(`^([^/@]+)/([^@]+)@(sha256:[a-f0-9]{64})$`)
+
+	manifestMediaTypes = []string{
+		"application/vnd.oci.image.manifest.v1+json",
+		"application/vnd.docker.distribution.manifest.v2+json",
+	}
+)
+
+// Reference is a reference to an artifact in an OCI registry, pinned by digest.
+type Reference struct {
+	Registry   string
+	Repository string
+	Digest     string
+}
+
+// ParseReference parses a reference of the form registry/repository@sha256:<hex>.
+func ParseReference(image string) (*Reference, error) {
+	m := imageRegexp.FindStringSubmatch(image)
+	if m == nil {
+		return nil, fmt.Errorf("%q is not of the form registry/repository@sha256:<hex>", image)
+	}
+	return &Reference{Registry: m[1], Repository: m[2], Digest: m[3]}, nil
+}
+
+func (r *Reference) String() string {
+	return fmt.Sprintf("%s/%s@%s", r.Registry, r.Repository, r.Digest)
+}
+
+// Fetcher fetches the manifests of resources held in artifacts in OCI registries. As artifacts are pinned by digest,
+// the manifests of recently fetched artifacts are cached for the credentials which fetched them.
+type Fetcher struct {
+	client *http.Client
+	cache  *cache.LRUExpireCache
+}
+
+// NewFetcher returns a Fetcher using the client, or a default client if it is nil.
+func NewFetcher(client *http.Client) *Fetcher {
+	if client == nil {
+		client = &http.Client{Timeout: defaultTimeout}
+	}
+	return &Fetcher{
+		client: client,
+		cache:  cache.NewLRUExpireCache(cacheSize),
+	}
+}
+
+// Fetch returns the manifests held in the layers of the artifact, in the order of the layers. The pull secret is the
+// docker config JSON with the credentials for the registry, and may be empty for public artifacts.
+func (f *Fetcher) Fetch(image string, pullSecret []byte) ([][]byte, error) {
+	ref, err := ParseReference(image)
+	if err != nil {
+		return nil, err
+	}
+	username, password, err := credentialsForRegistry(pullSecret, ref.Registry)
+	if err != nil {
+		return nil, err
+	}
+	cacheKey := ref.String() + "|" + digestOf([]byte(username+":"+password))
+	if manifests, ok := f.cache.Get(cacheKey); ok {
+		return manifests.([][]byte), nil
+	}
+
+	s := &session{
+		client:   f.client,
+		ref:      ref,
+		username: username,
+		password: password,
+		budget:   maxArtifactSize,
+	}
+	manifests, err := s.fetch()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to fetch artifact %s", ref)
+	}
+	f.cache.Add(cacheKey, manifests, cacheTTL)
+	return manifests, nil
+}
+
+// session fetches a single artifact, carrying the authorization obtained from the registry between requests.
+type session struct {
+	client        *http.Client
+	ref           *Reference
+	username      string
+	password      string
+	authorization string
+	// budget is the number of bytes which can still be read from the registry.
+	budget int64
+}
+
+type imageManifest struct {
+	Layers []descriptor `json:"layers"`
+}
+
+type descriptor struct {
+	MediaType string `json:"mediaType"`
+	Digest    string `json:"digest"`
+}
+
+func (s *session) fetch() ([][]byte, error) {
+	content, err := s.get("manifests", s.ref.Digest, manifestMediaTypes)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not fetch manifest")
+	}
+	manifest := &imageManifest{}
+	if err := json.Unmarshal(content, manifest); err != nil {
+		return nil, errors.Wrap(err, "could not decode manifest")
+	}
+	var manifests [][]byte
+	for _, layer := range manifest.Layers {
+		content, err := s.get("blobs", layer.Digest, nil)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not fetch layer %s", layer.Digest)
+		}
+		layerManifests, err := decodeLayer(layer.MediaType, content)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not decode layer %s", layer.Digest)
+		}
+		manifests = append(manifests, layerManifests...)
+	}
+	return manifests, nil
+}
+
+// get fetches the manifest or blob with the digest, and verifies that its content matches the digest.
+func (s *session) get(kind, digest string, accept []string) ([]byte, error) {
+	if !strings.HasPrefix(digest, "sha256:") {
+		return nil, fmt.Errorf("unsupported digest %q", digest)
+	}
+	target := fmt.Sprintf("https://%s/v2/%s/%s/%s", s.ref.Registry, s.ref.Repository, kind, digest)
+	resp, err := s.do(target, accept)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("registry responded with %s", resp.Status)
+	}
+	content, err := ioutil.ReadAll(io.LimitReader(resp.Body, s.budget+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(content)) > s.budget {
+		return nil, fmt.Errorf("artifact is larger than %d bytes", maxArtifactSize)
+	}
+	s.budget -= int64(len(content))
+	if actual := digestOf(content); actual != digest {
+		return nil, fmt.Errorf("content has digest %s", actual)
+	}
+	return content, nil
+}
+
+// do sends a GET request to the registry, authorizing it as challenged by the registry.
+func (s *session) do(target string, accept []string) (*http.Response, error) {
+	send := func() (*http.Response, error) {
+		req, err := http.NewRequest(http.MethodGet, target, nil)
+		if err != nil {
+			return nil, err
+		}
+		if len(accept) > 0 {
+			req.Header.Set("Accept", strings.Join(accept, ", "))
+		}
+		if s.authorization != "" {
+			req.Header.Set("Authorization", s.authorization)
+		}
+		return s.client.Do(req)
+	}
+	resp, err := send()
+	if err != nil || resp.StatusCode != http.StatusUnauthorized || s.authorization != "" {
+		return resp, err
+	}
+	challenge := resp.Header.Get("WWW-Authenticate")
+	resp.Body.Close()
+	if err := s.authorize(challenge); err != nil {
+		return nil, errors.Wrap(err, "could not authorize with registry")
+	}
+	return send()
+}
+
+// authorize sets the authorization for the challenge of the registry, which is either basic authentication or a
+// bearer token obtained from the token service of the registry.
+func (s *session) authorize(challenge string) error {
+	scheme, params := parseChallenge(challenge)
+	switch strings.ToLower(scheme) {
+	case "basic":
+		if s.username == "" {
+			return errors.New("registry requires credentials")
+		}
+		s.authorization = "Basic " + base64.StdEncoding.EncodeToString([]byte(s.username+":"+s.password))
+		return nil
+	case "bearer":
+	default:
+		return fmt.Errorf("unsupported challenge %q", challenge)
+	}
+
+	realm, err := url.Parse(params["realm"])
+	if err != nil || realm.Host == "" {
+		return fmt.Errorf("invalid token realm %q", params["realm"])
+	}
+	query := realm.Query()
+	if service := params["service"]; service != "" {
+		query.Set("service", service)
+	}
+	query.Set("scope", fmt.Sprintf("repository:%s:pull", s.ref.Repository))
+	realm.RawQuery = query.Encode()
+	req, err := http.NewRequest(http.MethodGet, realm.String(), nil)
+	if err != nil {
+		return err
+	}
+	if s.username != "" {
+		req.SetBasicAuth(s.username, s.password)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("token service responded with %s", resp.Status)
+	}
+	token := struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}{}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return errors.Wrap(err, "could not decode token")
+	}
+	if token.Token == "" {
+		token.Token = token.AccessToken
+	}
+	if token.Token == "" {
+		return errors.New("token service returned no token")
+	}
+	s.authorization = "Bearer " + token.Token
+	return nil
+}
+
+// parseChallenge parses a WWW-Authenticate header such as: Bearer realm="https://auth.example.com/token",service="registry".
+func parseChallenge(challenge string) (string, map[string]string) {
+	params := map[string]string{}
+	parts := strings.SplitN(strings.TrimSpace(challenge), " ", 2)
+	if len(parts) == 2 {
+		for _, param := range strings.Split(parts[1], ",") {
+			kv := strings.SplitN(strings.TrimSpace(param), "=", 2)
+			if len(kv) == 2 {
+				params[strings.ToLower(kv[0])] = strings.Trim(kv[1], `"`)
+			}
+		}
+	}
+	return parts[0], params
+}
+
+// credentialsForRegistry returns the username and password for the registry from the docker config JSON.
+func credentialsForRegistry(pullSecret []byte, registry string) (string, string, error) {
+	if len(pullSecret) == 0 {
+		return "", "", nil
+	}
+	config := struct {
+		Auths map[string]struct {
+			Auth     string `json:"auth"`
+			Username string `json:"username"`
+			Password string `json:"password"`
+		} `json:"auths"`
+	}{}
+	if err := json.Unmarshal(pullSecret, &config); err != nil {
+		return "", "", errors.Wrap(err, "invalid pull secret")
+	}
+	auth, ok := config.Auths[registry]
+	if !ok {
+		return "", "", nil
+	}
+	if auth.Auth == "" {
+		return auth.Username, auth.Password, nil
+	}
+	decoded, err := base64.StdEncoding.DecodeString(auth.Auth)
+	if err != nil {
+		return "", "", errors.Wrapf(err, "invalid auth for registry %s", registry)
+	}
+	parts := strings.SplitN(string(decoded), ":", 2)
+	if len(parts) != 2 {
+		return "", "", fmt.Errorf("invalid auth for registry %s", registry)
+	}
+	return parts[0], parts[1], nil
+}
+
+// decodeLayer returns the manifests in the layer. Layers with a tar media type are archives of manifest files,
+// other layers are a stream of manifests. Either may be gzipped.
+func decodeLayer(mediaType string, content []byte) ([][]byte, error) {
+	if len(content) > 2 && content[0] == 0x1f && content[1] == 0x8b {
+		r, err := gzip.NewReader(bytes.NewReader(content))
+		if err != nil {
+			return nil, err
+		}
+		if content, err = ioutil.ReadAll(io.LimitReader(r, maxArtifactSize+1)); err != nil {
+			return nil, err
+		}
+		if len(content) > maxArtifactSize {
+			return nil, fmt.Errorf("uncompressed layer is larger than %d bytes", maxArtifactSize)
+		}
+	}
+	if !strings.Contains(mediaType, "tar") {
+		return SplitManifests(content)
+	}
+	var manifests [][]byte
+	r := tar.NewReader(bytes.NewReader(content))
+	for {
+		header, err := r.Next()
+		if err == io.EOF {
+			return manifests, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch path.Ext(header.Name) {
+		case ".yaml", ".yml", ".json":
+		default:
+			continue
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		file, err := ioutil.ReadAll(r)
+		if err != nil {
+			return nil, err
+		}
+		fileManifests, err := SplitManifests(file)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid manifests in %s", header.Name)
+		}
+		manifests = append(manifests, fileManifests...)
+	}
+}
+
+// SplitManifests splits a stream of YAML or JSON manifests separated by "---", skipping empty documents.
+func SplitManifests(content []byte) ([][]byte, error) {
+	var manifests [][]byte
+	r := utilyaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(content)))
+	for {
+		doc, err := r.Read()
+		if err == io.EOF {
+			return manifests, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		obj, err := yaml.YAMLToJSON(doc)
+		if err != nil {
+			return nil, err
+		}
+		// Skip documents which are empty or only hold comments.
+		if string(obj) == "null" {
+			continue
+		}
+		manifests = append(manifests, doc)
+	}
+}
+
+func digestOf(content []byte) string {
+	sum := sha256.Sum256(content)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
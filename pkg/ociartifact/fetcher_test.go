@@ -0,0 +1,182 @@
+package ociartifact
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	testRepository = "example/manifests"
+	testToken      = "test-token"
+)
+
+// testRegistry is a registry serving a single artifact, which requires a bearer token from its token service.
+type testRegistry struct {
+	server    *httptest.Server
+	blobs     map[string][]byte
+	manifest  string
+	requests  int
+	basicAuth string
+}
+
+func newTestRegistry(t *testing.T, layers ...descriptorWithContent) *testRegistry {
+	r := &testRegistry{blobs: map[string][]byte{}}
+	manifest := imageManifest{}
+	for _, layer := range layers {
+		digest := digestOf(layer.content)
+		r.blobs[digest] = layer.content
+		manifest.Layers = append(manifest.Layers, descriptor{MediaType: layer.mediaType, Digest: digest})
+	}
+	content, err := json.Marshal(manifest)
+	require.NoError(t, err, "could not marshal manifest")
+	r.manifest = digestOf(content)
+	r.blobs[r.manifest] = content
+
+	r.server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/token" {
+			r.basicAuth = req.Header.Get("Authorization")
+			assert.Equal(t, "repository:"+testRepository+":pull", req.URL.Query().Get("scope"), "unexpected scope")
+			json.NewEncoder(w).Encode(map[string]string{"token": testToken})
+			return
+		}
+		if req.Header.Get("Authorization") != "Bearer "+testToken {
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="https://%s/token",service="registry"`, req.Host))
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		r.requests++
+		parts := strings.Split(req.URL.Path, "/")
+		content, ok := r.blobs[parts[len(parts)-1]]
+		if !ok || !strings.HasPrefix(req.URL.Path, "/v2/"+testRepository+"/") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write(content)
+	}))
+	t.Cleanup(r.server.Close)
+	return r
+}
+
+func (r *testRegistry) image() string {
+	return fmt.Sprintf("%s/%s@%s", r.server.Listener.Addr().String(), testRepository, r.manifest)
+}
+
+type descriptorWithContent struct {
+	mediaType string
+	content   []byte
+}
+
+func tarGzipLayer(t *testing.T, files map[string]string, names ...string) descriptorWithContent {
+	buf := &bytes.Buffer{}
+	gz := gzip.NewWriter(buf)
+	tw := tar.NewWriter(gz)
+	for _, name := range names {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(files[name])), Typeflag: tar.TypeReg}), "could not write tar header")
+		_, err := tw.Write([]byte(files[name]))
+		require.NoError(t, err, "could not write tar file")
+	}
+	require.NoError(t, tw.Close(), "could not close tar")
+	require.NoError(t, gz.Close(), "could not close gzip")
+	return descriptorWithContent{mediaType: "application/vnd.oci.image.layer.v1.tar+gzip", content: buf.Bytes()}
+}
+
+func TestFetch(t *testing.T) {
+	registry := newTestRegistry(t,
+		descriptorWithContent{
+			mediaType: "application/yaml",
+			content:   []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: a\n---\n---\napiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: b\n"),
+		},
+		tarGzipLayer(t, map[string]string{
+			"manifests/c.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: c\n",
+			"README.md":        "not a manifest",
+			"manifests/d.json": `{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "d"}}`,
+		}, "manifests/c.yaml", "README.md", "manifests/d.json"),
+	)
+	pullSecret := []byte(fmt.Sprintf(`{"auths": {"%s": {"auth": "aGl2ZTpwdw=="}}}`, registry.server.Listener.Addr().String()))
+	fetcher := NewFetcher(registry.server.Client())
+
+	manifests, err := fetcher.Fetch(registry.image(), pullSecret)
+	require.NoError(t, err, "unexpected error fetching artifact")
+	var names []string
+	for _, manifest := range manifests {
+		obj := struct {
+			Metadata struct {
+				Name string `json:"name"`
+			} `json:"metadata"`
+		}{}
+		require.NoError(t, yaml.Unmarshal(manifest, &obj), "could not decode manifest")
+		names = append(names, obj.Metadata.Name)
+	}
+	assert.Equal(t, []string{"a", "b", "c", "d"}, names, "unexpected manifests")
+	assert.Equal(t, "Basic aGl2ZTpwdw==", registry.basicAuth, "expected credentials to be sent to token service")
+
+	requests := registry.requests
+	_, err = fetcher.Fetch(registry.image(), pullSecret)
+	require.NoError(t, err, "unexpected error fetching cached artifact")
+	assert.Equal(t, requests, registry.requests, "expected artifact to be cached")
+
+	_, err = fetcher.Fetch(registry.image(), nil)
+	require.NoError(t, err, "unexpected error fetching artifact without credentials")
+	assert.Greater(t, registry.requests, requests, "expected artifact to be fetched again for different credentials")
+}
+
+func TestFetchDigestMismatch(t *testing.T) {
+	registry := newTestRegistry(t, descriptorWithContent{mediaType: "application/yaml", content: []byte("apiVersion: v1\nkind: ConfigMap\n")})
+	for digest := range registry.blobs {
+		if digest != registry.manifest {
+			registry.blobs[digest] = []byte("apiVersion: v1\nkind: Secret\n")
+		}
+	}
+	_, err := NewFetcher(registry.server.Client()).Fetch(registry.image(), nil)
+	if assert.Error(t, err, "expected tampered layer to be rejected") {
+		assert.Contains(t, err.Error(), "content has digest", "unexpected error")
+	}
+}
+
+func TestParseReference(t *testing.T) {
+	digest := "sha256:" + strings.Repeat("a", 64)
+	cases := []struct {
+		image     string
+		expected  *Reference
+		expectErr bool
+	}{
+		{
+			image:    "quay.io/example/manifests@" + digest,
+			expected: &Reference{Registry: "quay.io", Repository: "example/manifests", Digest: digest},
+		},
+		{
+			image:    "registry.example.com:5000/manifests@" + digest,
+			expected: &Reference{Registry: "registry.example.com:5000", Repository: "manifests", Digest: digest},
+		},
+		{
+			image:     "quay.io/example/manifests:latest",
+			expectErr: true,
+		},
+		{
+			image:     "manifests@" + digest,
+			expectErr: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.image, func(t *testing.T) {
+			ref, err := ParseReference(tc.image)
+			if tc.expectErr {
+				assert.Error(t, err, "expected reference to be rejected")
+				return
+			}
+			require.NoError(t, err, "unexpected error parsing reference")
+			assert.Equal(t, tc.expected, ref, "unexpected reference")
+		})
+	}
+}
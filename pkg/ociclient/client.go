@@ -0,0 +1,355 @@
+package ociclient
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/openshift/hive/pkg/constants"
+)
+
+//go:generate mockgen -source=./client.go -destination=./mock/client_generated.go -package=mock
+
+// Client is a wrapper object for the OCI REST APIs to allow for easier mocking/testing.
+type Client interface {
+	// GetZone gets the DNS zone with the given name or OCID.
+	GetZone(zoneNameOrID string) (*Zone, error)
+
+	// CreateZone creates a primary DNS zone in the given compartment.
+	CreateZone(compartmentID, name string, freeformTags map[string]string) (*Zone, error)
+
+	// UpdateZoneTags replaces the freeform tags on the DNS zone.
+	UpdateZoneTags(zoneNameOrID string, freeformTags map[string]string) (*Zone, error)
+
+	// DeleteZone deletes the DNS zone with the given name or OCID.
+	DeleteZone(zoneNameOrID string) error
+
+	// ListInstances lists the compute instances in the given compartment.
+	ListInstances(compartmentID string) ([]Instance, error)
+
+	// TerminateInstance terminates the compute instance with the given OCID along with its boot volume.
+	TerminateInstance(instanceID string) error
+}
+
+// Zone is the subset of the OCI DNS zone resource used by hive.
+type Zone struct {
+	ID             string            `json:"id"`
+	Name           string            `json:"name"`
+	CompartmentID  string            `json:"compartmentId"`
+	ZoneType       string            `json:"zoneType"`
+	LifecycleState string            `json:"lifecycleState"`
+	FreeformTags   map[string]string `json:"freeformTags,omitempty"`
+	Nameservers    []Nameserver      `json:"nameservers,omitempty"`
+}
+
+// Nameserver is an authoritative name server for an OCI DNS zone.
+type Nameserver struct {
+	Hostname string `json:"hostname"`
+}
+
+// Instance is the subset of the OCI compute instance resource used by hive.
+type Instance struct {
+	ID             string            `json:"id"`
+	DisplayName    string            `json:"displayName"`
+	CompartmentID  string            `json:"compartmentId"`
+	LifecycleState string            `json:"lifecycleState"`
+	FreeformTags   map[string]string `json:"freeformTags,omitempty"`
+}
+
+const (
+	// InstanceStateTerminating is the lifecycle state of an instance being terminated.
+	InstanceStateTerminating = "TERMINATING"
+	// InstanceStateTerminated is the lifecycle state of a terminated instance.
+	InstanceStateTerminated = "TERMINATED"
+
+	dnsAPIVersion     = "20180115"
+	computeAPIVersion = "20160918"
+
+	defaultCallTimeout = 2 * time.Minute
+)
+
+// Error is returned for any non-2xx response from the OCI APIs.
+type Error struct {
+	StatusCode int
+	Code       string `json:"code"`
+	Message    string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("OCI API error (%d %s): %s", e.StatusCode, e.Code, e.Message)
+}
+
+// IsNotFound returns true if the error is an OCI API error indicating the resource does not exist.
+func IsNotFound(err error) bool {
+	ociErr, ok := errors.Cause(err).(*Error)
+	return ok && ociErr.StatusCode == http.StatusNotFound
+}
+
+// Credentials are the API signing key credentials used to authenticate with OCI.
+type Credentials struct {
+	Tenancy     string
+	User        string
+	Fingerprint string
+	PrivateKey  []byte
+	Passphrase  []byte
+}
+
+type ociClient struct {
+	region     string
+	keyID      string
+	key        *rsa.PrivateKey
+	httpClient *http.Client
+}
+
+// NewClient creates our client wrapper object for interacting with OCI in the given region.
+func NewClient(creds Credentials, region string) (Client, error) {
+	if region == "" {
+		return nil, errors.New("region is required")
+	}
+	for name, value := range map[string]string{
+		constants.OCITenancySecretKey:     creds.Tenancy,
+		constants.OCIUserSecretKey:        creds.User,
+		constants.OCIFingerprintSecretKey: creds.Fingerprint,
+	} {
+		if value == "" {
+			return nil, fmt.Errorf("credentials are missing %s", name)
+		}
+	}
+	key, err := parsePrivateKey(creds.PrivateKey, creds.Passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return &ociClient{
+		region:     region,
+		keyID:      strings.Join([]string{creds.Tenancy, creds.User, creds.Fingerprint}, "/"),
+		key:        key,
+		httpClient: &http.Client{},
+	}, nil
+}
+
+// NewClientFromSecret creates our client wrapper object for interacting with OCI. The OCI creds are read from the
+// specified secret.
+func NewClientFromSecret(secret *corev1.Secret, region string) (Client, error) {
+	creds, err := CredentialsFromSecret(secret)
+	if err != nil {
+		return nil, err
+	}
+	return NewClient(creds, region)
+}
+
+// CredentialsFromSecret reads the OCI API signing key credentials from the specified secret.
+func CredentialsFromSecret(secret *corev1.Secret) (Credentials, error) {
+	creds := Credentials{
+		Tenancy:     string(secret.Data[constants.OCITenancySecretKey]),
+		User:        string(secret.Data[constants.OCIUserSecretKey]),
+		Fingerprint: string(secret.Data[constants.OCIFingerprintSecretKey]),
+		PrivateKey:  secret.Data[constants.OCIPrivateKeySecretKey],
+		Passphrase:  secret.Data[constants.OCIPassphraseSecretKey],
+	}
+	if len(creds.PrivateKey) == 0 {
+		return creds, fmt.Errorf("secret %s is missing %s", secret.Name, constants.OCIPrivateKeySecretKey)
+	}
+	return creds, nil
+}
+
+func parsePrivateKey(keyPEM, passphrase []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(keyPEM)
+	if block == nil {
+		return nil, errors.New("private key is not PEM encoded")
+	}
+	der := block.Bytes
+	//nolint:staticcheck // OCI still issues legacy encrypted PEM keys
+	if x509.IsEncryptedPEMBlock(block) {
+		var err error
+		//nolint:staticcheck
+		der, err = x509.DecryptPEMBlock(block, passphrase)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not decrypt private key")
+		}
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(der); err == nil {
+		return key, nil
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not parse private key")
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("private key is not an RSA key")
+	}
+	return key, nil
+}
+
+func (c *ociClient) dnsEndpoint() string {
+	return fmt.Sprintf("https://dns.%s.oraclecloud.com/%s", c.region, dnsAPIVersion)
+}
+
+func (c *ociClient) computeEndpoint() string {
+	return fmt.Sprintf("https://iaas.%s.oraclecloud.com/%s", c.region, computeAPIVersion)
+}
+
+func (c *ociClient) GetZone(zoneNameOrID string) (*Zone, error) {
+	zone := &Zone{}
+	err := c.do(http.MethodGet, c.dnsEndpoint()+"/zones/"+url.PathEscape(zoneNameOrID), nil, zone)
+	return zone, err
+}
+
+func (c *ociClient) CreateZone(compartmentID, name string, freeformTags map[string]string) (*Zone, error) {
+	zone := &Zone{}
+	err := c.do(http.MethodPost, c.dnsEndpoint()+"/zones", &Zone{
+		Name:          name,
+		CompartmentID: compartmentID,
+		ZoneType:      "PRIMARY",
+		FreeformTags:  freeformTags,
+	}, zone)
+	return zone, err
+}
+
+func (c *ociClient) UpdateZoneTags(zoneNameOrID string, freeformTags map[string]string) (*Zone, error) {
+	zone := &Zone{}
+	err := c.do(http.MethodPut, c.dnsEndpoint()+"/zones/"+url.PathEscape(zoneNameOrID), map[string]interface{}{
+		"freeformTags": freeformTags,
+	}, zone)
+	return zone, err
+}
+
+func (c *ociClient) DeleteZone(zoneNameOrID string) error {
+	return c.do(http.MethodDelete, c.dnsEndpoint()+"/zones/"+url.PathEscape(zoneNameOrID), nil, nil)
+}
+
+func (c *ociClient) ListInstances(compartmentID string) ([]Instance, error) {
+	instances := []Instance{}
+	err := c.paginate(c.computeEndpoint()+"/instances", url.Values{"compartmentId": []string{compartmentID}}, func(body []byte) error {
+		page := []Instance{}
+		if err := json.Unmarshal(body, &page); err != nil {
+			return err
+		}
+		instances = append(instances, page...)
+		return nil
+	})
+	return instances, err
+}
+
+func (c *ociClient) TerminateInstance(instanceID string) error {
+	return c.do(http.MethodDelete, c.computeEndpoint()+"/instances/"+url.PathEscape(instanceID)+"?preserveBootVolume=false", nil, nil)
+}
+
+func (c *ociClient) paginate(endpoint string, query url.Values, handle func([]byte) error) error {
+	for {
+		resp, body, err := c.send(http.MethodGet, endpoint+"?"+query.Encode(), nil)
+		if err != nil {
+			return err
+		}
+		if err := handle(body); err != nil {
+			return errors.Wrap(err, "could not decode response")
+		}
+		next := resp.Header.Get("opc-next-page")
+		if next == "" {
+			return nil
+		}
+		query.Set("page", next)
+	}
+}
+
+func (c *ociClient) do(method, endpoint string, in, out interface{}) error {
+	var payload []byte
+	if in != nil {
+		var err error
+		if payload, err = json.Marshal(in); err != nil {
+			return err
+		}
+	}
+	_, body, err := c.send(method, endpoint, payload)
+	if err != nil {
+		return err
+	}
+	if out != nil && len(body) > 0 {
+		return errors.Wrap(json.Unmarshal(body, out), "could not decode response")
+	}
+	return nil
+}
+
+func (c *ociClient) send(method, endpoint string, payload []byte) (*http.Response, []byte, error) {
+	ctx, cancel := context.WithTimeout(context.TODO(), defaultCallTimeout)
+	defer cancel()
+
+	var reader io.Reader
+	if payload != nil {
+		reader = bytes.NewReader(payload)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := c.sign(req, payload); err != nil {
+		return nil, nil, err
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return resp, nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		apiErr := &Error{StatusCode: resp.StatusCode}
+		json.Unmarshal(body, apiErr)
+		return resp, body, apiErr
+	}
+	return resp, body, nil
+}
+
+// sign adds the OCI HTTP signature (draft-cavage-http-signatures) authorization header to the request.
+func (c *ociClient) sign(req *http.Request, payload []byte) error {
+	req.Header.Set("date", time.Now().UTC().Format(http.TimeFormat))
+	req.Header.Set("host", req.URL.Host)
+	headers := []string{"date", "(request-target)", "host"}
+	if req.Method == http.MethodPost || req.Method == http.MethodPut {
+		sum := sha256.Sum256(payload)
+		req.Header.Set("content-type", "application/json")
+		req.Header.Set("content-length", fmt.Sprint(len(payload)))
+		req.Header.Set("x-content-sha256", base64.StdEncoding.EncodeToString(sum[:]))
+		headers = append(headers, "content-length", "content-type", "x-content-sha256")
+	}
+
+	lines := make([]string, len(headers))
+	for i, h := range headers {
+		if h == "(request-target)" {
+			lines[i] = fmt.Sprintf("%s: %s %s", h, strings.ToLower(req.Method), req.URL.RequestURI())
+			continue
+		}
+		lines[i] = fmt.Sprintf("%s: %s", h, req.Header.Get(h))
+	}
+	digest := sha256.Sum256([]byte(strings.Join(lines, "\n")))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, c.key, crypto.SHA256, digest[:])
+	if err != nil {
+		return errors.Wrap(err, "could not sign request")
+	}
+	req.Header.Set("authorization", fmt.Sprintf(
+		`Signature version="1",keyId="%s",algorithm="rsa-sha256",headers="%s",signature="%s"`,
+		c.keyID,
+		strings.Join(headers, " "),
+		base64.StdEncoding.EncodeToString(signature),
+	))
+	return nil
+}
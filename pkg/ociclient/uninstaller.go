@@ -0,0 +1,70 @@
+package ociclient
+
+import (
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// ClusterUninstaller removes the OCI resources created by the installer for a cluster. The installer names every
+// instance it creates with the cluster infra ID as a prefix, which is what we key off of here.
+type ClusterUninstaller struct {
+	Client        Client
+	CompartmentID string
+	InfraID       string
+	Logger        log.FieldLogger
+
+	// PollInterval is how often to check whether terminated instances are gone. Defaults to 10 seconds.
+	PollInterval time.Duration
+	// Timeout is how long to wait for all instances to be terminated. Defaults to 10 minutes.
+	Timeout time.Duration
+}
+
+// Run terminates all cluster instances and waits for them to be gone.
+func (o *ClusterUninstaller) Run() error {
+	if o.InfraID == "" {
+		return errors.New("infra ID is required")
+	}
+	interval, timeout := o.PollInterval, o.Timeout
+	if interval == 0 {
+		interval = 10 * time.Second
+	}
+	if timeout == 0 {
+		timeout = 10 * time.Minute
+	}
+	return wait.PollImmediate(interval, timeout, func() (bool, error) {
+		instances, err := o.Client.ListInstances(o.CompartmentID)
+		if err != nil {
+			o.Logger.WithError(err).Warn("failed to list instances")
+			return false, nil
+		}
+		remaining := 0
+		for _, instance := range instances {
+			if !strings.HasPrefix(instance.DisplayName, o.InfraID+"-") {
+				continue
+			}
+			switch instance.LifecycleState {
+			case InstanceStateTerminated:
+				continue
+			case InstanceStateTerminating:
+				remaining++
+				continue
+			}
+			remaining++
+			logger := o.Logger.WithField("instance", instance.DisplayName)
+			logger.Info("terminating instance")
+			if err := o.Client.TerminateInstance(instance.ID); err != nil && !IsNotFound(err) {
+				logger.WithError(err).Warn("failed to terminate instance")
+			}
+		}
+		if remaining > 0 {
+			o.Logger.WithField("remaining", remaining).Info("waiting for instances to terminate")
+			return false, nil
+		}
+		o.Logger.Info("all cluster instances terminated")
+		return true, nil
+	})
+}
@@ -0,0 +1,137 @@
+package openstackclient
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
+	"github.com/gophercloud/gophercloud/pagination"
+	"github.com/gophercloud/utils/openstack/clientconfig"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/openshift/hive/pkg/constants"
+)
+
+//go:generate mockgen -source=./client.go -destination=./mock/client_generated.go -package=mock
+
+// Client is a wrapper object for the OpenStack compute API to allow for easier mocking/testing.
+type Client interface {
+	// ListServers lists the servers whose names match the given regular expression.
+	ListServers(nameRegex string) ([]Server, error)
+
+	// StartServer starts the stopped server with the given ID.
+	StartServer(serverID string) error
+
+	// StopServer stops the running server with the given ID.
+	StopServer(serverID string) error
+}
+
+// Server is the subset of the OpenStack compute server resource used by hive.
+type Server struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	// TaskState is the task the server is running, such as powering-off, which does not change its status until done.
+	TaskState string `json:"OS-EXT-STS:task_state"`
+}
+
+type openstackClient struct {
+	computeClient *gophercloud.ServiceClient
+}
+
+func (c *openstackClient) ListServers(nameRegex string) ([]Server, error) {
+	var result []Server
+	err := servers.List(c.computeClient, servers.ListOpts{Name: nameRegex}).EachPage(func(page pagination.Page) (bool, error) {
+		var list []Server
+		if err := servers.ExtractServersInto(page, &list); err != nil {
+			return false, err
+		}
+		result = append(result, list...)
+		return true, nil
+	})
+	return result, err
+}
+
+func (c *openstackClient) StartServer(serverID string) error {
+	return c.serverAction(serverID, "os-start")
+}
+
+func (c *openstackClient) StopServer(serverID string) error {
+	return c.serverAction(serverID, "os-stop")
+}
+
+// serverAction runs the action of the start/stop extension of the compute API on the server.
+func (c *openstackClient) serverAction(serverID, action string) error {
+	_, err := c.computeClient.Post(
+		c.computeClient.ServiceURL("servers", serverID, "action"),
+		map[string]interface{}{action: nil},
+		nil,
+		&gophercloud.RequestOpts{OkCodes: []int{202}},
+	)
+	return err
+}
+
+// NewClientFromSecret creates our client wrapper object for interacting with OpenStack. The credentials are read from
+// the clouds.yaml in the specified secret, for the given cloud. When a trust bundle is given, it is used in place of
+// the CA certificate file named in the clouds.yaml, which is a path in the cluster rather than in hive.
+func NewClientFromSecret(secret *corev1.Secret, cloud string, trustBundle []byte) (Client, error) {
+	cloudsYAML, ok := secret.Data[constants.OpenStackCredentialsName]
+	if !ok {
+		return nil, errors.New("did not find credentials in the OpenStack credentials secret")
+	}
+	var clouds clientconfig.Clouds
+	if err := yaml.Unmarshal(cloudsYAML, &clouds); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal yaml stored in secret")
+	}
+	conf, ok := clouds.Clouds[cloud]
+	if !ok {
+		return nil, errors.Errorf("no cloud %s found", cloud)
+	}
+
+	if len(trustBundle) > 0 {
+		// The compute client reads the CA certificates when it is created, so the file is only needed until then.
+		caFile, err := ioutil.TempFile("", "openstack-ca")
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create trust bundle file")
+		}
+		defer os.Remove(caFile.Name())
+		_, err = caFile.Write(trustBundle)
+		if closeErr := caFile.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to write trust bundle file")
+		}
+		conf.CACertFile = caFile.Name()
+	}
+	clouds.Clouds[cloud] = conf
+
+	computeClient, err := clientconfig.NewServiceClient("compute", &clientconfig.ClientOpts{
+		Cloud:    cloud,
+		YAMLOpts: yamlOpts(clouds.Clouds),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create OpenStack compute client")
+	}
+	return &openstackClient{computeClient: computeClient}, nil
+}
+
+// yamlOpts provides the clouds.yaml read from the credentials secret in place of the one on disk.
+type yamlOpts map[string]clientconfig.Cloud
+
+func (opts yamlOpts) LoadCloudsYAML() (map[string]clientconfig.Cloud, error) {
+	return opts, nil
+}
+
+func (opts yamlOpts) LoadSecureCloudsYAML() (map[string]clientconfig.Cloud, error) {
+	// secure.yaml is optional so just pretend it doesn't exist
+	return nil, nil
+}
+
+func (opts yamlOpts) LoadPublicCloudsYAML() (map[string]clientconfig.Cloud, error) {
+	return nil, fmt.Errorf("LoadPublicCloudsYAML() not implemented")
+}
@@ -3,6 +3,10 @@
 // config/clustersync/service.yaml
 // config/clustersync/statefulset.yaml
 // config/hiveadmission/apiservice.yaml
+// config/hiveadmission/basedomainpool-webhook.yaml
+// config/hiveadmission/clusterclaim-mutating-webhook.yaml
+// config/hiveadmission/clusterclaim-webhook.yaml
+// config/hiveadmission/clusterdeployment-mutating-webhook.yaml
 // config/hiveadmission/clusterdeployment-webhook.yaml
 // config/hiveadmission/clusterimageset-webhook.yaml
 // config/hiveadmission/clusterprovision-webhook.yaml
@@ -252,6 +256,172 @@ func configHiveadmissionApiserviceYaml() (*asset, error) {
 	return a, nil
 }
 
+var _configHiveadmissionBasedomainpoolWebhookYaml = []byte(`---
+apiVersion: admissionregistration.k8s.io/v1beta1
+kind: ValidatingWebhookConfiguration
+metadata:
+  name: basedomainpoolvalidators.admission.hive.openshift.io
+webhooks:
+- name: basedomainpoolvalidators.admission.hive.openshift.io
+  clientConfig:
+    service:
+      # reach the webhook via the registered aggregated API
+      namespace: default
+      name: kubernetes
+      path: /apis/admission.hive.openshift.io/v1/basedomainpoolvalidators
+  rules:
+  - operations:
+    - CREATE
+    - UPDATE
+    apiGroups:
+    - hive.openshift.io
+    apiVersions:
+    - v1
+    resources:
+    - basedomainpools
+  failurePolicy: Fail
+  sideEffects: None
+`)
+
+func configHiveadmissionBasedomainpoolWebhookYamlBytes() ([]byte, error) {
+	return _configHiveadmissionBasedomainpoolWebhookYaml, nil
+}
+
+func configHiveadmissionBasedomainpoolWebhookYaml() (*asset, error) {
+	bytes, err := configHiveadmissionBasedomainpoolWebhookYamlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "config/hiveadmission/basedomainpool-webhook.yaml", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _configHiveadmissionClusterclaimMutatingWebhookYaml = []byte(`---
+apiVersion: admissionregistration.k8s.io/v1beta1
+kind: MutatingWebhookConfiguration
+metadata:
+  name: clusterclaimmutators.admission.hive.openshift.io
+webhooks:
+- name: clusterclaimmutators.admission.hive.openshift.io
+  clientConfig:
+    service:
+      # reach the webhook via the registered aggregated API
+      namespace: default
+      name: kubernetes
+      path: /apis/admission.hive.openshift.io/v1/clusterclaimmutators
+  rules:
+  - operations:
+    - CREATE
+    apiGroups:
+    - hive.openshift.io
+    apiVersions:
+    - v1
+    resources:
+    - clusterclaims
+  failurePolicy: Fail
+  sideEffects: None
+`)
+
+func configHiveadmissionClusterclaimMutatingWebhookYamlBytes() ([]byte, error) {
+	return _configHiveadmissionClusterclaimMutatingWebhookYaml, nil
+}
+
+func configHiveadmissionClusterclaimMutatingWebhookYaml() (*asset, error) {
+	bytes, err := configHiveadmissionClusterclaimMutatingWebhookYamlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "config/hiveadmission/clusterclaim-mutating-webhook.yaml", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _configHiveadmissionClusterclaimWebhookYaml = []byte(`---
+apiVersion: admissionregistration.k8s.io/v1beta1
+kind: ValidatingWebhookConfiguration
+metadata:
+  name: clusterclaimvalidators.admission.hive.openshift.io
+webhooks:
+- name: clusterclaimvalidators.admission.hive.openshift.io
+  clientConfig:
+    service:
+      # reach the webhook via the registered aggregated API
+      namespace: default
+      name: kubernetes
+      path: /apis/admission.hive.openshift.io/v1/clusterclaimvalidators
+  rules:
+  - operations:
+    - CREATE
+    - UPDATE
+    apiGroups:
+    - hive.openshift.io
+    apiVersions:
+    - v1
+    resources:
+    - clusterclaims
+  failurePolicy: Fail
+  sideEffects: None
+`)
+
+func configHiveadmissionClusterclaimWebhookYamlBytes() ([]byte, error) {
+	return _configHiveadmissionClusterclaimWebhookYaml, nil
+}
+
+func configHiveadmissionClusterclaimWebhookYaml() (*asset, error) {
+	bytes, err := configHiveadmissionClusterclaimWebhookYamlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "config/hiveadmission/clusterclaim-webhook.yaml", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _configHiveadmissionClusterdeploymentMutatingWebhookYaml = []byte(`---
+apiVersion: admissionregistration.k8s.io/v1beta1
+kind: MutatingWebhookConfiguration
+metadata:
+  name: clusterdeploymentmutators.admission.hive.openshift.io
+webhooks:
+- name: clusterdeploymentmutators.admission.hive.openshift.io
+  clientConfig:
+    service:
+      # reach the webhook via the registered aggregated API
+      namespace: default
+      name: kubernetes
+      path: /apis/admission.hive.openshift.io/v1/clusterdeploymentmutators
+  rules:
+  - operations:
+    - CREATE
+    apiGroups:
+    - hive.openshift.io
+    apiVersions:
+    - v1
+    resources:
+    - clusterdeployments
+  failurePolicy: Fail
+  sideEffects: None
+`)
+
+func configHiveadmissionClusterdeploymentMutatingWebhookYamlBytes() ([]byte, error) {
+	return _configHiveadmissionClusterdeploymentMutatingWebhookYaml, nil
+}
+
+func configHiveadmissionClusterdeploymentMutatingWebhookYaml() (*asset, error) {
+	bytes, err := configHiveadmissionClusterdeploymentMutatingWebhookYamlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "config/hiveadmission/clusterdeployment-mutating-webhook.yaml", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 var _configHiveadmissionClusterdeploymentWebhookYaml = []byte(`---
 apiVersion: admissionregistration.k8s.io/v1beta1
 kind: ValidatingWebhookConfiguration
@@ -277,6 +447,7 @@ webhooks:
     resources:
     - clusterdeployments
   failurePolicy: Fail
+  sideEffects: None
 `)
 
 func configHiveadmissionClusterdeploymentWebhookYamlBytes() ([]byte, error) {
@@ -311,6 +482,7 @@ webhooks:
   - operations:
     - CREATE
     - UPDATE
+    - DELETE
     apiGroups:
     - hive.openshift.io
     apiVersions:
@@ -318,6 +490,7 @@ webhooks:
     resources:
     - clusterimagesets
   failurePolicy: Fail
+  sideEffects: None
 `)
 
 func configHiveadmissionClusterimagesetWebhookYamlBytes() ([]byte, error) {
@@ -359,6 +532,7 @@ webhooks:
     resources:
     - clusterprovisions
   failurePolicy: Fail
+  sideEffects: None
 `)
 
 func configHiveadmissionClusterprovisionWebhookYamlBytes() ([]byte, error) {
@@ -476,6 +650,7 @@ webhooks:
     resources:
     - dnszones
   failurePolicy: Fail
+  sideEffects: None
 `)
 
 func configHiveadmissionDnszonesWebhookYamlBytes() ([]byte, error) {
@@ -515,6 +690,12 @@ rules:
   - get
   - list
   - watch
+- apiGroups:
+  - ""
+  resources:
+  - secrets
+  verbs:
+  - get
 - apiGroups:
   - admissionregistration.k8s.io
   resources:
@@ -532,6 +713,17 @@ rules:
   - get
   - list
   - watch
+- apiGroups:
+  - hive.openshift.io
+  resources:
+  - clusterclaims
+  - clusterdeployments
+  - clusterpools
+  - hivenamespaceconfigs
+  verbs:
+  - get
+  - list
+  - watch
 - apiGroups:
   - authorization.k8s.io
   resources:
@@ -609,6 +801,7 @@ webhooks:
     resources:
     - machinepools
   failurePolicy: Fail
+  sideEffects: None
 `)
 
 func configHiveadmissionMachinepoolWebhookYamlBytes() ([]byte, error) {
@@ -650,6 +843,7 @@ webhooks:
     resources:
     - selectorsyncsets
   failurePolicy: Fail
+  sideEffects: None
 `)
 
 func configHiveadmissionSelectorsyncsetWebhookYamlBytes() ([]byte, error) {
@@ -746,6 +940,7 @@ webhooks:
     resources:
     - syncsets
   failurePolicy: Fail
+  sideEffects: None
 `)
 
 func configHiveadmissionSyncsetWebhookYamlBytes() ([]byte, error) {
@@ -895,6 +1090,17 @@ rules:
   - get
   - list
   - watch
+- apiGroups:
+  - apps
+  resources:
+  - daemonsets
+  verbs:
+  - get
+  - list
+  - watch
+  - create
+  - update
+  - delete
 - apiGroups:
   - ""
   resources:
@@ -904,6 +1110,36 @@ rules:
   - get
   - list
   - watch
+- apiGroups:
+  - ""
+  resources:
+  - nodes
+  verbs:
+  - get
+  - list
+  - watch
+- apiGroups:
+  - networking.k8s.io
+  resources:
+  - networkpolicies
+  verbs:
+  - get
+  - list
+  - watch
+  - create
+  - update
+  - delete
+- apiGroups:
+  - k8s.ovn.org
+  resources:
+  - egressfirewalls
+  verbs:
+  - get
+  - list
+  - watch
+  - create
+  - update
+  - delete
 - apiGroups:
   - rbac.authorization.k8s.io
   resources:
@@ -923,6 +1159,36 @@ rules:
   - backups
   verbs:
   - create
+- apiGroups:
+  - cert-manager.io
+  resources:
+  - certificates
+  verbs:
+  - get
+  - list
+  - watch
+  - create
+  - update
+  - patch
+  - delete
+- apiGroups:
+  - authentication.k8s.io
+  resources:
+  - tokenreviews
+  verbs:
+  - create
+- apiGroups:
+  - authorization.k8s.io
+  resources:
+  - subjectaccessreviews
+  verbs:
+  - create
+- apiGroups:
+  - "*"
+  resources:
+  - "*"
+  verbs:
+  - list
 `)
 
 func configControllersHive_controllers_roleYamlBytes() ([]byte, error) {
@@ -1070,6 +1336,7 @@ rules:
   # TODO: remove once v1alpha1 compat removed
   - clusterdeprovisionrequests
   - clusterstates
+  - clusterinventories
   verbs:
   - get
   - list
@@ -1077,8 +1344,12 @@ rules:
 - apiGroups:
   - hive.openshift.io
   resources:
+  - basedomainpools
+  - changefreezes
   - clusterimagesets
+  - gitsyncsources
   - hiveconfigs
+  - hivenamespaceconfigs
   - selectorsyncsets
   - selectorsyncidentityproviders
   verbs:
@@ -1229,6 +1500,7 @@ rules:
   resources:
   - clusterpools
   - clusterclaims
+  - clusterdeploymentcustomizations
   verbs:
   - get
   - list
@@ -1310,6 +1582,8 @@ rules:
   # TODO: remove once v1alpha1 compat removed
   - clusterdeprovisionrequests
   - clusterstates
+  - clusterinventories
+  - hivenamespaceconfigs
   verbs:
   - get
   - list
@@ -1321,7 +1595,10 @@ rules:
 - apiGroups:
   - hive.openshift.io
   resources:
+  - basedomainpools
+  - changefreezes
   - clusterimagesets
+  - gitsyncsources
   - hiveconfigs
   verbs:
   - get
@@ -1447,6 +1724,8 @@ rules:
   # TODO: remove once v1alpha1 compat removed
   - clusterdeprovisionrequests
   - clusterstates
+  - clusterinventories
+  - hivenamespaceconfigs
   verbs:
   - get
   - list
@@ -1454,7 +1733,10 @@ rules:
 - apiGroups:
   - hive.openshift.io
   resources:
+  - basedomainpools
+  - changefreezes
   - clusterimagesets
+  - gitsyncsources
   - hiveconfigs
   verbs:
   - get
@@ -1714,35 +1996,39 @@ func AssetNames() []string {
 
 // _bindata is a table, holding each asset generator, mapped to its name.
 var _bindata = map[string]func() (*asset, error){
-	"config/clustersync/service.yaml":                           configClustersyncServiceYaml,
-	"config/clustersync/statefulset.yaml":                       configClustersyncStatefulsetYaml,
-	"config/hiveadmission/apiservice.yaml":                      configHiveadmissionApiserviceYaml,
-	"config/hiveadmission/clusterdeployment-webhook.yaml":       configHiveadmissionClusterdeploymentWebhookYaml,
-	"config/hiveadmission/clusterimageset-webhook.yaml":         configHiveadmissionClusterimagesetWebhookYaml,
-	"config/hiveadmission/clusterprovision-webhook.yaml":        configHiveadmissionClusterprovisionWebhookYaml,
-	"config/hiveadmission/deployment.yaml":                      configHiveadmissionDeploymentYaml,
-	"config/hiveadmission/dnszones-webhook.yaml":                configHiveadmissionDnszonesWebhookYaml,
-	"config/hiveadmission/hiveadmission_rbac_role.yaml":         configHiveadmissionHiveadmission_rbac_roleYaml,
-	"config/hiveadmission/hiveadmission_rbac_role_binding.yaml": configHiveadmissionHiveadmission_rbac_role_bindingYaml,
-	"config/hiveadmission/machinepool-webhook.yaml":             configHiveadmissionMachinepoolWebhookYaml,
-	"config/hiveadmission/selectorsyncset-webhook.yaml":         configHiveadmissionSelectorsyncsetWebhookYaml,
-	"config/hiveadmission/service-account.yaml":                 configHiveadmissionServiceAccountYaml,
-	"config/hiveadmission/service.yaml":                         configHiveadmissionServiceYaml,
-	"config/hiveadmission/syncset-webhook.yaml":                 configHiveadmissionSyncsetWebhookYaml,
-	"config/controllers/deployment.yaml":                        configControllersDeploymentYaml,
-	"config/controllers/hive_controllers_role.yaml":             configControllersHive_controllers_roleYaml,
-	"config/controllers/hive_controllers_role_binding.yaml":     configControllersHive_controllers_role_bindingYaml,
-	"config/controllers/hive_controllers_serviceaccount.yaml":   configControllersHive_controllers_serviceaccountYaml,
-	"config/controllers/service.yaml":                           configControllersServiceYaml,
-	"config/rbac/hive_admin_role.yaml":                          configRbacHive_admin_roleYaml,
-	"config/rbac/hive_admin_role_binding.yaml":                  configRbacHive_admin_role_bindingYaml,
-	"config/rbac/hive_clusterpool_admin.yaml":                   configRbacHive_clusterpool_adminYaml,
-	"config/rbac/hive_frontend_role.yaml":                       configRbacHive_frontend_roleYaml,
-	"config/rbac/hive_frontend_role_binding.yaml":               configRbacHive_frontend_role_bindingYaml,
-	"config/rbac/hive_frontend_serviceaccount.yaml":             configRbacHive_frontend_serviceaccountYaml,
-	"config/rbac/hive_reader_role.yaml":                         configRbacHive_reader_roleYaml,
-	"config/rbac/hive_reader_role_binding.yaml":                 configRbacHive_reader_role_bindingYaml,
-	"config/configmaps/install-log-regexes-configmap.yaml":      configConfigmapsInstallLogRegexesConfigmapYaml,
+	"config/clustersync/service.yaml":                              configClustersyncServiceYaml,
+	"config/clustersync/statefulset.yaml":                          configClustersyncStatefulsetYaml,
+	"config/hiveadmission/apiservice.yaml":                         configHiveadmissionApiserviceYaml,
+	"config/hiveadmission/basedomainpool-webhook.yaml":             configHiveadmissionBasedomainpoolWebhookYaml,
+	"config/hiveadmission/clusterclaim-mutating-webhook.yaml":      configHiveadmissionClusterclaimMutatingWebhookYaml,
+	"config/hiveadmission/clusterclaim-webhook.yaml":               configHiveadmissionClusterclaimWebhookYaml,
+	"config/hiveadmission/clusterdeployment-mutating-webhook.yaml": configHiveadmissionClusterdeploymentMutatingWebhookYaml,
+	"config/hiveadmission/clusterdeployment-webhook.yaml":          configHiveadmissionClusterdeploymentWebhookYaml,
+	"config/hiveadmission/clusterimageset-webhook.yaml":            configHiveadmissionClusterimagesetWebhookYaml,
+	"config/hiveadmission/clusterprovision-webhook.yaml":           configHiveadmissionClusterprovisionWebhookYaml,
+	"config/hiveadmission/deployment.yaml":                         configHiveadmissionDeploymentYaml,
+	"config/hiveadmission/dnszones-webhook.yaml":                   configHiveadmissionDnszonesWebhookYaml,
+	"config/hiveadmission/hiveadmission_rbac_role.yaml":            configHiveadmissionHiveadmission_rbac_roleYaml,
+	"config/hiveadmission/hiveadmission_rbac_role_binding.yaml":    configHiveadmissionHiveadmission_rbac_role_bindingYaml,
+	"config/hiveadmission/machinepool-webhook.yaml":                configHiveadmissionMachinepoolWebhookYaml,
+	"config/hiveadmission/selectorsyncset-webhook.yaml":            configHiveadmissionSelectorsyncsetWebhookYaml,
+	"config/hiveadmission/service-account.yaml":                    configHiveadmissionServiceAccountYaml,
+	"config/hiveadmission/service.yaml":                            configHiveadmissionServiceYaml,
+	"config/hiveadmission/syncset-webhook.yaml":                    configHiveadmissionSyncsetWebhookYaml,
+	"config/controllers/deployment.yaml":                           configControllersDeploymentYaml,
+	"config/controllers/hive_controllers_role.yaml":                configControllersHive_controllers_roleYaml,
+	"config/controllers/hive_controllers_role_binding.yaml":        configControllersHive_controllers_role_bindingYaml,
+	"config/controllers/hive_controllers_serviceaccount.yaml":      configControllersHive_controllers_serviceaccountYaml,
+	"config/controllers/service.yaml":                              configControllersServiceYaml,
+	"config/rbac/hive_admin_role.yaml":                             configRbacHive_admin_roleYaml,
+	"config/rbac/hive_admin_role_binding.yaml":                     configRbacHive_admin_role_bindingYaml,
+	"config/rbac/hive_clusterpool_admin.yaml":                      configRbacHive_clusterpool_adminYaml,
+	"config/rbac/hive_frontend_role.yaml":                          configRbacHive_frontend_roleYaml,
+	"config/rbac/hive_frontend_role_binding.yaml":                  configRbacHive_frontend_role_bindingYaml,
+	"config/rbac/hive_frontend_serviceaccount.yaml":                configRbacHive_frontend_serviceaccountYaml,
+	"config/rbac/hive_reader_role.yaml":                            configRbacHive_reader_roleYaml,
+	"config/rbac/hive_reader_role_binding.yaml":                    configRbacHive_reader_role_bindingYaml,
+	"config/configmaps/install-log-regexes-configmap.yaml":         configConfigmapsInstallLogRegexesConfigmapYaml,
 }
 
 // AssetDir returns the file names below a certain
@@ -1802,19 +2088,23 @@ var _bintree = &bintree{nil, map[string]*bintree{
 			"service.yaml":                         {configControllersServiceYaml, map[string]*bintree{}},
 		}},
 		"hiveadmission": {nil, map[string]*bintree{
-			"apiservice.yaml":                      {configHiveadmissionApiserviceYaml, map[string]*bintree{}},
-			"clusterdeployment-webhook.yaml":       {configHiveadmissionClusterdeploymentWebhookYaml, map[string]*bintree{}},
-			"clusterimageset-webhook.yaml":         {configHiveadmissionClusterimagesetWebhookYaml, map[string]*bintree{}},
-			"clusterprovision-webhook.yaml":        {configHiveadmissionClusterprovisionWebhookYaml, map[string]*bintree{}},
-			"deployment.yaml":                      {configHiveadmissionDeploymentYaml, map[string]*bintree{}},
-			"dnszones-webhook.yaml":                {configHiveadmissionDnszonesWebhookYaml, map[string]*bintree{}},
-			"hiveadmission_rbac_role.yaml":         {configHiveadmissionHiveadmission_rbac_roleYaml, map[string]*bintree{}},
-			"hiveadmission_rbac_role_binding.yaml": {configHiveadmissionHiveadmission_rbac_role_bindingYaml, map[string]*bintree{}},
-			"machinepool-webhook.yaml":             {configHiveadmissionMachinepoolWebhookYaml, map[string]*bintree{}},
-			"selectorsyncset-webhook.yaml":         {configHiveadmissionSelectorsyncsetWebhookYaml, map[string]*bintree{}},
-			"service-account.yaml":                 {configHiveadmissionServiceAccountYaml, map[string]*bintree{}},
-			"service.yaml":                         {configHiveadmissionServiceYaml, map[string]*bintree{}},
-			"syncset-webhook.yaml":                 {configHiveadmissionSyncsetWebhookYaml, map[string]*bintree{}},
+			"apiservice.yaml":                         {configHiveadmissionApiserviceYaml, map[string]*bintree{}},
+			"basedomainpool-webhook.yaml":             {configHiveadmissionBasedomainpoolWebhookYaml, map[string]*bintree{}},
+			"clusterclaim-mutating-webhook.yaml":      {configHiveadmissionClusterclaimMutatingWebhookYaml, map[string]*bintree{}},
+			"clusterclaim-webhook.yaml":               {configHiveadmissionClusterclaimWebhookYaml, map[string]*bintree{}},
+			"clusterdeployment-mutating-webhook.yaml": {configHiveadmissionClusterdeploymentMutatingWebhookYaml, map[string]*bintree{}},
+			"clusterdeployment-webhook.yaml":          {configHiveadmissionClusterdeploymentWebhookYaml, map[string]*bintree{}},
+			"clusterimageset-webhook.yaml":            {configHiveadmissionClusterimagesetWebhookYaml, map[string]*bintree{}},
+			"clusterprovision-webhook.yaml":           {configHiveadmissionClusterprovisionWebhookYaml, map[string]*bintree{}},
+			"deployment.yaml":                         {configHiveadmissionDeploymentYaml, map[string]*bintree{}},
+			"dnszones-webhook.yaml":                   {configHiveadmissionDnszonesWebhookYaml, map[string]*bintree{}},
+			"hiveadmission_rbac_role.yaml":            {configHiveadmissionHiveadmission_rbac_roleYaml, map[string]*bintree{}},
+			"hiveadmission_rbac_role_binding.yaml":    {configHiveadmissionHiveadmission_rbac_role_bindingYaml, map[string]*bintree{}},
+			"machinepool-webhook.yaml":                {configHiveadmissionMachinepoolWebhookYaml, map[string]*bintree{}},
+			"selectorsyncset-webhook.yaml":            {configHiveadmissionSelectorsyncsetWebhookYaml, map[string]*bintree{}},
+			"service-account.yaml":                    {configHiveadmissionServiceAccountYaml, map[string]*bintree{}},
+			"service.yaml":                            {configHiveadmissionServiceYaml, map[string]*bintree{}},
+			"syncset-webhook.yaml":                    {configHiveadmissionSyncsetWebhookYaml, map[string]*bintree{}},
 		}},
 		"rbac": {nil, map[string]*bintree{
 			"hive_admin_role.yaml":              {configRbacHive_admin_roleYaml, map[string]*bintree{}},
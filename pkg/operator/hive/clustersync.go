@@ -11,6 +11,7 @@ import (
 	"k8s.io/utils/pointer"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hiveconstants "github.com/openshift/hive/pkg/constants"
 	"github.com/openshift/hive/pkg/controller/images"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 	"github.com/openshift/hive/pkg/operator/assets"
@@ -55,7 +56,7 @@ func (r *ReconcileHiveConfig) deployClusterSync(hLog log.FieldLogger, h resource
 		hiveContainer.Args = append(hiveContainer.Args, "--log-level", level)
 	}
 
-	if syncSetReapplyInterval := hiveconfig.Spec.SyncSetReapplyInterval; syncSetReapplyInterval != "" {
+	if syncSetReapplyInterval := syncSetReapplyInterval(hiveconfig); syncSetReapplyInterval != "" {
 		syncsetReapplyIntervalEnvVar := corev1.EnvVar{
 			Name:  "SYNCSET_REAPPLY_INTERVAL",
 			Value: syncSetReapplyInterval,
@@ -63,6 +64,31 @@ func (r *ReconcileHiveConfig) deployClusterSync(hLog log.FieldLogger, h resource
 
 		hiveContainer.Env = append(hiveContainer.Env, syncsetReapplyIntervalEnvVar)
 	}
+	hiveContainer.Env = append(hiveContainer.Env, periodicSyncJitterEnvVars(hiveconfig)...)
+	hiveContainer.Env = append(hiveContainer.Env, syncSetAuditEnvVars(hiveconfig)...)
+
+	if hiveconfig.Spec.ScopedRemoteAccess == hivev1.ScopedRemoteAccessEnabled {
+		hiveContainer.Env = append(
+			hiveContainer.Env,
+			corev1.EnvVar{
+				Name:  hiveconstants.ScopedRemoteAccessEnvVar,
+				Value: "true",
+			},
+		)
+		hiveContainer.Env = append(hiveContainer.Env, remoteAccessRBACEnvVars(hLog, hiveconfig)...)
+	}
+
+	if hiveconfig.Spec.AdminKubeconfigContext == hivev1.AdminKubeconfigContextInternal {
+		hiveContainer.Env = append(
+			hiveContainer.Env,
+			corev1.EnvVar{
+				Name:  hiveconstants.AdminKubeconfigContextEnvVar,
+				Value: string(hivev1.AdminKubeconfigContextInternal),
+			},
+		)
+	}
+
+	configureMetrics(hiveconfig, &newClusterSyncStatefulSet.Spec.Template.Spec, "hive-clustersync")
 
 	hiveNSName := getHiveNamespace(hiveconfig)
 
@@ -71,16 +97,8 @@ func (r *ReconcileHiveConfig) deployClusterSync(hLog log.FieldLogger, h resource
 	}
 	newClusterSyncStatefulSet.Spec.Template.Annotations[hiveConfigHashAnnotation] = hiveControllersConfigHash
 
-	// Load namespaced assets, decode them, set to our target namespace, and apply:
-	namespacedAssets := []string{
-		"config/clustersync/service.yaml",
-	}
-	for _, assetPath := range namespacedAssets {
-		if err := util.ApplyAssetWithNSOverrideAndGC(h, assetPath, hiveNSName, hiveconfig); err != nil {
-			hLog.WithError(err).Error("error applying object with namespace override")
-			return err
-		}
-		hLog.WithField("asset", assetPath).Info("applied asset with namespace override")
+	if err := applyMetricsService(hLog, h, "config/clustersync/service.yaml", hiveNSName, hiveconfig); err != nil {
+		return err
 	}
 
 	if hiveconfig.Spec.MaintenanceMode != nil && *hiveconfig.Spec.MaintenanceMode {
@@ -149,6 +167,34 @@ func (r *ReconcileHiveConfig) deployClusterSync(hLog log.FieldLogger, h resource
 	return nil
 }
 
+// syncSetAuditEnvVars returns the environment variables with which the clustersync controller stamps the resources
+// it applies with where they came from.
+func syncSetAuditEnvVars(hiveconfig *hivev1.HiveConfig) []corev1.EnvVar {
+	audit := hiveconfig.Spec.SyncSetAudit
+	if audit == nil {
+		return nil
+	}
+	envVars := []corev1.EnvVar{
+		{
+			Name:  hiveconstants.SyncSetAuditEnvVar,
+			Value: "true",
+		},
+	}
+	if audit.HubName != "" {
+		envVars = append(envVars, corev1.EnvVar{
+			Name:  hiveconstants.SyncSetAuditHubNameEnvVar,
+			Value: audit.HubName,
+		})
+	}
+	if audit.OwnershipLabel {
+		envVars = append(envVars, corev1.EnvVar{
+			Name:  hiveconstants.SyncSetAuditOwnershipLabelEnvVar,
+			Value: "true",
+		})
+	}
+	return envVars
+}
+
 func hasStatefulSetSpecChanged(existingClusterSyncStatefulSet, newClusterSyncStatefulSet *appsv1.StatefulSet, hLog log.FieldLogger) bool {
 	// hash doesn't exist, assume the spec has changed.
 	if existingClusterSyncStatefulSet == nil {
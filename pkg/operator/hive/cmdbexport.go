@@ -0,0 +1,77 @@
+package hive
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/operator/util"
+	"github.com/openshift/hive/pkg/resource"
+)
+
+const (
+	cmdbExportConfigMapName      = "cmdb-export"
+	cmdbExportConfigMapNameKey   = "cmdb-export"
+	cmdbExportConfigMapMountPath = "/data/cmdb-export-config"
+)
+
+func (r *ReconcileHiveConfig) deployCMDBExportConfigMap(hLog log.FieldLogger, h resource.Helper, instance *hivev1.HiveConfig) (string, error) {
+	cm := &corev1.ConfigMap{}
+	cm.Name = cmdbExportConfigMapName
+	cm.Namespace = getHiveNamespace(instance)
+	cm.Data = make(map[string]string)
+
+	if instance.Spec.CMDBExport != nil {
+		data, err := json.Marshal(instance.Spec.CMDBExport)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to marshal cmdb export config")
+		}
+		cm.Data[cmdbExportConfigMapNameKey] = string(data)
+	}
+
+	result, err := util.ApplyRuntimeObjectWithGC(h, cm, instance)
+	if err != nil {
+		hLog.WithError(err).Error("error applying cmdb-export configmap")
+		return "", err
+	}
+	hLog.WithField("result", result).Info("cmdb-export configmap applied")
+
+	return computeCMDBExportConfigHash(cm), nil
+}
+
+func computeCMDBExportConfigHash(cm *corev1.ConfigMap) string {
+	hasher := md5.New()
+	hasher.Write([]byte(fmt.Sprintf("%v", cm.Data)))
+	return hex.EncodeToString(hasher.Sum(nil))
+}
+
+func addCMDBExportConfigVolume(podSpec *corev1.PodSpec) {
+	optional := true
+	volume := corev1.Volume{}
+	volume.Name = cmdbExportConfigMapName
+	volume.ConfigMap = &corev1.ConfigMapVolumeSource{
+		LocalObjectReference: corev1.LocalObjectReference{
+			Name: cmdbExportConfigMapName,
+		},
+		Optional: &optional,
+	}
+	volumeMount := corev1.VolumeMount{
+		Name:      cmdbExportConfigMapName,
+		MountPath: cmdbExportConfigMapMountPath,
+	}
+	envVar := corev1.EnvVar{
+		Name:  constants.CMDBExportConfigFileEnvVar,
+		Value: fmt.Sprintf("%s/%s", cmdbExportConfigMapMountPath, cmdbExportConfigMapNameKey),
+	}
+	podSpec.Volumes = append(podSpec.Volumes, volume)
+	podSpec.Containers[0].VolumeMounts = append(podSpec.Containers[0].VolumeMounts, volumeMount)
+	podSpec.Containers[0].Env = append(podSpec.Containers[0].Env, envVar)
+}
@@ -0,0 +1,77 @@
+package hive
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/operator/util"
+	"github.com/openshift/hive/pkg/resource"
+)
+
+const (
+	csrApprovalPolicyConfigMapName      = "csr-approval-policy"
+	csrApprovalPolicyConfigMapNameKey   = "csr-approval-policy"
+	csrApprovalPolicyConfigMapMountPath = "/data/csr-approval-policy-config"
+)
+
+func (r *ReconcileHiveConfig) deployCSRApprovalPolicyConfigMap(hLog log.FieldLogger, h resource.Helper, instance *hivev1.HiveConfig) (string, error) {
+	cm := &corev1.ConfigMap{}
+	cm.Name = csrApprovalPolicyConfigMapName
+	cm.Namespace = getHiveNamespace(instance)
+	cm.Data = make(map[string]string)
+
+	if instance.Spec.CSRApprovalPolicy != nil {
+		data, err := json.Marshal(instance.Spec.CSRApprovalPolicy)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to marshal csr approval policy")
+		}
+		cm.Data[csrApprovalPolicyConfigMapNameKey] = string(data)
+	}
+
+	result, err := util.ApplyRuntimeObjectWithGC(h, cm, instance)
+	if err != nil {
+		hLog.WithError(err).Error("error applying csr-approval-policy configmap")
+		return "", err
+	}
+	hLog.WithField("result", result).Info("csr-approval-policy configmap applied")
+
+	return computeCSRApprovalPolicyConfigHash(cm), nil
+}
+
+func computeCSRApprovalPolicyConfigHash(cm *corev1.ConfigMap) string {
+	hasher := md5.New()
+	hasher.Write([]byte(fmt.Sprintf("%v", cm.Data)))
+	return hex.EncodeToString(hasher.Sum(nil))
+}
+
+func addCSRApprovalPolicyConfigVolume(podSpec *corev1.PodSpec) {
+	optional := true
+	volume := corev1.Volume{}
+	volume.Name = csrApprovalPolicyConfigMapName
+	volume.ConfigMap = &corev1.ConfigMapVolumeSource{
+		LocalObjectReference: corev1.LocalObjectReference{
+			Name: csrApprovalPolicyConfigMapName,
+		},
+		Optional: &optional,
+	}
+	volumeMount := corev1.VolumeMount{
+		Name:      csrApprovalPolicyConfigMapName,
+		MountPath: csrApprovalPolicyConfigMapMountPath,
+	}
+	envVar := corev1.EnvVar{
+		Name:  constants.CSRApprovalPolicyConfigFileEnvVar,
+		Value: fmt.Sprintf("%s/%s", csrApprovalPolicyConfigMapMountPath, csrApprovalPolicyConfigMapNameKey),
+	}
+	podSpec.Volumes = append(podSpec.Volumes, volume)
+	podSpec.Containers[0].VolumeMounts = append(podSpec.Containers[0].VolumeMounts, volumeMount)
+	podSpec.Containers[0].Env = append(podSpec.Containers[0].Env, envVar)
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"crypto/md5"
+	"encoding/json"
 	"fmt"
 	"os"
 	"strconv"
@@ -28,24 +29,31 @@ import (
 	"github.com/openshift/hive/pkg/constants"
 	hiveconstants "github.com/openshift/hive/pkg/constants"
 	"github.com/openshift/hive/pkg/controller/images"
+	"github.com/openshift/hive/pkg/objectstorage"
 	"github.com/openshift/hive/pkg/operator/assets"
 	"github.com/openshift/hive/pkg/operator/util"
 	"github.com/openshift/hive/pkg/resource"
 )
 
 const (
-	dnsServersEnvVar = "ZONE_CHECK_DNS_SERVERS"
-
 	// hiveAdditionalCASecret is the name of the secret in the hive namespace
 	// that will contain the aggregate of all AdditionalCertificateAuthorities
 	// secrets specified in HiveConfig
 	hiveAdditionalCASecret = "hive-additional-ca"
 
+	// hiveAdditionalTrustBundleSecret is the name of the secret in the hive namespace
+	// that will contain the aggregate of all AdditionalCertificateAuthoritiesSecretRefs
+	// secrets specified in HiveConfig
+	hiveAdditionalTrustBundleSecret = "hive-additional-trust-bundle"
+
 	// hiveConfigHashAnnotation is annotation on hivedeployment that contains
 	// the hash of the contents of the hive-controllers-config configmap
 	hiveConfigHashAnnotation = "hive.openshift.io/hiveconfig-hash"
 
 	hiveClusterSyncStatefulSetSpecHashAnnotation = "hive.openshift.io/clustersync-statefulset-spec-hash"
+
+	// defaultPodLogSnapshotMaxSizeKB is the size of the pod log snapshots when the HiveConfig does not specify one.
+	defaultPodLogSnapshotMaxSizeKB = 256
 )
 
 var (
@@ -90,7 +98,7 @@ func (r *ReconcileHiveConfig) deployHive(hLog log.FieldLogger, h resource.Helper
 		hiveContainer.Args = append(hiveContainer.Args, "--log-level", level)
 	}
 
-	if syncSetReapplyInterval := instance.Spec.SyncSetReapplyInterval; syncSetReapplyInterval != "" {
+	if syncSetReapplyInterval := syncSetReapplyInterval(instance); syncSetReapplyInterval != "" {
 		syncsetReapplyIntervalEnvVar := corev1.EnvVar{
 			Name:  "SYNCSET_REAPPLY_INTERVAL",
 			Value: syncSetReapplyInterval,
@@ -99,8 +107,33 @@ func (r *ReconcileHiveConfig) deployHive(hLog log.FieldLogger, h resource.Helper
 		hiveContainer.Env = append(hiveContainer.Env, syncsetReapplyIntervalEnvVar)
 	}
 
+	if periodicSync := instance.Spec.PeriodicSync; periodicSync != nil {
+		if periodicSync.ClusterStatePollInterval != nil {
+			hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+				Name:  constants.ClusterStatePollIntervalEnvVar,
+				Value: periodicSync.ClusterStatePollInterval.Duration.String(),
+			})
+		}
+		if periodicSync.MachineSetResyncInterval != nil {
+			hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+				Name:  constants.MachineSetResyncIntervalEnvVar,
+				Value: periodicSync.MachineSetResyncInterval.Duration.String(),
+			})
+		}
+	}
+	hiveContainer.Env = append(hiveContainer.Env, periodicSyncJitterEnvVars(instance)...)
+
+	if instance.Spec.CollectNodeInfo {
+		hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+			Name:  constants.ClusterStateCollectNodeInfoEnvVar,
+			Value: "true",
+		})
+	}
+
 	addManagedDomainsVolume(&hiveDeployment.Spec.Template.Spec, mdConfigMap.Name)
 	addAWSPrivateLinkConfigVolume(&hiveDeployment.Spec.Template.Spec)
+	addCMDBExportConfigVolume(&hiveDeployment.Spec.Template.Spec)
+	addCSRApprovalPolicyConfigVolume(&hiveDeployment.Spec.Template.Spec)
 
 	hiveNSName := getHiveNamespace(instance)
 
@@ -133,9 +166,269 @@ func (r *ReconcileHiveConfig) deployHive(hLog log.FieldLogger, h resource.Helper
 		hiveContainer.Env = append(hiveContainer.Env, awsLogsEnvVars...)
 	}
 
-	if zoneCheckDNSServers := os.Getenv(dnsServersEnvVar); len(zoneCheckDNSServers) > 0 {
+	if instance.Spec.FailedProvisionConfig.CollectMustGather {
+		hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+			Name:  constants.FailedProvisionMustGatherEnvVar,
+			Value: "true",
+		})
+	}
+
+	if refs := instance.Spec.FailedProvisionConfig.InstallLogRegexesConfigMapRefs; len(refs) > 0 {
+		names := make([]string, len(refs))
+		for i, ref := range refs {
+			names[i] = ref.Name
+		}
+		hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+			Name:  constants.InstallLogRegexesConfigMapsEnvVar,
+			Value: strings.Join(names, ","),
+		})
+	}
+
+	if provisionSLA := instance.Spec.ProvisionSLA; provisionSLA != nil {
+		hLog.WithField("sla", provisionSLA.Duration.Duration).Info("Provision SLA enabled")
+		hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+			Name:  constants.ProvisionSLAEnvVar,
+			Value: provisionSLA.Duration.Duration.String(),
+		})
+		if provisionSLA.Action != "" {
+			hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+				Name:  constants.ProvisionSLAActionEnvVar,
+				Value: string(provisionSLA.Action),
+			})
+		}
+	}
+
+	if timeouts := instance.Spec.InstallTimeouts; timeouts != nil {
+		if timeouts.Default != nil {
+			hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+				Name:  constants.DefaultInstallTimeoutEnvVar,
+				Value: timeouts.Default.Duration.String(),
+			})
+		}
+		if len(timeouts.Platforms) > 0 {
+			platformTimeouts := make([]string, len(timeouts.Platforms))
+			for i, pt := range timeouts.Platforms {
+				platformTimeouts[i] = fmt.Sprintf("%s=%s", pt.Platform, pt.Timeout.Duration)
+			}
+			hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+				Name:  constants.PlatformInstallTimeoutsEnvVar,
+				Value: strings.Join(platformTimeouts, ","),
+			})
+		}
+	}
+
+	if stageTimeouts := instance.Spec.StageTimeouts; stageTimeouts != nil {
+		if stageTimeouts.Initializing != nil {
+			hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+				Name:  constants.InitializingStageTimeoutEnvVar,
+				Value: stageTimeouts.Initializing.Duration.String(),
+			})
+		}
+		if stageTimeouts.Provisioning != nil {
+			hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+				Name:  constants.ProvisioningStageTimeoutEnvVar,
+				Value: stageTimeouts.Provisioning.Duration.String(),
+			})
+		}
+	}
+
+	if remediation := instance.Spec.InstallPodStuckRemediation; remediation != nil {
+		if remediation.RecreateJob {
+			hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+				Name:  constants.InstallPodStuckRecreateJobEnvVar,
+				Value: "true",
+			})
+		}
+		if remediation.FailAfter != nil {
+			hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+				Name:  constants.InstallPodStuckFailAfterEnvVar,
+				Value: remediation.FailAfter.Duration.String(),
+			})
+		}
+	}
+
+	if snapshots := instance.Spec.PodLogSnapshots; snapshots != nil {
+		maxSizeKB := snapshots.MaxSizeKB
+		if maxSizeKB == 0 {
+			maxSizeKB = defaultPodLogSnapshotMaxSizeKB
+		}
+		hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+			Name:  constants.PodLogSnapshotMaxSizeKBEnvVar,
+			Value: strconv.Itoa(maxSizeKB),
+		})
+	}
+
+	if streaming := instance.Spec.InstallLogStreaming; streaming != nil {
+		var streamEnvVars []corev1.EnvVar
+		names := objectstorage.NewEnvVarNames(constants.InstallLogStreamEnvVarPrefix)
+		switch config := objectstorage.NewConfig(streaming.S3, streaming.GCS, nil); {
+		case config != nil:
+			streamEnvVars = config.EnvVars(names)
+		case streaming.HTTP != nil:
+			config = &objectstorage.Config{Destination: constants.InstallLogStreamDestinationHTTP}
+			if streaming.HTTP.CredentialsSecretRef != nil {
+				config.CredentialsSecret = streaming.HTTP.CredentialsSecretRef.Name
+			}
+			streamEnvVars = append(config.EnvVars(names), corev1.EnvVar{
+				Name:  constants.InstallLogStreamURLEnvVar,
+				Value: streaming.HTTP.URL,
+			})
+		default:
+			hLog.Warn("install log streaming is configured without a sink, not streaming install logs")
+		}
+		if len(streamEnvVars) > 0 && streaming.Interval != nil {
+			streamEnvVars = append(streamEnvVars, corev1.EnvVar{
+				Name:  constants.InstallLogStreamIntervalEnvVar,
+				Value: streaming.Interval.Duration.String(),
+			})
+		}
+		hiveContainer.Env = append(hiveContainer.Env, streamEnvVars...)
+	}
+
+	if artifacts := instance.Spec.InstallArtifacts; artifacts != nil {
+		var artifactsEnvVars []corev1.EnvVar
+		if config := objectstorage.NewConfig(artifacts.S3, artifacts.GCS, artifacts.AzureBlob); config != nil {
+			artifactsEnvVars = config.EnvVars(objectstorage.NewEnvVarNames(constants.InstallArtifactsEnvVarPrefix))
+		} else {
+			hLog.Warn("install artifacts upload is configured without a destination, not uploading install artifacts")
+		}
+		if len(artifactsEnvVars) > 0 {
+			if artifacts.Upload != "" {
+				artifactsEnvVars = append(artifactsEnvVars, corev1.EnvVar{
+					Name:  constants.InstallArtifactsUploadEnvVar,
+					Value: string(artifacts.Upload),
+				})
+			}
+			if artifacts.Prefix != "" {
+				artifactsEnvVars = append(artifactsEnvVars, corev1.EnvVar{
+					Name:  constants.InstallArtifactsPrefixEnvVar,
+					Value: artifacts.Prefix,
+				})
+			}
+		}
+		hiveContainer.Env = append(hiveContainer.Env, artifactsEnvVars...)
+	}
+
+	if export := instance.Spec.SnapshotExport; export != nil {
+		var exportEnvVars []corev1.EnvVar
+		if config := objectstorage.NewConfig(export.S3, export.GCS, nil); config != nil {
+			exportEnvVars = config.EnvVars(objectstorage.NewEnvVarNames(constants.SnapshotExportEnvVarPrefix))
+		} else {
+			hLog.Warn("snapshot export is configured without a destination, not exporting snapshots")
+		}
+		if len(exportEnvVars) > 0 {
+			if export.Prefix != "" {
+				exportEnvVars = append(exportEnvVars, corev1.EnvVar{
+					Name:  constants.SnapshotExportPrefixEnvVar,
+					Value: export.Prefix,
+				})
+			}
+			if export.Interval != nil {
+				exportEnvVars = append(exportEnvVars, corev1.EnvVar{
+					Name:  constants.SnapshotExportIntervalEnvVar,
+					Value: export.Interval.Duration.String(),
+				})
+			}
+		}
+		hiveContainer.Env = append(hiveContainer.Env, exportEnvVars...)
+	}
+
+	if spread := instance.Spec.InstallJobSpread; spread != nil {
+		hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+			Name:  constants.InstallJobSpreadModeEnvVar,
+			Value: string(spread.Mode),
+		})
+		if spread.TopologyKey != "" {
+			hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+				Name:  constants.InstallJobSpreadTopologyKeyEnvVar,
+				Value: spread.TopologyKey,
+			})
+		}
+	}
+
+	if maxConcurrent := instance.Spec.ClusterPoolMaxConcurrent; maxConcurrent != nil {
+		hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+			Name:  constants.ClusterPoolMaxConcurrentEnvVar,
+			Value: strconv.Itoa(int(*maxConcurrent)),
+		})
+	}
+
+	hiveContainer.Env = append(hiveContainer.Env, clusterClaimQuotaEnvVars(instance)...)
+
+	if egress := instance.Spec.InstallEgressPolicy; egress != nil {
+		hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+			Name:  constants.InstallEgressPolicyTypeEnvVar,
+			Value: string(egress.Type),
+		})
+		if len(egress.AdditionalEndpoints) > 0 {
+			hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+				Name:  constants.InstallEgressAdditionalEndpointsEnvVar,
+				Value: strings.Join(egress.AdditionalEndpoints, ","),
+			})
+		}
+	}
+
+	if rateLimit := instance.Spec.AWSRateLimit; rateLimit != nil {
+		if rateLimit.RequestsPerSecond > 0 {
+			hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+				Name:  constants.AWSRequestsPerSecondEnvVar,
+				Value: strconv.Itoa(rateLimit.RequestsPerSecond),
+			})
+		}
+		if rateLimit.Burst > 0 {
+			hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+				Name:  constants.AWSBurstEnvVar,
+				Value: strconv.Itoa(rateLimit.Burst),
+			})
+		}
+		if rateLimit.MaxRetries != nil {
+			hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+				Name:  constants.AWSMaxRetriesEnvVar,
+				Value: strconv.Itoa(*rateLimit.MaxRetries),
+			})
+		}
+	}
+
+	if plugins := instance.Spec.ProviderPlugins; len(plugins) > 0 {
+		pluginsJSON, err := json.Marshal(plugins)
+		if err != nil {
+			hLog.WithError(err).Error("error marshaling provider plugins")
+			return err
+		}
+		hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+			Name:  constants.ProviderPluginsEnvVar,
+			Value: string(pluginsJSON),
+		})
+	}
+
+	if policy := instance.Spec.PullSecretConflictPolicy; policy != "" {
+		hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+			Name:  constants.PullSecretConflictPolicyEnvVar,
+			Value: string(policy),
+		})
+	}
+
+	zoneCheckDNSServers := os.Getenv(constants.ZoneCheckDNSServersEnvVar)
+	if dnsPropagation := instance.Spec.DNSPropagation; dnsPropagation != nil {
+		if len(dnsPropagation.Resolvers) > 0 {
+			zoneCheckDNSServers = strings.Join(dnsPropagation.Resolvers, ",")
+		}
+		if dnsPropagation.QueryTimeout != nil {
+			hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+				Name:  constants.ZoneCheckQueryTimeoutEnvVar,
+				Value: dnsPropagation.QueryTimeout.Duration.String(),
+			})
+		}
+		if dnsPropagation.MaxCheckInterval != nil {
+			hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+				Name:  constants.ZoneCheckMaxIntervalEnvVar,
+				Value: dnsPropagation.MaxCheckInterval.Duration.String(),
+			})
+		}
+	}
+	if len(zoneCheckDNSServers) > 0 {
 		dnsServersEnvVar := corev1.EnvVar{
-			Name:  dnsServersEnvVar,
+			Name:  constants.ZoneCheckDNSServersEnvVar,
 			Value: zoneCheckDNSServers,
 		}
 		hiveContainer.Env = append(hiveContainer.Env, dnsServersEnvVar)
@@ -185,12 +478,43 @@ func (r *ReconcileHiveConfig) deployHive(hLog log.FieldLogger, h resource.Helper
 		})
 	}
 
+	if instance.Spec.NamespaceCleanup == hivev1.NamespaceCleanupEnabled {
+		hLog.Info("Namespace Cleanup enabled")
+		hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+			Name:  hiveconstants.NamespaceCleanupEnvVar,
+			Value: "true",
+		})
+	}
+
+	if instance.Spec.ScopedRemoteAccess == hivev1.ScopedRemoteAccessEnabled {
+		hLog.Info("Scoped Remote Access enabled")
+		hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+			Name:  hiveconstants.ScopedRemoteAccessEnvVar,
+			Value: "true",
+		})
+		hiveContainer.Env = append(hiveContainer.Env, remoteAccessRBACEnvVars(hLog, instance)...)
+	}
+
+	if instance.Spec.AdminKubeconfigContext == hivev1.AdminKubeconfigContextInternal {
+		hLog.Info("Internal admin kubeconfig context enabled")
+		hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+			Name:  hiveconstants.AdminKubeconfigContextEnvVar,
+			Value: string(hivev1.AdminKubeconfigContextInternal),
+		})
+	}
+
 	if err := r.includeAdditionalCAs(hLog, h, instance, hiveDeployment); err != nil {
 		return err
 	}
 
+	if err := r.includeAdditionalTrustBundle(hLog, h, instance, hiveDeployment); err != nil {
+		return err
+	}
+
 	r.includeGlobalPullSecret(hLog, h, instance, hiveDeployment)
 
+	configureMetrics(instance, &hiveDeployment.Spec.Template.Spec, "hive-controllers")
+
 	if instance.Spec.MaintenanceMode != nil && *instance.Spec.MaintenanceMode {
 		hLog.Warn("maintenanceMode enabled in HiveConfig, setting hive-controllers replicas to 0")
 		replicas := int32(0)
@@ -204,8 +528,10 @@ func (r *ReconcileHiveConfig) deployHive(hLog log.FieldLogger, h resource.Helper
 	hiveDeployment.Spec.Template.Annotations[hiveConfigHashAnnotation] = hiveControllersConfigHash
 
 	// Load namespaced assets, decode them, set to our target namespace, and apply:
+	if err := applyMetricsService(hLog, h, "config/controllers/service.yaml", hiveNSName, instance); err != nil {
+		return err
+	}
 	namespacedAssets := []string{
-		"config/controllers/service.yaml",
 		"config/configmaps/install-log-regexes-configmap.yaml",
 		"config/rbac/hive_frontend_serviceaccount.yaml",
 		"config/controllers/hive_controllers_serviceaccount.yaml",
@@ -358,6 +684,62 @@ func (r *ReconcileHiveConfig) includeAdditionalCAs(hLog log.FieldLogger, h resou
 	return nil
 }
 
+// includeAdditionalTrustBundle aggregates the certificate authorities trusted by installed clusters into a secret
+// in the hive namespace and points the controllers at it.
+func (r *ReconcileHiveConfig) includeAdditionalTrustBundle(hLog log.FieldLogger, h resource.Helper, instance *hivev1.HiveConfig, hiveDeployment *appsv1.Deployment) error {
+	trustBundle := &bytes.Buffer{}
+	for _, caRef := range instance.Spec.AdditionalCertificateAuthoritiesSecretRefs {
+		caSecret := &corev1.Secret{}
+		err := r.Get(context.TODO(), types.NamespacedName{Namespace: getHiveNamespace(instance), Name: caRef.Name}, caSecret)
+		if err != nil {
+			hLog.WithError(err).WithField("secret", caRef.Name).Error("Cannot read additional trust bundle secret")
+			continue
+		}
+		crt, ok := caSecret.Data[hiveconstants.AdditionalTrustBundleSecretKey]
+		if !ok {
+			hLog.WithField("secret", caRef.Name).Warningf("Secret does not contain expected key (%s)", hiveconstants.AdditionalTrustBundleSecretKey)
+			continue
+		}
+		fmt.Fprintf(trustBundle, "%s\n", bytes.TrimSpace(crt))
+	}
+
+	if trustBundle.Len() == 0 {
+		caSecret := &corev1.Secret{}
+		err := r.Get(context.TODO(), types.NamespacedName{Namespace: getHiveNamespace(instance), Name: hiveAdditionalTrustBundleSecret}, caSecret)
+		if err == nil {
+			err = r.Delete(context.TODO(), caSecret)
+			if err != nil {
+				hLog.WithError(err).WithField("secret", fmt.Sprintf("%s/%s", getHiveNamespace(instance), hiveAdditionalTrustBundleSecret)).
+					Error("cannot delete hive additional trust bundle secret")
+				return err
+			}
+		}
+		return nil
+	}
+
+	caSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: getHiveNamespace(instance),
+			Name:      hiveAdditionalTrustBundleSecret,
+		},
+		Data: map[string][]byte{
+			hiveconstants.AdditionalTrustBundleSecretKey: trustBundle.Bytes(),
+		},
+	}
+	result, err := util.ApplyRuntimeObjectWithGC(h, caSecret, instance)
+	if err != nil {
+		hLog.WithError(err).Error("error applying additional trust bundle secret")
+		return err
+	}
+	hLog.Infof("additional trust bundle secret applied (%s)", result)
+
+	hiveDeployment.Spec.Template.Spec.Containers[0].Env = append(hiveDeployment.Spec.Template.Spec.Containers[0].Env, corev1.EnvVar{
+		Name:  hiveconstants.AdditionalTrustBundleSecretEnvVar,
+		Value: hiveAdditionalTrustBundleSecret,
+	})
+	return nil
+}
+
 func (r *ReconcileHiveConfig) includeGlobalPullSecret(hLog log.FieldLogger, h resource.Helper, instance *hivev1.HiveConfig, hiveDeployment *appsv1.Deployment) {
 	if instance.Spec.GlobalPullSecretRef == nil || instance.Spec.GlobalPullSecretRef.Name == "" {
 		hLog.Debug("GlobalPullSecret is not provided in HiveConfig, it will not be deployed")
@@ -371,6 +753,29 @@ func (r *ReconcileHiveConfig) includeGlobalPullSecret(hLog log.FieldLogger, h re
 	hiveDeployment.Spec.Template.Spec.Containers[0].Env = append(hiveDeployment.Spec.Template.Spec.Containers[0].Env, globalPullSecretEnvVar)
 }
 
+// clusterClaimQuotaEnvVars returns the environment variables with which the controllers and hiveadmission limit the
+// number of ClusterClaims.
+func clusterClaimQuotaEnvVars(instance *hivev1.HiveConfig) []corev1.EnvVar {
+	quota := instance.Spec.ClusterClaimQuota
+	if quota == nil {
+		return nil
+	}
+	var envVars []corev1.EnvVar
+	if quota.MaxClaimsPerNamespace != nil {
+		envVars = append(envVars, corev1.EnvVar{
+			Name:  constants.ClusterClaimQuotaPerNamespaceEnvVar,
+			Value: strconv.Itoa(int(*quota.MaxClaimsPerNamespace)),
+		})
+	}
+	if quota.MaxClaimsPerRequester != nil {
+		envVars = append(envVars, corev1.EnvVar{
+			Name:  constants.ClusterClaimQuotaPerRequesterEnvVar,
+			Value: strconv.Itoa(int(*quota.MaxClaimsPerRequester)),
+		})
+	}
+	return envVars
+}
+
 func (r *ReconcileHiveConfig) runningOnOpenShift(hLog log.FieldLogger) (bool, error) {
 	deploymentConfigGroupVersion := oappsv1.GroupVersion.String()
 	list, err := r.discoveryClient.ServerResourcesForGroupVersion(deploymentConfigGroupVersion)
@@ -346,7 +346,21 @@ func (r *ReconcileHiveConfig) Reconcile(request reconcile.Request) (reconcile.Re
 		return reconcile.Result{}, err
 	}
 
-	confighash, err := r.deployHiveControllersConfigMap(hLog, h, instance, plConfigHash)
+	cmdbConfigHash, err := r.deployCMDBExportConfigMap(hLog, h, instance)
+	if err != nil {
+		hLog.WithError(err).Error("error deploying cmdb export configmap")
+		r.updateHiveConfigStatus(origHiveConfig, instance, hLog, false)
+		return reconcile.Result{}, err
+	}
+
+	csrConfigHash, err := r.deployCSRApprovalPolicyConfigMap(hLog, h, instance)
+	if err != nil {
+		hLog.WithError(err).Error("error deploying csr approval policy configmap")
+		r.updateHiveConfigStatus(origHiveConfig, instance, hLog, false)
+		return reconcile.Result{}, err
+	}
+
+	confighash, err := r.deployHiveControllersConfigMap(hLog, h, instance, plConfigHash, cmdbConfigHash, csrConfigHash)
 	if err != nil {
 		hLog.WithError(err).Error("error deploying controllers configmap")
 		r.updateHiveConfigStatus(origHiveConfig, instance, hLog, false)
@@ -415,7 +429,7 @@ func (r *ReconcileHiveConfig) establishSecretWatch(hLog *log.Entry, hiveNSName s
 		}
 
 		// Watch Secrets in hive namespace, so we can detect changes to the hiveadmission serving cert secret and
-		// force a deployment rollout.
+		// force a deployment rollout, and changes to the additional certificate authorities of installed clusters.
 		err := r.ctrlr.Watch(&source.Informer{Informer: secretsInformer}, handler.Funcs{
 			CreateFunc: func(e event.CreateEvent, q workqueue.RateLimitingInterface) {
 				hLog.Debug("eventHandler CreateFunc")
@@ -427,12 +441,14 @@ func (r *ReconcileHiveConfig) establishSecretWatch(hLog *log.Entry, hiveNSName s
 			},
 		}, predicate.Funcs{
 			CreateFunc: func(e event.CreateEvent) bool {
-				hLog.WithField("predicateResponse", e.Meta.GetName() == hiveAdmissionServingCertSecretName).Debug("secret CreateEvent")
-				return e.Meta.GetName() == hiveAdmissionServingCertSecretName
+				watched := r.isWatchedSecret(e.Meta.GetName())
+				hLog.WithField("predicateResponse", watched).Debug("secret CreateEvent")
+				return watched
 			},
 			UpdateFunc: func(e event.UpdateEvent) bool {
-				hLog.WithField("predicateResponse", e.MetaNew.GetName() == hiveAdmissionServingCertSecretName).Debug("secret UpdateEvent")
-				return e.MetaNew.GetName() == hiveAdmissionServingCertSecretName
+				watched := r.isWatchedSecret(e.MetaNew.GetName())
+				hLog.WithField("predicateResponse", watched).Debug("secret UpdateEvent")
+				return watched
 			},
 		})
 		if err != nil {
@@ -446,6 +462,25 @@ func (r *ReconcileHiveConfig) establishSecretWatch(hLog *log.Entry, hiveNSName s
 	return nil
 }
 
+// isWatchedSecret returns whether a secret in the hive namespace is the hiveadmission serving cert secret or one of the
+// additional certificate authorities of the HiveConfig.
+func (r *ReconcileHiveConfig) isWatchedSecret(name string) bool {
+	if name == hiveAdmissionServingCertSecretName {
+		return true
+	}
+	instance := &hivev1.HiveConfig{}
+	if err := r.Get(context.TODO(), types.NamespacedName{Name: hiveConfigName}, instance); err != nil {
+		log.WithError(err).Debug("cannot get the HiveConfig to check the secret")
+		return false
+	}
+	for _, caRef := range instance.Spec.AdditionalCertificateAuthoritiesSecretRefs {
+		if caRef.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
 func (r *ReconcileHiveConfig) cleanupLegacyObjects(hLog log.FieldLogger) error {
 	gvrNSNames := []gvrNSName{
 		{group: "rbac.authorization.k8s.io", version: "v1", resource: "clusterroles", name: "manager-role"},
@@ -486,6 +521,10 @@ func computeHash(data map[string]string) string {
 func (r *ReconcileHiveConfig) updateHiveConfigStatus(origHiveConfig, newHiveConfig *hivev1.HiveConfig, logger log.FieldLogger, succeeded bool) error {
 	newHiveConfig.Status.ObservedGeneration = newHiveConfig.Generation
 	newHiveConfig.Status.ConfigApplied = succeeded
+	newHiveConfig.Status.DisabledFeatures = disabledFeatures(newHiveConfig)
+	if len(newHiveConfig.Status.DisabledFeatures) > 0 {
+		logger.WithField("features", newHiveConfig.Status.DisabledFeatures).Warn("features disabled by the restricted remote access RBAC mode")
+	}
 
 	if reflect.DeepEqual(origHiveConfig, newHiveConfig) {
 		logger.Debug("HiveConfig unchanged, no update required")
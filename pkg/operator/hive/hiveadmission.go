@@ -45,6 +45,8 @@ const (
 )
 
 var webhookAssets = []string{
+	"config/hiveadmission/basedomainpool-webhook.yaml",
+	"config/hiveadmission/clusterclaim-webhook.yaml",
 	"config/hiveadmission/clusterdeployment-webhook.yaml",
 	"config/hiveadmission/clusterimageset-webhook.yaml",
 	"config/hiveadmission/clusterprovision-webhook.yaml",
@@ -54,6 +56,11 @@ var webhookAssets = []string{
 	"config/hiveadmission/selectorsyncset-webhook.yaml",
 }
 
+var mutatingWebhookAssets = []string{
+	"config/hiveadmission/clusterdeployment-mutating-webhook.yaml",
+	"config/hiveadmission/clusterclaim-mutating-webhook.yaml",
+}
+
 func (r *ReconcileHiveConfig) deployHiveAdmission(hLog log.FieldLogger, h resource.Helper, instance *hivev1.HiveConfig, recorder events.Recorder, mdConfigMap *corev1.ConfigMap, additionalHashes ...string) error {
 	hiveNSName := getHiveNamespace(instance)
 
@@ -102,6 +109,10 @@ func (r *ReconcileHiveConfig) deployHiveAdmission(hLog log.FieldLogger, h resour
 	if r.hiveImagePullPolicy != "" {
 		hiveAdmDeployment.Spec.Template.Spec.Containers[0].ImagePullPolicy = r.hiveImagePullPolicy
 	}
+	hiveAdmDeployment.Spec.Template.Spec.Containers[0].Env = append(
+		hiveAdmDeployment.Spec.Template.Spec.Containers[0].Env,
+		clusterClaimQuotaEnvVars(instance)...,
+	)
 	if hiveAdmDeployment.Annotations == nil {
 		hiveAdmDeployment.Annotations = map[string]string{}
 	}
@@ -127,6 +138,13 @@ func (r *ReconcileHiveConfig) deployHiveAdmission(hLog log.FieldLogger, h resour
 		validatingWebhooks[i] = wh
 	}
 
+	mutatingWebhooks := make([]*admregv1.MutatingWebhookConfiguration, len(mutatingWebhookAssets))
+	for i, yaml := range mutatingWebhookAssets {
+		asset = assets.MustAsset(yaml)
+		wh := util.ReadMutatingWebhookConfigurationV1Beta1OrDie(asset, scheme.Scheme)
+		mutatingWebhooks[i] = wh
+	}
+
 	hLog.Debug("reading apiservice")
 	asset = assets.MustAsset("config/hiveadmission/apiservice.yaml")
 	apiService := util.ReadAPIServiceV1Beta1OrDie(asset, scheme.Scheme)
@@ -149,7 +167,7 @@ func (r *ReconcileHiveConfig) deployHiveAdmission(hLog log.FieldLogger, h resour
 	}
 	if !isOpenShift || is311 {
 		hLog.Debug("non-OpenShift 4.x cluster detected, modifying hiveadmission webhooks for CA certs")
-		err = r.injectCerts(apiService, validatingWebhooks, nil, hiveNSName, hLog)
+		err = r.injectCerts(apiService, validatingWebhooks, mutatingWebhooks, hiveNSName, hLog)
 		if err != nil {
 			hLog.WithError(err).Error("error injecting certs")
 			return err
@@ -192,6 +210,15 @@ func (r *ReconcileHiveConfig) deployHiveAdmission(hLog log.FieldLogger, h resour
 		hLog.WithField("webhook", webhook.Name).Infof("validating webhook: %s", result)
 	}
 
+	for _, webhook := range mutatingWebhooks {
+		result, err = util.ApplyRuntimeObjectWithGC(h, webhook, instance)
+		if err != nil {
+			hLog.WithField("webhook", webhook.Name).WithError(err).Errorf("error applying mutating webhook")
+			return err
+		}
+		hLog.WithField("webhook", webhook.Name).Infof("mutating webhook: %s", result)
+	}
+
 	hLog.Info("hiveadmission components reconciled successfully")
 	return nil
 }
@@ -0,0 +1,129 @@
+package hive
+
+import (
+	"fmt"
+	"path"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/openshift/library-go/pkg/operator/resource/resourceread"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hiveconstants "github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/operator/assets"
+	"github.com/openshift/hive/pkg/operator/util"
+	hiveresource "github.com/openshift/hive/pkg/resource"
+)
+
+const (
+	metricsPortName  = "metrics"
+	metricsPort      = 2112
+	metricsProxyPort = 8443
+
+	metricsProxyContainerName      = "kube-rbac-proxy"
+	metricsServingCertVolumeName   = "metrics-serving-cert"
+	metricsServingCertMountPath    = "/etc/tls/private"
+	metricsServingCertSecretSuffix = "-metrics-serving-cert"
+
+	// servingCertSecretAnnotation has the service CA operator generate a serving certificate for a service.
+	servingCertSecretAnnotation = "service.beta.openshift.io/serving-cert-secret-name"
+)
+
+// configureMetrics sets up how the pod behind the service of the given name serves its metrics, as configured in
+// HiveConfig. The Hive container must be the first container of the pod.
+func configureMetrics(hiveconfig *hivev1.HiveConfig, podSpec *corev1.PodSpec, serviceName string) {
+	metricsConfig := hiveconfig.Spec.Metrics
+	if metricsConfig == nil {
+		return
+	}
+	hiveContainer := &podSpec.Containers[0]
+
+	if metricsConfig.NamespaceFiltering == hivev1.MetricsNamespaceFilteringEnabled {
+		hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+			Name:  hiveconstants.MetricsNamespaceFilteringEnvVar,
+			Value: "true",
+		})
+	}
+
+	proxy := metricsConfig.KubeRBACProxy
+	if proxy == nil {
+		return
+	}
+	// The metrics are only served to the proxy, which shares the network namespace of the pod.
+	hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+		Name:  hiveconstants.MetricsBindAddressEnvVar,
+		Value: fmt.Sprintf("127.0.0.1:%d", metricsPort),
+	})
+	podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+		Name: metricsServingCertVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: metricsServingCertSecretName(proxy, serviceName),
+			},
+		},
+	})
+	podSpec.Containers = append(podSpec.Containers, corev1.Container{
+		Name:  metricsProxyContainerName,
+		Image: proxy.Image,
+		Args: []string{
+			fmt.Sprintf("--secure-listen-address=0.0.0.0:%d", metricsProxyPort),
+			fmt.Sprintf("--upstream=http://127.0.0.1:%d/", metricsPort),
+			fmt.Sprintf("--tls-cert-file=%s", path.Join(metricsServingCertMountPath, corev1.TLSCertKey)),
+			fmt.Sprintf("--tls-private-key-file=%s", path.Join(metricsServingCertMountPath, corev1.TLSPrivateKeyKey)),
+			"--logtostderr=true",
+		},
+		Ports: []corev1.ContainerPort{{
+			Name:          metricsPortName,
+			ContainerPort: metricsProxyPort,
+			Protocol:      corev1.ProtocolTCP,
+		}},
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("10m"),
+				corev1.ResourceMemory: resource.MustParse("20Mi"),
+			},
+		},
+		VolumeMounts: []corev1.VolumeMount{{
+			Name:      metricsServingCertVolumeName,
+			MountPath: metricsServingCertMountPath,
+			ReadOnly:  true,
+		}},
+	})
+}
+
+// applyMetricsService applies the service asset of a pod serving metrics, pointing its metrics port at the
+// kube-rbac-proxy sidecar of the pod when one is configured in HiveConfig.
+func applyMetricsService(hLog log.FieldLogger, h hiveresource.Helper, assetPath, namespace string, hiveconfig *hivev1.HiveConfig) error {
+	service := resourceread.ReadServiceV1OrDie(assets.MustAsset(assetPath))
+	service.Namespace = namespace
+	if hiveconfig.Spec.Metrics != nil && hiveconfig.Spec.Metrics.KubeRBACProxy != nil {
+		for i := range service.Spec.Ports {
+			if service.Spec.Ports[i].Name == metricsPortName {
+				service.Spec.Ports[i].TargetPort = intstr.FromInt(metricsProxyPort)
+			}
+		}
+		if hiveconfig.Spec.Metrics.KubeRBACProxy.ServingCertSecretRef == nil {
+			if service.Annotations == nil {
+				service.Annotations = map[string]string{}
+			}
+			service.Annotations[servingCertSecretAnnotation] = metricsServingCertSecretName(hiveconfig.Spec.Metrics.KubeRBACProxy, service.Name)
+		}
+	}
+	if _, err := util.ApplyRuntimeObjectWithGC(h, service, hiveconfig); err != nil {
+		hLog.WithError(err).WithField("asset", assetPath).Error("error applying service")
+		return err
+	}
+	hLog.WithField("asset", assetPath).Info("applied metrics service")
+	return nil
+}
+
+func metricsServingCertSecretName(proxy *hivev1.KubeRBACProxyConfig, serviceName string) string {
+	if proxy.ServingCertSecretRef != nil {
+		return proxy.ServingCertSecretRef.Name
+	}
+	return serviceName + metricsServingCertSecretSuffix
+}
@@ -0,0 +1,34 @@
+package hive
+
+import (
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hiveconstants "github.com/openshift/hive/pkg/constants"
+)
+
+// syncSetReapplyInterval returns the interval at which all the SyncSets of a cluster are reapplied, giving precedence
+// to the full apply interval of the periodic syncs over the legacy SyncSetReapplyInterval.
+func syncSetReapplyInterval(instance *hivev1.HiveConfig) string {
+	if periodicSync := instance.Spec.PeriodicSync; periodicSync != nil && periodicSync.ClusterSyncFullApplyInterval != nil {
+		return periodicSync.ClusterSyncFullApplyInterval.Duration.String()
+	}
+	return instance.Spec.SyncSetReapplyInterval
+}
+
+// periodicSyncJitterEnvVars returns the environment variables telling the controllers the maximum jitter of the
+// periodic syncs of each cluster.
+func periodicSyncJitterEnvVars(instance *hivev1.HiveConfig) []corev1.EnvVar {
+	periodicSync := instance.Spec.PeriodicSync
+	if periodicSync == nil || periodicSync.JitterPercent == nil {
+		return nil
+	}
+	return []corev1.EnvVar{
+		{
+			Name:  hiveconstants.PeriodicSyncJitterPercentEnvVar,
+			Value: strconv.Itoa(int(*periodicSync.JitterPercent)),
+		},
+	}
+}
@@ -0,0 +1,55 @@
+package hive
+
+import (
+	"encoding/json"
+
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hiveconstants "github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/remoteclient"
+)
+
+// restrictedRemoteAccess returns whether the HiveConfig limits Hive to the Restricted remote access RBAC mode.
+func restrictedRemoteAccess(instance *hivev1.HiveConfig) bool {
+	return instance.Spec.ScopedRemoteAccess == hivev1.ScopedRemoteAccessEnabled &&
+		instance.Spec.RemoteAccessRBAC != nil &&
+		instance.Spec.RemoteAccessRBAC.Mode == hivev1.RemoteAccessRBACModeRestricted
+}
+
+// remoteAccessRBACEnvVars returns the environment variables which configure the Restricted remote access RBAC mode
+// in the Hive controllers.
+func remoteAccessRBACEnvVars(hLog log.FieldLogger, instance *hivev1.HiveConfig) []corev1.EnvVar {
+	if !restrictedRemoteAccess(instance) {
+		return nil
+	}
+	groups := instance.Spec.RemoteAccessRBAC.SyncSetAPIGroups
+	if groups == nil {
+		groups = []string{}
+	}
+	groupsJSON, err := json.Marshal(groups)
+	if err != nil {
+		hLog.WithError(err).Error("could not marshal the syncset API groups of the restricted remote access RBAC mode")
+	}
+	return []corev1.EnvVar{
+		{
+			Name:  hiveconstants.RemoteAccessRBACModeEnvVar,
+			Value: string(hivev1.RemoteAccessRBACModeRestricted),
+		},
+		{
+			Name:  hiveconstants.RemoteAccessSyncSetAPIGroupsEnvVar,
+			Value: string(groupsJSON),
+		},
+	}
+}
+
+// disabledFeatures returns the sorted names of the Hive features which cannot work with the permissions granted to
+// Hive in installed clusters by the remote access RBAC mode of the HiveConfig.
+func disabledFeatures(instance *hivev1.HiveConfig) []string {
+	if !restrictedRemoteAccess(instance) {
+		return nil
+	}
+	return remoteclient.RestrictedDisabledFeatures(instance.Spec.RemoteAccessRBAC.SyncSetAPIGroups)
+}
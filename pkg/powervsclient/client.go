@@ -0,0 +1,288 @@
+package powervsclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/openshift/hive/pkg/constants"
+)
+
+//go:generate mockgen -source=./client.go -destination=./mock/client_generated.go -package=mock
+
+// Client is a wrapper object for the IBM Cloud and Power Virtual Server REST APIs to allow for easier
+// mocking/testing. A Client is scoped to a single PowerVS service instance.
+type Client interface {
+	// GetServiceInstance gets the resource controller record for the client's PowerVS service instance.
+	GetServiceInstance() (*ServiceInstance, error)
+
+	// ListNetworks lists the networks in the service instance.
+	ListNetworks() ([]Network, error)
+
+	// ListInstances lists the PVM instances in the service instance.
+	ListInstances() ([]Instance, error)
+
+	// DeleteInstance deletes the PVM instance with the given ID.
+	DeleteInstance(instanceID string) error
+}
+
+// ServiceInstance is the subset of the IBM Cloud resource instance used by hive.
+type ServiceInstance struct {
+	GUID     string `json:"guid"`
+	CRN      string `json:"crn"`
+	Name     string `json:"name"`
+	State    string `json:"state"`
+	RegionID string `json:"region_id"`
+}
+
+// Network is the subset of the PowerVS network resource used by hive.
+type Network struct {
+	NetworkID string `json:"networkID"`
+	Name      string `json:"name"`
+	Type      string `json:"type"`
+}
+
+// Instance is the subset of the PowerVS PVM instance resource used by hive.
+type Instance struct {
+	PVMInstanceID string `json:"pvmInstanceID"`
+	ServerName    string `json:"serverName"`
+	Status        string `json:"status"`
+}
+
+const (
+	// ServiceInstanceStateActive is the state of a provisioned service instance that is ready for use.
+	ServiceInstanceStateActive = "active"
+
+	iamTokenEndpoint           = "https://iam.cloud.ibm.com/identity/token"
+	resourceControllerEndpoint = "https://resource-controller.cloud.ibm.com/v2/resource_instances"
+
+	defaultCallTimeout = 2 * time.Minute
+	// tokenExpiryMargin is how long before the IAM token expires that we fetch a new one.
+	tokenExpiryMargin = time.Minute
+)
+
+// Error is returned for any non-2xx response from the IBM Cloud APIs.
+type Error struct {
+	StatusCode  int
+	Description string `json:"description"`
+	Message     string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	msg := e.Description
+	if msg == "" {
+		msg = e.Message
+	}
+	return fmt.Sprintf("IBM Cloud API error (%d): %s", e.StatusCode, msg)
+}
+
+// IsNotFound returns true if the error is an IBM Cloud API error indicating the resource does not exist.
+func IsNotFound(err error) bool {
+	apiErr, ok := errors.Cause(err).(*Error)
+	return ok && apiErr.StatusCode == http.StatusNotFound
+}
+
+// IsUnauthorized returns true if the error is an IBM Cloud API error indicating that the API key could not
+// authenticate or is not authorized to access the resource.
+func IsUnauthorized(err error) bool {
+	apiErr, ok := errors.Cause(err).(*Error)
+	return ok && (apiErr.StatusCode == http.StatusUnauthorized || apiErr.StatusCode == http.StatusForbidden)
+}
+
+type powerVSClient struct {
+	apiKey            string
+	region            string
+	serviceInstanceID string
+	httpClient        *http.Client
+
+	mutex       sync.Mutex
+	token       string
+	tokenExpiry time.Time
+	crn         string
+}
+
+// NewClient creates our client wrapper object for interacting with the PowerVS service instance in the
+// given region.
+func NewClient(apiKey, region, serviceInstanceID string) (Client, error) {
+	if apiKey == "" {
+		return nil, errors.New("API key is required")
+	}
+	if region == "" {
+		return nil, errors.New("region is required")
+	}
+	if serviceInstanceID == "" {
+		return nil, errors.New("service instance ID is required")
+	}
+	return &powerVSClient{
+		apiKey:            apiKey,
+		region:            region,
+		serviceInstanceID: serviceInstanceID,
+		httpClient:        &http.Client{},
+	}, nil
+}
+
+// NewClientFromSecret creates our client wrapper object for interacting with PowerVS. The API key is read from
+// the specified secret.
+func NewClientFromSecret(secret *corev1.Secret, region, serviceInstanceID string) (Client, error) {
+	apiKey, ok := secret.Data[constants.PowerVSAPIKeySecretKey]
+	if !ok {
+		return nil, fmt.Errorf("secret %s is missing %s", secret.Name, constants.PowerVSAPIKeySecretKey)
+	}
+	return NewClient(strings.TrimSpace(string(apiKey)), region, serviceInstanceID)
+}
+
+func (c *powerVSClient) powerEndpoint() string {
+	return fmt.Sprintf("https://%s.power-iaas.cloud.ibm.com/pcloud/v1/cloud-instances/%s", c.region, url.PathEscape(c.serviceInstanceID))
+}
+
+func (c *powerVSClient) GetServiceInstance() (*ServiceInstance, error) {
+	instance := &ServiceInstance{}
+	err := c.do(http.MethodGet, resourceControllerEndpoint+"/"+url.PathEscape(c.serviceInstanceID), nil, instance, false)
+	return instance, err
+}
+
+func (c *powerVSClient) ListNetworks() ([]Network, error) {
+	resp := struct {
+		Networks []Network `json:"networks"`
+	}{}
+	err := c.do(http.MethodGet, c.powerEndpoint()+"/networks", nil, &resp, true)
+	return resp.Networks, err
+}
+
+func (c *powerVSClient) ListInstances() ([]Instance, error) {
+	resp := struct {
+		PVMInstances []Instance `json:"pvmInstances"`
+	}{}
+	err := c.do(http.MethodGet, c.powerEndpoint()+"/pvm-instances", nil, &resp, true)
+	return resp.PVMInstances, err
+}
+
+func (c *powerVSClient) DeleteInstance(instanceID string) error {
+	return c.do(http.MethodDelete, c.powerEndpoint()+"/pvm-instances/"+url.PathEscape(instanceID), nil, nil, true)
+}
+
+// getToken returns a cached IAM bearer token, exchanging the API key for a new one when needed.
+func (c *powerVSClient) getToken() (string, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.token != "" && time.Now().Add(tokenExpiryMargin).Before(c.tokenExpiry) {
+		return c.token, nil
+	}
+
+	form := url.Values{
+		"grant_type": []string{"urn:ibm:params:oauth:grant-type:apikey"},
+		"apikey":     []string{c.apiKey},
+	}
+	ctx, cancel := context.WithTimeout(context.TODO(), defaultCallTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, iamTokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	body, err := c.send(req)
+	if err != nil {
+		return "", errors.Wrap(err, "could not get IAM token")
+	}
+	token := struct {
+		AccessToken string `json:"access_token"`
+		Expiration  int64  `json:"expiration"`
+	}{}
+	if err := json.Unmarshal(body, &token); err != nil {
+		return "", errors.Wrap(err, "could not decode IAM token")
+	}
+	c.token = token.AccessToken
+	c.tokenExpiry = time.Unix(token.Expiration, 0)
+	return c.token, nil
+}
+
+// getCRN returns the CRN of the service instance, which the PowerVS API requires on every request.
+func (c *powerVSClient) getCRN() (string, error) {
+	c.mutex.Lock()
+	crn := c.crn
+	c.mutex.Unlock()
+	if crn != "" {
+		return crn, nil
+	}
+	instance, err := c.GetServiceInstance()
+	if err != nil {
+		return "", errors.Wrap(err, "could not look up service instance")
+	}
+	c.mutex.Lock()
+	c.crn = instance.CRN
+	c.mutex.Unlock()
+	return instance.CRN, nil
+}
+
+func (c *powerVSClient) do(method, endpoint string, in, out interface{}, power bool) error {
+	var reader io.Reader
+	if in != nil {
+		payload, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(payload)
+	}
+	token, err := c.getToken()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.TODO(), defaultCallTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Accept", "application/json")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if power {
+		crn, err := c.getCRN()
+		if err != nil {
+			return err
+		}
+		req.Header.Set("CRN", crn)
+	}
+
+	body, err := c.send(req)
+	if err != nil {
+		return err
+	}
+	if out != nil && len(body) > 0 {
+		return errors.Wrap(json.Unmarshal(body, out), "could not decode response")
+	}
+	return nil
+}
+
+func (c *powerVSClient) send(req *http.Request) ([]byte, error) {
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		apiErr := &Error{StatusCode: resp.StatusCode}
+		json.Unmarshal(body, apiErr)
+		return body, apiErr
+	}
+	return body, nil
+}
@@ -0,0 +1,55 @@
+package powervsclient
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// PrerequisiteError is returned when a resource required for installing a PowerVS cluster is missing or unusable.
+type PrerequisiteError struct {
+	Message string
+}
+
+func (e *PrerequisiteError) Error() string {
+	return e.Message
+}
+
+// IsPrerequisiteError returns true if the error indicates an unmet install prerequisite.
+func IsPrerequisiteError(err error) bool {
+	_, ok := errors.Cause(err).(*PrerequisiteError)
+	return ok
+}
+
+func prerequisiteErrorf(format string, args ...interface{}) error {
+	return &PrerequisiteError{Message: fmt.Sprintf(format, args...)}
+}
+
+// ValidatePrerequisites checks that the resources a PowerVS cluster install depends on exist before provisioning
+// begins: the service instance must be active in the expected zone and, if one is named, the network must exist
+// in the service instance.
+func ValidatePrerequisites(client Client, zone, networkName string) error {
+	instance, err := client.GetServiceInstance()
+	if err != nil {
+		return err
+	}
+	if instance.State != ServiceInstanceStateActive {
+		return prerequisiteErrorf("service instance %s is in state %q, expected %q", instance.GUID, instance.State, ServiceInstanceStateActive)
+	}
+	if zone != "" && instance.RegionID != "" && instance.RegionID != zone {
+		return prerequisiteErrorf("service instance %s is in zone %q, not %q", instance.GUID, instance.RegionID, zone)
+	}
+	if networkName == "" {
+		return nil
+	}
+	networks, err := client.ListNetworks()
+	if err != nil {
+		return err
+	}
+	for _, network := range networks {
+		if network.Name == networkName {
+			return nil
+		}
+	}
+	return prerequisiteErrorf("network %q not found in service instance %s", networkName, instance.GUID)
+}
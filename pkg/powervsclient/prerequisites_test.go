@@ -0,0 +1,87 @@
+package powervsclient_test
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/hive/pkg/powervsclient"
+	"github.com/openshift/hive/pkg/powervsclient/mock"
+)
+
+func TestValidatePrerequisites(t *testing.T) {
+	cases := []struct {
+		name        string
+		zone        string
+		networkName string
+		instance    *powervsclient.ServiceInstance
+		networks    []powervsclient.Network
+		expectErr   bool
+	}{
+		{
+			name:     "active instance",
+			zone:     "dal12",
+			instance: testServiceInstance(powervsclient.ServiceInstanceStateActive),
+		},
+		{
+			name:      "inactive instance",
+			zone:      "dal12",
+			instance:  testServiceInstance("provisioning"),
+			expectErr: true,
+		},
+		{
+			name:      "instance in wrong zone",
+			zone:      "lon04",
+			instance:  testServiceInstance(powervsclient.ServiceInstanceStateActive),
+			expectErr: true,
+		},
+		{
+			name:        "network exists",
+			zone:        "dal12",
+			networkName: "cluster-net",
+			instance:    testServiceInstance(powervsclient.ServiceInstanceStateActive),
+			networks: []powervsclient.Network{
+				{NetworkID: "1", Name: "other-net"},
+				{NetworkID: "2", Name: "cluster-net"},
+			},
+		},
+		{
+			name:        "network missing",
+			zone:        "dal12",
+			networkName: "cluster-net",
+			instance:    testServiceInstance(powervsclient.ServiceInstanceStateActive),
+			networks: []powervsclient.Network{
+				{NetworkID: "1", Name: "other-net"},
+			},
+			expectErr: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			client := mock.NewMockClient(mockCtrl)
+			client.EXPECT().GetServiceInstance().Return(tc.instance, nil)
+			if tc.networks != nil {
+				client.EXPECT().ListNetworks().Return(tc.networks, nil)
+			}
+
+			err := powervsclient.ValidatePrerequisites(client, tc.zone, tc.networkName)
+			if tc.expectErr {
+				assert.True(t, powervsclient.IsPrerequisiteError(err), "expected prerequisite error, got %v", err)
+			} else {
+				assert.NoError(t, err, "unexpected prerequisites validation failure")
+			}
+		})
+	}
+}
+
+func testServiceInstance(state string) *powervsclient.ServiceInstance {
+	return &powervsclient.ServiceInstance{
+		GUID:     "fake-guid",
+		CRN:      "crn:v1:bluemix:public:power-iaas:dal12:a/fake:fake-guid::",
+		State:    state,
+		RegionID: "dal12",
+	}
+}
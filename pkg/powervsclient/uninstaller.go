@@ -0,0 +1,62 @@
+package powervsclient
+
+import (
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// ClusterUninstaller removes the PowerVS resources created by the installer for a cluster. The installer names
+// every PVM instance it creates with the cluster infra ID as a prefix, which is what we key off of here.
+type ClusterUninstaller struct {
+	Client  Client
+	InfraID string
+	Logger  log.FieldLogger
+
+	// PollInterval is how often to check whether deleted instances are gone. Defaults to 15 seconds.
+	PollInterval time.Duration
+	// Timeout is how long to wait for all instances to be deleted. Defaults to 15 minutes.
+	Timeout time.Duration
+}
+
+// Run deletes all cluster instances and waits for them to be gone.
+func (o *ClusterUninstaller) Run() error {
+	if o.InfraID == "" {
+		return errors.New("infra ID is required")
+	}
+	interval, timeout := o.PollInterval, o.Timeout
+	if interval == 0 {
+		interval = 15 * time.Second
+	}
+	if timeout == 0 {
+		timeout = 15 * time.Minute
+	}
+	return wait.PollImmediate(interval, timeout, func() (bool, error) {
+		instances, err := o.Client.ListInstances()
+		if err != nil {
+			o.Logger.WithError(err).Warn("failed to list instances")
+			return false, nil
+		}
+		remaining := 0
+		for _, instance := range instances {
+			if !strings.HasPrefix(instance.ServerName, o.InfraID+"-") {
+				continue
+			}
+			remaining++
+			logger := o.Logger.WithField("instance", instance.ServerName)
+			logger.Info("deleting instance")
+			if err := o.Client.DeleteInstance(instance.PVMInstanceID); err != nil && !IsNotFound(err) {
+				logger.WithError(err).Warn("failed to delete instance")
+			}
+		}
+		if remaining > 0 {
+			o.Logger.WithField("remaining", remaining).Info("waiting for instances to be deleted")
+			return false, nil
+		}
+		o.Logger.Info("all cluster instances deleted")
+		return true, nil
+	})
+}
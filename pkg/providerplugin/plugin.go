@@ -0,0 +1,145 @@
+// Package providerplugin calls the external executables which implement hibernation and machine management for
+// platforms that Hive does not support natively.
+//
+// A plugin is run once per operation. The name of the operation is appended to the command of the plugin, the Request
+// is written to its standard input as JSON, and the Response is read from its standard output as JSON. The plugin
+// reports a failure by exiting with a non-zero status, with the reason on its standard error.
+package providerplugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	machineapi "github.com/openshift/machine-api-operator/pkg/apis/machine/v1beta1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+const (
+	// OperationStopMachines stops the machines of the cluster.
+	OperationStopMachines = "stop-machines"
+	// OperationStartMachines starts the machines of the cluster.
+	OperationStartMachines = "start-machines"
+	// OperationMachinesRunning reports in Response.Running whether the machines of the cluster are running.
+	OperationMachinesRunning = "machines-running"
+	// OperationMachinesStopped reports in Response.Stopped whether the machines of the cluster are stopped.
+	OperationMachinesStopped = "machines-stopped"
+	// OperationGenerateMachineSets returns in Response.MachineSets the MachineSets of the machine pool.
+	OperationGenerateMachineSets = "generate-machinesets"
+
+	defaultTimeout = 5 * time.Minute
+
+	// maxStderrBytes is how much of the standard error of a failed plugin is included in the returned error.
+	maxStderrBytes = 1024
+)
+
+// Request is written to the standard input of the plugin.
+type Request struct {
+	ClusterDeployment *hivev1.ClusterDeployment `json:"clusterDeployment"`
+	// MachinePool is only set for the generate-machinesets operation.
+	MachinePool *hivev1.MachinePool `json:"machinePool,omitempty"`
+}
+
+// Response is read from the standard output of the plugin.
+type Response struct {
+	// Running is whether the machines are running, for the machines-running operation.
+	Running bool `json:"running,omitempty"`
+	// Stopped is whether the machines are stopped, for the machines-stopped operation.
+	Stopped bool `json:"stopped,omitempty"`
+	// MachineSets are the MachineSets of the machine pool, for the generate-machinesets operation.
+	MachineSets []*machineapi.MachineSet `json:"machineSets,omitempty"`
+	// Wait can be set to true by the generate-machinesets operation when the MachineSets cannot be generated yet, so
+	// that the machine pool is synced again later.
+	Wait bool `json:"wait,omitempty"`
+}
+
+// Plugin is a provider plugin configured in HiveConfig.
+type Plugin struct {
+	Name    string
+	Command []string
+	Timeout time.Duration
+}
+
+// Plugins are the configured provider plugins, by name.
+type Plugins map[string]*Plugin
+
+// Load returns the provider plugins configured for the controller manager through its environment.
+func Load() (Plugins, error) {
+	value := os.Getenv(constants.ProviderPluginsEnvVar)
+	if value == "" {
+		return nil, nil
+	}
+	var config []hivev1.ProviderPlugin
+	if err := json.Unmarshal([]byte(value), &config); err != nil {
+		return nil, errors.Wrapf(err, "could not parse %s", constants.ProviderPluginsEnvVar)
+	}
+	plugins := Plugins{}
+	for _, c := range config {
+		if len(c.Command) == 0 {
+			return nil, errors.Errorf("provider plugin %s has no command", c.Name)
+		}
+		plugin := &Plugin{Name: c.Name, Command: c.Command, Timeout: defaultTimeout}
+		if c.Timeout != nil {
+			plugin.Timeout = c.Timeout.Duration
+		}
+		plugins[c.Name] = plugin
+	}
+	return plugins, nil
+}
+
+// For returns the plugin named in the provider plugin annotation of the ClusterDeployment, and whether the
+// ClusterDeployment names a plugin at all. The returned plugin is nil when the named plugin is not configured.
+func (p Plugins) For(cd *hivev1.ClusterDeployment) (*Plugin, bool) {
+	name, ok := cd.Annotations[constants.ProviderPluginAnnotation]
+	if !ok {
+		return nil, false
+	}
+	return p[name], true
+}
+
+// Call runs the plugin for the operation.
+func (p *Plugin) Call(operation string, request *Request, logger log.FieldLogger) (*Response, error) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not marshal provider plugin request")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), p.Timeout)
+	defer cancel()
+	args := append(append([]string{}, p.Command[1:]...), operation)
+	cmd := exec.CommandContext(ctx, p.Command[0], args...)
+	cmd.Stdin = bytes.NewReader(body)
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	cmd.Stdout, cmd.Stderr = stdout, stderr
+
+	logger = logger.WithField("plugin", p.Name).WithField("operation", operation)
+	logger.Debug("calling provider plugin")
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			err = errors.Errorf("timed out after %v", p.Timeout)
+		}
+		msg := stderr.String()
+		if len(msg) > maxStderrBytes {
+			msg = msg[len(msg)-maxStderrBytes:]
+		}
+		logger.WithError(err).WithField("stderr", msg).Error("provider plugin failed")
+		return nil, errors.Wrapf(err, "provider plugin %s failed to %s: %s", p.Name, operation, strings.TrimSpace(msg))
+	}
+
+	response := &Response{}
+	if out := bytes.TrimSpace(stdout.Bytes()); len(out) > 0 {
+		if err := json.Unmarshal(out, response); err != nil {
+			return nil, errors.Wrapf(err, "could not parse response of provider plugin %s to %s", p.Name, operation)
+		}
+	}
+	return response, nil
+}
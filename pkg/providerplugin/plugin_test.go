@@ -0,0 +1,94 @@
+package providerplugin
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+// testScript is a plugin which reports the machines of the test-cd cluster deployment as running, sleeps for the sleep
+// operation, and fails other operations.
+const testScript = `
+case "$1" in
+machines-running) grep -q '"name":"test-cd"' && echo '{"running": true}' ;;
+sleep) exec sleep 5 ;;
+*) echo "unsupported operation $1" >&2; exit 1 ;;
+esac
+`
+
+func TestCall(t *testing.T) {
+	cases := []struct {
+		name             string
+		operation        string
+		timeout          time.Duration
+		expectErr        string
+		expectedResponse *Response
+	}{
+		{
+			name:             "success",
+			operation:        OperationMachinesRunning,
+			expectedResponse: &Response{Running: true},
+		},
+		{
+			name:      "failure",
+			operation: OperationStopMachines,
+			expectErr: "unsupported operation stop-machines",
+		},
+		{
+			name:      "timeout",
+			operation: "sleep",
+			timeout:   100 * time.Millisecond,
+			expectErr: "timed out",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			timeout := tc.timeout
+			if timeout == 0 {
+				timeout = time.Minute
+			}
+			plugin := &Plugin{Name: "test", Command: []string{"sh", "-c", testScript, "test"}, Timeout: timeout}
+			cd := &hivev1.ClusterDeployment{ObjectMeta: metav1.ObjectMeta{Name: "test-cd"}}
+			response, err := plugin.Call(tc.operation, &Request{ClusterDeployment: cd}, log.New())
+			if tc.expectErr != "" {
+				if assert.Error(t, err, "expected error") {
+					assert.Contains(t, err.Error(), tc.expectErr, "unexpected error")
+				}
+				return
+			}
+			require.NoError(t, err, "unexpected error")
+			assert.Equal(t, tc.expectedResponse, response, "unexpected response")
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	os.Setenv(constants.ProviderPluginsEnvVar, `[{"name":"a","command":["/bin/a"]},{"name":"b","command":["/bin/b","--flag"],"timeout":"1m"}]`)
+	defer os.Unsetenv(constants.ProviderPluginsEnvVar)
+	plugins, err := Load()
+	require.NoError(t, err, "unexpected error")
+	assert.Equal(t, Plugins{
+		"a": {Name: "a", Command: []string{"/bin/a"}, Timeout: defaultTimeout},
+		"b": {Name: "b", Command: []string{"/bin/b", "--flag"}, Timeout: time.Minute},
+	}, plugins, "unexpected plugins")
+
+	annotated := &hivev1.ClusterDeployment{ObjectMeta: metav1.ObjectMeta{
+		Annotations: map[string]string{constants.ProviderPluginAnnotation: "b"},
+	}}
+	plugin, ok := plugins.For(annotated)
+	assert.True(t, ok, "expected cluster deployment to name a plugin")
+	assert.Equal(t, plugins["b"], plugin, "unexpected plugin")
+
+	plugin, ok = plugins.For(&hivev1.ClusterDeployment{})
+	assert.False(t, ok, "expected cluster deployment not to name a plugin")
+	assert.Nil(t, plugin, "expected no plugin")
+}
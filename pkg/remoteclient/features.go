@@ -0,0 +1,60 @@
+package remoteclient
+
+import (
+	"sort"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// Hive features which need permissions in installed clusters beyond those of the Restricted remote access RBAC mode.
+const (
+	HibernationFeature              = "Hibernation"
+	IdentityProvidersFeature        = "IdentityProviders"
+	ControlPlaneCertificatesFeature = "ControlPlaneCertificates"
+	RemoteIngressFeature            = "RemoteIngress"
+	NodeTuningFeature               = "NodeTuning"
+	AdditionalTrustBundleFeature    = "AdditionalTrustBundle"
+	SyncSetSecretMappingsFeature    = "SyncSetSecretMappings"
+)
+
+// restrictedFeatureAPIGroups maps the Hive features which need permissions beyond those of the Restricted remote
+// access RBAC mode to the API groups which SyncSets must be allowed to manage for the feature to work. A nil list
+// means that the feature never works in the Restricted mode.
+var restrictedFeatureAPIGroups = map[string][]string{
+	HibernationFeature:              nil,
+	IdentityProvidersFeature:        {"config.openshift.io"},
+	ControlPlaneCertificatesFeature: {"", "config.openshift.io", "operator.openshift.io"},
+	RemoteIngressFeature:            {"", "operator.openshift.io"},
+	NodeTuningFeature:               {"machineconfiguration.openshift.io"},
+	AdditionalTrustBundleFeature:    {"", "config.openshift.io"},
+	SyncSetSecretMappingsFeature:    {""},
+}
+
+// RestrictedDisabledFeatures returns the sorted names of the Hive features which cannot work in the Restricted remote
+// access RBAC mode when SyncSets can manage the given API groups.
+func RestrictedDisabledFeatures(syncSetAPIGroups []string) []string {
+	allowedGroups := sets.NewString(syncSetAPIGroups...)
+	var disabled []string
+	for feature, groups := range restrictedFeatureAPIGroups {
+		if groups == nil || !allowedGroups.HasAll(groups...) {
+			disabled = append(disabled, feature)
+		}
+	}
+	sort.Strings(disabled)
+	return disabled
+}
+
+// FeatureDisabled returns whether the Hive feature is disabled by the Restricted remote access RBAC mode which the
+// controllers run with. The controllers of a disabled feature leave the installed clusters untouched.
+func FeatureDisabled(feature string) bool {
+	restricted, syncSetAPIGroups := RestrictedRemoteAccess()
+	if !restricted {
+		return false
+	}
+	for _, disabled := range RestrictedDisabledFeatures(syncSetAPIGroups) {
+		if disabled == feature {
+			return true
+		}
+	}
+	return false
+}
@@ -4,19 +4,25 @@ package remoteclient
 
 import (
 	"context"
+	"encoding/json"
+	"os"
 	"time"
 
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 
 	corev1 "k8s.io/api/core/v1"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/client-go/dynamic"
 	kubeclient "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	velerov1 "github.com/heptio/velero/pkg/apis/velero/v1"
 	openshiftapiv1 "github.com/openshift/api/config/v1"
 	routev1 "github.com/openshift/api/route/v1"
 	autoscalingv1 "github.com/openshift/cluster-autoscaler-operator/pkg/apis/autoscaling/v1"
@@ -28,6 +34,10 @@ import (
 	"github.com/openshift/hive/pkg/controller/utils"
 )
 
+// ErrControllerKubeconfigUnavailable is returned by restricted builders when the short-lived controller kubeconfig
+// of the ClusterDeployment has not been minted yet or has expired.
+var ErrControllerKubeconfigUnavailable = errors.New("controller kubeconfig is not available")
+
 // Builder is used to build API clients to the remote cluster
 type Builder interface {
 	// Build will return a static controller-runtime client for the remote cluster.
@@ -57,6 +67,23 @@ type Builder interface {
 // If the ClusterDeployment carries the fake cluster annotation, a fake client will be returned populated with
 // runtime.Objects we need to query for in all our controllers.
 func NewBuilder(c client.Client, cd *hivev1.ClusterDeployment, controllerName hivev1.ControllerName) Builder {
+	if utils.IsFakeCluster(cd) {
+		return &fakeBuilder{
+			urlToUse: activeURL,
+		}
+	}
+	return &builder{
+		c:              c,
+		cd:             cd,
+		controllerName: controllerName,
+		urlToUse:       activeURL,
+		scoped:         os.Getenv(constants.ScopedRemoteAccessEnvVar) == "true",
+	}
+}
+
+// NewAdminBuilder creates a new Builder like NewBuilder, except that the client always connects with the admin
+// kubeconfig of the ClusterDeployment, even when scoped remote access is enabled.
+func NewAdminBuilder(c client.Client, cd *hivev1.ClusterDeployment, controllerName hivev1.ControllerName) Builder {
 	if utils.IsFakeCluster(cd) {
 		return &fakeBuilder{
 			urlToUse: activeURL,
@@ -121,6 +148,40 @@ func connectToRemoteCluster(
 	return
 }
 
+// NewRestrictedBuilder creates a new Builder like NewBuilder, except that the client only connects with the
+// short-lived controller kubeconfig of the ClusterDeployment. Building fails with ErrControllerKubeconfigUnavailable
+// rather than falling back to the admin kubeconfig when the controller kubeconfig is not available.
+func NewRestrictedBuilder(c client.Client, cd *hivev1.ClusterDeployment, controllerName hivev1.ControllerName) Builder {
+	if utils.IsFakeCluster(cd) {
+		return &fakeBuilder{
+			urlToUse: activeURL,
+		}
+	}
+	return &builder{
+		c:              c,
+		cd:             cd,
+		controllerName: controllerName,
+		urlToUse:       activeURL,
+		scoped:         true,
+		noFallback:     true,
+	}
+}
+
+// RestrictedRemoteAccess returns whether Hive is limited to the Restricted remote access RBAC mode in installed
+// clusters, and the API groups of the resources which SyncSets can manage in that mode.
+func RestrictedRemoteAccess() (restricted bool, syncSetAPIGroups []string) {
+	if os.Getenv(constants.ScopedRemoteAccessEnvVar) != "true" ||
+		os.Getenv(constants.RemoteAccessRBACModeEnvVar) != string(hivev1.RemoteAccessRBACModeRestricted) {
+		return false, nil
+	}
+	if groups := os.Getenv(constants.RemoteAccessSyncSetAPIGroupsEnvVar); groups != "" {
+		if err := json.Unmarshal([]byte(groups), &syncSetAPIGroups); err != nil {
+			log.WithError(err).Error("could not parse the syncset API groups of the restricted remote access RBAC mode")
+		}
+	}
+	return true, syncSetAPIGroups
+}
+
 // InitialURL returns the initial API URL for the ClusterDeployment.
 func InitialURL(c client.Client, cd *hivev1.ClusterDeployment) (string, error) {
 
@@ -187,6 +248,11 @@ type builder struct {
 	cd             *hivev1.ClusterDeployment
 	controllerName hivev1.ControllerName
 	urlToUse       int
+	// scoped is whether to connect with the short-lived controller kubeconfig when it is available.
+	scoped bool
+	// noFallback is whether to fail rather than fall back to the admin kubeconfig when the controller kubeconfig is
+	// not available.
+	noFallback bool
 }
 
 const (
@@ -217,6 +283,14 @@ func buildScheme() (*runtime.Scheme, error) {
 		return nil, err
 	}
 
+	if err := velerov1.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+
+	if err := apiextv1.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+
 	return scheme, nil
 }
 
@@ -275,7 +349,13 @@ func (b *builder) UseSecondaryAPIURL() Builder {
 }
 
 func (b *builder) RESTConfig() (*rest.Config, error) {
-	cfg, err := unadulteratedRESTConfig(b.c, b.cd)
+	var cfg *rest.Config
+	var err error
+	if b.scoped {
+		cfg, err = scopedRESTConfig(b.c, b.cd, !b.noFallback)
+	} else {
+		cfg, err = adminRESTConfig(b.c, b.cd)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -293,6 +373,30 @@ func (b *builder) RESTConfig() (*rest.Config, error) {
 }
 
 func unadulteratedRESTConfig(c client.Client, cd *hivev1.ClusterDeployment) (*rest.Config, error) {
+	kubeconfigSecret, err := adminKubeconfigSecret(c, cd)
+	if err != nil {
+		return nil, err
+	}
+	return restConfigFromSecret(kubeconfigSecret)
+}
+
+// adminRESTConfig returns the config for the context of the admin kubeconfig of the ClusterDeployment with which the
+// controllers connect to the cluster.
+func adminRESTConfig(c client.Client, cd *hivev1.ClusterDeployment) (*rest.Config, error) {
+	kubeconfigSecret, err := adminKubeconfigSecret(c, cd)
+	if err != nil {
+		return nil, err
+	}
+	config, err := kubeconfigFromSecret(kubeconfigSecret)
+	if err != nil {
+		return nil, err
+	}
+	contextName, _ := utils.AdminKubeconfigContext(config)
+	kubeConfig := clientcmd.NewDefaultClientConfig(*config, &clientcmd.ConfigOverrides{CurrentContext: contextName})
+	return kubeConfig.ClientConfig()
+}
+
+func adminKubeconfigSecret(c client.Client, cd *hivev1.ClusterDeployment) (*corev1.Secret, error) {
 	kubeconfigSecret := &corev1.Secret{}
 	if err := c.Get(
 		context.Background(),
@@ -301,18 +405,50 @@ func unadulteratedRESTConfig(c client.Client, cd *hivev1.ClusterDeployment) (*re
 	); err != nil {
 		return nil, errors.Wrap(err, "could not get admin kubeconfig secret")
 	}
+	return kubeconfigSecret, nil
+}
+
+// scopedRESTConfig returns the config for the short-lived controller kubeconfig of the ClusterDeployment. When the
+// controller kubeconfig has not been minted yet or has expired, the config falls back to the admin kubeconfig if
+// allowed.
+func scopedRESTConfig(c client.Client, cd *hivev1.ClusterDeployment, fallback bool) (*rest.Config, error) {
+	kubeconfigSecret := &corev1.Secret{}
+	switch err := c.Get(
+		context.Background(),
+		client.ObjectKey{Namespace: cd.Namespace, Name: constants.GetControllerKubeconfigSecretName(cd)},
+		kubeconfigSecret,
+	); {
+	case apierrors.IsNotFound(err):
+		if !fallback {
+			return nil, ErrControllerKubeconfigUnavailable
+		}
+		return adminRESTConfig(c, cd)
+	case err != nil:
+		return nil, errors.Wrap(err, "could not get controller kubeconfig secret")
+	}
+	expiration, err := time.Parse(time.RFC3339, kubeconfigSecret.Annotations[constants.TokenExpirationAnnotation])
+	if err != nil || !time.Now().Before(expiration) {
+		if !fallback {
+			return nil, ErrControllerKubeconfigUnavailable
+		}
+		return adminRESTConfig(c, cd)
+	}
 	return restConfigFromSecret(kubeconfigSecret)
 }
 
 func restConfigFromSecret(kubeconfigSecret *corev1.Secret) (*rest.Config, error) {
-	kubeconfigData, ok := kubeconfigSecret.Data[constants.KubeconfigSecretKey]
-	if !ok {
-		return nil, errors.Errorf("kubeconfig secret does not contain %q data", constants.KubeconfigSecretKey)
-	}
-	config, err := clientcmd.Load(kubeconfigData)
+	config, err := kubeconfigFromSecret(kubeconfigSecret)
 	if err != nil {
 		return nil, err
 	}
 	kubeConfig := clientcmd.NewDefaultClientConfig(*config, &clientcmd.ConfigOverrides{})
 	return kubeConfig.ClientConfig()
 }
+
+func kubeconfigFromSecret(kubeconfigSecret *corev1.Secret) (*clientcmdapi.Config, error) {
+	kubeconfigData, ok := kubeconfigSecret.Data[constants.KubeconfigSecretKey]
+	if !ok {
+		return nil, errors.Errorf("kubeconfig secret does not contain %q data", constants.KubeconfigSecretKey)
+	}
+	return clientcmd.Load(kubeconfigData)
+}
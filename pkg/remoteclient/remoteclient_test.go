@@ -3,6 +3,7 @@ package remoteclient
 import (
 	"context"
 	"io/ioutil"
+	"os"
 	"path/filepath"
 	"testing"
 	"time"
@@ -17,6 +18,7 @@ import (
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/controller/utils"
 	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
 )
 
@@ -25,6 +27,23 @@ const (
 	testKubeconfigSecretName                       = "test-kubeconfig"
 	apiURL                                         = "https://api.hive-cluster.example.com:6443"
 	testControllerName       hivev1.ControllerName = "test-controller-name"
+
+	testControllerKubeconfig = `clusters:
+- cluster:
+    insecure-skip-tls-verify: true
+    server: https://api.hive-cluster.example.com:6443
+  name: hive-cluster
+contexts:
+- context:
+    cluster: hive-cluster
+    user: hive-controller
+  name: hive-controller
+current-context: hive-controller
+users:
+- name: hive-controller
+  user:
+    token: test-token
+`
 )
 
 func TestNewBuilder(t *testing.T) {
@@ -135,6 +154,149 @@ func Test_builder_RESTConfig(t *testing.T) {
 	}
 }
 
+func Test_builder_RESTConfig_scoped(t *testing.T) {
+	cases := []struct {
+		name                 string
+		controllerKubeconfig bool
+		expiration           string
+		admin                bool
+		restricted           bool
+		expectedToken        string
+		expectedErr          error
+	}{
+		{
+			name: "no controller kubeconfig",
+		},
+		{
+			name:                 "valid controller kubeconfig",
+			controllerKubeconfig: true,
+			expiration:           time.Now().Add(time.Hour).Format(time.RFC3339),
+			expectedToken:        "test-token",
+		},
+		{
+			name:                 "expired controller kubeconfig",
+			controllerKubeconfig: true,
+			expiration:           time.Now().Add(-time.Minute).Format(time.RFC3339),
+		},
+		{
+			name:                 "controller kubeconfig without expiration",
+			controllerKubeconfig: true,
+		},
+		{
+			name:                 "admin builder",
+			controllerKubeconfig: true,
+			expiration:           time.Now().Add(time.Hour).Format(time.RFC3339),
+			admin:                true,
+		},
+		{
+			name:        "restricted builder without controller kubeconfig",
+			restricted:  true,
+			expectedErr: ErrControllerKubeconfigUnavailable,
+		},
+		{
+			name:                 "restricted builder with expired controller kubeconfig",
+			controllerKubeconfig: true,
+			expiration:           time.Now().Add(-time.Minute).Format(time.RFC3339),
+			restricted:           true,
+			expectedErr:          ErrControllerKubeconfigUnavailable,
+		},
+		{
+			name:                 "restricted builder with valid controller kubeconfig",
+			controllerKubeconfig: true,
+			expiration:           time.Now().Add(time.Hour).Format(time.RFC3339),
+			restricted:           true,
+			expectedToken:        "test-token",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			os.Setenv(constants.ScopedRemoteAccessEnvVar, "true")
+			defer os.Unsetenv(constants.ScopedRemoteAccessEnvVar)
+			cd := testClusterDeployment()
+			objs := []runtime.Object{cd, testKubeconfigSecret(t)}
+			if tc.controllerKubeconfig {
+				secret := &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: testNamespace,
+						Name:      constants.GetControllerKubeconfigSecretName(cd),
+					},
+					Data: map[string][]byte{constants.KubeconfigSecretKey: []byte(testControllerKubeconfig)},
+				}
+				if tc.expiration != "" {
+					secret.Annotations = map[string]string{constants.TokenExpirationAnnotation: tc.expiration}
+				}
+				objs = append(objs, secret)
+			}
+			c := fakeClient(objs...)
+			builder := NewBuilder(c, cd, testControllerName)
+			if tc.admin {
+				builder = NewAdminBuilder(c, cd, testControllerName)
+			}
+			if tc.restricted {
+				builder = NewRestrictedBuilder(c, cd, testControllerName)
+			}
+			cfg, err := builder.RESTConfig()
+			if tc.expectedErr != nil {
+				assert.Equal(t, tc.expectedErr, err, "unexpected error getting REST config")
+				return
+			}
+			assert.NoError(t, err, "unexpected error getting REST config")
+			assert.Equal(t, apiURL, cfg.Host, "unexpected host")
+			assert.Equal(t, tc.expectedToken, cfg.BearerToken, "unexpected bearer token")
+		})
+	}
+}
+
+func Test_builder_RESTConfig_adminKubeconfigContext(t *testing.T) {
+	cases := []struct {
+		name            string
+		internal        bool
+		internalContext bool
+		expectedHost    string
+	}{
+		{
+			name:            "external context",
+			internalContext: true,
+			expectedHost:    apiURL,
+		},
+		{
+			name:            "internal context",
+			internal:        true,
+			internalContext: true,
+			expectedHost:    "https://api-int.hive-cluster.example.com:6443",
+		},
+		{
+			name:         "internal context missing from kubeconfig",
+			internal:     true,
+			expectedHost: apiURL,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.internal {
+				os.Setenv(constants.AdminKubeconfigContextEnvVar, string(hivev1.AdminKubeconfigContextInternal))
+				defer os.Unsetenv(constants.AdminKubeconfigContextEnvVar)
+			}
+			cd := testClusterDeployment()
+			kubeconfigSecret := testKubeconfigSecret(t)
+			if tc.internalContext {
+				data, err := utils.AddInternalKubeconfigContext(kubeconfigSecret.Data[constants.KubeconfigSecretKey])
+				if !assert.NoError(t, err, "unexpected error adding internal context") {
+					return
+				}
+				kubeconfigSecret.Data[constants.KubeconfigSecretKey] = data
+			}
+			c := fakeClient(cd, kubeconfigSecret)
+			cfg, err := NewBuilder(c, cd, testControllerName).RESTConfig()
+			assert.NoError(t, err, "unexpected error getting REST config")
+			assert.Equal(t, tc.expectedHost, cfg.Host, "unexpected host")
+			initialURL, err := InitialURL(c, cd)
+			assert.NoError(t, err, "unexpected error getting initial URL")
+			assert.Equal(t, apiURL, initialURL, "unexpected initial URL")
+		})
+	}
+}
+
 func Test_Unreachable(t *testing.T) {
 	probeTime := time.Unix(123456789, 0)
 	cases := []struct {
@@ -281,3 +443,17 @@ func testKubeconfigSecret(t *testing.T) *corev1.Secret {
 		Data: map[string][]byte{constants.KubeconfigSecretKey: kubeconfig},
 	}
 }
+
+func TestFeatureDisabled(t *testing.T) {
+	assert.False(t, FeatureDisabled(HibernationFeature), "unexpected disabled feature without restricted mode")
+
+	os.Setenv(constants.ScopedRemoteAccessEnvVar, "true")
+	defer os.Unsetenv(constants.ScopedRemoteAccessEnvVar)
+	os.Setenv(constants.RemoteAccessRBACModeEnvVar, string(hivev1.RemoteAccessRBACModeRestricted))
+	defer os.Unsetenv(constants.RemoteAccessRBACModeEnvVar)
+	os.Setenv(constants.RemoteAccessSyncSetAPIGroupsEnvVar, `["config.openshift.io"]`)
+	defer os.Unsetenv(constants.RemoteAccessSyncSetAPIGroupsEnvVar)
+	assert.True(t, FeatureDisabled(HibernationFeature), "expected hibernation to be disabled")
+	assert.True(t, FeatureDisabled(RemoteIngressFeature), "expected remote ingress to be disabled")
+	assert.False(t, FeatureDisabled(IdentityProvidersFeature), "unexpected disabled identity providers")
+}
@@ -0,0 +1,209 @@
+package syncagent
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/hive/apis"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/controller/clustersync"
+	"github.com/openshift/hive/pkg/resource"
+)
+
+const (
+	// requeueInterval is how long the agent waits before syncing again when the sync asks to be requeued right away.
+	requeueInterval = 10 * time.Second
+)
+
+// SyncAgentOptions contains options for running the sync agent
+type SyncAgentOptions struct {
+	ClusterDeploymentName      string
+	ClusterDeploymentNamespace string
+	HubKubeconfig              string
+	LogLevel                   string
+	log                        log.FieldLogger
+	hubClient                  client.Client
+	resourceHelper             resource.Helper
+}
+
+// NewSyncAgentCommand returns a command which runs in a cluster to apply the SyncSets and SelectorSyncSets pulled
+// from Hive to the cluster.
+func NewSyncAgentCommand() *cobra.Command {
+	opt := &SyncAgentOptions{}
+	cmd := &cobra.Command{
+		Use:   "sync-agent OPTIONS",
+		Short: "Pulls the SyncSets of a clusterdeployment from Hive and applies them to the cluster it runs in",
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := opt.Complete(); err != nil {
+				log.WithError(err).Fatal("cannot complete command")
+				return
+			}
+
+			if err := opt.Validate(); err != nil {
+				log.WithError(err).Fatal("invalid command options")
+				return
+			}
+
+			if err := opt.Run(); err != nil {
+				log.WithError(err).Fatal("sync agent failed")
+			}
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&opt.LogLevel, "log-level", "info", "log level, one of: debug, info, warn, error, fatal, panic")
+	flags.StringVar(&opt.HubKubeconfig, "hub-kubeconfig", "", "path to the kubeconfig used to connect to Hive")
+	flags.StringVar(&opt.ClusterDeploymentName, "cluster-deployment-name", "", "name of the ClusterDeployment of the cluster")
+	flags.StringVar(&opt.ClusterDeploymentNamespace, "cluster-deployment-namespace", "", "namespace of the ClusterDeployment of the cluster")
+	return cmd
+}
+
+// Complete sets remaining fields on the SyncAgentOptions based on command options and arguments.
+func (o *SyncAgentOptions) Complete() error {
+	// Set log level
+	level, err := log.ParseLevel(o.LogLevel)
+	if err != nil {
+		log.WithError(err).Error("cannot parse log level")
+		return err
+	}
+
+	o.log = log.NewEntry(&log.Logger{
+		Out: os.Stdout,
+		Formatter: &log.TextFormatter{
+			FullTimestamp: true,
+		},
+		Hooks: make(log.LevelHooks),
+		Level: level,
+	}).WithField("clusterDeployment", types.NamespacedName{Namespace: o.ClusterDeploymentNamespace, Name: o.ClusterDeploymentName})
+
+	if o.HubKubeconfig == "" {
+		// Leave the remaining fields unset for Validate to report the missing flag
+		return nil
+	}
+	hubConfig, err := clientcmd.BuildConfigFromFlags("", o.HubKubeconfig)
+	if err != nil {
+		log.WithError(err).Error("Cannot obtain hub client config")
+		return err
+	}
+	clientScheme := scheme.Scheme
+	apis.AddToScheme(clientScheme)
+	o.hubClient, err = client.New(hubConfig, client.Options{Scheme: clientScheme})
+	if err != nil {
+		log.WithError(err).Error("Cannot obtain hub API client")
+		return err
+	}
+
+	localConfig, err := rest.InClusterConfig()
+	if err != nil {
+		log.WithError(err).Error("Cannot obtain in-cluster client config")
+		return err
+	}
+	o.resourceHelper, err = resource.NewHelperFromRESTConfig(localConfig, o.log)
+	if err != nil {
+		log.WithError(err).Error("Cannot create resource helper")
+		return err
+	}
+	return nil
+}
+
+// Validate ensures the given options and arguments are valid.
+func (o *SyncAgentOptions) Validate() error {
+	if o.HubKubeconfig == "" {
+		return fmt.Errorf("--hub-kubeconfig is required")
+	}
+	if o.ClusterDeploymentName == "" {
+		return fmt.Errorf("--cluster-deployment-name is required")
+	}
+	if o.ClusterDeploymentNamespace == "" {
+		return fmt.Errorf("--cluster-deployment-namespace is required")
+	}
+	return nil
+}
+
+// Run pulls the state published by Hive and applies it to the cluster until the process is stopped.
+func (o *SyncAgentOptions) Run() error {
+	o.log.Info("starting sync agent")
+	for {
+		wait, err := o.sync()
+		if err != nil {
+			o.log.WithError(err).Error("sync failed")
+		}
+		o.log.WithField("wait", wait).Debug("waiting for next sync")
+		time.Sleep(wait)
+	}
+}
+
+// sync applies the state published by Hive once, and returns how long to wait until the next sync.
+func (o *SyncAgentOptions) sync() (time.Duration, error) {
+	state, err := o.getState()
+	if err != nil {
+		return requeueInterval, err
+	}
+	wait := clustersync.SyncAgentPollInterval(state.ClusterDeployment)
+	result, err := clustersync.ApplySyncAgentState(o.hubClient, state, o.resourceHelper, o.log)
+	if err != nil {
+		return wait, err
+	}
+	if result.Requeue && result.RequeueAfter == 0 {
+		return requeueInterval, nil
+	}
+	return wait, nil
+}
+
+// getState gets the state published by Hive for the agent, which is split into shards held by several secrets. The
+// state is read again on the next sync when the shards read belong to different states.
+func (o *SyncAgentOptions) getState() (*clustersync.SyncAgentState, error) {
+	cd := &hivev1.ClusterDeployment{ObjectMeta: metav1.ObjectMeta{Namespace: o.ClusterDeploymentNamespace, Name: o.ClusterDeploymentName}}
+	var stateData []byte
+	shards, hash := 1, ""
+	for i := 0; i < shards; i++ {
+		secret := &corev1.Secret{}
+		if err := o.hubClient.Get(
+			context.Background(),
+			types.NamespacedName{Namespace: o.ClusterDeploymentNamespace, Name: constants.GetSyncAgentStateShardName(cd, i)},
+			secret,
+		); err != nil {
+			return nil, errors.Wrap(err, "could not get sync agent state")
+		}
+		if i == 0 {
+			if n, err := strconv.Atoi(string(secret.Data[constants.SyncAgentStateShardsSecretKey])); err == nil && n > 0 {
+				shards = n
+			}
+			hash = string(secret.Data[constants.SyncAgentStateHashSecretKey])
+		}
+		stateData = append(stateData, secret.Data[constants.SyncAgentStateSecretKey]...)
+	}
+	if hash != "" {
+		if sum := sha256.Sum256(stateData); hex.EncodeToString(sum[:]) != hash {
+			return nil, errors.New("sync agent state changed while it was read")
+		}
+	}
+	state := &clustersync.SyncAgentState{}
+	if err := json.Unmarshal(stateData, state); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal sync agent state")
+	}
+	if state.ClusterDeployment == nil {
+		return nil, errors.New("sync agent state is missing the clusterdeployment")
+	}
+	return state, nil
+}
@@ -111,3 +111,27 @@ func WithLifetime(lifetime time.Duration) Option {
 		clusterClaim.Spec.Lifetime = &metav1.Duration{Duration: lifetime}
 	}
 }
+
+func WithIdleTimeout(idleTimeout time.Duration) Option {
+	return func(clusterClaim *hivev1.ClusterClaim) {
+		clusterClaim.Spec.IdleTimeout = &metav1.Duration{Duration: idleTimeout}
+	}
+}
+
+func WithLastActivityTime(lastActivityTime time.Time) Option {
+	return func(clusterClaim *hivev1.ClusterClaim) {
+		clusterClaim.Status.LastActivityTime = &metav1.Time{Time: lastActivityTime}
+	}
+}
+
+func WithActivationTime(activationTime time.Time) Option {
+	return func(clusterClaim *hivev1.ClusterClaim) {
+		clusterClaim.Spec.ActivationTime = &metav1.Time{Time: activationTime}
+	}
+}
+
+func WithPriority(priority int32) Option {
+	return func(clusterClaim *hivev1.ClusterClaim) {
+		clusterClaim.Spec.Priority = priority
+	}
+}
@@ -104,6 +104,16 @@ func WithCondition(cond hivev1.ClusterDeploymentCondition) Option {
 	}
 }
 
+// WithReadinessGates sets readiness gates for the specified condition types on the ClusterDeployment
+func WithReadinessGates(conditionTypes ...hivev1.ClusterDeploymentConditionType) Option {
+	return func(clusterDeployment *hivev1.ClusterDeployment) {
+		for _, t := range conditionTypes {
+			clusterDeployment.Spec.ReadinessGates = append(clusterDeployment.Spec.ReadinessGates,
+				hivev1.ClusterDeploymentReadinessGate{ConditionType: t})
+		}
+	}
+}
+
 func WithUnclaimedClusterPoolReference(namespace, poolName string) Option {
 	return WithClusterPoolReference(namespace, poolName, "")
 }
@@ -153,6 +163,13 @@ func WithHibernateAfter(dur time.Duration) Option {
 	}
 }
 
+// WithSelectorSyncSetExclusions sets the names of the SelectorSyncSets excluded from the cluster.
+func WithSelectorSyncSetExclusions(names ...string) Option {
+	return func(clusterDeployment *hivev1.ClusterDeployment) {
+		clusterDeployment.Spec.SelectorSyncSetExclusions = names
+	}
+}
+
 // WithAWSPlatform sets the specified aws platform on the supplied object.
 func WithAWSPlatform(platform *hivev1aws.Platform) Option {
 	return func(clusterDeployment *hivev1.ClusterDeployment) {
@@ -116,6 +116,12 @@ func WithImageSet(clusterImageSetName string) Option {
 	}
 }
 
+func WithRunningCount(size int) Option {
+	return func(clusterPool *hivev1.ClusterPool) {
+		clusterPool.Spec.RunningCount = int32(size)
+	}
+}
+
 func WithMaxSize(size int) Option {
 	return func(clusterPool *hivev1.ClusterPool) {
 		clusterPool.Spec.MaxSize = pointer.Int32Ptr(int32(size))
@@ -128,6 +134,12 @@ func WithMaxConcurrent(size int) Option {
 	}
 }
 
+func WithMaxClusterAge(d time.Duration) Option {
+	return func(clusterPool *hivev1.ClusterPool) {
+		clusterPool.Spec.MaxClusterAge = &metav1.Duration{Duration: d}
+	}
+}
+
 func WithDefaultClaimLifetime(d time.Duration) Option {
 	return func(clusterPool *hivev1.ClusterPool) {
 		if clusterPool.Spec.ClaimLifetime == nil {
@@ -146,6 +158,70 @@ func WithMaximumClaimLifetime(d time.Duration) Option {
 	}
 }
 
+func WithClaimIdleTimeout(d time.Duration) Option {
+	return func(clusterPool *hivev1.ClusterPool) {
+		if clusterPool.Spec.ClaimLifetime == nil {
+			clusterPool.Spec.ClaimLifetime = &hivev1.ClusterPoolClaimLifetime{}
+		}
+		clusterPool.Spec.ClaimLifetime.IdleTimeout = &metav1.Duration{Duration: d}
+	}
+}
+
+func WithInstallFailureBudget(maxFailures int32, window time.Duration) Option {
+	return func(clusterPool *hivev1.ClusterPool) {
+		clusterPool.Spec.InstallFailureBudget = &hivev1.ClusterPoolInstallFailureBudget{
+			MaxFailures: maxFailures,
+			Window:      metav1.Duration{Duration: window},
+		}
+	}
+}
+
+func WithInstallFailure(clusterProvision string, failedAt time.Time, reason string) Option {
+	return func(clusterPool *hivev1.ClusterPool) {
+		clusterPool.Status.InstallFailures = append(clusterPool.Status.InstallFailures, hivev1.ClusterPoolInstallFailure{
+			ClusterProvision: clusterProvision,
+			Time:             metav1.NewTime(failedAt),
+			Reason:           reason,
+		})
+	}
+}
+
+func WithReadinessGates(conditionTypes ...hivev1.ClusterDeploymentConditionType) Option {
+	return func(clusterPool *hivev1.ClusterPool) {
+		for _, t := range conditionTypes {
+			clusterPool.Spec.ReadinessGates = append(clusterPool.Spec.ReadinessGates,
+				hivev1.ClusterDeploymentReadinessGate{ConditionType: t})
+		}
+	}
+}
+
+func WithReservationLeadTime(d time.Duration) Option {
+	return func(clusterPool *hivev1.ClusterPool) {
+		clusterPool.Spec.ReservationLeadTime = &metav1.Duration{Duration: d}
+	}
+}
+
+func WithPreemptReservedClaims() Option {
+	return func(clusterPool *hivev1.ClusterPool) {
+		clusterPool.Spec.PreemptReservedClaims = true
+	}
+}
+
+func WithReleasePolicy(policy hivev1.ClusterPoolReleasePolicy, rebaselineSyncSets ...string) Option {
+	return func(clusterPool *hivev1.ClusterPool) {
+		clusterPool.Spec.ReleasePolicy = policy
+		for _, name := range rebaselineSyncSets {
+			clusterPool.Spec.RebaselineSyncSets = append(clusterPool.Spec.RebaselineSyncSets, corev1.LocalObjectReference{Name: name})
+		}
+	}
+}
+
+func WithMode(mode hivev1.ClusterPoolMode) Option {
+	return func(clusterPool *hivev1.ClusterPool) {
+		clusterPool.Spec.Mode = mode
+	}
+}
+
 // WithCondition adds the specified condition to the ClusterPool
 func WithCondition(cond hivev1.ClusterPoolCondition) Option {
 	return func(clusterPool *hivev1.ClusterPool) {
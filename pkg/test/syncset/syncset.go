@@ -107,6 +107,12 @@ func WithApplyBehavior(applyBehavior hivev1.SyncSetApplyBehavior) Option {
 	}
 }
 
+func WithApplyAs(namespace, name string) Option {
+	return func(syncSet *hivev1.SyncSet) {
+		syncSet.Spec.ApplyAs = &hivev1.ServiceAccountReference{Namespace: namespace, Name: name}
+	}
+}
+
 func WithResources(objs ...hivev1.MetaRuntimeObject) Option {
 	return func(syncSet *hivev1.SyncSet) {
 		syncSet.Spec.Resources = make([]runtime.RawExtension, len(objs))
@@ -127,3 +133,9 @@ func WithPatches(patches ...hivev1.SyncObjectPatch) Option {
 		syncSet.Spec.Patches = patches
 	}
 }
+
+func WithResourceRefs(refs ...hivev1.SyncSetResourceRef) Option {
+	return func(syncSet *hivev1.SyncSet) {
+		syncSet.Spec.ResourceRefs = refs
+	}
+}
@@ -0,0 +1,188 @@
+// Package cron parses cron expressions and computes the times at which they fire.
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	// The time zone database is embedded so that the time zones of schedules load in images without one.
+	_ "time/tzdata"
+)
+
+// maxSearchDays bounds the search for the times a schedule fires, so that a schedule which fires rarely or never,
+// such as one on February 30th, does not search forever.
+const maxSearchDays = 5 * 366
+
+var (
+	monthNames = map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}
+	dayNames = map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}
+)
+
+// Schedule is a parsed cron expression with the five fields minute, hour, day of month, month and day of week.
+type Schedule struct {
+	minutes, hours, daysOfMonth, months, daysOfWeek uint64
+
+	// When both the day of month and the day of week are restricted, that is not "*", a day matches when either of
+	// them does, as in cron.
+	daysOfMonthRestricted, daysOfWeekRestricted bool
+}
+
+// Parse parses a cron expression. Each field is "*", a value, a range "a-b" or a comma-separated list of them, and
+// can be followed by a step "/n". Months and days of week can also be given by their three-letter English names, and
+// Sunday is either 0 or 7.
+func Parse(expr string) (*Schedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("expected 5 fields, found %d", len(fields))
+	}
+	s := &Schedule{
+		daysOfMonthRestricted: fields[2] != "*",
+		daysOfWeekRestricted:  fields[4] != "*",
+	}
+	var err error
+	if s.minutes, err = parseField(fields[0], 0, 59, nil); err != nil {
+		return nil, fmt.Errorf("invalid minute: %v", err)
+	}
+	if s.hours, err = parseField(fields[1], 0, 23, nil); err != nil {
+		return nil, fmt.Errorf("invalid hour: %v", err)
+	}
+	if s.daysOfMonth, err = parseField(fields[2], 1, 31, nil); err != nil {
+		return nil, fmt.Errorf("invalid day of month: %v", err)
+	}
+	if s.months, err = parseField(fields[3], 1, 12, monthNames); err != nil {
+		return nil, fmt.Errorf("invalid month: %v", err)
+	}
+	if s.daysOfWeek, err = parseField(fields[4], 0, 7, dayNames); err != nil {
+		return nil, fmt.Errorf("invalid day of week: %v", err)
+	}
+	if s.daysOfWeek&(1<<7) != 0 {
+		s.daysOfWeek |= 1
+	}
+	return s, nil
+}
+
+func parseField(field string, min, max int, names map[string]int) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		rangePart, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			var err error
+			rangePart = part[:i]
+			if step, err = strconv.Atoi(part[i+1:]); err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step %q", part[i+1:])
+			}
+		}
+		lo, hi := min, max
+		switch {
+		case rangePart == "*":
+		case strings.Contains(rangePart, "-"):
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var err error
+			if lo, err = parseValue(bounds[0], min, max, names); err != nil {
+				return 0, err
+			}
+			if hi, err = parseValue(bounds[1], min, max, names); err != nil {
+				return 0, err
+			}
+			if lo > hi {
+				return 0, fmt.Errorf("invalid range %q", rangePart)
+			}
+		default:
+			var err error
+			if lo, err = parseValue(rangePart, min, max, names); err != nil {
+				return 0, err
+			}
+			// A single value with a step, such as "5/15", starts a range running to the maximum.
+			if !strings.Contains(part, "/") {
+				hi = lo
+			}
+		}
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+func parseValue(value string, min, max int, names map[string]int) (int, error) {
+	if v, ok := names[strings.ToLower(value)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", value)
+	}
+	if v < min || v > max {
+		return 0, fmt.Errorf("value %d out of range %d-%d", v, min, max)
+	}
+	return v, nil
+}
+
+// Next returns the first time after t at which the schedule fires, in the location of t, or the zero time if the
+// schedule does not fire in the next five years.
+func (s *Schedule) Next(t time.Time) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	for i := 0; i < maxSearchDays; i++ {
+		d := day.AddDate(0, 0, i)
+		if !s.matchesDay(d) {
+			continue
+		}
+		for hour := 0; hour < 24; hour++ {
+			for minute := 0; minute < 60; minute++ {
+				if fires, at := s.firesAt(d, hour, minute); fires && at.After(t) {
+					return at
+				}
+			}
+		}
+	}
+	return time.Time{}
+}
+
+// Prev returns the last time at or before t at which the schedule fired, in the location of t, or the zero time if
+// the schedule did not fire in the last five years.
+func (s *Schedule) Prev(t time.Time) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	for i := 0; i < maxSearchDays; i++ {
+		d := day.AddDate(0, 0, -i)
+		if !s.matchesDay(d) {
+			continue
+		}
+		for hour := 23; hour >= 0; hour-- {
+			for minute := 59; minute >= 0; minute-- {
+				if fires, at := s.firesAt(d, hour, minute); fires && !at.After(t) {
+					return at
+				}
+			}
+		}
+	}
+	return time.Time{}
+}
+
+func (s *Schedule) matchesDay(d time.Time) bool {
+	if s.months&(1<<uint(d.Month())) == 0 {
+		return false
+	}
+	dayOfMonth := s.daysOfMonth&(1<<uint(d.Day())) != 0
+	dayOfWeek := s.daysOfWeek&(1<<uint(d.Weekday())) != 0
+	if s.daysOfMonthRestricted && s.daysOfWeekRestricted {
+		return dayOfMonth || dayOfWeek
+	}
+	return dayOfMonth && dayOfWeek
+}
+
+// firesAt returns whether the schedule fires at the hour and minute of the day, and the time it does. A time skipped
+// by a daylight saving time change does not fire.
+func (s *Schedule) firesAt(d time.Time, hour, minute int) (bool, time.Time) {
+	if s.hours&(1<<uint(hour)) == 0 || s.minutes&(1<<uint(minute)) == 0 {
+		return false, time.Time{}
+	}
+	at := time.Date(d.Year(), d.Month(), d.Day(), hour, minute, 0, 0, d.Location())
+	return at.Hour() == hour && at.Minute() == minute, at
+}
@@ -0,0 +1,116 @@
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	for _, expr := range []string{
+		"* * * * *",
+		"0 19 * * 1-5",
+		"*/15 8-18 * * mon-fri",
+		"5/10 0 1,15 jan,jul *",
+		"0 0 * * 7",
+	} {
+		_, err := Parse(expr)
+		assert.NoError(t, err, "expected %q to parse", expr)
+	}
+	for _, expr := range []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"a * * * *",
+	} {
+		_, err := Parse(expr)
+		assert.Error(t, err, "expected %q not to parse", expr)
+	}
+}
+
+func TestNextAndPrev(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	require.NoError(t, err, "could not load time zone")
+	// Wednesday
+	now := time.Date(2026, 10, 14, 12, 30, 0, 0, time.UTC)
+
+	cases := []struct {
+		name         string
+		expr         string
+		now          time.Time
+		expectedNext time.Time
+		expectedPrev time.Time
+	}{
+		{
+			name:         "every minute",
+			expr:         "* * * * *",
+			now:          now,
+			expectedNext: now.Add(time.Minute),
+			expectedPrev: now,
+		},
+		{
+			name:         "weekday evenings",
+			expr:         "0 19 * * 1-5",
+			now:          now,
+			expectedNext: time.Date(2026, 10, 14, 19, 0, 0, 0, time.UTC),
+			expectedPrev: time.Date(2026, 10, 13, 19, 0, 0, 0, time.UTC),
+		},
+		{
+			name:         "weekend mornings",
+			expr:         "0 7 * * sat,sun",
+			now:          now,
+			expectedNext: time.Date(2026, 10, 17, 7, 0, 0, 0, time.UTC),
+			expectedPrev: time.Date(2026, 10, 11, 7, 0, 0, 0, time.UTC),
+		},
+		{
+			name:         "day of month or day of week",
+			expr:         "0 0 1 * fri",
+			now:          now,
+			expectedNext: time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC),
+			expectedPrev: time.Date(2026, 10, 9, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:         "step from value",
+			expr:         "5/20 12 * * *",
+			now:          now,
+			expectedNext: time.Date(2026, 10, 14, 12, 45, 0, 0, time.UTC),
+			expectedPrev: time.Date(2026, 10, 14, 12, 25, 0, 0, time.UTC),
+		},
+		{
+			name:         "time zone",
+			expr:         "0 19 * * *",
+			now:          now.In(paris),
+			expectedNext: time.Date(2026, 10, 14, 19, 0, 0, 0, paris),
+			expectedPrev: time.Date(2026, 10, 13, 19, 0, 0, 0, paris),
+		},
+		{
+			name:         "time skipped by daylight saving time",
+			expr:         "30 2 * * *",
+			now:          time.Date(2026, 3, 29, 12, 0, 0, 0, paris),
+			expectedNext: time.Date(2026, 3, 30, 2, 30, 0, 0, paris),
+			expectedPrev: time.Date(2026, 3, 28, 2, 30, 0, 0, paris),
+		},
+		{
+			name: "never",
+			expr: "0 0 30 feb *",
+			now:  now,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s, err := Parse(tc.expr)
+			require.NoError(t, err, "unexpected error parsing schedule")
+			assert.True(t, tc.expectedNext.Equal(s.Next(tc.now)), "unexpected next time: %v", s.Next(tc.now))
+			assert.True(t, tc.expectedPrev.Equal(s.Prev(tc.now)), "unexpected previous time: %v", s.Prev(tc.now))
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package webhook
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// NewClient returns the client an admission hook reads other resources with. Discovery is lazy so that the hook can
+// start before the API server is reachable.
+func NewClient(kubeClientConfig *rest.Config, scheme *runtime.Scheme) (client.Client, error) {
+	mapper, err := apiutil.NewDynamicRESTMapper(kubeClientConfig, apiutil.WithLazyDiscovery)
+	if err != nil {
+		return nil, err
+	}
+	return client.New(kubeClientConfig, client.Options{Scheme: scheme, Mapper: mapper})
+}
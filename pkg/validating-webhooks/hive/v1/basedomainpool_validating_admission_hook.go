@@ -0,0 +1,199 @@
+package v1
+
+import (
+	"fmt"
+	"net/http"
+
+	log "github.com/sirupsen/logrus"
+
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/manageddns"
+)
+
+const (
+	baseDomainPoolGroup    = "hive.openshift.io"
+	baseDomainPoolVersion  = "v1"
+	baseDomainPoolResource = "basedomainpools"
+)
+
+// BaseDomainPoolValidatingAdmissionHook is a struct that is used to reference what code should be run by the generic-admission-server.
+type BaseDomainPoolValidatingAdmissionHook struct {
+	decoder *admission.Decoder
+
+	validManagedDomains []string
+}
+
+// NewBaseDomainPoolValidatingAdmissionHook constructs a new BaseDomainPoolValidatingAdmissionHook
+func NewBaseDomainPoolValidatingAdmissionHook(decoder *admission.Decoder) *BaseDomainPoolValidatingAdmissionHook {
+	logger := log.WithField("validatingWebhook", "basedomainpool")
+	managedDomains, err := manageddns.ReadManagedDomainsFile()
+	if err != nil {
+		logger.WithError(err).Fatal("Unable to read managedDomains file")
+	}
+	domains := []string{}
+	for _, md := range managedDomains {
+		domains = append(domains, md.Domains...)
+	}
+	logger.WithField("managedDomains", domains).Info("Read managed domains")
+	return &BaseDomainPoolValidatingAdmissionHook{
+		decoder:             decoder,
+		validManagedDomains: domains,
+	}
+}
+
+// ValidatingResource is called by generic-admission-server on startup to register the returned REST resource through which the
+//                    webhook is accessed by the kube apiserver.
+// For example, generic-admission-server uses the data below to register the webhook on the REST resource "/apis/admission.hive.openshift.io/v1/basedomainpoolvalidators".
+//              When the kube apiserver calls this registered REST resource, the generic-admission-server calls the Validate() method below.
+func (a *BaseDomainPoolValidatingAdmissionHook) ValidatingResource() (plural schema.GroupVersionResource, singular string) {
+	log.WithFields(log.Fields{
+		"group":    "admission.hive.openshift.io",
+		"version":  "v1",
+		"resource": "basedomainpoolvalidator",
+	}).Info("Registering validation REST resource")
+	// NOTE: This GVR is meant to be different than the BaseDomainPool CRD GVR which has group "hive.openshift.io".
+	return schema.GroupVersionResource{
+			Group:    "admission.hive.openshift.io",
+			Version:  "v1",
+			Resource: "basedomainpoolvalidators",
+		},
+		"basedomainpoolvalidator"
+}
+
+// Initialize is called by generic-admission-server on startup to setup any special initialization that your webhook needs.
+func (a *BaseDomainPoolValidatingAdmissionHook) Initialize(kubeClientConfig *rest.Config, stopCh <-chan struct{}) error {
+	log.WithFields(log.Fields{
+		"group":    "admission.hive.openshift.io",
+		"version":  "v1",
+		"resource": "basedomainpoolvalidator",
+	}).Info("Initializing validation REST resource")
+	return nil // No initialization needed right now.
+}
+
+// Validate is called by generic-admission-server when the registered REST resource above is called with an admission request.
+// Usually it's the kube apiserver that is making the admission validation request.
+func (a *BaseDomainPoolValidatingAdmissionHook) Validate(admissionSpec *admissionv1beta1.AdmissionRequest) *admissionv1beta1.AdmissionResponse {
+	contextLogger := log.WithFields(log.Fields{
+		"operation": admissionSpec.Operation,
+		"group":     admissionSpec.Resource.Group,
+		"version":   admissionSpec.Resource.Version,
+		"resource":  admissionSpec.Resource.Resource,
+		"method":    "Validate",
+	})
+
+	if !a.shouldValidate(admissionSpec) {
+		contextLogger.Info("Skipping validation for request")
+		// The request object isn't something that this validator should validate.
+		// Therefore, we say that it's allowed.
+		return &admissionv1beta1.AdmissionResponse{
+			Allowed: true,
+		}
+	}
+
+	contextLogger.Info("Validating request")
+
+	// Creates and updates are validated alike: every parent domain must be managed.
+	if admissionSpec.Operation == admissionv1beta1.Create || admissionSpec.Operation == admissionv1beta1.Update {
+		return a.validateDomains(admissionSpec)
+	}
+
+	// We're only validating creates and updates at this time, so all other operations are explicitly allowed.
+	contextLogger.Info("Successful validation")
+	return &admissionv1beta1.AdmissionResponse{
+		Allowed: true,
+	}
+}
+
+// shouldValidate explicitly checks if the request should validated. For example, this webhook may have accidentally been registered to check
+// the validity of some other type of object with a different GVR.
+func (a *BaseDomainPoolValidatingAdmissionHook) shouldValidate(admissionSpec *admissionv1beta1.AdmissionRequest) bool {
+	contextLogger := log.WithFields(log.Fields{
+		"operation": admissionSpec.Operation,
+		"group":     admissionSpec.Resource.Group,
+		"version":   admissionSpec.Resource.Version,
+		"resource":  admissionSpec.Resource.Resource,
+		"method":    "shouldValidate",
+	})
+
+	if admissionSpec.Resource.Group != baseDomainPoolGroup {
+		contextLogger.Debug("Returning False, not our group")
+		return false
+	}
+
+	if admissionSpec.Resource.Version != baseDomainPoolVersion {
+		contextLogger.Debug("Returning False, it's our group, but not the right version")
+		return false
+	}
+
+	if admissionSpec.Resource.Resource != baseDomainPoolResource {
+		contextLogger.Debug("Returning False, it's our group and version, but not the right resource")
+		return false
+	}
+
+	// If we get here, then we're supposed to validate the object.
+	contextLogger.Debug("Returning True, passed all prerequisites.")
+	return true
+}
+
+// validateDomains validates that the parent domains of a BaseDomainPool are managed domains of the HiveConfig, which
+// ClusterDeployments managing DNS must use a direct subdomain of.
+func (a *BaseDomainPoolValidatingAdmissionHook) validateDomains(admissionSpec *admissionv1beta1.AdmissionRequest) *admissionv1beta1.AdmissionResponse {
+	contextLogger := log.WithFields(log.Fields{
+		"operation": admissionSpec.Operation,
+		"group":     admissionSpec.Resource.Group,
+		"version":   admissionSpec.Resource.Version,
+		"resource":  admissionSpec.Resource.Resource,
+		"method":    "validateDomains",
+	})
+
+	newObject := &hivev1.BaseDomainPool{}
+	if err := a.decoder.DecodeRaw(admissionSpec.Object, newObject); err != nil {
+		contextLogger.Errorf("Failed unmarshaling Object: %v", err.Error())
+		return &admissionv1beta1.AdmissionResponse{
+			Allowed: false,
+			Result: &metav1.Status{
+				Status: metav1.StatusFailure, Code: http.StatusBadRequest, Reason: metav1.StatusReasonBadRequest,
+				Message: err.Error(),
+			},
+		}
+	}
+
+	// Add the new data to the contextLogger
+	contextLogger.Data["object.Name"] = newObject.Name
+
+	managedDomains := sets.NewString(a.validManagedDomains...)
+	seen := sets.NewString()
+	for _, domain := range newObject.Spec.Domains {
+		var message string
+		switch {
+		case seen.Has(domain):
+			message = fmt.Sprintf("domain %s is listed more than once", domain)
+		case !managedDomains.Has(domain):
+			message = fmt.Sprintf("domain %s is not a managed domain of the HiveConfig", domain)
+		}
+		if message != "" {
+			contextLogger.Infof("Failed validation: %v", message)
+			return &admissionv1beta1.AdmissionResponse{
+				Allowed: false,
+				Result: &metav1.Status{
+					Status: metav1.StatusFailure, Code: http.StatusBadRequest, Reason: metav1.StatusReasonBadRequest,
+					Message: message,
+				},
+			}
+		}
+		seen.Insert(domain)
+	}
+
+	// If we get here, then all checks passed, so the object is valid.
+	contextLogger.Info("Successful validation")
+	return &admissionv1beta1.AdmissionResponse{
+		Allowed: true,
+	}
+}
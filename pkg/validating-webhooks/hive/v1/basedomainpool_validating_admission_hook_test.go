@@ -0,0 +1,110 @@
+package v1
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+func TestBaseDomainPoolValidate(t *testing.T) {
+	cases := []struct {
+		name            string
+		domains         []string
+		newObjectRaw    []byte
+		operation       admissionv1beta1.Operation
+		expectedAllowed bool
+		gvr             *metav1.GroupVersionResource
+	}{
+		{
+			name:            "Test managed domains on create",
+			domains:         []string{"pool1.example.com", "pool2.example.com"},
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name:            "Test managed domains on update",
+			domains:         []string{"pool1.example.com"},
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: true,
+		},
+		{
+			name:            "Test unmanaged domain",
+			domains:         []string{"pool1.example.com", "pool.unmanaged.com"},
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test subdomain of a managed domain",
+			domains:         []string{"sub.pool1.example.com"},
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test duplicate domain",
+			domains:         []string{"pool1.example.com", "pool1.example.com"},
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test unable to marshal new object during create",
+			newObjectRaw:    []byte{0},
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test that we don't validate deletes",
+			operation:       admissionv1beta1.Delete,
+			expectedAllowed: true,
+		},
+		{
+			name:    "Test doesn't validate with right group and version, wrong resource",
+			domains: []string{"pool.unmanaged.com"},
+			gvr: &metav1.GroupVersionResource{
+				Group:    "hive.openshift.io",
+				Version:  "v1",
+				Resource: "not the right resource",
+			},
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			data := &BaseDomainPoolValidatingAdmissionHook{
+				decoder:             createDecoder(t),
+				validManagedDomains: []string{"pool1.example.com", "pool2.example.com"},
+			}
+			if tc.newObjectRaw == nil {
+				tc.newObjectRaw, _ = json.Marshal(&hivev1.BaseDomainPool{
+					Spec: hivev1.BaseDomainPoolSpec{Domains: tc.domains},
+				})
+			}
+			if tc.gvr == nil {
+				tc.gvr = &metav1.GroupVersionResource{
+					Group:    "hive.openshift.io",
+					Version:  "v1",
+					Resource: "basedomainpools",
+				}
+			}
+			request := &admissionv1beta1.AdmissionRequest{
+				Operation: tc.operation,
+				Resource:  *tc.gvr,
+				Object: runtime.RawExtension{
+					Raw: tc.newObjectRaw,
+				},
+			}
+
+			response := data.Validate(request)
+
+			assert.Equal(t, tc.expectedAllowed, response.Allowed)
+		})
+	}
+}
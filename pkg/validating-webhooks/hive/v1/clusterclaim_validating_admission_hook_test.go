@@ -0,0 +1,286 @@
+package v1
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const testClusterClaimNamespace = "test-namespace"
+
+func TestClusterClaimValidatingResource(t *testing.T) {
+	// Arrange
+	data := NewClusterClaimValidatingAdmissionHook(createDecoder(t))
+	expectedPlural := schema.GroupVersionResource{
+		Group:    "admission.hive.openshift.io",
+		Version:  "v1",
+		Resource: "clusterclaimvalidators",
+	}
+	expectedSingular := "clusterclaimvalidator"
+
+	// Act
+	plural, singular := data.ValidatingResource()
+
+	// Assert
+	assert.Equal(t, expectedPlural, plural)
+	assert.Equal(t, expectedSingular, singular)
+}
+
+func TestClusterClaimInitialize(t *testing.T) {
+	// Arrange
+	data := NewClusterClaimValidatingAdmissionHook(createDecoder(t))
+
+	// Act
+	err := data.Initialize(&rest.Config{}, nil)
+
+	// Assert
+	assert.Nil(t, err)
+}
+
+func TestClusterClaimValidate(t *testing.T) {
+	scheme := runtime.NewScheme()
+	hivev1.AddToScheme(scheme)
+
+	claim := func(namespace, name, requester string) *hivev1.ClusterClaim {
+		claim := &hivev1.ClusterClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:         namespace,
+				Name:              name,
+				CreationTimestamp: metav1.NewTime(time.Now().Add(-time.Hour)),
+			},
+			Spec: hivev1.ClusterClaimSpec{
+				ClusterPoolName: "test-pool",
+			},
+		}
+		if requester != "" {
+			claim.Annotations = map[string]string{constants.ClaimRequesterAnnotation: requester}
+		}
+		return claim
+	}
+	quota := func(n int) *int {
+		return &n
+	}
+	deletedClaim := func(namespace, name, requester string) *hivev1.ClusterClaim {
+		claim := claim(namespace, name, requester)
+		now := metav1.Now()
+		claim.DeletionTimestamp = &now
+		return claim
+	}
+
+	cases := []struct {
+		name            string
+		existing        []runtime.Object
+		quota           controllerutils.ClaimQuota
+		newObject       *hivev1.ClusterClaim
+		oldObject       *hivev1.ClusterClaim
+		newObjectRaw    []byte
+		oldObjectRaw    []byte
+		operation       admissionv1beta1.Operation
+		expectedAllowed bool
+		gvr             *metav1.GroupVersionResource
+	}{
+		{
+			name:            "Test create without quota",
+			existing:        []runtime.Object{claim(testClusterClaimNamespace, "other-claim", "test-user")},
+			newObject:       claim(testClusterClaimNamespace, "test-claim", "test-user"),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name:            "Test create within namespace quota",
+			existing:        []runtime.Object{claim(testClusterClaimNamespace, "other-claim", "other-user")},
+			quota:           controllerutils.ClaimQuota{PerNamespace: quota(2)},
+			newObject:       claim(testClusterClaimNamespace, "test-claim", "test-user"),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "Test create over namespace quota",
+			existing: []runtime.Object{
+				claim(testClusterClaimNamespace, "other-claim-1", "other-user"),
+				claim(testClusterClaimNamespace, "other-claim-2", "other-user"),
+			},
+			quota:           controllerutils.ClaimQuota{PerNamespace: quota(2)},
+			newObject:       claim(testClusterClaimNamespace, "test-claim", "test-user"),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test create with claims in other namespaces",
+			existing: []runtime.Object{
+				claim("other-namespace", "other-claim-1", "other-user"),
+				claim("other-namespace", "other-claim-2", "other-user"),
+			},
+			quota:           controllerutils.ClaimQuota{PerNamespace: quota(2)},
+			newObject:       claim(testClusterClaimNamespace, "test-claim", "test-user"),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "Test create with deleted claims",
+			existing: []runtime.Object{
+				deletedClaim(testClusterClaimNamespace, "other-claim-1", "other-user"),
+				claim(testClusterClaimNamespace, "other-claim-2", "other-user"),
+			},
+			quota:           controllerutils.ClaimQuota{PerNamespace: quota(2)},
+			newObject:       claim(testClusterClaimNamespace, "test-claim", "test-user"),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "Test create over requester quota",
+			existing: []runtime.Object{
+				claim("other-namespace", "other-claim", "test-user"),
+			},
+			quota:           controllerutils.ClaimQuota{PerRequester: quota(1)},
+			newObject:       claim(testClusterClaimNamespace, "test-claim", "test-user"),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test create within requester quota",
+			existing: []runtime.Object{
+				claim(testClusterClaimNamespace, "other-claim", "other-user"),
+			},
+			quota:           controllerutils.ClaimQuota{PerRequester: quota(1)},
+			newObject:       claim(testClusterClaimNamespace, "test-claim", "test-user"),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name:            "Test unable to marshal new object during create",
+			newObjectRaw:    []byte{0},
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test update without changing requester",
+			newObject:       claim(testClusterClaimNamespace, "test-claim", "test-user"),
+			oldObject:       claim(testClusterClaimNamespace, "test-claim", "test-user"),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: true,
+		},
+		{
+			name:            "Test update changing requester",
+			newObject:       claim(testClusterClaimNamespace, "test-claim", "other-user"),
+			oldObject:       claim(testClusterClaimNamespace, "test-claim", "test-user"),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test update removing requester",
+			newObject:       claim(testClusterClaimNamespace, "test-claim", ""),
+			oldObject:       claim(testClusterClaimNamespace, "test-claim", "test-user"),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test unable to marshal new object during update",
+			newObjectRaw:    []byte{0},
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test unable to marshal old object during update",
+			oldObjectRaw:    []byte{0},
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test delete",
+			quota:           controllerutils.ClaimQuota{PerNamespace: quota(0)},
+			operation:       admissionv1beta1.Delete,
+			expectedAllowed: true,
+		},
+		{
+			name: "Test doesn't validate with right version and resource, but wrong group",
+			gvr: &metav1.GroupVersionResource{
+				Group:    "not the right group",
+				Version:  "v1",
+				Resource: "clusterclaims",
+			},
+			expectedAllowed: true,
+		},
+		{
+			name: "Test doesn't validate with right group and resource, wrong version",
+			gvr: &metav1.GroupVersionResource{
+				Group:    "hive.openshift.io",
+				Version:  "not the right version",
+				Resource: "clusterclaims",
+			},
+			expectedAllowed: true,
+		},
+		{
+			name: "Test doesn't validate with right group and version, wrong resource",
+			gvr: &metav1.GroupVersionResource{
+				Group:    "hive.openshift.io",
+				Version:  "v1",
+				Resource: "not the right resource",
+			},
+			expectedAllowed: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			data := NewClusterClaimValidatingAdmissionHook(createDecoder(t))
+			data.client = fake.NewFakeClientWithScheme(scheme, tc.existing...)
+			data.quota = tc.quota
+
+			if tc.newObject == nil {
+				tc.newObject = claim(testClusterClaimNamespace, "test-claim", "test-user")
+			}
+			if tc.oldObject == nil {
+				tc.oldObject = claim(testClusterClaimNamespace, "test-claim", "test-user")
+			}
+
+			if tc.newObjectRaw == nil {
+				tc.newObjectRaw, _ = json.Marshal(tc.newObject)
+			}
+
+			if tc.oldObjectRaw == nil {
+				tc.oldObjectRaw, _ = json.Marshal(tc.oldObject)
+			}
+
+			if tc.gvr == nil {
+				tc.gvr = &metav1.GroupVersionResource{
+					Group:    "hive.openshift.io",
+					Version:  "v1",
+					Resource: "clusterclaims",
+				}
+			}
+
+			request := &admissionv1beta1.AdmissionRequest{
+				Operation: tc.operation,
+				Resource:  *tc.gvr,
+				Namespace: testClusterClaimNamespace,
+				Name:      tc.newObject.Name,
+				Object: runtime.RawExtension{
+					Raw: tc.newObjectRaw,
+				},
+				OldObject: runtime.RawExtension{
+					Raw: tc.oldObjectRaw,
+				},
+			}
+
+			// Act
+			response := data.Validate(request)
+
+			// Assert
+			assert.Equal(t, tc.expectedAllowed, response.Allowed)
+		})
+	}
+}
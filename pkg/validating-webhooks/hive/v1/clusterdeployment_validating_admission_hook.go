@@ -1,34 +1,46 @@
 package v1
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 	"reflect"
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
+	"unicode"
 
+	configv1 "github.com/openshift/api/config/v1"
 	log "github.com/sirupsen/logrus"
 
 	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	apivalidation "k8s.io/apimachinery/pkg/api/validation"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/client-go/rest"
 
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
+	hivev1none "github.com/openshift/hive/apis/hive/v1/none"
 
 	"github.com/openshift/hive/pkg/constants"
 	"github.com/openshift/hive/pkg/controller/awsprivatelink"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 	"github.com/openshift/hive/pkg/manageddns"
+	"github.com/openshift/hive/pkg/util/cron"
+	webhookutil "github.com/openshift/hive/pkg/util/webhook"
 )
 
 const (
@@ -38,15 +50,18 @@ const (
 
 	clusterDeploymentAdmissionGroup   = "admission.hive.openshift.io"
 	clusterDeploymentAdmissionVersion = "v1"
+
+	maxDisplayNameLength = 256
 )
 
 var (
-	mutableFields = []string{"CertificateBundles", "ClusterMetadata", "ControlPlaneConfig", "Ingress", "Installed", "PreserveOnDelete", "ClusterPoolRef", "PowerState", "HibernateAfter", "InstallAttemptsLimit", "MachineManagement"}
+	mutableFields = []string{"CertificateBundles", "ClusterMetadata", "ControlPlaneConfig", "DisplayName", "Ingress", "Installed", "PreserveOnDelete", "PreserveDNSZoneOnDelete", "ForceCleanup", "ExitBackup", "NodeTuning", "SyncAgent", "ReadinessGates", "ClusterPoolRef", "PowerState", "HibernateAfter", "HibernateAfterIdle", "PowerStateSchedule", "ResumeReadiness", "InstallAttemptsLimit", "ProvisionRetryPolicy", "MachineManagement", "UnreachableRemediation", "Kubeadmin"}
 )
 
 // ClusterDeploymentValidatingAdmissionHook is a struct that is used to reference what code should be run by the generic-admission-server.
 type ClusterDeploymentValidatingAdmissionHook struct {
 	decoder *admission.Decoder
+	client  client.Client
 
 	validManagedDomains  []string
 	fs                   *featureSet
@@ -106,7 +121,20 @@ func (a *ClusterDeploymentValidatingAdmissionHook) Initialize(kubeClientConfig *
 		"version":  clusterDeploymentAdmissionVersion,
 		"resource": "clusterdeploymentvalidator",
 	}).Info("Initializing validation REST resource")
-	return nil // No initialization needed right now.
+
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		return err
+	}
+	if err := hivev1.AddToScheme(scheme); err != nil {
+		return err
+	}
+	c, err := webhookutil.NewClient(kubeClientConfig, scheme)
+	if err != nil {
+		return err
+	}
+	a.client = c
+	return nil
 }
 
 // Validate is called by generic-admission-server when the registered REST resource above is called with an admission request.
@@ -243,7 +271,8 @@ func (a *ClusterDeploymentValidatingAdmissionHook) validateCreate(admissionSpec
 		return r
 	}
 
-	if cd.Spec.ManageDNS {
+	// A base domain allocated from a pool is a child of a managed domain of the pool.
+	if cd.Spec.ManageDNS && !(cd.Spec.BaseDomain == "" && cd.Spec.BaseDomainPoolRef != nil) {
 		if !validateDomain(cd.Spec.BaseDomain, a.validManagedDomains) {
 			message := "The base domain must be a child of one of the managed domains for ClusterDeployments with manageDNS set to true"
 			return &admissionv1beta1.AdmissionResponse{
@@ -282,11 +311,25 @@ func (a *ClusterDeploymentValidatingAdmissionHook) validateCreate(admissionSpec
 					field.Forbidden(specPath.Child("platform", "agentBareMetal"),
 						"agent bare metal platform can only be used with agent install strategy"))
 			}
+
+			if cd.Spec.Provisioning.InstallStrategy != nil &&
+				cd.Spec.Provisioning.InstallStrategy.AgentImage != nil {
+				allErrs = append(allErrs, validateAgentImageInstallStrategy(specPath, cd)...)
+			}
 		}
 	}
 
 	allErrs = append(allErrs, validateClusterPlatform(specPath.Child("platform"), cd.Spec.Platform)...)
+	if !cd.Spec.Installed {
+		allErrs = append(allErrs, a.validateCredentialsSecret(specPath.Child("platform"), cd, contextLogger)...)
+	}
 	allErrs = append(allErrs, validateCanManageDNSForClusterPlatform(specPath, cd.Spec)...)
+	if cd.Spec.BaseDomainPoolRef != nil {
+		allErrs = append(allErrs, validateBaseDomainPoolRef(specPath.Child("baseDomainPoolRef"), cd.Spec.BaseDomainPoolRef)...)
+		if !cd.Spec.ManageDNS {
+			allErrs = append(allErrs, field.Forbidden(specPath.Child("baseDomainPoolRef"), "base domains can only be allocated from a pool when manageDNS is set to true"))
+		}
+	}
 
 	if cd.Spec.Platform.AWS != nil {
 		allErrs = append(allErrs, validateAWSPrivateLink(specPath.Child("platform", "aws"), cd.Spec.Platform.AWS, a.awsPrivateLinkConfig)...)
@@ -296,8 +339,41 @@ func (a *ClusterDeploymentValidatingAdmissionHook) validateCreate(admissionSpec
 		if cd.Spec.Provisioning.SSHPrivateKeySecretRef != nil && cd.Spec.Provisioning.SSHPrivateKeySecretRef.Name == "" {
 			allErrs = append(allErrs, field.Required(specPath.Child("provisioning", "sshPrivateKeySecretRef", "name"), "must specify a name for the ssh private key secret if the ssh private key secret is specified"))
 		}
+		if cd.Spec.Provisioning.FeatureSet != nil {
+			allErrs = append(allErrs, validateFeatureSetConfig(specPath.Child("provisioning", "featureSet"), cd.Spec.Provisioning.FeatureSet)...)
+		}
+		if timeout := cd.Spec.Provisioning.InstallTimeout; timeout != nil && timeout.Duration <= 0 {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("provisioning", "installTimeout"), timeout.Duration.String(), "must be positive"))
+		}
 	}
 
+	allErrs = append(allErrs, validateDisplayName(specPath.Child("displayName"), cd.Spec.DisplayName)...)
+	if cd.Spec.ForceCleanup != nil {
+		allErrs = append(allErrs, validateForceCleanup(specPath.Child("forceCleanup"), cd.Spec.ForceCleanup)...)
+	}
+	if cd.Spec.NodeTuning != nil {
+		allErrs = append(allErrs, validateNodeTuning(specPath.Child("nodeTuning"), cd.Spec.NodeTuning)...)
+	}
+	if cd.Spec.SyncAgent != nil {
+		allErrs = append(allErrs, validateSyncAgent(specPath.Child("syncAgent"), cd.Spec.SyncAgent)...)
+	}
+	if cd.Spec.UnreachableRemediation != nil {
+		allErrs = append(allErrs, validateUnreachableRemediation(specPath.Child("unreachableRemediation"), cd.Spec.UnreachableRemediation)...)
+	}
+	if cd.Spec.PowerStateSchedule != nil {
+		allErrs = append(allErrs, validatePowerStateSchedule(specPath.Child("powerStateSchedule"), cd.Spec.PowerStateSchedule)...)
+	}
+	if cd.Spec.ResumeReadiness != nil {
+		allErrs = append(allErrs, validateResumeReadiness(specPath.Child("resumeReadiness"), cd.Spec.ResumeReadiness)...)
+	}
+	if cd.Spec.RestoreRef != nil && cd.Spec.RestoreRef.BackupName == "" {
+		allErrs = append(allErrs, field.Required(specPath.Child("restoreRef", "backupName"), "must specify the backup to restore"))
+	}
+	if cd.Spec.ProvisionRetryPolicy != nil {
+		allErrs = append(allErrs, validateProvisionRetryPolicy(specPath.Child("provisionRetryPolicy"), cd.Spec.ProvisionRetryPolicy)...)
+	}
+	allErrs = append(allErrs, validateReadinessGates(specPath.Child("readinessGates"), cd.Spec.ReadinessGates)...)
+
 	if poolRef := cd.Spec.ClusterPoolRef; poolRef != nil {
 		if claimName := poolRef.ClaimName; claimName != "" {
 			allErrs = append(allErrs, field.Invalid(specPath.Child("clusterPoolRef", "claimName"), claimName, "cannot create a ClusterDeployment that is already claimed"))
@@ -347,6 +423,53 @@ func validateAWSPrivateLink(path *field.Path, platform *hivev1aws.Platform, conf
 			fmt.Sprintf("AWS Private Link is not supported in %s region", platform.Region)))
 	}
 
+	allErrs = append(allErrs, validateAWSPrivateLinkAllowedPrincipals(path.Child("privateLink", "additionalAllowedPrincipals"),
+		pl.AdditionalAllowedPrincipals)...)
+	allErrs = append(allErrs, validateAWSPrivateLinkEndpointAcceptance(path.Child("privateLink", "endpointAcceptance"),
+		pl.EndpointAcceptance)...)
+
+	return allErrs
+}
+
+func validateAWSPrivateLinkAllowedPrincipals(path *field.Path, principals []string) field.ErrorList {
+	allErrs := field.ErrorList{}
+	seen := sets.NewString()
+	for i, principal := range principals {
+		switch {
+		case principal == "*":
+			allErrs = append(allErrs, field.Forbidden(path.Index(i), "all principals cannot be allowed"))
+		case !strings.HasPrefix(principal, "arn:"):
+			allErrs = append(allErrs, field.Invalid(path.Index(i), principal, "must be the ARN of an AWS principal"))
+		case seen.Has(principal):
+			allErrs = append(allErrs, field.Duplicate(path.Index(i), principal))
+		}
+		seen.Insert(principal)
+	}
+	return allErrs
+}
+
+var awsAccountIDRegex = regexp.MustCompile(`^[0-9]{12}$`)
+
+func validateAWSPrivateLinkEndpointAcceptance(path *field.Path, acceptance *hivev1aws.PrivateLinkEndpointAcceptance) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if acceptance == nil {
+		return allErrs
+	}
+	seen := sets.NewString()
+	for i, accountID := range acceptance.AutoAcceptAccountIDs {
+		idPath := path.Child("autoAcceptAccountIDs").Index(i)
+		switch {
+		case !awsAccountIDRegex.MatchString(accountID):
+			allErrs = append(allErrs, field.Invalid(idPath, accountID, "must be a 12 digit AWS account ID"))
+		case seen.Has(accountID):
+			allErrs = append(allErrs, field.Duplicate(idPath, accountID))
+		}
+		seen.Insert(accountID)
+	}
+	if len(acceptance.AutoAcceptAccountIDs) > 0 && !acceptance.AcceptanceRequired {
+		allErrs = append(allErrs, field.Forbidden(path.Child("autoAcceptAccountIDs"),
+			"connection requests are only reviewed when acceptance is required"))
+	}
 	return allErrs
 }
 
@@ -388,6 +511,80 @@ func validateAgentInstallStrategy(specPath *field.Path, cd *hivev1.ClusterDeploy
 	return allErrs
 }
 
+func validateExternalDestroyer(path *field.Path, destroyer *hivev1none.ExternalDestroyer) field.ErrorList {
+	allErrs := field.ErrorList{}
+	switch {
+	case destroyer.Job != nil && destroyer.Webhook != nil:
+		allErrs = append(allErrs, field.Invalid(path, destroyer, "must specify only one of job or webhook"))
+	case destroyer.Job != nil:
+		if destroyer.Job.Image == "" {
+			allErrs = append(allErrs, field.Required(path.Child("job", "image"), "must specify destroyer image"))
+		}
+	case destroyer.Webhook != nil:
+		webhookPath := path.Child("webhook")
+		if u, err := url.Parse(destroyer.Webhook.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			allErrs = append(allErrs, field.Invalid(webhookPath.Child("url"), destroyer.Webhook.URL, "must be an http or https URL"))
+		}
+		if destroyer.Webhook.TokenSecretRef != nil && destroyer.Webhook.TokenSecretRef.Name == "" {
+			allErrs = append(allErrs, field.Required(webhookPath.Child("tokenSecretRef", "name"), "must specify a name for the token secret if the token secret is specified"))
+		}
+	default:
+		allErrs = append(allErrs, field.Required(path, "must specify either job or webhook"))
+	}
+	return allErrs
+}
+
+func validateAgentImageInstallStrategy(specPath *field.Path, cd *hivev1.ClusterDeployment) field.ErrorList {
+	strategy := cd.Spec.Provisioning.InstallStrategy.AgentImage
+	allErrs := field.ErrorList{}
+	strategyPath := specPath.Child("provisioning", "installStrategy", "agentImage")
+
+	if cd.Spec.Provisioning.InstallStrategy.Agent != nil {
+		allErrs = append(allErrs, field.Forbidden(strategyPath, "agent image install strategy cannot be combined with agent install strategy"))
+	}
+	// the cluster hosts are not managed by hive, so only the none platform makes sense:
+	if cd.Spec.Platform.None == nil {
+		allErrs = append(allErrs, field.Forbidden(strategyPath, "agent image install strategy can only be used with the none platform"))
+	}
+	if strategy.AgentConfigSecretRef.Name == "" {
+		allErrs = append(allErrs, field.Required(strategyPath.Child("agentConfigSecretRef", "name"), "must specify an agent config secret"))
+	}
+	s3Path := strategyPath.Child("s3")
+	if strategy.S3.CredentialsSecretRef.Name == "" {
+		allErrs = append(allErrs, field.Required(s3Path.Child("credentialsSecretRef", "name"), "must specify secrets for AWS access"))
+	}
+	if strategy.S3.Region == "" {
+		allErrs = append(allErrs, field.Required(s3Path.Child("region"), "must specify AWS region"))
+	}
+	if strategy.S3.Bucket == "" {
+		allErrs = append(allErrs, field.Required(s3Path.Child("bucket"), "must specify S3 bucket"))
+	}
+	// pre-signed S3 URLs can be valid for at most 7 days
+	if exp := strategy.S3.URLExpiration; exp != nil && (exp.Duration <= 0 || exp.Duration > 7*24*time.Hour) {
+		allErrs = append(allErrs, field.Invalid(s3Path.Child("urlExpiration"), exp.Duration.String(), "must be positive and no longer than 7 days"))
+	}
+	if timeout := strategy.InstallTimeout; timeout != nil && timeout.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(strategyPath.Child("installTimeout"), timeout.Duration.String(), "must be positive"))
+	}
+	return allErrs
+}
+
+// validateCredentialsSecret rejects a ClusterDeployment whose platform credentials secret exists but does not have the
+// structure expected for the platform. A secret created after the ClusterDeployment, or changed later, is checked when
+// the ClusterDeployment is reconciled.
+func (a *ClusterDeploymentValidatingAdmissionHook) validateCredentialsSecret(platformPath *field.Path, cd *hivev1.ClusterDeployment, contextLogger *log.Entry) field.ErrorList {
+	message, err := controllerutils.CheckCredentialsSecretSchema(a.client, cd)
+	if err != nil {
+		// The controller checks the secret again, so failing to read it does not block the create.
+		contextLogger.WithError(err).Warn("could not check the platform credentials secret")
+		return nil
+	}
+	if message == "" {
+		return nil
+	}
+	return field.ErrorList{field.Forbidden(platformPath, message)}
+}
+
 func validatefeatureGates(decoder *admission.Decoder, admissionSpec *admissionv1beta1.AdmissionRequest, fs *featureSet, contextLogger *log.Entry) *admissionv1beta1.AdmissionResponse {
 	obj := &unstructured.Unstructured{}
 	if err := decoder.DecodeRaw(admissionSpec.Object, obj); err != nil {
@@ -432,6 +629,9 @@ func validateClusterPlatform(path *field.Path, platform hivev1.Platform) field.E
 		if aws.Region == "" {
 			allErrs = append(allErrs, field.Required(awsPath.Child("region"), "must specify AWS region"))
 		}
+		if aws.STS != nil && aws.CredentialsMode != hivev1aws.ManualCredentialsMode {
+			allErrs = append(allErrs, field.Invalid(awsPath.Child("credentialsMode"), aws.CredentialsMode, "must be Manual to create STS resources"))
+		}
 	}
 	if azure := platform.Azure; azure != nil {
 		numberOfPlatforms++
@@ -504,9 +704,57 @@ func validateClusterPlatform(path *field.Path, platform hivev1.Platform) field.E
 			allErrs = append(allErrs, field.Required(ovirtPath.Child("ovirt_storage_domain_id"), "must specify ovirt_storage_domain_id"))
 		}
 	}
+	if oci := platform.OCI; oci != nil {
+		numberOfPlatforms++
+		ociPath := path.Child("oci")
+		if oci.CredentialsSecretRef.Name == "" {
+			allErrs = append(allErrs, field.Required(ociPath.Child("credentialsSecretRef", "name"), "must specify secrets for OCI access"))
+		}
+		if oci.Region == "" {
+			allErrs = append(allErrs, field.Required(ociPath.Child("region"), "must specify OCI region"))
+		}
+		if oci.CompartmentID == "" {
+			allErrs = append(allErrs, field.Required(ociPath.Child("compartmentID"), "must specify OCI compartment"))
+		}
+	}
+	if powervs := platform.PowerVS; powervs != nil {
+		numberOfPlatforms++
+		powervsPath := path.Child("powervs")
+		if powervs.CredentialsSecretRef.Name == "" {
+			allErrs = append(allErrs, field.Required(powervsPath.Child("credentialsSecretRef", "name"), "must specify secrets for PowerVS access"))
+		}
+		if powervs.Region == "" {
+			allErrs = append(allErrs, field.Required(powervsPath.Child("region"), "must specify PowerVS region"))
+		}
+		if powervs.Zone == "" {
+			allErrs = append(allErrs, field.Required(powervsPath.Child("zone"), "must specify PowerVS zone"))
+		}
+		if powervs.ServiceInstanceID == "" {
+			allErrs = append(allErrs, field.Required(powervsPath.Child("serviceInstanceID"), "must specify PowerVS service instance"))
+		}
+	}
 	if baremetal := platform.BareMetal; baremetal != nil {
 		numberOfPlatforms++
 	}
+	if none := platform.None; none != nil {
+		numberOfPlatforms++
+		nonePath := path.Child("none")
+		if none.IgnitionDelivery.Type == hivev1none.IgnitionDeliveryObjectStore {
+			objectStorePath := nonePath.Child("ignitionDelivery", "objectStore")
+			switch {
+			case none.IgnitionDelivery.ObjectStore == nil:
+				allErrs = append(allErrs, field.Required(objectStorePath, "must specify object store for ObjectStore ignition delivery"))
+			case none.IgnitionDelivery.ObjectStore.URLsSecretRef.Name == "":
+				allErrs = append(allErrs, field.Required(objectStorePath.Child("urlsSecretRef", "name"), "must specify secret with ignition upload URLs"))
+			}
+		}
+		if none.InfrastructureTimeout != nil && none.InfrastructureTimeout.Duration <= 0 {
+			allErrs = append(allErrs, field.Invalid(nonePath.Child("infrastructureTimeout"), none.InfrastructureTimeout.Duration.String(), "must be positive"))
+		}
+		if none.Destroyer != nil {
+			allErrs = append(allErrs, validateExternalDestroyer(nonePath.Child("destroyer"), none.Destroyer)...)
+		}
+	}
 	if agent := platform.AgentBareMetal; agent != nil {
 		numberOfPlatforms++
 	}
@@ -531,6 +779,9 @@ func validateCanManageDNSForClusterPlatform(specPath *field.Path, spec hivev1.Cl
 	if spec.Platform.GCP != nil {
 		canManageDNS = true
 	}
+	if spec.Platform.OCI != nil {
+		canManageDNS = true
+	}
 	if !canManageDNS && spec.ManageDNS {
 		allErrs = append(allErrs, field.Invalid(specPath.Child("manageDNS"), spec.ManageDNS, "cannot manage DNS for the selected platform"))
 	}
@@ -582,7 +833,19 @@ func (a *ClusterDeploymentValidatingAdmissionHook) validateUpdate(admissionSpec
 	// Add the new data to the contextLogger
 	contextLogger.Data["oldObject.Name"] = oldObject.Name
 
-	hasChangedImmutableField, changedFieldName := hasChangedImmutableField(&oldObject.Spec, &cd.Spec)
+	oldSpec := oldObject.Spec.DeepCopy()
+	if oldSpec.BaseDomain == "" && oldSpec.BaseDomainPoolRef != nil {
+		// The base domain is set once it is allocated from the pool.
+		oldSpec.BaseDomain = cd.Spec.BaseDomain
+	}
+	if oldAWS, newAWS := oldSpec.Platform.AWS, cd.Spec.Platform.AWS; oldAWS != nil && oldAWS.PrivateLink != nil &&
+		newAWS != nil && newAWS.PrivateLink != nil {
+		// The additional allowed principals and the acceptance of connection requests of the VPC endpoint service are
+		// reconciled on changes.
+		oldAWS.PrivateLink.AdditionalAllowedPrincipals = newAWS.PrivateLink.AdditionalAllowedPrincipals
+		oldAWS.PrivateLink.EndpointAcceptance = newAWS.PrivateLink.EndpointAcceptance
+	}
+	hasChangedImmutableField, changedFieldName := hasChangedImmutableField(oldSpec, &cd.Spec)
 	if hasChangedImmutableField {
 		message := fmt.Sprintf("Attempted to change ClusterDeployment.Spec.%v. ClusterDeployment.Spec is immutable except for %v", changedFieldName, mutableFields)
 		contextLogger.Infof("Failed validation: %v", message)
@@ -647,6 +910,39 @@ func (a *ClusterDeploymentValidatingAdmissionHook) validateUpdate(admissionSpec
 		allErrs = append(allErrs, field.Invalid(specPath.Child("clusterPoolRef"), newPoolRef, "cannot add clusterPoolRef"))
 	}
 
+	if aws := cd.Spec.Platform.AWS; aws != nil && aws.PrivateLink != nil {
+		allErrs = append(allErrs, validateAWSPrivateLinkAllowedPrincipals(
+			specPath.Child("platform", "aws", "privateLink", "additionalAllowedPrincipals"),
+			aws.PrivateLink.AdditionalAllowedPrincipals)...)
+		allErrs = append(allErrs, validateAWSPrivateLinkEndpointAcceptance(
+			specPath.Child("platform", "aws", "privateLink", "endpointAcceptance"),
+			aws.PrivateLink.EndpointAcceptance)...)
+	}
+
+	allErrs = append(allErrs, validateDisplayName(specPath.Child("displayName"), cd.Spec.DisplayName)...)
+	if cd.Spec.ForceCleanup != nil {
+		allErrs = append(allErrs, validateForceCleanup(specPath.Child("forceCleanup"), cd.Spec.ForceCleanup)...)
+	}
+	if cd.Spec.NodeTuning != nil {
+		allErrs = append(allErrs, validateNodeTuning(specPath.Child("nodeTuning"), cd.Spec.NodeTuning)...)
+	}
+	if cd.Spec.SyncAgent != nil {
+		allErrs = append(allErrs, validateSyncAgent(specPath.Child("syncAgent"), cd.Spec.SyncAgent)...)
+	}
+	if cd.Spec.UnreachableRemediation != nil {
+		allErrs = append(allErrs, validateUnreachableRemediation(specPath.Child("unreachableRemediation"), cd.Spec.UnreachableRemediation)...)
+	}
+	if cd.Spec.PowerStateSchedule != nil {
+		allErrs = append(allErrs, validatePowerStateSchedule(specPath.Child("powerStateSchedule"), cd.Spec.PowerStateSchedule)...)
+	}
+	if cd.Spec.ResumeReadiness != nil {
+		allErrs = append(allErrs, validateResumeReadiness(specPath.Child("resumeReadiness"), cd.Spec.ResumeReadiness)...)
+	}
+	if cd.Spec.ProvisionRetryPolicy != nil {
+		allErrs = append(allErrs, validateProvisionRetryPolicy(specPath.Child("provisionRetryPolicy"), cd.Spec.ProvisionRetryPolicy)...)
+	}
+	allErrs = append(allErrs, validateReadinessGates(specPath.Child("readinessGates"), cd.Spec.ReadinessGates)...)
+
 	// Validate cd.Spec.MachineManagement.TargetNamespace
 	if cd.Spec.MachineManagement != nil {
 		switch oldTargetNamespace, newTargetNamespace := oldObject.Spec.MachineManagement.TargetNamespace, cd.Spec.MachineManagement.TargetNamespace; {
@@ -734,6 +1030,226 @@ func (a *ClusterDeploymentValidatingAdmissionHook) validateDelete(request *admis
 	}
 }
 
+func validateBaseDomainPoolRef(path *field.Path, ref *hivev1.BaseDomainPoolReference) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if ref.Name == "" {
+		allErrs = append(allErrs, field.Required(path.Child("name"), "must specify the name of the BaseDomainPool"))
+	}
+	return allErrs
+}
+
+func validateNodeTuning(path *field.Path, nodeTuning *hivev1.NodeTuning) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if kubeletConfig := nodeTuning.KubeletConfig; kubeletConfig != nil {
+		if err := json.Unmarshal(kubeletConfig.Raw, &map[string]interface{}{}); err != nil {
+			allErrs = append(allErrs, field.Invalid(path.Child("kubeletConfig"), string(kubeletConfig.Raw), "must be a JSON object"))
+		}
+	}
+	for i, ref := range nodeTuning.SysctlProfileRefs {
+		if ref.Name == "" {
+			allErrs = append(allErrs, field.Required(path.Child("sysctlProfileRefs").Index(i).Child("name"), "must specify the name of the sysctl profile ConfigMap"))
+		}
+	}
+	poolNames := sets.NewString()
+	for i, pool := range nodeTuning.MachinePools {
+		poolPath := path.Child("machinePools").Index(i)
+		// The name of the MachinePool is part of the name of its machine config pool and of the node role label.
+		if errs := validation.IsDNS1123Label("hive-" + pool.Name); pool.Name == "" || len(errs) > 0 {
+			allErrs = append(allErrs, field.Invalid(poolPath.Child("name"), pool.Name, "must be the name of a MachinePool no longer than 58 characters"))
+		}
+		if poolNames.Has(pool.Name) {
+			allErrs = append(allErrs, field.Duplicate(poolPath.Child("name"), pool.Name))
+		}
+		poolNames.Insert(pool.Name)
+		if len(pool.SysctlProfileRefs) == 0 {
+			allErrs = append(allErrs, field.Required(poolPath.Child("sysctlProfileRefs"), "must specify the sysctl profiles of the machine pool"))
+		}
+		for j, ref := range pool.SysctlProfileRefs {
+			if ref.Name == "" {
+				allErrs = append(allErrs, field.Required(poolPath.Child("sysctlProfileRefs").Index(j).Child("name"), "must specify the name of the sysctl profile ConfigMap"))
+			}
+		}
+	}
+	return allErrs
+}
+
+// validateDisplayName checks that the display name can be shown on a single line.
+func validateDisplayName(path *field.Path, displayName string) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if len(displayName) > maxDisplayNameLength {
+		allErrs = append(allErrs, field.TooLong(path, displayName, maxDisplayNameLength))
+	}
+	if strings.IndexFunc(displayName, unicode.IsControl) >= 0 {
+		allErrs = append(allErrs, field.Invalid(path, displayName, "must not contain control characters"))
+	}
+	return allErrs
+}
+
+func validateForceCleanup(path *field.Path, forceCleanup *hivev1.ForceCleanup) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if strings.TrimSpace(forceCleanup.Reason) == "" {
+		allErrs = append(allErrs, field.Required(path.Child("reason"), "must explain why the cleanup is forced"))
+	}
+	if timeout := forceCleanup.Timeout; timeout != nil && timeout.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("timeout"), timeout.Duration.String(), "must not be negative"))
+	}
+	return allErrs
+}
+
+// validateReadinessGates checks that the readiness gates name distinct conditions which are not managed by Hive.
+func validateReadinessGates(path *field.Path, gates []hivev1.ClusterDeploymentReadinessGate) field.ErrorList {
+	allErrs := field.ErrorList{}
+	hiveConditions := sets.NewString()
+	for _, t := range hivev1.AllClusterDeploymentConditions {
+		hiveConditions.Insert(string(t))
+	}
+	seen := sets.NewString()
+	for i, gate := range gates {
+		gatePath := path.Index(i).Child("conditionType")
+		conditionType := string(gate.ConditionType)
+		for _, msg := range validation.IsQualifiedName(conditionType) {
+			allErrs = append(allErrs, field.Invalid(gatePath, conditionType, msg))
+		}
+		if hiveConditions.Has(conditionType) {
+			allErrs = append(allErrs, field.Invalid(gatePath, conditionType, "must not be a condition managed by Hive"))
+		}
+		if seen.Has(conditionType) {
+			allErrs = append(allErrs, field.Duplicate(gatePath, conditionType))
+		}
+		seen.Insert(conditionType)
+	}
+	return allErrs
+}
+
+func validateSyncAgent(path *field.Path, syncAgent *hivev1.SyncAgent) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if syncAgent.HubAPIURL == "" {
+		allErrs = append(allErrs, field.Required(path.Child("hubAPIURL"), "must specify the URL of the Hive API the agent pulls from"))
+	} else if u, err := url.Parse(syncAgent.HubAPIURL); err != nil || u.Scheme != "https" || u.Host == "" {
+		allErrs = append(allErrs, field.Invalid(path.Child("hubAPIURL"), syncAgent.HubAPIURL, "must be an https URL"))
+	}
+	if pollInterval := syncAgent.PollInterval; pollInterval != nil && pollInterval.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("pollInterval"), pollInterval.Duration.String(), "must be positive"))
+	}
+	return allErrs
+}
+
+func validateProvisionRetryPolicy(path *field.Path, policy *hivev1.ProvisionRetryPolicy) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if base := policy.BackoffBase; base != nil && base.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("backoffBase"), base.Duration.String(), "must be positive"))
+	}
+	if max := policy.MaxBackoff; max != nil {
+		if max.Duration <= 0 {
+			allErrs = append(allErrs, field.Invalid(path.Child("maxBackoff"), max.Duration.String(), "must be positive"))
+		} else if base := policy.BackoffBase; base != nil && max.Duration < base.Duration {
+			allErrs = append(allErrs, field.Invalid(path.Child("maxBackoff"), max.Duration.String(), "must not be less than backoffBase"))
+		}
+	}
+	return allErrs
+}
+
+func validateFeatureSetConfig(path *field.Path, featureSet *hivev1.FeatureSetConfig) field.ErrorList {
+	allErrs := field.ErrorList{}
+	switch featureSet.FeatureSet {
+	case configv1.TechPreviewNoUpgrade:
+		if len(featureSet.FeatureGates) > 0 {
+			allErrs = append(allErrs, field.Forbidden(path.Child("featureGates"), "feature gates can only be set with the CustomNoUpgrade feature set"))
+		}
+	case configv1.CustomNoUpgrade:
+		for i, gate := range featureSet.FeatureGates {
+			parts := strings.Split(gate, "=")
+			if len(parts) != 2 || parts[0] == "" || (parts[1] != "true" && parts[1] != "false") {
+				allErrs = append(allErrs, field.Invalid(path.Child("featureGates").Index(i), gate, "must be in the form Name=true or Name=false"))
+			}
+		}
+	default:
+		allErrs = append(allErrs, field.NotSupported(path.Child("featureSet"), featureSet.FeatureSet, []string{string(configv1.TechPreviewNoUpgrade), string(configv1.CustomNoUpgrade)}))
+	}
+	return allErrs
+}
+
+func validatePowerStateSchedule(path *field.Path, schedule *hivev1.PowerStateSchedule) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if _, err := cron.Parse(schedule.Hibernate); err != nil {
+		allErrs = append(allErrs, field.Invalid(path.Child("hibernate"), schedule.Hibernate, fmt.Sprintf("must be a cron expression: %v", err)))
+	}
+	if _, err := cron.Parse(schedule.Resume); err != nil {
+		allErrs = append(allErrs, field.Invalid(path.Child("resume"), schedule.Resume, fmt.Sprintf("must be a cron expression: %v", err)))
+	}
+	if schedule.TimeZone != "" {
+		if _, err := time.LoadLocation(schedule.TimeZone); err != nil {
+			allErrs = append(allErrs, field.Invalid(path.Child("timeZone"), schedule.TimeZone, "must be an IANA time zone"))
+		}
+	}
+	return allErrs
+}
+
+// validateResumeReadiness checks the node percentage and the timeouts of the resume readiness checks.
+func validateResumeReadiness(path *field.Path, rr *hivev1.ResumeReadiness) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if rr.Nodes != nil {
+		if pct := rr.Nodes.ReadyPercent; pct != nil && (*pct < 1 || *pct > 100) {
+			allErrs = append(allErrs, field.Invalid(path.Child("nodes", "readyPercent"), *pct, "must be between 1 and 100"))
+		}
+		allErrs = append(allErrs, validateResumeCheck(path.Child("nodes"), &rr.Nodes.ResumeCheck)...)
+	}
+	if rr.ClusterOperators != nil {
+		allErrs = append(allErrs, validateResumeCheck(path.Child("clusterOperators"), rr.ClusterOperators)...)
+	}
+	if rr.Certificates != nil {
+		allErrs = append(allErrs, validateResumeCheck(path.Child("certificates"), rr.Certificates)...)
+	}
+	return allErrs
+}
+
+func validateResumeCheck(path *field.Path, check *hivev1.ResumeCheck) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if timeout := check.Timeout; timeout != nil && timeout.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("timeout"), timeout.Duration.String(), "must not be negative"))
+	}
+	return allErrs
+}
+
+func validateUnreachableRemediation(path *field.Path, remediation *hivev1.UnreachableRemediation) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if threshold := remediation.Threshold; threshold != nil && threshold.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("threshold"), threshold.Duration.String(), "must not be negative"))
+	}
+	if len(remediation.Steps) == 0 {
+		allErrs = append(allErrs, field.Required(path.Child("steps"), "must specify at least one remediation step"))
+	}
+	for i, step := range remediation.Steps {
+		stepPath := path.Child("steps").Index(i)
+		switch step.Type {
+		case hivev1.RotateKubeconfigRemediationStep:
+			if step.KubeconfigSecretRef == nil || step.KubeconfigSecretRef.Name == "" {
+				allErrs = append(allErrs, field.Required(stepPath.Child("kubeconfigSecretRef", "name"), "must specify the secret of the kubeconfig to rotate to"))
+			}
+		case hivev1.AlternateAPIURLRemediationStep:
+			if step.APIURL == "" {
+				allErrs = append(allErrs, field.Required(stepPath.Child("apiURL"), "must specify the alternate API URL"))
+			} else if u, err := url.Parse(step.APIURL); err != nil || u.Scheme != "https" || u.Host == "" {
+				allErrs = append(allErrs, field.Invalid(stepPath.Child("apiURL"), step.APIURL, "must be an https URL"))
+			}
+		case hivev1.ResumeHibernationRemediationStep:
+		default:
+			allErrs = append(allErrs, field.NotSupported(stepPath.Child("type"), step.Type, []string{
+				string(hivev1.RotateKubeconfigRemediationStep),
+				string(hivev1.AlternateAPIURLRemediationStep),
+				string(hivev1.ResumeHibernationRemediationStep),
+			}))
+		}
+		if step.Type != hivev1.RotateKubeconfigRemediationStep && step.KubeconfigSecretRef != nil {
+			allErrs = append(allErrs, field.Forbidden(stepPath.Child("kubeconfigSecretRef"), "is only used by the RotateKubeconfig step"))
+		}
+		if step.Type != hivev1.AlternateAPIURLRemediationStep && step.APIURL != "" {
+			allErrs = append(allErrs, field.Forbidden(stepPath.Child("apiURL"), "is only used by the AlternateAPIURL step"))
+		}
+	}
+	return allErrs
+}
+
 // isFieldMutable says whether the ClusterDeployment.spec field is meant to be mutable or not.
 func isFieldMutable(value string) bool {
 	for _, mutableField := range mutableFields {
@@ -776,12 +1292,16 @@ func hasClearedOutPreviouslyDefinedIngressList(oldObject, cd *hivev1.ClusterDepl
 }
 
 func validateIngressDomainsShareClusterDomain(cd *hivev1.ClusterDeploymentSpec) bool {
-	// ingress entries must share the same domain as the cluster
+	// the default ingress must share the same domain as the cluster
 	// so watch for an ingress domain ending in: .<clusterName>.<baseDomain>
+	// additional ingress controllers (shards) may serve custom domains
 	regexString := fmt.Sprintf(`(?i).*\.%s.%s$`, cd.ClusterName, cd.BaseDomain)
 	sharedSubdomain := regexp.MustCompile(regexString)
 
 	for _, ingress := range cd.Ingress {
+		if ingress.Name != "default" {
+			continue
+		}
 		if !sharedSubdomain.Match([]byte(ingress.Domain)) {
 			return false
 		}
@@ -880,7 +1400,7 @@ func validateIngress(cd *hivev1.ClusterDeployment, contextLogger *log.Entry) *ad
 	}
 
 	if !validateIngressDomainsShareClusterDomain(&cd.Spec) {
-		message := "Ingress domains must share the same domain as the cluster"
+		message := "Default ingress domain must share the same domain as the cluster"
 		contextLogger.Infof("Failed validation: %v", message)
 		return &admissionv1beta1.AdmissionResponse{
 			Allowed: false,
@@ -931,6 +1451,17 @@ func validateCertificateBundles(cd *hivev1.ClusterDeployment, contextLogger *log
 				},
 			}
 		}
+		if certBundle.CertManager != nil && certBundle.CertManager.IssuerRef.Name == "" {
+			message := "Certificate bundle issued by cert-manager is missing an issuer reference"
+			contextLogger.Infof("Failed validation: %v", message)
+			return &admissionv1beta1.AdmissionResponse{
+				Allowed: false,
+				Result: &metav1.Status{
+					Status: metav1.StatusFailure, Code: http.StatusBadRequest, Reason: metav1.StatusReasonBadRequest,
+					Message: message,
+				},
+			}
+		}
 	}
 	return nil
 }
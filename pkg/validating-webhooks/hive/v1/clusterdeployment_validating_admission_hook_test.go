@@ -4,8 +4,11 @@ import (
 	"encoding/json"
 	"io/ioutil"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
+	configv1 "github.com/openshift/api/config/v1"
 	"github.com/stretchr/testify/assert"
 
 	admissionv1beta1 "k8s.io/api/admission/v1beta1"
@@ -13,14 +16,19 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	hivev1agent "github.com/openshift/hive/apis/hive/v1/agent"
 	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
 	hivev1azure "github.com/openshift/hive/apis/hive/v1/azure"
 	hivev1gcp "github.com/openshift/hive/apis/hive/v1/gcp"
+	hivev1none "github.com/openshift/hive/apis/hive/v1/none"
+	hivev1oci "github.com/openshift/hive/apis/hive/v1/oci"
 	hivev1openstack "github.com/openshift/hive/apis/hive/v1/openstack"
 	hivev1ovirt "github.com/openshift/hive/apis/hive/v1/ovirt"
+	hivev1powervs "github.com/openshift/hive/apis/hive/v1/powervs"
 	hivev1vsphere "github.com/openshift/hive/apis/hive/v1/vsphere"
 
 	"github.com/openshift/hive/pkg/constants"
@@ -64,6 +72,109 @@ func clusterDeploymentWithManagedDomain(domain string) *hivev1.ClusterDeployment
 	return cd
 }
 
+func clusterDeploymentWithBaseDomainPool(domain string) *hivev1.ClusterDeployment {
+	cd := clusterDeploymentWithManagedDomain(domain)
+	cd.Spec.BaseDomainPoolRef = &hivev1.BaseDomainPoolReference{Name: "test-pool"}
+	return cd
+}
+
+func clusterDeploymentWithNodeTuning(kubeletConfig string, sysctlProfiles ...string) *hivev1.ClusterDeployment {
+	cd := validAWSClusterDeployment()
+	cd.Spec.NodeTuning = &hivev1.NodeTuning{}
+	if kubeletConfig != "" {
+		cd.Spec.NodeTuning.KubeletConfig = &runtime.RawExtension{Raw: []byte(kubeletConfig)}
+	}
+	for _, profile := range sysctlProfiles {
+		cd.Spec.NodeTuning.SysctlProfileRefs = append(cd.Spec.NodeTuning.SysctlProfileRefs, corev1.LocalObjectReference{Name: profile})
+	}
+	return cd
+}
+
+func clusterDeploymentWithMachinePoolNodeTuning(poolNames ...string) *hivev1.ClusterDeployment {
+	cd := validAWSClusterDeployment()
+	cd.Spec.NodeTuning = &hivev1.NodeTuning{}
+	for _, name := range poolNames {
+		cd.Spec.NodeTuning.MachinePools = append(cd.Spec.NodeTuning.MachinePools, hivev1.MachinePoolNodeTuning{
+			Name:              name,
+			SysctlProfileRefs: []corev1.LocalObjectReference{{Name: name + "-tuning"}},
+		})
+	}
+	return cd
+}
+
+func clusterDeploymentWithForceCleanup(reason string, timeout time.Duration) *hivev1.ClusterDeployment {
+	cd := validAWSClusterDeployment()
+	cd.Spec.ForceCleanup = &hivev1.ForceCleanup{
+		Reason:  reason,
+		Timeout: &metav1.Duration{Duration: timeout},
+	}
+	return cd
+}
+
+func clusterDeploymentWithDisplayName(displayName string) *hivev1.ClusterDeployment {
+	cd := validAWSClusterDeployment()
+	cd.Spec.DisplayName = displayName
+	return cd
+}
+
+func clusterDeploymentWithReadinessGates(conditionTypes ...hivev1.ClusterDeploymentConditionType) *hivev1.ClusterDeployment {
+	cd := validAWSClusterDeployment()
+	for _, t := range conditionTypes {
+		cd.Spec.ReadinessGates = append(cd.Spec.ReadinessGates, hivev1.ClusterDeploymentReadinessGate{ConditionType: t})
+	}
+	return cd
+}
+
+func clusterDeploymentWithSyncAgent(hubAPIURL string, pollInterval time.Duration) *hivev1.ClusterDeployment {
+	cd := validAWSClusterDeployment()
+	cd.Spec.SyncAgent = &hivev1.SyncAgent{HubAPIURL: hubAPIURL, PollInterval: &metav1.Duration{Duration: pollInterval}}
+	return cd
+}
+
+func clusterDeploymentWithProvisionRetryPolicy(backoffBase, maxBackoff time.Duration) *hivev1.ClusterDeployment {
+	cd := validAWSClusterDeployment()
+	cd.Spec.ProvisionRetryPolicy = &hivev1.ProvisionRetryPolicy{
+		BackoffBase: &metav1.Duration{Duration: backoffBase},
+		MaxBackoff:  &metav1.Duration{Duration: maxBackoff},
+	}
+	return cd
+}
+
+func clusterDeploymentWithKubeadmin(kubeadmin hivev1.KubeadminManagement) *hivev1.ClusterDeployment {
+	cd := validAWSClusterDeployment()
+	cd.Spec.Kubeadmin = &kubeadmin
+	return cd
+}
+
+func clusterDeploymentWithRestoreRef(backupName string) *hivev1.ClusterDeployment {
+	cd := validAWSClusterDeployment()
+	cd.Spec.RestoreRef = &hivev1.RestoreReference{BackupName: backupName}
+	return cd
+}
+
+func clusterDeploymentWithUnreachableRemediation(steps ...hivev1.UnreachableRemediationStep) *hivev1.ClusterDeployment {
+	cd := validAWSClusterDeployment()
+	cd.Spec.UnreachableRemediation = &hivev1.UnreachableRemediation{Steps: steps}
+	return cd
+}
+
+func clusterDeploymentWithPowerStateSchedule(hibernate, resume, timeZone string) *hivev1.ClusterDeployment {
+	cd := validAWSClusterDeployment()
+	cd.Spec.PowerStateSchedule = &hivev1.PowerStateSchedule{Hibernate: hibernate, Resume: resume, TimeZone: timeZone}
+	return cd
+}
+
+func clusterDeploymentWithResumeReadiness(readyPercent int32, timeout time.Duration) *hivev1.ClusterDeployment {
+	cd := validAWSClusterDeployment()
+	cd.Spec.ResumeReadiness = &hivev1.ResumeReadiness{
+		Nodes: &hivev1.NodesResumeCheck{ReadyPercent: &readyPercent},
+		ClusterOperators: &hivev1.ResumeCheck{
+			Timeout: &metav1.Duration{Duration: timeout},
+		},
+	}
+	return cd
+}
+
 func validGCPClusterDeployment() *hivev1.ClusterDeployment {
 	cd := clusterDeploymentTemplate()
 	cd.Spec.Platform.GCP = &hivev1gcp.Platform{
@@ -141,6 +252,48 @@ func validOvirtClusterDeployment() *hivev1.ClusterDeployment {
 	return cd
 }
 
+func validOCIClusterDeployment() *hivev1.ClusterDeployment {
+	cd := clusterDeploymentTemplate()
+	cd.Spec.Platform.OCI = &hivev1oci.Platform{
+		CredentialsSecretRef: corev1.LocalObjectReference{Name: "fake-creds-secret"},
+		Region:               "us-ashburn-1",
+		CompartmentID:        "ocid1.compartment.oc1..fake",
+	}
+	return cd
+}
+
+func validPowerVSClusterDeployment() *hivev1.ClusterDeployment {
+	cd := clusterDeploymentTemplate()
+	cd.Spec.Platform.PowerVS = &hivev1powervs.Platform{
+		CredentialsSecretRef: corev1.LocalObjectReference{Name: "fake-creds-secret"},
+		Region:               "dal",
+		Zone:                 "dal12",
+		ServiceInstanceID:    "fake-service-instance",
+	}
+	return cd
+}
+
+func validNoneClusterDeployment() *hivev1.ClusterDeployment {
+	cd := clusterDeploymentTemplate()
+	cd.Spec.Platform.None = &hivev1none.Platform{}
+	return cd
+}
+
+func validAgentImageClusterDeployment() *hivev1.ClusterDeployment {
+	cd := validNoneClusterDeployment()
+	cd.Spec.Provisioning.InstallStrategy = &hivev1.InstallStrategy{
+		AgentImage: &hivev1agent.ImageInstallStrategy{
+			AgentConfigSecretRef: corev1.LocalObjectReference{Name: "agent-config"},
+			S3: hivev1agent.S3ArtifactStore{
+				CredentialsSecretRef: corev1.LocalObjectReference{Name: "fake-creds-secret"},
+				Region:               "us-east-1",
+				Bucket:               "agent-artifacts",
+			},
+		},
+	}
+	return cd
+}
+
 func validAgentBareMetalClusterDeployment() *hivev1.ClusterDeployment {
 	cd := clusterDeploymentTemplate()
 	cd.Spec.Platform.AgentBareMetal = &hivev1agent.BareMetalPlatform{
@@ -219,7 +372,7 @@ func TestClusterDeploymentInitialize(t *testing.T) {
 	data := NewClusterDeploymentValidatingAdmissionHook(createDecoder(t))
 
 	// Act
-	err := data.Initialize(nil, nil)
+	err := data.Initialize(&rest.Config{}, nil)
 
 	// Assert
 	assert.Nil(t, err)
@@ -237,6 +390,7 @@ func TestClusterDeploymentValidate(t *testing.T) {
 		gvr                 *metav1.GroupVersionResource
 		enabledFeatureGates []string
 		awsPrivateLink      *hivev1.AWSPrivateLinkConfig
+		existing            []runtime.Object
 	}{
 		{
 			name:            "Test valid create",
@@ -244,6 +398,49 @@ func TestClusterDeploymentValidate(t *testing.T) {
 			operation:       admissionv1beta1.Create,
 			expectedAllowed: true,
 		},
+		{
+			name:      "Test create with well formed credentials secret",
+			newObject: validAWSClusterDeployment(),
+			operation: admissionv1beta1.Create,
+			existing: []runtime.Object{&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "fake-creds-secret"},
+				Data: map[string][]byte{
+					constants.AWSAccessKeyIDSecretKey:     []byte("key-id"),
+					constants.AWSSecretAccessKeySecretKey: []byte("secret-key"),
+				},
+			}},
+			expectedAllowed: true,
+		},
+		{
+			name:      "Test create with malformed credentials secret",
+			newObject: validAzureClusterDeployment(),
+			operation: admissionv1beta1.Create,
+			existing: []runtime.Object{&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "fake-creds-secret"},
+				Data: map[string][]byte{
+					constants.AzureCredentialsName: []byte(`{"clientId":"client","clientSecret":"secret"}`),
+				},
+			}},
+			expectedAllowed: false,
+		},
+		{
+			name: "Test create installed cluster with malformed credentials secret",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Installed = true
+				cd.Spec.ClusterMetadata = &hivev1.ClusterMetadata{
+					InfraID:                  "infra-id",
+					ClusterID:                "cluster-id",
+					AdminKubeconfigSecretRef: corev1.LocalObjectReference{Name: "kubeconfig"},
+				}
+				return cd
+			}(),
+			operation: admissionv1beta1.Create,
+			existing: []runtime.Object{&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "fake-creds-secret"},
+			}},
+			expectedAllowed: true,
+		},
 		{
 			name:            "Test Delete Operation is allowed even with mismatch objects",
 			oldObject:       validAWSClusterDeployment(),
@@ -483,211 +680,660 @@ func TestClusterDeploymentValidate(t *testing.T) {
 			expectedAllowed: false,
 		},
 		{
-			name:            "Test updating existing empty ingress to populated ingress",
-			oldObject:       validAWSClusterDeployment(),
-			newObject:       validClusterDeploymentWithIngress(),
-			operation:       admissionv1beta1.Update,
-			expectedAllowed: true,
-		},
-		{
-			name:      "Test updating existing ingress to one missing default",
-			oldObject: validClusterDeploymentWithIngress(),
+			name: "Test new clusterdeployment with STS",
 			newObject: func() *hivev1.ClusterDeployment {
-				cd := validClusterDeploymentWithIngress()
-				cd.Spec.Ingress[0].Name = "notdefault"
+				cd := validAWSClusterDeployment()
+				cd.Spec.Platform.AWS.CredentialsMode = hivev1aws.ManualCredentialsMode
+				cd.Spec.Platform.AWS.STS = &hivev1aws.STSConfig{}
 				return cd
 			}(),
-			operation:       admissionv1beta1.Update,
-			expectedAllowed: false,
-		},
-		{
-			name:            "Test valid managed domain",
-			newObject:       clusterDeploymentWithManagedDomain("bar.foo.aaa.com"),
 			operation:       admissionv1beta1.Create,
 			expectedAllowed: true,
 		},
 		{
-			name:            "Test base domain is not child of a managed domain",
-			newObject:       clusterDeploymentWithManagedDomain("bar.bad-domain.com"),
+			name: "Test new clusterdeployment with STS without manual credentials mode",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Platform.AWS.CredentialsMode = hivev1aws.MintCredentialsMode
+				cd.Spec.Platform.AWS.STS = &hivev1aws.STSConfig{}
+				return cd
+			}(),
 			operation:       admissionv1beta1.Create,
 			expectedAllowed: false,
 		},
 		{
-			name:            "Test base domain is not direct child of a managed domain",
-			newObject:       clusterDeploymentWithManagedDomain("baz.foo.bbb.com"),
+			name: "Test new clusterdeployment with install timeout",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Provisioning.InstallTimeout = &metav1.Duration{Duration: 2 * time.Hour}
+				return cd
+			}(),
 			operation:       admissionv1beta1.Create,
-			expectedAllowed: false,
+			expectedAllowed: true,
 		},
 		{
-			name:            "Test base domain is not same as one of the managed domains",
-			newObject:       clusterDeploymentWithManagedDomain("foo.aaa.com"),
+			name: "Test new clusterdeployment with negative install timeout",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Provisioning.InstallTimeout = &metav1.Duration{Duration: -time.Hour}
+				return cd
+			}(),
 			operation:       admissionv1beta1.Create,
 			expectedAllowed: false,
 		},
 		{
-			name: "Test managed DNS is valid on GCP",
+			name: "Test new clusterdeployment with tech preview feature set",
 			newObject: func() *hivev1.ClusterDeployment {
-				cd := validGCPClusterDeployment()
-				cd.Spec.ManageDNS = true
-				cd.Spec.BaseDomain = "bar.foo.aaa.com"
+				cd := validAWSClusterDeployment()
+				cd.Spec.Provisioning.FeatureSet = &hivev1.FeatureSetConfig{FeatureSet: configv1.TechPreviewNoUpgrade}
 				return cd
 			}(),
 			operation:       admissionv1beta1.Create,
 			expectedAllowed: true,
 		},
 		{
-			name: "Test managed DNS is valid on Azure",
+			name: "Test new clusterdeployment with feature gates of tech preview feature set",
 			newObject: func() *hivev1.ClusterDeployment {
-				cd := validAzureClusterDeployment()
-				cd.Spec.ManageDNS = true
-				cd.Spec.BaseDomain = "bar.foo.aaa.com"
+				cd := validAWSClusterDeployment()
+				cd.Spec.Provisioning.FeatureSet = &hivev1.FeatureSetConfig{
+					FeatureSet:   configv1.TechPreviewNoUpgrade,
+					FeatureGates: []string{"GatewayAPI=true"},
+				}
 				return cd
 			}(),
 			operation:       admissionv1beta1.Create,
-			expectedAllowed: true,
+			expectedAllowed: false,
 		},
 		{
-			name:      "Test allow modifying controlPlaneConfig",
-			oldObject: validAWSClusterDeployment(),
+			name: "Test new clusterdeployment with custom feature set",
 			newObject: func() *hivev1.ClusterDeployment {
 				cd := validAWSClusterDeployment()
-				cd.Spec.ControlPlaneConfig = hivev1.ControlPlaneConfigSpec{
-					ServingCertificates: hivev1.ControlPlaneServingCertificateSpec{
-						Default: "someNonExistentCertificateBundle",
-					},
+				cd.Spec.Provisioning.FeatureSet = &hivev1.FeatureSetConfig{
+					FeatureSet:   configv1.CustomNoUpgrade,
+					FeatureGates: []string{"GatewayAPI=true", "Foo=false"},
 				}
 				return cd
 			}(),
-			operation:       admissionv1beta1.Update,
+			operation:       admissionv1beta1.Create,
 			expectedAllowed: true,
 		},
 		{
-			name:      "Test allow modifying certificateBundles",
-			oldObject: validAWSClusterDeployment(),
+			name: "Test new clusterdeployment with malformed feature gate",
 			newObject: func() *hivev1.ClusterDeployment {
 				cd := validAWSClusterDeployment()
-				cd.Spec.CertificateBundles = []hivev1.CertificateBundleSpec{
-					{
-						Name:     "testCertificateBundle",
-						Generate: false,
-						CertificateSecretRef: corev1.LocalObjectReference{
-							Name: "testCertBundle-Secret",
-						},
-					},
+				cd.Spec.Provisioning.FeatureSet = &hivev1.FeatureSetConfig{
+					FeatureSet:   configv1.CustomNoUpgrade,
+					FeatureGates: []string{"GatewayAPI"},
 				}
-
 				return cd
 			}(),
-			operation:       admissionv1beta1.Update,
-			expectedAllowed: true,
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
 		},
 		{
-			name: "Test allow modifying installAttemptsLimit",
-			oldObject: func() *hivev1.ClusterDeployment {
-				cd := validAWSClusterDeployment()
-				cd.Spec.InstallAttemptsLimit = new(int32) // zero
-				return cd
-			}(),
+			name: "Test new clusterdeployment with unsupported feature set",
 			newObject: func() *hivev1.ClusterDeployment {
 				cd := validAWSClusterDeployment()
-				cd.Spec.InstallAttemptsLimit = new(int32) // zero
-				*cd.Spec.InstallAttemptsLimit = 1
+				cd.Spec.Provisioning.FeatureSet = &hivev1.FeatureSetConfig{FeatureSet: configv1.LatencySensitive}
 				return cd
 			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test updating existing empty ingress to populated ingress",
+			oldObject:       validAWSClusterDeployment(),
+			newObject:       validClusterDeploymentWithIngress(),
 			operation:       admissionv1beta1.Update,
 			expectedAllowed: true,
 		},
 		{
-			name: "Test invalid wildcard ingress domain",
+			name:      "Test updating existing ingress to one missing default",
+			oldObject: validClusterDeploymentWithIngress(),
 			newObject: func() *hivev1.ClusterDeployment {
 				cd := validClusterDeploymentWithIngress()
-				cd.Spec.Ingress[0].Domain = "*.apps.sameclustername.example.com"
+				cd.Spec.Ingress[0].Name = "notdefault"
 				return cd
 			}(),
-			operation:       admissionv1beta1.Create,
+			operation:       admissionv1beta1.Update,
 			expectedAllowed: false,
 		},
 		{
-			name: "Test invalid domain on ingress domain",
-			newObject: func() *hivev1.ClusterDeployment {
-				cd := validClusterDeploymentWithIngress()
-				cd.Spec.Ingress[0].Domain = "apps.sameclustername.NOTexample.com"
-				return cd
-			}(),
+			name:            "Test valid managed domain",
+			newObject:       clusterDeploymentWithManagedDomain("bar.foo.aaa.com"),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name:            "Test base domain is not child of a managed domain",
+			newObject:       clusterDeploymentWithManagedDomain("bar.bad-domain.com"),
 			operation:       admissionv1beta1.Create,
 			expectedAllowed: false,
 		},
 		{
-			name: "Cluster deployment name is too long",
-			newObject: func() *hivev1.ClusterDeployment {
-				cd := validAWSClusterDeployment()
-				cd.Name = "this-is-a-long-long-long-long-long-long-long-long-long-long-long-long-long-long-long-long-long-long-long-name"
-				return cd
-			}(),
+			name:            "Test base domain is not direct child of a managed domain",
+			newObject:       clusterDeploymentWithManagedDomain("baz.foo.bbb.com"),
 			operation:       admissionv1beta1.Create,
 			expectedAllowed: false,
 		},
 		{
-			name: "Cluster name is too long",
-			newObject: func() *hivev1.ClusterDeployment {
-				cd := validAWSClusterDeployment()
-				cd.Spec.ClusterName = "this-is-a-long-long-long-long-long-long-long-long-long-long-long-long-long-long-long-long-long-long-long-name"
-				return cd
-			}(),
+			name:            "Test base domain is not same as one of the managed domains",
+			newObject:       clusterDeploymentWithManagedDomain("foo.aaa.com"),
 			operation:       admissionv1beta1.Create,
 			expectedAllowed: false,
 		},
 		{
-			name:            "Azure create valid",
-			newObject:       validAzureClusterDeployment(),
+			name:            "Test valid base domain pool",
+			newObject:       clusterDeploymentWithBaseDomainPool(""),
 			operation:       admissionv1beta1.Create,
 			expectedAllowed: true,
 		},
 		{
-			name: "Azure create missing region",
+			name: "Test base domain pool without managed DNS",
 			newObject: func() *hivev1.ClusterDeployment {
-				cd := validAzureClusterDeployment()
-				cd.Spec.Platform.Azure.Region = ""
+				cd := clusterDeploymentWithBaseDomainPool("")
+				cd.Spec.ManageDNS = false
 				return cd
 			}(),
 			operation:       admissionv1beta1.Create,
 			expectedAllowed: false,
 		},
 		{
-			name: "Azure create missing credentials",
+			name: "Test base domain pool without name",
 			newObject: func() *hivev1.ClusterDeployment {
-				cd := validAzureClusterDeployment()
-				cd.Spec.Platform.Azure.CredentialsSecretRef.Name = ""
+				cd := clusterDeploymentWithBaseDomainPool("")
+				cd.Spec.BaseDomainPoolRef.Name = ""
 				return cd
 			}(),
 			operation:       admissionv1beta1.Create,
 			expectedAllowed: false,
 		},
 		{
-			name: "Azure create missing baseDomainResourceGroupName",
-			newObject: func() *hivev1.ClusterDeployment {
-				cd := validAzureClusterDeployment()
-				cd.Spec.Platform.Azure.BaseDomainResourceGroupName = ""
-				return cd
-			}(),
-			operation:       admissionv1beta1.Create,
-			expectedAllowed: false,
+			name:            "Test allocating base domain from pool",
+			oldObject:       clusterDeploymentWithBaseDomainPool(""),
+			newObject:       clusterDeploymentWithBaseDomainPool("abcd1234.foo.aaa.com"),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: true,
 		},
 		{
-			name:      "Azure update region",
-			oldObject: validAzureClusterDeployment(),
-			newObject: func() *hivev1.ClusterDeployment {
-				cd := validAzureClusterDeployment()
-				cd.Spec.Platform.Azure.Region = ""
-				return cd
-			}(),
+			name:            "Test changing base domain allocated from pool",
+			oldObject:       clusterDeploymentWithBaseDomainPool("abcd1234.foo.aaa.com"),
+			newObject:       clusterDeploymentWithBaseDomainPool("efgh5678.foo.aaa.com"),
 			operation:       admissionv1beta1.Update,
 			expectedAllowed: false,
 		},
-		// TODO: ensure Azure clusterDeployments have necessary info for
-		// machine sets
-		// {
-		// 	name: "not setting instance type on Azure machine pool",
+		{
+			name:            "Test create with node tuning",
+			newObject:       clusterDeploymentWithNodeTuning(`{"maxPods": 500}`, "tuning"),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name:            "Test create with node tuning kubelet config not an object",
+			newObject:       clusterDeploymentWithNodeTuning(`[500]`),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test create with node tuning sysctl profile without name",
+			newObject:       clusterDeploymentWithNodeTuning("", ""),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test create with machine pool node tuning",
+			newObject:       clusterDeploymentWithMachinePoolNodeTuning("worker", "infra"),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name:            "Test create with duplicate machine pool node tuning",
+			newObject:       clusterDeploymentWithMachinePoolNodeTuning("infra", "infra"),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test create with machine pool node tuning of invalid name",
+			newObject:       clusterDeploymentWithMachinePoolNodeTuning("Infra_Pool"),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test create with machine pool node tuning without sysctl profiles",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := clusterDeploymentWithMachinePoolNodeTuning("infra")
+				cd.Spec.NodeTuning.MachinePools[0].SysctlProfileRefs = nil
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test adding node tuning",
+			oldObject:       validAWSClusterDeployment(),
+			newObject:       clusterDeploymentWithNodeTuning(`{"maxPods": 500}`),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: true,
+		},
+		{
+			name:            "Test adding force cleanup",
+			oldObject:       validAWSClusterDeployment(),
+			newObject:       clusterDeploymentWithForceCleanup("cloud account closed", time.Hour),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: true,
+		},
+		{
+			name:            "Test adding force cleanup without reason",
+			oldObject:       validAWSClusterDeployment(),
+			newObject:       clusterDeploymentWithForceCleanup(" ", time.Hour),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test create with display name",
+			newObject:       clusterDeploymentWithDisplayName("Payments (staging)"),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name:            "Test create with multi-line display name",
+			newObject:       clusterDeploymentWithDisplayName("Payments\nstaging"),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test changing display name",
+			oldObject:       clusterDeploymentWithDisplayName("Payments (staging)"),
+			newObject:       clusterDeploymentWithDisplayName("Payments (production)"),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: true,
+		},
+		{
+			name:            "Test changing display name to one too long",
+			oldObject:       validAWSClusterDeployment(),
+			newObject:       clusterDeploymentWithDisplayName(strings.Repeat("a", 257)),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test adding sync agent",
+			oldObject:       validAWSClusterDeployment(),
+			newObject:       clusterDeploymentWithSyncAgent("https://api.hub.example.com:6443", time.Minute),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: true,
+		},
+		{
+			name:            "Test create with zero sync agent poll interval",
+			newObject:       clusterDeploymentWithSyncAgent("https://api.hub.example.com:6443", 0),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test create without sync agent hub API URL",
+			newObject:       clusterDeploymentWithSyncAgent("", time.Minute),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test create with non-https sync agent hub API URL",
+			newObject:       clusterDeploymentWithSyncAgent("http://api.hub.example.com:6443", time.Minute),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test adding unreachable remediation",
+			oldObject: validAWSClusterDeployment(),
+			newObject: clusterDeploymentWithUnreachableRemediation(
+				hivev1.UnreachableRemediationStep{
+					Type:                hivev1.RotateKubeconfigRemediationStep,
+					KubeconfigSecretRef: &corev1.LocalObjectReference{Name: "saved-kubeconfig"},
+				},
+				hivev1.UnreachableRemediationStep{Type: hivev1.AlternateAPIURLRemediationStep, APIURL: "https://api.example.com:6443"},
+				hivev1.UnreachableRemediationStep{Type: hivev1.ResumeHibernationRemediationStep},
+			),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: true,
+		},
+		{
+			name:            "Test create with no unreachable remediation steps",
+			newObject:       clusterDeploymentWithUnreachableRemediation(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test create with rotate kubeconfig remediation step without secret",
+			newObject:       clusterDeploymentWithUnreachableRemediation(hivev1.UnreachableRemediationStep{Type: hivev1.RotateKubeconfigRemediationStep}),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test create with invalid alternate API URL remediation step",
+			newObject:       clusterDeploymentWithUnreachableRemediation(hivev1.UnreachableRemediationStep{Type: hivev1.AlternateAPIURLRemediationStep, APIURL: "api.example.com"}),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test create with API URL on resume hibernation remediation step",
+			newObject:       clusterDeploymentWithUnreachableRemediation(hivev1.UnreachableRemediationStep{Type: hivev1.ResumeHibernationRemediationStep, APIURL: "https://api.example.com:6443"}),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test adding power state schedule",
+			oldObject:       validAWSClusterDeployment(),
+			newObject:       clusterDeploymentWithPowerStateSchedule("0 19 * * mon-fri", "0 7 * * mon-fri", "Europe/Paris"),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: true,
+		},
+		{
+			name:            "Test create with invalid power state schedule",
+			newObject:       clusterDeploymentWithPowerStateSchedule("0 19 * *", "0 7 * * *", ""),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test create with invalid power state schedule time zone",
+			newObject:       clusterDeploymentWithPowerStateSchedule("0 19 * * *", "0 7 * * *", "Nowhere/Special"),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test adding resume readiness",
+			oldObject:       validAWSClusterDeployment(),
+			newObject:       clusterDeploymentWithResumeReadiness(90, 20*time.Minute),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: true,
+		},
+		{
+			name:            "Test create with invalid resume readiness node percentage",
+			newObject:       clusterDeploymentWithResumeReadiness(0, 20*time.Minute),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test create with negative resume readiness timeout",
+			newObject:       clusterDeploymentWithResumeReadiness(90, -time.Minute),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test adding provision retry policy",
+			oldObject:       validAWSClusterDeployment(),
+			newObject:       clusterDeploymentWithProvisionRetryPolicy(time.Minute, time.Hour),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: true,
+		},
+		{
+			name:            "Test create with zero provision retry backoff base",
+			newObject:       clusterDeploymentWithProvisionRetryPolicy(0, time.Hour),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test create with provision retry max backoff less than backoff base",
+			newObject:       clusterDeploymentWithProvisionRetryPolicy(time.Hour, time.Minute),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test rotating kubeadmin password",
+			oldObject:       clusterDeploymentWithKubeadmin(hivev1.KubeadminManagement{PasswordRotation: "1"}),
+			newObject:       clusterDeploymentWithKubeadmin(hivev1.KubeadminManagement{PasswordRotation: "2"}),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: true,
+		},
+		{
+			name:            "Test create with restore ref",
+			newObject:       clusterDeploymentWithRestoreRef("test-cluster-exit"),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name:            "Test create with restore ref without backup name",
+			newObject:       clusterDeploymentWithRestoreRef(""),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test changing restore ref",
+			oldObject:       clusterDeploymentWithRestoreRef("test-cluster-exit"),
+			newObject:       clusterDeploymentWithRestoreRef("other-backup"),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test adding readiness gate",
+			oldObject:       validAWSClusterDeployment(),
+			newObject:       clusterDeploymentWithReadinessGates("example.com/ComplianceScanPassed"),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: true,
+		},
+		{
+			name:            "Test create with invalid readiness gate",
+			newObject:       clusterDeploymentWithReadinessGates("compliance scan passed"),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test create with readiness gate on hive condition",
+			newObject:       clusterDeploymentWithReadinessGates(hivev1.ReadyClusterDeploymentCondition),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test create with duplicate readiness gates",
+			newObject:       clusterDeploymentWithReadinessGates("example.com/ComplianceScanPassed", "example.com/ComplianceScanPassed"),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test create with negative force cleanup timeout",
+			newObject:       clusterDeploymentWithForceCleanup("cloud account closed", -time.Minute),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test managed DNS is valid on GCP",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validGCPClusterDeployment()
+				cd.Spec.ManageDNS = true
+				cd.Spec.BaseDomain = "bar.foo.aaa.com"
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "Test managed DNS is valid on OCI",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validOCIClusterDeployment()
+				cd.Spec.ManageDNS = true
+				cd.Spec.BaseDomain = "bar.foo.aaa.com"
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "Test managed DNS is valid on Azure",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAzureClusterDeployment()
+				cd.Spec.ManageDNS = true
+				cd.Spec.BaseDomain = "bar.foo.aaa.com"
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name:      "Test allow modifying controlPlaneConfig",
+			oldObject: validAWSClusterDeployment(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.ControlPlaneConfig = hivev1.ControlPlaneConfigSpec{
+					ServingCertificates: hivev1.ControlPlaneServingCertificateSpec{
+						Default: "someNonExistentCertificateBundle",
+					},
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: true,
+		},
+		{
+			name:      "Test allow modifying certificateBundles",
+			oldObject: validAWSClusterDeployment(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.CertificateBundles = []hivev1.CertificateBundleSpec{
+					{
+						Name:     "testCertificateBundle",
+						Generate: false,
+						CertificateSecretRef: corev1.LocalObjectReference{
+							Name: "testCertBundle-Secret",
+						},
+					},
+				}
+
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: true,
+		},
+		{
+			name: "Test allow modifying installAttemptsLimit",
+			oldObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.InstallAttemptsLimit = new(int32) // zero
+				return cd
+			}(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.InstallAttemptsLimit = new(int32) // zero
+				*cd.Spec.InstallAttemptsLimit = 1
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: true,
+		},
+		{
+			name: "Test invalid wildcard ingress domain",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validClusterDeploymentWithIngress()
+				cd.Spec.Ingress[0].Domain = "*.apps.sameclustername.example.com"
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test invalid domain on ingress domain",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validClusterDeploymentWithIngress()
+				cd.Spec.Ingress[0].Domain = "apps.sameclustername.NOTexample.com"
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test additional ingress with custom domain",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validClusterDeploymentWithIngress()
+				cd.Spec.Ingress = append(cd.Spec.Ingress, hivev1.ClusterIngress{
+					Name:   "shard",
+					Domain: "apps.custom.example.org",
+					Scope:  hivev1.IngressScopeInternal,
+				})
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "Test invalid wildcard domain on additional ingress",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validClusterDeploymentWithIngress()
+				cd.Spec.Ingress = append(cd.Spec.Ingress, hivev1.ClusterIngress{
+					Name:   "shard",
+					Domain: "*.apps.custom.example.org",
+				})
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Cluster deployment name is too long",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Name = "this-is-a-long-long-long-long-long-long-long-long-long-long-long-long-long-long-long-long-long-long-long-name"
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Cluster name is too long",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.ClusterName = "this-is-a-long-long-long-long-long-long-long-long-long-long-long-long-long-long-long-long-long-long-long-name"
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:            "Azure create valid",
+			newObject:       validAzureClusterDeployment(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "Azure create missing region",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAzureClusterDeployment()
+				cd.Spec.Platform.Azure.Region = ""
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Azure create missing credentials",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAzureClusterDeployment()
+				cd.Spec.Platform.Azure.CredentialsSecretRef.Name = ""
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Azure create missing baseDomainResourceGroupName",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAzureClusterDeployment()
+				cd.Spec.Platform.Azure.BaseDomainResourceGroupName = ""
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:      "Azure update region",
+			oldObject: validAzureClusterDeployment(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAzureClusterDeployment()
+				cd.Spec.Platform.Azure.Region = ""
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
+		// TODO: ensure Azure clusterDeployments have necessary info for
+		// machine sets
+		// {
+		// 	name: "not setting instance type on Azure machine pool",
 		// 	newObject: func() *hivev1.ClusterDeployment {
 		// 		cd := validAzureClusterDeployment()
 		// 		cd.Spec.Compute = []hivev1.MachinePool{
@@ -759,69 +1405,338 @@ func TestClusterDeploymentValidate(t *testing.T) {
 			expectedAllowed: false,
 		},
 		{
-			name: "valid serving certificate",
+			name: "valid serving certificate",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.CertificateBundles = []hivev1.CertificateBundleSpec{
+					{
+						Name:                 "test-serving-cert",
+						CertificateSecretRef: corev1.LocalObjectReference{Name: "test-serving-cert-secret"},
+					},
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "serving certificate without name",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.CertificateBundles = []hivev1.CertificateBundleSpec{
+					{
+						CertificateSecretRef: corev1.LocalObjectReference{Name: "test-serving-cert-secret"},
+					},
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "serving certificate without secret reference",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.CertificateBundles = []hivev1.CertificateBundleSpec{
+					{
+						Name: "test-serving-cert",
+					},
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "serving certificate issued by cert-manager",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.CertificateBundles = []hivev1.CertificateBundleSpec{
+					{
+						Name:                 "test-serving-cert",
+						CertificateSecretRef: corev1.LocalObjectReference{Name: "test-serving-cert-secret"},
+						CertManager: &hivev1.CertManagerCertificate{
+							IssuerRef: hivev1.CertManagerIssuerReference{Name: "test-issuer"},
+						},
+					},
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "serving certificate issued by cert-manager without issuer",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.CertificateBundles = []hivev1.CertificateBundleSpec{
+					{
+						Name:                 "test-serving-cert",
+						CertificateSecretRef: corev1.LocalObjectReference{Name: "test-serving-cert-secret"},
+						CertManager:          &hivev1.CertManagerCertificate{},
+					},
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "InstallConfig is missing",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Provisioning.InstallConfigSecretRef = nil
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:            "valid GCP clusterdeployment",
+			newObject:       validGCPClusterDeployment(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "Provisioning is missing",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Provisioning = nil
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:            "OCI create valid",
+			newObject:       validOCIClusterDeployment(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "OCI create missing compartment",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validOCIClusterDeployment()
+				cd.Spec.Platform.OCI.CompartmentID = ""
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "OCI create missing region",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validOCIClusterDeployment()
+				cd.Spec.Platform.OCI.Region = ""
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:            "PowerVS create valid",
+			newObject:       validPowerVSClusterDeployment(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "PowerVS create missing service instance",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validPowerVSClusterDeployment()
+				cd.Spec.Platform.PowerVS.ServiceInstanceID = ""
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "PowerVS create missing zone",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validPowerVSClusterDeployment()
+				cd.Spec.Platform.PowerVS.Zone = ""
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test managed DNS is not valid on PowerVS",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validPowerVSClusterDeployment()
+				cd.Spec.ManageDNS = true
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:            "None create valid",
+			newObject:       validNoneClusterDeployment(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "None create valid with object store ignition delivery",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validNoneClusterDeployment()
+				cd.Spec.Platform.None.IgnitionDelivery = hivev1none.IgnitionDelivery{
+					Type: hivev1none.IgnitionDeliveryObjectStore,
+					ObjectStore: &hivev1none.IgnitionObjectStore{
+						URLsSecretRef: corev1.LocalObjectReference{Name: "ignition-urls"},
+					},
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "None create object store ignition delivery missing URLs secret",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validNoneClusterDeployment()
+				cd.Spec.Platform.None.IgnitionDelivery = hivev1none.IgnitionDelivery{
+					Type: hivev1none.IgnitionDeliveryObjectStore,
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "None create negative infrastructure timeout",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validNoneClusterDeployment()
+				cd.Spec.Platform.None.InfrastructureTimeout = &metav1.Duration{Duration: -time.Minute}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "None create valid with job destroyer",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validNoneClusterDeployment()
+				cd.Spec.Platform.None.Destroyer = &hivev1none.ExternalDestroyer{
+					Job: &hivev1none.DestroyerJob{Image: "example.com/destroyer:latest"},
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "None create valid with webhook destroyer",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validNoneClusterDeployment()
+				cd.Spec.Platform.None.Destroyer = &hivev1none.ExternalDestroyer{
+					Webhook: &hivev1none.DestroyerWebhook{
+						URL:            "https://destroyer.example.com/destroy",
+						TokenSecretRef: &corev1.LocalObjectReference{Name: "destroyer-token"},
+					},
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "None create destroyer with job and webhook",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validNoneClusterDeployment()
+				cd.Spec.Platform.None.Destroyer = &hivev1none.ExternalDestroyer{
+					Job:     &hivev1none.DestroyerJob{Image: "example.com/destroyer:latest"},
+					Webhook: &hivev1none.DestroyerWebhook{URL: "https://destroyer.example.com/destroy"},
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "None create empty destroyer",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validNoneClusterDeployment()
+				cd.Spec.Platform.None.Destroyer = &hivev1none.ExternalDestroyer{}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "None create job destroyer missing image",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validNoneClusterDeployment()
+				cd.Spec.Platform.None.Destroyer = &hivev1none.ExternalDestroyer{
+					Job: &hivev1none.DestroyerJob{},
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "None create webhook destroyer invalid URL",
 			newObject: func() *hivev1.ClusterDeployment {
-				cd := validAWSClusterDeployment()
-				cd.Spec.CertificateBundles = []hivev1.CertificateBundleSpec{
-					{
-						Name:                 "test-serving-cert",
-						CertificateSecretRef: corev1.LocalObjectReference{Name: "test-serving-cert-secret"},
-					},
+				cd := validNoneClusterDeployment()
+				cd.Spec.Platform.None.Destroyer = &hivev1none.ExternalDestroyer{
+					Webhook: &hivev1none.DestroyerWebhook{URL: "ftp://destroyer.example.com"},
 				}
 				return cd
 			}(),
 			operation:       admissionv1beta1.Create,
-			expectedAllowed: true,
+			expectedAllowed: false,
 		},
 		{
-			name: "serving certificate without name",
+			name: "Test managed DNS is not valid on None",
 			newObject: func() *hivev1.ClusterDeployment {
-				cd := validAWSClusterDeployment()
-				cd.Spec.CertificateBundles = []hivev1.CertificateBundleSpec{
-					{
-						CertificateSecretRef: corev1.LocalObjectReference{Name: "test-serving-cert-secret"},
-					},
-				}
+				cd := validNoneClusterDeployment()
+				cd.Spec.ManageDNS = true
 				return cd
 			}(),
 			operation:       admissionv1beta1.Create,
 			expectedAllowed: false,
 		},
 		{
-			name: "serving certificate without secret reference",
+			name:            "Agent image create valid",
+			newObject:       validAgentImageClusterDeployment(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "Agent image create on AWS",
 			newObject: func() *hivev1.ClusterDeployment {
 				cd := validAWSClusterDeployment()
-				cd.Spec.CertificateBundles = []hivev1.CertificateBundleSpec{
-					{
-						Name: "test-serving-cert",
-					},
-				}
+				cd.Spec.Provisioning.InstallStrategy = validAgentImageClusterDeployment().Spec.Provisioning.InstallStrategy
 				return cd
 			}(),
 			operation:       admissionv1beta1.Create,
 			expectedAllowed: false,
 		},
 		{
-			name: "InstallConfig is missing",
+			name: "Agent image create missing agent config",
 			newObject: func() *hivev1.ClusterDeployment {
-				cd := validAWSClusterDeployment()
-				cd.Spec.Provisioning.InstallConfigSecretRef = nil
+				cd := validAgentImageClusterDeployment()
+				cd.Spec.Provisioning.InstallStrategy.AgentImage.AgentConfigSecretRef.Name = ""
 				return cd
 			}(),
 			operation:       admissionv1beta1.Create,
 			expectedAllowed: false,
 		},
 		{
-			name:            "valid GCP clusterdeployment",
-			newObject:       validGCPClusterDeployment(),
+			name: "Agent image create missing bucket",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAgentImageClusterDeployment()
+				cd.Spec.Provisioning.InstallStrategy.AgentImage.S3.Bucket = ""
+				return cd
+			}(),
 			operation:       admissionv1beta1.Create,
-			expectedAllowed: true,
+			expectedAllowed: false,
 		},
 		{
-			name: "Provisioning is missing",
+			name: "Agent image create URL expiration too long",
 			newObject: func() *hivev1.ClusterDeployment {
-				cd := validAWSClusterDeployment()
-				cd.Spec.Provisioning = nil
+				cd := validAgentImageClusterDeployment()
+				cd.Spec.Provisioning.InstallStrategy.AgentImage.S3.URLExpiration = &metav1.Duration{Duration: 8 * 24 * time.Hour}
 				return cd
 			}(),
 			operation:       admissionv1beta1.Create,
@@ -1124,6 +2039,272 @@ func TestClusterDeploymentValidate(t *testing.T) {
 				}},
 			},
 		},
+		{
+			name: "private link enabled, additional allowed principals",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Platform.AWS.PrivateLink = &hivev1aws.PrivateLinkAccess{
+					Enabled:                     true,
+					AdditionalAllowedPrincipals: []string{"arn:aws:iam::67890:root"},
+				}
+				return cd
+			}(),
+			operation:           admissionv1beta1.Create,
+			expectedAllowed:     true,
+			enabledFeatureGates: []string{hivev1.FeatureGateMachineManagement},
+			awsPrivateLink: &hivev1.AWSPrivateLinkConfig{
+				EndpointVPCInventory: []hivev1.AWSPrivateLinkInventory{{
+					AWSPrivateLinkVPC: hivev1.AWSPrivateLinkVPC{
+						Region: "test-region",
+						VPCID:  "vpc-id",
+					},
+				}},
+			},
+		},
+		{
+			name: "private link enabled, additional allowed principal is not an ARN",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Platform.AWS.PrivateLink = &hivev1aws.PrivateLinkAccess{
+					Enabled:                     true,
+					AdditionalAllowedPrincipals: []string{"67890"},
+				}
+				return cd
+			}(),
+			operation:           admissionv1beta1.Create,
+			expectedAllowed:     false,
+			enabledFeatureGates: []string{hivev1.FeatureGateMachineManagement},
+			awsPrivateLink: &hivev1.AWSPrivateLinkConfig{
+				EndpointVPCInventory: []hivev1.AWSPrivateLinkInventory{{
+					AWSPrivateLinkVPC: hivev1.AWSPrivateLinkVPC{
+						Region: "test-region",
+						VPCID:  "vpc-id",
+					},
+				}},
+			},
+		},
+		{
+			name: "private link enabled, all principals allowed",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Platform.AWS.PrivateLink = &hivev1aws.PrivateLinkAccess{
+					Enabled:                     true,
+					AdditionalAllowedPrincipals: []string{"*"},
+				}
+				return cd
+			}(),
+			operation:           admissionv1beta1.Create,
+			expectedAllowed:     false,
+			enabledFeatureGates: []string{hivev1.FeatureGateMachineManagement},
+			awsPrivateLink: &hivev1.AWSPrivateLinkConfig{
+				EndpointVPCInventory: []hivev1.AWSPrivateLinkInventory{{
+					AWSPrivateLinkVPC: hivev1.AWSPrivateLinkVPC{
+						Region: "test-region",
+						VPCID:  "vpc-id",
+					},
+				}},
+			},
+		},
+		{
+			name: "private link enabled, duplicate additional allowed principals",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Platform.AWS.PrivateLink = &hivev1aws.PrivateLinkAccess{
+					Enabled:                     true,
+					AdditionalAllowedPrincipals: []string{"arn:aws:iam::67890:root", "arn:aws:iam::67890:root"},
+				}
+				return cd
+			}(),
+			operation:           admissionv1beta1.Create,
+			expectedAllowed:     false,
+			enabledFeatureGates: []string{hivev1.FeatureGateMachineManagement},
+			awsPrivateLink: &hivev1.AWSPrivateLinkConfig{
+				EndpointVPCInventory: []hivev1.AWSPrivateLinkInventory{{
+					AWSPrivateLinkVPC: hivev1.AWSPrivateLinkVPC{
+						Region: "test-region",
+						VPCID:  "vpc-id",
+					},
+				}},
+			},
+		},
+		{
+			name: "private link enabled, additional allowed principals changed",
+			oldObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Platform.AWS.PrivateLink = &hivev1aws.PrivateLinkAccess{
+					Enabled:                     true,
+					AdditionalAllowedPrincipals: []string{"arn:aws:iam::67890:root"},
+				}
+				return cd
+			}(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Platform.AWS.PrivateLink = &hivev1aws.PrivateLinkAccess{
+					Enabled:                     true,
+					AdditionalAllowedPrincipals: []string{"arn:aws:iam::67890:root", "arn:aws:iam::13579:root"},
+				}
+				return cd
+			}(),
+			operation:           admissionv1beta1.Update,
+			expectedAllowed:     true,
+			enabledFeatureGates: []string{hivev1.FeatureGateMachineManagement},
+			awsPrivateLink: &hivev1.AWSPrivateLinkConfig{
+				EndpointVPCInventory: []hivev1.AWSPrivateLinkInventory{{
+					AWSPrivateLinkVPC: hivev1.AWSPrivateLinkVPC{
+						Region: "test-region",
+						VPCID:  "vpc-id",
+					},
+				}},
+			},
+		},
+		{
+			name: "private link enabled, additional allowed principals changed to an invalid principal",
+			oldObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Platform.AWS.PrivateLink = &hivev1aws.PrivateLinkAccess{
+					Enabled:                     true,
+					AdditionalAllowedPrincipals: []string{"arn:aws:iam::67890:root"},
+				}
+				return cd
+			}(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Platform.AWS.PrivateLink = &hivev1aws.PrivateLinkAccess{
+					Enabled:                     true,
+					AdditionalAllowedPrincipals: []string{"*"},
+				}
+				return cd
+			}(),
+			operation:           admissionv1beta1.Update,
+			expectedAllowed:     false,
+			enabledFeatureGates: []string{hivev1.FeatureGateMachineManagement},
+			awsPrivateLink: &hivev1.AWSPrivateLinkConfig{
+				EndpointVPCInventory: []hivev1.AWSPrivateLinkInventory{{
+					AWSPrivateLinkVPC: hivev1.AWSPrivateLinkVPC{
+						Region: "test-region",
+						VPCID:  "vpc-id",
+					},
+				}},
+			},
+		},
+		{
+			name: "private link enabled, acceptance required with auto-accepted accounts",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Platform.AWS.PrivateLink = &hivev1aws.PrivateLinkAccess{
+					Enabled:            true,
+					EndpointAcceptance: &hivev1aws.PrivateLinkEndpointAcceptance{AcceptanceRequired: true, AutoAcceptAccountIDs: []string{"123456789012"}},
+				}
+				return cd
+			}(),
+			operation:           admissionv1beta1.Create,
+			expectedAllowed:     true,
+			enabledFeatureGates: []string{hivev1.FeatureGateMachineManagement},
+			awsPrivateLink: &hivev1.AWSPrivateLinkConfig{
+				EndpointVPCInventory: []hivev1.AWSPrivateLinkInventory{{
+					AWSPrivateLinkVPC: hivev1.AWSPrivateLinkVPC{
+						Region: "test-region",
+						VPCID:  "vpc-id",
+					},
+				}},
+			},
+		},
+		{
+			name: "private link enabled, auto-accepted account is not an account ID",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Platform.AWS.PrivateLink = &hivev1aws.PrivateLinkAccess{
+					Enabled:            true,
+					EndpointAcceptance: &hivev1aws.PrivateLinkEndpointAcceptance{AcceptanceRequired: true, AutoAcceptAccountIDs: []string{"12345"}},
+				}
+				return cd
+			}(),
+			operation:           admissionv1beta1.Create,
+			expectedAllowed:     false,
+			enabledFeatureGates: []string{hivev1.FeatureGateMachineManagement},
+			awsPrivateLink: &hivev1.AWSPrivateLinkConfig{
+				EndpointVPCInventory: []hivev1.AWSPrivateLinkInventory{{
+					AWSPrivateLinkVPC: hivev1.AWSPrivateLinkVPC{
+						Region: "test-region",
+						VPCID:  "vpc-id",
+					},
+				}},
+			},
+		},
+		{
+			name: "private link enabled, duplicate auto-accepted accounts",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Platform.AWS.PrivateLink = &hivev1aws.PrivateLinkAccess{
+					Enabled:            true,
+					EndpointAcceptance: &hivev1aws.PrivateLinkEndpointAcceptance{AcceptanceRequired: true, AutoAcceptAccountIDs: []string{"123456789012", "123456789012"}},
+				}
+				return cd
+			}(),
+			operation:           admissionv1beta1.Create,
+			expectedAllowed:     false,
+			enabledFeatureGates: []string{hivev1.FeatureGateMachineManagement},
+			awsPrivateLink: &hivev1.AWSPrivateLinkConfig{
+				EndpointVPCInventory: []hivev1.AWSPrivateLinkInventory{{
+					AWSPrivateLinkVPC: hivev1.AWSPrivateLinkVPC{
+						Region: "test-region",
+						VPCID:  "vpc-id",
+					},
+				}},
+			},
+		},
+		{
+			name: "private link enabled, auto-accepted accounts without acceptance required",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Platform.AWS.PrivateLink = &hivev1aws.PrivateLinkAccess{
+					Enabled:            true,
+					EndpointAcceptance: &hivev1aws.PrivateLinkEndpointAcceptance{AcceptanceRequired: false, AutoAcceptAccountIDs: []string{"123456789012"}},
+				}
+				return cd
+			}(),
+			operation:           admissionv1beta1.Create,
+			expectedAllowed:     false,
+			enabledFeatureGates: []string{hivev1.FeatureGateMachineManagement},
+			awsPrivateLink: &hivev1.AWSPrivateLinkConfig{
+				EndpointVPCInventory: []hivev1.AWSPrivateLinkInventory{{
+					AWSPrivateLinkVPC: hivev1.AWSPrivateLinkVPC{
+						Region: "test-region",
+						VPCID:  "vpc-id",
+					},
+				}},
+			},
+		},
+		{
+			name: "private link enabled, acceptance required changed",
+			oldObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Platform.AWS.PrivateLink = &hivev1aws.PrivateLinkAccess{
+					Enabled:            true,
+					EndpointAcceptance: nil,
+				}
+				return cd
+			}(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Platform.AWS.PrivateLink = &hivev1aws.PrivateLinkAccess{
+					Enabled:            true,
+					EndpointAcceptance: &hivev1aws.PrivateLinkEndpointAcceptance{AcceptanceRequired: true, AutoAcceptAccountIDs: []string{"123456789012"}},
+				}
+				return cd
+			}(),
+			operation:           admissionv1beta1.Update,
+			expectedAllowed:     true,
+			enabledFeatureGates: []string{hivev1.FeatureGateMachineManagement},
+			awsPrivateLink: &hivev1.AWSPrivateLinkConfig{
+				EndpointVPCInventory: []hivev1.AWSPrivateLinkInventory{{
+					AWSPrivateLinkVPC: hivev1.AWSPrivateLinkVPC{
+						Region: "test-region",
+						VPCID:  "vpc-id",
+					},
+				}},
+			},
+		},
 	}
 
 	for _, tc := range cases {
@@ -1138,6 +2319,7 @@ func TestClusterDeploymentValidate(t *testing.T) {
 					},
 				},
 				awsPrivateLinkConfig: tc.awsPrivateLink,
+				client:               fake.NewFakeClient(tc.existing...),
 			}
 
 			if tc.gvr == nil {
@@ -1,29 +1,39 @@
 package v1
 
 import (
+	"fmt"
 	"net/http"
 	"reflect"
+	"strings"
 
 	log "github.com/sirupsen/logrus"
 
 	admissionv1beta1 "k8s.io/api/admission/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	webhookutil "github.com/openshift/hive/pkg/util/webhook"
 )
 
 const (
 	clusterImageSetGroup    = "hive.openshift.io"
 	clusterImageSetVersion  = "v1"
 	clusterImageSetResource = "clusterimagesets"
+
+	// maxListedImageSetUsers is the most users of a ClusterImageSet listed when refusing its deletion.
+	maxListedImageSetUsers = 5
 )
 
 // ClusterImageSetValidatingAdmissionHook is a struct that is used to reference what code should be run by the generic-admission-server.
 type ClusterImageSetValidatingAdmissionHook struct {
 	decoder *admission.Decoder
+	client  client.Client
 }
 
 // NewClusterImageSetValidatingAdmissionHook constructs a new ClusterImageSetValidatingAdmissionHook
@@ -57,7 +67,17 @@ func (a *ClusterImageSetValidatingAdmissionHook) Initialize(kubeClientConfig *re
 		"version":  "v1",
 		"resource": "clusterimagesetvalidator",
 	}).Info("Initializing validation REST resource")
-	return nil // No initialization needed right now.
+
+	scheme := runtime.NewScheme()
+	if err := hivev1.AddToScheme(scheme); err != nil {
+		return err
+	}
+	c, err := webhookutil.NewClient(kubeClientConfig, scheme)
+	if err != nil {
+		return err
+	}
+	a.client = c
+	return nil
 }
 
 // Validate is called by generic-admission-server when the registered REST resource above is called with an admission request.
@@ -90,7 +110,11 @@ func (a *ClusterImageSetValidatingAdmissionHook) Validate(admissionSpec *admissi
 		return a.validateUpdate(admissionSpec)
 	}
 
-	// We're only validating creates and updates at this time, so all other operations are explicitly allowed.
+	if admissionSpec.Operation == admissionv1beta1.Delete {
+		return a.validateDelete(admissionSpec)
+	}
+
+	// We're only validating creates, updates and deletes at this time, so all other operations are explicitly allowed.
 	contextLogger.Info("Successful validation")
 	return &admissionv1beta1.AdmissionResponse{
 		Allowed: true,
@@ -212,8 +236,11 @@ func (a *ClusterImageSetValidatingAdmissionHook) validateUpdate(admissionSpec *a
 	// Add the new data to the contextLogger
 	contextLogger.Data["oldObject.Name"] = oldObject.Name
 
-	if !reflect.DeepEqual(oldObject.Spec, newObject.Spec) {
-		message := "ClusterImageSet.Spec is immutable"
+	// Whether the images are pre-pulled can be changed, but not what they are.
+	oldSpec := oldObject.Spec
+	oldSpec.PrePullImages = newObject.Spec.PrePullImages
+	if !reflect.DeepEqual(oldSpec, newObject.Spec) {
+		message := "ClusterImageSet.Spec is immutable except for prePullImages"
 		contextLogger.Infof("Failed validation: %v", message)
 
 		return &admissionv1beta1.AdmissionResponse{
@@ -231,3 +258,57 @@ func (a *ClusterImageSetValidatingAdmissionHook) validateUpdate(admissionSpec *a
 		Allowed: true,
 	}
 }
+
+// validateDelete specifically validates delete operations for ClusterImageSet objects. An image set cannot be deleted
+// while ClusterDeployments or ClusterPools use it.
+func (a *ClusterImageSetValidatingAdmissionHook) validateDelete(admissionSpec *admissionv1beta1.AdmissionRequest) *admissionv1beta1.AdmissionResponse {
+	contextLogger := log.WithFields(log.Fields{
+		"operation":   admissionSpec.Operation,
+		"group":       admissionSpec.Resource.Group,
+		"version":     admissionSpec.Resource.Version,
+		"resource":    admissionSpec.Resource.Resource,
+		"method":      "validateDelete",
+		"object.Name": admissionSpec.Name,
+	})
+
+	cds, pools, err := controllerutils.ClusterImageSetUsers(a.client, admissionSpec.Name)
+	if err != nil {
+		contextLogger.WithError(err).Error("Failed listing users of ClusterImageSet")
+		return &admissionv1beta1.AdmissionResponse{
+			Allowed: false,
+			Result: &metav1.Status{
+				Status: metav1.StatusFailure, Code: http.StatusInternalServerError, Reason: metav1.StatusReasonInternalError,
+				Message: err.Error(),
+			},
+		}
+	}
+
+	if len(cds)+len(pools) > 0 {
+		var users []string
+		for _, cd := range cds {
+			users = append(users, fmt.Sprintf("ClusterDeployment %s/%s", cd.Namespace, cd.Name))
+		}
+		for _, pool := range pools {
+			users = append(users, fmt.Sprintf("ClusterPool %s/%s", pool.Namespace, pool.Name))
+		}
+		if len(users) > maxListedImageSetUsers {
+			users = append(users[:maxListedImageSetUsers], fmt.Sprintf("%d more", len(users)-maxListedImageSetUsers))
+		}
+		message := fmt.Sprintf("ClusterImageSet is in use by %d ClusterDeployments and %d ClusterPools: %s",
+			len(cds), len(pools), strings.Join(users, ", "))
+		contextLogger.Infof("Failed validation: %v", message)
+		return &admissionv1beta1.AdmissionResponse{
+			Allowed: false,
+			Result: &metav1.Status{
+				Status: metav1.StatusFailure, Code: http.StatusConflict, Reason: metav1.StatusReasonConflict,
+				Message: message,
+			},
+		}
+	}
+
+	// If we get here, then all checks passed, so the object is valid.
+	contextLogger.Info("Successful validation")
+	return &admissionv1beta1.AdmissionResponse{
+		Allowed: true,
+	}
+}
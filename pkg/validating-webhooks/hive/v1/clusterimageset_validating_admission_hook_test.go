@@ -10,8 +10,12 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
+const testClusterImageSetName = "test-image-set"
+
 func TestClusterImageSetValidatingResource(t *testing.T) {
 	// Arrange
 	data := NewClusterImageSetValidatingAdmissionHook(createDecoder(t))
@@ -35,15 +39,38 @@ func TestClusterImageSetInitialize(t *testing.T) {
 	data := NewClusterImageSetValidatingAdmissionHook(createDecoder(t))
 
 	// Act
-	err := data.Initialize(nil, nil)
+	err := data.Initialize(&rest.Config{}, nil)
 
 	// Assert
 	assert.Nil(t, err)
 }
 
 func TestClusterImageSetValidate(t *testing.T) {
+	scheme := runtime.NewScheme()
+	hivev1.AddToScheme(scheme)
+
+	clusterDeployment := func(imageSetName string) *hivev1.ClusterDeployment {
+		return &hivev1.ClusterDeployment{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "test-cluster-deployment"},
+			Spec: hivev1.ClusterDeploymentSpec{
+				Provisioning: &hivev1.Provisioning{
+					ImageSetRef: &hivev1.ClusterImageSetReference{Name: imageSetName},
+				},
+			},
+		}
+	}
+	clusterPool := func(imageSetName string) *hivev1.ClusterPool {
+		return &hivev1.ClusterPool{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "test-cluster-pool"},
+			Spec: hivev1.ClusterPoolSpec{
+				ImageSetRef: hivev1.ClusterImageSetReference{Name: imageSetName},
+			},
+		}
+	}
+
 	cases := []struct {
 		name            string
+		existing        []runtime.Object
 		newSpec         hivev1.ClusterImageSetSpec
 		oldSpec         hivev1.ClusterImageSetSpec
 		newObjectRaw    []byte
@@ -96,10 +123,43 @@ func TestClusterImageSetValidate(t *testing.T) {
 			expectedAllowed: false,
 		},
 		{
-			name:            "Test that we don't validate deletes",
+			name: "Test ClusterImageSet.Spec.PrePullImages is mutable",
+			newSpec: hivev1.ClusterImageSetSpec{
+				ReleaseImage:  "a:tag",
+				PrePullImages: true,
+			},
+			oldSpec: hivev1.ClusterImageSetSpec{
+				ReleaseImage: "a:tag",
+			},
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: true,
+		},
+		{
+			name:            "Test delete of unused ClusterImageSet",
 			operation:       admissionv1beta1.Delete,
 			expectedAllowed: true,
 		},
+		{
+			name: "Test delete of ClusterImageSet used by others",
+			existing: []runtime.Object{
+				clusterDeployment("other-image-set"),
+				clusterPool("other-image-set"),
+			},
+			operation:       admissionv1beta1.Delete,
+			expectedAllowed: true,
+		},
+		{
+			name:            "Test delete of ClusterImageSet in use by ClusterDeployment",
+			existing:        []runtime.Object{clusterDeployment(testClusterImageSetName)},
+			operation:       admissionv1beta1.Delete,
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test delete of ClusterImageSet in use by ClusterPool",
+			existing:        []runtime.Object{clusterPool(testClusterImageSetName)},
+			operation:       admissionv1beta1.Delete,
+			expectedAllowed: false,
+		},
 		{
 			name: "Test doesn't validate with right version and resource, but wrong group",
 			gvr: &metav1.GroupVersionResource{
@@ -133,6 +193,7 @@ func TestClusterImageSetValidate(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			// Arrange
 			data := NewClusterImageSetValidatingAdmissionHook(createDecoder(t))
+			data.client = fake.NewFakeClientWithScheme(scheme, tc.existing...)
 			newObject := &hivev1.ClusterImageSet{
 				Spec: tc.newSpec,
 			}
@@ -159,6 +220,7 @@ func TestClusterImageSetValidate(t *testing.T) {
 			request := &admissionv1beta1.AdmissionRequest{
 				Operation: tc.operation,
 				Resource:  *tc.gvr,
+				Name:      testClusterImageSetName,
 				Object: runtime.RawExtension{
 					Raw: tc.newObjectRaw,
 				},
@@ -3,6 +3,7 @@ package v1
 import (
 	"fmt"
 	"net/http"
+	"reflect"
 
 	log "github.com/sirupsen/logrus"
 
@@ -10,6 +11,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/client-go/rest"
@@ -177,7 +179,13 @@ func (a *ClusterPoolValidatingAdmissionHook) validateCreate(admissionSpec *admis
 	allErrs := field.ErrorList{}
 	specPath := field.NewPath("spec")
 
-	allErrs = append(allErrs, validateClusterPlatform(specPath, newObject.Spec.Platform)...)
+	allErrs = append(allErrs, validateClusterPoolPlatforms(specPath, &newObject.Spec)...)
+	allErrs = append(allErrs, validateClusterPoolBaseDomain(specPath, &newObject.Spec)...)
+	allErrs = append(allErrs, validateReadinessGates(specPath.Child("readinessGates"), newObject.Spec.ReadinessGates)...)
+	if newObject.Spec.ResumeReadiness != nil {
+		allErrs = append(allErrs, validateResumeReadiness(specPath.Child("resumeReadiness"), newObject.Spec.ResumeReadiness)...)
+	}
+	allErrs = append(allErrs, validateInventory(specPath.Child("inventory"), newObject.Spec.Inventory)...)
 
 	if len(allErrs) > 0 {
 		status := errors.NewInvalid(schemaGVK(admissionSpec.Kind).GroupKind(), admissionSpec.Name, allErrs).Status()
@@ -237,7 +245,13 @@ func (a *ClusterPoolValidatingAdmissionHook) validateUpdate(admissionSpec *admis
 	allErrs := field.ErrorList{}
 	specPath := field.NewPath("spec")
 
-	allErrs = append(allErrs, validateClusterPlatform(specPath, newObject.Spec.Platform)...)
+	allErrs = append(allErrs, validateClusterPoolPlatforms(specPath, &newObject.Spec)...)
+	allErrs = append(allErrs, validateClusterPoolBaseDomain(specPath, &newObject.Spec)...)
+	allErrs = append(allErrs, validateReadinessGates(specPath.Child("readinessGates"), newObject.Spec.ReadinessGates)...)
+	if newObject.Spec.ResumeReadiness != nil {
+		allErrs = append(allErrs, validateResumeReadiness(specPath.Child("resumeReadiness"), newObject.Spec.ResumeReadiness)...)
+	}
+	allErrs = append(allErrs, validateInventory(specPath.Child("inventory"), newObject.Spec.Inventory)...)
 
 	if len(allErrs) > 0 {
 		contextLogger.WithError(allErrs.ToAggregate()).Info("failed validation")
@@ -254,3 +268,56 @@ func (a *ClusterPoolValidatingAdmissionHook) validateUpdate(admissionSpec *admis
 		Allowed: true,
 	}
 }
+
+func validateClusterPoolBaseDomain(path *field.Path, spec *hivev1.ClusterPoolSpec) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if spec.BaseDomainPoolRef != nil {
+		allErrs = append(allErrs, validateBaseDomainPoolRef(path.Child("baseDomainPoolRef"), spec.BaseDomainPoolRef)...)
+	} else if spec.BaseDomain == "" {
+		allErrs = append(allErrs, field.Required(path.Child("baseDomain"), "must specify either baseDomain or baseDomainPoolRef"))
+	}
+	return allErrs
+}
+
+func validateClusterPoolPlatforms(path *field.Path, spec *hivev1.ClusterPoolSpec) field.ErrorList {
+	if len(spec.Platforms) == 0 {
+		return validateClusterPlatform(path, spec.Platform)
+	}
+	allErrs := field.ErrorList{}
+	if !reflect.DeepEqual(spec.Platform, hivev1.Platform{}) {
+		allErrs = append(allErrs, field.Forbidden(path.Child("platform"), "must not specify platform when platforms are specified"))
+	}
+	seen := sets.NewString()
+	for i, entry := range spec.Platforms {
+		entryPath := path.Child("platforms").Index(i)
+		switch {
+		case entry.Name == "":
+			allErrs = append(allErrs, field.Required(entryPath.Child("name"), "must specify the name of the platform"))
+		case seen.Has(entry.Name):
+			allErrs = append(allErrs, field.Duplicate(entryPath.Child("name"), entry.Name))
+		}
+		seen.Insert(entry.Name)
+		if entry.Weight != nil && *entry.Weight < 0 {
+			allErrs = append(allErrs, field.Invalid(entryPath.Child("weight"), *entry.Weight, "must not be negative"))
+		}
+		allErrs = append(allErrs, validateClusterPlatform(entryPath, entry.Platform)...)
+	}
+	return allErrs
+}
+
+func validateInventory(path *field.Path, inventory []hivev1.InventoryEntry) field.ErrorList {
+	allErrs := field.ErrorList{}
+	seen := sets.NewString()
+	for i, entry := range inventory {
+		namePath := path.Index(i).Child("name")
+		if entry.Name == "" {
+			allErrs = append(allErrs, field.Required(namePath, "must specify the name of the ClusterDeploymentCustomization"))
+			continue
+		}
+		if seen.Has(entry.Name) {
+			allErrs = append(allErrs, field.Duplicate(namePath, entry.Name))
+		}
+		seen.Insert(entry.Name)
+	}
+	return allErrs
+}
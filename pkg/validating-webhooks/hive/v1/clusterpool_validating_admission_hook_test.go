@@ -10,6 +10,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/pointer"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
@@ -102,6 +103,116 @@ func TestClusterPoolValidate(t *testing.T) {
 			operation:       admissionv1beta1.Update,
 			expectedAllowed: true,
 		},
+		{
+			name: "Test valid create with base domain pool",
+			newObject: func() *hivev1.ClusterPool {
+				pool := validAWSClusterPool()
+				pool.Spec.BaseDomain = ""
+				pool.Spec.BaseDomainPoolRef = &hivev1.BaseDomainPoolReference{Name: "test-pool"}
+				return pool
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "Test create without base domain",
+			newObject: func() *hivev1.ClusterPool {
+				pool := validAWSClusterPool()
+				pool.Spec.BaseDomain = ""
+				return pool
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test create with unnamed base domain pool",
+			newObject: func() *hivev1.ClusterPool {
+				pool := validAWSClusterPool()
+				pool.Spec.BaseDomain = ""
+				pool.Spec.BaseDomainPoolRef = &hivev1.BaseDomainPoolReference{}
+				return pool
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test valid create with readiness gates",
+			newObject: func() *hivev1.ClusterPool {
+				pool := validAWSClusterPool()
+				pool.Spec.ReadinessGates = []hivev1.ClusterDeploymentReadinessGate{{ConditionType: "example.com/ComplianceScanPassed"}}
+				return pool
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "Test create with readiness gate on hive condition",
+			newObject: func() *hivev1.ClusterPool {
+				pool := validAWSClusterPool()
+				pool.Spec.ReadinessGates = []hivev1.ClusterDeploymentReadinessGate{{ConditionType: hivev1.ProvisionFailedCondition}}
+				return pool
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test valid create with platforms",
+			newObject: func() *hivev1.ClusterPool {
+				pool := clusterPoolTemplate()
+				pool.Spec.Platforms = []hivev1.ClusterPoolPlatform{
+					{Name: "aws", Weight: pointer.Int32Ptr(2), Platform: validAWSClusterPool().Spec.Platform},
+					{Name: "azure", Platform: validAzureClusterPool().Spec.Platform},
+				}
+				return pool
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "Test create with platform and platforms",
+			newObject: func() *hivev1.ClusterPool {
+				pool := validAWSClusterPool()
+				pool.Spec.Platforms = []hivev1.ClusterPoolPlatform{
+					{Name: "azure", Platform: validAzureClusterPool().Spec.Platform},
+				}
+				return pool
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test create with duplicate platforms",
+			newObject: func() *hivev1.ClusterPool {
+				pool := clusterPoolTemplate()
+				pool.Spec.Platforms = []hivev1.ClusterPoolPlatform{
+					{Name: "aws", Platform: validAWSClusterPool().Spec.Platform},
+					{Name: "aws", Platform: validAWSClusterPool().Spec.Platform},
+				}
+				return pool
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test valid create with inventory",
+			newObject: func() *hivev1.ClusterPool {
+				pool := validAWSClusterPool()
+				pool.Spec.Inventory = []hivev1.InventoryEntry{{Name: "cdc1"}, {Name: "cdc2"}}
+				return pool
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "Test create with duplicate inventory entries",
+			newObject: func() *hivev1.ClusterPool {
+				pool := validAWSClusterPool()
+				pool.Spec.Inventory = []hivev1.InventoryEntry{{Name: "cdc1"}, {Name: "cdc1"}}
+				return pool
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
 		{
 			name:            "Test unable to marshal new object during create",
 			newObjectRaw:    []byte{0},
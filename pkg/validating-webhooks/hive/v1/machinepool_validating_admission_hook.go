@@ -1,8 +1,11 @@
 package v1
 
 import (
+	"context"
 	"fmt"
+	"math"
 	"net/http"
+	"strconv"
 
 	log "github.com/sirupsen/logrus"
 
@@ -13,17 +16,24 @@ import (
 	metavalidation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
 	hivev1azure "github.com/openshift/hive/apis/hive/v1/azure"
 	hivev1gcp "github.com/openshift/hive/apis/hive/v1/gcp"
+	hivev1oci "github.com/openshift/hive/apis/hive/v1/oci"
 	hivev1openstack "github.com/openshift/hive/apis/hive/v1/openstack"
 	hivev1ovirt "github.com/openshift/hive/apis/hive/v1/ovirt"
+	hivev1powervs "github.com/openshift/hive/apis/hive/v1/powervs"
 	hivev1vsphere "github.com/openshift/hive/apis/hive/v1/vsphere"
+	"github.com/openshift/hive/pkg/constants"
+	webhookutil "github.com/openshift/hive/pkg/util/webhook"
 )
 
 const (
@@ -39,6 +49,7 @@ const (
 // MachinePoolValidatingAdmissionHook is a struct that is used to reference what code should be run by the generic-admission-server.
 type MachinePoolValidatingAdmissionHook struct {
 	decoder *admission.Decoder
+	client  client.Client
 }
 
 // NewMachinePoolValidatingAdmissionHook constructs a new MachinePoolValidatingAdmissionHook
@@ -73,7 +84,16 @@ func (a *MachinePoolValidatingAdmissionHook) Initialize(kubeClientConfig *rest.C
 		"resource": "machinepoolvalidator",
 	}).Info("Initializing validation REST resource")
 
-	return nil // No initialization needed right now.
+	scheme := runtime.NewScheme()
+	if err := hivev1.AddToScheme(scheme); err != nil {
+		return err
+	}
+	c, err := webhookutil.NewClient(kubeClientConfig, scheme)
+	if err != nil {
+		return err
+	}
+	a.client = c
+	return nil
 }
 
 // Validate is called by generic-admission-server when the registered REST resource above is called with an admission request.
@@ -149,7 +169,12 @@ func (a *MachinePoolValidatingAdmissionHook) validateCreateRequest(request *admi
 		WithField("object.Name", newObject.Name).
 		WithField("object.Namespace", newObject.Namespace)
 
-	if allErrs := validateMachinePoolCreate(newObject); len(allErrs) > 0 {
+	providerPlugin, resp := a.usesProviderPlugin(newObject, logger)
+	if resp != nil {
+		return resp
+	}
+
+	if allErrs := validateMachinePoolCreate(newObject, providerPlugin); len(allErrs) > 0 {
 		logger.WithError(allErrs.ToAggregate()).Info("failed validation")
 		status := errors.NewInvalid(schemaGVK(request.Kind).GroupKind(), request.Name, allErrs).Status()
 		return &admissionv1beta1.AdmissionResponse{
@@ -183,7 +208,12 @@ func (a *MachinePoolValidatingAdmissionHook) validateUpdateRequest(request *admi
 		return resp
 	}
 
-	if allErrs := validateMachinePoolUpdate(oldObject, newObject); len(allErrs) > 0 {
+	providerPlugin, resp := a.usesProviderPlugin(newObject, logger)
+	if resp != nil {
+		return resp
+	}
+
+	if allErrs := validateMachinePoolUpdate(oldObject, newObject, providerPlugin); len(allErrs) > 0 {
 		logger.WithError(allErrs.ToAggregate()).Info("failed validation")
 		status := errors.NewInvalid(schemaGVK(request.Kind).GroupKind(), request.Name, allErrs).Status()
 		return &admissionv1beta1.AdmissionResponse{
@@ -214,13 +244,34 @@ func (a *MachinePoolValidatingAdmissionHook) decode(raw runtime.RawExtension, lo
 	return obj, nil
 }
 
-func validateMachinePoolCreate(pool *hivev1.MachinePool) field.ErrorList {
-	return validateMachinePoolInvariants(pool)
+// usesProviderPlugin returns whether the ClusterDeployment of the pool is handled by a provider plugin. A pool whose
+// ClusterDeployment does not exist yet is not.
+func (a *MachinePoolValidatingAdmissionHook) usesProviderPlugin(pool *hivev1.MachinePool, logger log.FieldLogger) (bool, *admissionv1beta1.AdmissionResponse) {
+	cd := &hivev1.ClusterDeployment{}
+	switch err := a.client.Get(context.TODO(), types.NamespacedName{Namespace: pool.Namespace, Name: pool.Spec.ClusterDeploymentRef.Name}, cd); {
+	case errors.IsNotFound(err):
+		return false, nil
+	case err != nil:
+		logger.WithError(err).Error("failed to get the clusterdeployment of the pool")
+		return false, &admissionv1beta1.AdmissionResponse{
+			Allowed: false,
+			Result: &metav1.Status{
+				Status: metav1.StatusFailure, Code: http.StatusInternalServerError, Reason: metav1.StatusReasonInternalError,
+				Message: err.Error(),
+			},
+		}
+	}
+	_, ok := cd.Annotations[constants.ProviderPluginAnnotation]
+	return ok, nil
 }
 
-func validateMachinePoolUpdate(old, new *hivev1.MachinePool) field.ErrorList {
+func validateMachinePoolCreate(pool *hivev1.MachinePool, providerPlugin bool) field.ErrorList {
+	return validateMachinePoolInvariants(pool, providerPlugin)
+}
+
+func validateMachinePoolUpdate(old, new *hivev1.MachinePool, providerPlugin bool) field.ErrorList {
 	allErrs := field.ErrorList{}
-	allErrs = append(allErrs, validateMachinePoolInvariants(new)...)
+	allErrs = append(allErrs, validateMachinePoolInvariants(new, providerPlugin)...)
 	specPath := field.NewPath("spec")
 	allErrs = append(allErrs, validation.ValidateImmutableField(new.Spec.ClusterDeploymentRef, old.Spec.ClusterDeploymentRef, specPath.Child("clusterDeploymentRef"))...)
 	allErrs = append(allErrs, validation.ValidateImmutableField(new.Spec.Name, old.Spec.Name, specPath.Child("name"))...)
@@ -241,14 +292,16 @@ func validateMachinePoolName(pool *hivev1.MachinePool) field.ErrorList {
 	return allErrs
 }
 
-func validateMachinePoolInvariants(pool *hivev1.MachinePool) field.ErrorList {
+func validateMachinePoolInvariants(pool *hivev1.MachinePool, providerPlugin bool) field.ErrorList {
 	allErrs := field.ErrorList{}
 	allErrs = append(allErrs, validateMachinePoolName(pool)...)
-	allErrs = append(allErrs, validateMachinePoolSpecInvariants(&pool.Spec, field.NewPath("spec"))...)
+	// The machines of the pools of clusters handled by a provider plugin are described to the plugin by the pool
+	// itself, so such pools need not specify a platform.
+	allErrs = append(allErrs, validateMachinePoolSpecInvariants(&pool.Spec, field.NewPath("spec"), !providerPlugin)...)
 	return allErrs
 }
 
-func validateMachinePoolSpecInvariants(spec *hivev1.MachinePoolSpec, fldPath *field.Path) field.ErrorList {
+func validateMachinePoolSpecInvariants(spec *hivev1.MachinePoolSpec, fldPath *field.Path, platformRequired bool) field.ErrorList {
 	allErrs := field.ErrorList{}
 	if spec.ClusterDeploymentRef.Name == "" {
 		allErrs = append(allErrs, field.Required(fldPath.Child("clusterDeploymentRef", "name"), "must have reference to clusterdeployment"))
@@ -294,10 +347,21 @@ func validateMachinePoolSpecInvariants(spec *hivev1.MachinePoolSpec, fldPath *fi
 		platforms = append(platforms, "ovirt")
 		allErrs = append(allErrs, validateOvirtMachinePoolPlatformInvariants(p, platformPath.Child("ovirt"))...)
 	}
+	if p := spec.Platform.OCI; p != nil {
+		platforms = append(platforms, "oci")
+		allErrs = append(allErrs, validateOCIMachinePoolPlatformInvariants(p, platformPath.Child("oci"))...)
+		numberOfMachineSets = len(p.AvailabilityDomains)
+	}
+	if p := spec.Platform.PowerVS; p != nil {
+		platforms = append(platforms, "powervs")
+		allErrs = append(allErrs, validatePowerVSMachinePoolPlatformInvariants(p, platformPath.Child("powervs"))...)
+	}
 
 	switch len(platforms) {
 	case 0:
-		allErrs = append(allErrs, field.Required(platformPath, "must specify a platform"))
+		if platformRequired {
+			allErrs = append(allErrs, field.Required(platformPath, "must specify a platform"))
+		}
 	case 1:
 		// valid
 	default:
@@ -329,9 +393,30 @@ func validateAWSMachinePoolPlatformInvariants(platform *hivev1aws.MachinePoolPla
 			allErrs = append(allErrs, field.Invalid(fldPath.Child("zones").Index(i), zone, "zone cannot be an empty string"))
 		}
 	}
+	allErrs = append(allErrs, validateZoneSubnets(platform.Zones, platform.ZoneSubnets, fldPath)...)
+	if len(platform.ZoneSubnets) > 0 {
+		if len(platform.Subnets) > 0 {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("zoneSubnets"), "zone subnets cannot be used with subnets"))
+		}
+		for _, zone := range platform.Zones {
+			if _, ok := platform.ZoneSubnets[zone]; !ok {
+				allErrs = append(allErrs, field.Required(fldPath.Child("zoneSubnets").Key(zone), "each zone must be mapped to a subnet"))
+			}
+		}
+	}
 	if platform.InstanceType == "" {
 		allErrs = append(allErrs, field.Required(fldPath.Child("instanceType"), "instance type is required"))
 	}
+	instanceTypes := map[string]bool{platform.InstanceType: true}
+	for i, instanceType := range platform.InstanceTypes {
+		switch {
+		case instanceType == "":
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("instanceTypes").Index(i), instanceType, "instance type cannot be an empty string"))
+		case instanceTypes[instanceType]:
+			allErrs = append(allErrs, field.Duplicate(fldPath.Child("instanceTypes").Index(i), instanceType))
+		}
+		instanceTypes[instanceType] = true
+	}
 	rootVolume := &platform.EC2RootVolume
 	rootVolumePath := fldPath.Child("ec2RootVolume")
 	if rootVolume.IOPS < 0 {
@@ -353,6 +438,7 @@ func validateGCPMachinePoolPlatformInvariants(platform *hivev1gcp.MachinePool, f
 			allErrs = append(allErrs, field.Invalid(fldPath.Child("zones").Index(i), zone, "zone cannot be an empty string"))
 		}
 	}
+	allErrs = append(allErrs, validateZoneSubnets(platform.Zones, platform.ZoneSubnets, fldPath)...)
 	if platform.InstanceType == "" {
 		allErrs = append(allErrs, field.Required(fldPath.Child("instanceType"), "instance type is required"))
 	}
@@ -366,6 +452,7 @@ func validateAzureMachinePoolPlatformInvariants(platform *hivev1azure.MachinePoo
 			allErrs = append(allErrs, field.Invalid(fldPath.Child("zones").Index(i), zone, "zone cannot be an empty string"))
 		}
 	}
+	allErrs = append(allErrs, validateZoneSubnets(platform.Zones, platform.ZoneSubnets, fldPath)...)
 	if platform.InstanceType == "" {
 		allErrs = append(allErrs, field.Required(fldPath.Child("instanceType"), "instance type is required"))
 	}
@@ -377,6 +464,24 @@ func validateAzureMachinePoolPlatformInvariants(platform *hivev1azure.MachinePoo
 	return allErrs
 }
 
+// validateZoneSubnets validates the mapping of zones to subnets of a machine pool platform, whose zones must be in the
+// zones of the platform when they are set.
+func validateZoneSubnets(zones []string, zoneSubnets map[string]string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	zonesPath := fldPath.Child("zoneSubnets")
+	for _, zone := range sets.StringKeySet(zoneSubnets).List() {
+		switch {
+		case zone == "":
+			allErrs = append(allErrs, field.Invalid(zonesPath, zone, "zone cannot be an empty string"))
+		case len(zones) > 0 && !sets.NewString(zones...).Has(zone):
+			allErrs = append(allErrs, field.Invalid(zonesPath.Key(zone), zoneSubnets[zone], "zone is not one of the zones of the machine pool"))
+		case zoneSubnets[zone] == "":
+			allErrs = append(allErrs, field.Invalid(zonesPath.Key(zone), zoneSubnets[zone], "subnet cannot be an empty string"))
+		}
+	}
+	return allErrs
+}
+
 func validateOpenStackMachinePoolPlatformInvariants(platform *hivev1openstack.MachinePool, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 	if platform.Flavor == "" {
@@ -410,3 +515,43 @@ func validateOvirtMachinePoolPlatformInvariants(platform *hivev1ovirt.MachinePoo
 	allErrs := field.ErrorList{}
 	return allErrs
 }
+
+func validateOCIMachinePoolPlatformInvariants(platform *hivev1oci.MachinePool, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for i, ad := range platform.AvailabilityDomains {
+		if ad == "" {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("availabilityDomains").Index(i), ad, "availability domain cannot be an empty string"))
+		}
+	}
+	if platform.Shape == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("shape"), "shape is required"))
+	}
+	if platform.OCPUs != nil && *platform.OCPUs <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("ocpus"), *platform.OCPUs, "OCPUs must be positive"))
+	}
+	if platform.MemoryGBs != nil && *platform.MemoryGBs <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("memoryGBs"), *platform.MemoryGBs, "memory must be positive"))
+	}
+	return allErrs
+}
+
+func validatePowerVSMachinePoolPlatformInvariants(platform *hivev1powervs.MachinePool, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if platform.Processors != "" {
+		processors, err := strconv.ParseFloat(platform.Processors, 64)
+		switch {
+		case err != nil:
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("processors"), platform.Processors, "processors must be a number"))
+		case processors <= 0:
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("processors"), platform.Processors, "processors must be positive"))
+		case platform.ProcType == "dedicated" && processors != math.Trunc(processors):
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("processors"), platform.Processors, "dedicated processors must be a whole number"))
+		case processors*4 != math.Trunc(processors*4):
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("processors"), platform.Processors, "processors must be in increments of 0.25"))
+		}
+	}
+	if platform.MemoryGiB < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("memoryGiB"), platform.MemoryGiB, "memory must be positive"))
+	}
+	return allErrs
+}
@@ -12,11 +12,15 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
 	hivev1azure "github.com/openshift/hive/apis/hive/v1/azure"
 	hivev1gcp "github.com/openshift/hive/apis/hive/v1/gcp"
+	hivev1oci "github.com/openshift/hive/apis/hive/v1/oci"
+	hivev1powervs "github.com/openshift/hive/apis/hive/v1/powervs"
+	"github.com/openshift/hive/pkg/constants"
 )
 
 func Test_MachinePoolAdmission_Validate_Kind(t *testing.T) {
@@ -57,8 +61,7 @@ func Test_MachinePoolAdmission_Validate_Kind(t *testing.T) {
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
-			cut := NewMachinePoolValidatingAdmissionHook(createDecoder(t))
-			cut.Initialize(nil, nil)
+			cut := newTestMachinePoolHook(t)
 			request := &admissionv1beta1.AdmissionRequest{
 				Resource: metav1.GroupVersionResource{
 					Group:    tc.group,
@@ -95,8 +98,7 @@ func Test_MachinePoolAdmission_Validate_Operation(t *testing.T) {
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
-			cut := NewMachinePoolValidatingAdmissionHook(createDecoder(t))
-			cut.Initialize(nil, nil)
+			cut := newTestMachinePoolHook(t)
 			request := &admissionv1beta1.AdmissionRequest{
 				Resource: metav1.GroupVersionResource{
 					Group:    machinePoolGroup,
@@ -113,9 +115,10 @@ func Test_MachinePoolAdmission_Validate_Operation(t *testing.T) {
 
 func Test_MachinePoolAdmission_Validate_Create(t *testing.T) {
 	cases := []struct {
-		name          string
-		provision     *hivev1.MachinePool
-		expectAllowed bool
+		name              string
+		provision         *hivev1.MachinePool
+		clusterDeployment *hivev1.ClusterDeployment
+		expectAllowed     bool
 	}{
 		{
 			name:          "good",
@@ -305,6 +308,30 @@ func Test_MachinePoolAdmission_Validate_Create(t *testing.T) {
 				return pool
 			}(),
 		},
+		{
+			name: "missing platform with provider plugin",
+			provision: func() *hivev1.MachinePool {
+				pool := testMachinePool()
+				pool.Spec.Platform = hivev1.MachinePoolPlatform{}
+				return pool
+			}(),
+			clusterDeployment: func() *hivev1.ClusterDeployment {
+				cd := testMachinePoolClusterDeployment()
+				cd.Annotations = map[string]string{constants.ProviderPluginAnnotation: "test-plugin"}
+				return cd
+			}(),
+			expectAllowed: true,
+		},
+		{
+			name: "missing platform with provider plugin annotation on the pool",
+			provision: func() *hivev1.MachinePool {
+				pool := testMachinePool()
+				pool.Annotations = map[string]string{constants.ProviderPluginAnnotation: "test-plugin"}
+				pool.Spec.Platform = hivev1.MachinePoolPlatform{}
+				return pool
+			}(),
+			clusterDeployment: testMachinePoolClusterDeployment(),
+		},
 		{
 			name: "multiple platforms",
 			provision: func() *hivev1.MachinePool {
@@ -338,6 +365,76 @@ func Test_MachinePoolAdmission_Validate_Create(t *testing.T) {
 				return pool
 			}(),
 		},
+		{
+			name: "AWS fallback instance types",
+			provision: func() *hivev1.MachinePool {
+				pool := testAWSMachinePool()
+				pool.Spec.Platform.AWS.InstanceTypes = []string{"fallback-instance-type-1", "fallback-instance-type-2"}
+				return pool
+			}(),
+			expectAllowed: true,
+		},
+		{
+			name: "empty AWS fallback instance type",
+			provision: func() *hivev1.MachinePool {
+				pool := testAWSMachinePool()
+				pool.Spec.Platform.AWS.InstanceTypes = []string{""}
+				return pool
+			}(),
+		},
+		{
+			name: "duplicate AWS fallback instance type",
+			provision: func() *hivev1.MachinePool {
+				pool := testAWSMachinePool()
+				pool.Spec.Platform.AWS.InstanceTypes = []string{"fallback-instance-type", pool.Spec.Platform.AWS.InstanceType}
+				return pool
+			}(),
+		},
+		{
+			name: "AWS zone subnets",
+			provision: func() *hivev1.MachinePool {
+				pool := testAWSMachinePool()
+				pool.Spec.Platform.AWS.Zones = []string{"zone1", "zone2"}
+				pool.Spec.Platform.AWS.ZoneSubnets = map[string]string{"zone1": "subnet-1", "zone2": "subnet-2"}
+				return pool
+			}(),
+			expectAllowed: true,
+		},
+		{
+			name: "AWS zone subnets with subnets",
+			provision: func() *hivev1.MachinePool {
+				pool := testAWSMachinePool()
+				pool.Spec.Platform.AWS.Subnets = []string{"subnet-1"}
+				pool.Spec.Platform.AWS.ZoneSubnets = map[string]string{"zone1": "subnet-1"}
+				return pool
+			}(),
+		},
+		{
+			name: "AWS zone without zone subnet",
+			provision: func() *hivev1.MachinePool {
+				pool := testAWSMachinePool()
+				pool.Spec.Platform.AWS.Zones = []string{"zone1", "zone2"}
+				pool.Spec.Platform.AWS.ZoneSubnets = map[string]string{"zone1": "subnet-1"}
+				return pool
+			}(),
+		},
+		{
+			name: "AWS zone subnet for unused zone",
+			provision: func() *hivev1.MachinePool {
+				pool := testAWSMachinePool()
+				pool.Spec.Platform.AWS.Zones = []string{"zone1"}
+				pool.Spec.Platform.AWS.ZoneSubnets = map[string]string{"zone1": "subnet-1", "zone2": "subnet-2"}
+				return pool
+			}(),
+		},
+		{
+			name: "empty AWS zone subnet",
+			provision: func() *hivev1.MachinePool {
+				pool := testAWSMachinePool()
+				pool.Spec.Platform.AWS.ZoneSubnets = map[string]string{"zone1": ""}
+				return pool
+			}(),
+		},
 		{
 			name: "invalid AWS volume IOPS",
 			provision: func() *hivev1.MachinePool {
@@ -388,6 +485,25 @@ func Test_MachinePoolAdmission_Validate_Create(t *testing.T) {
 				return pool
 			}(),
 		},
+		{
+			name: "GCP zone subnets",
+			provision: func() *hivev1.MachinePool {
+				pool := testGCPMachinePool()
+				pool.Spec.Platform.GCP.Zones = []string{"zone1", "zone2"}
+				pool.Spec.Platform.GCP.ZoneSubnets = map[string]string{"zone1": "subnet-1"}
+				return pool
+			}(),
+			expectAllowed: true,
+		},
+		{
+			name: "GCP zone subnet for unused zone",
+			provision: func() *hivev1.MachinePool {
+				pool := testGCPMachinePool()
+				pool.Spec.Platform.GCP.Zones = []string{"zone1"}
+				pool.Spec.Platform.GCP.ZoneSubnets = map[string]string{"zone2": "subnet-2"}
+				return pool
+			}(),
+		},
 		{
 			name: "missing GCP instance type",
 			provision: func() *hivev1.MachinePool {
@@ -396,6 +512,56 @@ func Test_MachinePoolAdmission_Validate_Create(t *testing.T) {
 				return pool
 			}(),
 		},
+		{
+			name: "explicit OCI availability domains",
+			provision: func() *hivev1.MachinePool {
+				pool := testOCIMachinePool()
+				pool.Spec.Platform.OCI.AvailabilityDomains = []string{"AD-1", "AD-2"}
+				return pool
+			}(),
+			expectAllowed: true,
+		},
+		{
+			name: "empty OCI availability domain",
+			provision: func() *hivev1.MachinePool {
+				pool := testOCIMachinePool()
+				pool.Spec.Platform.OCI.AvailabilityDomains = []string{""}
+				return pool
+			}(),
+		},
+		{
+			name: "missing OCI shape",
+			provision: func() *hivev1.MachinePool {
+				pool := testOCIMachinePool()
+				pool.Spec.Platform.OCI.Shape = ""
+				return pool
+			}(),
+		},
+		{
+			name: "non-positive OCI OCPUs",
+			provision: func() *hivev1.MachinePool {
+				pool := testOCIMachinePool()
+				pool.Spec.Platform.OCI.OCPUs = pointer.Int32Ptr(0)
+				return pool
+			}(),
+		},
+		{
+			name:          "valid PowerVS processors",
+			provision:     testPowerVSMachinePool("0.75", "shared"),
+			expectAllowed: true,
+		},
+		{
+			name:      "non-numeric PowerVS processors",
+			provision: testPowerVSMachinePool("lots", "shared"),
+		},
+		{
+			name:      "PowerVS processors not in quarter increments",
+			provision: testPowerVSMachinePool("0.3", "shared"),
+		},
+		{
+			name:      "fractional dedicated PowerVS processors",
+			provision: testPowerVSMachinePool("1.5", "dedicated"),
+		},
 		{
 			name: "explicit Azure zones",
 			provision: func() *hivev1.MachinePool {
@@ -413,6 +579,25 @@ func Test_MachinePoolAdmission_Validate_Create(t *testing.T) {
 				return pool
 			}(),
 		},
+		{
+			name: "Azure zone subnets",
+			provision: func() *hivev1.MachinePool {
+				pool := testAzureMachinePool()
+				pool.Spec.Platform.Azure.Zones = []string{"zone1", "zone2"}
+				pool.Spec.Platform.Azure.ZoneSubnets = map[string]string{"zone1": "subnet-1"}
+				return pool
+			}(),
+			expectAllowed: true,
+		},
+		{
+			name: "Azure zone subnet for unused zone",
+			provision: func() *hivev1.MachinePool {
+				pool := testAzureMachinePool()
+				pool.Spec.Platform.Azure.Zones = []string{"zone1"}
+				pool.Spec.Platform.Azure.ZoneSubnets = map[string]string{"zone2": "subnet-2"}
+				return pool
+			}(),
+		},
 		{
 			name: "missing Azure instance type",
 			provision: func() *hivev1.MachinePool {
@@ -449,8 +634,11 @@ func Test_MachinePoolAdmission_Validate_Create(t *testing.T) {
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
-			cut := NewMachinePoolValidatingAdmissionHook(createDecoder(t))
-			cut.Initialize(nil, nil)
+			var existing []runtime.Object
+			if tc.clusterDeployment != nil {
+				existing = append(existing, tc.clusterDeployment)
+			}
+			cut := newTestMachinePoolHook(t, existing...)
 			rawProvision, err := json.Marshal(tc.provision)
 			if !assert.NoError(t, err, "unexpected error marshalling provision") {
 				return
@@ -552,8 +740,7 @@ func Test_MachinePoolAdmission_Validate_Update(t *testing.T) {
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
-			cut := NewMachinePoolValidatingAdmissionHook(createDecoder(t))
-			cut.Initialize(nil, nil)
+			cut := newTestMachinePoolHook(t)
 			oldAsJSON, err := json.Marshal(tc.old)
 			if !assert.NoError(t, err, "unexpected error marshalling old provision") {
 				return
@@ -578,6 +765,22 @@ func Test_MachinePoolAdmission_Validate_Update(t *testing.T) {
 	}
 }
 
+func newTestMachinePoolHook(t *testing.T, existing ...runtime.Object) *MachinePoolValidatingAdmissionHook {
+	scheme := runtime.NewScheme()
+	hivev1.AddToScheme(scheme)
+	cut := NewMachinePoolValidatingAdmissionHook(createDecoder(t))
+	cut.client = fake.NewFakeClientWithScheme(scheme, existing...)
+	return cut
+}
+
+func testMachinePoolClusterDeployment() *hivev1.ClusterDeployment {
+	return &hivev1.ClusterDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-deployment",
+		},
+	}
+}
+
 func testMachinePool() *hivev1.MachinePool {
 	cdName := "test-deployment"
 	return &hivev1.MachinePool{
@@ -620,6 +823,25 @@ func testAzureMachinePool() *hivev1.MachinePool {
 	return pool
 }
 
+func testOCIMachinePool() *hivev1.MachinePool {
+	pool := testMachinePool()
+	pool.Spec.Platform = hivev1.MachinePoolPlatform{
+		OCI: validOCIMachinePoolPlatform(),
+	}
+	return pool
+}
+
+func testPowerVSMachinePool(processors, procType string) *hivev1.MachinePool {
+	pool := testMachinePool()
+	pool.Spec.Platform = hivev1.MachinePoolPlatform{
+		PowerVS: &hivev1powervs.MachinePool{
+			Processors: processors,
+			ProcType:   procType,
+		},
+	}
+	return pool
+}
+
 func validAWSMachinePoolPlatform() *hivev1aws.MachinePoolPlatform {
 	return &hivev1aws.MachinePoolPlatform{
 		InstanceType: "test-instance-type",
@@ -645,3 +867,9 @@ func validAzureMachinePoolPlatform() *hivev1azure.MachinePool {
 		},
 	}
 }
+
+func validOCIMachinePoolPlatform() *hivev1oci.MachinePool {
+	return &hivev1oci.MachinePool{
+		Shape: "test-shape",
+	}
+}
@@ -355,6 +355,32 @@ func TestSelectorSyncSetValidate(t *testing.T) {
 			selectorSyncSet: testSelectorSyncSetWithResources(`{"apiVersion": "authorization.openshift.io/v1", "kind": "SubjectAccessReview"}`),
 			expectedAllowed: false,
 		},
+		{
+			name:            "Test valid resource refs create",
+			operation:       admissionv1beta1.Create,
+			selectorSyncSet: testResourceRefsSelectorSyncSet(),
+			expectedAllowed: true,
+		},
+		{
+			name:      "Test invalid resource ref configmap with no namespace",
+			operation: admissionv1beta1.Create,
+			selectorSyncSet: func() *hivev1.SelectorSyncSet {
+				ss := testResourceRefsSelectorSyncSet()
+				ss.Spec.ResourceRefs[0].ConfigMap.Namespace = ""
+				return ss
+			}(),
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test invalid resource ref pull secret with no namespace",
+			operation: admissionv1beta1.Update,
+			selectorSyncSet: func() *hivev1.SelectorSyncSet {
+				ss := testResourceRefsSelectorSyncSet()
+				ss.Spec.ResourceRefs[1].OCIArtifact.PullSecretRef.Namespace = ""
+				return ss
+			}(),
+			expectedAllowed: false,
+		},
 	}
 
 	for _, tc := range cases {
@@ -435,6 +461,25 @@ func testSecretReferenceSelectorSyncSet() *hivev1.SelectorSyncSet {
 	return ss
 }
 
+func testResourceRefsSelectorSyncSet() *hivev1.SelectorSyncSet {
+	ss := testSelectorSyncSet()
+	ss.Spec.ResourceRefs = []hivev1.SyncSetResourceRef{
+		{
+			ConfigMap: &hivev1.ConfigMapResourceSource{
+				Name:      "manifests",
+				Namespace: "foo",
+			},
+		},
+		{
+			OCIArtifact: &hivev1.OCIArtifactResourceSource{
+				Image:         "quay.io/example/manifests@sha256:aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+				PullSecretRef: &hivev1.SecretReference{Name: "pull-secret", Namespace: "foo"},
+			},
+		},
+	}
+	return ss
+}
+
 func testSelectorSyncSet() *hivev1.SelectorSyncSet {
 	return &hivev1.SelectorSyncSet{
 		ObjectMeta: metav1.ObjectMeta{
@@ -3,6 +3,7 @@ package v1
 import (
 	"encoding/json"
 	"net/http"
+	"regexp"
 
 	log "github.com/sirupsen/logrus"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -17,6 +18,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/ociartifact"
 )
 
 const (
@@ -43,6 +45,8 @@ var validPatchTypes = map[string]bool{
 
 var validPatchTypeSlice = []string{"json", "merge", "strategic"}
 
+var digestRegexp = regexp.MustCompile(`^sha256:[a-f0-9]{64}$`)
+
 var (
 	validResourceApplyModes = map[hivev1.SyncSetResourceApplyMode]bool{
 		hivev1.UpsertResourceApplyMode: true,
@@ -195,7 +199,9 @@ func (a *SyncSetValidatingAdmissionHook) validateCreate(admissionSpec *admission
 	allErrs = append(allErrs, validatePatches(newObject.Spec.Patches, field.NewPath("spec").Child("patches"))...)
 	allErrs = append(allErrs, validateSecrets(newObject.Spec.Secrets, field.NewPath("spec").Child("secretMappings"))...)
 	allErrs = append(allErrs, validateSourceSecretInSyncSetNamespace(newObject.Spec.Secrets, newObject.Namespace, field.NewPath("spec", "secretMappings"))...)
+	allErrs = append(allErrs, validateResourceRefs(newObject.Spec.ResourceRefs, newObject.Namespace, field.NewPath("spec", "resourceRefs"))...)
 	allErrs = append(allErrs, validateResourceApplyMode(newObject.Spec.ResourceApplyMode, field.NewPath("spec", "resourceApplyMode"))...)
+	allErrs = append(allErrs, validateApplyAs(newObject.Spec.ApplyAs, field.NewPath("spec", "applyAs"))...)
 
 	if len(allErrs) > 0 {
 		statusError := errors.NewInvalid(newObject.GroupVersionKind().GroupKind(), newObject.Name, allErrs).Status()
@@ -243,7 +249,9 @@ func (a *SyncSetValidatingAdmissionHook) validateUpdate(admissionSpec *admission
 	allErrs = append(allErrs, validatePatches(newObject.Spec.Patches, field.NewPath("spec", "patches"))...)
 	allErrs = append(allErrs, validateSecrets(newObject.Spec.Secrets, field.NewPath("spec", "secretMappings"))...)
 	allErrs = append(allErrs, validateSourceSecretInSyncSetNamespace(newObject.Spec.Secrets, newObject.Namespace, field.NewPath("spec", "secretMappings"))...)
+	allErrs = append(allErrs, validateResourceRefs(newObject.Spec.ResourceRefs, newObject.Namespace, field.NewPath("spec", "resourceRefs"))...)
 	allErrs = append(allErrs, validateResourceApplyMode(newObject.Spec.ResourceApplyMode, field.NewPath("spec", "resourceApplyMode"))...)
+	allErrs = append(allErrs, validateApplyAs(newObject.Spec.ApplyAs, field.NewPath("spec", "applyAs"))...)
 
 	if len(allErrs) > 0 {
 		statusError := errors.NewInvalid(newObject.GroupVersionKind().GroupKind(), newObject.Name, allErrs).Status()
@@ -327,6 +335,67 @@ func validateSourceSecretInSyncSetNamespace(secrets []hivev1.SecretMapping, sync
 	return allErrs
 }
 
+// validateResourceRefs validates the resource refs of a SyncSet in the namespace, or of a SelectorSyncSet if the
+// namespace is empty.
+func validateResourceRefs(refs []hivev1.SyncSetResourceRef, syncSetNS string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for i, ref := range refs {
+		path := fldPath.Index(i)
+		switch {
+		case ref.ConfigMap != nil && ref.OCIArtifact != nil:
+			allErrs = append(allErrs, field.Invalid(path, "", "only one of configMap or ociArtifact may be set"))
+		case ref.ConfigMap != nil:
+			path := path.Child("configMap")
+			if ref.ConfigMap.Name == "" {
+				allErrs = append(allErrs, field.Required(path.Child("name"), "Name is required"))
+			}
+			if ref.ConfigMap.Digest != "" && !digestRegexp.MatchString(ref.ConfigMap.Digest) {
+				allErrs = append(allErrs, field.Invalid(path.Child("digest"), ref.ConfigMap.Digest, "must be of the form sha256:<hex>"))
+			}
+			allErrs = append(allErrs, validateSourceNamespace(ref.ConfigMap.Namespace, syncSetNS, path.Child("namespace"))...)
+		case ref.OCIArtifact != nil:
+			path := path.Child("ociArtifact")
+			if _, err := ociartifact.ParseReference(ref.OCIArtifact.Image); err != nil {
+				allErrs = append(allErrs, field.Invalid(path.Child("image"), ref.OCIArtifact.Image, "must be pinned by digest as registry/repository@sha256:<hex>"))
+			}
+			if secretRef := ref.OCIArtifact.PullSecretRef; secretRef != nil {
+				allErrs = append(allErrs, validateSecretRef(*secretRef, path.Child("pullSecretRef"))...)
+				allErrs = append(allErrs, validateSourceNamespace(secretRef.Namespace, syncSetNS, path.Child("pullSecretRef", "namespace"))...)
+			}
+		default:
+			allErrs = append(allErrs, field.Required(path, "one of configMap or ociArtifact must be set"))
+		}
+	}
+	return allErrs
+}
+
+// validateSourceNamespace validates the namespace of an object on the management cluster referenced by a SyncSet in
+// the namespace, or by a SelectorSyncSet if the namespace is empty.
+func validateSourceNamespace(namespace, syncSetNS string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	switch {
+	case syncSetNS == "" && namespace == "":
+		allErrs = append(allErrs, field.Required(fldPath, "namespace is required for SelectorSyncSets"))
+	case syncSetNS != "" && namespace != "" && namespace != syncSetNS:
+		allErrs = append(allErrs, field.Invalid(fldPath, namespace, "must be in same namespace as SyncSet"))
+	}
+	return allErrs
+}
+
+func validateApplyAs(ref *hivev1.ServiceAccountReference, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if ref == nil {
+		return allErrs
+	}
+	if len(ref.Name) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("name"), "Name is required"))
+	}
+	if len(ref.Namespace) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("namespace"), "Namespace is required"))
+	}
+	return allErrs
+}
+
 func validateSecretRef(ref hivev1.SecretReference, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 	if len(ref.Name) == 0 {
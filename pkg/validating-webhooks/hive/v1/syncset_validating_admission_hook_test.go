@@ -375,6 +375,108 @@ func TestSyncSetValidate(t *testing.T) {
 			syncSet:         testSyncSetWithResources(`{"apiVersion": "authorization.openshift.io/v1", "kind": "SubjectAccessReview"}`),
 			expectedAllowed: false,
 		},
+		{
+			name:            "Test valid resource refs create",
+			operation:       admissionv1beta1.Create,
+			syncSet:         testResourceRefsSyncSet(),
+			expectedAllowed: true,
+		},
+		{
+			name:            "Test valid resource refs update",
+			operation:       admissionv1beta1.Update,
+			syncSet:         testResourceRefsSyncSet(),
+			expectedAllowed: true,
+		},
+		{
+			name:      "Test invalid resource ref with no source",
+			operation: admissionv1beta1.Create,
+			syncSet: func() *hivev1.SyncSet {
+				ss := testResourceRefsSyncSet()
+				ss.Spec.ResourceRefs = append(ss.Spec.ResourceRefs, hivev1.SyncSetResourceRef{})
+				return ss
+			}(),
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test invalid resource ref with both sources",
+			operation: admissionv1beta1.Create,
+			syncSet: func() *hivev1.SyncSet {
+				ss := testResourceRefsSyncSet()
+				ss.Spec.ResourceRefs[0].OCIArtifact = ss.Spec.ResourceRefs[1].OCIArtifact
+				return ss
+			}(),
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test invalid resource ref configmap not in SyncSet namespace",
+			operation: admissionv1beta1.Create,
+			syncSet: func() *hivev1.SyncSet {
+				ss := testResourceRefsSyncSet()
+				ss.Spec.ResourceRefs[0].ConfigMap.Namespace = "anotherns"
+				return ss
+			}(),
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test invalid resource ref configmap digest",
+			operation: admissionv1beta1.Create,
+			syncSet: func() *hivev1.SyncSet {
+				ss := testResourceRefsSyncSet()
+				ss.Spec.ResourceRefs[0].ConfigMap.Digest = "md5:abc"
+				return ss
+			}(),
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test invalid resource ref artifact not pinned by digest",
+			operation: admissionv1beta1.Create,
+			syncSet: func() *hivev1.SyncSet {
+				ss := testResourceRefsSyncSet()
+				ss.Spec.ResourceRefs[1].OCIArtifact.Image = "quay.io/example/manifests:latest"
+				return ss
+			}(),
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test invalid resource ref pull secret not in SyncSet namespace",
+			operation: admissionv1beta1.Update,
+			syncSet: func() *hivev1.SyncSet {
+				ss := testResourceRefsSyncSet()
+				ss.Spec.ResourceRefs[1].OCIArtifact.PullSecretRef.Namespace = "anotherns"
+				return ss
+			}(),
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test valid applyAs create",
+			operation: admissionv1beta1.Create,
+			syncSet: func() *hivev1.SyncSet {
+				ss := testSyncSet()
+				ss.Spec.ApplyAs = &hivev1.ServiceAccountReference{Namespace: "team-ns", Name: "team-sa"}
+				return ss
+			}(),
+			expectedAllowed: true,
+		},
+		{
+			name:      "Test invalid applyAs no name create",
+			operation: admissionv1beta1.Create,
+			syncSet: func() *hivev1.SyncSet {
+				ss := testSyncSet()
+				ss.Spec.ApplyAs = &hivev1.ServiceAccountReference{Namespace: "team-ns"}
+				return ss
+			}(),
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test invalid applyAs no namespace update",
+			operation: admissionv1beta1.Update,
+			syncSet: func() *hivev1.SyncSet {
+				ss := testSyncSet()
+				ss.Spec.ApplyAs = &hivev1.ServiceAccountReference{Name: "team-sa"}
+				return ss
+			}(),
+			expectedAllowed: false,
+		},
 	}
 
 	for _, tc := range cases {
@@ -455,6 +557,25 @@ func testSecretReferenceSyncSet() *hivev1.SyncSet {
 	return ss
 }
 
+func testResourceRefsSyncSet() *hivev1.SyncSet {
+	ss := testSyncSet()
+	ss.Spec.ResourceRefs = []hivev1.SyncSetResourceRef{
+		{
+			ConfigMap: &hivev1.ConfigMapResourceSource{
+				Name:   "manifests",
+				Digest: "sha256:aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+			},
+		},
+		{
+			OCIArtifact: &hivev1.OCIArtifactResourceSource{
+				Image:         "quay.io/example/manifests@sha256:aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+				PullSecretRef: &hivev1.SecretReference{Name: "pull-secret"},
+			},
+		},
+	}
+	return ss
+}
+
 func testSyncSet() *hivev1.SyncSet {
 	return &hivev1.SyncSet{
 		ObjectMeta: metav1.ObjectMeta{
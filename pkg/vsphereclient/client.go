@@ -0,0 +1,212 @@
+package vsphereclient
+
+import (
+	"context"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/property"
+	"github.com/vmware/govmomi/session"
+	"github.com/vmware/govmomi/vapi/rest"
+	"github.com/vmware/govmomi/vapi/tags"
+	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/soap"
+	"github.com/vmware/govmomi/vim25/types"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/openshift/hive/pkg/constants"
+)
+
+// callTimeout bounds each call to vCenter.
+const callTimeout = 60 * time.Second
+
+//go:generate mockgen -source=./client.go -destination=./mock/client_generated.go -package=mock
+
+// Client is a wrapper object for the vSphere APIs to allow for easier mocking/testing.
+type Client interface {
+	// ListVirtualMachines lists the virtual machines attached to the tag in the given tag category.
+	ListVirtualMachines(category, tag string) ([]VirtualMachine, error)
+
+	// PowerOnVirtualMachine powers on the virtual machine.
+	PowerOnVirtualMachine(vm VirtualMachine) error
+
+	// PowerOffVirtualMachine shuts down the guest of the virtual machine when VMware Tools runs in it, and otherwise
+	// powers the virtual machine off.
+	PowerOffVirtualMachine(vm VirtualMachine) error
+
+	// Logout ends the sessions of the client.
+	Logout() error
+}
+
+// VirtualMachine is the subset of the vSphere virtual machine properties used by hive.
+type VirtualMachine struct {
+	Reference          types.ManagedObjectReference
+	Name               string
+	PowerState         types.VirtualMachinePowerState
+	ToolsRunningStatus string
+}
+
+type vsphereClient struct {
+	vimClient      *vim25.Client
+	sessionManager *session.Manager
+	restClient     *rest.Client
+}
+
+func (c *vsphereClient) ListVirtualMachines(category, tag string) ([]VirtualMachine, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), callTimeout)
+	defer cancel()
+
+	tagManager := tags.NewManager(c.restClient)
+	t, err := tagManager.GetTagForCategory(ctx, tag, category)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get tag %s in category %s", tag, category)
+	}
+	attached, err := tagManager.ListAttachedObjects(ctx, t.ID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list objects attached to tag %s", tag)
+	}
+	var refs []types.ManagedObjectReference
+	for _, obj := range attached {
+		if ref := obj.Reference(); ref.Type == "VirtualMachine" {
+			refs = append(refs, ref)
+		}
+	}
+	if len(refs) == 0 {
+		return nil, nil
+	}
+
+	var vms []mo.VirtualMachine
+	if err := property.DefaultCollector(c.vimClient).Retrieve(
+		ctx,
+		refs,
+		[]string{"name", "runtime.powerState", "guest.toolsRunningStatus"},
+		&vms,
+	); err != nil {
+		return nil, errors.Wrap(err, "failed to retrieve virtual machine properties")
+	}
+	result := make([]VirtualMachine, 0, len(vms))
+	for _, vm := range vms {
+		v := VirtualMachine{
+			Reference:  vm.Reference(),
+			Name:       vm.Name,
+			PowerState: vm.Runtime.PowerState,
+		}
+		if vm.Guest != nil {
+			v.ToolsRunningStatus = vm.Guest.ToolsRunningStatus
+		}
+		result = append(result, v)
+	}
+	return result, nil
+}
+
+func (c *vsphereClient) PowerOnVirtualMachine(vm VirtualMachine) error {
+	ctx, cancel := context.WithTimeout(context.Background(), callTimeout)
+	defer cancel()
+	task, err := object.NewVirtualMachine(c.vimClient, vm.Reference).PowerOn(ctx)
+	if err != nil {
+		return errors.Wrapf(err, "failed to power on virtual machine %s", vm.Name)
+	}
+	return errors.Wrapf(task.Wait(ctx), "failed to power on virtual machine %s", vm.Name)
+}
+
+func (c *vsphereClient) PowerOffVirtualMachine(vm VirtualMachine) error {
+	ctx, cancel := context.WithTimeout(context.Background(), callTimeout)
+	defer cancel()
+	objVM := object.NewVirtualMachine(c.vimClient, vm.Reference)
+	if vm.ToolsRunningStatus == string(types.VirtualMachineToolsRunningStatusGuestToolsRunning) {
+		return errors.Wrapf(objVM.ShutdownGuest(ctx), "failed to shut down guest of virtual machine %s", vm.Name)
+	}
+	task, err := objVM.PowerOff(ctx)
+	if err != nil {
+		return errors.Wrapf(err, "failed to power off virtual machine %s", vm.Name)
+	}
+	return errors.Wrapf(task.Wait(ctx), "failed to power off virtual machine %s", vm.Name)
+}
+
+func (c *vsphereClient) Logout() error {
+	ctx, cancel := context.WithTimeout(context.Background(), callTimeout)
+	defer cancel()
+	restErr := c.restClient.Logout(ctx)
+	if err := c.sessionManager.Logout(ctx); err != nil {
+		return err
+	}
+	return restErr
+}
+
+// NewClientFromSecrets creates our client wrapper object for interacting with vSphere, logged into the vCenter. The
+// username and password are read from the credentials secret, and the CA certificates trusted for the vCenter from
+// the certificates secret, if any.
+func NewClientFromSecrets(vcenter string, credsSecret, certificatesSecret *corev1.Secret) (Client, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), callTimeout)
+	defer cancel()
+
+	// The high-level govmomi client doesn't allow us to set custom CAs early enough
+	// so we need to reproduce a lot of the logic to allow setting things up properly
+	// for the cases where a custom CA is needed.
+	u, err := soap.ParseURL(vcenter)
+	if err != nil {
+		return nil, err
+	}
+	u.User = url.UserPassword(
+		string(credsSecret.Data[constants.UsernameSecretKey]),
+		string(credsSecret.Data[constants.PasswordSecretKey]),
+	)
+
+	soapClient := soap.NewClient(u, false)
+	if certificatesSecret != nil && len(certificatesSecret.Data) > 0 {
+		if err := setRootCAs(soapClient, certificatesSecret); err != nil {
+			return nil, errors.Wrap(err, "failed to set vSphere root CAs")
+		}
+	}
+
+	vimClient, err := vim25.NewClient(ctx, soapClient)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create vSphere client")
+	}
+	sessionManager := session.NewManager(vimClient)
+	if err := sessionManager.Login(ctx, u.User); err != nil {
+		return nil, errors.Wrap(err, "failed to log into vSphere")
+	}
+	restClient := rest.NewClient(vimClient)
+	if err := restClient.Login(ctx, u.User); err != nil {
+		sessionManager.Logout(ctx)
+		return nil, errors.Wrap(err, "failed to log into the vSphere REST API")
+	}
+	return &vsphereClient{
+		vimClient:      vimClient,
+		sessionManager: sessionManager,
+		restClient:     restClient,
+	}, nil
+}
+
+// setRootCAs sets the CA certificates in the secret as the ones trusted by the client. The client only reads them
+// from files, which are removed once read.
+func setRootCAs(soapClient *soap.Client, certificatesSecret *corev1.Secret) error {
+	var files []string
+	defer func() {
+		for _, f := range files {
+			os.Remove(f)
+		}
+	}()
+	for _, content := range certificatesSecret.Data {
+		tmpFile, err := ioutil.TempFile("", "rootcacerts")
+		if err != nil {
+			return err
+		}
+		files = append(files, tmpFile.Name())
+		_, err = tmpFile.Write(content)
+		if closeErr := tmpFile.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return soapClient.SetRootCAs(strings.Join(files, string(os.PathListSeparator)))
+}